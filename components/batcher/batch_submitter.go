@@ -5,8 +5,8 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
-	_ "net/http/pprof"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
 
@@ -22,6 +22,14 @@ type BatchSubmitter struct {
 	// lastStoredBlock is the last block loaded into `state`. If it is empty it should be set to the l2 safe head.
 	lastStoredBlock eth.BlockID
 	lastL1Tip       eth.L1BlockRef
+	// lastSafeBlock is the L2 safe head of the previous sync status, to detect safe head regressions.
+	lastSafeBlock eth.BlockID
+
+	// unfinalizedInclusions are the L1 inclusion blocks of submitted frames that are not L1ReorgDepth deep yet.
+	unfinalizedInclusions []eth.BlockID
+
+	// journaled is the channel persisted by the previous batcher process, until its restoration was attempted.
+	journaled *journaledChannel
 
 	state *channelManager
 }
@@ -29,9 +37,24 @@ type BatchSubmitter struct {
 // NewBatchSubmitter initializes the BatchSubmitter, gathering any resources
 // that will be needed during operation.
 func NewBatchSubmitter(cfg Config, l log.Logger, m metrics.Metricer) (*BatchSubmitter, error) {
+	state := NewChannelManager(l, m, cfg.Channel)
+	if cfg.TracerProvider != nil {
+		state.tracer = newBatchTracer(cfg.TracerProvider.Tracer("batcher"))
+	}
+	state.costs = newDACostTracker(l, cfg.DACosts)
+	if cfg.VerifierClient != nil {
+		state.acks = new(verifierAcks)
+	}
+	state.journal = newChannelJournal(l, cfg.ChannelJournal)
+	journaled, err := state.journal.load()
+	if err != nil {
+		l.Warn("ignoring channel journal", "err", err)
+		state.journal.clear()
+	}
 	return &BatchSubmitter{
-		Config: cfg,
-		state:  NewChannelManager(l, m, cfg.Channel),
+		Config:    cfg,
+		state:     state,
+		journaled: journaled,
 	}, nil
 }
 
@@ -47,6 +70,12 @@ func (b *BatchSubmitter) LoadBlocksIntoState(ctx context.Context) {
 		b.log.Trace("unable to calculate L2 block range", "err", err)
 		return
 	}
+	if b.journaled != nil {
+		if start, err = b.restorePendingChannel(ctx, start); err != nil {
+			b.log.Warn("failed to restore journaled channel", "err", err)
+			return
+		}
+	}
 
 	// Add all blocks to "state"
 	for i := start.Number + 1; i < end.Number+1; i++ {
@@ -96,6 +125,18 @@ func (b *BatchSubmitter) calculateL2BlockRangeToStore(ctx context.Context) (eth.
 
 	// Check last stored to see if it needs to be set on startup OR set if is lagged behind.
 	// It lagging implies that the kroma-node processed some batches that where submitted prior to the current instance of the kroma-batcher being alive.
+	// An L1 reorg of submitted frames makes the node derive the safe head again from an older L1 block.
+	// Blocks after the new safe head were submitted in the reorged frames, so they have to be batched again.
+	if b.L1ReorgDepth > 0 && syncStatus.SafeL2.Number < b.lastSafeBlock.Number {
+		b.log.Warn("L2 safe head regressed after an L1 reorg, re-anchoring batching at the safe head",
+			"safe", syncStatus.SafeL2, "previous_safe", b.lastSafeBlock)
+		b.metr.RecordL1ReorgReanchor()
+		b.state.Clear()
+		b.lastStoredBlock = eth.BlockID{}
+		b.unfinalizedInclusions = nil
+	}
+	b.lastSafeBlock = syncStatus.SafeL2.ID()
+
 	if b.lastStoredBlock == (eth.BlockID{}) {
 		b.log.Info("Starting batch-submitter work at safe-head", "safe", syncStatus.SafeL2)
 		b.lastStoredBlock = syncStatus.SafeL2.ID()
@@ -104,15 +145,34 @@ func (b *BatchSubmitter) calculateL2BlockRangeToStore(ctx context.Context) (eth.
 		b.lastStoredBlock = syncStatus.SafeL2.ID()
 	}
 
+	b.state.RegisterUnsafeHead(syncStatus.UnsafeL2.Number)
+
 	// Check if we should even attempt to load any blocks. TODO: May not need this check
 	if syncStatus.SafeL2.Number >= syncStatus.UnsafeL2.Number {
 		return eth.BlockID{}, eth.BlockID{}, errors.New("L2 safe head ahead of L2 unsafe head")
 	}
 
-	return b.lastStoredBlock, syncStatus.UnsafeL2.ID(), nil
+	end := syncStatus.UnsafeL2
+	// Do not batch blocks too far ahead of the safe head, these may still be reorged locally.
+	if b.MaxSafeLag != 0 && end.Number > syncStatus.SafeL2.Number+b.MaxSafeLag {
+		maxNumber := syncStatus.SafeL2.Number + b.MaxSafeLag
+		if b.lastStoredBlock.Number >= maxNumber {
+			b.metr.RecordSafeLagReached(true)
+			return eth.BlockID{}, eth.BlockID{}, fmt.Errorf("max safe lag of %d blocks reached: last stored block %d, safe head %d",
+				b.MaxSafeLag, b.lastStoredBlock.Number, syncStatus.SafeL2.Number)
+		}
+		b.log.Warn("unsafe head is too far ahead of the safe head, batching up to the max safe lag only",
+			"unsafe", syncStatus.UnsafeL2, "safe", syncStatus.SafeL2, "max_safe_lag", b.MaxSafeLag)
+		b.metr.RecordSafeLagReached(true)
+		return b.lastStoredBlock, eth.BlockID{Number: maxNumber}, nil
+	}
+	b.metr.RecordSafeLagReached(false)
+
+	return b.lastStoredBlock, end.ID(), nil
 }
 
 func (b *BatchSubmitter) recordL1Tip(l1tip eth.L1BlockRef) {
+	b.state.RegisterL1Head(l1tip)
 	if b.lastL1Tip == l1tip {
 		return
 	}
@@ -122,13 +182,71 @@ func (b *BatchSubmitter) recordL1Tip(l1tip eth.L1BlockRef) {
 
 func (b *BatchSubmitter) recordFailedTx(id txID, err error) {
 	b.log.Warn("Failed to send transaction", "err", err)
+	b.state.tracer.frameFailed(id, err)
+	b.state.costs.frameFailed(id)
 	b.state.TxFailed(id)
 }
 
 func (b *BatchSubmitter) recordConfirmedTx(id txID, receipt *types.Receipt) {
 	b.log.Info("Transaction confirmed", "tx_hash", receipt.TxHash, "status", receipt.Status, "block_hash", receipt.BlockHash, "block_number", receipt.BlockNumber)
 	l1block := eth.BlockID{Number: receipt.BlockNumber.Uint64(), Hash: receipt.BlockHash}
+	b.state.tracer.frameConfirmed(id, receipt)
+	b.state.costs.frameConfirmed(id, receipt)
 	b.state.TxConfirmed(id, l1block)
+	if b.L1ReorgDepth > 0 {
+		b.unfinalizedInclusions = append(b.unfinalizedInclusions, l1block)
+	}
+}
+
+// CheckL1Reorg checks that the inclusion blocks of the submitted frames are still canonical on L1,
+// until they are L1ReorgDepth deep. If any of them was reorged out, the channels of the frames may be
+// incomplete, so the state is cleared to batch again from the safe head, as after a restart.
+func (b *BatchSubmitter) CheckL1Reorg(ctx context.Context) {
+	if len(b.unfinalizedInclusions) == 0 {
+		return
+	}
+	l1tip, err := b.l1Tip(ctx)
+	if err != nil {
+		b.log.Warn("failed to check submitted frames for L1 reorgs", "err", err)
+		return
+	}
+
+	unfinalized := b.unfinalizedInclusions[:0]
+	for i, inclusion := range b.unfinalizedInclusions {
+		if l1tip.Number >= inclusion.Number+b.L1ReorgDepth {
+			continue
+		}
+		canonical, err := b.isCanonicalL1Block(ctx, inclusion)
+		if err != nil {
+			b.log.Warn("failed to check submitted frames for L1 reorgs", "err", err)
+			b.unfinalizedInclusions = append(unfinalized, b.unfinalizedInclusions[i:]...)
+			return
+		}
+		if !canonical {
+			b.log.Warn("submitted frames were reorged out of L1, re-anchoring batching at the safe head",
+				"inclusion_block", inclusion, "l1_tip", l1tip)
+			b.metr.RecordL1ReorgReanchor()
+			b.state.Clear()
+			b.lastStoredBlock = eth.BlockID{}
+			b.unfinalizedInclusions = nil
+			return
+		}
+		unfinalized = append(unfinalized, inclusion)
+	}
+	b.unfinalizedInclusions = unfinalized
+}
+
+func (b *BatchSubmitter) isCanonicalL1Block(ctx context.Context, id eth.BlockID) (bool, error) {
+	tctx, cancel := context.WithTimeout(ctx, b.NetworkTimeout)
+	defer cancel()
+	header, err := b.L1Client.HeaderByNumber(tctx, new(big.Int).SetUint64(id.Number))
+	if errors.Is(err, ethereum.NotFound) {
+		// the L1 chain was reorged to a shorter chain
+		return false, nil
+	} else if err != nil {
+		return false, fmt.Errorf("getting L1 block %d: %w", id.Number, err)
+	}
+	return header.Hash() == id.Hash, nil
 }
 
 // l1Tip gets the current L1 tip as a L1BlockRef. The passed context is assumed
@@ -0,0 +1,258 @@
+package batcher
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kroma-network/kroma/components/batcher/metrics"
+	"github.com/kroma-network/kroma/components/node/eth"
+	"github.com/kroma-network/kroma/components/node/sources"
+	"github.com/kroma-network/kroma/components/node/testlog"
+)
+
+// fakeRollupRPC serves the sync status of a rollup node.
+type fakeRollupRPC struct {
+	status eth.SyncStatus
+}
+
+func (f *fakeRollupRPC) setHeads(safe, unsafe uint64) {
+	f.status.HeadL1 = eth.L1BlockRef{Number: 1000, Hash: common.Hash{0x01}}
+	f.status.SafeL2 = eth.L2BlockRef{Number: safe, Hash: common.Hash{byte(safe)}}
+	f.status.UnsafeL2 = eth.L2BlockRef{Number: unsafe, Hash: common.Hash{byte(unsafe)}}
+}
+
+func (f *fakeRollupRPC) CallContext(_ context.Context, result any, method string, _ ...any) error {
+	if method != "kroma_syncStatus" {
+		return fmt.Errorf("unexpected method %s", method)
+	}
+	data, err := json.Marshal(&f.status)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, result)
+}
+
+func (f *fakeRollupRPC) BatchCallContext(_ context.Context, _ []rpc.BatchElem) error {
+	return errors.New("not supported")
+}
+
+func (f *fakeRollupRPC) EthSubscribe(_ context.Context, _ any, _ ...any) (ethereum.Subscription, error) {
+	return nil, errors.New("not supported")
+}
+
+func (f *fakeRollupRPC) Close() {}
+
+// fakeL1 serves the headers of an L1 chain.
+type fakeL1 struct {
+	headers []*types.Header
+}
+
+// setChain sets the L1 chain to blocks of the numbers up to tip, distinguished by the fork.
+func (f *fakeL1) setChain(tip uint64, fork byte) {
+	f.headers = make([]*types.Header, tip+1)
+	for i := range f.headers {
+		f.headers[i] = &types.Header{Number: new(big.Int).SetUint64(uint64(i)), Extra: []byte{fork}, Difficulty: common.Big0}
+	}
+}
+
+func (f *fakeL1) id(num uint64) eth.BlockID {
+	return eth.BlockID{Number: num, Hash: f.headers[num].Hash()}
+}
+
+func (f *fakeL1) GetBlockByNumber(number rpc.BlockNumber, _ bool) (*types.Header, error) {
+	if number == rpc.LatestBlockNumber {
+		return f.headers[len(f.headers)-1], nil
+	}
+	if number < 0 {
+		return nil, fmt.Errorf("unexpected block number %d", number)
+	}
+	if int(number) >= len(f.headers) {
+		return nil, nil
+	}
+	return f.headers[number], nil
+}
+
+func newFakeL1Client(t *testing.T, l1 *fakeL1) *ethclient.Client {
+	srv := rpc.NewServer()
+	require.NoError(t, srv.RegisterName("eth", l1))
+	t.Cleanup(srv.Stop)
+	cl := ethclient.NewClient(rpc.DialInProc(srv))
+	t.Cleanup(cl.Close)
+	return cl
+}
+
+// testMetrics records whether the max safe lag is reached and the re-anchors after L1 reorgs.
+type testMetrics struct {
+	metrics.Metricer
+	reached   bool
+	reanchors int
+}
+
+func (m *testMetrics) RecordSafeLagReached(reached bool) {
+	m.reached = reached
+}
+
+func (m *testMetrics) RecordL1ReorgReanchor() {
+	m.reanchors++
+}
+
+func newTestBatchSubmitter(t *testing.T, cfg Config) *BatchSubmitter {
+	cfg.log = testlog.Logger(t, log.LvlCrit)
+	if cfg.metr == nil {
+		cfg.metr = metrics.NoopMetrics
+	}
+	cfg.NetworkTimeout = time.Second
+	cfg.Channel = ChannelConfig{
+		ChannelTimeout:   100,
+		MaxFrameSize:     120000,
+		TargetFrameSize:  100000,
+		TargetNumFrames:  1,
+		ApproxComprRatio: 1.0,
+	}
+	b, err := NewBatchSubmitter(cfg, cfg.log, cfg.metr)
+	require.NoError(t, err)
+	return b
+}
+
+func TestCalculateL2BlockRangeMaxSafeLag(t *testing.T) {
+	rollup := &fakeRollupRPC{}
+	m := &testMetrics{Metricer: metrics.NoopMetrics}
+	b := newTestBatchSubmitter(t, Config{
+		metr:         m,
+		RollupClient: sources.NewRollupClient(rollup),
+		MaxSafeLag:   10,
+	})
+
+	// the unsafe head is within the max safe lag
+	rollup.setHeads(100, 105)
+	start, end, err := b.calculateL2BlockRangeToStore(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, rollup.status.SafeL2.ID(), start)
+	require.Equal(t, rollup.status.UnsafeL2.ID(), end)
+	require.False(t, m.reached)
+
+	// the blocks are batched up to the max safe lag only
+	rollup.setHeads(100, 150)
+	start, end, err = b.calculateL2BlockRangeToStore(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, rollup.status.SafeL2.ID(), start)
+	require.Equal(t, uint64(110), end.Number)
+	require.True(t, m.reached)
+
+	// batching is held back once the blocks up to the max safe lag are stored
+	b.lastStoredBlock = eth.BlockID{Number: 110, Hash: common.Hash{110}}
+	_, _, err = b.calculateL2BlockRangeToStore(context.Background())
+	require.ErrorContains(t, err, "max safe lag of 10 blocks reached")
+	require.True(t, m.reached)
+
+	// and resumes as the safe head advances
+	rollup.setHeads(105, 150)
+	start, end, err = b.calculateL2BlockRangeToStore(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, b.lastStoredBlock, start)
+	require.Equal(t, uint64(115), end.Number)
+
+	rollup.setHeads(145, 150)
+	_, end, err = b.calculateL2BlockRangeToStore(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, rollup.status.UnsafeL2.ID(), end)
+	require.False(t, m.reached)
+
+	// every unsafe block is batched without a max safe lag
+	b.MaxSafeLag = 0
+	rollup.setHeads(145, 250)
+	_, end, err = b.calculateL2BlockRangeToStore(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, rollup.status.UnsafeL2.ID(), end)
+	require.False(t, m.reached)
+}
+
+func TestCalculateL2BlockRangeL1Reorg(t *testing.T) {
+	rollup := &fakeRollupRPC{}
+	m := &testMetrics{Metricer: metrics.NoopMetrics}
+	b := newTestBatchSubmitter(t, Config{
+		metr:         m,
+		RollupClient: sources.NewRollupClient(rollup),
+		L1ReorgDepth: 10,
+	})
+
+	rollup.setHeads(100, 150)
+	_, _, err := b.calculateL2BlockRangeToStore(context.Background())
+	require.NoError(t, err)
+	b.lastStoredBlock = eth.BlockID{Number: 140, Hash: common.Hash{140}}
+	b.unfinalizedInclusions = []eth.BlockID{{Number: 990}}
+
+	// the safe head advances
+	rollup.setHeads(120, 150)
+	start, _, err := b.calculateL2BlockRangeToStore(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, uint64(140), start.Number)
+	require.Zero(t, m.reanchors)
+
+	// the safe head regresses as the frames it was derived from were reorged out of L1
+	rollup.setHeads(110, 150)
+	start, _, err = b.calculateL2BlockRangeToStore(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, rollup.status.SafeL2.ID(), start, "batching must be re-anchored at the safe head")
+	require.Nil(t, b.unfinalizedInclusions)
+	require.Equal(t, 1, m.reanchors)
+
+	// the regression of the safe head is ignored without an L1 reorg depth
+	b.L1ReorgDepth = 0
+	b.lastStoredBlock = eth.BlockID{Number: 140, Hash: common.Hash{140}}
+	rollup.setHeads(100, 150)
+	start, _, err = b.calculateL2BlockRangeToStore(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, uint64(140), start.Number)
+	require.Equal(t, 1, m.reanchors)
+}
+
+func TestCheckL1Reorg(t *testing.T) {
+	l1 := &fakeL1{}
+	l1.setChain(20, 0)
+	m := &testMetrics{Metricer: metrics.NoopMetrics}
+	b := newTestBatchSubmitter(t, Config{
+		metr:         m,
+		L1Client:     newFakeL1Client(t, l1),
+		L1ReorgDepth: 10,
+	})
+	anchor := func() {
+		b.lastStoredBlock = eth.BlockID{Number: 140, Hash: common.Hash{140}}
+		b.unfinalizedInclusions = []eth.BlockID{l1.id(5), l1.id(12), l1.id(18)}
+	}
+
+	// the inclusion blocks L1ReorgDepth deep are not checked anymore
+	anchor()
+	b.CheckL1Reorg(context.Background())
+	require.Equal(t, []eth.BlockID{l1.id(12), l1.id(18)}, b.unfinalizedInclusions)
+	require.Equal(t, uint64(140), b.lastStoredBlock.Number)
+	require.Zero(t, m.reanchors)
+
+	// an inclusion block was reorged out of L1
+	l1.setChain(20, 1)
+	b.CheckL1Reorg(context.Background())
+	require.Nil(t, b.unfinalizedInclusions)
+	require.Equal(t, eth.BlockID{}, b.lastStoredBlock, "batching must be re-anchored at the safe head")
+	require.Equal(t, 1, m.reanchors)
+
+	// the L1 chain was reorged to a shorter chain
+	anchor()
+	l1.setChain(15, 1)
+	b.CheckL1Reorg(context.Background())
+	require.Nil(t, b.unfinalizedInclusions)
+	require.Equal(t, eth.BlockID{}, b.lastStoredBlock, "batching must be re-anchored at the safe head")
+	require.Equal(t, 2, m.reanchors)
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/kroma-network/kroma/utils/monitoring"
 	klog "github.com/kroma-network/kroma/utils/service/log"
 	krpc "github.com/kroma-network/kroma/utils/service/rpc"
+	ktracing "github.com/kroma-network/kroma/utils/service/tracing"
 	"github.com/kroma-network/kroma/utils/service/txmgr"
 )
 
@@ -41,12 +42,31 @@ func Main(version string, cliCtx *cli.Context) error {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	monitoring.MaybeStartPprof(ctx, cliCfg.PprofConfig, l)
+	if err := monitoring.MaybeStartPprof(ctx, cliCfg.PprofConfig, l); err != nil {
+		return err
+	}
+	tracerProvider, stopTracing, err := ktracing.NewTracerProvider(ctx, cliCfg.TracingConfig, "kroma-batcher", version)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := stopTracing(context.Background()); err != nil {
+			l.Error("Error shutting down tracing", "err", err)
+		}
+	}()
+	batcherCfg.TracerProvider = tracerProvider
 	monitoring.MaybeStartMetrics(ctx, cliCfg.MetricsConfig, l, m, batcherCfg.L1Client, batcherCfg.TxManager.From())
-	server, err := monitoring.StartRPC(cliCfg.RPCConfig.ToServiceCLIConfig(), version, krpc.WithLogger(l))
+	server, err := monitoring.StartRPC(cliCfg.RPCConfig.ToServiceCLIConfig(), version, krpc.WithLogger(l),
+		krpc.WithAPIs(append(append(txmgr.ApprovalAPIs(batcherCfg.TxApprovals, cliCfg.RPCConfig.EnableAdmin), txmgr.PendingAPIs(cliCfg.RPCConfig.EnableAdmin, batcherCfg.TxInFlight)...),
+			DACostAPIs(batcherCfg.DACosts)...)))
 	if err != nil {
 		return err
 	}
+	defer func() {
+		if err := batcherCfg.DACosts.Close(); err != nil {
+			l.Error("Error closing DA cost journal", "err", err)
+		}
+	}()
 	defer func() {
 		if err = server.Stop(); err != nil {
 			l.Error("Error shutting down http server: %w", err)
@@ -151,6 +171,13 @@ func (b *Batcher) Stop(ctx context.Context) error {
 	b.wg.Wait()
 	b.cancelKillCtx()
 
+	// the broadcast hooks of the tx manager are closed once no transaction is sent anymore
+	if closer, ok := b.cfg.TxManager.(io.Closer); ok {
+		if err := closer.Close(); err != nil {
+			b.l.Error("failed to close tx manager", "err", err)
+		}
+	}
+
 	b.l.Info("Batcher stopped")
 
 	return nil
@@ -176,6 +203,8 @@ func (b *Batcher) loop() {
 	for {
 		select {
 		case <-ticker.C:
+			b.batchSubmitter.CheckL1Reorg(b.shutdownCtx)
+			b.batchSubmitter.CheckVerifierAcks(b.shutdownCtx)
 			b.batchSubmitter.LoadBlocksIntoState(b.shutdownCtx)
 			if err := b.submitBatch(b.killCtx); err != nil {
 				b.l.Error("failed to submit batch channel frame", "err", err)
@@ -6,9 +6,11 @@ import (
 	"fmt"
 	"io"
 	"math"
+	"time"
 
 	"github.com/ethereum/go-ethereum/core/types"
 
+	"github.com/kroma-network/kroma/components/node/rollup"
 	"github.com/kroma-network/kroma/components/node/rollup/derive"
 )
 
@@ -19,6 +21,7 @@ var (
 	ErrMaxDurationReached    = errors.New("max channel duration reached")
 	ErrChannelTimeoutClose   = errors.New("close to channel timeout")
 	ErrProposerWindowClose   = errors.New("close to proposer window timeout")
+	ErrSafeLagReached        = errors.New("safe head lag reached")
 	ErrTerminated            = errors.New("channel terminated")
 )
 
@@ -69,6 +72,44 @@ type ChannelConfig struct {
 	// average from experiments to avoid the chances of creating a small
 	// additional leftover frame.
 	ApproxComprRatio float64
+	// Compression is the algorithm and level the channels are compressed
+	// with. The ApproxComprRatio should be set for the algorithm.
+	Compression derive.CompressionConfig
+	// DeferralWindows are the recurring time windows during which the
+	// MaxChannelDuration is not enforced. Channels are then only closed when
+	// they are full or close to the channel timeout or proposing window, so that
+	// submission is deferred to cheaper L1 periods.
+	DeferralWindows DeferralWindows
+	// DepositOnlyChannelDuration is the maximum duration (in #L1-blocks) to keep
+	// the channel open while it only contains deposit-only blocks, e.g. during a
+	// sequencer downtime. The batches of such blocks have no transactions, so
+	// they are batched in fewer, longer channels to keep the DA costs low. Once
+	// span batches are activated by the RollupConfig, a channel opened with
+	// deposit-only blocks is also encoded as a span batch whatever the
+	// BatchType, amortizing the parent hash, the epoch and the timestamp of the
+	// singular batches down to about a byte per block.
+	//
+	// If 0, the MaxChannelDuration also applies to deposit-only channels, and
+	// they are encoded with the BatchType.
+	DepositOnlyChannelDuration uint64
+	// FlushSafeLag is the maximum number of L2 blocks the oldest block of the
+	// channel may lag the unsafe head before the channel is closed and
+	// submitted, so that the data of the blocks lands on L1 within bounded
+	// time even at low L2 throughput. Unlike the MaxChannelDuration, it is not
+	// deferred by the DeferralWindows. The blocks of the channels already
+	// submitted are not counted, as they only become safe once their data is
+	// included and derived.
+	//
+	// If 0, safe head lag checks are disabled.
+	FlushSafeLag uint64
+	// BatchType is the type of the batches of the channels: derive.BatchV1Type
+	// for a batch per block, or derive.SpanBatchType for a single span batch of
+	// all the blocks of a channel. Channels starting before the span batches
+	// are activated by the RollupConfig get a batch per block.
+	BatchType uint
+	// RollupConfig is the rollup config the span batches are built with. It is
+	// required for span batches only.
+	RollupConfig *rollup.Config
 }
 
 // Check validates the [ChannelConfig] parameters.
@@ -94,6 +135,33 @@ func (cc *ChannelConfig) Check() error {
 		return fmt.Errorf("max frame size %d is less than the minimum 23", cc.MaxFrameSize)
 	}
 
+	if err := cc.Compression.Check(); err != nil {
+		return err
+	}
+	if !isZlib(cc.Compression) && (cc.RollupConfig == nil || cc.RollupConfig.ChannelCompressionTime == nil) {
+		return fmt.Errorf("%s compression is not scheduled by the rollup config", cc.Compression.Algo)
+	}
+
+	switch cc.BatchType {
+	case derive.BatchV1Type:
+	case derive.SpanBatchType:
+		if cc.RollupConfig == nil || cc.RollupConfig.SpanBatchTime == nil {
+			return errors.New("span batches are not scheduled by the rollup config")
+		}
+	default:
+		return fmt.Errorf("unknown batch type %d", cc.BatchType)
+	}
+
+	// The deposit-only channel duration extends the max channel duration, so
+	// it cannot be enabled without it, nor be shorter.
+	if cc.DepositOnlyChannelDuration != 0 && cc.DepositOnlyChannelDuration < cc.MaxChannelDuration {
+		return fmt.Errorf("deposit-only channel duration %d is less than the max channel duration %d",
+			cc.DepositOnlyChannelDuration, cc.MaxChannelDuration)
+	}
+	if cc.DepositOnlyChannelDuration != 0 && cc.MaxChannelDuration == 0 {
+		return errors.New("deposit-only channel duration requires a max channel duration")
+	}
+
 	return nil
 }
 
@@ -126,6 +194,20 @@ type channelBuilder struct {
 	timeout uint64
 	// reason for currently set timeout
 	timeoutReason error
+	// L1 block number timeout of the proposing window only, which must be
+	// respected even if the channel duration timeout is deferred.
+	// 0 if no block number timeout set yet.
+	pwTimeout uint64
+	// L1 block number timeout of the consensus channel timeout only, which
+	// must be respected even if the channel duration timeout is deferred.
+	// 0 if no frame published yet.
+	ctTimeout uint64
+	// L1 block number the channel was first registered at, the start of its
+	// channel duration. 0 if no L1 block registered yet.
+	openL1Block uint64
+	// whether all blocks of the channel are deposit-only blocks, i.e. their
+	// batches have no transactions. True while the channel has no blocks.
+	depositOnly bool
 
 	// Reason for the channel being full. Set by setFullErr so it's always
 	// guaranteed to be a ChannelFullError wrapping the specific reason.
@@ -138,22 +220,57 @@ type channelBuilder struct {
 	frames []frameData
 	// total amount of output data of all frames created yet
 	outputBytes int
+
+	// timeNow enables channel builder testing to mock the time of the
+	// deferral windows
+	timeNow func() time.Time
+}
+
+// isZlib returns whether the compression is zlib, the only one before the fork.
+func isZlib(cfg derive.CompressionConfig) bool {
+	return cfg.Algo == "" || cfg.Algo == derive.Zlib
 }
 
 // newChannelBuilder creates a new channel builder or returns an error if the
 // channel out could not be created.
 func newChannelBuilder(cfg ChannelConfig) (*channelBuilder, error) {
-	co, err := derive.NewChannelOut()
+	var co *derive.ChannelOut
+	var err error
+	if cfg.BatchType == derive.SpanBatchType {
+		co, err = derive.NewSpanChannelOut(cfg.Compression, cfg.RollupConfig)
+	} else {
+		co, err = derive.NewChannelOutWithCompression(cfg.Compression)
+	}
 	if err != nil {
 		return nil, err
 	}
 
 	return &channelBuilder{
-		cfg: cfg,
-		co:  co,
+		cfg:         cfg,
+		co:          co,
+		depositOnly: true,
+		timeNow:     time.Now,
 	}, nil
 }
 
+// restoreChannelBuilder creates a full channel builder of a channel closed by a previous batcher process, to resume
+// the submission of its frames. The frames are the ones of the numFrames frames of the channel that were not
+// confirmed yet.
+func restoreChannelBuilder(cfg ChannelConfig, id derive.ChannelID, blocks []*types.Block, frames []frameData, numFrames int) *channelBuilder {
+	c := &channelBuilder{
+		cfg:     cfg,
+		co:      derive.NewClosedChannelOut(id, uint64(numFrames)),
+		blocks:  blocks,
+		frames:  frames,
+		timeNow: time.Now,
+	}
+	for _, frame := range frames {
+		c.outputBytes += len(frame.data)
+	}
+	c.setFullErr(ErrTerminated)
+	return c
+}
+
 func (c *channelBuilder) ID() derive.ChannelID {
 	return c.co.ID()
 }
@@ -185,6 +302,10 @@ func (c *channelBuilder) Reset() error {
 	c.blocks = c.blocks[:0]
 	c.frames = c.frames[:0]
 	c.timeout = 0
+	c.pwTimeout = 0
+	c.ctTimeout = 0
+	c.openL1Block = 0
+	c.depositOnly = true
 	c.fullErr = nil
 	return c.co.Reset()
 }
@@ -218,6 +339,13 @@ func (c *channelBuilder) AddBlock(block *types.Block) (derive.L1BlockInfo, error
 	}
 	c.blocks = append(c.blocks, block)
 	c.updatePwTimeout(batch)
+	if c.depositOnly && len(batch.Transactions) > 0 {
+		// the channel is no longer deposit-only, so the regular channel duration applies from its start
+		c.depositOnly = false
+		if c.openL1Block != 0 {
+			c.updateDurationTimeout(c.openL1Block)
+		}
+	}
 
 	if c.inputTargetReached() {
 		c.setFullErr(ErrInputTargetReached)
@@ -234,15 +362,34 @@ func (c *channelBuilder) AddBlock(block *types.Block) (derive.L1BlockInfo, error
 // It ensures proper tracking of all possible timeouts (max channel duration,
 // close to consensus channel timeout, close to end of proposing window).
 func (c *channelBuilder) RegisterL1Block(l1BlockNum uint64) {
+	if c.openL1Block == 0 {
+		c.openL1Block = l1BlockNum
+	}
 	c.updateDurationTimeout(l1BlockNum)
 	c.checkTimeout(l1BlockNum)
 }
 
+// RegisterUnsafeHead should be called with the number of the unsafe head
+// whenever it is seen. It closes a channel with blocks once its oldest block
+// lags the unsafe head by more than the FlushSafeLag.
+func (c *channelBuilder) RegisterUnsafeHead(number uint64) {
+	if c.cfg.FlushSafeLag == 0 || c.IsFull() || len(c.blocks) == 0 {
+		return
+	}
+	if oldest := c.blocks[0].NumberU64(); number <= oldest || number-oldest <= c.cfg.FlushSafeLag {
+		return
+	}
+	c.setFullErr(ErrSafeLagReached)
+}
+
 // FramePublished should be called whenever a frame of this channel got
 // published with the L1-block number of the block that the frame got included
 // in.
 func (c *channelBuilder) FramePublished(l1BlockNum uint64) {
 	timeout := l1BlockNum + c.cfg.ChannelTimeout - c.cfg.SubSafetyMargin
+	if c.ctTimeout == 0 || c.ctTimeout > timeout {
+		c.ctTimeout = timeout
+	}
 	c.updateTimeout(timeout, ErrChannelTimeoutClose)
 }
 
@@ -252,19 +399,55 @@ func (c *channelBuilder) FramePublished(l1BlockNum uint64) {
 //
 // It does nothing if the max channel duration is set to 0.
 func (c *channelBuilder) updateDurationTimeout(l1BlockNum uint64) {
-	if c.cfg.MaxChannelDuration == 0 {
+	if c.cfg.MaxChannelDuration == 0 || c.durationDeferred() {
 		return
 	}
-	timeout := l1BlockNum + c.cfg.MaxChannelDuration
+	timeout := l1BlockNum + c.channelDuration()
 	c.updateTimeout(timeout, ErrMaxDurationReached)
 }
 
+// channelDuration returns the max channel duration, extended to the
+// deposit-only channel duration as long as the channel is deposit-only.
+func (c *channelBuilder) channelDuration() uint64 {
+	if c.depositOnly && c.cfg.DepositOnlyChannelDuration != 0 {
+		return c.cfg.DepositOnlyChannelDuration
+	}
+	return c.cfg.MaxChannelDuration
+}
+
+// isDepositOnly returns whether the block only has deposit transactions, so
+// that its batch has no transactions.
+func isDepositOnly(block *types.Block) bool {
+	for _, tx := range block.Transactions() {
+		if tx.Type() != types.DepositTxType {
+			return false
+		}
+	}
+	return true
+}
+
+// CompressionAlgo returns the algorithm the channel is compressed with.
+func (c *channelBuilder) CompressionAlgo() derive.CompressionAlgo {
+	if isZlib(c.cfg.Compression) {
+		return derive.Zlib
+	}
+	return c.cfg.Compression.Algo
+}
+
+// DepositOnly returns whether all blocks of the channel are deposit-only blocks.
+func (c *channelBuilder) DepositOnly() bool {
+	return c.depositOnly && len(c.blocks) > 0
+}
+
 // updatePwTimeout updates the block timeout with the proposer window timeout
 // derived from the batch's origin L1 block. The timeout is only moved forward
 // if the derived proposer window timeout is earlier than the currently set
 // timeout.
 func (c *channelBuilder) updatePwTimeout(batch *derive.BatchData) {
 	timeout := uint64(batch.EpochNum) + c.cfg.ProposerWindowSize - c.cfg.SubSafetyMargin
+	if c.pwTimeout == 0 || c.pwTimeout > timeout {
+		c.pwTimeout = timeout
+	}
 	c.updateTimeout(timeout, ErrProposerWindowClose)
 }
 
@@ -283,9 +466,27 @@ func (c *channelBuilder) updateTimeout(timeoutBlockNum uint64, reason error) {
 // checkTimeout checks if the channel is timed out at the given block number and
 // in this case marks the channel as full, if it wasn't full already.
 func (c *channelBuilder) checkTimeout(blockNum uint64) {
-	if !c.IsFull() && c.TimedOut(blockNum) {
-		c.setFullErr(c.timeoutReason)
+	if c.IsFull() || !c.TimedOut(blockNum) {
+		return
+	}
+	// The channel duration timeout is not urgent, so it is deferred during a
+	// deferral window as long as the proposing window and the channel timeout
+	// are not close.
+	if errors.Is(c.timeoutReason, ErrMaxDurationReached) && c.durationDeferred() {
+		if c.pwTimeout != 0 && blockNum >= c.pwTimeout {
+			c.setFullErr(ErrProposerWindowClose)
+		} else if c.ctTimeout != 0 && blockNum >= c.ctTimeout {
+			c.setFullErr(ErrChannelTimeoutClose)
+		}
+		return
 	}
+	c.setFullErr(c.timeoutReason)
+}
+
+// durationDeferred returns whether the channel duration timeout is currently
+// deferred by a deferral window.
+func (c *channelBuilder) durationDeferred() bool {
+	return c.cfg.DeferralWindows.Contains(c.timeNow())
 }
 
 // TimedOut returns whether the passed block number is after the timeout block
@@ -15,6 +15,7 @@ import (
 	"github.com/ethereum/go-ethereum/trie"
 	"github.com/stretchr/testify/require"
 
+	btest "github.com/kroma-network/kroma/components/batcher/test"
 	"github.com/kroma-network/kroma/components/node/eth"
 	"github.com/kroma-network/kroma/components/node/rollup"
 	"github.com/kroma-network/kroma/components/node/rollup/derive"
@@ -45,6 +46,19 @@ func TestChannelConfig_Check(t *testing.T) {
 	timeoutChannelConfig := defaultTestChannelConfig
 	timeoutChannelConfig.ChannelTimeout = 0
 	timeoutChannelConfig.SubSafetyMargin = 1
+	shortDepositOnlyChannelConfig := defaultTestChannelConfig
+	shortDepositOnlyChannelConfig.MaxChannelDuration = 5
+	shortDepositOnlyChannelConfig.DepositOnlyChannelDuration = 4
+	noDurationDepositOnlyChannelConfig := defaultTestChannelConfig
+	noDurationDepositOnlyChannelConfig.MaxChannelDuration = 0
+	noDurationDepositOnlyChannelConfig.DepositOnlyChannelDuration = 4
+	unknownCompressionChannelConfig := defaultTestChannelConfig
+	unknownCompressionChannelConfig.Compression = derive.CompressionConfig{Algo: "lz4"}
+	unknownBatchTypeChannelConfig := defaultTestChannelConfig
+	unknownBatchTypeChannelConfig.BatchType = 2
+	unscheduledSpanBatchChannelConfig := defaultTestChannelConfig
+	unscheduledSpanBatchChannelConfig.BatchType = derive.SpanBatchType
+	unscheduledSpanBatchChannelConfig.RollupConfig = &rollup.Config{}
 	tests := []test{
 		{
 			input: defaultTestChannelConfig,
@@ -64,6 +78,36 @@ func TestChannelConfig_Check(t *testing.T) {
 				require.EqualError(t, output, "max frame size cannot be zero")
 			},
 		},
+		{
+			input: shortDepositOnlyChannelConfig,
+			assertion: func(output error) {
+				require.EqualError(t, output, "deposit-only channel duration 4 is less than the max channel duration 5")
+			},
+		},
+		{
+			input: noDurationDepositOnlyChannelConfig,
+			assertion: func(output error) {
+				require.EqualError(t, output, "deposit-only channel duration requires a max channel duration")
+			},
+		},
+		{
+			input: unknownCompressionChannelConfig,
+			assertion: func(output error) {
+				require.ErrorIs(t, output, derive.ErrUnknownCompressionAlgo)
+			},
+		},
+		{
+			input: unknownBatchTypeChannelConfig,
+			assertion: func(output error) {
+				require.EqualError(t, output, "unknown batch type 2")
+			},
+		},
+		{
+			input: unscheduledSpanBatchChannelConfig,
+			assertion: func(output error) {
+				require.EqualError(t, output, "span batches are not scheduled by the rollup config")
+			},
+		},
 	}
 	for i := 1; i < derive.FrameV0OverHeadSize; i++ {
 		smallChannelConfig := defaultTestChannelConfig
@@ -153,6 +197,42 @@ func newMiniL2BlockWithNumberParent(numTx int, number *big.Int, parent common.Ha
 	}, txs, nil, nil, trie.NewStackTrie(nil))
 }
 
+// newConsecutiveL2Blocks returns n minimal L2 blocks, each with one
+// transaction, following each other every blockTime seconds from time, and
+// adopting a new L1 origin, starting at 100, every third block.
+func newConsecutiveL2Blocks(n int, time uint64, blockTime uint64) []*types.Block {
+	return newConsecutiveL2BlocksWithTxs(n, 1, time, blockTime)
+}
+
+// newConsecutiveL2BlocksWithTxs is newConsecutiveL2Blocks with numTx
+// transactions per block, deposit-only blocks if 0.
+func newConsecutiveL2BlocksWithTxs(n int, numTx int, time uint64, blockTime uint64) []*types.Block {
+	blocks := make([]*types.Block, n)
+	parent := common.Hash{}
+	for i := range blocks {
+		l1Block := types.NewBlock(&types.Header{
+			BaseFee:    big.NewInt(10),
+			Difficulty: common.Big0,
+			Number:     big.NewInt(100 + int64(i/3)),
+		}, nil, nil, nil, trie.NewStackTrie(nil))
+		l1InfoTx, err := derive.L1InfoDeposit(uint64(i%3), l1Block, eth.SystemConfig{})
+		if err != nil {
+			panic(err)
+		}
+		txs := []*types.Transaction{types.NewTx(l1InfoTx)}
+		for j := 0; j < numTx; j++ {
+			txs = append(txs, types.NewTx(&types.DynamicFeeTx{Nonce: uint64(i*numTx + j)}))
+		}
+		blocks[i] = types.NewBlock(&types.Header{
+			Number:     big.NewInt(int64(i)),
+			ParentHash: parent,
+			Time:       time + uint64(i)*blockTime,
+		}, txs, nil, nil, trie.NewStackTrie(nil))
+		parent = blocks[i].Hash()
+	}
+	return blocks
+}
+
 // addTooManyBlocks adds blocks to the channel until it hits an error,
 // which is presumably ErrTooManyRLPBytes.
 func addTooManyBlocks(cb *channelBuilder) error {
@@ -657,6 +737,174 @@ func TestBuilderRegisterL1BlockZeroMaxChannelDuration(t *testing.T) {
 	require.Equal(t, uint64(0), cb.timeout)
 }
 
+// TestBuilderDeferralWindow tests that the channel duration timeout is not
+// enforced during a deferral window, while the proposing window timeout is.
+func TestBuilderDeferralWindow(t *testing.T) {
+	channelConfig := defaultTestChannelConfig
+	channelConfig.DeferralWindows = DeferralWindows{{Start: 0, End: 24 * time.Hour}}
+
+	// Construct the channel builder
+	cb, err := newChannelBuilder(channelConfig)
+	require.NoError(t, err)
+
+	// The duration timeout is not set during the deferral window
+	cb.RegisterL1Block(uint64(100))
+	require.Equal(t, uint64(0), cb.timeout)
+	require.NoError(t, cb.FullErr())
+
+	// An already set duration timeout is deferred
+	cb.updateTimeout(101, ErrMaxDurationReached)
+	cb.RegisterL1Block(uint64(102))
+	require.NoError(t, cb.FullErr())
+
+	// The proposing window timeout still closes the channel
+	cb.pwTimeout = 103
+	cb.RegisterL1Block(uint64(103))
+	require.ErrorIs(t, cb.FullErr(), ErrProposerWindowClose)
+}
+
+// TestBuilderDeferralWindowChannelTimeout tests that a channel with a frame
+// published during a deferral window is closed at the channel timeout, even if
+// the earlier channel duration timeout is deferred.
+func TestBuilderDeferralWindowChannelTimeout(t *testing.T) {
+	channelConfig := defaultTestChannelConfig
+	channelConfig.DeferralWindows = DeferralWindows{{Start: 0, End: 24 * time.Hour}}
+
+	// Construct the channel builder
+	cb, err := newChannelBuilder(channelConfig)
+	require.NoError(t, err)
+
+	// The duration timeout set before the window is kept as the earliest
+	cb.updateTimeout(101, ErrMaxDurationReached)
+	cb.FramePublished(100)
+	require.Equal(t, uint64(101), cb.timeout)
+
+	cb.RegisterL1Block(uint64(135))
+	require.NoError(t, cb.FullErr())
+	cb.RegisterL1Block(uint64(136))
+	require.ErrorIs(t, cb.FullErr(), ErrChannelTimeoutClose)
+
+	// A reset channel has no channel timeout
+	require.NoError(t, cb.Reset())
+	require.Zero(t, cb.ctTimeout)
+}
+
+// TestBuilderDeferralWindowEdges tests that the channel duration timeout is
+// deferred from the start of a deferral window until right before its end.
+func TestBuilderDeferralWindowEdges(t *testing.T) {
+	channelConfig := defaultTestChannelConfig
+	channelConfig.MaxChannelDuration = 1
+	channelConfig.DeferralWindows = DeferralWindows{{Start: 22 * time.Hour, End: 2 * time.Hour}}
+	at := func(day, hour, min, sec int) time.Time {
+		return time.Date(2023, 5, day, hour, min, sec, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name     string
+		now      time.Time
+		deferred bool
+	}{
+		{name: "before the window", now: at(1, 21, 59, 59), deferred: false},
+		{name: "start of the window", now: at(1, 22, 0, 0), deferred: true},
+		{name: "midnight", now: at(2, 0, 0, 0), deferred: true},
+		{name: "end of the window", now: at(2, 1, 59, 59), deferred: true},
+		{name: "after the window", now: at(2, 2, 0, 0), deferred: false},
+		{name: "local time zone", now: at(1, 22, 30, 0).In(time.FixedZone("UTC+9", 9*60*60)), deferred: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cb, err := newChannelBuilder(channelConfig)
+			require.NoError(t, err)
+			cb.timeNow = func() time.Time { return test.now }
+
+			cb.RegisterL1Block(100)
+			if test.deferred {
+				require.Zero(t, cb.timeout)
+			} else {
+				require.Equal(t, uint64(101), cb.timeout)
+			}
+
+			// an already set duration timeout only closes the channel outside of the window
+			cb.updateTimeout(101, ErrMaxDurationReached)
+			cb.RegisterL1Block(101)
+			if test.deferred {
+				require.NoError(t, cb.FullErr())
+			} else {
+				require.ErrorIs(t, cb.FullErr(), ErrMaxDurationReached)
+			}
+		})
+	}
+}
+
+// TestBuilderDepositOnlyChannelDuration tests that a deposit-only channel is
+// kept open for the deposit-only channel duration, and that the max channel
+// duration applies from its start once a block with transactions is added.
+func TestBuilderDepositOnlyChannelDuration(t *testing.T) {
+	channelConfig := defaultTestChannelConfig
+	channelConfig.ProposerWindowSize = 100
+	channelConfig.DepositOnlyChannelDuration = 5
+
+	// Construct the channel builder
+	cb, err := newChannelBuilder(channelConfig)
+	require.NoError(t, err)
+
+	_, err = cb.AddBlock(newMiniL2Block(0))
+	require.NoError(t, err)
+	require.True(t, cb.DepositOnly())
+	cb.RegisterL1Block(uint64(100))
+	require.Equal(t, uint64(105), cb.timeout)
+	cb.RegisterL1Block(uint64(104))
+	require.NoError(t, cb.FullErr())
+
+	_, err = cb.AddBlock(newMiniL2Block(1))
+	require.NoError(t, err)
+	require.False(t, cb.DepositOnly())
+	require.Equal(t, uint64(101), cb.timeout)
+	cb.RegisterL1Block(uint64(104))
+	require.ErrorIs(t, cb.FullErr(), ErrMaxDurationReached)
+
+	// A reset channel is deposit-only again
+	require.NoError(t, cb.Reset())
+	_, err = cb.AddBlock(newMiniL2Block(0))
+	require.NoError(t, err)
+	require.True(t, cb.DepositOnly())
+	cb.RegisterL1Block(uint64(150))
+	require.Equal(t, uint64(155), cb.timeout)
+}
+
+// TestBuilderFlushSafeLag tests that a channel with blocks is closed once its
+// oldest block lags the unsafe head by more than the flush safe lag, even
+// during a deferral window.
+func TestBuilderFlushSafeLag(t *testing.T) {
+	channelConfig := defaultTestChannelConfig
+	channelConfig.FlushSafeLag = 10
+	channelConfig.DeferralWindows = DeferralWindows{{Start: 0, End: 24 * time.Hour}}
+
+	// Construct the channel builder
+	cb, err := newChannelBuilder(channelConfig)
+	require.NoError(t, err)
+
+	// An empty channel is not closed
+	cb.RegisterUnsafeHead(11)
+	require.NoError(t, cb.FullErr())
+
+	_, err = cb.AddBlock(newMiniL2Block(0))
+	require.NoError(t, err)
+	cb.RegisterUnsafeHead(10)
+	require.NoError(t, cb.FullErr())
+	cb.RegisterUnsafeHead(11)
+	require.ErrorIs(t, cb.FullErr(), ErrSafeLagReached)
+
+	// The safe head lag is not checked if disabled
+	channelConfig.FlushSafeLag = 0
+	cb, err = newChannelBuilder(channelConfig)
+	require.NoError(t, err)
+	_, err = cb.AddBlock(newMiniL2Block(0))
+	require.NoError(t, err)
+	cb.RegisterUnsafeHead(1000)
+	require.NoError(t, cb.FullErr())
+}
+
 // TestFramePublished tests the FramePublished function
 func TestFramePublished(t *testing.T) {
 	channelConfig := defaultTestChannelConfig
@@ -747,3 +995,202 @@ func blockBatchRlpSize(t *testing.T, b *types.Block) int {
 	require.NoError(t, batch.EncodeRLP(&buf), "RLP-encoding batch")
 	return buf.Len()
 }
+
+// harnessChannelBuilder adapts the channelBuilder to the channel builder of the
+// round-trip harness.
+type harnessChannelBuilder struct {
+	*channelBuilder
+}
+
+func (c harnessChannelBuilder) AddBlock(block *types.Block) error {
+	_, err := c.channelBuilder.AddBlock(block)
+	return err
+}
+
+func (c harnessChannelBuilder) NextFrame() ([]byte, bool) {
+	if !c.HasFrame() {
+		return nil, false
+	}
+	return c.channelBuilder.NextFrame().data, true
+}
+
+func newHarnessChannelBuilder(cfg ChannelConfig) func() (btest.ChannelBuilder, error) {
+	return func() (btest.ChannelBuilder, error) {
+		cb, err := newChannelBuilder(cfg)
+		if err != nil {
+			return nil, err
+		}
+		return harnessChannelBuilder{cb}, nil
+	}
+}
+
+// TestChannelBuilder_RoundTrip tests that the frames of the channel builder
+// are decoded into the batches of the added blocks by the rollup node, for
+// channels closed by the input target and by the max channel duration.
+func TestChannelBuilder_RoundTrip(t *testing.T) {
+	cfg := defaultTestChannelConfig
+	cfg.MaxFrameSize = 1000
+	cfg.TargetFrameSize = 1000
+	cfg.TargetNumFrames = 4
+	cfg.ApproxComprRatio = 1.0
+	cfg.MaxChannelDuration = 2
+
+	for _, interval := range []int{0, 1, 5} {
+		t.Run(fmt.Sprintf("l1 block interval %d", interval), func(t *testing.T) {
+			rng := rand.New(rand.NewSource(1234))
+			btest.CheckChannelRoundTrip(t, rng, newHarnessChannelBuilder(cfg), btest.ChannelParams{
+				Blocks:          64,
+				MaxTxs:          8,
+				L1BlockInterval: interval,
+				MaxFrameSize:    cfg.MaxFrameSize,
+			})
+		})
+	}
+
+	for _, algo := range derive.CompressionAlgos {
+		cfg := cfg
+		cfg.Compression = derive.CompressionConfig{Algo: algo}
+		t.Run(fmt.Sprintf("compression %s", algo), func(t *testing.T) {
+			rng := rand.New(rand.NewSource(1234))
+			btest.CheckChannelRoundTrip(t, rng, newHarnessChannelBuilder(cfg), btest.ChannelParams{
+				Blocks:          64,
+				MaxTxs:          8,
+				L1BlockInterval: 1,
+				MaxFrameSize:    cfg.MaxFrameSize,
+			})
+		})
+	}
+}
+
+// TestChannelBuilder_SpanBatch tests that the blocks of a span batch channel
+// are decoded by the rollup node into a single span batch of all the blocks.
+func TestChannelBuilder_SpanBatch(t *testing.T) {
+	cfg := defaultTestChannelConfig
+	cfg.BatchType = derive.SpanBatchType
+	cfg.RollupConfig = &rollup.Config{
+		Genesis:       rollup.Genesis{L2Time: 10},
+		BlockTime:     2,
+		SpanBatchTime: new(uint64),
+	}
+	blocks := newConsecutiveL2Blocks(10, 12, cfg.RollupConfig.BlockTime)
+
+	cb, err := newChannelBuilder(cfg)
+	require.NoError(t, err)
+	singular := 0
+	for _, block := range blocks {
+		_, err := cb.AddBlock(block)
+		require.NoError(t, err)
+		singular += blockBatchRlpSize(t, block)
+	}
+	require.Less(t, cb.InputBytes(), singular, "span batch must be smaller than the singular batches")
+	cb.Close()
+	require.NoError(t, cb.OutputFrames())
+	var frames [][]byte
+	for cb.HasFrame() {
+		frames = append(frames, cb.NextFrame().data)
+	}
+
+	batches, err := btest.DecodeChannel(frames)
+	require.NoError(t, err)
+	require.Len(t, batches, 1)
+	span := batches[0].SpanBatch
+	require.NotNil(t, span)
+	require.Len(t, span.Blocks, len(blocks))
+	require.Equal(t, blocks[0].ParentHash().Bytes()[:20], span.ParentCheck[:])
+	for i, block := range blocks {
+		batch, l1Info, err := derive.BlockToBatch(block)
+		require.NoError(t, err)
+		require.Equal(t, block.Time(), span.Timestamp(cfg.RollupConfig, i))
+		require.Equal(t, l1Info.Number, span.EpochNum(i))
+		require.Equal(t, batch.Transactions, span.Blocks[i].Transactions)
+	}
+
+	// the blocks of a span batch must be consecutive
+	cb, err = newChannelBuilder(cfg)
+	require.NoError(t, err)
+	_, err = cb.AddBlock(blocks[0])
+	require.NoError(t, err)
+	_, err = cb.AddBlock(blocks[2])
+	require.Error(t, err)
+	require.False(t, cb.IsFull())
+}
+
+// TestChannelBuilder_DepositOnlySpanBatch tests the round trip of a span
+// batch channel of deposit-only blocks, and that its batch grows by about a
+// byte per block, unlike the singular batches of the blocks.
+func TestChannelBuilder_DepositOnlySpanBatch(t *testing.T) {
+	cfg := defaultTestChannelConfig
+	cfg.BatchType = derive.SpanBatchType
+	cfg.RollupConfig = &rollup.Config{
+		Genesis:       rollup.Genesis{L2Time: 10},
+		BlockTime:     2,
+		SpanBatchTime: new(uint64),
+	}
+	const n = 300
+	blocks := newConsecutiveL2BlocksWithTxs(n, 0, 12, cfg.RollupConfig.BlockTime)
+
+	cb, err := newChannelBuilder(cfg)
+	require.NoError(t, err)
+	singular := 0
+	firstBytes := 0
+	for i, block := range blocks {
+		_, err := cb.AddBlock(block)
+		require.NoError(t, err)
+		singular += blockBatchRlpSize(t, block)
+		if i == 0 {
+			firstBytes = cb.InputBytes()
+		}
+	}
+	require.True(t, cb.DepositOnly())
+	// the tx count and the origin bit of each block, plus the RLP string header
+	// and the block count of the span batch
+	require.LessOrEqual(t, cb.InputBytes()-firstBytes, n*9/8+8, "span batch must grow by about a byte per block")
+	require.Less(t, cb.InputBytes()*50, singular, "span batch must be much smaller than the singular batches")
+	cb.Close()
+	require.NoError(t, cb.OutputFrames())
+	var frames [][]byte
+	for cb.HasFrame() {
+		frames = append(frames, cb.NextFrame().data)
+	}
+
+	batches, err := btest.DecodeChannel(frames)
+	require.NoError(t, err)
+	require.Len(t, batches, 1)
+	span := batches[0].SpanBatch
+	require.NotNil(t, span)
+	require.Len(t, span.Blocks, n)
+	for i, block := range blocks {
+		_, l1Info, err := derive.BlockToBatch(block)
+		require.NoError(t, err)
+		require.Equal(t, block.Time(), span.Timestamp(cfg.RollupConfig, i))
+		require.Equal(t, l1Info.Number, span.EpochNum(i))
+		require.Empty(t, span.Blocks[i].Transactions)
+	}
+}
+
+// FuzzChannelBuilder_RoundTrip fuzzes the size limits and timeouts of the
+// channel builder with the round-trip harness.
+func FuzzChannelBuilder_RoundTrip(f *testing.F) {
+	f.Add(int64(0), uint16(1000), uint8(1), uint8(1), uint8(0))
+	f.Add(int64(1), uint16(200), uint8(10), uint8(0), uint8(3))
+	f.Add(int64(2), uint16(20000), uint8(2), uint8(4), uint8(1))
+	f.Fuzz(func(t *testing.T, seed int64, maxFrameSize uint16, targetNumFrames uint8, maxChannelDuration uint8, l1BlockInterval uint8) {
+		cfg := defaultTestChannelConfig
+		cfg.MaxFrameSize = uint64(maxFrameSize)
+		if cfg.MaxFrameSize < derive.FrameV0OverHeadSize+1 {
+			cfg.MaxFrameSize = derive.FrameV0OverHeadSize + 1
+		}
+		cfg.TargetFrameSize = cfg.MaxFrameSize
+		cfg.TargetNumFrames = int(targetNumFrames%16) + 1
+		cfg.ApproxComprRatio = 1.0
+		cfg.MaxChannelDuration = uint64(maxChannelDuration % 8)
+
+		rng := rand.New(rand.NewSource(seed))
+		btest.CheckChannelRoundTrip(t, rng, newHarnessChannelBuilder(cfg), btest.ChannelParams{
+			Blocks:          16,
+			MaxTxs:          8,
+			L1BlockInterval: int(l1BlockInterval % 8),
+			MaxFrameSize:    cfg.MaxFrameSize,
+		})
+	})
+}
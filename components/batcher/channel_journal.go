@@ -0,0 +1,247 @@
+package batcher
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/kroma-network/kroma/components/node/eth"
+	"github.com/kroma-network/kroma/components/node/rollup/derive"
+)
+
+// journaledChannel is the state of a closed pending channel, as persisted by the channelJournal.
+type journaledChannel struct {
+	ID derive.ChannelID `json:"id"`
+	// Blocks are the L2 blocks batched in the channel.
+	Blocks []eth.BlockID `json:"blocks"`
+	// Frames are all the frames of the channel, by frame number.
+	Frames []journaledFrame `json:"frames"`
+}
+
+type journaledFrame struct {
+	// Data is the frame data, omitted once the frame is confirmed.
+	Data hexutil.Bytes `json:"data,omitempty"`
+	// Inclusion is the L1 block the frame was confirmed in, nil while the frame is not confirmed.
+	Inclusion *eth.BlockID `json:"inclusion,omitempty"`
+}
+
+// channelJournal persists the frames of the pending channel and their submission status to a file once the channel
+// is closed, so that a restarted batcher resumes the submission of a partially submitted channel instead of batching
+// its blocks again. The frames of a channel still open cannot be resumed, as the compression state is not persisted.
+// A nil *channelJournal does not persist the channel.
+type channelJournal struct {
+	log  log.Logger
+	path string
+}
+
+// newChannelJournal creates a journal persisting to the file of the path, nil if the path is empty.
+func newChannelJournal(log log.Logger, path string) *channelJournal {
+	if path == "" {
+		return nil
+	}
+	return &channelJournal{log: log, path: path}
+}
+
+// load returns the persisted channel, nil if there is none.
+func (j *channelJournal) load() (*journaledChannel, error) {
+	if j == nil {
+		return nil, nil
+	}
+	data, err := os.ReadFile(j.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read channel journal: %w", err)
+	}
+	var ch journaledChannel
+	if err := json.Unmarshal(data, &ch); err != nil {
+		return nil, fmt.Errorf("failed to decode channel journal: %w", err)
+	}
+	return &ch, nil
+}
+
+// write persists the channel, replacing the file atomically. The data is synced to disk before the file is replaced,
+// so that a crash leaves either the previous or the new journal, never a truncated one.
+func (j *channelJournal) write(ch *journaledChannel) {
+	if j == nil {
+		return
+	}
+	data, err := json.Marshal(ch)
+	if err != nil {
+		j.log.Warn("failed to encode channel journal", "id", ch.ID, "err", err)
+		return
+	}
+	tmp := j.path + ".tmp"
+	if err := writeFileSync(tmp, data); err != nil {
+		j.log.Warn("failed to write channel journal", "id", ch.ID, "err", err)
+		return
+	}
+	if err := os.Rename(tmp, j.path); err != nil {
+		j.log.Warn("failed to write channel journal", "id", ch.ID, "err", err)
+	}
+}
+
+// writeFileSync writes the data to the file of the path, and syncs it to disk.
+func writeFileSync(path string, data []byte) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// clear removes the persisted channel.
+func (j *channelJournal) clear() {
+	if j == nil {
+		return
+	}
+	if err := os.Remove(j.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		j.log.Warn("failed to clear channel journal", "err", err)
+	}
+}
+
+// persistPendingChannel persists the pending channel to the journal, once it is closed.
+func (c *channelManager) persistPendingChannel() {
+	if c.journal == nil || c.pendingChannel == nil || !c.pendingChannel.IsFull() {
+		return
+	}
+	numFrames := c.pendingChannel.NumFrames() + len(c.pendingTransactions) + len(c.confirmedTransactions)
+	ch := &journaledChannel{
+		ID:     c.pendingChannel.ID(),
+		Frames: make([]journaledFrame, numFrames),
+	}
+	for _, block := range c.pendingChannel.Blocks() {
+		ch.Blocks = append(ch.Blocks, eth.ToBlockID(block))
+	}
+	unconfirmed := append([]frameData(nil), c.pendingChannel.frames...)
+	for _, data := range c.pendingTransactions {
+		unconfirmed = append(unconfirmed, data.Frame())
+	}
+	for _, frame := range unconfirmed {
+		if int(frame.id.frameNumber) >= numFrames {
+			c.log.Warn("not persisting channel with unexpected frame number", "id", frame.id)
+			return
+		}
+		ch.Frames[frame.id.frameNumber].Data = frame.data
+	}
+	for id, inclusion := range c.confirmedTransactions {
+		if int(id.frameNumber) >= numFrames {
+			c.log.Warn("not persisting channel with unexpected frame number", "id", id)
+			return
+		}
+		inclusion := inclusion
+		ch.Frames[id.frameNumber].Inclusion = &inclusion
+	}
+	c.journal.write(ch)
+}
+
+// restoreChannel restores the journaled channel of the blocks as the pending channel, to submit its unconfirmed
+// frames. The blocks must be the next blocks of the state.
+func (c *channelManager) restoreChannel(ch *journaledChannel, blocks []*types.Block, l1Head eth.BlockID) {
+	var frames []frameData
+	for i, frame := range ch.Frames {
+		if frame.Inclusion == nil {
+			frames = append(frames, frameData{id: frameID{chID: ch.ID, frameNumber: uint16(i)}, data: frame.Data})
+		}
+	}
+	cb := restoreChannelBuilder(c.cfg, ch.ID, blocks, frames, len(ch.Frames))
+	c.pendingChannel = cb
+	for i, frame := range ch.Frames {
+		if frame.Inclusion != nil {
+			c.confirmedTransactions[frameID{chID: ch.ID, frameNumber: uint16(i)}] = *frame.Inclusion
+			cb.FramePublished(frame.Inclusion.Number)
+		}
+	}
+	c.tip = blocks[len(blocks)-1].Hash()
+	c.tracer.channelOpened(ch.ID, l1Head)
+	c.costs.channelOpened(ch.ID)
+	c.metr.RecordChannelOpened(ch.ID, 0)
+	c.log.Info("Restored channel", "id", ch.ID, "l1Head", l1Head, "blocks", len(blocks),
+		"num_frames", len(ch.Frames), "unconfirmed_frames", len(frames))
+}
+
+// restorePendingChannel resumes the submission of the channel persisted by the previous batcher process, if its
+// blocks are the next blocks after the safe head and it did not time out. The blocks of the channel are then
+// considered stored, and the last one is returned, else the safe head. It returns an error if the channel could not
+// be checked, to retry on the next poll.
+func (b *BatchSubmitter) restorePendingChannel(ctx context.Context, safe eth.BlockID) (eth.BlockID, error) {
+	ch := b.journaled
+	if ch == nil {
+		return safe, nil
+	}
+	discard := func(msg string, logCtx ...interface{}) (eth.BlockID, error) {
+		b.log.Info("Not restoring channel, "+msg, append([]interface{}{"id", ch.ID}, logCtx...)...)
+		b.journaled = nil
+		b.state.journal.clear()
+		return safe, nil
+	}
+	if len(ch.Blocks) == 0 || len(ch.Frames) == 0 || ch.Blocks[0].Number != safe.Number+1 {
+		return discard("its blocks do not extend the safe head", "safe", safe)
+	}
+
+	l1tip, err := b.l1Tip(ctx)
+	if err != nil {
+		return eth.BlockID{}, fmt.Errorf("checking timeout of journaled channel: %w", err)
+	}
+	var unconfirmed int
+	for _, frame := range ch.Frames {
+		if frame.Inclusion == nil {
+			if len(frame.Data) == 0 {
+				return discard("a frame is missing")
+			}
+			unconfirmed++
+		} else if l1tip.Number >= frame.Inclusion.Number+b.Channel.ChannelTimeout {
+			return discard("it timed out", "inclusion", frame.Inclusion, "l1_tip", l1tip)
+		}
+	}
+
+	blocks := make([]*types.Block, 0, len(ch.Blocks))
+	parent := safe.Hash
+	for _, id := range ch.Blocks {
+		tctx, cancel := context.WithTimeout(ctx, b.NetworkTimeout)
+		block, err := b.L2Client.BlockByNumber(tctx, new(big.Int).SetUint64(id.Number))
+		cancel()
+		if err != nil {
+			return eth.BlockID{}, fmt.Errorf("fetching block %d of journaled channel: %w", id.Number, err)
+		}
+		if block.Hash() != id.Hash || block.ParentHash() != parent {
+			return discard("its blocks were reorged", "block", id)
+		}
+		blocks = append(blocks, block)
+		parent = block.Hash()
+	}
+
+	b.journaled = nil
+	last := ch.Blocks[len(ch.Blocks)-1]
+	b.lastStoredBlock = last
+	if unconfirmed == 0 {
+		b.log.Info("Channel was fully submitted before the restart", "id", ch.ID, "last_block", last)
+		b.state.tip = last.Hash
+		b.state.journal.clear()
+		return last, nil
+	}
+	b.state.restoreChannel(ch, blocks, l1tip.ID())
+	if b.L1ReorgDepth > 0 {
+		for _, frame := range ch.Frames {
+			if frame.Inclusion != nil {
+				b.unfinalizedInclusions = append(b.unfinalizedInclusions, *frame.Inclusion)
+			}
+		}
+	}
+	return last, nil
+}
@@ -0,0 +1,128 @@
+package batcher
+
+import (
+	"io"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kroma-network/kroma/components/batcher/metrics"
+	"github.com/kroma-network/kroma/components/node/eth"
+	"github.com/kroma-network/kroma/components/node/testlog"
+)
+
+func TestChannelJournalRestore(t *testing.T) {
+	require := require.New(t)
+	l := testlog.Logger(t, log.LvlCrit)
+	path := filepath.Join(t.TempDir(), "channel.json")
+	newManager := func() *channelManager {
+		m := NewChannelManager(l, metrics.NoopMetrics, ChannelConfig{
+			ChannelTimeout:   100,
+			MaxFrameSize:     40,
+			TargetFrameSize:  40,
+			TargetNumFrames:  100,
+			ApproxComprRatio: 1.0,
+			FlushSafeLag:     1,
+		})
+		m.journal = newChannelJournal(l, path)
+		return m
+	}
+
+	ch, err := newChannelJournal(l, path).load()
+	require.NoError(err)
+	require.Nil(ch, "no journal yet")
+
+	m := newManager()
+	a := newMiniL2Block(0)
+	b := newMiniL2BlockWithNumberParent(0, big.NewInt(1), a.Hash())
+	require.NoError(m.AddL2Block(a))
+	require.NoError(m.AddL2Block(b))
+	m.RegisterUnsafeHead(2)
+	confirmed, err := m.TxData(eth.BlockID{Number: 1})
+	require.NoError(err)
+	m.TxConfirmed(confirmed.ID(), eth.BlockID{Number: 2})
+	inFlight, err := m.TxData(eth.BlockID{Number: 2})
+	require.NoError(err)
+	numFrames := m.pendingChannel.NumFrames() + 2
+	require.Greater(numFrames, 2)
+
+	// the batcher stops with a frame in flight
+	ch, err = newChannelJournal(l, path).load()
+	require.NoError(err)
+	require.NotNil(ch)
+	require.Equal(m.pendingChannel.ID(), ch.ID)
+	require.Equal([]eth.BlockID{eth.ToBlockID(a), eth.ToBlockID(b)}, ch.Blocks)
+	require.Len(ch.Frames, numFrames)
+	require.Equal(&eth.BlockID{Number: 2}, ch.Frames[0].Inclusion)
+	require.Empty(ch.Frames[0].Data, "confirmed frame data is not kept")
+	require.Nil(ch.Frames[1].Inclusion)
+	require.Equal(inFlight.Frame().data, []byte(ch.Frames[1].Data))
+
+	restored := newManager()
+	restored.restoreChannel(ch, []*types.Block{a, b}, eth.BlockID{Number: 3})
+	require.Equal(ch.ID, restored.pendingChannel.ID())
+	for i := 1; i < numFrames; i++ {
+		txdata, err := restored.TxData(eth.BlockID{Number: 3})
+		require.NoError(err)
+		require.Equal(frameID{chID: ch.ID, frameNumber: uint16(i)}, txdata.ID(), "unconfirmed frames are resubmitted in order")
+		require.Equal([]byte(ch.Frames[i].Data), txdata.Frame().data)
+		restored.TxConfirmed(txdata.ID(), eth.BlockID{Number: 4})
+	}
+	require.Nil(restored.pendingChannel, "channel must be fully submitted")
+	_, err = os.Stat(path)
+	require.ErrorIs(err, os.ErrNotExist, "journal is cleared once the channel is fully submitted")
+
+	// the restored blocks are the tip of the state
+	require.NoError(restored.AddL2Block(newMiniL2BlockWithNumberParent(0, big.NewInt(2), b.Hash())))
+}
+
+func TestChannelJournalNotPersistedWhileOpen(t *testing.T) {
+	require := require.New(t)
+	l := testlog.Logger(t, log.LvlCrit)
+	path := filepath.Join(t.TempDir(), "channel.json")
+	m := NewChannelManager(l, metrics.NoopMetrics, ChannelConfig{
+		ChannelTimeout:   100,
+		MaxFrameSize:     40,
+		TargetFrameSize:  40,
+		TargetNumFrames:  100,
+		ApproxComprRatio: 1.0,
+	})
+	m.journal = newChannelJournal(l, path)
+
+	require.NoError(m.AddL2Block(newMiniL2Block(0)))
+	_, err := m.TxData(eth.BlockID{Number: 1})
+	require.ErrorIs(err, io.EOF)
+	require.False(m.pendingChannel.IsFull())
+	_, err = os.Stat(path)
+	require.ErrorIs(err, os.ErrNotExist, "the frames of an open channel cannot be resumed")
+
+	// a closed channel without submitted frames is not kept on shutdown
+	require.NoError(m.Close())
+	_, err = os.Stat(path)
+	require.ErrorIs(err, os.ErrNotExist)
+}
+
+func TestChannelJournalWrite(t *testing.T) {
+	require := require.New(t)
+	path := filepath.Join(t.TempDir(), "channel.json")
+	j := newChannelJournal(testlog.Logger(t, log.LvlCrit), path)
+
+	// a leftover of an interrupted write is replaced
+	require.NoError(os.WriteFile(path+".tmp", make([]byte, 4096), 0o600))
+	ch := &journaledChannel{
+		Blocks: []eth.BlockID{{Number: 1}},
+		Frames: []journaledFrame{{Data: []byte{0x01, 0x02}}},
+	}
+	j.write(ch)
+	_, err := os.Stat(path + ".tmp")
+	require.ErrorIs(err, os.ErrNotExist, "the temporary file is renamed to the journal")
+
+	loaded, err := j.load()
+	require.NoError(err)
+	require.Equal(ch, loaded)
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/kroma-network/kroma/components/batcher/metrics"
 	"github.com/kroma-network/kroma/components/node/eth"
@@ -25,14 +26,23 @@ var ErrReorg = errors.New("block does not extend existing chain")
 // channel.
 // Functions on channelManager are not safe for concurrent access.
 type channelManager struct {
-	log  log.Logger
-	metr metrics.Metricer
-	cfg  ChannelConfig
+	log    log.Logger
+	metr   metrics.Metricer
+	cfg    ChannelConfig
+	tracer *batchTracer
+	costs  *daCostTracker
+	acks   *verifierAcks
+	// journal persists the pending channel once it is closed, optional (may be nil)
+	journal *channelJournal
 
 	// All blocks since the last request for new tx data.
 	blocks []*types.Block
 	// last block hash - for reorg detection
 	tip common.Hash
+	// number of the unsafe head, as last seen
+	unsafeHead uint64
+	// timestamp of the L1 head, as last seen
+	l1HeadTime uint64
 
 	// Pending data returned by TxData waiting on Tx Confirmed/Failed
 
@@ -49,9 +59,11 @@ type channelManager struct {
 
 func NewChannelManager(log log.Logger, metr metrics.Metricer, cfg ChannelConfig) *channelManager {
 	return &channelManager{
-		log:  log,
-		metr: metr,
-		cfg:  cfg,
+		log:    log,
+		metr:   metr,
+		cfg:    cfg,
+		tracer: newBatchTracer(trace.NewNoopTracerProvider().Tracer("")),
+		costs:  newDACostTracker(log, nil),
 
 		pendingTransactions:   make(map[txID]txData),
 		confirmedTransactions: make(map[txID]eth.BlockID),
@@ -64,8 +76,12 @@ func (c *channelManager) Clear() {
 	c.log.Trace("clearing channel manager state")
 	c.blocks = c.blocks[:0]
 	c.tip = common.Hash{}
+	c.unsafeHead = 0
 	c.closed = false
-	c.clearPendingChannel()
+	c.clearPendingChannel(errChannelCleared)
+	c.tracer.clear()
+	c.costs.clear()
+	c.acks.clear()
 }
 
 // TxFailed records a transaction as failed. It will attempt to resubmit the data
@@ -85,7 +101,7 @@ func (c *channelManager) TxFailed(id txID) {
 	c.metr.RecordBatchTxFailed()
 	if c.closed && len(c.confirmedTransactions) == 0 && len(c.pendingTransactions) == 0 {
 		c.log.Info("Channel has no submitted transactions, clearing for shutdown", "chID", c.pendingChannel.ID())
-		c.clearPendingChannel()
+		c.clearPendingChannel(errChannelShutdown)
 	}
 }
 
@@ -112,19 +128,31 @@ func (c *channelManager) TxConfirmed(id txID, inclusionBlock eth.BlockID) {
 		c.metr.RecordChannelTimedOut(c.pendingChannel.ID())
 		c.log.Warn("Channel timed out", "id", c.pendingChannel.ID())
 		c.blocks = append(c.pendingChannel.Blocks(), c.blocks...)
-		c.clearPendingChannel()
+		c.clearPendingChannel(errChannelTimedOut)
 	}
 	// If we are done with this channel, record that.
 	if c.pendingChannelIsFullySubmitted() {
 		c.metr.RecordChannelFullySubmitted(c.pendingChannel.ID())
 		c.log.Info("Channel is fully submitted", "id", c.pendingChannel.ID())
-		c.clearPendingChannel()
+		if blocks := c.pendingChannel.Blocks(); len(blocks) > 0 {
+			c.acks.channelSubmitted(submittedChannel{
+				id:        c.pendingChannel.ID(),
+				lastBlock: eth.ToBlockID(blocks[len(blocks)-1]),
+				inclusion: c.lastInclusionBlock(),
+			})
+		}
+		c.clearPendingChannel(nil)
 	}
+	c.persistPendingChannel()
 }
 
 // clearPendingChannel resets all pending state back to an initialized but empty state.
+// The reason is the error why the channel is cleared before it was fully submitted, nil otherwise.
 // TODO: Create separate "pending" state
-func (c *channelManager) clearPendingChannel() {
+func (c *channelManager) clearPendingChannel(reason error) {
+	c.tracer.channelEnded(reason)
+	c.costs.channelEnded(reason)
+	c.journal.clear()
 	c.pendingChannel = nil
 	c.pendingTransactions = make(map[txID]txData)
 	c.confirmedTransactions = make(map[txID]eth.BlockID)
@@ -155,6 +183,17 @@ func (c *channelManager) pendingChannelIsTimedOut() bool {
 	return max-min >= c.cfg.ChannelTimeout
 }
 
+// lastInclusionBlock returns the highest L1 inclusion block number of the confirmed transactions.
+func (c *channelManager) lastInclusionBlock() uint64 {
+	var last uint64
+	for _, inclusionBlock := range c.confirmedTransactions {
+		if inclusionBlock.Number > last {
+			last = inclusionBlock.Number
+		}
+	}
+	return last
+}
+
 // pendingChannelIsFullySubmitted returns true if the channel has been fully submitted.
 func (c *channelManager) pendingChannelIsFullySubmitted() bool {
 	if c.pendingChannel == nil {
@@ -176,6 +215,8 @@ func (c *channelManager) nextTxData() (txData, error) {
 
 	c.log.Trace("returning next tx data", "id", id)
 	c.pendingTransactions[id] = txdata
+	c.tracer.frameSubmitted(id, len(frame.data))
+	c.costs.frameSubmitted(id, len(txdata.Bytes()))
 	return txdata, nil
 }
 
@@ -225,7 +266,22 @@ func (c *channelManager) ensurePendingChannel(l1Head eth.BlockID) error {
 		return nil
 	}
 
-	cb, err := newChannelBuilder(c.cfg)
+	cfg := c.cfg
+	if cfg.BatchType == derive.SpanBatchType && !cfg.RollupConfig.IsSpanBatch(c.blocks[0].Time()) {
+		// the span batch would not be accepted yet
+		cfg.BatchType = derive.BatchV1Type
+	}
+	if cfg.BatchType == derive.BatchV1Type && cfg.DepositOnlyChannelDuration != 0 &&
+		cfg.RollupConfig != nil && cfg.RollupConfig.IsSpanBatch(c.blocks[0].Time()) && c.depositOnlyBlocks() {
+		// the batches of deposit-only blocks are mostly made of their parent hashes, epochs and timestamps,
+		// which the span batch amortizes over all the blocks of the channel
+		cfg.BatchType = derive.SpanBatchType
+	}
+	if !isZlib(cfg.Compression) && (cfg.RollupConfig == nil || !cfg.RollupConfig.IsChannelCompression(c.l1HeadTime)) {
+		// the channel is included at or after the L1 head, and would not be accepted yet
+		cfg.Compression = derive.CompressionConfig{}
+	}
+	cb, err := newChannelBuilder(cfg)
 	if err != nil {
 		return fmt.Errorf("creating new channel: %w", err)
 	}
@@ -233,17 +289,47 @@ func (c *channelManager) ensurePendingChannel(l1Head eth.BlockID) error {
 	c.log.Info("Created channel",
 		"id", cb.ID(),
 		"l1Head", l1Head,
-		"blocks_pending", len(c.blocks))
+		"blocks_pending", len(c.blocks),
+		"batch_type", cfg.BatchType,
+		"compression", cfg.Compression.Algo)
 	c.metr.RecordChannelOpened(cb.ID(), len(c.blocks))
+	c.tracer.channelOpened(cb.ID(), l1Head)
+	c.costs.channelOpened(cb.ID())
 
 	return nil
 }
 
-// registerL1Block registers the given block at the pending channel.
+// depositOnlyBlocks returns whether all the pending blocks are deposit-only blocks.
+func (c *channelManager) depositOnlyBlocks() bool {
+	for _, block := range c.blocks {
+		if !isDepositOnly(block) {
+			return false
+		}
+	}
+	return true
+}
+
+// RegisterL1Head records the timestamp of the L1 head. The channels are included at or after the L1 head, so they
+// are compressed with zlib until the channel compression activation of the rollup config is reached.
+func (c *channelManager) RegisterL1Head(head eth.L1BlockRef) {
+	c.l1HeadTime = head.Time
+}
+
+// RegisterUnsafeHead records the number of the unsafe head, to close the
+// pending channel once its oldest block lags the unsafe head by more than the
+// FlushSafeLag.
+func (c *channelManager) RegisterUnsafeHead(number uint64) {
+	c.unsafeHead = number
+}
+
+// registerL1Block registers the given block and the last seen unsafe head at
+// the pending channel.
 func (c *channelManager) registerL1Block(l1Head eth.BlockID) {
 	c.pendingChannel.RegisterL1Block(l1Head.Number)
+	c.pendingChannel.RegisterUnsafeHead(c.unsafeHead)
 	c.log.Debug("new L1-block registered at channel builder",
 		"l1Head", l1Head,
+		"unsafe_head", c.unsafeHead,
 		"channel_full", c.pendingChannel.IsFull(),
 		"full_reason", c.pendingChannel.FullErr(),
 	)
@@ -258,6 +344,7 @@ func (c *channelManager) processBlocks() error {
 		latestL2ref eth.L2BlockRef
 	)
 	for i, block := range c.blocks {
+		inputBytes := c.pendingChannel.InputBytes()
 		l1info, err := c.pendingChannel.AddBlock(block)
 		if errors.As(err, &_chFullErr) {
 			// current block didn't get added because channel is already full
@@ -266,6 +353,8 @@ func (c *channelManager) processBlocks() error {
 			return fmt.Errorf("adding block[%d] to channel builder: %w", i, err)
 		}
 		blocksAdded += 1
+		c.tracer.blockAdded(block, l1info)
+		c.costs.blockAdded(block, c.pendingChannel.InputBytes()-inputBytes)
 		latestL2ref = l2BlockRefFromBlockAndL1Info(block, l1info)
 		// current block got added but channel is now full
 		if c.pendingChannel.IsFull() {
@@ -311,8 +400,10 @@ func (c *channelManager) outputFrames() error {
 		c.pendingChannel.NumFrames(),
 		inBytes,
 		outBytes,
+		c.pendingChannel.CompressionAlgo(),
 		c.pendingChannel.FullErr(),
 	)
+	c.tracer.channelFull(c.pendingChannel.NumFrames(), inBytes, outBytes, c.pendingChannel.FullErr())
 
 	var comprRatio float64
 	if inBytes > 0 {
@@ -326,7 +417,10 @@ func (c *channelManager) outputFrames() error {
 		"output_bytes", outBytes,
 		"full_reason", c.pendingChannel.FullErr(),
 		"compr_ratio", comprRatio,
+		"compression", c.pendingChannel.CompressionAlgo(),
+		"deposit_only", c.pendingChannel.DepositOnly(),
 	)
+	c.persistPendingChannel()
 	return nil
 }
 
@@ -339,6 +433,7 @@ func (c *channelManager) AddL2Block(block *types.Block) error {
 	}
 	c.blocks = append(c.blocks, block)
 	c.tip = block.Hash()
+	c.tracer.blockIngested(block)
 
 	return nil
 }
@@ -366,7 +461,7 @@ func (c *channelManager) Close() error {
 
 	// Any pending state can be proactively cleared if there are no submitted transactions
 	if len(c.confirmedTransactions) == 0 && len(c.pendingTransactions) == 0 {
-		c.clearPendingChannel()
+		c.clearPendingChannel(errChannelShutdown)
 	}
 
 	if c.pendingChannel == nil {
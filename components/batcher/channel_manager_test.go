@@ -14,6 +14,7 @@ import (
 
 	"github.com/kroma-network/kroma/components/batcher/metrics"
 	"github.com/kroma-network/kroma/components/node/eth"
+	"github.com/kroma-network/kroma/components/node/rollup"
 	"github.com/kroma-network/kroma/components/node/rollup/derive"
 	derivetest "github.com/kroma-network/kroma/components/node/rollup/derive/test"
 	"github.com/kroma-network/kroma/components/node/testlog"
@@ -231,6 +232,209 @@ func TestChannelManager_Clear(t *testing.T) {
 	require.Empty(m.confirmedTransactions)
 }
 
+// TestChannelManagerSpanBatchActivation checks that span batch channels are
+// only created once the span batches are activated.
+func TestChannelManagerSpanBatchActivation(t *testing.T) {
+	require := require.New(t)
+	log := testlog.Logger(t, log.LvlCrit)
+	spanBatchTime := uint64(16)
+	m := NewChannelManager(log, metrics.NoopMetrics, ChannelConfig{
+		ChannelTimeout:   10,
+		MaxFrameSize:     1000,
+		TargetFrameSize:  1000,
+		TargetNumFrames:  1,
+		ApproxComprRatio: 1.0,
+		BatchType:        derive.SpanBatchType,
+		RollupConfig: &rollup.Config{
+			Genesis:       rollup.Genesis{L2Time: 10},
+			BlockTime:     2,
+			SpanBatchTime: &spanBatchTime,
+		},
+	})
+
+	blocks := newConsecutiveL2Blocks(4, 14, 2)
+	require.NoError(m.AddL2Block(blocks[0]))
+	require.NoError(m.ensurePendingChannel(eth.BlockID{}))
+	require.Equal(uint(derive.BatchV1Type), m.pendingChannel.cfg.BatchType, "span batches are not activated yet")
+
+	m.Clear()
+	require.NoError(m.AddL2Block(blocks[1]))
+	require.NoError(m.ensurePendingChannel(eth.BlockID{}))
+	require.Equal(uint(derive.SpanBatchType), m.pendingChannel.cfg.BatchType)
+}
+
+// TestChannelManagerDepositOnlySpanBatch checks that channels opened with
+// deposit-only blocks are encoded as span batches once they are activated,
+// whatever the batch type.
+func TestChannelManagerDepositOnlySpanBatch(t *testing.T) {
+	require := require.New(t)
+	log := testlog.Logger(t, log.LvlCrit)
+	spanBatchTime := uint64(16)
+	cfg := ChannelConfig{
+		ChannelTimeout:             10,
+		MaxFrameSize:               1000,
+		TargetFrameSize:            1000,
+		TargetNumFrames:            1,
+		ApproxComprRatio:           1.0,
+		MaxChannelDuration:         2,
+		DepositOnlyChannelDuration: 8,
+		RollupConfig: &rollup.Config{
+			Genesis:       rollup.Genesis{L2Time: 10},
+			BlockTime:     2,
+			SpanBatchTime: &spanBatchTime,
+		},
+	}
+	m := NewChannelManager(log, metrics.NoopMetrics, cfg)
+
+	blocks := newConsecutiveL2BlocksWithTxs(4, 0, 14, 2)
+	require.NoError(m.AddL2Block(blocks[0]))
+	require.NoError(m.ensurePendingChannel(eth.BlockID{}))
+	require.Equal(uint(derive.BatchV1Type), m.pendingChannel.cfg.BatchType, "span batches are not activated yet")
+
+	m.Clear()
+	require.NoError(m.AddL2Block(blocks[1]))
+	require.NoError(m.AddL2Block(blocks[2]))
+	require.NoError(m.ensurePendingChannel(eth.BlockID{}))
+	require.Equal(uint(derive.SpanBatchType), m.pendingChannel.cfg.BatchType)
+
+	m.Clear()
+	withTxs := newConsecutiveL2Blocks(4, 14, 2)
+	require.NoError(m.AddL2Block(withTxs[1]))
+	require.NoError(m.ensurePendingChannel(eth.BlockID{}))
+	require.Equal(uint(derive.BatchV1Type), m.pendingChannel.cfg.BatchType, "pending blocks are not deposit-only")
+
+	cfg.DepositOnlyChannelDuration = 0
+	m = NewChannelManager(log, metrics.NoopMetrics, cfg)
+	require.NoError(m.AddL2Block(blocks[1]))
+	require.NoError(m.ensurePendingChannel(eth.BlockID{}))
+	require.Equal(uint(derive.BatchV1Type), m.pendingChannel.cfg.BatchType, "deposit-only channels are not enabled")
+}
+
+// compressionMetrics records the compression of the closed channels.
+type compressionMetrics struct {
+	metrics.Metricer
+	closed []derive.CompressionAlgo
+}
+
+func (m *compressionMetrics) RecordChannelClosed(_ derive.ChannelID, _ int, _ int, _ int, _ int, compression derive.CompressionAlgo, _ error) {
+	m.closed = append(m.closed, compression)
+}
+
+// TestChannelManagerCompressionActivation checks that the channels are
+// compressed with zlib until the channel compression is activated at the L1
+// head.
+func TestChannelManagerCompressionActivation(t *testing.T) {
+	require := require.New(t)
+	log := testlog.Logger(t, log.LvlCrit)
+	activation := uint64(100)
+	cfg := ChannelConfig{
+		ChannelTimeout:   10,
+		MaxFrameSize:     1000,
+		TargetFrameSize:  1000,
+		TargetNumFrames:  1,
+		ApproxComprRatio: 1.0,
+		Compression:      derive.CompressionConfig{Algo: derive.Brotli, Level: 11},
+		RollupConfig:     &rollup.Config{ChannelCompressionTime: &activation},
+	}
+	require.NoError(cfg.Check())
+	metr := &compressionMetrics{Metricer: metrics.NoopMetrics}
+	m := NewChannelManager(log, metr, cfg)
+	closeChannel := func() {
+		m.pendingChannel.Close()
+		require.NoError(m.outputFrames())
+	}
+
+	require.NoError(m.AddL2Block(newMiniL2Block(0)))
+	m.RegisterL1Head(eth.L1BlockRef{Time: activation - 1})
+	require.NoError(m.ensurePendingChannel(eth.BlockID{}))
+	require.Equal(derive.CompressionConfig{}, m.pendingChannel.cfg.Compression, "channel compression is not activated yet")
+	closeChannel()
+
+	m.Clear()
+	require.NoError(m.AddL2Block(newMiniL2Block(0)))
+	m.RegisterL1Head(eth.L1BlockRef{Time: activation})
+	require.NoError(m.ensurePendingChannel(eth.BlockID{}))
+	require.Equal(cfg.Compression, m.pendingChannel.cfg.Compression)
+	closeChannel()
+	require.Equal([]derive.CompressionAlgo{derive.Zlib, derive.Brotli}, metr.closed, "the compression of each channel is reported")
+
+	cfg.RollupConfig = &rollup.Config{}
+	require.EqualError(cfg.Check(), "brotli compression is not scheduled by the rollup config")
+}
+
+// TestChannelManagerFlushSafeLag tests that the pending channel is closed and
+// its frames are returned once its oldest block lags too far behind the unsafe
+// head.
+func TestChannelManagerFlushSafeLag(t *testing.T) {
+	require := require.New(t)
+	log := testlog.Logger(t, log.LvlCrit)
+	m := NewChannelManager(log, metrics.NoopMetrics, ChannelConfig{
+		ChannelTimeout:   100,
+		MaxFrameSize:     120000,
+		TargetFrameSize:  100000,
+		TargetNumFrames:  1,
+		ApproxComprRatio: 1.0,
+		FlushSafeLag:     5,
+	})
+
+	a := newMiniL2Block(0)
+	require.NoError(m.AddL2Block(a))
+	m.RegisterUnsafeHead(5)
+	_, err := m.TxData(eth.BlockID{Number: 1})
+	require.ErrorIs(err, io.EOF, "channel must still be open")
+	require.False(m.pendingChannel.IsFull())
+
+	require.NoError(m.AddL2Block(newMiniL2BlockWithNumberParent(0, big.NewInt(1), a.Hash())))
+	m.RegisterUnsafeHead(6)
+	txdata, err := m.TxData(eth.BlockID{Number: 1})
+	require.NoError(err)
+	require.ErrorIs(m.pendingChannel.FullErr(), ErrSafeLagReached)
+	require.Len(m.pendingChannel.Blocks(), 2)
+	m.TxConfirmed(txdata.ID(), eth.BlockID{Number: 2})
+	require.Nil(m.pendingChannel, "channel must be fully submitted")
+
+	m.Clear()
+	require.Zero(m.unsafeHead)
+}
+
+// TestChannelManagerFlushSafeLagInFlight tests that the blocks of the channels
+// already submitted do not count towards the flush safe lag, so that the
+// channels are not closed after every block while the safe head lags behind
+// the submitted data at a constant lag.
+func TestChannelManagerFlushSafeLagInFlight(t *testing.T) {
+	require := require.New(t)
+	log := testlog.Logger(t, log.LvlCrit)
+	m := NewChannelManager(log, metrics.NoopMetrics, ChannelConfig{
+		ChannelTimeout:   100,
+		MaxFrameSize:     120000,
+		TargetFrameSize:  100000,
+		TargetNumFrames:  1,
+		ApproxComprRatio: 1.0,
+		FlushSafeLag:     5,
+	})
+
+	// an L2 block is produced per L1 block, and the safe head stays behind the unsafe head by more than the flush
+	// safe lag, as the submitted data is only derived later
+	var channels []int
+	parent := newMiniL2Block(0)
+	require.NoError(m.AddL2Block(parent))
+	for i := uint64(1); i <= 20; i++ {
+		block := newMiniL2BlockWithNumberParent(0, new(big.Int).SetUint64(i), parent.Hash())
+		require.NoError(m.AddL2Block(block))
+		parent = block
+		m.RegisterUnsafeHead(i)
+
+		txdata, err := m.TxData(eth.BlockID{Number: i})
+		if err == io.EOF {
+			continue
+		}
+		require.NoError(err)
+		channels = append(channels, len(m.pendingChannel.Blocks()))
+		m.TxConfirmed(txdata.ID(), eth.BlockID{Number: i + 1})
+	}
+	require.Equal([]int{7, 7, 7}, channels, "channels must be closed once their oldest block lags by more than the flush safe lag")
+}
+
 // TestChannelManagerTxConfirmed checks the [ChannelManager.TxConfirmed] function.
 func TestChannelManagerTxConfirmed(t *testing.T) {
 	// Create a channel manager
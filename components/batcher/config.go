@@ -2,22 +2,26 @@ package batcher
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/urfave/cli"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/kroma-network/kroma/components/batcher/flags"
 	"github.com/kroma-network/kroma/components/batcher/metrics"
 	"github.com/kroma-network/kroma/components/batcher/rpc"
 	"github.com/kroma-network/kroma/components/node/rollup"
+	"github.com/kroma-network/kroma/components/node/rollup/derive"
 	"github.com/kroma-network/kroma/components/node/sources"
 	"github.com/kroma-network/kroma/utils"
 	klog "github.com/kroma-network/kroma/utils/service/log"
 	kmetrics "github.com/kroma-network/kroma/utils/service/metrics"
 	kpprof "github.com/kroma-network/kroma/utils/service/pprof"
+	ktracing "github.com/kroma-network/kroma/utils/service/tracing"
 	"github.com/kroma-network/kroma/utils/service/txmgr"
 )
 
@@ -29,9 +33,39 @@ type Config struct {
 	RollupClient *sources.RollupClient
 	TxManager    txmgr.TxManager
 
+	// TxApprovals parks the transactions of the TxManager requiring approval, optional (may be nil).
+	TxApprovals *txmgr.ApprovalQueue
+
+	// TxInFlight tracks the candidates being sent by the TxManager, optional (may be nil).
+	TxInFlight *txmgr.InFlightTxs
+
+	// DACosts reports the L1 data availability costs of the L2 blocks, optional (may be nil).
+	DACosts *DACostReport
+
+	// TracerProvider provides the tracer of the batched blocks. If nil, the blocks are not traced.
+	TracerProvider trace.TracerProvider
+
 	NetworkTimeout time.Duration
 	PollInterval   time.Duration
 
+	// MaxSafeLag is the maximum number of L2 blocks ahead of the safe head to batch.
+	// If 0, all unsafe blocks are batched.
+	MaxSafeLag uint64
+
+	// L1ReorgDepth is the number of L1 blocks after which an L1 block is assumed not to be reorged.
+	// If 0, frames are assumed to be final once the tx manager confirmed them.
+	L1ReorgDepth uint64
+
+	// ChannelJournal is the path of the file the closed pending channel is persisted to, to resume its submission
+	// after a restart. A channel still open is not persisted. If empty, the channel is not persisted.
+	ChannelJournal string
+
+	// VerifierClient is the rollup node of a trusted verifier acknowledging the submitted channels, optional (may be nil).
+	VerifierClient VerifierClient
+	// VerifierAckWindow is the number of L1 blocks the verifier may derive past the inclusion of a channel
+	// before the channel is considered unreadable.
+	VerifierAckWindow uint64
+
 	// Rollup config is queried at startup
 	Rollup *rollup.Config
 
@@ -92,11 +126,67 @@ type CLIConfig struct {
 	// compression algorithm.
 	ApproxComprRatio float64
 
+	// CompressionAlgo is the algorithm the channels are compressed with, and
+	// CompressionLevel its level. If 0, the default level of the algorithm is used.
+	CompressionAlgo  string
+	CompressionLevel int
+
+	// BatchType is the type of the batches: 0 for a batch per block, 1 for a
+	// span batch of all the blocks of a channel, once span batches are activated.
+	BatchType uint
+
+	// DeferralWindows are daily UTC time windows (HH:MM-HH:MM) during which the
+	// MaxChannelDuration is not enforced, deferring non-urgent channels.
+	DeferralWindows []string
+	// DepositOnlyChannelDuration is the maximum duration (in #L1-blocks) to
+	// keep a channel open while it only contains deposit-only blocks, e.g.
+	// during a sequencer downtime. Such channels are encoded as span batches
+	// once they are activated.
+	//
+	// If 0, the MaxChannelDuration also applies to deposit-only channels.
+	DepositOnlyChannelDuration uint64
+
+	// MaxSafeLag is the maximum number of L2 blocks ahead of the safe head to batch,
+	// to avoid posting data of blocks that may still be reorged locally.
+	// If 0, all unsafe blocks are batched.
+	MaxSafeLag uint64
+
+	// FlushSafeLag is the maximum number of L2 blocks the oldest block of the pending channel may lag
+	// the unsafe head before the channel is closed and submitted, regardless of its duration and size.
+	// If 0, the channels are not closed by the safe head lag.
+	FlushSafeLag uint64
+
+	// L1ReorgDepth is the number of L1 blocks after which an L1 block is assumed not to be reorged,
+	// for L1s with weaker finality. Submitted frames are re-anchored if they are reorged out before.
+	// If 0, frames are assumed to be final once the tx manager confirmed them.
+	L1ReorgDepth uint64
+
+	// ChannelJournal is the path of a file the closed pending channel and the submission status of its frames
+	// are persisted to, so that a restarted batcher resumes submitting its frames. If empty, it is not persisted.
+	ChannelJournal string
+
+	// VerifierRollupRpc is the HTTP provider URL for the rollup node of a trusted verifier. If set, a channel
+	// is only delivered once the safe head of the verifier includes its blocks.
+	VerifierRollupRpc string
+
+	// VerifierAckWindow is the number of L1 blocks the verifier may derive past the inclusion of the last frame
+	// of a channel without including its blocks, before batching is re-anchored at the safe head.
+	VerifierAckWindow uint64
+
+	// DACostsRetained is the number of L2 blocks whose L1 data availability costs are kept in memory,
+	// to serve them over RPC. If 0, and no DACostsJournal is set, the costs are not reported.
+	DACostsRetained int
+
+	// DACostsJournal is the path of a file the L1 data availability costs of the L2 blocks are appended to,
+	// as JSON lines. If empty, the costs are not written to a file.
+	DACostsJournal string
+
 	TxMgrConfig   txmgr.CLIConfig
 	RPCConfig     rpc.CLIConfig
 	LogConfig     klog.CLIConfig
 	MetricsConfig kmetrics.CLIConfig
 	PprofConfig   kpprof.CLIConfig
+	TracingConfig ktracing.CLIConfig
 }
 
 func (c CLIConfig) Check() error {
@@ -112,12 +202,37 @@ func (c CLIConfig) Check() error {
 	if err := c.PprofConfig.Check(); err != nil {
 		return err
 	}
+	if err := c.TracingConfig.Check(); err != nil {
+		return err
+	}
 	if err := c.TxMgrConfig.Check(); err != nil {
 		return err
 	}
+	if policy, _ := c.TxMgrConfig.ApprovalPolicy(); policy.Enabled() && !c.RPCConfig.EnableAdmin {
+		return errors.New("the approval of the transactions requires the admin API to be enabled")
+	}
+	if err := c.compressionConfig().Check(); err != nil {
+		return err
+	}
+	if _, err := ParseDeferralWindows(c.DeferralWindows); err != nil {
+		return err
+	}
+	if c.VerifierRollupRpc != "" && c.VerifierAckWindow == 0 {
+		return errors.New("verifier ack window must be set with a verifier rollup RPC")
+	}
+	if c.DACostsRetained < 0 {
+		return errors.New("DA costs retained must not be negative")
+	}
 	return nil
 }
 
+func (c CLIConfig) compressionConfig() derive.CompressionConfig {
+	return derive.CompressionConfig{
+		Algo:  derive.CompressionAlgo(c.CompressionAlgo),
+		Level: c.CompressionLevel,
+	}
+}
+
 // NewCLIConfig parses the CLIConfig from the provided flags or environment variables.
 func NewCLIConfig(ctx *cli.Context) CLIConfig {
 	return CLIConfig{
@@ -129,16 +244,30 @@ func NewCLIConfig(ctx *cli.Context) CLIConfig {
 		PollInterval:    ctx.GlobalDuration(flags.PollIntervalFlag.Name),
 
 		// Optional Flags
-		MaxChannelDuration: ctx.GlobalUint64(flags.MaxChannelDurationFlag.Name),
-		MaxL1TxSize:        ctx.GlobalUint64(flags.MaxL1TxSizeBytesFlag.Name),
-		TargetL1TxSize:     ctx.GlobalUint64(flags.TargetL1TxSizeBytesFlag.Name),
-		TargetNumFrames:    ctx.GlobalInt(flags.TargetNumFramesFlag.Name),
-		ApproxComprRatio:   ctx.GlobalFloat64(flags.ApproxComprRatioFlag.Name),
-		TxMgrConfig:        txmgr.ReadCLIConfig(ctx),
-		RPCConfig:          rpc.ReadCLIConfig(ctx),
-		LogConfig:          klog.ReadCLIConfig(ctx),
-		MetricsConfig:      kmetrics.ReadCLIConfig(ctx),
-		PprofConfig:        kpprof.ReadCLIConfig(ctx),
+		MaxChannelDuration:         ctx.GlobalUint64(flags.MaxChannelDurationFlag.Name),
+		MaxL1TxSize:                ctx.GlobalUint64(flags.MaxL1TxSizeBytesFlag.Name),
+		TargetL1TxSize:             ctx.GlobalUint64(flags.TargetL1TxSizeBytesFlag.Name),
+		TargetNumFrames:            ctx.GlobalInt(flags.TargetNumFramesFlag.Name),
+		ApproxComprRatio:           ctx.GlobalFloat64(flags.ApproxComprRatioFlag.Name),
+		CompressionAlgo:            ctx.GlobalString(flags.CompressionAlgoFlag.Name),
+		CompressionLevel:           ctx.GlobalInt(flags.CompressionLevelFlag.Name),
+		BatchType:                  ctx.GlobalUint(flags.BatchTypeFlag.Name),
+		DeferralWindows:            ctx.GlobalStringSlice(flags.DeferralWindowsFlag.Name),
+		DepositOnlyChannelDuration: ctx.GlobalUint64(flags.DepositOnlyChannelDurationFlag.Name),
+		MaxSafeLag:                 ctx.GlobalUint64(flags.MaxSafeLagFlag.Name),
+		FlushSafeLag:               ctx.GlobalUint64(flags.FlushSafeLagFlag.Name),
+		L1ReorgDepth:               ctx.GlobalUint64(flags.L1ReorgDepthFlag.Name),
+		ChannelJournal:             ctx.GlobalString(flags.ChannelJournalFlag.Name),
+		VerifierRollupRpc:          ctx.GlobalString(flags.VerifierRollupRpcFlag.Name),
+		VerifierAckWindow:          ctx.GlobalUint64(flags.VerifierAckWindowFlag.Name),
+		DACostsRetained:            ctx.GlobalInt(flags.DACostsRetainedFlag.Name),
+		DACostsJournal:             ctx.GlobalString(flags.DACostsJournalFlag.Name),
+		TxMgrConfig:                txmgr.ReadCLIConfig(ctx),
+		RPCConfig:                  rpc.ReadCLIConfig(ctx),
+		LogConfig:                  klog.ReadCLIConfig(ctx),
+		MetricsConfig:              kmetrics.ReadCLIConfig(ctx),
+		PprofConfig:                kpprof.ReadCLIConfig(ctx),
+		TracingConfig:              ktracing.ReadCLIConfig(ctx),
 	}
 }
 
@@ -167,30 +296,82 @@ func NewBatcherConfig(cfg CLIConfig, l log.Logger, m metrics.Metricer) (*Config,
 		return nil, fmt.Errorf("querying rollup config: %w", err)
 	}
 
+	var verifierClient VerifierClient
+	if cfg.VerifierRollupRpc != "" {
+		client, err := utils.DialRollupClientWithTimeout(ctx, cfg.VerifierRollupRpc)
+		if err != nil {
+			return nil, fmt.Errorf("dialing verifier rollup node: %w", err)
+		}
+		vcfg, err := client.RollupConfig(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("querying verifier rollup config: %w", err)
+		}
+		if err := checkSameRollupConfig(rcfg, vcfg); err != nil {
+			return nil, err
+		}
+		verifierClient = &rollupVerifierClient{RollupClient: client}
+	}
+
 	txManager, err := txmgr.NewSimpleTxManager("batcher", l, m, cfg.TxMgrConfig)
 	if err != nil {
 		return nil, err
 	}
 
+	deferralWindows, err := ParseDeferralWindows(cfg.DeferralWindows)
+	if err != nil {
+		return nil, err
+	}
+
+	var daCosts *DACostReport
+	if cfg.DACostsRetained > 0 || cfg.DACostsJournal != "" {
+		daCosts, err = NewDACostReport(cfg.DACostsRetained, cfg.DACostsJournal)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return &Config{
-		log:            l,
-		metr:           m,
-		L1Client:       l1Client,
-		L2Client:       l2Client,
-		RollupClient:   rollupClient,
-		PollInterval:   cfg.PollInterval,
-		NetworkTimeout: cfg.TxMgrConfig.NetworkTimeout,
-		TxManager:      txManager,
-		Rollup:         rcfg,
+		log:               l,
+		metr:              m,
+		L1Client:          l1Client,
+		L2Client:          l2Client,
+		RollupClient:      rollupClient,
+		PollInterval:      cfg.PollInterval,
+		NetworkTimeout:    cfg.TxMgrConfig.NetworkTimeout,
+		MaxSafeLag:        cfg.MaxSafeLag,
+		L1ReorgDepth:      cfg.L1ReorgDepth,
+		ChannelJournal:    cfg.ChannelJournal,
+		VerifierClient:    verifierClient,
+		VerifierAckWindow: cfg.VerifierAckWindow,
+		TxManager:         txManager,
+		TxApprovals:       txManager.Approvals,
+		TxInFlight:        txManager.InFlight,
+		DACosts:           daCosts,
+		Rollup:            rcfg,
 		Channel: ChannelConfig{
-			ProposerWindowSize: rcfg.ProposerWindowSize,
-			ChannelTimeout:     rcfg.ChannelTimeout,
-			MaxChannelDuration: cfg.MaxChannelDuration,
-			SubSafetyMargin:    cfg.SubSafetyMargin,
-			MaxFrameSize:       cfg.MaxL1TxSize - 1,    // subtract 1 byte for version
-			TargetFrameSize:    cfg.TargetL1TxSize - 1, // subtract 1 byte for version
-			TargetNumFrames:    cfg.TargetNumFrames,
-			ApproxComprRatio:   cfg.ApproxComprRatio,
+			ProposerWindowSize:         rcfg.ProposerWindowSize,
+			ChannelTimeout:             rcfg.ChannelTimeout,
+			MaxChannelDuration:         cfg.MaxChannelDuration,
+			SubSafetyMargin:            cfg.SubSafetyMargin,
+			MaxFrameSize:               cfg.MaxL1TxSize - 1,    // subtract 1 byte for version
+			TargetFrameSize:            cfg.TargetL1TxSize - 1, // subtract 1 byte for version
+			TargetNumFrames:            cfg.TargetNumFrames,
+			ApproxComprRatio:           cfg.ApproxComprRatio,
+			Compression:                cfg.compressionConfig(),
+			DeferralWindows:            deferralWindows,
+			DepositOnlyChannelDuration: cfg.DepositOnlyChannelDuration,
+			FlushSafeLag:               cfg.FlushSafeLag,
+			BatchType:                  cfg.BatchType,
+			RollupConfig:               rcfg,
 		},
 	}, nil
 }
+
+// checkSameRollupConfig checks that the verifier runs the rollup config of the rollup node of the batcher,
+// so that it derives the same chain from the submitted channels.
+func checkSameRollupConfig(cfg *rollup.Config, verifierCfg *rollup.Config) error {
+	if err := cfg.CheckSameConsensus(verifierCfg); err != nil {
+		return fmt.Errorf("verifier rollup config does not match: %w", err)
+	}
+	return nil
+}
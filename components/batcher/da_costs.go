@@ -0,0 +1,329 @@
+package batcher
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/kroma-network/kroma/components/node/rollup/derive"
+)
+
+// BlockDACost is the L1 data availability cost attributed to an L2 block. The cost of a channel is split over its
+// blocks in proportion to the size of their batches, since the compression of the channel does not allow to tell the
+// compressed bytes of a block apart. The cost of the channels of the block that timed out is added to the block, as
+// it was spent to make the block available, too.
+type BlockDACost struct {
+	Number uint64      `json:"number"`
+	Hash   common.Hash `json:"hash"`
+	// ChannelID is the channel the block was fully submitted in.
+	ChannelID string `json:"channelId"`
+	// InputBytes is the size of the batch of the block in the channel, before compression.
+	InputBytes hexutil.Uint64 `json:"inputBytes"`
+	// DataBytes is the share of the block of the calldata submitted to L1.
+	DataBytes hexutil.Uint64 `json:"dataBytes"`
+	// L1GasUsed and L1Fee are the shares of the block of the gas used and the fees paid by the batcher transactions.
+	L1GasUsed hexutil.Uint64 `json:"l1GasUsed"`
+	L1Fee     *hexutil.Big   `json:"l1Fee"`
+	// L1Txs are the batcher transactions that carried the channels of the block.
+	L1Txs []common.Hash `json:"l1Txs"`
+}
+
+// DACostReport keeps the L1 data availability costs of the latest L2 blocks to serve them over RPC, and appends all
+// of them to a journal file of JSON lines if configured, to reconcile the fees of the L2 blocks against their L1 costs.
+// A nil *DACostReport does not keep the costs. It is safe for concurrent access.
+type DACostReport struct {
+	retain int
+
+	mu      sync.Mutex
+	costs   []BlockDACost
+	journal *os.File
+}
+
+// NewDACostReport creates a report that keeps the costs of the latest retain L2 blocks, and appends the costs to the
+// journal file if the path is not empty.
+func NewDACostReport(retain int, journalPath string) (*DACostReport, error) {
+	r := &DACostReport{retain: retain}
+	if journalPath != "" {
+		f, err := os.OpenFile(journalPath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open DA cost journal: %w", err)
+		}
+		r.journal = f
+	}
+	return r, nil
+}
+
+func (r *DACostReport) add(costs []BlockDACost) error {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.retain > 0 {
+		r.costs = append(r.costs, costs...)
+		if len(r.costs) > r.retain {
+			r.costs = append(r.costs[:0], r.costs[len(r.costs)-r.retain:]...)
+		}
+	}
+	if r.journal == nil {
+		return nil
+	}
+	var lines []byte
+	for _, cost := range costs {
+		line, err := json.Marshal(cost)
+		if err != nil {
+			return err
+		}
+		lines = append(append(lines, line...), '\n')
+	}
+	if _, err := r.journal.Write(lines); err != nil {
+		return fmt.Errorf("failed to write DA cost journal: %w", err)
+	}
+	return nil
+}
+
+// Range returns the kept costs of the L2 blocks numbered from to to, inclusive, ordered by block number. A block may
+// be listed more than once if it was submitted again after an L2 reorg or a restart of the batcher.
+func (r *DACostReport) Range(from, to uint64) []BlockDACost {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var costs []BlockDACost
+	for _, cost := range r.costs {
+		if cost.Number >= from && cost.Number <= to {
+			costs = append(costs, cost)
+		}
+	}
+	sort.SliceStable(costs, func(i, j int) bool { return costs[i].Number < costs[j].Number })
+	return costs
+}
+
+// Close closes the journal file.
+func (r *DACostReport) Close() error {
+	if r == nil || r.journal == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.journal.Close()
+}
+
+// DACostAPI is the RPC API serving the L1 data availability costs of the L2 blocks.
+type DACostAPI struct {
+	report *DACostReport
+}
+
+func NewDACostAPI(report *DACostReport) *DACostAPI {
+	return &DACostAPI{report: report}
+}
+
+// BlockCosts returns the costs of the L2 blocks numbered from to to, inclusive.
+func (a *DACostAPI) BlockCosts(_ context.Context, from, to hexutil.Uint64) ([]BlockDACost, error) {
+	if to < from {
+		return nil, errors.New("invalid block range")
+	}
+	costs := a.report.Range(uint64(from), uint64(to))
+	if costs == nil {
+		costs = []BlockDACost{}
+	}
+	return costs, nil
+}
+
+// DACostAPIs returns the RPC APIs serving batcher_blockCosts, none if the report is nil.
+func DACostAPIs(report *DACostReport) []rpc.API {
+	if report == nil {
+		return nil
+	}
+	return []rpc.API{{
+		Namespace: "batcher",
+		Service:   NewDACostAPI(report),
+	}}
+}
+
+// daCost is an L1 data availability cost, of a channel or of a share of it.
+type daCost struct {
+	dataBytes uint64
+	gasUsed   uint64
+	fee       *big.Int
+	txs       []common.Hash
+}
+
+func newDACost() daCost {
+	return daCost{fee: new(big.Int)}
+}
+
+func (c *daCost) add(o daCost) {
+	c.dataBytes += o.dataBytes
+	c.gasUsed += o.gasUsed
+	c.fee.Add(c.fee, o.fee)
+	c.txs = append(c.txs, o.txs...)
+}
+
+type daCostBlock struct {
+	number     uint64
+	hash       common.Hash
+	inputBytes uint64
+}
+
+// daCostTracker follows the blocks and the frames of the pending channel through the channel manager, to attribute
+// the L1 cost of the channel to its blocks once it is fully submitted.
+type daCostTracker struct {
+	log    log.Logger
+	report *DACostReport
+
+	channelID derive.ChannelID
+	blocks    []daCostBlock
+	frames    map[txID]int
+	channel   daCost
+
+	// carried are the costs of the timed out channels of the blocks not fully submitted yet, by block hash.
+	carried map[common.Hash]daCost
+}
+
+func newDACostTracker(log log.Logger, report *DACostReport) *daCostTracker {
+	return &daCostTracker{
+		log:     log,
+		report:  report,
+		frames:  make(map[txID]int),
+		channel: newDACost(),
+		carried: make(map[common.Hash]daCost),
+	}
+}
+
+func (t *daCostTracker) channelOpened(id derive.ChannelID) {
+	t.channelID = id
+}
+
+func (t *daCostTracker) blockAdded(block *types.Block, inputBytes int) {
+	t.blocks = append(t.blocks, daCostBlock{number: block.NumberU64(), hash: block.Hash(), inputBytes: uint64(inputBytes)})
+}
+
+// frameSubmitted records the size of the calldata of the transaction carrying the frame.
+func (t *daCostTracker) frameSubmitted(id txID, size int) {
+	t.frames[id] = size
+}
+
+// frameConfirmed adds the cost of the transaction carrying the frame to the pending channel.
+func (t *daCostTracker) frameConfirmed(id txID, receipt *types.Receipt) {
+	size, ok := t.frames[id]
+	if !ok {
+		return
+	}
+	delete(t.frames, id)
+	fee := new(big.Int)
+	if receipt.EffectiveGasPrice != nil {
+		fee.Mul(new(big.Int).SetUint64(receipt.GasUsed), receipt.EffectiveGasPrice)
+	}
+	t.channel.add(daCost{dataBytes: uint64(size), gasUsed: receipt.GasUsed, fee: fee, txs: []common.Hash{receipt.TxHash}})
+}
+
+func (t *daCostTracker) frameFailed(id txID) {
+	delete(t.frames, id)
+}
+
+// channelEnded attributes the cost of the pending channel to its blocks. The cost is reported if the channel was fully
+// submitted, and carried over to the blocks if it timed out, since they are added to the next channel. The cost of a
+// channel cleared otherwise cannot be attributed, as its blocks are loaded again from the safe head.
+func (t *daCostTracker) channelEnded(reason error) {
+	defer t.reset()
+	if len(t.blocks) == 0 {
+		return
+	}
+	shares := t.shares()
+	switch {
+	case reason == nil:
+		costs := make([]BlockDACost, 0, len(t.blocks))
+		for i, block := range t.blocks {
+			cost := newDACost()
+			if carried, ok := t.carried[block.hash]; ok {
+				cost.add(carried)
+				delete(t.carried, block.hash)
+			}
+			cost.add(shares[i])
+			costs = append(costs, BlockDACost{
+				Number:     block.number,
+				Hash:       block.hash,
+				ChannelID:  t.channelID.String(),
+				InputBytes: hexutil.Uint64(block.inputBytes),
+				DataBytes:  hexutil.Uint64(cost.dataBytes),
+				L1GasUsed:  hexutil.Uint64(cost.gasUsed),
+				L1Fee:      (*hexutil.Big)(cost.fee),
+				L1Txs:      cost.txs,
+			})
+		}
+		if err := t.report.add(costs); err != nil {
+			t.log.Error("failed to report DA costs", "channel", t.channelID, "err", err)
+		}
+	case errors.Is(reason, errChannelTimedOut):
+		for i, block := range t.blocks {
+			carried, ok := t.carried[block.hash]
+			if !ok {
+				carried = newDACost()
+			}
+			carried.add(shares[i])
+			t.carried[block.hash] = carried
+		}
+	case t.channel.gasUsed > 0:
+		t.log.Warn("DA cost of cleared channel not attributed", "channel", t.channelID, "reason", reason,
+			"l1_fee", t.channel.fee, "data_bytes", t.channel.dataBytes)
+	}
+}
+
+// shares splits the cost of the pending channel over its blocks in proportion to their input bytes. The remainders
+// go to the last block, so that the shares add up to the cost of the channel.
+func (t *daCostTracker) shares() []daCost {
+	var total uint64
+	for _, block := range t.blocks {
+		total += block.inputBytes
+	}
+	shares := make([]daCost, len(t.blocks))
+	left := daCost{dataBytes: t.channel.dataBytes, gasUsed: t.channel.gasUsed, fee: new(big.Int).Set(t.channel.fee)}
+	for i, block := range t.blocks {
+		if i == len(t.blocks)-1 {
+			shares[i] = left
+		} else {
+			weight, whole := block.inputBytes, total
+			if total == 0 {
+				weight, whole = 1, uint64(len(t.blocks))
+			}
+			fee := new(big.Int).Mul(t.channel.fee, new(big.Int).SetUint64(weight))
+			shares[i] = daCost{
+				dataBytes: t.channel.dataBytes * weight / whole,
+				gasUsed:   t.channel.gasUsed * weight / whole,
+				fee:       fee.Div(fee, new(big.Int).SetUint64(whole)),
+			}
+			left.dataBytes -= shares[i].dataBytes
+			left.gasUsed -= shares[i].gasUsed
+			left.fee.Sub(left.fee, shares[i].fee)
+		}
+		shares[i].txs = t.channel.txs
+	}
+	return shares
+}
+
+func (t *daCostTracker) reset() {
+	t.channelID = derive.ChannelID{}
+	t.blocks = nil
+	t.frames = make(map[txID]int)
+	t.channel = newDACost()
+}
+
+// clear forgets the carried costs, after the state of the batcher was cleared.
+func (t *daCostTracker) clear() {
+	t.carried = make(map[common.Hash]daCost)
+}
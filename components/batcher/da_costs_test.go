@@ -0,0 +1,148 @@
+package batcher
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kroma-network/kroma/components/batcher/metrics"
+	"github.com/kroma-network/kroma/components/node/eth"
+	"github.com/kroma-network/kroma/components/node/testlog"
+)
+
+func newDACostChannelManager(t *testing.T, report *DACostReport) *channelManager {
+	l := testlog.Logger(t, log.LvlCrit)
+	m := NewChannelManager(l, metrics.NoopMetrics, ChannelConfig{
+		TargetNumFrames:  100,
+		TargetFrameSize:  1000,
+		MaxFrameSize:     1000,
+		ApproxComprRatio: 1.0,
+		ChannelTimeout:   1000,
+	})
+	m.costs = newDACostTracker(l, report)
+	return m
+}
+
+// submitChannel submits the pending blocks in a single channel, confirming its frames in the given L1 blocks.
+// The blocks must fill the first frame, so that the channel is not cleared when it is closed.
+func submitChannel(t *testing.T, m *channelManager, l1Blocks func(i int) uint64) (frames int, dataBytes uint64) {
+	m.closed = false
+	for ; ; frames++ {
+		if frames == 1 && m.pendingChannel != nil && !m.pendingChannel.IsFull() {
+			require.NoError(t, m.Close())
+		}
+		txdata, err := m.TxData(eth.BlockID{})
+		if err == io.EOF {
+			return frames, dataBytes
+		}
+		require.NoError(t, err)
+		dataBytes += uint64(len(txdata.Bytes()))
+		receipt := &types.Receipt{
+			TxHash:            common.Hash{byte(frames)},
+			BlockNumber:       new(big.Int).SetUint64(l1Blocks(frames)),
+			GasUsed:           50_000,
+			EffectiveGasPrice: big.NewInt(7),
+		}
+		m.costs.frameConfirmed(txdata.ID(), receipt)
+		m.TxConfirmed(txdata.ID(), eth.BlockID{Number: l1Blocks(frames)})
+	}
+}
+
+func TestDACostAttribution(t *testing.T) {
+	require := require.New(t)
+	report, err := NewDACostReport(10, "")
+	require.NoError(err)
+	m := newDACostChannelManager(t, report)
+
+	a := newMiniL2Block(10)
+	b := newMiniL2BlockWithNumberParent(50_000, big.NewInt(1), a.Hash())
+	require.NoError(m.AddL2Block(a))
+	require.NoError(m.AddL2Block(b))
+	frames, dataBytes := submitChannel(t, m, func(int) uint64 { return 1 })
+
+	costs := report.Range(0, 1)
+	require.Len(costs, 2)
+	require.Equal(a.Hash(), costs[0].Hash)
+	require.Equal(b.Hash(), costs[1].Hash)
+	require.Greater(costs[1].InputBytes, costs[0].InputBytes, "the larger block gets the larger share")
+	require.Greater(costs[1].L1Fee.ToInt().Cmp(costs[0].L1Fee.ToInt()), 0)
+
+	// the shares add up to the cost of the channel
+	require.Equal(dataBytes, uint64(costs[0].DataBytes+costs[1].DataBytes))
+	require.Equal(uint64(frames*50_000), uint64(costs[0].L1GasUsed+costs[1].L1GasUsed))
+	fee := new(big.Int).Add(costs[0].L1Fee.ToInt(), costs[1].L1Fee.ToInt())
+	require.Equal(big.NewInt(int64(frames*50_000*7)), fee)
+	require.Len(costs[0].L1Txs, frames)
+
+	api := NewDACostAPI(report)
+	costs, err = api.BlockCosts(context.Background(), 1, 5)
+	require.NoError(err)
+	require.Len(costs, 1)
+	require.Equal(b.Hash(), costs[0].Hash)
+	_, err = api.BlockCosts(context.Background(), 2, 1)
+	require.Error(err)
+}
+
+func TestDACostTimedOutChannel(t *testing.T) {
+	require := require.New(t)
+	report, err := NewDACostReport(10, filepath.Join(t.TempDir(), "costs.jsonl"))
+	require.NoError(err)
+	m := newDACostChannelManager(t, report)
+	m.cfg.ChannelTimeout = 10
+
+	block := newMiniL2Block(50_000)
+	require.NoError(m.AddL2Block(block))
+	// the first two frames span the channel timeout, the block is submitted again in a new channel
+	frames, _ := submitChannel(t, m, func(i int) uint64 {
+		if i == 0 {
+			return 0
+		}
+		return 10
+	})
+	single := newDACostChannelManager(t, nil)
+	require.NoError(single.AddL2Block(block))
+	channelFrames, _ := submitChannel(t, single, func(int) uint64 { return 0 })
+	require.Equal(channelFrames+2, frames)
+
+	// the cost of the block includes the timed out channel
+	costs := report.Range(0, 0)
+	require.Len(costs, 1)
+	require.Equal(uint64(frames*50_000), uint64(costs[0].L1GasUsed))
+	require.Len(costs[0].L1Txs, frames)
+	require.Empty(m.costs.carried)
+
+	// the costs are appended to the journal
+	require.NoError(report.Close())
+	f, err := os.Open(report.journal.Name())
+	require.NoError(err)
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	require.True(scanner.Scan())
+	var journaled BlockDACost
+	require.NoError(json.Unmarshal(scanner.Bytes(), &journaled))
+	require.Equal(costs[0], journaled)
+	require.False(scanner.Scan())
+}
+
+func TestDACostReportRetain(t *testing.T) {
+	report, err := NewDACostReport(2, "")
+	require.NoError(t, err)
+	require.NoError(t, report.add([]BlockDACost{{Number: 1}, {Number: 2}, {Number: 3}}))
+	costs := report.Range(0, 10)
+	require.Len(t, costs, 2)
+	require.Equal(t, uint64(2), costs[0].Number)
+
+	var none *DACostReport
+	require.NoError(t, none.add([]BlockDACost{{Number: 1}}))
+	require.Nil(t, none.Range(0, 10))
+}
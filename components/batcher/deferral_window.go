@@ -0,0 +1,86 @@
+package batcher
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DeferralWindow is a daily recurring UTC time window, in which the batcher defers
+// the submission of channels that are not urgent. Start and End are offsets from
+// midnight. If End is before Start, the window wraps around midnight.
+type DeferralWindow struct {
+	Start time.Duration
+	End   time.Duration
+}
+
+// ParseDeferralWindow parses a window in the format of HH:MM-HH:MM.
+func ParseDeferralWindow(s string) (DeferralWindow, error) {
+	start, end, ok := strings.Cut(strings.TrimSpace(s), "-")
+	if !ok {
+		return DeferralWindow{}, fmt.Errorf("invalid deferral window %q: expected HH:MM-HH:MM", s)
+	}
+
+	startOffset, err := parseTimeOfDay(start)
+	if err != nil {
+		return DeferralWindow{}, fmt.Errorf("invalid deferral window %q: %w", s, err)
+	}
+	endOffset, err := parseTimeOfDay(end)
+	if err != nil {
+		return DeferralWindow{}, fmt.Errorf("invalid deferral window %q: %w", s, err)
+	}
+	if startOffset == endOffset {
+		return DeferralWindow{}, fmt.Errorf("invalid deferral window %q: start and end must differ", s)
+	}
+
+	return DeferralWindow{Start: startOffset, End: endOffset}, nil
+}
+
+func parseTimeOfDay(s string) (time.Duration, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(s))
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// Contains returns whether the given time is within the window.
+func (w DeferralWindow) Contains(t time.Time) bool {
+	t = t.UTC()
+	offset := t.Sub(time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC))
+	if w.Start < w.End {
+		return offset >= w.Start && offset < w.End
+	}
+	return offset >= w.Start || offset < w.End
+}
+
+func (w DeferralWindow) String() string {
+	return fmt.Sprintf("%02d:%02d-%02d:%02d",
+		int(w.Start.Hours()), int(w.Start.Minutes())%60, int(w.End.Hours()), int(w.End.Minutes())%60)
+}
+
+// DeferralWindows is a set of deferral windows.
+type DeferralWindows []DeferralWindow
+
+// ParseDeferralWindows parses every given window in the format of HH:MM-HH:MM.
+func ParseDeferralWindows(windows []string) (DeferralWindows, error) {
+	res := make(DeferralWindows, 0, len(windows))
+	for _, s := range windows {
+		w, err := ParseDeferralWindow(s)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, w)
+	}
+	return res, nil
+}
+
+// Contains returns whether the given time is within any of the windows.
+func (ws DeferralWindows) Contains(t time.Time) bool {
+	for _, w := range ws {
+		if w.Contains(t) {
+			return true
+		}
+	}
+	return false
+}
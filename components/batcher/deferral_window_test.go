@@ -0,0 +1,43 @@
+package batcher
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDeferralWindow(t *testing.T) {
+	w, err := ParseDeferralWindow("22:30-06:00")
+	require.NoError(t, err)
+	require.Equal(t, 22*time.Hour+30*time.Minute, w.Start)
+	require.Equal(t, 6*time.Hour, w.End)
+	require.Equal(t, "22:30-06:00", w.String())
+
+	for _, s := range []string{"", "22:30", "25:00-06:00", "06:00-06:00"} {
+		_, err := ParseDeferralWindow(s)
+		require.Error(t, err, s)
+	}
+}
+
+func TestDeferralWindowContains(t *testing.T) {
+	at := func(hour, min int) time.Time {
+		return time.Date(2023, 5, 1, hour, min, 0, 0, time.UTC)
+	}
+
+	w := DeferralWindow{Start: 1 * time.Hour, End: 5 * time.Hour}
+	require.False(t, w.Contains(at(0, 59)))
+	require.True(t, w.Contains(at(1, 0)))
+	require.True(t, w.Contains(at(4, 59)))
+	require.False(t, w.Contains(at(5, 0)))
+
+	// The window wraps around midnight
+	w = DeferralWindow{Start: 22 * time.Hour, End: 2 * time.Hour}
+	require.True(t, w.Contains(at(23, 0)))
+	require.True(t, w.Contains(at(1, 0)))
+	require.False(t, w.Contains(at(12, 0)))
+
+	ws := DeferralWindows{{Start: 1 * time.Hour, End: 2 * time.Hour}, {Start: 3 * time.Hour, End: 4 * time.Hour}}
+	require.True(t, ws.Contains(at(3, 30)))
+	require.False(t, ws.Contains(at(2, 30)))
+}
@@ -9,6 +9,7 @@ import (
 	kmetrics "github.com/kroma-network/kroma/utils/service/metrics"
 	kpprof "github.com/kroma-network/kroma/utils/service/pprof"
 	krpc "github.com/kroma-network/kroma/utils/service/rpc"
+	ktracing "github.com/kroma-network/kroma/utils/service/tracing"
 	"github.com/kroma-network/kroma/utils/service/txmgr"
 )
 
@@ -83,6 +84,98 @@ var (
 		Value:  1.0,
 		EnvVar: kservice.PrefixEnvVar(envVarPrefix, "APPROX_COMPR_RATIO"),
 	}
+	CompressionAlgoFlag = cli.StringFlag{
+		Name: "compression-algo",
+		Usage: "The algorithm the channels are compressed with: zlib, brotli or zstd. " +
+			"Channels are compressed with zlib until the channel compression time of the rollup config is reached",
+		Value:  "zlib",
+		EnvVar: kservice.PrefixEnvVar(envVarPrefix, "COMPRESSION_ALGO"),
+	}
+	CompressionLevelFlag = cli.IntFlag{
+		Name: "compression-level",
+		Usage: "The compression level of the algorithm: 1-9 for zlib, 0-11 for brotli and 1-22 for zstd. " +
+			"0 for the default level of the algorithm: 9 for zlib, 10 for brotli and 19 for zstd.",
+		Value:  0,
+		EnvVar: kservice.PrefixEnvVar(envVarPrefix, "COMPRESSION_LEVEL"),
+	}
+	BatchTypeFlag = cli.UintFlag{
+		Name: "batch-type",
+		Usage: "The type of the batches: 0 for a batch per block, 1 for a span batch of all the blocks of a channel. " +
+			"Channels starting before the span batches are activated by the rollup config get a batch per block.",
+		Value:  0,
+		EnvVar: kservice.PrefixEnvVar(envVarPrefix, "BATCH_TYPE"),
+	}
+	DeferralWindowsFlag = cli.StringSliceFlag{
+		Name: "deferral-windows",
+		Usage: "Daily UTC time windows (HH:MM-HH:MM) during which the max channel duration " +
+			"is not enforced, deferring non-urgent channels to cheaper L1 periods",
+		EnvVar: kservice.PrefixEnvVar(envVarPrefix, "DEFERRAL_WINDOWS"),
+	}
+	DepositOnlyChannelDurationFlag = cli.Uint64Flag{
+		Name: "deposit-only-channel-duration",
+		Usage: "The maximum duration of L1-blocks to keep a channel open while it only contains deposit-only " +
+			"blocks, e.g. during a sequencer downtime. Such channels are encoded as span batches once they " +
+			"are activated. Must not be less than the max channel duration. " +
+			"0 to apply the max channel duration.",
+		Value:  0,
+		EnvVar: kservice.PrefixEnvVar(envVarPrefix, "DEPOSIT_ONLY_CHANNEL_DURATION"),
+	}
+	MaxSafeLagFlag = cli.Uint64Flag{
+		Name: "max-safe-lag",
+		Usage: "Maximum number of L2 blocks ahead of the safe head to batch, to avoid posting data " +
+			"of blocks that may still be reorged locally. Disabled if 0.",
+		Value:  0,
+		EnvVar: kservice.PrefixEnvVar(envVarPrefix, "MAX_SAFE_LAG"),
+	}
+	FlushSafeLagFlag = cli.Uint64Flag{
+		Name: "flush-safe-lag",
+		Usage: "Maximum number of L2 blocks the oldest block of the pending channel may lag the unsafe head " +
+			"before the channel is closed and submitted, so that data lands on L1 within bounded time at low L2 throughput. " +
+			"Not deferred by the deferral windows. Disabled if 0.",
+		Value:  0,
+		EnvVar: kservice.PrefixEnvVar(envVarPrefix, "FLUSH_SAFE_LAG"),
+	}
+	L1ReorgDepthFlag = cli.Uint64Flag{
+		Name: "l1-reorg-depth",
+		Usage: "Number of L1 blocks after which an L1 block is assumed not to be reorged. Submitted frames are " +
+			"tracked until their inclusion blocks are this deep, and batching is re-anchored at the safe head " +
+			"if they are reorged out. Disabled if 0.",
+		Value:  0,
+		EnvVar: kservice.PrefixEnvVar(envVarPrefix, "L1_REORG_DEPTH"),
+	}
+	ChannelJournalFlag = cli.StringFlag{
+		Name: "channel-journal",
+		Usage: "Path of a file the closed pending channel and the submission status of its frames are persisted to, " +
+			"so that a restarted batcher resumes submitting the frames instead of batching the blocks again. " +
+			"A channel still open is not persisted: its blocks are batched again after a restart",
+		EnvVar: kservice.PrefixEnvVar(envVarPrefix, "CHANNEL_JOURNAL"),
+	}
+	VerifierRollupRpcFlag = cli.StringFlag{
+		Name: "verifier-rollup-rpc",
+		Usage: "HTTP provider URL for the rollup node of a trusted verifier. If set, a submitted channel is only " +
+			"delivered once the safe head of the verifier includes its blocks, and batching is re-anchored at the " +
+			"safe head if it does not within the verifier ack window.",
+		EnvVar: kservice.PrefixEnvVar(envVarPrefix, "VERIFIER_ROLLUP_RPC"),
+	}
+	VerifierAckWindowFlag = cli.Uint64Flag{
+		Name: "verifier-ack-window",
+		Usage: "Number of L1 blocks the verifier may derive past the inclusion of the last frame of a channel " +
+			"without including its blocks, before the channel is considered unreadable.",
+		Value:  10,
+		EnvVar: kservice.PrefixEnvVar(envVarPrefix, "VERIFIER_ACK_WINDOW"),
+	}
+	DACostsRetainedFlag = cli.IntFlag{
+		Name: "da-costs.retained",
+		Usage: "Number of L2 blocks whose L1 data availability costs are kept in memory, " +
+			"served by the batcher_blockCosts RPC. Disabled if 0.",
+		Value:  10000,
+		EnvVar: kservice.PrefixEnvVar(envVarPrefix, "DA_COSTS_RETAINED"),
+	}
+	DACostsJournalFlag = cli.StringFlag{
+		Name:   "da-costs.journal",
+		Usage:  "Path of a file the L1 data availability costs of the L2 blocks are appended to, as JSON lines",
+		EnvVar: kservice.PrefixEnvVar(envVarPrefix, "DA_COSTS_JOURNAL"),
+	}
 )
 
 var requiredFlags = []cli.Flag{
@@ -99,6 +192,19 @@ var optionalFlags = []cli.Flag{
 	TargetL1TxSizeBytesFlag,
 	TargetNumFramesFlag,
 	ApproxComprRatioFlag,
+	CompressionAlgoFlag,
+	CompressionLevelFlag,
+	BatchTypeFlag,
+	DeferralWindowsFlag,
+	DepositOnlyChannelDurationFlag,
+	MaxSafeLagFlag,
+	FlushSafeLagFlag,
+	L1ReorgDepthFlag,
+	ChannelJournalFlag,
+	VerifierRollupRpcFlag,
+	VerifierAckWindowFlag,
+	DACostsRetainedFlag,
+	DACostsJournalFlag,
 }
 
 func init() {
@@ -107,6 +213,7 @@ func init() {
 	optionalFlags = append(optionalFlags, klog.CLIFlags(envVarPrefix)...)
 	optionalFlags = append(optionalFlags, kmetrics.CLIFlags(envVarPrefix)...)
 	optionalFlags = append(optionalFlags, kpprof.CLIFlags(envVarPrefix)...)
+	optionalFlags = append(optionalFlags, ktracing.CLIFlags(envVarPrefix)...)
 	optionalFlags = append(optionalFlags, rpc.CLIFlags(envVarPrefix)...)
 	optionalFlags = append(optionalFlags, txmgr.CLIFlags(envVarPrefix)...)
 
@@ -30,14 +30,19 @@ type Metricer interface {
 	RecordL2BlocksLoaded(l2ref eth.L2BlockRef)
 	RecordChannelOpened(id derive.ChannelID, numPendingBlocks int)
 	RecordL2BlocksAdded(l2ref eth.L2BlockRef, numBlocksAdded, numPendingBlocks, inputBytes, outputComprBytes int)
-	RecordChannelClosed(id derive.ChannelID, numPendingBlocks int, numFrames int, inputBytes int, outputComprBytes int, reason error)
+	RecordChannelClosed(id derive.ChannelID, numPendingBlocks int, numFrames int, inputBytes int, outputComprBytes int, compression derive.CompressionAlgo, reason error)
 	RecordChannelFullySubmitted(id derive.ChannelID)
 	RecordChannelTimedOut(id derive.ChannelID)
+	RecordChannelAcknowledged(id derive.ChannelID)
+	RecordChannelUnacknowledged(id derive.ChannelID)
 
 	RecordBatchTxSubmitted()
 	RecordBatchTxSuccess()
 	RecordBatchTxFailed()
 
+	RecordSafeLagReached(reached bool)
+	RecordL1ReorgReanchor()
+
 	Document() []kmetrics.DocumentedMetric
 }
 
@@ -52,7 +57,7 @@ type Metrics struct {
 	Info prometheus.GaugeVec
 	Up   prometheus.Gauge
 
-	// label by opened, closed, fully_submitted, timed_out
+	// label by opened, closed, fully_submitted, timed_out, acknowledged, unacknowledged
 	ChannelEvs kmetrics.EventVec
 
 	PendingBlocksCount prometheus.GaugeVec
@@ -63,9 +68,12 @@ type Metrics struct {
 	ChannelOutputBytes  prometheus.Gauge
 	ChannelClosedReason prometheus.Gauge
 	ChannelNumFrames    prometheus.Gauge
-	ChannelComprRatio   prometheus.Histogram
+	ChannelComprRatio   prometheus.HistogramVec
 
 	BatcherTxEvs kmetrics.EventVec
+
+	SafeLagReached   prometheus.Gauge
+	L1ReorgReanchors prometheus.Counter
 }
 
 var _ Metricer = (*Metrics)(nil)
@@ -138,14 +146,27 @@ func NewMetrics(procName string) *Metrics {
 			Name:      "channel_num_frames",
 			Help:      "Total number of frames of closed channel.",
 		}),
-		ChannelComprRatio: factory.NewHistogram(prometheus.HistogramOpts{
+		ChannelComprRatio: *factory.NewHistogramVec(prometheus.HistogramOpts{
 			Namespace: ns,
 			Name:      "channel_compr_ratio",
-			Help:      "Compression ratios of closed channel.",
+			Help:      "Compression ratios of closed channel, by compression algorithm.",
 			Buckets:   append([]float64{0.1, 0.2}, prometheus.LinearBuckets(0.3, 0.05, 14)...),
+		}, []string{
+			"algo",
 		}),
 
 		BatcherTxEvs: kmetrics.NewEventVec(factory, ns, "batcher_tx", "BatcherTx", []string{"stage"}),
+
+		SafeLagReached: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "safe_lag_reached",
+			Help:      "1 if batching is held back because the unsafe head is too far ahead of the safe head.",
+		}),
+		L1ReorgReanchors: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "l1_reorg_reanchors",
+			Help:      "Number of times batching was re-anchored at the safe head because submitted frames were reorged out of L1.",
+		}),
 	}
 }
 
@@ -182,6 +203,8 @@ const (
 	StageClosed         = "closed"
 	StageFullySubmitted = "fully_submitted"
 	StageTimedOut       = "timed_out"
+	StageAcknowledged   = "acknowledged"
+	StageUnacknowledged = "unacknowledged"
 
 	TxStageSubmitted = "submitted"
 	TxStageSuccess   = "success"
@@ -214,7 +237,7 @@ func (m *Metrics) RecordL2BlocksAdded(l2ref eth.L2BlockRef, numBlocksAdded, numP
 	m.ChannelReadyBytes.Set(float64(outputComprBytes))
 }
 
-func (m *Metrics) RecordChannelClosed(id derive.ChannelID, numPendingBlocks int, numFrames int, inputBytes int, outputComprBytes int, reason error) {
+func (m *Metrics) RecordChannelClosed(id derive.ChannelID, numPendingBlocks int, numFrames int, inputBytes int, outputComprBytes int, compression derive.CompressionAlgo, reason error) {
 	m.ChannelEvs.Record(StageClosed)
 	m.PendingBlocksCount.WithLabelValues(StageClosed).Set(float64(numPendingBlocks))
 	m.ChannelNumFrames.Set(float64(numFrames))
@@ -225,7 +248,7 @@ func (m *Metrics) RecordChannelClosed(id derive.ChannelID, numPendingBlocks int,
 	if inputBytes > 0 {
 		comprRatio = float64(outputComprBytes) / float64(inputBytes)
 	}
-	m.ChannelComprRatio.Observe(comprRatio)
+	m.ChannelComprRatio.WithLabelValues(string(compression)).Observe(comprRatio)
 
 	m.ChannelClosedReason.Set(float64(ClosedReasonToNum(reason)))
 }
@@ -243,6 +266,14 @@ func (m *Metrics) RecordChannelTimedOut(id derive.ChannelID) {
 	m.ChannelEvs.Record(StageTimedOut)
 }
 
+func (m *Metrics) RecordChannelAcknowledged(id derive.ChannelID) {
+	m.ChannelEvs.Record(StageAcknowledged)
+}
+
+func (m *Metrics) RecordChannelUnacknowledged(id derive.ChannelID) {
+	m.ChannelEvs.Record(StageUnacknowledged)
+}
+
 func (m *Metrics) RecordBatchTxSubmitted() {
 	m.BatcherTxEvs.Record(TxStageSubmitted)
 }
@@ -254,3 +285,15 @@ func (m *Metrics) RecordBatchTxSuccess() {
 func (m *Metrics) RecordBatchTxFailed() {
 	m.BatcherTxEvs.Record(TxStageFailed)
 }
+
+func (m *Metrics) RecordSafeLagReached(reached bool) {
+	if reached {
+		m.SafeLagReached.Set(1)
+	} else {
+		m.SafeLagReached.Set(0)
+	}
+}
+
+func (m *Metrics) RecordL1ReorgReanchor() {
+	m.L1ReorgReanchors.Inc()
+}
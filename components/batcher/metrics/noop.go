@@ -24,11 +24,17 @@ func (*noopMetrics) RecordL2BlocksLoaded(eth.L2BlockRef)                    {}
 func (*noopMetrics) RecordChannelOpened(derive.ChannelID, int)              {}
 func (*noopMetrics) RecordL2BlocksAdded(eth.L2BlockRef, int, int, int, int) {}
 
-func (*noopMetrics) RecordChannelClosed(derive.ChannelID, int, int, int, int, error) {}
+func (*noopMetrics) RecordChannelClosed(derive.ChannelID, int, int, int, int, derive.CompressionAlgo, error) {
+}
 
 func (*noopMetrics) RecordChannelFullySubmitted(derive.ChannelID) {}
 func (*noopMetrics) RecordChannelTimedOut(derive.ChannelID)       {}
+func (*noopMetrics) RecordChannelAcknowledged(derive.ChannelID)   {}
+func (*noopMetrics) RecordChannelUnacknowledged(derive.ChannelID) {}
 
 func (*noopMetrics) RecordBatchTxSubmitted() {}
 func (*noopMetrics) RecordBatchTxSuccess()   {}
 func (*noopMetrics) RecordBatchTxFailed()    {}
+
+func (*noopMetrics) RecordSafeLagReached(bool) {}
+func (*noopMetrics) RecordL1ReorgReanchor()    {}
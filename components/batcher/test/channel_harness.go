@@ -0,0 +1,169 @@
+package test
+
+import (
+	"errors"
+	"io"
+	"math/rand"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kroma-network/kroma/components/node/eth"
+	"github.com/kroma-network/kroma/components/node/rollup/derive"
+	dtest "github.com/kroma-network/kroma/components/node/rollup/derive/test"
+)
+
+// ChannelBuilder is the channel builder under test. It matches the channel
+// builder of the batcher, so that alternative compression or encoding
+// implementations can be checked against the decoder of the rollup node with
+// the same harness.
+type ChannelBuilder interface {
+	// AddBlock adds a block to the channel. It returns an error if the channel
+	// is full, in which case the block must not have been added.
+	AddBlock(block *types.Block) error
+	// RegisterL1Block registers a new L1 block, possibly timing out the channel.
+	RegisterL1Block(l1BlockNum uint64)
+	// IsFull returns whether the channel is full, i.e. no more blocks can be
+	// added and all its frames must be output.
+	IsFull() bool
+	// OutputFrames creates the frames that are ready, or all the remaining
+	// frames if the channel is full.
+	OutputFrames() error
+	// NextFrame pops the next frame created, without the derivation version
+	// byte. It returns false if there is no frame.
+	NextFrame() ([]byte, bool)
+	// Close marks the channel as full.
+	Close()
+}
+
+// ChannelParams configures the random block stream fed to the channel builders.
+type ChannelParams struct {
+	// Blocks is the number of L2 blocks of the stream.
+	Blocks int
+	// MaxTxs is the maximum number of transactions of an L2 block, excluding the
+	// L1 info deposit transaction.
+	MaxTxs int
+	// L1BlockInterval is the number of L2 blocks after which a new L1 block is
+	// registered, to exercise the timeouts. If 0, no L1 block is registered.
+	L1BlockInterval int
+	// MaxFrameSize is the maximum size of a frame the channel builders must respect.
+	MaxFrameSize uint64
+}
+
+// RandomBlocks returns a stream of random L2 blocks, each with a random L1
+// info deposit transaction and up to maxTxs transactions.
+func RandomBlocks(rng *rand.Rand, n int, maxTxs int) []*types.Block {
+	blocks := make([]*types.Block, n)
+	for i := range blocks {
+		blocks[i], _ = dtest.RandomL2Block(rng, rng.Intn(maxTxs+1))
+	}
+	return blocks
+}
+
+// CheckChannelRoundTrip feeds a random block stream to channel builders
+// created by newBuilder, starting a new channel whenever one gets full, and
+// asserts that decoding the frames of the channels with the decoder of the
+// rollup node returns the batches of the blocks of the stream, in order.
+// It also asserts that no frame exceeds the maximum frame size.
+func CheckChannelRoundTrip(t testing.TB, rng *rand.Rand, newBuilder func() (ChannelBuilder, error), params ChannelParams) {
+	t.Helper()
+	blocks := RandomBlocks(rng, params.Blocks, params.MaxTxs)
+
+	var (
+		channels [][][]byte
+		frames   [][]byte
+		l1Num    uint64
+		// number of blocks added to the current channel
+		added int
+	)
+	cb, err := newBuilder()
+	require.NoError(t, err, "creating channel builder")
+
+	// drainFrames outputs the frames of the channel, and starts a new channel if it is full.
+	drainFrames := func() {
+		require.NoError(t, cb.OutputFrames(), "outputting frames")
+		for frame, ok := cb.NextFrame(); ok; frame, ok = cb.NextFrame() {
+			require.LessOrEqual(t, uint64(len(frame)), params.MaxFrameSize, "frame exceeds max frame size")
+			frames = append(frames, frame)
+		}
+		if cb.IsFull() {
+			channels = append(channels, frames)
+			frames, added = nil, 0
+			cb, err = newBuilder()
+			require.NoError(t, err, "creating channel builder")
+		}
+	}
+
+	for i, block := range blocks {
+		if err := cb.AddBlock(block); err != nil {
+			require.True(t, cb.IsFull(), "adding block %d: %v", i, err)
+			drainFrames()
+			require.NoError(t, cb.AddBlock(block), "adding block %d to new channel", i)
+		}
+		added++
+		if params.L1BlockInterval > 0 && (i+1)%params.L1BlockInterval == 0 {
+			l1Num++
+			cb.RegisterL1Block(l1Num)
+		}
+		drainFrames()
+	}
+	if added > 0 {
+		cb.Close()
+		drainFrames()
+	}
+
+	var batches []*derive.BatchData
+	for i, channel := range channels {
+		chBatches, err := DecodeChannel(channel)
+		require.NoError(t, err, "decoding channel %d", i)
+		batches = append(batches, chBatches...)
+	}
+	require.Len(t, batches, len(blocks), "decoded batches must match blocks")
+	for i, block := range blocks {
+		batch, _, err := derive.BlockToBatch(block)
+		require.NoError(t, err)
+		require.Equal(t, batch, batches[i], "batch %d", i)
+	}
+}
+
+// DecodeChannel decodes the frames of a single channel, in order, with the
+// decoder of the rollup node, and returns their batches.
+func DecodeChannel(frames [][]byte) ([]*derive.BatchData, error) {
+	if len(frames) == 0 {
+		return nil, errors.New("channel must have at least one frame")
+	}
+	var ch *derive.Channel
+	for i, data := range frames {
+		parsed, err := derive.ParseFrames(append([]byte{derive.DerivationVersion0}, data...))
+		if err != nil {
+			return nil, err
+		}
+		for _, frame := range parsed {
+			if ch == nil {
+				ch = derive.NewChannel(frame.ID, eth.L1BlockRef{})
+			}
+			if err := ch.AddFrame(frame, eth.L1BlockRef{Number: uint64(i)}); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if !ch.IsReady() {
+		return nil, errors.New("channel is not ready after all frames")
+	}
+
+	next, err := derive.BatchReader(ch.Reader(), eth.L1BlockRef{}, true)
+	if err != nil {
+		return nil, err
+	}
+	var batches []*derive.BatchData
+	for {
+		batch, err := next()
+		if err == io.EOF {
+			return batches, nil
+		} else if err != nil {
+			return nil, err
+		}
+		batches = append(batches, batch.Batch)
+	}
+}
@@ -0,0 +1,142 @@
+package batcher
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/kroma-network/kroma/components/node/eth"
+	"github.com/kroma-network/kroma/components/node/rollup/derive"
+)
+
+var (
+	errChannelTimedOut = errors.New("channel timed out")
+	errChannelCleared  = errors.New("channel cleared")
+	errChannelShutdown = errors.New("channel cleared for shutdown")
+)
+
+// batchTracer traces the L2 blocks through the batcher. Every channel is a trace, spanning from the
+// opening of the channel until it is fully submitted, timed out or cleared. The blocks of the channel
+// are child spans from their ingestion until they were added to the channel, and the frames of the channel
+// are child spans from their submission until the L1 confirmation of the transaction that carried them.
+type batchTracer struct {
+	tracer trace.Tracer
+
+	// ingested are the ingestion times of the blocks not added to a channel yet, by block hash.
+	ingested map[common.Hash]time.Time
+
+	channel    trace.Span
+	channelCtx context.Context
+	frames     map[txID]trace.Span
+}
+
+func newBatchTracer(tracer trace.Tracer) *batchTracer {
+	return &batchTracer{
+		tracer:   tracer,
+		ingested: make(map[common.Hash]time.Time),
+		frames:   make(map[txID]trace.Span),
+	}
+}
+
+func (t *batchTracer) blockIngested(block *types.Block) {
+	t.ingested[block.Hash()] = time.Now()
+}
+
+func (t *batchTracer) channelOpened(id derive.ChannelID, l1Head eth.BlockID) {
+	t.channelCtx, t.channel = t.tracer.Start(context.Background(), "channel", trace.WithAttributes(
+		attribute.String("channel.id", id.String()),
+		attribute.Int64("channel.l1_head", int64(l1Head.Number)),
+	))
+}
+
+func (t *batchTracer) blockAdded(block *types.Block, l1info derive.L1BlockInfo) {
+	opts := []trace.SpanStartOption{trace.WithAttributes(
+		attribute.Int64("block.number", int64(block.NumberU64())),
+		attribute.String("block.hash", block.Hash().String()),
+		attribute.Int("block.tx_count", len(block.Transactions())),
+		attribute.Int64("block.l1_origin", int64(l1info.Number)),
+	)}
+	// blocks re-added after a channel timeout were traced from their ingestion in the timed out channel already.
+	if ingested, ok := t.ingested[block.Hash()]; ok {
+		opts = append(opts, trace.WithTimestamp(ingested))
+		delete(t.ingested, block.Hash())
+	}
+	_, span := t.tracer.Start(t.channelCtx, "block", opts...)
+	span.End()
+}
+
+func (t *batchTracer) channelFull(numFrames, inputBytes, outputBytes int, reason error) {
+	t.channel.AddEvent("channel closed", trace.WithAttributes(
+		attribute.Int("channel.num_frames", numFrames),
+		attribute.Int("channel.input_bytes", inputBytes),
+		attribute.Int("channel.output_bytes", outputBytes),
+		attribute.String("channel.full_reason", reason.Error()),
+	))
+}
+
+func (t *batchTracer) frameSubmitted(id txID, size int) {
+	_, span := t.tracer.Start(t.channelCtx, "frame", trace.WithAttributes(
+		attribute.Int("frame.number", int(id.frameNumber)),
+		attribute.Int("frame.size", size),
+		// the batcher submits frames as calldata only, there are no blobs.
+		attribute.String("frame.data_availability", "calldata"),
+	))
+	t.frames[id] = span
+}
+
+func (t *batchTracer) frameConfirmed(id txID, receipt *types.Receipt) {
+	span, ok := t.frames[id]
+	if !ok {
+		return
+	}
+	delete(t.frames, id)
+	span.SetAttributes(
+		attribute.String("tx.hash", receipt.TxHash.String()),
+		attribute.Int64("tx.l1_block", receipt.BlockNumber.Int64()),
+		attribute.String("tx.l1_block_hash", receipt.BlockHash.String()),
+		attribute.Int64("tx.gas_used", int64(receipt.GasUsed)),
+		attribute.Int64("tx.status", int64(receipt.Status)),
+	)
+	if receipt.EffectiveGasPrice != nil {
+		span.SetAttributes(attribute.String("tx.effective_gas_price", receipt.EffectiveGasPrice.String()))
+	}
+	span.End()
+}
+
+func (t *batchTracer) frameFailed(id txID, err error) {
+	span, ok := t.frames[id]
+	if !ok {
+		return
+	}
+	delete(t.frames, id)
+	span.SetStatus(codes.Error, err.Error())
+	span.End()
+}
+
+// channelEnded ends the trace of the pending channel, with the error of the channel if it was not fully submitted.
+func (t *batchTracer) channelEnded(err error) {
+	if t.channel == nil {
+		return
+	}
+	for id, span := range t.frames {
+		span.SetStatus(codes.Error, errChannelCleared.Error())
+		span.End()
+		delete(t.frames, id)
+	}
+	if err != nil {
+		t.channel.SetStatus(codes.Error, err.Error())
+	}
+	t.channel.End()
+	t.channel, t.channelCtx = nil, nil
+}
+
+// clear forgets the ingested blocks, after the state of the batcher was cleared.
+func (t *batchTracer) clear() {
+	t.ingested = make(map[common.Hash]time.Time)
+}
@@ -0,0 +1,121 @@
+package batcher
+
+import (
+	"io"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/kroma-network/kroma/components/batcher/metrics"
+	"github.com/kroma-network/kroma/components/node/eth"
+	"github.com/kroma-network/kroma/components/node/testlog"
+)
+
+func newTracedChannelManager(t *testing.T) (*channelManager, *tracetest.SpanRecorder) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	m := NewChannelManager(testlog.Logger(t, log.LvlCrit), metrics.NoopMetrics, ChannelConfig{
+		TargetNumFrames:  100,
+		TargetFrameSize:  1000,
+		MaxFrameSize:     1000,
+		ApproxComprRatio: 1.0,
+		ChannelTimeout:   1000,
+	})
+	m.tracer = newBatchTracer(provider.Tracer("test"))
+	return m, recorder
+}
+
+func spansByName(spans []sdktrace.ReadOnlySpan, name string) []sdktrace.ReadOnlySpan {
+	var named []sdktrace.ReadOnlySpan
+	for _, span := range spans {
+		if span.Name() == name {
+			named = append(named, span)
+		}
+	}
+	return named
+}
+
+func spanAttribute(span sdktrace.ReadOnlySpan, key attribute.Key) attribute.Value {
+	for _, kv := range span.Attributes() {
+		if kv.Key == key {
+			return kv.Value
+		}
+	}
+	return attribute.Value{}
+}
+
+// TestBatchTracerChannel checks that a fully submitted channel is traced with its blocks and frames.
+func TestBatchTracerChannel(t *testing.T) {
+	require := require.New(t)
+	m, recorder := newTracedChannelManager(t)
+
+	block := newMiniL2Block(50_000)
+	require.NoError(m.AddL2Block(block))
+
+	confirm := func(txdata txData, i int) {
+		receipt := &types.Receipt{TxHash: common.Hash{byte(i)}, BlockNumber: big.NewInt(int64(i)), Status: types.ReceiptStatusSuccessful}
+		m.tracer.frameConfirmed(txdata.ID(), receipt)
+		m.TxConfirmed(txdata.ID(), eth.BlockID{Number: uint64(i)})
+	}
+	txdata, err := m.TxData(eth.BlockID{})
+	require.NoError(err)
+	confirm(txdata, 0)
+	m.Close()
+	numFrames := 1
+	for ; ; numFrames++ {
+		txdata, err := m.TxData(eth.BlockID{})
+		if err == io.EOF {
+			break
+		}
+		require.NoError(err)
+		confirm(txdata, numFrames)
+	}
+
+	spans := recorder.Ended()
+	channels := spansByName(spans, "channel")
+	require.Len(channels, 1)
+	channel := channels[0]
+	require.Equal(codes.Unset, channel.Status().Code, "fully submitted channel")
+
+	blocks := spansByName(spans, "block")
+	require.Len(blocks, 1)
+	require.Equal(channel.SpanContext().SpanID(), blocks[0].Parent().SpanID())
+	require.Equal(block.Hash().String(), spanAttribute(blocks[0], "block.hash").AsString())
+
+	frames := spansByName(spans, "frame")
+	require.Len(frames, numFrames)
+	for i, frame := range frames {
+		require.Equal(channel.SpanContext().SpanID(), frame.Parent().SpanID())
+		require.Equal(common.Hash{byte(i)}.String(), spanAttribute(frame, "tx.hash").AsString())
+		require.Equal("calldata", spanAttribute(frame, "frame.data_availability").AsString())
+	}
+}
+
+// TestBatchTracerClear checks that a cleared channel and its pending frames are traced as failed.
+func TestBatchTracerClear(t *testing.T) {
+	require := require.New(t)
+	m, recorder := newTracedChannelManager(t)
+
+	require.NoError(m.AddL2Block(newMiniL2Block(50_000)))
+	_, err := m.TxData(eth.BlockID{})
+	require.NoError(err)
+	m.Clear()
+
+	spans := recorder.Ended()
+	channels := spansByName(spans, "channel")
+	require.Len(channels, 1)
+	require.Equal(codes.Error, channels[0].Status().Code)
+	require.Equal(errChannelCleared.Error(), channels[0].Status().Description)
+
+	frames := spansByName(spans, "frame")
+	require.Len(frames, 1)
+	require.Equal(codes.Error, frames[0].Status().Code)
+}
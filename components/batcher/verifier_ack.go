@@ -0,0 +1,122 @@
+package batcher
+
+import (
+	"context"
+
+	"github.com/kroma-network/kroma/components/node/eth"
+	"github.com/kroma-network/kroma/components/node/rollup/derive"
+	"github.com/kroma-network/kroma/components/node/sources"
+)
+
+// SyncStatusProvider provides the sync status of a rollup node.
+type SyncStatusProvider interface {
+	SyncStatus(ctx context.Context) (*eth.SyncStatus, error)
+}
+
+// VerifierClient provides the sync status and the L2 blocks of the rollup node of a verifier.
+type VerifierClient interface {
+	SyncStatusProvider
+	L2BlockRefByNumber(ctx context.Context, num uint64) (eth.L2BlockRef, error)
+}
+
+// rollupVerifierClient is the VerifierClient of a rollup node, reading its L2 blocks from its outputs.
+type rollupVerifierClient struct {
+	*sources.RollupClient
+}
+
+func (c *rollupVerifierClient) L2BlockRefByNumber(ctx context.Context, num uint64) (eth.L2BlockRef, error) {
+	output, err := c.OutputAtBlock(ctx, num)
+	if err != nil {
+		return eth.L2BlockRef{}, err
+	}
+	return output.BlockRef, nil
+}
+
+// submittedChannel is a fully submitted channel awaiting the acknowledgment of the verifier.
+type submittedChannel struct {
+	id derive.ChannelID
+	// lastBlock is the last L2 block batched in the channel.
+	lastBlock eth.BlockID
+	// inclusion is the number of the L1 block the last frame of the channel was included in.
+	inclusion uint64
+}
+
+// verifierAcks keeps the fully submitted channels, in submission order, until the safe head of the verifier
+// includes their blocks. A nil *verifierAcks does not keep the channels.
+type verifierAcks struct {
+	pending []submittedChannel
+}
+
+func (a *verifierAcks) channelSubmitted(ch submittedChannel) {
+	if a == nil {
+		return
+	}
+	a.pending = append(a.pending, ch)
+}
+
+func (a *verifierAcks) clear() {
+	if a == nil {
+		return
+	}
+	a.pending = nil
+}
+
+// CheckVerifierAcks checks that the verifier derived the blocks of the fully submitted channels. A channel is
+// delivered once the safe head of the verifier includes its last block, with the same hash. If the verifier derived
+// a different block at the height of the last block, or derived VerifierAckWindow L1 blocks past the inclusion of the
+// last frame of a channel without including its blocks, the data posted is assumed to be unreadable by the derivation
+// pipeline, so the state is cleared to batch again from the safe head.
+func (b *BatchSubmitter) CheckVerifierAcks(ctx context.Context) {
+	acks := b.state.acks
+	if acks == nil || len(acks.pending) == 0 {
+		return
+	}
+	tctx, cancel := context.WithTimeout(ctx, b.NetworkTimeout)
+	status, err := b.VerifierClient.SyncStatus(tctx)
+	cancel()
+	if err != nil {
+		b.log.Warn("failed to check submitted channels against the verifier", "err", err)
+		return
+	}
+
+	for len(acks.pending) > 0 {
+		ch := acks.pending[0]
+		if status.SafeL2.Number >= ch.lastBlock.Number {
+			tctx, cancel := context.WithTimeout(ctx, b.NetworkTimeout)
+			ref, err := b.VerifierClient.L2BlockRefByNumber(tctx, ch.lastBlock.Number)
+			cancel()
+			if err != nil {
+				b.log.Warn("failed to check submitted channel against the verifier", "id", ch.id, "last_block", ch.lastBlock, "err", err)
+				return
+			}
+			if ref.Hash != ch.lastBlock.Hash {
+				b.log.Error("verifier derived a different block than the last block of a submitted channel, re-anchoring batching at the safe head",
+					"id", ch.id, "last_block", ch.lastBlock, "verifier_block", ref, "verifier_safe", status.SafeL2)
+				b.channelUnacknowledged(ch)
+				return
+			}
+			b.log.Info("Channel is acknowledged by the verifier", "id", ch.id, "last_block", ch.lastBlock, "verifier_safe", status.SafeL2)
+			b.metr.RecordChannelAcknowledged(ch.id)
+			acks.pending = acks.pending[1:]
+			continue
+		}
+		if status.CurrentL1.Number < ch.inclusion+b.VerifierAckWindow {
+			// the verifier may not have derived the channel yet
+			return
+		}
+		b.log.Error("verifier did not derive the blocks of a submitted channel, re-anchoring batching at the safe head",
+			"id", ch.id, "last_block", ch.lastBlock, "inclusion_block", ch.inclusion,
+			"verifier_safe", status.SafeL2, "verifier_l1", status.CurrentL1)
+		b.channelUnacknowledged(ch)
+		return
+	}
+}
+
+// channelUnacknowledged clears the state to batch again from the safe head, as the channel was not derived by the
+// verifier.
+func (b *BatchSubmitter) channelUnacknowledged(ch submittedChannel) {
+	b.metr.RecordChannelUnacknowledged(ch.id)
+	b.state.Clear()
+	b.lastStoredBlock = eth.BlockID{}
+	b.unfinalizedInclusions = nil
+}
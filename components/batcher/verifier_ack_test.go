@@ -0,0 +1,150 @@
+package batcher
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kroma-network/kroma/components/batcher/metrics"
+	"github.com/kroma-network/kroma/components/node/eth"
+	"github.com/kroma-network/kroma/components/node/testlog"
+)
+
+type fakeSyncStatusProvider struct {
+	mu     sync.Mutex
+	status eth.SyncStatus
+	err    error
+	blocks map[uint64]common.Hash
+}
+
+func (p *fakeSyncStatusProvider) SyncStatus(_ context.Context) (*eth.SyncStatus, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.err != nil {
+		return nil, p.err
+	}
+	status := p.status
+	return &status, nil
+}
+
+func (p *fakeSyncStatusProvider) L2BlockRefByNumber(_ context.Context, num uint64) (eth.L2BlockRef, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	hash, ok := p.blocks[num]
+	if !ok {
+		return eth.L2BlockRef{}, ethereum.NotFound
+	}
+	return eth.L2BlockRef{Hash: hash, Number: num}, nil
+}
+
+// setBlock sets the hash of the L2 block of the number derived by the verifier.
+func (p *fakeSyncStatusProvider) setBlock(num uint64, hash common.Hash) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.blocks == nil {
+		p.blocks = make(map[uint64]common.Hash)
+	}
+	p.blocks[num] = hash
+}
+
+func (p *fakeSyncStatusProvider) set(safe uint64, currentL1 uint64, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.status.SafeL2 = eth.L2BlockRef{Number: safe}
+	p.status.CurrentL1 = eth.L1BlockRef{Number: currentL1}
+	p.err = err
+}
+
+func TestCheckVerifierAcks(t *testing.T) {
+	require := require.New(t)
+	verifier := &fakeSyncStatusProvider{}
+	b, err := NewBatchSubmitter(Config{
+		log:               testlog.Logger(t, log.LvlCrit),
+		metr:              metrics.NoopMetrics,
+		NetworkTimeout:    time.Second,
+		VerifierClient:    verifier,
+		VerifierAckWindow: 2,
+		Channel: ChannelConfig{
+			ChannelTimeout:   100,
+			MaxFrameSize:     120000,
+			TargetFrameSize:  100000,
+			TargetNumFrames:  1,
+			ApproxComprRatio: 1.0,
+			FlushSafeLag:     1,
+		},
+	}, testlog.Logger(t, log.LvlCrit), metrics.NoopMetrics)
+	require.NoError(err)
+
+	// submit submits a channel of the blocks in a single frame, included in the L1 block.
+	submit := func(inclusion uint64, blocks ...*types.Block) {
+		for _, block := range blocks {
+			require.NoError(b.state.AddL2Block(block))
+		}
+		b.state.RegisterUnsafeHead(blocks[len(blocks)-1].NumberU64() + 1)
+		txdata, err := b.state.TxData(eth.BlockID{Number: inclusion - 1})
+		require.NoError(err)
+		b.state.TxConfirmed(txdata.ID(), eth.BlockID{Number: inclusion})
+		require.Nil(b.state.pendingChannel, "channel must be fully submitted")
+	}
+
+	a := newMiniL2Block(0)
+	a1 := newMiniL2BlockWithNumberParent(0, big.NewInt(1), a.Hash())
+	submit(2, a, a1)
+	require.Len(b.state.acks.pending, 1)
+	require.Equal(eth.ToBlockID(a1), b.state.acks.pending[0].lastBlock)
+	require.Equal(uint64(2), b.state.acks.pending[0].inclusion)
+
+	verifier.set(1, 10, errors.New("rpc failure"))
+	b.CheckVerifierAcks(context.Background())
+	require.Len(b.state.acks.pending, 1, "kept while the verifier is unreachable")
+
+	verifier.set(0, 3, nil)
+	b.CheckVerifierAcks(context.Background())
+	require.Len(b.state.acks.pending, 1, "verifier may not have derived the channel yet")
+
+	verifier.set(1, 3, nil)
+	b.CheckVerifierAcks(context.Background())
+	require.Len(b.state.acks.pending, 1, "kept while the block of the verifier is unknown")
+
+	verifier.setBlock(1, a1.Hash())
+	b.CheckVerifierAcks(context.Background())
+	require.Empty(b.state.acks.pending)
+
+	a2 := newMiniL2BlockWithNumberParent(0, big.NewInt(2), a1.Hash())
+	a3 := newMiniL2BlockWithNumberParent(0, big.NewInt(3), a2.Hash())
+	submit(5, a2, a3)
+	b.lastStoredBlock = eth.ToBlockID(a3)
+	require.NoError(b.state.AddL2Block(newMiniL2BlockWithNumberParent(0, big.NewInt(4), a3.Hash())))
+
+	verifier.set(2, 6, nil)
+	b.CheckVerifierAcks(context.Background())
+	require.Len(b.state.acks.pending, 1)
+	require.Equal(eth.ToBlockID(a3), b.state.acks.pending[0].lastBlock)
+
+	// the verifier derived past the inclusion of the channel without its blocks
+	verifier.set(2, 7, nil)
+	b.CheckVerifierAcks(context.Background())
+	require.Empty(b.state.acks.pending)
+	require.Empty(b.state.blocks, "state must be cleared")
+	require.Equal(eth.BlockID{}, b.lastStoredBlock, "batching must be re-anchored at the safe head")
+
+	// the verifier derived a different block at the height of the last block of the channel
+	b4 := newMiniL2BlockWithNumberParent(0, big.NewInt(4), a3.Hash())
+	b5 := newMiniL2BlockWithNumberParent(0, big.NewInt(5), b4.Hash())
+	submit(8, b4, b5)
+	b.lastStoredBlock = eth.ToBlockID(b5)
+	verifier.set(5, 8, nil)
+	verifier.setBlock(5, common.Hash{0xff})
+	b.CheckVerifierAcks(context.Background())
+	require.Empty(b.state.acks.pending)
+	require.Equal(eth.BlockID{}, b.lastStoredBlock, "batching must be re-anchored at the safe head")
+}
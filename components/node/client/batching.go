@@ -0,0 +1,127 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// BatchingClient is a wrapper around a pure RPC that bundles concurrent single requests into batch requests,
+// to save round trips on high-latency links. A request waits at most maxLatency for other requests to join its batch,
+// and a batch is sent as soon as it holds maxSize requests. Batches are sent concurrently, without waiting for
+// the responses of the previous batches.
+type BatchingClient struct {
+	c          RPC
+	maxSize    int
+	maxLatency time.Duration
+
+	mu      sync.Mutex
+	pending []*batchedCall
+	timer   *time.Timer
+}
+
+type batchedCall struct {
+	elem rpc.BatchElem
+	raw  json.RawMessage
+	done chan struct{}
+}
+
+// NewBatchingClient bundles the single requests to the RPC into batches of up to maxSize requests,
+// waiting up to maxLatency for requests to join a batch.
+func NewBatchingClient(c RPC, maxSize int, maxLatency time.Duration) *BatchingClient {
+	return &BatchingClient{c: c, maxSize: maxSize, maxLatency: maxLatency}
+}
+
+func (b *BatchingClient) Close() {
+	b.flush()
+	b.c.Close()
+}
+
+func (b *BatchingClient) CallContext(ctx context.Context, result any, method string, args ...any) error {
+	call := &batchedCall{done: make(chan struct{})}
+	// the response is decoded into the result by the caller, as the caller may return before the batch is done.
+	call.elem = rpc.BatchElem{Method: method, Args: args, Result: &call.raw}
+	b.enqueue(call)
+
+	select {
+	case <-call.done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	if call.elem.Error != nil {
+		return call.elem.Error
+	}
+	if result == nil {
+		return nil
+	}
+	return json.Unmarshal(call.raw, result)
+}
+
+func (b *BatchingClient) BatchCallContext(ctx context.Context, batch []rpc.BatchElem) error {
+	return b.c.BatchCallContext(ctx, batch)
+}
+
+func (b *BatchingClient) EthSubscribe(ctx context.Context, channel any, args ...any) (ethereum.Subscription, error) {
+	return b.c.EthSubscribe(ctx, channel, args...)
+}
+
+func (b *BatchingClient) enqueue(call *batchedCall) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.pending = append(b.pending, call)
+	if len(b.pending) >= b.maxSize {
+		b.flushLocked()
+	} else if b.timer == nil {
+		b.timer = time.AfterFunc(b.maxLatency, b.flush)
+	}
+}
+
+func (b *BatchingClient) flush() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.flushLocked()
+}
+
+func (b *BatchingClient) flushLocked() {
+	if b.timer != nil {
+		b.timer.Stop()
+		b.timer = nil
+	}
+	if len(b.pending) == 0 {
+		return
+	}
+	calls := b.pending
+	b.pending = nil
+	go b.send(calls)
+}
+
+// send sends the calls as a single batch. The batch is not canceled with the contexts of the calls,
+// since it is shared by several of them.
+func (b *BatchingClient) send(calls []*batchedCall) {
+	cCtx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	if len(calls) == 1 {
+		elem := &calls[0].elem
+		elem.Error = b.c.CallContext(cCtx, elem.Result, elem.Method, elem.Args...)
+	} else {
+		batch := make([]rpc.BatchElem, len(calls))
+		for i, call := range calls {
+			batch[i] = call.elem
+		}
+		err := b.c.BatchCallContext(cCtx, batch)
+		for i, call := range calls {
+			call.elem.Error = batch[i].Error
+			if err != nil {
+				call.elem.Error = err
+			}
+		}
+	}
+	for _, call := range calls {
+		close(call.done)
+	}
+}
@@ -0,0 +1,153 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+// echoRPC responds to every request with its first argument, and records the sizes of the requests.
+type echoRPC struct {
+	mu      sync.Mutex
+	sizes   []int
+	release chan struct{}
+	err     error
+}
+
+func (e *echoRPC) Close() {}
+
+func (e *echoRPC) CallContext(_ context.Context, result any, _ string, args ...any) error {
+	e.record(1)
+	if e.err != nil {
+		return e.err
+	}
+	*result.(*json.RawMessage) = json.RawMessage(fmt.Sprintf("%q", args[0]))
+	return nil
+}
+
+func (e *echoRPC) BatchCallContext(_ context.Context, b []rpc.BatchElem) error {
+	e.record(len(b))
+	if e.release != nil {
+		<-e.release
+	}
+	if e.err != nil {
+		return e.err
+	}
+	for i := range b {
+		if b[i].Args[0] == "fail" {
+			b[i].Error = errors.New("request failed")
+			continue
+		}
+		*b[i].Result.(*json.RawMessage) = json.RawMessage(fmt.Sprintf("%q", b[i].Args[0]))
+	}
+	return nil
+}
+
+func (e *echoRPC) EthSubscribe(context.Context, any, ...any) (ethereum.Subscription, error) {
+	return nil, errors.New("not supported")
+}
+
+func (e *echoRPC) record(size int) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.sizes = append(e.sizes, size)
+}
+
+func (e *echoRPC) requestSizes() []int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]int(nil), e.sizes...)
+}
+
+func callConcurrently(t *testing.T, c RPC, args ...string) []error {
+	errs := make([]error, len(args))
+	var wg sync.WaitGroup
+	for i, arg := range args {
+		wg.Add(1)
+		go func(i int, arg string) {
+			defer wg.Done()
+			var out string
+			errs[i] = c.CallContext(context.Background(), &out, "test_echo", arg)
+			if errs[i] == nil {
+				require.Equal(t, arg, out)
+			}
+		}(i, arg)
+	}
+	wg.Wait()
+	return errs
+}
+
+func TestBatchingClient(t *testing.T) {
+	t.Run("bundles concurrent requests", func(t *testing.T) {
+		e := &echoRPC{}
+		c := NewBatchingClient(e, 3, time.Second)
+		errs := callConcurrently(t, c, "a", "b", "c")
+		require.Equal(t, []error{nil, nil, nil}, errs)
+		require.Equal(t, []int{3}, e.requestSizes())
+	})
+
+	t.Run("sends after max latency", func(t *testing.T) {
+		e := &echoRPC{}
+		c := NewBatchingClient(e, 10, 10*time.Millisecond)
+		errs := callConcurrently(t, c, "a", "b")
+		require.Equal(t, []error{nil, nil}, errs)
+		sizes := e.requestSizes()
+		total := 0
+		for _, size := range sizes {
+			total += size
+		}
+		require.Equal(t, 2, total)
+	})
+
+	t.Run("single request", func(t *testing.T) {
+		e := &echoRPC{}
+		c := NewBatchingClient(e, 10, time.Millisecond)
+		var out string
+		require.NoError(t, c.CallContext(context.Background(), &out, "test_echo", "a"))
+		require.Equal(t, "a", out)
+		require.Equal(t, []int{1}, e.requestSizes())
+	})
+
+	t.Run("request errors", func(t *testing.T) {
+		e := &echoRPC{}
+		c := NewBatchingClient(e, 2, time.Second)
+		errs := callConcurrently(t, c, "a", "fail")
+		require.NoError(t, errs[0])
+		require.EqualError(t, errs[1], "request failed")
+	})
+
+	t.Run("batch error", func(t *testing.T) {
+		e := &echoRPC{err: errors.New("connection lost")}
+		c := NewBatchingClient(e, 2, time.Second)
+		errs := callConcurrently(t, c, "a", "b")
+		require.EqualError(t, errs[0], "connection lost")
+		require.EqualError(t, errs[1], "connection lost")
+	})
+
+	t.Run("canceled request", func(t *testing.T) {
+		e := &echoRPC{release: make(chan struct{})}
+		c := NewBatchingClient(e, 2, time.Second)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		var out string
+		require.ErrorIs(t, c.CallContext(ctx, &out, "test_echo", "a"), context.Canceled)
+		// the next request completes the batch, which is sent regardless of the canceled request.
+		done := make(chan error)
+		go func() {
+			var out string
+			done <- c.CallContext(context.Background(), &out, "test_echo", "b")
+		}()
+		require.Eventually(t, func() bool { return len(e.requestSizes()) == 1 }, time.Second, time.Millisecond)
+		close(e.release)
+		require.NoError(t, <-done)
+		require.Empty(t, out, "result of the canceled request must not be written")
+	})
+}
@@ -31,6 +31,8 @@ type rpcConfig struct {
 	backoffAttempts  int
 	limit            float64
 	burst            int
+	batchSize        int
+	batchLatency     time.Duration
 }
 
 type RPCOption func(cfg *rpcConfig) error
@@ -70,6 +72,16 @@ func WithRateLimit(rateLimit float64, burst int) RPCOption {
 	}
 }
 
+// WithCallBatching configures the RPC to bundle concurrent single requests into batches of up to maxSize requests,
+// waiting up to maxLatency for requests to join a batch. See NewBatchingClient for more details.
+func WithCallBatching(maxSize int, maxLatency time.Duration) RPCOption {
+	return func(cfg *rpcConfig) error {
+		cfg.batchSize = maxSize
+		cfg.batchLatency = maxLatency
+		return nil
+	}
+}
+
 // NewRPC returns the correct client.RPC instance for a given RPC url.
 func NewRPC(ctx context.Context, lgr log.Logger, addr string, opts ...RPCOption) (RPC, error) {
 	var cfg rpcConfig
@@ -95,6 +107,10 @@ func NewRPC(ctx context.Context, lgr log.Logger, addr string, opts ...RPCOption)
 		wrapped = NewRateLimitingClient(wrapped, rate.Limit(cfg.limit), cfg.burst)
 	}
 
+	if cfg.batchSize > 1 && cfg.batchLatency > 0 {
+		wrapped = NewBatchingClient(wrapped, cfg.batchSize, cfg.batchLatency)
+	}
+
 	if httpRegex.MatchString(addr) {
 		wrapped = NewPollingClient(ctx, lgr, wrapped, WithPollRate(cfg.httpPollInterval))
 	}
@@ -157,15 +173,18 @@ func (b *BaseRPCClient) EthSubscribe(ctx context.Context, channel any, args ...a
 // InstrumentedRPCClient is an RPC client that tracks
 // Prometheus metrics for each call.
 type InstrumentedRPCClient struct {
-	c RPC
-	m *metrics.Metrics
+	c    RPC
+	m    *metrics.Metrics
+	name string
 }
 
 // NewInstrumentedRPC creates a new instrumented RPC client.
-func NewInstrumentedRPC(c RPC, m *metrics.Metrics) *InstrumentedRPCClient {
+// The name is used as the client label of the recorded metrics.
+func NewInstrumentedRPC(c RPC, m *metrics.Metrics, name string) *InstrumentedRPCClient {
 	return &InstrumentedRPCClient{
-		c: c,
-		m: m,
+		c:    c,
+		m:    m,
+		name: name,
 	}
 }
 
@@ -174,13 +193,13 @@ func (ic *InstrumentedRPCClient) Close() {
 }
 
 func (ic *InstrumentedRPCClient) CallContext(ctx context.Context, result any, method string, args ...any) error {
-	return instrument1(ic.m, method, func() error {
+	return instrument1(ic.m, ic.name, method, func() error {
 		return ic.c.CallContext(ctx, result, method, args...)
 	})
 }
 
 func (ic *InstrumentedRPCClient) BatchCallContext(ctx context.Context, b []rpc.BatchElem) error {
-	return instrumentBatch(ic.m, func() error {
+	return instrumentBatch(ic.m, ic.name, func() error {
 		return ic.c.BatchCallContext(ctx, b)
 	}, b)
 }
@@ -194,21 +213,21 @@ func (ic *InstrumentedRPCClient) EthSubscribe(ctx context.Context, channel any,
 // the batch as a whole using a special <batch> method. Errors are tracked
 // for each individual batch response, unless the overall request fails in
 // which case the <batch> method is used.
-func instrumentBatch(m *metrics.Metrics, cb func() error, b []rpc.BatchElem) error {
-	m.RPCClientRequestsTotal.WithLabelValues(metrics.BatchMethod).Inc()
+func instrumentBatch(m *metrics.Metrics, name string, cb func() error, b []rpc.BatchElem) error {
+	m.RPCClientRequestsTotal.WithLabelValues(name, metrics.BatchMethod).Inc()
 	for _, elem := range b {
-		m.RPCClientRequestsTotal.WithLabelValues(elem.Method).Inc()
+		m.RPCClientRequestsTotal.WithLabelValues(name, elem.Method).Inc()
 	}
-	timer := prometheus.NewTimer(m.RPCClientRequestDurationSeconds.WithLabelValues(metrics.BatchMethod))
+	timer := prometheus.NewTimer(m.RPCClientRequestDurationSeconds.WithLabelValues(name, metrics.BatchMethod))
 	defer timer.ObserveDuration()
 
 	// Track response times for batch requests separately.
 	if err := cb(); err != nil {
-		m.RecordRPCClientResponse(metrics.BatchMethod, err)
+		m.RecordRPCClientResponse(name, metrics.BatchMethod, err)
 		return err
 	}
 	for _, elem := range b {
-		m.RecordRPCClientResponse(elem.Method, elem.Error)
+		m.RecordRPCClientResponse(name, elem.Method, elem.Error)
 	}
 	return nil
 }
@@ -0,0 +1,248 @@
+package chain
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/log"
+	gn "github.com/ethereum/go-ethereum/node"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/urfave/cli"
+
+	"github.com/kroma-network/kroma/components/node/client"
+	"github.com/kroma-network/kroma/components/node/eth"
+)
+
+var Subcommands = cli.Commands{
+	{
+		Name:  "export",
+		Usage: "Exports a finalized segment of the canonical L2 chain as RLP encoded blocks, to bootstrap new verifiers",
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name:  "l2-rpc",
+				Usage: "L2 execution engine RPC URL to export the blocks from",
+			},
+			cli.Uint64Flag{
+				Name:  "start",
+				Usage: "First block number to export",
+				Value: 1,
+			},
+			cli.Uint64Flag{
+				Name:  "end",
+				Usage: "Last block number to export. Defaults to the finalized block, which is also the maximum.",
+			},
+			cli.StringFlag{
+				Name:  "out",
+				Usage: "Path to the output file, gzip compressed if it ends with .gz. The segment description is written to <out>.json.",
+			},
+		},
+		Action: func(ctx *cli.Context) error {
+			l := log.Root()
+			l2, err := ethclient.Dial(ctx.String("l2-rpc"))
+			if err != nil {
+				return fmt.Errorf("cannot dial %s: %w", ctx.String("l2-rpc"), err)
+			}
+			defer l2.Close()
+
+			chainID, err := l2.ChainID(context.Background())
+			if err != nil {
+				return fmt.Errorf("failed to fetch L2 chain ID: %w", err)
+			}
+			finalized, err := l2.HeaderByNumber(context.Background(), big.NewInt(rpc.FinalizedBlockNumber.Int64()))
+			if err != nil {
+				return fmt.Errorf("failed to fetch finalized L2 block: %w", err)
+			}
+			end := ctx.Uint64("end")
+			if end == 0 {
+				end = finalized.Number.Uint64()
+			} else if end > finalized.Number.Uint64() {
+				// the importing engine marks the segment as finalized, so it must not be reorged.
+				return fmt.Errorf("end block %d is after the finalized block %d", end, finalized.Number.Uint64())
+			}
+
+			out := ctx.String("out")
+			if out == "" {
+				return errors.New("must provide an output file")
+			}
+			seg, err := exportToFile(l, l2, out, ctx.Uint64("start"), end)
+			if err != nil {
+				return err
+			}
+			seg.ChainID = chainID
+			if err := writeSegment(out+".json", seg); err != nil {
+				return err
+			}
+			l.Info("Exported L2 chain segment", "first", seg.First, "last", seg.Last, "count", seg.Count, "out", out)
+			return nil
+		},
+	},
+	{
+		Name:  "import",
+		Usage: "Imports an exported segment of the canonical L2 chain into a fresh L2 execution engine",
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name:  "l2",
+				Usage: "Address of L2 Engine JSON-RPC endpoint to use (engine and eth namespace required)",
+			},
+			cli.StringFlag{
+				Name:  "l2.jwt-secret",
+				Usage: "Path to JWT secret key. Keys are 32 bytes, hex encoded in a file.",
+			},
+			cli.StringFlag{
+				Name:  "in",
+				Usage: "Path to the exported file, gzip compressed if it ends with .gz. The segment description is read from <in>.json if it exists.",
+			},
+		},
+		Action: func(ctx *cli.Context) error {
+			l := log.Root()
+			secret, err := readJWTSecret(ctx.String("l2.jwt-secret"))
+			if err != nil {
+				return err
+			}
+			auth := rpc.WithHTTPAuth(gn.NewJWTAuth(secret))
+			rpcClient, err := client.NewRPC(context.Background(), l, ctx.String("l2"), client.WithGethRPCOptions(auth))
+			if err != nil {
+				return fmt.Errorf("cannot dial %s: %w", ctx.String("l2"), err)
+			}
+			defer rpcClient.Close()
+
+			in := ctx.String("in")
+			if in == "" {
+				return errors.New("must provide an input file")
+			}
+			seg, err := readSegment(in + ".json")
+			if err != nil {
+				return err
+			}
+			if seg != nil {
+				var chainID hexutil.Big
+				if err := rpcClient.CallContext(context.Background(), &chainID, "eth_chainId"); err != nil {
+					return fmt.Errorf("failed to fetch L2 chain ID: %w", err)
+				}
+				if seg.ChainID != nil && seg.ChainID.Cmp((*big.Int)(&chainID)) != 0 {
+					return fmt.Errorf("segment of chain %s cannot be imported into chain %s", seg.ChainID, (*big.Int)(&chainID))
+				}
+			} else {
+				l.Warn("No segment description found, the imported blocks are not checked for completeness", "path", in+".json")
+			}
+
+			last, err := importFromFile(l, &engineClient{rpc: rpcClient}, in, seg)
+			if err != nil {
+				return err
+			}
+			l.Info("Imported L2 chain segment, the rollup node can be started now", "last", last)
+			return nil
+		},
+	},
+}
+
+func exportToFile(l log.Logger, src BlockSource, path string, start, end uint64) (*Segment, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create output file: %w", err)
+	}
+	defer f.Close()
+
+	if !strings.HasSuffix(path, ".gz") {
+		return Export(context.Background(), l, src, f, start, end)
+	}
+	gw := gzip.NewWriter(f)
+	seg, err := Export(context.Background(), l, src, gw, start, end)
+	if err != nil {
+		return nil, err
+	}
+	if err := gw.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write output file: %w", err)
+	}
+	return seg, nil
+}
+
+func importFromFile(l log.Logger, engine Engine, path string, seg *Segment) (eth.BlockID, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return eth.BlockID{}, fmt.Errorf("failed to open input file: %w", err)
+	}
+	defer f.Close()
+
+	var r io.Reader = f
+	if strings.HasSuffix(path, ".gz") {
+		gr, err := gzip.NewReader(f)
+		if err != nil {
+			return eth.BlockID{}, fmt.Errorf("failed to read input file: %w", err)
+		}
+		defer gr.Close()
+		r = gr
+	}
+	return Import(context.Background(), l, engine, r, seg)
+}
+
+func writeSegment(path string, seg *Segment) error {
+	data, err := json.MarshalIndent(seg, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// readSegment returns the segment description at the path, or nil if it does not exist.
+func readSegment(path string) (*Segment, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read segment description: %w", err)
+	}
+	var seg Segment
+	if err := json.Unmarshal(data, &seg); err != nil {
+		return nil, fmt.Errorf("failed to decode segment description: %w", err)
+	}
+	return &seg, nil
+}
+
+func readJWTSecret(path string) ([32]byte, error) {
+	var secret [32]byte
+	if path == "" {
+		return secret, errors.New("must provide the JWT secret of the L2 engine")
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return secret, fmt.Errorf("failed to read JWT secret: %w", err)
+	}
+	jwtSecret := common.FromHex(strings.TrimSpace(string(data)))
+	if len(jwtSecret) != 32 {
+		return secret, fmt.Errorf("invalid jwt secret in path %s, not 32 hex-formatted bytes", path)
+	}
+	copy(secret[:], jwtSecret)
+	return secret, nil
+}
+
+// engineClient is a minimal engine API client, the import does not need the caches of sources.EngineClient.
+type engineClient struct {
+	rpc client.RPC
+}
+
+func (e *engineClient) NewPayload(ctx context.Context, payload *eth.ExecutionPayload) (*eth.PayloadStatusV1, error) {
+	var result eth.PayloadStatusV1
+	if err := e.rpc.CallContext(ctx, &result, "engine_newPayloadV1", payload); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (e *engineClient) ForkchoiceUpdate(ctx context.Context, fc *eth.ForkchoiceState, attributes *eth.PayloadAttributes) (*eth.ForkchoiceUpdatedResult, error) {
+	var result eth.ForkchoiceUpdatedResult
+	if err := e.rpc.CallContext(ctx, &result, "engine_forkchoiceUpdatedV1", fc, attributes); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
@@ -0,0 +1,154 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+
+	"github.com/kroma-network/kroma/components/node/eth"
+)
+
+// importBatchSize is the number of imported blocks after which the forkchoice of the engine is updated.
+const importBatchSize = 1024
+
+// Segment describes an exported segment of the canonical L2 chain.
+// It is written next to the exported blocks, so that an import can be checked for completeness.
+type Segment struct {
+	ChainID *big.Int `json:"chainId"`
+	// Parent is the block the segment is built on top of, it must be known to the importing engine.
+	Parent eth.BlockID `json:"parent"`
+	First  eth.BlockID `json:"first"`
+	Last   eth.BlockID `json:"last"`
+	Count  uint64      `json:"count"`
+}
+
+type BlockSource interface {
+	BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error)
+}
+
+type Engine interface {
+	NewPayload(ctx context.Context, payload *eth.ExecutionPayload) (*eth.PayloadStatusV1, error)
+	ForkchoiceUpdate(ctx context.Context, fc *eth.ForkchoiceState, attributes *eth.PayloadAttributes) (*eth.ForkchoiceUpdatedResult, error)
+}
+
+// Export writes the blocks from start to end (inclusive) as a stream of RLP encoded blocks,
+// the same format as the export of geth. The receipts are not exported, they are re-computed
+// by the importing engine and checked against the receipts root of every block header.
+func Export(ctx context.Context, l log.Logger, src BlockSource, w io.Writer, start, end uint64) (*Segment, error) {
+	if start == 0 {
+		return nil, errors.New("genesis block cannot be exported, the engine is initialized with it")
+	}
+	if start > end {
+		return nil, fmt.Errorf("start block %d is after end block %d", start, end)
+	}
+	seg := &Segment{}
+	var prev *types.Block
+	for n := start; n <= end; n++ {
+		block, err := src.BlockByNumber(ctx, new(big.Int).SetUint64(n))
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch block %d: %w", n, err)
+		}
+		// the chain may reorg while exporting, which would make the segment unusable.
+		if prev != nil && block.ParentHash() != prev.Hash() {
+			return nil, fmt.Errorf("block %s does not build on top of exported block %s", eth.ToBlockID(block), eth.ToBlockID(prev))
+		}
+		if err := rlp.Encode(w, block); err != nil {
+			return nil, fmt.Errorf("failed to write block %d: %w", n, err)
+		}
+		if prev == nil {
+			seg.Parent = eth.BlockID{Hash: block.ParentHash(), Number: n - 1}
+			seg.First = eth.ToBlockID(block)
+		}
+		seg.Last = eth.ToBlockID(block)
+		seg.Count++
+		prev = block
+		if seg.Count%importBatchSize == 0 {
+			l.Info("Exporting blocks", "last", seg.Last, "remaining", end-n)
+		}
+	}
+	return seg, nil
+}
+
+// Import executes the exported blocks on the engine and marks the last block as the head,
+// safe and finalized block, so that the rollup node continues to derive the chain from there.
+// The blocks must be exported up to the finalized block, as they are not derived again.
+// If seg is not nil, the imported blocks must match it.
+func Import(ctx context.Context, l log.Logger, engine Engine, r io.Reader, seg *Segment) (eth.BlockID, error) {
+	stream := rlp.NewStream(r, 0)
+	var (
+		last  eth.BlockID
+		count uint64
+	)
+	for {
+		var block types.Block
+		if err := stream.Decode(&block); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return eth.BlockID{}, fmt.Errorf("failed to read block %d of the segment: %w", count, err)
+		}
+		if count == 0 && seg != nil && block.ParentHash() != seg.Parent.Hash {
+			return eth.BlockID{}, fmt.Errorf("first block %s does not build on top of segment parent %s", eth.ToBlockID(&block), seg.Parent)
+		}
+		if count > 0 && block.ParentHash() != last.Hash {
+			return eth.BlockID{}, fmt.Errorf("block %s does not build on top of imported block %s", eth.ToBlockID(&block), last)
+		}
+
+		payload, err := eth.BlockAsPayload(&block)
+		if err != nil {
+			return eth.BlockID{}, fmt.Errorf("failed to convert block %s to payload: %w", eth.ToBlockID(&block), err)
+		}
+		status, err := engine.NewPayload(ctx, payload)
+		if err != nil {
+			return eth.BlockID{}, fmt.Errorf("failed to import block %s: %w", payload.ID(), err)
+		}
+		switch status.Status {
+		case eth.ExecutionValid:
+		case eth.ExecutionSyncing, eth.ExecutionAccepted:
+			return eth.BlockID{}, fmt.Errorf("parent of block %s is unknown to the engine", payload.ID())
+		default:
+			return eth.BlockID{}, eth.NewPayloadErr(payload, status)
+		}
+		last = payload.ID()
+		count++
+
+		if count%importBatchSize == 0 {
+			if err := updateForkchoice(ctx, engine, last); err != nil {
+				return eth.BlockID{}, err
+			}
+			l.Info("Imported blocks", "last", last, "count", count)
+		}
+	}
+
+	if count == 0 {
+		return eth.BlockID{}, errors.New("no blocks to import")
+	}
+	if seg != nil && (last != seg.Last || count != seg.Count) {
+		return eth.BlockID{}, fmt.Errorf("imported %d blocks up to %s, but the segment has %d blocks up to %s", count, last, seg.Count, seg.Last)
+	}
+	if err := updateForkchoice(ctx, engine, last); err != nil {
+		return eth.BlockID{}, err
+	}
+	return last, nil
+}
+
+func updateForkchoice(ctx context.Context, engine Engine, head eth.BlockID) error {
+	fc := &eth.ForkchoiceState{
+		HeadBlockHash:      head.Hash,
+		SafeBlockHash:      head.Hash,
+		FinalizedBlockHash: head.Hash,
+	}
+	res, err := engine.ForkchoiceUpdate(ctx, fc, nil)
+	if err != nil {
+		return fmt.Errorf("failed to update forkchoice to %s: %w", head, err)
+	}
+	if err := eth.ForkchoiceUpdateErr(res.PayloadStatus); err != nil {
+		return fmt.Errorf("failed to update forkchoice to %s: %w", head, err)
+	}
+	return nil
+}
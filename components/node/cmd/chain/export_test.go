@@ -0,0 +1,124 @@
+package chain
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kroma-network/kroma/components/node/eth"
+	"github.com/kroma-network/kroma/components/node/testlog"
+)
+
+type fakeBlockSource struct {
+	blocks []*types.Block
+}
+
+func newFakeBlockSource(n int) *fakeBlockSource {
+	src := &fakeBlockSource{}
+	parent := common.Hash{0xaa}
+	for i := 0; i < n; i++ {
+		block := types.NewBlockWithHeader(&types.Header{
+			ParentHash: parent,
+			Number:     big.NewInt(int64(i)),
+			BaseFee:    big.NewInt(7),
+			Time:       uint64(i) * 2,
+		})
+		src.blocks = append(src.blocks, block)
+		parent = block.Hash()
+	}
+	return src
+}
+
+func (s *fakeBlockSource) BlockByNumber(_ context.Context, number *big.Int) (*types.Block, error) {
+	if number.Uint64() >= uint64(len(s.blocks)) {
+		return nil, errors.New("not found")
+	}
+	return s.blocks[number.Uint64()], nil
+}
+
+type fakeEngine struct {
+	payloads []*eth.ExecutionPayload
+	fc       *eth.ForkchoiceState
+	status   eth.ExecutePayloadStatus
+}
+
+func (e *fakeEngine) NewPayload(_ context.Context, payload *eth.ExecutionPayload) (*eth.PayloadStatusV1, error) {
+	e.payloads = append(e.payloads, payload)
+	return &eth.PayloadStatusV1{Status: e.status}, nil
+}
+
+func (e *fakeEngine) ForkchoiceUpdate(_ context.Context, fc *eth.ForkchoiceState, _ *eth.PayloadAttributes) (*eth.ForkchoiceUpdatedResult, error) {
+	e.fc = fc
+	return &eth.ForkchoiceUpdatedResult{PayloadStatus: eth.PayloadStatusV1{Status: eth.ExecutionValid}}, nil
+}
+
+func TestExportImport(t *testing.T) {
+	l := testlog.Logger(t, log.LvlCrit)
+	src := newFakeBlockSource(10)
+
+	var buf bytes.Buffer
+	seg, err := Export(context.Background(), l, src, &buf, 3, 7)
+	require.NoError(t, err)
+	require.Equal(t, eth.ToBlockID(src.blocks[2]), seg.Parent)
+	require.Equal(t, eth.ToBlockID(src.blocks[3]), seg.First)
+	require.Equal(t, eth.ToBlockID(src.blocks[7]), seg.Last)
+	require.Equal(t, uint64(5), seg.Count)
+
+	engine := &fakeEngine{status: eth.ExecutionValid}
+	last, err := Import(context.Background(), l, engine, bytes.NewReader(buf.Bytes()), seg)
+	require.NoError(t, err)
+	require.Equal(t, seg.Last, last)
+	require.Len(t, engine.payloads, 5)
+	for i, payload := range engine.payloads {
+		require.Equal(t, src.blocks[3+i].Hash(), payload.BlockHash)
+	}
+	require.Equal(t, &eth.ForkchoiceState{
+		HeadBlockHash:      last.Hash,
+		SafeBlockHash:      last.Hash,
+		FinalizedBlockHash: last.Hash,
+	}, engine.fc)
+}
+
+func TestExportGenesis(t *testing.T) {
+	_, err := Export(context.Background(), testlog.Logger(t, log.LvlCrit), newFakeBlockSource(3), &bytes.Buffer{}, 0, 2)
+	require.Error(t, err)
+}
+
+func TestImportIncompleteSegment(t *testing.T) {
+	l := testlog.Logger(t, log.LvlCrit)
+	src := newFakeBlockSource(10)
+
+	var buf bytes.Buffer
+	seg, err := Export(context.Background(), l, src, &buf, 1, 9)
+	require.NoError(t, err)
+
+	// cut off the last block
+	var truncated bytes.Buffer
+	_, err = Export(context.Background(), l, src, &truncated, 1, 8)
+	require.NoError(t, err)
+
+	engine := &fakeEngine{status: eth.ExecutionValid}
+	_, err = Import(context.Background(), l, engine, &truncated, seg)
+	require.ErrorContains(t, err, "but the segment has 9 blocks")
+	require.Nil(t, engine.fc, "incomplete segment must not be marked as finalized")
+}
+
+func TestImportUnknownParent(t *testing.T) {
+	l := testlog.Logger(t, log.LvlCrit)
+
+	var buf bytes.Buffer
+	_, err := Export(context.Background(), l, newFakeBlockSource(5), &buf, 2, 4)
+	require.NoError(t, err)
+
+	engine := &fakeEngine{status: eth.ExecutionSyncing}
+	_, err = Import(context.Background(), l, engine, &buf, nil)
+	require.ErrorContains(t, err, "unknown to the engine")
+	require.Nil(t, engine.fc)
+}
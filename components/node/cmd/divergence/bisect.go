@@ -0,0 +1,204 @@
+package divergence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/kroma-network/kroma/components/node/eth"
+	"github.com/kroma-network/kroma/components/node/rollup/derive"
+)
+
+var ErrNoDivergence = errors.New("the nodes agree on all blocks in the range")
+
+type RollupSource interface {
+	OutputAtBlock(ctx context.Context, blockNum uint64) (*eth.OutputResponse, error)
+}
+
+type BlockSource interface {
+	BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error)
+}
+
+// Node is a rollup node to compare, with its L2 execution engine to compare the block inputs of, if not nil.
+type Node struct {
+	Rollup RollupSource
+	L2     BlockSource
+}
+
+// Report describes the first L2 block the nodes derived differently.
+type Report struct {
+	// LastAgreed is the last block both nodes agree on, nil if they diverge at the start of the range already.
+	LastAgreed *eth.L2BlockRef `json:"lastAgreed"`
+	// FirstDivergent is the number of the first block the nodes disagree on.
+	FirstDivergent uint64          `json:"firstDivergent"`
+	A              *eth.L2BlockRef `json:"a"`
+	B              *eth.L2BlockRef `json:"b"`
+	// Differences are the differing derivation inputs and outputs of the first divergent block.
+	Differences []Difference `json:"differences"`
+}
+
+type Difference struct {
+	Field string `json:"field"`
+	A     string `json:"a"`
+	B     string `json:"b"`
+}
+
+// Bisect binary searches the first block in [from, to] that the nodes disagree on.
+// The blocks are compared by hash, which commits to the whole chain before it, so once the nodes
+// disagree on a block they disagree on all the blocks after it. The output roots are not compared,
+// as an output root of the V1 format also commits to the next block.
+func Bisect(ctx context.Context, l log.Logger, a, b Node, from, to uint64) (*Report, error) {
+	if from > to {
+		return nil, fmt.Errorf("start block %d is after the end block %d", from, to)
+	}
+	agree := func(number uint64) (bool, *eth.OutputResponse, *eth.OutputResponse, error) {
+		outA, err := a.Rollup.OutputAtBlock(ctx, number)
+		if err != nil {
+			return false, nil, nil, fmt.Errorf("failed to fetch output of block %d from node A: %w", number, err)
+		}
+		outB, err := b.Rollup.OutputAtBlock(ctx, number)
+		if err != nil {
+			return false, nil, nil, fmt.Errorf("failed to fetch output of block %d from node B: %w", number, err)
+		}
+		return outA.BlockRef.Hash == outB.BlockRef.Hash, outA, outB, nil
+	}
+
+	ok, _, _, err := agree(to)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		return nil, ErrNoDivergence
+	}
+	report := &Report{}
+	ok, lastAgreed, _, err := agree(from)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		// the nodes agree on lo, and disagree on hi
+		lo, hi := from, to
+		for hi-lo > 1 {
+			mid := lo + (hi-lo)/2
+			ok, out, _, err := agree(mid)
+			if err != nil {
+				return nil, err
+			}
+			l.Debug("Compared block", "number", mid, "agree", ok)
+			if ok {
+				lo, lastAgreed = mid, out
+			} else {
+				hi = mid
+			}
+		}
+		from = hi
+		report.LastAgreed = &lastAgreed.BlockRef
+	} else {
+		l.Warn("Nodes disagree on the start block already", "number", from)
+	}
+
+	_, outA, outB, err := agree(from)
+	if err != nil {
+		return nil, err
+	}
+	report.FirstDivergent = from
+	report.A, report.B = &outA.BlockRef, &outB.BlockRef
+	report.Differences = diffOutputs(outA, outB)
+	if a.L2 != nil && b.L2 != nil {
+		blockA, err := a.L2.BlockByNumber(ctx, new(big.Int).SetUint64(from))
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch block %d from L2 engine A: %w", from, err)
+		}
+		blockB, err := b.L2.BlockByNumber(ctx, new(big.Int).SetUint64(from))
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch block %d from L2 engine B: %w", from, err)
+		}
+		report.Differences = append(report.Differences, diffBlocks(blockA, blockB)...)
+	}
+	return report, nil
+}
+
+// differences collects the fields that differ, formatted with %v.
+type differences []Difference
+
+func (d *differences) add(field string, a, b any) {
+	if sa, sb := fmt.Sprintf("%v", a), fmt.Sprintf("%v", b); sa != sb {
+		*d = append(*d, Difference{Field: field, A: sa, B: sb})
+	}
+}
+
+func diffOutputs(a, b *eth.OutputResponse) []Difference {
+	var d differences
+	d.add("blockRef.hash", a.BlockRef.Hash, b.BlockRef.Hash)
+	d.add("blockRef.parentHash", a.BlockRef.ParentHash, b.BlockRef.ParentHash)
+	d.add("blockRef.timestamp", a.BlockRef.Time, b.BlockRef.Time)
+	d.add("blockRef.l1origin", a.BlockRef.L1Origin, b.BlockRef.L1Origin)
+	d.add("blockRef.sequenceNumber", a.BlockRef.SequenceNumber, b.BlockRef.SequenceNumber)
+	d.add("output.version", a.Version, b.Version)
+	d.add("output.outputRoot", a.OutputRoot, b.OutputRoot)
+	d.add("output.stateRoot", a.StateRoot, b.StateRoot)
+	d.add("output.withdrawalStorageRoot", a.WithdrawalStorageRoot, b.WithdrawalStorageRoot)
+	return d
+}
+
+// diffBlocks compares the payload attributes the blocks were built from: the L1 info deposit,
+// the user deposits and the sequenced transactions, and the attributes of the header.
+func diffBlocks(a, b *types.Block) []Difference {
+	var d differences
+	d.add("header.timestamp", a.Time(), b.Time())
+	d.add("header.prevRandao", a.MixDigest(), b.MixDigest())
+	d.add("header.feeRecipient", a.Coinbase(), b.Coinbase())
+	d.add("header.gasLimit", a.GasLimit(), b.GasLimit())
+	d.add("header.baseFee", a.BaseFee(), b.BaseFee())
+	d.add("header.extraData", common.Bytes2Hex(a.Extra()), common.Bytes2Hex(b.Extra()))
+	d.add("header.gasUsed", a.GasUsed(), b.GasUsed())
+	d.add("header.stateRoot", a.Root(), b.Root())
+	d.add("header.receiptsRoot", a.ReceiptHash(), b.ReceiptHash())
+
+	infoA, depositsA, txsA := splitTransactions(a)
+	infoB, depositsB, txsB := splitTransactions(b)
+	d.add("l1Info.number", infoA.Number, infoB.Number)
+	d.add("l1Info.time", infoA.Time, infoB.Time)
+	d.add("l1Info.baseFee", infoA.BaseFee, infoB.BaseFee)
+	d.add("l1Info.blockHash", infoA.BlockHash, infoB.BlockHash)
+	d.add("l1Info.sequenceNumber", infoA.SequenceNumber, infoB.SequenceNumber)
+	d.add("l1Info.batcherAddr", infoA.BatcherAddr, infoB.BatcherAddr)
+	d.add("l1Info.l1FeeOverhead", infoA.L1FeeOverhead, infoB.L1FeeOverhead)
+	d.add("l1Info.l1FeeScalar", infoA.L1FeeScalar, infoB.L1FeeScalar)
+	d.add("l1Info.validatorRewardRatio", infoA.ValidatorRewardRatio, infoB.ValidatorRewardRatio)
+	diffHashes(&d, "deposits", depositsA, depositsB)
+	diffHashes(&d, "transactions", txsA, txsB)
+	return d
+}
+
+// splitTransactions splits the transactions of a block into its L1 info, and the hashes of
+// the user deposits and the sequenced transactions. The L1 info is empty if it cannot be decoded.
+func splitTransactions(block *types.Block) (info derive.L1BlockInfo, deposits []common.Hash, txs []common.Hash) {
+	for i, tx := range block.Transactions() {
+		switch {
+		case i == 0 && tx.IsDepositTx():
+			info, _ = derive.L1InfoDepositTxData(tx.Data())
+		case tx.IsDepositTx():
+			deposits = append(deposits, tx.Hash())
+		default:
+			txs = append(txs, tx.Hash())
+		}
+	}
+	return info, deposits, txs
+}
+
+// diffHashes adds the count of the lists if it differs, and the first differing position.
+func diffHashes(d *differences, field string, a, b []common.Hash) {
+	d.add(field+".count", len(a), len(b))
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] {
+			d.add(fmt.Sprintf("%s[%d]", field, i), a[i], b[i])
+			return
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package divergence
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kroma-network/kroma/components/node/eth"
+	"github.com/kroma-network/kroma/components/node/rollup/derive"
+	"github.com/kroma-network/kroma/components/node/testlog"
+)
+
+type fakeNode struct {
+	blocks []*types.Block
+	calls  int
+}
+
+func (n *fakeNode) OutputAtBlock(_ context.Context, blockNum uint64) (*eth.OutputResponse, error) {
+	n.calls++
+	block := n.blocks[blockNum]
+	return &eth.OutputResponse{
+		BlockRef:   eth.L2BlockRef{Hash: block.Hash(), Number: blockNum, ParentHash: block.ParentHash(), Time: block.Time()},
+		OutputRoot: eth.Bytes32(block.Hash()),
+		StateRoot:  block.Root(),
+	}, nil
+}
+
+func (n *fakeNode) BlockByNumber(_ context.Context, number *big.Int) (*types.Block, error) {
+	return n.blocks[number.Uint64()], nil
+}
+
+func l1InfoTx(t *testing.T, l1Number uint64) *types.Transaction {
+	l1Info := eth.HeaderBlockInfo(&types.Header{Number: new(big.Int).SetUint64(l1Number), BaseFee: big.NewInt(7)})
+	dep, err := derive.L1InfoDeposit(0, l1Info, eth.SystemConfig{})
+	require.NoError(t, err)
+	return types.NewTx(dep)
+}
+
+// newFakeChain builds a chain of the given length, with the block at the given number built from the given transactions.
+func newFakeChain(t *testing.T, length uint64, divergeAt uint64, l1Number uint64, txs ...*types.Transaction) *fakeNode {
+	n := &fakeNode{}
+	parent := common.Hash{}
+	for i := uint64(0); i < length; i++ {
+		blockTxs := types.Transactions{l1InfoTx(t, i)}
+		if i == divergeAt {
+			blockTxs = append(types.Transactions{l1InfoTx(t, l1Number)}, txs...)
+		}
+		block := types.NewBlock(&types.Header{
+			ParentHash: parent,
+			Number:     new(big.Int).SetUint64(i),
+			Time:       i * 2,
+			BaseFee:    big.NewInt(1),
+		}, blockTxs, nil, nil, trie.NewStackTrie(nil))
+		n.blocks = append(n.blocks, block)
+		parent = block.Hash()
+	}
+	return n
+}
+
+func TestBisect(t *testing.T) {
+	l := testlog.Logger(t, log.LvlCrit)
+	ctx := context.Background()
+	tx := types.NewTransaction(0, common.Address{0x01}, big.NewInt(1), 21000, big.NewInt(1), nil)
+
+	a := newFakeChain(t, 1000, 617, 617, tx)
+	b := newFakeChain(t, 1000, 617, 618, tx)
+	report, err := Bisect(ctx, l, Node{Rollup: a, L2: a}, Node{Rollup: b, L2: b}, 0, 999)
+	require.NoError(t, err)
+	require.Equal(t, uint64(617), report.FirstDivergent)
+	require.Equal(t, a.blocks[616].Hash(), report.LastAgreed.Hash)
+	require.Equal(t, a.blocks[617].Hash(), report.A.Hash)
+	require.Equal(t, b.blocks[617].Hash(), report.B.Hash)
+	require.LessOrEqual(t, a.calls, 14, "expected a binary search")
+
+	fields := make(map[string]Difference)
+	for _, d := range report.Differences {
+		fields[d.Field] = d
+	}
+	require.Contains(t, fields, "blockRef.hash")
+	require.Contains(t, fields, "l1Info.blockHash", "expected the L2 blocks to be compared")
+	require.Equal(t, Difference{Field: "l1Info.number", A: "617", B: "618"}, fields["l1Info.number"])
+	require.NotContains(t, fields, "blockRef.parentHash")
+	require.NotContains(t, fields, "transactions.count")
+	require.NotContains(t, fields, "transactions[0]")
+
+	// the sequenced transactions differ
+	b = newFakeChain(t, 1000, 20, 20, tx)
+	report, err = Bisect(ctx, l, Node{Rollup: a}, Node{Rollup: b, L2: b}, 10, 999)
+	require.NoError(t, err)
+	require.Equal(t, uint64(20), report.FirstDivergent)
+	for _, d := range report.Differences {
+		require.NotContains(t, d.Field, "header.", "expected the L2 blocks to be compared only if both are known")
+	}
+	report, err = Bisect(ctx, l, Node{Rollup: a, L2: a}, Node{Rollup: b, L2: b}, 10, 999)
+	require.NoError(t, err)
+	require.Contains(t, report.Differences, Difference{Field: "transactions.count", A: "0", B: "1"})
+	for _, d := range report.Differences {
+		require.NotContains(t, d.Field, "l1Info.")
+	}
+
+	// the nodes disagree on the start block already
+	report, err = Bisect(ctx, l, Node{Rollup: a}, Node{Rollup: b}, 20, 999)
+	require.NoError(t, err)
+	require.Nil(t, report.LastAgreed)
+	require.Equal(t, uint64(20), report.FirstDivergent)
+
+	_, err = Bisect(ctx, l, Node{Rollup: a}, Node{Rollup: b}, 0, 19)
+	require.ErrorIs(t, err, ErrNoDivergence)
+}
@@ -0,0 +1,140 @@
+package divergence
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/urfave/cli"
+
+	"github.com/kroma-network/kroma/components/node/client"
+	"github.com/kroma-network/kroma/components/node/sources"
+)
+
+var Command = cli.Command{
+	Name:  "bisect-divergence",
+	Usage: "Finds the first L2 block two rollup nodes derived differently, and dumps its differing derivation inputs as JSON",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "a.rollup-rpc",
+			Usage: "Rollup node RPC URL of node A",
+		},
+		cli.StringFlag{
+			Name:  "b.rollup-rpc",
+			Usage: "Rollup node RPC URL of node B",
+		},
+		cli.StringFlag{
+			Name:  "a.l2-rpc",
+			Usage: "Optional L2 execution engine RPC URL of node A, to compare the transactions and header of the divergent block",
+		},
+		cli.StringFlag{
+			Name:  "b.l2-rpc",
+			Usage: "Optional L2 execution engine RPC URL of node B, to compare the transactions and header of the divergent block",
+		},
+		cli.Uint64Flag{
+			Name:  "from",
+			Usage: "First L2 block number to compare. Defaults to the L2 genesis block.",
+		},
+		cli.Uint64Flag{
+			Name:  "to",
+			Usage: "Last L2 block number to compare. Defaults to the lower safe L2 block of the nodes.",
+		},
+		cli.StringFlag{
+			Name:  "out",
+			Usage: "Path to the output file. Defaults to the standard output.",
+		},
+	},
+	Action: func(ctx *cli.Context) error {
+		l := log.Root()
+		a, rollupA, closeA, err := dialNode(ctx.String("a.rollup-rpc"), ctx.String("a.l2-rpc"))
+		if err != nil {
+			return fmt.Errorf("node A: %w", err)
+		}
+		defer closeA()
+		b, rollupB, closeB, err := dialNode(ctx.String("b.rollup-rpc"), ctx.String("b.l2-rpc"))
+		if err != nil {
+			return fmt.Errorf("node B: %w", err)
+		}
+		defer closeB()
+
+		from := ctx.Uint64("from")
+		if !ctx.IsSet("from") {
+			cfg, err := rollupA.RollupConfig(context.Background())
+			if err != nil {
+				return fmt.Errorf("failed to fetch rollup config of node A: %w", err)
+			}
+			from = cfg.Genesis.L2.Number
+		}
+		to := ctx.Uint64("to")
+		if !ctx.IsSet("to") {
+			statusA, err := rollupA.SyncStatus(context.Background())
+			if err != nil {
+				return fmt.Errorf("failed to fetch sync status of node A: %w", err)
+			}
+			statusB, err := rollupB.SyncStatus(context.Background())
+			if err != nil {
+				return fmt.Errorf("failed to fetch sync status of node B: %w", err)
+			}
+			to = statusA.SafeL2.Number
+			if statusB.SafeL2.Number < to {
+				to = statusB.SafeL2.Number
+			}
+		}
+
+		report, err := Bisect(context.Background(), l, a, b, from, to)
+		if errors.Is(err, ErrNoDivergence) {
+			l.Info("Nodes agree on all blocks", "from", from, "to", to)
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		var out io.Writer = os.Stdout
+		if path := ctx.String("out"); path != "" {
+			f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+			if err != nil {
+				return fmt.Errorf("failed to create output file: %w", err)
+			}
+			defer f.Close()
+			out = f
+		}
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(report); err != nil {
+			return fmt.Errorf("failed to write report: %w", err)
+		}
+		l.Warn("Found divergent block", "number", report.FirstDivergent, "a", report.A, "b", report.B, "differences", len(report.Differences))
+		return nil
+	},
+}
+
+func dialNode(rollupRPC string, l2RPC string) (Node, *sources.RollupClient, func(), error) {
+	if rollupRPC == "" {
+		return Node{}, nil, nil, errors.New("must provide a rollup node RPC URL")
+	}
+	rollupClient, err := rpc.DialContext(context.Background(), rollupRPC)
+	if err != nil {
+		return Node{}, nil, nil, fmt.Errorf("cannot dial %s: %w", rollupRPC, err)
+	}
+	rollup := sources.NewRollupClient(client.NewBaseRPCClient(rollupClient))
+	n := Node{Rollup: rollup}
+	if l2RPC == "" {
+		return n, rollup, rollupClient.Close, nil
+	}
+	l2, err := ethclient.Dial(l2RPC)
+	if err != nil {
+		rollupClient.Close()
+		return Node{}, nil, nil, fmt.Errorf("cannot dial %s: %w", l2RPC, err)
+	}
+	n.L2 = l2
+	return n, rollup, func() {
+		l2.Close()
+		rollupClient.Close()
+	}, nil
+}
@@ -3,10 +3,8 @@ package main
 import (
 	"context"
 	"fmt"
-	"net"
 	"os"
 	"os/signal"
-	"strconv"
 	"syscall"
 
 	"github.com/ethereum/go-ethereum/log"
@@ -14,16 +12,21 @@ import (
 
 	knode "github.com/kroma-network/kroma/components/node"
 	"github.com/kroma-network/kroma/components/node/chaincfg"
+	"github.com/kroma-network/kroma/components/node/cmd/chain"
+	"github.com/kroma-network/kroma/components/node/cmd/divergence"
 	"github.com/kroma-network/kroma/components/node/cmd/doc"
 	"github.com/kroma-network/kroma/components/node/cmd/genesis"
 	"github.com/kroma-network/kroma/components/node/cmd/p2p"
+	"github.com/kroma-network/kroma/components/node/cmd/rollback"
+	"github.com/kroma-network/kroma/components/node/cmd/rpcproxy"
+	"github.com/kroma-network/kroma/components/node/cmd/witness"
 	"github.com/kroma-network/kroma/components/node/flags"
 	"github.com/kroma-network/kroma/components/node/heartbeat"
 	"github.com/kroma-network/kroma/components/node/metrics"
 	"github.com/kroma-network/kroma/components/node/node"
 	"github.com/kroma-network/kroma/components/node/version"
+	"github.com/kroma-network/kroma/utils/monitoring"
 	klog "github.com/kroma-network/kroma/utils/service/log"
-	kpprof "github.com/kroma-network/kroma/utils/service/pprof"
 )
 
 var (
@@ -67,6 +70,17 @@ func main() {
 			Name:        "doc",
 			Subcommands: doc.Subcommands,
 		},
+		{
+			Name:        "chain",
+			Subcommands: chain.Subcommands,
+		},
+		{
+			Name:        "witness",
+			Subcommands: witness.Subcommands,
+		},
+		divergence.Command,
+		rollback.Command,
+		rpcproxy.Command,
 	}
 
 	err := app.Run(os.Args)
@@ -144,15 +158,10 @@ func RollupNodeMain(ctx *cli.Context) error {
 		defer beatCtxCancel()
 	}
 
-	if cfg.Pprof.Enabled {
-		pprofCtx, pprofCancel := context.WithCancel(context.Background())
-		go func() {
-			log.Info("pprof server started", "addr", net.JoinHostPort(cfg.Pprof.ListenAddr, strconv.Itoa(cfg.Pprof.ListenPort)))
-			if err := kpprof.ListenAndServe(pprofCtx, cfg.Pprof.ListenAddr, cfg.Pprof.ListenPort); err != nil {
-				log.Error("error starting pprof", "err", err)
-			}
-		}()
-		defer pprofCancel()
+	pprofCtx, pprofCancel := context.WithCancel(context.Background())
+	defer pprofCancel()
+	if err := monitoring.MaybeStartPprof(pprofCtx, cfg.Pprof, log); err != nil {
+		return err
 	}
 
 	interruptChannel := make(chan os.Signal, 1)
@@ -162,7 +171,11 @@ func RollupNodeMain(ctx *cli.Context) error {
 		syscall.SIGTERM,
 		syscall.SIGQUIT,
 	}...)
-	<-interruptChannel
+	select {
+	case <-interruptChannel:
+	case <-n.Halted():
+		return node.ErrUnsupportedProtocolVersion
+	}
 
 	return nil
 }
@@ -0,0 +1,115 @@
+package rollback
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/log"
+	gn "github.com/ethereum/go-ethereum/node"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/urfave/cli"
+
+	knode "github.com/kroma-network/kroma/components/node"
+	"github.com/kroma-network/kroma/components/node/eth"
+)
+
+var Command = cli.Command{
+	Name:  "rollback",
+	Usage: "Rolls back the L2 execution engine to a block before a corrupted segment, to re-derive the chain from its L1 origin. The rollup node must be stopped, and the rollup config is read from --network or --rollup.config.",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "l2",
+			Usage: "Address of L2 Engine JSON-RPC endpoint to use (engine and eth namespace required)",
+		},
+		cli.StringFlag{
+			Name:  "l2.jwt-secret",
+			Usage: "Path to JWT secret key. Keys are 32 bytes, hex encoded in a file.",
+		},
+		cli.StringFlag{
+			Name:  "l1",
+			Usage: "Optional L1 RPC URL, to check that the L1 origin of the target block is canonical",
+		},
+		cli.Uint64Flag{
+			Name:     "to-block",
+			Usage:    "Number of the L2 block to roll back to",
+			Required: true,
+		},
+		cli.BoolFlag{
+			Name:  "allow-finalized",
+			Usage: "Allow rolling back past the finalized block",
+		},
+	},
+	Action: func(ctx *cli.Context) error {
+		l := log.Root()
+		cfg, err := knode.NewRollupConfig(ctx)
+		if err != nil {
+			return err
+		}
+
+		secret, err := readJWTSecret(ctx.String("l2.jwt-secret"))
+		if err != nil {
+			return err
+		}
+		rpcClient, err := rpc.DialOptions(context.Background(), ctx.String("l2"), rpc.WithHTTPAuth(gn.NewJWTAuth(secret)))
+		if err != nil {
+			return fmt.Errorf("cannot dial %s: %w", ctx.String("l2"), err)
+		}
+		defer rpcClient.Close()
+
+		var l1 L1Source
+		if url := ctx.String("l1"); url != "" {
+			l1Client, err := ethclient.Dial(url)
+			if err != nil {
+				return fmt.Errorf("cannot dial %s: %w", url, err)
+			}
+			defer l1Client.Close()
+			l1 = l1Client
+		}
+
+		target, err := Rollback(context.Background(), l, &engineClient{rpc: rpcClient}, ethclient.NewClient(rpcClient), l1, cfg, Options{
+			To:             ctx.Uint64("to-block"),
+			AllowFinalized: ctx.Bool("allow-finalized"),
+		})
+		if err != nil {
+			return err
+		}
+		l.Info("Rolled back L2 engine, the rollup node re-derives the chain from the L1 origin of the block once started",
+			"block", target, "l1Origin", target.L1Origin)
+		return nil
+	},
+}
+
+func readJWTSecret(path string) ([32]byte, error) {
+	var secret [32]byte
+	if path == "" {
+		return secret, errors.New("must provide the JWT secret of the L2 engine")
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return secret, fmt.Errorf("failed to read JWT secret: %w", err)
+	}
+	jwtSecret := common.FromHex(strings.TrimSpace(string(data)))
+	if len(jwtSecret) != 32 {
+		return secret, fmt.Errorf("invalid jwt secret in path %s, not 32 hex-formatted bytes", path)
+	}
+	copy(secret[:], jwtSecret)
+	return secret, nil
+}
+
+// engineClient is a minimal engine API client, the rollback only updates the forkchoice.
+type engineClient struct {
+	rpc *rpc.Client
+}
+
+func (e *engineClient) ForkchoiceUpdate(ctx context.Context, fc *eth.ForkchoiceState, attributes *eth.PayloadAttributes) (*eth.ForkchoiceUpdatedResult, error) {
+	var result eth.ForkchoiceUpdatedResult
+	if err := e.rpc.CallContext(ctx, &result, "engine_forkchoiceUpdatedV1", fc, attributes); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
@@ -0,0 +1,104 @@
+package rollback
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/kroma-network/kroma/components/node/eth"
+	"github.com/kroma-network/kroma/components/node/rollup"
+	"github.com/kroma-network/kroma/components/node/rollup/derive"
+)
+
+// ErrFinalized is returned when rolling back past the finalized block, which is not allowed by default.
+var ErrFinalized = errors.New("target block is before the finalized block")
+
+type Engine interface {
+	ForkchoiceUpdate(ctx context.Context, fc *eth.ForkchoiceState, attributes *eth.PayloadAttributes) (*eth.ForkchoiceUpdatedResult, error)
+}
+
+type BlockSource interface {
+	BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error)
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+}
+
+type L1Source interface {
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+}
+
+// Options configures a rollback.
+type Options struct {
+	// To is the number of the L2 block the engine is rolled back to.
+	To uint64
+	// AllowFinalized allows rolling back past the finalized block, e.g. if the corrupted segment was finalized.
+	AllowFinalized bool
+}
+
+// Rollback rewinds the engine to the L2 block, by making it the unsafe, safe and, if it is before the finalized
+// block, finalized head. The L1 origin of the block is checked to be canonical on L1 if l1 is not nil, since the
+// derivation restarts from it: on the next start, the rollup node finds the L2 heads of the engine and re-derives
+// the chain from the L1 origin of the safe head. The rollup node must be stopped during the rollback.
+func Rollback(ctx context.Context, l log.Logger, engine Engine, l2 BlockSource, l1 L1Source, cfg *rollup.Config, opts Options) (eth.L2BlockRef, error) {
+	block, err := l2.BlockByNumber(ctx, new(big.Int).SetUint64(opts.To))
+	if err != nil {
+		return eth.L2BlockRef{}, fmt.Errorf("failed to fetch L2 block %d: %w", opts.To, err)
+	}
+	target, err := derive.L2BlockToBlockRef(block, &cfg.Genesis)
+	if err != nil {
+		return eth.L2BlockRef{}, fmt.Errorf("failed to read L1 origin of L2 block %d: %w", opts.To, err)
+	}
+
+	if l1 != nil {
+		origin, err := l1.HeaderByNumber(ctx, new(big.Int).SetUint64(target.L1Origin.Number))
+		if err != nil {
+			return eth.L2BlockRef{}, fmt.Errorf("failed to fetch L1 origin %s: %w", target.L1Origin, err)
+		}
+		if origin.Hash() != target.L1Origin.Hash {
+			return eth.L2BlockRef{}, fmt.Errorf("L1 origin %s of L2 block %s is not canonical on L1 anymore, roll back to an earlier block",
+				target.L1Origin, target.ID())
+		}
+	} else {
+		l.Warn("No L1 RPC given, the L1 origin of the target block is not checked to be canonical", "l1Origin", target.L1Origin)
+	}
+
+	finalized, err := l2.HeaderByNumber(ctx, big.NewInt(rpc.FinalizedBlockNumber.Int64()))
+	if err != nil {
+		return eth.L2BlockRef{}, fmt.Errorf("failed to fetch finalized L2 block: %w", err)
+	}
+	finalizedHash := finalized.Hash()
+	if finalized.Number.Uint64() > target.Number {
+		if !opts.AllowFinalized {
+			return eth.L2BlockRef{}, fmt.Errorf("%w %d", ErrFinalized, finalized.Number.Uint64())
+		}
+		l.Warn("Rolling back past the finalized block", "finalized", finalized.Number, "target", target.Number)
+		finalizedHash = target.Hash
+	}
+
+	fc := &eth.ForkchoiceState{
+		HeadBlockHash:      target.Hash,
+		SafeBlockHash:      target.Hash,
+		FinalizedBlockHash: finalizedHash,
+	}
+	res, err := engine.ForkchoiceUpdate(ctx, fc, nil)
+	if err != nil {
+		return eth.L2BlockRef{}, fmt.Errorf("failed to update forkchoice to %s: %w", target, err)
+	}
+	if err := eth.ForkchoiceUpdateErr(res.PayloadStatus); err != nil {
+		return eth.L2BlockRef{}, fmt.Errorf("failed to update forkchoice to %s: %w", target, err)
+	}
+
+	// the engine may ignore a forkchoice update to an ancestor of its head, so the new head is checked.
+	head, err := l2.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return eth.L2BlockRef{}, fmt.Errorf("failed to fetch L2 head: %w", err)
+	}
+	if head.Hash() != target.Hash {
+		return eth.L2BlockRef{}, fmt.Errorf("engine did not roll back to %s, its head is %d (%s)", target, head.Number, head.Hash())
+	}
+	return target, nil
+}
@@ -0,0 +1,155 @@
+package rollback
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kroma-network/kroma/components/node/eth"
+	"github.com/kroma-network/kroma/components/node/rollup"
+	"github.com/kroma-network/kroma/components/node/rollup/derive"
+	"github.com/kroma-network/kroma/components/node/testlog"
+	"github.com/kroma-network/kroma/components/node/testutils"
+)
+
+type fakeL1 struct {
+	headers []*types.Header
+}
+
+func (s *fakeL1) HeaderByNumber(_ context.Context, number *big.Int) (*types.Header, error) {
+	if number.Uint64() >= uint64(len(s.headers)) {
+		return nil, errors.New("not found")
+	}
+	return s.headers[number.Uint64()], nil
+}
+
+// fakeChain is an L2 engine with a chain of blocks, each with the L1 block of the same number as L1 origin.
+type fakeChain struct {
+	blocks    []*types.Block
+	head      int
+	finalized int
+	fc        *eth.ForkchoiceState
+}
+
+func newFakeChain(t *testing.T, n int) (*fakeChain, *fakeL1, *rollup.Config) {
+	l1 := &fakeL1{}
+	chain := &fakeChain{head: n - 1}
+	parent := common.Hash{0xaa}
+	for i := 0; i < n; i++ {
+		l1Header := &types.Header{Number: big.NewInt(int64(i)), Time: uint64(i) * 12, BaseFee: big.NewInt(7)}
+		l1.headers = append(l1.headers, l1Header)
+
+		var txs []*types.Transaction
+		if i > 0 {
+			info := testutils.NewMockBlockInfoWithHeader(l1Header)
+			dep, err := derive.L1InfoDeposit(0, &info, eth.SystemConfig{})
+			require.NoError(t, err)
+			txs = append(txs, types.NewTx(dep))
+		}
+		block := types.NewBlockWithHeader(&types.Header{
+			ParentHash: parent,
+			Number:     big.NewInt(int64(i)),
+			Time:       uint64(i) * 2,
+		}).WithBody(txs, nil)
+		chain.blocks = append(chain.blocks, block)
+		parent = block.Hash()
+	}
+	cfg := &rollup.Config{Genesis: rollup.Genesis{
+		L1: eth.BlockID{Hash: l1.headers[0].Hash(), Number: l1.headers[0].Number.Uint64()},
+		L2: eth.ToBlockID(chain.blocks[0]),
+	}}
+	return chain, l1, cfg
+}
+
+func (c *fakeChain) BlockByNumber(_ context.Context, number *big.Int) (*types.Block, error) {
+	if number.Uint64() >= uint64(len(c.blocks)) {
+		return nil, errors.New("not found")
+	}
+	return c.blocks[number.Uint64()], nil
+}
+
+func (c *fakeChain) HeaderByNumber(_ context.Context, number *big.Int) (*types.Header, error) {
+	switch {
+	case number == nil:
+		return c.blocks[c.head].Header(), nil
+	case number.Int64() == rpc.FinalizedBlockNumber.Int64():
+		return c.blocks[c.finalized].Header(), nil
+	}
+	return c.blocks[number.Uint64()].Header(), nil
+}
+
+func (c *fakeChain) ForkchoiceUpdate(_ context.Context, fc *eth.ForkchoiceState, _ *eth.PayloadAttributes) (*eth.ForkchoiceUpdatedResult, error) {
+	c.fc = fc
+	for i, block := range c.blocks {
+		if block.Hash() == fc.HeadBlockHash {
+			c.head = i
+		}
+	}
+	return &eth.ForkchoiceUpdatedResult{PayloadStatus: eth.PayloadStatusV1{Status: eth.ExecutionValid}}, nil
+}
+
+func TestRollback(t *testing.T) {
+	l := testlog.Logger(t, log.LvlCrit)
+	chain, l1, cfg := newFakeChain(t, 10)
+	chain.finalized = 2
+
+	target, err := Rollback(context.Background(), l, chain, chain, l1, cfg, Options{To: 5})
+	require.NoError(t, err)
+	require.Equal(t, chain.blocks[5].Hash(), target.Hash)
+	require.Equal(t, eth.BlockID{Hash: l1.headers[5].Hash(), Number: l1.headers[5].Number.Uint64()}, target.L1Origin)
+	require.Equal(t, chain.blocks[5].Hash(), chain.fc.HeadBlockHash)
+	require.Equal(t, chain.blocks[5].Hash(), chain.fc.SafeBlockHash)
+	require.Equal(t, chain.blocks[2].Hash(), chain.fc.FinalizedBlockHash, "expected the finalized block to be kept")
+	require.Equal(t, 5, chain.head)
+
+	// the genesis block has the L1 genesis as origin.
+	target, err = Rollback(context.Background(), l, chain, chain, nil, cfg, Options{To: 0, AllowFinalized: true})
+	require.NoError(t, err)
+	require.Equal(t, cfg.Genesis.L1, target.L1Origin)
+}
+
+func TestRollbackFinalized(t *testing.T) {
+	l := testlog.Logger(t, log.LvlCrit)
+	chain, l1, cfg := newFakeChain(t, 10)
+	chain.finalized = 8
+
+	_, err := Rollback(context.Background(), l, chain, chain, l1, cfg, Options{To: 5})
+	require.ErrorIs(t, err, ErrFinalized)
+	require.Nil(t, chain.fc)
+
+	_, err = Rollback(context.Background(), l, chain, chain, l1, cfg, Options{To: 5, AllowFinalized: true})
+	require.NoError(t, err)
+	require.Equal(t, chain.blocks[5].Hash(), chain.fc.FinalizedBlockHash)
+}
+
+func TestRollbackReorgedL1Origin(t *testing.T) {
+	l := testlog.Logger(t, log.LvlCrit)
+	chain, l1, cfg := newFakeChain(t, 10)
+	l1.headers[5] = &types.Header{Number: big.NewInt(5), Time: 61, BaseFee: big.NewInt(7)}
+
+	_, err := Rollback(context.Background(), l, chain, chain, l1, cfg, Options{To: 5})
+	require.ErrorContains(t, err, "not canonical")
+	require.Nil(t, chain.fc)
+}
+
+func TestRollbackIgnoredByEngine(t *testing.T) {
+	l := testlog.Logger(t, log.LvlCrit)
+	chain, l1, cfg := newFakeChain(t, 10)
+	engine := &ignoringEngine{}
+
+	_, err := Rollback(context.Background(), l, engine, chain, l1, cfg, Options{To: 5})
+	require.ErrorContains(t, err, "did not roll back")
+}
+
+type ignoringEngine struct{}
+
+func (e *ignoringEngine) ForkchoiceUpdate(_ context.Context, _ *eth.ForkchoiceState, _ *eth.PayloadAttributes) (*eth.ForkchoiceUpdatedResult, error) {
+	return &eth.ForkchoiceUpdatedResult{PayloadStatus: eth.PayloadStatusV1{Status: eth.ExecutionValid}}, nil
+}
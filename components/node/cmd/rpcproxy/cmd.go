@@ -0,0 +1,132 @@
+package rpcproxy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/urfave/cli"
+
+	kservice "github.com/kroma-network/kroma/utils/service"
+	"github.com/kroma-network/kroma/utils/service/httputil"
+	kmetrics "github.com/kroma-network/kroma/utils/service/metrics"
+)
+
+const envVarPrefix = "NODE_RPCPROXY"
+
+func prefixEnvVar(name string) string {
+	return kservice.PrefixEnvVar(envVarPrefix, name)
+}
+
+var Command = cli.Command{
+	Name:  "rpcproxy",
+	Usage: "Serves a JSON-RPC endpoint balancing the reads across L2 replicas and routing the transactions to the sequencer",
+	Flags: append([]cli.Flag{
+		cli.StringFlag{
+			Name:   "rpc.addr",
+			Usage:  "Proxy listening address",
+			Value:  "127.0.0.1",
+			EnvVar: prefixEnvVar("RPC_ADDR"),
+		},
+		cli.IntFlag{
+			Name:   "rpc.port",
+			Usage:  "Proxy listening port",
+			Value:  8545,
+			EnvVar: prefixEnvVar("RPC_PORT"),
+		},
+		cli.StringFlag{
+			Name:   "sequencer-rpc",
+			Usage:  "L2 RPC URL of the sequencer, serving the transactions, and the reads while no replica is healthy",
+			EnvVar: prefixEnvVar("SEQUENCER_RPC"),
+		},
+		cli.StringSliceFlag{
+			Name:   "replica-rpcs",
+			Usage:  "L2 RPC URLs of the replicas the reads are balanced across",
+			EnvVar: prefixEnvVar("REPLICA_RPCS"),
+		},
+		cli.Uint64Flag{
+			Name:   "max-head-lag",
+			Usage:  "Number of blocks a replica may be behind the highest head of the backends to serve reads",
+			Value:  5,
+			EnvVar: prefixEnvVar("MAX_HEAD_LAG"),
+		},
+		cli.DurationFlag{
+			Name:   "health-check-interval",
+			Usage:  "Interval of the head height checks of the backends",
+			Value:  5 * time.Second,
+			EnvVar: prefixEnvVar("HEALTH_CHECK_INTERVAL"),
+		},
+		cli.DurationFlag{
+			Name:   "request-timeout",
+			Usage:  "Timeout of a request forwarded to a backend",
+			Value:  10 * time.Second,
+			EnvVar: prefixEnvVar("REQUEST_TIMEOUT"),
+		},
+		cli.StringSliceFlag{
+			Name: "allowed-methods",
+			Usage: "Methods forwarded to the backends, by name, or by namespace if ending with \"_\". " +
+				"Defaults to the eth_, net_, web3_ and kroma_ namespaces, except the methods signing with the keys of the backend",
+			EnvVar: prefixEnvVar("ALLOWED_METHODS"),
+		},
+	}, kmetrics.CLIFlags(envVarPrefix)...),
+	Action: func(ctx *cli.Context) error {
+		cfg := Config{
+			Sequencer:           ctx.String("sequencer-rpc"),
+			Replicas:            ctx.StringSlice("replica-rpcs"),
+			MaxHeadLag:          ctx.Uint64("max-head-lag"),
+			HealthCheckInterval: ctx.Duration("health-check-interval"),
+			RequestTimeout:      ctx.Duration("request-timeout"),
+			AllowedMethods:      ctx.StringSlice("allowed-methods"),
+		}
+		metricsCfg := kmetrics.ReadLocalCLIConfig(ctx)
+		if err := metricsCfg.Check(); err != nil {
+			return fmt.Errorf("invalid metrics config: %w", err)
+		}
+		l := log.Root()
+		m := NewMetrics()
+		proxy, err := NewProxy(l, m, cfg)
+		if err != nil {
+			return err
+		}
+		addr := net.JoinHostPort(ctx.String("rpc.addr"), strconv.Itoa(ctx.Int("rpc.port")))
+
+		return kservice.CloseAction(func(ctx context.Context, shutdown <-chan struct{}) error {
+			var wg sync.WaitGroup
+			defer wg.Wait()
+
+			if metricsCfg.Enabled {
+				l.Info("starting metrics server", "addr", metricsCfg.ListenAddr, "port", metricsCfg.ListenPort)
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					if err := m.Serve(ctx, metricsCfg.ListenAddr, metricsCfg.ListenPort); err != nil {
+						l.Error("error starting metrics server", "err", err)
+					}
+				}()
+			}
+
+			proxy.Start(ctx, &wg)
+			mux := http.NewServeMux()
+			mux.Handle("/", proxy)
+			mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+				if !proxy.Healthy() {
+					http.Error(w, ErrNoBackend.Error(), http.StatusServiceUnavailable)
+					return
+				}
+				_, _ = w.Write([]byte("OK"))
+			})
+			l.Info("starting RPC proxy", "addr", addr, "sequencer", cfg.Sequencer, "replicas", len(cfg.Replicas))
+			err := httputil.ListenAndServeContext(ctx, &http.Server{Addr: addr, Handler: mux})
+			if errors.Is(err, context.Canceled) {
+				return nil
+			}
+			return err
+		})
+	},
+}
@@ -0,0 +1,77 @@
+package rpcproxy
+
+import (
+	"context"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	kmetrics "github.com/kroma-network/kroma/utils/service/metrics"
+)
+
+const Namespace = "kroma_rpcproxy"
+
+// Metrics are the per-backend health and traffic metrics of the proxy.
+type Metrics struct {
+	registry *prometheus.Registry
+
+	backendUp       *prometheus.GaugeVec
+	backendHead     *prometheus.GaugeVec
+	backendLag      *prometheus.GaugeVec
+	backendRequests *prometheus.CounterVec
+	backendErrors   *prometheus.CounterVec
+}
+
+func NewMetrics() *Metrics {
+	registry := kmetrics.NewRegistry()
+	factory := kmetrics.With(registry)
+	return &Metrics{
+		registry: registry,
+		backendUp: factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "backend_up",
+			Help:      "1 if the backend is healthy and served requests, 0 otherwise",
+		}, []string{"backend"}),
+		backendHead: factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "backend_head",
+			Help:      "Latest block number of the backend at the last health check",
+		}, []string{"backend"}),
+		backendLag: factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "backend_head_lag",
+			Help:      "Number of blocks the backend is behind the highest head of the backends",
+		}, []string{"backend"}),
+		backendRequests: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "backend_requests_total",
+			Help:      "Number of requests forwarded to the backend, by route",
+		}, []string{"backend", "route"}),
+		backendErrors: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "backend_errors_total",
+			Help:      "Number of forwarded requests and health checks the backend failed",
+		}, []string{"backend"}),
+	}
+}
+
+func (m *Metrics) Serve(ctx context.Context, host string, port int) error {
+	return kmetrics.ListenAndServe(ctx, m.registry, host, port)
+}
+
+func (m *Metrics) RecordBackendHealth(backend string, up bool, head uint64, lag uint64) {
+	if up {
+		m.backendUp.WithLabelValues(backend).Set(1)
+	} else {
+		m.backendUp.WithLabelValues(backend).Set(0)
+	}
+	m.backendHead.WithLabelValues(backend).Set(float64(head))
+	m.backendLag.WithLabelValues(backend).Set(float64(lag))
+}
+
+func (m *Metrics) RecordBackendRequest(backend string, route string) {
+	m.backendRequests.WithLabelValues(backend, route).Inc()
+}
+
+func (m *Metrics) RecordBackendError(backend string) {
+	m.backendErrors.WithLabelValues(backend).Inc()
+}
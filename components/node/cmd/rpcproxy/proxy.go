@@ -0,0 +1,423 @@
+package rpcproxy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+const (
+	routeRead  = "read"
+	routeWrite = "write"
+
+	// maxRequestBodySize is the size limit of the JSON-RPC requests, batches included.
+	maxRequestBodySize = 5 * 1024 * 1024
+)
+
+// writeMethods are the methods submitting transactions, which are always routed to the sequencer,
+// so that the transactions are not held in the pool of a replica.
+var writeMethods = map[string]bool{
+	"eth_sendRawTransaction": true,
+	"eth_sendTransaction":    true,
+}
+
+// DefaultAllowedMethods are the methods served by default: the public namespaces of the L2 RPC, except the
+// signingMethods.
+var DefaultAllowedMethods = []string{"eth_", "net_", "web3_", "kroma_"}
+
+// signingMethods are the methods signing with the keys of the backend, only served if listed by name in the allowed
+// methods.
+var signingMethods = map[string]bool{
+	"eth_sendTransaction":  true,
+	"eth_sign":             true,
+	"eth_signTransaction":  true,
+	"eth_signTypedData":    true,
+	"eth_signTypedData_v4": true,
+}
+
+var ErrNoBackend = errors.New("no backend available")
+
+type Config struct {
+	// Sequencer is the RPC URL of the sequencer, serving the writes, and the reads while no replica is healthy.
+	Sequencer string
+	// Replicas are the RPC URLs of the replicas the reads are balanced across.
+	Replicas []string
+	// MaxHeadLag is the number of blocks a replica may be behind the highest head of the backends to serve reads.
+	MaxHeadLag uint64
+	// HealthCheckInterval is the interval of the head height checks of the backends.
+	HealthCheckInterval time.Duration
+	// RequestTimeout is the timeout of a request forwarded to a backend.
+	RequestTimeout time.Duration
+	// AllowedMethods are the methods forwarded to the backends, by name, or by namespace if ending with "_", e.g.
+	// "eth_". The other methods are rejected. Empty for the DefaultAllowedMethods.
+	AllowedMethods []string
+}
+
+func (c *Config) Check() error {
+	if c.Sequencer == "" {
+		return errors.New("sequencer RPC is required")
+	}
+	if c.HealthCheckInterval == 0 {
+		return errors.New("health check interval must be set")
+	}
+	if c.RequestTimeout == 0 {
+		return errors.New("request timeout must be set")
+	}
+	return nil
+}
+
+type backend struct {
+	name string
+	url  string
+
+	mu      sync.Mutex
+	healthy bool
+	head    uint64
+}
+
+func (b *backend) isHealthy() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.healthy
+}
+
+func (b *backend) setHealthy(healthy bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.healthy = healthy
+}
+
+// Proxy fronts a sequencer and its replicas with a single JSON-RPC HTTP endpoint.
+// The reads are balanced round-robin across the replicas whose head is within MaxHeadLag blocks of the highest head
+// of the backends, and retried on the next one if a replica fails. The writes are routed to the sequencer.
+type Proxy struct {
+	log  log.Logger
+	metr *Metrics
+	cfg  Config
+
+	client    *http.Client
+	allowed   []string
+	sequencer *backend
+	replicas  []*backend
+	next      atomic.Uint64
+}
+
+func NewProxy(l log.Logger, m *Metrics, cfg Config) (*Proxy, error) {
+	if err := cfg.Check(); err != nil {
+		return nil, err
+	}
+	p := &Proxy{
+		log:       l,
+		metr:      m,
+		cfg:       cfg,
+		client:    &http.Client{Timeout: cfg.RequestTimeout},
+		allowed:   cfg.AllowedMethods,
+		sequencer: &backend{name: "sequencer", url: cfg.Sequencer},
+	}
+	if len(p.allowed) == 0 {
+		p.allowed = DefaultAllowedMethods
+	}
+	for i, url := range cfg.Replicas {
+		p.replicas = append(p.replicas, &backend{name: fmt.Sprintf("replica-%d", i), url: url})
+	}
+	return p, nil
+}
+
+// Start checks the health of the backends, and rechecks it in the background every HealthCheckInterval.
+func (p *Proxy) Start(ctx context.Context, wg *sync.WaitGroup) {
+	p.CheckHealth(ctx)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(p.cfg.HealthCheckInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				p.CheckHealth(ctx)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// CheckHealth fetches the head height of every backend. The sequencer is healthy if it responds,
+// and a replica if its head is at most MaxHeadLag blocks behind the highest head of the backends.
+func (p *Proxy) CheckHealth(ctx context.Context) {
+	backends := append([]*backend{p.sequencer}, p.replicas...)
+	heads := make([]uint64, len(backends))
+	errs := make([]error, len(backends))
+	var wg sync.WaitGroup
+	for i, b := range backends {
+		wg.Add(1)
+		go func(i int, b *backend) {
+			defer wg.Done()
+			heads[i], errs[i] = p.blockNumber(ctx, b)
+		}(i, b)
+	}
+	wg.Wait()
+
+	var highest uint64
+	for i := range backends {
+		if errs[i] == nil && heads[i] > highest {
+			highest = heads[i]
+		}
+	}
+	for i, b := range backends {
+		if errs[i] != nil {
+			p.log.Warn("backend failed health check", "backend", b.name, "err", errs[i])
+			p.metr.RecordBackendError(b.name)
+			heads[i] = 0
+		}
+		lag := highest - heads[i]
+		healthy := errs[i] == nil && (b == p.sequencer || lag <= p.cfg.MaxHeadLag)
+		if errs[i] == nil && !healthy {
+			p.log.Warn("replica head lags behind", "backend", b.name, "head", heads[i], "highest", highest, "max_lag", p.cfg.MaxHeadLag)
+		}
+
+		b.mu.Lock()
+		b.healthy = healthy
+		b.head = heads[i]
+		b.mu.Unlock()
+		p.metr.RecordBackendHealth(b.name, healthy, heads[i], lag)
+	}
+}
+
+func (p *Proxy) blockNumber(ctx context.Context, b *backend) (uint64, error) {
+	status, body, err := p.forward(ctx, b, []byte(`{"jsonrpc":"2.0","id":1,"method":"eth_blockNumber","params":[]}`))
+	if err != nil {
+		return 0, err
+	}
+	if status != http.StatusOK {
+		return 0, fmt.Errorf("unexpected status %d", status)
+	}
+	var res struct {
+		Result *hexutil.Uint64 `json:"result"`
+		Error  *rpcError       `json:"error"`
+	}
+	if err := json.Unmarshal(body, &res); err != nil {
+		return 0, fmt.Errorf("failed to decode response: %w", err)
+	}
+	if res.Error != nil {
+		return 0, fmt.Errorf("rpc error %d: %s", res.Error.Code, res.Error.Message)
+	}
+	if res.Result == nil {
+		return 0, errors.New("missing block number")
+	}
+	return uint64(*res.Result), nil
+}
+
+// Healthy returns whether a backend can serve the reads.
+func (p *Proxy) Healthy() bool {
+	if p.sequencer.isHealthy() {
+		return true
+	}
+	for _, b := range p.replicas {
+		if b.isHealthy() {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *Proxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "only POST is supported", http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxRequestBodySize))
+	if err != nil {
+		writeError(w, http.StatusRequestEntityTooLarge, -32600, "request too large")
+		return
+	}
+	req, err := parseRequest(body)
+	if errors.Is(err, errEmptyBatch) {
+		writeError(w, http.StatusBadRequest, -32600, "empty batch")
+		return
+	} else if err != nil {
+		writeError(w, http.StatusBadRequest, -32700, "parse error")
+		return
+	}
+
+	// a batch with a method not allowed is rejected as a whole, as its other requests are not forwarded
+	var blocked bool
+	for _, msg := range req.msgs {
+		if !p.isAllowed(msg.Method) {
+			p.log.Debug("rejected method not allowed", "method", msg.Method)
+			blocked = true
+		}
+	}
+	if blocked {
+		req.writeErrors(w, http.StatusForbidden, func(msg rpcMessage) rpcError {
+			if !p.isAllowed(msg.Method) {
+				return rpcError{Code: -32601, Message: fmt.Sprintf("method %s is not allowed", msg.Method)}
+			}
+			return rpcError{Code: -32600, Message: "batch includes a method that is not allowed"}
+		})
+		return
+	}
+
+	route, candidates := routeRead, p.readBackends()
+	for _, msg := range req.msgs {
+		if writeMethods[msg.Method] {
+			route, candidates = routeWrite, []*backend{p.sequencer}
+			break
+		}
+	}
+
+	for _, b := range candidates {
+		p.metr.RecordBackendRequest(b.name, route)
+		status, resBody, err := p.forward(r.Context(), b, body)
+		if err == nil && status < http.StatusInternalServerError {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(status)
+			_, _ = w.Write(resBody)
+			return
+		}
+		if err == nil {
+			err = fmt.Errorf("unexpected status %d", status)
+		}
+		p.metr.RecordBackendError(b.name)
+		if r.Context().Err() != nil {
+			return
+		}
+		p.log.Warn("backend failed request", "backend", b.name, "route", route, "err", err)
+		// the backend is back in rotation once it passes a health check again
+		if b != p.sequencer {
+			b.setHealthy(false)
+		}
+	}
+	req.writeErrors(w, http.StatusServiceUnavailable, func(rpcMessage) rpcError {
+		return rpcError{Code: -32603, Message: ErrNoBackend.Error()}
+	})
+}
+
+// isAllowed returns whether the method is forwarded to the backends.
+func (p *Proxy) isAllowed(method string) bool {
+	for _, allowed := range p.allowed {
+		if method == allowed {
+			return true
+		}
+		if strings.HasSuffix(allowed, "_") && strings.HasPrefix(method, allowed) && !signingMethods[method] {
+			return true
+		}
+	}
+	return false
+}
+
+// readBackends returns the healthy replicas, starting from the next one in rotation, followed by the sequencer.
+func (p *Proxy) readBackends() []*backend {
+	var healthy []*backend
+	for _, b := range p.replicas {
+		if b.isHealthy() {
+			healthy = append(healthy, b)
+		}
+	}
+	out := make([]*backend, 0, len(healthy)+1)
+	if len(healthy) > 0 {
+		start := int(p.next.Add(1) % uint64(len(healthy)))
+		out = append(out, healthy[start:]...)
+		out = append(out, healthy[:start]...)
+	}
+	return append(out, p.sequencer)
+}
+
+func (p *Proxy) forward(ctx context.Context, b *backend, body []byte) (int, []byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, b.url, bytes.NewReader(body))
+	if err != nil {
+		return 0, nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	res, err := p.client.Do(req)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer res.Body.Close()
+	resBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	return res.StatusCode, resBody, nil
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type rpcMessage struct {
+	ID     json.RawMessage `json:"id"`
+	Method string          `json:"method"`
+}
+
+type rpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Error   rpcError        `json:"error"`
+}
+
+var errEmptyBatch = errors.New("empty batch")
+
+// rpcRequest is a single or batch JSON-RPC request.
+type rpcRequest struct {
+	batch bool
+	msgs  []rpcMessage
+}
+
+// parseRequest parses a single or batch JSON-RPC request.
+func parseRequest(body []byte) (*rpcRequest, error) {
+	body = bytes.TrimSpace(body)
+	if len(body) > 0 && body[0] == '[' {
+		var batch []rpcMessage
+		if err := json.Unmarshal(body, &batch); err != nil {
+			return nil, err
+		}
+		if len(batch) == 0 {
+			return nil, errEmptyBatch
+		}
+		return &rpcRequest{batch: true, msgs: batch}, nil
+	}
+	var msg rpcMessage
+	if err := json.Unmarshal(body, &msg); err != nil {
+		return nil, err
+	}
+	return &rpcRequest{msgs: []rpcMessage{msg}}, nil
+}
+
+// writeErrors responds with the error of every request, in an array for a batch.
+func (r *rpcRequest) writeErrors(w http.ResponseWriter, status int, errFn func(msg rpcMessage) rpcError) {
+	responses := make([]rpcResponse, len(r.msgs))
+	for i, msg := range r.msgs {
+		id := msg.ID
+		if len(id) == 0 {
+			id = json.RawMessage("null")
+		}
+		responses[i] = rpcResponse{JSONRPC: "2.0", ID: id, Error: errFn(msg)}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if r.batch {
+		_ = json.NewEncoder(w).Encode(responses)
+	} else {
+		_ = json.NewEncoder(w).Encode(responses[0])
+	}
+}
+
+// writeError responds with an error not specific to a request, e.g. as the request cannot be parsed.
+func writeError(w http.ResponseWriter, status int, code int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(rpcResponse{JSONRPC: "2.0", ID: json.RawMessage("null"), Error: rpcError{Code: code, Message: message}})
+}
@@ -0,0 +1,256 @@
+package rpcproxy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kroma-network/kroma/components/node/testlog"
+)
+
+// fakeBackend is an L2 RPC reporting a configurable head, and recording the methods it served.
+type fakeBackend struct {
+	*httptest.Server
+
+	mu      sync.Mutex
+	head    uint64
+	down    bool
+	methods []string
+}
+
+func newFakeBackend(t *testing.T, head uint64) *fakeBackend {
+	b := &fakeBackend{head: head}
+	b.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		if b.down {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		req, err := parseRequest(body)
+		require.NoError(t, err)
+		if req.msgs[0].Method == "eth_blockNumber" {
+			_, _ = fmt.Fprintf(w, `{"jsonrpc":"2.0","id":1,"result":"0x%x"}`, b.head)
+			return
+		}
+		for _, msg := range req.msgs {
+			b.methods = append(b.methods, msg.Method)
+		}
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":"0x1"}`))
+	}))
+	t.Cleanup(b.Close)
+	return b
+}
+
+func (b *fakeBackend) set(head uint64, down bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.head, b.down = head, down
+}
+
+func (b *fakeBackend) served() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	methods := b.methods
+	b.methods = nil
+	return methods
+}
+
+func call(t *testing.T, p *Proxy, body string) int {
+	rec := httptest.NewRecorder()
+	p.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte(body))))
+	return rec.Code
+}
+
+func TestProxyRouting(t *testing.T) {
+	sequencer := newFakeBackend(t, 100)
+	replicaA := newFakeBackend(t, 100)
+	replicaB := newFakeBackend(t, 90)
+	p, err := NewProxy(testlog.Logger(t, log.LvlCrit), NewMetrics(), Config{
+		Sequencer:           sequencer.URL,
+		Replicas:            []string{replicaA.URL, replicaB.URL},
+		MaxHeadLag:          5,
+		HealthCheckInterval: time.Hour,
+		RequestTimeout:      time.Second,
+	})
+	require.NoError(t, err)
+	p.CheckHealth(context.Background())
+	require.True(t, p.replicas[0].isHealthy())
+	require.False(t, p.replicas[1].isHealthy(), "lagging replica")
+
+	read := `{"jsonrpc":"2.0","id":1,"method":"eth_getBalance","params":[]}`
+	for i := 0; i < 2; i++ {
+		require.Equal(t, http.StatusOK, call(t, p, read))
+	}
+	require.Equal(t, []string{"eth_getBalance", "eth_getBalance"}, replicaA.served())
+	require.Empty(t, replicaB.served())
+	require.Empty(t, sequencer.served())
+
+	// the reads are balanced once the replica caught up
+	replicaB.set(98, false)
+	p.CheckHealth(context.Background())
+	for i := 0; i < 2; i++ {
+		require.Equal(t, http.StatusOK, call(t, p, read))
+	}
+	require.Len(t, replicaA.served(), 1)
+	require.Len(t, replicaB.served(), 1)
+
+	t.Run("writes", func(t *testing.T) {
+		require.Equal(t, http.StatusOK, call(t, p, `{"jsonrpc":"2.0","id":1,"method":"eth_sendRawTransaction","params":["0x00"]}`))
+		require.Equal(t, []string{"eth_sendRawTransaction"}, sequencer.served())
+
+		batch := `[{"jsonrpc":"2.0","id":1,"method":"eth_chainId"},{"jsonrpc":"2.0","id":2,"method":"eth_sendRawTransaction","params":["0x00"]}]`
+		require.Equal(t, http.StatusOK, call(t, p, batch))
+		require.Equal(t, []string{"eth_chainId", "eth_sendRawTransaction"}, sequencer.served(), "batch with a write")
+		require.Empty(t, replicaA.served())
+		require.Empty(t, replicaB.served())
+	})
+
+	t.Run("failover", func(t *testing.T) {
+		replicaA.set(100, true)
+		for i := 0; i < 2; i++ {
+			require.Equal(t, http.StatusOK, call(t, p, read))
+		}
+		require.Len(t, replicaB.served(), 2)
+		require.False(t, p.replicas[0].isHealthy(), "failing replica is out of rotation")
+
+		replicaB.set(100, true)
+		require.Equal(t, http.StatusOK, call(t, p, read))
+		require.Equal(t, []string{"eth_getBalance"}, sequencer.served(), "sequencer serves reads without healthy replicas")
+
+		sequencer.set(100, true)
+		require.Equal(t, http.StatusServiceUnavailable, call(t, p, read))
+		p.CheckHealth(context.Background())
+		require.False(t, p.Healthy())
+
+		replicaA.set(100, false)
+		p.CheckHealth(context.Background())
+		require.True(t, p.Healthy())
+		require.Equal(t, http.StatusOK, call(t, p, read))
+		require.Len(t, replicaA.served(), 1)
+	})
+}
+
+func TestProxyInvalidRequest(t *testing.T) {
+	sequencer := newFakeBackend(t, 1)
+	p, err := NewProxy(testlog.Logger(t, log.LvlCrit), NewMetrics(), Config{
+		Sequencer:           sequencer.URL,
+		HealthCheckInterval: time.Hour,
+		RequestTimeout:      time.Second,
+	})
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	p.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte("{"))))
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	var res struct {
+		Error rpcError `json:"error"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+	require.Equal(t, -32700, res.Error.Code)
+
+	require.Equal(t, http.StatusBadRequest, call(t, p, "[]"))
+	rec = httptest.NewRecorder()
+	p.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	require.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	require.Empty(t, sequencer.served())
+
+	_, err = NewProxy(testlog.Logger(t, log.LvlCrit), NewMetrics(), Config{HealthCheckInterval: time.Hour, RequestTimeout: time.Second})
+	require.Error(t, err, "sequencer is required")
+}
+
+func TestProxyAllowedMethods(t *testing.T) {
+	sequencer := newFakeBackend(t, 1)
+	newProxy := func(allowed []string) *Proxy {
+		p, err := NewProxy(testlog.Logger(t, log.LvlCrit), NewMetrics(), Config{
+			Sequencer:           sequencer.URL,
+			HealthCheckInterval: time.Hour,
+			RequestTimeout:      time.Second,
+			AllowedMethods:      allowed,
+		})
+		require.NoError(t, err)
+		p.CheckHealth(context.Background())
+		return p
+	}
+
+	p := newProxy(nil)
+	for _, method := range []string{"eth_getBalance", "eth_sendRawTransaction", "net_version", "web3_clientVersion", "kroma_syncStatus"} {
+		require.Equal(t, http.StatusOK, call(t, p, `{"jsonrpc":"2.0","id":1,"method":"`+method+`"}`), method)
+	}
+	require.Len(t, sequencer.served(), 5)
+
+	for _, method := range []string{"admin_addPeer", "debug_traceTransaction", "personal_unlockAccount", "engine_forkchoiceUpdatedV1", "eth_sendTransaction", "eth_sign"} {
+		rec := httptest.NewRecorder()
+		p.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte(`{"jsonrpc":"2.0","id":7,"method":"`+method+`"}`))))
+		require.Equal(t, http.StatusForbidden, rec.Code, method)
+		var res rpcResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		require.Equal(t, json.RawMessage("7"), res.ID)
+		require.Equal(t, -32601, res.Error.Code)
+	}
+	require.Empty(t, sequencer.served(), "blocked methods are not forwarded")
+
+	// a batch with a blocked method is rejected as a whole, with an error per request
+	rec := httptest.NewRecorder()
+	batch := `[{"jsonrpc":"2.0","id":1,"method":"eth_chainId"},{"jsonrpc":"2.0","id":2,"method":"debug_traceTransaction"}]`
+	p.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte(batch))))
+	require.Equal(t, http.StatusForbidden, rec.Code)
+	var responses []rpcResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &responses))
+	require.Len(t, responses, 2)
+	require.Equal(t, json.RawMessage("1"), responses[0].ID)
+	require.Equal(t, -32600, responses[0].Error.Code)
+	require.Equal(t, json.RawMessage("2"), responses[1].ID)
+	require.Equal(t, -32601, responses[1].Error.Code)
+	require.Empty(t, sequencer.served())
+
+	// the allowed methods are configurable
+	p = newProxy([]string{"eth_chainId", "debug_", "eth_sendTransaction"})
+	for _, method := range []string{"eth_chainId", "debug_traceTransaction", "eth_sendTransaction"} {
+		require.Equal(t, http.StatusOK, call(t, p, `{"jsonrpc":"2.0","id":1,"method":"`+method+`"}`), method)
+	}
+	require.Equal(t, http.StatusForbidden, call(t, p, `{"jsonrpc":"2.0","id":1,"method":"eth_getBalance"}`))
+	require.Len(t, sequencer.served(), 3)
+}
+
+func TestProxyBatchFailure(t *testing.T) {
+	sequencer := newFakeBackend(t, 1)
+	p, err := NewProxy(testlog.Logger(t, log.LvlCrit), NewMetrics(), Config{
+		Sequencer:           sequencer.URL,
+		HealthCheckInterval: time.Hour,
+		RequestTimeout:      time.Second,
+	})
+	require.NoError(t, err)
+	sequencer.set(1, true)
+
+	rec := httptest.NewRecorder()
+	batch := `[{"jsonrpc":"2.0","id":1,"method":"eth_chainId"},{"jsonrpc":"2.0","id":"b","method":"eth_blockNumber"}]`
+	p.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte(batch))))
+	require.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	var responses []rpcResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &responses))
+	require.Len(t, responses, 2, "a failed batch gets an error per request")
+	require.Equal(t, json.RawMessage("1"), responses[0].ID)
+	require.Equal(t, json.RawMessage(`"b"`), responses[1].ID)
+	for _, res := range responses {
+		require.Equal(t, -32603, res.Error.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	p.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte(`{"jsonrpc":"2.0","id":3,"method":"eth_chainId"}`))))
+	require.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	var res rpcResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+	require.Equal(t, json.RawMessage("3"), res.ID)
+}
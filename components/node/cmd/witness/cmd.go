@@ -0,0 +1,121 @@
+package witness
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/urfave/cli"
+
+	"github.com/kroma-network/kroma/components/node/eth"
+)
+
+var Subcommands = cli.Commands{
+	{
+		Name:  "export",
+		Usage: "Exports the execution witness of an L2 block as JSON, for external provers",
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name:  "l2-rpc",
+				Usage: "L2 execution engine RPC URL to export the witness from (eth and debug namespace required)",
+			},
+			cli.Uint64Flag{
+				Name:  "block",
+				Usage: "Number of the L2 block to export the witness of",
+			},
+			cli.StringFlag{
+				Name:  "out",
+				Usage: "Path to the output file. Defaults to the standard output.",
+			},
+		},
+		Action: func(ctx *cli.Context) error {
+			l := log.Root()
+			rpcClient, err := rpc.DialContext(context.Background(), ctx.String("l2-rpc"))
+			if err != nil {
+				return fmt.Errorf("cannot dial %s: %w", ctx.String("l2-rpc"), err)
+			}
+			defer rpcClient.Close()
+			src := NewRPCSource(rpcClient)
+
+			if !ctx.IsSet("block") {
+				return errors.New("must provide a block number")
+			}
+			chainID, err := src.ChainID(context.Background())
+			if err != nil {
+				return fmt.Errorf("failed to fetch L2 chain ID: %w", err)
+			}
+			w, err := Export(context.Background(), src, ctx.Uint64("block"))
+			if err != nil {
+				return err
+			}
+			w.ChainID = chainID
+			// the witness is only as trustworthy as the RPC it was exported from, so it is checked before writing.
+			if err := w.Verify(); err != nil {
+				return fmt.Errorf("exported witness is invalid: %w", err)
+			}
+
+			var out io.Writer = os.Stdout
+			if path := ctx.String("out"); path != "" {
+				f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+				if err != nil {
+					return fmt.Errorf("failed to create output file: %w", err)
+				}
+				defer f.Close()
+				out = f
+			}
+			enc := json.NewEncoder(out)
+			enc.SetIndent("", "  ")
+			if err := enc.Encode(w); err != nil {
+				return fmt.Errorf("failed to write witness: %w", err)
+			}
+			l.Info("Exported execution witness", "number", w.Header.Number, "hash", w.Header.Hash(), "accounts", len(w.Accounts))
+			return nil
+		},
+	},
+}
+
+// RPCSource fetches the witness from an L2 execution engine with the debug namespace enabled.
+type RPCSource struct {
+	*ethclient.Client
+	rpc *rpc.Client
+}
+
+func NewRPCSource(rpcClient *rpc.Client) *RPCSource {
+	return &RPCSource{Client: ethclient.NewClient(rpcClient), rpc: rpcClient}
+}
+
+func (s *RPCSource) Prestate(ctx context.Context, blockHash common.Hash) ([]map[common.Address]*PrestateAccount, error) {
+	var results []struct {
+		Result map[common.Address]*PrestateAccount `json:"result"`
+		Error  string                              `json:"error"`
+	}
+	if err := s.rpc.CallContext(ctx, &results, "debug_traceBlockByHash", blockHash, map[string]any{"tracer": "prestateTracer"}); err != nil {
+		return nil, err
+	}
+	traces := make([]map[common.Address]*PrestateAccount, len(results))
+	for i, res := range results {
+		if res.Error != "" {
+			return nil, fmt.Errorf("failed to trace transaction %d: %s", i, res.Error)
+		}
+		traces[i] = res.Result
+	}
+	return traces, nil
+}
+
+func (s *RPCSource) GetProof(ctx context.Context, address common.Address, storage []common.Hash, blockHash common.Hash) (*eth.AccountResult, error) {
+	var result *eth.AccountResult
+	if err := s.rpc.CallContext(ctx, &result, "eth_getProof", address, storage, blockHash); err != nil {
+		return nil, err
+	}
+	if result == nil {
+		return nil, fmt.Errorf("proof of account %s not found", address)
+	}
+	return result, nil
+}
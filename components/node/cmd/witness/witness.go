@@ -0,0 +1,183 @@
+package witness
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/trie"
+
+	"github.com/kroma-network/kroma/components/node/eth"
+)
+
+// Version is the version of the witness format, it is bumped on any incompatible change.
+const Version = 1
+
+// Witness is the execution witness of an L2 block: the block input, and the pre-state that
+// executing the block reads or writes, proven against the state root of the parent block.
+type Witness struct {
+	Version uint64   `json:"version"`
+	ChainID *big.Int `json:"chainId"`
+	// Header is the header of the witnessed block, its state root is the post-state root.
+	Header *types.Header `json:"header"`
+	// Transactions are the binary encoded transactions of the block, in block order.
+	Transactions []hexutil.Bytes `json:"transactions"`
+	// ParentStateRoot is the pre-state root, that all the accounts are proven against.
+	ParentStateRoot common.Hash `json:"parentStateRoot"`
+	// Accounts are the accessed accounts, sorted by address.
+	Accounts []*Account `json:"accounts"`
+}
+
+// Account is an account accessed by the block, in the pre-state.
+type Account struct {
+	// Proof is the eth_getProof result of the account and its accessed storage slots, sorted by key.
+	Proof *eth.AccountResult `json:"proof"`
+	// Code is the code of the account, empty if it has no code.
+	Code hexutil.Bytes `json:"code,omitempty"`
+}
+
+// PrestateAccount is an account accessed by a transaction, as traced by the prestateTracer.
+type PrestateAccount struct {
+	Code    hexutil.Bytes               `json:"code,omitempty"`
+	Storage map[common.Hash]common.Hash `json:"storage,omitempty"`
+}
+
+type Source interface {
+	BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error)
+	// Prestate returns the accounts accessed by each transaction of the block, in block order.
+	Prestate(ctx context.Context, blockHash common.Hash) ([]map[common.Address]*PrestateAccount, error)
+	GetProof(ctx context.Context, address common.Address, storage []common.Hash, blockHash common.Hash) (*eth.AccountResult, error)
+}
+
+// feeAccounts are credited by the state transition outside the EVM, so they are not always traced.
+var feeAccounts = []common.Address{
+	params.KromaProtocolVault,
+	params.KromaProposerRewardVault,
+	params.KromaValidatorRewardVault,
+}
+
+// Export builds the execution witness of the block with the given number.
+// The witness is not verified, see Verify.
+func Export(ctx context.Context, src Source, number uint64) (*Witness, error) {
+	if number == 0 {
+		return nil, errors.New("genesis block has no execution witness")
+	}
+	block, err := src.BlockByNumber(ctx, new(big.Int).SetUint64(number))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch block %d: %w", number, err)
+	}
+	parent, err := src.BlockByNumber(ctx, new(big.Int).SetUint64(number-1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch parent block %d: %w", number-1, err)
+	}
+	// the chain may reorg between the two requests.
+	if block.ParentHash() != parent.Hash() {
+		return nil, fmt.Errorf("block %s does not build on top of block %s", eth.ToBlockID(block), eth.ToBlockID(parent))
+	}
+
+	traces, err := src.Prestate(ctx, block.Hash())
+	if err != nil {
+		return nil, fmt.Errorf("failed to trace prestate of block %s: %w", eth.ToBlockID(block), err)
+	}
+	if len(traces) != len(block.Transactions()) {
+		return nil, fmt.Errorf("got prestate of %d transactions, but block %s has %d", len(traces), eth.ToBlockID(block), len(block.Transactions()))
+	}
+
+	// the first transaction accessing an account sees it as in the pre-state of the block.
+	accessed := make(map[common.Address]map[common.Hash]struct{})
+	codes := make(map[common.Address]hexutil.Bytes)
+	access := func(addr common.Address) map[common.Hash]struct{} {
+		keys, ok := accessed[addr]
+		if !ok {
+			keys = make(map[common.Hash]struct{})
+			accessed[addr] = keys
+		}
+		return keys
+	}
+	for _, trace := range traces {
+		for addr, acc := range trace {
+			if _, ok := accessed[addr]; !ok && acc != nil {
+				codes[addr] = acc.Code
+			}
+			keys := access(addr)
+			if acc == nil {
+				continue
+			}
+			for key := range acc.Storage {
+				keys[key] = struct{}{}
+			}
+		}
+	}
+	access(block.Coinbase())
+	for _, addr := range feeAccounts {
+		access(addr)
+	}
+
+	addrs := make([]common.Address, 0, len(accessed))
+	for addr := range accessed {
+		addrs = append(addrs, addr)
+	}
+	sort.Slice(addrs, func(i, j int) bool { return bytes.Compare(addrs[i][:], addrs[j][:]) < 0 })
+
+	w := &Witness{
+		Version:         Version,
+		Header:          block.Header(),
+		ParentStateRoot: parent.Root(),
+		Accounts:        make([]*Account, 0, len(addrs)),
+	}
+	for _, addr := range addrs {
+		keys := make([]common.Hash, 0, len(accessed[addr]))
+		for key := range accessed[addr] {
+			keys = append(keys, key)
+		}
+		sort.Slice(keys, func(i, j int) bool { return bytes.Compare(keys[i][:], keys[j][:]) < 0 })
+
+		proof, err := src.GetProof(ctx, addr, keys, parent.Hash())
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch proof of account %s at block %s: %w", addr, eth.ToBlockID(parent), err)
+		}
+		if proof.Address != addr || len(proof.StorageProof) != len(keys) {
+			return nil, fmt.Errorf("got proof of account %s with %d storage slots, but requested account %s with %d", proof.Address, len(proof.StorageProof), addr, len(keys))
+		}
+		w.Accounts = append(w.Accounts, &Account{Proof: proof, Code: codes[addr]})
+	}
+
+	for i, tx := range block.Transactions() {
+		data, err := tx.MarshalBinary()
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode transaction %d: %w", i, err)
+		}
+		w.Transactions = append(w.Transactions, data)
+	}
+	return w, nil
+}
+
+// Verify checks the transactions against the header, and the accounts against the parent state root.
+func (w *Witness) Verify() error {
+	if w.Version != Version {
+		return fmt.Errorf("unsupported witness version %d, expected %d", w.Version, Version)
+	}
+	txs := make(types.Transactions, len(w.Transactions))
+	for i, data := range w.Transactions {
+		txs[i] = new(types.Transaction)
+		if err := txs[i].UnmarshalBinary(data); err != nil {
+			return fmt.Errorf("failed to decode transaction %d: %w", i, err)
+		}
+	}
+	if txHash := types.DeriveSha(txs, trie.NewStackTrie(nil)); txHash != w.Header.TxHash {
+		return fmt.Errorf("transactions root %s does not match header transactions root %s", txHash, w.Header.TxHash)
+	}
+	for _, acc := range w.Accounts {
+		if err := acc.Proof.Verify(w.ParentStateRoot); err != nil {
+			return fmt.Errorf("invalid proof of account %s: %w", acc.Proof.Address, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package witness
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kroma-network/kroma/components/node/eth"
+)
+
+type proofRequest struct {
+	address   common.Address
+	storage   []common.Hash
+	blockHash common.Hash
+}
+
+type fakeSource struct {
+	blocks   []*types.Block
+	prestate []map[common.Address]*PrestateAccount
+	proofs   []proofRequest
+}
+
+func newFakeSource(txs types.Transactions, prestate []map[common.Address]*PrestateAccount) *fakeSource {
+	parent := types.NewBlockWithHeader(&types.Header{Number: big.NewInt(0), Root: common.Hash{0x01}})
+	block := types.NewBlock(&types.Header{
+		ParentHash: parent.Hash(),
+		Number:     big.NewInt(1),
+		Coinbase:   common.Address{0xcb},
+		Root:       common.Hash{0x02},
+	}, txs, nil, nil, trie.NewStackTrie(nil))
+	return &fakeSource{blocks: []*types.Block{parent, block}, prestate: prestate}
+}
+
+func (s *fakeSource) BlockByNumber(_ context.Context, number *big.Int) (*types.Block, error) {
+	if number.Uint64() >= uint64(len(s.blocks)) {
+		return nil, errors.New("not found")
+	}
+	return s.blocks[number.Uint64()], nil
+}
+
+func (s *fakeSource) Prestate(_ context.Context, _ common.Hash) ([]map[common.Address]*PrestateAccount, error) {
+	return s.prestate, nil
+}
+
+func (s *fakeSource) GetProof(_ context.Context, address common.Address, storage []common.Hash, blockHash common.Hash) (*eth.AccountResult, error) {
+	s.proofs = append(s.proofs, proofRequest{address: address, storage: storage, blockHash: blockHash})
+	res := &eth.AccountResult{Address: address, Balance: (*hexutil.Big)(big.NewInt(0))}
+	for _, key := range storage {
+		res.StorageProof = append(res.StorageProof, eth.StorageProofEntry{Key: key})
+	}
+	return res, nil
+}
+
+func TestExport(t *testing.T) {
+	txs := types.Transactions{
+		types.NewTx(&types.DepositTx{To: &common.Address{0xaa}, Data: []byte{0x01}}),
+		types.NewTx(&types.DynamicFeeTx{ChainID: big.NewInt(901), To: &common.Address{0xbb}, Gas: 21000}),
+	}
+	alice, contract := common.Address{0x0a}, common.Address{0xaa}
+	src := newFakeSource(txs, []map[common.Address]*PrestateAccount{
+		{
+			contract: {Code: []byte{0x60}, Storage: map[common.Hash]common.Hash{{0x02}: {}, {0x01}: {}}},
+		},
+		{
+			alice: {},
+			// the code of the contract after the first transaction is not the pre-state of the block
+			contract: {Code: []byte{0x61}, Storage: map[common.Hash]common.Hash{{0x03}: {}, {0x01}: {}}},
+		},
+	})
+
+	w, err := Export(context.Background(), src, 1)
+	require.NoError(t, err)
+	require.Equal(t, uint64(Version), w.Version)
+	require.Equal(t, src.blocks[1].Hash(), w.Header.Hash())
+	require.Equal(t, src.blocks[0].Root(), w.ParentStateRoot)
+	require.Len(t, w.Transactions, 2)
+
+	var addrs []common.Address
+	for _, acc := range w.Accounts {
+		addrs = append(addrs, acc.Proof.Address)
+	}
+	require.Equal(t, []common.Address{
+		alice,
+		common.HexToAddress("0x4200000000000000000000000000000000000006"),
+		common.HexToAddress("0x4200000000000000000000000000000000000007"),
+		common.HexToAddress("0x4200000000000000000000000000000000000008"),
+		contract,
+		{0xcb},
+	}, addrs, "expected the accessed, fee and coinbase accounts sorted by address")
+	require.Equal(t, hexutil.Bytes{0x60}, w.Accounts[4].Code)
+	require.Equal(t, []common.Hash{{0x01}, {0x02}, {0x03}}, src.proofs[4].storage)
+	for _, req := range src.proofs {
+		require.Equal(t, src.blocks[0].Hash(), req.blockHash, "expected the proofs of the pre-state")
+	}
+
+	// the fake header commits to the transactions, but the fake proofs are not valid
+	w.Accounts = nil
+	require.NoError(t, w.Verify())
+	w.Transactions = w.Transactions[1:]
+	require.ErrorContains(t, w.Verify(), "does not match header transactions root")
+}
+
+func TestExportErrors(t *testing.T) {
+	src := newFakeSource(nil, nil)
+	_, err := Export(context.Background(), src, 0)
+	require.ErrorContains(t, err, "genesis block")
+
+	_, err = Export(context.Background(), src, 2)
+	require.ErrorContains(t, err, "failed to fetch block 2")
+
+	src.blocks[0] = types.NewBlockWithHeader(&types.Header{Number: big.NewInt(0), Root: common.Hash{0x03}})
+	_, err = Export(context.Background(), src, 1)
+	require.ErrorContains(t, err, "does not build on top of block")
+
+	src = newFakeSource(types.Transactions{types.NewTx(&types.DepositTx{})}, nil)
+	_, err = Export(context.Background(), src, 1)
+	require.ErrorContains(t, err, "got prestate of 0 transactions")
+}
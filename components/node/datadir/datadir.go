@@ -0,0 +1,213 @@
+// Package datadir manages the data directory of the rollup node: a directory per L2 chain, with a versioned layout
+// that is migrated automatically when the node is upgraded.
+package datadir
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+const (
+	// DBDir is the directory of the databases of the node.
+	DBDir = "db"
+	// P2PDir is the directory of the network key, the peerstore and the discovery database.
+	P2PDir = "p2p"
+	// SnapshotsDir is the directory of the snapshot log.
+	SnapshotsDir = "snapshots"
+	// JournalDir is the directory of the journals of the node.
+	JournalDir = "journal"
+
+	// schemaFile is the file the version of the layout of a chain directory is recorded in.
+	schemaFile = "schema.json"
+)
+
+// The files of the flat layout used before the data directory, in the working directory of the node by default.
+const (
+	legacyP2PPriv   = "kroma_node_p2p_priv.txt"
+	legacyPeerstore = "kroma_node_peerstore_db"
+	legacyDiscovery = "kroma_node_discovery_db"
+)
+
+// ErrNewerSchema is returned when opening a data directory of a layout newer than this release supports.
+var ErrNewerSchema = errors.New("data directory was created by a newer release")
+
+// Schema is the recorded version of the layout of a chain directory.
+type Schema struct {
+	Version uint64 `json:"version"`
+	// Migrated is the time of the last migration.
+	Migrated time.Time `json:"migrated"`
+}
+
+// Migration upgrades the layout of a chain directory from the previous version to Version.
+type Migration struct {
+	Version     uint64
+	Description string
+	Apply       func(log log.Logger, d *DataDir) error
+}
+
+// Migrations are the migrations of the layout, in the order of their versions. A new layout version is added by
+// appending a migration, which must be idempotent, since it is applied again if the node stops before the schema
+// is recorded.
+var Migrations = []Migration{
+	{
+		Version:     1,
+		Description: "create the directory layout, and import the files of the flat layout",
+		Apply:       migrateFlatLayout,
+	},
+}
+
+// SchemaVersion is the version of the layout of this release.
+func SchemaVersion() uint64 {
+	return Migrations[len(Migrations)-1].Version
+}
+
+// DataDir is the directory of the data of the node for a single L2 chain.
+type DataDir struct {
+	dir string
+	// legacyDir is the directory of the files of the flat layout, imported by the first migration.
+	legacyDir string
+}
+
+// Open opens the directory of the L2 chain in the data directory root, created if it does not exist, and migrates
+// its layout to the version of this release. The files of the flat layout are imported from legacyDir.
+func Open(log log.Logger, root string, l2ChainID *big.Int, legacyDir string) (*DataDir, error) {
+	if l2ChainID == nil {
+		return nil, errors.New("missing L2 chain id of the data directory")
+	}
+	d := &DataDir{dir: filepath.Join(root, l2ChainID.String()), legacyDir: legacyDir}
+	if err := os.MkdirAll(d.dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create data directory: %w", err)
+	}
+	if err := d.migrate(log, Migrations); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// Dir returns the directory of the chain.
+func (d *DataDir) Dir() string {
+	return d.dir
+}
+
+// Path returns the path of the elements in the directory of the chain.
+func (d *DataDir) Path(elem ...string) string {
+	return filepath.Join(append([]string{d.dir}, elem...)...)
+}
+
+// P2PPrivPath returns the path of the network key of the node.
+func (d *DataDir) P2PPrivPath() string {
+	return d.Path(P2PDir, "priv.txt")
+}
+
+// PeerstorePath returns the path of the peerstore database.
+func (d *DataDir) PeerstorePath() string {
+	return d.Path(P2PDir, "peerstore")
+}
+
+// DiscoveryPath returns the path of the discovery database.
+func (d *DataDir) DiscoveryPath() string {
+	return d.Path(P2PDir, "discovery")
+}
+
+// SnapshotLogPath returns the path of the snapshot log.
+func (d *DataDir) SnapshotLogPath() string {
+	return d.Path(SnapshotsDir, "snapshot.log")
+}
+
+// ReadSchema returns the recorded schema of the directory, of version 0 if none is recorded yet.
+func (d *DataDir) ReadSchema() (Schema, error) {
+	var schema Schema
+	data, err := os.ReadFile(d.Path(schemaFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return schema, nil
+	} else if err != nil {
+		return schema, fmt.Errorf("failed to read data directory schema: %w", err)
+	}
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return schema, fmt.Errorf("failed to decode data directory schema: %w", err)
+	}
+	return schema, nil
+}
+
+// writeSchema records the schema, replacing the file atomically.
+func (d *DataDir) writeSchema(schema Schema) error {
+	data, err := json.Marshal(schema)
+	if err != nil {
+		return err
+	}
+	tmp := d.Path(schemaFile + ".tmp")
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write data directory schema: %w", err)
+	}
+	if err := os.Rename(tmp, d.Path(schemaFile)); err != nil {
+		return fmt.Errorf("failed to write data directory schema: %w", err)
+	}
+	return nil
+}
+
+// migrate applies the migrations newer than the recorded schema, recording the schema after each migration.
+func (d *DataDir) migrate(log log.Logger, migrations []Migration) error {
+	schema, err := d.ReadSchema()
+	if err != nil {
+		return err
+	}
+	latest := migrations[len(migrations)-1].Version
+	if schema.Version > latest {
+		return fmt.Errorf("%w: schema version %d, supported up to %d", ErrNewerSchema, schema.Version, latest)
+	}
+	for _, m := range migrations {
+		if m.Version <= schema.Version {
+			continue
+		}
+		log.Info("migrating data directory", "dir", d.dir, "from", schema.Version, "to", m.Version, "migration", m.Description)
+		if err := m.Apply(log, d); err != nil {
+			return fmt.Errorf("failed to migrate data directory to version %d: %w", m.Version, err)
+		}
+		schema = Schema{Version: m.Version, Migrated: time.Now().UTC()}
+		if err := d.writeSchema(schema); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// migrateFlatLayout creates the directories of the layout, and moves the network key, the peerstore and the
+// discovery database of the flat layout into it, unless the files exist already.
+func migrateFlatLayout(log log.Logger, d *DataDir) error {
+	for _, dir := range []string{DBDir, P2PDir, SnapshotsDir, JournalDir} {
+		if err := os.MkdirAll(d.Path(dir), 0o700); err != nil {
+			return err
+		}
+	}
+	if d.legacyDir == "" {
+		return nil
+	}
+	for legacy, path := range map[string]string{
+		legacyP2PPriv:   d.P2PPrivPath(),
+		legacyPeerstore: d.PeerstorePath(),
+		legacyDiscovery: d.DiscoveryPath(),
+	} {
+		src := filepath.Join(d.legacyDir, legacy)
+		if _, err := os.Stat(src); errors.Is(err, os.ErrNotExist) {
+			continue
+		} else if err != nil {
+			return err
+		}
+		if _, err := os.Stat(path); err == nil {
+			log.Warn("not importing file of the flat layout, it exists in the data directory", "file", src, "path", path)
+			continue
+		}
+		if err := os.Rename(src, path); err != nil {
+			return fmt.Errorf("failed to import %s: %w", src, err)
+		}
+		log.Info("imported file of the flat layout into the data directory", "file", src, "path", path)
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package datadir
+
+import (
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kroma-network/kroma/components/node/testlog"
+)
+
+func TestOpen(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	root, legacyDir := t.TempDir(), t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(legacyDir, legacyP2PPriv), []byte("key"), 0o600))
+	require.NoError(t, os.Mkdir(filepath.Join(legacyDir, legacyPeerstore), 0o700))
+
+	d, err := Open(logger, root, big.NewInt(255), legacyDir)
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(root, "255"), d.Dir())
+	for _, dir := range []string{DBDir, P2PDir, SnapshotsDir, JournalDir} {
+		require.DirExists(t, d.Path(dir))
+	}
+	schema, err := d.ReadSchema()
+	require.NoError(t, err)
+	require.Equal(t, SchemaVersion(), schema.Version)
+
+	// the files of the flat layout are moved into the data directory
+	key, err := os.ReadFile(d.P2PPrivPath())
+	require.NoError(t, err)
+	require.Equal(t, "key", string(key))
+	require.DirExists(t, d.PeerstorePath())
+	require.NoFileExists(t, filepath.Join(legacyDir, legacyP2PPriv))
+	require.NoDirExists(t, d.DiscoveryPath(), "only existing files are imported")
+
+	// opening again is a no-op
+	require.NoError(t, os.WriteFile(filepath.Join(legacyDir, legacyP2PPriv), []byte("other"), 0o600))
+	_, err = Open(logger, root, big.NewInt(255), legacyDir)
+	require.NoError(t, err)
+	key, err = os.ReadFile(d.P2PPrivPath())
+	require.NoError(t, err)
+	require.Equal(t, "key", string(key))
+
+	// the chains are kept apart
+	other, err := Open(logger, root, big.NewInt(2358), "")
+	require.NoError(t, err)
+	require.NotEqual(t, d.Dir(), other.Dir())
+	require.NoFileExists(t, other.P2PPrivPath())
+}
+
+func TestOpenNewerSchema(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	root := t.TempDir()
+	d, err := Open(logger, root, big.NewInt(255), "")
+	require.NoError(t, err)
+	require.NoError(t, d.writeSchema(Schema{Version: SchemaVersion() + 1}))
+
+	_, err = Open(logger, root, big.NewInt(255), "")
+	require.ErrorIs(t, err, ErrNewerSchema)
+}
+
+func TestMigrate(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	d := &DataDir{dir: t.TempDir()}
+	var applied []uint64
+	errFailed := errors.New("failed")
+	fail := true
+	migrations := []Migration{
+		{Version: 1, Apply: func(log.Logger, *DataDir) error { applied = append(applied, 1); return nil }},
+		{Version: 2, Apply: func(log.Logger, *DataDir) error {
+			if fail {
+				return errFailed
+			}
+			applied = append(applied, 2)
+			return nil
+		}},
+		{Version: 3, Apply: func(log.Logger, *DataDir) error { applied = append(applied, 3); return nil }},
+	}
+
+	require.ErrorIs(t, d.migrate(logger, migrations), errFailed)
+	schema, err := d.ReadSchema()
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), schema.Version, "schema is recorded after every migration")
+
+	// the failed migration is resumed
+	fail = false
+	require.NoError(t, d.migrate(logger, migrations))
+	require.Equal(t, []uint64{1, 2, 3}, applied)
+	schema, err = d.ReadSchema()
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), schema.Version)
+}
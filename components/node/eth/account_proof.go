@@ -125,14 +125,29 @@ func (res *AccountResult) getAccountClaimedValue(isZktrie bool) ([]byte, error)
 	}
 }
 
+// isEmpty returns whether the account is claimed empty, as returned by the getProof RPC for an absent account.
+func (res *AccountResult) isEmpty() bool {
+	emptyCode := res.CodeHash == (common.Hash{}) || res.CodeHash == types.EmptyCodeHash
+	emptyStorage := res.StorageHash == (common.Hash{}) || res.StorageHash == types.EmptyRootHash(false)
+	return res.Nonce == 0 && (res.Balance == nil || res.Balance.ToInt().Sign() == 0) && emptyCode && emptyStorage
+}
+
 // Verify an account (and optionally storage) proof from the getProof RPC. See https://eips.ethereum.org/EIPS/eip-1186
 func (res *AccountResult) Verify(stateRoot common.Hash) error {
 	// verify storage proof values, if any, against the storage trie root hash of the account
 	for i, entry := range res.StorageProof {
 		validator := func(val []byte, isZktrie bool) error {
-			_, expected, _, err := rlp.Split(val)
-			if err != nil {
-				return err
+			// a proof of absence proves a zero value
+			var expected []byte
+			if isZktrie {
+				// storage values of the zktrie are not RLP encoded, but stored as 32 bytes words
+				expected = new(big.Int).SetBytes(val).Bytes()
+			} else if len(val) > 0 {
+				var err error
+				_, expected, _, err = rlp.Split(val)
+				if err != nil {
+					return err
+				}
 			}
 			if !bytes.Equal(expected, entry.Value.ToInt().Bytes()) {
 				return fmt.Errorf("value %d in storage proof does not match proven value at key %s", i, entry.Key)
@@ -148,6 +163,13 @@ func (res *AccountResult) Verify(stateRoot common.Hash) error {
 
 	// now get the full value from the account proof, and check that it matches
 	validator := func(val []byte, isZktrie bool) error {
+		// a proof of absence proves an empty account
+		if len(val) == 0 {
+			if !res.isEmpty() {
+				return fmt.Errorf("account %s is absent from the state, but not claimed empty", res.Address)
+			}
+			return nil
+		}
 		expected, err := res.getAccountClaimedValue(isZktrie)
 		if err != nil {
 			return err
@@ -7,6 +7,12 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+	zkt "github.com/kroma-network/zktrie/types"
 	"github.com/stretchr/testify/require"
 )
 
@@ -57,6 +63,126 @@ func TestAccountResult_Verify(t *testing.T) {
 	require.NotNil(t, result.Verify(goodRoot), "does not verify against bad proof")
 }
 
+// proofList collects the nodes of a proof in order, as returned by the getProof RPC.
+type proofList []hexutil.Bytes
+
+func (l *proofList) Put(_ []byte, value []byte) error {
+	*l = append(*l, common.CopyBytes(value))
+	return nil
+}
+
+func (l *proofList) Delete(_ []byte) error {
+	panic("not supported")
+}
+
+// proveMPT returns the proof of the key in the secure MPT of the values, and the root of the trie.
+func proveMPT(t *testing.T, values map[string][]byte, key []byte) ([]hexutil.Bytes, common.Hash) {
+	tr := trie.NewEmpty(trie.NewDatabase(rawdb.NewMemoryDatabase()))
+	for k, v := range values {
+		require.NoError(t, tr.TryUpdate(crypto.Keccak256([]byte(k)), v))
+	}
+	var proof proofList
+	require.NoError(t, tr.Prove(crypto.Keccak256(key), 0, &proof))
+	return proof, tr.Hash()
+}
+
+// proveZktrie returns the proof of the key in the zktrie.
+func proveZktrie(t *testing.T, tr *trie.ZkTrie, key []byte) []hexutil.Bytes {
+	secureKey, err := zkt.ToSecureKeyBytes(key)
+	require.NoError(t, err)
+	var proof proofList
+	require.NoError(t, tr.Prove(secureKey[:], 0, &proof))
+	return proof
+}
+
+func TestAccountResult_VerifyAbsentMPT(t *testing.T) {
+	slot := common.Hash{0x01}
+	absentSlot := common.Hash{0x02}
+	value, err := rlp.EncodeToBytes(big.NewInt(5).Bytes())
+	require.NoError(t, err)
+	_, storageRoot := proveMPT(t, map[string][]byte{string(slot[:]): value}, slot[:])
+
+	addr := common.Address{0xaa}
+	result := AccountResult{Address: addr, Nonce: 1, Balance: (*hexutil.Big)(big.NewInt(0)),
+		CodeHash: types.EmptyCodeHash, StorageHash: storageRoot}
+	account, err := result.getAccountClaimedValue(false)
+	require.NoError(t, err)
+	state := map[string][]byte{string(addr[:]): account}
+	_, stateRoot := proveMPT(t, state, addr[:])
+
+	t.Run("present account and zero slot", func(t *testing.T) {
+		result := result
+		result.AccountProof, _ = proveMPT(t, state, addr[:])
+		present, _ := proveMPT(t, map[string][]byte{string(slot[:]): value}, slot[:])
+		absent, _ := proveMPT(t, map[string][]byte{string(slot[:]): value}, absentSlot[:])
+		result.StorageProof = []StorageProofEntry{
+			{Key: slot, Value: hexutil.Big(*big.NewInt(5)), Proof: present},
+			{Key: absentSlot, Value: hexutil.Big(*big.NewInt(0)), Proof: absent},
+		}
+		require.NoError(t, result.Verify(stateRoot))
+
+		result.StorageProof[1].Value = hexutil.Big(*big.NewInt(1))
+		require.ErrorContains(t, result.Verify(stateRoot), "value 1 in storage proof does not match proven value")
+	})
+
+	t.Run("absent account", func(t *testing.T) {
+		absentAddr := common.Address{0xbb}
+		proof, _ := proveMPT(t, state, absentAddr[:])
+		absent := AccountResult{Address: absentAddr, Balance: (*hexutil.Big)(big.NewInt(0)), AccountProof: proof}
+		require.NoError(t, absent.Verify(stateRoot), "empty account is proven by its absence")
+
+		absent.Nonce = 1
+		require.ErrorContains(t, absent.Verify(stateRoot), "is absent from the state, but not claimed empty")
+		absent.Nonce = 0
+		absent.Balance = (*hexutil.Big)(big.NewInt(1))
+		require.ErrorContains(t, absent.Verify(stateRoot), "is absent from the state, but not claimed empty")
+	})
+}
+
+func TestAccountResult_VerifyZktrie(t *testing.T) {
+	slot := common.Hash{0x01}
+	absentSlot := common.Hash{0x02}
+	storage, err := trie.NewZkTrie(common.Hash{}, trie.NewZktrieDatabase(rawdb.NewMemoryDatabase()))
+	require.NoError(t, err)
+	require.NoError(t, storage.TryUpdate(slot[:], common.BigToHash(big.NewInt(5)).Bytes()))
+
+	addr := common.Address{0xaa}
+	state, err := trie.NewZkTrie(common.Hash{}, trie.NewZktrieDatabase(rawdb.NewMemoryDatabase()))
+	require.NoError(t, err)
+	require.NoError(t, state.TryUpdateAccount(addr, &types.StateAccount{
+		Nonce: 1, Balance: big.NewInt(0), Root: storage.Hash(), CodeHash: types.EmptyCodeHash.Bytes(),
+	}))
+
+	t.Run("storage values are not RLP encoded", func(t *testing.T) {
+		result := AccountResult{
+			Address: addr, Nonce: 1, Balance: (*hexutil.Big)(big.NewInt(0)),
+			CodeHash: types.EmptyCodeHash, StorageHash: storage.Hash(),
+			AccountProof: proveZktrie(t, state, addr[:]),
+			StorageProof: []StorageProofEntry{
+				{Key: slot, Value: hexutil.Big(*big.NewInt(5)), Proof: proveZktrie(t, storage, slot[:])},
+				{Key: absentSlot, Value: hexutil.Big(*big.NewInt(0)), Proof: proveZktrie(t, storage, absentSlot[:])},
+			},
+		}
+		require.NoError(t, result.Verify(state.Hash()))
+
+		result.StorageProof[0].Value = hexutil.Big(*big.NewInt(6))
+		require.ErrorContains(t, result.Verify(state.Hash()), "value 0 in storage proof does not match proven value")
+		result.StorageProof[0].Value = hexutil.Big(*big.NewInt(5))
+		result.StorageProof[1].Value = hexutil.Big(*big.NewInt(1))
+		require.ErrorContains(t, result.Verify(state.Hash()), "value 1 in storage proof does not match proven value")
+	})
+
+	t.Run("absent account", func(t *testing.T) {
+		absentAddr := common.Address{0xbb}
+		absent := AccountResult{Address: absentAddr, Balance: (*hexutil.Big)(big.NewInt(0)),
+			AccountProof: proveZktrie(t, state, absentAddr[:])}
+		require.NoError(t, absent.Verify(state.Hash()), "empty account is proven by its absence")
+
+		absent.Nonce = 1
+		require.ErrorContains(t, absent.Verify(state.Hash()), "is absent from the state, but not claimed empty")
+	})
+}
+
 func FuzzAccountResult_StorageProof(f *testing.F) {
 	f.Fuzz(func(t *testing.T, key []byte, value []byte) {
 		result := makeResult(t)
@@ -0,0 +1,30 @@
+package eth
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// TxCallArgs are the arguments of a transaction call to estimate the fee for.
+type TxCallArgs struct {
+	From                 *common.Address `json:"from"`
+	To                   *common.Address `json:"to"`
+	Gas                  *hexutil.Uint64 `json:"gas"`
+	MaxFeePerGas         *hexutil.Big    `json:"maxFeePerGas"`
+	MaxPriorityFeePerGas *hexutil.Big    `json:"maxPriorityFeePerGas"`
+	Value                *hexutil.Big    `json:"value"`
+	Nonce                *hexutil.Uint64 `json:"nonce"`
+	Data                 *hexutil.Bytes  `json:"data"`
+}
+
+// FeeEstimate is the estimated total fee of a transaction, which consists of
+// the L2 execution fee and the L1 data fee.
+type FeeEstimate struct {
+	GasLimit     hexutil.Uint64 `json:"gasLimit"`
+	GasPrice     *hexutil.Big   `json:"gasPrice"`
+	ExecutionFee *hexutil.Big   `json:"executionFee"`
+	L1GasUsed    hexutil.Uint64 `json:"l1GasUsed"`
+	L1BaseFee    *hexutil.Big   `json:"l1BaseFee"`
+	L1Fee        *hexutil.Big   `json:"l1Fee"`
+	TotalFee     *hexutil.Big   `json:"totalFee"`
+}
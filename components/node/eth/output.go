@@ -33,6 +33,16 @@ type OutputResponse struct {
 	PublicInputProof      *PublicInputProof `json:"publicInputProof"`
 }
 
+// OutputVerificationResponse is the verdict of verifying a proposed output root
+// against the output root computed by the local node.
+type OutputVerificationResponse struct {
+	Valid              bool            `json:"valid"`
+	ProposedOutputRoot Bytes32         `json:"proposedOutputRoot"`
+	Safe               bool            `json:"safe"`
+	Finalized          bool            `json:"finalized"`
+	LocalOutput        *OutputResponse `json:"localOutput"`
+}
+
 func (o *OutputResponse) ToOutputRootProof() bindings.TypesOutputRootProof {
 	return bindings.TypesOutputRootProof{
 		Version:                  o.Version,
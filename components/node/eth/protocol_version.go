@@ -0,0 +1,201 @@
+package eth
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// ProtocolVersion is the 32-byte protocol version signal of the network.
+//
+// Version 0 of the encoding is laid out as follows:
+//
+//	<reserved> (7 bytes) <version type> (1 byte) <build> (8 bytes) <major> (4 bytes) <minor> (4 bytes) <patch> (4 bytes) <pre-release> (4 bytes)
+type ProtocolVersion Bytes32
+
+func (p *ProtocolVersion) UnmarshalText(text []byte) error {
+	return (*Bytes32)(p).UnmarshalText(text)
+}
+
+func (p ProtocolVersion) MarshalText() ([]byte, error) {
+	return Bytes32(p).MarshalText()
+}
+
+// NodeProtocolVersion is the protocol version supported by a rollup node, for the components attached to the node
+// to check their compatibility with.
+type NodeProtocolVersion struct {
+	ProtocolVersion ProtocolVersion `json:"protocolVersion"`
+}
+
+// ProtocolVersionV0 is the decoded form of a version 0 ProtocolVersion.
+type ProtocolVersionV0 struct {
+	Build      [8]byte
+	Major      uint32
+	Minor      uint32
+	Patch      uint32
+	PreRelease uint32
+}
+
+// Encode encodes the version as a ProtocolVersion with version type 0.
+func (v ProtocolVersionV0) Encode() (out ProtocolVersion) {
+	copy(out[8:16], v.Build[:])
+	binary.BigEndian.PutUint32(out[16:20], v.Major)
+	binary.BigEndian.PutUint32(out[20:24], v.Minor)
+	binary.BigEndian.PutUint32(out[24:28], v.Patch)
+	binary.BigEndian.PutUint32(out[28:32], v.PreRelease)
+	return
+}
+
+func (v ProtocolVersionV0) String() string {
+	out := fmt.Sprintf("v%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.PreRelease != 0 {
+		out += fmt.Sprintf("-%d", v.PreRelease)
+	}
+	if v.Build != ([8]byte{}) {
+		out += fmt.Sprintf("+0x%x", v.Build[:])
+	}
+	return out
+}
+
+// VersionType returns the encoding type of the protocol version.
+func (p ProtocolVersion) VersionType() uint8 {
+	return p[7]
+}
+
+// IsEmpty returns true if no protocol version is signaled.
+func (p ProtocolVersion) IsEmpty() bool {
+	return p == (ProtocolVersion{})
+}
+
+// Parse decodes the protocol version. It returns an error if the version is not of type 0,
+// or if the reserved bytes are not zero.
+func (p ProtocolVersion) Parse() (ProtocolVersionV0, error) {
+	if p.VersionType() != 0 {
+		return ProtocolVersionV0{}, fmt.Errorf("unsupported protocol version type %d", p.VersionType())
+	}
+	if p[0] != 0 || p[1] != 0 || p[2] != 0 || p[3] != 0 || p[4] != 0 || p[5] != 0 || p[6] != 0 {
+		return ProtocolVersionV0{}, fmt.Errorf("protocol version has non-zero reserved bytes: %s", Bytes32(p))
+	}
+	var v ProtocolVersionV0
+	copy(v.Build[:], p[8:16])
+	v.Major = binary.BigEndian.Uint32(p[16:20])
+	v.Minor = binary.BigEndian.Uint32(p[20:24])
+	v.Patch = binary.BigEndian.Uint32(p[24:28])
+	v.PreRelease = binary.BigEndian.Uint32(p[28:32])
+	return v, nil
+}
+
+func (p ProtocolVersion) String() string {
+	if p.IsEmpty() {
+		return "none"
+	}
+	v, err := p.Parse()
+	if err != nil {
+		return Bytes32(p).String()
+	}
+	return v.String()
+}
+
+// ProtocolVersionComparison is the result of comparing two protocol versions.
+// Positive values mean that the compared version is ahead, negative values that it is outdated.
+type ProtocolVersionComparison int
+
+const (
+	AheadMajor         ProtocolVersionComparison = 4
+	OutdatedMajor      ProtocolVersionComparison = -4
+	AheadMinor         ProtocolVersionComparison = 3
+	OutdatedMinor      ProtocolVersionComparison = -3
+	AheadPatch         ProtocolVersionComparison = 2
+	OutdatedPatch      ProtocolVersionComparison = -2
+	AheadPrerelease    ProtocolVersionComparison = 1
+	OutdatedPrerelease ProtocolVersionComparison = -1
+	Matching           ProtocolVersionComparison = 0
+	DiffVersionType    ProtocolVersionComparison = 100
+	DiffBuildID        ProtocolVersionComparison = 101
+	EmptyVersion       ProtocolVersionComparison = 102
+	InvalidVersion     ProtocolVersionComparison = 103
+)
+
+func (c ProtocolVersionComparison) String() string {
+	switch c {
+	case AheadMajor:
+		return "ahead major"
+	case OutdatedMajor:
+		return "outdated major"
+	case AheadMinor:
+		return "ahead minor"
+	case OutdatedMinor:
+		return "outdated minor"
+	case AheadPatch:
+		return "ahead patch"
+	case OutdatedPatch:
+		return "outdated patch"
+	case AheadPrerelease:
+		return "ahead pre-release"
+	case OutdatedPrerelease:
+		return "outdated pre-release"
+	case Matching:
+		return "matching"
+	case DiffVersionType:
+		return "different version type"
+	case DiffBuildID:
+		return "different build"
+	case EmptyVersion:
+		return "empty version"
+	case InvalidVersion:
+		return "invalid version"
+	default:
+		return fmt.Sprintf("unknown comparison %d", int(c))
+	}
+}
+
+// Compare compares p to other: a positive result means that p is ahead of other,
+// a negative result that p is outdated compared to other.
+// A pre-release of zero is considered to be a full release, which is ahead of all its pre-releases.
+func (p ProtocolVersion) Compare(other ProtocolVersion) ProtocolVersionComparison {
+	if p.IsEmpty() || other.IsEmpty() {
+		return EmptyVersion
+	}
+	if p.VersionType() != other.VersionType() {
+		return DiffVersionType
+	}
+	a, err := p.Parse()
+	if err != nil {
+		return InvalidVersion
+	}
+	b, err := other.Parse()
+	if err != nil {
+		return InvalidVersion
+	}
+	if a.Build != b.Build {
+		return DiffBuildID
+	}
+	cmp := func(x, y uint32, ahead ProtocolVersionComparison) ProtocolVersionComparison {
+		if x > y {
+			return ahead
+		}
+		if x < y {
+			return -ahead
+		}
+		return Matching
+	}
+	if c := cmp(a.Major, b.Major, AheadMajor); c != Matching {
+		return c
+	}
+	if c := cmp(a.Minor, b.Minor, AheadMinor); c != Matching {
+		return c
+	}
+	if c := cmp(a.Patch, b.Patch, AheadPatch); c != Matching {
+		return c
+	}
+	if a.PreRelease == b.PreRelease {
+		return Matching
+	}
+	// a full release (pre-release 0) is ahead of any pre-release of the same version.
+	if a.PreRelease == 0 {
+		return AheadPrerelease
+	}
+	if b.PreRelease == 0 {
+		return OutdatedPrerelease
+	}
+	return cmp(a.PreRelease, b.PreRelease, AheadPrerelease)
+}
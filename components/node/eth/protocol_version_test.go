@@ -0,0 +1,59 @@
+package eth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestProtocolVersionEncoding(t *testing.T) {
+	v := ProtocolVersionV0{Build: [8]byte{0x01}, Major: 1, Minor: 2, Patch: 3, PreRelease: 4}
+	p := v.Encode()
+	require.Equal(t, uint8(0), p.VersionType())
+	parsed, err := p.Parse()
+	require.NoError(t, err)
+	require.Equal(t, v, parsed)
+	require.Equal(t, "v1.2.3-4+0x0100000000000000", p.String())
+	require.Equal(t, "v1.0.0", ProtocolVersionV0{Major: 1}.Encode().String())
+	require.Equal(t, "none", ProtocolVersion{}.String())
+
+	p[0] = 1
+	_, err = p.Parse()
+	require.Error(t, err)
+}
+
+func TestProtocolVersionCompare(t *testing.T) {
+	enc := func(major, minor, patch, preRelease uint32) ProtocolVersion {
+		return ProtocolVersionV0{Major: major, Minor: minor, Patch: patch, PreRelease: preRelease}.Encode()
+	}
+	base := enc(1, 2, 3, 0)
+	otherType := base
+	otherType[7] = 1
+	otherBuild := ProtocolVersionV0{Build: [8]byte{0x01}, Major: 1, Minor: 2, Patch: 3}.Encode()
+
+	tests := []struct {
+		name string
+		a    ProtocolVersion
+		b    ProtocolVersion
+		cmp  ProtocolVersionComparison
+	}{
+		{"matching", base, enc(1, 2, 3, 0), Matching},
+		{"ahead major", enc(2, 0, 0, 0), base, AheadMajor},
+		{"outdated major", enc(0, 9, 9, 0), base, OutdatedMajor},
+		{"ahead minor", enc(1, 3, 0, 0), base, AheadMinor},
+		{"outdated minor", enc(1, 1, 9, 0), base, OutdatedMinor},
+		{"ahead patch", enc(1, 2, 4, 0), base, AheadPatch},
+		{"outdated patch", enc(1, 2, 2, 0), base, OutdatedPatch},
+		{"release ahead of pre-release", base, enc(1, 2, 3, 1), AheadPrerelease},
+		{"pre-release outdated by release", enc(1, 2, 3, 1), base, OutdatedPrerelease},
+		{"ahead pre-release", enc(1, 2, 3, 2), enc(1, 2, 3, 1), AheadPrerelease},
+		{"empty", ProtocolVersion{}, base, EmptyVersion},
+		{"different version type", otherType, base, DiffVersionType},
+		{"different build", otherBuild, base, DiffBuildID},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.cmp, test.a.Compare(test.b))
+		})
+	}
+}
@@ -35,4 +35,7 @@ type SyncStatus struct {
 	// UnsafeL2SyncTarget points to the first unprocessed unsafe L2 block.
 	// It may be zeroed if there is no targeted block.
 	UnsafeL2SyncTarget L2BlockRef `json:"queued_unsafe_l2"`
+	// L1Only is true if the node derives exclusively from L1, with P2P and alt-sync disabled.
+	// The UnsafeL2 then matches the SafeL2.
+	L1Only bool `json:"l1_only"`
 }
@@ -0,0 +1,79 @@
+package eth
+
+import (
+	"encoding/json"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// TxPoolContent is the content of the transaction pool of the engine, as returned by txpool_content: the
+// transactions by sender and nonce, pending if executable, queued otherwise.
+type TxPoolContent struct {
+	Pending map[common.Address]map[string]*TxPoolTransaction `json:"pending"`
+	Queued  map[common.Address]map[string]*TxPoolTransaction `json:"queued"`
+}
+
+// TxPoolTransaction is a transaction of the transaction pool with its sender.
+type TxPoolTransaction struct {
+	Tx   *types.Transaction
+	From common.Address
+}
+
+func (t *TxPoolTransaction) UnmarshalJSON(data []byte) error {
+	var tx types.Transaction
+	if err := json.Unmarshal(data, &tx); err != nil {
+		return err
+	}
+	var extra struct {
+		From common.Address `json:"from"`
+	}
+	if err := json.Unmarshal(data, &extra); err != nil {
+		return err
+	}
+	t.Tx, t.From = &tx, extra.From
+	return nil
+}
+
+// PendingTransactions is the pending pool of the engine, annotated with the rollup context of the latest L2 block.
+type PendingTransactions struct {
+	BaseFee   *hexutil.Big   `json:"baseFee"`
+	L1BaseFee *hexutil.Big   `json:"l1BaseFee"`
+	GasLimit  hexutil.Uint64 `json:"gasLimit"`
+	// DABudget is the data availability budget of a block, 0 if there is none.
+	DABudget hexutil.Uint64 `json:"daBudget"`
+	// Pending are the pending transactions, in the order they would be included in the next block, up to the first
+	// one exceeding the gas limit or the data availability budget.
+	Pending []PendingTransaction `json:"pending"`
+	// Remaining is the number of the other pending transactions, not expected in the next block, and Underpriced the
+	// number of the pending transactions that cannot be included at the current base fee.
+	Remaining   hexutil.Uint64 `json:"remaining"`
+	Underpriced hexutil.Uint64 `json:"underpriced"`
+	Queued      hexutil.Uint64 `json:"queued"`
+}
+
+// PendingTransaction is a pending transaction annotated with its fees and data availability.
+type PendingTransaction struct {
+	Hash                 common.Hash    `json:"hash"`
+	From                 common.Address `json:"from"`
+	Nonce                hexutil.Uint64 `json:"nonce"`
+	Gas                  hexutil.Uint64 `json:"gas"`
+	MaxFeePerGas         *hexutil.Big   `json:"maxFeePerGas"`
+	MaxPriorityFeePerGas *hexutil.Big   `json:"maxPriorityFeePerGas"`
+	// Size is the number of bytes of the encoded transaction, published to L1 as batch data.
+	Size hexutil.Uint64 `json:"size"`
+	// ExecutionFee is the L2 execution fee at the base fee of the latest block, and L1Fee the L1 data fee.
+	ExecutionFee *hexutil.Big `json:"executionFee"`
+	L1Fee        *hexutil.Big `json:"l1Fee"`
+	// L1FeeShare is the share of the L1 data fee in the total fee.
+	L1FeeShare float64 `json:"l1FeeShare"`
+	// CumulativeSize and CumulativeGas are the size and the gas of the transactions included up to this one.
+	CumulativeSize *hexutil.Uint64 `json:"cumulativeSize"`
+	CumulativeGas  *hexutil.Uint64 `json:"cumulativeGas"`
+	// ExceedsGasLimit and ExceedsDABudget are whether the cumulative gas exceeds the gas limit of the latest block,
+	// and the cumulative size the data availability budget, in which case the transaction is not expected in the next
+	// block.
+	ExceedsGasLimit bool `json:"exceedsGasLimit"`
+	ExceedsDABudget bool `json:"exceedsDABudget"`
+}
@@ -58,6 +58,43 @@ var (
 		Usage:  "Enable the admin API (experimental)",
 		EnvVar: prefixEnvVar("RPC_ENABLE_ADMIN"),
 	}
+	RPCTxPoolDABudget = cli.Uint64Flag{
+		Name:   "rpc.txpool-da-budget",
+		Usage:  "Number of bytes of transaction data an L2 block is expected to fit, for the annotations of the pending transactions, e.g. the batch data the batcher posts per L1 block over the L2 blocks per L1 block. No budget if 0",
+		EnvVar: prefixEnvVar("RPC_TXPOOL_DA_BUDGET"),
+	}
+	RPCEnableEvents = cli.BoolFlag{
+		Name:   "rpc.enable-events",
+		Usage:  "Enable the derivation events stream at /events, served as server-sent events",
+		EnvVar: prefixEnvVar("RPC_ENABLE_EVENTS"),
+	}
+	RPCLogRequests = cli.BoolFlag{
+		Name:   "rpc.log-requests",
+		Usage:  "Log the served RPC requests with their method, duration, client and error",
+		EnvVar: prefixEnvVar("RPC_LOG_REQUESTS"),
+	}
+	RPCLogNamespaces = cli.StringFlag{
+		Name:   "rpc.log-namespaces",
+		Usage:  "Comma-separated list of RPC namespaces to log the requests of, e.g. 'kroma,admin'. All namespaces are logged if empty.",
+		EnvVar: prefixEnvVar("RPC_LOG_NAMESPACES"),
+	}
+	RPCLogSampledMethods = cli.StringFlag{
+		Name:   "rpc.log-sampled-methods",
+		Usage:  "Comma-separated list of hot RPC methods of which only every rpc.log-sample-rate-th request is logged",
+		EnvVar: prefixEnvVar("RPC_LOG_SAMPLED_METHODS"),
+		Value:  "kroma_syncStatus",
+	}
+	RPCLogSampleRate = cli.Uint64Flag{
+		Name:   "rpc.log-sample-rate",
+		Usage:  "Log only one of every N requests to the sampled RPC methods",
+		EnvVar: prefixEnvVar("RPC_LOG_SAMPLE_RATE"),
+		Value:  100,
+	}
+	RPCLogSlowThreshold = cli.DurationFlag{
+		Name:   "rpc.log-slow-threshold",
+		Usage:  "Log RPC requests that take longer than this as slow, regardless of sampling and of rpc.log-requests. Disabled if 0.",
+		EnvVar: prefixEnvVar("RPC_LOG_SLOW_THRESHOLD"),
+	}
 
 	/* Optional Flags */
 	L1TrustRPC = cli.BoolFlag{
@@ -87,12 +124,34 @@ var (
 		EnvVar: prefixEnvVar("L1_RPC_MAX_BATCH_SIZE"),
 		Value:  20,
 	}
+	L1RPCBatchLatency = cli.DurationFlag{
+		Name:   "l1.rpc-batch-latency",
+		Usage:  "Maximum time a single L1 RPC request waits for concurrent requests to bundle with, up to the max batch size. Disabled if set to 0.",
+		EnvVar: prefixEnvVar("L1_RPC_BATCH_LATENCY"),
+		Value:  0,
+	}
 	L1HTTPPollInterval = cli.DurationFlag{
 		Name:   "l1.http-poll-interval",
 		Usage:  "Polling interval for latest-block subscription when using an HTTP RPC provider. Ignored for other types of RPC endpoints.",
 		EnvVar: prefixEnvVar("L1_HTTP_POLL_INTERVAL"),
 		Value:  time.Second * 12,
 	}
+	L1BatchMirrorURL = cli.StringFlag{
+		Name:   "l1.batch-mirror.url",
+		Usage:  "Endpoint of a mirror of the batch data posted to L1, read as a fallback for the L1 blocks pruned from the L1 RPC history. Disabled if empty.",
+		EnvVar: prefixEnvVar("L1_BATCH_MIRROR_URL"),
+	}
+	L1BatchMirrorKind = cli.StringFlag{
+		Name:   "l1.batch-mirror.kind",
+		Usage:  "Kind of the batch mirror endpoint. Valid options: http (PUT and GET of <url>/<key>), ipfs (mutable file system through the RPC API of an IPFS node).",
+		EnvVar: prefixEnvVar("L1_BATCH_MIRROR_KIND"),
+		Value:  "http",
+	}
+	L1BatchMirrorPublish = cli.BoolFlag{
+		Name:   "l1.batch-mirror.publish",
+		Usage:  "Publish the batch data of the L1 blocks read from the L1 RPC to the batch mirror.",
+		EnvVar: prefixEnvVar("L1_BATCH_MIRROR_PUBLISH"),
+	}
 	L2EngineJWTSecret = cli.StringFlag{
 		Name:        "l2.jwt-secret",
 		Usage:       "Path to JWT secret key. Keys are 32 bytes, hex encoded in a file. A new key will be generated if left empty.",
@@ -101,6 +160,18 @@ var (
 		Value:       "",
 		Destination: new(string),
 	}
+	L2EngineRPCMaxBatchSize = cli.IntFlag{
+		Name:   "l2.rpc-max-batch-size",
+		Usage:  "Maximum number of concurrent L2 engine RPC requests to bundle, if l2.rpc-batch-latency is set.",
+		EnvVar: prefixEnvVar("L2_ENGINE_RPC_MAX_BATCH_SIZE"),
+		Value:  20,
+	}
+	L2EngineRPCBatchLatency = cli.DurationFlag{
+		Name:   "l2.rpc-batch-latency",
+		Usage:  "Maximum time a single L2 engine RPC request waits for concurrent requests to bundle with, up to the max batch size. Disabled if set to 0.",
+		EnvVar: prefixEnvVar("L2_ENGINE_RPC_BATCH_LATENCY"),
+		Value:  0,
+	}
 	SyncerL1Confs = cli.Uint64Flag{
 		Name:     "syncer.l1-confs",
 		Usage:    "Number of L1 blocks to keep distance from the L1 head before deriving L2 data from. Reorgs are supported, but may be slow to perform.",
@@ -125,6 +196,21 @@ var (
 		Required: false,
 		Value:    0,
 	}
+	ProposerTxSourceRPC = cli.StringFlag{
+		Name:   "proposer.tx-source-rpc",
+		Usage:  "RPC endpoint of an external ordering service that provides the transactions of proposed blocks instead of the tx pool of the engine. Falls back to the tx pool on failure. Disabled if empty.",
+		EnvVar: prefixEnvVar("PROPOSER_TX_SOURCE_RPC"),
+	}
+	ProposerBuilderRPC = cli.StringFlag{
+		Name:   "proposer.builder-rpc",
+		Usage:  "Engine API endpoint of an external block builder that builds proposed blocks in parallel to the engine. The payload of the engine is used if the builder times out or builds an invalid payload. Disabled if empty.",
+		EnvVar: prefixEnvVar("PROPOSER_BUILDER_RPC"),
+	}
+	ProposerBuilderJWTSecret = cli.StringFlag{
+		Name:   "proposer.builder-jwt-secret",
+		Usage:  "Path to the JWT secret key of the engine API of the external block builder. Keys are 32 bytes, hex encoded in a file.",
+		EnvVar: prefixEnvVar("PROPOSER_BUILDER_JWT_SECRET"),
+	}
 	ProposerL1Confs = cli.Uint64Flag{
 		Name:     "proposer.l1-confs",
 		Usage:    "Number of L1 blocks to keep distance from the L1 head as a proposer for picking an L1 origin.",
@@ -132,6 +218,16 @@ var (
 		Required: false,
 		Value:    4,
 	}
+	SyncerShadowPipeline = cli.StringFlag{
+		Name:   "syncer.shadow-pipeline",
+		Usage:  "Name of a candidate derivation pipeline version to run in shadow mode alongside the active one, logging and metering divergences. Disabled if empty.",
+		EnvVar: prefixEnvVar("SYNCER_SHADOW_PIPELINE"),
+	}
+	SyncerL1Only = cli.BoolFlag{
+		Name:   "syncer.l1-only",
+		Usage:  "Run as a verifier and API node that derives exclusively from L1. Disables P2P entirely, and rejects any alt-sync source and unsafe L2 payload. Incompatible with the proposer.",
+		EnvVar: prefixEnvVar("SYNCER_L1_ONLY"),
+	}
 	L1EpochPollIntervalFlag = cli.DurationFlag{
 		Name:     "l1.epoch-poll-interval",
 		Usage:    "Poll interval for retrieving new L1 epoch updates such as safe and finalized block changes. Disabled if 0 or negative.",
@@ -158,7 +254,7 @@ var (
 	}
 	PprofEnabledFlag = cli.BoolFlag{
 		Name:   "pprof.enabled",
-		Usage:  "Enable the pprof server",
+		Usage:  "Enable the diagnostics server, serving the pprof profiles and the expvar variables",
 		EnvVar: prefixEnvVar("PPROF_ENABLED"),
 	}
 	PprofAddrFlag = cli.StringFlag{
@@ -173,6 +269,22 @@ var (
 		Value:  6060,
 		EnvVar: prefixEnvVar("PPROF_PORT"),
 	}
+	PprofAuthTokenFileFlag = cli.StringFlag{
+		Name:   "pprof.auth-token-file",
+		Usage:  "Path to the file of the bearer token required by the pprof server. If not set, the requests are not authorized",
+		EnvVar: prefixEnvVar("PPROF_AUTH_TOKEN_FILE"),
+	}
+	PprofGoroutineDumpFlag = cli.BoolFlag{
+		Name:   "pprof.goroutine-dump",
+		Usage:  "Dump the stacks of all goroutines to stderr on SIGUSR1, without terminating the process",
+		EnvVar: prefixEnvVar("PPROF_GOROUTINE_DUMP"),
+	}
+	DataDir = cli.StringFlag{
+		Name:      "datadir",
+		Usage:     "Data directory of the node, with a directory per L2 chain holding the p2p key and databases and the snapshot log, unless their paths are set explicitly. The layout is migrated automatically on upgrades. If not set, the files are in the paths of their flags",
+		EnvVar:    prefixEnvVar("DATADIR"),
+		TakesFile: true,
+	}
 	SnapshotLog = cli.StringFlag{
 		Name:   "snapshotlog.file",
 		Usage:  "Path to the snapshot log file",
@@ -200,6 +312,33 @@ var (
 		EnvVar:   prefixEnvVar("L2_BACKUP_UNSAFE_SYNC_RPC"),
 		Required: false,
 	}
+	RollupManifest = cli.StringFlag{
+		Name:      "rollup.manifest",
+		Usage:     "Path to a signed manifest of the hashes of the rollup config and the genesis file, verified on start. Requires a trust anchor: rollup.manifest.signers or rollup.manifest.l1-slot",
+		EnvVar:    prefixEnvVar("ROLLUP_MANIFEST"),
+		TakesFile: true,
+	}
+	RollupManifestSigners = cli.StringFlag{
+		Name:   "rollup.manifest.signers",
+		Usage:  "Comma-separated addresses of the maintainer keys trusted to sign the rollup manifest",
+		EnvVar: prefixEnvVar("ROLLUP_MANIFEST_SIGNERS"),
+	}
+	RollupManifestGenesis = cli.StringFlag{
+		Name:      "rollup.manifest.genesis",
+		Usage:     "Path to the L2 genesis file verified against the rollup manifest",
+		EnvVar:    prefixEnvVar("ROLLUP_MANIFEST_GENESIS"),
+		TakesFile: true,
+	}
+	RollupManifestL1Slot = cli.StringFlag{
+		Name:   "rollup.manifest.l1-slot",
+		Usage:  "L1 storage slot holding the hash of the rollup manifest, as <address>:<slot>, verified against the manifest on start",
+		EnvVar: prefixEnvVar("ROLLUP_MANIFEST_L1_SLOT"),
+	}
+	RollupHalt = cli.StringFlag{
+		Name:   "rollup.halt",
+		Usage:  "Halt the node if the required protocol version signaled on L1 is not supported, at or above an upgrade of the given level. Valid options: major, minor, patch. Never halts if empty.",
+		EnvVar: prefixEnvVar("ROLLUP_HALT"),
+	}
 	BackupL2UnsafeSyncRPCTrustRPC = cli.StringFlag{
 		Name: "l2.backup-unsafe-sync-rpc.trustrpc",
 		Usage: "Like l1.trustrpc, configure if response data from the RPC needs to be verified, e.g. blockhash computation." +
@@ -223,27 +362,53 @@ var optionalFlags = []cli.Flag{
 	L1RPCProviderKind,
 	L1RPCRateLimit,
 	L1RPCMaxBatchSize,
+	L1RPCBatchLatency,
 	L1HTTPPollInterval,
+	L1BatchMirrorURL,
+	L1BatchMirrorKind,
+	L1BatchMirrorPublish,
 	L2EngineJWTSecret,
+	L2EngineRPCMaxBatchSize,
+	L2EngineRPCBatchLatency,
 	SyncerL1Confs,
 	ProposerEnabledFlag,
 	ProposerStoppedFlag,
 	ProposerMaxSafeLagFlag,
+	ProposerTxSourceRPC,
+	ProposerBuilderRPC,
+	ProposerBuilderJWTSecret,
 	ProposerL1Confs,
+	SyncerShadowPipeline,
+	SyncerL1Only,
 	L1EpochPollIntervalFlag,
 	RPCEnableAdmin,
+	RPCEnableEvents,
+	RPCTxPoolDABudget,
+	RPCLogRequests,
+	RPCLogNamespaces,
+	RPCLogSampledMethods,
+	RPCLogSampleRate,
+	RPCLogSlowThreshold,
 	MetricsEnabledFlag,
 	MetricsAddrFlag,
 	MetricsPortFlag,
 	PprofEnabledFlag,
 	PprofAddrFlag,
 	PprofPortFlag,
+	PprofAuthTokenFileFlag,
+	PprofGoroutineDumpFlag,
+	DataDir,
+	RollupManifest,
+	RollupManifestSigners,
+	RollupManifestGenesis,
+	RollupManifestL1Slot,
 	SnapshotLog,
 	HeartbeatEnabledFlag,
 	HeartbeatMonikerFlag,
 	HeartbeatURLFlag,
 	BackupL2UnsafeSyncRPC,
 	BackupL2UnsafeSyncRPCTrustRPC,
+	RollupHalt,
 }
 
 // Flags contains the list of configuration options available to the binary.
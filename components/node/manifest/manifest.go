@@ -0,0 +1,206 @@
+// Package manifest verifies the config files of the rollup node against a signed manifest of their hashes, so that
+// a tampered or stale rollup config or genesis file is detected before the node serves the wrong chain.
+package manifest
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// The names of the files in a manifest.
+const (
+	// RollupFile is the rollup config of the node.
+	RollupFile = "rollup"
+	// GenesisFile is the genesis of the L2 chain.
+	GenesisFile = "genesis"
+)
+
+var (
+	// ErrChainIDMismatch is returned when the manifest is of another L2 chain than the rollup config.
+	ErrChainIDMismatch = errors.New("manifest is of another chain")
+	// ErrFileMismatch is returned when the hash of a file differs from its hash in the manifest.
+	ErrFileMismatch = errors.New("file does not match manifest")
+	// ErrUntrustedSigner is returned when the manifest is not signed by one of the trusted signers.
+	ErrUntrustedSigner = errors.New("manifest is not signed by a trusted signer")
+	// ErrOnChainMismatch is returned when the hash of the manifest differs from the hash recorded on L1.
+	ErrOnChainMismatch = errors.New("manifest does not match hash on L1")
+)
+
+// Manifest is the list of the keccak256 hashes of the config files of an L2 chain, signed by its maintainer.
+type Manifest struct {
+	ChainID *big.Int               `json:"chainId"`
+	Files   map[string]common.Hash `json:"files"`
+	// Signature is the signature of the digest of the manifest, see SigningHash.
+	Signature hexutil.Bytes `json:"signature,omitempty"`
+}
+
+// Load reads the manifest at the path.
+func Load(path string) (*Manifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+	var m Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("failed to decode manifest: %w", err)
+	}
+	if m.ChainID == nil {
+		return nil, errors.New("manifest has no chain id")
+	}
+	return &m, nil
+}
+
+// Hash returns the keccak256 hash of the JSON encoding of the manifest without its signature. It is the hash that
+// is recorded on L1.
+func (m *Manifest) Hash() (common.Hash, error) {
+	data, err := json.Marshal(&Manifest{ChainID: m.ChainID, Files: m.Files})
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return crypto.Keccak256Hash(data), nil
+}
+
+// SigningHash returns the digest the manifest is signed over: the EIP-191 personal message of its hash, so that the
+// manifest can be signed with any wallet.
+func (m *Manifest) SigningHash() (common.Hash, error) {
+	hash, err := m.Hash()
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return common.BytesToHash(accounts.TextHash(hash.Bytes())), nil
+}
+
+// Sign signs the manifest with the key.
+func (m *Manifest) Sign(key *ecdsa.PrivateKey) error {
+	digest, err := m.SigningHash()
+	if err != nil {
+		return err
+	}
+	sig, err := crypto.Sign(digest.Bytes(), key)
+	if err != nil {
+		return err
+	}
+	sig[crypto.RecoveryIDOffset] += 27
+	m.Signature = sig
+	return nil
+}
+
+// Signer returns the address that signed the manifest.
+func (m *Manifest) Signer() (common.Address, error) {
+	if len(m.Signature) != crypto.SignatureLength {
+		return common.Address{}, fmt.Errorf("invalid manifest signature length %d", len(m.Signature))
+	}
+	digest, err := m.SigningHash()
+	if err != nil {
+		return common.Address{}, err
+	}
+	sig := make([]byte, crypto.SignatureLength)
+	copy(sig, m.Signature)
+	if sig[crypto.RecoveryIDOffset] >= 27 {
+		sig[crypto.RecoveryIDOffset] -= 27
+	}
+	pub, err := crypto.SigToPub(digest.Bytes(), sig)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("invalid manifest signature: %w", err)
+	}
+	return crypto.PubkeyToAddress(*pub), nil
+}
+
+// HashFile returns the keccak256 hash of the contents of the file.
+func HashFile(path string) (common.Hash, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return crypto.Keccak256Hash(data), nil
+}
+
+// Verify checks that the manifest is of the L2 chain, that the files, by their name in the manifest, match their
+// hashes, and that every file of the manifest is given. With signers, the manifest must be signed by one of them.
+func (m *Manifest) Verify(chainID *big.Int, files map[string]string, signers []common.Address) error {
+	if chainID == nil || m.ChainID.Cmp(chainID) != 0 {
+		return fmt.Errorf("%w: manifest chain id %s, rollup config chain id %s", ErrChainIDMismatch, m.ChainID, chainID)
+	}
+	for name := range m.Files {
+		if _, ok := files[name]; !ok {
+			return fmt.Errorf("%s file of the manifest is not given", name)
+		}
+	}
+	for name, path := range files {
+		expected, ok := m.Files[name]
+		if !ok {
+			return fmt.Errorf("%w: %s file is not in the manifest", ErrFileMismatch, name)
+		}
+		hash, err := HashFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to hash %s file: %w", name, err)
+		}
+		if hash != expected {
+			return fmt.Errorf("%w: %s file %s has hash %s, expected %s", ErrFileMismatch, name, path, hash, expected)
+		}
+	}
+	if len(signers) == 0 {
+		return nil
+	}
+	signer, err := m.Signer()
+	if err != nil {
+		return err
+	}
+	for _, s := range signers {
+		if s == signer {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: signed by %s", ErrUntrustedSigner, signer)
+}
+
+// Slot is the L1 storage slot the hash of the manifest is recorded in.
+type Slot struct {
+	Address common.Address
+	Key     common.Hash
+}
+
+// ParseSlot parses a slot of the form <address>:<slot>.
+func ParseSlot(s string) (*Slot, error) {
+	address, key, ok := strings.Cut(s, ":")
+	if !ok || !common.IsHexAddress(address) {
+		return nil, fmt.Errorf("invalid L1 slot %q, expected <address>:<slot>", s)
+	}
+	slotKey, err := hexutil.DecodeBig(key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid L1 slot %q: %w", s, err)
+	}
+	return &Slot{Address: common.HexToAddress(address), Key: common.BigToHash(slotKey)}, nil
+}
+
+// StorageReader reads the L1 storage with eth_getStorageAt.
+type StorageReader interface {
+	CallContext(ctx context.Context, result any, method string, args ...any) error
+}
+
+// VerifyOnChain checks that the hash of the manifest is the value of the slot at the latest L1 block.
+func (m *Manifest) VerifyOnChain(ctx context.Context, l1 StorageReader, slot *Slot) error {
+	hash, err := m.Hash()
+	if err != nil {
+		return err
+	}
+	var value hexutil.Bytes
+	if err := l1.CallContext(ctx, &value, "eth_getStorageAt", slot.Address, slot.Key, "latest"); err != nil {
+		return fmt.Errorf("failed to read manifest hash on L1: %w", err)
+	}
+	if onChain := common.BytesToHash(value); onChain != hash {
+		return fmt.Errorf("%w: manifest hash %s, slot %s of %s holds %s", ErrOnChainMismatch, hash, slot.Key, slot.Address, onChain)
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package manifest
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, dir, name, content string) string {
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestVerify(t *testing.T) {
+	dir := t.TempDir()
+	rollupPath := writeFile(t, dir, "rollup.json", `{"l2_chain_id":255}`)
+	genesisPath := writeFile(t, dir, "genesis.json", `{"config":{}}`)
+	rollupHash, err := HashFile(rollupPath)
+	require.NoError(t, err)
+	genesisHash, err := HashFile(genesisPath)
+	require.NoError(t, err)
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	signer := crypto.PubkeyToAddress(key.PublicKey)
+	m := &Manifest{ChainID: big.NewInt(255), Files: map[string]common.Hash{RollupFile: rollupHash, GenesisFile: genesisHash}}
+	require.NoError(t, m.Sign(key))
+
+	// the manifest round-trips through its file
+	encoded := `{"chainId":255,"files":{"genesis":"` + genesisHash.Hex() + `","rollup":"` + rollupHash.Hex() +
+		`"},"signature":"` + hexutil.Encode(m.Signature) + `"}`
+	loaded, err := Load(writeFile(t, dir, "manifest.json", encoded))
+	require.NoError(t, err)
+	recovered, err := loaded.Signer()
+	require.NoError(t, err)
+	require.Equal(t, signer, recovered)
+
+	files := map[string]string{RollupFile: rollupPath, GenesisFile: genesisPath}
+	require.NoError(t, loaded.Verify(big.NewInt(255), files, []common.Address{{0x1}, signer}))
+	require.NoError(t, loaded.Verify(big.NewInt(255), files, nil), "the signature is not checked without signers")
+
+	require.ErrorIs(t, loaded.Verify(big.NewInt(256), files, nil), ErrChainIDMismatch)
+	require.ErrorIs(t, loaded.Verify(big.NewInt(255), files, []common.Address{{0x1}}), ErrUntrustedSigner)
+	require.ErrorContains(t, loaded.Verify(big.NewInt(255), map[string]string{RollupFile: rollupPath}, nil), "genesis file of the manifest is not given")
+
+	// a tampered file is detected
+	writeFile(t, dir, "genesis.json", `{"config":{"chainId":1}}`)
+	require.ErrorIs(t, loaded.Verify(big.NewInt(255), files, nil), ErrFileMismatch)
+
+	// a tampered manifest is not signed by the signer anymore
+	loaded.Files[GenesisFile], err = HashFile(genesisPath)
+	require.NoError(t, err)
+	require.NoError(t, loaded.Verify(big.NewInt(255), files, nil))
+	require.ErrorIs(t, loaded.Verify(big.NewInt(255), files, []common.Address{signer}), ErrUntrustedSigner)
+
+	_, err = Load(writeFile(t, dir, "no-chain.json", `{"files":{}}`))
+	require.ErrorContains(t, err, "no chain id")
+}
+
+func TestParseSlot(t *testing.T) {
+	slot, err := ParseSlot("0x4200000000000000000000000000000000000010:0x5")
+	require.NoError(t, err)
+	require.Equal(t, common.HexToAddress("0x4200000000000000000000000000000000000010"), slot.Address)
+	require.Equal(t, common.BigToHash(big.NewInt(5)), slot.Key)
+
+	for _, s := range []string{"", "0x4200000000000000000000000000000000000010", "0x42:0x5", "0x4200000000000000000000000000000000000010:5"} {
+		_, err := ParseSlot(s)
+		require.Error(t, err, s)
+	}
+}
+
+type fakeStorage struct {
+	value hexutil.Bytes
+	err   error
+	args  []any
+}
+
+func (f *fakeStorage) CallContext(_ context.Context, result any, method string, args ...any) error {
+	if method != "eth_getStorageAt" {
+		return errors.New("unexpected method " + method)
+	}
+	f.args = args
+	if f.err != nil {
+		return f.err
+	}
+	*result.(*hexutil.Bytes) = f.value
+	return nil
+}
+
+func TestVerifyOnChain(t *testing.T) {
+	m := &Manifest{ChainID: big.NewInt(255), Files: map[string]common.Hash{RollupFile: {0x1}}}
+	hash, err := m.Hash()
+	require.NoError(t, err)
+	slot := &Slot{Address: common.Address{0x42}, Key: common.Hash{0x5}}
+
+	storage := &fakeStorage{value: hash.Bytes()}
+	require.NoError(t, m.VerifyOnChain(context.Background(), storage, slot))
+	require.Equal(t, []any{slot.Address, slot.Key, "latest"}, storage.args)
+
+	// the signature is not part of the hash recorded on L1
+	m.Signature = make(hexutil.Bytes, crypto.SignatureLength)
+	require.NoError(t, m.VerifyOnChain(context.Background(), storage, slot))
+
+	storage.value = common.Hash{0x1}.Bytes()
+	require.ErrorIs(t, m.VerifyOnChain(context.Background(), storage, slot), ErrOnChainMismatch)
+
+	storage.err = errors.New("boom")
+	require.ErrorContains(t, m.VerifyOnChain(context.Background(), storage, slot), "boom")
+}
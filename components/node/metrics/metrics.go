@@ -31,14 +31,24 @@ const (
 	RPCClientSubsystem = "rpc_client"
 
 	BatchMethod = "<batch>"
+
+	// L1Client, EngineClient, L2SyncClient, TxSourceClient and BuilderClient label the RPC client metrics
+	// with the endpoint the request was sent to.
+	L1Client       = "l1"
+	EngineClient   = "engine"
+	L2SyncClient   = "l2_sync"
+	TxSourceClient = "tx_source"
+	BuilderClient  = "builder"
 )
 
 type Metricer interface {
 	RecordInfo(version string)
 	RecordUp()
+	RecordL1Only(enabled bool)
+	RecordProtocolVersions(local, recommended, required eth.ProtocolVersion, unsupported bool)
 	RecordRPCServerRequest(method string) func()
-	RecordRPCClientRequest(method string) func(err error)
-	RecordRPCClientResponse(method string, err error)
+	RecordRPCClientRequest(client string, method string) func(err error)
+	RecordRPCClientResponse(client string, method string, err error)
 	SetDerivationIdle(status bool)
 	RecordPipelineReset()
 	RecordSequencingError()
@@ -53,7 +63,13 @@ type Metricer interface {
 	RecordL1ReorgDepth(d uint64)
 	RecordProposerInconsistentL1Origin(from eth.BlockID, to eth.BlockID)
 	RecordProposerReset()
+	RecordProposerTxSourceFallback()
+	RecordProposerBuilderPayload()
+	RecordProposerBuilderFallback()
+	RecordShadowDerivationMatch()
+	RecordShadowDerivationDivergence(kind string)
 	RecordGossipEvent(evType int32)
+	RecordGossipPayloadRejection(reason string)
 	IncPeerCount()
 	DecPeerCount()
 	IncStreamCount()
@@ -75,6 +91,11 @@ type Metrics struct {
 	Info *prometheus.GaugeVec
 	Up   prometheus.Gauge
 
+	L1Only prometheus.Gauge
+
+	ProtocolVersions           *prometheus.GaugeVec
+	ProtocolVersionUnsupported prometheus.Gauge
+
 	RPCServerRequestsTotal          *prometheus.CounterVec
 	RPCServerRequestDurationSeconds *prometheus.HistogramVec
 	RPCClientRequestsTotal          *prometheus.CounterVec
@@ -92,6 +113,9 @@ type Metrics struct {
 	SequencingErrors *EventMetrics
 	PublishingErrors *EventMetrics
 
+	ShadowDerivationMatches     *EventMetrics
+	ShadowDerivationDivergences *prometheus.CounterVec
+
 	P2PReqDurationSeconds *prometheus.HistogramVec
 	P2PReqTotal           *prometheus.CounterVec
 	P2PPayloadByNumber    *prometheus.GaugeVec
@@ -100,6 +124,9 @@ type Metrics struct {
 
 	ProposerInconsistentL1Origin *EventMetrics
 	ProposerResets               *EventMetrics
+	ProposerTxSourceFallbacks    *EventMetrics
+	ProposerBuilderPayloads      *EventMetrics
+	ProposerBuilderFallbacks     *EventMetrics
 
 	ProposerBuildingDiffDurationSeconds prometheus.Histogram
 	ProposerBuildingDiffTotal           prometheus.Counter
@@ -130,6 +157,8 @@ type Metrics struct {
 	GossipEventsTotal *prometheus.CounterVec
 	BandwidthTotal    *prometheus.GaugeVec
 
+	GossipPayloadRejectionsTotal *prometheus.CounterVec
+
 	ChannelInputBytes prometheus.Counter
 
 	registry *prometheus.Registry
@@ -162,6 +191,26 @@ func NewMetrics(procName string) *Metrics {
 			Name:      "up",
 			Help:      "1 if the kroma-node has finished starting up",
 		}),
+		L1Only: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "l1_only",
+			Help:      "1 if the kroma-node derives exclusively from L1, with P2P and alt-sync disabled",
+		}),
+
+		ProtocolVersions: factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "protocol_versions",
+			Help:      "Pseudo-metric tracking the local and the signaled recommended and required protocol versions",
+		}, []string{
+			"local",
+			"recommended",
+			"required",
+		}),
+		ProtocolVersionUnsupported: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "protocol_version_unsupported",
+			Help:      "1 if the required protocol version is not supported by the kroma-node",
+		}),
 
 		RPCServerRequestsTotal: factory.NewCounterVec(prometheus.CounterOpts{
 			Namespace: ns,
@@ -186,6 +235,7 @@ func NewMetrics(procName string) *Metrics {
 			Name:      "requests_total",
 			Help:      "Total RPC requests initiated by the kroma-node's RPC client",
 		}, []string{
+			"client",
 			"method",
 		}),
 		RPCClientRequestDurationSeconds: factory.NewHistogramVec(prometheus.HistogramOpts{
@@ -195,6 +245,7 @@ func NewMetrics(procName string) *Metrics {
 			Buckets:   []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10},
 			Help:      "Histogram of RPC client request durations",
 		}, []string{
+			"client",
 			"method",
 		}),
 		RPCClientResponsesTotal: factory.NewCounterVec(prometheus.CounterOpts{
@@ -203,6 +254,7 @@ func NewMetrics(procName string) *Metrics {
 			Name:      "responses_total",
 			Help:      "Total RPC request responses received by the kroma-node's RPC client",
 		}, []string{
+			"client",
 			"method",
 			"error",
 		}),
@@ -222,8 +274,20 @@ func NewMetrics(procName string) *Metrics {
 		SequencingErrors: NewEventMetrics(factory, ns, "sequencing_errors", "sequencing errors"),
 		PublishingErrors: NewEventMetrics(factory, ns, "publishing_errors", "p2p publishing errors"),
 
+		ShadowDerivationMatches: NewEventMetrics(factory, ns, "shadow_derivation_matches", "payload attributes derived the same by the shadow derivation pipeline"),
+		ShadowDerivationDivergences: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "shadow_derivation_divergences_total",
+			Help:      "Divergences of the shadow derivation pipeline from the active pipeline, by kind",
+		}, []string{
+			"kind",
+		}),
+
 		ProposerInconsistentL1Origin: NewEventMetrics(factory, ns, "proposer_inconsistent_l1_origin", "events when the proposer selects an inconsistent L1 origin"),
 		ProposerResets:               NewEventMetrics(factory, ns, "proposer_resets", "proposer resets"),
+		ProposerTxSourceFallbacks:    NewEventMetrics(factory, ns, "proposer_tx_source_fallbacks", "blocks the proposer built from the tx pool after failing to build them with the tx source"),
+		ProposerBuilderPayloads:      NewEventMetrics(factory, ns, "proposer_builder_payloads", "proposed blocks built by the external block builder"),
+		ProposerBuilderFallbacks:     NewEventMetrics(factory, ns, "proposer_builder_fallbacks", "proposed blocks built by the engine after the external block builder failed to build them"),
 
 		UnsafePayloadsBufferLen: factory.NewGauge(prometheus.GaugeOpts{
 			Namespace: ns,
@@ -323,6 +387,14 @@ func NewMetrics(procName string) *Metrics {
 		}, []string{
 			"type",
 		}),
+		GossipPayloadRejectionsTotal: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Subsystem: "p2p",
+			Name:      "gossip_payload_rejections_total",
+			Help:      "Count of gossiped payloads rejected for their content, by reason",
+		}, []string{
+			"reason",
+		}),
 		BandwidthTotal: factory.NewGaugeVec(prometheus.GaugeOpts{
 			Namespace: ns,
 			Subsystem: "p2p",
@@ -425,6 +497,27 @@ func (m *Metrics) RecordUp() {
 	m.Up.Set(1)
 }
 
+// RecordL1Only sets the l1_only metric to 1 if the node runs in L1-only mode.
+func (m *Metrics) RecordL1Only(enabled bool) {
+	if enabled {
+		m.L1Only.Set(1)
+	} else {
+		m.L1Only.Set(0)
+	}
+}
+
+// RecordProtocolVersions sets a pseudo-metric that contains the local and the signaled protocol versions,
+// and flags whether the required protocol version is unsupported.
+func (m *Metrics) RecordProtocolVersions(local, recommended, required eth.ProtocolVersion, unsupported bool) {
+	m.ProtocolVersions.Reset()
+	m.ProtocolVersions.WithLabelValues(local.String(), recommended.String(), required.String()).Set(1)
+	if unsupported {
+		m.ProtocolVersionUnsupported.Set(1)
+	} else {
+		m.ProtocolVersionUnsupported.Set(0)
+	}
+}
+
 // RecordRPCServerRequest is a helper method to record an incoming RPC
 // call to the kroma-node's RPC server. It bumps the requests metric,
 // and tracks how long it takes to serve a response.
@@ -439,11 +532,11 @@ func (m *Metrics) RecordRPCServerRequest(method string) func() {
 // RecordRPCClientRequest is a helper method to record an RPC client
 // request. It bumps the requests metric, tracks the response
 // duration, and records the response's error code.
-func (m *Metrics) RecordRPCClientRequest(method string) func(err error) {
-	m.RPCClientRequestsTotal.WithLabelValues(method).Inc()
-	timer := prometheus.NewTimer(m.RPCClientRequestDurationSeconds.WithLabelValues(method))
+func (m *Metrics) RecordRPCClientRequest(client string, method string) func(err error) {
+	m.RPCClientRequestsTotal.WithLabelValues(client, method).Inc()
+	timer := prometheus.NewTimer(m.RPCClientRequestDurationSeconds.WithLabelValues(client, method))
 	return func(err error) {
-		m.RecordRPCClientResponse(method, err)
+		m.RecordRPCClientResponse(client, method, err)
 		timer.ObserveDuration()
 	}
 }
@@ -454,7 +547,7 @@ func (m *Metrics) RecordRPCClientRequest(method string) func(err error) {
 // into rpc_<error code>, HTTP errors are converted into
 // http_<status code>, and everything else is converted into
 // <unknown>.
-func (m *Metrics) RecordRPCClientResponse(method string, err error) {
+func (m *Metrics) RecordRPCClientResponse(client string, method string, err error) {
 	var errStr string
 	var rpcErr rpc.Error
 	var httpErr rpc.HTTPError
@@ -469,7 +562,7 @@ func (m *Metrics) RecordRPCClientResponse(method string, err error) {
 	} else {
 		errStr = "<unknown>"
 	}
-	m.RPCClientResponsesTotal.WithLabelValues(method, errStr).Inc()
+	m.RPCClientResponsesTotal.WithLabelValues(client, method, errStr).Inc()
 }
 
 func (m *Metrics) SetDerivationIdle(status bool) {
@@ -550,10 +643,34 @@ func (m *Metrics) RecordProposerReset() {
 	m.ProposerResets.RecordEvent()
 }
 
+func (m *Metrics) RecordProposerTxSourceFallback() {
+	m.ProposerTxSourceFallbacks.RecordEvent()
+}
+
+func (m *Metrics) RecordProposerBuilderPayload() {
+	m.ProposerBuilderPayloads.RecordEvent()
+}
+
+func (m *Metrics) RecordProposerBuilderFallback() {
+	m.ProposerBuilderFallbacks.RecordEvent()
+}
+
+func (m *Metrics) RecordShadowDerivationMatch() {
+	m.ShadowDerivationMatches.RecordEvent()
+}
+
+func (m *Metrics) RecordShadowDerivationDivergence(kind string) {
+	m.ShadowDerivationDivergences.WithLabelValues(kind).Inc()
+}
+
 func (m *Metrics) RecordGossipEvent(evType int32) {
 	m.GossipEventsTotal.WithLabelValues(pb.TraceEvent_Type_name[evType]).Inc()
 }
 
+func (m *Metrics) RecordGossipPayloadRejection(reason string) {
+	m.GossipPayloadRejectionsTotal.WithLabelValues(reason).Inc()
+}
+
 func (m *Metrics) IncPeerCount() {
 	m.PeerCount.Inc()
 }
@@ -655,15 +772,21 @@ func (n *noopMetricer) RecordInfo(version string) {
 func (n *noopMetricer) RecordUp() {
 }
 
+func (n *noopMetricer) RecordL1Only(enabled bool) {
+}
+
+func (n *noopMetricer) RecordProtocolVersions(local, recommended, required eth.ProtocolVersion, unsupported bool) {
+}
+
 func (n *noopMetricer) RecordRPCServerRequest(method string) func() {
 	return func() {}
 }
 
-func (n *noopMetricer) RecordRPCClientRequest(method string) func(err error) {
+func (n *noopMetricer) RecordRPCClientRequest(client string, method string) func(err error) {
 	return func(err error) {}
 }
 
-func (n *noopMetricer) RecordRPCClientResponse(method string, err error) {
+func (n *noopMetricer) RecordRPCClientResponse(client string, method string, err error) {
 }
 
 func (n *noopMetricer) SetDerivationIdle(status bool) {
@@ -708,9 +831,27 @@ func (n *noopMetricer) RecordProposerInconsistentL1Origin(from eth.BlockID, to e
 func (n *noopMetricer) RecordProposerReset() {
 }
 
+func (n *noopMetricer) RecordProposerTxSourceFallback() {
+}
+
+func (n *noopMetricer) RecordProposerBuilderPayload() {
+}
+
+func (n *noopMetricer) RecordProposerBuilderFallback() {
+}
+
+func (n *noopMetricer) RecordShadowDerivationMatch() {
+}
+
+func (n *noopMetricer) RecordShadowDerivationDivergence(kind string) {
+}
+
 func (n *noopMetricer) RecordGossipEvent(evType int32) {
 }
 
+func (n *noopMetricer) RecordGossipPayloadRejection(reason string) {
+}
+
 func (n *noopMetricer) SetPeerScores(scores map[string]float64) {
 }
 
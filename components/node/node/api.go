@@ -1,9 +1,11 @@
 package node
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"math/big"
+	"sort"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
@@ -15,6 +17,7 @@ import (
 	"github.com/kroma-network/kroma/bindings/predeploys"
 	"github.com/kroma-network/kroma/components/node/eth"
 	"github.com/kroma-network/kroma/components/node/rollup"
+	"github.com/kroma-network/kroma/components/node/rollup/derive"
 	"github.com/kroma-network/kroma/components/node/version"
 )
 
@@ -24,6 +27,10 @@ type l2EthClient interface {
 	// GetProof returns a proof of the account, it may return a nil result without error if the address was not found.
 	// Optionally keys of the account storage trie can be specified to include with corresponding values in the proof.
 	GetProof(ctx context.Context, address common.Address, storage []common.Hash, blockTag string) (*eth.AccountResult, error)
+	InfoAndTxsByLabel(ctx context.Context, label eth.BlockLabel) (eth.BlockInfo, types.Transactions, error)
+	EstimateGas(ctx context.Context, args any) (uint64, error)
+	MaxPriorityFeePerGas(ctx context.Context) (*big.Int, error)
+	TxPoolContent(ctx context.Context) (*eth.TxPoolContent, error)
 }
 
 type driverClient interface {
@@ -32,6 +39,7 @@ type driverClient interface {
 	ResetDerivationPipeline(context.Context) error
 	StartProposer(ctx context.Context, blockHash common.Hash) error
 	StopProposer(context.Context) (common.Hash, error)
+	DerivationState(ctx context.Context) (*derive.DerivationState, error)
 }
 
 type rpcMetrics interface {
@@ -69,12 +77,22 @@ func (n *adminAPI) StopProposer(ctx context.Context) (common.Hash, error) {
 	return n.dr.StopProposer(ctx)
 }
 
+func (n *adminAPI) DerivationState(ctx context.Context) (*derive.DerivationState, error) {
+	recordDur := n.m.RecordRPCServerRequest("admin_derivationState")
+	defer recordDur()
+	return n.dr.DerivationState(ctx)
+}
+
 type nodeAPI struct {
 	config *rollup.Config
 	client l2EthClient
 	dr     driverClient
 	log    log.Logger
 	m      rpcMetrics
+
+	// daBudget is the number of bytes of transaction data a block is expected to fit, for the annotations of the
+	// pending transactions, no budget if 0
+	daBudget uint64
 }
 
 func NewNodeAPI(config *rollup.Config, l2Client l2EthClient, dr driverClient, log log.Logger, m rpcMetrics) *nodeAPI {
@@ -90,7 +108,30 @@ func NewNodeAPI(config *rollup.Config, l2Client l2EthClient, dr driverClient, lo
 func (n *nodeAPI) OutputAtBlock(ctx context.Context, number hexutil.Uint64) (*eth.OutputResponse, error) {
 	recordDur := n.m.RecordRPCServerRequest("kroma_outputAtBlock")
 	defer recordDur()
+	return n.outputAtBlock(ctx, number)
+}
+
+// VerifyOutputProposal recomputes the output root at the given block and compares it with the proposed one.
+// The locally computed output is returned as evidence of the verdict.
+func (n *nodeAPI) VerifyOutputProposal(ctx context.Context, outputRoot eth.Bytes32, number hexutil.Uint64) (*eth.OutputVerificationResponse, error) {
+	recordDur := n.m.RecordRPCServerRequest("kroma_verifyOutputProposal")
+	defer recordDur()
 
+	output, err := n.outputAtBlock(ctx, number)
+	if err != nil {
+		return nil, err
+	}
+
+	return &eth.OutputVerificationResponse{
+		Valid:              output.OutputRoot == outputRoot,
+		ProposedOutputRoot: outputRoot,
+		Safe:               uint64(number) <= output.Status.SafeL2.Number,
+		Finalized:          uint64(number) <= output.Status.FinalizedL2.Number,
+		LocalOutput:        output,
+	}, nil
+}
+
+func (n *nodeAPI) outputAtBlock(ctx context.Context, number hexutil.Uint64) (*eth.OutputResponse, error) {
 	ref, nextRef, status, err := n.dr.BlockRefsWithStatus(ctx, uint64(number))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get L2 block ref with sync status: %w", err)
@@ -249,6 +290,217 @@ func (n *nodeAPI) OutputWithProofAtBlock(ctx context.Context, number hexutil.Uin
 	}, nil
 }
 
+// EstimateTotalFee estimates the total fee of the given transaction call, which is the sum of
+// the L2 execution fee and the L1 data fee under the L1 fee parameters of the latest L2 block.
+func (n *nodeAPI) EstimateTotalFee(ctx context.Context, args eth.TxCallArgs) (*eth.FeeEstimate, error) {
+	recordDur := n.m.RecordRPCServerRequest("kroma_estimateTotalFee")
+	defer recordDur()
+
+	head, l1Info, err := n.latestL1Info(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	gas, err := n.client.EstimateGas(ctx, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to estimate gas: %w", err)
+	}
+
+	tip := (*big.Int)(args.MaxPriorityFeePerGas)
+	if tip == nil {
+		tip, err = n.client.MaxPriorityFeePerGas(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get max priority fee per gas: %w", err)
+		}
+	}
+	gasPrice := new(big.Int).Add(head.BaseFee(), tip)
+	if args.MaxFeePerGas != nil && gasPrice.Cmp(args.MaxFeePerGas.ToInt()) > 0 {
+		gasPrice = new(big.Int).Set(args.MaxFeePerGas.ToInt())
+	}
+	executionFee := new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(gas))
+
+	// The L1 data fee is charged for the signed transaction as posted to L1.
+	txData, err := estimationTxData(args, n.config.L2ChainID, gas, gasPrice, tip)
+	if err != nil {
+		return nil, err
+	}
+	overhead := new(big.Int).SetBytes(l1Info.L1FeeOverhead[:])
+	scalar := new(big.Int).SetBytes(l1Info.L1FeeScalar[:])
+	dataGas := rollupDataGas(txData)
+	l1Fee := types.L1Cost(dataGas, l1Info.BaseFee, overhead, scalar)
+
+	return &eth.FeeEstimate{
+		GasLimit:     hexutil.Uint64(gas),
+		GasPrice:     (*hexutil.Big)(gasPrice),
+		ExecutionFee: (*hexutil.Big)(executionFee),
+		L1GasUsed:    hexutil.Uint64(dataGas + overhead.Uint64()),
+		L1BaseFee:    (*hexutil.Big)(l1Info.BaseFee),
+		L1Fee:        (*hexutil.Big)(l1Fee),
+		TotalFee:     (*hexutil.Big)(new(big.Int).Add(executionFee, l1Fee)),
+	}, nil
+}
+
+// latestL1Info returns the latest L2 block with its L1 attributes.
+func (n *nodeAPI) latestL1Info(ctx context.Context) (eth.BlockInfo, *derive.L1BlockInfo, error) {
+	head, txs, err := n.client.InfoAndTxsByLabel(ctx, eth.Unsafe)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get latest L2 block: %w", err)
+	}
+	if len(txs) == 0 {
+		return nil, nil, fmt.Errorf("latest L2 block %s has no L1 info deposit transaction", head.Hash())
+	}
+	l1Info, err := derive.L1InfoDepositTxData(txs[0].Data())
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse L1 info deposit transaction: %w", err)
+	}
+	return head, &l1Info, nil
+}
+
+// PendingTransactions returns the pending transactions of the engine expected in the next block, in the order they
+// would be included in it, annotated with their fees under the latest L2 block. The transactions are listed up to the
+// first one exceeding the gas limit of the latest block or the data availability budget, the others are only counted.
+func (n *nodeAPI) PendingTransactions(ctx context.Context) (*eth.PendingTransactions, error) {
+	recordDur := n.m.RecordRPCServerRequest("kroma_pendingTransactions")
+	defer recordDur()
+
+	head, l1Info, err := n.latestL1Info(ctx)
+	if err != nil {
+		return nil, err
+	}
+	content, err := n.client.TxPoolContent(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transaction pool content: %w", err)
+	}
+
+	baseFee := head.BaseFee()
+	overhead := new(big.Int).SetBytes(l1Info.L1FeeOverhead[:])
+	scalar := new(big.Int).SetBytes(l1Info.L1FeeScalar[:])
+	annotate := func(tx *eth.TxPoolTransaction) eth.PendingTransaction {
+		gasPrice := new(big.Int).Add(baseFee, tx.Tx.GasTipCap())
+		if gasPrice.Cmp(tx.Tx.GasFeeCap()) > 0 {
+			gasPrice = tx.Tx.GasFeeCap()
+		}
+		executionFee := new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(tx.Tx.Gas()))
+		l1Fee := types.L1Cost(tx.Tx.RollupDataGas().DataGas(), l1Info.BaseFee, overhead, scalar)
+		var l1FeeShare float64
+		if total := new(big.Int).Add(executionFee, l1Fee); total.Sign() > 0 {
+			l1FeeShare, _ = new(big.Float).Quo(new(big.Float).SetInt(l1Fee), new(big.Float).SetInt(total)).Float64()
+		}
+		return eth.PendingTransaction{
+			Hash:                 tx.Tx.Hash(),
+			From:                 tx.From,
+			Nonce:                hexutil.Uint64(tx.Tx.Nonce()),
+			Gas:                  hexutil.Uint64(tx.Tx.Gas()),
+			MaxFeePerGas:         (*hexutil.Big)(tx.Tx.GasFeeCap()),
+			MaxPriorityFeePerGas: (*hexutil.Big)(tx.Tx.GasTipCap()),
+			Size:                 hexutil.Uint64(tx.Tx.Size()),
+			ExecutionFee:         (*hexutil.Big)(executionFee),
+			L1Fee:                (*hexutil.Big)(l1Fee),
+			L1FeeShare:           l1FeeShare,
+		}
+	}
+
+	byHash := make(map[common.Hash]*eth.TxPoolTransaction)
+	byAccount := make(map[common.Address]types.Transactions)
+	for from, txs := range content.Pending {
+		for _, tx := range txs {
+			byHash[tx.Tx.Hash()] = tx
+			byAccount[from] = append(byAccount[from], tx.Tx)
+		}
+		sort.Sort(types.TxByNonce(byAccount[from]))
+	}
+
+	res := &eth.PendingTransactions{
+		BaseFee:   (*hexutil.Big)(baseFee),
+		L1BaseFee: (*hexutil.Big)(l1Info.BaseFee),
+		GasLimit:  hexutil.Uint64(head.GasLimit()),
+		DABudget:  hexutil.Uint64(n.daBudget),
+	}
+	for _, txs := range content.Queued {
+		res.Queued += hexutil.Uint64(len(txs))
+	}
+
+	// the sequencer includes the transactions by price, in the nonce order of every sender
+	signer := types.LatestSignerForChainID(n.config.L2ChainID)
+	ordered := types.NewTransactionsByPriceAndNonce(signer, byAccount, baseFee)
+	var size, gas uint64
+	for tx := ordered.Peek(); tx != nil; tx = ordered.Peek() {
+		ordered.Shift()
+		ptx, ok := byHash[tx.Hash()]
+		if !ok {
+			continue
+		}
+		delete(byHash, tx.Hash())
+		size += tx.Size()
+		gas += tx.Gas()
+		cumulativeSize, cumulativeGas := hexutil.Uint64(size), hexutil.Uint64(gas)
+		annotated := annotate(ptx)
+		annotated.CumulativeSize = &cumulativeSize
+		annotated.CumulativeGas = &cumulativeGas
+		annotated.ExceedsGasLimit = gas > head.GasLimit()
+		annotated.ExceedsDABudget = n.daBudget != 0 && size > n.daBudget
+		res.Pending = append(res.Pending, annotated)
+		if annotated.ExceedsGasLimit || annotated.ExceedsDABudget {
+			break
+		}
+	}
+
+	// the other transactions are not expected in the next block, or cannot be included at the current base fee
+	for _, tx := range byHash {
+		if tx.Tx.GasFeeCap().Cmp(baseFee) < 0 {
+			res.Underpriced++
+		} else {
+			res.Remaining++
+		}
+	}
+	return res, nil
+}
+
+// placeholderSigValue is the R and S values of the signature of the transactions encoded to estimate their L1 data fee.
+// The bytes of a signature are mostly non-zero, so the non-zero placeholder does not underestimate the fee.
+var placeholderSigValue = new(big.Int).SetBytes(bytes.Repeat([]byte{0xff}, 32))
+
+// estimationTxData returns the encoding of the transaction of the call arguments, signed with a placeholder
+// signature, to estimate the L1 data fee charged for it.
+func estimationTxData(args eth.TxCallArgs, chainID *big.Int, gas uint64, gasFeeCap *big.Int, gasTipCap *big.Int) ([]byte, error) {
+	tx := &types.DynamicFeeTx{
+		ChainID:   chainID,
+		To:        args.To,
+		Gas:       gas,
+		GasFeeCap: gasFeeCap,
+		GasTipCap: gasTipCap,
+		V:         common.Big1,
+		R:         placeholderSigValue,
+		S:         placeholderSigValue,
+	}
+	if args.Nonce != nil {
+		tx.Nonce = uint64(*args.Nonce)
+	}
+	if args.Value != nil {
+		tx.Value = args.Value.ToInt()
+	}
+	if args.Data != nil {
+		tx.Data = *args.Data
+	}
+	data, err := types.NewTx(tx).MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode transaction: %w", err)
+	}
+	return data, nil
+}
+
+func rollupDataGas(data []byte) uint64 {
+	var gasData types.RollupGasData
+	for _, b := range data {
+		if b == 0 {
+			gasData.Zeroes++
+		} else {
+			gasData.Ones++
+		}
+	}
+	return gasData.DataGas()
+}
+
 func (n *nodeAPI) SyncStatus(ctx context.Context) (*eth.SyncStatus, error) {
 	recordDur := n.m.RecordRPCServerRequest("kroma_syncStatus")
 	defer recordDur()
@@ -261,6 +513,13 @@ func (n *nodeAPI) RollupConfig(_ context.Context) (*rollup.Config, error) {
 	return n.config, nil
 }
 
+// ProtocolVersion returns the protocol version supported by the node.
+func (n *nodeAPI) ProtocolVersion(ctx context.Context) (*eth.NodeProtocolVersion, error) {
+	recordDur := n.m.RecordRPCServerRequest("kroma_protocolVersion")
+	defer recordDur()
+	return &eth.NodeProtocolVersion{ProtocolVersion: rollup.SupportedProtocolVersion}, nil
+}
+
 func (n *nodeAPI) Version(ctx context.Context) (string, error) {
 	recordDur := n.m.RecordRPCServerRequest("kroma_version")
 	defer recordDur()
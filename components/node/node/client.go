@@ -12,6 +12,7 @@ import (
 
 	"github.com/kroma-network/kroma/components/node/client"
 	"github.com/kroma-network/kroma/components/node/rollup"
+	"github.com/kroma-network/kroma/components/node/rollup/derive"
 	"github.com/kroma-network/kroma/components/node/sources"
 )
 
@@ -28,6 +29,29 @@ type L2SyncEndpointSetup interface {
 	Check() error
 }
 
+type TxSourceEndpointSetup interface {
+	// Setup a RPC client to an external ordering service to pull the transactions of proposed blocks from.
+	// It may return a nil client with nil error if the external tx source is not enabled.
+	Setup(ctx context.Context, log log.Logger) (cl client.RPC, err error)
+	Check() error
+}
+
+type BuilderEndpointSetup interface {
+	// Setup a RPC client to an external block builder to request proposed blocks from.
+	// It may return a nil client with nil error if the builder is not enabled.
+	Setup(ctx context.Context, log log.Logger) (cl client.RPC, err error)
+	Check() error
+}
+
+type BatchMirrorEndpointSetup interface {
+	// Setup a mirror of the batch data posted to L1, to read the L1 blocks pruned from the L1 RPC history from.
+	// It may return a nil mirror with nil error if the batch mirror is not enabled.
+	Setup(ctx context.Context, log log.Logger, rollupCfg *rollup.Config) (mirror derive.BatchMirror, err error)
+	// Publish returns whether the batch data of the L1 blocks read from the L1 RPC is published to the mirror.
+	Publish() bool
+	Check() error
+}
+
 type L1EndpointSetup interface {
 	// Setup a RPC client to a L1 node to pull rollup input-data from.
 	// The results of the RPC client may be trusted for faster processing, or strictly validated.
@@ -42,6 +66,13 @@ type L2EndpointConfig struct {
 	// JWT secrets for L2 Engine API authentication during HTTP or initial Websocket communication.
 	// Any value for an IPC connection.
 	L2EngineJWTSecret [32]byte
+
+	// BatchSize specifies the maximum number of concurrent requests bundled into a batch, if BatchLatency is set.
+	BatchSize int
+
+	// BatchLatency specifies the maximum time a request waits for concurrent requests to bundle with.
+	// Setting this to 0 disables the bundling.
+	BatchLatency time.Duration
 }
 
 var _ L2EndpointSetup = (*L2EndpointConfig)(nil)
@@ -50,6 +81,12 @@ func (cfg *L2EndpointConfig) Check() error {
 	if cfg.L2EngineAddr == "" {
 		return errors.New("empty L2 Engine Address")
 	}
+	if cfg.BatchLatency < 0 {
+		return errors.New("batch latency cannot be negative")
+	}
+	if cfg.BatchLatency > 0 && (cfg.BatchSize < 1 || cfg.BatchSize > 500) {
+		return fmt.Errorf("batch size is invalid or unreasonable: %d", cfg.BatchSize)
+	}
 
 	return nil
 }
@@ -59,7 +96,11 @@ func (cfg *L2EndpointConfig) Setup(ctx context.Context, log log.Logger, rollupCf
 		return nil, nil, err
 	}
 	auth := rpc.WithHTTPAuth(gn.NewJWTAuth(cfg.L2EngineJWTSecret))
-	l2Node, err := client.NewRPC(ctx, log, cfg.L2EngineAddr, client.WithGethRPCOptions(auth))
+	opts := []client.RPCOption{client.WithGethRPCOptions(auth)}
+	if cfg.BatchLatency != 0 {
+		opts = append(opts, client.WithCallBatching(cfg.BatchSize, cfg.BatchLatency))
+	}
+	l2Node, err := client.NewRPC(ctx, log, cfg.L2EngineAddr, opts...)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -129,6 +170,94 @@ func (cfg *PreparedL2SyncEndpoint) Check() error {
 	return nil
 }
 
+// TxSourceEndpointConfig contains configuration for the external ordering service of the proposer
+type TxSourceEndpointConfig struct {
+	// Address of the ordering service RPC, may be empty if the proposer uses the tx pool of the engine.
+	TxSourceAddr string
+}
+
+var _ TxSourceEndpointSetup = (*TxSourceEndpointConfig)(nil)
+
+// Setup creates an RPC client to pull the transactions of proposed blocks from.
+// It will return nil without error if no tx source is configured.
+func (cfg *TxSourceEndpointConfig) Setup(ctx context.Context, log log.Logger) (client.RPC, error) {
+	if cfg.TxSourceAddr == "" {
+		return nil, nil
+	}
+	return client.NewRPC(ctx, log, cfg.TxSourceAddr)
+}
+
+func (cfg *TxSourceEndpointConfig) Check() error {
+	return nil
+}
+
+// BuilderEndpointConfig contains configuration for the external block builder of the proposer
+type BuilderEndpointConfig struct {
+	// Address of the engine API of the builder, may be empty if the proposer builds blocks with the engine only.
+	BuilderAddr string
+
+	// JWT secret for the engine API authentication of the builder.
+	BuilderJWTSecret [32]byte
+}
+
+var _ BuilderEndpointSetup = (*BuilderEndpointConfig)(nil)
+
+// Setup creates an RPC client to request proposed blocks from.
+// It will return nil without error if no builder is configured.
+func (cfg *BuilderEndpointConfig) Setup(ctx context.Context, log log.Logger) (client.RPC, error) {
+	if cfg.BuilderAddr == "" {
+		return nil, nil
+	}
+	auth := rpc.WithHTTPAuth(gn.NewJWTAuth(cfg.BuilderJWTSecret))
+	return client.NewRPC(ctx, log, cfg.BuilderAddr, client.WithGethRPCOptions(auth))
+}
+
+func (cfg *BuilderEndpointConfig) Check() error {
+	return nil
+}
+
+// BatchMirrorEndpointConfig contains configuration for the mirror of the batch data posted to L1
+type BatchMirrorEndpointConfig struct {
+	// Address of the mirror, may be empty if the batch data is only read from L1.
+	BatchMirrorAddr string
+
+	// BatchMirrorKind is the kind of the mirror endpoint: http or ipfs.
+	BatchMirrorKind string
+
+	// BatchMirrorPublish publishes the batch data read from L1 to the mirror.
+	BatchMirrorPublish bool
+}
+
+var _ BatchMirrorEndpointSetup = (*BatchMirrorEndpointConfig)(nil)
+
+// Setup creates the mirror to read the batch data of pruned L1 blocks from.
+// It will return nil without error if no batch mirror is configured.
+func (cfg *BatchMirrorEndpointConfig) Setup(ctx context.Context, log log.Logger, rollupCfg *rollup.Config) (derive.BatchMirror, error) {
+	switch {
+	case cfg.BatchMirrorAddr == "":
+		return nil, nil
+	case cfg.BatchMirrorKind == "ipfs":
+		// the batch data of each chain is kept apart in the file system of a shared IPFS node
+		return sources.NewIPFSBatchMirror(cfg.BatchMirrorAddr, fmt.Sprintf("kroma/%d/batches", rollupCfg.L2ChainID)), nil
+	default:
+		return sources.NewHTTPBatchMirror(cfg.BatchMirrorAddr), nil
+	}
+}
+
+func (cfg *BatchMirrorEndpointConfig) Publish() bool {
+	return cfg.BatchMirrorPublish
+}
+
+func (cfg *BatchMirrorEndpointConfig) Check() error {
+	if cfg.BatchMirrorAddr == "" {
+		return nil
+	}
+	if cfg.BatchMirrorKind != "http" && cfg.BatchMirrorKind != "ipfs" {
+		return fmt.Errorf("unknown batch mirror kind: %q", cfg.BatchMirrorKind)
+	}
+	return nil
+}
+
 type L1EndpointConfig struct {
 	L1NodeAddr string // Address of L1 User JSON-RPC endpoint to use (eth namespace required)
 
@@ -147,6 +276,10 @@ type L1EndpointConfig struct {
 	// BatchSize specifies the maximum batch-size, which also applies as L1 rate-limit burst amount (if set).
 	BatchSize int
 
+	// BatchLatency specifies the maximum time a single request waits for concurrent requests
+	// to bundle with, up to BatchSize requests. Setting this to 0 disables the bundling.
+	BatchLatency time.Duration
+
 	// HttpPollInterval specifies the interval between polling for the latest L1 block,
 	// when the RPC is detected to be an HTTP type.
 	// It is recommended to use websockets or IPC for efficient following of the changing block.
@@ -163,6 +296,9 @@ func (cfg *L1EndpointConfig) Check() error {
 	if cfg.RateLimit < 0 {
 		return fmt.Errorf("rate limit cannot be negative")
 	}
+	if cfg.BatchLatency < 0 {
+		return fmt.Errorf("batch latency cannot be negative")
+	}
 	return nil
 }
 
@@ -174,6 +310,9 @@ func (cfg *L1EndpointConfig) Setup(ctx context.Context, log log.Logger, rollupCf
 	if cfg.RateLimit != 0 {
 		opts = append(opts, client.WithRateLimit(cfg.RateLimit, cfg.BatchSize))
 	}
+	if cfg.BatchLatency != 0 {
+		opts = append(opts, client.WithCallBatching(cfg.BatchSize, cfg.BatchLatency))
+	}
 
 	l1Node, err := client.NewRPC(ctx, log, cfg.L1NodeAddr, opts...)
 	if err != nil {
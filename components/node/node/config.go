@@ -6,8 +6,10 @@ import (
 	"math"
 	"time"
 
+	"github.com/kroma-network/kroma/components/node/manifest"
 	"github.com/kroma-network/kroma/components/node/p2p"
 	"github.com/kroma-network/kroma/components/node/rollup"
+	"github.com/kroma-network/kroma/components/node/rollup/derive"
 	"github.com/kroma-network/kroma/components/node/rollup/driver"
 	kpprof "github.com/kroma-network/kroma/utils/service/pprof"
 )
@@ -36,15 +38,38 @@ type Config struct {
 	// Used to poll the L1 for new finalized or safe blocks
 	L1EpochPollInterval time.Duration
 
+	// RollupHalt is the halt option for unsupported required protocol versions:
+	// the node halts if the required protocol upgrade is at or above this level (major, minor or patch).
+	// The node never halts if empty.
+	RollupHalt string
+
+	// RollupManifest is the manifest the rollup config was verified against on load, and RollupManifestSlot the L1
+	// storage slot its hash is checked against on start. No check on L1 is done if either is nil.
+	RollupManifest     *manifest.Manifest
+	RollupManifestSlot *manifest.Slot
+
 	// Optional
 	Tracer    Tracer
 	Heartbeat HeartbeatConfig
+	// TxSource replaces the tx pool of the engine with an external ordering service when proposing, if set.
+	TxSource TxSourceEndpointSetup
+
+	// Builder builds proposed blocks with an external block builder, in addition to the engine, if set.
+	Builder BuilderEndpointSetup
+
+	// BatchMirror mirrors the batch data posted to L1, to derive the L1 blocks pruned from the L1 RPC history, if set.
+	BatchMirror BatchMirrorEndpointSetup
 }
 
 type RPCConfig struct {
-	ListenAddr  string
-	ListenPort  int
-	EnableAdmin bool
+	ListenAddr   string
+	ListenPort   int
+	EnableAdmin  bool
+	EnableEvents bool
+	RequestLog   RPCRequestLogConfig
+	// TxPoolDABudget is the number of bytes of transaction data a block is expected to fit, for the annotations of
+	// kroma_pendingTransactions. No budget if 0.
+	TxPoolDABudget uint64
 }
 
 func (cfg *RPCConfig) HttpEndpoint() string {
@@ -83,9 +108,35 @@ func (cfg *Config) Check() error {
 	if err := cfg.L2Sync.Check(); err != nil {
 		return fmt.Errorf("sync config error: %w", err)
 	}
+	if err := cfg.Driver.Check(); err != nil {
+		return fmt.Errorf("driver config error: %w", err)
+	}
+	if cfg.TxSource != nil {
+		if err := cfg.TxSource.Check(); err != nil {
+			return fmt.Errorf("tx source config error: %w", err)
+		}
+	}
+	if cfg.Builder != nil {
+		if err := cfg.Builder.Check(); err != nil {
+			return fmt.Errorf("builder config error: %w", err)
+		}
+	}
+	if cfg.BatchMirror != nil {
+		if err := cfg.BatchMirror.Check(); err != nil {
+			return fmt.Errorf("batch mirror config error: %w", err)
+		}
+	}
 	if err := cfg.Rollup.Check(); err != nil {
 		return fmt.Errorf("rollup config error: %w", err)
 	}
+	if cfg.Rollup.LegacyInbox != nil {
+		if _, err := derive.LegacyDataDecoderByFormat(cfg.Rollup.LegacyInbox.Format); err != nil {
+			return fmt.Errorf("rollup config error: %w", err)
+		}
+	}
+	if err := checkHaltOption(cfg.RollupHalt); err != nil {
+		return fmt.Errorf("rollup halt config error: %w", err)
+	}
 	if err := cfg.Metrics.Check(); err != nil {
 		return fmt.Errorf("metrics config error: %w", err)
 	}
@@ -96,6 +147,9 @@ func (cfg *Config) Check() error {
 		if err := cfg.P2P.Check(); err != nil {
 			return fmt.Errorf("p2p config error: %w", err)
 		}
+		if cfg.Driver.L1Only && !cfg.P2P.Disabled() {
+			return errors.New("p2p must be disabled in L1-only mode")
+		}
 	}
 	return nil
 }
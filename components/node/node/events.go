@@ -0,0 +1,283 @@
+package node
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/kroma-network/kroma/components/node/eth"
+	"github.com/kroma-network/kroma/components/node/rollup/derive"
+)
+
+type EventType string
+
+const (
+	EventNewSafeBlock  EventType = "new_safe_block"
+	EventChannelClosed EventType = "channel_closed"
+	EventBatchConsumed EventType = "batch_consumed"
+
+	// eventDropped is sent to a subscriber instead of events that are no longer buffered.
+	eventDropped EventType = "dropped"
+)
+
+const (
+	// eventBufferSize is the number of recent events kept for replay to reconnecting subscribers.
+	eventBufferSize = 4096
+
+	eventKeepAliveInterval = 10 * time.Second
+)
+
+// eventStreamDuration is how long a single event stream is held open.
+// The stream has to end before the write timeout of the RPC server, clients reconnect
+// with the Last-Event-ID header to continue where they stopped.
+var eventStreamDuration = rpc.DefaultHTTPTimeouts.WriteTimeout * 3 / 4
+
+// Event is a derivation milestone, delivered to subscribers of the event stream.
+// Seq is strictly increasing, starting at 1 when the node starts.
+type Event struct {
+	Seq  uint64    `json:"seq"`
+	Type EventType `json:"type"`
+	Time uint64    `json:"time"`
+	Data any       `json:"data"`
+}
+
+type NewSafeBlockEvent struct {
+	Block    eth.L2BlockRef `json:"block"`
+	L1Origin eth.BlockID    `json:"l1_origin"`
+}
+
+type ChannelClosedEvent struct {
+	ChannelID derive.ChannelID `json:"channel_id"`
+	Frames    int              `json:"frames"`
+	L1Origin  eth.BlockID      `json:"l1_origin"`
+}
+
+type BatchConsumedEvent struct {
+	ParentHash common.Hash `json:"parent_hash"`
+	Epoch      eth.BlockID `json:"epoch"`
+	Timestamp  uint64      `json:"timestamp"`
+	TxCount    int         `json:"tx_count"`
+	L1Origin   eth.BlockID `json:"l1_origin"`
+}
+
+// eventHub buffers the recent derivation events and notifies the event stream subscribers.
+// Subscribers read the events from the buffer by sequence number, so a reconnecting subscriber
+// receives every event it missed, as long as it is still buffered.
+type eventHub struct {
+	log log.Logger
+
+	mu      sync.Mutex
+	nextSeq uint64
+	events  []Event
+	subs    map[chan struct{}]struct{}
+}
+
+var _ derive.Events = (*eventHub)(nil)
+
+func newEventHub(log log.Logger) *eventHub {
+	return &eventHub{
+		log:     log,
+		nextSeq: 1,
+		events:  make([]Event, 0, eventBufferSize),
+		subs:    make(map[chan struct{}]struct{}),
+	}
+}
+
+func (h *eventHub) OnNewSafeBlock(ref eth.L2BlockRef, origin eth.L1BlockRef) {
+	h.publish(EventNewSafeBlock, NewSafeBlockEvent{Block: ref, L1Origin: origin.ID()})
+}
+
+func (h *eventHub) OnChannelClosed(id derive.ChannelID, frames int, origin eth.L1BlockRef) {
+	h.publish(EventChannelClosed, ChannelClosedEvent{ChannelID: id, Frames: frames, L1Origin: origin.ID()})
+}
+
+func (h *eventHub) OnBatchConsumed(batch *derive.BatchData, origin eth.L1BlockRef) {
+	h.publish(EventBatchConsumed, BatchConsumedEvent{
+		ParentHash: batch.ParentHash,
+		Epoch:      batch.Epoch(),
+		Timestamp:  batch.Timestamp,
+		TxCount:    len(batch.Transactions),
+		L1Origin:   origin.ID(),
+	})
+}
+
+func (h *eventHub) publish(typ EventType, data any) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if len(h.events) >= eventBufferSize {
+		h.events = append(h.events[:0], h.events[1:]...)
+	}
+	h.events = append(h.events, Event{
+		Seq:  h.nextSeq,
+		Type: typ,
+		Time: uint64(time.Now().Unix()),
+		Data: data,
+	})
+	h.nextSeq++
+	for ch := range h.subs {
+		// subscribers read from the buffer, a pending notification is enough.
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (h *eventHub) subscribe() chan struct{} {
+	ch := make(chan struct{}, 1)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.subs[ch] = struct{}{}
+	return ch
+}
+
+func (h *eventHub) unsubscribe(ch chan struct{}) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.subs, ch)
+}
+
+// lastSeq returns the sequence number of the latest event, or 0 if there are no events yet.
+func (h *eventHub) lastSeq() uint64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.nextSeq - 1
+}
+
+// since returns the buffered events after the given sequence number.
+// If events after seq are no longer buffered, the sequence number of the first dropped event is returned too.
+func (h *eventHub) since(seq uint64) (events []Event, firstDropped uint64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if len(h.events) == 0 {
+		return nil, 0
+	}
+	oldest := h.events[0].Seq
+	if seq+1 < oldest {
+		firstDropped = seq + 1
+		seq = oldest - 1
+	}
+	if seq >= h.nextSeq-1 {
+		return nil, firstDropped
+	}
+	start := int(seq + 1 - oldest)
+	return append([]Event(nil), h.events[start:]...), firstDropped
+}
+
+// ServeHTTP streams the events as server-sent events.
+// A subscriber that sets the Last-Event-ID header, or the last_event_id query parameter,
+// first receives all buffered events after that sequence number. Otherwise only new events are sent.
+// The types query parameter optionally filters the streamed event types, e.g. types=new_safe_block,channel_closed.
+func (h *eventHub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	lastID := r.Header.Get("Last-Event-ID")
+	if lastID == "" {
+		lastID = r.URL.Query().Get("last_event_id")
+	}
+	seq := h.lastSeq()
+	if lastID != "" {
+		id, err := strconv.ParseUint(lastID, 10, 64)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("invalid last event id: %q", lastID), http.StatusBadRequest)
+			return
+		}
+		// the sequence restarts with the node, replay all buffered events to a subscriber that is ahead.
+		if id <= seq {
+			seq = id
+		} else {
+			seq = 0
+		}
+	}
+
+	var types map[EventType]struct{}
+	if param := r.URL.Query().Get("types"); param != "" {
+		types = make(map[EventType]struct{})
+		for _, typ := range strings.Split(param, ",") {
+			switch t := EventType(strings.TrimSpace(typ)); t {
+			case EventNewSafeBlock, EventChannelClosed, EventBatchConsumed:
+				types[t] = struct{}{}
+			default:
+				http.Error(w, fmt.Sprintf("unknown event type: %q", t), http.StatusBadRequest)
+				return
+			}
+		}
+	}
+
+	notify := h.subscribe()
+	defer h.unsubscribe(notify)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	// reconnect quickly once the stream ends
+	if _, err := fmt.Fprint(w, "retry: 1000\n\n"); err != nil {
+		return
+	}
+	flusher.Flush()
+
+	end := time.NewTimer(eventStreamDuration)
+	defer end.Stop()
+	keepAlive := time.NewTicker(eventKeepAliveInterval)
+	defer keepAlive.Stop()
+
+	for {
+		events, firstDropped := h.since(seq)
+		if firstDropped != 0 {
+			h.log.Warn("event stream subscriber missed events", "first_dropped", firstDropped, "remote", r.RemoteAddr)
+			if err := writeEvent(w, "", eventDropped, map[string]uint64{"first_dropped_seq": firstDropped}); err != nil {
+				return
+			}
+		}
+		for _, ev := range events {
+			seq = ev.Seq
+			if _, ok := types[ev.Type]; types != nil && !ok {
+				continue
+			}
+			if err := writeEvent(w, strconv.FormatUint(ev.Seq, 10), ev.Type, ev); err != nil {
+				return
+			}
+		}
+		flusher.Flush()
+
+		select {
+		case <-notify:
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+			flusher.Flush()
+		case <-end.C:
+			return
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+func writeEvent(w http.ResponseWriter, id string, typ EventType, data any) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	if id != "" {
+		if _, err := fmt.Fprintf(w, "id: %s\n", id); err != nil {
+			return err
+		}
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", typ, payload)
+	return err
+}
@@ -0,0 +1,99 @@
+package node
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kroma-network/kroma/components/node/eth"
+	"github.com/kroma-network/kroma/components/node/rollup/derive"
+	"github.com/kroma-network/kroma/components/node/testlog"
+)
+
+func TestEventHubSince(t *testing.T) {
+	hub := newEventHub(testlog.Logger(t, log.LvlCrit))
+
+	events, dropped := hub.since(0)
+	require.Empty(t, events)
+	require.Zero(t, dropped)
+
+	for i := uint64(1); i <= eventBufferSize+10; i++ {
+		hub.OnNewSafeBlock(eth.L2BlockRef{Number: i}, eth.L1BlockRef{})
+	}
+	require.Equal(t, uint64(eventBufferSize+10), hub.lastSeq())
+
+	events, dropped = hub.since(eventBufferSize)
+	require.Zero(t, dropped)
+	require.Len(t, events, 10)
+	require.Equal(t, uint64(eventBufferSize+1), events[0].Seq)
+	require.Equal(t, EventNewSafeBlock, events[0].Type)
+
+	// the first 10 events are no longer buffered
+	events, dropped = hub.since(5)
+	require.Equal(t, uint64(6), dropped)
+	require.Len(t, events, eventBufferSize)
+	require.Equal(t, uint64(11), events[0].Seq)
+
+	events, dropped = hub.since(hub.lastSeq())
+	require.Empty(t, events)
+	require.Zero(t, dropped)
+}
+
+func TestEventHubStream(t *testing.T) {
+	hub := newEventHub(testlog.Logger(t, log.LvlCrit))
+	hub.OnNewSafeBlock(eth.L2BlockRef{Number: 1}, eth.L1BlockRef{Number: 10})
+	hub.OnBatchConsumed(&derive.BatchData{}, eth.L1BlockRef{Number: 10})
+	hub.OnNewSafeBlock(eth.L2BlockRef{Number: 2}, eth.L1BlockRef{Number: 10})
+
+	server := httptest.NewServer(hub)
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"?types=new_safe_block", nil)
+	require.NoError(t, err)
+	req.Header.Set("Last-Event-ID", "0")
+	res, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer res.Body.Close()
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	require.Equal(t, "text/event-stream", res.Header.Get("Content-Type"))
+
+	// buffered events are replayed, then new events are streamed
+	hub.OnNewSafeBlock(eth.L2BlockRef{Number: 3}, eth.L1BlockRef{Number: 11})
+
+	var ids []string
+	scanner := bufio.NewScanner(res.Body)
+	for len(ids) < 3 && scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "id: ") {
+			ids = append(ids, strings.TrimPrefix(line, "id: "))
+		} else if strings.HasPrefix(line, "event: ") {
+			require.Equal(t, "event: "+string(EventNewSafeBlock), line)
+		}
+	}
+	require.NoError(t, scanner.Err())
+	require.Equal(t, []string{"1", "3", "4"}, ids)
+}
+
+func TestEventHubStreamInvalidRequest(t *testing.T) {
+	hub := newEventHub(testlog.Logger(t, log.LvlCrit))
+	server := httptest.NewServer(hub)
+	defer server.Close()
+
+	res, err := http.Get(server.URL + "?types=unknown")
+	require.NoError(t, err)
+	res.Body.Close()
+	require.Equal(t, http.StatusBadRequest, res.StatusCode)
+
+	res, err = http.Get(server.URL + "?last_event_id=abc")
+	require.NoError(t, err)
+	res.Body.Close()
+	require.Equal(t, http.StatusBadRequest, res.StatusCode)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum"
@@ -16,6 +17,7 @@ import (
 	"github.com/kroma-network/kroma/components/node/eth"
 	"github.com/kroma-network/kroma/components/node/metrics"
 	"github.com/kroma-network/kroma/components/node/p2p"
+	"github.com/kroma-network/kroma/components/node/rollup/derive"
 	"github.com/kroma-network/kroma/components/node/rollup/driver"
 	"github.com/kroma-network/kroma/components/node/sources"
 )
@@ -38,6 +40,16 @@ type KromaNode struct {
 	p2pSigner p2p.Signer            // p2p gossip application messages will be signed with this signer
 	tracer    Tracer                // tracer to get events for testing/debugging
 	runCfg    *RuntimeConfig        // runtime configurables
+	eventHub  *eventHub             // derivation events stream, optional (may be nil)
+
+	runCfgReload chan eth.L1BlockRef // latest L1 head to reload the runtime config at, coalescing the heads
+
+	txSource *sources.TxSourceClient // external ordering service of the proposer, optional (may be nil)
+	builder  *sources.BuilderClient  // external block builder of the proposer, optional (may be nil)
+
+	haltOption string        // halt option for unsupported required protocol versions
+	halted     chan struct{} // closed when the node halts for an unsupported required protocol version
+	haltOnce   sync.Once
 
 	// some resources cannot be stopped directly, like the p2p gossipsub router (not our design),
 	// and depend on this ctx to be closed.
@@ -57,6 +69,10 @@ func New(ctx context.Context, cfg *Config, log log.Logger, snapshotLog log.Logge
 		log:        log,
 		appVersion: appVersion,
 		metrics:    m,
+		haltOption: cfg.RollupHalt,
+		halted:     make(chan struct{}),
+
+		runCfgReload: make(chan eth.L1BlockRef, 1),
 	}
 	// not a context leak, gossipsub is closed with a context.
 	n.resourcesCtx, n.resourcesClose = context.WithCancel(context.Background())
@@ -86,6 +102,10 @@ func (n *KromaNode) init(ctx context.Context, cfg *Config, snapshotLog log.Logge
 	if err := n.initL2(ctx, cfg, snapshotLog); err != nil {
 		return err
 	}
+	if cfg.Driver.L1Only {
+		n.log.Info("Running in L1-only mode, P2P and alt-sync are disabled")
+	}
+	n.metrics.RecordL1Only(cfg.Driver.L1Only)
 	if err := n.initRPCSync(ctx, cfg); err != nil {
 		return err
 	}
@@ -121,7 +141,7 @@ func (n *KromaNode) initL1(ctx context.Context, cfg *Config) error {
 	}
 
 	n.l1Source, err = sources.NewL1Client(
-		client.NewInstrumentedRPC(l1Node, n.metrics), n.log, n.metrics.L1SourceCache, rpcCfg)
+		client.NewInstrumentedRPC(l1Node, n.metrics, metrics.L1Client), n.log, n.metrics.L1SourceCache, rpcCfg)
 	if err != nil {
 		return fmt.Errorf("failed to create L1 source: %w", err)
 	}
@@ -130,6 +150,13 @@ func (n *KromaNode) initL1(ctx context.Context, cfg *Config) error {
 		return err
 	}
 
+	if cfg.RollupManifest != nil && cfg.RollupManifestSlot != nil {
+		if err := cfg.RollupManifest.VerifyOnChain(ctx, l1Node, cfg.RollupManifestSlot); err != nil {
+			return fmt.Errorf("failed to verify rollup manifest: %w", err)
+		}
+		n.log.Info("verified rollup manifest against L1", "address", cfg.RollupManifestSlot.Address, "slot", cfg.RollupManifestSlot.Key)
+	}
+
 	// Keep subscribed to the L1 heads, which keeps the L1 maintainer pointing to the best headers to sync
 	n.l1HeadsSub = event.ResubscribeErr(time.Second*10, func(ctx context.Context, err error) (event.Subscription, error) {
 		if err != nil {
@@ -175,6 +202,15 @@ func (n *KromaNode) initRuntimeConfig(ctx context.Context, cfg *Config) error {
 			continue
 		}
 
+		halt, err := n.checkProtocolVersions()
+		if halt {
+			return err
+		}
+		if err != nil && cfg.Driver.ProposerEnabled && !cfg.Driver.ProposerStopped {
+			n.log.Warn("starting with a stopped proposer, refusing to propose blocks with an unsupported protocol version", "err", err)
+			cfg.Driver.ProposerStopped = true
+		}
+
 		return nil
 	}
 
@@ -188,7 +224,7 @@ func (n *KromaNode) initL2(ctx context.Context, cfg *Config, snapshotLog log.Log
 	}
 
 	n.l2Source, err = sources.NewEngineClient(
-		client.NewInstrumentedRPC(rpcClient, n.metrics), n.log, n.metrics.L2SourceCache, rpcCfg,
+		client.NewInstrumentedRPC(rpcClient, n.metrics, metrics.EngineClient), n.log, n.metrics.L2SourceCache, rpcCfg,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to create Engine client: %w", err)
@@ -198,7 +234,48 @@ func (n *KromaNode) initL2(ctx context.Context, cfg *Config, snapshotLog log.Log
 		return err
 	}
 
-	n.l2Driver = driver.NewDriver(&cfg.Driver, &cfg.Rollup, n.l2Source, n.l1Source, n, n, n.log, snapshotLog, n.metrics)
+	var events derive.Events = derive.NoopEvents
+	if cfg.RPC.EnableEvents {
+		n.eventHub = newEventHub(n.log.New("module", "events"))
+		events = n.eventHub
+	}
+
+	var txSource driver.TxSource
+	if cfg.TxSource != nil {
+		txSourceClient, err := cfg.TxSource.Setup(ctx, n.log)
+		if err != nil {
+			return fmt.Errorf("failed to setup tx source RPC client: %w", err)
+		}
+		if txSourceClient != nil {
+			n.txSource = sources.NewTxSourceClient(client.NewInstrumentedRPC(txSourceClient, n.metrics, metrics.TxSourceClient))
+			txSource = n.txSource
+		}
+	}
+
+	var l2 driver.L2Chain = n.l2Source
+	if cfg.Builder != nil {
+		builderClient, err := cfg.Builder.Setup(ctx, n.log)
+		if err != nil {
+			return fmt.Errorf("failed to setup builder RPC client: %w", err)
+		}
+		if builderClient != nil {
+			n.builder = sources.NewBuilderClient(client.NewInstrumentedRPC(builderClient, n.metrics, metrics.BuilderClient))
+			l2 = driver.NewBuilderEngine(n.l2Source, n.builder, n.log.New("module", "builder"), n.metrics)
+		}
+	}
+
+	var l1 driver.L1Chain = n.l1Source
+	if cfg.BatchMirror != nil {
+		mirror, err := cfg.BatchMirror.Setup(ctx, n.log, &cfg.Rollup)
+		if err != nil {
+			return fmt.Errorf("failed to setup batch mirror: %w", err)
+		}
+		if mirror != nil {
+			l1 = derive.NewMirrorFetcher(n.log.New("module", "batch-mirror"), &cfg.Rollup, n.l1Source, mirror, cfg.BatchMirror.Publish())
+		}
+	}
+
+	n.l2Driver = driver.NewDriver(&cfg.Driver, &cfg.Rollup, l2, l1, n, n, n.log, snapshotLog, n.metrics, events, txSource)
 
 	return nil
 }
@@ -211,7 +288,12 @@ func (n *KromaNode) initRPCSync(ctx context.Context, cfg *Config) error {
 	if rpcSyncClient == nil { // if no RPC client is configured to sync from, then don't add the RPC sync client
 		return nil
 	}
-	syncClient, err := sources.NewSyncClient(n.OnUnsafeL2Payload, rpcSyncClient, n.log, n.metrics.L2SourceCache, rpcCfg)
+	if cfg.Driver.L1Only {
+		rpcSyncClient.Close()
+		return errors.New("backup unsafe sync RPC cannot be used in L1-only mode")
+	}
+	syncClient, err := sources.NewSyncClient(n.OnUnsafeL2Payload,
+		client.NewInstrumentedRPC(rpcSyncClient, n.metrics, metrics.L2SyncClient), n.log, n.metrics.L2SourceCache, rpcCfg)
 	if err != nil {
 		return fmt.Errorf("failed to create sync client: %w", err)
 	}
@@ -231,6 +313,10 @@ func (n *KromaNode) initRPCServer(ctx context.Context, cfg *Config) error {
 		server.EnableAdminAPI(NewAdminAPI(n.l2Driver, n.metrics))
 		n.log.Info("Admin RPC enabled")
 	}
+	if n.eventHub != nil {
+		server.EnableEvents(n.eventHub)
+		n.log.Info("Events stream enabled")
+	}
 	n.log.Info("Starting JSON-RPC server")
 	if err := server.Start(); err != nil {
 		return fmt.Errorf("unable to start RPC server: %w", err)
@@ -296,12 +382,25 @@ func (n *KromaNode) Start(ctx context.Context) error {
 		n.log.Info("Started L2-RPC sync service")
 	}
 
+	go n.reloadRuntimeConfig(n.resourcesCtx)
+
 	return nil
 }
 
 func (n *KromaNode) OnNewL1Head(ctx context.Context, sig eth.L1BlockRef) {
 	n.tracer.OnNewL1Head(ctx, sig)
 
+	// Reload the runtime config, which includes the signaled protocol versions, without delaying the driver.
+	// A head still pending reload is replaced, there is a single producer of heads.
+	select {
+	case <-n.runCfgReload:
+	default:
+	}
+	select {
+	case n.runCfgReload <- sig:
+	default:
+	}
+
 	if n.l2Driver == nil {
 		return
 	}
@@ -313,6 +412,25 @@ func (n *KromaNode) OnNewL1Head(ctx context.Context, sig eth.L1BlockRef) {
 	}
 }
 
+// reloadRuntimeConfig reloads the runtime config at the L1 heads signaled by OnNewL1Head, and handles the update of
+// the signaled protocol versions, until the ctx is done.
+func (n *KromaNode) reloadRuntimeConfig(ctx context.Context) {
+	for {
+		select {
+		case sig := <-n.runCfgReload:
+			cfgCtx, cancel := context.WithTimeout(ctx, time.Second*10)
+			if err := n.runCfg.Load(cfgCtx, sig); err != nil {
+				n.log.Warn("failed to reload runtime config", "l1_head", sig, "err", err)
+			} else {
+				n.handleProtocolVersionsUpdate(cfgCtx)
+			}
+			cancel()
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
 func (n *KromaNode) OnNewL1Safe(ctx context.Context, sig eth.L1BlockRef) {
 	if n.l2Driver == nil {
 		return
@@ -428,6 +546,14 @@ func (n *KromaNode) Close() error {
 		}
 	}
 
+	if n.txSource != nil {
+		n.txSource.Close()
+	}
+
+	if n.builder != nil {
+		n.builder.Close()
+	}
+
 	// close L2 engine RPC client
 	if n.l2Source != nil {
 		n.l2Source.Close()
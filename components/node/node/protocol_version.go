@@ -0,0 +1,105 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/kroma-network/kroma/components/node/eth"
+	"github.com/kroma-network/kroma/components/node/rollup"
+)
+
+// Halt options, configuring which unsupported required protocol upgrades make the node halt.
+const (
+	HaltNone  = ""
+	HaltMajor = "major"
+	HaltMinor = "minor"
+	HaltPatch = "patch"
+)
+
+var ErrUnsupportedProtocolVersion = errors.New("required protocol version is not supported")
+
+func checkHaltOption(opt string) error {
+	switch opt {
+	case HaltNone, HaltMajor, HaltMinor, HaltPatch:
+		return nil
+	default:
+		return fmt.Errorf("unknown halt option %q", opt)
+	}
+}
+
+// isUnsupported returns true if the required protocol version, compared to the local one, cannot be supported.
+// A required version that cannot be interpreted is never supported, a different build is.
+func isUnsupported(cmp eth.ProtocolVersionComparison) bool {
+	switch cmp {
+	case eth.AheadMajor, eth.AheadMinor, eth.AheadPatch, eth.AheadPrerelease, eth.DiffVersionType, eth.InvalidVersion:
+		return true
+	default:
+		return false
+	}
+}
+
+// shouldHalt returns true if the halt option covers the unsupported required protocol version.
+func shouldHalt(opt string, cmp eth.ProtocolVersionComparison) bool {
+	if cmp == eth.DiffVersionType || cmp == eth.InvalidVersion {
+		return opt != HaltNone
+	}
+	switch opt {
+	case HaltMajor:
+		return cmp == eth.AheadMajor
+	case HaltMinor:
+		return cmp == eth.AheadMajor || cmp == eth.AheadMinor
+	case HaltPatch:
+		return cmp == eth.AheadMajor || cmp == eth.AheadMinor || cmp == eth.AheadPatch
+	default:
+		return false
+	}
+}
+
+// checkProtocolVersions compares the protocol versions signaled on L1 with the supported protocol version.
+// It records the result in the metrics, and returns ErrUnsupportedProtocolVersion if the required
+// protocol version is not supported. The returned bool is true if the node should halt for it.
+func (n *KromaNode) checkProtocolVersions() (bool, error) {
+	local := rollup.SupportedProtocolVersion
+	required := n.runCfg.RequiredProtocolVersion()
+	recommended := n.runCfg.RecommendedProtocolVersion()
+
+	cmpRequired := required.Compare(local)
+	unsupported := isUnsupported(cmpRequired)
+	n.metrics.RecordProtocolVersions(local, recommended, required, unsupported)
+
+	if cmp := recommended.Compare(local); isUnsupported(cmp) {
+		n.log.Warn("recommended protocol version is not supported, upgrade the node soon",
+			"local", local, "recommended", recommended, "comparison", cmp)
+	}
+	if !unsupported {
+		return false, nil
+	}
+	n.log.Error("required protocol version is not supported, upgrade the node now!",
+		"local", local, "required", required, "comparison", cmpRequired)
+	return shouldHalt(n.haltOption, cmpRequired), fmt.Errorf("%w: local %s, required %s", ErrUnsupportedProtocolVersion, local, required)
+}
+
+// handleProtocolVersionsUpdate stops the proposer if the required protocol version is not supported,
+// and halts the node if the configured halt option covers the required upgrade.
+func (n *KromaNode) handleProtocolVersionsUpdate(ctx context.Context) {
+	halt, err := n.checkProtocolVersions()
+	if err == nil {
+		return
+	}
+	if n.l2Driver != nil {
+		if _, stopErr := n.l2Driver.StopProposer(ctx); stopErr == nil {
+			n.log.Warn("stopped the proposer, refusing to propose blocks with an unsupported protocol version", "err", err)
+		}
+	}
+	if halt {
+		n.log.Error("halting the node, the required protocol version is not supported", "halt", n.haltOption, "err", err)
+		n.haltOnce.Do(func() { close(n.halted) })
+	}
+}
+
+// Halted returns a channel that is closed when the node halts,
+// because the required protocol version is not supported.
+func (n *KromaNode) Halted() <-chan struct{} {
+	return n.halted
+}
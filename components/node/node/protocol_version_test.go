@@ -0,0 +1,188 @@
+package node
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kroma-network/kroma/components/node/eth"
+	"github.com/kroma-network/kroma/components/node/metrics"
+	"github.com/kroma-network/kroma/components/node/rollup"
+	"github.com/kroma-network/kroma/components/node/testlog"
+)
+
+// fakeStorageSource serves the storage of the L1 contracts, and records the reads.
+type fakeStorageSource struct {
+	mu      sync.Mutex
+	storage map[common.Address]map[common.Hash]common.Hash
+	reads   map[common.Address]int
+	blocks  []common.Hash
+	// release, if not nil, blocks the reads until it is closed.
+	release chan struct{}
+}
+
+func (s *fakeStorageSource) ReadStorageAt(ctx context.Context, address common.Address, storageSlot common.Hash, blockHash common.Hash) (common.Hash, error) {
+	if s.release != nil {
+		select {
+		case <-s.release:
+		case <-ctx.Done():
+			return common.Hash{}, ctx.Err()
+		}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.reads == nil {
+		s.reads = make(map[common.Address]int)
+	}
+	s.reads[address]++
+	s.blocks = append(s.blocks, blockHash)
+	return s.storage[address][storageSlot], nil
+}
+
+func (s *fakeStorageSource) set(address common.Address, slot common.Hash, value common.Hash) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.storage == nil {
+		s.storage = make(map[common.Address]map[common.Hash]common.Hash)
+	}
+	if s.storage[address] == nil {
+		s.storage[address] = make(map[common.Hash]common.Hash)
+	}
+	s.storage[address][slot] = value
+}
+
+func (s *fakeStorageSource) numReads(address common.Address) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.reads[address]
+}
+
+func (s *fakeStorageSource) lastBlock() common.Hash {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.blocks) == 0 {
+		return common.Hash{}
+	}
+	return s.blocks[len(s.blocks)-1]
+}
+
+var (
+	testSystemConfigAddr     = common.Address{0x01}
+	testProtocolVersionsAddr = common.Address{0x02}
+)
+
+func TestShouldHalt(t *testing.T) {
+	tests := []struct {
+		cmp         eth.ProtocolVersionComparison
+		unsupported bool
+		halts       []string
+	}{
+		{cmp: eth.AheadMajor, unsupported: true, halts: []string{HaltMajor, HaltMinor, HaltPatch}},
+		{cmp: eth.AheadMinor, unsupported: true, halts: []string{HaltMinor, HaltPatch}},
+		{cmp: eth.AheadPatch, unsupported: true, halts: []string{HaltPatch}},
+		{cmp: eth.AheadPrerelease, unsupported: true},
+		{cmp: eth.DiffVersionType, unsupported: true, halts: []string{HaltMajor, HaltMinor, HaltPatch}},
+		{cmp: eth.InvalidVersion, unsupported: true, halts: []string{HaltMajor, HaltMinor, HaltPatch}},
+		{cmp: eth.Matching},
+		{cmp: eth.DiffBuildID},
+		{cmp: eth.EmptyVersion},
+		{cmp: eth.OutdatedMajor},
+	}
+	for _, test := range tests {
+		t.Run(test.cmp.String(), func(t *testing.T) {
+			require.Equal(t, test.unsupported, isUnsupported(test.cmp))
+			for _, opt := range []string{HaltNone, HaltMajor, HaltMinor, HaltPatch} {
+				require.Equal(t, contains(test.halts, opt), shouldHalt(opt, test.cmp), "halt option %q", opt)
+			}
+		})
+	}
+}
+
+func contains(opts []string, opt string) bool {
+	for _, o := range opts {
+		if o == opt {
+			return true
+		}
+	}
+	return false
+}
+
+func TestRuntimeConfigLoadProtocolVersions(t *testing.T) {
+	required := eth.ProtocolVersionV0{Major: 2}.Encode()
+	recommended := eth.ProtocolVersionV0{Major: 2, Minor: 1}.Encode()
+	l1Ref := eth.L1BlockRef{Hash: common.Hash{0xaa}, Number: 10}
+
+	t.Run("disabled without address", func(t *testing.T) {
+		src := &fakeStorageSource{}
+		src.set(testProtocolVersionsAddr, RequiredProtocolVersionStorageSlot, common.Hash(required))
+		runCfg := NewRuntimeConfig(testlog.Logger(t, log.LvlError), src, &rollup.Config{L1SystemConfigAddress: testSystemConfigAddr})
+		require.NoError(t, runCfg.Load(context.Background(), l1Ref))
+		require.Zero(t, src.numReads(testProtocolVersionsAddr))
+		require.True(t, runCfg.RequiredProtocolVersion().IsEmpty())
+		require.True(t, runCfg.RecommendedProtocolVersion().IsEmpty())
+	})
+
+	t.Run("enabled with address", func(t *testing.T) {
+		src := &fakeStorageSource{}
+		src.set(testProtocolVersionsAddr, RequiredProtocolVersionStorageSlot, common.Hash(required))
+		src.set(testProtocolVersionsAddr, RecommendedProtocolVersionStorageSlot, common.Hash(recommended))
+		runCfg := NewRuntimeConfig(testlog.Logger(t, log.LvlError), src, &rollup.Config{
+			L1SystemConfigAddress:   testSystemConfigAddr,
+			ProtocolVersionsAddress: testProtocolVersionsAddr,
+		})
+		require.NoError(t, runCfg.Load(context.Background(), l1Ref))
+		require.Equal(t, 2, src.numReads(testProtocolVersionsAddr))
+		require.Equal(t, required, runCfg.RequiredProtocolVersion())
+		require.Equal(t, recommended, runCfg.RecommendedProtocolVersion())
+	})
+}
+
+func TestReloadRuntimeConfig(t *testing.T) {
+	src := &fakeStorageSource{release: make(chan struct{})}
+	src.set(testProtocolVersionsAddr, RequiredProtocolVersionStorageSlot,
+		common.Hash(eth.ProtocolVersionV0{Major: 2}.Encode()))
+	n := &KromaNode{
+		log:          testlog.Logger(t, log.LvlError),
+		metrics:      metrics.NewMetrics(""),
+		tracer:       noOpTracer{},
+		haltOption:   HaltMajor,
+		halted:       make(chan struct{}),
+		runCfgReload: make(chan eth.L1BlockRef, 1),
+	}
+	n.runCfg = NewRuntimeConfig(n.log, src, &rollup.Config{
+		L1SystemConfigAddress:   testSystemConfigAddr,
+		ProtocolVersionsAddress: testProtocolVersionsAddr,
+	})
+
+	// the heads are queued without waiting for the L1 source, and coalesce to the latest one
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := byte(1); i <= 3; i++ {
+			n.OnNewL1Head(context.Background(), eth.L1BlockRef{Hash: common.Hash{i}, Number: uint64(i)})
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("OnNewL1Head must not wait for the runtime config reload")
+	}
+	require.Len(t, n.runCfgReload, 1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go n.reloadRuntimeConfig(ctx)
+	close(src.release)
+
+	select {
+	case <-n.Halted():
+	case <-time.After(5 * time.Second):
+		t.Fatal("node must halt on the reloaded unsupported required protocol version")
+	}
+	require.Equal(t, common.Hash{3}, src.lastBlock(), "runtime config must be reloaded at the latest head")
+}
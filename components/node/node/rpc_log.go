@@ -0,0 +1,196 @@
+package node
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// maxLoggedBodySize is the maximum size of a request or response body that is decoded for the request log,
+// larger bodies are served as usual but not logged.
+const maxLoggedBodySize = 1024 * 1024
+
+// clientIDHeader is the header that callers may set to identify themselves in the request log.
+const clientIDHeader = "X-Client-Id"
+
+type RPCRequestLogConfig struct {
+	Enabled bool
+	// Namespaces are the namespaces to log the requests of, all namespaces are logged if empty.
+	Namespaces []string
+	// SampledMethods are hot methods, e.g. polled ones, of which only every SampleRate-th request is logged.
+	SampledMethods []string
+	SampleRate     uint64
+	// SlowThreshold is the duration after which a request is logged as slow, regardless of the sampling
+	// and of whether the request log is enabled. Disabled if 0.
+	SlowThreshold time.Duration
+}
+
+func (cfg *RPCRequestLogConfig) active() bool {
+	return cfg.Enabled || cfg.SlowThreshold > 0
+}
+
+type rpcMessage struct {
+	ID     json.RawMessage `json:"id,omitempty"`
+	Method string          `json:"method,omitempty"`
+	Error  *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
+}
+
+// rpcRequestLogger logs the JSON-RPC requests served over HTTP by the wrapped handler.
+type rpcRequestLogger struct {
+	cfg        RPCRequestLogConfig
+	namespaces map[string]struct{}
+	sampled    map[string]struct{}
+	log        log.Logger
+	next       http.Handler
+
+	mu     sync.Mutex
+	counts map[string]uint64
+}
+
+func newRPCRequestLogger(cfg RPCRequestLogConfig, log log.Logger, next http.Handler) *rpcRequestLogger {
+	l := &rpcRequestLogger{
+		cfg:        cfg,
+		namespaces: make(map[string]struct{}),
+		sampled:    make(map[string]struct{}),
+		log:        log,
+		next:       next,
+		counts:     make(map[string]uint64),
+	}
+	for _, ns := range cfg.Namespaces {
+		l.namespaces[ns] = struct{}{}
+	}
+	for _, method := range cfg.SampledMethods {
+		l.sampled[method] = struct{}{}
+	}
+	return l
+}
+
+func (l *rpcRequestLogger) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		l.next.ServeHTTP(w, r)
+		return
+	}
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxLoggedBodySize+1))
+	if err != nil {
+		l.next.ServeHTTP(w, r)
+		return
+	}
+	// the body is restored in full, the request is served as if it was not read.
+	r.Body = readCloser{io.MultiReader(bytes.NewReader(body), r.Body), r.Body}
+	if len(body) > maxLoggedBodySize {
+		l.next.ServeHTTP(w, r)
+		return
+	}
+	requests, batch, ok := decodeRPCMessages(body)
+	if !ok {
+		l.next.ServeHTTP(w, r)
+		return
+	}
+
+	rec := &responseRecorder{ResponseWriter: w}
+	start := time.Now()
+	l.next.ServeHTTP(rec, r)
+	duration := time.Since(start)
+
+	errs := make(map[string]string)
+	if responses, _, ok := decodeRPCMessages(rec.body.Bytes()); ok && !rec.overflow {
+		for _, res := range responses {
+			if res.Error != nil {
+				errs[string(res.ID)] = res.Error.Message
+			}
+		}
+	}
+
+	client := r.Header.Get(clientIDHeader)
+	remote, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		remote = r.RemoteAddr
+	}
+	for _, req := range requests {
+		ctx := []any{"method", req.Method, "duration", duration, "remote", remote}
+		if client != "" {
+			ctx = append(ctx, "client", client)
+		}
+		if batch {
+			ctx = append(ctx, "batch", len(requests))
+		}
+		if msg, ok := errs[string(req.ID)]; ok {
+			ctx = append(ctx, "err", msg)
+		}
+
+		if l.cfg.SlowThreshold > 0 && duration >= l.cfg.SlowThreshold {
+			l.log.Warn("Slow RPC request", ctx...)
+		} else if l.cfg.Enabled && l.shouldLog(req.Method) {
+			l.log.Info("Served RPC request", ctx...)
+		}
+	}
+}
+
+// shouldLog returns whether a request to the method is logged, taking the namespace filter and sampling into account.
+func (l *rpcRequestLogger) shouldLog(method string) bool {
+	if len(l.namespaces) > 0 {
+		ns, _, _ := strings.Cut(method, "_")
+		if _, ok := l.namespaces[ns]; !ok {
+			return false
+		}
+	}
+	if _, ok := l.sampled[method]; !ok || l.cfg.SampleRate <= 1 {
+		return true
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	n := l.counts[method]
+	l.counts[method] = n + 1
+	return n%l.cfg.SampleRate == 0
+}
+
+// decodeRPCMessages decodes a single or a batch of JSON-RPC messages.
+func decodeRPCMessages(data []byte) ([]rpcMessage, bool, bool) {
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '[' {
+		var msgs []rpcMessage
+		if err := json.Unmarshal(data, &msgs); err != nil {
+			return nil, true, false
+		}
+		return msgs, true, true
+	}
+	var msg rpcMessage
+	if err := json.Unmarshal(data, &msg); err != nil {
+		return nil, false, false
+	}
+	return []rpcMessage{msg}, false, true
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// responseRecorder keeps a copy of the response body, up to maxLoggedBodySize.
+type responseRecorder struct {
+	http.ResponseWriter
+	body     bytes.Buffer
+	overflow bool
+}
+
+func (r *responseRecorder) Write(p []byte) (int, error) {
+	if !r.overflow {
+		if r.body.Len()+len(p) > maxLoggedBodySize {
+			r.overflow = true
+			r.body.Reset()
+		} else {
+			r.body.Write(p)
+		}
+	}
+	return r.ResponseWriter.Write(p)
+}
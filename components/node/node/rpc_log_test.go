@@ -0,0 +1,120 @@
+package node
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+type rpcLogTestAPI struct{}
+
+func (rpcLogTestAPI) Ping() string { return "pong" }
+
+func (rpcLogTestAPI) Fail() error { return errors.New("boom") }
+
+func (rpcLogTestAPI) Sleep() { time.Sleep(20 * time.Millisecond) }
+
+type logRecords struct {
+	records []*log.Record
+}
+
+func (l *logRecords) Log(r *log.Record) error {
+	l.records = append(l.records, r)
+	return nil
+}
+
+func (l *logRecords) take() []*log.Record {
+	records := l.records
+	l.records = nil
+	return records
+}
+
+func ctxValue(r *log.Record, key string) any {
+	for i := 0; i+1 < len(r.Ctx); i += 2 {
+		if r.Ctx[i] == key {
+			return r.Ctx[i+1]
+		}
+	}
+	return nil
+}
+
+func TestRPCRequestLogger(t *testing.T) {
+	srv := rpc.NewServer()
+	require.NoError(t, srv.RegisterName("test", rpcLogTestAPI{}))
+	require.NoError(t, srv.RegisterName("other", rpcLogTestAPI{}))
+	defer srv.Stop()
+
+	records := &logRecords{}
+	logger := log.New()
+	logger.SetHandler(records)
+
+	cfg := RPCRequestLogConfig{
+		Enabled:        true,
+		Namespaces:     []string{"test"},
+		SampledMethods: []string{"test_ping"},
+		SampleRate:     3,
+		SlowThreshold:  10 * time.Millisecond,
+	}
+	ts := httptest.NewServer(newRPCRequestLogger(cfg, logger, srv))
+	defer ts.Close()
+
+	call := func(body string, header http.Header) {
+		req, err := http.NewRequest(http.MethodPost, ts.URL, strings.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		for k, v := range header {
+			req.Header[k] = v
+		}
+		res, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		require.NoError(t, res.Body.Close())
+		require.Equal(t, http.StatusOK, res.StatusCode)
+	}
+
+	t.Run("sampling", func(t *testing.T) {
+		for i := 0; i < 7; i++ {
+			call(`{"jsonrpc":"2.0","id":1,"method":"test_ping"}`, nil)
+		}
+		require.Len(t, records.take(), 3)
+	})
+
+	t.Run("error and client", func(t *testing.T) {
+		call(`{"jsonrpc":"2.0","id":1,"method":"test_fail"}`, http.Header{clientIDHeader: {"alice"}})
+		logged := records.take()
+		require.Len(t, logged, 1)
+		require.Equal(t, log.LvlInfo, logged[0].Lvl)
+		require.Equal(t, "test_fail", ctxValue(logged[0], "method"))
+		require.Equal(t, "boom", ctxValue(logged[0], "err"))
+		require.Equal(t, "alice", ctxValue(logged[0], "client"))
+	})
+
+	t.Run("namespace filter", func(t *testing.T) {
+		call(`{"jsonrpc":"2.0","id":1,"method":"other_fail"}`, nil)
+		require.Empty(t, records.take())
+	})
+
+	t.Run("batch", func(t *testing.T) {
+		call(`[{"jsonrpc":"2.0","id":1,"method":"test_fail"},{"jsonrpc":"2.0","id":2,"method":"test_sleep"}]`, nil)
+		logged := records.take()
+		require.Len(t, logged, 2)
+		require.Equal(t, "boom", ctxValue(logged[0], "err"))
+		require.Nil(t, ctxValue(logged[1], "err"))
+		require.Equal(t, 2, ctxValue(logged[1], "batch"))
+	})
+
+	t.Run("slow", func(t *testing.T) {
+		// slow requests are logged regardless of the namespace filter
+		call(`{"jsonrpc":"2.0","id":1,"method":"other_sleep"}`, nil)
+		logged := records.take()
+		require.Len(t, logged, 1)
+		require.Equal(t, log.LvlWarn, logged[0].Lvl)
+		require.Equal(t, "Slow RPC request", logged[0].Msg)
+	})
+}
@@ -2,7 +2,9 @@ package node
 
 import (
 	"context"
+	"encoding/binary"
 	"fmt"
+	"math/big"
 	"sync"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -17,6 +19,21 @@ var (
 	// UnsafeBlockSignerAddressSystemConfigStorageSlot is the storage slot identifier of the unsafeBlockSigner
 	// `address` storage value in the SystemConfig L1 contract. Computed as `keccak256("systemconfig.unsafeblocksigner")`
 	UnsafeBlockSignerAddressSystemConfigStorageSlot = common.HexToHash("0x65a7ed542fb37fe237fdfbdd70b31598523fe5b32879e307bae27a0bd9581c08")
+
+	// RequiredProtocolVersionStorageSlot is the storage slot identifier of the required protocol version
+	// in the ProtocolVersions L1 contract. Computed as `keccak256("protocolversion.required")`
+	RequiredProtocolVersionStorageSlot = common.HexToHash("0x4aaefe95bd84fd3f32700cf3b7566bc944b73138e41958b5785826df2aecace1")
+
+	// RecommendedProtocolVersionStorageSlot is the storage slot identifier of the recommended protocol version
+	// in the ProtocolVersions L1 contract. Computed as `keccak256("protocolversion.recommended")`
+	RecommendedProtocolVersionStorageSlot = common.HexToHash("0xe314dfc40f0025322aacc0ba8ef420b62fb3b702cf01e0cdf3d829117ac2ff1b")
+
+	// OverheadSystemConfigStorageSlot, ScalarSystemConfigStorageSlot and GasLimitSystemConfigStorageSlot are
+	// the storage slots of the `overhead`, `scalar` and `gasLimit` values in the storage layout of the SystemConfig
+	// L1 contract.
+	OverheadSystemConfigStorageSlot = common.BigToHash(big.NewInt(101))
+	ScalarSystemConfigStorageSlot   = common.BigToHash(big.NewInt(102))
+	GasLimitSystemConfigStorageSlot = common.BigToHash(big.NewInt(104))
 )
 
 type RuntimeCfgL1Source interface {
@@ -45,6 +62,21 @@ type RuntimeConfig struct {
 // runtimeConfigData is a flat bundle of configurable data, easy and light to copy around.
 type runtimeConfigData struct {
 	p2pBlockSignerAddr common.Address
+
+	// required and recommended protocol versions signaled on L1, empty if signaling is disabled.
+	required    eth.ProtocolVersion
+	recommended eth.ProtocolVersion
+
+	// sysCfgs are the latest SystemConfig values on L1, and the values before their last change, if any,
+	// with the L1 blocks they were loaded at: the unsafe payloads are verified against the values at their L1 origin.
+	sysCfgs []sysCfgRange
+}
+
+// sysCfgRange is SystemConfig values, as loaded at the L1 blocks from and to, inclusive.
+type sysCfgRange struct {
+	sysCfg eth.SystemConfig
+	from   uint64
+	to     uint64
 }
 
 var _ p2p.GossipRuntimeConfig = (*RuntimeConfig)(nil)
@@ -63,6 +95,35 @@ func (r *RuntimeConfig) P2PProposerAddress() common.Address {
 	return r.p2pBlockSignerAddr
 }
 
+// P2PSystemConfig returns the SystemConfig values at the L1 block of the number. The values are not known past the
+// latest loaded L1 block, nor between the loads before and after their last change. The oldest loaded values are
+// assumed unchanged before the L1 block they were first loaded at, as the gossiped payloads are recent.
+func (r *RuntimeConfig) P2PSystemConfig(l1Origin uint64) (eth.SystemConfig, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if len(r.sysCfgs) == 0 || l1Origin > r.sysCfgs[0].to {
+		return eth.SystemConfig{}, false
+	}
+	for i, entry := range r.sysCfgs {
+		if l1Origin >= entry.from || i == len(r.sysCfgs)-1 {
+			return entry.sysCfg, l1Origin <= entry.to
+		}
+	}
+	return eth.SystemConfig{}, false
+}
+
+func (r *RuntimeConfig) RequiredProtocolVersion() eth.ProtocolVersion {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.required
+}
+
+func (r *RuntimeConfig) RecommendedProtocolVersion() eth.ProtocolVersion {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.recommended
+}
+
 // Load resets the runtime configuration by fetching the latest config data from L1 at the given L1 block.
 // Load is safe to call concurrently, but will lock the runtime configuration modifications only,
 // and will thus not block other Load calls with possibly alternative L1 block views.
@@ -71,10 +132,69 @@ func (r *RuntimeConfig) Load(ctx context.Context, l1Ref eth.L1BlockRef) error {
 	if err != nil {
 		return fmt.Errorf("failed to fetch unsafe block signing address from system config: %w", err)
 	}
+	sysCfg, err := r.loadSystemConfig(ctx, l1Ref)
+	if err != nil {
+		return err
+	}
+	var required, recommended common.Hash
+	if r.rollupCfg.ProtocolVersionsAddress != (common.Address{}) {
+		required, err = r.l1Client.ReadStorageAt(ctx, r.rollupCfg.ProtocolVersionsAddress, RequiredProtocolVersionStorageSlot, l1Ref.Hash)
+		if err != nil {
+			return fmt.Errorf("failed to fetch required protocol version: %w", err)
+		}
+		recommended, err = r.l1Client.ReadStorageAt(ctx, r.rollupCfg.ProtocolVersionsAddress, RecommendedProtocolVersionStorageSlot, l1Ref.Hash)
+		if err != nil {
+			return fmt.Errorf("failed to fetch recommended protocol version: %w", err)
+		}
+	}
 	r.mu.Lock()
 	defer r.mu.Unlock()
 	r.l1Ref = l1Ref
 	r.p2pBlockSignerAddr = common.BytesToAddress(val[:])
-	r.log.Info("loaded new runtime config values!", "p2p_proposer_address", r.p2pBlockSignerAddr)
+	r.required = eth.ProtocolVersion(required)
+	r.recommended = eth.ProtocolVersion(recommended)
+	r.loadedSystemConfig(sysCfg, l1Ref.Number)
+	r.log.Info("loaded new runtime config values!", "p2p_proposer_address", r.p2pBlockSignerAddr,
+		"required_protocol_version", r.required, "recommended_protocol_version", r.recommended,
+		"gas_limit", sysCfg.GasLimit, "overhead", sysCfg.Overhead, "scalar", sysCfg.Scalar)
 	return nil
 }
+
+// loadSystemConfig fetches the SystemConfig values constraining the content of the unsafe payloads.
+// The batcher address is not loaded, as it does not constrain the payloads.
+func (r *RuntimeConfig) loadSystemConfig(ctx context.Context, l1Ref eth.L1BlockRef) (eth.SystemConfig, error) {
+	overhead, err := r.l1Client.ReadStorageAt(ctx, r.rollupCfg.L1SystemConfigAddress, OverheadSystemConfigStorageSlot, l1Ref.Hash)
+	if err != nil {
+		return eth.SystemConfig{}, fmt.Errorf("failed to fetch overhead from system config: %w", err)
+	}
+	scalar, err := r.l1Client.ReadStorageAt(ctx, r.rollupCfg.L1SystemConfigAddress, ScalarSystemConfigStorageSlot, l1Ref.Hash)
+	if err != nil {
+		return eth.SystemConfig{}, fmt.Errorf("failed to fetch scalar from system config: %w", err)
+	}
+	gasLimit, err := r.l1Client.ReadStorageAt(ctx, r.rollupCfg.L1SystemConfigAddress, GasLimitSystemConfigStorageSlot, l1Ref.Hash)
+	if err != nil {
+		return eth.SystemConfig{}, fmt.Errorf("failed to fetch gas limit from system config: %w", err)
+	}
+	return eth.SystemConfig{
+		Overhead: eth.Bytes32(overhead),
+		Scalar:   eth.Bytes32(scalar),
+		GasLimit: binary.BigEndian.Uint64(gasLimit[24:]),
+	}, nil
+}
+
+// loadedSystemConfig records the SystemConfig values loaded at the L1 block of the number.
+func (r *RuntimeConfig) loadedSystemConfig(sysCfg eth.SystemConfig, l1Num uint64) {
+	switch {
+	case len(r.sysCfgs) == 0 || l1Num < r.sysCfgs[0].from:
+		// the first load, or a reorg past the last change
+		r.sysCfgs = []sysCfgRange{{sysCfg: sysCfg, from: l1Num, to: l1Num}}
+	case r.sysCfgs[0].sysCfg == sysCfg:
+		r.sysCfgs[0].to = l1Num
+	default:
+		prev := r.sysCfgs[0]
+		if prev.to >= l1Num {
+			prev.to = l1Num - 1
+		}
+		r.sysCfgs = []sysCfgRange{{sysCfg: sysCfg, from: l1Num, to: l1Num}, prev}
+	}
+}
@@ -0,0 +1,68 @@
+package node
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kroma-network/kroma/bindings/bindings"
+	"github.com/kroma-network/kroma/components/node/eth"
+	"github.com/kroma-network/kroma/components/node/rollup"
+	"github.com/kroma-network/kroma/components/node/testlog"
+)
+
+func TestSystemConfigStorageSlots(t *testing.T) {
+	layout, err := bindings.GetStorageLayout("SystemConfig")
+	require.NoError(t, err)
+
+	slots := make(map[string]common.Hash)
+	for _, entry := range layout.Storage {
+		slots[entry.Label] = common.BigToHash(big.NewInt(int64(entry.Slot)))
+	}
+	require.Equal(t, slots["overhead"], OverheadSystemConfigStorageSlot)
+	require.Equal(t, slots["scalar"], ScalarSystemConfigStorageSlot)
+	require.Equal(t, slots["gasLimit"], GasLimitSystemConfigStorageSlot)
+}
+
+func TestRuntimeConfigSystemConfigAtL1Origin(t *testing.T) {
+	src := &fakeStorageSource{}
+	runCfg := NewRuntimeConfig(testlog.Logger(t, log.LvlError), src, &rollup.Config{L1SystemConfigAddress: testSystemConfigAddr})
+	load := func(num uint64, gasLimit uint64) {
+		src.set(testSystemConfigAddr, GasLimitSystemConfigStorageSlot, common.BigToHash(new(big.Int).SetUint64(gasLimit)))
+		require.NoError(t, runCfg.Load(context.Background(), eth.L1BlockRef{Hash: common.Hash{byte(num)}, Number: num}))
+	}
+	requireGasLimit := func(l1Origin uint64, gasLimit uint64) {
+		sysCfg, ok := runCfg.P2PSystemConfig(l1Origin)
+		require.True(t, ok, "system config at %d must be known", l1Origin)
+		require.Equal(t, gasLimit, sysCfg.GasLimit, "gas limit at %d", l1Origin)
+	}
+	requireUnknown := func(l1Origin uint64) {
+		_, ok := runCfg.P2PSystemConfig(l1Origin)
+		require.False(t, ok, "system config at %d must not be known", l1Origin)
+	}
+
+	requireUnknown(10)
+	load(10, 30_000_000)
+	load(11, 30_000_000)
+	requireGasLimit(5, 30_000_000)
+	requireGasLimit(11, 30_000_000)
+	requireUnknown(12)
+
+	// the gas limit changed at the L1 block 12 or 13
+	load(13, 20_000_000)
+	load(14, 20_000_000)
+	requireGasLimit(11, 30_000_000)
+	requireUnknown(12)
+	requireGasLimit(13, 20_000_000)
+	requireGasLimit(14, 20_000_000)
+	requireUnknown(15)
+
+	// a reorg past the change
+	load(12, 25_000_000)
+	requireGasLimit(12, 25_000_000)
+	requireUnknown(13)
+}
@@ -25,11 +25,14 @@ type rpcServer struct {
 	appVersion string
 	listenAddr net.Addr
 	log        log.Logger
+	events     http.Handler
+	requestLog RPCRequestLogConfig
 	sources.L2Client
 }
 
 func newRPCServer(ctx context.Context, rpcCfg *RPCConfig, rollupCfg *rollup.Config, l2Client l2EthClient, dr driverClient, log log.Logger, appVersion string, m metrics.Metricer) (*rpcServer, error) {
 	api := NewNodeAPI(rollupCfg, l2Client, dr, log.New("rpc", "node"), m)
+	api.daBudget = rpcCfg.TxPoolDABudget
 	// TODO: extend RPC config with options for WS, IPC and HTTP RPC connections
 	endpoint := net.JoinHostPort(rpcCfg.ListenAddr, strconv.Itoa(rpcCfg.ListenPort))
 	r := &rpcServer{
@@ -42,6 +45,7 @@ func newRPCServer(ctx context.Context, rpcCfg *RPCConfig, rollupCfg *rollup.Conf
 		}},
 		appVersion: appVersion,
 		log:        log,
+		requestLog: rpcCfg.RequestLog,
 	}
 	return r, nil
 }
@@ -66,6 +70,10 @@ func (s *rpcServer) EnableP2P(backend *p2p.APIBackend) {
 	})
 }
 
+func (s *rpcServer) EnableEvents(events http.Handler) {
+	s.events = events
+}
+
 func (s *rpcServer) Start() error {
 	srv := rpc.NewServer()
 	if err := node.RegisterApis(s.apis, nil, srv); err != nil {
@@ -76,11 +84,18 @@ func (s *rpcServer) Start() error {
 	// other services to connect to the kroma-node. VHosts in particular
 	// defaults to localhost, which will prevent containers from
 	// calling into the kroma-node without an "invalid host" error.
-	nodeHandler := node.NewHTTPHandlerStack(srv, []string{"*"}, []string{"*"}, nil)
+	var handler http.Handler = srv
+	if s.requestLog.active() {
+		handler = newRPCRequestLogger(s.requestLog, s.log.New("rpc", "requests"), srv)
+	}
+	nodeHandler := node.NewHTTPHandlerStack(handler, []string{"*"}, []string{"*"}, nil)
 
 	mux := http.NewServeMux()
 	mux.Handle("/", nodeHandler)
 	mux.HandleFunc("/healthz", healthzHandler(s.appVersion))
+	if s.events != nil {
+		mux.Handle("/events", s.events)
+	}
 
 	listener, err := net.Listen("tcp", s.endpoint)
 	if err != nil {
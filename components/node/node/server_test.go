@@ -2,12 +2,16 @@ package node
 
 import (
 	"context"
+	"crypto/ecdsa"
 	"encoding/json"
+	"math/big"
 	"math/rand"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -18,6 +22,8 @@ import (
 	"github.com/kroma-network/kroma/components/node/eth"
 	"github.com/kroma-network/kroma/components/node/metrics"
 	"github.com/kroma-network/kroma/components/node/rollup"
+	"github.com/kroma-network/kroma/components/node/rollup/derive"
+	"github.com/kroma-network/kroma/components/node/sources"
 	"github.com/kroma-network/kroma/components/node/testlog"
 	"github.com/kroma-network/kroma/components/node/testutils"
 	"github.com/kroma-network/kroma/components/node/version"
@@ -147,6 +153,25 @@ func TestOutputAtBlock(t *testing.T) {
 	require.Equal(t, "0xb46d4bcb0e471e1b8506031a1f34ebc6f200253cbaba56246dd2320e8e2c8f13", out.StateRoot.String())
 	require.Equal(t, "0xc1917a80cb25ccc50d0d1921525a44fb619b4601194ca726ae32312f08a799f8", out.WithdrawalStorageRoot.String())
 	require.Equal(t, *status, *out.Status)
+
+	for _, tc := range []struct {
+		outputRoot eth.Bytes32
+		valid      bool
+	}{
+		{outputRoot: out.OutputRoot, valid: true},
+		{outputRoot: eth.Bytes32{0x01}, valid: false},
+	} {
+		l2Client.ExpectInfoByHash(common.HexToHash("0x8512bee03061475e4b069171f7b406097184f16b22c3f5c97c0abfc49591c524"), &info, nil)
+		l2Client.ExpectGetProof(predeploys.L2ToL1MessagePasserAddr, []common.Hash{}, "0x8512bee03061475e4b069171f7b406097184f16b22c3f5c97c0abfc49591c524", &result, nil)
+
+		var verification *eth.OutputVerificationResponse
+		err = client.CallContext(context.Background(), &verification, "kroma_verifyOutputProposal", tc.outputRoot, "0xdcdc89")
+		require.NoError(t, err)
+		require.Equal(t, tc.valid, verification.Valid)
+		require.Equal(t, tc.outputRoot, verification.ProposedOutputRoot)
+		require.Equal(t, out.OutputRoot, verification.LocalOutput.OutputRoot)
+	}
+
 	l2Client.Mock.AssertExpectations(t)
 	drClient.Mock.AssertExpectations(t)
 }
@@ -176,6 +201,71 @@ func TestVersion(t *testing.T) {
 	assert.Equal(t, version.Version+"-"+version.Meta, out)
 }
 
+func TestProtocolVersion(t *testing.T) {
+	log := testlog.Logger(t, log.LvlError)
+	l2Client := &testutils.MockL2Client{}
+	drClient := &mockDriverClient{}
+	rpcCfg := &RPCConfig{
+		ListenAddr: "localhost",
+		ListenPort: 0,
+	}
+	rollupCfg := &rollup.Config{
+		BlockTime: 2,
+		L2ChainID: big.NewInt(901),
+	}
+	server, err := newRPCServer(context.Background(), rpcCfg, rollupCfg, l2Client, drClient, log, "0.0", metrics.NoopMetrics)
+	require.NoError(t, err)
+	require.NoError(t, server.Start())
+	defer server.Stop()
+
+	client, err := rpcclient.NewRPC(context.Background(), log, "http://"+server.Addr().String(), rpcclient.WithDialBackoff(3))
+	require.NoError(t, err)
+
+	var out *eth.NodeProtocolVersion
+	err = client.CallContext(context.Background(), &out, "kroma_protocolVersion")
+	require.NoError(t, err)
+	require.Equal(t, rollup.SupportedProtocolVersion, out.ProtocolVersion)
+}
+
+func TestDerivationState(t *testing.T) {
+	log := testlog.Logger(t, log.LvlError)
+	l2Client := &testutils.MockL2Client{}
+	drClient := &mockDriverClient{}
+	rng := rand.New(rand.NewSource(1234))
+	state := &derive.DerivationState{
+		Origin: testutils.RandomBlockRef(rng),
+		PendingFrames: []derive.FrameState{
+			{ID: derive.ChannelID{0x01}, FrameNumber: 1, Size: 300, IsLast: true},
+		},
+		Channels: []derive.ChannelState{
+			{ID: derive.ChannelID{0x02}, OpenBlock: testutils.RandomBlockID(rng), Age: 3, Size: 500, Frames: []uint16{0, 2}, Closed: true, EndFrameNumber: 2},
+		},
+		ChannelBankSize:    500,
+		MaxChannelBankSize: derive.MaxChannelBankSize,
+	}
+	drClient.On("DerivationState").Return(state)
+
+	rpcCfg := &RPCConfig{
+		ListenAddr: "localhost",
+		ListenPort: 0,
+	}
+	rollupCfg := &rollup.Config{
+		// ignore other rollup config info in this test
+	}
+	server, err := newRPCServer(context.Background(), rpcCfg, rollupCfg, l2Client, drClient, log, "0.0", metrics.NoopMetrics)
+	assert.NoError(t, err)
+	server.EnableAdminAPI(NewAdminAPI(drClient, metrics.NoopMetrics))
+	assert.NoError(t, server.Start())
+	defer server.Stop()
+
+	client, err := rpcclient.NewRPC(context.Background(), log, "http://"+server.Addr().String(), rpcclient.WithDialBackoff(3))
+	assert.NoError(t, err)
+
+	out, err := sources.NewRollupClient(client).DerivationState(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, state, out)
+}
+
 func randomSyncStatus(rng *rand.Rand) *eth.SyncStatus {
 	return &eth.SyncStatus{
 		CurrentL1:          testutils.RandomBlockRef(rng),
@@ -247,3 +337,220 @@ func (c *mockDriverClient) StartProposer(ctx context.Context, blockHash common.H
 func (c *mockDriverClient) StopProposer(ctx context.Context) (common.Hash, error) {
 	return c.Mock.MethodCalled("StopProposer").Get(0).(common.Hash), nil
 }
+
+func (c *mockDriverClient) DerivationState(ctx context.Context) (*derive.DerivationState, error) {
+	return c.Mock.MethodCalled("DerivationState").Get(0).(*derive.DerivationState), nil
+}
+
+func TestEstimateTotalFee(t *testing.T) {
+	log := testlog.Logger(t, log.LvlError)
+	rng := rand.New(rand.NewSource(1234))
+
+	l1Info := testutils.RandomBlockInfo(rng)
+	l1Info.InfoBaseFee = big.NewInt(30_000_000_000)
+	sysCfg := eth.SystemConfig{
+		Overhead: eth.Bytes32(common.BigToHash(big.NewInt(2100))),
+		Scalar:   eth.Bytes32(common.BigToHash(big.NewInt(1_000_000))),
+	}
+	dep, err := derive.L1InfoDeposit(0, l1Info, sysCfg)
+	require.NoError(t, err)
+
+	head := testutils.RandomBlockInfo(rng)
+	head.InfoBaseFee = big.NewInt(1_000_000_000)
+
+	l2Client := &testutils.MockL2Client{}
+	l2Client.ExpectInfoAndTxsByLabel(eth.Unsafe, head, types.Transactions{types.NewTx(dep)}, nil)
+	l2Client.ExpectEstimateGas(mock.Anything, 21000, nil)
+	l2Client.ExpectMaxPriorityFeePerGas(big.NewInt(100_000_000), nil)
+
+	drClient := &mockDriverClient{}
+	rpcCfg := &RPCConfig{
+		ListenAddr: "localhost",
+		ListenPort: 0,
+	}
+	rollupCfg := &rollup.Config{
+		L2ChainID: big.NewInt(901),
+	}
+	server, err := newRPCServer(context.Background(), rpcCfg, rollupCfg, l2Client, drClient, log, "0.0", metrics.NoopMetrics)
+	require.NoError(t, err)
+	require.NoError(t, server.Start())
+	defer server.Stop()
+
+	client, err := rpcclient.NewRPC(context.Background(), log, "http://"+server.Addr().String(), rpcclient.WithDialBackoff(3))
+	require.NoError(t, err)
+
+	to := testutils.RandomAddress(rng)
+	nonce := hexutil.Uint64(7)
+	args := eth.TxCallArgs{
+		To:    &to,
+		Nonce: &nonce,
+		Value: (*hexutil.Big)(big.NewInt(1)),
+	}
+	var out *eth.FeeEstimate
+	err = client.CallContext(context.Background(), &out, "kroma_estimateTotalFee", args)
+	require.NoError(t, err)
+
+	gasPrice := big.NewInt(1_100_000_000)
+	executionFee := new(big.Int).Mul(gasPrice, big.NewInt(21000))
+	require.Equal(t, hexutil.Uint64(21000), out.GasLimit)
+	testutils.RequireBigEqual(t, gasPrice, out.GasPrice.ToInt())
+	testutils.RequireBigEqual(t, executionFee, out.ExecutionFee.ToInt())
+	testutils.RequireBigEqual(t, l1Info.InfoBaseFee, out.L1BaseFee.ToInt())
+	require.Greater(t, uint64(out.L1GasUsed), uint64(2100))
+	l1Fee := new(big.Int).Mul(new(big.Int).SetUint64(uint64(out.L1GasUsed)), l1Info.InfoBaseFee)
+	testutils.RequireBigEqual(t, l1Fee, out.L1Fee.ToInt())
+	testutils.RequireBigEqual(t, new(big.Int).Add(executionFee, l1Fee), out.TotalFee.ToInt())
+	l2Client.Mock.AssertExpectations(t)
+
+	// the L1 gas of the estimate covers the transaction once signed
+	key, err := crypto.ToECDSA(common.FromHex("0x8a1f9a8f95be41cd7ccb6168179afb4504aefe388d1e14474d32c45c72ce7b7a"))
+	require.NoError(t, err)
+	signed := types.MustSignNewTx(key, types.LatestSignerForChainID(rollupCfg.L2ChainID), &types.DynamicFeeTx{
+		ChainID:   rollupCfg.L2ChainID,
+		Nonce:     uint64(nonce),
+		To:        &to,
+		Value:     big.NewInt(1),
+		Gas:       21000,
+		GasFeeCap: gasPrice,
+		GasTipCap: big.NewInt(100_000_000),
+	})
+	require.GreaterOrEqual(t, uint64(out.L1GasUsed), signed.RollupDataGas().DataGas()+2100)
+	signedData, err := signed.MarshalBinary()
+	require.NoError(t, err)
+	data, err := estimationTxData(args, rollupCfg.L2ChainID, 21000, gasPrice, big.NewInt(100_000_000))
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, len(data), len(signedData), "the estimated transaction must not be shorter than the signed transaction")
+}
+
+func TestPendingTransactions(t *testing.T) {
+	log := testlog.Logger(t, log.LvlError)
+	rng := rand.New(rand.NewSource(1234))
+
+	l1Info := testutils.RandomBlockInfo(rng)
+	l1Info.InfoBaseFee = big.NewInt(30_000_000_000)
+	sysCfg := eth.SystemConfig{
+		Overhead: eth.Bytes32(common.BigToHash(big.NewInt(2100))),
+		Scalar:   eth.Bytes32(common.BigToHash(big.NewInt(1_000_000))),
+	}
+	dep, err := derive.L1InfoDeposit(0, l1Info, sysCfg)
+	require.NoError(t, err)
+
+	head := testutils.RandomBlockInfo(rng)
+	head.InfoBaseFee = big.NewInt(1_000_000_000)
+	head.InfoGasLimit = 30_000_000
+
+	chainID := big.NewInt(901)
+	signer := types.LatestSignerForChainID(chainID)
+	newTx := func(key *ecdsa.PrivateKey, nonce uint64, gasTipCap, gasFeeCap int64) *eth.TxPoolTransaction {
+		tx := types.MustSignNewTx(key, signer, &types.DynamicFeeTx{
+			ChainID:   chainID,
+			Nonce:     nonce,
+			GasTipCap: big.NewInt(gasTipCap),
+			GasFeeCap: big.NewInt(gasFeeCap),
+			Gas:       21000,
+			To:        &common.Address{0x01},
+			Value:     big.NewInt(1),
+		})
+		return &eth.TxPoolTransaction{Tx: tx, From: crypto.PubkeyToAddress(key.PublicKey)}
+	}
+	keyA, keyB, keyC := testutils.RandomKey(), testutils.RandomKey(), testutils.RandomKey()
+	a0 := newTx(keyA, 0, 2_000_000_000, 10_000_000_000)
+	a1 := newTx(keyA, 1, 2_000_000_000, 10_000_000_000)
+	a5 := newTx(keyA, 5, 2_000_000_000, 10_000_000_000)
+	b0 := newTx(keyB, 0, 1_000_000_000, 10_000_000_000)
+	c0 := newTx(keyC, 0, 100_000_000, 500_000_000)
+	keyD := testutils.RandomKey()
+	d0 := newTx(keyD, 0, 500_000_000, 10_000_000_000)
+	content := &eth.TxPoolContent{
+		Pending: map[common.Address]map[string]*eth.TxPoolTransaction{
+			a0.From: {"0": a0, "1": a1},
+			b0.From: {"0": b0},
+			c0.From: {"0": c0},
+			d0.From: {"0": d0},
+		},
+		Queued: map[common.Address]map[string]*eth.TxPoolTransaction{
+			a5.From: {"5": a5},
+		},
+	}
+
+	l2Client := &testutils.MockL2Client{}
+	l2Client.ExpectInfoAndTxsByLabel(eth.Unsafe, head, types.Transactions{types.NewTx(dep)}, nil)
+	l2Client.ExpectTxPoolContent(content, nil)
+
+	drClient := &mockDriverClient{}
+	// the budget only fits the transactions of the first sender
+	daBudget := a0.Tx.Size() + a1.Tx.Size()
+	rpcCfg := &RPCConfig{
+		ListenAddr:     "localhost",
+		ListenPort:     0,
+		TxPoolDABudget: daBudget,
+	}
+	rollupCfg := &rollup.Config{
+		L2ChainID: chainID,
+	}
+	server, err := newRPCServer(context.Background(), rpcCfg, rollupCfg, l2Client, drClient, log, "0.0", metrics.NoopMetrics)
+	require.NoError(t, err)
+	require.NoError(t, server.Start())
+	defer server.Stop()
+
+	client, err := rpcclient.NewRPC(context.Background(), log, "http://"+server.Addr().String(), rpcclient.WithDialBackoff(3))
+	require.NoError(t, err)
+
+	var out *eth.PendingTransactions
+	err = client.CallContext(context.Background(), &out, "kroma_pendingTransactions")
+	require.NoError(t, err)
+
+	testutils.RequireBigEqual(t, head.InfoBaseFee, out.BaseFee.ToInt())
+	testutils.RequireBigEqual(t, l1Info.InfoBaseFee, out.L1BaseFee.ToInt())
+	require.Equal(t, hexutil.Uint64(daBudget), out.DABudget)
+	require.Equal(t, hexutil.Uint64(30_000_000), out.GasLimit)
+	require.Equal(t, hexutil.Uint64(1), out.Queued)
+
+	// the listing stops at the first transaction exceeding the budget
+	require.Len(t, out.Pending, 3)
+	for i, tx := range []*eth.TxPoolTransaction{a0, a1, b0} {
+		require.Equal(t, tx.Tx.Hash(), out.Pending[i].Hash, "transaction %d", i)
+		require.Equal(t, tx.From, out.Pending[i].From)
+		require.Equal(t, hexutil.Uint64(tx.Tx.Size()), out.Pending[i].Size)
+	}
+	require.Equal(t, hexutil.Uint64(1), out.Remaining, "transaction beyond the budget")
+	require.Equal(t, hexutil.Uint64(1), out.Underpriced, "transaction that cannot be included at the base fee")
+
+	// the execution fee is paid at the base fee plus the tip
+	testutils.RequireBigEqual(t, big.NewInt(3_000_000_000*21000), out.Pending[0].ExecutionFee.ToInt())
+	l1Fee := types.L1Cost(a0.Tx.RollupDataGas().DataGas(), l1Info.InfoBaseFee, big.NewInt(2100), big.NewInt(1_000_000))
+	testutils.RequireBigEqual(t, l1Fee, out.Pending[0].L1Fee.ToInt())
+	total := new(big.Int).Add(l1Fee, out.Pending[0].ExecutionFee.ToInt())
+	share, _ := new(big.Float).Quo(new(big.Float).SetInt(l1Fee), new(big.Float).SetInt(total)).Float64()
+	require.InDelta(t, share, out.Pending[0].L1FeeShare, 1e-9)
+
+	require.Equal(t, hexutil.Uint64(a0.Tx.Size()), *out.Pending[0].CumulativeSize)
+	require.Equal(t, hexutil.Uint64(21000), *out.Pending[0].CumulativeGas)
+	require.Equal(t, hexutil.Uint64(daBudget), *out.Pending[1].CumulativeSize)
+	require.False(t, out.Pending[1].ExceedsDABudget)
+	require.Equal(t, hexutil.Uint64(daBudget+b0.Tx.Size()), *out.Pending[2].CumulativeSize)
+	require.True(t, out.Pending[2].ExceedsDABudget)
+	require.False(t, out.Pending[2].ExceedsGasLimit)
+	l2Client.Mock.AssertExpectations(t)
+
+	// without a data availability budget, the listing stops at the gas limit, and the lower base fee makes the
+	// underpriced transaction executable
+	head.InfoGasLimit = 3*21000 + 1
+	head.InfoBaseFee = big.NewInt(400_000_000)
+	l2Client.ExpectInfoAndTxsByLabel(eth.Unsafe, head, types.Transactions{types.NewTx(dep)}, nil)
+	l2Client.ExpectTxPoolContent(content, nil)
+	api := NewNodeAPI(rollupCfg, l2Client, drClient, log, metrics.NoopMetrics)
+	out, err = api.PendingTransactions(context.Background())
+	require.NoError(t, err)
+	require.Zero(t, out.DABudget)
+	require.Len(t, out.Pending, 4)
+	for i, tx := range []*eth.TxPoolTransaction{a0, a1, b0, d0} {
+		require.Equal(t, tx.Tx.Hash(), out.Pending[i].Hash, "transaction %d", i)
+		require.False(t, out.Pending[i].ExceedsDABudget)
+		require.Equal(t, i == 3, out.Pending[i].ExceedsGasLimit, "transaction %d", i)
+	}
+	require.Equal(t, hexutil.Uint64(4*21000), *out.Pending[3].CumulativeGas)
+	require.Equal(t, hexutil.Uint64(1), out.Remaining, "transaction beyond the gas limit")
+	require.Zero(t, out.Underpriced)
+	l2Client.Mock.AssertExpectations(t)
+}
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/golang/snappy"
@@ -21,6 +22,7 @@ import (
 
 	"github.com/kroma-network/kroma/components/node/eth"
 	"github.com/kroma-network/kroma/components/node/rollup"
+	"github.com/kroma-network/kroma/components/node/rollup/derive"
 )
 
 const (
@@ -59,11 +61,15 @@ type GossipSetupConfigurables interface {
 
 type GossipRuntimeConfig interface {
 	P2PProposerAddress() common.Address
+	// P2PSystemConfig returns the SystemConfig values at the L1 block of the number, which the content of the
+	// gossiped payloads with that L1 origin is verified against, or false if the values are not known.
+	P2PSystemConfig(l1Origin uint64) (eth.SystemConfig, bool)
 }
 
 //go:generate mockery --name GossipMetricer
 type GossipMetricer interface {
 	RecordGossipEvent(evType int32)
+	RecordGossipPayloadRejection(reason string)
 	// Peer Scoring Metric Funcs
 	SetPeerScores(map[string]float64)
 }
@@ -239,7 +245,7 @@ func (sb *seenBlocks) markSeen(h common.Hash) {
 	sb.blockHashes = append(sb.blockHashes, h)
 }
 
-func BuildBlocksValidator(log log.Logger, cfg *rollup.Config, runCfg GossipRuntimeConfig) pubsub.ValidatorEx {
+func BuildBlocksValidator(log log.Logger, cfg *rollup.Config, runCfg GossipRuntimeConfig, m GossipMetricer) pubsub.ValidatorEx {
 
 	// Seen block hashes per block height
 	// uint64 -> *seenBlocks
@@ -310,6 +316,14 @@ func BuildBlocksValidator(log log.Logger, cfg *rollup.Config, runCfg GossipRunti
 			return pubsub.ValidationReject
 		}
 
+		// [REJECT] if the content of the payload violates the constraints of the SystemConfig
+		if result, reason := verifyPayloadContent(log, cfg, runCfg, &payload); result != pubsub.ValidationAccept {
+			if result == pubsub.ValidationReject {
+				m.RecordGossipPayloadRejection(reason)
+			}
+			return result
+		}
+
 		seen, ok := blockHeightLRU.Get(uint64(payload.BlockNumber))
 		if !ok {
 			seen = new(seenBlocks)
@@ -365,6 +379,73 @@ func verifyBlockSignature(log log.Logger, cfg *rollup.Config, runCfg GossipRunti
 	return pubsub.ValidationAccept
 }
 
+// The reasons a gossiped payload is rejected for its content.
+const (
+	PayloadRejectionGasLimit       = "gas-limit"
+	PayloadRejectionL1Info         = "l1-info"
+	PayloadRejectionFeeParams      = "fee-params"
+	PayloadRejectionTimestampDrift = "timestamp-drift"
+)
+
+// verifyPayloadContent verifies the content of the payload against the SystemConfig values of the runtime config at
+// the L1 origin of the payload, and the derivation rules of the L1 info deposit. It returns the validation result,
+// with the reason to reject the payload. The payload is ignored if the SystemConfig values at its L1 origin are not
+// known.
+func verifyPayloadContent(log log.Logger, cfg *rollup.Config, runCfg GossipRuntimeConfig, payload *eth.ExecutionPayload) (pubsub.ValidationResult, string) {
+	// [REJECT] if the first transaction is not a valid L1 info deposit
+	if len(payload.Transactions) == 0 {
+		log.Warn("payload has no L1 info deposit")
+		return pubsub.ValidationReject, PayloadRejectionL1Info
+	}
+	var infoTx types.Transaction
+	if err := infoTx.UnmarshalBinary(payload.Transactions[0]); err != nil || infoTx.Type() != types.DepositTxType {
+		log.Warn("payload has invalid L1 info deposit", "err", err)
+		return pubsub.ValidationReject, PayloadRejectionL1Info
+	}
+	l1Info, err := derive.L1InfoDepositTxData(infoTx.Data())
+	if err != nil {
+		log.Warn("payload has invalid L1 info deposit", "err", err)
+		return pubsub.ValidationReject, PayloadRejectionL1Info
+	}
+
+	// [IGNORE] if the SystemConfig at the L1 origin is not known
+	sysCfg, ok := runCfg.P2PSystemConfig(l1Info.Number)
+	if !ok {
+		log.Warn("unknown system config at the L1 origin of the payload, ignoring gossiped block", "l1_origin", l1Info.Number)
+		return pubsub.ValidationIgnore, ""
+	}
+
+	// [REJECT] if the gas limit is not the gas limit of the SystemConfig
+	if uint64(payload.GasLimit) != sysCfg.GasLimit {
+		log.Warn("payload has unexpected gas limit", "gas_limit", uint64(payload.GasLimit), "expected", sysCfg.GasLimit)
+		return pubsub.ValidationReject, PayloadRejectionGasLimit
+	}
+
+	// [REJECT] if the L1 fee parameters are not the fee parameters of the SystemConfig
+	if l1Info.L1FeeOverhead != sysCfg.Overhead || l1Info.L1FeeScalar != sysCfg.Scalar {
+		log.Warn("payload has unexpected L1 fee parameters", "overhead", l1Info.L1FeeOverhead, "scalar", l1Info.L1FeeScalar)
+		return pubsub.ValidationReject, PayloadRejectionFeeParams
+	}
+
+	// [REJECT] if the `payload.timestamp` is before the L1 origin,
+	// or drifts past the max proposer drift while including transactions
+	timestamp := uint64(payload.Timestamp)
+	if timestamp < l1Info.Time {
+		log.Warn("payload is older than its L1 origin", "timestamp", timestamp, "l1_origin_time", l1Info.Time)
+		return pubsub.ValidationReject, PayloadRejectionTimestampDrift
+	}
+	if timestamp > l1Info.Time+cfg.MaxProposerDrift {
+		for _, tx := range payload.Transactions {
+			// empty batches, with deposits only, may exceed the drift to maintain the L2 block time
+			if len(tx) > 0 && tx[0] != types.DepositTxType {
+				log.Warn("payload with transactions drifts past its L1 origin", "timestamp", timestamp, "l1_origin_time", l1Info.Time)
+				return pubsub.ValidationReject, PayloadRejectionTimestampDrift
+			}
+		}
+	}
+	return pubsub.ValidationAccept, ""
+}
+
 type GossipIn interface {
 	OnUnsafeL2Payload(ctx context.Context, from peer.ID, msg *eth.ExecutionPayload) error
 }
@@ -423,8 +504,8 @@ func (p *publisher) Close() error {
 	return p.blocksTopic.Close()
 }
 
-func JoinGossip(p2pCtx context.Context, self peer.ID, topicScoreParams *pubsub.TopicScoreParams, ps *pubsub.PubSub, log log.Logger, cfg *rollup.Config, runCfg GossipRuntimeConfig, gossipIn GossipIn) (GossipOut, error) {
-	val := guardGossipValidator(log, logValidationResult(self, "validated block", log, BuildBlocksValidator(log, cfg, runCfg)))
+func JoinGossip(p2pCtx context.Context, self peer.ID, topicScoreParams *pubsub.TopicScoreParams, ps *pubsub.PubSub, log log.Logger, cfg *rollup.Config, runCfg GossipRuntimeConfig, gossipIn GossipIn, m GossipMetricer) (GossipOut, error) {
+	val := guardGossipValidator(log, logValidationResult(self, "validated block", log, BuildBlocksValidator(log, cfg, runCfg, m)))
 	blocksTopicName := blocksTopicV1(cfg)
 	err := ps.RegisterTopicValidator(blocksTopicName,
 		val,
@@ -3,16 +3,20 @@ package p2p
 import (
 	"context"
 	"math/big"
+	"math/rand"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/log"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/stretchr/testify/require"
 
+	"github.com/kroma-network/kroma/components/node/eth"
 	"github.com/kroma-network/kroma/components/node/rollup"
+	"github.com/kroma-network/kroma/components/node/rollup/derive"
 	"github.com/kroma-network/kroma/components/node/testlog"
 	"github.com/kroma-network/kroma/components/node/testutils"
 	"github.com/kroma-network/kroma/e2e/e2eutils"
@@ -83,3 +87,89 @@ func TestVerifyBlockSignature(t *testing.T) {
 		require.Equal(t, pubsub.ValidationIgnore, result)
 	})
 }
+
+func TestVerifyPayloadContent(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlCrit)
+	cfg := &rollup.Config{MaxProposerDrift: 600}
+	sysCfg := eth.SystemConfig{
+		Overhead: eth.Bytes32{0x01},
+		Scalar:   eth.Bytes32{0x02},
+		GasLimit: 30_000_000,
+	}
+	newSysCfg := sysCfg
+	newSysCfg.GasLimit = 20_000_000
+	l1Info := testutils.RandomBlockInfo(rand.New(rand.NewSource(1234)))
+	l1Info.InfoNum = 10
+	l1Info.InfoTime = 1000
+
+	payloadWith := func(sysCfg eth.SystemConfig, l1Origin uint64, timestamp uint64, txs ...eth.Data) *eth.ExecutionPayload {
+		info := *l1Info
+		info.InfoNum = l1Origin
+		infoTx, err := derive.L1InfoDepositBytes(0, &info, sysCfg)
+		require.NoError(t, err)
+		return &eth.ExecutionPayload{
+			GasLimit:     eth.Uint64Quantity(sysCfg.GasLimit),
+			Timestamp:    eth.Uint64Quantity(timestamp),
+			Transactions: append([]eth.Data{infoTx}, txs...),
+		}
+	}
+	userTx := eth.Data{types.DynamicFeeTxType, 0x01}
+	// the gas limit was changed at the L1 block 11
+	runCfg := &testutils.MockRuntimeConfig{P2PSysCfgs: map[uint64]eth.SystemConfig{10: sysCfg, 11: newSysCfg}}
+	accepts := func(t *testing.T, payload *eth.ExecutionPayload) {
+		result, reason := verifyPayloadContent(logger, cfg, runCfg, payload)
+		require.Equal(t, pubsub.ValidationAccept, result)
+		require.Empty(t, reason)
+	}
+	rejects := func(t *testing.T, payload *eth.ExecutionPayload, expected string) {
+		result, reason := verifyPayloadContent(logger, cfg, runCfg, payload)
+		require.Equal(t, pubsub.ValidationReject, result)
+		require.Equal(t, expected, reason)
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		accepts(t, payloadWith(sysCfg, 10, 1002, userTx))
+		accepts(t, payloadWith(newSysCfg, 11, 1002, userTx))
+	})
+	t.Run("unknown l1 origin", func(t *testing.T) {
+		result, _ := verifyPayloadContent(logger, cfg, runCfg, payloadWith(newSysCfg, 12, 1002))
+		require.Equal(t, pubsub.ValidationIgnore, result)
+	})
+	t.Run("system config of another l1 origin", func(t *testing.T) {
+		rejects(t, payloadWith(newSysCfg, 10, 1002), PayloadRejectionGasLimit)
+		rejects(t, payloadWith(sysCfg, 11, 1002), PayloadRejectionGasLimit)
+	})
+	t.Run("gas limit", func(t *testing.T) {
+		payload := payloadWith(sysCfg, 10, 1002)
+		payload.GasLimit++
+		rejects(t, payload, PayloadRejectionGasLimit)
+	})
+	t.Run("no l1 info", func(t *testing.T) {
+		payload := payloadWith(sysCfg, 10, 1002)
+		payload.Transactions = payload.Transactions[1:]
+		rejects(t, payload, PayloadRejectionL1Info)
+	})
+	t.Run("invalid l1 info", func(t *testing.T) {
+		payload := payloadWith(sysCfg, 10, 1002)
+		payload.Transactions[0] = userTx
+		rejects(t, payload, PayloadRejectionL1Info)
+	})
+	t.Run("fee params", func(t *testing.T) {
+		payload := payloadWith(sysCfg, 10, 1002)
+		otherSysCfg := sysCfg
+		otherSysCfg.Scalar = eth.Bytes32{0x03}
+		infoTx, err := derive.L1InfoDepositBytes(0, l1Info, otherSysCfg)
+		require.NoError(t, err)
+		payload.Transactions[0] = infoTx
+		rejects(t, payload, PayloadRejectionFeeParams)
+	})
+	t.Run("before l1 origin", func(t *testing.T) {
+		rejects(t, payloadWith(sysCfg, 10, 998), PayloadRejectionTimestampDrift)
+	})
+	t.Run("drift with transactions", func(t *testing.T) {
+		rejects(t, payloadWith(sysCfg, 10, 1602, userTx), PayloadRejectionTimestampDrift)
+	})
+	t.Run("drift without transactions", func(t *testing.T) {
+		accepts(t, payloadWith(sysCfg, 10, 1602))
+	})
+}
@@ -14,6 +14,11 @@ func (_m *GossipMetricer) RecordGossipEvent(evType int32) {
 	_m.Called(evType)
 }
 
+// RecordGossipPayloadRejection provides a mock function with given fields: reason
+func (_m *GossipMetricer) RecordGossipPayloadRejection(reason string) {
+	_m.Called(reason)
+}
+
 // SetPeerScores provides a mock function with given fields: _a0
 func (_m *GossipMetricer) SetPeerScores(_a0 map[string]float64) {
 	_m.Called(_a0)
@@ -21,6 +21,9 @@ import (
 // BatchV1Type := 0
 // batchV1 := BatchV1Type ++ RLP([epoch, timestamp, transaction_list]
 //
+// SpanBatchType := 1
+// spanBatch := SpanBatchType ++ prefix ++ payload, see span_batch.go
+//
 // An empty input is not a valid batch.
 //
 // Note: the type system is based on L1 typed transactions.
@@ -45,6 +48,8 @@ type BatchV1 struct {
 
 type BatchData struct {
 	BatchV1
+	// SpanBatch is the span batch of many L2 blocks, nil for a singular batch. The BatchV1 of a span batch is empty.
+	SpanBatch *SpanBatch
 	// batches may contain additional data with new upgrades
 }
 
@@ -71,6 +76,10 @@ func (b *BatchData) MarshalBinary() ([]byte, error) {
 }
 
 func (b *BatchData) encodeTyped(buf *bytes.Buffer) error {
+	if b.SpanBatch != nil {
+		buf.WriteByte(SpanBatchType)
+		return b.SpanBatch.encode(buf)
+	}
 	buf.WriteByte(BatchV1Type)
 	return rlp.Encode(buf, &b.BatchV1)
 }
@@ -102,6 +111,9 @@ func (b *BatchData) decodeTyped(data []byte) error {
 	switch data[0] {
 	case BatchV1Type:
 		return rlp.DecodeBytes(data[1:], &b.BatchV1)
+	case SpanBatchType:
+		b.SpanBatch = new(SpanBatch)
+		return b.SpanBatch.decode(data[1:])
 	default:
 		return fmt.Errorf("unrecognized batch type: %d", data[0])
 	}
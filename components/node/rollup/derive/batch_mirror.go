@@ -0,0 +1,229 @@
+package derive
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	lru "github.com/hashicorp/golang-lru/v2"
+
+	"github.com/kroma-network/kroma/components/node/eth"
+	"github.com/kroma-network/kroma/components/node/rollup"
+)
+
+const (
+	// mirrorPublishConcurrency is the number of L1 blocks published to the mirror at the same time. The batch data of
+	// more blocks is not published, to not fall behind the derivation.
+	mirrorPublishConcurrency = 4
+	mirrorPublishTimeout     = 30 * time.Second
+	// mirrorPublishedCacheSize is the number of L1 blocks remembered as published, to not publish them again when the
+	// pipeline is reset.
+	mirrorPublishedCacheSize = 1000
+)
+
+// BatchMirror is a key-value store mirroring the batch data posted to L1, e.g. on an HTTP or IPFS endpoint, to keep
+// the batch data available after the L1 history is pruned.
+type BatchMirror interface {
+	// Put stores the data under the key, replacing earlier data.
+	Put(ctx context.Context, key string, data []byte) error
+	// Get returns the data stored under the key, or an error wrapping ethereum.NotFound if there is none.
+	Get(ctx context.Context, key string) ([]byte, error)
+}
+
+// MirroredL1Block is the batch data of an L1 block in the mirror: the transactions sent to the batch inbox, in the
+// order of the block. It is stored for every L1 block, empty if the block has no batch data.
+type MirroredL1Block struct {
+	Number uint64      `json:"number"`
+	Hash   common.Hash `json:"hash"`
+	// Transactions are the binary encoded signed transactions.
+	Transactions []hexutil.Bytes `json:"transactions"`
+}
+
+// MirroredFrame is a frame of a channel in the mirror, with the L1 transaction that carried it.
+type MirroredFrame struct {
+	L1Block     eth.BlockID   `json:"l1Block"`
+	Transaction common.Hash   `json:"transaction"`
+	Frame       hexutil.Bytes `json:"frame"`
+}
+
+// MirrorBlockKey is the mirror key of the batch data of the L1 block.
+func MirrorBlockKey(hash common.Hash) string {
+	return "blocks/" + hash.Hex()
+}
+
+// MirrorFrameKey is the mirror key of the frame of the channel. The frames are keyed by their sender, so that frames
+// sent by others to the batch inbox do not replace the frames of the batcher.
+func MirrorFrameKey(sender common.Address, id ChannelID, frameNumber uint16) string {
+	return fmt.Sprintf("channels/%s/%s/%d", sender.Hex(), id, frameNumber)
+}
+
+// MirrorFetcher is an L1Fetcher that publishes the batch data of the fetched L1 blocks to a mirror, and falls back
+// to the mirror for the transactions of the L1 blocks pruned from the L1 RPC history.
+//
+// The mirror is trusted to serve all the batch inbox transactions of an L1 block: the completeness of the served
+// transactions cannot be verified against the header without the other transactions of the block. The senders of the
+// transactions are verified by their signatures when the batch data is read.
+type MirrorFetcher struct {
+	L1Fetcher
+
+	log     log.Logger
+	cfg     *rollup.Config
+	mirror  BatchMirror
+	publish bool
+
+	publishing chan struct{}
+	published  *lru.Cache[common.Hash, struct{}]
+}
+
+// NewMirrorFetcher wraps the fetcher with the mirror, publishing to it the batch data of the fetched L1 blocks if
+// publish is set.
+func NewMirrorFetcher(log log.Logger, cfg *rollup.Config, fetcher L1Fetcher, mirror BatchMirror, publish bool) *MirrorFetcher {
+	published, _ := lru.New[common.Hash, struct{}](mirrorPublishedCacheSize)
+	return &MirrorFetcher{
+		L1Fetcher:  fetcher,
+		log:        log,
+		cfg:        cfg,
+		mirror:     mirror,
+		publish:    publish,
+		publishing: make(chan struct{}, mirrorPublishConcurrency),
+		published:  published,
+	}
+}
+
+// InfoAndTxsByHash fetches the L1 block from the L1 RPC, and the batch inbox transactions of it from the mirror if the
+// block is not found. The transactions of a mirrored block are only those sent to the batch inbox.
+func (m *MirrorFetcher) InfoAndTxsByHash(ctx context.Context, hash common.Hash) (eth.BlockInfo, types.Transactions, error) {
+	info, txs, err := m.L1Fetcher.InfoAndTxsByHash(ctx, hash)
+	if err == nil {
+		if m.publish {
+			m.startPublish(info, txs)
+		}
+		return info, txs, nil
+	}
+	if !errors.Is(err, ethereum.NotFound) {
+		return nil, nil, err
+	}
+	// the headers stay available when the block bodies are pruned
+	info, infoErr := m.L1Fetcher.InfoByHash(ctx, hash)
+	if infoErr != nil {
+		return nil, nil, err
+	}
+	txs, mirrorErr := m.mirroredTxs(ctx, info)
+	if errors.Is(mirrorErr, ethereum.NotFound) {
+		return nil, nil, err
+	} else if mirrorErr != nil {
+		return nil, nil, fmt.Errorf("failed to read pruned L1 block %s from mirror: %w", hash, mirrorErr)
+	}
+	m.log.Debug("read pruned L1 block from mirror", "block", eth.InfoToL1BlockRef(info), "txs", len(txs))
+	return info, txs, nil
+}
+
+func (m *MirrorFetcher) mirroredTxs(ctx context.Context, info eth.BlockInfo) (types.Transactions, error) {
+	data, err := m.mirror.Get(ctx, MirrorBlockKey(info.Hash()))
+	if err != nil {
+		return nil, err
+	}
+	var block MirroredL1Block
+	if err := json.Unmarshal(data, &block); err != nil {
+		return nil, fmt.Errorf("failed to decode mirrored block: %w", err)
+	}
+	if block.Hash != info.Hash() || block.Number != info.NumberU64() {
+		return nil, fmt.Errorf("mirrored block %d %s does not match the L1 block", block.Number, block.Hash)
+	}
+	txs := make(types.Transactions, 0, len(block.Transactions))
+	for i, data := range block.Transactions {
+		var tx types.Transaction
+		if err := tx.UnmarshalBinary(data); err != nil {
+			return nil, fmt.Errorf("failed to decode mirrored transaction %d: %w", i, err)
+		}
+		if to := tx.To(); to == nil || *to != m.cfg.BatchInboxAddress {
+			return nil, fmt.Errorf("mirrored transaction %d %s is not sent to the batch inbox", i, tx.Hash())
+		}
+		txs = append(txs, &tx)
+	}
+	return txs, nil
+}
+
+// startPublish publishes the batch data of the L1 block in the background, unless it was published already or too
+// many blocks are being published.
+func (m *MirrorFetcher) startPublish(info eth.BlockInfo, txs types.Transactions) {
+	if m.published.Contains(info.Hash()) {
+		return
+	}
+	select {
+	case m.publishing <- struct{}{}:
+	default:
+		m.log.Warn("batch mirror is busy, not publishing L1 block", "block", eth.InfoToL1BlockRef(info))
+		return
+	}
+	go func() {
+		defer func() { <-m.publishing }()
+		ctx, cancel := context.WithTimeout(context.Background(), mirrorPublishTimeout)
+		defer cancel()
+		if err := m.publishBlock(ctx, info, txs); err != nil {
+			m.log.Warn("failed to publish L1 block to batch mirror", "block", eth.InfoToL1BlockRef(info), "err", err)
+			return
+		}
+		m.published.Add(info.Hash(), struct{}{})
+	}()
+}
+
+// publishBlock publishes the frames of the batch inbox transactions of the L1 block, then the block itself, so that a
+// mirrored block is only served once its frames are published.
+func (m *MirrorFetcher) publishBlock(ctx context.Context, info eth.BlockInfo, txs types.Transactions) error {
+	block := MirroredL1Block{Number: info.NumberU64(), Hash: info.Hash(), Transactions: []hexutil.Bytes{}}
+	l1Signer := m.cfg.L1Signer()
+	for _, tx := range txs {
+		if to := tx.To(); to == nil || *to != m.cfg.BatchInboxAddress {
+			continue
+		}
+		data, err := tx.MarshalBinary()
+		if err != nil {
+			return fmt.Errorf("failed to encode transaction %s: %w", tx.Hash(), err)
+		}
+		block.Transactions = append(block.Transactions, data)
+
+		sender, err := l1Signer.Sender(tx)
+		if err != nil {
+			continue
+		}
+		frames, err := ParseFrames(tx.Data())
+		if err != nil {
+			continue
+		}
+		for _, frame := range frames {
+			if err := m.publishFrame(ctx, sender, info, tx.Hash(), frame); err != nil {
+				return err
+			}
+		}
+	}
+	data, err := json.Marshal(&block)
+	if err != nil {
+		return err
+	}
+	return m.mirror.Put(ctx, MirrorBlockKey(block.Hash), data)
+}
+
+func (m *MirrorFetcher) publishFrame(ctx context.Context, sender common.Address, info eth.BlockInfo, txHash common.Hash, frame Frame) error {
+	var buf bytes.Buffer
+	if err := frame.MarshalBinary(&buf); err != nil {
+		return fmt.Errorf("failed to encode frame: %w", err)
+	}
+	data, err := json.Marshal(&MirroredFrame{
+		L1Block:     eth.BlockID{Hash: info.Hash(), Number: info.NumberU64()},
+		Transaction: txHash,
+		Frame:       buf.Bytes(),
+	})
+	if err != nil {
+		return err
+	}
+	return m.mirror.Put(ctx, MirrorFrameKey(sender, frame.ID, frame.FrameNumber), data)
+}
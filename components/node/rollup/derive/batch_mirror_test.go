@@ -0,0 +1,128 @@
+package derive
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"math/rand"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kroma-network/kroma/components/node/eth"
+	"github.com/kroma-network/kroma/components/node/rollup"
+	"github.com/kroma-network/kroma/components/node/testlog"
+	"github.com/kroma-network/kroma/components/node/testutils"
+)
+
+type memBatchMirror struct {
+	mu   sync.Mutex
+	data map[string][]byte
+}
+
+func (m *memBatchMirror) Put(_ context.Context, key string, data []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.data[key] = data
+	return nil
+}
+
+func (m *memBatchMirror) Get(_ context.Context, key string) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	data, ok := m.data[key]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ethereum.NotFound, key)
+	}
+	return data, nil
+}
+
+func TestMirrorFetcher(t *testing.T) {
+	ctx := context.Background()
+	rng := rand.New(rand.NewSource(1234))
+	batcherPriv := testutils.RandomKey()
+	batcherAddr := crypto.PubkeyToAddress(batcherPriv.PublicKey)
+	cfg := &rollup.Config{
+		L1ChainID:         big.NewInt(100),
+		BatchInboxAddress: testutils.RandomAddress(rng),
+	}
+	signer := cfg.L1Signer()
+
+	frame := Frame{ID: ChannelID{0x1}, FrameNumber: 3, Data: testutils.RandomData(rng, 100), IsLast: true}
+	var buf bytes.Buffer
+	buf.WriteByte(DerivationVersion0)
+	require.NoError(t, frame.MarshalBinary(&buf))
+	batchTx, err := types.SignNewTx(batcherPriv, signer, &types.DynamicFeeTx{
+		ChainID: signer.ChainID(),
+		Gas:     100_000,
+		To:      &cfg.BatchInboxAddress,
+		Data:    buf.Bytes(),
+	})
+	require.NoError(t, err)
+	other := testutils.RandomAddress(rng)
+	otherTx, err := types.SignNewTx(batcherPriv, signer, &types.DynamicFeeTx{ChainID: signer.ChainID(), Gas: 21_000, To: &other})
+	require.NoError(t, err)
+
+	info := testutils.RandomBlockInfo(rng)
+	l1 := &testutils.MockL1Source{}
+	mirror := &memBatchMirror{data: make(map[string][]byte)}
+	fetcher := NewMirrorFetcher(testlog.Logger(t, log.LvlCrit), cfg, l1, mirror, true)
+
+	// the batch data of a fetched block is published
+	l1.ExpectInfoAndTxsByHash(info.Hash(), info, types.Transactions{otherTx, batchTx}, nil)
+	_, txs, err := fetcher.InfoAndTxsByHash(ctx, info.Hash())
+	require.NoError(t, err)
+	require.Len(t, txs, 2)
+	require.Eventually(t, func() bool {
+		_, err := mirror.Get(ctx, MirrorBlockKey(info.Hash()))
+		return err == nil
+	}, time.Second, 10*time.Millisecond)
+	data, err := mirror.Get(ctx, MirrorFrameKey(batcherAddr, frame.ID, frame.FrameNumber))
+	require.NoError(t, err)
+	var mirroredFrame MirroredFrame
+	require.NoError(t, json.Unmarshal(data, &mirroredFrame))
+	require.Equal(t, batchTx.Hash(), mirroredFrame.Transaction)
+	require.Equal(t, eth.BlockID{Hash: info.Hash(), Number: info.NumberU64()}, mirroredFrame.L1Block)
+	parsed, err := ParseFrames(append([]byte{DerivationVersion0}, mirroredFrame.Frame...))
+	require.NoError(t, err)
+	require.Equal(t, []Frame{frame}, parsed)
+
+	// the batch inbox transactions of a pruned block are read from the mirror
+	l1.ExpectInfoAndTxsByHash(info.Hash(), info, nil, ethereum.NotFound)
+	l1.ExpectInfoByHash(info.Hash(), info, nil)
+	_, txs, err = fetcher.InfoAndTxsByHash(ctx, info.Hash())
+	require.NoError(t, err)
+	require.Len(t, txs, 1)
+	require.Equal(t, batchTx.Hash(), txs[0].Hash())
+	out := DataFromEVMTransactions(cfg, batcherAddr, txs, testlog.Logger(t, log.LvlCrit))
+	require.Equal(t, []eth.Data{batchTx.Data()}, out)
+
+	// a pruned block missing from the mirror is not found
+	missing := testutils.RandomBlockInfo(rng)
+	l1.ExpectInfoAndTxsByHash(missing.Hash(), missing, nil, ethereum.NotFound)
+	l1.ExpectInfoByHash(missing.Hash(), missing, nil)
+	_, _, err = fetcher.InfoAndTxsByHash(ctx, missing.Hash())
+	require.ErrorIs(t, err, ethereum.NotFound)
+
+	// a mirrored transaction not sent to the batch inbox is rejected
+	otherData, err := otherTx.MarshalBinary()
+	require.NoError(t, err)
+	block, err := json.Marshal(&MirroredL1Block{Number: missing.NumberU64(), Hash: missing.Hash(), Transactions: []hexutil.Bytes{otherData}})
+	require.NoError(t, err)
+	require.NoError(t, mirror.Put(ctx, MirrorBlockKey(missing.Hash()), block))
+	l1.ExpectInfoAndTxsByHash(missing.Hash(), missing, nil, ethereum.NotFound)
+	l1.ExpectInfoByHash(missing.Hash(), missing, nil)
+	_, _, err = fetcher.InfoAndTxsByHash(ctx, missing.Hash())
+	require.Error(t, err)
+	require.NotErrorIs(t, err, ethereum.NotFound)
+	l1.AssertExpectations(t)
+}
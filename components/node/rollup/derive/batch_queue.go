@@ -39,19 +39,28 @@ type BatchQueue struct {
 	config *rollup.Config
 	prev   NextBatchProvider
 	origin eth.L1BlockRef
+	// l2 fetches the safe chain, to check the span batches overlapping it
+	l2 SafeBlockFetcher
 
 	l1Blocks []eth.L1BlockRef
 
 	// batches in order of when we've first seen them, grouped by L2 timestamp
 	batches map[uint64][]*BatchWithL1InclusionBlock
+
+	// nextSpan are the remaining singular batches of the last accepted span batch, derived before any other batch
+	nextSpan []*BatchData
+
+	events Events
 }
 
 // NewBatchQueue creates a BatchQueue, which should be Reset(origin) before use.
-func NewBatchQueue(log log.Logger, cfg *rollup.Config, prev NextBatchProvider) *BatchQueue {
+func NewBatchQueue(log log.Logger, cfg *rollup.Config, prev NextBatchProvider, l2 SafeBlockFetcher, events Events) *BatchQueue {
 	return &BatchQueue{
 		log:    log,
 		config: cfg,
 		prev:   prev,
+		l2:     l2,
+		events: events,
 	}
 }
 
@@ -89,7 +98,7 @@ func (bq *BatchQueue) NextBatch(ctx context.Context, safeL2Head eth.L2BlockRef)
 	} else if err != nil {
 		return nil, err
 	} else if !originBehind {
-		bq.AddBatch(batch, safeL2Head)
+		bq.AddBatch(ctx, batch, safeL2Head)
 	}
 
 	// Skip adding data unless we are up to date with the origin, but do fully
@@ -111,6 +120,7 @@ func (bq *BatchQueue) NextBatch(ctx context.Context, safeL2Head eth.L2BlockRef)
 	} else if err != nil {
 		return nil, err
 	}
+	bq.events.OnBatchConsumed(batch, bq.origin)
 	return batch, nil
 }
 
@@ -119,6 +129,7 @@ func (bq *BatchQueue) Reset(ctx context.Context, base eth.L1BlockRef, _ eth.Syst
 	// It is set in the engine queue (two stages away) such that the L2 Safe Head origin is the progress
 	bq.origin = base
 	bq.batches = make(map[uint64][]*BatchWithL1InclusionBlock)
+	bq.nextSpan = nil
 	// Include the new origin as an origin to build on
 	// Note: This is only for the initialization case. During normal resets we will later
 	// throw out this block.
@@ -127,20 +138,32 @@ func (bq *BatchQueue) Reset(ctx context.Context, base eth.L1BlockRef, _ eth.Syst
 	return io.EOF
 }
 
-func (bq *BatchQueue) AddBatch(batch *BatchData, l2SafeHead eth.L2BlockRef) {
+func (bq *BatchQueue) AddBatch(ctx context.Context, batch *BatchData, l2SafeHead eth.L2BlockRef) {
+	timestamp := batch.Timestamp
+	if batch.SpanBatch != nil {
+		timestamp = batch.SpanBatch.Timestamp(bq.config, 0)
+	}
 	if len(bq.l1Blocks) == 0 {
-		panic(fmt.Errorf("cannot add batch with timestamp %d, no origin was prepared", batch.Timestamp))
+		panic(fmt.Errorf("cannot add batch with timestamp %d, no origin was prepared", timestamp))
 	}
 	data := BatchWithL1InclusionBlock{
 		L1InclusionBlock: bq.origin,
 		Batch:            batch,
 	}
-	validity := CheckBatch(bq.config, bq.log, bq.l1Blocks, l2SafeHead, &data)
+	validity := CheckBatch(ctx, bq.config, bq.log, bq.l1Blocks, l2SafeHead, &data, bq.l2)
 	if validity == BatchDrop {
 		return // if we do drop the batch, CheckBatch will log the drop reason with WARN level.
 	}
-	bq.log.Debug("Adding batch", "batch_timestamp", batch.Timestamp, "parent_hash", batch.ParentHash, "batch_epoch", batch.Epoch(), "txs", len(batch.Transactions))
-	bq.batches[batch.Timestamp] = append(bq.batches[batch.Timestamp], &data)
+	if batch.SpanBatch != nil {
+		// a span batch overlapping the safe chain is derived from the block after the safe head
+		if nextTimestamp := l2SafeHead.Time + bq.config.BlockTime; timestamp < nextTimestamp {
+			timestamp = nextTimestamp
+		}
+		bq.log.Debug("Adding span batch", "batch_timestamp", timestamp, "batch_end_epoch", batch.SpanBatch.L1OriginNum, "blocks", len(batch.SpanBatch.Blocks))
+	} else {
+		bq.log.Debug("Adding batch", "batch_timestamp", batch.Timestamp, "parent_hash", batch.ParentHash, "batch_epoch", batch.Epoch(), "txs", len(batch.Transactions))
+	}
+	bq.batches[timestamp] = append(bq.batches[timestamp], &data)
 }
 
 // deriveNextBatch derives the next batch to apply on top of the current L2 safe head,
@@ -165,6 +188,22 @@ func (bq *BatchQueue) deriveNextBatch(ctx context.Context, outOfData bool, l2Saf
 	// We may not have sufficient information to proceed filtering, and then we stop.
 	// There may be none: in that case we force-create an empty batch
 	nextTimestamp := l2SafeHead.Time + bq.config.BlockTime
+
+	// Continue with the remaining batches of the last accepted span batch, as long as they follow the safe head.
+	if len(bq.nextSpan) > 0 {
+		if batch := bq.nextSpan[0]; batch.Timestamp == nextTimestamp {
+			bq.nextSpan = bq.nextSpan[1:]
+			// the batches buffered for the timestamp are superseded by the span batch
+			delete(bq.batches, nextTimestamp)
+			// the batches of a span batch build on each other
+			batch.ParentHash = l2SafeHead.Hash
+			return bq.popNextBatch(epoch, batch), nil
+		}
+		bq.log.Warn("dropping the remaining batches of the span batch, the safe head did not advance as expected",
+			"next_timestamp", nextTimestamp, "span_timestamp", bq.nextSpan[0].Timestamp, "remaining", len(bq.nextSpan))
+		bq.nextSpan = nil
+	}
+
 	var nextBatch *BatchWithL1InclusionBlock
 
 	// Go over all batches, in order of inclusion, and find the first batch we can accept.
@@ -173,11 +212,21 @@ func (bq *BatchQueue) deriveNextBatch(ctx context.Context, outOfData bool, l2Saf
 	candidates := bq.batches[nextTimestamp]
 batchLoop:
 	for i, batch := range candidates {
-		validity := CheckBatch(bq.config, bq.log.New("batch_index", i), bq.l1Blocks, l2SafeHead, batch)
+		validity := CheckBatch(ctx, bq.config, bq.log.New("batch_index", i), bq.l1Blocks, l2SafeHead, batch, bq.l2)
 		switch validity {
 		case BatchFuture:
 			return nil, NewCriticalError(fmt.Errorf("found batch with timestamp %d marked as future batch, but expected timestamp %d", batch.Batch.Timestamp, nextTimestamp))
 		case BatchDrop:
+			if batch.Batch.SpanBatch != nil {
+				bq.log.Warn("dropping span batch",
+					"batch_timestamp", batch.Batch.SpanBatch.Timestamp(bq.config, 0),
+					"batch_end_epoch", batch.Batch.SpanBatch.L1OriginNum,
+					"blocks", len(batch.Batch.SpanBatch.Blocks),
+					"l2_safe_head", l2SafeHead.ID(),
+					"l2_safe_head_time", l2SafeHead.Time,
+				)
+				continue
+			}
 			bq.log.Warn("dropping batch",
 				"batch_timestamp", batch.Batch.Timestamp,
 				"parent_hash", batch.Batch.ParentHash,
@@ -209,12 +258,19 @@ batchLoop:
 	}
 
 	if nextBatch != nil {
-		// advance epoch if necessary
-		if nextBatch.Batch.EpochNum == rollup.Epoch(epoch.Number)+1 {
-			bq.l1Blocks = bq.l1Blocks[1:]
+		batch := nextBatch.Batch
+		if batch.SpanBatch != nil {
+			// the blocks already in the safe chain were checked to match it, and are skipped
+			overlap := int((nextTimestamp - batch.SpanBatch.Timestamp(bq.config, 0)) / bq.config.BlockTime)
+			batches, err := batch.SpanBatch.Batches(bq.config, bq.l1Blocks, overlap)
+			if err != nil {
+				return nil, NewCriticalError(fmt.Errorf("failed to expand accepted span batch: %w", err))
+			}
+			bq.log.Info("Found next span batch", "epoch", epoch, "batch_timestamp", nextTimestamp, "blocks", len(batches), "overlap", overlap)
+			batch, bq.nextSpan = batches[0], batches[1:]
+			batch.ParentHash = l2SafeHead.Hash
 		}
-		bq.log.Info("Found next batch", "epoch", epoch, "batch_epoch", nextBatch.Batch.EpochNum, "batch_timestamp", nextBatch.Batch.Timestamp)
-		return nextBatch.Batch, nil
+		return bq.popNextBatch(epoch, batch), nil
 	}
 
 	// If the current epoch is too old compared to the L1 block we are at,
@@ -244,7 +300,7 @@ batchLoop:
 	if nextTimestamp < nextEpoch.Time || firstOfEpoch {
 		bq.log.Info("Generating next batch", "epoch", epoch, "timestamp", nextTimestamp)
 		return &BatchData{
-			BatchV1{
+			BatchV1: BatchV1{
 				ParentHash:   l2SafeHead.Hash,
 				EpochNum:     rollup.Epoch(epoch.Number),
 				EpochHash:    epoch.Hash,
@@ -260,3 +316,14 @@ batchLoop:
 	bq.l1Blocks = bq.l1Blocks[1:]
 	return nil, io.EOF
 }
+
+// popNextBatch advances the epoch if the batch adopts the next L1 origin, and returns the batch to apply on top of
+// the current L2 safe head.
+func (bq *BatchQueue) popNextBatch(epoch eth.L1BlockRef, batch *BatchData) *BatchData {
+	// advance epoch if necessary
+	if batch.EpochNum == rollup.Epoch(epoch.Number)+1 {
+		bq.l1Blocks = bq.l1Blocks[1:]
+	}
+	bq.log.Info("Found next batch", "epoch", epoch, "batch_epoch", batch.EpochNum, "batch_timestamp", batch.Timestamp)
+	return batch
+}
@@ -48,7 +48,7 @@ func mockHash(time uint64, layer uint8) common.Hash {
 func b(timestamp uint64, epoch eth.L1BlockRef) *BatchData {
 	rng := rand.New(rand.NewSource(int64(timestamp)))
 	data := testutils.RandomData(rng, 20)
-	return &BatchData{BatchV1{
+	return &BatchData{BatchV1: BatchV1{
 		ParentHash:   mockHash(timestamp-2, 2),
 		Timestamp:    timestamp,
 		EpochNum:     rollup.Epoch(epoch.Number),
@@ -102,7 +102,7 @@ func TestBatchQueueNewOrigin(t *testing.T) {
 		origin:  l1[0],
 	}
 
-	bq := NewBatchQueue(log, cfg, input)
+	bq := NewBatchQueue(log, cfg, input, nil, NoopEvents)
 	_ = bq.Reset(context.Background(), l1[0], eth.SystemConfig{})
 	require.Equal(t, []eth.L1BlockRef{l1[0]}, bq.l1Blocks)
 
@@ -164,7 +164,7 @@ func TestBatchQueueEager(t *testing.T) {
 		origin:  l1[0],
 	}
 
-	bq := NewBatchQueue(log, cfg, input)
+	bq := NewBatchQueue(log, cfg, input, nil, NoopEvents)
 	_ = bq.Reset(context.Background(), l1[0], eth.SystemConfig{})
 	// Advance the origin
 	input.origin = l1[1]
@@ -183,6 +183,142 @@ func TestBatchQueueEager(t *testing.T) {
 	}
 }
 
+// TestBatchQueueSpanBatch asserts that the batches of a span batch are derived one at a time, and that the remaining
+// batches are dropped once the safe head does not advance as expected.
+func TestBatchQueueSpanBatch(t *testing.T) {
+	log := testlog.Logger(t, log.LvlCrit)
+	l1 := L1Chain([]uint64{10, 20, 30})
+	genesis := eth.L2BlockRef{
+		Hash:           mockHash(10, 2),
+		Number:         0,
+		ParentHash:     common.Hash{},
+		Time:           10,
+		L1Origin:       l1[0].ID(),
+		SequenceNumber: 0,
+	}
+	cfg := &rollup.Config{
+		Genesis: rollup.Genesis{
+			L2Time: 10,
+		},
+		BlockTime:          2,
+		MaxProposerDrift:   600,
+		ProposerWindowSize: 30,
+		SpanBatchTime:      new(uint64),
+	}
+
+	batches := []*BatchData{b(12, l1[0]), b(14, l1[0]), b(16, l1[0]), b(18, l1[0]), b(20, l1[0]), b(22, l1[0]), b(24, l1[1])}
+	span := spanBatch(t, cfg, batches...)
+
+	input := &fakeBatchQueueInput{
+		// the singular batches of the blocks of the span batch are superseded by it
+		batches: []*BatchData{span, b(14, l1[0]), b(16, l1[0]), nil},
+		errors:  []error{nil, nil, nil, io.EOF},
+		origin:  l1[0],
+	}
+	bq := NewBatchQueue(log, cfg, input, nil, NoopEvents)
+	_ = bq.Reset(context.Background(), l1[0], eth.SystemConfig{})
+	// Advance the origin
+	input.origin = l1[1]
+
+	safeHead := genesis
+	for i := 0; i < len(batches); i++ {
+		b, e := bq.NextBatch(context.Background(), safeHead)
+		require.NoError(t, e)
+		require.Equal(t, batches[i], b)
+
+		safeHead.Number += 1
+		safeHead.Time += 2
+		safeHead.Hash = mockHash(b.Timestamp, 2)
+		safeHead.L1Origin = b.Epoch()
+	}
+	require.Equal(t, []eth.L1BlockRef{l1[1]}, bq.l1Blocks, "epoch must have advanced")
+	require.Empty(t, bq.batches, "the batches superseded by the span batch must not be buffered")
+	_, e := bq.NextBatch(context.Background(), safeHead)
+	require.ErrorIs(t, e, io.EOF)
+
+	// the safe head does not advance, e.g. if the engine rejects the first block
+	input.i = 0
+	input.batches, input.errors = []*BatchData{span, nil}, []error{nil, io.EOF}
+	input.origin = l1[0]
+	_ = bq.Reset(context.Background(), l1[0], eth.SystemConfig{})
+	input.origin = l1[1]
+	b, e := bq.NextBatch(context.Background(), genesis)
+	require.NoError(t, e)
+	require.Equal(t, batches[0], b)
+	_, e = bq.NextBatch(context.Background(), genesis)
+	require.ErrorIs(t, e, io.EOF)
+	require.Empty(t, bq.nextSpan)
+}
+
+// TestBatchQueueSpanBatchReset tests that the remaining blocks of a span batch are derived after a reset of the
+// pipeline in the middle of the span batch, by checking the blocks overlapping the safe chain against it.
+func TestBatchQueueSpanBatchReset(t *testing.T) {
+	log := testlog.Logger(t, log.LvlCrit)
+	l1 := L1Chain([]uint64{10, 20, 30})
+	cfg := &rollup.Config{
+		Genesis: rollup.Genesis{
+			L1:     l1[0].ID(),
+			L2:     eth.BlockID{Hash: mockHash(10, 2)},
+			L2Time: 10,
+		},
+		BlockTime:          2,
+		MaxProposerDrift:   600,
+		ProposerWindowSize: 30,
+		SpanBatchTime:      new(uint64),
+	}
+
+	batches := []*BatchData{b(12, l1[0]), b(14, l1[0]), b(16, l1[0]), b(18, l1[0]), b(20, l1[1]), b(22, l1[1])}
+	span := spanBatch(t, cfg, batches...)
+	input := &fakeBatchQueueInput{
+		batches: []*BatchData{span, nil},
+		errors:  []error{nil, io.EOF},
+		origin:  l1[0],
+	}
+	safeChain := newFakeSafeChain(t, cfg)
+	bq := NewBatchQueue(log, cfg, input, safeChain, NoopEvents)
+	_ = bq.Reset(context.Background(), l1[0], eth.SystemConfig{})
+	input.origin = l1[1]
+
+	safeHead := eth.L2BlockRef{Hash: cfg.Genesis.L2.Hash, Time: cfg.Genesis.L2Time, L1Origin: l1[0].ID()}
+	next := func(expected *BatchData) {
+		b, e := bq.NextBatch(context.Background(), safeHead)
+		require.NoError(t, e)
+		require.Equal(t, expected, b)
+		safeHead.Number += 1
+		safeHead.Time += 2
+		safeHead.Hash = mockHash(b.Timestamp, 2)
+		safeHead.L1Origin = b.Epoch()
+		*safeChain = *newFakeSafeChain(t, cfg, batches[:safeHead.Number]...)
+	}
+	for i := 0; i < 3; i++ {
+		next(batches[i])
+	}
+
+	// the pipeline is reset in the middle of the span batch, and reads it again
+	input.i = 0
+	input.origin = l1[0]
+	_ = bq.Reset(context.Background(), l1[0], eth.SystemConfig{})
+	input.origin = l1[1]
+	for i := 3; i < len(batches); i++ {
+		next(batches[i])
+	}
+	_, e := bq.NextBatch(context.Background(), safeHead)
+	require.ErrorIs(t, e, io.EOF)
+
+	// the span batch is dropped if it does not match the safe chain it overlaps
+	input.i = 0
+	input.origin = l1[0]
+	_ = bq.Reset(context.Background(), l1[0], eth.SystemConfig{})
+	input.origin = l1[1]
+	safeHead = eth.L2BlockRef{Hash: mockHash(16, 2), Number: 3, Time: 16, L1Origin: l1[0].ID()}
+	other := b(14, l1[0])
+	other.Transactions = []hexutil.Bytes{{0x01}}
+	*safeChain = *newFakeSafeChain(t, cfg, batches[0], other, batches[2])
+	_, e = bq.NextBatch(context.Background(), safeHead)
+	require.ErrorIs(t, e, NotEnoughData)
+	require.Empty(t, bq.batches, "span batch must be dropped")
+}
+
 // TestBatchQueueInvalidInternalAdvance asserts that we do not miss an epoch when generating batches.
 // This is a regression test for CLI-3378.
 func TestBatchQueueInvalidInternalAdvance(t *testing.T) {
@@ -214,7 +350,7 @@ func TestBatchQueueInvalidInternalAdvance(t *testing.T) {
 		origin:  l1[0],
 	}
 
-	bq := NewBatchQueue(log, cfg, input)
+	bq := NewBatchQueue(log, cfg, input, nil, NoopEvents)
 	_ = bq.Reset(context.Background(), l1[0], eth.SystemConfig{})
 
 	// Load continuous batches for epoch 0
@@ -307,7 +443,7 @@ func TestBatchQueueMissing(t *testing.T) {
 		origin:  l1[0],
 	}
 
-	bq := NewBatchQueue(log, cfg, input)
+	bq := NewBatchQueue(log, cfg, input, nil, NoopEvents)
 	_ = bq.Reset(context.Background(), l1[0], eth.SystemConfig{})
 
 	for i := 0; i < len(batches); i++ {
@@ -1,6 +1,9 @@
 package derive
 
 import (
+	"bytes"
+	"context"
+
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
 
@@ -26,10 +29,20 @@ const (
 	BatchFuture
 )
 
+// SafeBlockFetcher fetches the blocks of the safe L2 chain, to check the span batches overlapping it.
+type SafeBlockFetcher interface {
+	PayloadByNumber(context.Context, uint64) (*eth.ExecutionPayload, error)
+}
+
 // CheckBatch checks if the given batch can be applied on top of the given l2SafeHead, given the contextual L1 blocks the batch was included in.
 // The first entry of the l1Blocks should match the origin of the l2SafeHead. One or more consecutive l1Blocks should be provided.
 // In case of only a single L1 block, the decision whether a batch is valid may have to stay undecided.
-func CheckBatch(cfg *rollup.Config, log log.Logger, l1Blocks []eth.L1BlockRef, l2SafeHead eth.L2BlockRef, batch *BatchWithL1InclusionBlock) BatchValidity {
+// The l2Fetcher fetches the blocks of the safe chain that a span batch overlaps, and is not used for singular batches.
+func CheckBatch(ctx context.Context, cfg *rollup.Config, log log.Logger, l1Blocks []eth.L1BlockRef, l2SafeHead eth.L2BlockRef, batch *BatchWithL1InclusionBlock, l2Fetcher SafeBlockFetcher) BatchValidity {
+	if batch.Batch.SpanBatch != nil {
+		return CheckSpanBatch(ctx, cfg, log, l1Blocks, l2SafeHead, batch, l2Fetcher)
+	}
+
 	// add details to the log
 	log = log.New(
 		"batch_timestamp", batch.Batch.Timestamp,
@@ -142,3 +155,204 @@ func CheckBatch(cfg *rollup.Config, log log.Logger, l1Blocks []eth.L1BlockRef, l
 
 	return BatchAccept
 }
+
+// CheckSpanBatch checks if the given span batch can be applied on top of the given l2SafeHead, given the contextual L1
+// blocks the batch was included in, following the rules of CheckBatch for every block of the span batch after the
+// l2SafeHead. The blocks of a span batch overlapping the safe chain, e.g. after a reset in the middle of the span
+// batch, are checked block by block against the safe chain fetched with the l2Fetcher instead.
+func CheckSpanBatch(ctx context.Context, cfg *rollup.Config, log log.Logger, l1Blocks []eth.L1BlockRef, l2SafeHead eth.L2BlockRef, batch *BatchWithL1InclusionBlock, l2Fetcher SafeBlockFetcher) BatchValidity {
+	span := batch.Batch.SpanBatch
+	startTimestamp := span.Timestamp(cfg, 0)
+	startEpochNum := span.EpochNum(0)
+	// add details to the log
+	log = log.New(
+		"batch_type", "span",
+		"batch_timestamp", startTimestamp,
+		"batch_epoch", startEpochNum,
+		"batch_end_epoch", span.L1OriginNum,
+		"blocks", len(span.Blocks),
+	)
+
+	// sanity check we have consistent inputs
+	if len(l1Blocks) == 0 {
+		log.Warn("missing L1 block input, cannot proceed with batch checking")
+		return BatchUndecided
+	}
+	epoch := l1Blocks[0]
+
+	if !cfg.IsSpanBatch(startTimestamp) {
+		log.Warn("dropping span batch before span batch activation", "span_batch_time", cfg.SpanBatchTime)
+		return BatchDrop
+	}
+
+	nextTimestamp := l2SafeHead.Time + cfg.BlockTime
+	if startTimestamp > nextTimestamp {
+		log.Trace("received out-of-order batch for future processing after next batch", "next_timestamp", nextTimestamp)
+		return BatchFuture
+	}
+	if endTimestamp := span.Timestamp(cfg, len(span.Blocks)-1); endTimestamp < nextTimestamp {
+		log.Warn("dropping span batch with old timestamp, it has no block after the safe head", "min_timestamp", nextTimestamp)
+		return BatchDrop
+	}
+	if (nextTimestamp-startTimestamp)%cfg.BlockTime != 0 {
+		log.Warn("dropping span batch with timestamps misaligned with the safe chain", "next_timestamp", nextTimestamp)
+		return BatchDrop
+	}
+	// overlap is the number of blocks of the span batch before the safe head, which must match the safe chain
+	overlap := int((nextTimestamp - startTimestamp) / cfg.BlockTime)
+
+	// dependent on above timestamp check. If the timestamp is correct, then it must build on top of the safe head,
+	// or on the parent of the first overlapped block of the safe chain.
+	parent := l2SafeHead
+	if overlap > 0 {
+		if uint64(overlap) > l2SafeHead.Number-cfg.Genesis.L2.Number {
+			log.Warn("dropping span batch overlapping the safe chain before the L2 genesis", "overlap", overlap)
+			return BatchDrop
+		}
+		if l2Fetcher == nil {
+			log.Warn("dropping span batch overlapping the safe chain, the safe chain cannot be fetched", "overlap", overlap)
+			return BatchDrop
+		}
+		payload, err := l2Fetcher.PayloadByNumber(ctx, l2SafeHead.Number-uint64(overlap))
+		if err != nil {
+			log.Warn("failed to fetch the parent of the span batch in the safe chain", "overlap", overlap, "err", err)
+			return BatchUndecided
+		}
+		if parent, err = PayloadToBlockRef(payload, &cfg.Genesis); err != nil {
+			log.Warn("failed to read the parent of the span batch in the safe chain", "overlap", overlap, "err", err)
+			return BatchUndecided
+		}
+	}
+	if !bytes.Equal(span.ParentCheck[:], parent.Hash[:spanBatchChecksLen]) {
+		log.Warn("ignoring batch with mismatching parent check", "parent", parent.ID(), "current_safe_head", l2SafeHead.Hash)
+		return BatchDrop
+	}
+
+	// Filter out batches that were included too late.
+	if startEpochNum+cfg.ProposerWindowSize < batch.L1InclusionBlock.Number {
+		log.Warn("batch was included too late, proposer window expired")
+		return BatchDrop
+	}
+
+	// Check the L1 origin of the first block after the safe head
+	if firstEpochNum := span.EpochNum(overlap); firstEpochNum < epoch.Number {
+		log.Warn("dropped batch, epoch is too old", "minimum", epoch.ID(), "first_new_epoch", firstEpochNum)
+		return BatchDrop
+	} else if firstEpochNum > epoch.Number+1 {
+		log.Warn("batch is for future epoch too far ahead, while it has the next timestamp, so it must be invalid",
+			"current_epoch", epoch.ID(), "first_new_epoch", firstEpochNum)
+		return BatchDrop
+	}
+
+	// The origins of the blocks are consecutive, so checking the hash of the last one checks them all
+	if span.L1OriginNum-epoch.Number >= uint64(len(l1Blocks)) {
+		log.Info("span batch wants to advance epoch, but could not without more L1 blocks", "current_epoch", epoch.ID())
+		return BatchUndecided
+	}
+	if endOrigin := l1Blocks[span.L1OriginNum-epoch.Number]; !bytes.Equal(span.L1OriginCheck[:], endOrigin.Hash[:spanBatchChecksLen]) {
+		log.Warn("batch is for different L1 chain, epoch hash does not match", "expected", endOrigin.ID())
+		return BatchDrop
+	}
+
+	originNum := startEpochNum
+	for i, block := range span.Blocks {
+		if i > 0 && block.OriginAdvanced {
+			originNum++
+		}
+		if i < overlap {
+			// checked against the safe chain instead
+			continue
+		}
+		timestamp := span.Timestamp(cfg, i)
+		originIndex := originNum - epoch.Number
+		batchOrigin := l1Blocks[originIndex]
+		log := log.New("block_index", i, "block_timestamp", timestamp, "block_epoch", batchOrigin.ID())
+
+		if timestamp < batchOrigin.Time {
+			log.Warn("batch timestamp is less than L1 origin timestamp", "l2_timestamp", timestamp, "l1_timestamp", batchOrigin.Time, "origin", batchOrigin.ID())
+			return BatchDrop
+		}
+
+		// Check if we ran out of proposer time drift
+		if max := batchOrigin.Time + cfg.MaxProposerDrift; timestamp > max {
+			if len(block.Transactions) == 0 {
+				// As for singular batches, only the empty blocks that do not advance the epoch are allowed past the
+				// time drift, if the next L1 origin could not have been adopted yet.
+				if (i == 0 && originIndex == 0) || (i > 0 && !block.OriginAdvanced) {
+					if originIndex+1 >= uint64(len(l1Blocks)) {
+						log.Info("without the next L1 origin we cannot determine yet if this empty batch that exceeds the time drift is still valid")
+						return BatchUndecided
+					}
+					if nextOrigin := l1Blocks[originIndex+1]; timestamp >= nextOrigin.Time {
+						log.Info("batch exceeded proposer time drift without adopting next origin, and next L1 origin would have been valid")
+						return BatchDrop
+					} else {
+						log.Info("continuing with empty batch before late L1 block to preserve L2 time invariant")
+					}
+				}
+			} else {
+				log.Warn("batch exceeded proposer time drift, proposer must adopt new L1 origin to include transactions again", "max_time", max)
+				return BatchDrop
+			}
+		}
+
+		for j, txBytes := range block.Transactions {
+			if len(txBytes) == 0 {
+				log.Warn("transaction data must not be empty, but found empty tx", "tx_index", j)
+				return BatchDrop
+			}
+			if txBytes[0] == types.DepositTxType {
+				log.Warn("proposers may not embed any deposits into batch data, but found tx that has one", "tx_index", j)
+				return BatchDrop
+			}
+		}
+	}
+
+	return checkSpanBatchOverlap(ctx, cfg, log, span, parent, overlap, l2Fetcher)
+}
+
+// checkSpanBatchOverlap checks that the first overlap blocks of the span batch, after the parent, match the blocks of
+// the safe chain: the same L1 origins and the same transactions, besides the deposits.
+func checkSpanBatchOverlap(ctx context.Context, cfg *rollup.Config, log log.Logger, span *SpanBatch, parent eth.L2BlockRef, overlap int, l2Fetcher SafeBlockFetcher) BatchValidity {
+	originNum := span.EpochNum(0)
+	for i := 0; i < overlap; i++ {
+		block := span.Blocks[i]
+		if i > 0 && block.OriginAdvanced {
+			originNum++
+		}
+		number := parent.Number + 1 + uint64(i)
+		log := log.New("block_index", i, "block_number", number)
+		payload, err := l2Fetcher.PayloadByNumber(ctx, number)
+		if err != nil {
+			log.Warn("failed to fetch the safe block overlapped by the span batch", "err", err)
+			return BatchUndecided
+		}
+		ref, err := PayloadToBlockRef(payload, &cfg.Genesis)
+		if err != nil {
+			log.Warn("failed to read the safe block overlapped by the span batch", "err", err)
+			return BatchUndecided
+		}
+		if ref.L1Origin.Number != originNum {
+			log.Warn("overlapped block has a different L1 origin than the safe block", "l1_origin", ref.L1Origin, "batch_epoch", originNum)
+			return BatchDrop
+		}
+		var txs []eth.Data
+		for _, tx := range payload.Transactions {
+			if len(tx) > 0 && tx[0] != types.DepositTxType {
+				txs = append(txs, tx)
+			}
+		}
+		if len(txs) != len(block.Transactions) {
+			log.Warn("overlapped block has a different number of transactions than the safe block",
+				"txs", len(block.Transactions), "safe_txs", len(txs))
+			return BatchDrop
+		}
+		for j, tx := range txs {
+			if !bytes.Equal(tx, block.Transactions[j]) {
+				log.Warn("overlapped block has a different transaction than the safe block", "tx_index", j)
+				return BatchDrop
+			}
+		}
+	}
+	return BatchAccept
+}
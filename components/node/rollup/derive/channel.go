@@ -2,7 +2,6 @@ package derive
 
 import (
 	"bytes"
-	"compress/zlib"
 	"fmt"
 	"io"
 
@@ -144,10 +143,11 @@ func (ch *Channel) Reader() io.Reader {
 }
 
 // BatchReader provides a function that iteratively consumes batches from the reader.
-// The L1Inclusion block is also provided at creation time.
-func BatchReader(r io.Reader, l1InclusionBlock eth.L1BlockRef) (func() (BatchWithL1InclusionBlock, error), error) {
+// The L1Inclusion block is also provided at creation time. channelCompression is whether the channels compressed
+// with brotli or zstd are accepted, see rollup.Config.IsChannelCompression.
+func BatchReader(r io.Reader, l1InclusionBlock eth.L1BlockRef, channelCompression bool) (func() (BatchWithL1InclusionBlock, error), error) {
 	// Setup decompressor stage + RLP reader
-	zr, err := zlib.NewReader(r)
+	zr, err := newDecompressor(r, channelCompression)
 	if err != nil {
 		return nil, err
 	}
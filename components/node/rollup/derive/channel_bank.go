@@ -3,6 +3,7 @@ package derive
 import (
 	"context"
 	"io"
+	"sort"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
@@ -37,12 +38,14 @@ type ChannelBank struct {
 
 	prev    NextFrameProvider
 	fetcher L1Fetcher
+
+	events Events
 }
 
 var _ ResetableStage = (*ChannelBank)(nil)
 
 // NewChannelBank creates a ChannelBank, which should be Reset(origin) before use.
-func NewChannelBank(log log.Logger, cfg *rollup.Config, prev NextFrameProvider, fetcher L1Fetcher) *ChannelBank {
+func NewChannelBank(log log.Logger, cfg *rollup.Config, prev NextFrameProvider, fetcher L1Fetcher, events Events) *ChannelBank {
 	return &ChannelBank{
 		log:          log,
 		cfg:          cfg,
@@ -50,6 +53,7 @@ func NewChannelBank(log log.Logger, cfg *rollup.Config, prev NextFrameProvider,
 		channelQueue: make([]ChannelID, 0, 10),
 		prev:         prev,
 		fetcher:      fetcher,
+		events:       events,
 	}
 }
 
@@ -125,6 +129,7 @@ func (cb *ChannelBank) Read() (data []byte, err error) {
 		return nil, io.EOF
 	}
 	cb.log.Info("Reading channel", "channel", first, "frames", len(ch.inputs))
+	cb.events.OnChannelClosed(first, len(ch.inputs), cb.Origin())
 
 	delete(cb.channels, first)
 	cb.channelQueue = cb.channelQueue[1:]
@@ -167,6 +172,53 @@ func (cb *ChannelBank) Reset(ctx context.Context, base eth.L1BlockRef, _ eth.Sys
 	return io.EOF
 }
 
+// ChannelState is a snapshot of a channel buffered in the channel bank, for debugging.
+type ChannelState struct {
+	ID        ChannelID   `json:"id"`
+	OpenBlock eth.BlockID `json:"openBlock"`
+	// Age is the number of L1 blocks since the channel was opened, at the origin of the channel bank.
+	Age      uint64 `json:"age"`
+	TimedOut bool   `json:"timedOut"`
+	// Size is the estimated memory size of the channel, including the frame overhead.
+	Size uint64 `json:"size"`
+	// Frames are the numbers of the buffered frames, in ascending order.
+	Frames []uint16 `json:"frames"`
+	// Closed is true if the last frame is buffered, with EndFrameNumber as frame number.
+	Closed         bool   `json:"closed"`
+	EndFrameNumber uint16 `json:"endFrameNumber"`
+	Ready          bool   `json:"ready"`
+}
+
+// State returns a snapshot of the buffered channels, in the order they are read.
+func (cb *ChannelBank) State() []ChannelState {
+	origin := cb.Origin().Number
+	channels := make([]ChannelState, 0, len(cb.channelQueue))
+	for _, id := range cb.channelQueue {
+		ch := cb.channels[id]
+		var age uint64
+		if origin > ch.OpenBlockNumber() {
+			age = origin - ch.OpenBlockNumber()
+		}
+		frames := make([]uint16, 0, len(ch.inputs))
+		for num := range ch.inputs {
+			frames = append(frames, uint16(num))
+		}
+		sort.Slice(frames, func(i, j int) bool { return frames[i] < frames[j] })
+		channels = append(channels, ChannelState{
+			ID:             id,
+			OpenBlock:      ch.openBlock.ID(),
+			Age:            age,
+			TimedOut:       age > cb.cfg.ChannelTimeout,
+			Size:           ch.size,
+			Frames:         frames,
+			Closed:         ch.closed,
+			EndFrameNumber: ch.endFrameNumber,
+			Ready:          ch.IsReady(),
+		})
+	}
+	return channels
+}
+
 type L1BlockRefByHashFetcher interface {
 	L1BlockRefByHash(context.Context, common.Hash) (eth.L1BlockRef, error)
 }
@@ -102,7 +102,7 @@ func TestChannelBankSimple(t *testing.T) {
 
 	cfg := &rollup.Config{ChannelTimeout: 10}
 
-	cb := NewChannelBank(testlog.Logger(t, log.LvlCrit), cfg, input, nil)
+	cb := NewChannelBank(testlog.Logger(t, log.LvlCrit), cfg, input, nil, NoopEvents)
 
 	// Load the first frame
 	out, err := cb.NextData(context.Background())
@@ -142,7 +142,7 @@ func TestChannelBankDuplicates(t *testing.T) {
 
 	cfg := &rollup.Config{ChannelTimeout: 10}
 
-	cb := NewChannelBank(testlog.Logger(t, log.LvlCrit), cfg, input, nil)
+	cb := NewChannelBank(testlog.Logger(t, log.LvlCrit), cfg, input, nil, NoopEvents)
 
 	// Load the first frame
 	out, err := cb.NextData(context.Background())
@@ -177,3 +177,42 @@ func TestChannelBankDuplicates(t *testing.T) {
 	require.Nil(t, out)
 	require.Equal(t, io.EOF, err)
 }
+
+func TestChannelBankState(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	a := testutils.RandomBlockRef(rng)
+
+	input := &fakeChannelBankInput{origin: a}
+	input.AddFrames("a:0:first", "a:2:third!")
+	input.AddFrames("b:1:second")
+	input.AddFrame(Frame{}, io.EOF)
+
+	cfg := &rollup.Config{ChannelTimeout: 10}
+
+	cb := NewChannelBank(testlog.Logger(t, log.LvlCrit), cfg, input, nil, NoopEvents)
+	require.Empty(t, cb.State())
+
+	for i := 0; i < 3; i++ {
+		_, err := cb.NextData(context.Background())
+		require.ErrorIs(t, err, NotEnoughData)
+	}
+
+	// age the channels beyond the timeout
+	input.origin.Number += cfg.ChannelTimeout + 1
+
+	state := cb.State()
+	require.Len(t, state, 2)
+	require.Equal(t, testFrame("a:0:").ChannelID(), state[0].ID)
+	require.Equal(t, a.ID(), state[0].OpenBlock)
+	require.Equal(t, cfg.ChannelTimeout+1, state[0].Age)
+	require.True(t, state[0].TimedOut)
+	require.Equal(t, []uint16{0, 2}, state[0].Frames)
+	require.True(t, state[0].Closed)
+	require.Equal(t, uint16(2), state[0].EndFrameNumber)
+	require.False(t, state[0].Ready, "frame 1 is missing")
+	require.Equal(t, frameSize(testFrame("a:0:first").ToFrame())+frameSize(testFrame("a:2:third!").ToFrame()), state[0].Size)
+
+	require.Equal(t, testFrame("b:1:").ChannelID(), state[1].ID)
+	require.Equal(t, []uint16{1}, state[1].Frames)
+	require.False(t, state[1].Closed)
+}
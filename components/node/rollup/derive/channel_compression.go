@@ -0,0 +1,168 @@
+package derive
+
+import (
+	"bufio"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/andybalholm/brotli"
+	"github.com/klauspost/compress/zstd"
+)
+
+// CompressionAlgo is the algorithm the data of a channel is compressed with.
+type CompressionAlgo string
+
+const (
+	// Zlib compresses the channel data as specified in RFC-1950, with no prefix.
+	Zlib CompressionAlgo = "zlib"
+	// Brotli compresses the channel data as specified in RFC-7932, prefixed with ChannelVersionBrotli.
+	Brotli CompressionAlgo = "brotli"
+	// Zstd compresses the channel data as specified in RFC-8878, prefixed with ChannelVersionZstd.
+	Zstd CompressionAlgo = "zstd"
+)
+
+// CompressionAlgos are the supported compression algorithms.
+var CompressionAlgos = []CompressionAlgo{Zlib, Brotli, Zstd}
+
+// The channel versions prefix the compressed data of the channels not compressed with zlib. They cannot be
+// confused with the first byte of a zlib stream, whose low nibble is the compression method 8 (deflate).
+const (
+	ChannelVersionBrotli byte = 0x01
+	ChannelVersionZstd   byte = 0x02
+)
+
+// zstdMaxWindowSize bounds the memory of the zstd decompression of a channel, whose batches cannot exceed
+// MaxRLPBytesPerChannel anyway.
+const zstdMaxWindowSize = 1 << 23
+
+var (
+	ErrUnknownCompressionAlgo = errors.New("unknown compression algorithm")
+	// ErrCompressionNotActive is returned for the channels compressed with brotli or zstd before the channel
+	// compression activation of the rollup config.
+	ErrCompressionNotActive = errors.New("compression algorithm is not active")
+)
+
+// CompressionConfig is the algorithm and the level the channels are compressed with.
+type CompressionConfig struct {
+	// Algo is the compression algorithm, zlib if empty.
+	Algo CompressionAlgo
+	// Level is the compression level, in the range of the algorithm: 1-9 for zlib, 0-11 for brotli and 1-22 for
+	// zstd. If 0, the default level of the algorithm is used.
+	Level int
+}
+
+// Check validates the algorithm and the level.
+func (c CompressionConfig) Check() error {
+	lo, hi, ok := compressionLevels(c.algo())
+	if !ok {
+		return fmt.Errorf("%w: %q", ErrUnknownCompressionAlgo, c.Algo)
+	}
+	if c.Level != 0 && (c.Level < lo || c.Level > hi) {
+		return fmt.Errorf("%s compression level %d is out of range [%d, %d]", c.algo(), c.Level, lo, hi)
+	}
+	return nil
+}
+
+// compressionLevels returns the range of levels of the algorithm, and whether the algorithm is known.
+func compressionLevels(algo CompressionAlgo) (int, int, bool) {
+	switch algo {
+	case Zlib:
+		return zlib.BestSpeed, zlib.BestCompression, true
+	case Brotli:
+		return brotli.BestSpeed, brotli.BestCompression, true
+	case Zstd:
+		return 1, 22, true
+	default:
+		return 0, 0, false
+	}
+}
+
+func (c CompressionConfig) algo() CompressionAlgo {
+	if c.Algo == "" {
+		return Zlib
+	}
+	return c.Algo
+}
+
+// level returns the compression level, or the default level of the algorithm if not set.
+func (c CompressionConfig) level() int {
+	if c.Level != 0 {
+		return c.Level
+	}
+	switch c.algo() {
+	case Brotli:
+		return 10
+	case Zstd:
+		return 19
+	default:
+		return zlib.BestCompression
+	}
+}
+
+// compressor is the streaming compression stage of a channel.
+type compressor interface {
+	io.WriteCloser
+	Flush() error
+	Reset(w io.Writer)
+}
+
+// newCompressor creates the compressor of the configuration, writing the compressed data to w.
+func newCompressor(cfg CompressionConfig, w io.Writer) (compressor, error) {
+	if err := cfg.Check(); err != nil {
+		return nil, err
+	}
+	switch cfg.algo() {
+	case Brotli:
+		return brotli.NewWriterLevel(w, cfg.level()), nil
+	case Zstd:
+		return zstd.NewWriter(w,
+			zstd.WithEncoderLevel(zstd.EncoderLevelFromZstd(cfg.level())),
+			zstd.WithEncoderConcurrency(1),
+		)
+	default:
+		return zlib.NewWriterLevel(w, cfg.level())
+	}
+}
+
+// channelVersion returns the prefix of the compressed data of the algorithm, if any.
+func channelVersion(algo CompressionAlgo) []byte {
+	switch algo {
+	case Brotli:
+		return []byte{ChannelVersionBrotli}
+	case Zstd:
+		return []byte{ChannelVersionZstd}
+	default:
+		return nil
+	}
+}
+
+// newDecompressor detects the compression algorithm of the channel data from its first byte, and returns the
+// reader of the decompressed data. The channels not compressed with zlib are rejected unless channelCompression,
+// i.e. the channel compression activation of the rollup config, is active.
+func newDecompressor(r io.Reader, channelCompression bool) (io.Reader, error) {
+	br := bufio.NewReader(r)
+	prefix, err := br.Peek(1)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case prefix[0]&0x0F == 8:
+		return zlib.NewReader(br)
+	case !channelCompression && (prefix[0] == ChannelVersionBrotli || prefix[0] == ChannelVersionZstd):
+		return nil, fmt.Errorf("%w: channel version %#x", ErrCompressionNotActive, prefix[0])
+	case prefix[0] == ChannelVersionBrotli:
+		if _, err := br.Discard(1); err != nil {
+			return nil, err
+		}
+		return brotli.NewReader(br), nil
+	case prefix[0] == ChannelVersionZstd:
+		if _, err := br.Discard(1); err != nil {
+			return nil, err
+		}
+		return zstd.NewReader(br, zstd.WithDecoderConcurrency(1), zstd.WithDecoderMaxWindow(zstdMaxWindowSize))
+	default:
+		return nil, fmt.Errorf("%w: channel version %#x", ErrUnknownCompressionAlgo, prefix[0])
+	}
+}
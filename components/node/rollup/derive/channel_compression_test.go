@@ -0,0 +1,110 @@
+package derive
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kroma-network/kroma/components/node/eth"
+	"github.com/kroma-network/kroma/components/node/rollup"
+	"github.com/kroma-network/kroma/components/node/testlog"
+	"github.com/kroma-network/kroma/components/node/testutils"
+)
+
+func TestChannelCompressionRoundTrip(t *testing.T) {
+	batch := &BatchData{
+		BatchV1: BatchV1{
+			ParentHash:   common.Hash{31: 0x42},
+			EpochNum:     1,
+			EpochHash:    common.Hash{29: 0x37},
+			Timestamp:    123,
+			Transactions: []hexutil.Bytes{bytes.Repeat([]byte{0xaa}, 1000), {0x01, 0x02}},
+		},
+	}
+
+	for _, cfg := range []CompressionConfig{
+		{Algo: Zlib},
+		{Algo: Zlib, Level: 1},
+		{Algo: Brotli},
+		{Algo: Brotli, Level: 11},
+		{Algo: Zstd},
+		{Algo: Zstd, Level: 3},
+	} {
+		cfg := cfg
+		t.Run(string(cfg.Algo), func(t *testing.T) {
+			co, err := NewChannelOutWithCompression(cfg)
+			require.NoError(t, err)
+
+			// the channel version is written again once the channel out is reset
+			for i := 0; i < 2; i++ {
+				_, err = co.AddBatch(batch)
+				require.NoError(t, err)
+				require.NoError(t, co.Close())
+				if version := channelVersion(cfg.Algo); version != nil {
+					require.Equal(t, version, co.buf.Bytes()[:1])
+				}
+				require.Less(t, co.ReadyBytes(), co.InputBytes())
+
+				next, err := BatchReader(bytes.NewReader(co.buf.Bytes()), eth.L1BlockRef{}, true)
+				require.NoError(t, err)
+				decoded, err := next()
+				require.NoError(t, err)
+				require.Equal(t, batch.BatchV1, decoded.Batch.BatchV1)
+
+				// only zlib is accepted before the channel compression activation
+				_, err = BatchReader(bytes.NewReader(co.buf.Bytes()), eth.L1BlockRef{}, false)
+				if cfg.Algo == Zlib {
+					require.NoError(t, err)
+				} else {
+					require.ErrorIs(t, err, ErrCompressionNotActive)
+				}
+
+				require.NoError(t, co.Reset())
+			}
+		})
+	}
+}
+
+func TestBatchReaderUnknownChannelVersion(t *testing.T) {
+	_, err := BatchReader(bytes.NewReader([]byte{0x03, 0x00}), eth.L1BlockRef{}, true)
+	require.ErrorIs(t, err, ErrUnknownCompressionAlgo)
+}
+
+func TestChannelInReaderCompressionActivation(t *testing.T) {
+	activation := uint64(100)
+	cfg := &rollup.Config{ChannelCompressionTime: &activation}
+	for _, algo := range CompressionAlgos {
+		co, err := NewChannelOutWithCompression(CompressionConfig{Algo: algo})
+		require.NoError(t, err)
+		_, err = co.AddBatch(&BatchData{BatchV1: BatchV1{Timestamp: 123}})
+		require.NoError(t, err)
+		require.NoError(t, co.Close())
+
+		for _, l1Time := range []uint64{activation - 1, activation} {
+			input := &fakeChannelBankInput{origin: eth.L1BlockRef{Number: 1, Time: l1Time}}
+			bank := NewChannelBank(testlog.Logger(t, log.LvlCrit), cfg, input, nil, NoopEvents)
+			cr := NewChannelInReader(testlog.Logger(t, log.LvlCrit), cfg, bank, &testutils.TestDerivationMetrics{})
+			err := cr.WriteChannel(co.buf.Bytes())
+			if algo == Zlib || l1Time >= activation {
+				require.NoError(t, err, "%s channel read at L1 time %d", algo, l1Time)
+			} else {
+				require.ErrorIs(t, err, ErrCompressionNotActive, "%s channel read at L1 time %d", algo, l1Time)
+			}
+		}
+	}
+}
+
+func TestCompressionConfigCheck(t *testing.T) {
+	require.NoError(t, CompressionConfig{}.Check())
+	require.NoError(t, CompressionConfig{Algo: Zlib}.Check())
+	require.NoError(t, CompressionConfig{Algo: Brotli, Level: 11}.Check())
+	require.NoError(t, CompressionConfig{Algo: Zstd, Level: 22}.Check())
+	require.ErrorIs(t, CompressionConfig{Algo: "lz4"}.Check(), ErrUnknownCompressionAlgo)
+	require.Error(t, CompressionConfig{Algo: Zlib, Level: 10}.Check())
+	require.Error(t, CompressionConfig{Algo: Brotli, Level: 12}.Check())
+	require.Error(t, CompressionConfig{Algo: Zstd, Level: -1}.Check())
+}
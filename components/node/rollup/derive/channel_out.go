@@ -2,7 +2,6 @@ package derive
 
 import (
 	"bytes"
-	"compress/zlib"
 	"crypto/rand"
 	"errors"
 	"fmt"
@@ -36,9 +35,14 @@ type ChannelOut struct {
 	rlpLength int
 
 	// Compressor stage. Write input data to it
-	compress *zlib.Writer
+	compress compressor
+	// version prefixes the compressed data, if the compression algorithm has a channel version
+	version []byte
 	// post compression buffer
 	buf bytes.Buffer
+	// span builds the span batch of the channel, nil if the channel holds singular batches. The span batch is
+	// written to the compressor once the channel is closed.
+	span *spanBatchBuilder
 
 	closed bool
 }
@@ -47,7 +51,13 @@ func (co *ChannelOut) ID() ChannelID {
 	return co.id
 }
 
+// NewChannelOut creates a channel out compressing with zlib at the best compression level.
 func NewChannelOut() (*ChannelOut, error) {
+	return NewChannelOutWithCompression(CompressionConfig{})
+}
+
+// NewChannelOutWithCompression creates a channel out compressing with the algorithm and level of the config.
+func NewChannelOutWithCompression(cfg CompressionConfig) (*ChannelOut, error) {
 	c := &ChannelOut{
 		id:        ChannelID{}, // TODO: use GUID here instead of fully random data
 		frame:     0,
@@ -58,21 +68,48 @@ func NewChannelOut() (*ChannelOut, error) {
 		return nil, err
 	}
 
-	compress, err := zlib.NewWriterLevel(&c.buf, zlib.BestCompression)
+	compress, err := newCompressor(cfg, &c.buf)
 	if err != nil {
 		return nil, err
 	}
 	c.compress = compress
+	c.version = channelVersion(cfg.Algo)
+	c.buf.Write(c.version)
+
+	return c, nil
+}
 
+// NewSpanChannelOut creates a channel out batching all the blocks of the channel in a single span batch, compressing
+// with the algorithm and level of the config.
+func NewSpanChannelOut(cfg CompressionConfig, rollupCfg *rollup.Config) (*ChannelOut, error) {
+	c, err := NewChannelOutWithCompression(cfg)
+	if err != nil {
+		return nil, err
+	}
+	c.span = newSpanBatchBuilder(rollupCfg)
 	return c, nil
 }
 
+// NewClosedChannelOut creates a closed channel out of the channel whose first numFrames frames were output already,
+// e.g. by a previous batcher process. It outputs no more frames and cannot be reset.
+func NewClosedChannelOut(id ChannelID, numFrames uint64) *ChannelOut {
+	return &ChannelOut{
+		id:     id,
+		frame:  numFrames,
+		closed: true,
+	}
+}
+
 // TODO: reuse ChannelOut for performance
 func (co *ChannelOut) Reset() error {
 	co.frame = 0
 	co.rlpLength = 0
 	co.buf.Reset()
+	co.buf.Write(co.version)
 	co.compress.Reset(&co.buf)
+	if co.span != nil {
+		co.span.reset()
+	}
 	co.closed = false
 	_, err := rand.Read(co.id[:])
 	return err
@@ -106,6 +143,9 @@ func (co *ChannelOut) AddBatch(batch *BatchData) (uint64, error) {
 	if co.closed {
 		return 0, errors.New("already closed")
 	}
+	if co.span != nil {
+		return co.addToSpan(batch)
+	}
 
 	// We encode to a temporary buffer to determine the encoded length to
 	// ensure that the total size of all RLP elements is less than or equal to MAX_RLP_BYTES_PER_CHANNEL
@@ -123,6 +163,23 @@ func (co *ChannelOut) AddBatch(batch *BatchData) (uint64, error) {
 	return uint64(written), err
 }
 
+// addToSpan appends a batch to the span batch of the channel. It returns the growth of the RLP encoded size of the
+// span batch, and the same errors as AddBatch.
+func (co *ChannelOut) addToSpan(batch *BatchData) (uint64, error) {
+	if err := co.span.check(batch); err != nil {
+		return 0, err
+	}
+	size := co.span.rlpSizeWith(batch)
+	if size > MaxRLPBytesPerChannel {
+		return 0, fmt.Errorf("could not add batch to span batch of %d bytes, it would be %d bytes, max is %d. err: %w",
+			co.rlpLength, size, MaxRLPBytesPerChannel, ErrTooManyRLPBytes)
+	}
+	co.span.append(batch)
+	added := size - co.rlpLength
+	co.rlpLength = size
+	return uint64(added), nil
+}
+
 // InputBytes returns the total amount of RLP-encoded input bytes.
 func (co *ChannelOut) InputBytes() int {
 	return co.rlpLength
@@ -146,6 +203,11 @@ func (co *ChannelOut) Close() error {
 		return errors.New("already closed")
 	}
 	co.closed = true
+	if co.span != nil && co.span.span != nil {
+		if err := rlp.Encode(co.compress, &BatchData{SpanBatch: co.span.span}); err != nil {
+			return err
+		}
+	}
 	return co.compress.Close()
 }
 
@@ -222,7 +284,7 @@ func BlockToBatch(block *types.Block) (*BatchData, L1BlockInfo, error) {
 	}
 
 	return &BatchData{
-		BatchV1{
+		BatchV1: BatchV1{
 			ParentHash:   block.ParentHash(),
 			EpochNum:     rollup.Epoch(l1Info.Number),
 			EpochHash:    l1Info.BlockHash,
@@ -96,6 +96,9 @@ type EngineQueue struct {
 	buildingOnto eth.L2BlockRef
 	buildingID   eth.PayloadID
 	buildingSafe bool
+	// buildingPayload is the payload of the building job once it was sealed, so that a confirmation retried after
+	// a temporary insertion error reuses it, instead of getting the payload of the job again. Nil until sealed.
+	buildingPayload *eth.ExecutionPayload
 
 	// Track when the rollup node changes the forkchoice without engine action,
 	// e.g. on a reset after a reorg, or after consolidating a block.
@@ -119,18 +122,20 @@ type EngineQueue struct {
 	sysCfg eth.SystemConfig // only used for pipeline resets
 
 	metrics   Metrics
+	events    Events
 	l1Fetcher L1Fetcher
 }
 
 var _ EngineControl = (*EngineQueue)(nil)
 
 // NewEngineQueue creates a new EngineQueue, which should be Reset(origin) before use.
-func NewEngineQueue(log log.Logger, cfg *rollup.Config, engine Engine, metrics Metrics, prev NextAttributesProvider, l1Fetcher L1Fetcher) *EngineQueue {
+func NewEngineQueue(log log.Logger, cfg *rollup.Config, engine Engine, metrics Metrics, events Events, prev NextAttributesProvider, l1Fetcher L1Fetcher) *EngineQueue {
 	return &EngineQueue{
 		log:            log,
 		cfg:            cfg,
 		engine:         engine,
 		metrics:        metrics,
+		events:         events,
 		finalityData:   make([]FinalityData, 0, finalityLookback),
 		unsafePayloads: NewPayloadsQueue(maxUnsafePayloadsMemory, payloadMemSize),
 		prev:           prev,
@@ -292,8 +297,9 @@ func (eq *EngineQueue) tryFinalizeL2() {
 }
 
 // postProcessSafeL2 buffers the L1 block the safe head was fully derived from,
-// to finalize it once the L1 block, or later, finalizes.
+// to finalize it once the L1 block, or later, finalizes. It also emits the new safe head event.
 func (eq *EngineQueue) postProcessSafeL2() {
+	eq.events.OnNewSafeBlock(eq.safeHead, eq.origin)
 	// prune finality data if necessary
 	if len(eq.finalityData) >= finalityLookback {
 		eq.finalityData = append(eq.finalityData[:0], eq.finalityData[1:finalityLookback]...)
@@ -557,6 +563,7 @@ func (eq *EngineQueue) StartPayload(ctx context.Context, parent eth.L2BlockRef,
 	eq.buildingID = id
 	eq.buildingSafe = updateSafe
 	eq.buildingOnto = parent
+	eq.buildingPayload = nil
 	return BlockInsertOK, nil
 }
 
@@ -572,7 +579,16 @@ func (eq *EngineQueue) ConfirmPayload(ctx context.Context) (out *eth.ExecutionPa
 		SafeBlockHash:      eq.safeHead.Hash,
 		FinalizedBlockHash: eq.finalized.Hash,
 	}
-	payload, errTyp, err := ConfirmPayload(ctx, eq.log, eq.engine, fc, eq.buildingID, eq.buildingSafe)
+	if eq.buildingPayload == nil {
+		payload, errTyp, err := SealPayload(ctx, eq.engine, eq.buildingID)
+		if err != nil {
+			return nil, errTyp, fmt.Errorf("failed to complete building on top of L2 chain %s, id: %s, error (%d): %w", eq.buildingOnto, eq.buildingID, errTyp, err)
+		}
+		eq.buildingPayload = payload
+	} else {
+		eq.log.Info("reusing sealed payload to retry its insertion", "onto", eq.buildingOnto, "payload_id", eq.buildingID, "hash", eq.buildingPayload.BlockHash)
+	}
+	payload, errTyp, err := InsertPayload(ctx, eq.log, eq.engine, fc, eq.buildingPayload, eq.buildingSafe)
 	if err != nil {
 		return nil, errTyp, fmt.Errorf("failed to complete building on top of L2 chain %s, id: %s, error (%d): %w", eq.buildingOnto, eq.buildingID, errTyp, err)
 	}
@@ -599,6 +615,10 @@ func (eq *EngineQueue) CancelPayload(ctx context.Context, force bool) error {
 	}
 	// the building job gets wrapped up as soon as the payload is retrieved, there's no explicit cancel in the Engine API
 	eq.log.Error("cancelling old block sealing job", "payload", eq.buildingID)
+	if eq.buildingPayload != nil { // already wrapped up when sealed
+		eq.resetBuildingState()
+		return nil
+	}
 	_, err := eq.engine.GetPayload(ctx, eq.buildingID)
 	if err != nil {
 		eq.log.Error("failed to cancel block building job", "payload", eq.buildingID, "err", err)
@@ -618,6 +638,7 @@ func (eq *EngineQueue) resetBuildingState() {
 	eq.buildingID = eth.PayloadID{}
 	eq.buildingOnto = eth.L2BlockRef{}
 	eq.buildingSafe = false
+	eq.buildingPayload = nil
 }
 
 // ResetStep Walks the L2 chain backwards until it finds an L2 block whose L1 origin is canonical.
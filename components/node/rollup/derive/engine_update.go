@@ -118,6 +118,15 @@ func StartPayload(ctx context.Context, eng Engine, fc eth.ForkchoiceState, attrs
 // If updateSafe is true, then the payload will also be recognized as safe-head at the same time.
 // The severity of the error is distinguished to determine whether the payload was valid and can become canonical.
 func ConfirmPayload(ctx context.Context, log log.Logger, eng Engine, fc eth.ForkchoiceState, id eth.PayloadID, updateSafe bool) (out *eth.ExecutionPayload, errTyp BlockInsertionErrType, err error) {
+	payload, errTyp, err := SealPayload(ctx, eng, id)
+	if err != nil {
+		return nil, errTyp, err
+	}
+	return InsertPayload(ctx, log, eng, fc, payload, updateSafe)
+}
+
+// SealPayload ends an execution payload building process in the provided Engine, and returns the sealed payload.
+func SealPayload(ctx context.Context, eng Engine, id eth.PayloadID) (out *eth.ExecutionPayload, errTyp BlockInsertionErrType, err error) {
 	payload, err := eng.GetPayload(ctx, id)
 	if err != nil {
 		// even if it is an input-error (unknown payload ID), it is temporary, since we will re-attempt the full payload building, not just the retrieval of the payload.
@@ -126,7 +135,12 @@ func ConfirmPayload(ctx context.Context, log log.Logger, eng Engine, fc eth.Fork
 	if err := sanityCheckPayload(payload); err != nil {
 		return nil, BlockInsertPayloadErr, err
 	}
+	return payload, BlockInsertOK, nil
+}
 
+// InsertPayload persists a sealed payload as the canonical head, see ConfirmPayload.
+// A payload that failed to be inserted with a temporary error can be inserted again, without sealing it again.
+func InsertPayload(ctx context.Context, log log.Logger, eng Engine, fc eth.ForkchoiceState, payload *eth.ExecutionPayload, updateSafe bool) (out *eth.ExecutionPayload, errTyp BlockInsertionErrType, err error) {
 	status, err := eng.NewPayload(ctx, payload)
 	if err != nil {
 		return nil, BlockInsertTemporaryErr, fmt.Errorf("failed to insert execution payload: %w", err)
@@ -0,0 +1,27 @@
+package derive
+
+import (
+	"github.com/kroma-network/kroma/components/node/eth"
+)
+
+// Events is notified of derivation milestones, e.g. to stream them to monitoring systems.
+// Implementations must not block, as they are called from within the derivation pipeline.
+type Events interface {
+	// OnChannelClosed is called when a complete channel is read from the channel bank.
+	OnChannelClosed(id ChannelID, frames int, origin eth.L1BlockRef)
+	// OnBatchConsumed is called when the batch queue hands the next batch to the attributes queue.
+	OnBatchConsumed(batch *BatchData, origin eth.L1BlockRef)
+	// OnNewSafeBlock is called whenever the safe head advances to a block derived from L1.
+	OnNewSafeBlock(ref eth.L2BlockRef, origin eth.L1BlockRef)
+}
+
+type noopEvents struct{}
+
+func (noopEvents) OnChannelClosed(ChannelID, int, eth.L1BlockRef) {}
+
+func (noopEvents) OnBatchConsumed(*BatchData, eth.L1BlockRef) {}
+
+func (noopEvents) OnNewSafeBlock(eth.L2BlockRef, eth.L1BlockRef) {}
+
+// NoopEvents discards all derivation events.
+var NoopEvents Events = noopEvents{}
@@ -56,6 +56,28 @@ func (fq *FrameQueue) NextFrame(ctx context.Context) (Frame, error) {
 	return ret, nil
 }
 
+// FrameState is a snapshot of a frame buffered in the frame queue, for debugging.
+type FrameState struct {
+	ID          ChannelID `json:"id"`
+	FrameNumber uint16    `json:"frameNumber"`
+	Size        uint64    `json:"size"`
+	IsLast      bool      `json:"isLast"`
+}
+
+// PendingFrames returns a snapshot of the frames that are parsed, but not yet ingested by the channel bank.
+func (fq *FrameQueue) PendingFrames() []FrameState {
+	frames := make([]FrameState, 0, len(fq.frames))
+	for _, f := range fq.frames {
+		frames = append(frames, FrameState{
+			ID:          f.ID,
+			FrameNumber: f.FrameNumber,
+			Size:        frameSize(f),
+			IsLast:      f.IsLast,
+		})
+	}
+	return frames
+}
+
 func (fq *FrameQueue) Reset(_ context.Context, _ eth.L1BlockRef, _ eth.SystemConfig) error {
 	fq.frames = fq.frames[:0]
 	return io.EOF
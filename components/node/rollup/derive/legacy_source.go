@@ -0,0 +1,185 @@
+package derive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/kroma-network/kroma/components/node/eth"
+	"github.com/kroma-network/kroma/components/node/rollup"
+)
+
+// LegacyDataDecoder decodes the data of a transaction posted to the legacy inbox, before the migration onto Kroma,
+// into the data of the current format, i.e. versioned channel frames. A transaction may decode into no data.
+type LegacyDataDecoder interface {
+	DecodeLegacyData(data []byte) ([]eth.Data, error)
+}
+
+// LegacyDataDecoderFunc is a LegacyDataDecoder function.
+type LegacyDataDecoderFunc func(data []byte) ([]eth.Data, error)
+
+func (f LegacyDataDecoderFunc) DecodeLegacyData(data []byte) ([]eth.Data, error) {
+	return f(data)
+}
+
+// The built-in legacy inbox formats.
+const (
+	// LegacyFormatFrames is the current format, posted to another inbox by another batcher.
+	LegacyFormatFrames = "frames"
+	// LegacyFormatUnversionedFrames is the current format without the derivation version byte.
+	LegacyFormatUnversionedFrames = "unversioned-frames"
+)
+
+var (
+	legacyDecodersLock sync.RWMutex
+	legacyDecoders     = map[string]LegacyDataDecoder{
+		LegacyFormatFrames: LegacyDataDecoderFunc(func(data []byte) ([]eth.Data, error) {
+			return []eth.Data{data}, nil
+		}),
+		LegacyFormatUnversionedFrames: LegacyDataDecoderFunc(func(data []byte) ([]eth.Data, error) {
+			return []eth.Data{append([]byte{DerivationVersion0}, data...)}, nil
+		}),
+	}
+)
+
+// RegisterLegacyDataDecoder registers the decoder of a legacy inbox format, to be selected by the rollup config.
+func RegisterLegacyDataDecoder(format string, decoder LegacyDataDecoder) {
+	legacyDecodersLock.Lock()
+	defer legacyDecodersLock.Unlock()
+	legacyDecoders[format] = decoder
+}
+
+// LegacyDataDecoderByFormat returns the registered decoder of the legacy inbox format.
+func LegacyDataDecoderByFormat(format string) (LegacyDataDecoder, error) {
+	legacyDecodersLock.RLock()
+	defer legacyDecodersLock.RUnlock()
+	decoder, ok := legacyDecoders[format]
+	if !ok {
+		return nil, fmt.Errorf("unknown legacy inbox format: %q", format)
+	}
+	return decoder, nil
+}
+
+// LegacyDataSourceFactory reads the batch data of the L1 blocks before the end of the legacy inbox from the legacy
+// inbox, and the data of the later blocks from the current data source.
+// This is not a stage in the pipeline, but a wrapper for another stage in the pipeline
+type LegacyDataSourceFactory struct {
+	log     log.Logger
+	cfg     *rollup.Config
+	fetcher L1TransactionFetcher
+	decoder LegacyDataDecoder
+	// decoderErr is the error of the lookup of the decoder of the legacy inbox format.
+	decoderErr error
+	current    DataAvailabilitySource
+}
+
+// NewLegacyDataSourceFactory creates the factory with the registered decoder of the legacy inbox format of the
+// config. The format is expected to be checked with the config of the node, an unknown format fails the derivation of
+// the legacy blocks with a critical error.
+func NewLegacyDataSourceFactory(log log.Logger, cfg *rollup.Config, fetcher L1TransactionFetcher, current DataAvailabilitySource) *LegacyDataSourceFactory {
+	decoder, err := LegacyDataDecoderByFormat(cfg.LegacyInbox.Format)
+	return &LegacyDataSourceFactory{log: log, cfg: cfg, fetcher: fetcher, decoder: decoder, decoderErr: err, current: current}
+}
+
+// OpenData returns a DataIter of the legacy inbox if the block is before the end of the legacy inbox.
+// The batcher of the system config is ignored for the legacy inbox, which has its own batcher.
+func (ds *LegacyDataSourceFactory) OpenData(ctx context.Context, id eth.BlockID, batcherAddr common.Address) DataIter {
+	if !ds.cfg.LegacyInbox.IsLegacy(id.Number) {
+		return ds.current.OpenData(ctx, id, batcherAddr)
+	}
+	src := &LegacyDataSource{
+		id:         id,
+		cfg:        ds.cfg,
+		fetcher:    ds.fetcher,
+		decoder:    ds.decoder,
+		decoderErr: ds.decoderErr,
+		log:        ds.log.New("origin", id, "inbox", "legacy"),
+	}
+	// errors are suppressed, and the source is opened again on the next call to `Next`.
+	_ = src.tryOpen(ctx)
+	return src
+}
+
+// LegacyDataSource is the fault tolerant source of the decoded data of a block in the legacy inbox,
+// like DataSource.
+type LegacyDataSource struct {
+	open bool
+	data []eth.Data
+
+	id         eth.BlockID
+	cfg        *rollup.Config
+	fetcher    L1TransactionFetcher
+	decoder    LegacyDataDecoder
+	decoderErr error
+	log        log.Logger
+}
+
+func (ds *LegacyDataSource) tryOpen(ctx context.Context) error {
+	if ds.decoderErr != nil {
+		return ds.decoderErr
+	}
+	_, txs, err := ds.fetcher.InfoAndTxsByHash(ctx, ds.id.Hash)
+	if err != nil {
+		return err
+	}
+	ds.open = true
+	ds.data = LegacyDataFromEVMTransactions(ds.cfg, ds.decoder, txs, ds.log)
+	return nil
+}
+
+// Next returns the next piece of decoded data if it has it. If the legacy inbox format is unknown it returns a
+// CriticalError. If it cannot find the block it returns a ResetError, otherwise it returns a temporary error if
+// fetching the block returns an error.
+func (ds *LegacyDataSource) Next(ctx context.Context) (eth.Data, error) {
+	if !ds.open {
+		if err := ds.tryOpen(ctx); ds.decoderErr != nil {
+			return nil, NewCriticalError(fmt.Errorf("failed to open legacy calldata source: %w", err))
+		} else if errors.Is(err, ethereum.NotFound) {
+			return nil, NewResetError(fmt.Errorf("failed to open legacy calldata source: %w", err))
+		} else if err != nil {
+			return nil, NewTemporaryError(fmt.Errorf("failed to open legacy calldata source: %w", err))
+		}
+	}
+	if len(ds.data) == 0 {
+		return nil, io.EOF
+	}
+	data := ds.data[0]
+	ds.data = ds.data[1:]
+	return data, nil
+}
+
+// LegacyDataFromEVMTransactions filters the transactions sent to the legacy inbox from the legacy batcher,
+// and returns their decoded data. Transactions that cannot be decoded are ignored.
+func LegacyDataFromEVMTransactions(config *rollup.Config, decoder LegacyDataDecoder, txs types.Transactions, log log.Logger) []eth.Data {
+	legacy := config.LegacyInbox
+	var out []eth.Data
+	l1Signer := config.L1Signer()
+	for j, tx := range txs {
+		if to := tx.To(); to == nil || *to != legacy.Address {
+			continue
+		}
+		sender, err := l1Signer.Sender(tx)
+		if err != nil {
+			log.Warn("tx in legacy inbox with invalid signature", "index", j, "err", err)
+			continue
+		}
+		if sender != legacy.BatcherAddr {
+			log.Warn("tx in legacy inbox with unauthorized submitter", "index", j, "sender", sender)
+			continue
+		}
+		data, err := decoder.DecodeLegacyData(tx.Data())
+		if err != nil {
+			log.Warn("tx in legacy inbox with invalid data", "index", j, "err", err)
+			continue
+		}
+		out = append(out, data...)
+	}
+	return out
+}
@@ -0,0 +1,119 @@
+package derive
+
+import (
+	"context"
+	"io"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kroma-network/kroma/components/node/eth"
+	"github.com/kroma-network/kroma/components/node/rollup"
+	"github.com/kroma-network/kroma/components/node/testlog"
+	"github.com/kroma-network/kroma/components/node/testutils"
+)
+
+type fakeDataSource struct {
+	opened []eth.BlockID
+}
+
+func (s *fakeDataSource) OpenData(_ context.Context, id eth.BlockID, _ common.Address) DataIter {
+	s.opened = append(s.opened, id)
+	return nil
+}
+
+func TestLegacyDataFromEVMTransactions(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	legacyBatcherPriv := testutils.RandomKey()
+	batcherPriv := testutils.RandomKey()
+	cfg := &rollup.Config{
+		L1ChainID:         big.NewInt(100),
+		BatchInboxAddress: testutils.RandomAddress(rng),
+		LegacyInbox: &rollup.LegacyInboxConfig{
+			Format:      LegacyFormatUnversionedFrames,
+			Address:     testutils.RandomAddress(rng),
+			BatcherAddr: crypto.PubkeyToAddress(legacyBatcherPriv.PublicKey),
+			EndBlock:    100,
+		},
+	}
+	decoder, err := LegacyDataDecoderByFormat(cfg.LegacyInbox.Format)
+	require.NoError(t, err)
+
+	testTxs := []testTx{
+		{to: &cfg.LegacyInbox.Address, dataLen: 1234, author: legacyBatcherPriv, good: true},
+		{to: &cfg.LegacyInbox.Address, dataLen: 1234, author: batcherPriv, good: false},
+		{to: &cfg.BatchInboxAddress, dataLen: 1234, author: legacyBatcherPriv, good: false},
+		{to: &cfg.BatchInboxAddress, dataLen: 1234, author: batcherPriv, good: false},
+		{to: nil, dataLen: 1234, author: legacyBatcherPriv, good: false},
+		{to: &cfg.LegacyInbox.Address, dataLen: 0, author: legacyBatcherPriv, good: true},
+	}
+	var expectedData []eth.Data
+	var txs []*types.Transaction
+	for _, tx := range testTxs {
+		txs = append(txs, tx.Create(t, cfg.L1Signer(), rng))
+		if tx.good {
+			expectedData = append(expectedData, append([]byte{DerivationVersion0}, txs[len(txs)-1].Data()...))
+		}
+	}
+
+	out := LegacyDataFromEVMTransactions(cfg, decoder, txs, testlog.Logger(t, log.LvlCrit))
+	require.Equal(t, expectedData, out)
+}
+
+func TestLegacyDataSourceFactory(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	legacyBatcherPriv := testutils.RandomKey()
+	cfg := &rollup.Config{
+		L1ChainID: big.NewInt(100),
+		LegacyInbox: &rollup.LegacyInboxConfig{
+			Format:      LegacyFormatFrames,
+			Address:     testutils.RandomAddress(rng),
+			BatcherAddr: crypto.PubkeyToAddress(legacyBatcherPriv.PublicKey),
+			EndBlock:    100,
+		},
+	}
+	l1F := &testutils.MockL1Source{}
+	current := &fakeDataSource{}
+	factory := NewLegacyDataSourceFactory(testlog.Logger(t, log.LvlCrit), cfg, l1F, current)
+
+	tx := (&testTx{to: &cfg.LegacyInbox.Address, dataLen: 100, author: legacyBatcherPriv}).Create(t, cfg.L1Signer(), rng)
+	legacyID := eth.BlockID{Hash: testutils.RandomHash(rng), Number: 99}
+	l1F.ExpectInfoAndTxsByHash(legacyID.Hash, testutils.RandomBlockInfo(rng), types.Transactions{tx}, nil)
+
+	src := factory.OpenData(context.Background(), legacyID, common.Address{})
+	data, err := src.Next(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, eth.Data(tx.Data()), data)
+	_, err = src.Next(context.Background())
+	require.ErrorIs(t, err, io.EOF)
+	require.Empty(t, current.opened, "legacy block must not be read from the current inbox")
+
+	currentID := eth.BlockID{Hash: testutils.RandomHash(rng), Number: 100}
+	factory.OpenData(context.Background(), currentID, common.Address{})
+	require.Equal(t, []eth.BlockID{currentID}, current.opened)
+	l1F.AssertExpectations(t)
+
+	// an unknown format fails the derivation instead of skipping the legacy data
+	unknown := *cfg
+	unknown.LegacyInbox = &rollup.LegacyInboxConfig{Format: "unknown", EndBlock: 100}
+	factory = NewLegacyDataSourceFactory(testlog.Logger(t, log.LvlCrit), &unknown, l1F, current)
+	_, err = factory.OpenData(context.Background(), legacyID, common.Address{}).Next(context.Background())
+	require.ErrorIs(t, err, ErrCritical)
+}
+
+func TestLegacyDataDecoderByFormat(t *testing.T) {
+	_, err := LegacyDataDecoderByFormat("unknown")
+	require.Error(t, err)
+
+	RegisterLegacyDataDecoder("test", LegacyDataDecoderFunc(func(data []byte) ([]eth.Data, error) {
+		return nil, nil
+	}))
+	_, err = LegacyDataDecoderByFormat("test")
+	require.NoError(t, err)
+}
@@ -56,6 +56,11 @@ type EngineQueueStage interface {
 	Step(context.Context) error
 }
 
+// L1OriginAdvancer advances the L1 origin of the pull stages, once all stages returned io.EOF.
+type L1OriginAdvancer interface {
+	AdvanceL1Block(ctx context.Context) error
+}
+
 // DerivationPipeline is updated with new L1 data, and the Step() function can be iterated on to keep the L2 Engine in sync.
 type DerivationPipeline struct {
 	log       log.Logger
@@ -68,33 +73,77 @@ type DerivationPipeline struct {
 	stages    []ResetableStage
 
 	// Special stages to keep track of
-	traversal *L1Traversal
+	traversal L1OriginAdvancer
 	eng       EngineQueueStage
 
 	metrics Metrics
+
+	// shadow derives alongside the pipeline, without affecting it. Nil if shadow mode is disabled.
+	shadow *ShadowPipeline
 }
 
-// NewDerivationPipeline creates a derivation pipeline, which should be reset before use.
-func NewDerivationPipeline(log log.Logger, cfg *rollup.Config, l1Fetcher L1Fetcher, engine Engine, metrics Metrics) *DerivationPipeline {
+// AttributesStages are the pull stages of a derivation pipeline, from the L1 traversal up to the payload attributes.
+type AttributesStages struct {
+	Traversal L1OriginAdvancer
+	// Stages are reset in order, after the engine queue.
+	Stages     []ResetableStage
+	Attributes NextAttributesProvider
+}
 
-	// Pull stages
+// AttributesStagesFactory creates the pull stages of a derivation pipeline version.
+type AttributesStagesFactory func(log log.Logger, cfg *rollup.Config, l1Fetcher L1Fetcher, engine Engine, metrics Metrics, events Events) AttributesStages
+
+// NewAttributesStages creates the pull stages of the active derivation pipeline.
+func NewAttributesStages(log log.Logger, cfg *rollup.Config, l1Fetcher L1Fetcher, engine Engine, metrics Metrics, events Events) AttributesStages {
 	l1Traversal := NewL1Traversal(log, cfg, l1Fetcher)
-	dataSrc := NewDataSourceFactory(log, cfg, l1Fetcher) // auxiliary stage for L1Retrieval
+	var dataSrc DataAvailabilitySource = NewDataSourceFactory(log, cfg, l1Fetcher) // auxiliary stage for L1Retrieval
+	if cfg.LegacyInbox != nil {
+		dataSrc = NewLegacyDataSourceFactory(log, cfg, l1Fetcher, dataSrc)
+	}
 	l1Src := NewL1Retrieval(log, dataSrc, l1Traversal)
 	frameQueue := NewFrameQueue(log, l1Src)
-	bank := NewChannelBank(log, cfg, frameQueue, l1Fetcher)
-	chInReader := NewChannelInReader(log, bank, metrics)
-	batchQueue := NewBatchQueue(log, cfg, chInReader)
+	bank := NewChannelBank(log, cfg, frameQueue, l1Fetcher, events)
+	chInReader := NewChannelInReader(log, cfg, bank, metrics)
+	batchQueue := NewBatchQueue(log, cfg, chInReader, engine, events)
 	attrBuilder := NewFetchingAttributesBuilder(cfg, l1Fetcher, engine)
 	attributesQueue := NewAttributesQueue(log, cfg, attrBuilder, batchQueue)
 
+	return AttributesStages{
+		Traversal:  l1Traversal,
+		Stages:     []ResetableStage{l1Traversal, l1Src, frameQueue, bank, chInReader, batchQueue, attributesQueue},
+		Attributes: attributesQueue,
+	}
+}
+
+// NewDerivationPipeline creates a derivation pipeline, which should be reset before use.
+func NewDerivationPipeline(log log.Logger, cfg *rollup.Config, l1Fetcher L1Fetcher, engine Engine, metrics Metrics, events Events) *DerivationPipeline {
+	return newDerivationPipeline(log, cfg, l1Fetcher, engine, metrics, events, nil)
+}
+
+// NewShadowedDerivationPipeline creates a derivation pipeline, which should be reset before use,
+// and which runs the candidate pipeline version in shadow mode alongside, see ShadowPipeline.
+func NewShadowedDerivationPipeline(log log.Logger, cfg *rollup.Config, l1Fetcher L1Fetcher, engine Engine, metrics Metrics, events Events,
+	candidate AttributesStagesFactory, shadowMetrics ShadowMetrics,
+) *DerivationPipeline {
+	shadow := NewShadowPipeline(log.New("derivation", "shadow"), cfg, l1Fetcher, engine, candidate, shadowMetrics)
+	return newDerivationPipeline(log, cfg, l1Fetcher, engine, metrics, events, shadow)
+}
+
+func newDerivationPipeline(log log.Logger, cfg *rollup.Config, l1Fetcher L1Fetcher, engine Engine, metrics Metrics, events Events, shadow *ShadowPipeline) *DerivationPipeline {
+	// Pull stages
+	attrStages := NewAttributesStages(log, cfg, l1Fetcher, engine, metrics, events)
+	prev := attrStages.Attributes
+	if shadow != nil {
+		prev = shadow.Tap(prev)
+	}
+
 	// Step stages
-	eng := NewEngineQueue(log, cfg, engine, metrics, attributesQueue, l1Fetcher)
+	eng := NewEngineQueue(log, cfg, engine, metrics, events, prev, l1Fetcher)
 
 	// Reset from engine queue then up from L1 Traversal. The stages do not talk to each other during
 	// the reset, but after the engine queue, this is the order in which the stages could talk to each other.
 	// Note: The engine queue stage is the only reset that can fail.
-	stages := []ResetableStage{eng, l1Traversal, l1Src, frameQueue, bank, chInReader, batchQueue, attributesQueue}
+	stages := append([]ResetableStage{eng}, attrStages.Stages...)
 
 	return &DerivationPipeline{
 		log:       log,
@@ -104,7 +153,8 @@ func NewDerivationPipeline(log log.Logger, cfg *rollup.Config, l1Fetcher L1Fetch
 		stages:    stages,
 		eng:       eng,
 		metrics:   metrics,
-		traversal: l1Traversal,
+		traversal: attrStages.Traversal,
+		shadow:    shadow,
 	}
 }
 
@@ -173,6 +223,44 @@ func (dp *DerivationPipeline) UnsafeL2SyncTarget() eth.L2BlockRef {
 	return dp.eng.UnsafeL2SyncTarget()
 }
 
+// DerivationState is a snapshot of the in-memory buffers of the derivation pipeline,
+// to debug why the safe head is not advancing.
+type DerivationState struct {
+	Origin eth.L1BlockRef `json:"origin"`
+	// Resetting is true if the stages are being reset, in which case the buffers may not be consistent.
+	Resetting bool `json:"resetting"`
+	// PendingFrames are the frames of the frame queue, not yet ingested by the channel bank.
+	PendingFrames []FrameState `json:"pendingFrames"`
+	// Channels are the channels of the channel bank, in the order they are read.
+	Channels []ChannelState `json:"channels"`
+	// ChannelBankSize is the total size of the channels, which are pruned beyond MaxChannelBankSize.
+	ChannelBankSize    uint64 `json:"channelBankSize"`
+	MaxChannelBankSize uint64 `json:"maxChannelBankSize"`
+}
+
+// State returns a snapshot of the frame queue and channel bank of the pipeline.
+func (dp *DerivationPipeline) State() *DerivationState {
+	state := &DerivationState{
+		Origin:             dp.Origin(),
+		Resetting:          dp.resetting < len(dp.stages),
+		PendingFrames:      []FrameState{},
+		Channels:           []ChannelState{},
+		MaxChannelBankSize: MaxChannelBankSize,
+	}
+	for _, stage := range dp.stages {
+		switch stage := stage.(type) {
+		case *FrameQueue:
+			state.PendingFrames = stage.PendingFrames()
+		case *ChannelBank:
+			state.Channels = stage.State()
+			for _, ch := range state.Channels {
+				state.ChannelBankSize += ch.Size
+			}
+		}
+	}
+	return state
+}
+
 // Step tries to progress the buffer.
 // An EOF is returned if there pipeline is blocked by waiting for new L1 data.
 // If ctx errors no error is returned, but the step may exit early in a state that can still be continued.
@@ -186,6 +274,10 @@ func (dp *DerivationPipeline) Step(ctx context.Context) error {
 	if dp.resetting < len(dp.stages) {
 		if err := dp.stages[dp.resetting].Reset(ctx, dp.eng.Origin(), dp.eng.SystemConfig()); err == io.EOF {
 			dp.log.Debug("reset of stage completed", "stage", dp.resetting, "origin", dp.eng.Origin())
+			if dp.resetting == 0 && dp.shadow != nil {
+				// the shadow derives from the same base as the pull stages.
+				dp.shadow.Reset(dp.eng.Origin(), dp.eng.SystemConfig())
+			}
 			dp.resetting += 1
 			return nil
 		} else if err != nil {
@@ -196,7 +288,11 @@ func (dp *DerivationPipeline) Step(ctx context.Context) error {
 	}
 
 	// Now step the engine queue. It will pull earlier data as needed.
-	if err := dp.eng.Step(ctx); err == io.EOF {
+	err := dp.eng.Step(ctx)
+	if dp.shadow != nil {
+		dp.shadow.Step(ctx)
+	}
+	if err == io.EOF {
 		// If every stage has returned io.EOF, try to advance the L1 Origin
 		return dp.traversal.AdvanceL1Block(ctx)
 	} else if err != nil {
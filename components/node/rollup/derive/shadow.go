@@ -0,0 +1,216 @@
+package derive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/kroma-network/kroma/components/node/eth"
+	"github.com/kroma-network/kroma/components/node/rollup"
+)
+
+// CandidatePipelines are the derivation pipeline versions that can run in shadow mode, by name.
+// A change of the derivation is added as a candidate first, to verify it against the active pipeline
+// on live data before it replaces NewAttributesStages.
+var CandidatePipelines = map[string]AttributesStagesFactory{
+	"active": NewAttributesStages,
+}
+
+// The kinds of shadow derivation divergences.
+const (
+	ShadowDivergenceAttributes = "attributes"
+	ShadowDivergenceOrigin     = "origin"
+	ShadowDivergenceError      = "error"
+	ShadowDivergenceLag        = "lag"
+)
+
+// maxShadowExpectations bounds the attributes of the active pipeline that wait to be compared.
+const maxShadowExpectations = 256
+
+// shadowStepBudget bounds the stage steps of the shadow per step of the active pipeline,
+// so that the shadow can catch up without stalling the active pipeline.
+const shadowStepBudget = 16
+
+type ShadowMetrics interface {
+	RecordShadowDerivationMatch()
+	RecordShadowDerivationDivergence(kind string)
+}
+
+// shadowExpectation are the attributes the active pipeline derived onto the parent.
+type shadowExpectation struct {
+	parent eth.L2BlockRef
+	attrs  *eth.PayloadAttributes
+	origin eth.L1BlockRef
+}
+
+// ShadowPipeline runs the pull stages of a candidate derivation pipeline version alongside the active pipeline.
+// It derives onto the same safe heads as the active pipeline, and compares the derived payload attributes
+// and the L1 origin they are derived from. Divergences are logged and metered, the shadow never affects the L2 chain:
+// it does not use the engine other than to read the L2 chain, and does not emit derivation events.
+type ShadowPipeline struct {
+	log     log.Logger
+	metrics ShadowMetrics
+	stages  AttributesStages
+
+	// active is false until the active pipeline is reset.
+	active    bool
+	resetting int
+	base      eth.L1BlockRef
+	baseCfg   eth.SystemConfig
+
+	expected []shadowExpectation
+}
+
+func NewShadowPipeline(log log.Logger, cfg *rollup.Config, l1Fetcher L1Fetcher, engine Engine, candidate AttributesStagesFactory, metrics ShadowMetrics) *ShadowPipeline {
+	return &ShadowPipeline{
+		log:     log,
+		metrics: metrics,
+		stages:  candidate(log, cfg, l1Fetcher, engine, discardMetrics{}, NoopEvents),
+	}
+}
+
+// Tap returns the attributes provider of the active pipeline, which hands every
+// derived payload attributes to the shadow to compare.
+func (s *ShadowPipeline) Tap(prev NextAttributesProvider) NextAttributesProvider {
+	return &shadowTap{NextAttributesProvider: prev, shadow: s}
+}
+
+// Reset resets the shadow to derive from the base, like the pull stages of the active pipeline.
+func (s *ShadowPipeline) Reset(base eth.L1BlockRef, baseCfg eth.SystemConfig) {
+	s.active = true
+	s.resetting = 0
+	s.base = base
+	s.baseCfg = baseCfg
+	s.expected = nil
+}
+
+func (s *ShadowPipeline) expect(parent eth.L2BlockRef, attrs *eth.PayloadAttributes, origin eth.L1BlockRef) {
+	if !s.active {
+		return
+	}
+	if len(s.expected) >= maxShadowExpectations {
+		s.log.Warn("shadow derivation falls behind, skipping the comparison of derived attributes", "parent", s.expected[0].parent)
+		s.metrics.RecordShadowDerivationDivergence(ShadowDivergenceLag)
+		s.expected = s.expected[1:]
+	}
+	s.expected = append(s.expected, shadowExpectation{parent: parent, attrs: attrs, origin: origin})
+}
+
+// Step progresses the shadow, until all attributes of the active pipeline are compared or the step budget is used up.
+// Errors of the shadow are not returned, but logged and metered.
+func (s *ShadowPipeline) Step(ctx context.Context) {
+	for i := 0; i < shadowStepBudget && s.active && ctx.Err() == nil; i++ {
+		if s.resetting < len(s.stages.Stages) {
+			if err := s.stages.Stages[s.resetting].Reset(ctx, s.base, s.baseCfg); err == io.EOF {
+				s.resetting += 1
+			} else if errors.Is(err, ErrTemporary) {
+				return
+			} else if err != nil {
+				s.fail(fmt.Errorf("stage %d failed resetting: %w", s.resetting, err))
+				return
+			}
+			continue
+		}
+		if len(s.expected) == 0 {
+			return
+		}
+
+		next := s.expected[0]
+		attrs, err := s.stages.Attributes.NextAttributes(ctx, next.parent)
+		if err == io.EOF {
+			if err := s.stages.Traversal.AdvanceL1Block(ctx); err == io.EOF {
+				return
+			} else if err != nil && !errors.Is(err, ErrTemporary) {
+				s.fail(err)
+				return
+			}
+			continue
+		} else if errors.Is(err, NotEnoughData) {
+			continue
+		} else if errors.Is(err, ErrTemporary) {
+			s.log.Debug("temporary error in shadow derivation", "err", err)
+			return
+		} else if err != nil {
+			s.fail(err)
+			return
+		}
+
+		s.expected = s.expected[1:]
+		s.compare(next, attrs, s.stages.Attributes.Origin())
+	}
+}
+
+func (s *ShadowPipeline) compare(expected shadowExpectation, attrs *eth.PayloadAttributes, origin eth.L1BlockRef) {
+	if diff := diffAttributes(expected.attrs, attrs); diff != "" {
+		s.log.Error("shadow derivation diverged in derived attributes", "parent", expected.parent, "diff", diff)
+		s.metrics.RecordShadowDerivationDivergence(ShadowDivergenceAttributes)
+		return
+	}
+	if origin.ID() != expected.origin.ID() {
+		s.log.Error("shadow derivation diverged in L1 origin", "parent", expected.parent,
+			"origin", expected.origin, "shadow_origin", origin)
+		s.metrics.RecordShadowDerivationDivergence(ShadowDivergenceOrigin)
+		return
+	}
+	s.metrics.RecordShadowDerivationMatch()
+}
+
+// fail restarts the shadow from the base of the last reset, since the active pipeline did not fail.
+func (s *ShadowPipeline) fail(err error) {
+	var parent eth.L2BlockRef
+	if len(s.expected) > 0 {
+		parent = s.expected[0].parent
+	}
+	s.log.Error("shadow derivation failed, restarting it", "parent", parent, "base", s.base, "err", err)
+	s.metrics.RecordShadowDerivationDivergence(ShadowDivergenceError)
+	s.Reset(s.base, s.baseCfg)
+}
+
+// diffAttributes describes the first difference between the attributes, or returns an empty string if they are equal.
+func diffAttributes(a, b *eth.PayloadAttributes) string {
+	switch {
+	case a.Timestamp != b.Timestamp:
+		return fmt.Sprintf("timestamp %d != %d", a.Timestamp, b.Timestamp)
+	case a.PrevRandao != b.PrevRandao:
+		return fmt.Sprintf("prev randao %s != %s", a.PrevRandao, b.PrevRandao)
+	case a.SuggestedFeeRecipient != b.SuggestedFeeRecipient:
+		return fmt.Sprintf("fee recipient %s != %s", a.SuggestedFeeRecipient, b.SuggestedFeeRecipient)
+	case a.NoTxPool != b.NoTxPool:
+		return fmt.Sprintf("no tx pool %t != %t", a.NoTxPool, b.NoTxPool)
+	case (a.GasLimit == nil) != (b.GasLimit == nil) || (a.GasLimit != nil && *a.GasLimit != *b.GasLimit):
+		return fmt.Sprintf("gas limit %v != %v", a.GasLimit, b.GasLimit)
+	case len(a.Transactions) != len(b.Transactions):
+		return fmt.Sprintf("%d transactions != %d transactions", len(a.Transactions), len(b.Transactions))
+	}
+	for i := range a.Transactions {
+		if string(a.Transactions[i]) != string(b.Transactions[i]) {
+			return fmt.Sprintf("transaction %d differs", i)
+		}
+	}
+	return ""
+}
+
+// shadowTap hands the attributes of the active pipeline to the shadow.
+type shadowTap struct {
+	NextAttributesProvider
+	shadow *ShadowPipeline
+}
+
+func (t *shadowTap) NextAttributes(ctx context.Context, l2SafeHead eth.L2BlockRef) (*eth.PayloadAttributes, error) {
+	attrs, err := t.NextAttributesProvider.NextAttributes(ctx, l2SafeHead)
+	if err == nil && attrs != nil {
+		t.shadow.expect(l2SafeHead, attrs, t.Origin())
+	}
+	return attrs, err
+}
+
+// discardMetrics discards the metrics of the shadow stages, to not mix them with the metrics of the active pipeline.
+type discardMetrics struct{}
+
+func (discardMetrics) RecordL1Ref(string, eth.L1BlockRef)                     {}
+func (discardMetrics) RecordL2Ref(string, eth.L2BlockRef)                     {}
+func (discardMetrics) RecordUnsafePayloadsBuffer(uint64, uint64, eth.BlockID) {}
+func (discardMetrics) RecordChannelInputBytes(int)                            {}
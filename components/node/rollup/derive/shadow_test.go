@@ -0,0 +1,164 @@
+package derive
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kroma-network/kroma/components/node/eth"
+	"github.com/kroma-network/kroma/components/node/rollup"
+	"github.com/kroma-network/kroma/components/node/testlog"
+)
+
+// fakeShadowStages derives the scripted attributes onto their parent hash, from their origin.
+type fakeShadowStages struct {
+	attrs  map[common.Hash]*eth.PayloadAttributes
+	origin eth.L1BlockRef
+	err    error
+	resets int
+}
+
+func (f *fakeShadowStages) Reset(context.Context, eth.L1BlockRef, eth.SystemConfig) error {
+	f.resets++
+	return io.EOF
+}
+
+func (f *fakeShadowStages) AdvanceL1Block(context.Context) error {
+	return io.EOF
+}
+
+func (f *fakeShadowStages) Origin() eth.L1BlockRef {
+	return f.origin
+}
+
+func (f *fakeShadowStages) NextAttributes(_ context.Context, parent eth.L2BlockRef) (*eth.PayloadAttributes, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	attrs, ok := f.attrs[parent.Hash]
+	if !ok {
+		return nil, io.EOF
+	}
+	return attrs, nil
+}
+
+type fakeShadowMetrics struct {
+	matches     int
+	divergences map[string]int
+}
+
+func (m *fakeShadowMetrics) RecordShadowDerivationMatch() {
+	m.matches++
+}
+
+func (m *fakeShadowMetrics) RecordShadowDerivationDivergence(kind string) {
+	m.divergences[kind]++
+}
+
+func TestShadowPipeline(t *testing.T) {
+	parent := eth.L2BlockRef{Hash: common.Hash{0x1}, Number: 10}
+	origin := eth.L1BlockRef{Hash: common.Hash{0xa}, Number: 100}
+	attrs := &eth.PayloadAttributes{Timestamp: 20, NoTxPool: true, Transactions: []eth.Data{{0x7e, 0x01}}}
+
+	setup := func(t *testing.T) (*ShadowPipeline, *fakeShadowStages, *fakeShadowMetrics) {
+		stages := &fakeShadowStages{attrs: make(map[common.Hash]*eth.PayloadAttributes), origin: origin}
+		m := &fakeShadowMetrics{divergences: make(map[string]int)}
+		candidate := func(log.Logger, *rollup.Config, L1Fetcher, Engine, Metrics, Events) AttributesStages {
+			return AttributesStages{Traversal: stages, Stages: []ResetableStage{stages}, Attributes: stages}
+		}
+		s := NewShadowPipeline(testlog.Logger(t, log.LvlCrit), &rollup.Config{}, nil, nil, candidate, m)
+		return s, stages, m
+	}
+
+	t.Run("inactive until reset", func(t *testing.T) {
+		s, stages, m := setup(t)
+		s.expect(parent, attrs, origin)
+		s.Step(context.Background())
+		require.Zero(t, stages.resets)
+		require.Zero(t, m.matches)
+	})
+
+	t.Run("match", func(t *testing.T) {
+		s, stages, m := setup(t)
+		s.Reset(origin, eth.SystemConfig{})
+		stages.attrs[parent.Hash] = &eth.PayloadAttributes{Timestamp: 20, NoTxPool: true, Transactions: []eth.Data{{0x7e, 0x01}}}
+		s.expect(parent, attrs, origin)
+		s.Step(context.Background())
+		require.Equal(t, 1, stages.resets)
+		require.Equal(t, 1, m.matches)
+		require.Empty(t, m.divergences)
+		require.Empty(t, s.expected)
+	})
+
+	t.Run("waits for the shadow", func(t *testing.T) {
+		s, stages, m := setup(t)
+		s.Reset(origin, eth.SystemConfig{})
+		s.expect(parent, attrs, origin)
+		s.Step(context.Background())
+		require.Len(t, s.expected, 1)
+
+		stages.attrs[parent.Hash] = attrs
+		s.Step(context.Background())
+		require.Equal(t, 1, m.matches)
+	})
+
+	t.Run("attributes divergence", func(t *testing.T) {
+		s, stages, m := setup(t)
+		s.Reset(origin, eth.SystemConfig{})
+		stages.attrs[parent.Hash] = &eth.PayloadAttributes{Timestamp: 20, NoTxPool: true, Transactions: []eth.Data{{0x7e, 0x02}}}
+		s.expect(parent, attrs, origin)
+		s.Step(context.Background())
+		require.Zero(t, m.matches)
+		require.Equal(t, 1, m.divergences[ShadowDivergenceAttributes])
+	})
+
+	t.Run("origin divergence", func(t *testing.T) {
+		s, stages, m := setup(t)
+		s.Reset(origin, eth.SystemConfig{})
+		stages.attrs[parent.Hash] = attrs
+		stages.origin = eth.L1BlockRef{Hash: common.Hash{0xb}, Number: 101}
+		s.expect(parent, attrs, origin)
+		s.Step(context.Background())
+		require.Zero(t, m.matches)
+		require.Equal(t, 1, m.divergences[ShadowDivergenceOrigin])
+	})
+
+	t.Run("error restarts the shadow", func(t *testing.T) {
+		s, stages, m := setup(t)
+		s.Reset(origin, eth.SystemConfig{})
+		stages.err = NewResetError(errors.New("reorg"))
+		s.expect(parent, attrs, origin)
+		s.Step(context.Background())
+		require.Equal(t, 1, m.divergences[ShadowDivergenceError])
+		require.Empty(t, s.expected)
+		require.Zero(t, s.resetting)
+
+		stages.err = nil
+		s.Step(context.Background())
+		require.Equal(t, 2, stages.resets)
+	})
+
+	t.Run("lag", func(t *testing.T) {
+		s, _, m := setup(t)
+		s.Reset(origin, eth.SystemConfig{})
+		for i := 0; i <= maxShadowExpectations; i++ {
+			s.expect(parent, attrs, origin)
+		}
+		require.Len(t, s.expected, maxShadowExpectations)
+		require.Equal(t, 1, m.divergences[ShadowDivergenceLag])
+	})
+}
+
+func TestDiffAttributes(t *testing.T) {
+	gasLimit := eth.Uint64Quantity(30_000_000)
+	a := &eth.PayloadAttributes{Timestamp: 1, GasLimit: &gasLimit, Transactions: []eth.Data{{0x01}}}
+	require.Empty(t, diffAttributes(a, &eth.PayloadAttributes{Timestamp: 1, GasLimit: &gasLimit, Transactions: []eth.Data{{0x01}}}))
+	require.Equal(t, "timestamp 1 != 2", diffAttributes(a, &eth.PayloadAttributes{Timestamp: 2}))
+	require.Contains(t, diffAttributes(a, &eth.PayloadAttributes{Timestamp: 1}), "gas limit")
+	require.Equal(t, "transaction 0 differs", diffAttributes(a, &eth.PayloadAttributes{Timestamp: 1, GasLimit: &gasLimit, Transactions: []eth.Data{{0x02}}}))
+}
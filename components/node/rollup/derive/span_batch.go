@@ -0,0 +1,342 @@
+package derive
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/kroma-network/kroma/components/node/eth"
+	"github.com/kroma-network/kroma/components/node/rollup"
+)
+
+// Span batch format
+//
+// SpanBatchType := 1
+// spanBatch := SpanBatchType ++ prefix ++ payload
+// prefix := rel_timestamp ++ l1_origin_num ++ parent_check ++ l1_origin_check
+// payload := block_count ++ origin_bits ++ block_tx_counts ++ txs
+// txs := (tx_len ++ tx)*
+//
+// The numbers are encoded as unsigned varints, the checks are the first 20 bytes of the hashes, and the origin bits
+// are packed in little-endian bit order, the bit i-1 telling whether the block i adopts the next L1 origin.
+//
+// A span batch amortizes the parent hash, the epoch and the timestamp of the singular batches over all its blocks:
+// the timestamps and the origins of the blocks are implied by the ones of the first and the last block.
+
+const SpanBatchType = 1
+
+// spanBatchChecksLen is the length of the parent check and the L1 origin check of a span batch.
+const spanBatchChecksLen = 20
+
+type SpanBatch struct {
+	// RelTimestamp is the timestamp of the first block, relative to the L2 genesis time.
+	RelTimestamp uint64
+	// L1OriginNum is the number of the L1 origin of the last block.
+	L1OriginNum uint64
+	// ParentCheck is the first 20 bytes of the parent hash of the first block.
+	ParentCheck [spanBatchChecksLen]byte
+	// L1OriginCheck is the first 20 bytes of the hash of the L1 origin of the last block.
+	L1OriginCheck [spanBatchChecksLen]byte
+	Blocks        []SpanBatchBlock
+}
+
+type SpanBatchBlock struct {
+	// OriginAdvanced is whether the block adopts the next L1 origin of the previous block of the span batch. It is
+	// ignored for the first block.
+	OriginAdvanced bool
+	Transactions   []hexutil.Bytes
+}
+
+// Timestamp returns the timestamp of the i-th block of the span batch.
+func (b *SpanBatch) Timestamp(cfg *rollup.Config, i int) uint64 {
+	return cfg.Genesis.L2Time + b.RelTimestamp + uint64(i)*cfg.BlockTime
+}
+
+// EpochNum returns the number of the L1 origin of the i-th block of the span batch.
+func (b *SpanBatch) EpochNum(i int) uint64 {
+	num := b.L1OriginNum
+	for j := len(b.Blocks) - 1; j > i; j-- {
+		if b.Blocks[j].OriginAdvanced {
+			num--
+		}
+	}
+	return num
+}
+
+// Batches expands the blocks of the span batch from the index from to singular batches, taking the epoch hashes from
+// the L1 blocks. The blocks before from, e.g. already in the safe chain, are skipped. The parent hashes are left
+// empty, as only the parent of the first block is known.
+func (b *SpanBatch) Batches(cfg *rollup.Config, l1Blocks []eth.L1BlockRef, from int) ([]*BatchData, error) {
+	if len(l1Blocks) == 0 {
+		return nil, errors.New("cannot expand span batch without L1 blocks")
+	}
+	if from < 0 || from >= len(b.Blocks) {
+		return nil, fmt.Errorf("cannot expand span batch of %d blocks from block %d", len(b.Blocks), from)
+	}
+	batches := make([]*BatchData, 0, len(b.Blocks)-from)
+	epochNum := b.EpochNum(0)
+	for i, block := range b.Blocks {
+		if i > 0 && block.OriginAdvanced {
+			epochNum++
+		}
+		if i < from {
+			continue
+		}
+		if epochNum < l1Blocks[0].Number || epochNum-l1Blocks[0].Number >= uint64(len(l1Blocks)) {
+			return nil, fmt.Errorf("L1 origin %d of block %d of span batch is not in the L1 blocks %s - %s",
+				epochNum, i, l1Blocks[0].ID(), l1Blocks[len(l1Blocks)-1].ID())
+		}
+		epoch := l1Blocks[epochNum-l1Blocks[0].Number]
+		batches = append(batches, &BatchData{
+			BatchV1: BatchV1{
+				EpochNum:     rollup.Epoch(epoch.Number),
+				EpochHash:    epoch.Hash,
+				Timestamp:    b.Timestamp(cfg, i),
+				Transactions: block.Transactions,
+			},
+		})
+	}
+	return batches, nil
+}
+
+func (b *SpanBatch) encode(buf *bytes.Buffer) error {
+	if len(b.Blocks) == 0 {
+		return errors.New("span batch has no blocks")
+	}
+	writeUvarint(buf, b.RelTimestamp)
+	writeUvarint(buf, b.L1OriginNum)
+	buf.Write(b.ParentCheck[:])
+	buf.Write(b.L1OriginCheck[:])
+
+	writeUvarint(buf, uint64(len(b.Blocks)))
+	bits := make([]byte, originBitsLen(len(b.Blocks)))
+	for i := 1; i < len(b.Blocks); i++ {
+		if b.Blocks[i].OriginAdvanced {
+			bits[(i-1)/8] |= 1 << ((i - 1) % 8)
+		}
+	}
+	buf.Write(bits)
+	for _, block := range b.Blocks {
+		writeUvarint(buf, uint64(len(block.Transactions)))
+	}
+	for _, block := range b.Blocks {
+		for _, tx := range block.Transactions {
+			writeUvarint(buf, uint64(len(tx)))
+			buf.Write(tx)
+		}
+	}
+	return nil
+}
+
+func (b *SpanBatch) decode(data []byte) error {
+	r := bytes.NewReader(data)
+	var err error
+	if b.RelTimestamp, err = readUvarint(r); err != nil {
+		return fmt.Errorf("failed to read span batch timestamp: %w", err)
+	}
+	if b.L1OriginNum, err = readUvarint(r); err != nil {
+		return fmt.Errorf("failed to read span batch L1 origin number: %w", err)
+	}
+	if _, err := io.ReadFull(r, b.ParentCheck[:]); err != nil {
+		return fmt.Errorf("failed to read span batch parent check: %w", err)
+	}
+	if _, err := io.ReadFull(r, b.L1OriginCheck[:]); err != nil {
+		return fmt.Errorf("failed to read span batch L1 origin check: %w", err)
+	}
+
+	blockCount, err := readUvarint(r)
+	if err != nil {
+		return fmt.Errorf("failed to read span batch block count: %w", err)
+	}
+	// every block takes at least the byte of its transaction count
+	if blockCount == 0 || blockCount > uint64(r.Len()) {
+		return fmt.Errorf("invalid span batch block count %d", blockCount)
+	}
+	b.Blocks = make([]SpanBatchBlock, blockCount)
+
+	bits := make([]byte, originBitsLen(int(blockCount)))
+	if _, err := io.ReadFull(r, bits); err != nil {
+		return fmt.Errorf("failed to read span batch origin bits: %w", err)
+	}
+	advanced := uint64(0)
+	for i := 1; i < len(b.Blocks); i++ {
+		if bits[(i-1)/8]&(1<<((i-1)%8)) != 0 {
+			b.Blocks[i].OriginAdvanced = true
+			advanced++
+		}
+	}
+	if padding := (blockCount - 1) % 8; padding != 0 && bits[len(bits)-1]>>padding != 0 {
+		return errors.New("span batch origin bits have non-zero padding")
+	}
+	if advanced > b.L1OriginNum {
+		return fmt.Errorf("span batch advances the L1 origin %d times up to L1 origin %d", advanced, b.L1OriginNum)
+	}
+
+	txCounts := make([]uint64, blockCount)
+	totalTxs := uint64(0)
+	for i := range txCounts {
+		if txCounts[i], err = readUvarint(r); err != nil {
+			return fmt.Errorf("failed to read span batch transaction count of block %d: %w", i, err)
+		}
+		// every transaction takes at least the byte of its length
+		if totalTxs += txCounts[i]; totalTxs > uint64(r.Len()) {
+			return fmt.Errorf("invalid span batch transaction count %d of block %d", txCounts[i], i)
+		}
+	}
+	for i, txCount := range txCounts {
+		if txCount == 0 {
+			continue
+		}
+		txs := make([]hexutil.Bytes, txCount)
+		for j := range txs {
+			txLen, err := readUvarint(r)
+			if err != nil {
+				return fmt.Errorf("failed to read span batch transaction length: %w", err)
+			}
+			if txLen > uint64(r.Len()) {
+				return fmt.Errorf("span batch transaction %d of block %d is longer than the remaining data: %d", j, i, txLen)
+			}
+			txs[j] = make(hexutil.Bytes, txLen)
+			if _, err := io.ReadFull(r, txs[j]); err != nil {
+				return fmt.Errorf("failed to read span batch transaction: %w", err)
+			}
+		}
+		b.Blocks[i].Transactions = txs
+	}
+	if r.Len() != 0 {
+		return fmt.Errorf("span batch has %d trailing bytes", r.Len())
+	}
+	return nil
+}
+
+// originBitsLen returns the length of the origin bits of a span batch of the given number of blocks.
+func originBitsLen(blockCount int) int {
+	return (blockCount - 1 + 7) / 8
+}
+
+// readUvarint reads an unsigned varint, rejecting the encodings that are not the shortest one of the number, so that
+// the encoding of a span batch is unique.
+func readUvarint(r *bytes.Reader) (uint64, error) {
+	n := r.Len()
+	v, err := binary.ReadUvarint(r)
+	if err != nil {
+		return 0, err
+	}
+	if n-r.Len() != uvarintLen(v) {
+		return 0, fmt.Errorf("non-canonical varint encoding of %d", v)
+	}
+	return v, nil
+}
+
+func writeUvarint(buf *bytes.Buffer, v uint64) {
+	var tmp [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(tmp[:], v)
+	buf.Write(tmp[:n])
+}
+
+func uvarintLen(v uint64) int {
+	n := 1
+	for ; v >= 0x80; v >>= 7 {
+		n++
+	}
+	return n
+}
+
+// spanBatchBuilder builds a span batch from the singular batches of consecutive L2 blocks, tracking the size of its
+// encoding as the batches are appended.
+type spanBatchBuilder struct {
+	cfg  *rollup.Config
+	span *SpanBatch
+	// last is the last appended batch.
+	last *BatchData
+	// blocksSize is the size of the transaction counts and the transactions of the appended batches.
+	blocksSize int
+	// rlpSize is the size of the RLP encoding of the span batch, 0 if no batch was appended.
+	rlpSize int
+}
+
+func newSpanBatchBuilder(cfg *rollup.Config) *spanBatchBuilder {
+	return &spanBatchBuilder{cfg: cfg}
+}
+
+func (s *spanBatchBuilder) reset() {
+	s.span = nil
+	s.last = nil
+	s.blocksSize = 0
+	s.rlpSize = 0
+}
+
+// check returns an error if the batch cannot be appended to the span batch, because it does not follow the last
+// appended batch.
+func (s *spanBatchBuilder) check(batch *BatchData) error {
+	if batch.Timestamp < s.cfg.Genesis.L2Time {
+		return fmt.Errorf("batch timestamp %d is before the L2 genesis time %d", batch.Timestamp, s.cfg.Genesis.L2Time)
+	}
+	if s.last == nil {
+		return nil
+	}
+	if batch.Timestamp != s.last.Timestamp+s.cfg.BlockTime {
+		return fmt.Errorf("batch timestamp %d does not follow the last timestamp %d of the span batch", batch.Timestamp, s.last.Timestamp)
+	}
+	if batch.EpochNum != s.last.EpochNum && batch.EpochNum != s.last.EpochNum+1 {
+		return fmt.Errorf("batch epoch %d does not follow the last epoch %d of the span batch", batch.EpochNum, s.last.EpochNum)
+	}
+	return nil
+}
+
+// append appends the batch to the span batch. The batch must have been checked.
+func (s *spanBatchBuilder) append(batch *BatchData) {
+	s.rlpSize = s.rlpSizeWith(batch)
+	block := SpanBatchBlock{Transactions: batch.Transactions}
+	if s.span == nil {
+		s.span = &SpanBatch{RelTimestamp: batch.Timestamp - s.cfg.Genesis.L2Time}
+		copy(s.span.ParentCheck[:], batch.ParentHash[:spanBatchChecksLen])
+	} else {
+		block.OriginAdvanced = batch.EpochNum != s.last.EpochNum
+	}
+	s.span.L1OriginNum = uint64(batch.EpochNum)
+	copy(s.span.L1OriginCheck[:], batch.EpochHash[:spanBatchChecksLen])
+	s.span.Blocks = append(s.span.Blocks, block)
+	s.blocksSize += blockSize(batch)
+	s.last = batch
+}
+
+// rlpSizeWith returns the size of the RLP encoding of the span batch once the batch is appended.
+func (s *spanBatchBuilder) rlpSizeWith(batch *BatchData) int {
+	relTimestamp := batch.Timestamp - s.cfg.Genesis.L2Time
+	blockCount := 1
+	if s.span != nil {
+		relTimestamp = s.span.RelTimestamp
+		blockCount += len(s.span.Blocks)
+	}
+	size := 1 + // batch type
+		uvarintLen(relTimestamp) + uvarintLen(uint64(batch.EpochNum)) + 2*spanBatchChecksLen +
+		uvarintLen(uint64(blockCount)) + originBitsLen(blockCount) +
+		s.blocksSize + blockSize(batch)
+	return rlpStringSize(size)
+}
+
+// blockSize returns the size of the transaction count and the transactions of the batch in a span batch.
+func blockSize(batch *BatchData) int {
+	size := uvarintLen(uint64(len(batch.Transactions)))
+	for _, tx := range batch.Transactions {
+		size += uvarintLen(uint64(len(tx))) + len(tx)
+	}
+	return size
+}
+
+// rlpStringSize returns the size of the RLP encoding of a string of the given size, with more than one byte.
+func rlpStringSize(size int) int {
+	if size < 56 {
+		return 1 + size
+	}
+	header := 1
+	for n := size; n > 0; n >>= 8 {
+		header++
+	}
+	return header + size
+}
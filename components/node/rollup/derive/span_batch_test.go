@@ -0,0 +1,357 @@
+package derive
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kroma-network/kroma/components/node/eth"
+	"github.com/kroma-network/kroma/components/node/rollup"
+	"github.com/kroma-network/kroma/components/node/testlog"
+	"github.com/kroma-network/kroma/components/node/testutils"
+)
+
+func spanBatchConfig() *rollup.Config {
+	return &rollup.Config{
+		Genesis: rollup.Genesis{
+			L2Time: 10,
+		},
+		BlockTime:          2,
+		MaxProposerDrift:   6,
+		ProposerWindowSize: 4,
+		SpanBatchTime:      new(uint64),
+	}
+}
+
+// spanBatch batches the singular batches of consecutive blocks in a span batch.
+func spanBatch(t *testing.T, cfg *rollup.Config, batches ...*BatchData) *BatchData {
+	s := newSpanBatchBuilder(cfg)
+	for _, batch := range batches {
+		require.NoError(t, s.check(batch))
+		s.append(batch)
+	}
+	return &BatchData{SpanBatch: s.span}
+}
+
+func emptyBatch(timestamp uint64, epoch eth.L1BlockRef) *BatchData {
+	batch := b(timestamp, epoch)
+	batch.Transactions = nil
+	return batch
+}
+
+func TestSpanBatchRoundTrip(t *testing.T) {
+	cfg := spanBatchConfig()
+	l1 := L1Chain([]uint64{10, 16, 30})
+	var batches []*BatchData
+	for timestamp := uint64(12); timestamp < 40; timestamp += 2 {
+		epoch := l1[0]
+		if timestamp >= 18 {
+			epoch = l1[1]
+		}
+		if timestamp >= 30 {
+			epoch = l1[2]
+		}
+		if timestamp%6 == 0 {
+			batches = append(batches, emptyBatch(timestamp, epoch))
+		} else {
+			batches = append(batches, b(timestamp, epoch))
+		}
+	}
+
+	s := newSpanBatchBuilder(cfg)
+	for _, batch := range batches {
+		require.NoError(t, s.check(batch))
+		size := s.rlpSizeWith(batch)
+		s.append(batch)
+		require.Equal(t, size, s.rlpSize)
+
+		encoded, err := rlp.EncodeToBytes(&BatchData{SpanBatch: s.span})
+		require.NoError(t, err)
+		require.Len(t, encoded, s.rlpSize, "tracked size must match the encoding")
+	}
+	span := s.span
+	require.Equal(t, uint64(2), span.RelTimestamp)
+	require.Equal(t, l1[2].Number, span.L1OriginNum)
+	require.Equal(t, l1[0].Number, span.EpochNum(0))
+	require.Equal(t, uint64(38), span.Timestamp(cfg, len(batches)-1))
+
+	data, err := (&BatchData{SpanBatch: span}).MarshalBinary()
+	require.NoError(t, err)
+	require.Equal(t, byte(SpanBatchType), data[0])
+	var decoded BatchData
+	require.NoError(t, decoded.UnmarshalBinary(data))
+	require.Equal(t, span, decoded.SpanBatch)
+
+	expanded, err := decoded.SpanBatch.Batches(cfg, l1, 0)
+	require.NoError(t, err)
+	require.Len(t, expanded, len(batches))
+	for i, batch := range expanded {
+		require.Equal(t, common.Hash{}, batch.ParentHash)
+		batch.ParentHash = batches[i].ParentHash
+		require.Equal(t, batches[i], batch)
+	}
+
+	_, err = decoded.SpanBatch.Batches(cfg, l1[1:], 0)
+	require.Error(t, err, "L1 origin of the first block is missing")
+}
+
+func TestSpanBatchDecodeInvalid(t *testing.T) {
+	cfg := spanBatchConfig()
+	l1 := L1Chain([]uint64{10, 16})
+	data, err := spanBatch(t, cfg, b(12, l1[0]), b(14, l1[0]), b(16, l1[1])).MarshalBinary()
+	require.NoError(t, err)
+	// type, timestamp, origin number and checks
+	prefixLen := 1 + 1 + 1 + 2*spanBatchChecksLen
+
+	var batch BatchData
+	require.NoError(t, batch.UnmarshalBinary(data))
+	require.Error(t, batch.UnmarshalBinary(data[:len(data)-1]), "truncated")
+	require.Error(t, batch.UnmarshalBinary(append(data, 0x00)), "trailing data")
+	require.Error(t, batch.UnmarshalBinary(data[:prefixLen]), "missing block count")
+
+	invalid := append([]byte(nil), data...)
+	invalid[prefixLen] = 0x00
+	require.Error(t, batch.UnmarshalBinary(invalid), "no blocks")
+
+	invalid = append([]byte(nil), data...)
+	invalid[prefixLen+1] |= 0x80
+	require.Error(t, batch.UnmarshalBinary(invalid), "origin bits padding")
+
+	invalid = append([]byte(nil), data...)
+	invalid[2] = 0x00
+	invalid[prefixLen+1] = 0x03
+	require.Error(t, batch.UnmarshalBinary(invalid), "origin advanced before the first L1 block")
+
+	invalid = append([]byte(nil), data...)
+	invalid[prefixLen+2] = 0x7f
+	require.Error(t, batch.UnmarshalBinary(invalid), "too many transactions")
+}
+
+func TestSpanBatchDecodeNonCanonicalVarint(t *testing.T) {
+	cfg := spanBatchConfig()
+	l1 := L1Chain([]uint64{10, 16})
+	data, err := spanBatch(t, cfg, b(12, l1[0]), b(14, l1[0]), b(16, l1[1])).MarshalBinary()
+	require.NoError(t, err)
+
+	// the relative timestamp, padded with a zero continuation byte
+	padded := append([]byte{data[0], data[1] | 0x80, 0x00}, data[2:]...)
+	var batch BatchData
+	require.ErrorContains(t, batch.UnmarshalBinary(padded), "non-canonical varint")
+	require.NoError(t, batch.UnmarshalBinary(data))
+}
+
+func TestSpanBatchBuilderCheck(t *testing.T) {
+	cfg := spanBatchConfig()
+	l1 := L1Chain([]uint64{10, 16, 30})
+	s := newSpanBatchBuilder(cfg)
+	require.Error(t, s.check(b(8, l1[0])), "before genesis")
+	require.NoError(t, s.check(b(12, l1[0])))
+	s.append(b(12, l1[0]))
+	require.Error(t, s.check(b(12, l1[0])), "same timestamp")
+	require.Error(t, s.check(b(16, l1[0])), "missing block")
+	require.Error(t, s.check(b(14, l1[2])), "skipped L1 origin")
+	require.NoError(t, s.check(b(14, l1[1])))
+}
+
+func TestSpanChannelOutRoundTrip(t *testing.T) {
+	cfg := spanBatchConfig()
+	l1 := L1Chain([]uint64{10, 16})
+	batches := []*BatchData{b(12, l1[0]), emptyBatch(14, l1[0]), b(16, l1[1])}
+
+	co, err := NewSpanChannelOut(CompressionConfig{}, cfg)
+	require.NoError(t, err)
+	for i := 0; i < 2; i++ {
+		for _, batch := range batches {
+			_, err := co.AddBatch(batch)
+			require.NoError(t, err)
+		}
+		require.Equal(t, co.span.rlpSize, co.InputBytes())
+		require.NoError(t, co.Close())
+
+		next, err := BatchReader(bytes.NewReader(co.buf.Bytes()), eth.L1BlockRef{}, true)
+		require.NoError(t, err)
+		decoded, err := next()
+		require.NoError(t, err)
+		require.Equal(t, spanBatch(t, cfg, batches...), decoded.Batch)
+
+		require.NoError(t, co.Reset())
+		require.Zero(t, co.InputBytes())
+	}
+
+	_, err = co.AddBatch(b(12, l1[0]))
+	require.NoError(t, err)
+	_, err = co.AddBatch(b(16, l1[0]))
+	require.Error(t, err, "batches of a span batch must be consecutive")
+	_, err = co.AddBatch(&BatchData{BatchV1: BatchV1{Timestamp: 14, Transactions: []hexutil.Bytes{make([]byte, MaxRLPBytesPerChannel)}}})
+	require.ErrorIs(t, err, ErrTooManyRLPBytes)
+}
+
+func TestCheckSpanBatch(t *testing.T) {
+	cfg := spanBatchConfig()
+	l1 := L1Chain([]uint64{10, 16, 30, 40})
+	safeHead := eth.L2BlockRef{
+		Hash:     mockHash(10, 2),
+		Number:   0,
+		Time:     10,
+		L1Origin: l1[0].ID(),
+	}
+	otherL1B := l1[1]
+	otherL1B.Hash = common.Hash{0xff}
+	deposit := b(18, l1[1])
+	deposit.Transactions = []hexutil.Bytes{{types.DepositTxType, 0x01}}
+	emptyTx := b(18, l1[1])
+	emptyTx.Transactions = []hexutil.Bytes{{}}
+
+	valid := spanBatch(t, cfg, b(12, l1[0]), b(14, l1[0]), b(16, l1[0]), b(18, l1[1]), b(20, l1[1]))
+
+	notActivated := spanBatchConfig()
+	activation := uint64(14)
+	notActivated.SpanBatchTime = &activation
+
+	testCases := []struct {
+		name       string
+		cfg        *rollup.Config
+		l1Blocks   []eth.L1BlockRef
+		l2SafeHead eth.L2BlockRef
+		inclusion  eth.L1BlockRef
+		batch      *BatchData
+		expected   BatchValidity
+	}{
+		{"valid", cfg, l1[:3], safeHead, l1[2], valid, BatchAccept},
+		{"missing L1 info", cfg, nil, safeHead, l1[2], valid, BatchUndecided},
+		{"not activated", notActivated, l1[:3], safeHead, l1[2], valid, BatchDrop},
+		{"future timestamp", cfg, l1[:3], eth.L2BlockRef{Hash: safeHead.Hash, Time: 8, L1Origin: l1[0].ID()}, l1[2], valid, BatchFuture},
+		{"old timestamp", cfg, l1[:3], eth.L2BlockRef{Hash: safeHead.Hash, Time: 12, L1Origin: l1[0].ID()}, l1[2], valid, BatchDrop},
+		{"mismatching parent", cfg, l1[:3], eth.L2BlockRef{Hash: common.Hash{0x01}, Time: 10, L1Origin: l1[0].ID()}, l1[2], valid, BatchDrop},
+		{"included too late", cfg, l1[:3], safeHead, eth.L1BlockRef{Number: 5}, valid, BatchDrop},
+		{"epoch too old", cfg, l1[1:3], safeHead, l1[2], valid, BatchDrop},
+		{"epoch too far ahead", cfg, l1[:4], safeHead, l1[3], spanBatch(t, cfg, b(12, l1[2])), BatchDrop},
+		{"missing next L1 block", cfg, l1[:1], safeHead, l1[0], valid, BatchUndecided},
+		{"different L1 chain", cfg, l1[:3], safeHead, l1[2], spanBatch(t, cfg, b(12, l1[0]), b(14, l1[0]), b(16, l1[0]), b(18, otherL1B)), BatchDrop},
+		{"timestamp before L1 origin", cfg, l1[:3], safeHead, l1[2], spanBatch(t, cfg, b(12, l1[0]), b(14, l1[1])), BatchDrop},
+		{"exceeded time drift", cfg, l1[:3], safeHead, l1[2], spanBatch(t, cfg, b(12, l1[0]), b(14, l1[0]), b(16, l1[0]), b(18, l1[0])), BatchDrop},
+		{"empty block exceeded time drift when next origin is valid", cfg, l1[:3], safeHead, l1[2],
+			spanBatch(t, cfg, b(12, l1[0]), b(14, l1[0]), b(16, l1[0]), emptyBatch(18, l1[0])), BatchDrop},
+		{"empty block exceeded time drift without next origin", cfg, l1[:1], safeHead, l1[0],
+			spanBatch(t, cfg, b(12, l1[0]), b(14, l1[0]), b(16, l1[0]), emptyBatch(18, l1[0])), BatchUndecided},
+		{"deposit tx", cfg, l1[:3], safeHead, l1[2], spanBatch(t, cfg, b(12, l1[0]), b(14, l1[0]), b(16, l1[0]), deposit), BatchDrop},
+		{"empty tx", cfg, l1[:3], safeHead, l1[2], spanBatch(t, cfg, b(12, l1[0]), b(14, l1[0]), b(16, l1[0]), emptyTx), BatchDrop},
+	}
+
+	logger := testlog.Logger(t, log.LvlError)
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			batch := &BatchWithL1InclusionBlock{L1InclusionBlock: testCase.inclusion, Batch: testCase.batch}
+			validity := CheckBatch(context.Background(), testCase.cfg, logger, testCase.l1Blocks, testCase.l2SafeHead, batch, nil)
+			require.Equal(t, testCase.expected, validity, "batch check must return expected validity level")
+		})
+	}
+}
+
+// fakeSafeChain serves the payloads of the L2 genesis block and of the blocks of the batches, in order.
+type fakeSafeChain struct {
+	payloads []*eth.ExecutionPayload
+	err      error
+}
+
+func newFakeSafeChain(t *testing.T, cfg *rollup.Config, batches ...*BatchData) *fakeSafeChain {
+	chain := &fakeSafeChain{payloads: []*eth.ExecutionPayload{{
+		BlockHash: cfg.Genesis.L2.Hash,
+		Timestamp: eth.Uint64Quantity(cfg.Genesis.L2Time),
+	}}}
+	for i, batch := range batches {
+		l1Info, err := L1InfoDepositBytes(0, &testutils.MockBlockInfo{
+			InfoHash:    batch.EpochHash,
+			InfoNum:     uint64(batch.EpochNum),
+			InfoBaseFee: big.NewInt(1),
+		}, eth.SystemConfig{})
+		require.NoError(t, err)
+		chain.payloads = append(chain.payloads, &eth.ExecutionPayload{
+			ParentHash:   batch.ParentHash,
+			BlockHash:    mockHash(batch.Timestamp, 2),
+			BlockNumber:  eth.Uint64Quantity(i + 1),
+			Timestamp:    eth.Uint64Quantity(batch.Timestamp),
+			Transactions: append([]eth.Data{l1Info}, batch.Transactions...),
+		})
+	}
+	return chain
+}
+
+func (c *fakeSafeChain) PayloadByNumber(_ context.Context, number uint64) (*eth.ExecutionPayload, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	if number >= uint64(len(c.payloads)) {
+		return nil, ethereum.NotFound
+	}
+	return c.payloads[number], nil
+}
+
+func TestCheckSpanBatchOverlap(t *testing.T) {
+	l1 := L1Chain([]uint64{10, 16, 30, 40})
+	cfg := spanBatchConfig()
+	cfg.Genesis.L1 = l1[0].ID()
+	cfg.Genesis.L2 = eth.BlockID{Hash: mockHash(10, 2)}
+
+	batches := []*BatchData{b(12, l1[0]), b(14, l1[0]), b(16, l1[0]), b(18, l1[1]), b(20, l1[1])}
+	span := spanBatch(t, cfg, batches...)
+	safeHead := func(number int) eth.L2BlockRef {
+		batch := batches[number-1]
+		return eth.L2BlockRef{
+			Hash:     mockHash(batch.Timestamp, 2),
+			Number:   uint64(number),
+			Time:     batch.Timestamp,
+			L1Origin: batch.Epoch(),
+		}
+	}
+	otherTx := b(14, l1[0])
+	otherTx.Transactions = []hexutil.Bytes{{0x01}}
+	otherParent := b(12, l1[0])
+	otherParent.ParentHash = common.Hash{0x01}
+
+	testCases := []struct {
+		name       string
+		l2SafeHead eth.L2BlockRef
+		batch      *BatchData
+		safeChain  *fakeSafeChain
+		expected   BatchValidity
+	}{
+		{"overlapping the safe chain", safeHead(3), span, newFakeSafeChain(t, cfg, batches[:3]...), BatchAccept},
+		{"overlapping a single block", safeHead(1), span, newFakeSafeChain(t, cfg, batches[:1]...), BatchAccept},
+		{"overlapping the whole span batch", safeHead(3), spanBatch(t, cfg, batches[:3]...), newFakeSafeChain(t, cfg, batches[:3]...), BatchDrop},
+		{"overlapping before the genesis", eth.L2BlockRef{Hash: mockHash(16, 2), Number: 1, Time: 16, L1Origin: l1[0].ID()}, span,
+			newFakeSafeChain(t, cfg, batches[:3]...), BatchDrop},
+		{"different parent", safeHead(3), spanBatch(t, cfg, otherParent, batches[1], batches[2], batches[3]),
+			newFakeSafeChain(t, cfg, batches[:3]...), BatchDrop},
+		{"different transaction", safeHead(3), span, newFakeSafeChain(t, cfg, batches[0], otherTx, batches[2]), BatchDrop},
+		{"different transaction count", safeHead(3), span, newFakeSafeChain(t, cfg, batches[0], emptyBatch(14, l1[0]), batches[2]), BatchDrop},
+		{"different L1 origin", safeHead(3), span, newFakeSafeChain(t, cfg, batches[0], batches[1], b(16, l1[1])), BatchDrop},
+		{"safe chain unavailable", safeHead(3), span, &fakeSafeChain{err: errors.New("rpc failure")}, BatchUndecided},
+		{"safe block missing", safeHead(3), span, newFakeSafeChain(t, cfg, batches[:1]...), BatchUndecided},
+	}
+
+	logger := testlog.Logger(t, log.LvlError)
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			batch := &BatchWithL1InclusionBlock{L1InclusionBlock: l1[2], Batch: testCase.batch}
+			validity := CheckBatch(context.Background(), cfg, logger, l1[:3], testCase.l2SafeHead, batch, testCase.safeChain)
+			require.Equal(t, testCase.expected, validity, "batch check must return expected validity level")
+		})
+	}
+
+	batch := &BatchWithL1InclusionBlock{L1InclusionBlock: l1[2], Batch: span}
+	require.Equal(t, BatchValidity(BatchDrop), CheckBatch(context.Background(), cfg, logger, l1[:3], safeHead(3), batch, nil),
+		"an overlapping span batch cannot be checked without the safe chain")
+}
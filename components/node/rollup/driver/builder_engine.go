@@ -0,0 +1,177 @@
+package driver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/kroma-network/kroma/components/node/eth"
+)
+
+type BuilderMetrics interface {
+	RecordProposerBuilderPayload()
+	RecordProposerBuilderFallback()
+}
+
+// Builder is an external block builder that builds proposed blocks in parallel to the engine,
+// over the engine API, e.g. to experiment with MEV-aware block production.
+type Builder interface {
+	ForkchoiceUpdate(ctx context.Context, fc *eth.ForkchoiceState, attributes *eth.PayloadAttributes) (*eth.ForkchoiceUpdatedResult, error)
+	GetPayload(ctx context.Context, payloadId eth.PayloadID) (*eth.ExecutionPayload, error)
+}
+
+// builderTimeout bounds every call to the Builder, the payload of the engine is used if the builder does not respond in time.
+const builderTimeout = 200 * time.Millisecond
+
+// BuilderEngine wraps the L2 engine, and forwards the building of proposed blocks to a Builder as well.
+// The payload of the builder is used if it is valid, the payload of the engine otherwise.
+// Derived blocks, which never include transactions of the tx pool, are built by the engine only.
+type BuilderEngine struct {
+	L2Chain
+
+	builder Builder
+	log     log.Logger
+	metrics BuilderMetrics
+
+	mu sync.Mutex
+	// job is the latest block building job forwarded to the builder, nil if none.
+	job *builderJob
+}
+
+type builderJob struct {
+	// id is the payload ID of the job of the engine.
+	id        eth.PayloadID
+	builderID eth.PayloadID
+}
+
+var _ L2Chain = (*BuilderEngine)(nil)
+
+func NewBuilderEngine(l2 L2Chain, builder Builder, log log.Logger, metrics BuilderMetrics) *BuilderEngine {
+	return &BuilderEngine{
+		L2Chain: l2,
+		builder: builder,
+		log:     log,
+		metrics: metrics,
+	}
+}
+
+func (e *BuilderEngine) ForkchoiceUpdate(ctx context.Context, fc *eth.ForkchoiceState, attributes *eth.PayloadAttributes) (*eth.ForkchoiceUpdatedResult, error) {
+	res, err := e.L2Chain.ForkchoiceUpdate(ctx, fc, attributes)
+	if err != nil || attributes == nil || attributes.NoTxPool || res.PayloadStatus.Status != eth.ExecutionValid || res.PayloadID == nil {
+		return res, err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.job = nil
+	bCtx, cancel := context.WithTimeout(ctx, builderTimeout)
+	defer cancel()
+	bRes, err := e.builder.ForkchoiceUpdate(bCtx, fc, attributes)
+	if err == nil {
+		err = eth.ForkchoiceUpdateErr(bRes.PayloadStatus)
+	}
+	if err == nil && bRes.PayloadID == nil {
+		err = errors.New("nil payload id")
+	}
+	if err != nil {
+		// the engine keeps building the block, it will be used instead.
+		e.log.Warn("failed to start building block with the builder", "parent", fc.HeadBlockHash, "err", err)
+		return res, nil
+	}
+	e.job = &builderJob{id: *res.PayloadID, builderID: *bRes.PayloadID}
+	return res, nil
+}
+
+func (e *BuilderEngine) GetPayload(ctx context.Context, payloadId eth.PayloadID) (*eth.ExecutionPayload, error) {
+	e.mu.Lock()
+	job := e.job
+	if job != nil && job.id == payloadId {
+		e.job = nil
+	} else {
+		job = nil
+	}
+	e.mu.Unlock()
+	if job == nil {
+		return e.L2Chain.GetPayload(ctx, payloadId)
+	}
+
+	var (
+		built    *eth.ExecutionPayload
+		buildErr error
+		done     = make(chan struct{})
+	)
+	go func() {
+		defer close(done)
+		bCtx, cancel := context.WithTimeout(ctx, builderTimeout)
+		defer cancel()
+		built, buildErr = e.builder.GetPayload(bCtx, job.builderID)
+	}()
+	local, err := e.L2Chain.GetPayload(ctx, payloadId)
+	<-done
+	if err != nil {
+		return nil, err
+	}
+
+	if buildErr == nil {
+		buildErr = e.checkBuilderPayload(ctx, local, built)
+	}
+	if buildErr != nil {
+		e.log.Warn("falling back to payload of the engine", "block", local.ID(), "err", buildErr)
+		e.metrics.RecordProposerBuilderFallback()
+		return local, nil
+	}
+	e.log.Info("using payload of the builder", "block", built.ID(), "txs", len(built.Transactions), "engine_block", local.ID(), "engine_txs", len(local.Transactions))
+	e.metrics.RecordProposerBuilderPayload()
+	return built, nil
+}
+
+// checkBuilderPayload checks that the payload of the builder was built with the same attributes as the payload of the engine,
+// and that it is valid according to the engine.
+func (e *BuilderEngine) checkBuilderPayload(ctx context.Context, local, built *eth.ExecutionPayload) error {
+	if built == nil {
+		return errors.New("builder returned no payload")
+	}
+	if built.ParentHash != local.ParentHash || built.BlockNumber != local.BlockNumber || built.Timestamp != local.Timestamp ||
+		built.PrevRandao != local.PrevRandao || built.FeeRecipient != local.FeeRecipient || built.GasLimit != local.GasLimit {
+		return fmt.Errorf("builder payload %s does not match the attributes of engine payload %s", built.ID(), local.ID())
+	}
+	// the deposits are derived from L1, the builder must include exactly the same ones, first.
+	deposits := countDeposits(local.Transactions)
+	if countDeposits(built.Transactions) != deposits {
+		return fmt.Errorf("builder payload %s does not include the %d deposits", built.ID(), deposits)
+	}
+	for i := 0; i < deposits; i++ {
+		if string(built.Transactions[i]) != string(local.Transactions[i]) {
+			return fmt.Errorf("builder payload %s has a different deposit %d", built.ID(), i)
+		}
+	}
+	for i := deposits; i < len(built.Transactions); i++ {
+		if len(built.Transactions[i]) == 0 || built.Transactions[i][0] == types.DepositTxType {
+			return fmt.Errorf("builder payload %s has invalid transaction %d", built.ID(), i)
+		}
+	}
+
+	status, err := e.L2Chain.NewPayload(ctx, built)
+	if err != nil {
+		return fmt.Errorf("failed to insert builder payload %s: %w", built.ID(), err)
+	}
+	if status.Status != eth.ExecutionValid {
+		return eth.NewPayloadErr(built, status)
+	}
+	return nil
+}
+
+// countDeposits returns the number of leading deposit transactions.
+func countDeposits(txs []eth.Data) int {
+	for i, tx := range txs {
+		if len(tx) == 0 || tx[0] != types.DepositTxType {
+			return i
+		}
+	}
+	return len(txs)
+}
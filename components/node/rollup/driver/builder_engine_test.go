@@ -0,0 +1,125 @@
+package driver
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kroma-network/kroma/components/node/eth"
+	"github.com/kroma-network/kroma/components/node/metrics"
+	"github.com/kroma-network/kroma/components/node/testlog"
+	"github.com/kroma-network/kroma/components/node/testutils"
+)
+
+type fakeBuilder struct {
+	fcErr      error
+	payload    *eth.ExecutionPayload
+	payloadErr error
+	started    int
+}
+
+func (b *fakeBuilder) ForkchoiceUpdate(_ context.Context, _ *eth.ForkchoiceState, _ *eth.PayloadAttributes) (*eth.ForkchoiceUpdatedResult, error) {
+	b.started++
+	if b.fcErr != nil {
+		return nil, b.fcErr
+	}
+	return &eth.ForkchoiceUpdatedResult{
+		PayloadStatus: eth.PayloadStatusV1{Status: eth.ExecutionValid},
+		PayloadID:     &eth.PayloadID{0xb},
+	}, nil
+}
+
+func (b *fakeBuilder) GetPayload(_ context.Context, id eth.PayloadID) (*eth.ExecutionPayload, error) {
+	if id != (eth.PayloadID{0xb}) {
+		return nil, errors.New("unknown payload")
+	}
+	return b.payload, b.payloadErr
+}
+
+func TestBuilderEngine(t *testing.T) {
+	deposit := eth.Data{types.DepositTxType, 0x01}
+	fc := &eth.ForkchoiceState{HeadBlockHash: common.Hash{0x1}}
+	attrs := &eth.PayloadAttributes{Transactions: []eth.Data{deposit}}
+	localID := eth.PayloadID{0xa}
+	local := &eth.ExecutionPayload{
+		ParentHash:   fc.HeadBlockHash,
+		BlockNumber:  10,
+		BlockHash:    common.Hash{0x2},
+		Transactions: []eth.Data{deposit, {0x02, 0xaa}},
+	}
+	built := func(txs ...eth.Data) *eth.ExecutionPayload {
+		payload := *local
+		payload.BlockHash = common.Hash{0x3}
+		payload.Transactions = append([]eth.Data{deposit}, txs...)
+		return &payload
+	}
+
+	setup := func(builder *fakeBuilder, attrs *eth.PayloadAttributes) (*BuilderEngine, *testutils.MockEngine) {
+		engine := &testutils.MockEngine{}
+		engine.ExpectForkchoiceUpdate(fc, attrs, &eth.ForkchoiceUpdatedResult{
+			PayloadStatus: eth.PayloadStatusV1{Status: eth.ExecutionValid},
+			PayloadID:     &localID,
+		}, nil)
+		e := NewBuilderEngine(engine, builder, testlog.Logger(t, log.LvlCrit), metrics.NoopMetrics)
+		_, err := e.ForkchoiceUpdate(context.Background(), fc, attrs)
+		require.NoError(t, err)
+		return e, engine
+	}
+
+	t.Run("builder payload", func(t *testing.T) {
+		builder := &fakeBuilder{payload: built(eth.Data{0x02, 0xbb}, eth.Data{0x02, 0xcc})}
+		e, engine := setup(builder, attrs)
+		engine.ExpectGetPayload(localID, local, nil)
+		engine.ExpectNewPayload(builder.payload, &eth.PayloadStatusV1{Status: eth.ExecutionValid}, nil)
+
+		payload, err := e.GetPayload(context.Background(), localID)
+		require.NoError(t, err)
+		require.Equal(t, builder.payload, payload)
+		engine.AssertExpectations(t)
+	})
+
+	t.Run("invalid builder payload", func(t *testing.T) {
+		builder := &fakeBuilder{payload: built(eth.Data{0x02, 0xbb})}
+		e, engine := setup(builder, attrs)
+		engine.ExpectGetPayload(localID, local, nil)
+		engine.ExpectNewPayload(builder.payload, &eth.PayloadStatusV1{Status: eth.ExecutionInvalid}, nil)
+
+		payload, err := e.GetPayload(context.Background(), localID)
+		require.NoError(t, err)
+		require.Equal(t, local, payload)
+		engine.AssertExpectations(t)
+	})
+
+	t.Run("forged deposit", func(t *testing.T) {
+		builder := &fakeBuilder{payload: built(eth.Data{types.DepositTxType, 0x02})}
+		e, engine := setup(builder, attrs)
+		engine.ExpectGetPayload(localID, local, nil)
+
+		payload, err := e.GetPayload(context.Background(), localID)
+		require.NoError(t, err)
+		require.Equal(t, local, payload)
+		engine.AssertExpectations(t)
+	})
+
+	t.Run("builder unavailable", func(t *testing.T) {
+		builder := &fakeBuilder{fcErr: errors.New("timeout")}
+		e, engine := setup(builder, attrs)
+		engine.ExpectGetPayload(localID, local, nil)
+
+		payload, err := e.GetPayload(context.Background(), localID)
+		require.NoError(t, err)
+		require.Equal(t, local, payload)
+		require.Equal(t, 1, builder.started)
+	})
+
+	t.Run("derived block", func(t *testing.T) {
+		builder := &fakeBuilder{}
+		setup(builder, &eth.PayloadAttributes{Transactions: []eth.Data{deposit}, NoTxPool: true})
+		require.Zero(t, builder.started, "blocks without tx pool must not be built by the builder")
+	})
+}
@@ -1,5 +1,12 @@
 package driver
 
+import (
+	"errors"
+	"fmt"
+
+	"github.com/kroma-network/kroma/components/node/rollup/derive"
+)
+
 type Config struct {
 	// SyncerConfDepth is the distance to keep from the L1 head when reading L1 data for L2 derivation.
 	SyncerConfDepth uint64 `json:"syncer_conf_depth"`
@@ -20,4 +27,23 @@ type Config struct {
 	// ProposerMaxSafeLag is the maximum number of L2 blocks for restricting the distance between L2 safe and unsafe.
 	// Disabled if 0.
 	ProposerMaxSafeLag uint64 `json:"proposer_max_safe_lag"`
+
+	// ShadowPipeline is the name of the candidate derivation pipeline version, see derive.CandidatePipelines,
+	// that derives alongside the active pipeline in shadow mode. Disabled if empty.
+	ShadowPipeline string `json:"shadow_pipeline"`
+
+	// L1Only is true when the driver derives the L2 chain exclusively from L1, and rejects any unsafe L2 payload.
+	// The node then runs without P2P and alt-sync, and its unsafe head never goes ahead of the safe head.
+	L1Only bool `json:"l1_only"`
+}
+
+// Check verifies that the given configuration makes sense
+func (c *Config) Check() error {
+	if _, ok := derive.CandidatePipelines[c.ShadowPipeline]; c.ShadowPipeline != "" && !ok {
+		return fmt.Errorf("unknown shadow derivation pipeline %q", c.ShadowPipeline)
+	}
+	if c.L1Only && c.ProposerEnabled {
+		return errors.New("the proposer cannot be enabled in L1-only mode")
+	}
+	return nil
 }
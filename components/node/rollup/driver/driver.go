@@ -31,6 +31,7 @@ type Metrics interface {
 
 	EngineMetrics
 	ProposerMetrics
+	derive.ShadowMetrics
 }
 
 type L1Chain interface {
@@ -57,6 +58,7 @@ type DerivationPipeline interface {
 	UnsafeL2Head() eth.L2BlockRef
 	Origin() eth.L1BlockRef
 	EngineReady() bool
+	State() *derive.DerivationState
 }
 
 type L1StateIface interface {
@@ -102,16 +104,22 @@ type AltSync interface {
 }
 
 // NewDriver composes an events handler that tracks L1 state, triggers L2 derivation, and optionally proposes new L2 blocks.
-func NewDriver(driverCfg *Config, cfg *rollup.Config, l2 L2Chain, l1 L1Chain, altSync AltSync, network Network, log log.Logger, snapshotLog log.Logger, metrics Metrics) *Driver {
+func NewDriver(driverCfg *Config, cfg *rollup.Config, l2 L2Chain, l1 L1Chain, altSync AltSync, network Network, log log.Logger, snapshotLog log.Logger, metrics Metrics, events derive.Events, txSource TxSource) *Driver {
 	l1State := NewL1State(log, metrics)
 	proposerConfDepth := NewConfDepth(driverCfg.ProposerConfDepth, l1State.L1Head, l1)
 	findL1Origin := NewL1OriginSelector(log, cfg, proposerConfDepth)
 	syncConfDepth := NewConfDepth(driverCfg.SyncerConfDepth, l1State.L1Head, l1)
-	derivationPipeline := derive.NewDerivationPipeline(log, cfg, syncConfDepth, l2, metrics)
+	var derivationPipeline *derive.DerivationPipeline
+	if driverCfg.ShadowPipeline != "" {
+		candidate := derive.CandidatePipelines[driverCfg.ShadowPipeline]
+		derivationPipeline = derive.NewShadowedDerivationPipeline(log, cfg, syncConfDepth, l2, metrics, events, candidate, metrics)
+	} else {
+		derivationPipeline = derive.NewDerivationPipeline(log, cfg, syncConfDepth, l2, metrics, events)
+	}
 	attrBuilder := derive.NewFetchingAttributesBuilder(cfg, l1, l2)
 	engine := derivationPipeline
 	meteredEngine := NewMeteredEngine(cfg, engine, metrics, log)
-	proposer := NewProposer(log, cfg, meteredEngine, attrBuilder, findL1Origin, metrics)
+	proposer := NewProposer(log, cfg, meteredEngine, attrBuilder, findL1Origin, metrics, txSource)
 
 	return &Driver{
 		l1State:          l1State,
@@ -27,8 +27,23 @@ type L1OriginSelectorIface interface {
 type ProposerMetrics interface {
 	RecordProposerInconsistentL1Origin(from eth.BlockID, to eth.BlockID)
 	RecordProposerReset()
+	RecordProposerTxSourceFallback()
 }
 
+// TxSource provides the transactions of proposed blocks instead of the tx pool of the engine,
+// e.g. an external shared sequencing or MEV-mitigation service.
+type TxSource interface {
+	// BlockTransactions returns the raw transactions to include, in order, in the block built on top of parent at timestamp.
+	BlockTransactions(ctx context.Context, parent eth.L2BlockRef, timestamp uint64) ([]eth.Data, error)
+}
+
+// txSourceTimeout bounds the time to pull the transactions from the TxSource,
+// it must leave enough time to build the block within the block time.
+const txSourceTimeout = 500 * time.Millisecond
+
+// sealingTimeWeight is the inverse weight of a new measurement in the moving average of the sealing time.
+const sealingTimeWeight = 4
+
 // Proposer implements the proposing interface of the driver: it starts and completes block building jobs.
 type Proposer struct {
 	log    log.Logger
@@ -41,13 +56,19 @@ type Proposer struct {
 
 	metrics ProposerMetrics
 
+	// txSource optionally replaces the tx pool of the engine, nil if disabled.
+	txSource TxSource
+
 	// timeNow enables proposer testing to mock the time
 	timeNow func() time.Time
 
 	nextAction time.Time
+
+	// sealingTime is the moving average of the measured times the engine took to seal a block, zero until a block was sealed.
+	sealingTime time.Duration
 }
 
-func NewProposer(log log.Logger, cfg *rollup.Config, engine derive.ResettableEngineControl, attributesBuilder derive.AttributesBuilder, l1OriginSelector L1OriginSelectorIface, metrics ProposerMetrics) *Proposer {
+func NewProposer(log log.Logger, cfg *rollup.Config, engine derive.ResettableEngineControl, attributesBuilder derive.AttributesBuilder, l1OriginSelector L1OriginSelectorIface, metrics ProposerMetrics, txSource TxSource) *Proposer {
 	return &Proposer{
 		log:              log,
 		config:           cfg,
@@ -56,6 +77,7 @@ func NewProposer(log log.Logger, cfg *rollup.Config, engine derive.ResettableEng
 		attrBuilder:      attributesBuilder,
 		l1OriginSelector: l1OriginSelector,
 		metrics:          metrics,
+		txSource:         txSource,
 	}
 }
 
@@ -95,6 +117,16 @@ func (p *Proposer) StartBuildingBlock(ctx context.Context) error {
 		"num", l2Head.Number+1, "time", uint64(attrs.Timestamp),
 		"origin", l1Origin, "origin_time", l1Origin.Time, "noTxPool", attrs.NoTxPool)
 
+	if p.txSource != nil && !attrs.NoTxPool {
+		started, err := p.startBuildingWithTxSource(ctx, l2Head, attrs)
+		if started {
+			return nil
+		}
+		// fall back to the tx pool of the engine, so that the chain keeps progressing without the tx source.
+		p.log.Warn("failed to build block with transactions of the tx source, falling back to the tx pool", "parent", l2Head, "err", err)
+		p.metrics.RecordProposerTxSourceFallback()
+	}
+
 	// Start a payload building process.
 	errTyp, err := p.engine.StartPayload(ctx, l2Head, attrs, false)
 	if err != nil {
@@ -103,6 +135,33 @@ func (p *Proposer) StartBuildingBlock(ctx context.Context) error {
 	return nil
 }
 
+// startBuildingWithTxSource starts building a block with the transactions of the TxSource after the deposits,
+// without the tx pool of the engine. It returns true if the block building started.
+func (p *Proposer) startBuildingWithTxSource(ctx context.Context, l2Head eth.L2BlockRef, attrs *eth.PayloadAttributes) (bool, error) {
+	fetchCtx, cancel := context.WithTimeout(ctx, txSourceTimeout)
+	defer cancel()
+	txs, err := p.txSource.BlockTransactions(fetchCtx, l2Head, uint64(attrs.Timestamp))
+	if err != nil {
+		return false, fmt.Errorf("failed to fetch transactions: %w", err)
+	}
+	for i, tx := range txs {
+		// deposits are derived from L1 only, the tx source must not be able to forge them.
+		if len(tx) == 0 || tx[0] == types.DepositTxType {
+			return false, fmt.Errorf("invalid transaction %d from tx source", i)
+		}
+	}
+
+	sourceAttrs := *attrs
+	sourceAttrs.Transactions = append(append(make([]eth.Data, 0, len(attrs.Transactions)+len(txs)), attrs.Transactions...), txs...)
+	sourceAttrs.NoTxPool = true
+	errTyp, err := p.engine.StartPayload(ctx, l2Head, &sourceAttrs, false)
+	if err != nil {
+		return false, fmt.Errorf("failed to start building on top of L2 chain %s, error (%d): %w", l2Head, errTyp, err)
+	}
+	p.log.Info("started building block with transactions of the tx source", "parent", l2Head, "txs", len(txs))
+	return true, nil
+}
+
 // CompleteBuildingBlock takes the current block that is being built, and asks the engine to complete the building, seal the block, and persist it as canonical.
 // Warning: the safe and finalized L2 blocks as viewed during the initiation of the block building are reused for completion of the block building.
 // The Execution engine should not change the safe and finalized blocks between start and completion of block building.
@@ -150,11 +209,12 @@ func (p *Proposer) PlanNextProposerAction() time.Duration {
 	// then we would like to finish it by sealing the block.
 	if buildingID != (eth.PayloadID{}) && buildingOnto.Hash == head.Hash {
 		// if we started building already, then we will schedule the sealing.
-		if remainingTime < sealingDuration {
+		margin := p.sealingMargin()
+		if remainingTime < margin {
 			return 0 // if there's not enough time for sealing, don't wait.
 		} else {
 			// finish with margin of sealing duration before payloadTime
-			return remainingTime - sealingDuration
+			return remainingTime - margin
 		}
 	} else {
 		// if we did not yet start building, then we will schedule the start.
@@ -168,6 +228,29 @@ func (p *Proposer) PlanNextProposerAction() time.Duration {
 	}
 }
 
+// sealingMargin returns how long before the payload time the sealing of a block is scheduled, so that the block is
+// built for as long as possible: the sealingDuration, or more if the engine was measured to seal slower, with 50% of
+// margin for the variance. It is at most half of the block time, to leave the rest for building.
+func (p *Proposer) sealingMargin() time.Duration {
+	margin := p.sealingTime * 3 / 2
+	if margin < sealingDuration {
+		margin = sealingDuration
+	}
+	if limit := time.Duration(p.config.BlockTime) * time.Second / 2; margin > limit {
+		margin = limit
+	}
+	return margin
+}
+
+// recordSealingTime adds the measured time the engine took to seal a block to the moving average of the sealing time.
+func (p *Proposer) recordSealingTime(d time.Duration) {
+	if p.sealingTime == 0 {
+		p.sealingTime = d
+	} else {
+		p.sealingTime += (d - p.sealingTime) / sealingTimeWeight
+	}
+}
+
 // BuildingOnto returns the L2 head reference that the latest block is or was being built on top of.
 func (p *Proposer) BuildingOnto() eth.L2BlockRef {
 	ref, _, _ := p.engine.BuildingPayload()
@@ -204,6 +287,7 @@ func (p *Proposer) RunNextProposerAction(ctx context.Context) (*eth.ExecutionPay
 			p.nextAction = p.timeNow().Add(time.Second * time.Duration(p.config.BlockTime))
 			return nil, nil
 		}
+		sealingStart := p.timeNow()
 		payload, err := p.CompleteBuildingBlock(ctx)
 		if err != nil {
 			if errors.Is(err, derive.ErrCritical) {
@@ -217,7 +301,8 @@ func (p *Proposer) RunNextProposerAction(ctx context.Context) (*eth.ExecutionPay
 			} else if errors.Is(err, derive.ErrTemporary) {
 				p.log.Error("proposer failed temporarily to seal new block", "err", err)
 				p.nextAction = p.timeNow().Add(time.Second)
-				// We don't explicitly cancel block building jobs upon temporary errors: we may still finish the block.
+				// We don't explicitly cancel block building jobs upon temporary errors: we may still finish the block,
+				// reusing the payload if it was sealed already.
 				// Any unfinished block building work eventually times out, and will be cleaned up that way.
 			} else {
 				p.log.Error("proposer failed to seal block with unclassified error", "err", err)
@@ -226,7 +311,8 @@ func (p *Proposer) RunNextProposerAction(ctx context.Context) (*eth.ExecutionPay
 			}
 			return nil, nil
 		} else {
-			p.log.Info("proposer successfully built a new block", "block", payload.ID(), "time", uint64(payload.Timestamp), "txs", len(payload.Transactions))
+			p.recordSealingTime(p.timeNow().Sub(sealingStart))
+			p.log.Info("proposer successfully built a new block", "block", payload.ID(), "time", uint64(payload.Timestamp), "txs", len(payload.Transactions), "sealing_margin", p.sealingMargin())
 			return payload, nil
 		}
 	} else {
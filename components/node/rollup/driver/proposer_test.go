@@ -308,7 +308,7 @@ func TestProposerChaosMonkey(t *testing.T) {
 		}
 	})
 
-	proposer := NewProposer(log, cfg, engControl, attrBuilder, originSelector, metrics.NoopMetrics)
+	proposer := NewProposer(log, cfg, engControl, attrBuilder, originSelector, metrics.NoopMetrics, nil)
 	proposer.timeNow = clockFn
 
 	// try to build 1000 blocks, with 5x as many planning attempts, to handle errors and clock problems
@@ -382,3 +382,111 @@ func TestProposerChaosMonkey(t *testing.T) {
 	require.Greater(t, engControl.avgBuildingTime(), time.Second, "With 2 second block time and 1 second error backoff and healthy-on-average errors, building time should at least be a second")
 	require.Greater(t, engControl.avgTxsPerBlock(), 3.0, "We expect at least 1 system tx per block, but with a mocked 0-10 txs we expect an higher avg")
 }
+
+type testTxSourceFn func(ctx context.Context, parent eth.L2BlockRef, timestamp uint64) ([]eth.Data, error)
+
+func (fn testTxSourceFn) BlockTransactions(ctx context.Context, parent eth.L2BlockRef, timestamp uint64) ([]eth.Data, error) {
+	return fn(ctx, parent, timestamp)
+}
+
+var _ TxSource = (testTxSourceFn)(nil)
+
+// txSourceRejectingEngine fails to start building blocks that include more than the deposit.
+type txSourceRejectingEngine struct {
+	*FakeEngineControl
+}
+
+func (m *txSourceRejectingEngine) StartPayload(ctx context.Context, parent eth.L2BlockRef, attrs *eth.PayloadAttributes, updateSafe bool) (derive.BlockInsertionErrType, error) {
+	if len(attrs.Transactions) > 1 {
+		return derive.BlockInsertPayloadErr, errors.New("invalid forced tx")
+	}
+	return m.FakeEngineControl.StartPayload(ctx, parent, attrs, updateSafe)
+}
+
+func TestProposerTxSource(t *testing.T) {
+	l1Origin := eth.L1BlockRef{Hash: common.Hash{0x1}, Number: 100, Time: 1000}
+	l2Head := eth.L2BlockRef{Hash: common.Hash{0x2}, Number: 200, Time: 1000, L1Origin: l1Origin.ID()}
+	cfg := &rollup.Config{BlockTime: 2, MaxProposerDrift: 30}
+	deposit := eth.Data{types.DepositTxType, 0x1}
+	sourceTxs := []eth.Data{{types.DynamicFeeTxType, 0x1}, {types.DynamicFeeTxType, 0x2}}
+
+	attrBuilder := testAttrBuilderFn(func(ctx context.Context, l2Parent eth.L2BlockRef, epoch eth.BlockID) (*eth.PayloadAttributes, error) {
+		return &eth.PayloadAttributes{
+			Timestamp:    eth.Uint64Quantity(l2Parent.Time + cfg.BlockTime),
+			Transactions: []eth.Data{deposit},
+		}, nil
+	})
+	originSelector := testOriginSelectorFn(func(ctx context.Context, l2Head eth.L2BlockRef) (eth.L1BlockRef, error) {
+		return l1Origin, nil
+	})
+
+	tests := []struct {
+		name           string
+		txs            []eth.Data
+		err            error
+		rejectForced   bool
+		expectTxs      []eth.Data
+		expectNoTxPool bool
+	}{
+		{
+			name:           "transactions of the tx source",
+			txs:            sourceTxs,
+			expectTxs:      append([]eth.Data{deposit}, sourceTxs...),
+			expectNoTxPool: true,
+		},
+		{
+			name:      "tx source fails",
+			err:       errors.New("unavailable"),
+			expectTxs: []eth.Data{deposit},
+		},
+		{
+			name:      "tx source forges deposit",
+			txs:       []eth.Data{sourceTxs[0], {types.DepositTxType, 0x2}},
+			expectTxs: []eth.Data{deposit},
+		},
+		{
+			name:         "engine rejects transactions of the tx source",
+			txs:          sourceTxs,
+			rejectForced: true,
+			expectTxs:    []eth.Data{deposit},
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			fakeEngine := &FakeEngineControl{unsafe: l2Head, cfg: cfg, timeNow: time.Now}
+			var engine derive.ResettableEngineControl = fakeEngine
+			if test.rejectForced {
+				engine = &txSourceRejectingEngine{fakeEngine}
+			}
+			txSource := testTxSourceFn(func(ctx context.Context, parent eth.L2BlockRef, timestamp uint64) ([]eth.Data, error) {
+				require.Equal(t, l2Head, parent)
+				require.Equal(t, l2Head.Time+cfg.BlockTime, timestamp)
+				return test.txs, test.err
+			})
+			proposer := NewProposer(testlog.Logger(t, log.LvlCrit), cfg, engine, attrBuilder, originSelector, metrics.NoopMetrics, txSource)
+
+			require.NoError(t, proposer.StartBuildingBlock(context.Background()))
+			require.Equal(t, test.expectTxs, fakeEngine.buildingAttrs.Transactions)
+			require.Equal(t, test.expectNoTxPool, fakeEngine.buildingAttrs.NoTxPool)
+		})
+	}
+}
+
+func TestProposerSealingMargin(t *testing.T) {
+	p := &Proposer{config: &rollup.Config{BlockTime: 2}}
+	require.Equal(t, sealingDuration, p.sealingMargin(), "default margin before any block is sealed")
+
+	p.recordSealingTime(10 * time.Millisecond)
+	require.Equal(t, sealingDuration, p.sealingMargin(), "fast engine keeps the default margin")
+
+	p = &Proposer{config: &rollup.Config{BlockTime: 2}}
+	p.recordSealingTime(200 * time.Millisecond)
+	require.Equal(t, 300*time.Millisecond, p.sealingMargin(), "slow engine gets a margin for variance")
+	p.recordSealingTime(600 * time.Millisecond)
+	require.Equal(t, 300*time.Millisecond, p.sealingTime, "measurements are averaged")
+	require.Equal(t, 450*time.Millisecond, p.sealingMargin())
+
+	p.recordSealingTime(10 * time.Second)
+	require.Equal(t, time.Second, p.sealingMargin(), "margin leaves half of the block time to build")
+}
@@ -131,6 +131,9 @@ func (d *Driver) OnL1Finalized(ctx context.Context, finalized eth.L1BlockRef) er
 }
 
 func (d *Driver) OnUnsafeL2Payload(ctx context.Context, payload *eth.ExecutionPayload) error {
+	if d.driverConfig.L1Only {
+		return fmt.Errorf("unsafe L2 payload %s rejected in L1-only mode", payload.ID())
+	}
 	select {
 	case <-ctx.Done():
 		return ctx.Err()
@@ -422,6 +425,7 @@ func (d *Driver) syncStatus() *eth.SyncStatus {
 		SafeL2:             d.derivation.SafeL2Head(),
 		FinalizedL2:        d.derivation.Finalized(),
 		UnsafeL2SyncTarget: d.derivation.UnsafeL2SyncTarget(),
+		L1Only:             d.driverConfig.L1Only,
 	}
 }
 
@@ -459,6 +463,20 @@ func (d *Driver) BlockRefsWithStatus(ctx context.Context, num uint64) (eth.L2Blo
 	}
 }
 
+// DerivationState blocks the driver event loop and captures the state of the derivation pipeline buffers.
+// If the event loop is too busy and the context expires, a context error is returned.
+func (d *Driver) DerivationState(ctx context.Context) (*derive.DerivationState, error) {
+	wait := make(chan struct{})
+	select {
+	case d.stateReq <- wait:
+		resp := d.derivation.State()
+		<-wait
+		return resp, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
 // deferJSONString helps avoid a JSON-encoding performance hit if the snapshot logger does not run
 type deferJSONString struct {
 	x any
@@ -0,0 +1,7 @@
+package rollup
+
+import "github.com/kroma-network/kroma/components/node/eth"
+
+// SupportedProtocolVersion is the latest protocol version supported by this binary.
+// It has to be bumped with every release that implements a protocol upgrade.
+var SupportedProtocolVersion = eth.ProtocolVersionV0{Major: 1}.Encode()
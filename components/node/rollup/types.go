@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"reflect"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -34,6 +35,10 @@ var (
 	ErrChainIDsSame                  = errors.New("L1 and L2 chain IDs must be different")
 	ErrL1ChainIDNotPositive          = errors.New("L1 chain ID must be non-zero and positive")
 	ErrL2ChainIDNotPositive          = errors.New("L2 chain ID must be non-zero and positive")
+	ErrMissingLegacyInboxFormat      = errors.New("missing legacy inbox format")
+	ErrMissingLegacyInboxAddress     = errors.New("missing legacy inbox address")
+	ErrMissingLegacyBatcherAddr      = errors.New("missing legacy inbox batcher address")
+	ErrInvalidLegacyInboxEndBlock    = errors.New("legacy inbox end block must be after the L1 genesis block")
 )
 
 type Genesis struct {
@@ -72,6 +77,14 @@ type Config struct {
 	// BlueTime sets the activation time of the Blue network-upgrade.
 	// Active if BlueTime != nil && L2 block timestamp >= *BlueTime, inactive otherwise.
 	BlueTime *uint64 `json:"blue_time,omitempty"`
+	// SpanBatchTime sets the activation time of the span batches, which batch many L2 blocks at once.
+	// Active if SpanBatchTime != nil && L2 block timestamp >= *SpanBatchTime, inactive otherwise.
+	SpanBatchTime *uint64 `json:"span_batch_time,omitempty"`
+	// ChannelCompressionTime sets the activation time of the brotli and zstd compression of the channels, before
+	// which only the zlib compressed channels are valid. As the compression of a channel is read before its batches,
+	// it is compared with the timestamp of the L1 block the channel is read from, not with an L2 block timestamp.
+	// Active if ChannelCompressionTime != nil && L1 block timestamp >= *ChannelCompressionTime, inactive otherwise.
+	ChannelCompressionTime *uint64 `json:"channel_compression_time,omitempty"`
 
 	// Note: below addresses are part of the block-derivation process,
 	// and required to be the same network-wide to stay in consensus.
@@ -82,6 +95,32 @@ type Config struct {
 	DepositContractAddress common.Address `json:"deposit_contract_address"`
 	// L1 System Config Address
 	L1SystemConfigAddress common.Address `json:"l1_system_config_address"`
+
+	// LegacyInbox configures the batch data posted in the inbox format of the stack the chain migrated from,
+	// before the migration onto Kroma. Disabled if nil.
+	LegacyInbox *LegacyInboxConfig `json:"legacy_inbox,omitempty"`
+
+	// L1 address of the contract signaling the required and recommended protocol versions.
+	// This is not part of the block-derivation process. Protocol version signaling is disabled if not set.
+	ProtocolVersionsAddress common.Address `json:"protocol_versions_address,omitempty"`
+}
+
+// LegacyInboxConfig configures the batch data posted before a migration onto Kroma.
+// The data of the L1 blocks before EndBlock is read from the legacy inbox, and decoded from the legacy format.
+type LegacyInboxConfig struct {
+	// Format is the name of the decoder of the legacy inbox format.
+	Format string `json:"format"`
+	// L1 address that the legacy batches were sent to.
+	Address common.Address `json:"address"`
+	// L1 address that sent the legacy batches.
+	BatcherAddr common.Address `json:"batcher_address"`
+	// EndBlock is the first L1 block number of which the batch data is in the current format.
+	EndBlock uint64 `json:"end_block"`
+}
+
+// IsLegacy returns whether the batch data of the L1 block is in the legacy inbox format.
+func (c *LegacyInboxConfig) IsLegacy(l1BlockNum uint64) bool {
+	return c != nil && l1BlockNum < c.EndBlock
 }
 
 // ValidateL1Config checks L1 config variables for errors.
@@ -242,9 +281,69 @@ func (cfg *Config) Check() error {
 	if cfg.L2ChainID.Sign() < 1 {
 		return ErrL2ChainIDNotPositive
 	}
+	if legacy := cfg.LegacyInbox; legacy != nil {
+		if legacy.Format == "" {
+			return ErrMissingLegacyInboxFormat
+		}
+		if legacy.Address == (common.Address{}) {
+			return ErrMissingLegacyInboxAddress
+		}
+		if legacy.BatcherAddr == (common.Address{}) {
+			return ErrMissingLegacyBatcherAddr
+		}
+		if legacy.EndBlock <= cfg.Genesis.L1.Number {
+			return ErrInvalidLegacyInboxEndBlock
+		}
+	}
+	return nil
+}
+
+// CheckSameConsensus returns an error naming the first field of the block derivation that differs between the
+// configs. The fields that are not part of the block derivation may differ, and so may the fields unknown to the
+// binary, which are dropped when decoding the config: the protocol version tells apart the binaries deriving the
+// blocks by different rules.
+func (c *Config) CheckSameConsensus(other *Config) error {
+	fields := []struct {
+		name  string
+		equal bool
+	}{
+		{"genesis", reflect.DeepEqual(c.Genesis, other.Genesis)},
+		{"block_time", c.BlockTime == other.BlockTime},
+		{"max_proposer_drift", c.MaxProposerDrift == other.MaxProposerDrift},
+		{"proposer_window_size", c.ProposerWindowSize == other.ProposerWindowSize},
+		{"channel_timeout", c.ChannelTimeout == other.ChannelTimeout},
+		{"l1_chain_id", bigEqual(c.L1ChainID, other.L1ChainID)},
+		{"l2_chain_id", bigEqual(c.L2ChainID, other.L2ChainID)},
+		{"blue_time", timeEqual(c.BlueTime, other.BlueTime)},
+		{"span_batch_time", timeEqual(c.SpanBatchTime, other.SpanBatchTime)},
+		{"channel_compression_time", timeEqual(c.ChannelCompressionTime, other.ChannelCompressionTime)},
+		{"batch_inbox_address", c.BatchInboxAddress == other.BatchInboxAddress},
+		{"deposit_contract_address", c.DepositContractAddress == other.DepositContractAddress},
+		{"l1_system_config_address", c.L1SystemConfigAddress == other.L1SystemConfigAddress},
+		{"legacy_inbox", reflect.DeepEqual(c.LegacyInbox, other.LegacyInbox)},
+	}
+	for _, field := range fields {
+		if !field.equal {
+			return fmt.Errorf("rollup config %s differs", field.name)
+		}
+	}
 	return nil
 }
 
+func bigEqual(a, b *big.Int) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Cmp(b) == 0
+}
+
+func timeEqual(a, b *uint64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
 func (c *Config) L1Signer() types.Signer {
 	return types.NewLondonSigner(c.L1ChainID)
 }
@@ -263,6 +362,17 @@ func (c *Config) IsBlueBlock(blockNum uint64) bool {
 	return c.IsBlue(c.ComputeTimestamp(blockNum))
 }
 
+// IsSpanBatch returns true if the span batches are accepted at or past the given timestamp.
+func (c *Config) IsSpanBatch(timestamp uint64) bool {
+	return c.SpanBatchTime != nil && timestamp >= *c.SpanBatchTime
+}
+
+// IsChannelCompression returns true if the brotli and zstd compressed channels are accepted at or past the given L1
+// timestamp.
+func (c *Config) IsChannelCompression(l1Timestamp uint64) bool {
+	return c.ChannelCompressionTime != nil && l1Timestamp >= *c.ChannelCompressionTime
+}
+
 // Description outputs a banner describing the important parts of rollup configuration in a human-readable form.
 // Optionally provide a mapping of L2 chain IDs to network names to label the L2 chain with if not unknown.
 // The config should be config.Check()-ed before creating a description.
@@ -290,6 +400,11 @@ func (c *Config) Description(l2Chains map[string]string) string {
 	// Report the upgrade configuration
 	banner += "Kroma Network Upgrades (timestamp based):\n"
 	banner += fmt.Sprintf("  - Blue: %s\n", fmtForkTimeOrUnset(c.BlueTime))
+	banner += fmt.Sprintf("  - Span batch: %s\n", fmtForkTimeOrUnset(c.SpanBatchTime))
+	banner += fmt.Sprintf("  - Channel compression (L1 timestamp): %s\n", fmtForkTimeOrUnset(c.ChannelCompressionTime))
+	if c.LegacyInbox != nil {
+		banner += fmt.Sprintf("Legacy inbox (%s) until L1 block %d: %s\n", c.LegacyInbox.Format, c.LegacyInbox.EndBlock, c.LegacyInbox.Address)
+	}
 	return banner
 }
 
@@ -312,7 +427,9 @@ func (c *Config) LogDescription(log log.Logger, l2Chains map[string]string) {
 	log.Info("Rollup Config", "l2_chain_id", c.L2ChainID, "l2_network", networkL2, "l1_chain_id", c.L1ChainID,
 		"l1_network", networkL1, "l2_start_time", c.Genesis.L2Time, "l2_block_hash", c.Genesis.L2.Hash.String(),
 		"l2_block_number", c.Genesis.L2.Number, "l1_block_hash", c.Genesis.L1.Hash.String(),
-		"l1_block_number", c.Genesis.L1.Number, "blue_time", fmtForkTimeOrUnset(c.BlueTime))
+		"l1_block_number", c.Genesis.L1.Number, "blue_time", fmtForkTimeOrUnset(c.BlueTime),
+		"span_batch_time", fmtForkTimeOrUnset(c.SpanBatchTime),
+		"channel_compression_time", fmtForkTimeOrUnset(c.ChannelCompressionTime))
 }
 
 func fmtForkTimeOrUnset(v *uint64) string {
@@ -59,6 +59,33 @@ func TestConfigJSON(t *testing.T) {
 	assert.Equal(t, &roundTripped, config)
 }
 
+func TestConfigCheckSameConsensus(t *testing.T) {
+	config := randConfig()
+
+	// the config survives the JSON round trip of the rollup config RPC
+	data, err := json.Marshal(config)
+	require.NoError(t, err)
+	var roundTripped Config
+	require.NoError(t, json.Unmarshal(data, &roundTripped))
+	require.NoError(t, config.CheckSameConsensus(&roundTripped))
+
+	// the fields that are not part of the block derivation may differ
+	roundTripped.ProtocolVersionsAddress = common.Address{0x01}
+	require.NoError(t, config.CheckSameConsensus(&roundTripped))
+
+	blueTime := uint64(10)
+	roundTripped.BlueTime = &blueTime
+	require.EqualError(t, config.CheckSameConsensus(&roundTripped), "rollup config blue_time differs")
+	roundTripped.BlueTime = nil
+
+	roundTripped.L2ChainID = big.NewInt(902)
+	require.EqualError(t, config.CheckSameConsensus(&roundTripped), "rollup config l2_chain_id differs")
+	roundTripped.L2ChainID = big.NewInt(901)
+
+	roundTripped.Genesis.SystemConfig.GasLimit++
+	require.EqualError(t, config.CheckSameConsensus(&roundTripped), "rollup config genesis differs")
+}
+
 type mockL1Client struct {
 	chainID *big.Int
 	Hash    common.Hash
@@ -196,6 +223,35 @@ func TestBlueActivation(t *testing.T) {
 	require.True(t, config.IsBlue(124))
 }
 
+// TestSpanBatchActivation tests the activation condition of the span batches.
+func TestSpanBatchActivation(t *testing.T) {
+	config := randConfig()
+	config.SpanBatchTime = nil
+	require.False(t, config.IsSpanBatch(0), "false if nil time, even if checking 0")
+	require.False(t, config.IsSpanBatch(123456), "false if nil time")
+	config.SpanBatchTime = new(uint64)
+	require.True(t, config.IsSpanBatch(0), "true at zero")
+	x := uint64(123)
+	config.SpanBatchTime = &x
+	require.False(t, config.IsSpanBatch(122))
+	require.True(t, config.IsSpanBatch(123))
+	require.True(t, config.IsSpanBatch(124))
+}
+
+func TestChannelCompressionActivation(t *testing.T) {
+	config := randConfig()
+	config.ChannelCompressionTime = nil
+	require.False(t, config.IsChannelCompression(0), "false if nil time, even if checking 0")
+	require.False(t, config.IsChannelCompression(123456), "false if nil time")
+	config.ChannelCompressionTime = new(uint64)
+	require.True(t, config.IsChannelCompression(0), "true at zero")
+	x := uint64(123)
+	config.ChannelCompressionTime = &x
+	require.False(t, config.IsChannelCompression(122))
+	require.True(t, config.IsChannelCompression(123))
+	require.True(t, config.IsChannelCompression(124))
+}
+
 type mockL2Client struct {
 	chainID *big.Int
 	Hash    common.Hash
@@ -385,6 +441,32 @@ func TestConfig_Check(t *testing.T) {
 			modifier:    func(cfg *Config) { cfg.L2ChainID = big.NewInt(0) },
 			expectedErr: ErrL2ChainIDNotPositive,
 		},
+		{
+			name: "NoLegacyInboxFormat",
+			modifier: func(cfg *Config) {
+				cfg.LegacyInbox = &LegacyInboxConfig{Address: common.Address{1}, BatcherAddr: common.Address{2}}
+			},
+			expectedErr: ErrMissingLegacyInboxFormat,
+		},
+		{
+			name: "NoLegacyInboxAddress",
+			modifier: func(cfg *Config) {
+				cfg.LegacyInbox = &LegacyInboxConfig{Format: "frames", BatcherAddr: common.Address{2}}
+			},
+			expectedErr: ErrMissingLegacyInboxAddress,
+		},
+		{
+			name:        "NoLegacyBatcherAddr",
+			modifier:    func(cfg *Config) { cfg.LegacyInbox = &LegacyInboxConfig{Format: "frames", Address: common.Address{1}} },
+			expectedErr: ErrMissingLegacyBatcherAddr,
+		},
+		{
+			name: "LegacyInboxEndBlockBeforeGenesis",
+			modifier: func(cfg *Config) {
+				cfg.LegacyInbox = &LegacyInboxConfig{Format: "frames", Address: common.Address{1}, BatcherAddr: common.Address{2}, EndBlock: cfg.Genesis.L1.Number}
+			},
+			expectedErr: ErrInvalidLegacyInboxEndBlock,
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
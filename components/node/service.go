@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"math/big"
 	"os"
 	"strings"
 
@@ -14,8 +15,11 @@ import (
 	"github.com/urfave/cli"
 
 	"github.com/kroma-network/kroma/components/node/chaincfg"
+	"github.com/kroma-network/kroma/components/node/datadir"
 	"github.com/kroma-network/kroma/components/node/flags"
+	"github.com/kroma-network/kroma/components/node/manifest"
 	"github.com/kroma-network/kroma/components/node/node"
+	"github.com/kroma-network/kroma/components/node/p2p"
 	p2pcli "github.com/kroma-network/kroma/components/node/p2p/cli"
 	"github.com/kroma-network/kroma/components/node/rollup"
 	"github.com/kroma-network/kroma/components/node/rollup/driver"
@@ -34,6 +38,15 @@ func NewConfig(ctx *cli.Context, log log.Logger) (*node.Config, error) {
 		return nil, err
 	}
 
+	rollupManifest, rollupManifestSlot, err := NewRollupManifest(ctx, log, rollupConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify rollup manifest: %w", err)
+	}
+
+	if err := applyDataDir(ctx, log, rollupConfig.L2ChainID); err != nil {
+		return nil, fmt.Errorf("failed to open data directory: %w", err)
+	}
+
 	driverConfig := NewDriverConfig(ctx)
 
 	p2pSignerSetup, err := p2pcli.LoadSignerSetup(ctx)
@@ -41,9 +54,13 @@ func NewConfig(ctx *cli.Context, log log.Logger) (*node.Config, error) {
 		return nil, fmt.Errorf("failed to load p2p signer: %w", err)
 	}
 
-	p2pConfig, err := p2pcli.NewConfig(ctx, rollupConfig.BlockTime)
-	if err != nil {
-		return nil, fmt.Errorf("failed to load p2p config: %w", err)
+	// no libp2p host, gossip or discovery is set up at all in L1-only mode, nor is the p2p key loaded.
+	p2pConfig := &p2p.Config{DisableP2P: true}
+	if !driverConfig.L1Only {
+		p2pConfig, err = p2pcli.NewConfig(ctx, rollupConfig.BlockTime)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load p2p config: %w", err)
+		}
 	}
 
 	l1Endpoint := NewL1EndpointConfig(ctx)
@@ -55,6 +72,11 @@ func NewConfig(ctx *cli.Context, log log.Logger) (*node.Config, error) {
 
 	l2SyncEndpoint := NewL2SyncEndpointConfig(ctx)
 
+	builderEndpoint, err := NewBuilderEndpointConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load builder endpoint info: %w", err)
+	}
+
 	cfg := &node.Config{
 		L1:     l1Endpoint,
 		L2:     l2Endpoint,
@@ -62,9 +84,18 @@ func NewConfig(ctx *cli.Context, log log.Logger) (*node.Config, error) {
 		Rollup: *rollupConfig,
 		Driver: *driverConfig,
 		RPC: node.RPCConfig{
-			ListenAddr:  ctx.GlobalString(flags.RPCListenAddr.Name),
-			ListenPort:  ctx.GlobalInt(flags.RPCListenPort.Name),
-			EnableAdmin: ctx.GlobalBool(flags.RPCEnableAdmin.Name),
+			ListenAddr:     ctx.GlobalString(flags.RPCListenAddr.Name),
+			ListenPort:     ctx.GlobalInt(flags.RPCListenPort.Name),
+			EnableAdmin:    ctx.GlobalBool(flags.RPCEnableAdmin.Name),
+			EnableEvents:   ctx.GlobalBool(flags.RPCEnableEvents.Name),
+			TxPoolDABudget: ctx.GlobalUint64(flags.RPCTxPoolDABudget.Name),
+			RequestLog: node.RPCRequestLogConfig{
+				Enabled:        ctx.GlobalBool(flags.RPCLogRequests.Name),
+				Namespaces:     splitList(ctx.GlobalString(flags.RPCLogNamespaces.Name)),
+				SampledMethods: splitList(ctx.GlobalString(flags.RPCLogSampledMethods.Name)),
+				SampleRate:     ctx.GlobalUint64(flags.RPCLogSampleRate.Name),
+				SlowThreshold:  ctx.GlobalDuration(flags.RPCLogSlowThreshold.Name),
+			},
 		},
 		Metrics: node.MetricsConfig{
 			Enabled:    ctx.GlobalBool(flags.MetricsEnabledFlag.Name),
@@ -72,18 +103,26 @@ func NewConfig(ctx *cli.Context, log log.Logger) (*node.Config, error) {
 			ListenPort: ctx.GlobalInt(flags.MetricsPortFlag.Name),
 		},
 		Pprof: kpprof.CLIConfig{
-			Enabled:    ctx.GlobalBool(flags.PprofEnabledFlag.Name),
-			ListenAddr: ctx.GlobalString(flags.PprofAddrFlag.Name),
-			ListenPort: ctx.GlobalInt(flags.PprofPortFlag.Name),
+			Enabled:       ctx.GlobalBool(flags.PprofEnabledFlag.Name),
+			ListenAddr:    ctx.GlobalString(flags.PprofAddrFlag.Name),
+			ListenPort:    ctx.GlobalInt(flags.PprofPortFlag.Name),
+			AuthTokenFile: ctx.GlobalString(flags.PprofAuthTokenFileFlag.Name),
+			GoroutineDump: ctx.GlobalBool(flags.PprofGoroutineDumpFlag.Name),
 		},
 		P2P:                 p2pConfig,
 		P2PSigner:           p2pSignerSetup,
 		L1EpochPollInterval: ctx.GlobalDuration(flags.L1EpochPollIntervalFlag.Name),
+		RollupHalt:          ctx.GlobalString(flags.RollupHalt.Name),
+		RollupManifest:      rollupManifest,
+		RollupManifestSlot:  rollupManifestSlot,
 		Heartbeat: node.HeartbeatConfig{
 			Enabled: ctx.GlobalBool(flags.HeartbeatEnabledFlag.Name),
 			Moniker: ctx.GlobalString(flags.HeartbeatMonikerFlag.Name),
 			URL:     ctx.GlobalString(flags.HeartbeatURLFlag.Name),
 		},
+		TxSource:    NewTxSourceEndpointConfig(ctx),
+		Builder:     builderEndpoint,
+		BatchMirror: NewBatchMirrorEndpointConfig(ctx),
 	}
 	if err := cfg.Check(); err != nil {
 		return nil, err
@@ -98,6 +137,7 @@ func NewL1EndpointConfig(ctx *cli.Context) *node.L1EndpointConfig {
 		L1RPCKind:        sources.RPCProviderKind(strings.ToLower(ctx.GlobalString(flags.L1RPCProviderKind.Name))),
 		RateLimit:        ctx.GlobalFloat64(flags.L1RPCRateLimit.Name),
 		BatchSize:        ctx.GlobalInt(flags.L1RPCMaxBatchSize.Name),
+		BatchLatency:     ctx.GlobalDuration(flags.L1RPCBatchLatency.Name),
 		HttpPollInterval: ctx.Duration(flags.L1HTTPPollInterval.Name),
 	}
 }
@@ -129,6 +169,8 @@ func NewL2EndpointConfig(ctx *cli.Context, log log.Logger) (*node.L2EndpointConf
 	return &node.L2EndpointConfig{
 		L2EngineAddr:      l2Addr,
 		L2EngineJWTSecret: secret,
+		BatchSize:         ctx.GlobalInt(flags.L2EngineRPCMaxBatchSize.Name),
+		BatchLatency:      ctx.GlobalDuration(flags.L2EngineRPCBatchLatency.Name),
 	}, nil
 }
 
@@ -141,6 +183,49 @@ func NewL2SyncEndpointConfig(ctx *cli.Context) *node.L2SyncEndpointConfig {
 	}
 }
 
+// NewTxSourceEndpointConfig returns a pointer to a TxSourceEndpointConfig,
+// the tx source is disabled if the flag is not set.
+func NewTxSourceEndpointConfig(ctx *cli.Context) *node.TxSourceEndpointConfig {
+	return &node.TxSourceEndpointConfig{
+		TxSourceAddr: ctx.GlobalString(flags.ProposerTxSourceRPC.Name),
+	}
+}
+
+// NewBatchMirrorEndpointConfig returns a pointer to a BatchMirrorEndpointConfig,
+// the batch mirror is disabled if the flag is not set.
+func NewBatchMirrorEndpointConfig(ctx *cli.Context) *node.BatchMirrorEndpointConfig {
+	return &node.BatchMirrorEndpointConfig{
+		BatchMirrorAddr:    ctx.GlobalString(flags.L1BatchMirrorURL.Name),
+		BatchMirrorKind:    ctx.GlobalString(flags.L1BatchMirrorKind.Name),
+		BatchMirrorPublish: ctx.GlobalBool(flags.L1BatchMirrorPublish.Name),
+	}
+}
+
+// NewBuilderEndpointConfig returns a pointer to a BuilderEndpointConfig,
+// the builder is disabled if the flag is not set.
+func NewBuilderEndpointConfig(ctx *cli.Context) (*node.BuilderEndpointConfig, error) {
+	cfg := &node.BuilderEndpointConfig{
+		BuilderAddr: ctx.GlobalString(flags.ProposerBuilderRPC.Name),
+	}
+	if cfg.BuilderAddr == "" {
+		return cfg, nil
+	}
+	fileName := strings.TrimSpace(ctx.GlobalString(flags.ProposerBuilderJWTSecret.Name))
+	if fileName == "" {
+		return nil, fmt.Errorf("file-name of builder jwt secret is empty")
+	}
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read builder jwt secret: %w", err)
+	}
+	jwtSecret := common.FromHex(strings.TrimSpace(string(data)))
+	if len(jwtSecret) != 32 {
+		return nil, fmt.Errorf("invalid jwt secret in path %s, not 32 hex-formatted bytes", fileName)
+	}
+	copy(cfg.BuilderJWTSecret[:], jwtSecret)
+	return cfg, nil
+}
+
 func NewDriverConfig(ctx *cli.Context) *driver.Config {
 	return &driver.Config{
 		SyncerConfDepth:    ctx.GlobalUint64(flags.SyncerL1Confs.Name),
@@ -148,6 +233,8 @@ func NewDriverConfig(ctx *cli.Context) *driver.Config {
 		ProposerEnabled:    ctx.GlobalBool(flags.ProposerEnabledFlag.Name),
 		ProposerStopped:    ctx.GlobalBool(flags.ProposerStoppedFlag.Name),
 		ProposerMaxSafeLag: ctx.GlobalUint64(flags.ProposerMaxSafeLagFlag.Name),
+		ShadowPipeline:     ctx.GlobalString(flags.SyncerShadowPipeline.Name),
+		L1Only:             ctx.GlobalBool(flags.SyncerL1Only.Name),
 	}
 }
 
@@ -176,6 +263,89 @@ func NewRollupConfig(ctx *cli.Context) (*rollup.Config, error) {
 	return &rollupConfig, nil
 }
 
+// NewRollupManifest verifies the rollup config file, and the genesis file if given, against the signed manifest, if
+// configured. The manifest must be signed by one of the trusted signers, or be checked against its hash on L1 on
+// start, for which the L1 slot is returned.
+func NewRollupManifest(ctx *cli.Context, log log.Logger, rollupConfig *rollup.Config) (*manifest.Manifest, *manifest.Slot, error) {
+	path := ctx.GlobalString(flags.RollupManifest.Name)
+	if path == "" {
+		for _, flag := range []string{flags.RollupManifestSigners.Name, flags.RollupManifestGenesis.Name, flags.RollupManifestL1Slot.Name} {
+			if ctx.GlobalIsSet(flag) {
+				return nil, nil, fmt.Errorf("flag %s requires %s", flag, flags.RollupManifest.Name)
+			}
+		}
+		return nil, nil, nil
+	}
+	if ctx.GlobalString(flags.Network.Name) != "" {
+		return nil, nil, fmt.Errorf("cannot specify both %s and %s", flags.RollupManifest.Name, flags.Network.Name)
+	}
+
+	var signers []common.Address
+	for _, s := range splitList(ctx.GlobalString(flags.RollupManifestSigners.Name)) {
+		if !common.IsHexAddress(s) {
+			return nil, nil, fmt.Errorf("invalid manifest signer %q", s)
+		}
+		signers = append(signers, common.HexToAddress(s))
+	}
+	var slot *manifest.Slot
+	if s := ctx.GlobalString(flags.RollupManifestL1Slot.Name); s != "" {
+		var err error
+		if slot, err = manifest.ParseSlot(s); err != nil {
+			return nil, nil, err
+		}
+	}
+	if len(signers) == 0 && slot == nil {
+		return nil, nil, fmt.Errorf("flag %s requires %s or %s", flags.RollupManifest.Name,
+			flags.RollupManifestSigners.Name, flags.RollupManifestL1Slot.Name)
+	}
+
+	m, err := manifest.Load(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	files := map[string]string{manifest.RollupFile: ctx.GlobalString(flags.RollupConfig.Name)}
+	if genesis := ctx.GlobalString(flags.RollupManifestGenesis.Name); genesis != "" {
+		files[manifest.GenesisFile] = genesis
+	}
+	if err := m.Verify(rollupConfig.L2ChainID, files, signers); err != nil {
+		return nil, nil, err
+	}
+	log.Info("verified rollup config against manifest", "manifest", path, "files", len(files), "signers", len(signers))
+	return m, slot, nil
+}
+
+// applyDataDir opens the directory of the L2 chain in the data directory, if configured, and points the path flags
+// that are not set explicitly into it. The files of the flat layout are imported from the working directory.
+func applyDataDir(ctx *cli.Context, log log.Logger, l2ChainID *big.Int) error {
+	root := strings.TrimSpace(ctx.GlobalString(flags.DataDir.Name))
+	if root == "" {
+		return nil
+	}
+	d, err := datadir.Open(log, root, l2ChainID, ".")
+	if err != nil {
+		return err
+	}
+	paths := []struct {
+		flag string
+		path string
+	}{
+		{flags.P2PPrivPath.Name, d.P2PPrivPath()},
+		{flags.PeerstorePath.Name, d.PeerstorePath()},
+		{flags.DiscoveryPath.Name, d.DiscoveryPath()},
+		{flags.SnapshotLog.Name, d.SnapshotLogPath()},
+	}
+	for _, p := range paths {
+		if ctx.GlobalIsSet(p.flag) {
+			continue
+		}
+		if err := ctx.GlobalSet(p.flag, p.path); err != nil {
+			return fmt.Errorf("failed to set %s: %w", p.flag, err)
+		}
+	}
+	log.Info("using data directory", "dir", d.Dir())
+	return nil
+}
+
 func NewSnapshotLogger(ctx *cli.Context) (log.Logger, error) {
 	snapshotFile := ctx.GlobalString(flags.SnapshotLog.Name)
 	handler := log.DiscardHandler()
@@ -191,3 +361,14 @@ func NewSnapshotLogger(ctx *cli.Context) (log.Logger, error) {
 	logger.SetHandler(handler)
 	return logger, nil
 }
+
+// splitList splits a comma-separated flag value, ignoring empty entries.
+func splitList(value string) []string {
+	var out []string
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
@@ -0,0 +1,161 @@
+package sources
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+
+	"github.com/kroma-network/kroma/components/node/rollup/derive"
+)
+
+const batchMirrorRequestTimeout = 30 * time.Second
+
+// HTTPBatchMirror is a batch mirror on an HTTP endpoint, storing the data of a key with a PUT to <url>/<key> and
+// reading it with a GET of the same URL.
+type HTTPBatchMirror struct {
+	url    string
+	client *http.Client
+}
+
+var _ derive.BatchMirror = (*HTTPBatchMirror)(nil)
+
+func NewHTTPBatchMirror(url string) *HTTPBatchMirror {
+	return &HTTPBatchMirror{
+		url:    strings.TrimSuffix(url, "/"),
+		client: &http.Client{Timeout: batchMirrorRequestTimeout},
+	}
+}
+
+func (m *HTTPBatchMirror) Put(ctx context.Context, key string, data []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, m.url+"/"+key, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	res, err := m.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to put %s to batch mirror: %w", key, err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode/100 != 2 {
+		return fmt.Errorf("failed to put %s to batch mirror: status %s", key, res.Status)
+	}
+	return nil
+}
+
+func (m *HTTPBatchMirror) Get(ctx context.Context, key string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, m.url+"/"+key, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := m.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s from batch mirror: %w", key, err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: %s in batch mirror", ethereum.NotFound, key)
+	} else if res.StatusCode/100 != 2 {
+		return nil, fmt.Errorf("failed to get %s from batch mirror: status %s", key, res.Status)
+	}
+	return io.ReadAll(res.Body)
+}
+
+// IPFSBatchMirror is a batch mirror in the mutable file system of an IPFS node, written and read through the RPC API
+// of the node. The data of a key is stored in the file <root>/<key>, so that the mirrored batch data is published under
+// the CID of the root directory.
+type IPFSBatchMirror struct {
+	api    string
+	root   string
+	client *http.Client
+}
+
+var _ derive.BatchMirror = (*IPFSBatchMirror)(nil)
+
+// NewIPFSBatchMirror creates a mirror on the IPFS node with the RPC API at the URL, e.g. http://localhost:5001,
+// storing the data under the root directory of the mutable file system.
+func NewIPFSBatchMirror(api string, root string) *IPFSBatchMirror {
+	return &IPFSBatchMirror{
+		api:    strings.TrimSuffix(api, "/"),
+		root:   path.Join("/", root),
+		client: &http.Client{Timeout: batchMirrorRequestTimeout},
+	}
+}
+
+// ipfsError is the error returned by the RPC API of an IPFS node.
+type ipfsError struct {
+	Message string `json:"Message"`
+}
+
+func (m *IPFSBatchMirror) call(ctx context.Context, command string, args url.Values, body io.Reader, contentType string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, m.api+"/api/v0/"+command+"?"+args.Encode(), body)
+	if err != nil {
+		return nil, err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	res, err := m.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode/100 != 2 {
+		var ipfsErr ipfsError
+		if json.Unmarshal(data, &ipfsErr) == nil && ipfsErr.Message != "" {
+			if strings.Contains(ipfsErr.Message, "does not exist") {
+				return nil, fmt.Errorf("%w: %s", ethereum.NotFound, ipfsErr.Message)
+			}
+			return nil, fmt.Errorf("ipfs %s: %s", command, ipfsErr.Message)
+		}
+		return nil, fmt.Errorf("ipfs %s: status %s", command, res.Status)
+	}
+	return data, nil
+}
+
+func (m *IPFSBatchMirror) Put(ctx context.Context, key string, data []byte) error {
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	part, err := w.CreateFormFile("file", path.Base(key))
+	if err != nil {
+		return err
+	}
+	if _, err := part.Write(data); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	args := url.Values{
+		"arg":      {path.Join(m.root, key)},
+		"create":   {"true"},
+		"parents":  {"true"},
+		"truncate": {"true"},
+	}
+	if _, err := m.call(ctx, "files/write", args, &body, w.FormDataContentType()); err != nil {
+		return fmt.Errorf("failed to put %s to batch mirror: %w", key, err)
+	}
+	return nil
+}
+
+func (m *IPFSBatchMirror) Get(ctx context.Context, key string) ([]byte, error) {
+	data, err := m.call(ctx, "files/read", url.Values{"arg": {path.Join(m.root, key)}}, nil, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s from batch mirror: %w", key, err)
+	}
+	return data, nil
+}
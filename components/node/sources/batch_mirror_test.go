@@ -0,0 +1,97 @@
+package sources
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kroma-network/kroma/components/node/rollup/derive"
+)
+
+func testBatchMirror(t *testing.T, mirror derive.BatchMirror) {
+	ctx := context.Background()
+	_, err := mirror.Get(ctx, "blocks/0x01")
+	require.ErrorIs(t, err, ethereum.NotFound)
+
+	require.NoError(t, mirror.Put(ctx, "blocks/0x01", []byte(`{"number":1}`)))
+	require.NoError(t, mirror.Put(ctx, "channels/0x02/03/0", []byte(`{}`)))
+	data, err := mirror.Get(ctx, "blocks/0x01")
+	require.NoError(t, err)
+	require.Equal(t, []byte(`{"number":1}`), data)
+
+	require.NoError(t, mirror.Put(ctx, "blocks/0x01", []byte(`{"number":2}`)))
+	data, err = mirror.Get(ctx, "blocks/0x01")
+	require.NoError(t, err)
+	require.Equal(t, []byte(`{"number":2}`), data, "expected the data to be replaced")
+}
+
+func TestHTTPBatchMirror(t *testing.T) {
+	var mu sync.Mutex
+	files := make(map[string][]byte)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch r.Method {
+		case http.MethodPut:
+			data, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			files[r.URL.Path] = data
+		case http.MethodGet:
+			data, ok := files[r.URL.Path]
+			if !ok {
+				http.NotFound(w, r)
+				return
+			}
+			_, _ = w.Write(data)
+		}
+	}))
+	defer srv.Close()
+
+	testBatchMirror(t, NewHTTPBatchMirror(srv.URL+"/mirror/"))
+	require.Contains(t, files, "/mirror/blocks/0x01")
+}
+
+func TestIPFSBatchMirror(t *testing.T) {
+	var mu sync.Mutex
+	files := make(map[string][]byte)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		require.Equal(t, http.MethodPost, r.Method)
+		name := r.URL.Query().Get("arg")
+		switch r.URL.Path {
+		case "/api/v0/files/write":
+			require.Equal(t, "true", r.URL.Query().Get("parents"))
+			f, _, err := r.FormFile("file")
+			require.NoError(t, err)
+			data, err := io.ReadAll(f)
+			require.NoError(t, err)
+			files[name] = data
+		case "/api/v0/files/read":
+			data, ok := files[name]
+			if !ok {
+				w.WriteHeader(http.StatusInternalServerError)
+				_, _ = w.Write([]byte(`{"Message":"file does not exist","Code":0,"Type":"error"}`))
+				return
+			}
+			_, _ = w.Write(data)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	testBatchMirror(t, NewIPFSBatchMirror(srv.URL, "kroma/batches"))
+	require.Contains(t, files, "/kroma/batches/channels/0x02/03/0")
+
+	// other errors are not taken for missing data
+	_, err := NewIPFSBatchMirror(srv.URL+"/other", "kroma").Get(context.Background(), "blocks/0x01")
+	require.Error(t, err)
+	require.NotErrorIs(t, err, ethereum.NotFound)
+}
@@ -0,0 +1,40 @@
+package sources
+
+import (
+	"context"
+
+	"github.com/kroma-network/kroma/components/node/client"
+	"github.com/kroma-network/kroma/components/node/eth"
+)
+
+// BuilderClient requests proposed blocks from an external block builder over the engine API,
+// implementing the driver Builder interface.
+type BuilderClient struct {
+	rpc client.RPC
+}
+
+func NewBuilderClient(rpc client.RPC) *BuilderClient {
+	return &BuilderClient{rpc}
+}
+
+// ForkchoiceUpdate requests the builder to start building a block with the attributes on top of the head of fc.
+func (s *BuilderClient) ForkchoiceUpdate(ctx context.Context, fc *eth.ForkchoiceState, attributes *eth.PayloadAttributes) (*eth.ForkchoiceUpdatedResult, error) {
+	var result eth.ForkchoiceUpdatedResult
+	if err := s.rpc.CallContext(ctx, &result, "engine_forkchoiceUpdatedV1", fc, attributes); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetPayload returns the block the builder built for the payload ID.
+func (s *BuilderClient) GetPayload(ctx context.Context, payloadId eth.PayloadID) (*eth.ExecutionPayload, error) {
+	var result eth.ExecutionPayload
+	if err := s.rpc.CallContext(ctx, &result, "engine_getPayloadV1", payloadId); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (s *BuilderClient) Close() {
+	s.rpc.Close()
+}
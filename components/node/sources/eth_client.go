@@ -397,6 +397,27 @@ func (c *EthClient) GetProof(ctx context.Context, address common.Address, storag
 	return getProofResponse, nil
 }
 
+// EstimateGas returns the estimated gas limit of the given transaction call arguments.
+func (c *EthClient) EstimateGas(ctx context.Context, args any) (uint64, error) {
+	var gas hexutil.Uint64
+	err := c.client.CallContext(ctx, &gas, "eth_estimateGas", args)
+	return uint64(gas), err
+}
+
+// MaxPriorityFeePerGas returns the suggested gas tip cap of a transaction.
+func (c *EthClient) MaxPriorityFeePerGas(ctx context.Context) (*big.Int, error) {
+	var tip hexutil.Big
+	err := c.client.CallContext(ctx, &tip, "eth_maxPriorityFeePerGas")
+	return (*big.Int)(&tip), err
+}
+
+// TxPoolContent returns the pending and queued transactions of the transaction pool.
+func (c *EthClient) TxPoolContent(ctx context.Context) (*eth.TxPoolContent, error) {
+	var content eth.TxPoolContent
+	err := c.client.CallContext(ctx, &content, "txpool_content")
+	return &content, err
+}
+
 // GetStorageAt returns the storage value at the given address and storage slot, **without verifying the correctness of the result**.
 // This should only ever be used as alternative to GetProof when the user opts in.
 // E.g. Erigon L1 node users may have to use this, since Erigon does not support eth_getProof, see https://github.com/ledgerwatch/erigon/issues/1349
@@ -8,6 +8,7 @@ import (
 	"github.com/kroma-network/kroma/components/node/client"
 	"github.com/kroma-network/kroma/components/node/eth"
 	"github.com/kroma-network/kroma/components/node/rollup"
+	"github.com/kroma-network/kroma/components/node/rollup/derive"
 )
 
 type RollupClient struct {
@@ -30,6 +31,24 @@ func (r *RollupClient) OutputWithProofAtBlock(ctx context.Context, blockNum uint
 	return output, err
 }
 
+func (r *RollupClient) VerifyOutputProposal(ctx context.Context, outputRoot eth.Bytes32, blockNum uint64) (*eth.OutputVerificationResponse, error) {
+	var output *eth.OutputVerificationResponse
+	err := r.rpc.CallContext(ctx, &output, "kroma_verifyOutputProposal", outputRoot, hexutil.Uint64(blockNum))
+	return output, err
+}
+
+func (r *RollupClient) EstimateTotalFee(ctx context.Context, args eth.TxCallArgs) (*eth.FeeEstimate, error) {
+	var output *eth.FeeEstimate
+	err := r.rpc.CallContext(ctx, &output, "kroma_estimateTotalFee", args)
+	return output, err
+}
+
+func (r *RollupClient) PendingTransactions(ctx context.Context) (*eth.PendingTransactions, error) {
+	var output *eth.PendingTransactions
+	err := r.rpc.CallContext(ctx, &output, "kroma_pendingTransactions")
+	return output, err
+}
+
 func (r *RollupClient) SyncStatus(ctx context.Context) (*eth.SyncStatus, error) {
 	var output *eth.SyncStatus
 	err := r.rpc.CallContext(ctx, &output, "kroma_syncStatus")
@@ -47,3 +66,15 @@ func (r *RollupClient) Version(ctx context.Context) (string, error) {
 	err := r.rpc.CallContext(ctx, &output, "kroma_version")
 	return output, err
 }
+
+func (r *RollupClient) ProtocolVersion(ctx context.Context) (*eth.NodeProtocolVersion, error) {
+	var output *eth.NodeProtocolVersion
+	err := r.rpc.CallContext(ctx, &output, "kroma_protocolVersion")
+	return output, err
+}
+
+func (r *RollupClient) DerivationState(ctx context.Context) (*derive.DerivationState, error) {
+	var output *derive.DerivationState
+	err := r.rpc.CallContext(ctx, &output, "admin_derivationState")
+	return output, err
+}
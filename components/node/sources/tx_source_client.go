@@ -0,0 +1,31 @@
+package sources
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/kroma-network/kroma/components/node/client"
+	"github.com/kroma-network/kroma/components/node/eth"
+)
+
+// TxSourceClient pulls the ordered transactions of the next proposed block from an external ordering service,
+// e.g. a shared sequencing or MEV-mitigation layer, implementing the driver TxSource interface.
+type TxSourceClient struct {
+	rpc client.RPC
+}
+
+func NewTxSourceClient(rpc client.RPC) *TxSourceClient {
+	return &TxSourceClient{rpc}
+}
+
+// BlockTransactions returns the raw transactions to include, in order, in the block built on top of parent at timestamp.
+func (s *TxSourceClient) BlockTransactions(ctx context.Context, parent eth.L2BlockRef, timestamp uint64) ([]eth.Data, error) {
+	var txs []eth.Data
+	err := s.rpc.CallContext(ctx, &txs, "ordering_blockTransactions", parent.Hash, hexutil.Uint64(parent.Number+1), hexutil.Uint64(timestamp))
+	return txs, err
+}
+
+func (s *TxSourceClient) Close() {
+	s.rpc.Close()
+}
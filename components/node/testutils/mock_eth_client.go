@@ -2,6 +2,7 @@ package testutils
 
 import (
 	"context"
+	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -112,6 +113,33 @@ func (m *MockEthClient) ExpectGetProof(address common.Address, storage []common.
 	m.Mock.On("GetProof", address, storage, blockTag).Once().Return(result, &err)
 }
 
+func (m *MockEthClient) EstimateGas(ctx context.Context, args any) (uint64, error) {
+	out := m.Mock.MethodCalled("EstimateGas", args)
+	return out[0].(uint64), *out[1].(*error)
+}
+
+func (m *MockEthClient) ExpectEstimateGas(args any, gas uint64, err error) {
+	m.Mock.On("EstimateGas", args).Once().Return(gas, &err)
+}
+
+func (m *MockEthClient) MaxPriorityFeePerGas(ctx context.Context) (*big.Int, error) {
+	out := m.Mock.MethodCalled("MaxPriorityFeePerGas")
+	return out[0].(*big.Int), *out[1].(*error)
+}
+
+func (m *MockEthClient) ExpectMaxPriorityFeePerGas(tip *big.Int, err error) {
+	m.Mock.On("MaxPriorityFeePerGas").Once().Return(tip, &err)
+}
+
+func (m *MockEthClient) TxPoolContent(ctx context.Context) (*eth.TxPoolContent, error) {
+	out := m.Mock.MethodCalled("TxPoolContent")
+	return out[0].(*eth.TxPoolContent), *out[1].(*error)
+}
+
+func (m *MockEthClient) ExpectTxPoolContent(content *eth.TxPoolContent, err error) {
+	m.Mock.On("TxPoolContent").Once().Return(content, &err)
+}
+
 func (m *MockEthClient) GetStorageAt(ctx context.Context, address common.Address, storageSlot common.Hash, blockTag string) (common.Hash, error) {
 	return m.Mock.MethodCalled("GetStorageAt", address, storageSlot, blockTag).Get(0).(common.Hash), nil
 }
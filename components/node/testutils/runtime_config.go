@@ -1,11 +1,22 @@
 package testutils
 
-import "github.com/ethereum/go-ethereum/common"
+import (
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/kroma-network/kroma/components/node/eth"
+)
 
 type MockRuntimeConfig struct {
 	P2PPropAddress common.Address
+	// P2PSysCfgs are the SystemConfig values by L1 block number.
+	P2PSysCfgs map[uint64]eth.SystemConfig
 }
 
 func (m *MockRuntimeConfig) P2PProposerAddress() common.Address {
 	return m.P2PPropAddress
 }
+
+func (m *MockRuntimeConfig) P2PSystemConfig(l1Origin uint64) (eth.SystemConfig, bool) {
+	sysCfg, ok := m.P2PSysCfgs[l1Origin]
+	return sysCfg, ok
+}
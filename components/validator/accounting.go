@@ -0,0 +1,317 @@
+package validator
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/kroma-network/kroma/bindings/bindings"
+)
+
+// The actions of an operator address in the accounting report.
+const (
+	// The actions of the transactions sent by the operator, by the called method.
+	AccountingSubmission   = "submission"
+	AccountingChallenge    = "challenge"
+	AccountingBisect       = "bisect"
+	AccountingProve        = "prove"
+	AccountingConfirmation = "confirmation"
+	AccountingDeposit      = "deposit"
+	AccountingWithdrawal   = "withdrawal"
+	AccountingUnbond       = "unbond"
+	AccountingTransfer     = "transfer"
+	AccountingOther        = "other"
+
+	// The actions emitted by the contracts, possibly in the transactions of other accounts.
+	AccountingBond         = "bond"
+	AccountingBondIncrease = "bond-increase"
+	AccountingBondRelease  = "bond-release"
+	AccountingChallenged   = "challenged"
+	AccountingReward       = "reward"
+)
+
+// The sources of an accounting entry.
+const (
+	// AccountingSourceChain is an entry found on chain only.
+	AccountingSourceChain = "chain"
+	// AccountingSourceJournal is a sweep recorded in the journal, but not found on chain.
+	AccountingSourceJournal = "journal"
+	// AccountingSourceReconciled is a sweep recorded in the journal and found on chain.
+	AccountingSourceReconciled = "chain+journal"
+)
+
+// sweepJournalClockSkew is how long before the journal entry its transaction may be mined,
+// as the journal is timestamped with the local clock.
+const sweepJournalClockSkew = time.Minute
+
+// accountingActions are the actions of the methods of the L1 contracts called by the operator.
+var accountingActions = map[string]string{
+	"submitL2Output":     AccountingSubmission,
+	"createChallenge":    AccountingChallenge,
+	"bisect":             AccountingBisect,
+	"proveFault":         AccountingProve,
+	"confirmTransaction": AccountingConfirmation,
+	"deposit":            AccountingDeposit,
+	"withdraw":           AccountingWithdrawal,
+	"unbond":             AccountingUnbond,
+}
+
+// AccountingEntry is an action of an operator address.
+type AccountingEntry struct {
+	// Time is the timestamp of the block of the action, or of the journal entry if not found on chain.
+	Time uint64 `json:"time"`
+	// Layer is the chain of the action, "l1" or "l2". It is empty if not found on chain.
+	Layer   string         `json:"layer"`
+	Block   uint64         `json:"block"`
+	TxHash  common.Hash    `json:"txHash"`
+	Address common.Address `json:"address"`
+	Action  string         `json:"action"`
+	// To is the recipient of the transaction or of the sweep, zero for the actions emitted by the contracts.
+	To          common.Address `json:"to"`
+	OutputIndex *big.Int       `json:"outputIndex,omitempty"`
+	// GasUsed and GasCost (in wei) are only set on the transactions sent by the operator.
+	GasUsed uint64   `json:"gasUsed"`
+	GasCost *big.Int `json:"gasCost,omitempty"`
+	// Failed is true if the transaction reverted: it spent gas, but moved no funds.
+	Failed bool `json:"failed"`
+	// Bond is the bond (in wei) moved from (negative) or to (positive) the deposit of the operator in the ValidatorPool.
+	Bond *big.Int `json:"bond,omitempty"`
+	// Amount is the value (in wei) deposited, withdrawn, transferred or rewarded.
+	Amount *big.Int `json:"amount,omitempty"`
+	Source string   `json:"source"`
+}
+
+// AccountingContracts are the L1 contracts that the transactions of the operator are classified by.
+type AccountingContracts struct {
+	L2OutputOracle  common.Address
+	Colosseum       common.Address
+	ValidatorPool   common.Address
+	SecurityCouncil common.Address
+}
+
+// AccountingClassifier classifies the L1 transactions of the operator into accounting entries.
+type AccountingClassifier struct {
+	abis map[common.Address]*abi.ABI
+}
+
+func NewAccountingClassifier(contracts AccountingContracts) (*AccountingClassifier, error) {
+	c := &AccountingClassifier{abis: make(map[common.Address]*abi.ABI)}
+	for _, contract := range []struct {
+		addr common.Address
+		meta *bind.MetaData
+	}{
+		{contracts.L2OutputOracle, bindings.L2OutputOracleMetaData},
+		{contracts.Colosseum, bindings.ColosseumMetaData},
+		{contracts.ValidatorPool, bindings.ValidatorPoolMetaData},
+		{contracts.SecurityCouncil, bindings.SecurityCouncilMetaData},
+	} {
+		if contract.addr == (common.Address{}) {
+			continue
+		}
+		contractABI, err := contract.meta.GetAbi()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get ABI of %s: %w", contract.addr, err)
+		}
+		c.abis[contract.addr] = contractABI
+	}
+	return c, nil
+}
+
+// Classify returns the accounting entry of a transaction sent by an operator address,
+// mined in a block with the given timestamp.
+func (c *AccountingClassifier) Classify(tx *types.Transaction, from common.Address, receipt *types.Receipt, blockTime uint64) AccountingEntry {
+	entry := AccountingEntry{
+		Time:    blockTime,
+		Layer:   "l1",
+		Block:   receipt.BlockNumber.Uint64(),
+		TxHash:  tx.Hash(),
+		Address: from,
+		Action:  AccountingOther,
+		GasUsed: receipt.GasUsed,
+		Failed:  receipt.Status != types.ReceiptStatusSuccessful,
+		Source:  AccountingSourceChain,
+	}
+	if receipt.EffectiveGasPrice != nil {
+		entry.GasCost = new(big.Int).Mul(new(big.Int).SetUint64(receipt.GasUsed), receipt.EffectiveGasPrice)
+	}
+	if tx.To() == nil {
+		return entry
+	}
+	entry.To = *tx.To()
+
+	contractABI, ok := c.abis[*tx.To()]
+	if !ok {
+		if len(tx.Data()) == 0 && tx.Value().Sign() > 0 {
+			entry.Action = AccountingTransfer
+			entry.Amount = tx.Value()
+		}
+		return entry
+	}
+	if len(tx.Data()) < 4 {
+		return entry
+	}
+	method, err := contractABI.MethodById(tx.Data()[:4])
+	if err != nil {
+		return entry
+	}
+	action, ok := accountingActions[method.Name]
+	if !ok {
+		return entry
+	}
+	entry.Action = action
+
+	args := make(map[string]any)
+	if err := method.Inputs.UnpackIntoMap(args, tx.Data()[4:]); err != nil {
+		return entry
+	}
+	if outputIndex, ok := args["_outputIndex"].(*big.Int); ok {
+		entry.OutputIndex = outputIndex
+	}
+	switch action {
+	case AccountingDeposit:
+		entry.Amount = tx.Value()
+	case AccountingWithdrawal:
+		entry.Amount, _ = args["_amount"].(*big.Int)
+	}
+	return entry
+}
+
+// ReconcileSweepJournal matches the sweep journal to the withdrawal and transfer entries found on chain,
+// and returns the entries with the journal entries recorded in [from, to] that are not found on chain.
+// A journal entry is matched to the first unmatched successful transaction of its kind, from the same account,
+// to the same address and with the same amount, mined at or after the entry was recorded.
+func ReconcileSweepJournal(entries []AccountingEntry, journal []SweepJournalEntry, from, to uint64) []AccountingEntry {
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].Time < entries[j].Time })
+	for _, j := range journal {
+		recorded := uint64(j.Time.Unix())
+		if recorded < from || recorded > to {
+			continue
+		}
+		action := AccountingTransfer
+		if j.Kind == SweepKindWithdrawal {
+			action = AccountingWithdrawal
+		}
+		matched := false
+		for i := range entries {
+			e := &entries[i]
+			if e.Source != AccountingSourceChain || e.Action != action || e.Failed ||
+				e.Address != j.From || e.To != j.To || e.Amount == nil || e.Amount.Cmp(j.Amount.ToInt()) != 0 ||
+				e.Time+uint64(sweepJournalClockSkew/time.Second) < recorded {
+				continue
+			}
+			e.Source = AccountingSourceReconciled
+			matched = true
+			break
+		}
+		if !matched {
+			entries = append(entries, AccountingEntry{
+				Time:    recorded,
+				Address: j.From,
+				Action:  action,
+				To:      j.To,
+				Amount:  j.Amount.ToInt(),
+				Source:  AccountingSourceJournal,
+			})
+		}
+	}
+	return entries
+}
+
+// AccountingTotals are the totals of the entries of an operator address, in wei.
+type AccountingTotals struct {
+	Address            common.Address `json:"address"`
+	Transactions       int            `json:"transactions"`
+	FailedTransactions int            `json:"failedTransactions"`
+	GasCost            *big.Int       `json:"gasCost"`
+	// BondLocked and BondReleased are the bonds moved from and to the deposit in the ValidatorPool.
+	BondLocked   *big.Int `json:"bondLocked"`
+	BondReleased *big.Int `json:"bondReleased"`
+	Deposited    *big.Int `json:"deposited"`
+	Withdrawn    *big.Int `json:"withdrawn"`
+	Transferred  *big.Int `json:"transferred"`
+	Rewards      *big.Int `json:"rewards"`
+	// Unreconciled is the number of sweeps recorded in the journal, but not found on chain.
+	Unreconciled int `json:"unreconciled"`
+}
+
+// AccountingReport is the reconciled report of the actions of the operator addresses in a range of L1 blocks.
+type AccountingReport struct {
+	FromBlock uint64 `json:"fromBlock"`
+	ToBlock   uint64 `json:"toBlock"`
+	// Entries are sorted by time.
+	Entries []AccountingEntry  `json:"entries"`
+	Totals  []AccountingTotals `json:"totals"`
+}
+
+// BuildAccountingReport sorts the entries and totals them by operator address.
+// Every address is totaled, even if it has no entry.
+func BuildAccountingReport(entries []AccountingEntry, addrs []common.Address, fromBlock, toBlock uint64) AccountingReport {
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].Time != entries[j].Time {
+			return entries[i].Time < entries[j].Time
+		}
+		return entries[i].Block < entries[j].Block
+	})
+
+	totals := make([]AccountingTotals, len(addrs))
+	index := make(map[common.Address]int, len(addrs))
+	for i, addr := range addrs {
+		totals[i] = AccountingTotals{
+			Address:      addr,
+			GasCost:      new(big.Int),
+			BondLocked:   new(big.Int),
+			BondReleased: new(big.Int),
+			Deposited:    new(big.Int),
+			Withdrawn:    new(big.Int),
+			Transferred:  new(big.Int),
+			Rewards:      new(big.Int),
+		}
+		index[addr] = i
+	}
+	for _, e := range entries {
+		i, ok := index[e.Address]
+		if !ok {
+			continue
+		}
+		t := &totals[i]
+		if e.Source == AccountingSourceJournal {
+			t.Unreconciled++
+			continue
+		}
+		if e.GasCost != nil {
+			t.Transactions++
+			t.GasCost.Add(t.GasCost, e.GasCost)
+		}
+		if e.Failed {
+			t.FailedTransactions++
+			continue
+		}
+		if e.Bond != nil {
+			if e.Bond.Sign() < 0 {
+				t.BondLocked.Sub(t.BondLocked, e.Bond)
+			} else {
+				t.BondReleased.Add(t.BondReleased, e.Bond)
+			}
+		}
+		if e.Amount == nil {
+			continue
+		}
+		switch e.Action {
+		case AccountingDeposit:
+			t.Deposited.Add(t.Deposited, e.Amount)
+		case AccountingWithdrawal:
+			t.Withdrawn.Add(t.Withdrawn, e.Amount)
+		case AccountingTransfer:
+			t.Transferred.Add(t.Transferred, e.Amount)
+		case AccountingReward:
+			t.Rewards.Add(t.Rewards, e.Amount)
+		}
+	}
+	return AccountingReport{FromBlock: fromBlock, ToBlock: toBlock, Entries: entries, Totals: totals}
+}
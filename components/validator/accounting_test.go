@@ -0,0 +1,169 @@
+package validator
+
+import (
+	"math/big"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kroma-network/kroma/bindings/bindings"
+)
+
+func TestAccountingClassifier(t *testing.T) {
+	contracts := AccountingContracts{
+		L2OutputOracle: common.Address{0x01},
+		Colosseum:      common.Address{0x02},
+		ValidatorPool:  common.Address{0x03},
+	}
+	c, err := NewAccountingClassifier(contracts)
+	require.NoError(t, err)
+	colosseumABI, err := bindings.ColosseumMetaData.GetAbi()
+	require.NoError(t, err)
+	valPoolABI, err := bindings.ValidatorPoolMetaData.GetAbi()
+	require.NoError(t, err)
+
+	operator := common.Address{0xaa}
+	receipt := &types.Receipt{
+		Status:            types.ReceiptStatusSuccessful,
+		BlockNumber:       big.NewInt(100),
+		GasUsed:           50_000,
+		EffectiveGasPrice: big.NewInt(10),
+	}
+	classify := func(to *common.Address, value *big.Int, data []byte) AccountingEntry {
+		tx := types.NewTx(&types.DynamicFeeTx{To: to, Value: value, Data: data})
+		return c.Classify(tx, operator, receipt, 1000)
+	}
+
+	data, err := colosseumABI.Pack("createChallenge", big.NewInt(7), [][32]byte{})
+	require.NoError(t, err)
+	entry := classify(&contracts.Colosseum, big.NewInt(0), data)
+	require.Equal(t, AccountingChallenge, entry.Action)
+	require.Equal(t, big.NewInt(7), entry.OutputIndex)
+	require.Equal(t, uint64(100), entry.Block)
+	require.Equal(t, uint64(1000), entry.Time)
+	require.Equal(t, operator, entry.Address)
+	require.Equal(t, big.NewInt(500_000), entry.GasCost)
+	require.Equal(t, AccountingSourceChain, entry.Source)
+
+	data, err = valPoolABI.Pack("withdraw", big.NewInt(3))
+	require.NoError(t, err)
+	entry = classify(&contracts.ValidatorPool, big.NewInt(0), data)
+	require.Equal(t, AccountingWithdrawal, entry.Action)
+	require.Equal(t, big.NewInt(3), entry.Amount)
+	require.Equal(t, contracts.ValidatorPool, entry.To)
+
+	data, err = valPoolABI.Pack("deposit")
+	require.NoError(t, err)
+	entry = classify(&contracts.ValidatorPool, big.NewInt(5), data)
+	require.Equal(t, AccountingDeposit, entry.Action)
+	require.Equal(t, big.NewInt(5), entry.Amount)
+
+	sweepAddr := common.Address{0xcc}
+	entry = classify(&sweepAddr, big.NewInt(9), nil)
+	require.Equal(t, AccountingTransfer, entry.Action)
+	require.Equal(t, big.NewInt(9), entry.Amount)
+
+	// the SecurityCouncil is not set, so its methods are not known
+	entry = classify(&common.Address{0x04}, big.NewInt(0), []byte{0x01, 0x02, 0x03, 0x04})
+	require.Equal(t, AccountingOther, entry.Action)
+	entry = classify(&contracts.Colosseum, big.NewInt(0), []byte{0x01})
+	require.Equal(t, AccountingOther, entry.Action)
+
+	receipt.Status = types.ReceiptStatusFailed
+	entry = classify(&sweepAddr, big.NewInt(9), nil)
+	require.True(t, entry.Failed)
+}
+
+func TestReconcileSweepJournal(t *testing.T) {
+	operator, valPool, sweepAddr := common.Address{0xaa}, common.Address{0x03}, common.Address{0xcc}
+	entries := []AccountingEntry{
+		{Time: 2000, Address: operator, Action: AccountingTransfer, To: sweepAddr, Amount: big.NewInt(10), Source: AccountingSourceChain},
+		{Time: 1000, Address: operator, Action: AccountingWithdrawal, To: valPool, Amount: big.NewInt(10), Source: AccountingSourceChain},
+		// a manual transfer, not recorded in the journal
+		{Time: 1500, Address: operator, Action: AccountingTransfer, To: sweepAddr, Amount: big.NewInt(7), Source: AccountingSourceChain},
+	}
+	journal := []SweepJournalEntry{
+		{Time: time.Unix(1010, 0), Kind: SweepKindWithdrawal, From: operator, To: valPool, Amount: (*hexutil.Big)(big.NewInt(10))},
+		{Time: time.Unix(1990, 0), Kind: SweepKindTransfer, From: operator, To: sweepAddr, Amount: (*hexutil.Big)(big.NewInt(10))},
+		// a sweep that was not mined
+		{Time: time.Unix(2500, 0), Kind: SweepKindTransfer, From: operator, To: sweepAddr, Amount: (*hexutil.Big)(big.NewInt(20))},
+		// out of the reported range
+		{Time: time.Unix(5000, 0), Kind: SweepKindTransfer, From: operator, To: sweepAddr, Amount: (*hexutil.Big)(big.NewInt(30))},
+	}
+
+	entries = ReconcileSweepJournal(entries, journal, 900, 3000)
+	require.Len(t, entries, 4)
+	require.Equal(t, AccountingSourceReconciled, entries[0].Source, "withdrawal mined before the journal clock")
+	require.Equal(t, AccountingSourceChain, entries[1].Source)
+	require.Equal(t, AccountingSourceReconciled, entries[2].Source)
+	require.Equal(t, AccountingSourceJournal, entries[3].Source)
+	require.Equal(t, big.NewInt(20), entries[3].Amount)
+	require.Equal(t, uint64(2500), entries[3].Time)
+}
+
+func TestBuildAccountingReport(t *testing.T) {
+	alice, bob := common.Address{0xa}, common.Address{0xb}
+	entries := []AccountingEntry{
+		{Time: 30, Address: alice, Action: AccountingBondRelease, Bond: big.NewInt(200), Source: AccountingSourceChain},
+		{Time: 10, Address: alice, Action: AccountingSubmission, GasCost: big.NewInt(5), Source: AccountingSourceChain},
+		{Time: 10, Address: alice, Action: AccountingBond, Bond: big.NewInt(-100), Source: AccountingSourceChain},
+		{Time: 20, Address: alice, Action: AccountingWithdrawal, GasCost: big.NewInt(3), Amount: big.NewInt(50), Failed: true, Source: AccountingSourceChain},
+		{Time: 40, Address: alice, Action: AccountingReward, Layer: "l2", Amount: big.NewInt(8), Source: AccountingSourceChain},
+		{Time: 50, Address: alice, Action: AccountingTransfer, Amount: big.NewInt(60), Source: AccountingSourceJournal},
+		{Time: 15, Address: common.Address{0xc}, Action: AccountingDeposit, GasCost: big.NewInt(1), Amount: big.NewInt(1), Source: AccountingSourceChain},
+	}
+
+	report := BuildAccountingReport(entries, []common.Address{alice, bob}, 1, 2)
+	require.Equal(t, uint64(1), report.FromBlock)
+	require.Equal(t, uint64(2), report.ToBlock)
+	for i := 1; i < len(report.Entries); i++ {
+		require.LessOrEqual(t, report.Entries[i-1].Time, report.Entries[i].Time)
+	}
+	require.Len(t, report.Totals, 2)
+
+	a := report.Totals[0]
+	require.Equal(t, alice, a.Address)
+	require.Equal(t, 2, a.Transactions)
+	require.Equal(t, 1, a.FailedTransactions)
+	require.Equal(t, big.NewInt(8), a.GasCost)
+	require.Equal(t, big.NewInt(100), a.BondLocked)
+	require.Equal(t, big.NewInt(200), a.BondReleased)
+	require.Equal(t, big.NewInt(0), a.Withdrawn, "expected the failed withdrawal not to move funds")
+	require.Equal(t, big.NewInt(0), a.Transferred, "expected the unreconciled transfer not to move funds")
+	require.Equal(t, big.NewInt(8), a.Rewards)
+	require.Equal(t, 1, a.Unreconciled)
+
+	b := report.Totals[1]
+	require.Equal(t, bob, b.Address)
+	require.Zero(t, b.Transactions)
+	require.Equal(t, big.NewInt(0), b.GasCost)
+}
+
+func TestReadSweepJournal(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal.jsonl")
+	j, err := NewFileSweepJournal(path)
+	require.NoError(t, err)
+	entry := SweepJournalEntry{
+		Time:    time.Unix(1000, 0).UTC(),
+		Kind:    SweepKindTransfer,
+		From:    common.Address{0xaa},
+		To:      common.Address{0xcc},
+		Amount:  (*hexutil.Big)(big.NewInt(10)),
+		Balance: (*hexutil.Big)(big.NewInt(20)),
+	}
+	require.NoError(t, j.Record(entry))
+	require.NoError(t, j.Record(entry))
+	require.NoError(t, j.Close())
+
+	entries, err := ReadSweepJournal(path)
+	require.NoError(t, err)
+	require.Equal(t, []SweepJournalEntry{entry, entry}, entries)
+
+	_, err = ReadSweepJournal(filepath.Join(t.TempDir(), "missing.jsonl"))
+	require.ErrorContains(t, err, "failed to open sweep journal file")
+}
@@ -0,0 +1,53 @@
+package chaincfg
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Preset is the predefined configuration of the validator for a network.
+// Zero addresses are not preset, and must be configured explicitly.
+type Preset struct {
+	L1ChainID *big.Int
+	L2ChainID *big.Int
+
+	L2OutputOracleAddr  common.Address
+	ColosseumAddr       common.Address
+	ValidatorPoolAddr   common.Address
+	SecurityCouncilAddr common.Address
+
+	// OutputSubmitterBondAmount is the amount to bond when submitting each output (in wei).
+	OutputSubmitterBondAmount uint64
+}
+
+// Sepolia does not preset the ValidatorPool address, as it is not deployed on sepolia yet: it must be set explicitly.
+var Sepolia = Preset{
+	L1ChainID:                 big.NewInt(11155111),
+	L2ChainID:                 big.NewInt(2357),
+	L2OutputOracleAddr:        common.HexToAddress("0x29674FCFc8F24E96dE1c0caBf6366Be9E8A00FA1"),
+	ColosseumAddr:             common.HexToAddress("0xa27bAF0c3d9670375a00576234f8983029F81b91"),
+	OutputSubmitterBondAmount: 10_000_000_000_000_000, // 0.01 ETH
+}
+
+var NetworksByName = map[string]Preset{
+	"sepolia": Sepolia,
+}
+
+func AvailableNetworks() []string {
+	var networks []string
+	for name := range NetworksByName {
+		networks = append(networks, name)
+	}
+	return networks
+}
+
+func GetPreset(name string) (Preset, error) {
+	preset, ok := NetworksByName[name]
+	if !ok {
+		return Preset{}, fmt.Errorf("invalid network %s", name)
+	}
+
+	return preset, nil
+}
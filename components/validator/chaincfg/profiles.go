@@ -0,0 +1,102 @@
+package chaincfg
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Profile is a named set of the settings of the fees, the retries and the challenge strategy of the validator that
+// fit together, for operators to start with instead of tuning every setting on its own.
+type Profile struct {
+	// ResubmissionTimeout is how long to wait before bumping the fees of a pending transaction.
+	ResubmissionTimeout time.Duration
+	// StuckBumps and StuckTimeout are the fee bumps and the time after which a pending transaction is stuck, and its
+	// send is escalated. Transactions are never stuck if both are 0.
+	StuckBumps   uint64
+	StuckTimeout time.Duration
+	// StuckPriceBump is the fee bump in percent of a stuck transaction, and StuckMaxBumps the number of these bumps.
+	StuckPriceBump uint64
+	StuckMaxBumps  uint64
+	// GasOracleMaxDeviation is the maximum factor the suggestion of the gas oracle may deviate from L1 by.
+	GasOracleMaxDeviation float64
+
+	// GuardianMaxRetries is the number of failed validation attempts of a request retried, unlimited if 0.
+	GuardianMaxRetries int
+	// GuardianMaxConcurrentValidations is the number of the validation requests validated at once.
+	GuardianMaxConcurrentValidations int
+
+	// ChallengerCoordination is how an invalid output challenged by another challenger is handled.
+	ChallengerCoordination string
+	// ChallengerTakeoverMargin is how long before the deadline a stalling challenge is prepared to be taken over.
+	ChallengerTakeoverMargin time.Duration
+	// ChallengerProofMargin is how long before the proving deadline the failed proof requests are given up.
+	ChallengerProofMargin time.Duration
+}
+
+// Conservative spends less on fees, surfaces the failing validation requests to the operator early, and leaves more
+// time before the deadlines of the challenges.
+var Conservative = Profile{
+	ResubmissionTimeout:              72 * time.Second,
+	StuckPriceBump:                   25,
+	StuckMaxBumps:                    3,
+	GasOracleMaxDeviation:            1.5,
+	GuardianMaxRetries:               10,
+	GuardianMaxConcurrentValidations: 8,
+	ChallengerCoordination:           "hold-back",
+	ChallengerTakeoverMargin:         20 * time.Minute,
+	ChallengerProofMargin:            5 * time.Minute,
+}
+
+// Balanced is the defaults of the flags.
+var Balanced = Profile{
+	ResubmissionTimeout:              48 * time.Second,
+	StuckPriceBump:                   50,
+	StuckMaxBumps:                    5,
+	GasOracleMaxDeviation:            2,
+	GuardianMaxRetries:               30,
+	GuardianMaxConcurrentValidations: 16,
+	ChallengerCoordination:           "hold-back",
+	ChallengerTakeoverMargin:         10 * time.Minute,
+	ChallengerProofMargin:            2 * time.Minute,
+}
+
+// Aggressive lands the transactions fast at higher fees, retries the validation requests until they succeed, and
+// takes over the stalling challenges of other challengers.
+var Aggressive = Profile{
+	ResubmissionTimeout:              24 * time.Second,
+	StuckBumps:                       3,
+	StuckTimeout:                     5 * time.Minute,
+	StuckPriceBump:                   100,
+	StuckMaxBumps:                    3,
+	GasOracleMaxDeviation:            3,
+	GuardianMaxRetries:               0,
+	GuardianMaxConcurrentValidations: 32,
+	ChallengerCoordination:           "takeover",
+	ChallengerTakeoverMargin:         10 * time.Minute,
+	ChallengerProofMargin:            time.Minute,
+}
+
+var ProfilesByName = map[string]Profile{
+	"conservative": Conservative,
+	"balanced":     Balanced,
+	"aggressive":   Aggressive,
+}
+
+func AvailableProfiles() []string {
+	var profiles []string
+	for name := range ProfilesByName {
+		profiles = append(profiles, name)
+	}
+	sort.Strings(profiles)
+	return profiles
+}
+
+func GetProfile(name string) (Profile, error) {
+	profile, ok := ProfilesByName[name]
+	if !ok {
+		return Profile{}, fmt.Errorf("invalid profile %s", name)
+	}
+
+	return profile, nil
+}
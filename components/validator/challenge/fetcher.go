@@ -20,12 +20,13 @@ type Fetcher struct {
 	timeout time.Duration
 }
 
-func NewFetcher(grpcUrl string, timeout time.Duration, logger log.Logger) (*Fetcher, error) {
+func NewFetcher(grpcUrl string, timeout time.Duration, logger log.Logger, opts ...grpc.DialOption) (*Fetcher, error) {
 	if grpcUrl == "" {
 		return nil, fmt.Errorf("no grpc url specified")
 	}
 
-	conn, err := grpc.Dial(grpcUrl, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	opts = append([]grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}, opts...)
+	conn, err := grpc.Dial(grpcUrl, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to grpc server: %w", err)
 	}
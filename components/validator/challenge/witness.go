@@ -0,0 +1,46 @@
+package challenge
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/kroma-network/kroma/components/node/eth"
+)
+
+// FileWitnessProvider serves precomputed witnesses from a directory.
+// Each witness is stored as the JSON encoded output response in a file named <blockNumber>.json.
+type FileWitnessProvider struct {
+	dir string
+}
+
+func NewFileWitnessProvider(dir string) (*FileWitnessProvider, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open witness dir: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("witness dir %s is not a directory", dir)
+	}
+
+	return &FileWitnessProvider{dir: dir}, nil
+}
+
+func (f *FileWitnessProvider) OutputWithProofAtBlock(_ context.Context, blockNumber uint64) (*eth.OutputResponse, error) {
+	data, err := os.ReadFile(filepath.Join(f.dir, fmt.Sprintf("%d.json", blockNumber)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read witness: %w", err)
+	}
+
+	var output eth.OutputResponse
+	if err := json.Unmarshal(data, &output); err != nil {
+		return nil, fmt.Errorf("failed to decode witness: %w", err)
+	}
+	if output.PublicInputProof == nil {
+		return nil, fmt.Errorf("witness at block %d has no public input proof", blockNumber)
+	}
+
+	return &output, nil
+}
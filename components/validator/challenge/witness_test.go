@@ -0,0 +1,63 @@
+package challenge
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kroma-network/kroma/components/node/eth"
+)
+
+func writeWitness(t *testing.T, dir string, name string, output *eth.OutputResponse) {
+	data, err := json.Marshal(output)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name), data, 0o644))
+}
+
+func TestFileWitnessProvider(t *testing.T) {
+	dir := t.TempDir()
+	output := &eth.OutputResponse{
+		OutputRoot: eth.Bytes32{0x01},
+		BlockRef:   eth.L2BlockRef{Number: 100, Hash: common.Hash{0x02}},
+		PublicInputProof: &eth.PublicInputProof{
+			L2ToL1MessagePasserCodeHash: common.Hash{0x03},
+		},
+	}
+	writeWitness(t, dir, "100.json", output)
+	writeWitness(t, dir, "101.json", &eth.OutputResponse{OutputRoot: eth.Bytes32{0x04}})
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "102.json"), []byte("{"), 0o644))
+
+	p, err := NewFileWitnessProvider(dir)
+	require.NoError(t, err)
+
+	got, err := p.OutputWithProofAtBlock(context.Background(), 100)
+	require.NoError(t, err)
+	require.Equal(t, output.OutputRoot, got.OutputRoot)
+	require.Equal(t, output.BlockRef, got.BlockRef)
+	require.Equal(t, output.PublicInputProof.L2ToL1MessagePasserCodeHash, got.PublicInputProof.L2ToL1MessagePasserCodeHash)
+
+	_, err = p.OutputWithProofAtBlock(context.Background(), 101)
+	require.ErrorContains(t, err, "no public input proof")
+
+	_, err = p.OutputWithProofAtBlock(context.Background(), 102)
+	require.ErrorContains(t, err, "failed to decode witness")
+
+	_, err = p.OutputWithProofAtBlock(context.Background(), 103)
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestNewFileWitnessProvider(t *testing.T) {
+	dir := t.TempDir()
+	_, err := NewFileWitnessProvider(filepath.Join(dir, "missing"))
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	file := filepath.Join(dir, "file")
+	require.NoError(t, os.WriteFile(file, nil, 0o644))
+	_, err = NewFileWitnessProvider(file)
+	require.ErrorContains(t, err, "is not a directory")
+}
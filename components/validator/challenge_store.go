@@ -0,0 +1,288 @@
+package validator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	ds "github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+	leveldb "github.com/ipfs/go-ds-leveldb"
+
+	"github.com/kroma-network/kroma/bindings/bindings"
+	chal "github.com/kroma-network/kroma/components/validator/challenge"
+)
+
+// challengesKey is the key prefix of the challenges in the challenge store.
+var challengesKey = ds.NewKey("/challenges")
+
+// The kinds of the transactions crafted for a challenge.
+const (
+	ChallengeTxCreate = "create"
+	ChallengeTxBisect = "bisect"
+	ChallengeTxProve  = "prove"
+)
+
+// ChallengeSubmission is a transaction crafted for a turn of a challenge, recorded to not compute it again after a
+// restart while it may still be pending.
+type ChallengeSubmission struct {
+	Kind string `json:"kind"`
+	Turn uint8  `json:"turn"`
+	// Answers is the hash of the segments on chain the transaction answers, zero for the creation of the challenge.
+	Answers common.Hash `json:"answers"`
+	// Position is the fault position selected in the answered segments, nil for the creation of the challenge.
+	Position *big.Int `json:"position,omitempty"`
+	// SegStart, SegSize and Segments are the submitted segments, none for the proof of the fault.
+	SegStart uint64        `json:"segStart"`
+	SegSize  uint64        `json:"segSize"`
+	Segments []common.Hash `json:"segments,omitempty"`
+	Time     time.Time     `json:"time"`
+}
+
+// ChallengeRecord is the state of a challenge the validator takes part in, as it was last observed.
+type ChallengeRecord struct {
+	OutputIndex *big.Int       `json:"outputIndex"`
+	Asserter    common.Address `json:"asserter"`
+	Challenger  common.Address `json:"challenger"`
+	Status      uint8          `json:"status"`
+	Turn        uint8          `json:"turn"`
+	TimeoutAt   uint64         `json:"timeoutAt"`
+	// Submissions are the transactions crafted for the challenge, in the order they were crafted.
+	Submissions []ChallengeSubmission `json:"submissions"`
+	// Done is whether the challenge ended, so it is not resumed after a restart.
+	Done    bool      `json:"done"`
+	Updated time.Time `json:"updated"`
+}
+
+// submission returns the latest submission of the kind answering the segments of the turn, nil if there is none.
+func (r *ChallengeRecord) submission(kind string, turn uint8, answers common.Hash) *ChallengeSubmission {
+	for i := len(r.Submissions) - 1; i >= 0; i-- {
+		s := &r.Submissions[i]
+		if s.Kind == kind && s.Turn == turn && s.Answers == answers {
+			return s
+		}
+	}
+	return nil
+}
+
+// ChallengeStore records the state of the challenges of the validator, to resume them where they were left off after
+// a restart.
+type ChallengeStore interface {
+	// Challenge returns the record of the challenge of the output, nil if there is none.
+	Challenge(ctx context.Context, outputIndex *big.Int) (*ChallengeRecord, error)
+	// RecordChallenge records the challenge, replacing an earlier record of it.
+	RecordChallenge(ctx context.Context, record *ChallengeRecord) error
+	// Challenges returns all recorded challenges, in the order of the output indexes.
+	Challenges(ctx context.Context) ([]*ChallengeRecord, error)
+	Close() error
+}
+
+// datastoreChallengeStore is a ChallengeStore of JSON encoded records, keyed by output index.
+type datastoreChallengeStore struct {
+	store ds.Datastore
+}
+
+// NewChallengeStore opens the leveldb challenge store at the path, created if it does not exist.
+func NewChallengeStore(path string) (ChallengeStore, error) {
+	store, err := leveldb.NewDatastore(path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open challenge store %s: %w", path, err)
+	}
+	return &datastoreChallengeStore{store: store}, nil
+}
+
+func challengeKey(outputIndex *big.Int) ds.Key {
+	return challengesKey.ChildString(outputIndex.String())
+}
+
+func (s *datastoreChallengeStore) Challenge(ctx context.Context, outputIndex *big.Int) (*ChallengeRecord, error) {
+	data, err := s.store.Get(ctx, challengeKey(outputIndex))
+	if errors.Is(err, ds.ErrNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read challenge of output %s: %w", outputIndex, err)
+	}
+	var record ChallengeRecord
+	if err := json.Unmarshal(data, &record); err != nil {
+		return nil, fmt.Errorf("failed to decode challenge of output %s: %w", outputIndex, err)
+	}
+	return &record, nil
+}
+
+func (s *datastoreChallengeStore) RecordChallenge(ctx context.Context, record *ChallengeRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	if err := s.store.Put(ctx, challengeKey(record.OutputIndex), data); err != nil {
+		return fmt.Errorf("failed to record challenge of output %s: %w", record.OutputIndex, err)
+	}
+	return nil
+}
+
+func (s *datastoreChallengeStore) Challenges(ctx context.Context) ([]*ChallengeRecord, error) {
+	results, err := s.store.Query(ctx, query.Query{Prefix: challengesKey.String()})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query challenges: %w", err)
+	}
+	defer results.Close()
+	var records []*ChallengeRecord
+	for res := range results.Next() {
+		if res.Error != nil {
+			return nil, fmt.Errorf("failed to read challenges: %w", res.Error)
+		}
+		var record ChallengeRecord
+		if err := json.Unmarshal(res.Value, &record); err != nil {
+			return nil, fmt.Errorf("failed to decode challenge %s: %w", res.Key, err)
+		}
+		records = append(records, &record)
+	}
+	sort.Slice(records, func(i, j int) bool { return records[i].OutputIndex.Cmp(records[j].OutputIndex) < 0 })
+	return records, nil
+}
+
+func (s *datastoreChallengeStore) Close() error {
+	return s.store.Close()
+}
+
+// segmentsHash returns the hash identifying the segments on chain.
+func segmentsHash(segStart, segSize uint64, hashes []chal.Hash) common.Hash {
+	data := append(common.BigToHash(new(big.Int).SetUint64(segStart)).Bytes(), common.BigToHash(new(big.Int).SetUint64(segSize)).Bytes()...)
+	for _, h := range hashes {
+		data = append(data, h[:]...)
+	}
+	return crypto.Keccak256Hash(data)
+}
+
+func toCommonHashes(hashes []chal.Hash) []common.Hash {
+	converted := make([]common.Hash, len(hashes))
+	for i, h := range hashes {
+		converted[i] = h
+	}
+	return converted
+}
+
+func toSegmentHashes(hashes []common.Hash) []chal.Hash {
+	converted := make([]chal.Hash, len(hashes))
+	for i, h := range hashes {
+		converted[i] = h
+	}
+	return converted
+}
+
+// challengeRecord returns the record of the challenge of the output, nil if there is none or the challenges are not
+// recorded.
+func (c *Challenger) challengeRecord(outputIndex *big.Int) *ChallengeRecord {
+	if c.store == nil {
+		return nil
+	}
+	c.storeMu.Lock()
+	defer c.storeMu.Unlock()
+	record, err := c.store.Challenge(context.Background(), outputIndex)
+	if err != nil {
+		// computing the state of the challenge again is safer than skipping it
+		c.log.Error("failed to read challenge", "err", err, "outputIndex", outputIndex)
+		return nil
+	}
+	return record
+}
+
+// updateChallenge applies the change to the record of the challenge of the output, created if there is none.
+func (c *Challenger) updateChallenge(outputIndex *big.Int, change func(r *ChallengeRecord)) {
+	if c.store == nil {
+		return
+	}
+	c.storeMu.Lock()
+	defer c.storeMu.Unlock()
+	// the record is updated even if the challenger is stopping, e.g. right after a transaction was queued
+	ctx := context.Background()
+	record, err := c.store.Challenge(ctx, outputIndex)
+	if err != nil {
+		c.log.Error("failed to read challenge", "err", err, "outputIndex", outputIndex)
+		return
+	}
+	if record == nil {
+		record = &ChallengeRecord{OutputIndex: outputIndex}
+	}
+	change(record)
+	record.Updated = time.Now()
+	if err := c.store.RecordChallenge(ctx, record); err != nil {
+		c.log.Error("failed to record challenge", "err", err, "outputIndex", outputIndex)
+	}
+}
+
+// observeChallenge records the state of the challenge on chain. A challenge created again for the output after an
+// earlier one ended starts a new record.
+func (c *Challenger) observeChallenge(outputIndex *big.Int, challenge bindings.TypesChallenge, status uint8) {
+	c.updateChallenge(outputIndex, func(r *ChallengeRecord) {
+		if r.Done && !isInactivated(status) {
+			*r = ChallengeRecord{OutputIndex: outputIndex}
+		}
+		r.Asserter, r.Challenger = challenge.Asserter, challenge.Challenger
+		r.Status, r.Turn, r.TimeoutAt = status, challenge.Turn, challenge.TimeoutAt
+		r.Done = isInactivated(status)
+	})
+}
+
+// recordSubmission records the transaction crafted for the challenge of the output.
+func (c *Challenger) recordSubmission(outputIndex *big.Int, submission ChallengeSubmission) {
+	submission.Time = time.Now()
+	c.updateChallenge(outputIndex, func(r *ChallengeRecord) {
+		r.Submissions = append(r.Submissions, submission)
+	})
+}
+
+// recordedSubmission returns the recorded submission of the kind answering the segments of the turn of the challenge
+// of the output, nil if there is none.
+func (c *Challenger) recordedSubmission(outputIndex *big.Int, kind string, turn uint8, answers common.Hash) *ChallengeSubmission {
+	record := c.challengeRecord(outputIndex)
+	if record == nil {
+		return nil
+	}
+	return record.submission(kind, turn, answers)
+}
+
+// resumeChallenges handles the recorded challenges that did not end, right away on start instead of once their
+// events are scanned again.
+func (c *Challenger) resumeChallenges(ctx context.Context) error {
+	if c.store == nil {
+		return nil
+	}
+	records, err := c.store.Challenges(ctx)
+	if err != nil {
+		return err
+	}
+	for _, r := range records {
+		if r.Done {
+			continue
+		}
+		c.log.Info("resuming challenge", "outputIndex", r.OutputIndex, "status", r.Status, "turn", r.Turn,
+			"timeoutAt", time.Unix(int64(r.TimeoutAt), 0), "submissions", len(r.Submissions))
+		c.startHandleChallenge(ctx, r.OutputIndex)
+	}
+	return nil
+}
+
+// startHandleChallenge handles the challenge of the output, unless it is handled already.
+func (c *Challenger) startHandleChallenge(ctx context.Context, outputIndex *big.Int) {
+	key := outputIndex.String()
+	c.handlingMu.Lock()
+	defer c.handlingMu.Unlock()
+	if _, ok := c.handling[key]; ok {
+		return
+	}
+	c.handling[key] = struct{}{}
+	c.wg.Add(1)
+	go func() {
+		c.handleChallenge(ctx, outputIndex)
+		c.handlingMu.Lock()
+		delete(c.handling, key)
+		c.handlingMu.Unlock()
+	}()
+}
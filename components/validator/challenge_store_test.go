@@ -0,0 +1,95 @@
+package validator
+
+import (
+	"context"
+	"math/big"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kroma-network/kroma/bindings/bindings"
+	"github.com/kroma-network/kroma/components/node/testlog"
+	chal "github.com/kroma-network/kroma/components/validator/challenge"
+)
+
+func TestChallengeStore(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "store")
+	store, err := NewChallengeStore(path)
+	require.NoError(t, err)
+
+	record, err := store.Challenge(ctx, big.NewInt(1))
+	require.NoError(t, err)
+	require.Nil(t, record)
+
+	recorded := []*ChallengeRecord{
+		{OutputIndex: big.NewInt(12), Status: chal.StatusChallengerTurn, Turn: 1, TimeoutAt: 1700000600},
+		{OutputIndex: big.NewInt(2), Status: chal.StatusChallengerTurn, Turn: 1},
+		{OutputIndex: big.NewInt(2), Status: chal.StatusAsserterTurn, Turn: 2, Submissions: []ChallengeSubmission{
+			{Kind: ChallengeTxBisect, Turn: 2, Answers: common.Hash{0x1}, Position: big.NewInt(3), SegStart: 10, SegSize: 5, Segments: []common.Hash{{0x2}, {0x3}}},
+		}},
+	}
+	for _, r := range recorded {
+		r.Updated = time.Unix(1700000000, 0).UTC()
+		for i := range r.Submissions {
+			r.Submissions[i].Time = r.Updated
+		}
+		require.NoError(t, store.RecordChallenge(ctx, r))
+	}
+	require.NoError(t, store.Close())
+
+	// the challenges survive a restart
+	store, err = NewChallengeStore(path)
+	require.NoError(t, err)
+	defer store.Close()
+	records, err := store.Challenges(ctx)
+	require.NoError(t, err)
+	require.Len(t, records, 2, "expected a later record to replace the earlier one")
+	require.Equal(t, recorded[2], records[0], "expected the challenges in the order of the output indexes")
+	require.Equal(t, recorded[0], records[1])
+}
+
+func TestChallengerRecordsChallenge(t *testing.T) {
+	store, err := NewChallengeStore(filepath.Join(t.TempDir(), "store"))
+	require.NoError(t, err)
+	defer store.Close()
+	c := &Challenger{log: testlog.Logger(t, log.LvlCrit), store: store}
+	outputIndex := big.NewInt(5)
+
+	segments := []chal.Hash{{0x1}, {0x2}, {0x3}}
+	answers := segmentsHash(100, 10, segments)
+	require.NotEqual(t, answers, segmentsHash(100, 20, segments), "expected the range to identify the segments")
+
+	challenge := bindings.TypesChallenge{Turn: 1, TimeoutAt: 1700000600, Asserter: common.Address{0xa}, Challenger: common.Address{0xc}}
+	c.observeChallenge(outputIndex, challenge, chal.StatusChallengerTurn)
+	c.recordSubmission(outputIndex, ChallengeSubmission{Kind: ChallengeTxBisect, Turn: 2, Answers: answers, Position: big.NewInt(1)})
+
+	submission := c.recordedSubmission(outputIndex, ChallengeTxBisect, 2, answers)
+	require.NotNil(t, submission)
+	require.Equal(t, big.NewInt(1), submission.Position)
+	require.Nil(t, c.recordedSubmission(outputIndex, ChallengeTxBisect, 2, common.Hash{0x1}), "expected other segments not to match")
+	require.Nil(t, c.recordedSubmission(outputIndex, ChallengeTxProve, 2, answers))
+
+	record := c.challengeRecord(outputIndex)
+	require.Equal(t, common.Address{0xc}, record.Challenger)
+	require.Equal(t, uint64(1700000600), record.TimeoutAt)
+	require.False(t, record.Done)
+
+	// an ended challenge is not resumed, and a new challenge of the output starts a new record
+	c.observeChallenge(outputIndex, challenge, chal.StatusProven)
+	require.True(t, c.challengeRecord(outputIndex).Done)
+	require.NotNil(t, c.recordedSubmission(outputIndex, ChallengeTxBisect, 2, answers))
+	c.observeChallenge(outputIndex, challenge, chal.StatusChallengerTurn)
+	record = c.challengeRecord(outputIndex)
+	require.False(t, record.Done)
+	require.Empty(t, record.Submissions)
+
+	// the challenges are not recorded without a store
+	c.store = nil
+	c.recordSubmission(outputIndex, ChallengeSubmission{Kind: ChallengeTxProve})
+	require.Nil(t, c.challengeRecord(outputIndex))
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"math/big"
 	"sync"
 	"time"
@@ -15,14 +16,15 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/math"
 	"github.com/ethereum/go-ethereum/core/types"
-	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/log"
 
 	"github.com/kroma-network/kroma/bindings/bindings"
 	"github.com/kroma-network/kroma/components/node/eth"
 	chal "github.com/kroma-network/kroma/components/validator/challenge"
+	"github.com/kroma-network/kroma/components/validator/metrics"
 	"github.com/kroma-network/kroma/utils"
+	"github.com/kroma-network/kroma/utils/service/backoff"
 	"github.com/kroma-network/kroma/utils/service/txmgr"
 )
 
@@ -31,26 +33,65 @@ type ProofFetcher interface {
 	Close() error
 }
 
+// WitnessProvider provides the outputs with the public input proof that are required for ZK proving.
+// It allows the challenger to fetch this data from a dedicated witness service or precomputed artifacts,
+// instead of requiring the rollup node to be backed by an archive kroma-geth.
+type WitnessProvider interface {
+	OutputWithProofAtBlock(ctx context.Context, blockNumber uint64) (*eth.OutputResponse, error)
+}
+
 type Challenger struct {
 	log    log.Logger
+	metr   metrics.Metricer
 	cfg    Config
 	ctx    context.Context
 	cancel context.CancelFunc
 
-	l1Client *ethclient.Client
+	l1Client        *LimitedL1Client
+	witnessProvider WitnessProvider
+	// sweeper sweeps the recovered funds to the sweep address, nil if the sweep is disabled.
+	sweeper *sweeper
 
 	l2ooContract      *bindings.L2OutputOracle
 	l2ooABI           *abi.ABI
 	colosseumContract *bindings.Colosseum
 	colosseumABI      *abi.ABI
+	// depositPool is the ValidatorPool the bonds of the challenges are taken from.
+	depositPool ChallengeDepositPool
+	deposit     challengeDepositState
+	valPoolABI  *abi.ABI
 
 	submissionInterval        *big.Int
 	finalizationPeriodSeconds *big.Int
 	l2BlockTime               *big.Int
 	checkpoint                *big.Int
 
+	// provingTimeout is the proving timeout of the Colosseum, fetched on first use, zero until then,
+	// so that the roles not taking part in the challenges do not require the Colosseum.
+	provingTimeout   time.Duration
+	provingTimeoutMu sync.Mutex
+
+	// proofRetryStrategy is the backoff of the retries of the failed proof requests
+	proofRetryStrategy backoff.Strategy
+	// proofEstimator estimates whether the proofs can be generated before the deadlines
+	proofEstimator *proofEstimator
+
+	// store records the state of the challenges, optional (may be nil)
+	store   ChallengeStore
+	storeMu sync.Mutex
+	// handling are the output indexes of the challenges being handled
+	handling   map[string]struct{}
+	handlingMu sync.Mutex
+	// turns are the challenge turns in progress, finished before the challenger is stopped
+	turns workTracker
+
 	l2OutputSub  ethereum.Subscription
 	challengeSub ethereum.Subscription
+	// state is whether the previous outputs are scanned, and the subscription healths whether the subscriptions are
+	// active, reported as the health of the challenger
+	state              roleState
+	l2OutputSubHealth  subscriptionHealth
+	challengeSubHealth subscriptionHealth
 
 	txCandidatesChan           chan<- txmgr.TxCandidate
 	l2OutputSubmittedEventChan chan *bindings.L2OutputOracleOutputSubmitted
@@ -59,8 +100,9 @@ type Challenger struct {
 	wg sync.WaitGroup
 }
 
-func NewChallenger(ctx context.Context, cfg Config, l log.Logger) (*Challenger, error) {
-	colosseumContract, err := bindings.NewColosseum(cfg.ColosseumAddr, cfg.L1Client)
+func NewChallenger(ctx context.Context, cfg Config, l log.Logger, m metrics.Metricer) (*Challenger, error) {
+	l1Client := cfg.L1Limiter.Client(L1RoleChallenger, cfg.L1Client)
+	colosseumContract, err := bindings.NewColosseum(cfg.ColosseumAddr, l1Client)
 	if err != nil {
 		return nil, err
 	}
@@ -70,7 +112,7 @@ func NewChallenger(ctx context.Context, cfg Config, l log.Logger) (*Challenger,
 		return nil, err
 	}
 
-	l2ooContract, err := bindings.NewL2OutputOracle(cfg.L2OutputOracleAddr, cfg.L1Client)
+	l2ooContract, err := bindings.NewL2OutputOracle(cfg.L2OutputOracleAddr, l1Client)
 	if err != nil {
 		return nil, err
 	}
@@ -94,20 +136,62 @@ func NewChallenger(ctx context.Context, cfg Config, l log.Logger) (*Challenger,
 		return nil, fmt.Errorf("failed to get l2 block time: %w", err)
 	}
 
+	// If no witness provider is configured, the witness is fetched from the rollup node.
+	var witnessProvider WitnessProvider = cfg.RollupClient
+	if cfg.WitnessProvider != nil {
+		witnessProvider = cfg.WitnessProvider
+	}
+
+	valPoolContract, err := bindings.NewValidatorPoolCaller(cfg.ValidatorPoolAddr, l1Client)
+	if err != nil {
+		return nil, err
+	}
+	valPoolABI, err := bindings.ValidatorPoolMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+
+	var s *sweeper
+	if cfg.Sweep.Enabled() {
+		s, err = newSweeper(l, m, cfg.Sweep, cfg.TxManager.From(), cfg.ValidatorPoolAddr, valPoolContract, l1Client, cfg.NetworkTimeout)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var store ChallengeStore
+	if cfg.ChallengerStorePath != "" {
+		store, err = NewChallengeStore(cfg.ChallengerStorePath)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return &Challenger{
-		log: l,
-		cfg: cfg,
+		log:  l,
+		metr: m,
+		cfg:  cfg,
 
-		l1Client: cfg.L1Client,
+		l1Client:        l1Client,
+		witnessProvider: witnessProvider,
+		sweeper:         s,
 
 		l2ooContract:      l2ooContract,
 		l2ooABI:           l2ooABI,
 		colosseumContract: colosseumContract,
 		colosseumABI:      colosseumABI,
+		depositPool:       valPoolContract,
+		valPoolABI:        valPoolABI,
 
 		submissionInterval:        submissionInterval,
 		finalizationPeriodSeconds: finalizationPeriodSeconds,
 		l2BlockTime:               l2BlockTime,
+
+		proofRetryStrategy: newProofRetryStrategy(),
+		proofEstimator:     newProofEstimator(cfg.ChallengerProverThroughput),
+
+		store:    store,
+		handling: make(map[string]struct{}),
 	}, nil
 }
 
@@ -116,20 +200,20 @@ func (c *Challenger) initSub(ctx context.Context) {
 	opts := &bind.WatchOpts{Context: ctx}
 
 	if !c.cfg.ChallengerDisabled {
-		c.l2OutputSub = event.ResubscribeErr(time.Second*10, func(ctx context.Context, err error) (event.Subscription, error) {
+		c.l2OutputSub = event.ResubscribeErr(time.Second*10, c.l2OutputSubHealth.track(func(ctx context.Context, err error) (event.Subscription, error) {
 			if err != nil {
 				c.log.Warn("resubscribing after failed L2OutputSubmitted event", "err", err)
 			}
 			return c.l2ooContract.WatchOutputSubmitted(opts, c.l2OutputSubmittedEventChan, nil, nil, nil)
-		})
+		}))
 	}
 
-	c.challengeSub = event.ResubscribeErr(time.Second*10, func(ctx context.Context, err error) (event.Subscription, error) {
+	c.challengeSub = event.ResubscribeErr(time.Second*10, c.challengeSubHealth.track(func(ctx context.Context, err error) (event.Subscription, error) {
 		if err != nil {
 			c.log.Warn("resubscribing after failed ChallengeCreated event", "err", err)
 		}
 		return c.colosseumContract.WatchChallengeCreated(opts, c.challengeCreatedEventChan, nil, nil, nil)
-	})
+	}))
 }
 
 func (c *Challenger) Start(ctx context.Context, txCandidatesChan chan<- txmgr.TxCandidate) error {
@@ -140,6 +224,7 @@ func (c *Challenger) Start(ctx context.Context, txCandidatesChan chan<- txmgr.Tx
 	c.l2OutputSubmittedEventChan = make(chan *bindings.L2OutputOracleOutputSubmitted)
 	c.challengeCreatedEventChan = make(chan *bindings.ColosseumChallengeCreated)
 	c.txCandidatesChan = txCandidatesChan
+	c.state.set(RoleStatusSyncing)
 	c.initSub(c.ctx)
 
 	// if checkpoint is behind the latest output index, scan the previous outputs from the checkpoint
@@ -155,9 +240,14 @@ func (c *Challenger) Start(ctx context.Context, txCandidatesChan chan<- txmgr.Tx
 		c.checkpoint = new(big.Int).Sub(nextOutputIndex, common.Big1)
 	}
 
+	if err := c.resumeChallenges(c.ctx); err != nil {
+		return fmt.Errorf("failed to resume recorded challenges: %w", err)
+	}
+
 	if err := c.scanPrevOutputs(c.ctx); err != nil {
 		return fmt.Errorf("failed to scan previous outputs: %w", err)
 	}
+	c.state.set(RoleStatusSynced)
 
 	// if challenge mode on, subscribe L2 output submission events
 	if !c.cfg.ChallengerDisabled {
@@ -169,6 +259,11 @@ func (c *Challenger) Start(ctx context.Context, txCandidatesChan chan<- txmgr.Tx
 	c.wg.Add(1)
 	go c.subscribeChallengeCreated(c.ctx)
 
+	if c.sweeper != nil {
+		c.wg.Add(1)
+		go c.sweepLoop(c.ctx)
+	}
+
 	return nil
 }
 
@@ -189,6 +284,18 @@ func (c *Challenger) Stop() error {
 	close(c.l2OutputSubmittedEventChan)
 	close(c.challengeCreatedEventChan)
 
+	if c.store != nil {
+		if err := c.store.Close(); err != nil {
+			return fmt.Errorf("failed to close challenge store: %w", err)
+		}
+	}
+
+	if closer, ok := c.cfg.Sweep.Journal.(io.Closer); ok {
+		if err := closer.Close(); err != nil {
+			return fmt.Errorf("failed to close sweep journal: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -243,8 +350,7 @@ func (c *Challenger) scanPrevOutputs(ctx context.Context) error {
 		case c.cfg.ColosseumAddr:
 			ev := NewChallengeCreatedEvent(vLog)
 			if ev.OutputIndex.Sign() == 1 && c.isRelatedChallenge(ev.Asserter, ev.Challenger) {
-				c.wg.Add(1)
-				go c.handleChallenge(ctx, ev.OutputIndex)
+				c.startHandleChallenge(ctx, ev.OutputIndex)
 			}
 		default:
 			c.log.Warn("unknown event log", "logs", vLog)
@@ -285,8 +391,7 @@ func (c *Challenger) subscribeChallengeCreated(ctx context.Context) {
 		case ev := <-c.challengeCreatedEventChan:
 			// when challenge created, handle it
 			if ev.OutputIndex.Sign() == 1 && c.isRelatedChallenge(ev.Asserter, ev.Challenger) {
-				c.wg.Add(1)
-				go c.handleChallenge(ctx, ev.OutputIndex)
+				c.startHandleChallenge(ctx, ev.OutputIndex)
 			}
 		case <-ctx.Done():
 			return
@@ -301,6 +406,12 @@ func (c *Challenger) handleOutput(ctx context.Context, outputIndex *big.Int) {
 	ticker := time.NewTicker(time.Minute)
 	defer ticker.Stop()
 
+	// agrees is the verdict on the segments of the challenge of another challenger, and prepared the createChallenge
+	// tx prepared to take over the challenge if it stalls. foundAt is when the output was first to be challenged.
+	agrees := true
+	var prepared *types.Transaction
+	var foundAt time.Time
+
 	for {
 	Loop:
 		select {
@@ -324,18 +435,54 @@ func (c *Challenger) handleOutput(ctx context.Context, outputIndex *big.Int) {
 				break Loop
 			}
 
-			// if challenge is in progress, terminate handling
+			// if challenge is in progress, coordinate with its challenger
 			if isInProgress {
-				c.log.Info("found invalid output, but is already in progress", "outputIndex", outputIndex)
-				return
+				action, err := c.coordinateChallenge(ctx, outputIndex, &agrees)
+				if err != nil {
+					c.log.Error("unable to coordinate the challenge", "err", err, "outputIndex", outputIndex)
+					break Loop
+				}
+				if action == coordinationStop {
+					return
+				}
+				if action == coordinationPrepare && prepared == nil {
+					if prepared, err = c.CreateChallenge(ctx, outputRange); err != nil {
+						c.log.Error("failed to prepare createChallenge tx", "err", err, "outputIndex", outputIndex)
+					}
+				}
+				// watch the challenge at the pace of the challenges, not to miss its deadline
+				if c.cfg.ChallengerPollInterval > 0 {
+					ticker.Reset(c.cfg.ChallengerPollInterval)
+				}
+				break Loop
 			}
 
-			// if there is no challenge on invalid output, create a new challenge
-			tx, err := c.CreateChallenge(ctx, outputRange)
+			// if there is no challenge on invalid output, create a new challenge, or take over with the prepared one,
+			// once the deposit covers its bond, as the challenge tx reverts in the gas estimation otherwise
+			covered, err := c.ensureChallengeDeposit(ctx, outputIndex)
 			if err != nil {
-				c.log.Error("failed to create createChallenge tx", "err", err, "outputIndex", outputIndex)
+				c.log.Error("unable to cover the bond of the challenge", "err", err, "outputIndex", outputIndex)
+				break Loop
+			}
+			if !covered {
+				c.log.Info("waiting for the top up of the challenger deposit to create the challenge", "outputIndex", outputIndex)
+				break Loop
+			}
+
+			if foundAt.IsZero() {
+				foundAt = time.Now()
+			}
+			// a stalling challenge is taken over right away, as its deadline is near
+			if prepared == nil && c.deferChallenge(ctx, outputRange, foundAt, time.Now()) {
 				break Loop
 			}
+			tx := prepared
+			if tx == nil {
+				if tx, err = c.CreateChallenge(ctx, outputRange); err != nil {
+					c.log.Error("failed to create createChallenge tx", "err", err, "outputIndex", outputIndex)
+					break Loop
+				}
+			}
 
 			c.submitChallengeTx(tx)
 			return
@@ -372,42 +519,78 @@ func (c *Challenger) handleChallenge(ctx context.Context, outputIndex *big.Int)
 				break Loop
 			}
 
+			c.observeChallenge(outputIndex, challenge, status)
+
 			// if the challenge is inactivated, terminate handling
 			if isInactivated(status) {
 				c.log.Error("challenge is not in progress", "challengeStatus", status)
 				return
 			}
 
-			// if asserter
-			if isAsserter && !c.cfg.OutputSubmitterDisabled {
-				if status == chal.StatusAsserterTurn {
-					tx, err := c.Bisect(ctx, outputIndex)
-					if err != nil {
-						c.log.Error("asserter: failed to create bisect tx", "err", err, "outputIndex", outputIndex)
-						break Loop
-					}
-					c.submitChallengeTx(tx)
-				}
+			// the turn is left to the next process once the challenger is draining
+			if !c.turns.begin() {
+				break Loop
+			}
+			c.takeTurn(ctx, outputIndex, isAsserter, isChallenger, status)
+			c.turns.end()
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// takeTurn creates and queues the transaction of the turn of the challenge, if it is the turn of the role.
+func (c *Challenger) takeTurn(ctx context.Context, outputIndex *big.Int, isAsserter, isChallenger bool, status uint8) {
+	// if asserter
+	if isAsserter && !c.cfg.OutputSubmitterDisabled {
+		if status == chal.StatusAsserterTurn {
+			tx, err := c.Bisect(ctx, outputIndex)
+			if err != nil {
+				c.log.Error("asserter: failed to create bisect tx", "err", err, "outputIndex", outputIndex)
+				return
 			}
+			c.submitChallengeTx(tx)
+		}
+	}
 
-			// if challenger
-			if isChallenger && !c.cfg.ChallengerDisabled {
-				switch status {
-				case chal.StatusChallengerTurn:
-					tx, err := c.Bisect(ctx, outputIndex)
-					if err != nil {
-						c.log.Error("challenger: failed to create bisect tx", "err", err, "outputIndex", outputIndex)
-						break Loop
-					}
-					c.submitChallengeTx(tx)
-				case chal.StatusAsserterTimeout, chal.StatusReadyToProve:
-					tx, err := c.ProveFault(ctx, outputIndex)
-					if err != nil {
-						c.log.Error("failed to create prove fault tx", "err", err, "outputIndex", outputIndex)
-						break Loop
-					}
-					c.submitChallengeTx(tx)
-				}
+	// if challenger
+	if isChallenger && !c.cfg.ChallengerDisabled {
+		switch status {
+		case chal.StatusChallengerTurn:
+			tx, err := c.Bisect(ctx, outputIndex)
+			if err != nil {
+				c.log.Error("challenger: failed to create bisect tx", "err", err, "outputIndex", outputIndex)
+				return
+			}
+			c.submitChallengeTx(tx)
+		case chal.StatusAsserterTimeout, chal.StatusReadyToProve:
+			tx, err := c.ProveFault(ctx, outputIndex)
+			if err != nil {
+				c.log.Error("failed to create prove fault tx", "err", err, "outputIndex", outputIndex)
+				return
+			}
+			c.submitChallengeTx(tx)
+		}
+	}
+}
+
+// Drain stops taking the turns of the challenges, and waits for the turns in progress to queue their transactions.
+func (c *Challenger) Drain(ctx context.Context) {
+	c.turns.drain(ctx)
+}
+
+// sweepLoop periodically sweeps the recovered bonds and rewards to the sweep address.
+func (c *Challenger) sweepLoop(ctx context.Context) {
+	defer c.wg.Done()
+
+	ticker := time.NewTicker(c.cfg.Sweep.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := c.sweeper.sweep(ctx, c.txCandidatesChan); err != nil {
+				c.log.Error("failed to sweep recovered funds", "err", err)
 			}
 		case <-ctx.Done():
 			return
@@ -443,13 +626,24 @@ func (c *Challenger) OutputWithProofAtBlockSafe(ctx context.Context, blockNumber
 	return c.cfg.RollupClient.OutputWithProofAtBlock(ctx, blockNumber)
 }
 
+// WitnessAtBlock fetches the output with the public input proof at the given block from the witness provider.
+func (c *Challenger) WitnessAtBlock(ctx context.Context, blockNumber uint64) (*eth.OutputResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.cfg.NetworkTimeout)
+	defer cancel()
+	output, err := c.witnessProvider.OutputWithProofAtBlock(ctx, blockNumber)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch witness at block %d: %w", blockNumber, err)
+	}
+	return output, nil
+}
+
 func (c *Challenger) PublicInputProof(ctx context.Context, blockNumber uint64) (bindings.TypesPublicInputProof, error) {
-	srcOutput, err := c.OutputAtBlockSafe(ctx, blockNumber)
+	srcOutput, err := c.WitnessAtBlock(ctx, blockNumber)
 	if err != nil {
 		return bindings.TypesPublicInputProof{}, err
 	}
 
-	dstOutput, err := c.OutputAtBlockSafe(ctx, blockNumber+1)
+	dstOutput, err := c.WitnessAtBlock(ctx, blockNumber+1)
 	if err != nil {
 		return bindings.TypesPublicInputProof{}, err
 	}
@@ -509,6 +703,8 @@ type OutputRange struct {
 	OutputIndex *big.Int
 	StartBlock  uint64
 	EndBlock    uint64
+	// FinalizesAt is when the output finalizes, the last moment to challenge it.
+	FinalizesAt time.Time
 }
 
 // ValidateOutput validates the output given the outputIndex
@@ -529,10 +725,12 @@ func (c *Challenger) ValidateOutput(ctx context.Context, outputIndex *big.Int) (
 			"local", outputs.localOutput.OutputRoot,
 			"invalid", common.BytesToHash(outputs.remoteOutput.OutputRoot[:]),
 		)
+		finalizesAt := new(big.Int).Add(outputs.remoteOutput.Timestamp, c.finalizationPeriodSeconds)
 		return &OutputRange{
 			OutputIndex: outputIndex,
 			StartBlock:  start,
 			EndBlock:    end,
+			FinalizesAt: time.Unix(finalizesAt.Int64(), 0),
 		}, nil
 	} else {
 		c.log.Info("confirmed that the output is valid",
@@ -596,9 +794,23 @@ func (c *Challenger) CreateChallenge(ctx context.Context, outputRange *OutputRan
 	)
 
 	segSize := outputRange.EndBlock - outputRange.StartBlock
-	segments, err := c.BuildSegments(ctx, 1, outputRange.StartBlock, segSize)
-	if err != nil {
-		return nil, err
+	var segments *chal.Segments
+	if s := c.recordedSubmission(outputRange.OutputIndex, ChallengeTxCreate, 1, common.Hash{}); s != nil &&
+		s.SegStart == outputRange.StartBlock && s.SegSize == segSize {
+		c.log.Info("reusing recorded segments", "index", outputRange.OutputIndex, "turn", 1)
+		segments = chal.NewSegments(s.SegStart, s.SegSize, toSegmentHashes(s.Segments))
+	} else {
+		var err error
+		if segments, err = c.BuildSegments(ctx, 1, outputRange.StartBlock, segSize); err != nil {
+			return nil, err
+		}
+		c.recordSubmission(outputRange.OutputIndex, ChallengeSubmission{
+			Kind:     ChallengeTxCreate,
+			Turn:     1,
+			SegStart: segments.Start,
+			SegSize:  segments.Size,
+			Segments: toCommonHashes(segments.Hashes),
+		})
 	}
 
 	txOpts := utils.NewSimpleTxOpts(ctx, c.cfg.TxManager.From(), c.cfg.TxManager.Signer)
@@ -613,17 +825,34 @@ func (c *Challenger) Bisect(ctx context.Context, outputIndex *big.Int) (*types.T
 		return nil, err
 	}
 
+	nextTurn := challenge.Turn + 1
+	answers := segmentsHash(challenge.SegStart.Uint64(), challenge.SegSize.Uint64(), challenge.Segments)
+	if s := c.recordedSubmission(outputIndex, ChallengeTxBisect, nextTurn, answers); s != nil {
+		// the bisection was crafted before a restart, and may still be pending
+		c.log.Info("reusing recorded segments", "outputIndex", outputIndex, "turn", nextTurn)
+		txOpts := utils.NewSimpleTxOpts(ctx, c.cfg.TxManager.From(), c.cfg.TxManager.Signer)
+		return c.colosseumContract.Bisect(txOpts, outputIndex, s.Position, toSegmentHashes(s.Segments))
+	}
+
 	prevSegments := chal.NewSegments(challenge.SegStart.Uint64(), challenge.SegSize.Uint64(), challenge.Segments)
 	position, err := c.selectFaultPosition(ctx, prevSegments)
 	if err != nil {
 		return nil, err
 	}
-	nextTurn := challenge.Turn + 1
 	start, size := prevSegments.NextSegmentsRange(position.Uint64())
 	nextSegments, err := c.BuildSegments(ctx, nextTurn, start, size)
 	if err != nil {
 		return nil, err
 	}
+	c.recordSubmission(outputIndex, ChallengeSubmission{
+		Kind:     ChallengeTxBisect,
+		Turn:     nextTurn,
+		Answers:  answers,
+		Position: position,
+		SegStart: nextSegments.Start,
+		SegSize:  nextSegments.Size,
+		Segments: toCommonHashes(nextSegments.Hashes),
+	})
 
 	txOpts := utils.NewSimpleTxOpts(ctx, c.cfg.TxManager.From(), c.cfg.TxManager.Signer)
 	return c.colosseumContract.Bisect(txOpts, outputIndex, position, nextSegments.Hashes)
@@ -635,8 +864,25 @@ func (c *Challenger) ChallengerTimeout(ctx context.Context, outputIndex *big.Int
 	return c.colosseumContract.ChallengerTimeout(txOpts, outputIndex)
 }
 
-// ProveFault creates proveFault transaction for invalid output root
-// TODO: ProveFault will take long time, so that we may have to handle it carefully
+// getProvingTimeout returns the proving timeout of the Colosseum, fetching it on first use.
+func (c *Challenger) getProvingTimeout(ctx context.Context) (time.Duration, error) {
+	c.provingTimeoutMu.Lock()
+	defer c.provingTimeoutMu.Unlock()
+	if c.provingTimeout > 0 {
+		return c.provingTimeout, nil
+	}
+	cCtx, cCancel := context.WithTimeout(ctx, c.cfg.NetworkTimeout)
+	defer cCancel()
+	provingTimeout, err := c.colosseumContract.PROVINGTIMEOUT(utils.NewSimpleCallOpts(cCtx))
+	if err != nil {
+		return 0, fmt.Errorf("failed to get proving timeout: %w", err)
+	}
+	c.provingTimeout = time.Duration(provingTimeout.Uint64()) * time.Second
+	return c.provingTimeout, nil
+}
+
+// ProveFault creates proveFault transaction for invalid output root.
+// The proof is requested from the prover until the proving deadline of the challenge, see fetchProof.
 func (c *Challenger) ProveFault(ctx context.Context, outputIndex *big.Int) (*types.Transaction, error) {
 	c.log.Info("crafting proveFault tx")
 
@@ -650,16 +896,37 @@ func (c *Challenger) ProveFault(ctx context.Context, outputIndex *big.Int) (*typ
 		return nil, err
 	}
 
-	segments := chal.NewSegments(challenge.SegStart.Uint64(), challenge.SegSize.Uint64(), challenge.Segments)
-	position, err := c.selectFaultPosition(ctx, segments)
-	if err != nil {
-		return nil, err
+	answers := segmentsHash(challenge.SegStart.Uint64(), challenge.SegSize.Uint64(), challenge.Segments)
+	var position *big.Int
+	if s := c.recordedSubmission(outputIndex, ChallengeTxProve, challenge.Turn, answers); s != nil {
+		position = s.Position
+	} else {
+		segments := chal.NewSegments(challenge.SegStart.Uint64(), challenge.SegSize.Uint64(), challenge.Segments)
+		if position, err = c.selectFaultPosition(ctx, segments); err != nil {
+			return nil, err
+		}
+		c.recordSubmission(outputIndex, ChallengeSubmission{
+			Kind:     ChallengeTxProve,
+			Turn:     challenge.Turn,
+			Answers:  answers,
+			Position: position,
+		})
 	}
 
 	blockNumber := challenge.SegStart.Uint64() + position.Uint64()
-	fetchResult, err := c.cfg.ProofFetcher.FetchProofAndPair(blockNumber)
+	provingTimeout, err := c.getProvingTimeout(ctx)
+	if err != nil {
+		return nil, err
+	}
+	deadline := proofDeadline(challenge.TimeoutAt, provingTimeout, time.Now())
+	estimate := c.estimateProof(ctx, blockNumber, deadline)
+	start := time.Now()
+	fetchResult, err := c.fetchProof(ctx, blockNumber, deadline)
 	if err != nil {
-		return nil, fmt.Errorf("%w: blockNumber: %d", err, blockNumber)
+		return nil, err
+	}
+	if estimate != nil {
+		c.proofEstimator.observe(estimate.TraceLength, estimate.WitnessSize, time.Since(start))
 	}
 
 	proof, err := c.PublicInputProof(ctx, blockNumber)
@@ -0,0 +1,130 @@
+package validator
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/kroma-network/kroma/bindings/bindings"
+	chal "github.com/kroma-network/kroma/components/validator/challenge"
+)
+
+// ChallengeCoordination is how the challenger handles an invalid output that is already challenged by another
+// challenger. The Colosseum allows a single challenge per output, which can be created again once the challenger of
+// the previous one timed out.
+type ChallengeCoordination string
+
+const (
+	// CoordinationHoldBack leaves the output to the other challenger while its segments agree with the local
+	// outputs, so that no bond is spent when someone else is already winning. A challenge that disagrees with
+	// the local outputs is watched, and the output is challenged again once that challenge timed out.
+	CoordinationHoldBack ChallengeCoordination = "hold-back"
+	// CoordinationParallel watches every challenge of another challenger, and challenges the output again as
+	// soon as that challenge timed out.
+	CoordinationParallel ChallengeCoordination = "parallel"
+	// CoordinationTakeover watches every challenge of another challenger like CoordinationParallel, and prepares
+	// the challenge once the other challenger stalls on its turn within the takeover margin before the deadline,
+	// to take the output over in the first block after the challenge timed out.
+	CoordinationTakeover ChallengeCoordination = "takeover"
+)
+
+// ParseChallengeCoordination parses the coordination of the name, hold-back if empty.
+func ParseChallengeCoordination(name string) (ChallengeCoordination, error) {
+	switch c := ChallengeCoordination(name); c {
+	case "":
+		return CoordinationHoldBack, nil
+	case CoordinationHoldBack, CoordinationParallel, CoordinationTakeover:
+		return c, nil
+	default:
+		return "", fmt.Errorf("unknown challenge coordination %q, expected one of %s, %s or %s", name,
+			CoordinationHoldBack, CoordinationParallel, CoordinationTakeover)
+	}
+}
+
+// coordinationAction is what the challenger does on an output challenged by another challenger.
+type coordinationAction int
+
+const (
+	// coordinationStop stops handling the output, leaving it to the other challenger.
+	coordinationStop coordinationAction = iota
+	// coordinationWatch keeps watching the challenge, to challenge the output again once it timed out.
+	coordinationWatch
+	// coordinationPrepare keeps watching the challenge, with the challenge of the output prepared.
+	coordinationPrepare
+)
+
+// coordinate decides the action on an output challenged by another challenger, given the challenge and its status
+// at now, and whether the segments of the other challenger agree with the local outputs.
+func coordinate(mode ChallengeCoordination, takeoverMargin time.Duration, challenge bindings.TypesChallenge, status uint8, agrees bool, now time.Time) coordinationAction {
+	switch mode {
+	case CoordinationParallel:
+		return coordinationWatch
+	case CoordinationTakeover:
+		deadline := time.Unix(int64(challenge.TimeoutAt), 0)
+		if status == chal.StatusChallengerTurn && deadline.Sub(now) <= takeoverMargin {
+			return coordinationPrepare
+		}
+		return coordinationWatch
+	default:
+		if agrees {
+			return coordinationStop
+		}
+		return coordinationWatch
+	}
+}
+
+// segmentsAgree returns whether the segments of the challenge agree with the local outputs. The segments are the
+// ones of the challenger only on the turns of the asserter, so on the other turns it returns the previous verdict.
+func (c *Challenger) segmentsAgree(ctx context.Context, challenge bindings.TypesChallenge, status uint8, prev bool) (bool, error) {
+	if status != chal.StatusAsserterTurn && status != chal.StatusAsserterTimeout && status != chal.StatusReadyToProve {
+		return prev, nil
+	}
+	segments := chal.NewSegments(challenge.SegStart.Uint64(), challenge.SegSize.Uint64(), challenge.Segments)
+	for i, blockNumber := range segments.BlockNumbers() {
+		output, err := c.OutputAtBlockSafe(ctx, blockNumber)
+		if err != nil {
+			return false, err
+		}
+		if !bytes.Equal(segments.Hashes[i][:], output.OutputRoot[:]) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// coordinateChallenge decides the action on the output whose challenge is in progress. The verdict on the segments
+// of the other challenger is kept in agrees, since they can only be compared on the turns of the asserter.
+func (c *Challenger) coordinateChallenge(ctx context.Context, outputIndex *big.Int, agrees *bool) (coordinationAction, error) {
+	challenge, err := c.GetChallenge(ctx, outputIndex)
+	if err != nil {
+		return coordinationWatch, fmt.Errorf("failed to get challenge: %w", err)
+	}
+	// the own challenges are handled by handleChallenge
+	if challenge.Challenger == c.cfg.TxManager.From() {
+		c.log.Info("found invalid output, but is already challenged by this challenger", "outputIndex", outputIndex)
+		return coordinationStop, nil
+	}
+	status, err := c.GetChallengeStatus(ctx, outputIndex)
+	if err != nil {
+		return coordinationWatch, fmt.Errorf("unable to get challenge status: %w", err)
+	}
+	if *agrees, err = c.segmentsAgree(ctx, challenge, status, *agrees); err != nil {
+		return coordinationWatch, fmt.Errorf("unable to compare the segments of the challenge: %w", err)
+	}
+
+	action := coordinate(c.cfg.ChallengerCoordination, c.cfg.ChallengerTakeoverMargin, challenge, status, *agrees, time.Now())
+	switch action {
+	case coordinationStop:
+		c.log.Info("found invalid output, but is already in progress", "outputIndex", outputIndex,
+			"challenger", challenge.Challenger)
+	case coordinationPrepare:
+		c.log.Warn("challenger is stalling near the deadline, preparing to take over", "outputIndex", outputIndex,
+			"challenger", challenge.Challenger, "timeoutAt", challenge.TimeoutAt)
+	default:
+		c.log.Info("watching the challenge of another challenger", "outputIndex", outputIndex,
+			"challenger", challenge.Challenger, "status", status, "agrees", *agrees)
+	}
+	return action, nil
+}
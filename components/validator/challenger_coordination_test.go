@@ -0,0 +1,97 @@
+package validator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kroma-network/kroma/bindings/bindings"
+	chal "github.com/kroma-network/kroma/components/validator/challenge"
+)
+
+func TestParseChallengeCoordination(t *testing.T) {
+	for name, expected := range map[string]ChallengeCoordination{
+		"":          CoordinationHoldBack,
+		"hold-back": CoordinationHoldBack,
+		"parallel":  CoordinationParallel,
+		"takeover":  CoordinationTakeover,
+	} {
+		coordination, err := ParseChallengeCoordination(name)
+		require.NoError(t, err)
+		require.Equal(t, expected, coordination)
+	}
+	_, err := ParseChallengeCoordination("race")
+	require.ErrorContains(t, err, "unknown challenge coordination")
+}
+
+func TestCoordinate(t *testing.T) {
+	const margin = 10 * time.Minute
+	now := time.Unix(1_000_000, 0)
+	farDeadline := bindings.TypesChallenge{TimeoutAt: uint64(now.Add(time.Hour).Unix())}
+	nearDeadline := bindings.TypesChallenge{TimeoutAt: uint64(now.Add(margin / 2).Unix())}
+
+	tests := []struct {
+		name      string
+		mode      ChallengeCoordination
+		challenge bindings.TypesChallenge
+		status    uint8
+		agrees    bool
+		expected  coordinationAction
+	}{
+		{
+			name:      "hold back for an agreeing challenger",
+			mode:      CoordinationHoldBack,
+			challenge: nearDeadline,
+			status:    chal.StatusChallengerTurn,
+			agrees:    true,
+			expected:  coordinationStop,
+		},
+		{
+			name:      "watch a disagreeing challenger when holding back",
+			mode:      CoordinationHoldBack,
+			challenge: farDeadline,
+			status:    chal.StatusAsserterTurn,
+			expected:  coordinationWatch,
+		},
+		{
+			name:      "watch an agreeing challenger in parallel",
+			mode:      CoordinationParallel,
+			challenge: nearDeadline,
+			status:    chal.StatusChallengerTurn,
+			agrees:    true,
+			expected:  coordinationWatch,
+		},
+		{
+			name:      "watch a progressing challenger before taking over",
+			mode:      CoordinationTakeover,
+			challenge: farDeadline,
+			status:    chal.StatusChallengerTurn,
+			agrees:    true,
+			expected:  coordinationWatch,
+		},
+		{
+			name:      "watch near the deadline of the asserter",
+			mode:      CoordinationTakeover,
+			challenge: nearDeadline,
+			status:    chal.StatusAsserterTurn,
+			agrees:    true,
+			expected:  coordinationWatch,
+		},
+		{
+			name:      "prepare to take over a stalling challenger",
+			mode:      CoordinationTakeover,
+			challenge: nearDeadline,
+			status:    chal.StatusChallengerTurn,
+			agrees:    true,
+			expected:  coordinationPrepare,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			action := coordinate(test.mode, margin, test.challenge, test.status, test.agrees, now)
+			require.Equal(t, test.expected, action)
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+
+	"github.com/kroma-network/kroma/bindings/bindings"
+	"github.com/kroma-network/kroma/utils"
+	"github.com/kroma-network/kroma/utils/service/txmgr"
+)
+
+// ErrInsufficientChallengeDeposit is returned when the deposit of the challenger in the ValidatorPool does not cover
+// the bond of a challenge, and cannot be topped up to cover it.
+var ErrInsufficientChallengeDeposit = errors.New("challenger deposit does not cover the challenge bond")
+
+// ChallengeDepositConfig configures the top up of the deposit of the challenger in the ValidatorPool, which the bond
+// of a challenge is taken from: creating a challenge bonds as much as the bond of the challenged output.
+type ChallengeDepositConfig struct {
+	// Target is the deposit (in wei) topped up to when it does not cover the bond of a challenge, at least the bond.
+	// The deposit is not topped up if nil or 0.
+	Target *big.Int
+	// MaxTopUp is the maximum amount (in wei) deposited at once, no maximum if nil or 0.
+	MaxTopUp *big.Int
+}
+
+// ChallengeDepositPool is the set of ValidatorPool methods that the deposit of the challenger is managed with.
+type ChallengeDepositPool interface {
+	ValidatorPoolBalance
+	GetBond(opts *bind.CallOpts, _outputIndex *big.Int) (bindings.TypesBond, error)
+}
+
+// challengeTopUp returns the amount to deposit for the deposit to cover the bond, nil if it covers it already.
+func challengeTopUp(cfg ChallengeDepositConfig, deposit, bond *big.Int) (*big.Int, error) {
+	if deposit.Cmp(bond) >= 0 {
+		return nil, nil
+	}
+	if cfg.Target == nil || cfg.Target.Sign() == 0 {
+		return nil, fmt.Errorf("%w: deposit %s wei, bond %s wei", ErrInsufficientChallengeDeposit, deposit, bond)
+	}
+	target := cfg.Target
+	if target.Cmp(bond) < 0 {
+		target = bond
+	}
+	amount := new(big.Int).Sub(target, deposit)
+	if cfg.MaxTopUp != nil && cfg.MaxTopUp.Sign() > 0 && amount.Cmp(cfg.MaxTopUp) > 0 {
+		amount = new(big.Int).Set(cfg.MaxTopUp)
+	}
+	if new(big.Int).Add(deposit, amount).Cmp(bond) < 0 {
+		return nil, fmt.Errorf("%w: deposit %s wei, bond %s wei, top up capped at %s wei", ErrInsufficientChallengeDeposit,
+			deposit, bond, cfg.MaxTopUp)
+	}
+	return amount, nil
+}
+
+// challengeDepositState is the top up of the challenger deposit pending, shared by the challenges of all outputs.
+type challengeDepositState struct {
+	mu sync.Mutex
+	// pending is the deposit a top up was queued at, so that it is not topped up again until the top up was sent, and
+	// pendingTicks the number of checks since.
+	pending      *big.Int
+	pendingTicks int
+}
+
+// ensureChallengeDeposit checks that the deposit of the challenger covers the bond of the challenge of the output,
+// and queues a deposit into the ValidatorPool to top it up otherwise. It returns true if the deposit covers the bond:
+// the challenge must only be created, and its gas estimated, once the top up landed.
+func (c *Challenger) ensureChallengeDeposit(ctx context.Context, outputIndex *big.Int) (bool, error) {
+	cCtx, cCancel := context.WithTimeout(ctx, c.cfg.NetworkTimeout)
+	defer cCancel()
+	from := c.cfg.TxManager.From()
+	callOpts := utils.NewCallOptsWithSender(cCtx, from)
+	bond, err := c.depositPool.GetBond(callOpts, outputIndex)
+	if err != nil {
+		return false, fmt.Errorf("failed to fetch bond of output: %w", err)
+	}
+	deposit, err := c.depositPool.BalanceOf(callOpts, from)
+	if err != nil {
+		return false, fmt.Errorf("failed to fetch validator deposit: %w", err)
+	}
+
+	c.deposit.mu.Lock()
+	defer c.deposit.mu.Unlock()
+	amount, err := challengeTopUp(c.cfg.ChallengeDeposit, deposit, bond.Amount)
+	if err != nil {
+		return false, err
+	}
+	if amount == nil {
+		c.deposit.pending = nil
+		return true, nil
+	}
+	if c.deposit.pending != nil {
+		if c.deposit.pending.Cmp(deposit) == 0 && c.deposit.pendingTicks < depositPendingTicks {
+			c.deposit.pendingTicks++
+			c.log.Debug("challenger deposit top up is pending", "outputIndex", outputIndex, "deposit", deposit)
+			return false, nil
+		}
+		c.deposit.pending = nil
+	}
+
+	data, err := c.valPoolABI.Pack("deposit")
+	if err != nil {
+		return false, fmt.Errorf("failed to create deposit transaction data: %w", err)
+	}
+	to := c.cfg.ValidatorPoolAddr
+	select {
+	case c.txCandidatesChan <- txmgr.TxCandidate{TxData: data, To: &to, Value: amount}:
+	case <-ctx.Done():
+		return false, ctx.Err()
+	}
+	c.deposit.pending, c.deposit.pendingTicks = deposit, 0
+	c.metr.RecordChallengeDepositTopUp(amount)
+	c.log.Info("queued top up of challenger deposit", "outputIndex", outputIndex, "amount", amount, "deposit", deposit,
+		"bond", bond.Amount)
+	return false, nil
+}
@@ -0,0 +1,162 @@
+package validator
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kroma-network/kroma/bindings/bindings"
+	"github.com/kroma-network/kroma/components/node/testlog"
+	"github.com/kroma-network/kroma/components/validator/metrics"
+	"github.com/kroma-network/kroma/utils/service/txmgr"
+)
+
+func TestChallengeTopUp(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfg      ChallengeDepositConfig
+		deposit  int64
+		bond     int64
+		expected int64
+		err      bool
+	}{
+		{name: "covered", cfg: ChallengeDepositConfig{Target: big.NewInt(500)}, deposit: 100, bond: 100},
+		{name: "disabled", deposit: 50, bond: 100, err: true},
+		{name: "to target", cfg: ChallengeDepositConfig{Target: big.NewInt(500)}, deposit: 50, bond: 100, expected: 450},
+		{name: "target below bond", cfg: ChallengeDepositConfig{Target: big.NewInt(80)}, deposit: 50, bond: 100, expected: 50},
+		{name: "capped", cfg: ChallengeDepositConfig{Target: big.NewInt(500), MaxTopUp: big.NewInt(200)}, deposit: 50, bond: 100, expected: 200},
+		{name: "capped below bond", cfg: ChallengeDepositConfig{Target: big.NewInt(500), MaxTopUp: big.NewInt(20)}, deposit: 50, bond: 100, err: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			amount, err := challengeTopUp(test.cfg, big.NewInt(test.deposit), big.NewInt(test.bond))
+			if test.err {
+				require.ErrorIs(t, err, ErrInsufficientChallengeDeposit)
+				return
+			}
+			require.NoError(t, err)
+			if test.expected == 0 {
+				require.Nil(t, amount)
+				return
+			}
+			require.Equal(t, big.NewInt(test.expected), amount)
+		})
+	}
+}
+
+type fakeDepositPool struct {
+	fakeBalances
+	bond *big.Int
+}
+
+func (p *fakeDepositPool) GetBond(_ *bind.CallOpts, _ *big.Int) (bindings.TypesBond, error) {
+	return bindings.TypesBond{Amount: p.bond, ExpiresAt: big.NewInt(1)}, nil
+}
+
+func TestEnsureChallengeDeposit(t *testing.T) {
+	valPoolABI, err := bindings.ValidatorPoolMetaData.GetAbi()
+	require.NoError(t, err)
+	pool := &fakeDepositPool{fakeBalances: fakeBalances{pool: big.NewInt(150)}, bond: big.NewInt(100)}
+	txs := make(chan txmgr.TxCandidate, 10)
+	c := &Challenger{
+		log:  testlog.Logger(t, log.LvlCrit),
+		metr: metrics.NoopMetrics,
+		cfg: Config{
+			ValidatorPoolAddr: common.Address{0xbb},
+			NetworkTimeout:    time.Second,
+			TxManager:         &txmgr.SimpleTxManager{Config: txmgr.Config{From: common.Address{0xaa}}},
+			ChallengeDeposit:  ChallengeDepositConfig{Target: big.NewInt(300)},
+		},
+		depositPool:      pool,
+		valPoolABI:       valPoolABI,
+		txCandidatesChan: txs,
+	}
+
+	// the deposit covers the bond
+	covered, err := c.ensureChallengeDeposit(context.Background(), big.NewInt(1))
+	require.NoError(t, err)
+	require.True(t, covered)
+	require.Empty(t, txs)
+
+	// the deposit is underfunded: the challenge waits for the top up
+	pool.bond = big.NewInt(200)
+	covered, err = c.ensureChallengeDeposit(context.Background(), big.NewInt(1))
+	require.NoError(t, err)
+	require.False(t, covered, "the challenge must not be created before the top up landed")
+	require.Len(t, txs, 1)
+	deposit := <-txs
+	require.Equal(t, common.Address{0xbb}, *deposit.To)
+	require.Equal(t, big.NewInt(150), deposit.Value)
+	data, err := valPoolABI.Pack("deposit")
+	require.NoError(t, err)
+	require.Equal(t, data, deposit.TxData)
+
+	// the top up is not queued again while it is pending
+	for i := 0; i < depositPendingTicks; i++ {
+		covered, err = c.ensureChallengeDeposit(context.Background(), big.NewInt(1))
+		require.NoError(t, err)
+		require.False(t, covered)
+		require.Empty(t, txs)
+	}
+	// but is queued again if the deposit did not change after the pending checks, in case the top up failed
+	covered, err = c.ensureChallengeDeposit(context.Background(), big.NewInt(1))
+	require.NoError(t, err)
+	require.False(t, covered)
+	require.Len(t, txs, 1)
+	<-txs
+
+	// the top up landed
+	pool.pool = big.NewInt(300)
+	covered, err = c.ensureChallengeDeposit(context.Background(), big.NewInt(1))
+	require.NoError(t, err)
+	require.True(t, covered)
+	require.Empty(t, txs)
+
+	pool.pool = big.NewInt(150)
+	c.cfg.ChallengeDeposit = ChallengeDepositConfig{}
+	_, err = c.ensureChallengeDeposit(context.Background(), big.NewInt(1))
+	require.ErrorIs(t, err, ErrInsufficientChallengeDeposit)
+	require.Empty(t, txs)
+}
+
+func TestCLIConfigCheckChallengerDeposit(t *testing.T) {
+	valid, err := runWithProfile(t, "--l2oo-address", "0x01", "--colosseum-address", "0x02", "--valpool-address", "0x03",
+		"--challenger.deposit-target", "50000000000000000000", "--challenger.deposit-max-top-up", "20000000000000000000")
+	require.NoError(t, err)
+	require.NoError(t, valid.Check())
+	for _, test := range []struct {
+		name string
+		cfg  func(c *CLIConfig)
+		err  string
+	}{
+		{"max top up without target", func(c *CLIConfig) { c.ChallengerDepositTarget = "0" }, "challenger deposit max top up requires a deposit target"},
+		{"invalid target", func(c *CLIConfig) { c.ChallengerDepositTarget = "50 ether" }, "challenger deposit target is not a valid wei amount"},
+		{"negative max top up", func(c *CLIConfig) { c.ChallengerDepositMaxTopUp = "-1" }, "challenger deposit max top up is not a valid wei amount"},
+		{"no top up", func(c *CLIConfig) {
+			c.ChallengerDepositTarget = ""
+			c.ChallengerDepositMaxTopUp = ""
+		}, ""},
+		{"sweep pool reserve below target", func(c *CLIConfig) {
+			c.SweepAddress = "0xc0"
+			c.SweepPoolReserve = "40000000000000000000"
+		}, "sweep pool reserve must cover the challenger deposit target"},
+	} {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			cfg := valid
+			test.cfg(&cfg)
+			err := cfg.Check()
+			if test.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, test.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kroma-network/kroma/components/node/eth"
+)
+
+// blockWitnessProvider serves the witnesses of the blocks it has.
+type blockWitnessProvider struct {
+	outputs map[uint64]*eth.OutputResponse
+}
+
+func (p *blockWitnessProvider) OutputWithProofAtBlock(_ context.Context, blockNumber uint64) (*eth.OutputResponse, error) {
+	output, ok := p.outputs[blockNumber]
+	if !ok {
+		return nil, errors.New("witness not found")
+	}
+	return output, nil
+}
+
+func TestChallengerWitnessAtBlock(t *testing.T) {
+	output := &eth.OutputResponse{
+		OutputRoot:       eth.Bytes32{0x01},
+		PublicInputProof: &eth.PublicInputProof{},
+	}
+	c := &Challenger{
+		cfg:             Config{NetworkTimeout: time.Second},
+		witnessProvider: &blockWitnessProvider{outputs: map[uint64]*eth.OutputResponse{100: output}},
+	}
+
+	got, err := c.WitnessAtBlock(context.Background(), 100)
+	require.NoError(t, err)
+	require.Same(t, output, got)
+
+	_, err = c.WitnessAtBlock(context.Background(), 101)
+	require.ErrorContains(t, err, "failed to fetch witness at block 101")
+}
@@ -0,0 +1,127 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/kroma-network/kroma/components/validator/metrics"
+)
+
+// defaultClockSkewCheckInterval is the interval at which the clock skew is checked.
+const defaultClockSkewCheckInterval = time.Minute
+
+// ClockSkewSourceL1 is the clock skew against the timestamp of the latest L1 block.
+const ClockSkewSourceL1 = "l1"
+
+// L1HeadSource provides the latest L1 block header to compare the local clock against.
+type L1HeadSource interface {
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	HeaderByHash(ctx context.Context, hash common.Hash) (*types.Header, error)
+}
+
+// clockSkew returns the skew of the local clock against the timestamp of the latest block of a chain.
+// The latest block is expected to be at most one block interval old, so only the difference beyond that is skew.
+// A positive skew is a local clock ahead of the chain, a negative skew a local clock behind.
+func clockSkew(now time.Time, blockTime uint64, blockInterval time.Duration) time.Duration {
+	lag := now.Sub(time.Unix(int64(blockTime), 0))
+	switch {
+	case lag < 0:
+		return lag
+	case lag > blockInterval:
+		return lag - blockInterval
+	default:
+		return 0
+	}
+}
+
+// clockSkewMonitor periodically compares the local clock against the timestamp of the latest L1 block, which keeps
+// advancing even if the rollup node is behind. A skew beyond the max skew is warned about, as it breaks the
+// correlation of the logs with the chains. Note that the head of a stalled L1 looks like a local clock ahead of L1.
+type clockSkewMonitor struct {
+	log            log.Logger
+	metr           metrics.Metricer
+	l1             L1HeadSource
+	maxSkew        time.Duration
+	interval       time.Duration
+	networkTimeout time.Duration
+	now            func() time.Time
+
+	// exceeded is whether the last skew exceeded the max skew, only accessed by the check loop
+	exceeded bool
+}
+
+func newClockSkewMonitor(l log.Logger, m metrics.Metricer, l1 L1HeadSource, maxSkew time.Duration, networkTimeout time.Duration) *clockSkewMonitor {
+	return &clockSkewMonitor{
+		log:            l,
+		metr:           m,
+		l1:             l1,
+		maxSkew:        maxSkew,
+		interval:       defaultClockSkewCheckInterval,
+		networkTimeout: networkTimeout,
+		now:            time.Now,
+	}
+}
+
+func (c *clockSkewMonitor) Start(ctx context.Context, wg *sync.WaitGroup) {
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(c.interval)
+		defer ticker.Stop()
+		for {
+			c.check(ctx)
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// check measures the clock skew against L1, and returns whether it exceeds the max skew. If L1 is unavailable, the
+// last skew is kept.
+func (c *clockSkewMonitor) check(ctx context.Context) bool {
+	skew, err := c.l1Skew(ctx)
+	if err != nil {
+		c.log.Warn("failed to check clock skew against L1", "err", err)
+		return c.exceeded
+	}
+	c.metr.RecordClockSkew(ClockSkewSourceL1, skew)
+	exceeded := skew.Abs() > c.maxSkew
+	if exceeded {
+		c.log.Warn("clock skew against L1 exceeds the max skew", "skew", skew, "maxSkew", c.maxSkew)
+	} else if c.exceeded {
+		c.log.Info("clock skew against L1 recovered", "skew", skew, "maxSkew", c.maxSkew)
+	}
+	c.exceeded = exceeded
+	return exceeded
+}
+
+func (c *clockSkewMonitor) l1Skew(ctx context.Context) (time.Duration, error) {
+	cCtx, cCancel := context.WithTimeout(ctx, c.networkTimeout)
+	defer cCancel()
+	head, err := c.l1.HeaderByNumber(cCtx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get latest L1 block header: %w", err)
+	}
+	// the L1 block interval is not configured, so the interval of the latest block is used.
+	var interval time.Duration
+	if head.Number.Sign() > 0 {
+		parent, err := c.l1.HeaderByHash(cCtx, head.ParentHash)
+		if err != nil {
+			return 0, fmt.Errorf("failed to get L1 block header %s: %w", head.ParentHash, err)
+		}
+		if head.Time > parent.Time {
+			interval = time.Duration(head.Time-parent.Time) * time.Second
+		}
+	}
+	return clockSkew(c.now(), head.Time, interval), nil
+}
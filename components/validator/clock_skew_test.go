@@ -0,0 +1,105 @@
+package validator
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kroma-network/kroma/bindings/bindings"
+	"github.com/kroma-network/kroma/components/node/eth"
+	"github.com/kroma-network/kroma/components/node/testlog"
+	"github.com/kroma-network/kroma/components/validator/metrics"
+)
+
+func TestClockSkew(t *testing.T) {
+	now := time.Unix(1000, 0)
+	tests := []struct {
+		name      string
+		blockTime uint64
+		expect    time.Duration
+	}{
+		{name: "latest block", blockTime: 1000, expect: 0},
+		{name: "within block interval", blockTime: 988, expect: 0},
+		{name: "local clock ahead", blockTime: 958, expect: 30 * time.Second},
+		{name: "local clock behind", blockTime: 1030, expect: -30 * time.Second},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expect, clockSkew(now, test.blockTime, 12*time.Second))
+		})
+	}
+}
+
+type fakeL1Heads struct {
+	head   *types.Header
+	parent *types.Header
+	err    error
+}
+
+func (f *fakeL1Heads) HeaderByNumber(_ context.Context, _ *big.Int) (*types.Header, error) {
+	return f.head, f.err
+}
+
+func (f *fakeL1Heads) HeaderByHash(_ context.Context, _ common.Hash) (*types.Header, error) {
+	return f.parent, f.err
+}
+
+func TestClockSkewMonitor(t *testing.T) {
+	now := time.Unix(10_000, 0)
+	l1 := &fakeL1Heads{
+		head:   &types.Header{Number: big.NewInt(2), Time: 9_994},
+		parent: &types.Header{Number: big.NewInt(1), Time: 9_982},
+	}
+	c := newClockSkewMonitor(testlog.Logger(t, log.LvlCrit), metrics.NoopMetrics, l1, 30*time.Second, time.Second)
+	c.now = func() time.Time { return now }
+
+	require.False(t, c.check(context.Background()))
+
+	// the local clock is behind L1
+	l1.head.Time, l1.parent.Time = 10_060, 10_048
+	require.True(t, c.check(context.Background()))
+
+	// the last skew is kept while L1 is unavailable
+	l1.err = errFakeRpc
+	require.True(t, c.check(context.Background()))
+
+	l1.head.Time, l1.parent.Time, l1.err = 10_000, 9_988, nil
+	require.False(t, c.check(context.Background()))
+
+	// the local clock is ahead of L1, beyond the interval of the latest L1 block
+	l1.head.Time, l1.parent.Time = 9_950, 9_938
+	require.True(t, c.check(context.Background()))
+}
+
+func TestGuardianBlockWaitTimeoutClockSkew(t *testing.T) {
+	rollupClient := &fakeRollupClient{
+		outputRoot:  eth.Bytes32{0xaa},
+		blockNumber: 100,
+		syncBehind:  alwaysFail,
+	}
+	council := &fakeSecurityCouncil{}
+	g, candidates := newTestGuardian(t, rollupClient, council)
+	g.cfg.GuardianBlockWaitTimeout = 10 * time.Millisecond
+	l1 := &fakeL1Heads{head: &types.Header{Number: big.NewInt(0), Time: 0}}
+	g.clockSkew = newClockSkewMonitor(g.log, metrics.NoopMetrics, l1, time.Second, time.Second)
+	require.True(t, g.clockSkew.check(context.Background()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// the block wait timeout is measured on the monotonic clock, it applies with a skewed clock too
+	g.wg.Add(1)
+	g.processOutputValidation(ctx, &bindings.SecurityCouncilValidationRequested{
+		TransactionId: big.NewInt(1),
+		OutputRoot:    eth.Bytes32{0xaa},
+		L2BlockNumber: big.NewInt(100),
+	})
+	require.NoError(t, ctx.Err(), "must give up the request at the block wait timeout")
+	require.Empty(t, candidates)
+}
@@ -79,6 +79,7 @@ func sendTransaction(ctx *cli.Context, txData []byte, txValue uint64) error {
 	if err != nil {
 		return fmt.Errorf("failed to create tx manager: %w", err)
 	}
+	defer txManager.Close()
 
 	valpoolAddr, err := utils.ParseAddress(ctx.GlobalString(flags.ValPoolAddressFlag.Name))
 	if err != nil {
@@ -0,0 +1,139 @@
+package balance
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/urfave/cli"
+
+	"github.com/kroma-network/kroma/bindings/bindings"
+	"github.com/kroma-network/kroma/components/validator/flags"
+	"github.com/kroma-network/kroma/utils"
+	"github.com/kroma-network/kroma/utils/service/txmgr"
+)
+
+// Register walks through the registration of the account of the tx manager as a validator. A validator is
+// registered by the ValidatorPool once its deposit reaches the minimum bond amount, so the missing deposit is
+// deposited after a confirmation prompt, and the registration is verified.
+func Register(ctx *cli.Context) error {
+	valpoolAddr, err := utils.ParseAddress(ctx.GlobalString(flags.ValPoolAddressFlag.Name))
+	if err != nil {
+		return fmt.Errorf("failed to parse ValidatorPool address: %w", err)
+	}
+	txMgrConfig, err := txmgr.NewConfig(txmgr.ReadCLIConfig(ctx), log.New())
+	if err != nil {
+		return fmt.Errorf("failed to read tx manager config: %w", err)
+	}
+	from := txMgrConfig.From
+
+	l1Client, err := utils.DialEthClientWithTimeout(context.Background(), ctx.GlobalString(flags.L1EthRpcFlag.Name))
+	if err != nil {
+		return fmt.Errorf("failed to dial L1 RPC: %w", err)
+	}
+	defer l1Client.Close()
+	valpool, err := bindings.NewValidatorPoolCaller(valpoolAddr, l1Client)
+	if err != nil {
+		return fmt.Errorf("failed to bind ValidatorPool: %w", err)
+	}
+
+	cCtx, cCancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cCancel()
+	opts := &bind.CallOpts{Context: cCtx}
+
+	fmt.Printf("[1/3] Checking the deposit of %s in ValidatorPool %s\n", from, valpoolAddr)
+	minBond, err := valpool.MINBONDAMOUNT(opts)
+	if err != nil {
+		return fmt.Errorf("failed to get minimum bond amount: %w", err)
+	}
+	deposit, err := valpool.BalanceOf(opts, from)
+	if err != nil {
+		return fmt.Errorf("failed to get deposit: %w", err)
+	}
+	registered, err := valpool.IsValidator(opts, from)
+	if err != nil {
+		return fmt.Errorf("failed to check validator: %w", err)
+	}
+	accountBalance, err := l1Client.BalanceAt(cCtx, from, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get account balance: %w", err)
+	}
+	fmt.Printf("      minimum bond: %s wei, deposit: %s wei, account balance: %s wei, validator: %t\n",
+		minBond, deposit, accountBalance, registered)
+
+	amount := new(big.Int).SetUint64(ctx.Uint64("amount"))
+	if !ctx.IsSet("amount") {
+		if registered {
+			fmt.Println("Already registered as a validator")
+			return nil
+		}
+		amount.Sub(minBond, deposit)
+	}
+	if total := new(big.Int).Add(deposit, amount); total.Cmp(minBond) < 0 {
+		return fmt.Errorf("deposit of %s wei after the registration is less than the minimum bond amount %s wei", total, minBond)
+	}
+	if accountBalance.Cmp(amount) < 0 {
+		return fmt.Errorf("account balance %s wei is less than the amount to deposit %s wei", accountBalance, amount)
+	}
+	if !amount.IsUint64() {
+		return fmt.Errorf("amount to deposit %s wei is too large", amount)
+	}
+
+	fmt.Printf("[2/3] Depositing %s wei into ValidatorPool %s\n", amount, valpoolAddr)
+	if ctx.Bool("dry-run") {
+		fmt.Println("Dry run, no transaction sent")
+		return nil
+	}
+	if !ctx.Bool("yes") {
+		ok, err := confirm(os.Stdin, fmt.Sprintf("Deposit %s wei from %s?", amount, from))
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return errors.New("registration aborted")
+		}
+	}
+	valpoolABI, err := bindings.ValidatorPoolMetaData.GetAbi()
+	if err != nil {
+		return fmt.Errorf("failed to get ValidatorPool ABI: %w", err)
+	}
+	txData, err := valpoolABI.Pack("deposit")
+	if err != nil {
+		return fmt.Errorf("failed to create deposit transaction data: %w", err)
+	}
+	if err = sendTransaction(ctx, txData, amount.Uint64()); err != nil {
+		return err
+	}
+
+	fmt.Println("[3/3] Verifying the registration")
+	vCtx, vCancel := context.WithTimeout(context.Background(), time.Minute)
+	defer vCancel()
+	registered, err = valpool.IsValidator(&bind.CallOpts{Context: vCtx}, from)
+	if err != nil {
+		return fmt.Errorf("failed to check validator: %w", err)
+	}
+	if !registered {
+		return fmt.Errorf("%s is not a validator after the deposit", from)
+	}
+	fmt.Printf("Registered %s as a validator\n", from)
+	return nil
+}
+
+// confirm prompts the question on the standard output, and returns whether it was answered with yes.
+func confirm(in io.Reader, question string) (bool, error) {
+	fmt.Printf("%s [y/N] ", question)
+	answer, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return false, fmt.Errorf("failed to read answer: %w", err)
+	}
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes", nil
+}
@@ -0,0 +1,182 @@
+package council
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/urfave/cli"
+
+	"github.com/kroma-network/kroma/bindings/bindings"
+	"github.com/kroma-network/kroma/components/validator"
+	"github.com/kroma-network/kroma/components/validator/flags"
+	"github.com/kroma-network/kroma/utils"
+)
+
+// Report prints the responsiveness of the SecurityCouncil members to the validation requests
+// made in the given L1 block range.
+func Report(ctx *cli.Context) error {
+	councilAddr, err := utils.ParseAddress(ctx.GlobalString(flags.SecurityCouncilAddressFlag.Name))
+	if err != nil {
+		return fmt.Errorf("failed to parse SecurityCouncil address: %w", err)
+	}
+
+	l1Client, err := utils.DialEthClientWithTimeout(context.Background(), ctx.GlobalString(flags.L1EthRpcFlag.Name))
+	if err != nil {
+		return fmt.Errorf("failed to dial L1 RPC: %w", err)
+	}
+	defer l1Client.Close()
+
+	council, err := bindings.NewSecurityCouncil(councilAddr, l1Client)
+	if err != nil {
+		return fmt.Errorf("failed to bind SecurityCouncil: %w", err)
+	}
+
+	cCtx, cCancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cCancel()
+
+	header, err := l1Client.HeaderByNumber(cCtx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to fetch latest L1 block: %w", err)
+	}
+	fromBlock := ctx.Uint64("from-block")
+	toBlock := ctx.Uint64("to-block")
+	if toBlock == 0 || toBlock > header.Number.Uint64() {
+		toBlock = header.Number.Uint64()
+	}
+	if fromBlock > toBlock {
+		return fmt.Errorf("from block %d is after to block %d", fromBlock, toBlock)
+	}
+
+	opts := &bind.CallOpts{Context: cCtx, BlockNumber: header.Number}
+	owners, err := council.GetOwners(opts)
+	if err != nil {
+		return fmt.Errorf("failed to get owners: %w", err)
+	}
+	required, err := council.NumConfirmationsRequired(opts)
+	if err != nil {
+		return fmt.Errorf("failed to get number of required confirmations: %w", err)
+	}
+
+	blockTimes := newBlockTimes(l1Client)
+	requests, err := fetchRequests(cCtx, council, blockTimes, fromBlock, toBlock)
+	if err != nil {
+		return err
+	}
+	// requests may be confirmed after the end of the range, so confirmations are fetched up to the latest block.
+	confirmations, err := fetchConfirmations(cCtx, council, blockTimes, fromBlock, header.Number.Uint64())
+	if err != nil {
+		return err
+	}
+
+	report := validator.BuildCouncilReport(requests, confirmations, owners, required.Uint64())
+	printReport(report, fromBlock, toBlock)
+	return nil
+}
+
+// blockTimes caches the L1 block timestamps by block hash.
+type blockTimes struct {
+	client *ethclient.Client
+	times  map[common.Hash]uint64
+}
+
+func newBlockTimes(client *ethclient.Client) *blockTimes {
+	return &blockTimes{client: client, times: make(map[common.Hash]uint64)}
+}
+
+func (b *blockTimes) get(ctx context.Context, hash common.Hash) (uint64, error) {
+	if t, ok := b.times[hash]; ok {
+		return t, nil
+	}
+	header, err := b.client.HeaderByHash(ctx, hash)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch L1 block %s: %w", hash, err)
+	}
+	b.times[hash] = header.Time
+	return header.Time, nil
+}
+
+func fetchRequests(ctx context.Context, council *bindings.SecurityCouncil, blockTimes *blockTimes, from, to uint64) ([]validator.CouncilRequest, error) {
+	iter, err := council.FilterValidationRequested(&bind.FilterOpts{Context: ctx, Start: from, End: &to}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to filter validation requests: %w", err)
+	}
+	defer iter.Close()
+
+	var requests []validator.CouncilRequest
+	for iter.Next() {
+		t, err := blockTimes.get(ctx, iter.Event.Raw.BlockHash)
+		if err != nil {
+			return nil, err
+		}
+		requests = append(requests, validator.CouncilRequest{
+			TransactionId: iter.Event.TransactionId,
+			L2BlockNumber: iter.Event.L2BlockNumber.Uint64(),
+			Time:          t,
+		})
+	}
+	if err := iter.Error(); err != nil {
+		return nil, fmt.Errorf("failed to filter validation requests: %w", err)
+	}
+	return requests, nil
+}
+
+func fetchConfirmations(ctx context.Context, council *bindings.SecurityCouncil, blockTimes *blockTimes, from, to uint64) ([]validator.CouncilConfirmation, error) {
+	iter, err := council.FilterConfirmation(&bind.FilterOpts{Context: ctx, Start: from, End: &to}, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to filter confirmations: %w", err)
+	}
+	defer iter.Close()
+
+	var confirmations []validator.CouncilConfirmation
+	for iter.Next() {
+		t, err := blockTimes.get(ctx, iter.Event.Raw.BlockHash)
+		if err != nil {
+			return nil, err
+		}
+		confirmations = append(confirmations, validator.CouncilConfirmation{
+			TransactionId: iter.Event.TransactionId,
+			Member:        iter.Event.Sender,
+			Time:          t,
+		})
+	}
+	if err := iter.Error(); err != nil {
+		return nil, fmt.Errorf("failed to filter confirmations: %w", err)
+	}
+	return confirmations, nil
+}
+
+func printReport(report validator.CouncilReport, from, to uint64) {
+	fmt.Printf("L1 blocks: %d - %d\n", from, to)
+	fmt.Printf("Validation requests: %d, quorum reached: %d (%d confirmations required)\n",
+		len(report.Transactions), report.QuorumReached, report.Required)
+	if report.QuorumReached > 0 {
+		fmt.Printf("Quorum formation time: avg %s, max %s\n", report.AvgQuorumLatency, report.MaxQuorumLatency)
+	}
+	fmt.Println()
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TRANSACTION ID\tL2 BLOCK\tREQUESTED AT (UTC)\tCONFIRMATIONS\tQUORUM TIME")
+	for _, tx := range report.Transactions {
+		quorum := "pending"
+		if tx.QuorumReached {
+			quorum = tx.QuorumLatency.String()
+		}
+		fmt.Fprintf(w, "%s\t%d\t%s\t%d\t%s\n", tx.Request.TransactionId, tx.Request.L2BlockNumber,
+			time.Unix(int64(tx.Request.Time), 0).UTC().Format(time.RFC3339), len(tx.Confirmations), quorum)
+	}
+	_ = w.Flush()
+	fmt.Println()
+
+	w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "MEMBER\tCONFIRMED\tMISSED\tAVG LATENCY\tMAX LATENCY")
+	for _, m := range report.Members {
+		fmt.Fprintf(w, "%s\t%d\t%d\t%s\t%s\n", m.Member.Hex(), m.Confirmations, m.Missed, m.AvgLatency, m.MaxLatency)
+	}
+	_ = w.Flush()
+}
@@ -0,0 +1,580 @@
+package export
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/urfave/cli"
+
+	"github.com/kroma-network/kroma/bindings/bindings"
+	"github.com/kroma-network/kroma/bindings/predeploys"
+	"github.com/kroma-network/kroma/components/validator"
+	"github.com/kroma-network/kroma/components/validator/flags"
+	"github.com/kroma-network/kroma/utils"
+)
+
+const (
+	FormatCSV  = "csv"
+	FormatJSON = "json"
+)
+
+// Export writes the reconciled report of the submissions, challenges and confirmations of the operator addresses,
+// the gas they spent, the bonds they moved and the rewards they earned in the given L1 block range.
+func Export(ctx *cli.Context) error {
+	format := ctx.String("format")
+	if format != FormatCSV && format != FormatJSON {
+		return fmt.Errorf("unknown format %q, expected %s or %s", format, FormatCSV, FormatJSON)
+	}
+	addrs, err := parseAddresses(ctx.String("addresses"))
+	if err != nil {
+		return err
+	}
+	contracts, err := parseContracts(ctx)
+	if err != nil {
+		return err
+	}
+	classifier, err := validator.NewAccountingClassifier(contracts)
+	if err != nil {
+		return err
+	}
+
+	l1Client, err := utils.DialEthClientWithTimeout(context.Background(), ctx.GlobalString(flags.L1EthRpcFlag.Name))
+	if err != nil {
+		return fmt.Errorf("failed to dial L1 RPC: %w", err)
+	}
+	defer l1Client.Close()
+
+	// the scan is not bounded in time, as the block range may be large.
+	cCtx := context.Background()
+	header, err := l1Client.HeaderByNumber(cCtx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to fetch latest L1 block: %w", err)
+	}
+	fromBlock := ctx.Uint64("from")
+	toBlock := ctx.Uint64("to")
+	if toBlock == 0 || toBlock > header.Number.Uint64() {
+		toBlock = header.Number.Uint64()
+	}
+	if fromBlock > toBlock {
+		return fmt.Errorf("from block %d is after to block %d", fromBlock, toBlock)
+	}
+	logRange := ctx.Uint64("log-range")
+	if logRange == 0 {
+		return fmt.Errorf("log range must be positive")
+	}
+
+	l1Times := newBlockTimes(l1Client)
+	entries, err := scanTransactions(cCtx, l1Client, classifier, l1Times, addrs, fromBlock, toBlock)
+	if err != nil {
+		return err
+	}
+	bondEntries, err := fetchBondEntries(cCtx, l1Client, contracts, l1Times, addrs, fromBlock, toBlock, logRange)
+	if err != nil {
+		return err
+	}
+	entries = append(entries, bondEntries...)
+
+	if l2RPC := ctx.String("l2-rpc"); l2RPC != "" {
+		rewards, err := fetchRewards(cCtx, l1Client, l2RPC, contracts.L2OutputOracle, bondEntries, addrs, logRange)
+		if err != nil {
+			return err
+		}
+		entries = append(entries, rewards...)
+	} else {
+		log.Warn("No L2 RPC set, the rewards are not exported")
+	}
+
+	journalPath := ctx.String("journal")
+	if journalPath == "" {
+		journalPath = ctx.GlobalString(flags.SweepJournalFlag.Name)
+	}
+	if journalPath != "" {
+		journal, err := validator.ReadSweepJournal(journalPath)
+		if err != nil {
+			return err
+		}
+		fromTime, err := l1Times.byNumber(cCtx, fromBlock)
+		if err != nil {
+			return err
+		}
+		toTime, err := l1Times.byNumber(cCtx, toBlock)
+		if err != nil {
+			return err
+		}
+		entries = validator.ReconcileSweepJournal(entries, filterJournal(journal, addrs), fromTime, toTime)
+	}
+
+	report := validator.BuildAccountingReport(entries, addrs, fromBlock, toBlock)
+
+	var out io.Writer = os.Stdout
+	if path := ctx.String("out"); path != "" {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer f.Close()
+		out = f
+	}
+	if format == FormatJSON {
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(report); err != nil {
+			return fmt.Errorf("failed to write report: %w", err)
+		}
+	} else if err := writeCSV(out, report); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+
+	for _, t := range report.Totals {
+		log.Info("Exported actions", "address", t.Address, "transactions", t.Transactions, "failed", t.FailedTransactions,
+			"gasCost", t.GasCost, "bondLocked", t.BondLocked, "bondReleased", t.BondReleased, "rewards", t.Rewards,
+			"unreconciled", t.Unreconciled)
+	}
+	return nil
+}
+
+func parseAddresses(s string) ([]common.Address, error) {
+	var addrs []common.Address
+	for _, part := range strings.Split(s, ",") {
+		addr, err := utils.ParseAddress(strings.TrimSpace(part))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse operator address %q: %w", part, err)
+		}
+		addrs = append(addrs, addr)
+	}
+	return addrs, nil
+}
+
+func parseContracts(ctx *cli.Context) (validator.AccountingContracts, error) {
+	var contracts validator.AccountingContracts
+	var err error
+	if contracts.L2OutputOracle, err = utils.ParseAddress(ctx.GlobalString(flags.L2OOAddressFlag.Name)); err != nil {
+		return contracts, fmt.Errorf("failed to parse L2OutputOracle address: %w", err)
+	}
+	if contracts.Colosseum, err = utils.ParseAddress(ctx.GlobalString(flags.ColosseumAddressFlag.Name)); err != nil {
+		return contracts, fmt.Errorf("failed to parse Colosseum address: %w", err)
+	}
+	if contracts.ValidatorPool, err = utils.ParseAddress(ctx.GlobalString(flags.ValPoolAddressFlag.Name)); err != nil {
+		return contracts, fmt.Errorf("failed to parse ValidatorPool address: %w", err)
+	}
+	// the SecurityCouncil is only set for the guardians.
+	if addr := ctx.GlobalString(flags.SecurityCouncilAddressFlag.Name); addr != "" {
+		if contracts.SecurityCouncil, err = utils.ParseAddress(addr); err != nil {
+			return contracts, fmt.Errorf("failed to parse SecurityCouncil address: %w", err)
+		}
+	}
+	return contracts, nil
+}
+
+// blockTimes caches the block timestamps by block hash.
+type blockTimes struct {
+	client *ethclient.Client
+	times  map[common.Hash]uint64
+}
+
+func newBlockTimes(client *ethclient.Client) *blockTimes {
+	return &blockTimes{client: client, times: make(map[common.Hash]uint64)}
+}
+
+func (b *blockTimes) get(ctx context.Context, hash common.Hash) (uint64, error) {
+	if t, ok := b.times[hash]; ok {
+		return t, nil
+	}
+	header, err := b.client.HeaderByHash(ctx, hash)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch block %s: %w", hash, err)
+	}
+	b.times[hash] = header.Time
+	return header.Time, nil
+}
+
+func (b *blockTimes) byNumber(ctx context.Context, number uint64) (uint64, error) {
+	header, err := b.client.HeaderByNumber(ctx, new(big.Int).SetUint64(number))
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch block %d: %w", number, err)
+	}
+	b.times[header.Hash()] = header.Time
+	return header.Time, nil
+}
+
+// scanTransactions classifies the transactions sent by the operator addresses in the L1 block range.
+// The senders are not indexed, so the blocks the operator addresses sent transactions in are found by the changes of
+// their nonces, see senderBlocks. If the L1 RPC does not serve the historical nonces, every block of the range is
+// fetched instead.
+func scanTransactions(ctx context.Context, l1Client *ethclient.Client, classifier *validator.AccountingClassifier,
+	times *blockTimes, addrs []common.Address, from, to uint64,
+) ([]validator.AccountingEntry, error) {
+	chainID, err := l1Client.ChainID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch L1 chain ID: %w", err)
+	}
+	signer := types.LatestSignerForChainID(chainID)
+	operators := make(map[common.Address]struct{}, len(addrs))
+	for _, addr := range addrs {
+		operators[addr] = struct{}{}
+	}
+
+	numbers, err := senderBlocks(ctx, l1Client, addrs, from, to)
+	if err != nil {
+		log.Warn("Failed to find the blocks of the operator transactions by their nonces, fetching every block", "err", err)
+		numbers = nil
+		for number := from; number <= to; number++ {
+			numbers = append(numbers, number)
+		}
+	}
+
+	var entries []validator.AccountingEntry
+	for i, number := range numbers {
+		if i%1000 == 0 {
+			log.Info("Scanning L1 blocks", "number", number, "to", to, "blocks", len(numbers))
+		}
+		block, err := l1Client.BlockByNumber(ctx, new(big.Int).SetUint64(number))
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch L1 block %d: %w", number, err)
+		}
+		times.times[block.Hash()] = block.Time()
+		for _, tx := range block.Transactions() {
+			sender, err := types.Sender(signer, tx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to recover sender of tx %s: %w", tx.Hash(), err)
+			}
+			if _, ok := operators[sender]; !ok {
+				continue
+			}
+			receipt, err := l1Client.TransactionReceipt(ctx, tx.Hash())
+			if err != nil {
+				return nil, fmt.Errorf("failed to fetch receipt of tx %s: %w", tx.Hash(), err)
+			}
+			entries = append(entries, classifier.Classify(tx, sender, receipt, block.Time()))
+		}
+	}
+	return entries, nil
+}
+
+// NonceReader reads the nonces of the accounts at the end of a block.
+type NonceReader interface {
+	NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error)
+}
+
+// senderBlocks returns the numbers of the blocks of the range the addresses sent transactions in, in ascending order.
+// A block includes transactions of an address if the nonce of the address changed in it, so the range is bisected
+// until the nonce of the address changes within a single block. This takes a few nonce reads per transaction instead
+// of fetching every block, but needs the historical state of the range.
+func senderBlocks(ctx context.Context, client NonceReader, addrs []common.Address, from, to uint64) ([]uint64, error) {
+	blocks := make(map[uint64]struct{})
+	for _, addr := range addrs {
+		nonceAt := func(number uint64) (uint64, error) {
+			nonce, err := client.NonceAt(ctx, addr, new(big.Int).SetUint64(number))
+			if err != nil {
+				return 0, fmt.Errorf("failed to fetch nonce of %s at block %d: %w", addr, number, err)
+			}
+			return nonce, nil
+		}
+		// bisect finds the blocks after start up to end the nonce of the address changed in.
+		var bisect func(start, startNonce, end, endNonce uint64) error
+		bisect = func(start, startNonce, end, endNonce uint64) error {
+			if startNonce == endNonce {
+				return nil
+			}
+			if end == start+1 {
+				blocks[end] = struct{}{}
+				return nil
+			}
+			mid := start + (end-start)/2
+			midNonce, err := nonceAt(mid)
+			if err != nil {
+				return err
+			}
+			if err := bisect(start, startNonce, mid, midNonce); err != nil {
+				return err
+			}
+			return bisect(mid, midNonce, end, endNonce)
+		}
+
+		endNonce, err := nonceAt(to)
+		if err != nil {
+			return nil, err
+		}
+		if from == 0 {
+			// the genesis block has no transactions
+			from = 1
+		}
+		startNonce, err := nonceAt(from - 1)
+		if err != nil {
+			return nil, err
+		}
+		if err := bisect(from-1, startNonce, to, endNonce); err != nil {
+			return nil, err
+		}
+	}
+
+	numbers := make([]uint64, 0, len(blocks))
+	for number := range blocks {
+		numbers = append(numbers, number)
+	}
+	sort.Slice(numbers, func(i, j int) bool { return numbers[i] < numbers[j] })
+	return numbers, nil
+}
+
+// filterRanges calls filter with the options of the consecutive chunks of at most size blocks of the range, so that
+// the logs of a large range are filtered within the block range limits of the RPC providers.
+func filterRanges(ctx context.Context, from, to, size uint64, filter func(opts *bind.FilterOpts) error) error {
+	for start := from; start <= to; start += size {
+		end := to
+		if to-start >= size {
+			end = start + size - 1
+		}
+		if err := filter(&bind.FilterOpts{Context: ctx, Start: start, End: &end}); err != nil {
+			return err
+		}
+		if end == to {
+			return nil
+		}
+	}
+	return nil
+}
+
+// fetchBondEntries fetches the bonds moved from and to the deposits of the operator addresses in the ValidatorPool,
+// and the challenges against their outputs. The logs are filtered in chunks of logRange blocks.
+func fetchBondEntries(ctx context.Context, l1Client *ethclient.Client, contracts validator.AccountingContracts,
+	times *blockTimes, addrs []common.Address, from, to, logRange uint64,
+) ([]validator.AccountingEntry, error) {
+	valPool, err := bindings.NewValidatorPoolFilterer(contracts.ValidatorPool, l1Client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to bind ValidatorPool: %w", err)
+	}
+	colosseum, err := bindings.NewColosseumFilterer(contracts.Colosseum, l1Client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to bind Colosseum: %w", err)
+	}
+
+	var entries []validator.AccountingEntry
+	add := func(raw types.Log, addr common.Address, action string, outputIndex *big.Int, bond *big.Int) error {
+		t, err := times.get(ctx, raw.BlockHash)
+		if err != nil {
+			return err
+		}
+		entries = append(entries, validator.AccountingEntry{
+			Time:        t,
+			Layer:       "l1",
+			Block:       raw.BlockNumber,
+			TxHash:      raw.TxHash,
+			Address:     addr,
+			Action:      action,
+			OutputIndex: outputIndex,
+			Bond:        bond,
+			Source:      validator.AccountingSourceChain,
+		})
+		return nil
+	}
+
+	err = filterRanges(ctx, from, to, logRange, func(opts *bind.FilterOpts) error {
+		bonded, err := valPool.FilterBonded(opts, addrs, nil)
+		if err != nil {
+			return fmt.Errorf("failed to filter bonds: %w", err)
+		}
+		for bonded.Next() {
+			ev := bonded.Event
+			if err := add(ev.Raw, ev.Submitter, validator.AccountingBond, ev.OutputIndex, new(big.Int).Neg(ev.Amount)); err != nil {
+				return err
+			}
+		}
+		if err := bonded.Error(); err != nil {
+			return fmt.Errorf("failed to filter bonds: %w", err)
+		}
+
+		increased, err := valPool.FilterBondIncreased(opts, addrs, nil)
+		if err != nil {
+			return fmt.Errorf("failed to filter bond increases: %w", err)
+		}
+		for increased.Next() {
+			ev := increased.Event
+			if err := add(ev.Raw, ev.Challenger, validator.AccountingBondIncrease, ev.OutputIndex, new(big.Int).Neg(ev.Amount)); err != nil {
+				return err
+			}
+		}
+		if err := increased.Error(); err != nil {
+			return fmt.Errorf("failed to filter bond increases: %w", err)
+		}
+
+		unbonded, err := valPool.FilterUnbonded(opts, nil, addrs)
+		if err != nil {
+			return fmt.Errorf("failed to filter unbonds: %w", err)
+		}
+		for unbonded.Next() {
+			ev := unbonded.Event
+			if err := add(ev.Raw, ev.Recipient, validator.AccountingBondRelease, ev.OutputIndex, ev.Amount); err != nil {
+				return err
+			}
+		}
+		if err := unbonded.Error(); err != nil {
+			return fmt.Errorf("failed to filter unbonds: %w", err)
+		}
+
+		challenged, err := colosseum.FilterChallengeCreated(opts, nil, addrs, nil)
+		if err != nil {
+			return fmt.Errorf("failed to filter challenges: %w", err)
+		}
+		for challenged.Next() {
+			ev := challenged.Event
+			if err := add(ev.Raw, ev.Asserter, validator.AccountingChallenged, ev.OutputIndex, nil); err != nil {
+				return err
+			}
+		}
+		if err := challenged.Error(); err != nil {
+			return fmt.Errorf("failed to filter challenges: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// fetchRewards fetches the rewards paid on L2 for the outputs whose bonds were released to the operator addresses.
+// The rewards are paid by the ValidatorRewardVault once the bond is released, after the L2 block of the output. The
+// logs are filtered in chunks of logRange blocks up to the latest L2 block.
+func fetchRewards(ctx context.Context, l1Client *ethclient.Client, l2RPC string, l2ooAddr common.Address,
+	bondEntries []validator.AccountingEntry, addrs []common.Address, logRange uint64,
+) ([]validator.AccountingEntry, error) {
+	l2oo, err := bindings.NewL2OutputOracleCaller(l2ooAddr, l1Client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to bind L2OutputOracle: %w", err)
+	}
+	var l2Blocks []*big.Int
+	var start uint64
+	for _, e := range bondEntries {
+		if e.Action != validator.AccountingBondRelease {
+			continue
+		}
+		output, err := l2oo.GetL2Output(&bind.CallOpts{Context: ctx}, e.OutputIndex)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get output %d: %w", e.OutputIndex, err)
+		}
+		if len(l2Blocks) == 0 || output.L2BlockNumber.Uint64() < start {
+			start = output.L2BlockNumber.Uint64()
+		}
+		l2Blocks = append(l2Blocks, output.L2BlockNumber)
+	}
+	if len(l2Blocks) == 0 {
+		return nil, nil
+	}
+
+	l2Client, err := utils.DialEthClientWithTimeout(ctx, l2RPC)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial L2 RPC: %w", err)
+	}
+	defer l2Client.Close()
+	vault, err := bindings.NewValidatorRewardVaultFilterer(predeploys.ValidatorRewardVaultAddr, l2Client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to bind ValidatorRewardVault: %w", err)
+	}
+	head, err := l2Client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch latest L2 block: %w", err)
+	}
+
+	l2Times := newBlockTimes(l2Client)
+	var entries []validator.AccountingEntry
+	err = filterRanges(ctx, start, head.Number.Uint64(), logRange, func(opts *bind.FilterOpts) error {
+		rewarded, err := vault.FilterRewarded(opts, addrs, l2Blocks)
+		if err != nil {
+			return fmt.Errorf("failed to filter rewards: %w", err)
+		}
+		defer rewarded.Close()
+		for rewarded.Next() {
+			ev := rewarded.Event
+			t, err := l2Times.get(ctx, ev.Raw.BlockHash)
+			if err != nil {
+				return err
+			}
+			entries = append(entries, validator.AccountingEntry{
+				Time:    t,
+				Layer:   "l2",
+				Block:   ev.Raw.BlockNumber,
+				TxHash:  ev.Raw.TxHash,
+				Address: ev.Validator,
+				Action:  validator.AccountingReward,
+				Amount:  ev.Amount,
+				Source:  validator.AccountingSourceChain,
+			})
+		}
+		if err := rewarded.Error(); err != nil {
+			return fmt.Errorf("failed to filter rewards: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+func filterJournal(journal []validator.SweepJournalEntry, addrs []common.Address) []validator.SweepJournalEntry {
+	var filtered []validator.SweepJournalEntry
+	for _, entry := range journal {
+		for _, addr := range addrs {
+			if entry.From == addr {
+				filtered = append(filtered, entry)
+				break
+			}
+		}
+	}
+	return filtered
+}
+
+var csvHeader = []string{
+	"time", "layer", "block", "tx_hash", "address", "action", "to", "output_index",
+	"gas_used", "gas_cost_wei", "failed", "bond_wei", "amount_wei", "source",
+}
+
+func writeCSV(out io.Writer, report validator.AccountingReport) error {
+	w := csv.NewWriter(out)
+	if err := w.Write(csvHeader); err != nil {
+		return err
+	}
+	for _, e := range report.Entries {
+		var block, txHash, to, gasUsed string
+		if e.Layer != "" {
+			block = strconv.FormatUint(e.Block, 10)
+			txHash = e.TxHash.Hex()
+		}
+		if e.To != (common.Address{}) {
+			to = e.To.Hex()
+		}
+		if e.GasCost != nil {
+			gasUsed = strconv.FormatUint(e.GasUsed, 10)
+		}
+		if err := w.Write([]string{
+			time.Unix(int64(e.Time), 0).UTC().Format(time.RFC3339),
+			e.Layer, block, txHash, e.Address.Hex(), e.Action, to, formatInt(e.OutputIndex),
+			gasUsed, formatInt(e.GasCost), strconv.FormatBool(e.Failed), formatInt(e.Bond), formatInt(e.Amount), e.Source,
+		}); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
+
+func formatInt(x *big.Int) string {
+	if x == nil {
+		return ""
+	}
+	return x.String()
+}
@@ -0,0 +1,82 @@
+package export
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeNonces serves the nonces of the accounts sending a transaction in each of the blocks.
+type fakeNonces struct {
+	txBlocks map[common.Address][]uint64
+	reads    int
+	err      error
+}
+
+func (f *fakeNonces) NonceAt(_ context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+	if f.err != nil {
+		return 0, f.err
+	}
+	f.reads++
+	var nonce uint64
+	for _, number := range f.txBlocks[account] {
+		if number <= blockNumber.Uint64() {
+			nonce++
+		}
+	}
+	return nonce, nil
+}
+
+func TestSenderBlocks(t *testing.T) {
+	a, b := common.Address{0xa}, common.Address{0xb}
+	nonces := &fakeNonces{txBlocks: map[common.Address][]uint64{
+		a: {5, 5, 100, 10_000},
+		b: {1, 100, 99_999},
+	}}
+
+	blocks, err := senderBlocks(context.Background(), nonces, []common.Address{a, b}, 0, 99_999)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{1, 5, 100, 10_000, 99_999}, blocks)
+	require.Less(t, nonces.reads, 200, "expected a few nonce reads per transaction")
+
+	blocks, err = senderBlocks(context.Background(), nonces, []common.Address{a, b}, 6, 10_000)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{100, 10_000}, blocks)
+
+	blocks, err = senderBlocks(context.Background(), nonces, []common.Address{a}, 10_001, 99_999)
+	require.NoError(t, err)
+	require.Empty(t, blocks)
+
+	nonces.err = errors.New("missing trie node")
+	_, err = senderBlocks(context.Background(), nonces, []common.Address{a}, 0, 100)
+	require.ErrorContains(t, err, "missing trie node")
+}
+
+func TestFilterRanges(t *testing.T) {
+	var ranges [][2]uint64
+	filter := func(opts *bind.FilterOpts) error {
+		ranges = append(ranges, [2]uint64{opts.Start, *opts.End})
+		return nil
+	}
+
+	require.NoError(t, filterRanges(context.Background(), 10, 25, 10, filter))
+	require.Equal(t, [][2]uint64{{10, 19}, {20, 25}}, ranges)
+
+	ranges = nil
+	require.NoError(t, filterRanges(context.Background(), 10, 19, 10, filter))
+	require.Equal(t, [][2]uint64{{10, 19}}, ranges)
+
+	ranges = nil
+	require.NoError(t, filterRanges(context.Background(), 7, 7, 10, filter))
+	require.Equal(t, [][2]uint64{{7, 7}}, ranges)
+
+	err := filterRanges(context.Background(), 0, 100, 10, func(opts *bind.FilterOpts) error {
+		return errors.New("range too large")
+	})
+	require.ErrorContains(t, err, "range too large")
+}
@@ -0,0 +1,204 @@
+package guardian
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/urfave/cli"
+
+	"github.com/kroma-network/kroma/bindings/bindings"
+	"github.com/kroma-network/kroma/components/validator"
+	"github.com/kroma-network/kroma/components/validator/flags"
+	"github.com/kroma-network/kroma/utils"
+	"github.com/kroma-network/kroma/utils/service/txmgr"
+)
+
+// Check compares the local guardian configuration against the SecurityCouncil and the contracts
+// referencing it on L1, and fails if any of them is misconfigured.
+func Check(ctx *cli.Context) error {
+	local, err := readLocalConfig(ctx)
+	if err != nil {
+		return err
+	}
+
+	l1Client, err := utils.DialEthClientWithTimeout(context.Background(), ctx.GlobalString(flags.L1EthRpcFlag.Name))
+	if err != nil {
+		return fmt.Errorf("failed to dial L1 RPC: %w", err)
+	}
+	defer l1Client.Close()
+
+	cCtx, cCancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cCancel()
+
+	onChain, err := fetchOnChainConfig(cCtx, l1Client, local)
+	if err != nil {
+		return err
+	}
+
+	checks := validator.CheckGuardianConfig(local, onChain)
+	fmt.Printf("Guardian: %s\n", local.Guardian)
+	if onChain.CouncilDeployed {
+		fmt.Printf("SecurityCouncil: %s, %d of %d confirmations required\n", local.CouncilAddr, onChain.Required, len(onChain.Owners))
+	}
+	fmt.Println()
+
+	failed := 0
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "CHECK\tRESULT\tDETAIL")
+	for _, check := range checks {
+		if check.OK {
+			fmt.Fprintf(w, "%s\tOK\t\n", check.Name)
+		} else {
+			failed++
+			fmt.Fprintf(w, "%s\tFAIL\t%s\n", check.Name, check.Detail)
+		}
+	}
+	_ = w.Flush()
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d guardian config checks failed", failed, len(checks))
+	}
+	return nil
+}
+
+// Decisions prints the decisions recorded in the guardian store. The store is locked by a running validator.
+func Decisions(ctx *cli.Context) error {
+	path := ctx.GlobalString(flags.GuardianStorePathFlag.Name)
+	if path == "" {
+		return errors.New("must provide the guardian store path")
+	}
+	if _, err := os.Stat(path); err != nil {
+		return fmt.Errorf("failed to open guardian store: %w", err)
+	}
+	store, err := validator.NewGuardianStore(path)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	decisions, err := store.Decisions(context.Background())
+	if err != nil {
+		return err
+	}
+	outcome := validator.GuardianOutcome(ctx.String("outcome"))
+	filtered := make([]*validator.GuardianDecision, 0, len(decisions))
+	for _, decision := range decisions {
+		if outcome == "" || decision.Outcome == outcome {
+			filtered = append(filtered, decision)
+		}
+	}
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(filtered)
+}
+
+func readLocalConfig(ctx *cli.Context) (validator.GuardianLocalConfig, error) {
+	councilAddr, err := utils.ParseAddress(ctx.GlobalString(flags.SecurityCouncilAddressFlag.Name))
+	if err != nil {
+		return validator.GuardianLocalConfig{}, fmt.Errorf("failed to parse SecurityCouncil address: %w", err)
+	}
+	colosseumAddr, err := utils.ParseAddress(ctx.GlobalString(flags.ColosseumAddressFlag.Name))
+	if err != nil {
+		return validator.GuardianLocalConfig{}, fmt.Errorf("failed to parse Colosseum address: %w", err)
+	}
+	l2ooAddr, err := utils.ParseAddress(ctx.GlobalString(flags.L2OOAddressFlag.Name))
+	if err != nil {
+		return validator.GuardianLocalConfig{}, fmt.Errorf("failed to parse L2OutputOracle address: %w", err)
+	}
+	valpoolAddr, err := utils.ParseAddress(ctx.GlobalString(flags.ValPoolAddressFlag.Name))
+	if err != nil {
+		return validator.GuardianLocalConfig{}, fmt.Errorf("failed to parse ValidatorPool address: %w", err)
+	}
+
+	// the guardian confirms the validation requests with its own key if set, and the key of the tx manager otherwise.
+	var guardian common.Address
+	if key := ctx.GlobalString(flags.GuardianPrivateKeyFlag.Name); key != "" {
+		privKey, err := crypto.HexToECDSA(strings.TrimPrefix(key, "0x"))
+		if err != nil {
+			return validator.GuardianLocalConfig{}, fmt.Errorf("failed to parse guardian private key: %w", err)
+		}
+		guardian = crypto.PubkeyToAddress(privKey.PublicKey)
+	} else {
+		txMgrConfig, err := txmgr.NewConfig(txmgr.ReadCLIConfig(ctx), log.New())
+		if err != nil {
+			return validator.GuardianLocalConfig{}, fmt.Errorf("failed to read tx manager config: %w", err)
+		}
+		guardian = txMgrConfig.From
+	}
+
+	return validator.GuardianLocalConfig{
+		Guardian:        guardian,
+		GuardianEnabled: ctx.GlobalBool(flags.GuardianEnabledFlag.Name),
+		CouncilAddr:     councilAddr,
+		ColosseumAddr:   colosseumAddr,
+		L2OOAddr:        l2ooAddr,
+		ValPoolAddr:     valpoolAddr,
+	}, nil
+}
+
+func fetchOnChainConfig(ctx context.Context, l1Client *ethclient.Client, local validator.GuardianLocalConfig) (validator.GuardianOnChainConfig, error) {
+	var onChain validator.GuardianOnChainConfig
+	opts := &bind.CallOpts{Context: ctx}
+
+	colosseum, err := bindings.NewColosseumCaller(local.ColosseumAddr, l1Client)
+	if err != nil {
+		return onChain, fmt.Errorf("failed to bind Colosseum: %w", err)
+	}
+	if onChain.ColosseumCouncil, err = colosseum.SECURITYCOUNCIL(opts); err != nil {
+		return onChain, fmt.Errorf("failed to get SecurityCouncil address of Colosseum: %w", err)
+	}
+	if onChain.ColosseumL2OO, err = colosseum.L2ORACLE(opts); err != nil {
+		return onChain, fmt.Errorf("failed to get L2OutputOracle address of Colosseum: %w", err)
+	}
+
+	l2oo, err := bindings.NewL2OutputOracleCaller(local.L2OOAddr, l1Client)
+	if err != nil {
+		return onChain, fmt.Errorf("failed to bind L2OutputOracle: %w", err)
+	}
+	if onChain.L2OOValPool, err = l2oo.VALIDATORPOOL(opts); err != nil {
+		return onChain, fmt.Errorf("failed to get ValidatorPool address of L2OutputOracle: %w", err)
+	}
+
+	if local.CouncilAddr == (common.Address{}) {
+		return onChain, nil
+	}
+	code, err := l1Client.CodeAt(ctx, local.CouncilAddr, nil)
+	if err != nil {
+		return onChain, fmt.Errorf("failed to get code of SecurityCouncil: %w", err)
+	}
+	if len(code) == 0 {
+		return onChain, nil
+	}
+	onChain.CouncilDeployed = true
+
+	council, err := bindings.NewSecurityCouncilCaller(local.CouncilAddr, l1Client)
+	if err != nil {
+		return onChain, fmt.Errorf("failed to bind SecurityCouncil: %w", err)
+	}
+	if onChain.Owners, err = council.GetOwners(opts); err != nil {
+		return onChain, fmt.Errorf("failed to get owners: %w", err)
+	}
+	required, err := council.NumConfirmationsRequired(opts)
+	if err != nil {
+		return onChain, fmt.Errorf("failed to get number of required confirmations: %w", err)
+	}
+	if !required.IsUint64() {
+		return onChain, errors.New("invalid number of required confirmations")
+	}
+	onChain.Required = required.Uint64()
+	if onChain.CouncilColosseum, err = council.COLOSSEUM(opts); err != nil {
+		return onChain, fmt.Errorf("failed to get Colosseum address of SecurityCouncil: %w", err)
+	}
+	return onChain, nil
+}
@@ -9,6 +9,10 @@ import (
 
 	"github.com/kroma-network/kroma/components/validator"
 	"github.com/kroma-network/kroma/components/validator/cmd/balance"
+	"github.com/kroma-network/kroma/components/validator/cmd/council"
+	"github.com/kroma-network/kroma/components/validator/cmd/export"
+	"github.com/kroma-network/kroma/components/validator/cmd/guardian"
+	"github.com/kroma-network/kroma/components/validator/cmd/schedule"
 	"github.com/kroma-network/kroma/components/validator/flags"
 	klog "github.com/kroma-network/kroma/utils/service/log"
 )
@@ -28,6 +32,12 @@ func main() {
 	app.Usage = "L2 Output Submitter and Challenger Service"
 	app.Description = "Service for generating and submitting L2 output checkpoints to the L2OutputOracle contract as an L2 Output Submitter, " + "detecting and correcting invalid L2 outputs as a Challenger to ensure the integrity of the L2 state."
 
+	app.Before = func(ctx *cli.Context) error {
+		if err := validator.ApplyNetworkPreset(ctx); err != nil {
+			return err
+		}
+		return validator.ApplyProfile(ctx)
+	}
 	app.Action = curryMain(Version)
 	app.Commands = []cli.Command{
 		{
@@ -59,6 +69,118 @@ func main() {
 			Usage:  "Attempt to unbond in ValidatorPool",
 			Action: balance.Unbond,
 		},
+		{
+			Name:  "register",
+			Usage: "Register as a validator by depositing the minimum bond amount into ValidatorPool",
+			Flags: []cli.Flag{
+				cli.Uint64Flag{
+					Name:  "amount",
+					Usage: "Amount to deposit into ValidatorPool (in wei). Defaults to the deposit missing for the minimum bond amount",
+				},
+				cli.BoolFlag{
+					Name:  "dry-run",
+					Usage: "Only check the deposit and print the amount to deposit, without sending the transaction",
+				},
+				cli.BoolFlag{
+					Name:  "yes",
+					Usage: "Deposit without the confirmation prompt",
+				},
+			},
+			Action: balance.Register,
+		},
+		{
+			Name:  "schedule",
+			Usage: "Predict the upcoming output submission rounds and their priority validators",
+			Flags: []cli.Flag{
+				cli.Uint64Flag{
+					Name:  "n",
+					Usage: "Number of upcoming rounds to predict",
+					Value: 20,
+				},
+			},
+			Action: schedule.Schedule,
+		},
+		{
+			Name:  "council-report",
+			Usage: "Report how fast the SecurityCouncil members confirmed the validation requests",
+			Flags: []cli.Flag{
+				cli.Uint64Flag{
+					Name:     "from-block",
+					Usage:    "First L1 block to report the validation requests of",
+					Required: true,
+				},
+				cli.Uint64Flag{
+					Name:  "to-block",
+					Usage: "Last L1 block to report the validation requests of, the latest block if not set",
+				},
+			},
+			Action: council.Report,
+		},
+		{
+			Name:  "export",
+			Usage: "Export the historical submissions, challenges and confirmations of the operator for accounting",
+			Flags: []cli.Flag{
+				cli.Uint64Flag{
+					Name:     "from",
+					Usage:    "First L1 block to export the actions of",
+					Required: true,
+				},
+				cli.Uint64Flag{
+					Name:  "to",
+					Usage: "Last L1 block to export the actions of, the latest block if not set",
+				},
+				cli.StringFlag{
+					Name:  "format",
+					Usage: "Format of the report: csv or json",
+					Value: export.FormatCSV,
+				},
+				cli.StringFlag{
+					Name:     "addresses",
+					Usage:    "Comma separated operator addresses to export the actions of",
+					Required: true,
+				},
+				cli.StringFlag{
+					Name:  "journal",
+					Usage: "Sweep journal file to reconcile the sweeps with, the --challenger.sweep-journal if not set",
+				},
+				cli.StringFlag{
+					Name:  "l2-rpc",
+					Usage: "L2 RPC URL to export the rewards paid by the ValidatorRewardVault from. Rewards are not exported if not set",
+				},
+				cli.Uint64Flag{
+					Name:  "log-range",
+					Usage: "Maximum number of blocks to filter the logs of at once, within the block range limit of the RPC providers",
+					Value: 2000,
+				},
+				cli.StringFlag{
+					Name:  "out",
+					Usage: "Path to the output file. Defaults to the standard output.",
+				},
+			},
+			Action: export.Export,
+		},
+		{
+			Name:  "guardian",
+			Usage: "Guardian related commands",
+			Subcommands: []cli.Command{
+				{
+					Name:   "check",
+					Usage:  "Check the local guardian config against the on-chain SecurityCouncil parameters",
+					Action: guardian.Check,
+				},
+				{
+					Name:  "decisions",
+					Usage: "Print the decisions recorded in the guardian store as JSON, while the validator is stopped",
+					Flags: []cli.Flag{
+						cli.StringFlag{
+							Name:  "outcome",
+							Usage: "Only print the decisions of the outcome, e.g. mismatch",
+						},
+					},
+					Action: guardian.Decisions,
+				},
+			},
+		},
 	}
 
 	err := app.Run(os.Args)
@@ -0,0 +1,193 @@
+package schedule
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli"
+
+	"github.com/kroma-network/kroma/bindings/bindings"
+	"github.com/kroma-network/kroma/components/validator"
+	"github.com/kroma-network/kroma/components/validator/flags"
+	"github.com/kroma-network/kroma/utils"
+)
+
+// submissionRound is a predicted output submission round.
+type submissionRound struct {
+	OutputIndex   uint64
+	L2BlockNumber uint64
+	// PriorityStart is when the priority validator is allowed to submit the output.
+	PriorityStart time.Time
+	// PublicStart is when anyone is allowed to submit the output.
+	PublicStart time.Time
+	// Validator is the priority validator of the round, zero if it is not predictable yet.
+	Validator common.Address
+}
+
+// poolState is the on-chain state the rounds are predicted from.
+type poolState struct {
+	nextOutputIndex    uint64
+	nextBlockNumber    uint64
+	nextL2Timestamp    uint64
+	submissionInterval uint64
+	l2BlockTime        uint64
+	roundDuration      uint64
+	nextValidator      common.Address
+	trustedValidator   common.Address
+	validatorCount     uint64
+}
+
+// predictRounds predicts the next n output submission rounds.
+// Only the priority validator of the next round is known: the following ones are selected
+// pseudo-randomly when the preceding outputs are finalized, unless there is no validator in the pool,
+// in which case the trusted validator has the priority.
+func predictRounds(s poolState, n uint64) []submissionRound {
+	rounds := make([]submissionRound, 0, n)
+	for i := uint64(0); i < n; i++ {
+		priorityStart := s.nextL2Timestamp + i*s.submissionInterval*s.l2BlockTime
+		round := submissionRound{
+			OutputIndex:   s.nextOutputIndex + i,
+			L2BlockNumber: s.nextBlockNumber + i*s.submissionInterval,
+			PriorityStart: time.Unix(int64(priorityStart), 0),
+			PublicStart:   time.Unix(int64(priorityStart+s.roundDuration), 0),
+		}
+		switch {
+		case i == 0:
+			round.Validator = s.nextValidator
+		case s.validatorCount == 0:
+			round.Validator = s.trustedValidator
+		}
+		rounds = append(rounds, round)
+	}
+	return rounds
+}
+
+func Schedule(ctx *cli.Context) error {
+	n := ctx.Uint64("n")
+	if n == 0 {
+		return fmt.Errorf("number of rounds must not be 0")
+	}
+
+	l2ooAddr, err := utils.ParseAddress(ctx.GlobalString(flags.L2OOAddressFlag.Name))
+	if err != nil {
+		return fmt.Errorf("failed to parse L2OutputOracle address: %w", err)
+	}
+	valpoolAddr, err := utils.ParseAddress(ctx.GlobalString(flags.ValPoolAddressFlag.Name))
+	if err != nil {
+		return fmt.Errorf("failed to parse ValidatorPool address: %w", err)
+	}
+
+	l1Client, err := utils.DialEthClientWithTimeout(context.Background(), ctx.GlobalString(flags.L1EthRpcFlag.Name))
+	if err != nil {
+		return fmt.Errorf("failed to dial L1 RPC: %w", err)
+	}
+	defer l1Client.Close()
+
+	l2ooContract, err := bindings.NewL2OutputOracleCaller(l2ooAddr, l1Client)
+	if err != nil {
+		return fmt.Errorf("failed to bind L2OutputOracle: %w", err)
+	}
+	valpoolContract, err := bindings.NewValidatorPoolCaller(valpoolAddr, l1Client)
+	if err != nil {
+		return fmt.Errorf("failed to bind ValidatorPool: %w", err)
+	}
+
+	cCtx, cCancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cCancel()
+
+	// Pin all calls to the same L1 block for a consistent view of the state.
+	header, err := l1Client.HeaderByNumber(cCtx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to fetch latest L1 block: %w", err)
+	}
+	opts := &bind.CallOpts{Context: cCtx, BlockNumber: header.Number}
+
+	state, err := fetchPoolState(opts, l2ooContract, valpoolContract)
+	if err != nil {
+		return err
+	}
+	rounds := predictRounds(state, n)
+
+	fmt.Printf("L1 block: %d (%s)\n", header.Number, time.Unix(int64(header.Time), 0).UTC().Format(time.RFC3339))
+	fmt.Printf("Validators in pool: %d\n\n", state.validatorCount)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "OUTPUT INDEX\tL2 BLOCK\tPRIORITY ROUND START (UTC)\tPUBLIC ROUND START (UTC)\tPRIORITY VALIDATOR")
+	for _, r := range rounds {
+		fmt.Fprintf(w, "%d\t%d\t%s\t%s\t%s\n", r.OutputIndex, r.L2BlockNumber,
+			r.PriorityStart.UTC().Format(time.RFC3339), r.PublicStart.UTC().Format(time.RFC3339),
+			describeValidator(r.Validator, state.validatorCount))
+	}
+	return w.Flush()
+}
+
+func fetchPoolState(opts *bind.CallOpts, l2oo *bindings.L2OutputOracleCaller, valpool *bindings.ValidatorPoolCaller) (poolState, error) {
+	var s poolState
+
+	nextOutputIndex, err := l2oo.NextOutputIndex(opts)
+	if err != nil {
+		return s, fmt.Errorf("failed to get next output index: %w", err)
+	}
+	nextBlockNumber, err := l2oo.NextBlockNumber(opts)
+	if err != nil {
+		return s, fmt.Errorf("failed to get next block number: %w", err)
+	}
+	// The round of an output starts once the L2 block right after the checkpoint can be produced.
+	nextL2Timestamp, err := l2oo.ComputeL2Timestamp(opts, new(big.Int).Add(nextBlockNumber, common.Big1))
+	if err != nil {
+		return s, fmt.Errorf("failed to compute L2 timestamp: %w", err)
+	}
+	submissionInterval, err := l2oo.SUBMISSIONINTERVAL(opts)
+	if err != nil {
+		return s, fmt.Errorf("failed to get submission interval: %w", err)
+	}
+	l2BlockTime, err := l2oo.L2BLOCKTIME(opts)
+	if err != nil {
+		return s, fmt.Errorf("failed to get L2 block time: %w", err)
+	}
+	roundDuration, err := valpool.ROUNDDURATION(opts)
+	if err != nil {
+		return s, fmt.Errorf("failed to get round duration: %w", err)
+	}
+	trustedValidator, err := valpool.TRUSTEDVALIDATOR(opts)
+	if err != nil {
+		return s, fmt.Errorf("failed to get trusted validator: %w", err)
+	}
+	nextValidator, err := valpool.NextValidator(opts)
+	if err != nil {
+		return s, fmt.Errorf("failed to get next validator: %w", err)
+	}
+	validatorCount, err := valpool.ValidatorCount(opts)
+	if err != nil {
+		return s, fmt.Errorf("failed to get validator count: %w", err)
+	}
+
+	return poolState{
+		nextOutputIndex:    nextOutputIndex.Uint64(),
+		nextBlockNumber:    nextBlockNumber.Uint64(),
+		nextL2Timestamp:    nextL2Timestamp.Uint64(),
+		submissionInterval: submissionInterval.Uint64(),
+		l2BlockTime:        l2BlockTime.Uint64(),
+		roundDuration:      roundDuration.Uint64(),
+		nextValidator:      nextValidator,
+		trustedValidator:   trustedValidator,
+		validatorCount:     validatorCount.Uint64(),
+	}, nil
+}
+
+func describeValidator(addr common.Address, validatorCount uint64) string {
+	switch addr {
+	case common.Address{}:
+		return fmt.Sprintf("not selected yet (1 of %d validators)", validatorCount)
+	case validator.PublicRoundAddress:
+		return "none (public round)"
+	default:
+		return addr.Hex()
+	}
+}
@@ -0,0 +1,62 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kroma-network/kroma/components/validator"
+)
+
+func TestPredictRounds(t *testing.T) {
+	state := poolState{
+		nextOutputIndex:    5,
+		nextBlockNumber:    1800,
+		nextL2Timestamp:    1_000_000,
+		submissionInterval: 1800,
+		l2BlockTime:        2,
+		roundDuration:      600,
+		nextValidator:      common.Address{0xaa},
+		trustedValidator:   common.Address{0xbb},
+		validatorCount:     3,
+	}
+
+	rounds := predictRounds(state, 3)
+	require.Equal(t, []submissionRound{
+		{
+			OutputIndex:   5,
+			L2BlockNumber: 1800,
+			PriorityStart: time.Unix(1_000_000, 0),
+			PublicStart:   time.Unix(1_000_600, 0),
+			Validator:     common.Address{0xaa},
+		},
+		{
+			OutputIndex:   6,
+			L2BlockNumber: 3600,
+			PriorityStart: time.Unix(1_003_600, 0),
+			PublicStart:   time.Unix(1_004_200, 0),
+		},
+		{
+			OutputIndex:   7,
+			L2BlockNumber: 5400,
+			PriorityStart: time.Unix(1_007_200, 0),
+			PublicStart:   time.Unix(1_007_800, 0),
+		},
+	}, rounds, "only the priority validator of the next round is known")
+
+	// the trusted validator has the priority of every round while the pool is empty
+	state.validatorCount = 0
+	rounds = predictRounds(state, 2)
+	require.Equal(t, common.Address{0xaa}, rounds[0].Validator)
+	require.Equal(t, common.Address{0xbb}, rounds[1].Validator)
+
+	require.Empty(t, predictRounds(state, 0))
+}
+
+func TestDescribeValidator(t *testing.T) {
+	require.Equal(t, "not selected yet (1 of 3 validators)", describeValidator(common.Address{}, 3))
+	require.Equal(t, "none (public round)", describeValidator(validator.PublicRoundAddress, 3))
+	require.Equal(t, common.Address{0xaa}.Hex(), describeValidator(common.Address{0xaa}, 3))
+}
@@ -0,0 +1,163 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/kroma-network/kroma/components/node/eth"
+	"github.com/kroma-network/kroma/components/node/rollup"
+	"github.com/kroma-network/kroma/components/validator/metrics"
+)
+
+var ErrIncompatibleRollupNode = errors.New("rollup node is incompatible with the validator")
+
+// NodeProtocolVersionProvider provides the protocol version and the rollup config of the rollup node.
+type NodeProtocolVersionProvider interface {
+	ProtocolVersion(ctx context.Context) (*eth.NodeProtocolVersion, error)
+	RollupConfig(ctx context.Context) (*rollup.Config, error)
+}
+
+// checkNodeProtocolVersion returns ErrIncompatibleRollupNode unless the rollup node supports the protocol version of
+// the validator. The major and minor versions, bumped by the protocol upgrades, must match, the patch, the pre-release
+// and the build may differ.
+func checkNodeProtocolVersion(local, node eth.ProtocolVersion) error {
+	switch cmp := node.Compare(local); cmp {
+	case eth.Matching, eth.AheadPatch, eth.OutdatedPatch, eth.AheadPrerelease, eth.OutdatedPrerelease, eth.DiffBuildID:
+		return nil
+	default:
+		return fmt.Errorf("%w: protocol version of the rollup node %s, of the validator %s (%s)", ErrIncompatibleRollupNode, node, local, cmp)
+	}
+}
+
+// compatibilityMonitor checks on start and periodically that the rollup node supports the protocol version of the
+// validator, and still derives the blocks by the consensus fields of the rollup config the validator started with.
+// While the rollup node is incompatible, its outputs may be computed by rules the validator does not know of, so the
+// roles hold their transactions until it is compatible again.
+type compatibilityMonitor struct {
+	log            log.Logger
+	metr           metrics.Metricer
+	client         NodeProtocolVersionProvider
+	local          eth.ProtocolVersion
+	rollupConfig   *rollup.Config
+	interval       time.Duration
+	networkTimeout time.Duration
+
+	mu            sync.Mutex
+	err           error // the incompatibility found by the last check, nil if compatible
+	warnedUnknown bool  // whether the unknown protocol version of the rollup node was logged
+}
+
+func newCompatibilityMonitor(l log.Logger, m metrics.Metricer, client NodeProtocolVersionProvider, rollupConfig *rollup.Config,
+	interval time.Duration, networkTimeout time.Duration,
+) *compatibilityMonitor {
+	return &compatibilityMonitor{
+		log:            l,
+		metr:           m,
+		client:         client,
+		local:          rollup.SupportedProtocolVersion,
+		rollupConfig:   rollupConfig,
+		interval:       interval,
+		networkTimeout: networkTimeout,
+	}
+}
+
+// Start checks the compatibility of the rollup node, failing if it is incompatible or unreachable, and then checks it
+// periodically.
+func (c *compatibilityMonitor) Start(ctx context.Context, wg *sync.WaitGroup) error {
+	if err := c.check(ctx); err != nil {
+		return err
+	}
+	if err := c.Err(); err != nil {
+		return err
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(c.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if err := c.check(ctx); err != nil {
+					c.log.Warn("failed to check compatibility of the rollup node", "err", err)
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// Err returns the incompatibility of the rollup node found by the last check, nil if it is compatible.
+func (c *compatibilityMonitor) Err() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.err
+}
+
+// check compares the protocol version and the consensus fields of the rollup config of the rollup node against the
+// ones of the validator. The protocol version of a rollup node without the kroma_protocolVersion method is unknown,
+// and not checked. If the rollup node is unreachable, the result of the last check is kept.
+func (c *compatibilityMonitor) check(ctx context.Context) error {
+	var version eth.ProtocolVersion
+	cCtx, cCancel := context.WithTimeout(ctx, c.networkTimeout)
+	defer cCancel()
+	node, err := c.client.ProtocolVersion(cCtx)
+	if isMethodNotFound(err) {
+		c.warnUnknownProtocolVersion()
+	} else if err != nil {
+		return fmt.Errorf("failed to get protocol version of the rollup node: %w", err)
+	} else {
+		version = node.ProtocolVersion
+	}
+	nodeConfig, err := c.client.RollupConfig(cCtx)
+	if err != nil {
+		return fmt.Errorf("failed to get rollup config of the rollup node: %w", err)
+	}
+
+	var incompatible error
+	if node != nil {
+		incompatible = checkNodeProtocolVersion(c.local, version)
+	}
+	if incompatible == nil {
+		if err := c.rollupConfig.CheckSameConsensus(nodeConfig); err != nil {
+			incompatible = fmt.Errorf("%w: %v, restart the validator if the rollup config was changed", ErrIncompatibleRollupNode, err)
+		}
+	}
+	c.metr.RecordRollupNodeCompatibility(c.local, version, incompatible == nil)
+
+	c.mu.Lock()
+	prev := c.err
+	c.err = incompatible
+	c.mu.Unlock()
+	if incompatible != nil {
+		c.log.Error("rollup node is incompatible, holding transactions", "err", incompatible)
+	} else if prev != nil {
+		c.log.Info("rollup node is compatible again", "protocolVersion", version)
+	}
+	return nil
+}
+
+// warnUnknownProtocolVersion logs once that the protocol version of the rollup node is unknown.
+func (c *compatibilityMonitor) warnUnknownProtocolVersion() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.warnedUnknown {
+		c.warnedUnknown = true
+		c.log.Warn("rollup node does not provide its protocol version, protocol version is unknown")
+	}
+}
+
+// isMethodNotFound returns whether the error is the JSON-RPC method not found error.
+func isMethodNotFound(err error) bool {
+	var rpcErr rpc.Error
+	return errors.As(err, &rpcErr) && rpcErr.ErrorCode() == -32601
+}
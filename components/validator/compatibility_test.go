@@ -0,0 +1,187 @@
+package validator
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kroma-network/kroma/components/node/eth"
+	"github.com/kroma-network/kroma/components/node/rollup"
+	"github.com/kroma-network/kroma/components/node/testlog"
+	"github.com/kroma-network/kroma/components/validator/metrics"
+	"github.com/kroma-network/kroma/utils/service/txmgr"
+)
+
+type fakeNodeProtocolVersionProvider struct {
+	mu      sync.Mutex
+	version eth.NodeProtocolVersion
+	config  rollup.Config
+	err     error
+	// versionErr, if not nil, is returned by ProtocolVersion only.
+	versionErr error
+}
+
+func (p *fakeNodeProtocolVersionProvider) ProtocolVersion(_ context.Context) (*eth.NodeProtocolVersion, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.err != nil {
+		return nil, p.err
+	}
+	if p.versionErr != nil {
+		return nil, p.versionErr
+	}
+	version := p.version
+	return &version, nil
+}
+
+func (p *fakeNodeProtocolVersionProvider) RollupConfig(_ context.Context) (*rollup.Config, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.err != nil {
+		return nil, p.err
+	}
+	config := p.config
+	return &config, nil
+}
+
+func (p *fakeNodeProtocolVersionProvider) setConfig(config rollup.Config) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.config = config
+}
+
+// methodNotFoundError is the error of a rollup node without the called method.
+type methodNotFoundError struct{}
+
+func (methodNotFoundError) Error() string  { return "the method does not exist/is not available" }
+func (methodNotFoundError) ErrorCode() int { return -32601 }
+
+func (p *fakeNodeProtocolVersionProvider) set(version eth.NodeProtocolVersion, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.version, p.err = version, err
+}
+
+func TestCheckNodeProtocolVersion(t *testing.T) {
+	local := eth.ProtocolVersionV0{Major: 1, Minor: 2, Patch: 3}.Encode()
+	tests := []struct {
+		name       string
+		node       eth.ProtocolVersion
+		compatible bool
+	}{
+		{"matching", local, true},
+		{"ahead patch", eth.ProtocolVersionV0{Major: 1, Minor: 2, Patch: 4}.Encode(), true},
+		{"outdated patch", eth.ProtocolVersionV0{Major: 1, Minor: 2, Patch: 2}.Encode(), true},
+		{"pre-release", eth.ProtocolVersionV0{Major: 1, Minor: 2, Patch: 3, PreRelease: 1}.Encode(), true},
+		{"different build", eth.ProtocolVersionV0{Build: [8]byte{0x01}, Major: 1}.Encode(), true},
+		{"ahead minor", eth.ProtocolVersionV0{Major: 1, Minor: 3}.Encode(), false},
+		{"outdated minor", eth.ProtocolVersionV0{Major: 1, Minor: 1, Patch: 9}.Encode(), false},
+		{"ahead major", eth.ProtocolVersionV0{Major: 2}.Encode(), false},
+		{"outdated major", eth.ProtocolVersionV0{Minor: 2, Patch: 3}.Encode(), false},
+		{"empty", eth.ProtocolVersion{}, false},
+		{"different version type", eth.ProtocolVersion{7: 0x01, 19: 0x01}, false},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			err := checkNodeProtocolVersion(local, test.node)
+			if test.compatible {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, ErrIncompatibleRollupNode)
+			}
+		})
+	}
+}
+
+func TestCompatibilityMonitor(t *testing.T) {
+	l := testlog.Logger(t, log.LvlCrit)
+	rollupConfig := &rollup.Config{BlockTime: 2}
+	compatible := eth.NodeProtocolVersion{ProtocolVersion: rollup.SupportedProtocolVersion}
+
+	newMonitor := func(t *testing.T, provider *fakeNodeProtocolVersionProvider) *compatibilityMonitor {
+		provider.setConfig(*rollupConfig)
+		return newCompatibilityMonitor(l, metrics.NoopMetrics, provider, rollupConfig, time.Hour, time.Second)
+	}
+
+	t.Run("refuses to start", func(t *testing.T) {
+		var wg sync.WaitGroup
+		provider := &fakeNodeProtocolVersionProvider{version: compatible}
+		provider.version.ProtocolVersion = eth.ProtocolVersionV0{Major: 99}.Encode()
+		require.ErrorIs(t, newMonitor(t, provider).Start(context.Background(), &wg), ErrIncompatibleRollupNode)
+
+		provider.set(eth.NodeProtocolVersion{}, errFakeRpc)
+		require.ErrorIs(t, newMonitor(t, provider).Start(context.Background(), &wg), errFakeRpc)
+	})
+
+	t.Run("starts with unknown protocol version", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		var wg sync.WaitGroup
+		defer wg.Wait()
+		defer cancel()
+
+		provider := &fakeNodeProtocolVersionProvider{versionErr: methodNotFoundError{}}
+		monitor := newMonitor(t, provider)
+		require.NoError(t, monitor.Start(ctx, &wg))
+		require.NoError(t, monitor.Err())
+	})
+
+	t.Run("rechecks", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		var wg sync.WaitGroup
+		defer wg.Wait()
+		defer cancel()
+
+		provider := &fakeNodeProtocolVersionProvider{version: compatible}
+		monitor := newMonitor(t, provider)
+		require.NoError(t, monitor.Start(ctx, &wg))
+		require.NoError(t, monitor.Err())
+
+		// the fields out of the consensus may differ
+		other := *rollupConfig
+		other.ProtocolVersionsAddress = common.Address{0x01}
+		provider.setConfig(other)
+		require.NoError(t, monitor.check(ctx))
+		require.NoError(t, monitor.Err())
+
+		// the rollup node restarted with another rollup config
+		other.BlockTime = 3
+		provider.setConfig(other)
+		require.NoError(t, monitor.check(ctx))
+		require.ErrorIs(t, monitor.Err(), ErrIncompatibleRollupNode)
+		require.ErrorContains(t, monitor.Err(), "block_time differs")
+
+		// the last result is kept while the rollup node is unreachable
+		provider.set(eth.NodeProtocolVersion{}, errFakeRpc)
+		require.ErrorIs(t, monitor.check(ctx), errFakeRpc)
+		require.ErrorIs(t, monitor.Err(), ErrIncompatibleRollupNode)
+
+		provider.set(compatible, nil)
+		provider.setConfig(*rollupConfig)
+		require.NoError(t, monitor.check(ctx))
+		require.NoError(t, monitor.Err())
+	})
+}
+
+func TestRoleServiceGate(t *testing.T) {
+	l := testlog.Logger(t, log.LvlCrit)
+	provider := &fakeNodeProtocolVersionProvider{
+		version: eth.NodeProtocolVersion{ProtocolVersion: eth.ProtocolVersionV0{Major: 99}.Encode()},
+	}
+	monitor := newCompatibilityMonitor(l, metrics.NoopMetrics, provider, &rollup.Config{}, time.Hour, time.Second)
+	require.NoError(t, monitor.check(context.Background()))
+
+	// the transaction is held until the context is done
+	s := newRoleService(L1RoleGuardian, l, nil, time.Minute)
+	s.gate = monitor.Err
+	s.gateRetryInterval = time.Millisecond
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	err := s.sendTransaction(ctx, txmgr.TxCandidate{To: &common.Address{0xff}})
+	require.ErrorIs(t, err, ErrIncompatibleRollupNode)
+}
@@ -3,13 +3,21 @@ package validator
 import (
 	"context"
 	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"os"
+	"strings"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/urfave/cli"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
 
+	"github.com/kroma-network/kroma/bindings/bindings"
 	"github.com/kroma-network/kroma/components/node/rollup"
 	"github.com/kroma-network/kroma/components/node/sources"
 	chal "github.com/kroma-network/kroma/components/validator/challenge"
@@ -19,20 +27,26 @@ import (
 	klog "github.com/kroma-network/kroma/utils/service/log"
 	kmetrics "github.com/kroma-network/kroma/utils/service/metrics"
 	kpprof "github.com/kroma-network/kroma/utils/service/pprof"
+	"github.com/kroma-network/kroma/utils/service/proxy"
 	krpc "github.com/kroma-network/kroma/utils/service/rpc"
+	ktracing "github.com/kroma-network/kroma/utils/service/tracing"
 	"github.com/kroma-network/kroma/utils/service/txmgr"
+	"github.com/kroma-network/kroma/utils/signer/client"
 )
 
 // Config contains the well typed fields that are used to initialize the output submitter.
 // It is intended for programmatic use.
 type Config struct {
-	L2OutputOracleAddr           common.Address
-	ColosseumAddr                common.Address
-	SecurityCouncilAddr          common.Address
-	ValidatorPoolAddr            common.Address
-	ChallengerPollInterval       time.Duration
-	NetworkTimeout               time.Duration
-	TxManager                    *txmgr.SimpleTxManager
+	L2OutputOracleAddr     common.Address
+	ColosseumAddr          common.Address
+	SecurityCouncilAddr    common.Address
+	ValidatorPoolAddr      common.Address
+	ChallengerPollInterval time.Duration
+	NetworkTimeout         time.Duration
+	TxManager              *txmgr.SimpleTxManager
+	// RoleTxManagers are the tx managers of the roles sending from an account of their own, by role. The other
+	// roles send with TxManager.
+	RoleTxManagers               map[string]*txmgr.SimpleTxManager
 	L1Client                     *ethclient.Client
 	RollupClient                 *sources.RollupClient
 	RollupConfig                 *rollup.Config
@@ -43,7 +57,79 @@ type Config struct {
 	OutputSubmitterRoundBuffer   uint64
 	ChallengerDisabled           bool
 	GuardianEnabled              bool
+	GuardianBlockWaitTimeout     time.Duration
+	GuardianMaxClockSkew         time.Duration
+	GuardianMaxNodeLag           uint64
+	GuardianStateFile            string
+	GuardianBackfillMaxBlocks    uint64
+	GuardianStorePath            string
+	GuardianDissent              bool
+	GuardianDryRun               bool
+	ShutdownDrainTimeout         time.Duration
 	ProofFetcher                 ProofFetcher
+	WitnessProvider              WitnessProvider
+	// GuardianAlertSinks are delivered the alerts of output mismatches and failing confirmations.
+	GuardianAlertSinks []AlertSink
+	// GuardianRollupClients are the additional rollup nodes the outputs are validated against, and
+	// GuardianRollupQuorum the number of the nodes that must agree on an output, all of them if 0.
+	GuardianRollupClients []GuardianRollupClient
+	GuardianRollupQuorum  int
+	// GuardianOutputFallback provides the outputs validated against while the rollup node is unreachable, optional
+	// (may be nil). It is closed on the stop of the guardian if it is an io.Closer.
+	GuardianOutputFallback OutputRootProvider
+	// GuardianLeaseLock is the leader lease the guardian campaigns for, as GuardianLeaderIdentity, if not nil.
+	GuardianLeaseLock           LeaseLock
+	GuardianLeaderIdentity      string
+	GuardianLeaderLeaseDuration time.Duration
+	// GuardianMaxRetries is the number of failed validation attempts of a request retried, unlimited if 0, before
+	// the request is moved to GuardianDeadLetters.
+	GuardianMaxRetries  int
+	GuardianDeadLetters *GuardianDeadLetters
+	// GuardianMaxConcurrentValidations is the number of the validation requests validated at once, 16 if 0.
+	GuardianMaxConcurrentValidations int
+	// GuardianMaxTxFee is the fee cap (in wei) of a confirmation at the current L1 base fee, no cap if 0.
+	GuardianMaxTxFee uint64
+	// GuardianBatchWindow is how long the requests of a burst are collected before they are processed at once, every
+	// request is processed on its own if 0. GuardianMaxBatchSize is the maximum size of a batch, no maximum if 0.
+	GuardianBatchWindow  time.Duration
+	GuardianMaxBatchSize int
+	// ChallengerCoordination is how an invalid output challenged by another challenger is handled, and
+	// ChallengerTakeoverMargin how long before the deadline a stalling challenge is taken over.
+	ChallengerCoordination   ChallengeCoordination
+	ChallengerTakeoverMargin time.Duration
+	// ChallengerProofMargin is how long before the proving deadline of a challenge the proof requests are given up,
+	// to leave time for the proveFault transaction to land.
+	ChallengerProofMargin time.Duration
+	// ChallengerProverThroughput is the expected throughput of the prover (in gas per second) until it is measured, the
+	// time to generate a proof is not estimated until then if 0.
+	ChallengerProverThroughput uint64
+	// ChallengerInfeasibleDelay is how long the challenge of an invalid output is deferred when its proof is estimated
+	// not to be generated in time, to leave the output to another challenger. The challenge is not deferred if 0.
+	ChallengerInfeasibleDelay time.Duration
+	// ChallengerStorePath is the directory of the store the state of the challenges is recorded in, if not empty.
+	ChallengerStorePath string
+	// ChallengeDeposit configures the top up of the deposit the bonds of the challenges are taken from.
+	ChallengeDeposit ChallengeDepositConfig
+	// DepositTopUp configures the top up of the deposit the bonds of the outputs are taken from.
+	DepositTopUp DepositTopUpConfig
+	// UnbondInterval is how frequently the bonds of the finalized outputs are unbonded, they are not if 0.
+	UnbondInterval time.Duration
+	// FaultInjection is the interval of the L2 blocks whose outputs are submitted with a corrupted output root, for
+	// testing the challengers and the guardians. The outputs are not corrupted if 0.
+	FaultInjection uint64
+	// RecoveryAuditWindow is how far back the decisions of the guardian are audited on start, no audit is run if 0.
+	RecoveryAuditWindow time.Duration
+	// L1Limiter limits the L1 calls of the roles, shared by all roles. If nil, the calls are not limited.
+	L1Limiter *L1Limiter
+	// Sweep configures the sweep of the recovered funds of the challenger.
+	Sweep SweepConfig
+	// Heartbeat configures the heartbeats posted to a coordination endpoint.
+	Heartbeat HeartbeatConfig
+	// RollupCompatCheckInterval is how frequently the compatibility of the rollup node with the validator is checked,
+	// it is not checked if 0.
+	RollupCompatCheckInterval time.Duration
+	// TracerProvider provides the tracer of the validation requests of the guardian. If nil, they are not traced.
+	TracerProvider trace.TracerProvider
 }
 
 // Check ensures that the [Config] is valid.
@@ -64,6 +150,13 @@ type CLIConfig struct {
 	// RollupRpc is the HTTP provider URL for the rollup node.
 	RollupRpc string
 
+	// Network is the predefined network the contract addresses and protocol parameters are preset by.
+	// It is checked against the chain of the rollup node.
+	Network string
+
+	// Profile is the configuration profile the fees, retries and challenge strategy are set by.
+	Profile string
+
 	// L2OOAddress is the L2OutputOracle contract address.
 	L2OOAddress string
 
@@ -82,6 +175,10 @@ type CLIConfig struct {
 	// ProverGrpc is the URL of prover grpc server.
 	ProverGrpc string
 
+	// ProverGrpcSecondary is the URL of a second, independent prover grpc server.
+	// If set, a fault proof is only submitted if the proofs of both provers verify.
+	ProverGrpcSecondary string
+
 	// AllowNonFinalized can be set to true to submit outputs
 	// for L2 blocks derived from non-finalized L1 data.
 	AllowNonFinalized bool
@@ -101,16 +198,250 @@ type CLIConfig struct {
 
 	GuardianEnabled bool
 
+	// OutputSubmitterPrivateKey, ChallengerPrivateKey and GuardianPrivateKey are the private keys of the accounts of
+	// the roles sending from an account of their own. The roles without one send from the account of the tx manager.
+	OutputSubmitterPrivateKey string
+	ChallengerPrivateKey      string
+	GuardianPrivateKey        string
+
+	// GuardianBlockWaitTimeout is how long to wait for the requested L2 block to be derived before giving up the validation.
+	GuardianBlockWaitTimeout time.Duration
+
+	// GuardianMaxClockSkew is the maximum skew of the local clock against the timestamp of the latest L1 block,
+	// beyond which the guardian warns. 0 disables the clock skew check.
+	GuardianMaxClockSkew time.Duration
+
+	// GuardianMaxNodeLag is the maximum number of L1 blocks the derivation of the rollup node may lag behind the L1
+	// head to confirm the validation requests. 0 disables the node lag check.
+	GuardianMaxNodeLag uint64
+
+	// GuardianStateFile is the file the last processed L1 block is persisted to, to backfill the validation
+	// requests emitted while the guardian was offline. If empty, the backfill is disabled.
+	GuardianStateFile string
+
+	// GuardianBackfillMaxBlocks is the maximum number of L1 blocks before the head to backfill.
+	GuardianBackfillMaxBlocks uint64
+
+	// GuardianStorePath is the directory of the store the decisions of the validation requests are recorded in,
+	// to audit them and to not evaluate a request twice across restarts. If empty, the decisions are not recorded.
+	GuardianStorePath string
+
+	// GuardianDissent is whether the validation requests of invalid outputs are contested, by revoking an earlier
+	// confirmation of the guardian.
+	GuardianDissent bool
+
+	// GuardianDryRun is whether the guardian only logs the confirmations and revocations it would submit, e.g. to
+	// shadow-run a new member of the SecurityCouncil before it takes the signing responsibility.
+	GuardianDryRun bool
+
+	// GuardianAlertWebhook is the URL of a webhook the alerts of the guardian are posted to, if not empty.
+	GuardianAlertWebhook string
+
+	// GuardianAlertPagerDutyKey is the routing key of a PagerDuty integration the alerts trigger incidents of, if not empty.
+	GuardianAlertPagerDutyKey string
+
+	// GuardianAlertSlackWebhook is the URL of a Slack incoming webhook the alerts are posted to, if not empty.
+	GuardianAlertSlackWebhook string
+
+	// GuardianRollupRpcs are the HTTP provider URLs of additional rollup nodes the outputs are validated against.
+	GuardianRollupRpcs []string
+
+	// GuardianRollupQuorum is the number of the rollup nodes that must agree on an output, all of them if 0.
+	GuardianRollupQuorum int
+
+	// GuardianL2EthRpc is the HTTP provider URL of the L2 execution client the outputs are recomputed from while the
+	// rollup node is unreachable. Disabled if empty.
+	GuardianL2EthRpc string
+
+	// GuardianLeaderLease is the name of the Kubernetes Lease the guardian campaigns for, if not empty.
+	GuardianLeaderLease string
+
+	// GuardianLeaderLeaseNamespace is the namespace of the Lease, the namespace of the service account if empty.
+	GuardianLeaderLeaseNamespace string
+
+	// GuardianLeaderIdentity is the identity of the guardian in the Lease, the hostname if empty.
+	GuardianLeaderIdentity string
+
+	// GuardianLeaderLeaseDuration is how long the Lease is held without being renewed.
+	GuardianLeaderLeaseDuration time.Duration
+
+	// GuardianMaxRetries is the number of failed validation attempts of a request retried before it is given up,
+	// unlimited if 0.
+	GuardianMaxRetries int
+
+	// GuardianMaxConcurrentValidations is the maximum number of the validation requests validated at once, 16 if 0.
+	// The other requests are queued, ordered by their L2 block number.
+	GuardianMaxConcurrentValidations int
+
+	// GuardianMaxTxFee is the maximum fee (in wei) of a confirmation, its estimated gas times the L1 base fee plus
+	// the tip. The confirmations exceeding it are skipped and alerted until the fees drop. No cap if 0.
+	GuardianMaxTxFee uint64
+
+	// GuardianBatchWindow is how long the validation requests of a burst are collected, from the first one, to be
+	// validated once per requested output and have their confirmations queued together. The SecurityCouncil only
+	// takes the confirmation of the sender, so every confirmation is still a transaction of its own.
+	// Every request is validated on its own if 0.
+	GuardianBatchWindow time.Duration
+
+	// GuardianMaxBatchSize is the maximum number of the requests of a batch. No maximum if 0.
+	GuardianMaxBatchSize int
+
+	// ChallengerCoordination is how an invalid output already challenged by another challenger is handled:
+	// hold-back, parallel or takeover.
+	ChallengerCoordination string
+
+	// ChallengerTakeoverMargin is how long before the deadline of a stalling challenge it is prepared to be taken over.
+	ChallengerTakeoverMargin time.Duration
+
+	// ChallengerDepositTarget is the deposit (in wei, decimal) in the ValidatorPool topped up to when it does not
+	// cover the bond of a challenge. The deposit is not topped up if empty or 0.
+	ChallengerDepositTarget string
+
+	// ChallengerDepositMaxTopUp is the maximum amount (in wei, decimal) deposited at once. No maximum if empty or 0.
+	ChallengerDepositMaxTopUp string
+
+	// DepositTopUpThreshold is the deposit (in wei, decimal) in the ValidatorPool below which it is topped up before
+	// submitting outputs. The deposit is not topped up if empty or 0.
+	DepositTopUpThreshold string
+
+	// DepositTopUpTarget is the deposit (in wei, decimal) topped up to, at least the threshold.
+	DepositTopUpTarget string
+
+	// DepositTopUpCeiling is the maximum total amount (in wei, decimal) deposited by the top ups since the validator
+	// started.
+	DepositTopUpCeiling string
+
+	// DepositTopUpInterval is how frequently the deposit is checked.
+	DepositTopUpInterval time.Duration
+
+	// AutoUnbond is whether the bonds of the finalized outputs are unbonded automatically.
+	AutoUnbond bool
+
+	// UnbondInterval is how frequently the bonds of the finalized outputs are checked for unbonding.
+	UnbondInterval time.Duration
+
+	// FaultInjection is the interval of the L2 blocks whose outputs are submitted with a corrupted output root, a
+	// developer mode for testing the challengers and the guardians in devnets. The outputs are not corrupted if 0.
+	FaultInjection uint64
+
 	FetchingProofTimeout time.Duration
 
+	// ProofCacheDir is the directory the fetched proofs are kept in, to generate a proof once across restarts.
+	// The proofs are not cached if empty.
+	ProofCacheDir string
+
+	// ChallengerProofMargin is how long before the proving deadline of a challenge the failed proof requests stop
+	// being retried, to leave time for the proveFault transaction to land.
+	ChallengerProofMargin time.Duration
+
+	// ChallengerProverThroughput is the expected throughput of the prover (in gas per second), to estimate whether a
+	// proof can be generated before the deadline until the throughput is measured on the generated proofs.
+	ChallengerProverThroughput uint64
+
+	// ChallengerInfeasibleDelay is how long the challenge of an invalid output is deferred when the proof of a full
+	// block is estimated not to be generated within the proving timeout, so that another challenger with a faster
+	// prover challenges it first. If 0, the challenge is created right away.
+	ChallengerInfeasibleDelay time.Duration
+
+	// ChallengerStorePath is the directory of the store the state of the challenges is recorded in, to resume them
+	// where they were left off after a restart. If empty, the challenges are not recorded.
+	ChallengerStorePath string
+
+	// ShutdownDrainTimeout is how long to wait for the challenge turns in progress and the queued transactions to be
+	// sent on shutdown.
+	ShutdownDrainTimeout time.Duration
+
+	// RecoveryAuditWindow is how far back the decisions of the guardian are reconciled with the chain on start.
+	// The recovery audit is disabled if 0.
+	RecoveryAuditWindow time.Duration
+
+	// WitnessRpc is the URL of the witness service that serves outputs with the public input proof.
+	// If empty, the witness is fetched from the rollup node.
+	WitnessRpc string
+
+	// WitnessDir is the directory of precomputed witness artifacts.
+	WitnessDir string
+
+	// L1MaxConcurrentCalls is the maximum number of concurrent L1 calls of all roles. 0 means unlimited.
+	L1MaxConcurrentCalls int
+
+	// L1RateLimit is the maximum number of L1 calls per second of all roles. 0 means unlimited.
+	L1RateLimit float64
+
+	// L1RateLimitBurst is the maximum number of L1 calls allowed at once by the rate limit.
+	L1RateLimitBurst int
+
+	// SweepAddress is the cold address to sweep the recovered bonds and rewards to. If empty, the funds are not swept.
+	SweepAddress string
+
+	// SweepThreshold is the minimum amount (in wei, decimal) to sweep.
+	SweepThreshold string
+
+	// SweepPoolReserve is the deposit (in wei, decimal) kept in the ValidatorPool to bond outputs.
+	SweepPoolReserve string
+
+	// SweepAccountReserve is the balance (in wei, decimal) kept in the validator account to pay for gas.
+	SweepAccountReserve string
+
+	// SweepInterval is how frequently the balances are checked for funds to sweep.
+	SweepInterval time.Duration
+
+	// SweepJournal is the file to append an entry to for every sweep transaction.
+	SweepJournal string
+
+	// HeartbeatEndpoint is the HTTP URL to post the heartbeats to. If empty, no heartbeat is posted.
+	HeartbeatEndpoint string
+
+	// HeartbeatInterval is how frequently a heartbeat is posted.
+	HeartbeatInterval time.Duration
+
+	// HeartbeatSecretPath is the file of the hex encoded secret to sign the heartbeats with.
+	HeartbeatSecretPath string
+
+	// RollupCompatCheckInterval is how frequently the compatibility of the rollup node with the validator is checked,
+	// it is not checked if 0.
+	RollupCompatCheckInterval time.Duration
+
+	// EnableAdmin is whether the admin API is served.
+	EnableAdmin bool
+
+	// HealthEnabled is whether the health server is served, reporting the readiness of the roles.
+	HealthEnabled bool
+
+	// HealthAddr is the listening address of the health server.
+	HealthAddr string
+
+	// HealthPort is the listening port of the health server.
+	HealthPort int
+
 	TxMgrConfig   txmgr.CLIConfig
+	ProxyConfig   proxy.CLIConfig
 	RPCConfig     krpc.CLIConfig
 	LogConfig     klog.CLIConfig
 	MetricsConfig kmetrics.CLIConfig
 	PprofConfig   kpprof.CLIConfig
+	TracingConfig ktracing.CLIConfig
+}
+
+// missingAddress returns the error of a required contract address that is not set, naming the flag to set it with
+// when the selected network does not preset it.
+func (c CLIConfig) missingAddress(contract string, flag string) error {
+	if c.Network != "" {
+		return fmt.Errorf("%s address is required: network %s does not preset it, set --%s", contract, c.Network, flag)
+	}
+	return fmt.Errorf("%s address is required unless preset by the network, set --%s or --%s", contract, flag, flags.NetworkFlag.Name)
 }
 
 func (c CLIConfig) Check() error {
+	if c.L2OOAddress == "" {
+		return c.missingAddress("l2 output oracle", flags.L2OOAddressFlag.Name)
+	}
+	if c.ColosseumAddress == "" {
+		return c.missingAddress("colosseum", flags.ColosseumAddressFlag.Name)
+	}
+	if c.ValPoolAddress == "" {
+		return c.missingAddress("validator pool", flags.ValPoolAddressFlag.Name)
+	}
 	if err := c.RPCConfig.Check(); err != nil {
 		return err
 	}
@@ -123,9 +454,186 @@ func (c CLIConfig) Check() error {
 	if err := c.PprofConfig.Check(); err != nil {
 		return err
 	}
+	if err := c.TracingConfig.Check(); err != nil {
+		return err
+	}
 	if err := c.TxMgrConfig.Check(); err != nil {
 		return err
 	}
+	if err := c.ProxyConfig.Check(); err != nil {
+		return err
+	}
+	if c.OutputSubmitterPrivateKey != "" && c.OutputSubmitterDisabled {
+		return errors.New("output submitter private key requires the output submitter")
+	}
+	if c.ChallengerPrivateKey != "" && c.ChallengerDisabled {
+		return errors.New("challenger private key requires the challenger")
+	}
+	if c.GuardianPrivateKey != "" && !c.GuardianEnabled {
+		return errors.New("guardian private key requires the guardian")
+	}
+	// the challenger defends the outputs of the output submitter, so they must send from the same account
+	if (c.OutputSubmitterPrivateKey != "" || c.ChallengerPrivateKey != "") && !c.OutputSubmitterDisabled && !c.ChallengerDisabled {
+		return errors.New("output submitter and challenger must share an account, run them as separate validators to use an account of their own")
+	}
+	if c.WitnessRpc != "" && c.WitnessDir != "" {
+		return errors.New("only one of witness rpc and witness dir can be configured")
+	}
+	if c.GuardianMaxClockSkew < 0 {
+		return errors.New("guardian max clock skew must not be negative")
+	}
+	if c.GuardianStateFile != "" && c.GuardianBackfillMaxBlocks == 0 {
+		return errors.New("guardian backfill max blocks must be positive with a guardian state file")
+	}
+	if c.GuardianRollupQuorum < 0 {
+		return errors.New("guardian rollup quorum must not be negative")
+	}
+	if c.GuardianRollupQuorum > len(c.GuardianRollupRpcs)+1 {
+		return fmt.Errorf("guardian rollup quorum %d exceeds the number of rollup nodes %d", c.GuardianRollupQuorum, len(c.GuardianRollupRpcs)+1)
+	}
+	if c.GuardianLeaderLease != "" && c.GuardianLeaderLeaseDuration < time.Second {
+		return errors.New("guardian leader lease duration must be at least 1s")
+	}
+	if c.GuardianMaxRetries < 0 {
+		return errors.New("guardian max retries must not be negative")
+	}
+	if c.GuardianMaxConcurrentValidations < 0 {
+		return errors.New("guardian max concurrent validations must not be negative")
+	}
+	if c.GuardianBatchWindow < 0 {
+		return errors.New("guardian batch window must not be negative")
+	}
+	if c.GuardianMaxBatchSize < 0 {
+		return errors.New("guardian max batch size must not be negative")
+	}
+	if _, err := ParseChallengeCoordination(c.ChallengerCoordination); err != nil {
+		return err
+	}
+	if c.ChallengerTakeoverMargin < 0 {
+		return errors.New("challenger takeover margin must not be negative")
+	}
+	challengerDepositTarget, err := parseWei("challenger deposit target", c.ChallengerDepositTarget)
+	if err != nil {
+		return err
+	}
+	challengerDepositMaxTopUp, err := parseWei("challenger deposit max top up", c.ChallengerDepositMaxTopUp)
+	if err != nil {
+		return err
+	}
+	if challengerDepositMaxTopUp.Sign() > 0 && challengerDepositTarget.Sign() == 0 {
+		return errors.New("challenger deposit max top up requires a deposit target")
+	}
+	depositThreshold, err := parseWei("deposit top up threshold", c.DepositTopUpThreshold)
+	if err != nil {
+		return err
+	}
+	depositTarget, err := parseWei("deposit top up target", c.DepositTopUpTarget)
+	if err != nil {
+		return err
+	}
+	depositCeiling, err := parseWei("deposit top up ceiling", c.DepositTopUpCeiling)
+	if err != nil {
+		return err
+	}
+	if depositThreshold.Sign() > 0 {
+		if c.OutputSubmitterDisabled {
+			return errors.New("deposit top up requires the output submitter")
+		}
+		if depositTarget.Cmp(depositThreshold) < 0 {
+			return errors.New("deposit top up target must cover the threshold")
+		}
+		if depositCeiling.Sign() == 0 {
+			return errors.New("deposit top up ceiling must be positive")
+		}
+		if c.DepositTopUpInterval <= 0 {
+			return errors.New("deposit top up interval must be positive")
+		}
+	}
+	if c.FaultInjection > 0 {
+		if c.OutputSubmitterDisabled {
+			return errors.New("fault injection requires the output submitter")
+		}
+		if c.Network != "" {
+			return fmt.Errorf("fault injection is not allowed on network %s", c.Network)
+		}
+	}
+	if c.AutoUnbond {
+		if c.OutputSubmitterDisabled {
+			return errors.New("auto unbond requires the output submitter")
+		}
+		if c.UnbondInterval <= 0 {
+			return errors.New("unbond interval must be positive")
+		}
+	}
+	if c.ChallengerProofMargin < 0 {
+		return errors.New("challenger proof margin must not be negative")
+	}
+	if c.ChallengerInfeasibleDelay < 0 {
+		return errors.New("challenger infeasible delay must not be negative")
+	}
+	if c.RecoveryAuditWindow < 0 {
+		return errors.New("recovery audit window must not be negative")
+	}
+	if c.L1MaxConcurrentCalls < 0 {
+		return errors.New("l1 max concurrent calls must not be negative")
+	}
+	if c.L1RateLimit < 0 {
+		return errors.New("l1 rate limit must not be negative")
+	}
+	if c.SweepAddress != "" {
+		threshold, err := parseWei("sweep threshold", c.SweepThreshold)
+		if err != nil {
+			return err
+		}
+		if threshold.Sign() == 0 {
+			return errors.New("sweep threshold must be positive")
+		}
+		poolReserve, err := parseWei("sweep pool reserve", c.SweepPoolReserve)
+		if err != nil {
+			return err
+		}
+		// a zero reserve would sweep the deposit bonding the outputs and the challenges
+		if poolReserve.Sign() == 0 {
+			return errors.New("sweep pool reserve must be positive")
+		}
+		accountReserve, err := parseWei("sweep account reserve", c.SweepAccountReserve)
+		if err != nil {
+			return err
+		}
+		// a zero reserve would sweep the balance paying for the gas of the validator
+		if accountReserve.Sign() == 0 {
+			return errors.New("sweep account reserve must be positive")
+		}
+		if c.SweepInterval <= 0 {
+			return errors.New("sweep interval must be positive")
+		}
+		if !c.OutputSubmitterDisabled && poolReserve.Cmp(new(big.Int).SetUint64(c.OutputSubmitterBondAmount)) < 0 {
+			return errors.New("sweep pool reserve must cover the output submitter bond amount")
+		}
+		if poolReserve.Cmp(challengerDepositTarget) < 0 {
+			return errors.New("sweep pool reserve must cover the challenger deposit target")
+		}
+		if poolReserve.Cmp(depositTarget) < 0 {
+			return errors.New("sweep pool reserve must cover the deposit top up target")
+		}
+	}
+	if c.HeartbeatEndpoint != "" {
+		if c.HeartbeatInterval <= 0 {
+			return errors.New("heartbeat interval must be positive")
+		}
+		if c.HeartbeatSecretPath == "" {
+			return errors.New("heartbeat secret path is required to sign the heartbeats")
+		}
+	}
+	if c.RollupCompatCheckInterval < 0 {
+		return errors.New("rollup compatibility check interval must not be negative")
+	}
+	if c.HealthEnabled && (c.HealthPort < 0 || c.HealthPort > math.MaxUint16) {
+		return errors.New("invalid health port")
+	}
+	if policy, _ := c.TxMgrConfig.ApprovalPolicy(); policy.Enabled() && !c.EnableAdmin {
+		return errors.New("the approval of the transactions requires the admin API to be enabled")
+	}
 	return nil
 }
 
@@ -143,19 +651,86 @@ func NewCLIConfig(ctx *cli.Context) CLIConfig {
 		TxMgrConfig:            txmgr.ReadCLIConfig(ctx),
 
 		// Optional Flags
-		AllowNonFinalized:            ctx.GlobalBool(flags.AllowNonFinalizedFlag.Name),
-		OutputSubmitterDisabled:      ctx.GlobalBool(flags.OutputSubmitterDisabledFlag.Name),
-		OutputSubmitterBondAmount:    ctx.GlobalUint64(flags.OutputSubmitterBondAmountFlag.Name),
-		OutputSubmitterRetryInterval: ctx.GlobalDuration(flags.OutputSubmitterRetryIntervalFlag.Name),
-		OutputSubmitterRoundBuffer:   ctx.GlobalUint64(flags.OutputSubmitterRoundBufferFlag.Name),
-		ChallengerDisabled:           ctx.GlobalBool(flags.ChallengerDisabledFlag.Name),
-		SecurityCouncilAddress:       ctx.GlobalString(flags.SecurityCouncilAddressFlag.Name),
-		GuardianEnabled:              ctx.GlobalBool(flags.GuardianEnabledFlag.Name),
-		FetchingProofTimeout:         ctx.GlobalDuration(flags.FetchingProofTimeoutFlag.Name),
-		RPCConfig:                    krpc.ReadCLIConfig(ctx),
-		LogConfig:                    klog.ReadCLIConfig(ctx),
-		MetricsConfig:                kmetrics.ReadCLIConfig(ctx),
-		PprofConfig:                  kpprof.ReadCLIConfig(ctx),
+		Network:                          ctx.GlobalString(flags.NetworkFlag.Name),
+		Profile:                          ctx.GlobalString(flags.ProfileFlag.Name),
+		AllowNonFinalized:                ctx.GlobalBool(flags.AllowNonFinalizedFlag.Name),
+		OutputSubmitterDisabled:          ctx.GlobalBool(flags.OutputSubmitterDisabledFlag.Name),
+		OutputSubmitterBondAmount:        ctx.GlobalUint64(flags.OutputSubmitterBondAmountFlag.Name),
+		OutputSubmitterRetryInterval:     ctx.GlobalDuration(flags.OutputSubmitterRetryIntervalFlag.Name),
+		OutputSubmitterRoundBuffer:       ctx.GlobalUint64(flags.OutputSubmitterRoundBufferFlag.Name),
+		ChallengerDisabled:               ctx.GlobalBool(flags.ChallengerDisabledFlag.Name),
+		SecurityCouncilAddress:           ctx.GlobalString(flags.SecurityCouncilAddressFlag.Name),
+		GuardianEnabled:                  ctx.GlobalBool(flags.GuardianEnabledFlag.Name),
+		OutputSubmitterPrivateKey:        ctx.GlobalString(flags.OutputSubmitterPrivateKeyFlag.Name),
+		ChallengerPrivateKey:             ctx.GlobalString(flags.ChallengerPrivateKeyFlag.Name),
+		GuardianPrivateKey:               ctx.GlobalString(flags.GuardianPrivateKeyFlag.Name),
+		GuardianBlockWaitTimeout:         ctx.GlobalDuration(flags.GuardianBlockWaitTimeoutFlag.Name),
+		GuardianMaxClockSkew:             ctx.GlobalDuration(flags.GuardianMaxClockSkewFlag.Name),
+		GuardianMaxNodeLag:               ctx.GlobalUint64(flags.GuardianMaxNodeLagFlag.Name),
+		GuardianStateFile:                ctx.GlobalString(flags.GuardianStateFileFlag.Name),
+		GuardianBackfillMaxBlocks:        ctx.GlobalUint64(flags.GuardianBackfillMaxBlocksFlag.Name),
+		GuardianStorePath:                ctx.GlobalString(flags.GuardianStorePathFlag.Name),
+		GuardianDissent:                  ctx.GlobalBool(flags.GuardianDissentFlag.Name),
+		GuardianAlertWebhook:             ctx.GlobalString(flags.GuardianAlertWebhookFlag.Name),
+		GuardianAlertPagerDutyKey:        ctx.GlobalString(flags.GuardianAlertPagerDutyKeyFlag.Name),
+		GuardianAlertSlackWebhook:        ctx.GlobalString(flags.GuardianAlertSlackWebhookFlag.Name),
+		GuardianRollupRpcs:               ctx.GlobalStringSlice(flags.GuardianRollupRpcsFlag.Name),
+		GuardianRollupQuorum:             ctx.GlobalInt(flags.GuardianRollupQuorumFlag.Name),
+		GuardianL2EthRpc:                 ctx.GlobalString(flags.GuardianL2EthRpcFlag.Name),
+		GuardianLeaderLease:              ctx.GlobalString(flags.GuardianLeaderLeaseFlag.Name),
+		GuardianLeaderLeaseNamespace:     ctx.GlobalString(flags.GuardianLeaderLeaseNamespaceFlag.Name),
+		GuardianLeaderIdentity:           ctx.GlobalString(flags.GuardianLeaderIdentityFlag.Name),
+		GuardianLeaderLeaseDuration:      ctx.GlobalDuration(flags.GuardianLeaderLeaseDurationFlag.Name),
+		GuardianMaxRetries:               ctx.GlobalInt(flags.GuardianMaxRetriesFlag.Name),
+		GuardianMaxConcurrentValidations: ctx.GlobalInt(flags.GuardianMaxConcurrentValidationsFlag.Name),
+		GuardianMaxTxFee:                 ctx.GlobalUint64(flags.GuardianMaxTxFeeFlag.Name),
+		GuardianBatchWindow:              ctx.GlobalDuration(flags.GuardianBatchWindowFlag.Name),
+		GuardianMaxBatchSize:             ctx.GlobalInt(flags.GuardianMaxBatchSizeFlag.Name),
+		ChallengerCoordination:           ctx.GlobalString(flags.ChallengerCoordinationFlag.Name),
+		ChallengerTakeoverMargin:         ctx.GlobalDuration(flags.ChallengerTakeoverMarginFlag.Name),
+		ChallengerDepositTarget:          ctx.GlobalString(flags.ChallengerDepositTargetFlag.Name),
+		ChallengerDepositMaxTopUp:        ctx.GlobalString(flags.ChallengerDepositMaxTopUpFlag.Name),
+		DepositTopUpThreshold:            ctx.GlobalString(flags.DepositTopUpThresholdFlag.Name),
+		DepositTopUpTarget:               ctx.GlobalString(flags.DepositTopUpTargetFlag.Name),
+		DepositTopUpCeiling:              ctx.GlobalString(flags.DepositTopUpCeilingFlag.Name),
+		DepositTopUpInterval:             ctx.GlobalDuration(flags.DepositTopUpIntervalFlag.Name),
+		AutoUnbond:                       ctx.GlobalBool(flags.AutoUnbondFlag.Name),
+		UnbondInterval:                   ctx.GlobalDuration(flags.UnbondIntervalFlag.Name),
+		FaultInjection:                   ctx.GlobalUint64(flags.FaultInjectionFlag.Name),
+		FetchingProofTimeout:             ctx.GlobalDuration(flags.FetchingProofTimeoutFlag.Name),
+		ProofCacheDir:                    ctx.GlobalString(flags.ProofCacheDirFlag.Name),
+		ChallengerProofMargin:            ctx.GlobalDuration(flags.ChallengerProofMarginFlag.Name),
+		ChallengerProverThroughput:       ctx.GlobalUint64(flags.ChallengerProverThroughputFlag.Name),
+		ChallengerInfeasibleDelay:        ctx.GlobalDuration(flags.ChallengerInfeasibleDelayFlag.Name),
+		ChallengerStorePath:              ctx.GlobalString(flags.ChallengerStorePathFlag.Name),
+		ShutdownDrainTimeout:             ctx.GlobalDuration(flags.ShutdownDrainTimeoutFlag.Name),
+		RecoveryAuditWindow:              ctx.GlobalDuration(flags.RecoveryAuditWindowFlag.Name),
+		WitnessRpc:                       ctx.GlobalString(flags.WitnessRpcFlag.Name),
+		WitnessDir:                       ctx.GlobalString(flags.WitnessDirFlag.Name),
+		ProverGrpcSecondary:              ctx.GlobalString(flags.ProverGrpcSecondaryFlag.Name),
+		L1MaxConcurrentCalls:             ctx.GlobalInt(flags.L1MaxConcurrentCallsFlag.Name),
+		L1RateLimit:                      ctx.GlobalFloat64(flags.L1RateLimitFlag.Name),
+		L1RateLimitBurst:                 ctx.GlobalInt(flags.L1RateLimitBurstFlag.Name),
+		SweepAddress:                     ctx.GlobalString(flags.SweepAddressFlag.Name),
+		SweepThreshold:                   ctx.GlobalString(flags.SweepThresholdFlag.Name),
+		SweepPoolReserve:                 ctx.GlobalString(flags.SweepPoolReserveFlag.Name),
+		SweepAccountReserve:              ctx.GlobalString(flags.SweepAccountReserveFlag.Name),
+		SweepInterval:                    ctx.GlobalDuration(flags.SweepIntervalFlag.Name),
+		SweepJournal:                     ctx.GlobalString(flags.SweepJournalFlag.Name),
+		HeartbeatEndpoint:                ctx.GlobalString(flags.HeartbeatEndpointFlag.Name),
+		HeartbeatInterval:                ctx.GlobalDuration(flags.HeartbeatIntervalFlag.Name),
+		HeartbeatSecretPath:              ctx.GlobalString(flags.HeartbeatSecretPathFlag.Name),
+		RollupCompatCheckInterval:        ctx.GlobalDuration(flags.RollupCompatCheckIntervalFlag.Name),
+		EnableAdmin:                      ctx.GlobalBool(flags.RPCEnableAdminFlag.Name),
+		HealthEnabled:                    ctx.GlobalBool(flags.HealthEnabledFlag.Name),
+		HealthAddr:                       ctx.GlobalString(flags.HealthAddrFlag.Name),
+		HealthPort:                       ctx.GlobalInt(flags.HealthPortFlag.Name),
+		ProxyConfig:                      proxy.ReadCLIConfig(ctx),
+		RPCConfig:                        krpc.ReadCLIConfig(ctx),
+		LogConfig:                        klog.ReadCLIConfig(ctx),
+		MetricsConfig:                    kmetrics.ReadCLIConfig(ctx),
+		PprofConfig:                      kpprof.ReadCLIConfig(ctx),
+		TracingConfig:                    ktracing.ReadCLIConfig(ctx),
 	}
 }
 
@@ -181,22 +756,112 @@ func NewValidatorConfig(cfg CLIConfig, l log.Logger, m metrics.Metricer) (*Confi
 		return nil, err
 	}
 
-	txManager, err := txmgr.NewSimpleTxManager("validator", l, m, cfg.TxMgrConfig)
+	var challengeDeposit ChallengeDepositConfig
+	if challengeDeposit.Target, err = parseWei("challenger deposit target", cfg.ChallengerDepositTarget); err != nil {
+		return nil, err
+	}
+	if challengeDeposit.MaxTopUp, err = parseWei("challenger deposit max top up", cfg.ChallengerDepositMaxTopUp); err != nil {
+		return nil, err
+	}
+
+	depositTopUp := DepositTopUpConfig{
+		Interval: cfg.DepositTopUpInterval,
+	}
+	if depositTopUp.Threshold, err = parseWei("deposit top up threshold", cfg.DepositTopUpThreshold); err != nil {
+		return nil, err
+	}
+	if depositTopUp.Target, err = parseWei("deposit top up target", cfg.DepositTopUpTarget); err != nil {
+		return nil, err
+	}
+	if depositTopUp.Ceiling, err = parseWei("deposit top up ceiling", cfg.DepositTopUpCeiling); err != nil {
+		return nil, err
+	}
+
+	var unbondInterval time.Duration
+	if cfg.AutoUnbond {
+		unbondInterval = cfg.UnbondInterval
+	}
+
+	sweepCfg := SweepConfig{
+		Interval: cfg.SweepInterval,
+	}
+	if len(cfg.SweepAddress) > 0 {
+		sweepCfg.Address, err = utils.ParseAddress(cfg.SweepAddress)
+		if err != nil {
+			return nil, err
+		}
+		if sweepCfg.Threshold, err = parseWei("sweep threshold", cfg.SweepThreshold); err != nil {
+			return nil, err
+		}
+		if sweepCfg.PoolReserve, err = parseWei("sweep pool reserve", cfg.SweepPoolReserve); err != nil {
+			return nil, err
+		}
+		if sweepCfg.AccountReserve, err = parseWei("sweep account reserve", cfg.SweepAccountReserve); err != nil {
+			return nil, err
+		}
+		if len(cfg.SweepJournal) > 0 {
+			sweepCfg.Journal, err = NewFileSweepJournal(cfg.SweepJournal)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// All outbound connections are routed through the proxy, and the proxy of the environment if not configured.
+	proxyCfg, err := proxy.NewConfig(cfg.ProxyConfig)
 	if err != nil {
 		return nil, err
 	}
+	if len(cfg.ProxyConfig.URL) > 0 || len(cfg.ProxyConfig.Endpoints) > 0 {
+		l.Info("Routing outbound connections through the proxy", "endpoint_overrides", len(cfg.ProxyConfig.Endpoints))
+	}
 
-	if cfg.OutputSubmitterDisabled && cfg.ChallengerDisabled {
-		return nil, errors.New("output submitter and challenger are disabled. either output submitter or challenger must be enabled")
+	heartbeatCfg := HeartbeatConfig{
+		Endpoint: cfg.HeartbeatEndpoint,
+		Interval: cfg.HeartbeatInterval,
+		Proxy:    proxyCfg,
+	}
+	if heartbeatCfg.Enabled() {
+		heartbeatCfg.Secret, err = readHeartbeatSecret(cfg.HeartbeatSecretPath)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var alertSinks []AlertSink
+	if cfg.GuardianAlertWebhook != "" {
+		alertSinks = append(alertSinks, NewWebhookAlertSink(cfg.GuardianAlertWebhook, proxyCfg.HTTPClient(cfg.TxMgrConfig.NetworkTimeout)))
+	}
+	if cfg.GuardianAlertPagerDutyKey != "" {
+		alertSinks = append(alertSinks, NewPagerDutyAlertSink(cfg.GuardianAlertPagerDutyKey, proxyCfg.HTTPClient(cfg.TxMgrConfig.NetworkTimeout)))
+	}
+	if cfg.GuardianAlertSlackWebhook != "" {
+		alertSinks = append(alertSinks, NewSlackAlertSink(cfg.GuardianAlertSlackWebhook, proxyCfg.HTTPClient(cfg.TxMgrConfig.NetworkTimeout)))
+	}
+
+	l1Limiter := NewL1Limiter(cfg.L1MaxConcurrentCalls, cfg.L1RateLimit, cfg.L1RateLimitBurst, m)
+
+	if cfg.OutputSubmitterDisabled && cfg.ChallengerDisabled && !cfg.GuardianEnabled {
+		return nil, errors.New("output submitter, challenger and guardian are disabled. at least one of them must be enabled")
+	}
+
+	cfg.TxMgrConfig.Proxy = proxyCfg
+	txManager, roleTxManagers, err := newTxManagers(cfg, l, m, l1Limiter)
+	if err != nil {
+		return nil, err
 	}
 
 	if !cfg.ChallengerDisabled && len(cfg.ProverGrpc) == 0 {
 		return nil, errors.New("ProverGrpc is required but given empty")
 	}
 
+	if len(cfg.ProverGrpcSecondary) > 0 && len(cfg.ProverGrpc) == 0 {
+		return nil, errors.New("ProverGrpcSecondary is set but ProverGrpc is given empty")
+	}
+
 	var fetcher ProofFetcher
 	if len(cfg.ProverGrpc) > 0 {
-		fetcher, err = chal.NewFetcher(cfg.ProverGrpc, cfg.FetchingProofTimeout, l)
+		fetcher, err = chal.NewFetcher(cfg.ProverGrpc, cfg.FetchingProofTimeout, l, grpc.WithContextDialer(proxyCfg.DialContext))
 		if err != nil {
 			return nil, err
 		}
@@ -204,12 +869,12 @@ func NewValidatorConfig(cfg CLIConfig, l log.Logger, m metrics.Metricer) (*Confi
 
 	// Connect to L1 and L2 providers. Perform these last since they are the most expensive.
 	ctx := context.Background()
-	l1Client, err := utils.DialEthClientWithTimeout(ctx, cfg.L1EthRpc)
+	l1Client, err := utils.DialEthClientWithTimeout(ctx, cfg.L1EthRpc, proxyCfg.RPCOptions()...)
 	if err != nil {
 		return nil, err
 	}
 
-	rollupClient, err := utils.DialRollupClientWithTimeout(ctx, cfg.RollupRpc)
+	rollupClient, err := utils.DialRollupClientWithTimeout(ctx, cfg.RollupRpc, proxyCfg.RPCOptions()...)
 	if err != nil {
 		return nil, err
 	}
@@ -219,24 +884,242 @@ func NewValidatorConfig(cfg CLIConfig, l log.Logger, m metrics.Metricer) (*Confi
 		return nil, err
 	}
 
+	var leaseLock LeaseLock
+	leaderIdentity := cfg.GuardianLeaderIdentity
+	if cfg.GuardianLeaderLease != "" {
+		leaseLock, err = NewKubernetesLeaseLock(cfg.GuardianLeaderLeaseNamespace, cfg.GuardianLeaderLease)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create guardian leader lease: %w", err)
+		}
+		if leaderIdentity == "" {
+			if leaderIdentity, err = os.Hostname(); err != nil {
+				return nil, fmt.Errorf("failed to get hostname as guardian leader identity: %w", err)
+			}
+		}
+	}
+
+	var guardianRollupClients []GuardianRollupClient
+	for _, rpc := range cfg.GuardianRollupRpcs {
+		client, err := utils.DialRollupClientWithTimeout(ctx, rpc, proxyCfg.RPCOptions()...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to dial guardian rollup node %s: %w", rpc, err)
+		}
+		guardianRollupClients = append(guardianRollupClients, client)
+	}
+	var guardianOutputFallback OutputRootProvider
+	if cfg.GuardianL2EthRpc != "" {
+		client, err := DialL2ExecutionClientWithTimeout(ctx, cfg.GuardianL2EthRpc, proxyCfg.RPCOptions()...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to dial guardian L2 execution client: %w", err)
+		}
+		guardianOutputFallback = newExecutionOutputs(client, rollupConfig, cfg.AllowNonFinalized)
+	}
+	if len(cfg.Network) > 0 {
+		if err := checkNetwork(cfg.Network, rollupConfig); err != nil {
+			return nil, err
+		}
+	}
+	if cfg.FaultInjection > 0 {
+		if err := checkFaultInjection(rollupConfig); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(cfg.ProverGrpcSecondary) > 0 {
+		secondary, err := chal.NewFetcher(cfg.ProverGrpcSecondary, cfg.FetchingProofTimeout, l, grpc.WithContextDialer(proxyCfg.DialContext))
+		if err != nil {
+			return nil, err
+		}
+		challengerL1 := l1Limiter.Client(L1RoleChallenger, l1Client)
+		colosseum, err := bindings.NewColosseumCaller(colosseumAddress, challengerL1)
+		if err != nil {
+			return nil, err
+		}
+		zkVerifierAddress, err := colosseum.ZKVERIFIER(utils.NewSimpleCallOpts(ctx))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get zk verifier address: %w", err)
+		}
+		verifier, err := bindings.NewZKVerifierCaller(zkVerifierAddress, challengerL1)
+		if err != nil {
+			return nil, err
+		}
+		fetcher = newProofQuorum(l, fetcher, secondary, verifier, cfg.TxMgrConfig.NetworkTimeout)
+	}
+
+	// only the verified proofs of a quorum are cached
+	if fetcher != nil && len(cfg.ProofCacheDir) > 0 {
+		fetcher, err = newProofCache(l, fetcher, cfg.ProofCacheDir)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	coordination, err := ParseChallengeCoordination(cfg.ChallengerCoordination)
+	if err != nil {
+		return nil, err
+	}
+
+	var witnessProvider WitnessProvider
+	if len(cfg.WitnessRpc) > 0 {
+		witnessProvider, err = utils.DialRollupClientWithTimeout(ctx, cfg.WitnessRpc, proxyCfg.RPCOptions()...)
+		if err != nil {
+			return nil, err
+		}
+	} else if len(cfg.WitnessDir) > 0 {
+		witnessProvider, err = chal.NewFileWitnessProvider(cfg.WitnessDir)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return &Config{
-		L2OutputOracleAddr:           l2ooAddress,
-		ColosseumAddr:                colosseumAddress,
-		SecurityCouncilAddr:          securityCouncilAddress,
-		ValidatorPoolAddr:            valPoolAddress,
-		ChallengerPollInterval:       cfg.ChallengerPollInterval,
-		NetworkTimeout:               cfg.TxMgrConfig.NetworkTimeout,
-		TxManager:                    txManager,
-		L1Client:                     l1Client,
-		RollupClient:                 rollupClient,
-		RollupConfig:                 rollupConfig,
-		AllowNonFinalized:            cfg.AllowNonFinalized,
-		OutputSubmitterDisabled:      cfg.OutputSubmitterDisabled,
-		OutputSubmitterBondAmount:    cfg.OutputSubmitterBondAmount,
-		OutputSubmitterRetryInterval: cfg.OutputSubmitterRetryInterval,
-		OutputSubmitterRoundBuffer:   cfg.OutputSubmitterRoundBuffer,
-		ChallengerDisabled:           cfg.ChallengerDisabled,
-		GuardianEnabled:              cfg.GuardianEnabled,
-		ProofFetcher:                 fetcher,
+		L2OutputOracleAddr:               l2ooAddress,
+		ColosseumAddr:                    colosseumAddress,
+		SecurityCouncilAddr:              securityCouncilAddress,
+		ValidatorPoolAddr:                valPoolAddress,
+		ChallengerPollInterval:           cfg.ChallengerPollInterval,
+		NetworkTimeout:                   cfg.TxMgrConfig.NetworkTimeout,
+		TxManager:                        txManager,
+		RoleTxManagers:                   roleTxManagers,
+		L1Client:                         l1Client,
+		RollupClient:                     rollupClient,
+		RollupConfig:                     rollupConfig,
+		AllowNonFinalized:                cfg.AllowNonFinalized,
+		OutputSubmitterDisabled:          cfg.OutputSubmitterDisabled,
+		OutputSubmitterBondAmount:        cfg.OutputSubmitterBondAmount,
+		OutputSubmitterRetryInterval:     cfg.OutputSubmitterRetryInterval,
+		OutputSubmitterRoundBuffer:       cfg.OutputSubmitterRoundBuffer,
+		ChallengerDisabled:               cfg.ChallengerDisabled,
+		GuardianEnabled:                  cfg.GuardianEnabled,
+		GuardianBlockWaitTimeout:         cfg.GuardianBlockWaitTimeout,
+		GuardianMaxClockSkew:             cfg.GuardianMaxClockSkew,
+		GuardianMaxNodeLag:               cfg.GuardianMaxNodeLag,
+		GuardianStateFile:                cfg.GuardianStateFile,
+		GuardianBackfillMaxBlocks:        cfg.GuardianBackfillMaxBlocks,
+		GuardianStorePath:                cfg.GuardianStorePath,
+		GuardianDissent:                  cfg.GuardianDissent,
+		GuardianAlertSinks:               alertSinks,
+		GuardianRollupClients:            guardianRollupClients,
+		GuardianRollupQuorum:             cfg.GuardianRollupQuorum,
+		GuardianOutputFallback:           guardianOutputFallback,
+		GuardianLeaseLock:                leaseLock,
+		GuardianLeaderIdentity:           leaderIdentity,
+		GuardianLeaderLeaseDuration:      cfg.GuardianLeaderLeaseDuration,
+		GuardianMaxRetries:               cfg.GuardianMaxRetries,
+		GuardianDeadLetters:              NewGuardianDeadLetters(m),
+		GuardianMaxConcurrentValidations: cfg.GuardianMaxConcurrentValidations,
+		GuardianMaxTxFee:                 cfg.GuardianMaxTxFee,
+		GuardianBatchWindow:              cfg.GuardianBatchWindow,
+		GuardianMaxBatchSize:             cfg.GuardianMaxBatchSize,
+		ChallengerCoordination:           coordination,
+		ChallengerTakeoverMargin:         cfg.ChallengerTakeoverMargin,
+		ChallengerProofMargin:            cfg.ChallengerProofMargin,
+		ChallengerProverThroughput:       cfg.ChallengerProverThroughput,
+		ChallengerInfeasibleDelay:        cfg.ChallengerInfeasibleDelay,
+		ChallengerStorePath:              cfg.ChallengerStorePath,
+		ChallengeDeposit:                 challengeDeposit,
+		DepositTopUp:                     depositTopUp,
+		UnbondInterval:                   unbondInterval,
+		FaultInjection:                   cfg.FaultInjection,
+		ShutdownDrainTimeout:             cfg.ShutdownDrainTimeout,
+		RecoveryAuditWindow:              cfg.RecoveryAuditWindow,
+		ProofFetcher:                     fetcher,
+		WitnessProvider:                  witnessProvider,
+		L1Limiter:                        l1Limiter,
+		Sweep:                            sweepCfg,
+		Heartbeat:                        heartbeatCfg,
+		RollupCompatCheckInterval:        cfg.RollupCompatCheckInterval,
 	}, nil
 }
+
+// newTxManagers creates the tx manager of the validator, and the tx managers of the roles with a private key of their
+// own. The tx manager of the validator is the one of the first role if no account is configured for the validator,
+// which requires every enabled role to have a private key of its own. All tx managers share the approval queue.
+func newTxManagers(cfg CLIConfig, l log.Logger, m metrics.Metricer, l1Limiter *L1Limiter) (*txmgr.SimpleTxManager, map[string]*txmgr.SimpleTxManager, error) {
+	var approvals *txmgr.ApprovalQueue
+	newTxManager := func(name string, txMgrCfg txmgr.CLIConfig) (*txmgr.SimpleTxManager, error) {
+		txMgrConfig, err := txmgr.NewConfig(txMgrCfg, l)
+		if err != nil {
+			return nil, err
+		}
+		if l1, ok := txMgrConfig.Backend.(*ethclient.Client); ok {
+			txMgrConfig.Backend = l1Limiter.Client(L1RoleTxMgr, l1)
+		}
+		if approvals == nil {
+			approvals = txMgrConfig.Approvals
+		} else {
+			txMgrConfig.Approvals = approvals
+		}
+		return txmgr.NewSimpleTxManagerFromConfig(name, l, m, txMgrConfig), nil
+	}
+
+	roleTxManagers := make(map[string]*txmgr.SimpleTxManager)
+	// first is the tx manager of the first enabled role with a private key, and shared whether the account of the
+	// validator is used by an enabled role.
+	var first *txmgr.SimpleTxManager
+	shared := false
+	for _, role := range []struct {
+		name       string
+		enabled    bool
+		privateKey string
+	}{
+		{L1RoleSubmitter, !cfg.OutputSubmitterDisabled, cfg.OutputSubmitterPrivateKey},
+		{L1RoleChallenger, !cfg.ChallengerDisabled, cfg.ChallengerPrivateKey},
+		{L1RoleGuardian, cfg.GuardianEnabled, cfg.GuardianPrivateKey},
+	} {
+		if !role.enabled {
+			continue
+		}
+		if role.privateKey == "" {
+			shared = true
+			continue
+		}
+		roleCfg := cfg.TxMgrConfig
+		roleCfg.PrivateKey, roleCfg.Mnemonic, roleCfg.HDPath = role.privateKey, "", ""
+		roleCfg.SignerCLIConfig = client.CLIConfig{}
+		txManager, err := newTxManager("validator-"+role.name, roleCfg)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create tx manager of %s: %w", role.name, err)
+		}
+		roleTxManagers[role.name] = txManager
+		if first == nil {
+			first = txManager
+		}
+	}
+
+	if cfg.TxMgrConfig.PrivateKey == "" && cfg.TxMgrConfig.Mnemonic == "" && !cfg.TxMgrConfig.SignerCLIConfig.Enabled() {
+		if shared || first == nil {
+			return nil, nil, errors.New("no account is configured, a private key, mnemonic or signer is required unless every enabled role has a private key of its own")
+		}
+		return first, roleTxManagers, nil
+	}
+	txManager, err := newTxManager("validator", cfg.TxMgrConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+	return txManager, roleTxManagers, nil
+}
+
+func readHeartbeatSecret(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read heartbeat secret: %w", err)
+	}
+	secret := common.FromHex(strings.TrimSpace(string(data)))
+	if len(secret) < 32 {
+		return nil, fmt.Errorf("invalid heartbeat secret in path %s, less than 32 hex-formatted bytes", path)
+	}
+	return secret, nil
+}
+
+// parseWei parses the decimal wei amount of the named setting, 0 if it is empty.
+func parseWei(name string, s string) (*big.Int, error) {
+	if s == "" {
+		return new(big.Int), nil
+	}
+	wei, ok := new(big.Int).SetString(s, 10)
+	if !ok || wei.Sign() < 0 {
+		return nil, fmt.Errorf("%s is not a valid wei amount: %q", name, s)
+	}
+	return wei, nil
+}
@@ -0,0 +1,342 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/kroma-network/kroma/bindings/bindings"
+	"github.com/kroma-network/kroma/components/validator/metrics"
+)
+
+// CouncilRequest is a validation request to the SecurityCouncil.
+type CouncilRequest struct {
+	TransactionId *big.Int
+	L2BlockNumber uint64
+	// Time is the timestamp of the L1 block the request was made in.
+	Time uint64
+}
+
+// CouncilConfirmation is the confirmation of a validation request by a SecurityCouncil member.
+type CouncilConfirmation struct {
+	TransactionId *big.Int
+	Member        common.Address
+	// Time is the timestamp of the L1 block the confirmation was made in.
+	Time uint64
+}
+
+// CouncilMemberLatency is how long a member took to confirm a validation request.
+type CouncilMemberLatency struct {
+	Member  common.Address
+	Latency time.Duration
+}
+
+// CouncilTxReport is the confirmation history of a single validation request.
+type CouncilTxReport struct {
+	Request CouncilRequest
+	// Confirmations are the member confirmations in the order they were made.
+	Confirmations []CouncilMemberLatency
+	QuorumReached bool
+	// QuorumLatency is the time from the request until the quorum was reached.
+	QuorumLatency time.Duration
+}
+
+// CouncilMemberReport aggregates the responsiveness of a SecurityCouncil member.
+type CouncilMemberReport struct {
+	Member        common.Address
+	Confirmations int
+	// Missed is the number of requests that the member did not confirm.
+	Missed     int
+	AvgLatency time.Duration
+	MaxLatency time.Duration
+}
+
+// CouncilReport aggregates the responsiveness of the SecurityCouncil over a set of validation requests.
+type CouncilReport struct {
+	Required         uint64
+	Transactions     []CouncilTxReport
+	Members          []CouncilMemberReport
+	QuorumReached    int
+	AvgQuorumLatency time.Duration
+	MaxQuorumLatency time.Duration
+}
+
+func councilLatency(requestTime, confirmationTime uint64) time.Duration {
+	if confirmationTime < requestTime {
+		return 0
+	}
+	return time.Duration(confirmationTime-requestTime) * time.Second
+}
+
+// BuildCouncilReport builds the report of the given validation requests.
+// The confirmations must be in the order they were made, confirmations of unknown requests are ignored.
+// Every owner is reported, even if it did not confirm any request.
+func BuildCouncilReport(requests []CouncilRequest, confirmations []CouncilConfirmation, owners []common.Address, required uint64) CouncilReport {
+	report := CouncilReport{
+		Required:     required,
+		Transactions: make([]CouncilTxReport, len(requests)),
+	}
+	txIndex := make(map[string]int, len(requests))
+	for i, req := range requests {
+		report.Transactions[i].Request = req
+		txIndex[req.TransactionId.String()] = i
+	}
+
+	type memberStats struct {
+		confirmed map[string]struct{}
+		total     time.Duration
+		max       time.Duration
+	}
+	stats := make(map[common.Address]*memberStats)
+	members := make([]common.Address, 0, len(owners))
+	addMember := func(member common.Address) *memberStats {
+		s, ok := stats[member]
+		if !ok {
+			s = &memberStats{confirmed: make(map[string]struct{})}
+			stats[member] = s
+			members = append(members, member)
+		}
+		return s
+	}
+	for _, owner := range owners {
+		addMember(owner)
+	}
+
+	for _, conf := range confirmations {
+		id := conf.TransactionId.String()
+		i, ok := txIndex[id]
+		if !ok {
+			continue
+		}
+		s := addMember(conf.Member)
+		// a member may confirm again after revoking, only the first confirmation counts.
+		if _, ok := s.confirmed[id]; ok {
+			continue
+		}
+		s.confirmed[id] = struct{}{}
+
+		tx := &report.Transactions[i]
+		latency := councilLatency(tx.Request.Time, conf.Time)
+		tx.Confirmations = append(tx.Confirmations, CouncilMemberLatency{Member: conf.Member, Latency: latency})
+		s.total += latency
+		if latency > s.max {
+			s.max = latency
+		}
+		if !tx.QuorumReached && uint64(len(tx.Confirmations)) >= required {
+			tx.QuorumReached = true
+			tx.QuorumLatency = latency
+		}
+	}
+
+	var totalQuorumLatency time.Duration
+	for _, tx := range report.Transactions {
+		if !tx.QuorumReached {
+			continue
+		}
+		report.QuorumReached++
+		totalQuorumLatency += tx.QuorumLatency
+		if tx.QuorumLatency > report.MaxQuorumLatency {
+			report.MaxQuorumLatency = tx.QuorumLatency
+		}
+	}
+	if report.QuorumReached > 0 {
+		report.AvgQuorumLatency = totalQuorumLatency / time.Duration(report.QuorumReached)
+	}
+
+	for _, member := range members {
+		s := stats[member]
+		m := CouncilMemberReport{
+			Member:        member,
+			Confirmations: len(s.confirmed),
+			Missed:        len(requests) - len(s.confirmed),
+			MaxLatency:    s.max,
+		}
+		if m.Confirmations > 0 {
+			m.AvgLatency = s.total / time.Duration(m.Confirmations)
+		}
+		report.Members = append(report.Members, m)
+	}
+	return report
+}
+
+// councilRequestCacheSize bounds the cached request times of requests that never reach the quorum.
+const councilRequestCacheSize = 1024
+
+// L1HeaderSource provides the L1 block headers to resolve the timestamps of SecurityCouncil events.
+type L1HeaderSource interface {
+	HeaderByHash(ctx context.Context, hash common.Hash) (*types.Header, error)
+}
+
+// councilHealthTracker records the confirmation latency of the SecurityCouncil members and the time
+// until the quorum is reached, as metrics. Latencies are measured between the L1 block timestamps of
+// the validation request and the confirmations, and the confirmation count is read from the contract,
+// so that the metrics are consistent across restarts.
+type councilHealthTracker struct {
+	log            log.Logger
+	metr           metrics.Metricer
+	contract       *bindings.SecurityCouncil
+	l1             L1HeaderSource
+	networkTimeout time.Duration
+	// traces links the latencies to the traces of the requests, optional (may be nil)
+	traces *validationTraces
+
+	mu           sync.Mutex
+	requestTimes map[string]uint64 // request block timestamps by transaction id
+
+	sub              ethereum.Subscription
+	confirmationChan chan *bindings.SecurityCouncilConfirmation
+}
+
+func newCouncilHealthTracker(l log.Logger, m metrics.Metricer, contract *bindings.SecurityCouncil, l1 L1HeaderSource, networkTimeout time.Duration, traces *validationTraces) *councilHealthTracker {
+	return &councilHealthTracker{
+		log:              l,
+		metr:             m,
+		contract:         contract,
+		l1:               l1,
+		networkTimeout:   networkTimeout,
+		traces:           traces,
+		requestTimes:     make(map[string]uint64),
+		confirmationChan: make(chan *bindings.SecurityCouncilConfirmation),
+	}
+}
+
+func (t *councilHealthTracker) Start(ctx context.Context, wg *sync.WaitGroup) {
+	watchOpts := &bind.WatchOpts{Context: ctx, Start: nil}
+	t.sub = event.ResubscribeErr(time.Second*10, func(ctx context.Context, err error) (event.Subscription, error) {
+		if err != nil {
+			t.log.Warn("resubscribing after failed SecurityCouncilConfirmation event", "err", err)
+		}
+		return t.contract.WatchConfirmation(watchOpts, t.confirmationChan, nil, nil)
+	})
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case ev := <-t.confirmationChan:
+				if ev.Raw.Removed {
+					continue
+				}
+				if err := t.handleConfirmation(ctx, ev); err != nil {
+					t.log.Warn("failed to record SecurityCouncil confirmation", "err", err, "transactionId", ev.TransactionId, "member", ev.Sender)
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+func (t *councilHealthTracker) Stop() {
+	if t.sub != nil {
+		t.sub.Unsubscribe()
+	}
+}
+
+// onValidationRequested caches the request time, to avoid looking up the request on every confirmation.
+func (t *councilHealthTracker) onValidationRequested(ctx context.Context, ev *bindings.SecurityCouncilValidationRequested) {
+	reqTime, err := t.blockTime(ctx, ev.Raw.BlockHash)
+	if err != nil {
+		t.log.Warn("failed to get validation request time", "err", err, "transactionId", ev.TransactionId)
+		return
+	}
+	t.cacheRequestTime(ev.TransactionId, reqTime)
+}
+
+func (t *councilHealthTracker) cacheRequestTime(transactionId *big.Int, reqTime uint64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if len(t.requestTimes) >= councilRequestCacheSize {
+		// evicted requests are looked up on L1 again.
+		t.requestTimes = make(map[string]uint64)
+	}
+	t.requestTimes[transactionId.String()] = reqTime
+}
+
+func (t *councilHealthTracker) handleConfirmation(ctx context.Context, ev *bindings.SecurityCouncilConfirmation) error {
+	reqTime, err := t.requestTime(ctx, ev.TransactionId)
+	if err != nil {
+		return err
+	}
+	confTime, err := t.blockTime(ctx, ev.Raw.BlockHash)
+	if err != nil {
+		return err
+	}
+	latency := councilLatency(reqTime, confTime)
+	exemplar := t.traces.exemplar(ev.TransactionId, ev.Raw.TxHash)
+	t.metr.RecordCouncilConfirmation(ev.Sender, latency, exemplar)
+
+	cCtx, cCancel := context.WithTimeout(ctx, t.networkTimeout)
+	defer cCancel()
+	callOpts := &bind.CallOpts{Context: cCtx, BlockNumber: new(big.Int).SetUint64(ev.Raw.BlockNumber)}
+	count, err := t.contract.GetConfirmationCount(callOpts, ev.TransactionId)
+	if err != nil {
+		return fmt.Errorf("failed to get confirmation count: %w", err)
+	}
+	required, err := t.contract.NumConfirmationsRequired(callOpts)
+	if err != nil {
+		return fmt.Errorf("failed to get number of required confirmations: %w", err)
+	}
+	t.log.Info("SecurityCouncil member confirmed validation request", "transactionId", ev.TransactionId,
+		"member", ev.Sender, "latency", latency, "confirmations", count, "required", required)
+
+	// only the confirmation that reaches the quorum is recorded, later confirmations exceed it.
+	if count.Cmp(required) == 0 {
+		t.metr.RecordCouncilQuorum(latency, exemplar)
+		t.mu.Lock()
+		delete(t.requestTimes, ev.TransactionId.String())
+		t.mu.Unlock()
+	}
+	return nil
+}
+
+// requestTime returns the timestamp of the L1 block the validation request was made in.
+// If the request is not cached, e.g. after a restart, it is looked up on L1.
+func (t *councilHealthTracker) requestTime(ctx context.Context, transactionId *big.Int) (uint64, error) {
+	t.mu.Lock()
+	reqTime, ok := t.requestTimes[transactionId.String()]
+	t.mu.Unlock()
+	if ok {
+		return reqTime, nil
+	}
+
+	cCtx, cCancel := context.WithTimeout(ctx, t.networkTimeout)
+	defer cCancel()
+	iter, err := t.contract.FilterValidationRequested(&bind.FilterOpts{Context: cCtx}, []*big.Int{transactionId})
+	if err != nil {
+		return 0, fmt.Errorf("failed to filter validation requests: %w", err)
+	}
+	defer iter.Close()
+	if !iter.Next() {
+		if err := iter.Error(); err != nil {
+			return 0, fmt.Errorf("failed to filter validation requests: %w", err)
+		}
+		return 0, fmt.Errorf("validation request not found")
+	}
+	reqTime, err = t.blockTime(ctx, iter.Event.Raw.BlockHash)
+	if err != nil {
+		return 0, err
+	}
+	t.cacheRequestTime(transactionId, reqTime)
+	return reqTime, nil
+}
+
+func (t *councilHealthTracker) blockTime(ctx context.Context, blockHash common.Hash) (uint64, error) {
+	cCtx, cCancel := context.WithTimeout(ctx, t.networkTimeout)
+	defer cCancel()
+	header, err := t.l1.HeaderByHash(cCtx, blockHash)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get L1 block header %s: %w", blockHash, err)
+	}
+	return header.Time, nil
+}
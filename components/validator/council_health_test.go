@@ -0,0 +1,68 @@
+package validator
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildCouncilReport(t *testing.T) {
+	alice, bob, carol := common.Address{0xa}, common.Address{0xb}, common.Address{0xc}
+	outsider := common.Address{0xd}
+
+	requests := []CouncilRequest{
+		{TransactionId: big.NewInt(0), L2BlockNumber: 100, Time: 1000},
+		{TransactionId: big.NewInt(1), L2BlockNumber: 200, Time: 2000},
+		{TransactionId: big.NewInt(2), L2BlockNumber: 300, Time: 3000},
+	}
+	confirmations := []CouncilConfirmation{
+		{TransactionId: big.NewInt(0), Member: alice, Time: 1060},
+		{TransactionId: big.NewInt(0), Member: bob, Time: 1300},
+		// confirmation after reaching the quorum
+		{TransactionId: big.NewInt(0), Member: carol, Time: 1900},
+		{TransactionId: big.NewInt(1), Member: bob, Time: 2120},
+		// repeated confirmation after a revocation
+		{TransactionId: big.NewInt(1), Member: bob, Time: 2500},
+		{TransactionId: big.NewInt(1), Member: outsider, Time: 2600},
+		// confirmation of a request out of the report
+		{TransactionId: big.NewInt(9), Member: alice, Time: 2700},
+		{TransactionId: big.NewInt(2), Member: alice, Time: 3030},
+	}
+
+	report := BuildCouncilReport(requests, confirmations, []common.Address{alice, bob, carol}, 2)
+
+	require.Equal(t, uint64(2), report.Required)
+	require.Len(t, report.Transactions, 3)
+
+	tx0 := report.Transactions[0]
+	require.True(t, tx0.QuorumReached)
+	require.Equal(t, 300*time.Second, tx0.QuorumLatency)
+	require.Equal(t, []CouncilMemberLatency{
+		{Member: alice, Latency: 60 * time.Second},
+		{Member: bob, Latency: 300 * time.Second},
+		{Member: carol, Latency: 900 * time.Second},
+	}, tx0.Confirmations)
+
+	tx1 := report.Transactions[1]
+	require.True(t, tx1.QuorumReached)
+	require.Equal(t, 600*time.Second, tx1.QuorumLatency)
+	require.Len(t, tx1.Confirmations, 2)
+
+	tx2 := report.Transactions[2]
+	require.False(t, tx2.QuorumReached)
+	require.Len(t, tx2.Confirmations, 1)
+
+	require.Equal(t, 2, report.QuorumReached)
+	require.Equal(t, 450*time.Second, report.AvgQuorumLatency)
+	require.Equal(t, 600*time.Second, report.MaxQuorumLatency)
+
+	require.Equal(t, []CouncilMemberReport{
+		{Member: alice, Confirmations: 2, Missed: 1, AvgLatency: 45 * time.Second, MaxLatency: 60 * time.Second},
+		{Member: bob, Confirmations: 2, Missed: 1, AvgLatency: 210 * time.Second, MaxLatency: 300 * time.Second},
+		{Member: carol, Confirmations: 1, Missed: 2, AvgLatency: 900 * time.Second, MaxLatency: 900 * time.Second},
+		{Member: outsider, Confirmations: 1, Missed: 2, AvgLatency: 600 * time.Second, MaxLatency: 600 * time.Second},
+	}, report.Members)
+}
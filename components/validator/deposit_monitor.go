@@ -0,0 +1,137 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/kroma-network/kroma/bindings/bindings"
+	"github.com/kroma-network/kroma/utils"
+	"github.com/kroma-network/kroma/utils/service/txmgr"
+)
+
+// depositPendingTicks is the number of checks a top up is assumed to be pending while the deposit did not change.
+// The deposit is topped up again afterwards, in case the deposit transaction failed.
+const depositPendingTicks = 3
+
+type DepositTopUpMetrics interface {
+	RecordDepositTopUp(amount *big.Int)
+}
+
+// DepositTopUpConfig configures the top up of the deposit of the validator in the ValidatorPool, which the bonds of
+// the submitted outputs are taken from.
+type DepositTopUpConfig struct {
+	// Threshold is the deposit (in wei) below which the deposit is topped up. The deposit is not topped up if 0.
+	Threshold *big.Int
+	// Target is the deposit (in wei) topped up to, at least the threshold.
+	Target *big.Int
+	// Ceiling is the maximum total amount (in wei) deposited by the top ups since the validator started, so that a
+	// deposit drained again and again, e.g. by lost challenges, does not drain the account of the validator too.
+	Ceiling *big.Int
+	// Interval is how frequently the deposit is checked.
+	Interval time.Duration
+}
+
+func (c DepositTopUpConfig) Enabled() bool {
+	return c.Threshold != nil && c.Threshold.Sign() > 0
+}
+
+// depositMonitor watches the deposit of the validator in the ValidatorPool, and queues a deposit to top it up to the
+// target when it falls below the threshold, until the ceiling is reached.
+type depositMonitor struct {
+	log  log.Logger
+	metr DepositTopUpMetrics
+	cfg  DepositTopUpConfig
+
+	from           common.Address
+	valPoolAddr    common.Address
+	valPool        ValidatorPoolBalance
+	valPoolABI     *abi.ABI
+	networkTimeout time.Duration
+
+	// deposited is the total amount queued to be deposited since the validator started.
+	deposited *big.Int
+	// pending is the deposit a top up was queued at, so that it is not topped up again until the top up was sent, and
+	// pendingTicks the number of checks since.
+	pending      *big.Int
+	pendingTicks int
+}
+
+func newDepositMonitor(l log.Logger, m DepositTopUpMetrics, cfg DepositTopUpConfig, from common.Address,
+	valPoolAddr common.Address, valPool ValidatorPoolBalance, networkTimeout time.Duration,
+) (*depositMonitor, error) {
+	valPoolABI, err := bindings.ValidatorPoolMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return &depositMonitor{
+		log:            l,
+		metr:           m,
+		cfg:            cfg,
+		from:           from,
+		valPoolAddr:    valPoolAddr,
+		valPool:        valPool,
+		valPoolABI:     valPoolABI,
+		networkTimeout: networkTimeout,
+		deposited:      new(big.Int),
+	}, nil
+}
+
+// check queues a deposit to top up the deposit of the validator to the target, if it is below the threshold. The top
+// up is capped so that the total deposited does not exceed the ceiling.
+func (d *depositMonitor) check(ctx context.Context, txCandidatesChan chan<- txmgr.TxCandidate) error {
+	cCtx, cCancel := context.WithTimeout(ctx, d.networkTimeout)
+	deposit, err := d.valPool.BalanceOf(utils.NewCallOptsWithSender(cCtx, d.from), d.from)
+	cCancel()
+	if err != nil {
+		return fmt.Errorf("failed to fetch validator deposit: %w", err)
+	}
+
+	if d.pending != nil {
+		if d.pending.Cmp(deposit) == 0 && d.pendingTicks < depositPendingTicks {
+			d.pendingTicks++
+			d.log.Debug("deposit top up is pending", "deposit", deposit)
+			return nil
+		}
+		d.pending = nil
+	}
+
+	if deposit.Cmp(d.cfg.Threshold) >= 0 {
+		return nil
+	}
+	target := d.cfg.Target
+	if target.Cmp(d.cfg.Threshold) < 0 {
+		target = d.cfg.Threshold
+	}
+	amount := new(big.Int).Sub(target, deposit)
+	left := new(big.Int).Sub(d.cfg.Ceiling, d.deposited)
+	if amount.Cmp(left) > 0 {
+		amount = left
+	}
+	if amount.Sign() <= 0 {
+		d.log.Error("validator deposit is below the threshold, but the top up ceiling is reached", "deposit", deposit,
+			"threshold", d.cfg.Threshold, "deposited", d.deposited, "ceiling", d.cfg.Ceiling)
+		return nil
+	}
+
+	data, err := d.valPoolABI.Pack("deposit")
+	if err != nil {
+		return fmt.Errorf("failed to create deposit transaction data: %w", err)
+	}
+	select {
+	case txCandidatesChan <- txmgr.TxCandidate{TxData: data, To: &d.valPoolAddr, Value: amount}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	d.deposited.Add(d.deposited, amount)
+	d.pending, d.pendingTicks = deposit, 0
+	d.metr.RecordDepositTopUp(amount)
+	d.log.Info("queued top up of validator deposit", "amount", amount, "deposit", deposit, "deposited", d.deposited,
+		"ceiling", d.cfg.Ceiling)
+	return nil
+}
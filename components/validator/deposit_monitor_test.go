@@ -0,0 +1,117 @@
+package validator
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kroma-network/kroma/components/node/testlog"
+	"github.com/kroma-network/kroma/components/validator/metrics"
+	"github.com/kroma-network/kroma/utils/service/txmgr"
+)
+
+func newTestDepositMonitor(t *testing.T, balances *fakeBalances) *depositMonitor {
+	cfg := DepositTopUpConfig{
+		Threshold: big.NewInt(100),
+		Target:    big.NewInt(150),
+		Ceiling:   big.NewInt(200),
+		Interval:  time.Minute,
+	}
+	d, err := newDepositMonitor(testlog.Logger(t, log.LvlCrit), metrics.NoopMetrics, cfg, common.Address{0xaa},
+		common.Address{0xbb}, balances, time.Second)
+	require.NoError(t, err)
+	return d
+}
+
+func TestDepositMonitor(t *testing.T) {
+	balances := &fakeBalances{pool: big.NewInt(100)}
+	d := newTestDepositMonitor(t, balances)
+	txs := make(chan txmgr.TxCandidate, 10)
+
+	// the deposit is not below the threshold.
+	require.NoError(t, d.check(context.Background(), txs))
+	require.Empty(t, txs)
+
+	balances.pool = big.NewInt(30)
+	require.NoError(t, d.check(context.Background(), txs))
+	require.Len(t, txs, 1)
+	deposit := <-txs
+	require.Equal(t, common.Address{0xbb}, *deposit.To)
+	require.Equal(t, big.NewInt(120), deposit.Value)
+	depositData, err := d.valPoolABI.Pack("deposit")
+	require.NoError(t, err)
+	require.Equal(t, depositData, deposit.TxData)
+
+	// the top up is pending while the deposit did not change.
+	require.NoError(t, d.check(context.Background(), txs))
+	require.Empty(t, txs)
+
+	// the top up is capped by the ceiling, and stops once the ceiling is reached.
+	balances.pool = big.NewInt(0)
+	require.NoError(t, d.check(context.Background(), txs))
+	require.Len(t, txs, 1)
+	require.Equal(t, big.NewInt(80), (<-txs).Value)
+	balances.pool = big.NewInt(10)
+	require.NoError(t, d.check(context.Background(), txs))
+	require.Empty(t, txs)
+	require.Equal(t, big.NewInt(200), d.deposited)
+}
+
+func TestDepositMonitorRetriesPendingTopUp(t *testing.T) {
+	balances := &fakeBalances{pool: big.NewInt(90)}
+	d := newTestDepositMonitor(t, balances)
+	txs := make(chan txmgr.TxCandidate, 10)
+
+	for i := 0; i <= depositPendingTicks; i++ {
+		require.NoError(t, d.check(context.Background(), txs))
+	}
+	require.Len(t, txs, 1)
+	// the top up did not change the deposit in time, so it is assumed to have failed.
+	require.NoError(t, d.check(context.Background(), txs))
+	require.Len(t, txs, 2)
+	require.Equal(t, big.NewInt(120), d.deposited, "expected the failed top up to count towards the ceiling")
+}
+
+func TestCLIConfigCheckDepositTopUp(t *testing.T) {
+	valid, err := runWithProfile(t, "--l2oo-address", "0x01", "--colosseum-address", "0x02", "--valpool-address", "0x03",
+		"--output-submitter.deposit-threshold", "10000000000000000000",
+		"--output-submitter.deposit-target", "20000000000000000000",
+		"--output-submitter.deposit-ceiling", "100000000000000000000")
+	require.NoError(t, err)
+	require.NoError(t, valid.Check())
+	for _, test := range []struct {
+		name string
+		cfg  func(c *CLIConfig)
+		err  string
+	}{
+		{"target below threshold", func(c *CLIConfig) { c.DepositTopUpTarget = "1" }, "deposit top up target must cover the threshold"},
+		{"no ceiling", func(c *CLIConfig) { c.DepositTopUpCeiling = "" }, "deposit top up ceiling must be positive"},
+		{"invalid ceiling", func(c *CLIConfig) { c.DepositTopUpCeiling = "1e20" }, "deposit top up ceiling is not a valid wei amount"},
+		{"negative threshold", func(c *CLIConfig) { c.DepositTopUpThreshold = "-1" }, "deposit top up threshold is not a valid wei amount"},
+		{"no top up", func(c *CLIConfig) {
+			c.DepositTopUpThreshold = ""
+			c.DepositTopUpCeiling = ""
+		}, ""},
+		{"sweep pool reserve below target", func(c *CLIConfig) {
+			c.SweepAddress = "0xc0"
+			c.SweepPoolReserve = "19000000000000000000"
+		}, "sweep pool reserve must cover the deposit top up target"},
+	} {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			cfg := valid
+			test.cfg(&cfg)
+			err := cfg.Check()
+			if test.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, test.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package validator
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/kroma-network/kroma/components/node/eth"
+	"github.com/kroma-network/kroma/components/node/rollup"
+	"github.com/kroma-network/kroma/components/validator/chaincfg"
+)
+
+// checkFaultInjection refuses the fault injection on the predefined networks, so that a devnet configuration
+// does not submit invalid outputs to a public network and lose its bonds.
+func checkFaultInjection(rollupConfig *rollup.Config) error {
+	for name, preset := range chaincfg.NetworksByName {
+		if rollupConfig.L2ChainID.Cmp(preset.L2ChainID) == 0 {
+			return fmt.Errorf("fault injection is not allowed on network %s", name)
+		}
+	}
+	return nil
+}
+
+// injectFault corrupts the output root of the output, if its L2 block is at a multiple of the fault injection
+// interval. It returns whether the output was corrupted.
+func injectFault(output *eth.OutputResponse, interval uint64) bool {
+	if interval == 0 || output.BlockRef.Number%interval != 0 {
+		return false
+	}
+	output.OutputRoot = eth.Bytes32(crypto.Keccak256Hash(output.OutputRoot[:]))
+	return true
+}
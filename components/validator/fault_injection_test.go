@@ -0,0 +1,39 @@
+package validator
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kroma-network/kroma/components/node/eth"
+	"github.com/kroma-network/kroma/components/node/rollup"
+	"github.com/kroma-network/kroma/components/validator/chaincfg"
+)
+
+func TestInjectFault(t *testing.T) {
+	newOutput := func(number uint64) *eth.OutputResponse {
+		return &eth.OutputResponse{OutputRoot: eth.Bytes32{0xaa}, BlockRef: eth.L2BlockRef{Number: number}}
+	}
+
+	output := newOutput(1800)
+	require.False(t, injectFault(output, 0), "fault injection disabled")
+	require.Equal(t, eth.Bytes32{0xaa}, output.OutputRoot)
+
+	require.False(t, injectFault(output, 3600))
+	require.Equal(t, eth.Bytes32{0xaa}, output.OutputRoot)
+
+	require.True(t, injectFault(output, 900))
+	require.NotEqual(t, eth.Bytes32{0xaa}, output.OutputRoot)
+
+	other := newOutput(2700)
+	require.True(t, injectFault(other, 900))
+	require.Equal(t, output.OutputRoot, other.OutputRoot, "expected the corruption to be deterministic")
+}
+
+func TestCheckFaultInjection(t *testing.T) {
+	require.NoError(t, checkFaultInjection(&rollup.Config{L2ChainID: big.NewInt(901)}))
+	for name, preset := range chaincfg.NetworksByName {
+		require.ErrorContains(t, checkFaultInjection(&rollup.Config{L2ChainID: preset.L2ChainID}), name)
+	}
+}
@@ -1,15 +1,20 @@
 package flags
 
 import (
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/urfave/cli"
 
+	"github.com/kroma-network/kroma/components/validator/chaincfg"
 	kservice "github.com/kroma-network/kroma/utils/service"
 	klog "github.com/kroma-network/kroma/utils/service/log"
 	kmetrics "github.com/kroma-network/kroma/utils/service/metrics"
 	kpprof "github.com/kroma-network/kroma/utils/service/pprof"
+	"github.com/kroma-network/kroma/utils/service/proxy"
 	krpc "github.com/kroma-network/kroma/utils/service/rpc"
+	ktracing "github.com/kroma-network/kroma/utils/service/tracing"
 	"github.com/kroma-network/kroma/utils/service/txmgr"
 )
 
@@ -31,22 +36,19 @@ var (
 		EnvVar:   kservice.PrefixEnvVar(envVarPrefix, "ROLLUP_RPC"),
 	}
 	L2OOAddressFlag = cli.StringFlag{
-		Name:     "l2oo-address",
-		Usage:    "Address of the L2OutputOracle contract",
-		Required: true,
-		EnvVar:   kservice.PrefixEnvVar(envVarPrefix, "L2OO_ADDRESS"),
+		Name:   "l2oo-address",
+		Usage:  "Address of the L2OutputOracle contract. Required unless preset by the network",
+		EnvVar: kservice.PrefixEnvVar(envVarPrefix, "L2OO_ADDRESS"),
 	}
 	ColosseumAddressFlag = cli.StringFlag{
-		Name:     "colosseum-address",
-		Usage:    "Address of the Colosseum contract",
-		Required: true,
-		EnvVar:   kservice.PrefixEnvVar(envVarPrefix, "COLOSSEUM_ADDRESS"),
+		Name:   "colosseum-address",
+		Usage:  "Address of the Colosseum contract. Required unless preset by the network",
+		EnvVar: kservice.PrefixEnvVar(envVarPrefix, "COLOSSEUM_ADDRESS"),
 	}
 	ValPoolAddressFlag = cli.StringFlag{
-		Name:     "valpool-address",
-		Usage:    "Address of the ValidatorPool contract",
-		Required: true,
-		EnvVar:   kservice.PrefixEnvVar(envVarPrefix, "VALPOOL_ADDRESS"),
+		Name:   "valpool-address",
+		Usage:  "Address of the ValidatorPool contract. Required unless preset by the network, which sepolia does not",
+		EnvVar: kservice.PrefixEnvVar(envVarPrefix, "VALPOOL_ADDRESS"),
 	}
 	ChallengerPollIntervalFlag = cli.DurationFlag{
 		Name:     "challenger.poll-interval",
@@ -63,6 +65,18 @@ var (
 
 	// Optional flags
 
+	NetworkFlag = cli.StringFlag{
+		Name: "network",
+		Usage: fmt.Sprintf("Predefined network selection, presetting the contract addresses and protocol parameters "+
+			"not set explicitly. Available networks: %s", strings.Join(chaincfg.AvailableNetworks(), ", ")),
+		EnvVar: kservice.PrefixEnvVar(envVarPrefix, "NETWORK"),
+	}
+	ProfileFlag = cli.StringFlag{
+		Name: "profile",
+		Usage: fmt.Sprintf("Configuration profile, setting the txmgr fees, guardian retries and challenger strategy "+
+			"not set explicitly. Available profiles: %s", strings.Join(chaincfg.AvailableProfiles(), ", ")),
+		EnvVar: kservice.PrefixEnvVar(envVarPrefix, "PROFILE"),
+	}
 	AllowNonFinalizedFlag = cli.BoolFlag{
 		Name:   "allow-non-finalized",
 		Usage:  "Allow the validator to submit outputs for L2 blocks derived from non-finalized L1 blocks.",
@@ -73,6 +87,11 @@ var (
 		Usage:  "Disable l2 output submitter",
 		EnvVar: kservice.PrefixEnvVar(envVarPrefix, "OUTPUT_SUBMITTER_DISABLED"),
 	}
+	OutputSubmitterPrivateKeyFlag = cli.StringFlag{
+		Name:   "output-submitter.private-key",
+		Usage:  "The private key of an account of the output submitter of its own. If empty, the output submitter sends from the account of the validator.",
+		EnvVar: kservice.PrefixEnvVar(envVarPrefix, "OUTPUT_SUBMITTER_PRIVATE_KEY"),
+	}
 	OutputSubmitterBondAmountFlag = cli.Uint64Flag{
 		Name:   "output-submitter.bond-amount",
 		Usage:  "Amount to bond when submitting each output (in wei)",
@@ -96,6 +115,11 @@ var (
 		Usage:  "Disable challenger",
 		EnvVar: kservice.PrefixEnvVar(envVarPrefix, "CHALLENGER_DISABLED"),
 	}
+	ChallengerPrivateKeyFlag = cli.StringFlag{
+		Name:   "challenger.private-key",
+		Usage:  "The private key of an account of the challenger of its own. If empty, the challenger sends from the account of the validator.",
+		EnvVar: kservice.PrefixEnvVar(envVarPrefix, "CHALLENGER_PRIVATE_KEY"),
+	}
 	SecurityCouncilAddressFlag = cli.StringFlag{
 		Name:   "securitycouncil-address",
 		Usage:  "Address of the SecurityCouncil contract",
@@ -106,12 +130,345 @@ var (
 		Usage:  "Enable guardian",
 		EnvVar: kservice.PrefixEnvVar(envVarPrefix, "GUARDIAN_ENABLED"),
 	}
+	GuardianPrivateKeyFlag = cli.StringFlag{
+		Name:   "guardian.private-key",
+		Usage:  "The private key of an account of the guardian of its own, e.g. of a member of the SecurityCouncil. If empty, the guardian sends from the account of the validator.",
+		EnvVar: kservice.PrefixEnvVar(envVarPrefix, "GUARDIAN_PRIVATE_KEY"),
+	}
+	GuardianBlockWaitTimeoutFlag = cli.DurationFlag{
+		Name:   "guardian.block-wait-timeout",
+		Usage:  "Duration to wait for the requested L2 block to be derived before giving up the validation",
+		EnvVar: kservice.PrefixEnvVar(envVarPrefix, "GUARDIAN_BLOCK_WAIT_TIMEOUT"),
+		Value:  time.Minute * 30,
+	}
+	GuardianMaxClockSkewFlag = cli.DurationFlag{
+		Name:   "guardian.max-clock-skew",
+		Usage:  "Maximum skew of the local clock against the timestamp of the latest L1 block, beyond which the guardian warns. 0 disables the clock skew check",
+		EnvVar: kservice.PrefixEnvVar(envVarPrefix, "GUARDIAN_MAX_CLOCK_SKEW"),
+		Value:  time.Second * 30,
+	}
+	GuardianMaxNodeLagFlag = cli.Uint64Flag{
+		Name:   "guardian.max-node-lag",
+		Usage:  "Maximum number of L1 blocks the derivation of the rollup node may lag behind the L1 head to confirm the validation requests. 0 disables the node lag check",
+		EnvVar: kservice.PrefixEnvVar(envVarPrefix, "GUARDIAN_MAX_NODE_LAG"),
+	}
+	GuardianStateFileFlag = cli.StringFlag{
+		Name:   "guardian.state-file",
+		Usage:  "Path of the file the last processed L1 block is persisted to, to backfill the validation requests emitted while the guardian was offline. Disabled if empty",
+		EnvVar: kservice.PrefixEnvVar(envVarPrefix, "GUARDIAN_STATE_FILE"),
+	}
+	GuardianBackfillMaxBlocksFlag = cli.Uint64Flag{
+		Name:   "guardian.backfill-max-blocks",
+		Usage:  "Maximum number of L1 blocks before the head to backfill the validation requests of, also backfilled on the first start without a state file",
+		EnvVar: kservice.PrefixEnvVar(envVarPrefix, "GUARDIAN_BACKFILL_MAX_BLOCKS"),
+		Value:  50400,
+	}
+	GuardianStorePathFlag = cli.StringFlag{
+		Name:   "guardian.store",
+		Usage:  "Path of the leveldb directory the decisions of the validation requests are recorded in, to audit them and to not evaluate a request twice across restarts. Disabled if empty",
+		EnvVar: kservice.PrefixEnvVar(envVarPrefix, "GUARDIAN_STORE"),
+	}
+	GuardianDissentFlag = cli.BoolFlag{
+		Name:   "guardian.dissent",
+		Usage:  "Contest the validation requests of invalid outputs, by revoking an earlier confirmation of the guardian and alerting",
+		EnvVar: kservice.PrefixEnvVar(envVarPrefix, "GUARDIAN_DISSENT"),
+	}
+	GuardianDryRunFlag = cli.BoolFlag{
+		Name:   "guardian.dry-run",
+		Usage:  "Validate the requested outputs and log the confirmations and revocations that would be submitted, without submitting them",
+		EnvVar: kservice.PrefixEnvVar(envVarPrefix, "GUARDIAN_DRY_RUN"),
+	}
+	GuardianAlertWebhookFlag = cli.StringFlag{
+		Name:   "guardian.alert-webhook",
+		Usage:  "URL of a webhook the alerts of output mismatches and failing confirmations are posted to as JSON. Disabled if empty",
+		EnvVar: kservice.PrefixEnvVar(envVarPrefix, "GUARDIAN_ALERT_WEBHOOK"),
+	}
+	GuardianAlertPagerDutyKeyFlag = cli.StringFlag{
+		Name:   "guardian.alert-pagerduty-key",
+		Usage:  "Routing key of a PagerDuty Events API v2 integration the alerts trigger incidents of. Disabled if empty",
+		EnvVar: kservice.PrefixEnvVar(envVarPrefix, "GUARDIAN_ALERT_PAGERDUTY_KEY"),
+	}
+	GuardianAlertSlackWebhookFlag = cli.StringFlag{
+		Name:   "guardian.alert-slack-webhook",
+		Usage:  "URL of a Slack incoming webhook the alerts are posted to. Disabled if empty",
+		EnvVar: kservice.PrefixEnvVar(envVarPrefix, "GUARDIAN_ALERT_SLACK_WEBHOOK"),
+	}
+	GuardianRollupRpcsFlag = cli.StringSliceFlag{
+		Name:   "guardian.rollup-rpcs",
+		Usage:  "HTTP provider URLs of additional independent rollup nodes the guardian validates the outputs against, together with the rollup node",
+		EnvVar: kservice.PrefixEnvVar(envVarPrefix, "GUARDIAN_ROLLUP_RPCS"),
+	}
+	GuardianRollupQuorumFlag = cli.IntFlag{
+		Name:   "guardian.rollup-quorum",
+		Usage:  "Number of the rollup nodes that must agree on an output before the guardian confirms it. All of them if 0",
+		EnvVar: kservice.PrefixEnvVar(envVarPrefix, "GUARDIAN_ROLLUP_QUORUM"),
+	}
+	GuardianL2EthRpcFlag = cli.StringFlag{
+		Name:   "guardian.l2-eth-rpc",
+		Usage:  "HTTP provider URL of an L2 execution client the guardian recomputes the outputs from while the rollup node is unreachable. Disabled if empty",
+		EnvVar: kservice.PrefixEnvVar(envVarPrefix, "GUARDIAN_L2_ETH_RPC"),
+	}
+	GuardianLeaderLeaseFlag = cli.StringFlag{
+		Name:   "guardian.leader-lease",
+		Usage:  "Name of the Kubernetes Lease the guardian campaigns for, so that only the leader of the guardians sharing a key submits transactions, while the others validate in shadow mode. Disabled if empty",
+		EnvVar: kservice.PrefixEnvVar(envVarPrefix, "GUARDIAN_LEADER_LEASE"),
+	}
+	GuardianLeaderLeaseNamespaceFlag = cli.StringFlag{
+		Name:   "guardian.leader-lease-namespace",
+		Usage:  "Namespace of the Kubernetes Lease, the namespace of the service account of the pod if empty",
+		EnvVar: kservice.PrefixEnvVar(envVarPrefix, "GUARDIAN_LEADER_LEASE_NAMESPACE"),
+	}
+	GuardianLeaderIdentityFlag = cli.StringFlag{
+		Name:   "guardian.leader-identity",
+		Usage:  "Identity of the guardian in the Kubernetes Lease, the hostname if empty",
+		EnvVar: kservice.PrefixEnvVar(envVarPrefix, "GUARDIAN_LEADER_IDENTITY"),
+	}
+	GuardianLeaderLeaseDurationFlag = cli.DurationFlag{
+		Name:   "guardian.leader-lease-duration",
+		Usage:  "How long the Kubernetes Lease is held without being renewed before a standby takes over",
+		EnvVar: kservice.PrefixEnvVar(envVarPrefix, "GUARDIAN_LEADER_LEASE_DURATION"),
+		Value:  15 * time.Second,
+	}
+	GuardianMaxRetriesFlag = cli.IntFlag{
+		Name:   "guardian.max-retries",
+		Usage:  "Number of failed validation attempts of a request retried with an exponential backoff, before it is moved to the dead letter queue. Unlimited if 0",
+		EnvVar: kservice.PrefixEnvVar(envVarPrefix, "GUARDIAN_MAX_RETRIES"),
+		Value:  30,
+	}
+	GuardianMaxConcurrentValidationsFlag = cli.IntFlag{
+		Name:   "guardian.max-concurrent-validations",
+		Usage:  "Maximum number of validation requests validated at once. The other requests are queued, ordered by their L2 block number",
+		EnvVar: kservice.PrefixEnvVar(envVarPrefix, "GUARDIAN_MAX_CONCURRENT_VALIDATIONS"),
+		Value:  16,
+	}
+	GuardianMaxTxFeeFlag = cli.Uint64Flag{
+		Name:   "guardian.max-tx-fee",
+		Usage:  "Maximum fee of a confirmation at the current L1 base fee (in wei), skipping and alerting the confirmations exceeding it during fee spikes. The fees of a pending confirmation are not bumped above it. 0 for no cap",
+		EnvVar: kservice.PrefixEnvVar(envVarPrefix, "GUARDIAN_MAX_TX_FEE"),
+	}
+	GuardianBatchWindowFlag = cli.DurationFlag{
+		Name:   "guardian.batch-window",
+		Usage:  "How long to collect the validation requests of a burst, to validate them once per output and queue their confirmations together. If not set, every request is validated on its own",
+		EnvVar: kservice.PrefixEnvVar(envVarPrefix, "GUARDIAN_BATCH_WINDOW"),
+	}
+	GuardianMaxBatchSizeFlag = cli.IntFlag{
+		Name:   "guardian.max-batch-size",
+		Usage:  "Maximum number of the validation requests of a batch, the batch is processed early once it is full. 0 for no maximum",
+		EnvVar: kservice.PrefixEnvVar(envVarPrefix, "GUARDIAN_MAX_BATCH_SIZE"),
+	}
 	FetchingProofTimeoutFlag = cli.DurationFlag{
 		Name:   "fetching-proof-timeout",
 		Usage:  "Duration we will wait to fetching proof",
 		EnvVar: kservice.PrefixEnvVar(envVarPrefix, "FETCHING_PROOF_TIMEOUT"),
 		Value:  time.Hour * 2,
 	}
+	ShutdownDrainTimeoutFlag = cli.DurationFlag{
+		Name:   "shutdown.drain-timeout",
+		Usage:  "Duration to wait for the challenge turns in progress and the queued transactions to be sent on shutdown",
+		EnvVar: kservice.PrefixEnvVar(envVarPrefix, "SHUTDOWN_DRAIN_TIMEOUT"),
+		Value:  time.Minute * 5,
+	}
+	RecoveryAuditWindowFlag = cli.DurationFlag{
+		Name:   "recovery.audit-window",
+		Usage:  "How far back the decisions of the guardian are reconciled with the chain by the recovery audit on start, 0 to disable the audit",
+		EnvVar: kservice.PrefixEnvVar(envVarPrefix, "RECOVERY_AUDIT_WINDOW"),
+		Value:  time.Hour * 24,
+	}
+	WitnessRpcFlag = cli.StringFlag{
+		Name:   "challenger.witness-rpc-url",
+		Usage:  "HTTP provider URL for the witness service used for proving. If not set, the rollup node is used",
+		EnvVar: kservice.PrefixEnvVar(envVarPrefix, "CHALLENGER_WITNESS_RPC"),
+	}
+	WitnessDirFlag = cli.StringFlag{
+		Name:   "challenger.witness-dir",
+		Usage:  "Directory of precomputed witness artifacts used for proving. If not set, the rollup node is used",
+		EnvVar: kservice.PrefixEnvVar(envVarPrefix, "CHALLENGER_WITNESS_DIR"),
+	}
+	ProverGrpcSecondaryFlag = cli.StringFlag{
+		Name: "prover-grpc-secondary-url",
+		Usage: "gRPC URL for a second, independent kroma-prover. If set, a fault proof is only submitted " +
+			"if the proofs of both provers verify",
+		EnvVar: kservice.PrefixEnvVar(envVarPrefix, "PROVER_GRPC_SECONDARY"),
+	}
+	L1MaxConcurrentCallsFlag = cli.IntFlag{
+		Name:   "l1.max-concurrent-calls",
+		Usage:  "Maximum number of concurrent L1 calls shared by all roles. 0 means unlimited",
+		EnvVar: kservice.PrefixEnvVar(envVarPrefix, "L1_MAX_CONCURRENT_CALLS"),
+	}
+	L1RateLimitFlag = cli.Float64Flag{
+		Name:   "l1.rate-limit",
+		Usage:  "Maximum number of L1 calls per second shared by all roles. 0 means unlimited",
+		EnvVar: kservice.PrefixEnvVar(envVarPrefix, "L1_RATE_LIMIT"),
+	}
+	L1RateLimitBurstFlag = cli.IntFlag{
+		Name:   "l1.rate-limit-burst",
+		Usage:  "Maximum number of L1 calls allowed at once by the L1 rate limit",
+		EnvVar: kservice.PrefixEnvVar(envVarPrefix, "L1_RATE_LIMIT_BURST"),
+		Value:  10,
+	}
+	SweepAddressFlag = cli.StringFlag{
+		Name:   "challenger.sweep-address",
+		Usage:  "Cold address to sweep the recovered bonds and rewards to. If not set, the funds are not swept",
+		EnvVar: kservice.PrefixEnvVar(envVarPrefix, "CHALLENGER_SWEEP_ADDRESS"),
+	}
+	SweepThresholdFlag = cli.StringFlag{
+		Name:   "challenger.sweep-threshold",
+		Usage:  "Minimum amount to sweep (in wei)",
+		EnvVar: kservice.PrefixEnvVar(envVarPrefix, "CHALLENGER_SWEEP_THRESHOLD"),
+		Value:  "1000000000000000000",
+	}
+	SweepPoolReserveFlag = cli.StringFlag{
+		Name:   "challenger.sweep-pool-reserve",
+		Usage:  "Deposit kept in the ValidatorPool to bond outputs when sweeping (in wei). Required to sweep, must be positive",
+		EnvVar: kservice.PrefixEnvVar(envVarPrefix, "CHALLENGER_SWEEP_POOL_RESERVE"),
+	}
+	SweepAccountReserveFlag = cli.StringFlag{
+		Name:   "challenger.sweep-account-reserve",
+		Usage:  "Balance kept in the validator account to pay for gas when sweeping (in wei), must be positive",
+		EnvVar: kservice.PrefixEnvVar(envVarPrefix, "CHALLENGER_SWEEP_ACCOUNT_RESERVE"),
+		Value:  "1000000000000000000",
+	}
+	SweepIntervalFlag = cli.DurationFlag{
+		Name:   "challenger.sweep-interval",
+		Usage:  "Interval of checking the balances for funds to sweep",
+		EnvVar: kservice.PrefixEnvVar(envVarPrefix, "CHALLENGER_SWEEP_INTERVAL"),
+		Value:  time.Minute * 10,
+	}
+	SweepJournalFlag = cli.StringFlag{
+		Name:   "challenger.sweep-journal",
+		Usage:  "File to append an entry to for every sweep transaction. If not set, the sweeps are only logged",
+		EnvVar: kservice.PrefixEnvVar(envVarPrefix, "CHALLENGER_SWEEP_JOURNAL"),
+	}
+	ChallengerCoordinationFlag = cli.StringFlag{
+		Name:   "challenger.coordination",
+		Usage:  "How to handle an invalid output already challenged by another challenger: hold-back while its challenge agrees with the local outputs, parallel to challenge again as soon as its challenge timed out, or takeover to also prepare the challenge once it stalls near the deadline",
+		EnvVar: kservice.PrefixEnvVar(envVarPrefix, "CHALLENGER_COORDINATION"),
+		Value:  "hold-back",
+	}
+	ChallengerTakeoverMarginFlag = cli.DurationFlag{
+		Name:   "challenger.takeover-margin",
+		Usage:  "How long before the deadline of a stalling challenge of another challenger to prepare taking it over",
+		EnvVar: kservice.PrefixEnvVar(envVarPrefix, "CHALLENGER_TAKEOVER_MARGIN"),
+		Value:  time.Minute * 10,
+	}
+	ProofCacheDirFlag = cli.StringFlag{
+		Name:   "challenger.proof-cache-dir",
+		Usage:  "Directory to keep the fetched proofs in, so that a proof is generated once across restarts. If not set, the proofs are not cached",
+		EnvVar: kservice.PrefixEnvVar(envVarPrefix, "CHALLENGER_PROOF_CACHE_DIR"),
+	}
+	ChallengerProofMarginFlag = cli.DurationFlag{
+		Name:   "challenger.proof-margin",
+		Usage:  "How long before the proving deadline of a challenge to stop retrying the failed proof requests, to leave time for the proveFault transaction to land",
+		EnvVar: kservice.PrefixEnvVar(envVarPrefix, "CHALLENGER_PROOF_MARGIN"),
+		Value:  time.Minute * 2,
+	}
+	ChallengerProverThroughputFlag = cli.Uint64Flag{
+		Name:   "challenger.prover-throughput",
+		Usage:  "Expected throughput of the prover (in gas per second) to estimate whether a proof can be generated before the deadline, until it is measured on the generated proofs. The proofs are only estimated once measured if 0.",
+		EnvVar: kservice.PrefixEnvVar(envVarPrefix, "CHALLENGER_PROVER_THROUGHPUT"),
+	}
+	ChallengerInfeasibleDelayFlag = cli.DurationFlag{
+		Name:   "challenger.infeasible-delay",
+		Usage:  "How long to defer the challenge of an invalid output when the proof of a full block is estimated not to be generated within the proving timeout, to leave the output to another challenger. The challenge is still created once the delay elapsed, or the delay before the output finalizes. Disabled if 0",
+		EnvVar: kservice.PrefixEnvVar(envVarPrefix, "CHALLENGER_INFEASIBLE_DELAY"),
+		Value:  time.Minute * 10,
+	}
+	ChallengerStorePathFlag = cli.StringFlag{
+		Name:   "challenger.store",
+		Usage:  "Path of the leveldb directory the state of the challenges is recorded in, to resume them where they were left off after a restart. Disabled if empty",
+		EnvVar: kservice.PrefixEnvVar(envVarPrefix, "CHALLENGER_STORE"),
+	}
+	ChallengerDepositTargetFlag = cli.StringFlag{
+		Name:   "challenger.deposit-target",
+		Usage:  "Deposit (in wei) in the ValidatorPool to top up to before creating a challenge, if the deposit does not cover its bond. If not set, the deposit is not topped up",
+		EnvVar: kservice.PrefixEnvVar(envVarPrefix, "CHALLENGER_DEPOSIT_TARGET"),
+	}
+	ChallengerDepositMaxTopUpFlag = cli.StringFlag{
+		Name:   "challenger.deposit-max-top-up",
+		Usage:  "Maximum amount (in wei) to deposit into the ValidatorPool at once. If not set, there is no maximum",
+		EnvVar: kservice.PrefixEnvVar(envVarPrefix, "CHALLENGER_DEPOSIT_MAX_TOP_UP"),
+	}
+	DepositTopUpThresholdFlag = cli.StringFlag{
+		Name:   "output-submitter.deposit-threshold",
+		Usage:  "Deposit (in wei) in the ValidatorPool below which it is topped up automatically. If not set, the deposit is not topped up",
+		EnvVar: kservice.PrefixEnvVar(envVarPrefix, "OUTPUT_SUBMITTER_DEPOSIT_THRESHOLD"),
+	}
+	DepositTopUpTargetFlag = cli.StringFlag{
+		Name:   "output-submitter.deposit-target",
+		Usage:  "Deposit (in wei) in the ValidatorPool to top up to when it falls below the threshold",
+		EnvVar: kservice.PrefixEnvVar(envVarPrefix, "OUTPUT_SUBMITTER_DEPOSIT_TARGET"),
+	}
+	DepositTopUpCeilingFlag = cli.StringFlag{
+		Name:   "output-submitter.deposit-ceiling",
+		Usage:  "Maximum total amount (in wei) deposited by the automatic top ups since the validator started",
+		EnvVar: kservice.PrefixEnvVar(envVarPrefix, "OUTPUT_SUBMITTER_DEPOSIT_CEILING"),
+	}
+	DepositTopUpIntervalFlag = cli.DurationFlag{
+		Name:   "output-submitter.deposit-interval",
+		Usage:  "Interval of checking the deposit in the ValidatorPool for a top up",
+		EnvVar: kservice.PrefixEnvVar(envVarPrefix, "OUTPUT_SUBMITTER_DEPOSIT_INTERVAL"),
+		Value:  time.Minute,
+	}
+	AutoUnbondFlag = cli.BoolFlag{
+		Name:   "output-submitter.auto-unbond",
+		Usage:  "Unbond the bonds of the finalized outputs in the ValidatorPool automatically",
+		EnvVar: kservice.PrefixEnvVar(envVarPrefix, "OUTPUT_SUBMITTER_AUTO_UNBOND"),
+	}
+	UnbondIntervalFlag = cli.DurationFlag{
+		Name:   "output-submitter.unbond-interval",
+		Usage:  "Interval of checking the bonds of the finalized outputs in the ValidatorPool for unbonding",
+		EnvVar: kservice.PrefixEnvVar(envVarPrefix, "OUTPUT_SUBMITTER_UNBOND_INTERVAL"),
+		Value:  time.Minute,
+	}
+	FaultInjectionFlag = cli.Uint64Flag{
+		Name:   "validator.fault-injection",
+		Usage:  "Developer mode: submit the outputs of the L2 blocks at multiples of this interval with a corrupted output root, to test the challengers and the guardians in devnets. Disabled if 0",
+		EnvVar: kservice.PrefixEnvVar(envVarPrefix, "VALIDATOR_FAULT_INJECTION"),
+	}
+	HeartbeatEndpointFlag = cli.StringFlag{
+		Name:   "heartbeat.endpoint",
+		Usage:  "HTTP URL of the coordination endpoint to post the heartbeats to. If not set, no heartbeat is posted",
+		EnvVar: kservice.PrefixEnvVar(envVarPrefix, "HEARTBEAT_ENDPOINT"),
+	}
+	HeartbeatIntervalFlag = cli.DurationFlag{
+		Name:   "heartbeat.interval",
+		Usage:  "Interval of posting the heartbeats",
+		EnvVar: kservice.PrefixEnvVar(envVarPrefix, "HEARTBEAT_INTERVAL"),
+		Value:  time.Minute,
+	}
+	HeartbeatSecretPathFlag = cli.StringFlag{
+		Name:   "heartbeat.secret",
+		Usage:  "Path to the file of the hex encoded secret shared with the coordination endpoint, to sign the heartbeats with",
+		EnvVar: kservice.PrefixEnvVar(envVarPrefix, "HEARTBEAT_SECRET"),
+	}
+	RollupCompatCheckIntervalFlag = cli.DurationFlag{
+		Name:   "rollup.compat-check-interval",
+		Usage:  "Interval of checking that the rollup node supports the protocol version of the validator and runs with the same rollup config. The transactions are refused while it does not. Disabled if 0",
+		EnvVar: kservice.PrefixEnvVar(envVarPrefix, "ROLLUP_COMPAT_CHECK_INTERVAL"),
+		Value:  time.Minute,
+	}
+	RPCEnableAdminFlag = cli.BoolFlag{
+		Name:   "rpc.enable-admin",
+		Usage:  "Enable the admin API, e.g. to approve the transactions exceeding the approval thresholds of the tx manager, to list the in-flight transactions, or to revalidate the requests of the guardian",
+		EnvVar: kservice.PrefixEnvVar(envVarPrefix, "RPC_ENABLE_ADMIN"),
+	}
+	HealthEnabledFlag = cli.BoolFlag{
+		Name:   "health.enabled",
+		Usage:  "Enable the health server, serving the liveness on /healthz and the readiness of the roles on /readyz",
+		EnvVar: kservice.PrefixEnvVar(envVarPrefix, "HEALTH_ENABLED"),
+	}
+	HealthAddrFlag = cli.StringFlag{
+		Name:   "health.addr",
+		Usage:  "Health server listening address",
+		EnvVar: kservice.PrefixEnvVar(envVarPrefix, "HEALTH_ADDR"),
+		Value:  "0.0.0.0",
+	}
+	HealthPortFlag = cli.IntFlag{
+		Name:   "health.port",
+		Usage:  "Health server listening port",
+		EnvVar: kservice.PrefixEnvVar(envVarPrefix, "HEALTH_PORT"),
+		Value:  7310,
+	}
 )
 
 var requiredFlags = []cli.Flag{
@@ -125,15 +482,81 @@ var requiredFlags = []cli.Flag{
 }
 
 var optionalFlags = []cli.Flag{
+	NetworkFlag,
+	ProfileFlag,
 	AllowNonFinalizedFlag,
 	OutputSubmitterDisabledFlag,
+	OutputSubmitterPrivateKeyFlag,
 	OutputSubmitterBondAmountFlag,
 	OutputSubmitterRetryIntervalFlag,
 	OutputSubmitterRoundBufferFlag,
 	ChallengerDisabledFlag,
+	ChallengerPrivateKeyFlag,
 	SecurityCouncilAddressFlag,
 	GuardianEnabledFlag,
+	GuardianPrivateKeyFlag,
+	GuardianBlockWaitTimeoutFlag,
+	GuardianMaxClockSkewFlag,
+	GuardianMaxNodeLagFlag,
+	GuardianStateFileFlag,
+	GuardianBackfillMaxBlocksFlag,
+	GuardianStorePathFlag,
+	GuardianDissentFlag,
+	GuardianDryRunFlag,
+	GuardianAlertWebhookFlag,
+	GuardianAlertPagerDutyKeyFlag,
+	GuardianAlertSlackWebhookFlag,
+	GuardianRollupRpcsFlag,
+	GuardianRollupQuorumFlag,
+	GuardianL2EthRpcFlag,
+	GuardianLeaderLeaseFlag,
+	GuardianLeaderLeaseNamespaceFlag,
+	GuardianLeaderIdentityFlag,
+	GuardianLeaderLeaseDurationFlag,
+	GuardianMaxRetriesFlag,
+	GuardianMaxConcurrentValidationsFlag,
+	GuardianMaxTxFeeFlag,
+	GuardianBatchWindowFlag,
+	GuardianMaxBatchSizeFlag,
 	FetchingProofTimeoutFlag,
+	ShutdownDrainTimeoutFlag,
+	RecoveryAuditWindowFlag,
+	WitnessRpcFlag,
+	WitnessDirFlag,
+	ProverGrpcSecondaryFlag,
+	L1MaxConcurrentCallsFlag,
+	L1RateLimitFlag,
+	L1RateLimitBurstFlag,
+	SweepAddressFlag,
+	SweepThresholdFlag,
+	SweepPoolReserveFlag,
+	SweepAccountReserveFlag,
+	SweepIntervalFlag,
+	SweepJournalFlag,
+	ChallengerCoordinationFlag,
+	ChallengerTakeoverMarginFlag,
+	ProofCacheDirFlag,
+	ChallengerProofMarginFlag,
+	ChallengerProverThroughputFlag,
+	ChallengerInfeasibleDelayFlag,
+	ChallengerStorePathFlag,
+	ChallengerDepositTargetFlag,
+	ChallengerDepositMaxTopUpFlag,
+	DepositTopUpThresholdFlag,
+	DepositTopUpTargetFlag,
+	DepositTopUpCeilingFlag,
+	DepositTopUpIntervalFlag,
+	AutoUnbondFlag,
+	UnbondIntervalFlag,
+	FaultInjectionFlag,
+	HeartbeatEndpointFlag,
+	HeartbeatIntervalFlag,
+	HeartbeatSecretPathFlag,
+	RollupCompatCheckIntervalFlag,
+	RPCEnableAdminFlag,
+	HealthEnabledFlag,
+	HealthAddrFlag,
+	HealthPortFlag,
 }
 
 func init() {
@@ -142,7 +565,9 @@ func init() {
 	optionalFlags = append(optionalFlags, klog.CLIFlags(envVarPrefix)...)
 	optionalFlags = append(optionalFlags, kmetrics.CLIFlags(envVarPrefix)...)
 	optionalFlags = append(optionalFlags, kpprof.CLIFlags(envVarPrefix)...)
+	optionalFlags = append(optionalFlags, ktracing.CLIFlags(envVarPrefix)...)
 	optionalFlags = append(optionalFlags, txmgr.CLIFlags(envVarPrefix)...)
+	optionalFlags = append(optionalFlags, proxy.CLIFlags(envVarPrefix)...)
 
 	Flags = append(requiredFlags, optionalFlags...)
 }
@@ -1,99 +1,418 @@
 package validator
 
 import (
-	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"math/big"
-	_ "net/http/pprof"
 	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/log"
 
 	"github.com/kroma-network/kroma/bindings/bindings"
 	"github.com/kroma-network/kroma/components/node/eth"
+	"github.com/kroma-network/kroma/components/validator/metrics"
 	"github.com/kroma-network/kroma/utils"
+	"github.com/kroma-network/kroma/utils/service/backoff"
 	"github.com/kroma-network/kroma/utils/service/txmgr"
 )
 
+// defaultGuardianBlockWaitTimeout is used if no timeout to wait for the requested L2 block is configured.
+const defaultGuardianBlockWaitTimeout = 30 * time.Minute
+
+// defaultGuardianPollInterval is the interval at which a requested output validation is retried.
+const defaultGuardianPollInterval = 10 * time.Second
+
+// GuardianRollupClient is the set of rollup node RPC methods that the Guardian uses
+// to validate a requested output.
+type GuardianRollupClient interface {
+	OutputAtBlock(ctx context.Context, blockNumber uint64) (*eth.OutputResponse, error)
+	SyncStatus(ctx context.Context) (*eth.SyncStatus, error)
+}
+
+// SecurityCouncilContract is the set of SecurityCouncil contract methods that the Guardian uses.
+type SecurityCouncilContract interface {
+	IsConfirmed(opts *bind.CallOpts, transactionId *big.Int) (bool, error)
+	ConfirmTransaction(opts *bind.TransactOpts, transactionId *big.Int) (*types.Transaction, error)
+	RevokeConfirmation(opts *bind.TransactOpts, transactionId *big.Int) (*types.Transaction, error)
+	GetConfirmations(opts *bind.CallOpts, transactionId *big.Int) ([]common.Address, error)
+	WatchValidationRequested(opts *bind.WatchOpts, sink chan<- *bindings.SecurityCouncilValidationRequested, transactionId []*big.Int) (event.Subscription, error)
+	FilterValidationRequested(opts *bind.FilterOpts, transactionId []*big.Int) (*bindings.SecurityCouncilValidationRequestedIterator, error)
+}
+
+// GuardianL2OOContract is the set of L2OutputOracle contract methods that the Guardian uses
+// to discover the expected output checkpoints.
+type GuardianL2OOContract interface {
+	SUBMISSIONINTERVAL(opts *bind.CallOpts) (*big.Int, error)
+	StartingBlockNumber(opts *bind.CallOpts) (*big.Int, error)
+}
+
+// outputCheckpoints are the L2 block numbers at which outputs are submitted to the L2OutputOracle.
+type outputCheckpoints struct {
+	startingBlockNumber uint64
+	submissionInterval  uint64
+}
+
+// isCheckpoint returns whether an output can be submitted at the L2 block number.
+func (c outputCheckpoints) isCheckpoint(l2BlockNumber uint64) bool {
+	if c.submissionInterval == 0 || l2BlockNumber < c.startingBlockNumber {
+		return false
+	}
+	return (l2BlockNumber-c.startingBlockNumber)%c.submissionInterval == 0
+}
+
+// blockWait measures the time a validation request waits for its L2 block to be derived by the local node.
+// The time is measured on the monotonic clock, so the timeout does not depend on the skew of the local clock.
+type blockWait struct {
+	timeout time.Duration
+	now     func() time.Time
+	// start is the time the block was first found not derived yet, zero before
+	start time.Time
+}
+
+func newBlockWait(timeout time.Duration, now func() time.Time) *blockWait {
+	if timeout == 0 {
+		timeout = defaultGuardianBlockWaitTimeout
+	}
+	return &blockWait{timeout: timeout, now: now}
+}
+
+// elapsed returns the time waited since the block was first found not derived yet, and whether it exceeds the timeout.
+func (w *blockWait) elapsed() (time.Duration, bool) {
+	now := w.now()
+	if w.start.IsZero() {
+		w.start = now
+	}
+	elapsed := now.Sub(w.start)
+	return elapsed, elapsed > w.timeout
+}
+
 // Guardian is responsible for validating outputs
 type Guardian struct {
 	log    log.Logger
 	cfg    Config
 	ctx    context.Context
 	cancel context.CancelFunc
-	wg     sync.WaitGroup
+	// ctxMu guards ctx, set by Start while the admin API may read it
+	ctxMu sync.Mutex
+	wg    sync.WaitGroup
+
+	rollupClient GuardianRollupClient
+	pollInterval time.Duration
+	// retryStrategy is the backoff of the retries of the failed validation attempts of a request
+	retryStrategy backoff.Strategy
+	metr          metrics.Metricer
+
+	l2ooContract GuardianL2OOContract
+	checkpoints  outputCheckpoints
 
-	securityCouncilContract *bindings.SecurityCouncil
+	securityCouncilContract SecurityCouncilContract
 	securityCouncilSub      ethereum.Subscription
+	// securityCouncilSubHealth tracks the subscription to the validation requests, reported as the health of the guardian
+	securityCouncilSubHealth subscriptionHealth
 
 	validationRequestedChan chan *bindings.SecurityCouncilValidationRequested
+	// validations are the requests waiting for a validation worker
+	validations *validationQueue
+
+	// inFlight are the requests being processed, by transaction id, and lastProcessed the transaction id of the
+	// request processed last
+	inFlight      map[string]*bindings.SecurityCouncilValidationRequested
+	lastProcessed *big.Int
+	// unprocessed are the requests that ended without being processed, by transaction id, holding the progress
+	unprocessed map[string]struct{}
+	inFlightMu  sync.Mutex
+	// queued are the confirmations queued to be sent, by transaction candidate id, until their receipt is recorded
+	queued   map[string]queuedConfirmation
+	queuedMu sync.Mutex
+	// progress persists the processed L1 block to backfill the requests from, optional (may be nil)
+	progress *guardianProgress
+	l1Client GuardianL1Client
+	// feeClient estimates the gas and the fee of the confirmations, optional (may be nil)
+	feeClient GuardianFeeClient
+	// store records the decisions of the requests, optional (may be nil)
+	store GuardianStore
+
+	// councilHealth records the SecurityCouncil responsiveness, optional (may be nil)
+	councilHealth *councilHealthTracker
+	// clockSkew checks the local clock against L1, optional (may be nil)
+	clockSkew *clockSkewMonitor
+	// nodeLag checks the lag of the rollup node before confirming the requests, optional (may be nil)
+	nodeLag *nodeLagMonitor
+	// leader elects the guardian submitting the transactions among the guardians sharing the key, optional (may be nil)
+	leader LeaderElector
+	// traces traces the validation requests, optional (may be nil)
+	traces *validationTraces
+	// timeNow enables guardian testing to mock the time
+	timeNow func() time.Time
 
 	txCandidatesChan chan<- txmgr.TxCandidate
 }
 
 // NewGuardian creates a new Guardian
-func NewGuardian(cfg Config, l log.Logger) (*Guardian, error) {
-	securityCouncilContract, err := bindings.NewSecurityCouncil(cfg.SecurityCouncilAddr, cfg.L1Client)
+func NewGuardian(cfg Config, l log.Logger, m metrics.Metricer) (*Guardian, error) {
+	l1Client := cfg.L1Limiter.Client(L1RoleGuardian, cfg.L1Client)
+	securityCouncilContract, err := bindings.NewSecurityCouncil(cfg.SecurityCouncilAddr, l1Client)
 	if err != nil {
 		return nil, err
 	}
 
+	l2ooContract, err := bindings.NewL2OutputOracleCaller(cfg.L2OutputOracleAddr, l1Client)
+	if err != nil {
+		return nil, err
+	}
+
+	var clockSkew *clockSkewMonitor
+	if cfg.GuardianMaxClockSkew > 0 {
+		clockSkew = newClockSkewMonitor(l, m, l1Client, cfg.GuardianMaxClockSkew, cfg.NetworkTimeout)
+	}
+
+	var progress *guardianProgress
+	if cfg.GuardianStateFile != "" {
+		progress, err = loadGuardianProgress(l, cfg.GuardianStateFile)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var rollupClient GuardianRollupClient = cfg.RollupClient
+	if len(cfg.GuardianRollupClients) > 0 {
+		clients := append([]GuardianRollupClient{cfg.RollupClient}, cfg.GuardianRollupClients...)
+		quorum := cfg.GuardianRollupQuorum
+		if quorum == 0 {
+			quorum = len(clients)
+		}
+		rollupClient = newRollupQuorum(l, clients, quorum)
+		l.Info("validating outputs against a quorum of rollup nodes", "nodes", len(clients), "quorum", quorum)
+	}
+
+	var store GuardianStore
+	if cfg.GuardianStorePath != "" {
+		store, err = NewGuardianStore(cfg.GuardianStorePath)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var nodeLag *nodeLagMonitor
+	if cfg.GuardianMaxNodeLag > 0 {
+		nodeLag = newNodeLagMonitor(l, m, rollupClient, cfg.GuardianMaxNodeLag, cfg.NetworkTimeout)
+	}
+
+	var leader LeaderElector
+	if cfg.GuardianLeaseLock != nil {
+		leader = NewLeaseElector(l, m, cfg.GuardianLeaseLock, cfg.GuardianLeaderIdentity, cfg.GuardianLeaderLeaseDuration)
+	}
+
+	var traces *validationTraces
+	if cfg.TracerProvider != nil {
+		traces = newValidationTraces(cfg.TracerProvider.Tracer("guardian"))
+	}
+
 	return &Guardian{
 		log:                     l,
 		cfg:                     cfg,
+		rollupClient:            rollupClient,
+		pollInterval:            defaultGuardianPollInterval,
+		retryStrategy:           newGuardianRetryStrategy(),
+		metr:                    m,
+		l2ooContract:            l2ooContract,
 		securityCouncilContract: securityCouncilContract,
 		validationRequestedChan: make(chan *bindings.SecurityCouncilValidationRequested),
+		validations:             newValidationQueue(m, cfg.GuardianMaxConcurrentValidations),
+		inFlight:                make(map[string]*bindings.SecurityCouncilValidationRequested),
+		unprocessed:             make(map[string]struct{}),
+		queued:                  make(map[string]queuedConfirmation),
+		progress:                progress,
+		l1Client:                l1Client,
+		feeClient:               l1Client,
+		store:                   store,
+		councilHealth:           newCouncilHealthTracker(l, m, securityCouncilContract, l1Client, cfg.NetworkTimeout, traces),
+		clockSkew:               clockSkew,
+		nodeLag:                 nodeLag,
+		leader:                  leader,
+		traces:                  traces,
+		timeNow:                 time.Now,
 	}, nil
 }
 
 func (g *Guardian) Start(ctx context.Context, txCandidatesChan chan<- txmgr.TxCandidate) error {
-	g.ctx, g.cancel = context.WithCancel(ctx)
+	g.startContext(ctx)
 	g.log.Info("start Guardian")
 
+	if err := g.fetchCheckpoints(g.ctx); err != nil {
+		return err
+	}
+
 	watchOpts := &bind.WatchOpts{Context: g.ctx, Start: nil}
 
-	g.securityCouncilSub = event.ResubscribeErr(time.Second*10, func(ctx context.Context, err error) (event.Subscription, error) {
+	g.securityCouncilSub = event.ResubscribeErr(time.Second*10, g.securityCouncilSubHealth.track(func(ctx context.Context, err error) (event.Subscription, error) {
 		if err != nil {
 			g.log.Warn("resubscribing after failed SecurityCouncilValidationRequested event", "err", err)
 		}
 		return g.securityCouncilContract.WatchValidationRequested(watchOpts, g.validationRequestedChan, nil)
-	})
+	}))
+
+	if g.councilHealth != nil {
+		g.councilHealth.Start(g.ctx, &g.wg)
+	}
+	if g.clockSkew != nil {
+		g.clockSkew.Start(g.ctx, &g.wg)
+	}
+	if g.nodeLag != nil {
+		g.nodeLag.Start(g.ctx, &g.wg)
+	}
+	if g.leader != nil {
+		g.wg.Add(1)
+		go func() {
+			defer g.wg.Done()
+			g.leader.Run(g.ctx)
+		}()
+	}
 
 	g.txCandidatesChan = txCandidatesChan
 	g.wg.Add(1)
 	go g.handleValidationRequested(g.ctx)
+	if g.progress != nil {
+		g.wg.Add(1)
+		go g.backfill(g.ctx)
+	}
 
 	return nil
 }
 
+// startContext creates the context of the running guardian, canceled by Stop.
+func (g *Guardian) startContext(ctx context.Context) {
+	g.ctxMu.Lock()
+	defer g.ctxMu.Unlock()
+	g.ctx, g.cancel = context.WithCancel(ctx)
+}
+
+// runningContext returns the context of the running guardian, nil if it was not started.
+func (g *Guardian) runningContext() context.Context {
+	g.ctxMu.Lock()
+	defer g.ctxMu.Unlock()
+	return g.ctx
+}
+
 func (g *Guardian) Stop() error {
 	g.log.Info("stop Guardian")
 
 	if g.securityCouncilSub != nil {
 		g.securityCouncilSub.Unsubscribe()
 	}
+	if g.councilHealth != nil {
+		g.councilHealth.Stop()
+	}
 
 	g.cancel()
 	g.wg.Wait()
 
 	close(g.validationRequestedChan)
 
+	if g.store != nil {
+		if err := g.store.Close(); err != nil {
+			return fmt.Errorf("failed to close guardian store: %w", err)
+		}
+	}
+
+	if closer, ok := g.cfg.GuardianOutputFallback.(io.Closer); ok {
+		if err := closer.Close(); err != nil {
+			return fmt.Errorf("failed to close guardian output fallback: %w", err)
+		}
+	}
+
 	return nil
 }
 
-func (g *Guardian) ValidateL2Output(ctx context.Context, outputRoot eth.Bytes32, l2BlockNumber uint64) (bool, error) {
-	localOutputRoot, err := g.outputRootAtBlock(ctx, l2BlockNumber)
+// fetchCheckpoints fetches the output checkpoints from the L2OutputOracle, which the requested outputs must be aligned to.
+func (g *Guardian) fetchCheckpoints(ctx context.Context) error {
+	cCtx, cCancel := context.WithTimeout(ctx, g.cfg.NetworkTimeout)
+	defer cCancel()
+	callOpts := utils.NewSimpleCallOpts(cCtx)
+	submissionInterval, err := g.l2ooContract.SUBMISSIONINTERVAL(callOpts)
+	if err != nil {
+		return fmt.Errorf("failed to get submission interval: %w", err)
+	}
+	startingBlockNumber, err := g.l2ooContract.StartingBlockNumber(callOpts)
 	if err != nil {
-		return false, fmt.Errorf("failed to get outputRootAtBlock: %w", err)
+		return fmt.Errorf("failed to get starting block number: %w", err)
 	}
-	isValid := bytes.Equal(outputRoot[:], localOutputRoot[:])
-	return isValid, nil
+	if !submissionInterval.IsUint64() || submissionInterval.Uint64() == 0 || !startingBlockNumber.IsUint64() {
+		return fmt.Errorf("invalid output checkpoints: starting block number %s, submission interval %s", startingBlockNumber, submissionInterval)
+	}
+	g.checkpoints = outputCheckpoints{
+		startingBlockNumber: startingBlockNumber.Uint64(),
+		submissionInterval:  submissionInterval.Uint64(),
+	}
+	g.log.Info("fetched output checkpoints", "startingBlockNumber", g.checkpoints.startingBlockNumber,
+		"submissionInterval", g.checkpoints.submissionInterval)
+	return nil
+}
+
+// ValidationReason is the reason code of an output validation result, which tells an invalid output apart from
+// the infrastructure problems preventing the validation.
+type ValidationReason string
+
+const (
+	// ValidationReasonValid is a requested output root matching the local output root.
+	ValidationReasonValid ValidationReason = "valid"
+	// ValidationReasonMismatch is a requested output root differing from the local output root.
+	ValidationReasonMismatch ValidationReason = "mismatch"
+	// ValidationReasonNodeBehind is a requested L2 block the local node has not derived yet.
+	ValidationReasonNodeBehind ValidationReason = "node-behind"
+	// ValidationReasonRPCError is a failed call to the local node.
+	ValidationReasonRPCError ValidationReason = "rpc-error"
+	// ValidationReasonVersionUnknown is a local output of an unknown output root version.
+	ValidationReasonVersionUnknown ValidationReason = "version-unknown"
+	// ValidationReasonNoQuorum is a requested output that not enough of the local nodes agree on, if a quorum of
+	// rollup nodes is configured.
+	ValidationReasonNoQuorum ValidationReason = "no-quorum"
+)
+
+// ValidationResult is the result of the validation of a requested output against the local node.
+type ValidationResult struct {
+	Reason ValidationReason
+	// LocalOutputRoot is the output root of the local node, set if the local output was fetched.
+	LocalOutputRoot eth.Bytes32
+	// SafeBlockNumber is the latest L2 block number the local node has derived, set if the sync status was fetched.
+	SafeBlockNumber uint64
+	// Err is the error of the failed call to the local node, set if the reason is ValidationReasonRPCError or
+	// ValidationReasonNoQuorum.
+	Err error
+	// Fallback is whether the local output was recomputed from the L2 execution client, the rollup node being
+	// unreachable.
+	Fallback bool
+}
+
+// IsValid returns whether the requested output was validated.
+func (r ValidationResult) IsValid() bool {
+	return r.Reason == ValidationReasonValid
+}
+
+// ValidateL2Output validates the requested output against the local output of the rollup node. If the rollup node
+// is unreachable, the output is validated against the output recomputed from the L2 execution client, if configured.
+func (g *Guardian) ValidateL2Output(ctx context.Context, outputRoot eth.Bytes32, l2BlockNumber uint64) ValidationResult {
+	outputs := rollupOutputs{client: g.rollupClient, allowNonFinalized: g.cfg.AllowNonFinalized}
+	result := validateOutput(ctx, outputs, g.cfg.NetworkTimeout, outputRoot, l2BlockNumber)
+	if result.Reason != ValidationReasonRPCError || g.cfg.GuardianOutputFallback == nil {
+		return result
+	}
+	g.log.Warn("rollup node is unreachable, recomputing the output from the L2 execution client",
+		"l2BlockNumber", l2BlockNumber, "err", result.Err)
+	fallback := validateOutput(ctx, g.cfg.GuardianOutputFallback, g.cfg.NetworkTimeout, outputRoot, l2BlockNumber)
+	fallback.Fallback = true
+	if fallback.Reason == ValidationReasonRPCError {
+		fallback.Err = fmt.Errorf("%w, and the fallback failed: %v", result.Err, fallback.Err)
+	}
+	return fallback
 }
 
 func (g *Guardian) ConfirmTransaction(ctx context.Context, transactionId *big.Int) (*types.Transaction, error) {
@@ -103,79 +422,351 @@ func (g *Guardian) ConfirmTransaction(ctx context.Context, transactionId *big.In
 
 func (g *Guardian) handleValidationRequested(ctx context.Context) {
 	defer g.wg.Done()
+	// batch are the requests of the current burst, processed at once when flush fires, if batching is enabled
+	var batch []*bindings.SecurityCouncilValidationRequested
+	var flush <-chan time.Time
+	var flushTimer *time.Timer
 	for {
 		select {
 		case ev := <-g.validationRequestedChan:
-			g.wg.Add(1)
-			go g.processOutputValidation(ctx, ev)
+			if g.councilHealth != nil {
+				g.councilHealth.onValidationRequested(ctx, ev)
+			}
+			if g.cfg.GuardianBatchWindow > 0 {
+				batch = append(batch, ev)
+				if len(batch) == 1 {
+					flushTimer = time.NewTimer(g.cfg.GuardianBatchWindow)
+					flush = flushTimer.C
+				}
+				if g.cfg.GuardianMaxBatchSize > 0 && len(batch) >= g.cfg.GuardianMaxBatchSize {
+					flushTimer.Stop()
+					g.processBatch(ctx, batch)
+					batch, flush = nil, nil
+				}
+				continue
+			}
+			if !g.beginRequest(ev) {
+				continue
+			}
+			g.enqueueValidation(ctx, ev)
+		case <-flush:
+			g.processBatch(ctx, batch)
+			batch, flush = nil, nil
+		case ev := <-g.cfg.GuardianDeadLetters.retried():
+			g.log.Info("retrying validation request from the dead letter queue", "transactionId", ev.TransactionId)
+			if !g.beginRequest(ev) {
+				continue
+			}
+			g.enqueueValidation(ctx, ev)
 		case <-ctx.Done():
 			return
 		}
 	}
 }
 
+// processBatch validates the requests of a burst once per requested output, and queues their confirmations
+// together, see confirmBatch.
+func (g *Guardian) processBatch(ctx context.Context, batch []*bindings.SecurityCouncilValidationRequested) {
+	g.log.Debug("processing batch of validation requests", "count", len(batch))
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		g.confirmBatch(ctx, batch, func() {})
+	}()
+}
+
 func (g *Guardian) processOutputValidation(ctx context.Context, event *bindings.SecurityCouncilValidationRequested) {
-	ticker := time.NewTicker(10 * time.Second)
+	// delay is the time until the next validation attempt, the poll interval unless the attempt failed
+	delay := g.pollInterval
+	timer := time.NewTimer(delay)
+	// final is whether the request was decided to a final outcome, and sent whether its confirmation was queued,
+	// to be ended once its receipt is known
+	var final, sent bool
+	decide := func(outcome GuardianOutcome, localOutputRoot *eth.Bytes32) {
+		g.recordDecision(event, outcome, localOutputRoot)
+		final = outcome.Final()
+	}
 	defer func() {
-		ticker.Stop()
+		timer.Stop()
+		if !sent {
+			g.endRequest(event, final)
+		}
 		g.wg.Done()
 	}()
 
+	// outputs are only submitted at the checkpoints, any other request indicates a misuse of the contracts or an attack.
+	if !event.L2BlockNumber.IsUint64() || !g.checkpoints.isCheckpoint(event.L2BlockNumber.Uint64()) {
+		g.log.Error("rejecting validation request of an L2 block number that is not an output checkpoint",
+			"transactionId", event.TransactionId, "l2BlockNumber", event.L2BlockNumber, "outputRoot", event.OutputRoot,
+			"startingBlockNumber", g.checkpoints.startingBlockNumber, "submissionInterval", g.checkpoints.submissionInterval)
+		g.metr.RecordMisalignedValidationRequest()
+		decide(GuardianOutcomeMisaligned, nil)
+		return
+	}
+
+	l2BlockNumber := event.L2BlockNumber.Uint64()
+	wait := newBlockWait(g.cfg.GuardianBlockWaitTimeout, g.timeNow)
+	// confirmFailures is the number of failures to create the confirmation
+	var confirmFailures int
+	// feeCapAlerted is whether the confirmation skipped for exceeding the fee cap was alerted
+	var feeCapAlerted bool
+	// nodeLagAlerted is whether the confirmation refused for the lag of the rollup node was alerted
+	var nodeLagAlerted bool
+	// shadowed is whether the valid output was logged as waiting for the leader to confirm it
+	var shadowed bool
+	// failures is the number of failed validation attempts, retried with a backoff until the retry budget is exhausted
+	var failures int
+	// retry schedules the retry of a failed attempt, it returns false if the request was given up instead
+	retry := func(err error) bool {
+		failures++
+		if g.exhaustRetries(event, failures, err) {
+			return false
+		}
+		delay = g.retryStrategy.Duration(failures)
+		return true
+	}
+
 	for {
 	Loop:
 		select {
-		case <-ticker.C:
+		case <-timer.C:
+			delay = g.pollInterval
 			cCtx, cCancel := context.WithTimeout(ctx, g.cfg.NetworkTimeout)
 			callOpts := utils.NewCallOptsWithSender(cCtx, g.cfg.TxManager.From())
 			isConfirmed, err := g.securityCouncilContract.IsConfirmed(callOpts, event.TransactionId)
 			cCancel()
 			if err != nil {
 				g.log.Error("IsConfirmed failed", "err", err, "transactionId", event.TransactionId)
+				if !retry(err) {
+					return
+				}
 				break Loop
 			}
 
 			if isConfirmed {
 				g.log.Info(fmt.Sprintf("Skip validate L2Output. Current tx[%+v] status(confirmed) : (%+v)", event.TransactionId, isConfirmed))
+				decide(GuardianOutcomeAlreadyConfirmed, nil)
 				return
 			}
 
-			isValid, err := g.ValidateL2Output(ctx, event.OutputRoot, event.L2BlockNumber.Uint64())
-			if err != nil {
-				g.log.Error("validateL2Output failed", "err", err, "l2BlockNumber", event.L2BlockNumber.Uint64())
+			result := g.ValidateL2Output(ctx, event.OutputRoot, l2BlockNumber)
+			g.traces.validated(event.TransactionId, result)
+			g.metr.RecordOutputValidation(string(result.Reason), g.traces.exemplar(event.TransactionId, common.Hash{}))
+			switch result.Reason {
+			case ValidationReasonRPCError, ValidationReasonNoQuorum:
+				g.log.Error("failed to validate output", "reason", result.Reason, "err", result.Err,
+					"transactionId", event.TransactionId, "l2BlockNumber", l2BlockNumber, "attempts", failures+1)
+				if !retry(result.Err) {
+					return
+				}
+				break Loop
+			case ValidationReasonNodeBehind:
+				elapsed, timedOut := wait.elapsed()
+				if timedOut {
+					g.log.Error("timed out waiting for the requested L2 block to be derived", "reason", result.Reason,
+						"transactionId", event.TransactionId, "l2BlockNumber", l2BlockNumber,
+						"safeBlockNumber", result.SafeBlockNumber, "elapsed", elapsed)
+					decide(GuardianOutcomeTimedOut, nil)
+					return
+				}
+				g.log.Info("waiting for the requested L2 block to be derived",
+					"transactionId", event.TransactionId, "l2BlockNumber", l2BlockNumber,
+					"safeBlockNumber", result.SafeBlockNumber, "remainingBlocks", l2BlockNumber-result.SafeBlockNumber, "elapsed", elapsed)
 				break Loop
+			case ValidationReasonVersionUnknown:
+				g.log.Error("local output has an unknown output root version, the node may need to be upgraded", "reason", result.Reason,
+					"transactionId", event.TransactionId, "l2BlockNumber", l2BlockNumber)
+				decide(GuardianOutcomeVersionUnknown, &result.LocalOutputRoot)
+				return
+			case ValidationReasonMismatch:
+				g.log.Error("requested output does not match the local output", "reason", result.Reason,
+					"transactionId", event.TransactionId, "l2BlockNumber", l2BlockNumber,
+					"outputRoot", event.OutputRoot, "localOutputRoot", result.LocalOutputRoot)
+				outcome := GuardianOutcomeMismatch
+				alert := newGuardianAlert(GuardianAlertMismatch, event, result.LocalOutputRoot)
+				// only the leader of the guardians sharing the key contests the request, they all alert
+				if g.cfg.GuardianDissent && g.isLeader() && g.cfg.GuardianDryRun {
+					g.log.Info("dry run: would contest validation request of invalid output", "transactionId", event.TransactionId,
+						"l2BlockNumber", l2BlockNumber)
+				} else if g.cfg.GuardianDissent && g.isLeader() {
+					revoked, confirmations, err := g.dissent(ctx, event, result.LocalOutputRoot)
+					if err != nil {
+						g.log.Error("failed to contest validation request", "err", err, "transactionId", event.TransactionId)
+						if !retry(err) {
+							return
+						}
+						break Loop
+					}
+					if revoked {
+						outcome = GuardianOutcomeRevoked
+					}
+					alert.Dissent, alert.Revoked, alert.Confirmations = true, revoked, confirmations
+				}
+				g.alert(ctx, alert)
+				decide(outcome, &result.LocalOutputRoot)
+				return
+			}
+
+			if g.nodeLag != nil {
+				if exceeded, lag := g.nodeLag.Exceeded(); exceeded {
+					// the node may not have derived the batches changing the output yet, so it is not trusted until
+					// the lag recovers, without consuming the retry budget
+					g.log.Warn("not confirming validation request, the rollup node derivation lags behind the L1 head",
+						"transactionId", event.TransactionId, "l2BlockNumber", l2BlockNumber, "lag", lag,
+						"maxLag", g.cfg.GuardianMaxNodeLag)
+					if !nodeLagAlerted {
+						alert := newGuardianAlert(GuardianAlertNodeLagging, event, result.LocalOutputRoot)
+						alert.NodeLag, alert.MaxNodeLag = lag, g.cfg.GuardianMaxNodeLag
+						g.alert(ctx, alert)
+						nodeLagAlerted = true
+					}
+					break Loop
+				}
 			}
-			if isValid {
-				cCtx, cCancel := context.WithTimeout(ctx, g.cfg.NetworkTimeout)
-				tx, err := g.ConfirmTransaction(cCtx, event.TransactionId)
-				cCancel()
+
+			if g.cfg.GuardianDryRun {
+				g.log.Info("dry run: would confirm validation request of valid output", "transactionId", event.TransactionId,
+					"l2BlockNumber", l2BlockNumber, "outputRoot", event.OutputRoot)
+				decide(GuardianOutcomeDryRun, &result.LocalOutputRoot)
+				return
+			}
+
+			if g.leader != nil {
+				confirmed, err := g.confirmedByPeer(ctx, event.TransactionId)
 				if err != nil {
-					g.log.Error("tx call ConfirmTransaction failed", "err", err, "transactionId", event.TransactionId)
+					g.log.Error("failed to get confirmations", "err", err, "transactionId", event.TransactionId)
+					if !retry(err) {
+						return
+					}
 					break Loop
 				}
-				g.sendTransaction(tx)
+				if confirmed {
+					g.log.Info("validation request was already confirmed by the leader", "transactionId", event.TransactionId)
+					decide(GuardianOutcomeConfirmedByPeer, &result.LocalOutputRoot)
+					return
+				}
+				if !g.leader.IsLeader() {
+					if !shadowed {
+						g.log.Info("validated output in shadow mode, waiting for the leader to confirm it",
+							"transactionId", event.TransactionId, "l2BlockNumber", l2BlockNumber)
+						shadowed = true
+					}
+					break Loop
+				}
+			}
+
+			cCtx, cCancel = context.WithTimeout(ctx, g.cfg.NetworkTimeout)
+			tx, err := g.ConfirmTransaction(cCtx, event.TransactionId)
+			cCancel()
+			if err != nil {
+				g.log.Error("tx call ConfirmTransaction failed", "err", err, "transactionId", event.TransactionId)
+				confirmFailures++
+				if confirmFailures == guardianConfirmFailureAlerts {
+					alert := newGuardianAlert(GuardianAlertConfirmFailed, event, result.LocalOutputRoot)
+					alert.Failures, alert.Error = confirmFailures, err.Error()
+					g.alert(ctx, alert)
+				}
+				if !retry(err) {
+					return
+				}
+				break Loop
+			}
+			estimate, err := g.estimateConfirmation(ctx, tx)
+			if errors.Is(err, ErrGuardianFeeCapExceeded) {
+				// skipped until the fees drop, without consuming the retry budget
+				g.log.Warn("skipping confirmation during L1 fee spike", "err", err, "transactionId", event.TransactionId)
+				if !feeCapAlerted {
+					alert := newGuardianAlert(GuardianAlertFeeCapExceeded, event, result.LocalOutputRoot)
+					alert.Fee, alert.MaxFee, alert.Error = estimate.fee, new(big.Int).SetUint64(g.cfg.GuardianMaxTxFee), err.Error()
+					g.alert(ctx, alert)
+					feeCapAlerted = true
+				}
+				break Loop
+			} else if err != nil {
+				g.log.Error("failed to estimate confirmation", "err", err, "transactionId", event.TransactionId)
+				if !retry(err) {
+					return
+				}
+				break Loop
 			}
+			g.sendConfirmation(event, tx, estimate.gasLimit, &result.LocalOutputRoot)
+			sent = true
 			return
 		case <-ctx.Done():
 			return
 		}
+		timer.Reset(delay)
 	}
 }
 
-func (g *Guardian) outputRootAtBlock(ctx context.Context, blockNumber uint64) (eth.Bytes32, error) {
-	cCtx, cCancel := context.WithTimeout(ctx, g.cfg.NetworkTimeout)
-	defer cCancel()
-	output, err := g.cfg.RollupClient.OutputAtBlock(cCtx, blockNumber)
-	if err != nil {
-		return eth.Bytes32{}, err
-	}
-	return output.OutputRoot, nil
-}
-
-func (g *Guardian) sendTransaction(tx *types.Transaction) {
+// sendTransaction queues the transaction to be sent. The gas limit is estimated by the tx manager if 0.
+func (g *Guardian) sendTransaction(tx *types.Transaction, gasLimit uint64) {
 	g.txCandidatesChan <- txmgr.TxCandidate{
 		TxData:     tx.Data(),
 		To:         tx.To(),
-		GasLimit:   0,
+		GasLimit:   gasLimit,
 		AccessList: nil,
 	}
 }
+
+// queuedConfirmation is a confirmation queued to be sent, awaiting its receipt.
+type queuedConfirmation struct {
+	event           *bindings.SecurityCouncilValidationRequested
+	localOutputRoot *eth.Bytes32
+}
+
+// sendConfirmation queues the confirmation of the request to be sent, and records the request as queued until the
+// receipt of the confirmation is known, see TxSent.
+func (g *Guardian) sendConfirmation(event *bindings.SecurityCouncilValidationRequested, tx *types.Transaction, gasLimit uint64, localOutputRoot *eth.Bytes32) {
+	id := guardianConfirmationID(event.TransactionId)
+	g.queuedMu.Lock()
+	g.queued[id] = queuedConfirmation{event: event, localOutputRoot: localOutputRoot}
+	g.queuedMu.Unlock()
+	g.recordDecision(event, GuardianOutcomeQueued, localOutputRoot)
+	candidate := txmgr.TxCandidate{
+		TxData:   tx.Data(),
+		To:       tx.To(),
+		GasLimit: gasLimit,
+		ID:       id,
+	}
+	if g.cfg.GuardianMaxTxFee != 0 {
+		// the fees are not bumped above the fee cap while the confirmation is pending
+		candidate.MaxTxFee = new(big.Int).SetUint64(g.cfg.GuardianMaxTxFee)
+	}
+	g.txCandidatesChan <- candidate
+}
+
+// guardianConfirmationID is the transaction candidate id of the confirmation of the request.
+func guardianConfirmationID(transactionId *big.Int) string {
+	return "guardian-confirmation-" + transactionId.String()
+}
+
+// TxSent records the outcome of a queued confirmation from its receipt: confirmed if it was mined successfully,
+// failed if it reverted or could not be sent, so that the request is evaluated again. A confirmation abandoned as
+// the guardian stops is kept queued, to be checked by the recovery audit.
+func (g *Guardian) TxSent(candidate txmgr.TxCandidate, receipt *types.Receipt, err error) {
+	g.queuedMu.Lock()
+	confirmation, ok := g.queued[candidate.ID]
+	delete(g.queued, candidate.ID)
+	g.queuedMu.Unlock()
+	if !ok {
+		return
+	}
+	event := confirmation.event
+	switch {
+	case errors.Is(err, context.Canceled):
+		g.log.Warn("confirmation was abandoned, it may still be mined", "transactionId", event.TransactionId)
+		g.endRequest(event, false)
+	case err != nil:
+		g.log.Error("failed to send confirmation", "err", err, "transactionId", event.TransactionId)
+		g.recordDecision(event, GuardianOutcomeFailed, confirmation.localOutputRoot)
+		g.endRequest(event, false)
+	case receipt.Status != types.ReceiptStatusSuccessful:
+		g.log.Error("confirmation reverted", "tx_hash", receipt.TxHash, "transactionId", event.TransactionId)
+		g.recordDecision(event, GuardianOutcomeFailed, confirmation.localOutputRoot)
+		g.endRequest(event, false)
+	default:
+		g.recordDecision(event, GuardianOutcomeConfirmed, confirmation.localOutputRoot)
+		g.endRequest(event, true)
+	}
+}
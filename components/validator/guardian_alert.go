@@ -0,0 +1,183 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net/http"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/kroma-network/kroma/bindings/bindings"
+	"github.com/kroma-network/kroma/components/node/eth"
+	"github.com/kroma-network/kroma/utils/service/webhook"
+)
+
+// guardianConfirmFailureAlerts is the number of failures to create the confirmation of a request
+// after which it is alerted.
+const guardianConfirmFailureAlerts = 3
+
+// pagerDutyEventsURL is the endpoint of the PagerDuty Events API v2.
+const pagerDutyEventsURL = "https://events.pagerduty.com/v2/enqueue"
+
+// GuardianAlertKind is the kind of a GuardianAlert.
+type GuardianAlertKind string
+
+const (
+	// GuardianAlertMismatch is a request of an output root differing from the local output root.
+	GuardianAlertMismatch GuardianAlertKind = "mismatch"
+	// GuardianAlertConfirmFailed is a valid request whose confirmation repeatedly failed to be created.
+	GuardianAlertConfirmFailed GuardianAlertKind = "confirm-failed"
+	// GuardianAlertFeeCapExceeded is a valid request whose confirmation is skipped, as its fee exceeds the fee cap.
+	GuardianAlertFeeCapExceeded GuardianAlertKind = "fee-cap-exceeded"
+	// GuardianAlertNodeLagging is a valid request whose confirmation is refused, as the derivation of the rollup node
+	// lags behind the L1 head beyond the max lag.
+	GuardianAlertNodeLagging GuardianAlertKind = "node-lagging"
+)
+
+// GuardianAlert is the structured alert of a validation request delivered to the AlertSinks.
+type GuardianAlert struct {
+	Kind          GuardianAlertKind `json:"kind"`
+	TransactionId *big.Int          `json:"transactionId"`
+	L1Block       uint64            `json:"l1Block"`
+	L2BlockNumber *big.Int          `json:"l2BlockNumber"`
+	OutputRoot    eth.Bytes32       `json:"outputRoot"`
+	// LocalOutputRoot is the output root of the local node.
+	LocalOutputRoot eth.Bytes32 `json:"localOutputRoot"`
+	// Dissent is whether the request was contested, set if the guardian dissent is enabled.
+	Dissent bool `json:"dissent,omitempty"`
+	// Revoked is whether the confirmation of the guardian was revoked by the dissent.
+	Revoked bool `json:"revoked,omitempty"`
+	// Confirmations are the SecurityCouncil members that confirmed the request, set by the dissent.
+	Confirmations []common.Address `json:"confirmations,omitempty"`
+	// Failures is the number of failures to create the confirmation.
+	Failures int `json:"failures,omitempty"`
+	// Error is the last error creating the confirmation.
+	Error string `json:"error,omitempty"`
+	// Fee is the estimated fee of the confirmation, and MaxFee the fee cap it exceeds.
+	Fee    *big.Int `json:"fee,omitempty"`
+	MaxFee *big.Int `json:"maxFee,omitempty"`
+	// NodeLag is the number of L1 blocks the derivation of the rollup node lags behind the L1 head, and MaxNodeLag the
+	// max lag it exceeds.
+	NodeLag    uint64 `json:"nodeLag,omitempty"`
+	MaxNodeLag uint64 `json:"maxNodeLag,omitempty"`
+}
+
+func newGuardianAlert(kind GuardianAlertKind, event *bindings.SecurityCouncilValidationRequested, localOutputRoot eth.Bytes32) GuardianAlert {
+	return GuardianAlert{
+		Kind:            kind,
+		TransactionId:   event.TransactionId,
+		L1Block:         event.Raw.BlockNumber,
+		L2BlockNumber:   event.L2BlockNumber,
+		OutputRoot:      event.OutputRoot,
+		LocalOutputRoot: localOutputRoot,
+	}
+}
+
+// Summary returns a one-line description of the alert.
+func (a GuardianAlert) Summary() string {
+	switch a.Kind {
+	case GuardianAlertMismatch:
+		return fmt.Sprintf("guardian: output root %s of validation request %s (L2 block %s) does not match the local output root %s",
+			a.OutputRoot, a.TransactionId, a.L2BlockNumber, a.LocalOutputRoot)
+	case GuardianAlertConfirmFailed:
+		return fmt.Sprintf("guardian: confirmation of validation request %s (L2 block %s) failed %d times: %s",
+			a.TransactionId, a.L2BlockNumber, a.Failures, a.Error)
+	case GuardianAlertFeeCapExceeded:
+		return fmt.Sprintf("guardian: confirmation of validation request %s (L2 block %s) is skipped, its fee of %s wei exceeds the cap of %s wei",
+			a.TransactionId, a.L2BlockNumber, a.Fee, a.MaxFee)
+	case GuardianAlertNodeLagging:
+		return fmt.Sprintf("guardian: confirmation of validation request %s (L2 block %s) is refused, the rollup node derivation lags %d L1 blocks behind the L1 head, more than %d",
+			a.TransactionId, a.L2BlockNumber, a.NodeLag, a.MaxNodeLag)
+	default:
+		return fmt.Sprintf("guardian: %s alert of validation request %s", a.Kind, a.TransactionId)
+	}
+}
+
+// AlertSink delivers the alerts of the guardian, e.g. to page the operator.
+type AlertSink interface {
+	Alert(ctx context.Context, alert GuardianAlert) error
+}
+
+// alert delivers the alert to all sinks. A failed delivery is only logged.
+func (g *Guardian) alert(ctx context.Context, alert GuardianAlert) {
+	for _, sink := range g.cfg.GuardianAlertSinks {
+		if err := sink.Alert(ctx, alert); err != nil {
+			g.log.Error("failed to deliver alert", "err", err, "kind", alert.Kind, "transactionId", alert.TransactionId)
+		}
+	}
+}
+
+// WebhookAlertSink posts every alert as JSON to a webhook, e.g. of an alerting service.
+type WebhookAlertSink struct {
+	notifier *webhook.Notifier
+}
+
+// NewWebhookAlertSink creates the sink posting with the client, the default client if nil.
+func NewWebhookAlertSink(url string, client *http.Client) *WebhookAlertSink {
+	return &WebhookAlertSink{notifier: webhook.NewNotifier(url, client)}
+}
+
+func (s *WebhookAlertSink) Alert(ctx context.Context, alert GuardianAlert) error {
+	return s.notifier.Notify(ctx, alert)
+}
+
+// PagerDutyAlertSink triggers an incident of the PagerDuty Events API v2 for every alert, deduplicated by the
+// kind and the transaction id of the alert.
+type PagerDutyAlertSink struct {
+	routingKey string
+	notifier   *webhook.Notifier
+}
+
+// NewPagerDutyAlertSink creates the sink posting with the client, the default client if nil.
+func NewPagerDutyAlertSink(routingKey string, client *http.Client) *PagerDutyAlertSink {
+	return &PagerDutyAlertSink{routingKey: routingKey, notifier: webhook.NewNotifier(pagerDutyEventsURL, client)}
+}
+
+type pagerDutyEvent struct {
+	RoutingKey  string           `json:"routing_key"`
+	EventAction string           `json:"event_action"`
+	DedupKey    string           `json:"dedup_key"`
+	Payload     pagerDutyPayload `json:"payload"`
+}
+
+type pagerDutyPayload struct {
+	Summary       string        `json:"summary"`
+	Source        string        `json:"source"`
+	Severity      string        `json:"severity"`
+	CustomDetails GuardianAlert `json:"custom_details"`
+}
+
+func (s *PagerDutyAlertSink) Alert(ctx context.Context, alert GuardianAlert) error {
+	severity := "critical"
+	if alert.Kind == GuardianAlertConfirmFailed {
+		severity = "error"
+	}
+	return s.notifier.Notify(ctx, pagerDutyEvent{
+		RoutingKey:  s.routingKey,
+		EventAction: "trigger",
+		DedupKey:    fmt.Sprintf("kroma-guardian-%s-%s", alert.Kind, alert.TransactionId),
+		Payload: pagerDutyPayload{
+			Summary:       alert.Summary(),
+			Source:        "kroma-validator",
+			Severity:      severity,
+			CustomDetails: alert,
+		},
+	})
+}
+
+// SlackAlertSink posts the summary of every alert to a Slack incoming webhook.
+type SlackAlertSink struct {
+	notifier *webhook.Notifier
+}
+
+// NewSlackAlertSink creates the sink posting with the client, the default client if nil.
+func NewSlackAlertSink(url string, client *http.Client) *SlackAlertSink {
+	return &SlackAlertSink{notifier: webhook.NewNotifier(url, client)}
+}
+
+func (s *SlackAlertSink) Alert(ctx context.Context, alert GuardianAlert) error {
+	return s.notifier.Notify(ctx, struct {
+		Text string `json:"text"`
+	}{Text: alert.Summary()})
+}
@@ -0,0 +1,81 @@
+package validator
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kroma-network/kroma/components/node/eth"
+	"github.com/kroma-network/kroma/utils/service/webhook"
+)
+
+// newAlertServer serves an endpoint decoding the posted alerts into v, responding with status 500 if fail is set.
+func newAlertServer(t *testing.T, v any, fail *bool) *httptest.Server {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(v))
+		if *fail {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestAlertSinks(t *testing.T) {
+	alert := GuardianAlert{
+		Kind:            GuardianAlertMismatch,
+		TransactionId:   big.NewInt(7),
+		L2BlockNumber:   big.NewInt(100),
+		OutputRoot:      eth.Bytes32{0xbb},
+		LocalOutputRoot: eth.Bytes32{0xaa},
+	}
+
+	t.Run("webhook", func(t *testing.T) {
+		var received GuardianAlert
+		var fail bool
+		sink := NewWebhookAlertSink(newAlertServer(t, &received, &fail).URL, nil)
+		require.NoError(t, sink.Alert(context.Background(), alert))
+		require.Equal(t, alert, received)
+
+		fail = true
+		require.ErrorContains(t, sink.Alert(context.Background(), alert), "status 500")
+	})
+
+	t.Run("pagerduty", func(t *testing.T) {
+		var received pagerDutyEvent
+		var fail bool
+		sink := NewPagerDutyAlertSink("routing-key", nil)
+		sink.notifier = webhook.NewNotifier(newAlertServer(t, &received, &fail).URL, nil)
+		require.NoError(t, sink.Alert(context.Background(), alert))
+		require.Equal(t, "routing-key", received.RoutingKey)
+		require.Equal(t, "trigger", received.EventAction)
+		require.Equal(t, "kroma-guardian-mismatch-7", received.DedupKey)
+		require.Equal(t, "critical", received.Payload.Severity)
+		require.Equal(t, alert.Summary(), received.Payload.Summary)
+		require.Equal(t, alert, received.Payload.CustomDetails)
+
+		fail = true
+		require.ErrorContains(t, sink.Alert(context.Background(), alert), "status 500")
+	})
+
+	t.Run("slack", func(t *testing.T) {
+		var received struct {
+			Text string `json:"text"`
+		}
+		var fail bool
+		sink := NewSlackAlertSink(newAlertServer(t, &received, &fail).URL, nil)
+		require.NoError(t, sink.Alert(context.Background(), alert))
+		require.Equal(t, alert.Summary(), received.Text)
+		require.Contains(t, received.Text, alert.OutputRoot.String())
+
+		fail = true
+		require.ErrorContains(t, sink.Alert(context.Background(), alert), "status 500")
+	})
+}
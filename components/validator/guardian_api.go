@@ -0,0 +1,211 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/kroma-network/kroma/bindings/bindings"
+	"github.com/kroma-network/kroma/components/node/eth"
+)
+
+var (
+	// ErrGuardianNotRunning is returned by the GuardianAPI when the guardian is not enabled or not started.
+	ErrGuardianNotRunning = errors.New("guardian is not running")
+	// ErrNoGuardianStore is returned by the GuardianAPI when the decisions are not recorded.
+	ErrNoGuardianStore = errors.New("guardian decisions are not recorded, no guardian store is configured")
+	// ErrRequestInFlight is returned when revalidating a request that is being validated.
+	ErrRequestInFlight = errors.New("validation request is being validated")
+	// ErrUnknownRequest is returned when revalidating a request that was not emitted by the SecurityCouncil.
+	ErrUnknownRequest = errors.New("unknown validation request")
+)
+
+// GuardianPendingRequest is a validation request being processed by the guardian.
+type GuardianPendingRequest struct {
+	TransactionId *big.Int    `json:"transactionId"`
+	L1Block       uint64      `json:"l1Block"`
+	L2BlockNumber *big.Int    `json:"l2BlockNumber"`
+	OutputRoot    eth.Bytes32 `json:"outputRoot"`
+	// Queued is whether the request waits for a validation worker.
+	Queued bool `json:"queued"`
+}
+
+// pendingRequests returns the requests being processed, in the order of their transaction ids.
+func (g *Guardian) pendingRequests() []GuardianPendingRequest {
+	queued := g.validations.queued()
+	g.inFlightMu.Lock()
+	defer g.inFlightMu.Unlock()
+	requests := make([]GuardianPendingRequest, 0, len(g.inFlight))
+	for id, event := range g.inFlight {
+		requests = append(requests, GuardianPendingRequest{
+			TransactionId: event.TransactionId,
+			L1Block:       event.Raw.BlockNumber,
+			L2BlockNumber: event.L2BlockNumber,
+			OutputRoot:    event.OutputRoot,
+			Queued:        queued[id],
+		})
+	}
+	sort.Slice(requests, func(i, j int) bool { return requests[i].TransactionId.Cmp(requests[j].TransactionId) < 0 })
+	return requests
+}
+
+// revalidate validates the request of the transaction id again, even if it was decided already.
+func (g *Guardian) revalidate(ctx context.Context, transactionId *big.Int) error {
+	runCtx := g.runningContext()
+	if !g.cfg.GuardianEnabled || runCtx == nil || runCtx.Err() != nil {
+		return ErrGuardianNotRunning
+	}
+	g.inFlightMu.Lock()
+	_, inFlight := g.inFlight[transactionId.String()]
+	g.inFlightMu.Unlock()
+	if inFlight {
+		return fmt.Errorf("%w: %s", ErrRequestInFlight, transactionId)
+	}
+	event, err := g.validationRequest(ctx, transactionId)
+	if err != nil {
+		return err
+	}
+	if !g.trackRequest(event) {
+		return fmt.Errorf("%w: %s", ErrRequestInFlight, transactionId)
+	}
+	g.log.Info("revalidating validation request", "transactionId", transactionId, "l2BlockNumber", event.L2BlockNumber)
+	g.enqueueValidation(runCtx, event)
+	return nil
+}
+
+// validationRequest fetches the ValidationRequested event of the transaction id, in the L1 block of its recorded
+// decision if any.
+func (g *Guardian) validationRequest(ctx context.Context, transactionId *big.Int) (*bindings.SecurityCouncilValidationRequested, error) {
+	opts := &bind.FilterOpts{Context: ctx}
+	if g.store != nil {
+		decision, err := g.store.Decision(ctx, transactionId)
+		if err != nil {
+			return nil, err
+		}
+		if decision != nil {
+			opts.Start, opts.End = decision.L1Block, &decision.L1Block
+		}
+	}
+	cCtx, cCancel := context.WithTimeout(ctx, g.cfg.NetworkTimeout)
+	defer cCancel()
+	opts.Context = cCtx
+	iter, err := g.securityCouncilContract.FilterValidationRequested(opts, []*big.Int{transactionId})
+	if err != nil {
+		return nil, fmt.Errorf("failed to filter validation requests: %w", err)
+	}
+	defer iter.Close()
+	if !iter.Next() {
+		if err := iter.Error(); err != nil {
+			return nil, fmt.Errorf("failed to filter validation requests: %w", err)
+		}
+		return nil, fmt.Errorf("%w: %s", ErrUnknownRequest, transactionId)
+	}
+	return iter.Event, nil
+}
+
+// GuardianAPI is the RPC API of the status of the guardian.
+type GuardianAPI struct {
+	guardian *Guardian
+}
+
+func NewGuardianAPI(g *Guardian) *GuardianAPI {
+	return &GuardianAPI{guardian: g}
+}
+
+// PendingRequests returns the validation requests being processed, queued or being validated.
+func (a *GuardianAPI) PendingRequests(_ context.Context) []GuardianPendingRequest {
+	return a.guardian.pendingRequests()
+}
+
+// LastProcessed returns the transaction id of the validation request processed last, null if none was processed.
+func (a *GuardianAPI) LastProcessed(_ context.Context) *hexutil.Big {
+	a.guardian.inFlightMu.Lock()
+	defer a.guardian.inFlightMu.Unlock()
+	return (*hexutil.Big)(a.guardian.lastProcessed)
+}
+
+// Decisions returns the recorded decisions, of the outcome if not empty.
+func (a *GuardianAPI) Decisions(ctx context.Context, outcome GuardianOutcome) ([]*GuardianDecision, error) {
+	if a.guardian.store == nil {
+		return nil, ErrNoGuardianStore
+	}
+	decisions, err := a.guardian.store.Decisions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	filtered := make([]*GuardianDecision, 0, len(decisions))
+	for _, decision := range decisions {
+		if outcome == "" || decision.Outcome == outcome {
+			filtered = append(filtered, decision)
+		}
+	}
+	return filtered, nil
+}
+
+// Decision returns the recorded decision of the transaction id, null if it was not decided.
+func (a *GuardianAPI) Decision(ctx context.Context, transactionId *hexutil.Big) (*GuardianDecision, error) {
+	if transactionId == nil {
+		return nil, errors.New("missing transaction id")
+	}
+	if a.guardian.store == nil {
+		return nil, ErrNoGuardianStore
+	}
+	return a.guardian.store.Decision(ctx, transactionId.ToInt())
+}
+
+// DeadLetters returns the validation requests that exhausted their retry budget.
+func (a *GuardianAPI) DeadLetters(_ context.Context) []GuardianDeadLetter {
+	letters := a.guardian.cfg.GuardianDeadLetters.List()
+	if letters == nil {
+		return []GuardianDeadLetter{}
+	}
+	return letters
+}
+
+// GuardianAdminAPI is the RPC API of the guardian changing its processing of the validation requests, only served
+// with the admin API enabled.
+type GuardianAdminAPI struct {
+	guardian *Guardian
+}
+
+func NewGuardianAdminAPI(g *Guardian) *GuardianAdminAPI {
+	return &GuardianAdminAPI{guardian: g}
+}
+
+// Revalidate validates the request of the transaction id again, even if it was decided already.
+func (a *GuardianAdminAPI) Revalidate(ctx context.Context, transactionId *hexutil.Big) error {
+	if transactionId == nil {
+		return errors.New("missing transaction id")
+	}
+	return a.guardian.revalidate(ctx, transactionId.ToInt())
+}
+
+// RetryDeadLetter validates the dead letter of the transaction id again, with a new retry budget.
+func (a *GuardianAdminAPI) RetryDeadLetter(_ context.Context, transactionId *hexutil.Big) error {
+	if transactionId == nil {
+		return errors.New("missing transaction id")
+	}
+	return a.guardian.cfg.GuardianDeadLetters.Retry(transactionId.ToInt())
+}
+
+// GuardianAPIs returns the RPC APIs of the guardian namespace, serving e.g. guardian_pendingRequests, and
+// guardian_revalidate and guardian_retryDeadLetter if the admin API is enabled.
+func GuardianAPIs(g *Guardian, enableAdmin bool) []rpc.API {
+	apis := []rpc.API{{
+		Namespace: "guardian",
+		Service:   NewGuardianAPI(g),
+	}}
+	if enableAdmin {
+		apis = append(apis, rpc.API{
+			Namespace: "guardian",
+			Service:   NewGuardianAdminAPI(g),
+		})
+	}
+	return apis
+}
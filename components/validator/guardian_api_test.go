@@ -0,0 +1,161 @@
+package validator
+
+import (
+	"context"
+	"math/big"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kroma-network/kroma/bindings/bindings"
+	"github.com/kroma-network/kroma/components/node/eth"
+)
+
+func TestGuardianAPI(t *testing.T) {
+	const l2BlockNumber = 100
+	localOutputRoot := eth.Bytes32{0xaa}
+	ctx := context.Background()
+
+	rollupClient := &fakeRollupClient{outputRoot: localOutputRoot, blockNumber: l2BlockNumber}
+	council := &fakeSecurityCouncil{requests: []types.Log{
+		validationRequestedLog(t, 3000, 7, localOutputRoot, l2BlockNumber),
+		validationRequestedLog(t, 3001, 8, localOutputRoot, l2BlockNumber),
+	}}
+	g, candidates := newTestGuardian(t, rollupClient, council)
+	store, err := NewGuardianStore(filepath.Join(t.TempDir(), "store"))
+	require.NoError(t, err)
+	defer store.Close()
+	g.store = store
+	api := NewGuardianAPI(g)
+	adminAPI := NewGuardianAdminAPI(g)
+
+	require.ErrorIs(t, adminAPI.Revalidate(ctx, (*hexutil.Big)(big.NewInt(7))), ErrGuardianNotRunning)
+	g.cfg.GuardianEnabled = true
+	g.ctx = ctx
+
+	require.Empty(t, api.PendingRequests(ctx))
+	require.Nil(t, api.LastProcessed(ctx))
+	decisions, err := api.Decisions(ctx, "")
+	require.NoError(t, err)
+	require.Empty(t, decisions)
+
+	// a request in flight cannot be revalidated
+	pending := &bindings.SecurityCouncilValidationRequested{
+		TransactionId: big.NewInt(8),
+		OutputRoot:    localOutputRoot,
+		L2BlockNumber: big.NewInt(l2BlockNumber),
+		Raw:           types.Log{BlockNumber: 3001},
+	}
+	require.True(t, g.trackRequest(pending))
+	require.ErrorIs(t, adminAPI.Revalidate(ctx, (*hexutil.Big)(big.NewInt(8))), ErrRequestInFlight)
+	require.Equal(t, []GuardianPendingRequest{{
+		TransactionId: big.NewInt(8),
+		L1Block:       3001,
+		L2BlockNumber: big.NewInt(l2BlockNumber),
+		OutputRoot:    localOutputRoot,
+	}}, api.PendingRequests(ctx))
+	g.endRequest(pending, true)
+	require.Equal(t, (*hexutil.Big)(big.NewInt(8)), api.LastProcessed(ctx))
+
+	require.ErrorIs(t, adminAPI.Revalidate(ctx, (*hexutil.Big)(big.NewInt(9))), ErrUnknownRequest)
+
+	// a decided request is validated again
+	require.NoError(t, store.RecordDecision(ctx, &GuardianDecision{
+		TransactionId: big.NewInt(7),
+		L1Block:       3000,
+		L2BlockNumber: big.NewInt(l2BlockNumber),
+		OutputRoot:    localOutputRoot,
+		Outcome:       GuardianOutcomeTimedOut,
+	}))
+	require.NoError(t, adminAPI.Revalidate(ctx, (*hexutil.Big)(big.NewInt(7))))
+	select {
+	case candidate := <-candidates:
+		g.wg.Wait()
+		g.TxSent(candidate, &types.Receipt{Status: types.ReceiptStatusSuccessful}, nil)
+	case <-time.After(5 * time.Second):
+		t.Fatal("request was not revalidated")
+	}
+
+	require.Empty(t, api.PendingRequests(ctx))
+	require.Equal(t, (*hexutil.Big)(big.NewInt(7)), api.LastProcessed(ctx))
+	decision, err := api.Decision(ctx, (*hexutil.Big)(big.NewInt(7)))
+	require.NoError(t, err)
+	require.Equal(t, GuardianOutcomeConfirmed, decision.Outcome)
+	require.Equal(t, uint64(3000), decision.L1Block)
+	confirmed, err := api.Decisions(ctx, GuardianOutcomeConfirmed)
+	require.NoError(t, err)
+	require.Len(t, confirmed, 1)
+	timedOut, err := api.Decisions(ctx, GuardianOutcomeTimedOut)
+	require.NoError(t, err)
+	require.Empty(t, timedOut)
+}
+
+// TestGuardianAPIRevalidateWhileStarting tests that a revalidation requested while the guardian starts waits for
+// it to run, without racing on its context.
+func TestGuardianAPIRevalidateWhileStarting(t *testing.T) {
+	const l2BlockNumber = 100
+	localOutputRoot := eth.Bytes32{0xaa}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	rollupClient := &fakeRollupClient{outputRoot: localOutputRoot, blockNumber: l2BlockNumber}
+	council := &fakeSecurityCouncil{requests: []types.Log{validationRequestedLog(t, 3000, 7, localOutputRoot, l2BlockNumber)}}
+	g, candidates := newTestGuardian(t, rollupClient, council)
+	g.cfg.GuardianEnabled = true
+	adminAPI := NewGuardianAdminAPI(g)
+
+	go g.startContext(ctx)
+	for {
+		err := adminAPI.Revalidate(ctx, (*hexutil.Big)(big.NewInt(7)))
+		if err == nil {
+			break
+		}
+		require.ErrorIs(t, err, ErrGuardianNotRunning)
+		require.NoError(t, ctx.Err())
+	}
+	select {
+	case <-candidates:
+		g.wg.Wait()
+	case <-ctx.Done():
+		t.Fatal("request was not revalidated")
+	}
+}
+
+func TestGuardianAPIsAdmin(t *testing.T) {
+	for _, enableAdmin := range []bool{false, true} {
+		srv := rpc.NewServer()
+		for _, api := range GuardianAPIs(&Guardian{}, enableAdmin) {
+			require.NoError(t, srv.RegisterName(api.Namespace, api.Service))
+		}
+		client := rpc.DialInProc(srv)
+
+		var lastProcessed *hexutil.Big
+		require.NoError(t, client.Call(&lastProcessed, "guardian_lastProcessed"))
+		err := client.Call(nil, "guardian_revalidate", (*hexutil.Big)(big.NewInt(7)))
+		if enableAdmin {
+			require.ErrorContains(t, err, ErrGuardianNotRunning.Error())
+		} else {
+			requireMethodNotFound(t, err)
+		}
+		err = client.Call(nil, "guardian_retryDeadLetter", (*hexutil.Big)(big.NewInt(7)))
+		if enableAdmin {
+			require.ErrorContains(t, err, ErrUnknownDeadLetter.Error())
+		} else {
+			requireMethodNotFound(t, err)
+		}
+		client.Close()
+		srv.Stop()
+	}
+}
+
+// requireMethodNotFound requires the error to be the JSON-RPC error of a method that is not served.
+func requireMethodNotFound(t *testing.T, err error) {
+	var rpcErr rpc.Error
+	require.ErrorAs(t, err, &rpcErr)
+	require.Equal(t, -32601, rpcErr.ErrorCode())
+}
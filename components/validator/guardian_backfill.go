@@ -0,0 +1,403 @@
+package validator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/kroma-network/kroma/bindings/bindings"
+	"github.com/kroma-network/kroma/components/node/eth"
+	"github.com/kroma-network/kroma/utils"
+)
+
+// guardianBackfillChunk is the number of L1 blocks of a single ValidationRequested filter call of the backfill.
+const guardianBackfillChunk = 2000
+
+// GuardianL1Client is the set of L1 methods that the Guardian uses to backfill the validation requests.
+type GuardianL1Client interface {
+	BlockNumber(ctx context.Context) (uint64, error)
+}
+
+// guardianState is the persisted state of the guardian.
+type guardianState struct {
+	// L1Block is the L1 block up to which all validation requests were processed.
+	L1Block uint64 `json:"l1Block"`
+}
+
+// guardianProgress tracks the L1 block up to which all validation requests were processed, and persists it to a file.
+// A request is processed once it is decided to a final outcome, see GuardianOutcome.Final. The persisted block never
+// passes a request that was not processed, so the requests still pending on shutdown, given up on or whose confirmation
+// failed are backfilled again on restart.
+type guardianProgress struct {
+	mu   sync.Mutex
+	log  log.Logger
+	path string
+
+	// loaded is whether the state was loaded from the file, false on the first start.
+	loaded bool
+	// scanned is the L1 block up to which all requests were received.
+	scanned uint64
+	// live is whether the backfill completed, after which the requests of the subscription advance the scanned block.
+	live bool
+	// pending is the number of pending requests by L1 block.
+	pending map[uint64]int
+	saved   uint64
+}
+
+// loadGuardianProgress loads the progress from the state file, which does not exist on the first start.
+func loadGuardianProgress(l log.Logger, path string) (*guardianProgress, error) {
+	p := &guardianProgress{log: l, path: path, pending: make(map[uint64]int)}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return p, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read guardian state file: %w", err)
+	}
+	var state guardianState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to decode guardian state file %s: %w", path, err)
+	}
+	p.loaded = true
+	p.scanned = state.L1Block
+	p.saved = state.L1Block
+	return p, nil
+}
+
+// backfillStart returns the first L1 block to backfill, at most maxBlocks before the head.
+func (p *guardianProgress) backfillStart(head uint64, maxBlocks uint64) uint64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var start uint64
+	if head+1 > maxBlocks {
+		start = head + 1 - maxBlocks
+	}
+	if p.loaded && p.scanned+1 > start {
+		start = p.scanned + 1
+	}
+	return start
+}
+
+// begin records a received request emitted in the L1 block.
+func (p *guardianProgress) begin(l1Block uint64) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.pending[l1Block]++
+	if p.live && l1Block > p.scanned {
+		p.scanned = l1Block
+	}
+}
+
+// done records a processed request emitted in the L1 block.
+func (p *guardianProgress) done(l1Block uint64) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.pending[l1Block]--; p.pending[l1Block] <= 0 {
+		delete(p.pending, l1Block)
+	}
+	p.save()
+}
+
+// backfilled records that all requests up to the L1 block were received by the backfill.
+func (p *guardianProgress) backfilled(l1Block uint64) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if l1Block > p.scanned {
+		p.scanned = l1Block
+	}
+	p.live = true
+	p.save()
+}
+
+// processed returns the L1 block up to which all requests were processed.
+func (p *guardianProgress) processed() uint64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.processedLocked()
+}
+
+func (p *guardianProgress) processedLocked() uint64 {
+	processed := p.scanned
+	for l1Block := range p.pending {
+		if l1Block == 0 {
+			return 0
+		}
+		if l1Block <= processed {
+			processed = l1Block - 1
+		}
+	}
+	return processed
+}
+
+// save persists the processed L1 block if it advanced, replacing the state file atomically.
+func (p *guardianProgress) save() {
+	processed := p.processedLocked()
+	if processed <= p.saved {
+		return
+	}
+	data, err := json.Marshal(guardianState{L1Block: processed})
+	if err != nil {
+		p.log.Error("failed to encode guardian state", "err", err)
+		return
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(p.path), filepath.Base(p.path)+".tmp")
+	if err != nil {
+		p.log.Error("failed to create guardian state file", "err", err)
+		return
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		p.log.Error("failed to write guardian state file", "err", err)
+		return
+	}
+	if err := tmp.Close(); err != nil {
+		p.log.Error("failed to write guardian state file", "err", err)
+		return
+	}
+	if err := os.Rename(tmp.Name(), p.path); err != nil {
+		p.log.Error("failed to replace guardian state file", "err", err)
+		return
+	}
+	p.saved = processed
+}
+
+// beginRequest records the request as in flight, and returns false if it already is,
+// e.g. if it was both backfilled and received from the subscription, or if it was decided before a restart.
+func (g *Guardian) beginRequest(event *bindings.SecurityCouncilValidationRequested) bool {
+	if g.decided(event) {
+		return false
+	}
+	return g.trackRequest(event)
+}
+
+// trackRequest records the request as in flight, and returns false if it already is.
+func (g *Guardian) trackRequest(event *bindings.SecurityCouncilValidationRequested) bool {
+	g.inFlightMu.Lock()
+	defer g.inFlightMu.Unlock()
+	id := event.TransactionId.String()
+	if _, ok := g.inFlight[id]; ok {
+		return false
+	}
+	g.inFlight[id] = event
+	// an unprocessed request holds the progress already
+	if _, ok := g.unprocessed[id]; ok {
+		delete(g.unprocessed, id)
+	} else {
+		g.progress.begin(event.Raw.BlockNumber)
+	}
+	g.traces.begin(event)
+	return true
+}
+
+// endRequest records the request as no longer in flight, and as processed if it was decided to a final outcome.
+// The progress does not pass a request that was not processed, e.g. abandoned as the guardian stops, given up on,
+// or whose confirmation failed, so that it is backfilled again on restart.
+func (g *Guardian) endRequest(event *bindings.SecurityCouncilValidationRequested, processed bool) {
+	g.inFlightMu.Lock()
+	defer g.inFlightMu.Unlock()
+	id := event.TransactionId.String()
+	tracked, ok := g.inFlight[id]
+	if !ok {
+		return
+	}
+	delete(g.inFlight, id)
+	g.traces.end(event.TransactionId)
+	if !processed {
+		g.unprocessed[id] = struct{}{}
+		return
+	}
+	g.lastProcessed = event.TransactionId
+	g.progress.done(tracked.Raw.BlockNumber)
+}
+
+// backfill processes the unconfirmed validation requests emitted since the last processed L1 block,
+// retrying until it succeeds.
+func (g *Guardian) backfill(ctx context.Context) {
+	defer g.wg.Done()
+	for {
+		err := g.backfillOnce(ctx)
+		if err == nil {
+			return
+		}
+		g.log.Error("failed to backfill validation requests", "err", err)
+		select {
+		case <-time.After(g.pollInterval):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (g *Guardian) backfillOnce(ctx context.Context) error {
+	cCtx, cCancel := context.WithTimeout(ctx, g.cfg.NetworkTimeout)
+	head, err := g.l1Client.BlockNumber(cCtx)
+	cCancel()
+	if err != nil {
+		return fmt.Errorf("failed to get L1 head: %w", err)
+	}
+	start := g.progress.backfillStart(head, g.cfg.GuardianBackfillMaxBlocks)
+	g.log.Info("backfilling validation requests", "from", start, "to", head)
+
+	var events []*bindings.SecurityCouncilValidationRequested
+	for from := start; from <= head; from += guardianBackfillChunk {
+		to := from + guardianBackfillChunk - 1
+		if to > head {
+			to = head
+		}
+		chunk, err := g.filterValidationRequests(ctx, from, to)
+		if err != nil {
+			return err
+		}
+		events = append(events, chunk...)
+	}
+
+	var unconfirmed []*bindings.SecurityCouncilValidationRequested
+	for _, event := range events {
+		cCtx, cCancel := context.WithTimeout(ctx, g.cfg.NetworkTimeout)
+		callOpts := utils.NewCallOptsWithSender(cCtx, g.cfg.TxManager.From())
+		isConfirmed, err := g.securityCouncilContract.IsConfirmed(callOpts, event.TransactionId)
+		cCancel()
+		if err != nil {
+			return fmt.Errorf("failed to check confirmation of transaction %s: %w", event.TransactionId, err)
+		}
+		if !isConfirmed {
+			unconfirmed = append(unconfirmed, event)
+		}
+	}
+	g.log.Info("backfilled validation requests", "from", start, "to", head, "requests", len(events), "unconfirmed", len(unconfirmed))
+	g.metr.RecordBackfilledValidationRequests(len(unconfirmed))
+
+	g.confirmBatch(ctx, unconfirmed, func() { g.progress.backfilled(head) })
+	return nil
+}
+
+func (g *Guardian) filterValidationRequests(ctx context.Context, from, to uint64) ([]*bindings.SecurityCouncilValidationRequested, error) {
+	cCtx, cCancel := context.WithTimeout(ctx, g.cfg.NetworkTimeout)
+	defer cCancel()
+	it, err := g.securityCouncilContract.FilterValidationRequested(&bind.FilterOpts{Start: from, End: &to, Context: cCtx}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to filter validation requests from %d to %d: %w", from, to, err)
+	}
+	defer it.Close()
+	var events []*bindings.SecurityCouncilValidationRequested
+	for it.Next() {
+		events = append(events, it.Event)
+	}
+	if err := it.Error(); err != nil {
+		return nil, fmt.Errorf("failed to filter validation requests from %d to %d: %w", from, to, err)
+	}
+	return events, nil
+}
+
+// confirmBatch validates the requests once per requested output, and queues the confirmations of the requests of the
+// valid outputs at once, in the order of the transaction ids. The SecurityCouncil only takes the confirmation of the
+// sender, so every confirmation is still a transaction of its own. A request that cannot be confirmed right away,
+// e.g. of an output the node has not derived yet, is processed on its own. received is called once all requests
+// are recorded as in flight.
+func (g *Guardian) confirmBatch(ctx context.Context, events []*bindings.SecurityCouncilValidationRequested, received func()) {
+	type requestedOutput struct {
+		l2BlockNumber uint64
+		outputRoot    eth.Bytes32
+	}
+	var outputs []requestedOutput
+	requests := make(map[requestedOutput][]*bindings.SecurityCouncilValidationRequested)
+	var single []*bindings.SecurityCouncilValidationRequested
+	for _, event := range events {
+		if !g.beginRequest(event) {
+			continue
+		}
+		// the misaligned requests are rejected on their own
+		if !event.L2BlockNumber.IsUint64() || !g.checkpoints.isCheckpoint(event.L2BlockNumber.Uint64()) {
+			single = append(single, event)
+			continue
+		}
+		output := requestedOutput{l2BlockNumber: event.L2BlockNumber.Uint64(), outputRoot: event.OutputRoot}
+		if _, ok := requests[output]; !ok {
+			outputs = append(outputs, output)
+		}
+		requests[output] = append(requests[output], event)
+	}
+	received()
+
+	// a lagging node is not trusted to confirm the requests, the single validations wait for the lag to recover
+	var lagging bool
+	if g.nodeLag != nil {
+		lagging, _ = g.nodeLag.Exceeded()
+	}
+	var batch []*bindings.SecurityCouncilValidationRequested
+	for _, output := range outputs {
+		result := g.ValidateL2Output(ctx, output.outputRoot, output.l2BlockNumber)
+		for _, event := range requests[output] {
+			g.traces.validated(event.TransactionId, result)
+		}
+		// the validation is shared by the requests of the output, it is linked to the trace of the first one
+		g.metr.RecordOutputValidation(string(result.Reason), g.traces.exemplar(requests[output][0].TransactionId, common.Hash{}))
+		// a standby or a leader after a failover checks the confirmations of the leader on its own, and a dry run
+		// only logs the confirmations
+		if result.IsValid() && g.leader == nil && !g.cfg.GuardianDryRun && !lagging {
+			batch = append(batch, requests[output]...)
+		} else {
+			single = append(single, requests[output]...)
+		}
+	}
+	sort.Slice(batch, func(i, j int) bool { return batch[i].TransactionId.Cmp(batch[j].TransactionId) < 0 })
+
+	txs := make([]*types.Transaction, 0, len(batch))
+	gasLimits := make([]uint64, 0, len(batch))
+	confirmed := make([]*bindings.SecurityCouncilValidationRequested, 0, len(batch))
+	ids := make([]*big.Int, 0, len(batch))
+	for _, event := range batch {
+		cCtx, cCancel := context.WithTimeout(ctx, g.cfg.NetworkTimeout)
+		tx, err := g.ConfirmTransaction(cCtx, event.TransactionId)
+		cCancel()
+		if err != nil {
+			g.log.Error("tx call ConfirmTransaction failed", "err", err, "transactionId", event.TransactionId)
+			single = append(single, event)
+			continue
+		}
+		// the confirmations failing the estimation, e.g. during a fee spike, are handled by the single validations
+		estimate, err := g.estimateConfirmation(ctx, tx)
+		if err != nil {
+			g.log.Warn("failed to estimate confirmation of batch, validating it on its own", "err", err,
+				"transactionId", event.TransactionId)
+			single = append(single, event)
+			continue
+		}
+		txs = append(txs, tx)
+		gasLimits = append(gasLimits, estimate.gasLimit)
+		confirmed = append(confirmed, event)
+		ids = append(ids, event.TransactionId)
+	}
+	if len(txs) > 0 {
+		g.log.Info("confirming a batch of validation requests", "count", len(txs), "transactionIds", ids)
+	}
+	for i, tx := range txs {
+		localOutputRoot := eth.Bytes32(confirmed[i].OutputRoot)
+		g.sendConfirmation(confirmed[i], tx, gasLimits[i], &localOutputRoot)
+	}
+
+	for _, event := range single {
+		g.enqueueValidation(ctx, event)
+	}
+}
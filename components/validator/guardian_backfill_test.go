@@ -0,0 +1,241 @@
+package validator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/exp/slices"
+
+	"github.com/kroma-network/kroma/bindings/bindings"
+	"github.com/kroma-network/kroma/components/node/eth"
+	"github.com/kroma-network/kroma/components/node/testlog"
+	"github.com/kroma-network/kroma/utils/service/txmgr"
+)
+
+// fakeLogFilterer serves the logs of the L1 blocks in the range of a filter query, matching its topics.
+type fakeLogFilterer struct {
+	logs []types.Log
+}
+
+func (f *fakeLogFilterer) FilterLogs(_ context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	var logs []types.Log
+	for _, l := range f.logs {
+		if l.BlockNumber >= q.FromBlock.Uint64() && (q.ToBlock == nil || l.BlockNumber <= q.ToBlock.Uint64()) &&
+			topicsMatch(q.Topics, l.Topics) {
+			logs = append(logs, l)
+		}
+	}
+	return logs, nil
+}
+
+func topicsMatch(filter [][]common.Hash, topics []common.Hash) bool {
+	for i, alternatives := range filter {
+		if len(alternatives) == 0 {
+			continue
+		}
+		if i >= len(topics) || !slices.Contains(alternatives, topics[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func (f *fakeLogFilterer) SubscribeFilterLogs(_ context.Context, _ ethereum.FilterQuery, _ chan<- types.Log) (ethereum.Subscription, error) {
+	return nil, errors.New("not supported")
+}
+
+// fakeL1Head serves the L1 head, after the first failures calls fail.
+type fakeL1Head struct {
+	mu       sync.Mutex
+	head     uint64
+	failures int
+	calls    int
+}
+
+func (c *fakeL1Head) BlockNumber(_ context.Context) (uint64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.calls++
+	if c.calls <= c.failures {
+		return 0, errFakeRpc
+	}
+	return c.head, nil
+}
+
+func validationRequestedLog(t *testing.T, l1Block uint64, transactionId int64, outputRoot eth.Bytes32, l2BlockNumber uint64) types.Log {
+	councilABI, err := bindings.SecurityCouncilMetaData.GetAbi()
+	require.NoError(t, err)
+	ev := councilABI.Events["ValidationRequested"]
+	data, err := ev.Inputs.NonIndexed().Pack([32]byte(outputRoot), new(big.Int).SetUint64(l2BlockNumber))
+	require.NoError(t, err)
+	return types.Log{
+		Topics:      []common.Hash{ev.ID, common.BigToHash(big.NewInt(transactionId))},
+		Data:        data,
+		BlockNumber: l1Block,
+	}
+}
+
+func readGuardianState(t *testing.T, path string) uint64 {
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var state guardianState
+	require.NoError(t, json.Unmarshal(data, &state))
+	return state.L1Block
+}
+
+func TestGuardianProgress(t *testing.T) {
+	l := testlog.Logger(t, log.LvlCrit)
+	path := filepath.Join(t.TempDir(), "guardian.json")
+
+	p, err := loadGuardianProgress(l, path)
+	require.NoError(t, err)
+	require.False(t, p.loaded)
+	require.Equal(t, uint64(51), p.backfillStart(100, 50))
+	require.Equal(t, uint64(0), p.backfillStart(10, 50))
+
+	p.begin(10)
+	p.begin(20)
+	p.begin(20)
+	p.backfilled(30)
+	require.Equal(t, uint64(9), p.processed())
+	require.Equal(t, uint64(9), readGuardianState(t, path))
+	p.done(20)
+	p.done(10)
+	require.Equal(t, uint64(19), p.processed(), "expected the pending request to hold the progress")
+	require.Equal(t, uint64(19), readGuardianState(t, path))
+	p.done(20)
+	require.Equal(t, uint64(30), p.processed())
+	require.Equal(t, uint64(30), readGuardianState(t, path))
+
+	// the requests of the subscription advance the progress once processed
+	p.begin(40)
+	require.Equal(t, uint64(39), p.processed())
+	p.done(40)
+	require.Equal(t, uint64(40), readGuardianState(t, path))
+
+	p, err = loadGuardianProgress(l, path)
+	require.NoError(t, err)
+	require.True(t, p.loaded)
+	require.Equal(t, uint64(41), p.backfillStart(60, 50))
+	require.Equal(t, uint64(51), p.backfillStart(100, 50), "expected the backfill to be bounded")
+
+	require.NoError(t, os.WriteFile(path, []byte("{"), 0o600))
+	_, err = loadGuardianProgress(l, path)
+	require.ErrorContains(t, err, "failed to decode guardian state file")
+}
+
+func TestGuardianBackfill(t *testing.T) {
+	const l2BlockNumber = 100
+	localOutputRoot := eth.Bytes32{0xaa}
+	path := filepath.Join(t.TempDir(), "guardian.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"l1Block":100}`), 0o600))
+
+	council := &fakeSecurityCouncil{
+		confirmedOnChain: map[uint64]bool{4: true},
+		requests: []types.Log{
+			// processed before the guardian was stopped
+			validationRequestedLog(t, 90, 1, localOutputRoot, l2BlockNumber),
+			// confirmed in a batch, after a single validation of their output
+			validationRequestedLog(t, 2150, 3, localOutputRoot, l2BlockNumber),
+			validationRequestedLog(t, 101, 2, localOutputRoot, l2BlockNumber),
+			// confirmed by the guardian already
+			validationRequestedLog(t, 160, 4, localOutputRoot, l2BlockNumber),
+			// rejected on their own
+			validationRequestedLog(t, 170, 5, eth.Bytes32{0xbb}, l2BlockNumber),
+			validationRequestedLog(t, 180, 6, localOutputRoot, l2BlockNumber+5),
+		},
+	}
+	rollupClient := &fakeRollupClient{outputRoot: localOutputRoot, blockNumber: l2BlockNumber}
+	g, _ := newTestGuardian(t, rollupClient, council)
+	candidates := make(chan txmgr.TxCandidate, 10)
+	g.txCandidatesChan = candidates
+	g.cfg.GuardianBackfillMaxBlocks = 10000
+	l1Head := &fakeL1Head{head: 3000, failures: 1}
+	g.l1Client = l1Head
+	g.progress, _ = loadGuardianProgress(g.log, path)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	g.wg.Add(1)
+	g.backfill(ctx)
+	g.wg.Wait()
+	require.NoError(t, ctx.Err())
+
+	require.Equal(t, 2, l1Head.calls, "expected the failed backfill to be retried")
+	require.Equal(t, []*big.Int{big.NewInt(2), big.NewInt(3)}, council.confirmations())
+	require.Len(t, candidates, 2)
+	outputCalls, _ := rollupClient.calls()
+	require.Equal(t, 3, outputCalls, "expected a validation per requested output, and of the mismatch on its own")
+	require.Equal(t, uint64(100), g.progress.processed(), "expected the queued confirmations to hold the progress")
+	for i := 0; i < 2; i++ {
+		g.TxSent(<-candidates, &types.Receipt{Status: types.ReceiptStatusSuccessful}, nil)
+	}
+	require.Equal(t, uint64(3000), g.progress.processed())
+	require.Equal(t, uint64(3000), readGuardianState(t, path))
+
+	// a request received again from the subscription is not processed twice
+	g.inFlight["7"] = &bindings.SecurityCouncilValidationRequested{TransactionId: big.NewInt(7), Raw: types.Log{BlockNumber: 3001}}
+	require.False(t, g.beginRequest(&bindings.SecurityCouncilValidationRequested{TransactionId: big.NewInt(7)}))
+}
+
+func TestGuardianRetriesRequestInterruptedByShutdown(t *testing.T) {
+	const l2BlockNumber = 100
+	localOutputRoot := eth.Bytes32{0xaa}
+	path := filepath.Join(t.TempDir(), "guardian.json")
+	request := validationRequestedLog(t, 150, 1, localOutputRoot, l2BlockNumber)
+	event := &bindings.SecurityCouncilValidationRequested{
+		TransactionId: big.NewInt(1),
+		OutputRoot:    localOutputRoot,
+		L2BlockNumber: big.NewInt(l2BlockNumber),
+		Raw:           request,
+	}
+
+	// the guardian stops while waiting for the node to derive the requested output
+	behind := &fakeRollupClient{outputRoot: localOutputRoot, blockNumber: l2BlockNumber, syncBehind: alwaysFail}
+	g, candidates := newTestGuardian(t, behind, &fakeSecurityCouncil{})
+	g.progress, _ = loadGuardianProgress(g.log, path)
+	require.True(t, g.beginRequest(event))
+	g.progress.backfilled(200)
+	require.Equal(t, uint64(149), readGuardianState(t, path))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+	g.wg.Add(1)
+	g.processOutputValidation(ctx, event)
+	require.Empty(t, candidates)
+	require.Empty(t, g.inFlight)
+	require.Equal(t, uint64(149), g.progress.processed(), "expected the interrupted request to hold the progress")
+	require.Equal(t, uint64(149), readGuardianState(t, path))
+
+	// the request is backfilled again on restart
+	council := &fakeSecurityCouncil{requests: []types.Log{request}}
+	restarted, candidates := newTestGuardian(t, &fakeRollupClient{outputRoot: localOutputRoot, blockNumber: l2BlockNumber}, council)
+	restarted.cfg.GuardianBackfillMaxBlocks = 10000
+	restarted.l1Client = &fakeL1Head{head: 200}
+	restarted.progress, _ = loadGuardianProgress(restarted.log, path)
+	ctx, cancel = context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	restarted.wg.Add(1)
+	restarted.backfill(ctx)
+	restarted.wg.Wait()
+	require.Equal(t, []*big.Int{big.NewInt(1)}, council.confirmations())
+	restarted.TxSent(<-candidates, &types.Receipt{Status: types.ReceiptStatusSuccessful}, nil)
+	require.Equal(t, uint64(200), readGuardianState(t, path))
+
+	// a request retried without a restart holds the progress once
+	require.True(t, g.beginRequest(event))
+	g.endRequest(event, true)
+	require.Equal(t, uint64(200), g.progress.processed())
+}
@@ -0,0 +1,92 @@
+package validator
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// GuardianLocalConfig is the local configuration of a guardian that must match the deployed contracts.
+type GuardianLocalConfig struct {
+	Guardian        common.Address
+	GuardianEnabled bool
+	CouncilAddr     common.Address
+	ColosseumAddr   common.Address
+	L2OOAddr        common.Address
+	ValPoolAddr     common.Address
+}
+
+// GuardianOnChainConfig is the guardian related state of the contracts at the locally configured addresses.
+type GuardianOnChainConfig struct {
+	// CouncilDeployed is whether there is a contract at the configured SecurityCouncil address.
+	// If not, the other SecurityCouncil fields are not set.
+	CouncilDeployed bool
+	Owners          []common.Address
+	Required        uint64
+	// CouncilColosseum is the Colosseum address set in the SecurityCouncil.
+	CouncilColosseum common.Address
+	// ColosseumCouncil is the SecurityCouncil address set in the Colosseum.
+	ColosseumCouncil common.Address
+	// ColosseumL2OO is the L2OutputOracle address set in the Colosseum.
+	ColosseumL2OO common.Address
+	// L2OOValPool is the ValidatorPool address set in the L2OutputOracle.
+	L2OOValPool common.Address
+}
+
+// GuardianConfigCheck is the result of a single guardian configuration check.
+type GuardianConfigCheck struct {
+	Name   string
+	OK     bool
+	Detail string
+}
+
+// CheckGuardianConfig compares the local guardian configuration against the deployed contracts.
+func CheckGuardianConfig(local GuardianLocalConfig, onChain GuardianOnChainConfig) []GuardianConfigCheck {
+	checks := []GuardianConfigCheck{
+		{
+			Name:   "guardian enabled",
+			OK:     local.GuardianEnabled,
+			Detail: "--guardian.enabled is not set, the validation requests are not confirmed",
+		},
+		addressCheck("Colosseum SecurityCouncil", onChain.ColosseumCouncil, local.CouncilAddr),
+		addressCheck("Colosseum L2OutputOracle", onChain.ColosseumL2OO, local.L2OOAddr),
+		addressCheck("L2OutputOracle ValidatorPool", onChain.L2OOValPool, local.ValPoolAddr),
+		{
+			Name:   "SecurityCouncil deployed",
+			OK:     onChain.CouncilDeployed,
+			Detail: fmt.Sprintf("no contract at %s", local.CouncilAddr),
+		},
+	}
+	if !onChain.CouncilDeployed {
+		return checks
+	}
+
+	isOwner := false
+	for _, owner := range onChain.Owners {
+		if owner == local.Guardian {
+			isOwner = true
+			break
+		}
+	}
+	return append(checks,
+		addressCheck("SecurityCouncil Colosseum", onChain.CouncilColosseum, local.ColosseumAddr),
+		GuardianConfigCheck{
+			Name:   "guardian is owner",
+			OK:     isOwner,
+			Detail: fmt.Sprintf("%s is not one of the %d SecurityCouncil owners", local.Guardian, len(onChain.Owners)),
+		},
+		GuardianConfigCheck{
+			Name:   "quorum",
+			OK:     onChain.Required > 0 && onChain.Required <= uint64(len(onChain.Owners)),
+			Detail: fmt.Sprintf("%d of %d confirmations required", onChain.Required, len(onChain.Owners)),
+		},
+	)
+}
+
+func addressCheck(name string, onChain, local common.Address) GuardianConfigCheck {
+	return GuardianConfigCheck{
+		Name:   name,
+		OK:     onChain == local,
+		Detail: fmt.Sprintf("on-chain %s, configured %s", onChain, local),
+	}
+}
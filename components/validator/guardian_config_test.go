@@ -0,0 +1,68 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func failedChecks(checks []GuardianConfigCheck) []string {
+	var failed []string
+	for _, check := range checks {
+		if !check.OK {
+			failed = append(failed, check.Name)
+		}
+	}
+	return failed
+}
+
+func TestCheckGuardianConfig(t *testing.T) {
+	local := GuardianLocalConfig{
+		Guardian:        common.Address{0xa},
+		GuardianEnabled: true,
+		CouncilAddr:     common.Address{0x1},
+		ColosseumAddr:   common.Address{0x2},
+		L2OOAddr:        common.Address{0x3},
+		ValPoolAddr:     common.Address{0x4},
+	}
+	onChain := GuardianOnChainConfig{
+		CouncilDeployed:  true,
+		Owners:           []common.Address{{0xb}, {0xa}},
+		Required:         2,
+		CouncilColosseum: common.Address{0x2},
+		ColosseumCouncil: common.Address{0x1},
+		ColosseumL2OO:    common.Address{0x3},
+		L2OOValPool:      common.Address{0x4},
+	}
+	require.Empty(t, failedChecks(CheckGuardianConfig(local, onChain)))
+
+	t.Run("wrong council address", func(t *testing.T) {
+		wrong := local
+		wrong.CouncilAddr = common.Address{0x9}
+		require.Equal(t, []string{"Colosseum SecurityCouncil"}, failedChecks(CheckGuardianConfig(wrong, onChain)))
+	})
+
+	t.Run("not an owner", func(t *testing.T) {
+		wrong := local
+		wrong.Guardian = common.Address{0xc}
+		require.Equal(t, []string{"guardian is owner"}, failedChecks(CheckGuardianConfig(wrong, onChain)))
+	})
+
+	t.Run("unreachable quorum", func(t *testing.T) {
+		wrong := onChain
+		wrong.Required = 3
+		require.Equal(t, []string{"quorum"}, failedChecks(CheckGuardianConfig(local, wrong)))
+	})
+
+	t.Run("council not deployed", func(t *testing.T) {
+		wrong := GuardianOnChainConfig{
+			ColosseumCouncil: common.Address{0x1},
+			ColosseumL2OO:    common.Address{0x3},
+			L2OOValPool:      common.Address{0x4},
+		}
+		checks := CheckGuardianConfig(local, wrong)
+		require.Equal(t, []string{"SecurityCouncil deployed"}, failedChecks(checks))
+		require.Len(t, checks, 5, "council state must not be checked")
+	})
+}
@@ -0,0 +1,48 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/kroma-network/kroma/bindings/bindings"
+	"github.com/kroma-network/kroma/components/node/eth"
+	"github.com/kroma-network/kroma/utils"
+)
+
+// dissent contests the validation request of an invalid output. The SecurityCouncil has no vote against a
+// transaction, so the guardian revokes its confirmation if it confirmed the request before, e.g. from another
+// instance or before the local node was fixed, and the mismatch alert keeps the quorum from being reached.
+// It returns whether the confirmation was revoked, and the members that confirmed the request.
+func (g *Guardian) dissent(ctx context.Context, event *bindings.SecurityCouncilValidationRequested, localOutputRoot eth.Bytes32) (bool, []common.Address, error) {
+	cCtx, cCancel := context.WithTimeout(ctx, g.cfg.NetworkTimeout)
+	confirmations, err := g.securityCouncilContract.GetConfirmations(utils.NewSimpleCallOpts(cCtx), event.TransactionId)
+	cCancel()
+	if err != nil {
+		return false, nil, fmt.Errorf("failed to get confirmations: %w", err)
+	}
+
+	var revoked bool
+	for _, member := range confirmations {
+		if member != g.cfg.TxManager.From() {
+			continue
+		}
+		cCtx, cCancel = context.WithTimeout(ctx, g.cfg.NetworkTimeout)
+		txOpts := utils.NewSimpleTxOpts(cCtx, g.cfg.TxManager.From(), g.cfg.TxManager.Signer)
+		tx, err := g.securityCouncilContract.RevokeConfirmation(txOpts, event.TransactionId)
+		cCancel()
+		if err != nil {
+			return false, nil, fmt.Errorf("failed to create revoke confirmation tx: %w", err)
+		}
+		g.sendTransaction(tx, 0)
+		revoked = true
+		break
+	}
+
+	g.log.Error("contesting validation request of an invalid output", "transactionId", event.TransactionId,
+		"l2BlockNumber", event.L2BlockNumber, "outputRoot", event.OutputRoot, "localOutputRoot", localOutputRoot,
+		"revoked", revoked, "confirmations", len(confirmations))
+	g.metr.RecordGuardianDissent(revoked)
+	return revoked, confirmations, nil
+}
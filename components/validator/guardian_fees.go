@@ -0,0 +1,74 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// ErrGuardianFeeCapExceeded is returned when the fee of a confirmation at the current L1 base fee exceeds
+// the fee cap of the guardian.
+var ErrGuardianFeeCapExceeded = errors.New("fee exceeds the guardian fee cap")
+
+// GuardianFeeClient is the set of L1 methods that the Guardian estimates the gas and the fee of its
+// confirmations with.
+type GuardianFeeClient interface {
+	EstimateGas(ctx context.Context, call ethereum.CallMsg) (uint64, error)
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	SuggestGasTipCap(ctx context.Context) (*big.Int, error)
+}
+
+// guardianTxFee is the estimated gas limit and fee of a confirmation.
+type guardianTxFee struct {
+	gasLimit uint64
+	// fee is the gas limit times the L1 base fee plus the suggested tip.
+	fee     *big.Int
+	baseFee *big.Int
+}
+
+// estimateConfirmation estimates the gas limit of the confirmation against the L1, which also fails if the
+// confirmation would revert. With a fee cap, it fails with ErrGuardianFeeCapExceeded if the fee of the
+// confirmation at the current L1 base fee exceeds the cap, so that no funds are burnt during a fee spike.
+func (g *Guardian) estimateConfirmation(ctx context.Context, tx *types.Transaction) (guardianTxFee, error) {
+	var estimate guardianTxFee
+	if g.feeClient == nil {
+		return estimate, nil
+	}
+	cCtx, cCancel := context.WithTimeout(ctx, g.cfg.NetworkTimeout)
+	defer cCancel()
+	gasLimit, err := g.feeClient.EstimateGas(cCtx, ethereum.CallMsg{
+		From: g.cfg.TxManager.From(),
+		To:   tx.To(),
+		Data: tx.Data(),
+	})
+	if err != nil {
+		return estimate, fmt.Errorf("failed to estimate gas: %w", err)
+	}
+	estimate.gasLimit = gasLimit
+	if g.cfg.GuardianMaxTxFee == 0 {
+		return estimate, nil
+	}
+
+	head, err := g.feeClient.HeaderByNumber(cCtx, nil)
+	if err != nil {
+		return estimate, fmt.Errorf("failed to get L1 head: %w", err)
+	}
+	if head.BaseFee == nil {
+		return estimate, errors.New("L1 head has no base fee")
+	}
+	tip, err := g.feeClient.SuggestGasTipCap(cCtx)
+	if err != nil {
+		return estimate, fmt.Errorf("failed to get gas tip cap: %w", err)
+	}
+	estimate.baseFee = head.BaseFee
+	estimate.fee = new(big.Int).Mul(new(big.Int).SetUint64(gasLimit), new(big.Int).Add(head.BaseFee, tip))
+	if maxFee := new(big.Int).SetUint64(g.cfg.GuardianMaxTxFee); estimate.fee.Cmp(maxFee) > 0 {
+		return estimate, fmt.Errorf("%w: fee %s wei (gas %d, base fee %s wei) exceeds %s wei", ErrGuardianFeeCapExceeded,
+			estimate.fee, gasLimit, head.BaseFee, maxFee)
+	}
+	return estimate, nil
+}
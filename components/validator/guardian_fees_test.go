@@ -0,0 +1,157 @@
+package validator
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kroma-network/kroma/bindings/bindings"
+	"github.com/kroma-network/kroma/components/node/eth"
+)
+
+// fakeFeeClient estimates every call with the same gas, at a base fee that can be changed.
+type fakeFeeClient struct {
+	mu      sync.Mutex
+	gas     uint64
+	baseFee *big.Int
+	tip     *big.Int
+	// estimateErr fails the gas estimation if set.
+	estimateErr error
+}
+
+func (f *fakeFeeClient) EstimateGas(_ context.Context, _ ethereum.CallMsg) (uint64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.gas, f.estimateErr
+}
+
+func (f *fakeFeeClient) HeaderByNumber(_ context.Context, _ *big.Int) (*types.Header, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return &types.Header{BaseFee: f.baseFee}, nil
+}
+
+func (f *fakeFeeClient) SuggestGasTipCap(_ context.Context) (*big.Int, error) {
+	return f.tip, nil
+}
+
+func (f *fakeFeeClient) setBaseFee(baseFee *big.Int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.baseFee = baseFee
+}
+
+func TestGuardianEstimateConfirmation(t *testing.T) {
+	tx := types.NewTx(&types.DynamicFeeTx{Data: []byte{0x01}})
+	tests := []struct {
+		name        string
+		maxFee      uint64
+		feeClient   *fakeFeeClient
+		expectGas   uint64
+		expectFee   *big.Int
+		expectError error
+	}{
+		{
+			name:      "no fee client",
+			maxFee:    1,
+			expectGas: 0,
+		},
+		{
+			name:      "no cap",
+			feeClient: &fakeFeeClient{gas: 100_000, baseFee: big.NewInt(1e12), tip: big.NewInt(1)},
+			expectGas: 100_000,
+		},
+		{
+			name:      "under the cap",
+			maxFee:    2e15,
+			feeClient: &fakeFeeClient{gas: 100_000, baseFee: big.NewInt(19e9), tip: big.NewInt(1e9)},
+			expectGas: 100_000,
+			expectFee: big.NewInt(2e15),
+		},
+		{
+			name:        "over the cap",
+			maxFee:      2e15,
+			feeClient:   &fakeFeeClient{gas: 100_000, baseFee: big.NewInt(20e9), tip: big.NewInt(1e9)},
+			expectGas:   100_000,
+			expectFee:   big.NewInt(21e14),
+			expectError: ErrGuardianFeeCapExceeded,
+		},
+		{
+			name:        "estimation failure",
+			maxFee:      2e15,
+			feeClient:   &fakeFeeClient{estimateErr: errFakeRpc},
+			expectError: errFakeRpc,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			g, _ := newTestGuardian(t, &fakeRollupClient{}, &fakeSecurityCouncil{})
+			g.cfg.GuardianMaxTxFee = test.maxFee
+			if test.feeClient != nil {
+				g.feeClient = test.feeClient
+			}
+			estimate, err := g.estimateConfirmation(context.Background(), tx)
+			if test.expectError != nil {
+				require.ErrorIs(t, err, test.expectError)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, test.expectGas, estimate.gasLimit)
+			require.Equal(t, test.expectFee, estimate.fee)
+		})
+	}
+}
+
+func TestGuardianSkipsConfirmationOverFeeCap(t *testing.T) {
+	const l2BlockNumber = 100
+	localOutputRoot := eth.Bytes32{0xaa}
+	transactionId := big.NewInt(7)
+
+	rollupClient := &fakeRollupClient{outputRoot: localOutputRoot, blockNumber: l2BlockNumber}
+	g, candidates := newTestGuardian(t, rollupClient, &fakeSecurityCouncil{})
+	sink := &fakeAlertSink{}
+	g.cfg.GuardianAlertSinks = []AlertSink{sink}
+	g.cfg.GuardianMaxTxFee = 2e15
+	g.cfg.GuardianMaxRetries = 1
+	feeClient := &fakeFeeClient{gas: 100_000, baseFee: big.NewInt(100e9), tip: big.NewInt(1e9)}
+	g.feeClient = feeClient
+
+	event := &bindings.SecurityCouncilValidationRequested{
+		TransactionId: transactionId,
+		OutputRoot:    localOutputRoot,
+		L2BlockNumber: big.NewInt(l2BlockNumber),
+	}
+	done := make(chan struct{})
+	g.wg.Add(1)
+	go func() {
+		g.processOutputValidation(context.Background(), event)
+		close(done)
+	}()
+
+	// the skipped attempts do not exhaust the retry budget
+	time.Sleep(100 * time.Millisecond)
+	require.Empty(t, candidates)
+	alerts := sink.delivered()
+	require.Len(t, alerts, 1, "the skipped confirmation is alerted once")
+	require.Equal(t, GuardianAlertFeeCapExceeded, alerts[0].Kind)
+	require.Equal(t, big.NewInt(101e14), alerts[0].Fee)
+	require.Equal(t, big.NewInt(2e15), alerts[0].MaxFee)
+
+	feeClient.setBaseFee(big.NewInt(10e9))
+	select {
+	case candidate := <-candidates:
+		require.Equal(t, uint64(100_000), candidate.GasLimit)
+		require.Equal(t, big.NewInt(2e15), candidate.MaxTxFee, "the fees are bounded by the fee cap")
+	case <-time.After(5 * time.Second):
+		t.Fatal("confirmation was not sent once the fees dropped")
+	}
+	<-done
+}
@@ -0,0 +1,227 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/kroma-network/kroma/components/validator/metrics"
+	"github.com/kroma-network/kroma/utils"
+)
+
+// ErrLeaseConflict is returned by a LeaseLock when the lease was changed since it was read.
+var ErrLeaseConflict = errors.New("lease conflict")
+
+// LeaseRecord is the state of a leader lease.
+type LeaseRecord struct {
+	// HolderIdentity is the identity of the leader, empty if the lease was released.
+	HolderIdentity string
+	LeaseDuration  time.Duration
+	AcquireTime    time.Time
+	RenewTime      time.Time
+	// Transitions is the number of times the lease changed holders.
+	Transitions int
+}
+
+// LeaseLock stores the leader lease of the guardians sharing a key, e.g. a Kubernetes Lease or a database row.
+// The updates are optimistic, they fail with ErrLeaseConflict if the lease changed since it was read.
+type LeaseLock interface {
+	// Get returns the record of the lease and its version, or a nil record if the lease does not exist.
+	Get(ctx context.Context) (*LeaseRecord, string, error)
+	// Create creates the lease, it fails with ErrLeaseConflict if the lease exists.
+	Create(ctx context.Context, record LeaseRecord) error
+	// Update replaces the record of the lease of the version.
+	Update(ctx context.Context, record LeaseRecord, version string) error
+	// Describe names the lease for the logs.
+	Describe() string
+}
+
+// LeaderElector elects the guardian that submits the transactions among the guardians sharing a key, so that the
+// requests are not confirmed twice. The other guardians are standbys that validate the requests in shadow mode.
+type LeaderElector interface {
+	// Run campaigns for the leadership until the context is done, and then releases it.
+	Run(ctx context.Context)
+	// IsLeader returns whether the guardian is the leader.
+	IsLeader() bool
+}
+
+// LeaseElector is a LeaderElector holding the lease of a LeaseLock, which it renews every retry period.
+// A lease that was not renewed for its duration, as observed by the local clock, is taken over by a standby.
+// The leader steps down once it failed to renew the lease for the renew deadline, before the lease expires.
+type LeaseElector struct {
+	log      log.Logger
+	metr     metrics.Metricer
+	lock     LeaseLock
+	identity string
+
+	leaseDuration time.Duration
+	renewDeadline time.Duration
+	retryPeriod   time.Duration
+
+	// timeNow enables elector testing to mock the time
+	timeNow func() time.Time
+
+	// renewed is the time in unix nanoseconds the lease was last acquired or renewed, 0 if not leader
+	renewed atomic.Int64
+	// leader is whether the elector was the leader when last campaigning, to log the transitions
+	leader bool
+	// observed is the last observed record of the lease, and observedTime the local time it was observed at
+	observed     *LeaseRecord
+	observedTime time.Time
+}
+
+var _ LeaderElector = (*LeaseElector)(nil)
+
+func NewLeaseElector(l log.Logger, m metrics.Metricer, lock LeaseLock, identity string, leaseDuration time.Duration) *LeaseElector {
+	return &LeaseElector{
+		log:           l,
+		metr:          m,
+		lock:          lock,
+		identity:      identity,
+		leaseDuration: leaseDuration,
+		renewDeadline: leaseDuration * 2 / 3,
+		retryPeriod:   leaseDuration / 5,
+		timeNow:       time.Now,
+	}
+}
+
+func (e *LeaseElector) Run(ctx context.Context) {
+	e.log.Info("campaigning for the guardian leader lease", "lease", e.lock.Describe(), "identity", e.identity,
+		"leaseDuration", e.leaseDuration)
+	e.metr.RecordGuardianLeader(false)
+
+	ticker := time.NewTicker(e.retryPeriod)
+	defer ticker.Stop()
+	for {
+		e.tryAcquireOrRenew(ctx)
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			e.release()
+			return
+		}
+	}
+}
+
+func (e *LeaseElector) IsLeader() bool {
+	renewed := e.renewed.Load()
+	return renewed != 0 && e.timeNow().Sub(time.Unix(0, renewed)) < e.renewDeadline
+}
+
+// tryAcquireOrRenew renews the lease if the elector holds it, or acquires it if it is free or expired.
+func (e *LeaseElector) tryAcquireOrRenew(ctx context.Context) {
+	now := e.timeNow()
+	cCtx, cCancel := context.WithTimeout(ctx, e.retryPeriod)
+	defer cCancel()
+
+	record, version, err := e.lock.Get(cCtx)
+	if err != nil {
+		e.log.Warn("failed to get the guardian leader lease", "lease", e.lock.Describe(), "err", err)
+		e.update(now)
+		return
+	}
+	if record == nil {
+		next := LeaseRecord{HolderIdentity: e.identity, LeaseDuration: e.leaseDuration, AcquireTime: now, RenewTime: now}
+		e.updated(now, next, e.lock.Create(cCtx, next))
+		return
+	}
+
+	if e.observed == nil || e.observed.HolderIdentity != record.HolderIdentity || !e.observed.RenewTime.Equal(record.RenewTime) {
+		e.observed, e.observedTime = record, now
+	}
+	if record.HolderIdentity != e.identity && record.HolderIdentity != "" && now.Before(e.observedTime.Add(record.LeaseDuration)) {
+		// the lease is held by another guardian
+		e.renewed.Store(0)
+		e.update(now)
+		return
+	}
+
+	next := *record
+	if next.HolderIdentity != e.identity {
+		next.HolderIdentity, next.AcquireTime = e.identity, now
+		next.Transitions++
+	}
+	next.LeaseDuration, next.RenewTime = e.leaseDuration, now
+	e.updated(now, next, e.lock.Update(cCtx, next, version))
+}
+
+// updated handles the result of acquiring or renewing the lease at now.
+func (e *LeaseElector) updated(now time.Time, record LeaseRecord, err error) {
+	if errors.Is(err, ErrLeaseConflict) {
+		e.log.Debug("guardian leader lease was updated concurrently", "lease", e.lock.Describe())
+	} else if err != nil {
+		e.log.Warn("failed to update the guardian leader lease", "lease", e.lock.Describe(), "err", err)
+	} else {
+		e.renewed.Store(now.UnixNano())
+		e.observed, e.observedTime = &record, now
+	}
+	e.update(now)
+}
+
+// update logs and records the transitions of the leadership.
+func (e *LeaseElector) update(now time.Time) {
+	leader := e.IsLeader()
+	if leader == e.leader {
+		return
+	}
+	e.leader = leader
+	if leader {
+		e.log.Info("acquired the guardian leader lease, submitting confirmations", "lease", e.lock.Describe(), "identity", e.identity)
+	} else {
+		var holder string
+		if e.observed != nil {
+			holder = e.observed.HolderIdentity
+		}
+		e.log.Warn("lost the guardian leader lease, validating in shadow mode", "lease", e.lock.Describe(), "identity", e.identity,
+			"holder", holder)
+	}
+	e.metr.RecordGuardianLeader(leader)
+}
+
+// release releases a held lease, so that a standby takes over without waiting for the lease to expire.
+func (e *LeaseElector) release() {
+	if !e.IsLeader() {
+		return
+	}
+	e.renewed.Store(0)
+	e.update(e.timeNow())
+
+	ctx, cancel := context.WithTimeout(context.Background(), e.retryPeriod)
+	defer cancel()
+	record, version, err := e.lock.Get(ctx)
+	if err != nil || record == nil || record.HolderIdentity != e.identity {
+		return
+	}
+	record.HolderIdentity, record.RenewTime = "", e.timeNow()
+	if err := e.lock.Update(ctx, *record, version); err != nil {
+		e.log.Warn("failed to release the guardian leader lease", "lease", e.lock.Describe(), "err", err)
+		return
+	}
+	e.log.Info("released the guardian leader lease", "lease", e.lock.Describe())
+}
+
+// isLeader returns whether the guardian submits transactions, i.e. it is the leader or there is no leader election.
+func (g *Guardian) isLeader() bool {
+	return g.leader == nil || g.leader.IsLeader()
+}
+
+// confirmedByPeer returns whether the request was confirmed by the key of the guardian, e.g. by the leader of the
+// guardians sharing the key, while this guardian was a standby.
+func (g *Guardian) confirmedByPeer(ctx context.Context, transactionId *big.Int) (bool, error) {
+	cCtx, cCancel := context.WithTimeout(ctx, g.cfg.NetworkTimeout)
+	defer cCancel()
+	confirmations, err := g.securityCouncilContract.GetConfirmations(utils.NewSimpleCallOpts(cCtx), transactionId)
+	if err != nil {
+		return false, err
+	}
+	for _, member := range confirmations {
+		if member == g.cfg.TxManager.From() {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,322 @@
+package validator
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kroma-network/kroma/bindings/bindings"
+	"github.com/kroma-network/kroma/components/node/eth"
+	"github.com/kroma-network/kroma/components/node/testlog"
+	"github.com/kroma-network/kroma/components/validator/metrics"
+)
+
+// memLeaseLock is a LeaseLock in memory, whose version is the number of updates.
+type memLeaseLock struct {
+	mu      sync.Mutex
+	record  *LeaseRecord
+	version int
+	err     error
+}
+
+func (l *memLeaseLock) Get(_ context.Context) (*LeaseRecord, string, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.err != nil {
+		return nil, "", l.err
+	}
+	if l.record == nil {
+		return nil, "", nil
+	}
+	record := *l.record
+	return &record, strconv.Itoa(l.version), nil
+}
+
+func (l *memLeaseLock) Create(_ context.Context, record LeaseRecord) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.record != nil {
+		return ErrLeaseConflict
+	}
+	l.record = &record
+	return nil
+}
+
+func (l *memLeaseLock) Update(_ context.Context, record LeaseRecord, version string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.err != nil {
+		return l.err
+	}
+	if version != strconv.Itoa(l.version) {
+		return ErrLeaseConflict
+	}
+	l.record = &record
+	l.version++
+	return nil
+}
+
+func (l *memLeaseLock) Describe() string {
+	return "memory"
+}
+
+func (l *memLeaseLock) holder() string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.record == nil {
+		return ""
+	}
+	return l.record.HolderIdentity
+}
+
+func TestLeaseElector(t *testing.T) {
+	const leaseDuration = 15 * time.Second
+	lock := &memLeaseLock{}
+	now := time.Unix(1_000_000, 0)
+	clock := func() time.Time { return now }
+	newElector := func(identity string) *LeaseElector {
+		e := NewLeaseElector(testlog.Logger(t, log.LvlCrit), metrics.NoopMetrics, lock, identity, leaseDuration)
+		e.timeNow = clock
+		return e
+	}
+	a, b := newElector("a"), newElector("b")
+	ctx := context.Background()
+
+	a.tryAcquireOrRenew(ctx)
+	b.tryAcquireOrRenew(ctx)
+	require.True(t, a.IsLeader(), "first elector acquires the free lease")
+	require.False(t, b.IsLeader(), "second elector is a standby")
+	require.Equal(t, "a", lock.holder())
+
+	// the leader keeps renewing the lease
+	for i := 0; i < 10; i++ {
+		now = now.Add(a.retryPeriod)
+		a.tryAcquireOrRenew(ctx)
+		b.tryAcquireOrRenew(ctx)
+		require.True(t, a.IsLeader())
+		require.False(t, b.IsLeader())
+	}
+
+	// the leader fails to renew, and steps down before the standby takes over
+	lock.err = errFakeRpc
+	now = now.Add(a.renewDeadline)
+	a.tryAcquireOrRenew(ctx)
+	require.False(t, a.IsLeader(), "leader steps down after the renew deadline")
+	lock.err = nil
+	b.tryAcquireOrRenew(ctx)
+	require.False(t, b.IsLeader(), "standby waits for the lease to expire")
+	now = now.Add(leaseDuration - a.renewDeadline)
+	b.tryAcquireOrRenew(ctx)
+	require.True(t, b.IsLeader(), "standby takes over the expired lease")
+	require.Equal(t, "b", lock.holder())
+	a.tryAcquireOrRenew(ctx)
+	require.False(t, a.IsLeader())
+
+	// a released lease is taken over right away
+	b.release()
+	require.False(t, b.IsLeader())
+	require.Equal(t, "", lock.holder())
+	a.tryAcquireOrRenew(ctx)
+	require.True(t, a.IsLeader())
+	require.Equal(t, 2, lock.record.Transitions)
+}
+
+func TestKubernetesLeaseLock(t *testing.T) {
+	const token = "test-token"
+	var (
+		mu      sync.Mutex
+		lease   *k8sLease
+		version int
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if r.Header.Get("Authorization") != "Bearer "+token {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		const leases = "/apis/coordination.k8s.io/v1/namespaces/ns/leases"
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == leases+"/guardian":
+			if lease == nil {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(lease)
+		case r.Method == http.MethodPost && r.URL.Path == leases:
+			if lease != nil {
+				w.WriteHeader(http.StatusConflict)
+				return
+			}
+			lease = new(k8sLease)
+			_ = json.NewDecoder(r.Body).Decode(lease)
+			lease.Metadata.ResourceVersion = strconv.Itoa(version)
+			w.WriteHeader(http.StatusCreated)
+		case r.Method == http.MethodPut && r.URL.Path == leases+"/guardian":
+			var update k8sLease
+			_ = json.NewDecoder(r.Body).Decode(&update)
+			if update.Metadata.ResourceVersion != lease.Metadata.ResourceVersion {
+				w.WriteHeader(http.StatusConflict)
+				return
+			}
+			version++
+			update.Metadata.ResourceVersion = strconv.Itoa(version)
+			lease = &update
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer srv.Close()
+
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte(token+"\n"), 0o600))
+	lock := &KubernetesLeaseLock{server: srv.URL, namespace: "ns", name: "guardian", tokenFile: tokenFile, client: srv.Client()}
+	require.Equal(t, "ns/guardian", lock.Describe())
+	ctx := context.Background()
+
+	record, _, err := lock.Get(ctx)
+	require.NoError(t, err)
+	require.Nil(t, record, "lease does not exist yet")
+
+	now := time.Unix(1_000_000, 123_456_000).UTC()
+	created := LeaseRecord{HolderIdentity: "a", LeaseDuration: 15 * time.Second, AcquireTime: now, RenewTime: now}
+	require.NoError(t, lock.Create(ctx, created))
+	require.ErrorIs(t, lock.Create(ctx, created), ErrLeaseConflict)
+
+	record, version0, err := lock.Get(ctx)
+	require.NoError(t, err)
+	require.Equal(t, created, *record)
+
+	renewed := created
+	renewed.RenewTime = now.Add(time.Second)
+	require.NoError(t, lock.Update(ctx, renewed, version0))
+	require.ErrorIs(t, lock.Update(ctx, renewed, version0), ErrLeaseConflict, "stale version")
+
+	record, _, err = lock.Get(ctx)
+	require.NoError(t, err)
+	require.Equal(t, renewed, *record)
+
+	require.NoError(t, os.WriteFile(tokenFile, []byte("other-token"), 0o600))
+	_, _, err = lock.Get(ctx)
+	require.ErrorContains(t, err, "status 401")
+}
+
+// fakeLeader is a LeaderElector of a fixed leadership.
+type fakeLeader struct {
+	leader bool
+}
+
+func (l *fakeLeader) Run(ctx context.Context) {
+	<-ctx.Done()
+}
+
+func (l *fakeLeader) IsLeader() bool {
+	return l.leader
+}
+
+func TestGuardianShadowMode(t *testing.T) {
+	const l2BlockNumber = 100
+	localOutputRoot := eth.Bytes32{0xaa}
+	transactionId := big.NewInt(7)
+	self := common.Address{0x01}
+
+	tests := []struct {
+		name   string
+		leader bool
+		// members are the members that confirmed the request on chain
+		members []common.Address
+		// confirmBy are the members that confirmed the request after the first validation
+		confirmBy     []common.Address
+		expectConfirm bool
+		expectOutcome GuardianOutcome
+	}{
+		{
+			name:          "leader confirms",
+			leader:        true,
+			members:       []common.Address{{0x02}},
+			expectConfirm: true,
+			expectOutcome: GuardianOutcomeQueued,
+		},
+		{
+			name:          "leader does not confirm twice after failover",
+			leader:        true,
+			members:       []common.Address{self},
+			expectOutcome: GuardianOutcomeConfirmedByPeer,
+		},
+		{
+			name:          "standby waits for the leader to confirm",
+			members:       []common.Address{{0x02}},
+			confirmBy:     []common.Address{{0x02}, self},
+			expectOutcome: GuardianOutcomeConfirmedByPeer,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			rollupClient := &fakeRollupClient{outputRoot: localOutputRoot, blockNumber: l2BlockNumber}
+			council := &fakeSecurityCouncil{members: test.members}
+			g, candidates := newTestGuardian(t, rollupClient, council)
+			g.leader = &fakeLeader{leader: test.leader}
+			store, err := NewGuardianStore(filepath.Join(t.TempDir(), "store"))
+			require.NoError(t, err)
+			defer store.Close()
+			g.store = store
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			done := make(chan struct{})
+			g.wg.Add(1)
+			go func() {
+				g.processOutputValidation(ctx, &bindings.SecurityCouncilValidationRequested{
+					TransactionId: transactionId,
+					OutputRoot:    localOutputRoot,
+					L2BlockNumber: big.NewInt(l2BlockNumber),
+				})
+				close(done)
+			}()
+
+			if test.confirmBy != nil {
+				require.Eventually(t, func() bool {
+					outputCalls, _ := rollupClient.calls()
+					return outputCalls >= 2
+				}, 5*time.Second, 5*time.Millisecond, "standby keeps validating")
+				council.mu.Lock()
+				council.members = test.confirmBy
+				council.mu.Unlock()
+			}
+
+			select {
+			case <-done:
+			case <-time.After(5 * time.Second):
+				t.Fatal("output validation did not finish")
+			}
+
+			if test.expectConfirm {
+				require.Len(t, candidates, 1)
+				require.Equal(t, []*big.Int{transactionId}, council.confirmations())
+			} else {
+				require.Empty(t, candidates)
+				require.Empty(t, council.confirmations())
+			}
+			decision, err := store.Decision(context.Background(), transactionId)
+			require.NoError(t, err)
+			require.NotNil(t, decision)
+			require.Equal(t, test.expectOutcome, decision.Outcome)
+		})
+	}
+}
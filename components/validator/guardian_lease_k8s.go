@@ -0,0 +1,210 @@
+package validator
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// k8sServiceAccountDir is the directory the service account of a pod is mounted at.
+const k8sServiceAccountDir = "/var/run/secrets/kubernetes.io/serviceaccount"
+
+// k8sMicroTimeLayout is the layout of the MicroTime timestamps of a Lease.
+const k8sMicroTimeLayout = "2006-01-02T15:04:05.000000Z07:00"
+
+// KubernetesLeaseLock is a LeaseLock of a coordination.k8s.io/v1 Lease, accessed through the API server of the
+// cluster the guardian runs in, with the token of the service account of its pod. The service account must be
+// allowed to get, create and update the Lease.
+type KubernetesLeaseLock struct {
+	server    string
+	namespace string
+	name      string
+	tokenFile string
+	client    *http.Client
+}
+
+var _ LeaseLock = (*KubernetesLeaseLock)(nil)
+
+// NewKubernetesLeaseLock creates the lock of the Lease of the name, in the namespace of the service account
+// of the pod if the namespace is empty.
+func NewKubernetesLeaseLock(namespace, name string) (*KubernetesLeaseLock, error) {
+	host, port := os.Getenv("KUBERNETES_SERVICE_HOST"), os.Getenv("KUBERNETES_SERVICE_PORT")
+	if host == "" || port == "" {
+		return nil, errors.New("not running in a kubernetes cluster, KUBERNETES_SERVICE_HOST and KUBERNETES_SERVICE_PORT are not set")
+	}
+	if namespace == "" {
+		data, err := os.ReadFile(filepath.Join(k8sServiceAccountDir, "namespace"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the namespace of the service account: %w", err)
+		}
+		namespace = strings.TrimSpace(string(data))
+	}
+	ca, err := os.ReadFile(filepath.Join(k8sServiceAccountDir, "ca.crt"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the CA of the API server: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(ca) {
+		return nil, errors.New("failed to parse the CA of the API server")
+	}
+	return &KubernetesLeaseLock{
+		server:    "https://" + net.JoinHostPort(host, port),
+		namespace: namespace,
+		name:      name,
+		tokenFile: filepath.Join(k8sServiceAccountDir, "token"),
+		client: &http.Client{
+			Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}},
+		},
+	}, nil
+}
+
+type k8sLease struct {
+	APIVersion string       `json:"apiVersion"`
+	Kind       string       `json:"kind"`
+	Metadata   k8sLeaseMeta `json:"metadata"`
+	Spec       k8sLeaseSpec `json:"spec"`
+}
+
+type k8sLeaseMeta struct {
+	Name            string `json:"name"`
+	Namespace       string `json:"namespace"`
+	ResourceVersion string `json:"resourceVersion,omitempty"`
+}
+
+type k8sLeaseSpec struct {
+	HolderIdentity       string `json:"holderIdentity"`
+	LeaseDurationSeconds int    `json:"leaseDurationSeconds,omitempty"`
+	AcquireTime          string `json:"acquireTime,omitempty"`
+	RenewTime            string `json:"renewTime,omitempty"`
+	LeaseTransitions     int    `json:"leaseTransitions"`
+}
+
+func (l *KubernetesLeaseLock) Get(ctx context.Context) (*LeaseRecord, string, error) {
+	var lease k8sLease
+	status, err := l.do(ctx, http.MethodGet, l.leaseURL(), nil, &lease)
+	if status == http.StatusNotFound {
+		return nil, "", nil
+	} else if err != nil {
+		return nil, "", err
+	}
+	record := &LeaseRecord{
+		HolderIdentity: lease.Spec.HolderIdentity,
+		LeaseDuration:  time.Duration(lease.Spec.LeaseDurationSeconds) * time.Second,
+		Transitions:    lease.Spec.LeaseTransitions,
+	}
+	if record.AcquireTime, err = parseK8sTime(lease.Spec.AcquireTime); err != nil {
+		return nil, "", fmt.Errorf("invalid acquire time of lease: %w", err)
+	}
+	if record.RenewTime, err = parseK8sTime(lease.Spec.RenewTime); err != nil {
+		return nil, "", fmt.Errorf("invalid renew time of lease: %w", err)
+	}
+	return record, lease.Metadata.ResourceVersion, nil
+}
+
+func (l *KubernetesLeaseLock) Create(ctx context.Context, record LeaseRecord) error {
+	_, err := l.do(ctx, http.MethodPost, l.leasesURL(), l.lease(record, ""), nil)
+	return err
+}
+
+func (l *KubernetesLeaseLock) Update(ctx context.Context, record LeaseRecord, version string) error {
+	_, err := l.do(ctx, http.MethodPut, l.leaseURL(), l.lease(record, version), nil)
+	return err
+}
+
+func (l *KubernetesLeaseLock) Describe() string {
+	return l.namespace + "/" + l.name
+}
+
+func (l *KubernetesLeaseLock) leasesURL() string {
+	return fmt.Sprintf("%s/apis/coordination.k8s.io/v1/namespaces/%s/leases", l.server, l.namespace)
+}
+
+func (l *KubernetesLeaseLock) leaseURL() string {
+	return l.leasesURL() + "/" + l.name
+}
+
+func (l *KubernetesLeaseLock) lease(record LeaseRecord, version string) k8sLease {
+	return k8sLease{
+		APIVersion: "coordination.k8s.io/v1",
+		Kind:       "Lease",
+		Metadata:   k8sLeaseMeta{Name: l.name, Namespace: l.namespace, ResourceVersion: version},
+		Spec: k8sLeaseSpec{
+			HolderIdentity: record.HolderIdentity,
+			// the duration is in seconds, rounded up to not expire the lease early
+			LeaseDurationSeconds: int((record.LeaseDuration + time.Second - 1) / time.Second),
+			AcquireTime:          formatK8sTime(record.AcquireTime),
+			RenewTime:            formatK8sTime(record.RenewTime),
+			LeaseTransitions:     record.Transitions,
+		},
+	}
+}
+
+// do sends the request with the token of the service account, and decodes the response into out if not nil.
+// It returns the status code of the response, and ErrLeaseConflict if the lease was changed concurrently.
+func (l *KubernetesLeaseLock) do(ctx context.Context, method, url string, body any, out any) (int, error) {
+	var reqBody io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return 0, fmt.Errorf("failed to encode lease: %w", err)
+		}
+		reqBody = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create lease request: %w", err)
+	}
+	// the token is read on every request, since the tokens of the service accounts are rotated
+	token, err := os.ReadFile(l.tokenFile)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read the token of the service account: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := l.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to send lease request: %w", err)
+	}
+	defer resp.Body.Close()
+	switch {
+	case resp.StatusCode == http.StatusConflict:
+		return resp.StatusCode, ErrLeaseConflict
+	case resp.StatusCode < 200 || resp.StatusCode >= 300:
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return resp.StatusCode, fmt.Errorf("lease request %s %s failed with status %d: %s", method, url, resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return resp.StatusCode, fmt.Errorf("failed to decode lease: %w", err)
+		}
+	}
+	return resp.StatusCode, nil
+}
+
+func formatK8sTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(k8sMicroTimeLayout)
+}
+
+func parseK8sTime(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339Nano, s)
+}
@@ -0,0 +1,112 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/kroma-network/kroma/components/node/eth"
+	"github.com/kroma-network/kroma/components/validator/metrics"
+)
+
+// defaultNodeLagCheckInterval is the interval at which the lag of the rollup node is checked.
+const defaultNodeLagCheckInterval = time.Minute
+
+// nodeLag returns the number of L1 blocks the derivation of the rollup node lags behind the L1 head it knows of.
+// A synced node derives from the L1 head, so its lag is 0.
+func nodeLag(status *eth.SyncStatus) uint64 {
+	if status.CurrentL1.Number >= status.HeadL1.Number {
+		return 0
+	}
+	return status.HeadL1.Number - status.CurrentL1.Number
+}
+
+// nodeLagMonitor periodically checks how far the derivation of the rollup node lags behind the L1 head. A node
+// lagging beyond the max lag is not trusted to confirm the validation requests, since it may not have derived the
+// batches changing the requested outputs yet, until the lag recovers.
+type nodeLagMonitor struct {
+	log            log.Logger
+	metr           metrics.Metricer
+	rollupClient   SyncStatusProvider
+	maxLag         uint64
+	interval       time.Duration
+	networkTimeout time.Duration
+
+	mu       sync.Mutex
+	lag      uint64
+	exceeded bool // whether the last lag exceeded the max lag
+}
+
+func newNodeLagMonitor(l log.Logger, m metrics.Metricer, rollupClient SyncStatusProvider, maxLag uint64, networkTimeout time.Duration) *nodeLagMonitor {
+	return &nodeLagMonitor{
+		log:            l,
+		metr:           m,
+		rollupClient:   rollupClient,
+		maxLag:         maxLag,
+		interval:       defaultNodeLagCheckInterval,
+		networkTimeout: networkTimeout,
+	}
+}
+
+func (n *nodeLagMonitor) Start(ctx context.Context, wg *sync.WaitGroup) {
+	// check once before starting, so that no request is confirmed by a lagging node.
+	n.check(ctx)
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(n.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				n.check(ctx)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// Exceeded returns whether the last checked lag of the rollup node exceeded the max lag, and the lag.
+func (n *nodeLagMonitor) Exceeded() (bool, uint64) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.exceeded, n.lag
+}
+
+// check measures the lag of the rollup node. If the node is unavailable, its last lag is kept.
+func (n *nodeLagMonitor) check(ctx context.Context) {
+	lag, err := n.fetchLag(ctx)
+	if err != nil {
+		n.log.Warn("failed to check lag of the rollup node", "err", err)
+		return
+	}
+	n.metr.RecordGuardianNodeLag(lag)
+	exceeded := lag > n.maxLag
+	if exceeded {
+		n.log.Warn("rollup node derivation lags behind the L1 head beyond the max lag, not confirming validation requests",
+			"lag", lag, "maxLag", n.maxLag)
+	}
+
+	n.mu.Lock()
+	prev := n.exceeded
+	n.lag, n.exceeded = lag, exceeded
+	n.mu.Unlock()
+	if prev && !exceeded {
+		n.log.Info("rollup node lag recovered", "lag", lag, "maxLag", n.maxLag)
+	}
+}
+
+func (n *nodeLagMonitor) fetchLag(ctx context.Context) (uint64, error) {
+	cCtx, cCancel := context.WithTimeout(ctx, n.networkTimeout)
+	defer cCancel()
+	status, err := n.rollupClient.SyncStatus(cCtx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get sync status: %w", err)
+	}
+	return nodeLag(status), nil
+}
@@ -0,0 +1,143 @@
+package validator
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kroma-network/kroma/bindings/bindings"
+	"github.com/kroma-network/kroma/components/node/eth"
+	"github.com/kroma-network/kroma/components/node/testlog"
+	"github.com/kroma-network/kroma/components/validator/metrics"
+)
+
+// nodeLagStatus returns the sync status of a node deriving from the current L1 block, at the L1 head.
+func nodeLagStatus(current uint64, head uint64) *eth.SyncStatus {
+	return &eth.SyncStatus{
+		CurrentL1:   eth.L1BlockRef{Number: current},
+		HeadL1:      eth.L1BlockRef{Number: head},
+		FinalizedL1: eth.L1BlockRef{Number: head - 64},
+	}
+}
+
+func TestNodeLag(t *testing.T) {
+	require.Equal(t, uint64(0), nodeLag(nodeLagStatus(120, 100)), "head of the node behind its derivation")
+	require.Equal(t, uint64(0), nodeLag(nodeLagStatus(100, 100)), "synced node")
+	require.Equal(t, uint64(30), nodeLag(nodeLagStatus(70, 100)))
+}
+
+func TestNodeLagMonitor(t *testing.T) {
+	rollupClient := &fakeSyncStatus{status: nodeLagStatus(120, 100)}
+	n := newNodeLagMonitor(testlog.Logger(t, log.LvlCrit), metrics.NoopMetrics, rollupClient, 64, time.Second)
+
+	n.check(context.Background())
+	exceeded, _ := n.Exceeded()
+	require.False(t, exceeded)
+
+	rollupClient.status = nodeLagStatus(30, 100)
+	n.check(context.Background())
+	exceeded, lag := n.Exceeded()
+	require.True(t, exceeded)
+	require.Equal(t, uint64(70), lag)
+
+	// the last lag is kept while the rollup node is unavailable
+	rollupClient.err = errFakeRpc
+	n.check(context.Background())
+	exceeded, _ = n.Exceeded()
+	require.True(t, exceeded)
+
+	rollupClient.status, rollupClient.err = nodeLagStatus(40, 100), nil
+	n.check(context.Background())
+	exceeded, _ = n.Exceeded()
+	require.False(t, exceeded)
+}
+
+func TestGuardianRefusesConfirmationWhileNodeLags(t *testing.T) {
+	const l2BlockNumber = 100
+	localOutputRoot := eth.Bytes32{0xaa}
+
+	rollupClient := &fakeRollupClient{outputRoot: localOutputRoot, blockNumber: l2BlockNumber}
+	g, candidates := newTestGuardian(t, rollupClient, &fakeSecurityCouncil{})
+	sink := &fakeAlertSink{}
+	g.cfg.GuardianAlertSinks = []AlertSink{sink}
+	g.cfg.GuardianMaxRetries = 1
+	g.cfg.GuardianMaxNodeLag = 64
+	status := &fakeSyncStatus{status: nodeLagStatus(0, 100)}
+	g.nodeLag = newNodeLagMonitor(g.log, metrics.NoopMetrics, status, g.cfg.GuardianMaxNodeLag, time.Second)
+	g.nodeLag.check(context.Background())
+
+	event := &bindings.SecurityCouncilValidationRequested{
+		TransactionId: big.NewInt(7),
+		OutputRoot:    localOutputRoot,
+		L2BlockNumber: big.NewInt(l2BlockNumber),
+	}
+	done := make(chan struct{})
+	g.wg.Add(1)
+	go func() {
+		g.processOutputValidation(context.Background(), event)
+		close(done)
+	}()
+
+	// the refused attempts do not exhaust the retry budget
+	time.Sleep(100 * time.Millisecond)
+	require.Empty(t, candidates)
+	alerts := sink.delivered()
+	require.Len(t, alerts, 1, "the refused confirmation is alerted once")
+	require.Equal(t, GuardianAlertNodeLagging, alerts[0].Kind)
+	require.Equal(t, uint64(100), alerts[0].NodeLag)
+	require.Equal(t, uint64(64), alerts[0].MaxNodeLag)
+
+	status.status = nodeLagStatus(100, 100)
+	g.nodeLag.check(context.Background())
+	select {
+	case <-candidates:
+	case <-time.After(5 * time.Second):
+		t.Fatal("confirmation was not sent once the node lag recovered")
+	}
+	<-done
+}
+
+func TestGuardianBackfillWhileNodeLags(t *testing.T) {
+	const l2BlockNumber = 100
+	localOutputRoot := eth.Bytes32{0xaa}
+
+	rollupClient := &fakeRollupClient{outputRoot: localOutputRoot, blockNumber: l2BlockNumber}
+	council := &fakeSecurityCouncil{}
+	g, candidates := newTestGuardian(t, rollupClient, council)
+	g.cfg.GuardianMaxNodeLag = 64
+	status := &fakeSyncStatus{status: nodeLagStatus(0, 100)}
+	g.nodeLag = newNodeLagMonitor(g.log, metrics.NoopMetrics, status, g.cfg.GuardianMaxNodeLag, time.Second)
+	g.nodeLag.check(context.Background())
+
+	var events []*bindings.SecurityCouncilValidationRequested
+	for id := int64(1); id <= 2; id++ {
+		events = append(events, &bindings.SecurityCouncilValidationRequested{
+			TransactionId: big.NewInt(id),
+			OutputRoot:    localOutputRoot,
+			L2BlockNumber: big.NewInt(l2BlockNumber),
+		})
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	go g.confirmBatch(ctx, events, func() {})
+
+	// the batch is not confirmed by the lagging node
+	time.Sleep(100 * time.Millisecond)
+	require.Empty(t, candidates)
+	require.Empty(t, council.confirmations())
+
+	status.status = nodeLagStatus(100, 100)
+	g.nodeLag.check(context.Background())
+	for range events {
+		select {
+		case <-candidates:
+		case <-ctx.Done():
+			t.Fatal("confirmation was not sent once the node lag recovered")
+		}
+	}
+	g.wg.Wait()
+}
@@ -0,0 +1,205 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/kroma-network/kroma/bindings/bindings"
+	"github.com/kroma-network/kroma/bindings/predeploys"
+	"github.com/kroma-network/kroma/components/node/eth"
+	"github.com/kroma-network/kroma/components/node/rollup"
+	"github.com/kroma-network/kroma/utils"
+)
+
+// OutputRootProvider provides the local outputs that the Guardian validates the requested outputs against.
+type OutputRootProvider interface {
+	// SafeBlockNumber returns the latest L2 block number whose output can be validated.
+	SafeBlockNumber(ctx context.Context) (uint64, error)
+	// OutputAtBlock returns the local output at the L2 block number.
+	OutputAtBlock(ctx context.Context, blockNumber uint64) (*eth.OutputResponse, error)
+}
+
+// rollupOutputs provides the outputs of the rollup node, or of the quorum of rollup nodes.
+type rollupOutputs struct {
+	client GuardianRollupClient
+	// allowNonFinalized is whether the outputs up to the safe head are validated, up to the finalized head otherwise
+	allowNonFinalized bool
+}
+
+func (o rollupOutputs) SafeBlockNumber(ctx context.Context) (uint64, error) {
+	status, err := o.client.SyncStatus(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if o.allowNonFinalized {
+		return status.SafeL2.Number, nil
+	}
+	return status.FinalizedL2.Number, nil
+}
+
+func (o rollupOutputs) OutputAtBlock(ctx context.Context, blockNumber uint64) (*eth.OutputResponse, error) {
+	return o.client.OutputAtBlock(ctx, blockNumber)
+}
+
+// L2ExecutionClient is the set of L2 execution client RPC methods to recompute the outputs with.
+type L2ExecutionClient interface {
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	GetProof(ctx context.Context, address common.Address, storage []common.Hash, blockTag string) (*eth.AccountResult, error)
+	Close()
+}
+
+// executionOutputs recomputes the outputs from the state root, the withdrawal storage root and the block hashes of the
+// L2 execution client, the way the rollup node does, so that the outputs can be validated while the rollup node is
+// unreachable. The safe and finalized heads are the ones last set by the rollup node on the execution client.
+type executionOutputs struct {
+	client       L2ExecutionClient
+	rollupConfig *rollup.Config
+	// allowNonFinalized is whether the outputs up to the safe head are validated, up to the finalized head otherwise
+	allowNonFinalized bool
+}
+
+func newExecutionOutputs(client L2ExecutionClient, rollupConfig *rollup.Config, allowNonFinalized bool) *executionOutputs {
+	return &executionOutputs{
+		client:            client,
+		rollupConfig:      rollupConfig,
+		allowNonFinalized: allowNonFinalized,
+	}
+}
+
+// Close closes the client of the L2 execution client.
+func (o *executionOutputs) Close() error {
+	o.client.Close()
+	return nil
+}
+
+func (o *executionOutputs) SafeBlockNumber(ctx context.Context) (uint64, error) {
+	label, name := rpc.FinalizedBlockNumber, "finalized"
+	if o.allowNonFinalized {
+		label, name = rpc.SafeBlockNumber, "safe"
+	}
+	head, err := o.client.HeaderByNumber(ctx, big.NewInt(int64(label)))
+	if err != nil {
+		return 0, fmt.Errorf("failed to get %s L2 header: %w", name, err)
+	}
+	return head.Number.Uint64(), nil
+}
+
+func (o *executionOutputs) OutputAtBlock(ctx context.Context, blockNumber uint64) (*eth.OutputResponse, error) {
+	head, err := o.client.HeaderByNumber(ctx, new(big.Int).SetUint64(blockNumber))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get L2 header %d: %w", blockNumber, err)
+	}
+
+	proof, err := o.client.GetProof(ctx, predeploys.L2ToL1MessagePasserAddr, []common.Hash{}, head.Hash().String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get contract proof at block %d: %w", blockNumber, err)
+	}
+	// make sure that the withdrawal storage root is the one of the state root
+	if err := proof.Verify(head.Root); err != nil {
+		return nil, fmt.Errorf("invalid withdrawal root hash at block %d, state root was %s: %w", blockNumber, head.Root, err)
+	}
+
+	outputRootProof := &bindings.TypesOutputRootProof{
+		Version:                  rollup.L2OutputRootVersion(o.rollupConfig, head.Time),
+		StateRoot:                head.Root,
+		MessagePasserStorageRoot: proof.StorageHash,
+		BlockHash:                head.Hash(),
+	}
+	if outputRootProof.Version == rollup.V1 {
+		next, err := o.client.HeaderByNumber(ctx, new(big.Int).SetUint64(blockNumber+1))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get next L2 header %d: %w", blockNumber+1, err)
+		}
+		if next.ParentHash != head.Hash() {
+			return nil, fmt.Errorf("next L2 header %d does not extend block %s, the chain reorged", blockNumber+1, head.Hash())
+		}
+		outputRootProof.NextBlockHash = next.Hash()
+	}
+	outputRoot, err := rollup.ComputeL2OutputRoot(outputRootProof)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute output root at block %d: %w", blockNumber, err)
+	}
+
+	return &eth.OutputResponse{
+		Version:    outputRootProof.Version,
+		OutputRoot: outputRoot,
+		BlockRef: eth.L2BlockRef{
+			Hash:       head.Hash(),
+			Number:     blockNumber,
+			ParentHash: head.ParentHash,
+			Time:       head.Time,
+		},
+		WithdrawalStorageRoot: proof.StorageHash,
+		StateRoot:             head.Root,
+	}, nil
+}
+
+// l2ExecutionClient is the L2ExecutionClient of an L2 execution client RPC.
+type l2ExecutionClient struct {
+	*ethclient.Client
+	rpc *rpc.Client
+}
+
+// DialL2ExecutionClientWithTimeout dials the L2 execution client RPC, e.g. through the proxy, within the dial timeout.
+func DialL2ExecutionClientWithTimeout(ctx context.Context, url string, opts ...rpc.ClientOption) (L2ExecutionClient, error) {
+	ctx, cancel := context.WithTimeout(ctx, utils.DefaultDialTimeout)
+	defer cancel()
+
+	rpcCl, err := rpc.DialOptions(ctx, url, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &l2ExecutionClient{Client: ethclient.NewClient(rpcCl), rpc: rpcCl}, nil
+}
+
+func (c *l2ExecutionClient) GetProof(ctx context.Context, address common.Address, storage []common.Hash, blockTag string) (*eth.AccountResult, error) {
+	var res *eth.AccountResult
+	if err := c.rpc.CallContext(ctx, &res, "eth_getProof", address, storage, blockTag); err != nil {
+		return nil, err
+	}
+	if res == nil {
+		return nil, ethereum.NotFound
+	}
+	return res, nil
+}
+
+// validateOutput validates the requested output against the local output of the provider.
+func validateOutput(ctx context.Context, outputs OutputRootProvider, networkTimeout time.Duration, outputRoot eth.Bytes32, l2BlockNumber uint64) ValidationResult {
+	cCtx, cCancel := context.WithTimeout(ctx, networkTimeout)
+	safeBlockNumber, err := outputs.SafeBlockNumber(cCtx)
+	cCancel()
+	if err != nil {
+		return ValidationResult{Reason: ValidationReasonRPCError, Err: fmt.Errorf("failed to get safe L2 block number: %w", err)}
+	}
+	if l2BlockNumber > safeBlockNumber {
+		return ValidationResult{Reason: ValidationReasonNodeBehind, SafeBlockNumber: safeBlockNumber}
+	}
+
+	cCtx, cCancel = context.WithTimeout(ctx, networkTimeout)
+	defer cCancel()
+	output, err := outputs.OutputAtBlock(cCtx, l2BlockNumber)
+	if errors.Is(err, ErrOutputQuorum) {
+		return ValidationResult{Reason: ValidationReasonNoQuorum, SafeBlockNumber: safeBlockNumber, Err: err}
+	} else if err != nil {
+		return ValidationResult{Reason: ValidationReasonRPCError, SafeBlockNumber: safeBlockNumber, Err: fmt.Errorf("failed to get outputRootAtBlock: %w", err)}
+	}
+	result := ValidationResult{LocalOutputRoot: output.OutputRoot, SafeBlockNumber: safeBlockNumber}
+	switch {
+	case output.Version != rollup.V0 && output.Version != rollup.V1:
+		result.Reason = ValidationReasonVersionUnknown
+	case outputRoot == output.OutputRoot:
+		result.Reason = ValidationReasonValid
+	default:
+		result.Reason = ValidationReasonMismatch
+	}
+	return result
+}
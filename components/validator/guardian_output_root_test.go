@@ -0,0 +1,219 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kroma-network/kroma/bindings/bindings"
+	"github.com/kroma-network/kroma/bindings/predeploys"
+	"github.com/kroma-network/kroma/components/node/eth"
+	"github.com/kroma-network/kroma/components/node/rollup"
+)
+
+// fakeL2ExecutionClient serves a chain of headers, up to the safe head, whose state roots hold the
+// L2ToL1MessagePasser with withdrawalRoot as storage root.
+type fakeL2ExecutionClient struct {
+	headers        []*types.Header
+	proof          *eth.AccountResult
+	headerFailures bool
+	closed         bool
+}
+
+func newFakeL2ExecutionClient(t *testing.T, length int, timestamp uint64) *fakeL2ExecutionClient {
+	withdrawalRoot := common.Hash{0x77}
+	stateRoot, proof := messagePasserProof(t, withdrawalRoot)
+
+	c := &fakeL2ExecutionClient{proof: proof}
+	var parent common.Hash
+	for i := 0; i < length; i++ {
+		h := &types.Header{ParentHash: parent, Number: big.NewInt(int64(i)), Root: stateRoot, Time: timestamp}
+		c.headers = append(c.headers, h)
+		parent = h.Hash()
+	}
+	return c
+}
+
+// messagePasserProof returns the state root of a state holding only the L2ToL1MessagePasser, and its account proof.
+func messagePasserProof(t *testing.T, storageHash common.Hash) (common.Hash, *eth.AccountResult) {
+	res := &eth.AccountResult{
+		Address:     predeploys.L2ToL1MessagePasserAddr,
+		Balance:     (*hexutil.Big)(big.NewInt(0)),
+		CodeHash:    common.Hash{0xcc},
+		Nonce:       1,
+		StorageHash: storageHash,
+	}
+	account, err := rlp.EncodeToBytes([]any{uint64(res.Nonce), res.Balance.ToInt().Bytes(), res.StorageHash, res.CodeHash})
+	require.NoError(t, err)
+
+	tr := trie.NewEmpty(trie.NewDatabase(rawdb.NewMemoryDatabase()))
+	key := crypto.Keccak256(res.Address[:])
+	tr.Update(key, account)
+	proofDb := memorydb.New()
+	require.NoError(t, tr.Prove(key, 0, proofDb))
+	it := proofDb.NewIterator(nil, nil)
+	defer it.Release()
+	for it.Next() {
+		res.AccountProof = append(res.AccountProof, common.CopyBytes(it.Value()))
+	}
+	return tr.Hash(), res
+}
+
+func (c *fakeL2ExecutionClient) HeaderByNumber(_ context.Context, number *big.Int) (*types.Header, error) {
+	if c.headerFailures {
+		return nil, errFakeRpc
+	}
+	switch number.Int64() {
+	case int64(rpc.SafeBlockNumber), int64(rpc.FinalizedBlockNumber):
+		return c.headers[len(c.headers)-1], nil
+	}
+	if number.Uint64() >= uint64(len(c.headers)) {
+		return nil, errors.New("not found")
+	}
+	return c.headers[number.Uint64()], nil
+}
+
+func (c *fakeL2ExecutionClient) Close() {
+	c.closed = true
+}
+
+func (c *fakeL2ExecutionClient) GetProof(_ context.Context, address common.Address, _ []common.Hash, blockTag string) (*eth.AccountResult, error) {
+	if address != predeploys.L2ToL1MessagePasserAddr {
+		return nil, errors.New("unexpected account")
+	}
+	for _, h := range c.headers {
+		if h.Hash().String() == blockTag {
+			return c.proof, nil
+		}
+	}
+	return nil, errors.New("unknown block")
+}
+
+func TestExecutionOutputs(t *testing.T) {
+	blueTime := uint64(100)
+	rollupConfig := &rollup.Config{BlueTime: &blueTime}
+
+	t.Run("v0", func(t *testing.T) {
+		client := newFakeL2ExecutionClient(t, 3, 50)
+		outputs := newExecutionOutputs(client, rollupConfig, true)
+
+		safe, err := outputs.SafeBlockNumber(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, uint64(2), safe)
+
+		output, err := outputs.OutputAtBlock(context.Background(), 1)
+		require.NoError(t, err)
+		head := client.headers[1]
+		expected, err := rollup.ComputeL2OutputRoot(&bindings.TypesOutputRootProof{
+			Version:                  rollup.V0,
+			StateRoot:                head.Root,
+			MessagePasserStorageRoot: client.proof.StorageHash,
+			BlockHash:                head.Hash(),
+		})
+		require.NoError(t, err)
+		require.Equal(t, eth.Bytes32(rollup.V0), output.Version)
+		require.Equal(t, expected, output.OutputRoot)
+		require.Equal(t, head.Hash(), output.BlockRef.Hash)
+		require.Equal(t, client.proof.StorageHash, output.WithdrawalStorageRoot)
+	})
+
+	t.Run("v1", func(t *testing.T) {
+		client := newFakeL2ExecutionClient(t, 3, blueTime)
+		outputs := newExecutionOutputs(client, rollupConfig, true)
+
+		output, err := outputs.OutputAtBlock(context.Background(), 1)
+		require.NoError(t, err)
+		head := client.headers[1]
+		expected, err := rollup.ComputeL2OutputRoot(&bindings.TypesOutputRootProof{
+			Version:                  rollup.V1,
+			StateRoot:                head.Root,
+			MessagePasserStorageRoot: client.proof.StorageHash,
+			BlockHash:                head.Hash(),
+			NextBlockHash:            client.headers[2].Hash(),
+		})
+		require.NoError(t, err)
+		require.Equal(t, eth.Bytes32(rollup.V1), output.Version)
+		require.Equal(t, expected, output.OutputRoot)
+
+		_, err = outputs.OutputAtBlock(context.Background(), 2)
+		require.ErrorContains(t, err, "failed to get next L2 header", "next block is not known yet")
+	})
+
+	t.Run("invalid proof", func(t *testing.T) {
+		client := newFakeL2ExecutionClient(t, 3, 50)
+		client.headers[1].Root = common.Hash{0x01}
+		outputs := newExecutionOutputs(client, rollupConfig, true)
+
+		_, err := outputs.OutputAtBlock(context.Background(), 1)
+		require.ErrorContains(t, err, "invalid withdrawal root hash")
+	})
+}
+
+func TestGuardianValidateL2OutputFallback(t *testing.T) {
+	const l2BlockNumber = 1
+	client := newFakeL2ExecutionClient(t, 3, 50)
+	fallback := newExecutionOutputs(client, &rollup.Config{}, true)
+	localOutput, err := fallback.OutputAtBlock(context.Background(), l2BlockNumber)
+	require.NoError(t, err)
+
+	newGuardian := func(t *testing.T, rollupClient *fakeRollupClient) *Guardian {
+		rollupClient.outputRoot = localOutput.OutputRoot
+		rollupClient.blockNumber = l2BlockNumber
+		g, _ := newTestGuardian(t, rollupClient, &fakeSecurityCouncil{})
+		g.cfg.GuardianOutputFallback = fallback
+		return g
+	}
+
+	t.Run("rollup node reachable", func(t *testing.T) {
+		g := newGuardian(t, &fakeRollupClient{})
+		result := g.ValidateL2Output(context.Background(), eth.Bytes32{0xbb}, l2BlockNumber)
+		require.Equal(t, ValidationReasonMismatch, result.Reason)
+		require.False(t, result.Fallback)
+	})
+
+	t.Run("rollup node unreachable", func(t *testing.T) {
+		g := newGuardian(t, &fakeRollupClient{syncFailures: alwaysFail})
+		result := g.ValidateL2Output(context.Background(), localOutput.OutputRoot, l2BlockNumber)
+		require.Equal(t, ValidationReasonValid, result.Reason)
+		require.True(t, result.Fallback)
+		require.NoError(t, result.Err)
+
+		result = g.ValidateL2Output(context.Background(), eth.Bytes32{0xbb}, l2BlockNumber)
+		require.Equal(t, ValidationReasonMismatch, result.Reason)
+		require.True(t, result.Fallback)
+	})
+
+	t.Run("fallback unreachable", func(t *testing.T) {
+		g := newGuardian(t, &fakeRollupClient{outputFailures: alwaysFail})
+		client.headerFailures = true
+		defer func() { client.headerFailures = false }()
+		result := g.ValidateL2Output(context.Background(), localOutput.OutputRoot, l2BlockNumber)
+		require.Equal(t, ValidationReasonRPCError, result.Reason)
+		require.True(t, result.Fallback)
+		require.ErrorIs(t, result.Err, errFakeRpc)
+		require.ErrorContains(t, result.Err, "the fallback failed")
+	})
+}
+
+func TestGuardianClosesOutputFallback(t *testing.T) {
+	client := newFakeL2ExecutionClient(t, 3, 50)
+	g, _ := newTestGuardian(t, &fakeRollupClient{}, &fakeSecurityCouncil{})
+	g.cfg.GuardianOutputFallback = newExecutionOutputs(client, &rollup.Config{}, true)
+	g.cancel = func() {}
+	g.validationRequestedChan = make(chan *bindings.SecurityCouncilValidationRequested)
+
+	require.NoError(t, g.Stop())
+	require.True(t, client.closed, "the L2 execution client must be closed on stop")
+}
@@ -0,0 +1,121 @@
+package validator
+
+import (
+	"container/heap"
+	"context"
+	"sync"
+
+	"github.com/kroma-network/kroma/bindings/bindings"
+	"github.com/kroma-network/kroma/components/validator/metrics"
+)
+
+// defaultGuardianMaxConcurrentValidations is used if no limit of the concurrent validations is configured.
+const defaultGuardianMaxConcurrentValidations = 16
+
+// validationHeap is a heap of validation requests, ordered by the L2 block number and then the transaction id,
+// so that the requests of the older outputs, which are closer to their deadlines, are validated first.
+type validationHeap []*bindings.SecurityCouncilValidationRequested
+
+func (h validationHeap) Len() int { return len(h) }
+
+func (h validationHeap) Less(i, j int) bool {
+	if c := h[i].L2BlockNumber.Cmp(h[j].L2BlockNumber); c != 0 {
+		return c < 0
+	}
+	return h[i].TransactionId.Cmp(h[j].TransactionId) < 0
+}
+
+func (h validationHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *validationHeap) Push(x any) {
+	*h = append(*h, x.(*bindings.SecurityCouncilValidationRequested))
+}
+
+func (h *validationHeap) Pop() any {
+	old := *h
+	n := len(old)
+	x := old[n-1]
+	old[n-1] = nil
+	*h = old[:n-1]
+	return x
+}
+
+// validationQueue is the prioritized queue of the validation requests waiting for one of a bounded number of workers.
+// The workers are started on demand, and exit once the queue is empty.
+type validationQueue struct {
+	metr metrics.Metricer
+
+	mu         sync.Mutex
+	queue      validationHeap
+	workers    int
+	maxWorkers int
+}
+
+func newValidationQueue(m metrics.Metricer, maxWorkers int) *validationQueue {
+	if maxWorkers <= 0 {
+		maxWorkers = defaultGuardianMaxConcurrentValidations
+	}
+	return &validationQueue{metr: m, maxWorkers: maxWorkers}
+}
+
+// push queues the request, and returns whether a new worker must be started to validate it.
+func (q *validationQueue) push(event *bindings.SecurityCouncilValidationRequested) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	heap.Push(&q.queue, event)
+	q.metr.RecordGuardianQueuedValidations(len(q.queue))
+	if q.workers >= q.maxWorkers {
+		return false
+	}
+	q.workers++
+	return true
+}
+
+// pop returns the request of the highest priority, or nil if the queue is empty, in which case the calling worker
+// must exit.
+func (q *validationQueue) pop() *bindings.SecurityCouncilValidationRequested {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if len(q.queue) == 0 {
+		q.workers--
+		return nil
+	}
+	event := heap.Pop(&q.queue).(*bindings.SecurityCouncilValidationRequested)
+	q.metr.RecordGuardianQueuedValidations(len(q.queue))
+	return event
+}
+
+// queued returns the transaction ids of the queued requests.
+func (q *validationQueue) queued() map[string]bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	ids := make(map[string]bool, len(q.queue))
+	for _, event := range q.queue {
+		ids[event.TransactionId.String()] = true
+	}
+	return ids
+}
+
+// enqueueValidation queues the validation of the request, which must be recorded as in flight by beginRequest.
+func (g *Guardian) enqueueValidation(ctx context.Context, event *bindings.SecurityCouncilValidationRequested) {
+	if !g.validations.push(event) {
+		g.log.Debug("all validation workers are busy, queued validation request", "transactionId", event.TransactionId,
+			"l2BlockNumber", event.L2BlockNumber)
+		return
+	}
+	g.wg.Add(1)
+	go g.validationWorker(ctx)
+}
+
+// validationWorker validates the queued requests one at a time, until the queue is empty.
+func (g *Guardian) validationWorker(ctx context.Context) {
+	defer g.wg.Done()
+	for {
+		event := g.validations.pop()
+		if event == nil {
+			return
+		}
+		g.wg.Add(1)
+		g.processOutputValidation(ctx, event)
+	}
+}
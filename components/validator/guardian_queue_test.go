@@ -0,0 +1,37 @@
+package validator
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kroma-network/kroma/bindings/bindings"
+	"github.com/kroma-network/kroma/components/validator/metrics"
+)
+
+func TestValidationQueue(t *testing.T) {
+	request := func(transactionId, l2BlockNumber int64) *bindings.SecurityCouncilValidationRequested {
+		return &bindings.SecurityCouncilValidationRequested{
+			TransactionId: big.NewInt(transactionId),
+			L2BlockNumber: big.NewInt(l2BlockNumber),
+		}
+	}
+	q := newValidationQueue(metrics.NoopMetrics, 2)
+
+	require.True(t, q.push(request(1, 300)), "first worker is started")
+	require.True(t, q.push(request(2, 100)), "second worker is started")
+	require.False(t, q.push(request(3, 200)), "workers are bounded")
+	require.False(t, q.push(request(4, 100)))
+
+	var order []int64
+	for event := q.pop(); event != nil; event = q.pop() {
+		order = append(order, event.TransactionId.Int64())
+	}
+	require.Equal(t, []int64{2, 4, 3, 1}, order, "ordered by L2 block number, then transaction id")
+	require.Equal(t, 1, q.workers, "worker finding the queue empty exits")
+
+	require.True(t, q.push(request(5, 100)), "exited worker is started again")
+	require.False(t, q.push(request(6, 100)))
+	require.Equal(t, 2, q.workers)
+}
@@ -0,0 +1,138 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/kroma-network/kroma/bindings/bindings"
+	"github.com/kroma-network/kroma/components/node/eth"
+	"github.com/kroma-network/kroma/components/validator/metrics"
+	"github.com/kroma-network/kroma/utils/service/backoff"
+)
+
+// guardianRetryQueueSize is the number of dead letters that can be queued for a retry at once.
+const guardianRetryQueueSize = 16
+
+// ErrUnknownDeadLetter is returned when retrying a request that is not in the dead letter queue.
+var ErrUnknownDeadLetter = errors.New("unknown dead letter")
+
+// newGuardianRetryStrategy returns the backoff of the retries of the failed validation attempts of a request:
+// doubling from 2s up to 5m, with up to 1s of jitter.
+func newGuardianRetryStrategy() backoff.Strategy {
+	return &backoff.ExponentialStrategy{
+		Max:       float64(5 * time.Minute / time.Millisecond),
+		MaxJitter: 1000,
+	}
+}
+
+// GuardianDeadLetter is a validation request whose validation exhausted its retry budget.
+type GuardianDeadLetter struct {
+	TransactionId *big.Int    `json:"transactionId"`
+	L1Block       uint64      `json:"l1Block"`
+	L2BlockNumber *big.Int    `json:"l2BlockNumber"`
+	OutputRoot    eth.Bytes32 `json:"outputRoot"`
+	// Attempts is the number of failed validation attempts.
+	Attempts  int       `json:"attempts"`
+	LastError string    `json:"lastError"`
+	Time      time.Time `json:"time"`
+
+	event *bindings.SecurityCouncilValidationRequested
+}
+
+// GuardianDeadLetters is the dead letter queue of the validation requests that exhausted their retry budget,
+// surfaced by the GuardianAPI, until they are retried. All methods are no-ops on a nil GuardianDeadLetters.
+type GuardianDeadLetters struct {
+	metr metrics.Metricer
+
+	mu      sync.Mutex
+	letters map[string]*GuardianDeadLetter
+	retries chan *bindings.SecurityCouncilValidationRequested
+}
+
+func NewGuardianDeadLetters(m metrics.Metricer) *GuardianDeadLetters {
+	return &GuardianDeadLetters{
+		metr:    m,
+		letters: make(map[string]*GuardianDeadLetter),
+		retries: make(chan *bindings.SecurityCouncilValidationRequested, guardianRetryQueueSize),
+	}
+}
+
+// List returns the dead letters, in the order of their transaction ids.
+func (q *GuardianDeadLetters) List() []GuardianDeadLetter {
+	if q == nil {
+		return nil
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	letters := make([]GuardianDeadLetter, 0, len(q.letters))
+	for _, letter := range q.letters {
+		letters = append(letters, *letter)
+	}
+	sort.Slice(letters, func(i, j int) bool { return letters[i].TransactionId.Cmp(letters[j].TransactionId) < 0 })
+	return letters
+}
+
+// Retry removes the dead letter of the request, and queues the request to be validated again by the guardian.
+func (q *GuardianDeadLetters) Retry(transactionId *big.Int) error {
+	if q == nil {
+		return ErrUnknownDeadLetter
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	letter, ok := q.letters[transactionId.String()]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownDeadLetter, transactionId)
+	}
+	select {
+	case q.retries <- letter.event:
+	default:
+		return errors.New("too many retries queued")
+	}
+	delete(q.letters, transactionId.String())
+	q.metr.RecordGuardianDeadLetters(len(q.letters))
+	return nil
+}
+
+func (q *GuardianDeadLetters) add(event *bindings.SecurityCouncilValidationRequested, attempts int, err error) {
+	if q == nil {
+		return
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.letters[event.TransactionId.String()] = &GuardianDeadLetter{
+		TransactionId: event.TransactionId,
+		L1Block:       event.Raw.BlockNumber,
+		L2BlockNumber: event.L2BlockNumber,
+		OutputRoot:    event.OutputRoot,
+		Attempts:      attempts,
+		LastError:     err.Error(),
+		Time:          time.Now().UTC(),
+		event:         event,
+	}
+	q.metr.RecordGuardianDeadLetters(len(q.letters))
+}
+
+// retried returns the channel of the requests queued for a retry, nil on a nil GuardianDeadLetters.
+func (q *GuardianDeadLetters) retried() <-chan *bindings.SecurityCouncilValidationRequested {
+	if q == nil {
+		return nil
+	}
+	return q.retries
+}
+
+// exhaustRetries gives up the request once its validation attempts failed more often than the retry budget,
+// and moves it to the dead letter queue. It returns whether the request was given up.
+func (g *Guardian) exhaustRetries(event *bindings.SecurityCouncilValidationRequested, failures int, err error) bool {
+	if g.cfg.GuardianMaxRetries == 0 || failures <= g.cfg.GuardianMaxRetries {
+		return false
+	}
+	g.log.Error("giving up validation request after exhausting the retries", "err", err,
+		"transactionId", event.TransactionId, "l2BlockNumber", event.L2BlockNumber, "attempts", failures)
+	g.cfg.GuardianDeadLetters.add(event, failures, err)
+	g.recordDecision(event, GuardianOutcomeRetriesExhausted, nil)
+	return true
+}
@@ -0,0 +1,118 @@
+package validator
+
+import (
+	"context"
+	"math/big"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kroma-network/kroma/bindings/bindings"
+	"github.com/kroma-network/kroma/components/node/eth"
+	"github.com/kroma-network/kroma/components/validator/metrics"
+)
+
+func TestGuardianRetryStrategy(t *testing.T) {
+	strategy := newGuardianRetryStrategy()
+	for attempt := 1; attempt <= 8; attempt++ {
+		base := time.Duration(1<<attempt) * time.Second
+		d := strategy.Duration(attempt)
+		require.GreaterOrEqual(t, d, base)
+		require.Less(t, d, base+time.Second, "jitter is at most 1s")
+	}
+	require.Equal(t, 5*time.Minute, strategy.Duration(20), "backoff is capped")
+}
+
+func TestGuardianRetryBudget(t *testing.T) {
+	const l2BlockNumber = 100
+	localOutputRoot := eth.Bytes32{0xaa}
+	transactionId := big.NewInt(7)
+
+	tests := []struct {
+		name           string
+		outputFailures int
+		expectOutcome  GuardianOutcome
+		expectAttempts int
+	}{
+		{
+			name:           "transient errors recover",
+			outputFailures: 3,
+			expectOutcome:  GuardianOutcomeQueued,
+		},
+		{
+			name:           "retries exhausted",
+			outputFailures: alwaysFail,
+			expectOutcome:  GuardianOutcomeRetriesExhausted,
+			expectAttempts: 4,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			rollupClient := &fakeRollupClient{outputRoot: localOutputRoot, blockNumber: l2BlockNumber, outputFailures: test.outputFailures}
+			council := &fakeSecurityCouncil{}
+			g, candidates := newTestGuardian(t, rollupClient, council)
+			deadLetters := NewGuardianDeadLetters(metrics.NoopMetrics)
+			g.cfg.GuardianMaxRetries = 3
+			g.cfg.GuardianDeadLetters = deadLetters
+			store, err := NewGuardianStore(filepath.Join(t.TempDir(), "store"))
+			require.NoError(t, err)
+			defer store.Close()
+			g.store = store
+
+			event := &bindings.SecurityCouncilValidationRequested{
+				TransactionId: transactionId,
+				OutputRoot:    localOutputRoot,
+				L2BlockNumber: big.NewInt(l2BlockNumber),
+			}
+			done := make(chan struct{})
+			g.wg.Add(1)
+			go func() {
+				g.processOutputValidation(context.Background(), event)
+				close(done)
+			}()
+			select {
+			case <-done:
+			case <-time.After(5 * time.Second):
+				t.Fatal("output validation did not finish")
+			}
+
+			decision, err := store.Decision(context.Background(), transactionId)
+			require.NoError(t, err)
+			require.NotNil(t, decision)
+			require.Equal(t, test.expectOutcome, decision.Outcome)
+
+			api, adminAPI := NewGuardianAPI(g), NewGuardianAdminAPI(g)
+			if test.expectAttempts == 0 {
+				require.Len(t, candidates, 1)
+				require.Empty(t, api.DeadLetters(context.Background()))
+				return
+			}
+			require.Empty(t, candidates)
+			outputCalls, _ := rollupClient.calls()
+			require.Equal(t, test.expectAttempts, outputCalls)
+
+			letters := api.DeadLetters(context.Background())
+			require.Len(t, letters, 1)
+			require.Equal(t, transactionId, letters[0].TransactionId)
+			require.Equal(t, test.expectAttempts, letters[0].Attempts)
+			require.Contains(t, letters[0].LastError, errFakeRpc.Error())
+
+			require.ErrorIs(t, adminAPI.RetryDeadLetter(context.Background(), (*hexutil.Big)(big.NewInt(8))), ErrUnknownDeadLetter)
+			require.NoError(t, adminAPI.RetryDeadLetter(context.Background(), (*hexutil.Big)(transactionId)))
+			require.Empty(t, api.DeadLetters(context.Background()))
+			select {
+			case retried := <-deadLetters.retried():
+				require.Same(t, event, retried)
+			default:
+				t.Fatal("dead letter was not queued for a retry")
+			}
+		})
+	}
+}
@@ -0,0 +1,195 @@
+package validator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	ds "github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+	leveldb "github.com/ipfs/go-ds-leveldb"
+
+	"github.com/kroma-network/kroma/bindings/bindings"
+	"github.com/kroma-network/kroma/components/node/eth"
+)
+
+// guardianDecisionsKey is the key prefix of the decisions in the guardian store.
+var guardianDecisionsKey = ds.NewKey("/decisions")
+
+// GuardianOutcome is the outcome of the evaluation of a validation request.
+type GuardianOutcome string
+
+const (
+	// GuardianOutcomeQueued is a request whose confirmation was queued by the guardian, and not mined yet.
+	GuardianOutcomeQueued GuardianOutcome = "queued"
+	// GuardianOutcomeConfirmed is a request whose confirmation by the guardian was mined successfully.
+	GuardianOutcomeConfirmed GuardianOutcome = "confirmed"
+	// GuardianOutcomeFailed is a request whose confirmation by the guardian reverted or could not be sent.
+	GuardianOutcomeFailed GuardianOutcome = "failed"
+	// GuardianOutcomeAlreadyConfirmed is a request that reached the quorum of the SecurityCouncil before it was evaluated.
+	GuardianOutcomeAlreadyConfirmed GuardianOutcome = "already-confirmed"
+	// GuardianOutcomeConfirmedByPeer is a request that was confirmed by the key of the guardian, by the leader of the
+	// guardians sharing the key.
+	GuardianOutcomeConfirmedByPeer GuardianOutcome = "confirmed-by-peer"
+	// GuardianOutcomeMismatch is a request of an output root differing from the local output root.
+	GuardianOutcomeMismatch GuardianOutcome = "mismatch"
+	// GuardianOutcomeRevoked is a request of an output root differing from the local output root, whose earlier
+	// confirmation was revoked by the guardian.
+	GuardianOutcomeRevoked GuardianOutcome = "revoked"
+	// GuardianOutcomeMisaligned is a request of an L2 block number that is not an output checkpoint.
+	GuardianOutcomeMisaligned GuardianOutcome = "misaligned"
+	// GuardianOutcomeVersionUnknown is a request of an output the local node computes an unknown version of.
+	GuardianOutcomeVersionUnknown GuardianOutcome = "version-unknown"
+	// GuardianOutcomeTimedOut is a request of an L2 block the local node did not derive in time.
+	GuardianOutcomeTimedOut GuardianOutcome = "timed-out"
+	// GuardianOutcomeRetriesExhausted is a request whose validation attempts failed more often than the retry budget.
+	GuardianOutcomeRetriesExhausted GuardianOutcome = "retries-exhausted"
+	// GuardianOutcomeDryRun is a request of a valid output that was not confirmed, since the guardian runs dry.
+	GuardianOutcomeDryRun GuardianOutcome = "dry-run"
+)
+
+// Final returns whether the outcome is final, so the request is not evaluated again after a restart.
+// The requests given up on because of the local node or its connections are evaluated again, once they may
+// have been fixed, and so are the requests of a dry run, once the guardian signs, and the requests whose
+// confirmation failed. A queued confirmation is final, its transaction is checked by the recovery audit instead.
+func (o GuardianOutcome) Final() bool {
+	return o != GuardianOutcomeVersionUnknown && o != GuardianOutcomeTimedOut && o != GuardianOutcomeRetriesExhausted &&
+		o != GuardianOutcomeDryRun && o != GuardianOutcomeFailed
+}
+
+// GuardianDecision is the recorded evaluation of a SecurityCouncil validation request.
+type GuardianDecision struct {
+	TransactionId *big.Int    `json:"transactionId"`
+	L1Block       uint64      `json:"l1Block"`
+	L2BlockNumber *big.Int    `json:"l2BlockNumber"`
+	OutputRoot    eth.Bytes32 `json:"outputRoot"`
+	// LocalOutputRoot is the output root of the local node, nil if it was not fetched.
+	LocalOutputRoot *eth.Bytes32    `json:"localOutputRoot,omitempty"`
+	Outcome         GuardianOutcome `json:"outcome"`
+	Time            time.Time       `json:"time"`
+}
+
+// GuardianStore records the decisions of the guardian, to audit them and to not evaluate a request twice
+// across restarts.
+type GuardianStore interface {
+	// Decision returns the decision of the request, nil if it was not evaluated yet.
+	Decision(ctx context.Context, transactionId *big.Int) (*GuardianDecision, error)
+	// RecordDecision records the decision of a request, replacing an earlier decision of it.
+	RecordDecision(ctx context.Context, decision *GuardianDecision) error
+	// Decisions returns all recorded decisions, in the order of the transaction ids.
+	Decisions(ctx context.Context) ([]*GuardianDecision, error)
+	Close() error
+}
+
+// datastoreGuardianStore is a GuardianStore of JSON encoded decisions, keyed by transaction id.
+type datastoreGuardianStore struct {
+	store ds.Datastore
+}
+
+// NewGuardianStore opens the leveldb guardian store at the path, created if it does not exist.
+func NewGuardianStore(path string) (GuardianStore, error) {
+	store, err := leveldb.NewDatastore(path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open guardian store %s: %w", path, err)
+	}
+	return &datastoreGuardianStore{store: store}, nil
+}
+
+func guardianDecisionKey(transactionId *big.Int) ds.Key {
+	return guardianDecisionsKey.ChildString(transactionId.String())
+}
+
+func (s *datastoreGuardianStore) Decision(ctx context.Context, transactionId *big.Int) (*GuardianDecision, error) {
+	data, err := s.store.Get(ctx, guardianDecisionKey(transactionId))
+	if errors.Is(err, ds.ErrNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read decision of transaction %s: %w", transactionId, err)
+	}
+	var decision GuardianDecision
+	if err := json.Unmarshal(data, &decision); err != nil {
+		return nil, fmt.Errorf("failed to decode decision of transaction %s: %w", transactionId, err)
+	}
+	return &decision, nil
+}
+
+func (s *datastoreGuardianStore) RecordDecision(ctx context.Context, decision *GuardianDecision) error {
+	data, err := json.Marshal(decision)
+	if err != nil {
+		return err
+	}
+	if err := s.store.Put(ctx, guardianDecisionKey(decision.TransactionId), data); err != nil {
+		return fmt.Errorf("failed to record decision of transaction %s: %w", decision.TransactionId, err)
+	}
+	return nil
+}
+
+func (s *datastoreGuardianStore) Decisions(ctx context.Context) ([]*GuardianDecision, error) {
+	results, err := s.store.Query(ctx, query.Query{Prefix: guardianDecisionsKey.String()})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query decisions: %w", err)
+	}
+	defer results.Close()
+	var decisions []*GuardianDecision
+	for res := range results.Next() {
+		if res.Error != nil {
+			return nil, fmt.Errorf("failed to read decisions: %w", res.Error)
+		}
+		var decision GuardianDecision
+		if err := json.Unmarshal(res.Value, &decision); err != nil {
+			return nil, fmt.Errorf("failed to decode decision %s: %w", res.Key, err)
+		}
+		decisions = append(decisions, &decision)
+	}
+	sort.Slice(decisions, func(i, j int) bool { return decisions[i].TransactionId.Cmp(decisions[j].TransactionId) < 0 })
+	return decisions, nil
+}
+
+func (s *datastoreGuardianStore) Close() error {
+	return s.store.Close()
+}
+
+// decided returns whether the request was already given a final decision, e.g. before a restart.
+func (g *Guardian) decided(event *bindings.SecurityCouncilValidationRequested) bool {
+	if g.store == nil {
+		return false
+	}
+	decision, err := g.store.Decision(context.Background(), event.TransactionId)
+	if err != nil {
+		// evaluating the request again is safer than skipping it
+		g.log.Error("failed to read decision", "err", err, "transactionId", event.TransactionId)
+		return false
+	}
+	if decision == nil || !decision.Outcome.Final() {
+		return false
+	}
+	g.log.Info("skipping validation request decided already", "transactionId", event.TransactionId,
+		"outcome", decision.Outcome, "time", decision.Time)
+	return true
+}
+
+// recordDecision records the decision of the request in its trace and in the store, if any. localOutputRoot is nil if the local
+// output was not fetched.
+func (g *Guardian) recordDecision(event *bindings.SecurityCouncilValidationRequested, outcome GuardianOutcome, localOutputRoot *eth.Bytes32) {
+	g.traces.decided(event.TransactionId, outcome)
+	if g.store == nil {
+		return
+	}
+	decision := &GuardianDecision{
+		TransactionId:   event.TransactionId,
+		L1Block:         event.Raw.BlockNumber,
+		L2BlockNumber:   event.L2BlockNumber,
+		OutputRoot:      event.OutputRoot,
+		LocalOutputRoot: localOutputRoot,
+		Outcome:         outcome,
+		Time:            time.Now(),
+	}
+	// the decision is recorded even if the guardian is stopping, e.g. right after the confirmation was queued
+	if err := g.store.RecordDecision(context.Background(), decision); err != nil {
+		g.log.Error("failed to record decision", "err", err, "transactionId", event.TransactionId, "outcome", outcome)
+	}
+}
@@ -0,0 +1,120 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kroma-network/kroma/bindings/bindings"
+	"github.com/kroma-network/kroma/components/node/eth"
+	"github.com/kroma-network/kroma/utils/service/txmgr"
+)
+
+func TestGuardianStore(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "store")
+	store, err := NewGuardianStore(path)
+	require.NoError(t, err)
+
+	decision, err := store.Decision(ctx, big.NewInt(1))
+	require.NoError(t, err)
+	require.Nil(t, decision)
+
+	localOutputRoot := eth.Bytes32{0xaa}
+	recorded := []*GuardianDecision{
+		{TransactionId: big.NewInt(12), L1Block: 30, L2BlockNumber: big.NewInt(100), OutputRoot: eth.Bytes32{0xbb}, LocalOutputRoot: &localOutputRoot, Outcome: GuardianOutcomeMismatch},
+		{TransactionId: big.NewInt(2), L1Block: 10, L2BlockNumber: big.NewInt(100), OutputRoot: localOutputRoot, Outcome: GuardianOutcomeTimedOut},
+		{TransactionId: big.NewInt(2), L1Block: 10, L2BlockNumber: big.NewInt(100), OutputRoot: localOutputRoot, LocalOutputRoot: &localOutputRoot, Outcome: GuardianOutcomeConfirmed},
+	}
+	for _, d := range recorded {
+		d.Time = time.Unix(1700000000, 0).UTC()
+		require.NoError(t, store.RecordDecision(ctx, d))
+	}
+	require.NoError(t, store.Close())
+
+	// the decisions survive a restart
+	store, err = NewGuardianStore(path)
+	require.NoError(t, err)
+	defer store.Close()
+	decisions, err := store.Decisions(ctx)
+	require.NoError(t, err)
+	require.Len(t, decisions, 2, "expected a later decision to replace the earlier one")
+	require.Equal(t, recorded[2], decisions[0], "expected the decisions in the order of the transaction ids")
+	require.Equal(t, recorded[0], decisions[1])
+	decision, err = store.Decision(ctx, big.NewInt(12))
+	require.NoError(t, err)
+	require.Equal(t, GuardianOutcomeMismatch, decision.Outcome)
+}
+
+func TestGuardianSkipsDecidedRequests(t *testing.T) {
+	ctx := context.Background()
+	g, _ := newTestGuardian(t, &fakeRollupClient{}, &fakeSecurityCouncil{})
+	store, err := NewGuardianStore(filepath.Join(t.TempDir(), "store"))
+	require.NoError(t, err)
+	defer store.Close()
+	g.store = store
+
+	confirmed := &bindings.SecurityCouncilValidationRequested{TransactionId: big.NewInt(1), L2BlockNumber: big.NewInt(100)}
+	timedOut := &bindings.SecurityCouncilValidationRequested{TransactionId: big.NewInt(2), L2BlockNumber: big.NewInt(100)}
+	require.NoError(t, store.RecordDecision(ctx, &GuardianDecision{TransactionId: big.NewInt(1), Outcome: GuardianOutcomeConfirmed}))
+	require.NoError(t, store.RecordDecision(ctx, &GuardianDecision{TransactionId: big.NewInt(2), Outcome: GuardianOutcomeTimedOut}))
+
+	require.False(t, g.beginRequest(confirmed), "expected a confirmed request not to be evaluated again")
+	require.True(t, g.beginRequest(timedOut), "expected a timed out request to be evaluated again")
+	require.True(t, g.beginRequest(&bindings.SecurityCouncilValidationRequested{TransactionId: big.NewInt(3)}))
+}
+
+func TestGuardianRecordsConfirmationReceipt(t *testing.T) {
+	tests := []struct {
+		name          string
+		receipt       *types.Receipt
+		err           error
+		expectOutcome GuardianOutcome
+	}{
+		{name: "mined", receipt: &types.Receipt{Status: types.ReceiptStatusSuccessful}, expectOutcome: GuardianOutcomeConfirmed},
+		{name: "reverted", receipt: &types.Receipt{Status: types.ReceiptStatusFailed}, expectOutcome: GuardianOutcomeFailed},
+		{name: "not sent", err: errors.New("nonce too low"), expectOutcome: GuardianOutcomeFailed},
+		{name: "abandoned", err: context.Canceled, expectOutcome: GuardianOutcomeQueued},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+			g, candidates := newTestGuardian(t, &fakeRollupClient{}, &fakeSecurityCouncil{})
+			store, err := NewGuardianStore(filepath.Join(t.TempDir(), "store"))
+			require.NoError(t, err)
+			defer store.Close()
+			g.store = store
+
+			event := &bindings.SecurityCouncilValidationRequested{TransactionId: big.NewInt(1), L2BlockNumber: big.NewInt(100)}
+			localOutputRoot := eth.Bytes32{0x01}
+			g.sendConfirmation(event, types.NewTx(&types.DynamicFeeTx{Data: []byte{0x01}}), 0, &localOutputRoot)
+			candidate := <-candidates
+			decision, err := store.Decision(ctx, event.TransactionId)
+			require.NoError(t, err)
+			require.Equal(t, GuardianOutcomeQueued, decision.Outcome, "a queued confirmation is not confirmed yet")
+
+			// the outcome of another transaction is ignored
+			g.TxSent(txmgr.TxCandidate{ID: "other"}, &types.Receipt{Status: types.ReceiptStatusSuccessful}, nil)
+			g.TxSent(txmgr.TxCandidate{}, &types.Receipt{Status: types.ReceiptStatusSuccessful}, nil)
+			decision, err = store.Decision(ctx, event.TransactionId)
+			require.NoError(t, err)
+			require.Equal(t, GuardianOutcomeQueued, decision.Outcome)
+
+			g.TxSent(candidate, test.receipt, test.err)
+			decision, err = store.Decision(ctx, event.TransactionId)
+			require.NoError(t, err)
+			require.Equal(t, test.expectOutcome, decision.Outcome)
+			require.Equal(t, &localOutputRoot, decision.LocalOutputRoot)
+			require.Equal(t, test.expectOutcome != GuardianOutcomeFailed, decision.Outcome.Final(),
+				"a failed confirmation is evaluated again")
+			require.Empty(t, g.queued)
+		})
+	}
+}
@@ -0,0 +1,769 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kroma-network/kroma/bindings/bindings"
+	"github.com/kroma-network/kroma/components/node/eth"
+	"github.com/kroma-network/kroma/components/node/testlog"
+	"github.com/kroma-network/kroma/components/validator/metrics"
+	"github.com/kroma-network/kroma/utils/service/backoff"
+	"github.com/kroma-network/kroma/utils/service/txmgr"
+)
+
+var errFakeRpc = errors.New("fake rpc failure")
+
+// alwaysFail makes a fake fail on every call.
+const alwaysFail = -1
+
+// fakeRollupClient serves a single local output root. The first outputFailures and
+// syncFailures calls return errFakeRpc, and the next syncBehind SyncStatus calls return
+// a safe head right before the requested block.
+type fakeRollupClient struct {
+	mu sync.Mutex
+
+	outputRoot  eth.Bytes32
+	version     eth.Bytes32
+	blockNumber uint64
+
+	outputFailures int
+	syncFailures   int
+	syncBehind     int
+
+	outputCalls int
+	syncCalls   int
+}
+
+func (c *fakeRollupClient) OutputAtBlock(_ context.Context, blockNumber uint64) (*eth.OutputResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.outputCalls++
+	if c.outputFailures == alwaysFail || c.outputCalls <= c.outputFailures {
+		return nil, errFakeRpc
+	}
+	if blockNumber != c.blockNumber {
+		return nil, errors.New("unknown block")
+	}
+	return &eth.OutputResponse{Version: c.version, OutputRoot: c.outputRoot}, nil
+}
+
+func (c *fakeRollupClient) SyncStatus(_ context.Context) (*eth.SyncStatus, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.syncCalls++
+	if c.syncFailures == alwaysFail || c.syncCalls <= c.syncFailures {
+		return nil, errFakeRpc
+	}
+	safe := c.blockNumber
+	if c.syncBehind == alwaysFail || c.syncCalls-c.syncFailures <= c.syncBehind {
+		safe = c.blockNumber - 1
+	}
+	return &eth.SyncStatus{
+		SafeL2:      eth.L2BlockRef{Number: safe},
+		FinalizedL2: eth.L2BlockRef{Number: safe},
+	}, nil
+}
+
+func (c *fakeRollupClient) calls() (int, int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.outputCalls, c.syncCalls
+}
+
+// fakeSecurityCouncil is a SecurityCouncilContract that records the confirmed transaction ids.
+type fakeSecurityCouncil struct {
+	mu sync.Mutex
+
+	confirmed bool
+	// confirmedOnChain are the ids of the transactions confirmed, in addition to all if confirmed is set
+	confirmedOnChain    map[uint64]bool
+	isConfirmedFailures int
+	confirmFailures     int
+
+	// members are the SecurityCouncil members that confirmed every transaction
+	members                  []common.Address
+	getConfirmationsFailures int
+
+	isConfirmedCalls      int
+	confirmCalls          int
+	confirmedIds          []*big.Int
+	getConfirmationsCalls int
+	revokedIds            []*big.Int
+
+	// requests are the ValidationRequested logs served to FilterValidationRequested
+	requests []types.Log
+}
+
+func (c *fakeSecurityCouncil) IsConfirmed(_ *bind.CallOpts, transactionId *big.Int) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.isConfirmedCalls++
+	if c.isConfirmedFailures == alwaysFail || c.isConfirmedCalls <= c.isConfirmedFailures {
+		return false, errFakeRpc
+	}
+	return c.confirmed || c.confirmedOnChain[transactionId.Uint64()], nil
+}
+
+func (c *fakeSecurityCouncil) ConfirmTransaction(opts *bind.TransactOpts, transactionId *big.Int) (*types.Transaction, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.confirmCalls++
+	if c.confirmFailures == alwaysFail || c.confirmCalls <= c.confirmFailures {
+		return nil, errFakeRpc
+	}
+	c.confirmedIds = append(c.confirmedIds, transactionId)
+	to := common.Address{0xcc}
+	return types.NewTx(&types.DynamicFeeTx{To: &to, Data: transactionId.Bytes()}), nil
+}
+
+func (c *fakeSecurityCouncil) RevokeConfirmation(opts *bind.TransactOpts, transactionId *big.Int) (*types.Transaction, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.revokedIds = append(c.revokedIds, transactionId)
+	to := common.Address{0xcc}
+	return types.NewTx(&types.DynamicFeeTx{To: &to, Data: append([]byte{0xff}, transactionId.Bytes()...)}), nil
+}
+
+func (c *fakeSecurityCouncil) GetConfirmations(_ *bind.CallOpts, _ *big.Int) ([]common.Address, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.getConfirmationsCalls++
+	if c.getConfirmationsFailures == alwaysFail || c.getConfirmationsCalls <= c.getConfirmationsFailures {
+		return nil, errFakeRpc
+	}
+	return c.members, nil
+}
+
+func (c *fakeSecurityCouncil) WatchValidationRequested(opts *bind.WatchOpts, _ chan<- *bindings.SecurityCouncilValidationRequested, _ []*big.Int) (event.Subscription, error) {
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		<-quit
+		return nil
+	}), nil
+}
+
+func (c *fakeSecurityCouncil) FilterValidationRequested(opts *bind.FilterOpts, transactionId []*big.Int) (*bindings.SecurityCouncilValidationRequestedIterator, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	filterer, err := bindings.NewSecurityCouncilFilterer(common.Address{}, &fakeLogFilterer{logs: c.requests})
+	if err != nil {
+		return nil, err
+	}
+	return filterer.FilterValidationRequested(opts, transactionId)
+}
+
+func (c *fakeSecurityCouncil) confirmations() []*big.Int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.confirmedIds
+}
+
+func (c *fakeSecurityCouncil) revocations() []*big.Int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.revokedIds
+}
+
+// fakeAlertSink records the delivered alerts.
+type fakeAlertSink struct {
+	mu     sync.Mutex
+	alerts []GuardianAlert
+}
+
+func (a *fakeAlertSink) Alert(_ context.Context, alert GuardianAlert) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.alerts = append(a.alerts, alert)
+	return nil
+}
+
+func (a *fakeAlertSink) delivered() []GuardianAlert {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.alerts
+}
+
+func newTestGuardian(t *testing.T, rollupClient GuardianRollupClient, council SecurityCouncilContract) (*Guardian, chan txmgr.TxCandidate) {
+	candidates := make(chan txmgr.TxCandidate, 1)
+	g := &Guardian{
+		log: testlog.Logger(t, log.LvlCrit),
+		cfg: Config{
+			NetworkTimeout:           time.Second,
+			AllowNonFinalized:        true,
+			GuardianBlockWaitTimeout: time.Minute,
+			TxManager: &txmgr.SimpleTxManager{
+				Config: txmgr.Config{
+					From: common.Address{0x01},
+					Signer: func(_ context.Context, _ common.Address, tx *types.Transaction) (*types.Transaction, error) {
+						return tx, nil
+					},
+				},
+			},
+		},
+		rollupClient:            rollupClient,
+		pollInterval:            10 * time.Millisecond,
+		retryStrategy:           backoff.Fixed(10 * time.Millisecond),
+		metr:                    metrics.NoopMetrics,
+		checkpoints:             outputCheckpoints{startingBlockNumber: 0, submissionInterval: 10},
+		securityCouncilContract: council,
+		validations:             newValidationQueue(metrics.NoopMetrics, defaultGuardianMaxConcurrentValidations),
+		inFlight:                make(map[string]*bindings.SecurityCouncilValidationRequested),
+		unprocessed:             make(map[string]struct{}),
+		queued:                  make(map[string]queuedConfirmation),
+		txCandidatesChan:        candidates,
+		timeNow:                 time.Now,
+	}
+	return g, candidates
+}
+
+func TestGuardianProcessOutputValidation(t *testing.T) {
+	const l2BlockNumber = 100
+	localOutputRoot := eth.Bytes32{0xaa}
+	transactionId := big.NewInt(7)
+
+	tests := []struct {
+		name string
+		// requested is the output root requested to be validated.
+		requested    eth.Bytes32
+		rollupClient *fakeRollupClient
+		council      *fakeSecurityCouncil
+		// dissent enables the dissent of invalid outputs.
+		dissent bool
+		// dryRun enables the dry run.
+		dryRun bool
+		// cancelAfter cancels the validation context after the duration if set.
+		cancelAfter   time.Duration
+		expectConfirm bool
+		expectRevoke  bool
+		// expectAlert is the kind of the alert expected to be delivered, none if empty.
+		expectAlert GuardianAlertKind
+		// expectOutputCalls is the expected number of OutputAtBlock calls, ignored if negative.
+		expectOutputCalls int
+		// expectOutcome is the recorded decision, none if empty.
+		expectOutcome GuardianOutcome
+	}{
+		{
+			name:              "valid output",
+			requested:         localOutputRoot,
+			rollupClient:      &fakeRollupClient{},
+			council:           &fakeSecurityCouncil{},
+			expectConfirm:     true,
+			expectOutputCalls: 1,
+			expectOutcome:     GuardianOutcomeQueued,
+		},
+		{
+			name:              "invalid output",
+			requested:         eth.Bytes32{0xbb},
+			rollupClient:      &fakeRollupClient{},
+			council:           &fakeSecurityCouncil{},
+			expectConfirm:     false,
+			expectAlert:       GuardianAlertMismatch,
+			expectOutputCalls: 1,
+			expectOutcome:     GuardianOutcomeMismatch,
+		},
+		{
+			name:              "invalid output with dissent",
+			requested:         eth.Bytes32{0xbb},
+			rollupClient:      &fakeRollupClient{},
+			council:           &fakeSecurityCouncil{members: []common.Address{{0x02}}},
+			dissent:           true,
+			expectAlert:       GuardianAlertMismatch,
+			expectOutputCalls: 1,
+			expectOutcome:     GuardianOutcomeMismatch,
+		},
+		{
+			name:              "invalid output confirmed by the guardian with dissent",
+			requested:         eth.Bytes32{0xbb},
+			rollupClient:      &fakeRollupClient{},
+			council:           &fakeSecurityCouncil{members: []common.Address{{0x02}, {0x01}}},
+			dissent:           true,
+			expectRevoke:      true,
+			expectAlert:       GuardianAlertMismatch,
+			expectOutputCalls: 1,
+			expectOutcome:     GuardianOutcomeRevoked,
+		},
+		{
+			name:              "get confirmations rpc error is retried",
+			requested:         eth.Bytes32{0xbb},
+			rollupClient:      &fakeRollupClient{},
+			council:           &fakeSecurityCouncil{members: []common.Address{{0x01}}, getConfirmationsFailures: 2},
+			dissent:           true,
+			expectRevoke:      true,
+			expectAlert:       GuardianAlertMismatch,
+			expectOutputCalls: 3,
+			expectOutcome:     GuardianOutcomeRevoked,
+		},
+		{
+			name:              "valid output with dissent",
+			requested:         localOutputRoot,
+			rollupClient:      &fakeRollupClient{},
+			council:           &fakeSecurityCouncil{members: []common.Address{{0x02}}},
+			dissent:           true,
+			expectConfirm:     true,
+			expectOutputCalls: 1,
+			expectOutcome:     GuardianOutcomeQueued,
+		},
+		{
+			name:              "valid output in dry run",
+			requested:         localOutputRoot,
+			rollupClient:      &fakeRollupClient{},
+			council:           &fakeSecurityCouncil{},
+			dryRun:            true,
+			expectOutputCalls: 1,
+			expectOutcome:     GuardianOutcomeDryRun,
+		},
+		{
+			name:              "invalid output confirmed by the guardian with dissent in dry run",
+			requested:         eth.Bytes32{0xbb},
+			rollupClient:      &fakeRollupClient{},
+			council:           &fakeSecurityCouncil{members: []common.Address{{0x02}, {0x01}}},
+			dissent:           true,
+			dryRun:            true,
+			expectAlert:       GuardianAlertMismatch,
+			expectOutputCalls: 1,
+			expectOutcome:     GuardianOutcomeMismatch,
+		},
+		{
+			name:              "already confirmed",
+			requested:         localOutputRoot,
+			rollupClient:      &fakeRollupClient{},
+			council:           &fakeSecurityCouncil{confirmed: true},
+			expectConfirm:     false,
+			expectOutputCalls: 0,
+			expectOutcome:     GuardianOutcomeAlreadyConfirmed,
+		},
+		{
+			name:              "output rpc error is retried",
+			requested:         localOutputRoot,
+			rollupClient:      &fakeRollupClient{outputFailures: 2},
+			council:           &fakeSecurityCouncil{},
+			expectConfirm:     true,
+			expectOutputCalls: 3,
+			expectOutcome:     GuardianOutcomeQueued,
+		},
+		{
+			name:              "sync status rpc error is retried",
+			requested:         localOutputRoot,
+			rollupClient:      &fakeRollupClient{syncFailures: 2},
+			council:           &fakeSecurityCouncil{},
+			expectConfirm:     true,
+			expectOutputCalls: 1,
+			expectOutcome:     GuardianOutcomeQueued,
+		},
+		{
+			name:              "is confirmed rpc error is retried",
+			requested:         localOutputRoot,
+			rollupClient:      &fakeRollupClient{},
+			council:           &fakeSecurityCouncil{isConfirmedFailures: 2},
+			expectConfirm:     true,
+			expectOutputCalls: 1,
+			expectOutcome:     GuardianOutcomeQueued,
+		},
+		{
+			name:              "confirm transaction error is retried",
+			requested:         localOutputRoot,
+			rollupClient:      &fakeRollupClient{},
+			council:           &fakeSecurityCouncil{confirmFailures: 1},
+			expectConfirm:     true,
+			expectOutputCalls: 2,
+			expectOutcome:     GuardianOutcomeQueued,
+		},
+		{
+			name:              "repeated confirm transaction errors are alerted",
+			requested:         localOutputRoot,
+			rollupClient:      &fakeRollupClient{},
+			council:           &fakeSecurityCouncil{confirmFailures: guardianConfirmFailureAlerts},
+			expectConfirm:     true,
+			expectAlert:       GuardianAlertConfirmFailed,
+			expectOutputCalls: guardianConfirmFailureAlerts + 1,
+			expectOutcome:     GuardianOutcomeQueued,
+		},
+		{
+			name:              "waits for the requested block to be derived",
+			requested:         localOutputRoot,
+			rollupClient:      &fakeRollupClient{syncBehind: 3},
+			council:           &fakeSecurityCouncil{},
+			expectConfirm:     true,
+			expectOutputCalls: 1,
+			expectOutcome:     GuardianOutcomeQueued,
+		},
+		{
+			name:              "context cancellation",
+			requested:         localOutputRoot,
+			rollupClient:      &fakeRollupClient{outputFailures: alwaysFail},
+			council:           &fakeSecurityCouncil{},
+			cancelAfter:       50 * time.Millisecond,
+			expectConfirm:     false,
+			expectOutputCalls: -1,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			test.rollupClient.outputRoot = localOutputRoot
+			test.rollupClient.blockNumber = l2BlockNumber
+			g, candidates := newTestGuardian(t, test.rollupClient, test.council)
+			sink := &fakeAlertSink{}
+			g.cfg.GuardianDissent = test.dissent
+			g.cfg.GuardianDryRun = test.dryRun
+			g.cfg.GuardianAlertSinks = []AlertSink{sink}
+			store, err := NewGuardianStore(filepath.Join(t.TempDir(), "store"))
+			require.NoError(t, err)
+			defer store.Close()
+			g.store = store
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if test.cancelAfter != 0 {
+				time.AfterFunc(test.cancelAfter, cancel)
+			}
+
+			done := make(chan struct{})
+			g.wg.Add(1)
+			go func() {
+				g.processOutputValidation(ctx, &bindings.SecurityCouncilValidationRequested{
+					TransactionId: transactionId,
+					OutputRoot:    test.requested,
+					L2BlockNumber: big.NewInt(l2BlockNumber),
+				})
+				close(done)
+			}()
+
+			select {
+			case <-done:
+			case <-time.After(5 * time.Second):
+				t.Fatal("output validation did not finish")
+			}
+			require.NotErrorIs(t, ctx.Err(), context.DeadlineExceeded, "output validation must finish before the deadline")
+
+			switch {
+			case test.expectConfirm:
+				require.Len(t, candidates, 1)
+				candidate := <-candidates
+				require.Equal(t, transactionId.Bytes(), candidate.TxData)
+				require.Equal(t, []*big.Int{transactionId}, test.council.confirmations())
+			case test.expectRevoke:
+				require.Len(t, candidates, 1)
+				candidate := <-candidates
+				require.Equal(t, append([]byte{0xff}, transactionId.Bytes()...), candidate.TxData)
+				require.Equal(t, []*big.Int{transactionId}, test.council.revocations())
+			default:
+				require.Empty(t, candidates)
+			}
+			if !test.expectConfirm {
+				require.Empty(t, test.council.confirmations())
+			}
+			if !test.expectRevoke {
+				require.Empty(t, test.council.revocations())
+			}
+			if test.expectAlert != "" {
+				require.Len(t, sink.delivered(), 1)
+				alert := sink.delivered()[0]
+				require.Equal(t, test.expectAlert, alert.Kind)
+				require.Equal(t, transactionId, alert.TransactionId)
+				require.Equal(t, localOutputRoot, alert.LocalOutputRoot)
+				// a dry run does not contest the request
+				contested := test.dissent && !test.dryRun
+				require.Equal(t, contested, alert.Dissent)
+				require.Equal(t, test.expectRevoke, alert.Revoked)
+				if contested {
+					require.Equal(t, test.council.members, alert.Confirmations)
+				}
+				if alert.Kind == GuardianAlertConfirmFailed {
+					require.Equal(t, guardianConfirmFailureAlerts, alert.Failures)
+					require.Equal(t, errFakeRpc.Error(), alert.Error)
+				}
+			} else {
+				require.Empty(t, sink.delivered())
+			}
+			if test.expectOutputCalls >= 0 {
+				outputCalls, _ := test.rollupClient.calls()
+				require.Equal(t, test.expectOutputCalls, outputCalls)
+			}
+			decision, err := store.Decision(context.Background(), transactionId)
+			require.NoError(t, err)
+			if test.expectOutcome == "" {
+				require.Nil(t, decision)
+			} else {
+				require.NotNil(t, decision)
+				require.Equal(t, test.expectOutcome, decision.Outcome)
+				require.Equal(t, eth.Bytes32(test.requested), decision.OutputRoot)
+			}
+		})
+	}
+}
+
+func TestGuardianValidateL2Output(t *testing.T) {
+	const l2BlockNumber = 100
+	localOutputRoot := eth.Bytes32{0xaa}
+
+	tests := []struct {
+		name         string
+		requested    eth.Bytes32
+		rollupClient *fakeRollupClient
+		expect       ValidationReason
+	}{
+		{
+			name:         "valid output",
+			requested:    localOutputRoot,
+			rollupClient: &fakeRollupClient{},
+			expect:       ValidationReasonValid,
+		},
+		{
+			name:         "invalid output",
+			requested:    eth.Bytes32{0xbb},
+			rollupClient: &fakeRollupClient{},
+			expect:       ValidationReasonMismatch,
+		},
+		{
+			name:         "node behind",
+			requested:    localOutputRoot,
+			rollupClient: &fakeRollupClient{syncBehind: alwaysFail},
+			expect:       ValidationReasonNodeBehind,
+		},
+		{
+			name:         "sync status rpc error",
+			requested:    localOutputRoot,
+			rollupClient: &fakeRollupClient{syncFailures: alwaysFail},
+			expect:       ValidationReasonRPCError,
+		},
+		{
+			name:         "output rpc error",
+			requested:    localOutputRoot,
+			rollupClient: &fakeRollupClient{outputFailures: alwaysFail},
+			expect:       ValidationReasonRPCError,
+		},
+		{
+			name:         "unknown version",
+			requested:    localOutputRoot,
+			rollupClient: &fakeRollupClient{version: eth.Bytes32{0xff}},
+			expect:       ValidationReasonVersionUnknown,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			test.rollupClient.outputRoot = localOutputRoot
+			test.rollupClient.blockNumber = l2BlockNumber
+			g, _ := newTestGuardian(t, test.rollupClient, &fakeSecurityCouncil{})
+
+			result := g.ValidateL2Output(context.Background(), test.requested, l2BlockNumber)
+			require.Equal(t, test.expect, result.Reason)
+			if test.expect == ValidationReasonRPCError {
+				require.ErrorIs(t, result.Err, errFakeRpc)
+			} else {
+				require.NoError(t, result.Err)
+			}
+			require.Equal(t, test.expect == ValidationReasonValid, result.IsValid())
+		})
+	}
+}
+
+func TestGuardianBlockWaitTimeout(t *testing.T) {
+	rollupClient := &fakeRollupClient{
+		outputRoot:  eth.Bytes32{0xaa},
+		blockNumber: 100,
+		syncBehind:  alwaysFail,
+	}
+	council := &fakeSecurityCouncil{}
+	g, candidates := newTestGuardian(t, rollupClient, council)
+	g.cfg.GuardianBlockWaitTimeout = 50 * time.Millisecond
+	store, err := NewGuardianStore(filepath.Join(t.TempDir(), "store"))
+	require.NoError(t, err)
+	defer store.Close()
+	g.store = store
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	g.wg.Add(1)
+	g.processOutputValidation(ctx, &bindings.SecurityCouncilValidationRequested{
+		TransactionId: big.NewInt(1),
+		OutputRoot:    eth.Bytes32{0xaa},
+		L2BlockNumber: big.NewInt(100),
+	})
+	require.NoError(t, ctx.Err())
+	require.Empty(t, candidates)
+
+	outputCalls, syncCalls := rollupClient.calls()
+	require.Zero(t, outputCalls)
+	require.Greater(t, syncCalls, 1)
+
+	decision, err := store.Decision(context.Background(), big.NewInt(1))
+	require.NoError(t, err)
+	require.Equal(t, GuardianOutcomeTimedOut, decision.Outcome)
+}
+
+func TestGuardianBlockWaitTimeoutNotReached(t *testing.T) {
+	rollupClient := &fakeRollupClient{
+		outputRoot:  eth.Bytes32{0xaa},
+		blockNumber: 100,
+		syncBehind:  3,
+	}
+	council := &fakeSecurityCouncil{}
+	g, candidates := newTestGuardian(t, rollupClient, council)
+	g.cfg.GuardianBlockWaitTimeout = time.Hour
+	// the block is derived right before the timeout
+	now := time.Unix(1000, 0)
+	g.timeNow = func() time.Time {
+		now = now.Add(20 * time.Minute)
+		return now
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	g.wg.Add(1)
+	g.processOutputValidation(ctx, &bindings.SecurityCouncilValidationRequested{
+		TransactionId: big.NewInt(1),
+		OutputRoot:    eth.Bytes32{0xaa},
+		L2BlockNumber: big.NewInt(100),
+	})
+	require.NoError(t, ctx.Err())
+	require.Len(t, candidates, 1, "must confirm the output once the block is derived")
+}
+
+func TestBlockWait(t *testing.T) {
+	now := time.Unix(1000, 0)
+	clock := func() time.Time { return now }
+
+	w := newBlockWait(time.Minute, clock)
+	elapsed, timedOut := w.elapsed()
+	require.Zero(t, elapsed)
+	require.False(t, timedOut)
+
+	now = now.Add(time.Minute)
+	elapsed, timedOut = w.elapsed()
+	require.Equal(t, time.Minute, elapsed)
+	require.False(t, timedOut, "the timeout is exclusive")
+
+	now = now.Add(time.Nanosecond)
+	_, timedOut = w.elapsed()
+	require.True(t, timedOut)
+
+	// the wait starts on the first check
+	w = newBlockWait(0, clock)
+	require.Equal(t, defaultGuardianBlockWaitTimeout, w.timeout)
+	now = now.Add(time.Hour)
+	elapsed, timedOut = w.elapsed()
+	require.Zero(t, elapsed)
+	require.False(t, timedOut)
+
+	now = now.Add(defaultGuardianBlockWaitTimeout + time.Second)
+	elapsed, timedOut = w.elapsed()
+	require.Equal(t, defaultGuardianBlockWaitTimeout+time.Second, elapsed)
+	require.True(t, timedOut)
+}
+
+func TestGuardianRejectsMisalignedRequest(t *testing.T) {
+	rollupClient := &fakeRollupClient{
+		outputRoot:  eth.Bytes32{0xaa},
+		blockNumber: 105,
+	}
+	council := &fakeSecurityCouncil{}
+	g, candidates := newTestGuardian(t, rollupClient, council)
+
+	g.wg.Add(1)
+	g.processOutputValidation(context.Background(), &bindings.SecurityCouncilValidationRequested{
+		TransactionId: big.NewInt(1),
+		OutputRoot:    eth.Bytes32{0xaa},
+		L2BlockNumber: big.NewInt(105),
+	})
+	require.Empty(t, candidates)
+	require.Empty(t, council.confirmations())
+
+	outputCalls, syncCalls := rollupClient.calls()
+	require.Zero(t, outputCalls)
+	require.Zero(t, syncCalls)
+}
+
+type fakeL2OOContract struct {
+	startingBlockNumber *big.Int
+	submissionInterval  *big.Int
+}
+
+func (c *fakeL2OOContract) SUBMISSIONINTERVAL(_ *bind.CallOpts) (*big.Int, error) {
+	return c.submissionInterval, nil
+}
+
+func (c *fakeL2OOContract) StartingBlockNumber(_ *bind.CallOpts) (*big.Int, error) {
+	return c.startingBlockNumber, nil
+}
+
+func TestGuardianFetchCheckpoints(t *testing.T) {
+	g, _ := newTestGuardian(t, &fakeRollupClient{}, &fakeSecurityCouncil{})
+	g.l2ooContract = &fakeL2OOContract{startingBlockNumber: big.NewInt(5), submissionInterval: big.NewInt(1800)}
+	require.NoError(t, g.fetchCheckpoints(context.Background()))
+
+	require.False(t, g.checkpoints.isCheckpoint(0))
+	require.True(t, g.checkpoints.isCheckpoint(5))
+	require.False(t, g.checkpoints.isCheckpoint(1800))
+	require.True(t, g.checkpoints.isCheckpoint(3605))
+
+	g.l2ooContract = &fakeL2OOContract{startingBlockNumber: big.NewInt(0), submissionInterval: big.NewInt(0)}
+	require.ErrorContains(t, g.fetchCheckpoints(context.Background()), "invalid output checkpoints")
+}
+
+func TestGuardianBatchesBurst(t *testing.T) {
+	const l2BlockNumber = 100
+	localOutputRoot := eth.Bytes32{0xaa}
+	request := func(id int64) *bindings.SecurityCouncilValidationRequested {
+		return &bindings.SecurityCouncilValidationRequested{
+			TransactionId: big.NewInt(id),
+			OutputRoot:    localOutputRoot,
+			L2BlockNumber: big.NewInt(l2BlockNumber),
+		}
+	}
+
+	rollupClient := &fakeRollupClient{outputRoot: localOutputRoot, blockNumber: l2BlockNumber}
+	council := &fakeSecurityCouncil{}
+	g, _ := newTestGuardian(t, rollupClient, council)
+	candidates := make(chan txmgr.TxCandidate, 10)
+	g.txCandidatesChan = candidates
+	g.validationRequestedChan = make(chan *bindings.SecurityCouncilValidationRequested)
+	g.cfg.GuardianBatchWindow = time.Hour
+	g.cfg.GuardianMaxBatchSize = 3
+
+	ctx, cancel := context.WithCancel(context.Background())
+	g.wg.Add(1)
+	go g.handleValidationRequested(ctx)
+
+	// the full batch is processed before the window ends
+	for _, id := range []int64{3, 1, 2} {
+		g.validationRequestedChan <- request(id)
+	}
+	require.Eventually(t, func() bool { return len(candidates) == 3 }, 5*time.Second, 10*time.Millisecond)
+	require.Equal(t, []*big.Int{big.NewInt(1), big.NewInt(2), big.NewInt(3)}, council.confirmations())
+	outputCalls, _ := rollupClient.calls()
+	require.Equal(t, 1, outputCalls, "expected a single validation of the requested output")
+
+	cancel()
+	g.wg.Wait()
+
+	// a partial batch is processed once the window ends
+	g.cfg.GuardianBatchWindow = 20 * time.Millisecond
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	g.wg.Add(1)
+	go g.handleValidationRequested(ctx)
+	g.validationRequestedChan <- request(4)
+	require.Eventually(t, func() bool { return len(candidates) == 4 }, 5*time.Second, 10*time.Millisecond)
+	outputCalls, _ = rollupClient.calls()
+	require.Equal(t, 2, outputCalls)
+}
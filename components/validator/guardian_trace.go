@@ -0,0 +1,125 @@
+package validator
+
+import (
+	"context"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/kroma-network/kroma/bindings/bindings"
+	"github.com/kroma-network/kroma/components/validator/metrics"
+)
+
+// validationTraces traces the validation requests through the guardian. Every request is a trace, spanning from the
+// start of its processing until it is processed, with the results of the validations as events and the outcome as
+// attribute. The trace IDs are kept after the requests are processed, to link the confirmations of the
+// SecurityCouncil members, arriving later, to the trace of their request in the exemplars of the metrics. A nil
+// validationTraces traces nothing.
+type validationTraces struct {
+	tracer trace.Tracer
+
+	mu sync.Mutex
+	// spans are the spans of the requests being processed, by transaction id
+	spans map[string]trace.Span
+	// traceIDs are the trace IDs of the sampled requests, by transaction id
+	traceIDs map[string]trace.TraceID
+}
+
+func newValidationTraces(tracer trace.Tracer) *validationTraces {
+	return &validationTraces{
+		tracer:   tracer,
+		spans:    make(map[string]trace.Span),
+		traceIDs: make(map[string]trace.TraceID),
+	}
+}
+
+// begin starts the trace of the request.
+func (t *validationTraces) begin(event *bindings.SecurityCouncilValidationRequested) {
+	if t == nil {
+		return
+	}
+	_, span := t.tracer.Start(context.Background(), "validation", trace.WithAttributes(
+		attribute.String("validation.transaction_id", event.TransactionId.String()),
+		attribute.String("validation.l2_block_number", event.L2BlockNumber.String()),
+		attribute.String("validation.output_root", common.Hash(event.OutputRoot).String()),
+		attribute.String("validation.request_tx_hash", event.Raw.TxHash.String()),
+		attribute.Int64("validation.request_l1_block", int64(event.Raw.BlockNumber)),
+	))
+
+	id := event.TransactionId.String()
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.spans[id] = span
+	if sc := span.SpanContext(); sc.IsSampled() {
+		if len(t.traceIDs) >= councilRequestCacheSize {
+			// the confirmations of the evicted requests are recorded without exemplar
+			t.traceIDs = make(map[string]trace.TraceID)
+		}
+		t.traceIDs[id] = sc.TraceID()
+	}
+}
+
+// validated records the result of a validation of the request.
+func (t *validationTraces) validated(transactionId *big.Int, result ValidationResult) {
+	span, ok := t.span(transactionId)
+	if !ok {
+		return
+	}
+	attrs := []attribute.KeyValue{attribute.String("validation.reason", string(result.Reason))}
+	if result.Fallback {
+		attrs = append(attrs, attribute.Bool("validation.fallback", true))
+	}
+	if result.Err != nil {
+		attrs = append(attrs, attribute.String("validation.error", result.Err.Error()))
+	}
+	span.AddEvent("validated", trace.WithAttributes(attrs...))
+}
+
+// decided records the outcome of the request.
+func (t *validationTraces) decided(transactionId *big.Int, outcome GuardianOutcome) {
+	if span, ok := t.span(transactionId); ok {
+		span.SetAttributes(attribute.String("validation.outcome", string(outcome)))
+	}
+}
+
+// end ends the trace of the processed request.
+func (t *validationTraces) end(transactionId *big.Int) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	id := transactionId.String()
+	if span, ok := t.spans[id]; ok {
+		span.End()
+		delete(t.spans, id)
+	}
+}
+
+func (t *validationTraces) span(transactionId *big.Int) (trace.Span, bool) {
+	if t == nil {
+		return nil, false
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	span, ok := t.spans[transactionId.String()]
+	return span, ok
+}
+
+// exemplar returns the exemplar linking an observation of the request to its trace and the L1 transaction, without
+// trace ID if the request is not traced.
+func (t *validationTraces) exemplar(transactionId *big.Int, txHash common.Hash) metrics.Exemplar {
+	e := metrics.Exemplar{TxHash: txHash}
+	if t == nil {
+		return e
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if traceID, ok := t.traceIDs[transactionId.String()]; ok {
+		e.TraceID = traceID.String()
+	}
+	return e
+}
@@ -0,0 +1,86 @@
+package validator
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/kroma-network/kroma/bindings/bindings"
+	"github.com/kroma-network/kroma/components/validator/metrics"
+)
+
+func tracedValidationRequest(id int64) *bindings.SecurityCouncilValidationRequested {
+	return &bindings.SecurityCouncilValidationRequested{
+		TransactionId: big.NewInt(id),
+		L2BlockNumber: big.NewInt(1800),
+		OutputRoot:    common.HexToHash("0xaa"),
+		Raw:           types.Log{TxHash: common.HexToHash("0x01"), BlockNumber: 42},
+	}
+}
+
+func TestValidationTraces(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	traces := newValidationTraces(provider.Tracer("test"))
+
+	event := tracedValidationRequest(7)
+	traces.begin(event)
+	traces.validated(event.TransactionId, ValidationResult{Reason: ValidationReasonRPCError, Err: errors.New("node unavailable")})
+	traces.validated(event.TransactionId, ValidationResult{Reason: ValidationReasonValid})
+	traces.decided(event.TransactionId, GuardianOutcomeConfirmed)
+	require.Empty(t, recorder.Ended(), "span ends once the request is processed")
+	traces.end(event.TransactionId)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	span := spans[0]
+	require.Equal(t, "validation", span.Name())
+	require.Contains(t, span.Attributes(), attribute.String("validation.transaction_id", "7"))
+	require.Contains(t, span.Attributes(), attribute.String("validation.request_tx_hash", event.Raw.TxHash.String()))
+	require.Contains(t, span.Attributes(), attribute.String("validation.outcome", string(GuardianOutcomeConfirmed)))
+	require.Len(t, span.Events(), 2)
+	require.Contains(t, span.Events()[0].Attributes, attribute.String("validation.reason", string(ValidationReasonRPCError)))
+	require.Contains(t, span.Events()[0].Attributes, attribute.String("validation.error", "node unavailable"))
+	require.Contains(t, span.Events()[1].Attributes, attribute.String("validation.reason", string(ValidationReasonValid)))
+
+	// the confirmations arriving after the request was processed are linked to its trace
+	confirmation := common.HexToHash("0x02")
+	require.Equal(t, metrics.Exemplar{TraceID: span.SpanContext().TraceID().String(), TxHash: confirmation},
+		traces.exemplar(event.TransactionId, confirmation))
+	require.Equal(t, metrics.Exemplar{TxHash: confirmation}, traces.exemplar(big.NewInt(8), confirmation),
+		"untraced request")
+
+	// the updates of untraced requests are ignored
+	traces.validated(big.NewInt(8), ValidationResult{Reason: ValidationReasonValid})
+	traces.decided(big.NewInt(8), GuardianOutcomeConfirmed)
+	traces.end(big.NewInt(8))
+	require.Len(t, recorder.Ended(), 1)
+}
+
+func TestValidationTracesUnsampled(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder), sdktrace.WithSampler(sdktrace.NeverSample()))
+	traces := newValidationTraces(provider.Tracer("test"))
+
+	event := tracedValidationRequest(7)
+	traces.begin(event)
+	traces.end(event.TransactionId)
+	require.Equal(t, metrics.Exemplar{}, traces.exemplar(event.TransactionId, common.Hash{}))
+}
+
+func TestValidationTracesDisabled(t *testing.T) {
+	var traces *validationTraces
+	event := tracedValidationRequest(7)
+	traces.begin(event)
+	traces.validated(event.TransactionId, ValidationResult{Reason: ValidationReasonValid})
+	traces.decided(event.TransactionId, GuardianOutcomeConfirmed)
+	traces.end(event.TransactionId)
+	require.Equal(t, metrics.Exemplar{TxHash: common.HexToHash("0x02")}, traces.exemplar(event.TransactionId, common.HexToHash("0x02")))
+}
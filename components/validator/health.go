@@ -0,0 +1,271 @@
+package validator
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/event"
+
+	"github.com/kroma-network/kroma/utils/service/httputil"
+)
+
+// The statuses of the roles reported by the health server.
+const (
+	// RoleStatusStarting is a role which is not running yet.
+	RoleStatusStarting = "starting"
+	// RoleStatusSubscribed is a role subscribed to the events it handles.
+	RoleStatusSubscribed = "subscribed"
+	// RoleStatusUnsubscribed is a role which failed to subscribe to the events it handles.
+	RoleStatusUnsubscribed = "unsubscribed"
+	// RoleStatusReconnecting is a role which lost a subscription to the events it handles, until it resubscribes.
+	RoleStatusReconnecting = "reconnecting"
+	// RoleStatusSyncing is a challenger scanning the previous outputs and challenges.
+	RoleStatusSyncing = "syncing"
+	// RoleStatusSynced is a challenger which scanned the previous outputs and is subscribed to the new ones.
+	RoleStatusSynced = "synced"
+)
+
+// The states of the submission round of the output submitter, reported as its status.
+const (
+	// RoundStateWaiting is an output submitter waiting for the L2 blocks of the next output.
+	RoundStateWaiting = "waiting"
+	// RoundStatePriority is an output submitter selected as the priority validator of the round.
+	RoundStatePriority = "priority"
+	// RoundStateNotPriority is an output submitter waiting for the public round, not selected as the priority validator.
+	RoundStateNotPriority = "not-priority"
+	// RoundStatePublic is an output submitter in the public round.
+	RoundStatePublic = "public"
+	// RoundStateInsufficientDeposit is an output submitter whose deposit cannot cover the bond of an output.
+	RoundStateInsufficientDeposit = "insufficient-deposit"
+)
+
+// RoleHealth is the health of a role of the validator.
+type RoleHealth struct {
+	// Ready is whether the role is able to perform its duty.
+	Ready  bool   `json:"ready"`
+	Status string `json:"status"`
+	// Error is the error of the last attempt of the role, if it failed.
+	Error string `json:"error,omitempty"`
+}
+
+// HealthResponse is the response of the health server.
+type HealthResponse struct {
+	Version string `json:"version"`
+	// Ready is whether all the roles are ready.
+	Ready bool                  `json:"ready"`
+	Roles map[string]RoleHealth `json:"roles"`
+}
+
+type HealthReporter interface {
+	Health() map[string]RoleHealth
+}
+
+// roleState is the status of a role and the error of its last attempt, updated by the loop of the role. The role is
+// starting until its status is first set.
+type roleState struct {
+	mu     sync.Mutex
+	status string
+	err    error
+}
+
+// set sets the status of the role, clearing the error of the last attempt.
+func (s *roleState) set(status string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.status, s.err = status, nil
+}
+
+// fail records the error of the last attempt, keeping the status of the role.
+func (s *roleState) fail(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.err = err
+}
+
+func (s *roleState) get() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.status == "" {
+		return RoleStatusStarting, s.err
+	}
+	return s.status, s.err
+}
+
+// The states of a tracked subscription.
+const (
+	subscriptionPending int32 = iota
+	subscriptionActive
+	subscriptionFailed
+	subscriptionReconnecting
+)
+
+// subscriptionHealth tracks whether a subscription resubscribed by event.ResubscribeErr is active.
+type subscriptionHealth struct {
+	state atomic.Int32
+}
+
+// track wraps the subscribe function of the subscription, to follow its failures and resubscriptions. The
+// subscription is reconnecting from the moment it fails, through the backoff of the resubscriptions, until it is
+// resubscribed.
+func (s *subscriptionHealth) track(subscribe event.ResubscribeErrFunc) event.ResubscribeErrFunc {
+	return func(ctx context.Context, lastErr error) (event.Subscription, error) {
+		sub, err := subscribe(ctx, lastErr)
+		if err != nil {
+			if lastErr != nil {
+				s.state.Store(subscriptionReconnecting)
+			} else {
+				s.state.Store(subscriptionFailed)
+			}
+			return nil, err
+		}
+		s.state.Store(subscriptionActive)
+		return s.watch(sub), nil
+	}
+}
+
+// watch returns the subscription, marking it reconnecting as soon as it fails rather than once it is resubscribed.
+func (s *subscriptionHealth) watch(sub event.Subscription) event.Subscription {
+	errc := make(chan error, 1)
+	go func() {
+		defer close(errc)
+		err, ok := <-sub.Err()
+		if !ok {
+			return
+		}
+		if err != nil {
+			s.state.Store(subscriptionReconnecting)
+		}
+		errc <- err
+	}()
+	return &watchedSubscription{Subscription: sub, err: errc}
+}
+
+// watchedSubscription is a subscription whose errors are forwarded by subscriptionHealth.watch.
+type watchedSubscription struct {
+	event.Subscription
+	err chan error
+}
+
+func (s *watchedSubscription) Err() <-chan error {
+	return s.err
+}
+
+// status returns the status of the subscription, starting until it was first attempted.
+func (s *subscriptionHealth) status() string {
+	switch s.state.Load() {
+	case subscriptionActive:
+		return RoleStatusSubscribed
+	case subscriptionReconnecting:
+		return RoleStatusReconnecting
+	case subscriptionFailed:
+		return RoleStatusUnsubscribed
+	default:
+		return RoleStatusStarting
+	}
+}
+
+// inactiveStatus returns the status of a role whose subscription is not active: reconnecting while the subscription
+// resubscribes, unsubscribed otherwise.
+func (s *subscriptionHealth) inactiveStatus() string {
+	if s.state.Load() == subscriptionReconnecting {
+		return RoleStatusReconnecting
+	}
+	return RoleStatusUnsubscribed
+}
+
+func (s *subscriptionHealth) active() bool {
+	return s.state.Load() == subscriptionActive
+}
+
+// Health returns the health of the running roles. The challenger is reported whenever it runs, including when it only
+// defends the outputs of the output submitter. No role is ready while the rollup node is incompatible.
+func (v *Validator) Health() map[string]RoleHealth {
+	roles := make(map[string]RoleHealth)
+	if !v.cfg.OutputSubmitterDisabled {
+		roles[L1RoleSubmitter] = v.l2os.health()
+	}
+	if !v.cfg.OutputSubmitterDisabled || !v.cfg.ChallengerDisabled {
+		roles[L1RoleChallenger] = v.challenger.health()
+	}
+	if v.cfg.GuardianEnabled {
+		roles[L1RoleGuardian] = v.guardian.health()
+	}
+	if v.compat != nil {
+		if err := v.compat.Err(); err != nil {
+			for role, h := range roles {
+				h.Ready, h.Error = false, err.Error()
+				roles[role] = h
+			}
+		}
+	}
+	return roles
+}
+
+// health reports the round state of the output submitter, ready unless its deposit is insufficient or its last
+// attempt to submit an output failed.
+func (l *L2OutputSubmitter) health() RoleHealth {
+	status, err := l.state.get()
+	h := RoleHealth{
+		Ready:  status != RoleStatusStarting && status != RoundStateInsufficientDeposit && err == nil,
+		Status: status,
+	}
+	if err != nil {
+		h.Error = err.Error()
+	}
+	return h
+}
+
+// health reports whether the challenger scanned the previous outputs and is subscribed to the new outputs and
+// challenges.
+func (c *Challenger) health() RoleHealth {
+	status, _ := c.state.get()
+	if status == RoleStatusSynced {
+		if !c.challengeSubHealth.active() {
+			status = c.challengeSubHealth.inactiveStatus()
+		} else if !c.cfg.ChallengerDisabled && !c.l2OutputSubHealth.active() {
+			status = c.l2OutputSubHealth.inactiveStatus()
+		}
+	}
+	return RoleHealth{Ready: status == RoleStatusSynced, Status: status}
+}
+
+// health reports whether the guardian is subscribed to the validation requests of the SecurityCouncil.
+func (g *Guardian) health() RoleHealth {
+	status := g.securityCouncilSubHealth.status()
+	return RoleHealth{Ready: status == RoleStatusSubscribed, Status: status}
+}
+
+// newHealthHandler serves the liveness of the validator on /healthz, always successful, and its readiness on /readyz,
+// failing with 503 if any role is not ready. Both report the health of the roles.
+func newHealthHandler(version string, r HealthReporter) http.Handler {
+	respond := func(w http.ResponseWriter, readiness bool) {
+		res := HealthResponse{Version: version, Ready: true, Roles: r.Health()}
+		for _, role := range res.Roles {
+			res.Ready = res.Ready && role.Ready
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if readiness && !res.Ready {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		_ = json.NewEncoder(w).Encode(&res)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) { respond(w, false) })
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, _ *http.Request) { respond(w, true) })
+	return mux
+}
+
+// serveHealth serves the health of the validator until the context is done.
+func serveHealth(ctx context.Context, host string, port int, version string, r HealthReporter) error {
+	server := &http.Server{
+		Addr:    net.JoinHostPort(host, strconv.Itoa(port)),
+		Handler: newHealthHandler(version, r),
+	}
+	return httputil.ListenAndServeContext(ctx, server)
+}
@@ -0,0 +1,186 @@
+package validator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubscriptionHealth(t *testing.T) {
+	var h subscriptionHealth
+	require.Equal(t, RoleStatusStarting, h.status())
+
+	fail := true
+	subscribe := h.track(func(ctx context.Context, err error) (event.Subscription, error) {
+		if fail {
+			return nil, errors.New("dial failed")
+		}
+		return event.NewSubscription(func(quit <-chan struct{}) error {
+			<-quit
+			return nil
+		}), nil
+	})
+
+	_, err := subscribe(context.Background(), nil)
+	require.Error(t, err)
+	require.Equal(t, RoleStatusUnsubscribed, h.status())
+	require.False(t, h.active())
+
+	fail = false
+	sub, err := subscribe(context.Background(), nil)
+	require.NoError(t, err)
+	defer sub.Unsubscribe()
+	require.Equal(t, RoleStatusSubscribed, h.status())
+	require.True(t, h.active())
+
+	fail = true
+	_, err = subscribe(context.Background(), errors.New("connection lost"))
+	require.Error(t, err)
+	require.Equal(t, RoleStatusReconnecting, h.status(), "failed resubscriptions are reconnecting")
+
+	fail = false
+	_, err = subscribe(context.Background(), errors.New("connection lost"))
+	require.NoError(t, err)
+	require.Equal(t, RoleStatusSubscribed, h.status())
+}
+
+func TestSubscriptionHealthReconnecting(t *testing.T) {
+	var h subscriptionHealth
+	lost := make(chan struct{})
+	subscribe := h.track(func(ctx context.Context, err error) (event.Subscription, error) {
+		return event.NewSubscription(func(quit <-chan struct{}) error {
+			select {
+			case <-lost:
+				return errors.New("connection lost")
+			case <-quit:
+				return nil
+			}
+		}), nil
+	})
+
+	sub, err := subscribe(context.Background(), nil)
+	require.NoError(t, err)
+	require.Equal(t, RoleStatusSubscribed, h.status())
+
+	// the subscription is reconnecting as soon as it fails, before the resubscription after the backoff
+	close(lost)
+	require.EqualError(t, <-sub.Err(), "connection lost")
+	require.Equal(t, RoleStatusReconnecting, h.status())
+	sub.Unsubscribe()
+
+	sub, err = subscribe(context.Background(), errors.New("connection lost"))
+	require.NoError(t, err)
+	require.Equal(t, RoleStatusSubscribed, h.status())
+
+	// an unsubscribed subscription is not reconnecting
+	sub.Unsubscribe()
+	_, ok := <-sub.Err()
+	require.False(t, ok)
+	require.Equal(t, RoleStatusSubscribed, h.status())
+}
+
+func TestRoleHealth(t *testing.T) {
+	t.Run("output submitter", func(t *testing.T) {
+		l := &L2OutputSubmitter{}
+		require.Equal(t, RoleHealth{Status: RoleStatusStarting}, l.health())
+
+		l.state.set(RoundStatePriority)
+		require.Equal(t, RoleHealth{Ready: true, Status: RoundStatePriority}, l.health())
+
+		l.state.fail(errors.New("failed to fetch next output"))
+		require.Equal(t, RoleHealth{Status: RoundStatePriority, Error: "failed to fetch next output"}, l.health())
+
+		l.state.set(RoundStateWaiting)
+		require.Equal(t, RoleHealth{Ready: true, Status: RoundStateWaiting}, l.health())
+
+		l.state.set(RoundStateInsufficientDeposit)
+		require.Equal(t, RoleHealth{Status: RoundStateInsufficientDeposit}, l.health())
+	})
+
+	t.Run("challenger", func(t *testing.T) {
+		c := &Challenger{}
+		require.Equal(t, RoleHealth{Status: RoleStatusStarting}, c.health())
+
+		c.state.set(RoleStatusSyncing)
+		require.Equal(t, RoleHealth{Status: RoleStatusSyncing}, c.health())
+
+		c.state.set(RoleStatusSynced)
+		c.challengeSubHealth.state.Store(subscriptionActive)
+		require.Equal(t, RoleHealth{Status: RoleStatusUnsubscribed}, c.health(), "output subscription is not active")
+
+		c.l2OutputSubHealth.state.Store(subscriptionActive)
+		require.Equal(t, RoleHealth{Ready: true, Status: RoleStatusSynced}, c.health())
+
+		c.challengeSubHealth.state.Store(subscriptionFailed)
+		require.Equal(t, RoleHealth{Status: RoleStatusUnsubscribed}, c.health())
+
+		c.challengeSubHealth.state.Store(subscriptionReconnecting)
+		require.Equal(t, RoleHealth{Status: RoleStatusReconnecting}, c.health())
+	})
+
+	t.Run("challenger defending outputs", func(t *testing.T) {
+		c := &Challenger{cfg: Config{ChallengerDisabled: true}}
+		c.state.set(RoleStatusSynced)
+		c.challengeSubHealth.state.Store(subscriptionActive)
+		require.Equal(t, RoleHealth{Ready: true, Status: RoleStatusSynced}, c.health())
+	})
+
+	t.Run("guardian", func(t *testing.T) {
+		g := &Guardian{}
+		require.Equal(t, RoleHealth{Status: RoleStatusStarting}, g.health())
+
+		g.securityCouncilSubHealth.state.Store(subscriptionActive)
+		require.Equal(t, RoleHealth{Ready: true, Status: RoleStatusSubscribed}, g.health())
+
+		g.securityCouncilSubHealth.state.Store(subscriptionFailed)
+		require.Equal(t, RoleHealth{Status: RoleStatusUnsubscribed}, g.health())
+
+		g.securityCouncilSubHealth.state.Store(subscriptionReconnecting)
+		require.Equal(t, RoleHealth{Status: RoleStatusReconnecting}, g.health())
+	})
+}
+
+type staticHealth map[string]RoleHealth
+
+func (h staticHealth) Health() map[string]RoleHealth {
+	return h
+}
+
+func TestHealthHandler(t *testing.T) {
+	get := func(t *testing.T, h http.Handler, path string) (int, HealthResponse) {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		var res HealthResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		return rec.Code, res
+	}
+
+	roles := staticHealth{
+		L1RoleSubmitter:  {Ready: true, Status: RoundStatePublic},
+		L1RoleChallenger: {Ready: true, Status: RoleStatusSynced},
+		L1RoleGuardian:   {Ready: false, Status: RoleStatusUnsubscribed},
+	}
+	h := newHealthHandler("v1.0.0", roles)
+
+	code, res := get(t, h, "/healthz")
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, "v1.0.0", res.Version)
+	require.False(t, res.Ready)
+	require.Equal(t, map[string]RoleHealth(roles), res.Roles)
+
+	code, res = get(t, h, "/readyz")
+	require.Equal(t, http.StatusServiceUnavailable, code)
+	require.False(t, res.Ready)
+	require.Equal(t, RoleStatusUnsubscribed, res.Roles[L1RoleGuardian].Status)
+
+	roles[L1RoleGuardian] = RoleHealth{Ready: true, Status: RoleStatusSubscribed}
+	code, res = get(t, h, "/readyz")
+	require.Equal(t, http.StatusOK, code)
+	require.True(t, res.Ready)
+}
@@ -0,0 +1,176 @@
+package validator
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/kroma-network/kroma/components/node/eth"
+	"github.com/kroma-network/kroma/utils/service/proxy"
+	"github.com/kroma-network/kroma/utils/service/webhook"
+)
+
+// HeartbeatSignatureHeader is the HTTP header of the hex encoded HMAC-SHA256 signature of the body of a heartbeat.
+const HeartbeatSignatureHeader = "X-Heartbeat-Signature"
+
+// HeartbeatConfig configures the heartbeats posted to a coordination endpoint,
+// to monitor the liveness of a fleet of validators centrally.
+type HeartbeatConfig struct {
+	// Endpoint is the HTTP URL the heartbeats are posted to. The heartbeats are disabled if it is empty.
+	Endpoint string
+	// Interval is how frequently a heartbeat is posted.
+	Interval time.Duration
+	// Secret is the key of the signature of the heartbeats, shared with the endpoint.
+	Secret []byte
+	// Version is the version of the validator reported in the heartbeats.
+	Version string
+	// Proxy routes the heartbeats to the endpoint. If nil, the proxy of the environment is used.
+	Proxy *proxy.Config
+}
+
+func (c HeartbeatConfig) Enabled() bool {
+	return c.Endpoint != ""
+}
+
+// Heartbeat is the payload posted to the coordination endpoint.
+// The heads are omitted if the sync status of the rollup node could not be fetched.
+type Heartbeat struct {
+	Component   string         `json:"component"`
+	Version     string         `json:"version"`
+	Validator   common.Address `json:"validator"`
+	Time        uint64         `json:"time"`
+	Roles       []string       `json:"roles"`
+	HeadL1      *eth.BlockID   `json:"headL1,omitempty"`
+	UnsafeL2    *eth.BlockID   `json:"unsafeL2,omitempty"`
+	SafeL2      *eth.BlockID   `json:"safeL2,omitempty"`
+	FinalizedL2 *eth.BlockID   `json:"finalizedL2,omitempty"`
+}
+
+// SignHeartbeat returns the signature of the body of a heartbeat.
+func SignHeartbeat(secret []byte, body []byte) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return mac.Sum(nil)
+}
+
+// roles returns the enabled roles of the validator.
+func (c *Config) roles() []string {
+	var roles []string
+	if !c.OutputSubmitterDisabled {
+		roles = append(roles, L1RoleSubmitter)
+	}
+	if !c.ChallengerDisabled {
+		roles = append(roles, L1RoleChallenger)
+	}
+	if c.GuardianEnabled {
+		roles = append(roles, L1RoleGuardian)
+	}
+	return roles
+}
+
+type SyncStatusProvider interface {
+	SyncStatus(ctx context.Context) (*eth.SyncStatus, error)
+}
+
+// heartbeater posts the signed heartbeats of the validator on an interval.
+type heartbeater struct {
+	log      log.Logger
+	cfg      HeartbeatConfig
+	notifier *webhook.Notifier
+
+	from           common.Address
+	roles          []string
+	rollupClient   SyncStatusProvider
+	networkTimeout time.Duration
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func newHeartbeater(l log.Logger, cfg HeartbeatConfig, from common.Address, roles []string, rollupClient SyncStatusProvider,
+	networkTimeout time.Duration,
+) *heartbeater {
+	return &heartbeater{
+		log:            l.New("service", "heartbeat"),
+		cfg:            cfg,
+		notifier:       webhook.NewNotifier(cfg.Endpoint, cfg.Proxy.HTTPClient(networkTimeout)),
+		from:           from,
+		roles:          roles,
+		rollupClient:   rollupClient,
+		networkTimeout: networkTimeout,
+	}
+}
+
+func (h *heartbeater) Start(ctx context.Context) {
+	ctx, h.cancel = context.WithCancel(ctx)
+	h.wg.Add(1)
+	go h.loop(ctx)
+}
+
+func (h *heartbeater) Stop() {
+	h.cancel()
+	h.wg.Wait()
+}
+
+func (h *heartbeater) loop(ctx context.Context) {
+	defer h.wg.Done()
+
+	ticker := time.NewTicker(h.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		if err := h.send(ctx); err != nil {
+			h.log.Warn("failed to send heartbeat", "endpoint", h.cfg.Endpoint, "err", err)
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (h *heartbeater) heartbeat(ctx context.Context) Heartbeat {
+	hb := Heartbeat{
+		Component: "validator",
+		Version:   h.cfg.Version,
+		Validator: h.from,
+		Time:      uint64(time.Now().Unix()),
+		Roles:     h.roles,
+	}
+
+	cCtx, cCancel := context.WithTimeout(ctx, h.networkTimeout)
+	defer cCancel()
+	status, err := h.rollupClient.SyncStatus(cCtx)
+	if err != nil {
+		h.log.Warn("failed to fetch sync status for heartbeat", "err", err)
+		return hb
+	}
+	headL1, unsafeL2, safeL2, finalizedL2 := status.HeadL1.ID(), status.UnsafeL2.ID(), status.SafeL2.ID(), status.FinalizedL2.ID()
+	hb.HeadL1, hb.UnsafeL2, hb.SafeL2, hb.FinalizedL2 = &headL1, &unsafeL2, &safeL2, &finalizedL2
+	return hb
+}
+
+func (h *heartbeater) send(ctx context.Context) error {
+	body, err := json.Marshal(h.heartbeat(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to encode heartbeat: %w", err)
+	}
+
+	cCtx, cCancel := context.WithTimeout(ctx, h.networkTimeout)
+	defer cCancel()
+	header := http.Header{HeartbeatSignatureHeader: []string{hexutil.Encode(SignHeartbeat(h.cfg.Secret, body))}}
+	if err := h.notifier.Post(cCtx, body, header); err != nil {
+		return fmt.Errorf("failed to post heartbeat: %w", err)
+	}
+	return nil
+}
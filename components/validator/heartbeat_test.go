@@ -0,0 +1,98 @@
+package validator
+
+import (
+	"context"
+	"crypto/hmac"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kroma-network/kroma/components/node/eth"
+	"github.com/kroma-network/kroma/components/node/testlog"
+)
+
+type fakeSyncStatus struct {
+	status *eth.SyncStatus
+	err    error
+}
+
+func (s *fakeSyncStatus) SyncStatus(_ context.Context) (*eth.SyncStatus, error) {
+	return s.status, s.err
+}
+
+// heartbeatEndpoint serves a coordination endpoint, and returns the heartbeats with a valid signature.
+func heartbeatEndpoint(t *testing.T, secret []byte) (*httptest.Server, <-chan Heartbeat) {
+	heartbeats := make(chan Heartbeat, 10)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		sig, err := hexutil.Decode(r.Header.Get(HeartbeatSignatureHeader))
+		if err != nil || !hmac.Equal(sig, SignHeartbeat(secret, body)) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		var hb Heartbeat
+		require.NoError(t, json.Unmarshal(body, &hb))
+		heartbeats <- hb
+	}))
+	t.Cleanup(srv.Close)
+	return srv, heartbeats
+}
+
+func TestHeartbeater(t *testing.T) {
+	secret := common.FromHex("0x0102030405060708091011121314151617181920212223242526272829303132")
+	srv, heartbeats := heartbeatEndpoint(t, secret)
+
+	status := &eth.SyncStatus{
+		HeadL1:      eth.L1BlockRef{Hash: common.Hash{0x01}, Number: 100},
+		UnsafeL2:    eth.L2BlockRef{Hash: common.Hash{0x02}, Number: 300},
+		SafeL2:      eth.L2BlockRef{Hash: common.Hash{0x03}, Number: 200},
+		FinalizedL2: eth.L2BlockRef{Hash: common.Hash{0x04}, Number: 100},
+	}
+	cfg := HeartbeatConfig{Endpoint: srv.URL, Interval: time.Hour, Secret: secret, Version: "v0.1.0"}
+	roles := (&Config{GuardianEnabled: true, ChallengerDisabled: true}).roles()
+	h := newHeartbeater(testlog.Logger(t, log.LvlCrit), cfg, common.Address{0xaa}, roles, &fakeSyncStatus{status: status}, time.Second)
+
+	h.Start(context.Background())
+	hb := <-heartbeats
+	h.Stop()
+
+	require.Equal(t, "validator", hb.Component)
+	require.Equal(t, "v0.1.0", hb.Version)
+	require.Equal(t, common.Address{0xaa}, hb.Validator)
+	require.Equal(t, []string{L1RoleSubmitter, L1RoleGuardian}, hb.Roles)
+	require.Equal(t, status.HeadL1.ID(), *hb.HeadL1)
+	require.Equal(t, status.UnsafeL2.ID(), *hb.UnsafeL2)
+	require.Equal(t, status.SafeL2.ID(), *hb.SafeL2)
+	require.Equal(t, status.FinalizedL2.ID(), *hb.FinalizedL2)
+}
+
+func TestHeartbeaterSend(t *testing.T) {
+	secret := common.FromHex("0x0102030405060708091011121314151617181920212223242526272829303132")
+	srv, heartbeats := heartbeatEndpoint(t, secret)
+	l := testlog.Logger(t, log.LvlCrit)
+
+	t.Run("without sync status", func(t *testing.T) {
+		cfg := HeartbeatConfig{Endpoint: srv.URL, Interval: time.Hour, Secret: secret}
+		h := newHeartbeater(l, cfg, common.Address{0xaa}, nil, &fakeSyncStatus{err: errors.New("unavailable")}, time.Second)
+		require.NoError(t, h.send(context.Background()))
+		hb := <-heartbeats
+		require.Nil(t, hb.HeadL1, "heads must be omitted if the sync status is unavailable")
+		require.Nil(t, hb.SafeL2)
+	})
+
+	t.Run("invalid signature", func(t *testing.T) {
+		cfg := HeartbeatConfig{Endpoint: srv.URL, Interval: time.Hour, Secret: []byte("other secret")}
+		h := newHeartbeater(l, cfg, common.Address{0xaa}, nil, &fakeSyncStatus{status: &eth.SyncStatus{}}, time.Second)
+		require.ErrorContains(t, h.send(context.Background()), "401")
+	})
+}
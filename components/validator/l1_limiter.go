@@ -0,0 +1,350 @@
+package validator
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"golang.org/x/time/rate"
+
+	"github.com/kroma-network/kroma/utils/service/txmgr"
+)
+
+// The roles sharing the L1 call budget.
+const (
+	L1RoleSubmitter  = "submitter"
+	L1RoleChallenger = "challenger"
+	L1RoleGuardian   = "guardian"
+	L1RoleTxMgr      = "txmgr"
+)
+
+var l1Roles = []string{L1RoleSubmitter, L1RoleChallenger, L1RoleGuardian, L1RoleTxMgr}
+
+type L1LimiterMetrics interface {
+	RecordL1CallWait(role string, wait time.Duration)
+	RecordL1CallsInFlight(role string, inFlight int)
+	RecordL1CallsQueued(role string, queued int)
+}
+
+// L1Limiter bounds the outbound L1 calls of all roles of the validator, so that they do not trip the
+// rate limits of the L1 provider together. It limits the number of concurrent calls and the rate of calls.
+// When all call slots are in use, the freed slots are handed to the waiting roles in turn,
+// so that a busy role cannot starve the others.
+type L1Limiter struct {
+	maxConcurrent int
+	rl            *rate.Limiter
+	metr          L1LimiterMetrics
+
+	mu       sync.Mutex
+	inFlight map[string]int
+	waiters  map[string][]chan struct{}
+	// next is the index of the role in l1Roles that is handed the next freed slot.
+	next int
+}
+
+// NewL1Limiter creates an L1Limiter allowing maxConcurrent concurrent calls, and callsPerSecond calls per second
+// with bursts of burst calls. A maxConcurrent or callsPerSecond of 0 disables the respective limit.
+func NewL1Limiter(maxConcurrent int, callsPerSecond float64, burst int, m L1LimiterMetrics) *L1Limiter {
+	l := &L1Limiter{
+		maxConcurrent: maxConcurrent,
+		metr:          m,
+		inFlight:      make(map[string]int),
+		waiters:       make(map[string][]chan struct{}),
+	}
+	if callsPerSecond > 0 {
+		if burst < 1 {
+			burst = 1
+		}
+		l.rl = rate.NewLimiter(rate.Limit(callsPerSecond), burst)
+	}
+	return l
+}
+
+// Acquire blocks until the role may make an L1 call, and returns the function to call once the call is done.
+func (l *L1Limiter) Acquire(ctx context.Context, role string) (func(), error) {
+	start := time.Now()
+	if err := l.acquireSlot(ctx, role); err != nil {
+		return nil, err
+	}
+	if l.rl != nil {
+		if err := l.rl.Wait(ctx); err != nil {
+			l.release(role)
+			return nil, err
+		}
+	}
+	l.metr.RecordL1CallWait(role, time.Since(start))
+
+	var once sync.Once
+	return func() { once.Do(func() { l.release(role) }) }, nil
+}
+
+func (l *L1Limiter) acquireSlot(ctx context.Context, role string) error {
+	l.mu.Lock()
+	if l.maxConcurrent <= 0 || (l.total() < l.maxConcurrent && l.queued() == 0) {
+		l.setInFlight(role, l.inFlight[role]+1)
+		l.mu.Unlock()
+		return nil
+	}
+	ready := make(chan struct{})
+	l.setQueue(role, append(l.waiters[role], ready))
+	l.mu.Unlock()
+
+	select {
+	case <-ready:
+		return nil
+	case <-ctx.Done():
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		queue := l.waiters[role]
+		for i, w := range queue {
+			if w == ready {
+				l.setQueue(role, append(queue[:i:i], queue[i+1:]...))
+				return ctx.Err()
+			}
+		}
+		// the slot was handed over concurrently, pass it on.
+		l.setInFlight(role, l.inFlight[role]-1)
+		l.handOver()
+		return ctx.Err()
+	}
+}
+
+func (l *L1Limiter) release(role string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.setInFlight(role, l.inFlight[role]-1)
+	l.handOver()
+}
+
+// handOver hands a free slot to the first waiter of the next role with waiters, in turn.
+func (l *L1Limiter) handOver() {
+	if l.maxConcurrent > 0 && l.total() >= l.maxConcurrent {
+		return
+	}
+	for i := 0; i < len(l1Roles); i++ {
+		role := l1Roles[(l.next+i)%len(l1Roles)]
+		queue := l.waiters[role]
+		if len(queue) == 0 {
+			continue
+		}
+		l.next = (l.next + i + 1) % len(l1Roles)
+		l.setQueue(role, queue[1:])
+		l.setInFlight(role, l.inFlight[role]+1)
+		close(queue[0])
+		return
+	}
+}
+
+func (l *L1Limiter) total() int {
+	total := 0
+	for _, n := range l.inFlight {
+		total += n
+	}
+	return total
+}
+
+func (l *L1Limiter) queued() int {
+	queued := 0
+	for _, queue := range l.waiters {
+		queued += len(queue)
+	}
+	return queued
+}
+
+func (l *L1Limiter) setInFlight(role string, n int) {
+	l.inFlight[role] = n
+	l.metr.RecordL1CallsInFlight(role, n)
+}
+
+func (l *L1Limiter) setQueue(role string, queue []chan struct{}) {
+	l.waiters[role] = queue
+	l.metr.RecordL1CallsQueued(role, len(queue))
+}
+
+// Client returns the L1 client of the role, whose calls are limited by the L1Limiter.
+// A nil L1Limiter does not limit the calls.
+func (l *L1Limiter) Client(role string, client *ethclient.Client) *LimitedL1Client {
+	return &LimitedL1Client{client: client, limiter: l, role: role}
+}
+
+// LimitedL1Client is an L1 client of a role, whose calls are limited by the shared L1Limiter.
+// Subscriptions only count against the limits when they are created.
+type LimitedL1Client struct {
+	client  *ethclient.Client
+	limiter *L1Limiter
+	role    string
+}
+
+var (
+	_ bind.ContractBackend = (*LimitedL1Client)(nil)
+	_ txmgr.ETHBackend     = (*LimitedL1Client)(nil)
+	_ L1HeaderSource       = (*LimitedL1Client)(nil)
+	_ AccountBalance       = (*LimitedL1Client)(nil)
+	_ StorageReader        = (*LimitedL1Client)(nil)
+)
+
+func (c *LimitedL1Client) acquire(ctx context.Context) (func(), error) {
+	if c.limiter == nil {
+		return func() {}, nil
+	}
+	return c.limiter.Acquire(ctx, c.role)
+}
+
+func (c *LimitedL1Client) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
+	done, err := c.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+	return c.client.CodeAt(ctx, contract, blockNumber)
+}
+
+func (c *LimitedL1Client) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	done, err := c.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+	return c.client.CallContract(ctx, call, blockNumber)
+}
+
+func (c *LimitedL1Client) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	done, err := c.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+	return c.client.HeaderByNumber(ctx, number)
+}
+
+func (c *LimitedL1Client) HeaderByHash(ctx context.Context, hash common.Hash) (*types.Header, error) {
+	done, err := c.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+	return c.client.HeaderByHash(ctx, hash)
+}
+
+func (c *LimitedL1Client) BlockNumber(ctx context.Context) (uint64, error) {
+	done, err := c.acquire(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer done()
+	return c.client.BlockNumber(ctx)
+}
+
+func (c *LimitedL1Client) PendingCodeAt(ctx context.Context, account common.Address) ([]byte, error) {
+	done, err := c.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+	return c.client.PendingCodeAt(ctx, account)
+}
+
+func (c *LimitedL1Client) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+	done, err := c.acquire(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer done()
+	return c.client.NonceAt(ctx, account, blockNumber)
+}
+
+func (c *LimitedL1Client) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	done, err := c.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+	return c.client.BalanceAt(ctx, account, blockNumber)
+}
+
+func (c *LimitedL1Client) StorageAt(ctx context.Context, account common.Address, key common.Hash, blockNumber *big.Int) ([]byte, error) {
+	done, err := c.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+	return c.client.StorageAt(ctx, account, key, blockNumber)
+}
+
+func (c *LimitedL1Client) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	done, err := c.acquire(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer done()
+	return c.client.PendingNonceAt(ctx, account)
+}
+
+func (c *LimitedL1Client) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	done, err := c.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+	return c.client.SuggestGasPrice(ctx)
+}
+
+func (c *LimitedL1Client) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	done, err := c.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+	return c.client.SuggestGasTipCap(ctx)
+}
+
+func (c *LimitedL1Client) EstimateGas(ctx context.Context, call ethereum.CallMsg) (uint64, error) {
+	done, err := c.acquire(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer done()
+	return c.client.EstimateGas(ctx, call)
+}
+
+func (c *LimitedL1Client) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	done, err := c.acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer done()
+	return c.client.SendTransaction(ctx, tx)
+}
+
+func (c *LimitedL1Client) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	done, err := c.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+	return c.client.TransactionReceipt(ctx, txHash)
+}
+
+func (c *LimitedL1Client) FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error) {
+	done, err := c.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+	return c.client.FilterLogs(ctx, query)
+}
+
+func (c *LimitedL1Client) SubscribeFilterLogs(ctx context.Context, query ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	done, err := c.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+	return c.client.SubscribeFilterLogs(ctx, query, ch)
+}
@@ -0,0 +1,105 @@
+package validator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kroma-network/kroma/components/validator/metrics"
+)
+
+// queue acquires a slot for the role in the background, once it is queued.
+func queue(t *testing.T, l *L1Limiter, role string, acquired chan<- string) {
+	l.mu.Lock()
+	queued := len(l.waiters[role])
+	l.mu.Unlock()
+
+	go func() {
+		done, err := l.Acquire(context.Background(), role)
+		require.NoError(t, err)
+		acquired <- role
+		done()
+	}()
+	require.Eventually(t, func() bool {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		return len(l.waiters[role]) == queued+1
+	}, time.Second, time.Millisecond)
+}
+
+func TestL1LimiterFairness(t *testing.T) {
+	l := NewL1Limiter(1, 0, 0, metrics.NoopMetrics)
+	done, err := l.Acquire(context.Background(), L1RoleTxMgr)
+	require.NoError(t, err)
+
+	acquired := make(chan string, 4)
+	queue(t, l, L1RoleChallenger, acquired)
+	queue(t, l, L1RoleChallenger, acquired)
+	queue(t, l, L1RoleChallenger, acquired)
+	queue(t, l, L1RoleGuardian, acquired)
+	done()
+
+	var order []string
+	for i := 0; i < 4; i++ {
+		order = append(order, <-acquired)
+	}
+	// the guardian is not starved by the queued calls of the challenger.
+	require.Equal(t, []string{L1RoleChallenger, L1RoleGuardian, L1RoleChallenger, L1RoleChallenger}, order)
+	require.Eventually(t, func() bool {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		return l.total() == 0 && l.queued() == 0
+	}, time.Second, time.Millisecond)
+}
+
+func TestL1LimiterMaxConcurrent(t *testing.T) {
+	l := NewL1Limiter(2, 0, 0, metrics.NoopMetrics)
+	first, err := l.Acquire(context.Background(), L1RoleSubmitter)
+	require.NoError(t, err)
+	_, err = l.Acquire(context.Background(), L1RoleGuardian)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = l.Acquire(ctx, L1RoleChallenger)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	l.mu.Lock()
+	require.Zero(t, l.queued())
+	l.mu.Unlock()
+
+	// releasing twice frees a single slot.
+	first()
+	first()
+	_, err = l.Acquire(context.Background(), L1RoleChallenger)
+	require.NoError(t, err)
+	l.mu.Lock()
+	require.Equal(t, 2, l.total())
+	l.mu.Unlock()
+}
+
+func TestL1LimiterRateLimit(t *testing.T) {
+	l := NewL1Limiter(0, 1, 2, metrics.NoopMetrics)
+	for i := 0; i < 2; i++ {
+		done, err := l.Acquire(context.Background(), L1RoleSubmitter)
+		require.NoError(t, err)
+		done()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := l.Acquire(ctx, L1RoleSubmitter)
+	require.Error(t, err, "the burst is used up")
+	l.mu.Lock()
+	require.Zero(t, l.total())
+	l.mu.Unlock()
+}
+
+func TestLimitedL1ClientWithoutLimiter(t *testing.T) {
+	var l *L1Limiter
+	c := l.Client(L1RoleGuardian, nil)
+	done, err := c.acquire(context.Background())
+	require.NoError(t, err)
+	done()
+}
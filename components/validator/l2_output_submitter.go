@@ -1,18 +1,18 @@
 package validator
 
 import (
-	"bytes"
 	"context"
 	"errors"
 	"fmt"
 	"math/big"
-	_ "net/http/pprof"
 	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/log"
 
 	"github.com/kroma-network/kroma/bindings/bindings"
@@ -40,21 +40,40 @@ type L2OutputSubmitter struct {
 	metr metrics.Metricer
 
 	l2ooContract    *bindings.L2OutputOracleCaller
+	l2ooFilterer    *bindings.L2OutputOracleFilterer
 	l2ooABI         *abi.ABI
 	valpoolContract *bindings.ValidatorPoolCaller
 
+	// deposits tops up the deposit the bonds of the outputs are taken from, nil if the top up is disabled.
+	deposits *depositMonitor
+	// unbonds unbonds the bonds of the finalized outputs, nil if the auto unbond is disabled.
+	unbonds *unbonder
+
+	rounds                   *roundTracker
+	outputSubmittedEventChan chan *bindings.L2OutputOracleOutputSubmitted
+	outputSub                event.Subscription
+
 	singleRoundInterval *big.Int
 	l2BlockTime         *big.Int
 
 	txCandidatesChan chan<- txmgr.TxCandidate
 	submitChan       chan struct{}
+	// state is the state of the submission round and the error of the last attempt, reported as the health of the
+	// output submitter
+	state roleState
 
 	wg sync.WaitGroup
 }
 
 // NewL2OutputSubmitter creates a new L2OutputSubmitter.
 func NewL2OutputSubmitter(ctx context.Context, cfg Config, l log.Logger, m metrics.Metricer) (*L2OutputSubmitter, error) {
-	l2ooContract, err := bindings.NewL2OutputOracleCaller(cfg.L2OutputOracleAddr, cfg.L1Client)
+	l1Client := cfg.L1Limiter.Client(L1RoleSubmitter, cfg.L1Client)
+	l2ooContract, err := bindings.NewL2OutputOracleCaller(cfg.L2OutputOracleAddr, l1Client)
+	if err != nil {
+		return nil, err
+	}
+
+	l2ooFilterer, err := bindings.NewL2OutputOracleFilterer(cfg.L2OutputOracleAddr, l1Client)
 	if err != nil {
 		return nil, err
 	}
@@ -64,7 +83,7 @@ func NewL2OutputSubmitter(ctx context.Context, cfg Config, l log.Logger, m metri
 		return nil, err
 	}
 
-	valpoolContract, err := bindings.NewValidatorPoolCaller(cfg.ValidatorPoolAddr, cfg.L1Client)
+	valpoolContract, err := bindings.NewValidatorPoolCaller(cfg.ValidatorPoolAddr, l1Client)
 	if err != nil {
 		return nil, err
 	}
@@ -87,13 +106,38 @@ func NewL2OutputSubmitter(ctx context.Context, cfg Config, l log.Logger, m metri
 	}
 	singleRoundInterval := new(big.Int).Div(submissionInterval, new(big.Int).SetUint64(roundNums))
 
+	rounds, err := newRoundTracker(l, m, cfg.TxManager.From(), cfg.ValidatorPoolAddr, valpoolContract, l2ooContract, l1Client, cfg.NetworkTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	var deposits *depositMonitor
+	if cfg.DepositTopUp.Enabled() {
+		deposits, err = newDepositMonitor(l, m, cfg.DepositTopUp, cfg.TxManager.From(), cfg.ValidatorPoolAddr, valpoolContract, cfg.NetworkTimeout)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var unbonds *unbonder
+	if cfg.UnbondInterval > 0 {
+		unbonds, err = newUnbonder(l, m, cfg.ValidatorPoolAddr, valpoolContract, l2ooContract, l1Client, cfg.NetworkTimeout)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return &L2OutputSubmitter{
 		cfg:                 cfg,
 		log:                 l,
 		metr:                m,
 		l2ooContract:        l2ooContract,
+		l2ooFilterer:        l2ooFilterer,
 		l2ooABI:             parsed,
 		valpoolContract:     valpoolContract,
+		rounds:              rounds,
+		deposits:            deposits,
+		unbonds:             unbonds,
 		singleRoundInterval: singleRoundInterval,
 		l2BlockTime:         l2BlockTime,
 	}, nil
@@ -102,22 +146,48 @@ func NewL2OutputSubmitter(ctx context.Context, cfg Config, l log.Logger, m metri
 func (l *L2OutputSubmitter) Start(ctx context.Context, txCandidatesChan chan<- txmgr.TxCandidate) error {
 	l.ctx, l.cancel = context.WithCancel(ctx)
 	l.log.Info("starting L2 Output Submitter")
+	if l.cfg.FaultInjection > 0 {
+		l.log.Warn("fault injection is enabled, outputs are submitted with corrupted output roots",
+			"interval", l.cfg.FaultInjection)
+	}
 
 	l.submitChan = make(chan struct{}, 1)
 	l.txCandidatesChan = txCandidatesChan
 	l.wg.Add(1)
 	go l.loop()
 
+	l.outputSubmittedEventChan = make(chan *bindings.L2OutputOracleOutputSubmitted)
+	opts := &bind.WatchOpts{Context: l.ctx}
+	l.outputSub = event.ResubscribeErr(time.Second*10, func(ctx context.Context, err error) (event.Subscription, error) {
+		if err != nil {
+			l.log.Warn("resubscribing after failed L2OutputSubmitted event", "err", err)
+		}
+		return l.l2ooFilterer.WatchOutputSubmitted(opts, l.outputSubmittedEventChan, nil, nil, nil)
+	})
+	l.wg.Add(1)
+	go l.trackRounds(l.ctx)
+
+	if l.deposits != nil {
+		l.wg.Add(1)
+		go l.depositLoop(l.ctx)
+	}
+	if l.unbonds != nil {
+		l.wg.Add(1)
+		go l.unbondLoop(l.ctx)
+	}
+
 	return nil
 }
 
 func (l *L2OutputSubmitter) Stop() error {
 	l.log.Info("stopping L2 Output Submitter")
 
+	l.outputSub.Unsubscribe()
 	l.cancel()
 	l.wg.Wait()
 
 	close(l.submitChan)
+	close(l.outputSubmittedEventChan)
 
 	return nil
 }
@@ -131,6 +201,7 @@ func (l *L2OutputSubmitter) loop() {
 		select {
 		case <-l.submitChan:
 			if err := l.trySubmitL2Output(l.ctx); err != nil {
+				l.state.fail(err)
 				l.log.Error("failed to submit l2 output", "err", err)
 				l.retryAfter(l.cfg.OutputSubmitterRetryInterval)
 			}
@@ -140,6 +211,62 @@ func (l *L2OutputSubmitter) loop() {
 	}
 }
 
+// trackRounds records the outcomes of the submission rounds of the submitted outputs.
+func (l *L2OutputSubmitter) trackRounds(ctx context.Context) {
+	defer l.wg.Done()
+
+	for {
+		select {
+		case ev := <-l.outputSubmittedEventChan:
+			outcome, err := l.rounds.track(ctx, ev)
+			if err != nil {
+				l.log.Error("failed to track the submission round of output", "outputIndex", ev.L2OutputIndex, "err", err)
+			} else if outcome == RoundOutcomeMissed {
+				// reported as the health of the output submitter until its next submission attempt
+				l.state.fail(fmt.Errorf("missed the priority round of output %s (%d missed)", ev.L2OutputIndex, l.rounds.Missed()))
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// depositLoop periodically tops up the deposit of the validator below the threshold.
+func (l *L2OutputSubmitter) depositLoop(ctx context.Context) {
+	defer l.wg.Done()
+
+	ticker := time.NewTicker(l.cfg.DepositTopUp.Interval)
+	defer ticker.Stop()
+	for {
+		if err := l.deposits.check(ctx, l.txCandidatesChan); err != nil {
+			l.log.Error("failed to top up validator deposit", "err", err)
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// unbondLoop periodically unbonds the bonds of the finalized outputs.
+func (l *L2OutputSubmitter) unbondLoop(ctx context.Context) {
+	defer l.wg.Done()
+
+	ticker := time.NewTicker(l.cfg.UnbondInterval)
+	defer ticker.Stop()
+	for {
+		if err := l.unbonds.check(ctx, l.txCandidatesChan); err != nil {
+			l.log.Error("failed to unbond finalized outputs", "err", err)
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
 func (l *L2OutputSubmitter) retryAfter(d time.Duration) {
 	l.wg.Add(1)
 
@@ -163,12 +290,29 @@ func (l *L2OutputSubmitter) trySubmitL2Output(ctx context.Context) error {
 	if err != nil {
 		return fmt.Errorf("failed to fetch next output: %w", err)
 	}
+	if injectFault(output, l.cfg.FaultInjection) {
+		l.log.Warn("fault injection: submitting corrupted output root", "blockNumber", nextBlockNumber,
+			"outputRoot", output.OutputRoot)
+	}
 
 	data, err := SubmitL2OutputTxData(l.l2ooABI, output, l.cfg.OutputSubmitterBondAmount)
 	if err != nil {
 		return fmt.Errorf("failed to create submit l2 output transaction data: %w", err)
 	}
 
+	// The priority round may have ended while fetching the output, so check the round again right before the
+	// submission instead of sending a transaction that reverts.
+	roundInfo, err := l.fetchCurrentRound(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to check current round: %w", err)
+	}
+	if !roundInfo.canSubmit() {
+		l.log.Info("skip output submission since the round changed", "blockNumber", nextBlockNumber)
+		l.metr.RecordOutputRoundSkip(RoundSkipRoundChanged)
+		l.retryAfter(l.cfg.OutputSubmitterRetryInterval)
+		return nil
+	}
+
 	if err := l.submitL2OutputTx(data, nextBlockNumber); err != nil {
 		return fmt.Errorf("failed to submit l2 output transaction: %w", err)
 	}
@@ -185,6 +329,7 @@ func (l *L2OutputSubmitter) CanSubmit(ctx context.Context) (*big.Int, bool, erro
 		return nil, false, err
 	}
 	if !hasEnoughDeposit {
+		l.state.set(RoundStateInsufficientDeposit)
 		l.retryAfter(l.cfg.OutputSubmitterRetryInterval)
 		return nil, false, nil
 	}
@@ -206,6 +351,7 @@ func (l *L2OutputSubmitter) CanSubmit(ctx context.Context) (*big.Int, bool, erro
 	roundBuffer := new(big.Int).SetUint64(l.cfg.OutputSubmitterRoundBuffer)
 	if currentBlockNumber.Cmp(nextBlockNumberToWait) < 0 {
 		nextBlockNumberToWait = new(big.Int).Sub(nextBlockNumber, roundBuffer)
+		l.state.set(RoundStateWaiting)
 		l.waitL2Blocks(currentBlockNumber, nextBlockNumberToWait)
 		return nil, false, nil
 	}
@@ -217,16 +363,20 @@ func (l *L2OutputSubmitter) CanSubmit(ctx context.Context) (*big.Int, bool, erro
 	}
 	// if it's a public round, try to submit right now
 	if roundInfo.isPublicRound {
+		l.state.set(RoundStatePublic)
 		return nextBlockNumber, true, nil
 	}
 	// if it's a priority round, wait for L2 blocks proceeding until public round when not selected for priority validator
 	if !roundInfo.isPriorityValidator {
+		l.metr.RecordOutputRoundSkip(RoundSkipNotPriority)
+		l.state.set(RoundStateNotPriority)
 		roundIntervalToWait := new(big.Int).Sub(l.singleRoundInterval, roundBuffer)
 		nextBlockNumberToWait = new(big.Int).Add(nextBlockNumber, roundIntervalToWait)
 		l.waitL2Blocks(currentBlockNumber, nextBlockNumberToWait)
 		return nil, false, nil
 	}
 
+	l.state.set(RoundStatePriority)
 	return nextBlockNumber, true, nil
 }
 
@@ -295,11 +445,33 @@ func (l *L2OutputSubmitter) waitL2Blocks(currentBlockNumber *big.Int, targetBloc
 	l.retryAfter(waitDuration)
 }
 
+// The reasons of the submission rounds skipped by the validator.
+const (
+	// RoundSkipNotPriority is a priority round of another validator, waited out until the public round.
+	RoundSkipNotPriority = "not_priority"
+	// RoundSkipRoundChanged is a submission skipped since the priority round of the validator ended before the
+	// submission.
+	RoundSkipRoundChanged = "round_changed"
+)
+
 type roundInfo struct {
 	isPublicRound       bool
 	isPriorityValidator bool
 }
 
+// newRoundInfo returns the round of the next validator selected by the ValidatorPool for the validator.
+func newRoundInfo(nextValidator common.Address, from common.Address) roundInfo {
+	return roundInfo{
+		isPublicRound:       nextValidator == PublicRoundAddress,
+		isPriorityValidator: nextValidator != PublicRoundAddress && nextValidator == from,
+	}
+}
+
+// canSubmit returns if the validator is allowed to submit an output in the round.
+func (r roundInfo) canSubmit() bool {
+	return r.isPublicRound || r.isPriorityValidator
+}
+
 // fetchCurrentRound fetches next validator address from ValidatorPool contract.
 // It returns if current round is public round, and if selected for priority validator if it's a priority round.
 func (l *L2OutputSubmitter) fetchCurrentRound(ctx context.Context) (roundInfo, error) {
@@ -309,33 +481,19 @@ func (l *L2OutputSubmitter) fetchCurrentRound(ctx context.Context) (roundInfo, e
 	nextValidator, err := l.valpoolContract.NextValidator(callOpts)
 	if err != nil {
 		l.log.Error("validator unable to get next validator address", "err", err)
-		return roundInfo{
-			isPublicRound:       false,
-			isPriorityValidator: false,
-		}, err
+		return roundInfo{}, err
 	}
 
-	if bytes.Equal(nextValidator[:], PublicRoundAddress[:]) {
+	round := newRoundInfo(nextValidator, l.cfg.TxManager.From())
+	switch {
+	case round.isPublicRound:
 		l.log.Info("current round is public round")
-		return roundInfo{
-			isPublicRound:       true,
-			isPriorityValidator: false,
-		}, nil
-	}
-
-	if nextValidator == l.cfg.TxManager.From() {
+	case round.isPriorityValidator:
 		l.log.Info("current round is priority round, and selected for priority validator")
-		return roundInfo{
-			isPublicRound:       false,
-			isPriorityValidator: true,
-		}, nil
+	default:
+		l.log.Info("current round is priority round, and not selected for priority validator")
 	}
-
-	l.log.Info("current round is priority round, and not selected for priority validator")
-	return roundInfo{
-		isPublicRound:       false,
-		isPriorityValidator: false,
-	}, nil
+	return round, nil
 }
 
 // FetchOutput gets the output information to the corresponding block number.
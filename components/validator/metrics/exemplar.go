@@ -0,0 +1,47 @@
+package metrics
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Exemplar links an observation to the trace and the L1 transaction involved. It is only attached if the trace is
+// known, i.e. if tracing is enabled and the trace is sampled, and exposed in the OpenMetrics format.
+type Exemplar struct {
+	TraceID string
+	// TxHash is the L1 transaction involved, omitted if empty.
+	TxHash common.Hash
+}
+
+func (e Exemplar) labels() prometheus.Labels {
+	if e.TraceID == "" {
+		return nil
+	}
+	labels := prometheus.Labels{"trace_id": e.TraceID}
+	if e.TxHash != (common.Hash{}) {
+		labels["tx_hash"] = e.TxHash.Hex()
+	}
+	return labels
+}
+
+// observeWithExemplar observes the value, with the exemplar if it is known.
+func observeWithExemplar(o prometheus.Observer, v float64, e Exemplar) {
+	if labels := e.labels(); labels != nil {
+		if eo, ok := o.(prometheus.ExemplarObserver); ok {
+			eo.ObserveWithExemplar(v, labels)
+			return
+		}
+	}
+	o.Observe(v)
+}
+
+// incWithExemplar increments the counter, with the exemplar if it is known.
+func incWithExemplar(c prometheus.Counter, e Exemplar) {
+	if labels := e.labels(); labels != nil {
+		if ea, ok := c.(prometheus.ExemplarAdder); ok {
+			ea.AddWithExemplar(1, labels)
+			return
+		}
+	}
+	c.Inc()
+}
@@ -2,10 +2,14 @@ package metrics
 
 import (
 	"context"
+	"math/big"
+	"strconv"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/kroma-network/kroma/components/node/eth"
@@ -18,6 +22,9 @@ const (
 	L2OutputSubmitted = "submitted"
 )
 
+// councilLatencyBuckets range from a minute to a week.
+var councilLatencyBuckets = []float64{60, 300, 900, 1800, 3600, 3 * 3600, 6 * 3600, 12 * 3600, 24 * 3600, 3 * 24 * 3600, 7 * 24 * 3600}
+
 type Metricer interface {
 	RecordInfo(version string)
 	RecordUp()
@@ -29,6 +36,38 @@ type Metricer interface {
 	txmetrics.TxMetricer
 
 	RecordL2OutputSubmitted(l2ref eth.L2BlockRef)
+
+	RecordCouncilConfirmation(member common.Address, latency time.Duration, exemplar Exemplar)
+	RecordCouncilQuorum(latency time.Duration, exemplar Exemplar)
+
+	RecordMisalignedValidationRequest()
+	RecordOutputValidation(reason string, exemplar Exemplar)
+	RecordBackfilledValidationRequests(requests int)
+	RecordGuardianDissent(revoked bool)
+	RecordGuardianLeader(leader bool)
+	RecordGuardianDeadLetters(count int)
+	RecordGuardianQueuedValidations(count int)
+	RecordGuardianNodeLag(lag uint64)
+
+	RecordL1CallWait(role string, wait time.Duration)
+	RecordL1CallsInFlight(role string, inFlight int)
+	RecordL1CallsQueued(role string, queued int)
+
+	RecordSweep(kind string, amount *big.Int)
+	RecordChallengeDepositTopUp(amount *big.Int)
+	RecordDepositTopUp(amount *big.Int)
+	RecordUnbonds(count int)
+
+	RecordOutputRound(outcome string)
+	RecordOutputRoundSkip(reason string)
+
+	RecordClockSkew(source string, skew time.Duration)
+
+	RecordProofEstimate(stage string, estimate time.Duration, feasible bool)
+
+	RecordRecoveryFindings(kind string, count int)
+
+	RecordRollupNodeCompatibility(local, node eth.ProtocolVersion, compatible bool)
 }
 
 type Metrics struct {
@@ -41,6 +80,44 @@ type Metrics struct {
 
 	Info prometheus.GaugeVec
 	Up   prometheus.Gauge
+
+	CouncilConfirmationLatency prometheus.HistogramVec
+	CouncilQuorumLatency       prometheus.Histogram
+
+	MisalignedValidationRequests prometheus.Counter
+	OutputValidations            prometheus.CounterVec
+	BackfilledValidationRequests prometheus.Counter
+	GuardianDissents             prometheus.CounterVec
+	GuardianLeader               prometheus.Gauge
+	GuardianDeadLetters          prometheus.Gauge
+	GuardianQueuedValidations    prometheus.Gauge
+	GuardianNodeLag              prometheus.Gauge
+
+	L1CallWait      prometheus.HistogramVec
+	L1CallsInFlight prometheus.GaugeVec
+	L1CallsQueued   prometheus.GaugeVec
+
+	Sweeps      prometheus.CounterVec
+	SweptAmount prometheus.CounterVec
+
+	ChallengeDepositTopUps prometheus.Counter
+	ChallengeDepositAmount prometheus.Counter
+	DepositTopUps          prometheus.Counter
+	DepositTopUpAmount     prometheus.Counter
+	Unbonds                prometheus.Counter
+
+	OutputRounds     prometheus.CounterVec
+	OutputRoundSkips prometheus.CounterVec
+
+	ClockSkew prometheus.GaugeVec
+
+	ProofEstimate    prometheus.GaugeVec
+	ProofInfeasibles prometheus.CounterVec
+
+	RecoveryFindings prometheus.GaugeVec
+
+	RollupNodeProtocolVersions prometheus.GaugeVec
+	RollupNodeIncompatible     prometheus.Gauge
 }
 
 var _ Metricer = (*Metrics)(nil)
@@ -74,6 +151,180 @@ func NewMetrics(procName string) *Metrics {
 			Name:      "up",
 			Help:      "1 if the kroma-validator has finished starting up",
 		}),
+		CouncilConfirmationLatency: *factory.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: ns,
+			Name:      "council_confirmation_latency_seconds",
+			Buckets:   councilLatencyBuckets,
+			Help:      "Histogram of the time from a validation request to its confirmation, by SecurityCouncil member",
+		}, []string{
+			"member",
+		}),
+		CouncilQuorumLatency: factory.NewHistogram(prometheus.HistogramOpts{
+			Namespace: ns,
+			Name:      "council_quorum_latency_seconds",
+			Buckets:   councilLatencyBuckets,
+			Help:      "Histogram of the time from a validation request until the SecurityCouncil reached the quorum",
+		}),
+		MisalignedValidationRequests: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "misaligned_validation_requests_total",
+			Help:      "Number of rejected validation requests of L2 block numbers that are not output checkpoints",
+		}),
+		OutputValidations: *factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "output_validations_total",
+			Help:      "Number of output validation attempts of validation requests, by reason code",
+		}, []string{
+			"reason",
+		}),
+		BackfilledValidationRequests: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "backfilled_validation_requests_total",
+			Help:      "Number of unconfirmed validation requests emitted while the guardian was offline, found by the backfill",
+		}),
+		GuardianDissents: *factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "guardian_dissents_total",
+			Help:      "Number of contested validation requests of invalid outputs, by whether the confirmation of the guardian was revoked",
+		}, []string{
+			"revoked",
+		}),
+		GuardianLeader: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "guardian_leader",
+			Help:      "1 if the guardian holds the leader lease and submits confirmations, 0 if it is a standby validating in shadow mode",
+		}),
+		GuardianDeadLetters: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "guardian_dead_letters",
+			Help:      "Number of validation requests that exhausted their retry budget and wait to be retried through the RPC",
+		}),
+		GuardianQueuedValidations: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "guardian_queued_validations",
+			Help:      "Number of validation requests waiting for a validation worker",
+		}),
+		GuardianNodeLag: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "guardian_node_lag_blocks",
+			Help:      "Number of L1 blocks the L1 origin of the safe head of the rollup node lags behind the finalized L1 block",
+		}),
+		L1CallWait: *factory.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: ns,
+			Name:      "l1_call_wait_seconds",
+			Buckets:   []float64{.001, .005, .01, .05, .1, .5, 1, 2.5, 5, 10, 30},
+			Help:      "Histogram of the time L1 calls waited for the shared L1 call limits, by role",
+		}, []string{
+			"role",
+		}),
+		L1CallsInFlight: *factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "l1_calls_in_flight",
+			Help:      "Number of L1 calls in flight, by role",
+		}, []string{
+			"role",
+		}),
+		L1CallsQueued: *factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "l1_calls_queued",
+			Help:      "Number of L1 calls waiting for a free call slot, by role",
+		}, []string{
+			"role",
+		}),
+		Sweeps: *factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "sweeps_total",
+			Help:      "Number of queued sweep transactions of recovered funds, by kind",
+		}, []string{
+			"kind",
+		}),
+		SweptAmount: *factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "swept_eth_total",
+			Help:      "Amount of recovered funds (in ETH) in queued sweep transactions, by kind",
+		}, []string{
+			"kind",
+		}),
+		ChallengeDepositTopUps: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "challenge_deposit_top_ups_total",
+			Help:      "Number of queued deposits into the ValidatorPool to cover the bonds of challenges",
+		}),
+		ChallengeDepositAmount: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "challenge_deposit_eth_total",
+			Help:      "Amount (in ETH) of queued deposits into the ValidatorPool to cover the bonds of challenges",
+		}),
+		DepositTopUps: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "deposit_top_ups_total",
+			Help:      "Number of queued deposits into the ValidatorPool to top up the deposit below the threshold",
+		}),
+		DepositTopUpAmount: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "deposit_top_up_eth_total",
+			Help:      "Amount (in ETH) of queued deposits into the ValidatorPool to top up the deposit below the threshold",
+		}),
+		Unbonds: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "unbonds_total",
+			Help:      "Number of queued unbonds of the bonds of finalized outputs in the ValidatorPool",
+		}),
+		OutputRounds: *factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "output_rounds_total",
+			Help:      "Number of submitted outputs, by outcome for the validator: submitted, missed or taken",
+		}, []string{
+			"outcome",
+		}),
+		OutputRoundSkips: *factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "output_round_skips_total",
+			Help:      "Number of output submissions skipped since the validator was not allowed to submit in the round, by reason",
+		}, []string{
+			"reason",
+		}),
+		ClockSkew: *factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "clock_skew_seconds",
+			Help:      "Skew of the local clock against the latest block timestamps, by source: l1",
+		}, []string{
+			"source",
+		}),
+		ProofEstimate: *factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "proof_estimate_seconds",
+			Help:      "Estimated time to generate the latest fault proof, by stage of the challenge: challenge or prove",
+		}, []string{
+			"stage",
+		}),
+		ProofInfeasibles: *factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "proof_infeasible_total",
+			Help:      "Number of fault proofs estimated not to be generated before the deadline, by stage of the challenge: challenge or prove",
+		}, []string{
+			"stage",
+		}),
+		RecoveryFindings: *factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "recovery_findings",
+			Help:      "Number of mismatches between the recorded state and the chain found by the recovery audit on start, by kind",
+		}, []string{
+			"kind",
+		}),
+		RollupNodeProtocolVersions: *factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "rollup_node_protocol_versions",
+			Help:      "Pseudo-metric tracking the protocol versions of the validator and of the rollup node",
+		}, []string{
+			"local",
+			"node",
+		}),
+		RollupNodeIncompatible: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "rollup_node_incompatible",
+			Help:      "1 if the rollup node is incompatible with the validator, by protocol version or rollup config, and the transactions are refused, 0 otherwise",
+		}),
 	}
 }
 
@@ -103,3 +354,147 @@ func (m *Metrics) RecordUp() {
 func (m *Metrics) RecordL2OutputSubmitted(l2ref eth.L2BlockRef) {
 	m.RecordL2Ref(L2OutputSubmitted, l2ref)
 }
+
+// RecordCouncilConfirmation should be called when a SecurityCouncil member confirmed a validation request,
+// with the trace of the request and the confirmation transaction as exemplar.
+func (m *Metrics) RecordCouncilConfirmation(member common.Address, latency time.Duration, exemplar Exemplar) {
+	observeWithExemplar(m.CouncilConfirmationLatency.WithLabelValues(member.Hex()), latency.Seconds(), exemplar)
+}
+
+// RecordCouncilQuorum should be called when the SecurityCouncil reached the quorum on a validation request,
+// with the trace of the request and the confirmation transaction reaching the quorum as exemplar.
+func (m *Metrics) RecordCouncilQuorum(latency time.Duration, exemplar Exemplar) {
+	observeWithExemplar(m.CouncilQuorumLatency, latency.Seconds(), exemplar)
+}
+
+// RecordMisalignedValidationRequest should be called when a validation request of an L2 block number
+// that is not an output checkpoint is rejected.
+func (m *Metrics) RecordMisalignedValidationRequest() {
+	m.MisalignedValidationRequests.Inc()
+}
+
+// RecordOutputValidation should be called when a requested output was validated against the local node,
+// with the reason code of the result and the trace of the request as exemplar.
+func (m *Metrics) RecordOutputValidation(reason string, exemplar Exemplar) {
+	incWithExemplar(m.OutputValidations.WithLabelValues(reason), exemplar)
+}
+
+// RecordBackfilledValidationRequests should be called when the backfill found unconfirmed validation requests.
+func (m *Metrics) RecordBackfilledValidationRequests(requests int) {
+	m.BackfilledValidationRequests.Add(float64(requests))
+}
+
+// RecordGuardianDissent should be called when the guardian contested a validation request of an invalid output,
+// with whether its confirmation of the request was revoked.
+func (m *Metrics) RecordGuardianDissent(revoked bool) {
+	m.GuardianDissents.WithLabelValues(strconv.FormatBool(revoked)).Inc()
+}
+
+// RecordGuardianLeader should be called when the guardian acquired or lost the leader lease.
+func (m *Metrics) RecordGuardianLeader(leader bool) {
+	if leader {
+		m.GuardianLeader.Set(1)
+	} else {
+		m.GuardianLeader.Set(0)
+	}
+}
+
+// RecordGuardianDeadLetters should be called when the dead letter queue of the guardian changed.
+func (m *Metrics) RecordGuardianDeadLetters(count int) {
+	m.GuardianDeadLetters.Set(float64(count))
+}
+
+// RecordGuardianQueuedValidations should be called when the queue of the validation requests changed.
+func (m *Metrics) RecordGuardianQueuedValidations(count int) {
+	m.GuardianQueuedValidations.Set(float64(count))
+}
+
+// RecordGuardianNodeLag sets the lag of the safe head of the rollup node behind the L1 finality.
+func (m *Metrics) RecordGuardianNodeLag(lag uint64) {
+	m.GuardianNodeLag.Set(float64(lag))
+}
+
+// RecordL1CallWait should be called when an L1 call passed the shared L1 call limits.
+func (m *Metrics) RecordL1CallWait(role string, wait time.Duration) {
+	m.L1CallWait.WithLabelValues(role).Observe(wait.Seconds())
+}
+
+// RecordL1CallsInFlight sets the number of L1 calls in flight of the role.
+func (m *Metrics) RecordL1CallsInFlight(role string, inFlight int) {
+	m.L1CallsInFlight.WithLabelValues(role).Set(float64(inFlight))
+}
+
+// RecordL1CallsQueued sets the number of L1 calls of the role waiting for a free call slot.
+func (m *Metrics) RecordL1CallsQueued(role string, queued int) {
+	m.L1CallsQueued.WithLabelValues(role).Set(float64(queued))
+}
+
+func (m *Metrics) RecordSweep(kind string, amount *big.Int) {
+	m.Sweeps.WithLabelValues(kind).Inc()
+	ether, _ := new(big.Float).Quo(new(big.Float).SetInt(amount), big.NewFloat(params.Ether)).Float64()
+	m.SweptAmount.WithLabelValues(kind).Add(ether)
+}
+
+// RecordChallengeDepositTopUp should be called when a deposit into the ValidatorPool is queued to cover the bond of
+// a challenge.
+func (m *Metrics) RecordChallengeDepositTopUp(amount *big.Int) {
+	m.ChallengeDepositTopUps.Inc()
+	ether, _ := new(big.Float).Quo(new(big.Float).SetInt(amount), big.NewFloat(params.Ether)).Float64()
+	m.ChallengeDepositAmount.Add(ether)
+}
+
+// RecordDepositTopUp should be called when a deposit into the ValidatorPool is queued to top up the deposit below
+// the threshold.
+func (m *Metrics) RecordDepositTopUp(amount *big.Int) {
+	m.DepositTopUps.Inc()
+	ether, _ := new(big.Float).Quo(new(big.Float).SetInt(amount), big.NewFloat(params.Ether)).Float64()
+	m.DepositTopUpAmount.Add(ether)
+}
+
+// RecordUnbonds should be called when unbonds of the bonds of finalized outputs are queued.
+func (m *Metrics) RecordUnbonds(count int) {
+	m.Unbonds.Add(float64(count))
+}
+
+// RecordOutputRound should be called when an output is submitted to the L2OutputOracle, with the outcome of its
+// submission round for the validator.
+func (m *Metrics) RecordOutputRound(outcome string) {
+	m.OutputRounds.WithLabelValues(outcome).Inc()
+}
+
+// RecordOutputRoundSkip should be called when an output submission is skipped since the validator is not allowed to
+// submit in the current round.
+func (m *Metrics) RecordOutputRoundSkip(reason string) {
+	m.OutputRoundSkips.WithLabelValues(reason).Inc()
+}
+
+// RecordClockSkew sets the skew of the local clock against the latest block timestamp of the source.
+func (m *Metrics) RecordClockSkew(source string, skew time.Duration) {
+	m.ClockSkew.WithLabelValues(source).Set(skew.Seconds())
+}
+
+// RecordProofEstimate should be called when the time to generate a fault proof is estimated, with whether the proof
+// is likely to be generated before the deadline.
+func (m *Metrics) RecordProofEstimate(stage string, estimate time.Duration, feasible bool) {
+	m.ProofEstimate.WithLabelValues(stage).Set(estimate.Seconds())
+	if !feasible {
+		m.ProofInfeasibles.WithLabelValues(stage).Inc()
+	}
+}
+
+// RecordRecoveryFindings should be called when the recovery audit finished, with the number of its findings of the kind.
+func (m *Metrics) RecordRecoveryFindings(kind string, count int) {
+	m.RecoveryFindings.WithLabelValues(kind).Set(float64(count))
+}
+
+// RecordRollupNodeCompatibility should be called when the compatibility of the rollup node is checked, with the
+// protocol versions of the validator and of the rollup node.
+func (m *Metrics) RecordRollupNodeCompatibility(local, node eth.ProtocolVersion, compatible bool) {
+	m.RollupNodeProtocolVersions.Reset()
+	m.RollupNodeProtocolVersions.WithLabelValues(local.String(), node.String()).Set(1)
+	if compatible {
+		m.RollupNodeIncompatible.Set(0)
+	} else {
+		m.RollupNodeIncompatible.Set(1)
+	}
+}
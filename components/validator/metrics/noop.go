@@ -1,6 +1,11 @@
 package metrics
 
 import (
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
 	"github.com/kroma-network/kroma/components/node/eth"
 	kmetrics "github.com/kroma-network/kroma/utils/service/metrics"
 	txmetrics "github.com/kroma-network/kroma/utils/service/txmgr/metrics"
@@ -17,3 +22,40 @@ func (*noopMetrics) RecordInfo(version string) {}
 func (*noopMetrics) RecordUp()                 {}
 
 func (*noopMetrics) RecordL2OutputSubmitted(l2ref eth.L2BlockRef) {}
+
+func (*noopMetrics) RecordCouncilConfirmation(member common.Address, latency time.Duration, exemplar Exemplar) {
+}
+func (*noopMetrics) RecordCouncilQuorum(latency time.Duration, exemplar Exemplar) {}
+
+func (*noopMetrics) RecordMisalignedValidationRequest()                      {}
+func (*noopMetrics) RecordOutputValidation(reason string, exemplar Exemplar) {}
+
+func (*noopMetrics) RecordBackfilledValidationRequests(requests int) {}
+func (*noopMetrics) RecordGuardianDissent(revoked bool)              {}
+func (*noopMetrics) RecordGuardianLeader(leader bool)                {}
+func (*noopMetrics) RecordGuardianDeadLetters(count int)             {}
+func (*noopMetrics) RecordGuardianQueuedValidations(count int)       {}
+func (*noopMetrics) RecordGuardianNodeLag(lag uint64)                {}
+
+func (*noopMetrics) RecordL1CallWait(role string, wait time.Duration) {}
+func (*noopMetrics) RecordL1CallsInFlight(role string, inFlight int)  {}
+func (*noopMetrics) RecordL1CallsQueued(role string, queued int)      {}
+
+func (*noopMetrics) RecordSweep(kind string, amount *big.Int) {}
+
+func (*noopMetrics) RecordChallengeDepositTopUp(amount *big.Int) {}
+
+func (*noopMetrics) RecordDepositTopUp(amount *big.Int) {}
+
+func (*noopMetrics) RecordUnbonds(count int) {}
+
+func (*noopMetrics) RecordOutputRound(outcome string)    {}
+func (*noopMetrics) RecordOutputRoundSkip(reason string) {}
+
+func (*noopMetrics) RecordClockSkew(source string, skew time.Duration) {}
+
+func (*noopMetrics) RecordProofEstimate(stage string, estimate time.Duration, feasible bool) {}
+
+func (*noopMetrics) RecordRecoveryFindings(kind string, count int) {}
+
+func (*noopMetrics) RecordRollupNodeCompatibility(local, node eth.ProtocolVersion, compatible bool) {}
@@ -0,0 +1,67 @@
+package validator
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli"
+
+	"github.com/kroma-network/kroma/components/node/rollup"
+	"github.com/kroma-network/kroma/components/validator/chaincfg"
+	"github.com/kroma-network/kroma/components/validator/flags"
+)
+
+// ApplyNetworkPreset sets the flags that are not set explicitly, by flag or environment variable,
+// to the preset of the network selected by the network flag, if any.
+// It is applied before any command is run, so that the commands read the preset values as flag values.
+func ApplyNetworkPreset(ctx *cli.Context) error {
+	network := ctx.GlobalString(flags.NetworkFlag.Name)
+	if network == "" {
+		return nil
+	}
+	preset, err := chaincfg.GetPreset(network)
+	if err != nil {
+		return err
+	}
+
+	values := map[string]string{
+		flags.OutputSubmitterBondAmountFlag.Name: strconv.FormatUint(preset.OutputSubmitterBondAmount, 10),
+	}
+	addrs := map[string]common.Address{
+		flags.L2OOAddressFlag.Name:            preset.L2OutputOracleAddr,
+		flags.ColosseumAddressFlag.Name:       preset.ColosseumAddr,
+		flags.ValPoolAddressFlag.Name:         preset.ValidatorPoolAddr,
+		flags.SecurityCouncilAddressFlag.Name: preset.SecurityCouncilAddr,
+	}
+	for name, addr := range addrs {
+		if addr != (common.Address{}) {
+			values[name] = addr.Hex()
+		}
+	}
+
+	for name, value := range values {
+		if ctx.GlobalIsSet(name) {
+			continue
+		}
+		if err := ctx.GlobalSet(name, value); err != nil {
+			return fmt.Errorf("failed to preset %s of network %s: %w", name, network, err)
+		}
+	}
+	return nil
+}
+
+// checkNetwork ensures that the rollup node follows the chain of the network preset.
+func checkNetwork(network string, rollupConfig *rollup.Config) error {
+	preset, err := chaincfg.GetPreset(network)
+	if err != nil {
+		return err
+	}
+	if rollupConfig.L1ChainID.Cmp(preset.L1ChainID) != 0 {
+		return fmt.Errorf("rollup node L1 chain ID %s does not match network %s L1 chain ID %s", rollupConfig.L1ChainID, network, preset.L1ChainID)
+	}
+	if rollupConfig.L2ChainID.Cmp(preset.L2ChainID) != 0 {
+		return fmt.Errorf("rollup node L2 chain ID %s does not match network %s L2 chain ID %s", rollupConfig.L2ChainID, network, preset.L2ChainID)
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package validator
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli"
+
+	"github.com/kroma-network/kroma/components/node/rollup"
+	"github.com/kroma-network/kroma/components/validator/chaincfg"
+	"github.com/kroma-network/kroma/components/validator/flags"
+)
+
+// runWithNetworkPreset parses the args with the validator flags and the network preset applied, and returns the CLIConfig.
+func runWithNetworkPreset(t *testing.T, args ...string) (CLIConfig, error) {
+	var cfg CLIConfig
+	app := cli.NewApp()
+	app.Flags = flags.Flags
+	app.Before = ApplyNetworkPreset
+	app.Action = func(ctx *cli.Context) error {
+		cfg = NewCLIConfig(ctx)
+		return nil
+	}
+	args = append([]string{"validator",
+		"--l1-eth-rpc", "ws://localhost:8546",
+		"--rollup-rpc", "http://localhost:7545",
+		"--challenger.poll-interval", "1s",
+	}, args...)
+	err := app.Run(args)
+	return cfg, err
+}
+
+func TestApplyNetworkPreset(t *testing.T) {
+	t.Run("without network", func(t *testing.T) {
+		cfg, err := runWithNetworkPreset(t)
+		require.NoError(t, err)
+		require.Empty(t, cfg.L2OOAddress)
+		require.Equal(t, uint64(1), cfg.OutputSubmitterBondAmount)
+		require.ErrorContains(t, cfg.Check(), "l2 output oracle address is required")
+	})
+
+	t.Run("preset", func(t *testing.T) {
+		cfg, err := runWithNetworkPreset(t, "--network", "sepolia")
+		require.NoError(t, err)
+		require.Equal(t, "sepolia", cfg.Network)
+		require.Equal(t, chaincfg.Sepolia.L2OutputOracleAddr.Hex(), cfg.L2OOAddress)
+		require.Equal(t, chaincfg.Sepolia.ColosseumAddr.Hex(), cfg.ColosseumAddress)
+		require.Equal(t, chaincfg.Sepolia.OutputSubmitterBondAmount, cfg.OutputSubmitterBondAmount)
+		require.Empty(t, cfg.ValPoolAddress, "addresses that are not preset must be left unset")
+		require.ErrorContains(t, cfg.Check(), "validator pool address is required: network sepolia does not preset it, set --valpool-address")
+	})
+
+	t.Run("preset with validator pool", func(t *testing.T) {
+		cfg, err := runWithNetworkPreset(t, "--network", "sepolia",
+			"--valpool-address", "0x00000000000000000000000000000000000000cc")
+		require.NoError(t, err)
+		require.Equal(t, "0x00000000000000000000000000000000000000cc", cfg.ValPoolAddress)
+		require.NoError(t, cfg.Check())
+	})
+
+	t.Run("override", func(t *testing.T) {
+		cfg, err := runWithNetworkPreset(t, "--network", "sepolia",
+			"--colosseum-address", "0x00000000000000000000000000000000000000aa",
+			"--output-submitter.bond-amount", "5")
+		require.NoError(t, err)
+		require.Equal(t, chaincfg.Sepolia.L2OutputOracleAddr.Hex(), cfg.L2OOAddress)
+		require.Equal(t, "0x00000000000000000000000000000000000000aa", cfg.ColosseumAddress)
+		require.Equal(t, uint64(5), cfg.OutputSubmitterBondAmount)
+	})
+
+	t.Run("override by env var", func(t *testing.T) {
+		t.Setenv(flags.ColosseumAddressFlag.EnvVar, "0x00000000000000000000000000000000000000bb")
+		cfg, err := runWithNetworkPreset(t, "--network", "sepolia")
+		require.NoError(t, err)
+		require.Equal(t, "0x00000000000000000000000000000000000000bb", cfg.ColosseumAddress)
+	})
+
+	t.Run("unknown network", func(t *testing.T) {
+		_, err := runWithNetworkPreset(t, "--network", "unknown")
+		require.ErrorContains(t, err, "invalid network unknown")
+	})
+}
+
+func TestCheckNetwork(t *testing.T) {
+	cfg := &rollup.Config{L1ChainID: chaincfg.Sepolia.L1ChainID, L2ChainID: chaincfg.Sepolia.L2ChainID}
+	require.NoError(t, checkNetwork("sepolia", cfg))
+
+	cfg.L2ChainID = big.NewInt(901)
+	require.ErrorContains(t, checkNetwork("sepolia", cfg), "L2 chain ID 901 does not match")
+
+	cfg.L1ChainID = big.NewInt(900)
+	require.ErrorContains(t, checkNetwork("sepolia", cfg), "L1 chain ID 900 does not match")
+}
@@ -0,0 +1,49 @@
+package validator
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/urfave/cli"
+
+	"github.com/kroma-network/kroma/components/validator/chaincfg"
+	"github.com/kroma-network/kroma/components/validator/flags"
+	"github.com/kroma-network/kroma/utils/service/txmgr"
+)
+
+// ApplyProfile sets the flags that are not set explicitly, by flag or environment variable,
+// to the settings of the profile selected by the profile flag, if any.
+// Like the network preset, it is applied before any command is run.
+func ApplyProfile(ctx *cli.Context) error {
+	name := ctx.GlobalString(flags.ProfileFlag.Name)
+	if name == "" {
+		return nil
+	}
+	profile, err := chaincfg.GetProfile(name)
+	if err != nil {
+		return err
+	}
+
+	values := map[string]string{
+		txmgr.ResubmissionTimeoutFlagName:               profile.ResubmissionTimeout.String(),
+		txmgr.StuckBumpsFlagName:                        strconv.FormatUint(profile.StuckBumps, 10),
+		txmgr.StuckTimeoutFlagName:                      profile.StuckTimeout.String(),
+		txmgr.StuckPriceBumpFlagName:                    strconv.FormatUint(profile.StuckPriceBump, 10),
+		txmgr.StuckMaxBumpsFlagName:                     strconv.FormatUint(profile.StuckMaxBumps, 10),
+		txmgr.GasOracleMaxDeviationFlagName:             strconv.FormatFloat(profile.GasOracleMaxDeviation, 'f', -1, 64),
+		flags.GuardianMaxRetriesFlag.Name:               strconv.Itoa(profile.GuardianMaxRetries),
+		flags.GuardianMaxConcurrentValidationsFlag.Name: strconv.Itoa(profile.GuardianMaxConcurrentValidations),
+		flags.ChallengerCoordinationFlag.Name:           profile.ChallengerCoordination,
+		flags.ChallengerTakeoverMarginFlag.Name:         profile.ChallengerTakeoverMargin.String(),
+		flags.ChallengerProofMarginFlag.Name:            profile.ChallengerProofMargin.String(),
+	}
+	for flagName, value := range values {
+		if ctx.GlobalIsSet(flagName) {
+			continue
+		}
+		if err := ctx.GlobalSet(flagName, value); err != nil {
+			return fmt.Errorf("failed to set %s of profile %s: %w", flagName, name, err)
+		}
+	}
+	return nil
+}
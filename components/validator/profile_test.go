@@ -0,0 +1,87 @@
+package validator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli"
+
+	"github.com/kroma-network/kroma/components/validator/chaincfg"
+	"github.com/kroma-network/kroma/components/validator/flags"
+)
+
+// runWithProfile parses the args with the validator flags and the profile applied, and returns the CLIConfig.
+func runWithProfile(t *testing.T, args ...string) (CLIConfig, error) {
+	var cfg CLIConfig
+	app := cli.NewApp()
+	app.Flags = flags.Flags
+	app.Before = ApplyProfile
+	app.Action = func(ctx *cli.Context) error {
+		cfg = NewCLIConfig(ctx)
+		return nil
+	}
+	args = append([]string{"validator",
+		"--l1-eth-rpc", "ws://localhost:8546",
+		"--rollup-rpc", "http://localhost:7545",
+		"--challenger.poll-interval", "1s",
+	}, args...)
+	err := app.Run(args)
+	return cfg, err
+}
+
+func requireProfile(t *testing.T, profile chaincfg.Profile, cfg CLIConfig) {
+	require.Equal(t, profile.ResubmissionTimeout, cfg.TxMgrConfig.ResubmissionTimeout)
+	require.Equal(t, profile.StuckBumps, cfg.TxMgrConfig.StuckBumps)
+	require.Equal(t, profile.StuckTimeout, cfg.TxMgrConfig.StuckTimeout)
+	require.Equal(t, profile.StuckPriceBump, cfg.TxMgrConfig.StuckPriceBump)
+	require.Equal(t, profile.StuckMaxBumps, cfg.TxMgrConfig.StuckMaxBumps)
+	require.Equal(t, profile.GasOracleMaxDeviation, cfg.TxMgrConfig.GasOracleMaxDeviation)
+	require.Equal(t, profile.GuardianMaxRetries, cfg.GuardianMaxRetries)
+	require.Equal(t, profile.GuardianMaxConcurrentValidations, cfg.GuardianMaxConcurrentValidations)
+	require.Equal(t, profile.ChallengerCoordination, cfg.ChallengerCoordination)
+	require.Equal(t, profile.ChallengerTakeoverMargin, cfg.ChallengerTakeoverMargin)
+	require.Equal(t, profile.ChallengerProofMargin, cfg.ChallengerProofMargin)
+}
+
+func TestApplyProfile(t *testing.T) {
+	t.Run("balanced is the defaults", func(t *testing.T) {
+		cfg, err := runWithProfile(t)
+		require.NoError(t, err)
+		require.Empty(t, cfg.Profile)
+		requireProfile(t, chaincfg.Balanced, cfg)
+	})
+
+	for _, name := range chaincfg.AvailableProfiles() {
+		name := name
+		t.Run(name, func(t *testing.T) {
+			cfg, err := runWithProfile(t, "--profile", name)
+			require.NoError(t, err)
+			require.Equal(t, name, cfg.Profile)
+			requireProfile(t, chaincfg.ProfilesByName[name], cfg)
+		})
+	}
+
+	t.Run("override", func(t *testing.T) {
+		cfg, err := runWithProfile(t, "--profile", "aggressive",
+			"--challenger.coordination", "parallel",
+			"--txmgr.stuck-bumps", "0")
+		require.NoError(t, err)
+		require.Equal(t, "parallel", cfg.ChallengerCoordination)
+		require.Equal(t, uint64(0), cfg.TxMgrConfig.StuckBumps)
+		require.Equal(t, chaincfg.Aggressive.StuckTimeout, cfg.TxMgrConfig.StuckTimeout)
+	})
+
+	t.Run("override by env var", func(t *testing.T) {
+		t.Setenv(flags.GuardianMaxRetriesFlag.EnvVar, "7")
+		cfg, err := runWithProfile(t, "--profile", "conservative")
+		require.NoError(t, err)
+		require.Equal(t, 7, cfg.GuardianMaxRetries)
+		require.Equal(t, 72*time.Second, cfg.TxMgrConfig.ResubmissionTimeout)
+	})
+
+	t.Run("unknown profile", func(t *testing.T) {
+		_, err := runWithProfile(t, "--profile", "reckless")
+		require.ErrorContains(t, err, "invalid profile reckless")
+	})
+}
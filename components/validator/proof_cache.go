@@ -0,0 +1,148 @@
+package validator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	chal "github.com/kroma-network/kroma/components/validator/challenge"
+	"github.com/kroma-network/kroma/utils/service/backoff"
+)
+
+// ErrProofDeadline is returned when no proof was fetched before the proving deadline of the challenge, less the
+// margin to submit the proof.
+var ErrProofDeadline = errors.New("proof not fetched before the proving deadline")
+
+// proofCache is a ProofFetcher that keeps the fetched proofs on disk, a file per L2 block, so that a proof is
+// generated once even if the validator restarts or the proveFault transaction has to be sent again.
+type proofCache struct {
+	log     log.Logger
+	fetcher ProofFetcher
+	dir     string
+}
+
+var _ ProofFetcher = (*proofCache)(nil)
+
+func newProofCache(l log.Logger, fetcher ProofFetcher, dir string) (*proofCache, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create proof cache directory: %w", err)
+	}
+	return &proofCache{log: l, fetcher: fetcher, dir: dir}, nil
+}
+
+func (c *proofCache) path(blockNumber uint64) string {
+	return filepath.Join(c.dir, strconv.FormatUint(blockNumber, 10)+".json")
+}
+
+// FetchProofAndPair returns the cached proof of the block, or fetches and caches it. A proof that cannot be cached is
+// still returned.
+func (c *proofCache) FetchProofAndPair(blockNumber uint64) (*chal.ProofAndPair, error) {
+	path := c.path(blockNumber)
+	if data, err := os.ReadFile(path); err == nil {
+		var proof chal.ProofAndPair
+		if err := json.Unmarshal(data, &proof); err == nil {
+			c.log.Info("using cached proof", "blockNumber", blockNumber)
+			return &proof, nil
+		}
+		c.log.Warn("discarding corrupt cached proof", "blockNumber", blockNumber, "path", path, "err", err)
+	} else if !errors.Is(err, os.ErrNotExist) {
+		c.log.Warn("failed to read cached proof", "blockNumber", blockNumber, "path", path, "err", err)
+	}
+
+	proof, err := c.fetcher.FetchProofAndPair(blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.write(path, proof); err != nil {
+		c.log.Warn("failed to cache proof", "blockNumber", blockNumber, "path", path, "err", err)
+	}
+	return proof, nil
+}
+
+// write writes the proof, replacing the file atomically.
+func (c *proofCache) write(path string, proof *chal.ProofAndPair) error {
+	data, err := json.Marshal(proof)
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+func (c *proofCache) Close() error {
+	return c.fetcher.Close()
+}
+
+// newProofRetryStrategy returns the backoff of the retries of the failed proof requests: doubling from 2s up to 1m.
+func newProofRetryStrategy() backoff.Strategy {
+	return &backoff.ExponentialStrategy{
+		Max:       float64(time.Minute / time.Millisecond),
+		MaxJitter: 1000,
+	}
+}
+
+// proofDeadline returns the deadline of the challenger to prove the fault: the timeout of the challenge once it is
+// ready to prove, or the proving timeout after the timeout of the asserter.
+func proofDeadline(timeoutAt uint64, provingTimeout time.Duration, now time.Time) time.Time {
+	deadline := time.Unix(int64(timeoutAt), 0)
+	if now.After(deadline) {
+		deadline = deadline.Add(provingTimeout)
+	}
+	return deadline
+}
+
+// fetchProof fetches the proof of the block, retrying the failed requests until the deadline less the proof margin,
+// so that the proveFault transaction can still land in time.
+func (c *Challenger) fetchProof(ctx context.Context, blockNumber uint64, deadline time.Time) (*chal.ProofAndPair, error) {
+	budget := deadline.Add(-c.cfg.ChallengerProofMargin)
+	if !time.Now().Before(budget) {
+		return nil, fmt.Errorf("%w: blockNumber: %d, deadline %s", ErrProofDeadline, blockNumber, deadline)
+	}
+	ctx, cancel := context.WithDeadline(ctx, budget)
+	defer cancel()
+
+	var proof *chal.ProofAndPair
+	err := backoff.DoCtx(ctx, math.MaxInt32, c.proofRetryStrategy, func() error {
+		type result struct {
+			proof *chal.ProofAndPair
+			err   error
+		}
+		// the request is bounded by the fetching timeout of the prover, it is abandoned once the budget is spent
+		done := make(chan result, 1)
+		go func() {
+			proof, err := c.cfg.ProofFetcher.FetchProofAndPair(blockNumber)
+			done <- result{proof, err}
+		}()
+		select {
+		case r := <-done:
+			if r.err != nil {
+				c.log.Warn("failed to fetch proof, retrying", "blockNumber", blockNumber, "err", r.err, "budget", budget)
+				return r.err
+			}
+			proof = r.proof
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	})
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return nil, fmt.Errorf("%w: blockNumber: %d, deadline %s: %v", ErrProofDeadline, blockNumber, deadline, err)
+		} else if ctx.Err() != nil {
+			return nil, fmt.Errorf("%w: blockNumber: %d", ctx.Err(), blockNumber)
+		}
+		return nil, fmt.Errorf("%w: blockNumber: %d", err, blockNumber)
+	}
+	return proof, nil
+}
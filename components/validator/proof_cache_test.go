@@ -0,0 +1,168 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kroma-network/kroma/components/node/testlog"
+	chal "github.com/kroma-network/kroma/components/validator/challenge"
+	"github.com/kroma-network/kroma/utils/service/backoff"
+)
+
+// flakyProofFetcher fails the first failures requests, and counts the requests.
+type flakyProofFetcher struct {
+	mu       sync.Mutex
+	failures int
+	calls    int
+}
+
+func (f *flakyProofFetcher) FetchProofAndPair(blockNumber uint64) (*chal.ProofAndPair, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls++
+	if f.failures == alwaysFail || f.calls <= f.failures {
+		return nil, errors.New("prover unavailable")
+	}
+	n := new(big.Int).SetUint64(blockNumber)
+	return &chal.ProofAndPair{Proof: []*big.Int{n}, Pair: []*big.Int{n, big.NewInt(1)}}, nil
+}
+
+func (f *flakyProofFetcher) Close() error {
+	return nil
+}
+
+func (f *flakyProofFetcher) requests() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.calls
+}
+
+func TestProofCache(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "proofs")
+	fetcher := &flakyProofFetcher{failures: 1}
+	cache, err := newProofCache(testlog.Logger(t, log.LvlCrit), fetcher, dir)
+	require.NoError(t, err)
+
+	// a failed request is not cached
+	_, err = cache.FetchProofAndPair(100)
+	require.Error(t, err)
+	require.NoFileExists(t, cache.path(100))
+
+	proof, err := cache.FetchProofAndPair(100)
+	require.NoError(t, err)
+	require.FileExists(t, cache.path(100))
+	require.Equal(t, 2, fetcher.requests())
+
+	// the cached proof is served without a request, also after a restart
+	cache, err = newProofCache(testlog.Logger(t, log.LvlCrit), fetcher, dir)
+	require.NoError(t, err)
+	cached, err := cache.FetchProofAndPair(100)
+	require.NoError(t, err)
+	require.Equal(t, proof, cached)
+	require.Equal(t, 2, fetcher.requests())
+
+	// a corrupt proof is fetched again
+	require.NoError(t, os.WriteFile(cache.path(100), []byte("{"), 0o600))
+	_, err = cache.FetchProofAndPair(100)
+	require.NoError(t, err)
+	require.Equal(t, 3, fetcher.requests())
+}
+
+func TestProofDeadline(t *testing.T) {
+	timeoutAt := time.Unix(1000, 0)
+	require.Equal(t, timeoutAt, proofDeadline(1000, time.Hour, timeoutAt.Add(-time.Minute)))
+	// the challenger can prove the fault for the proving timeout after the asserter timed out
+	require.Equal(t, timeoutAt.Add(time.Hour), proofDeadline(1000, time.Hour, timeoutAt.Add(time.Minute)))
+}
+
+func TestChallengerFetchProof(t *testing.T) {
+	fetcher := &flakyProofFetcher{failures: 2}
+	c := &Challenger{
+		log: testlog.Logger(t, log.LvlCrit),
+		cfg: Config{
+			ProofFetcher:          fetcher,
+			ChallengerProofMargin: time.Minute,
+		},
+		proofRetryStrategy: backoff.Fixed(10 * time.Millisecond),
+	}
+
+	// the failed requests are retried
+	proof, err := c.fetchProof(context.Background(), 100, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(100), proof.Proof[0])
+	require.Equal(t, 3, fetcher.requests())
+
+	// no request is made once the margin to the deadline is reached
+	_, err = c.fetchProof(context.Background(), 100, time.Now().Add(time.Minute/2))
+	require.ErrorIs(t, err, ErrProofDeadline)
+	require.Equal(t, 3, fetcher.requests())
+
+	// the requests are given up at the margin to the deadline
+	fetcher.failures = alwaysFail
+	start := time.Now()
+	_, err = c.fetchProof(context.Background(), 100, time.Now().Add(time.Minute+100*time.Millisecond))
+	require.ErrorIs(t, err, ErrProofDeadline)
+	require.Less(t, time.Since(start), 5*time.Second)
+	require.Greater(t, fetcher.requests(), 4)
+}
+
+// blockingProofFetcher blocks the requests until it is released.
+type blockingProofFetcher struct {
+	started chan struct{}
+	release chan struct{}
+}
+
+func (f *blockingProofFetcher) FetchProofAndPair(uint64) (*chal.ProofAndPair, error) {
+	close(f.started)
+	<-f.release
+	return nil, errors.New("prover unavailable")
+}
+
+func (f *blockingProofFetcher) Close() error {
+	return nil
+}
+
+func TestChallengerFetchProofCancel(t *testing.T) {
+	fetcher := &blockingProofFetcher{started: make(chan struct{}), release: make(chan struct{})}
+	c := &Challenger{
+		log: testlog.Logger(t, log.LvlCrit),
+		cfg: Config{
+			ProofFetcher:          fetcher,
+			ChallengerProofMargin: time.Minute,
+		},
+		proofRetryStrategy: backoff.Fixed(10 * time.Millisecond),
+	}
+
+	goroutines := runtime.NumGoroutine()
+	ctx, cancel := context.WithCancel(context.Background())
+	errs := make(chan error, 1)
+	go func() {
+		_, err := c.fetchProof(ctx, 100, time.Now().Add(time.Hour))
+		errs <- err
+	}()
+	<-fetcher.started
+	cancel()
+	select {
+	case err := <-errs:
+		require.ErrorIs(t, err, context.Canceled)
+	case <-time.After(5 * time.Second):
+		t.Fatal("fetchProof must return once the context is cancelled")
+	}
+
+	// the abandoned request does not block on sending its result
+	close(fetcher.release)
+	// polled in the test goroutine, as require.Eventually runs goroutines of its own
+	for start := time.Now(); runtime.NumGoroutine() > goroutines; time.Sleep(10 * time.Millisecond) {
+		require.Less(t, time.Since(start), 5*time.Second, "abandoned proof request must not leak its goroutine")
+	}
+}
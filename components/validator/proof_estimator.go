@@ -0,0 +1,190 @@
+package validator
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/params"
+
+	"github.com/kroma-network/kroma/components/node/eth"
+)
+
+const (
+	// ProofStageChallenge is the estimate of the worst case proof, a full block, before creating a challenge.
+	ProofStageChallenge = "challenge"
+	// ProofStageProve is the estimate of the proof of the faulty block before fetching it.
+	ProofStageProve = "prove"
+)
+
+// proofWitnessGasPerByte is the work of a byte of the witness relative to the gas of the trace, weighted like a
+// non-zero byte of calldata.
+const proofWitnessGasPerByte = params.TxDataNonZeroGasEIP2028
+
+// proofMinObservedDuration is the minimum time of a fetched proof to measure the throughput of the prover on. The
+// proofs fetched faster were not generated, e.g. served from the proof cache.
+const proofMinObservedDuration = time.Second
+
+// proofThroughputWeight is the weight of the latest generated proof in the moving average of the prover throughput.
+const proofThroughputWeight = 0.3
+
+type ProofEstimateMetrics interface {
+	RecordProofEstimate(stage string, estimate time.Duration, feasible bool)
+}
+
+// ProofEstimate is the estimated time to generate the proof of a block.
+type ProofEstimate struct {
+	// TraceLength is the gas used by the proved block, the length of its execution trace.
+	TraceLength uint64
+	// WitnessSize is the size (in bytes) of the witness of the proved block, its transactions and merkle proof.
+	WitnessSize uint64
+	// Duration is the estimated time to generate the proof.
+	Duration time.Duration
+}
+
+// proofEstimator estimates the time to generate a proof from the size of its trace and witness, and the throughput of
+// the prover measured on the generated proofs.
+type proofEstimator struct {
+	mu sync.Mutex
+	// throughput is the moving average of the work proved per second, the expected throughput until a proof was
+	// measured. The throughput is unknown if 0.
+	throughput float64
+	measured   bool
+}
+
+func newProofEstimator(throughput uint64) *proofEstimator {
+	return &proofEstimator{throughput: float64(throughput)}
+}
+
+// proofWork is the work to prove a block, the gas of its trace and the weighted size of its witness.
+func proofWork(traceLength, witnessSize uint64) float64 {
+	return float64(traceLength) + float64(witnessSize)*float64(proofWitnessGasPerByte)
+}
+
+// estimate returns the estimated time to generate the proof, false if the throughput of the prover is unknown.
+func (e *proofEstimator) estimate(traceLength, witnessSize uint64) (ProofEstimate, bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.throughput <= 0 {
+		return ProofEstimate{}, false
+	}
+	seconds := proofWork(traceLength, witnessSize) / e.throughput
+	return ProofEstimate{
+		TraceLength: traceLength,
+		WitnessSize: witnessSize,
+		Duration:    time.Duration(seconds * float64(time.Second)),
+	}, true
+}
+
+// observe measures the throughput of the prover on a proof fetched in the duration.
+func (e *proofEstimator) observe(traceLength, witnessSize uint64, d time.Duration) {
+	if d < proofMinObservedDuration {
+		return
+	}
+	throughput := proofWork(traceLength, witnessSize) / d.Seconds()
+	if throughput <= 0 {
+		return
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if !e.measured {
+		e.throughput, e.measured = throughput, true
+		return
+	}
+	e.throughput = proofThroughputWeight*throughput + (1-proofThroughputWeight)*e.throughput
+}
+
+// witnessSize returns the size (in bytes) of the witness to prove the next block of the output.
+func witnessSize(output *eth.OutputResponse) uint64 {
+	p := output.PublicInputProof
+	if p == nil {
+		return 0
+	}
+	var size uint64
+	for _, tx := range p.NextTransactions {
+		size += tx.Size()
+	}
+	for _, proof := range p.MerkleProof {
+		size += uint64(len(proof))
+	}
+	return size
+}
+
+// estimateProof estimates the time to generate the proof of the block before the deadline, and warns if it is
+// unlikely to be generated before the budget of the proof requests, the deadline less the proof margin. The proof is
+// requested anyway, as the estimate may be too pessimistic. The estimate is nil if it is unknown.
+func (c *Challenger) estimateProof(ctx context.Context, blockNumber uint64, deadline time.Time) *ProofEstimate {
+	output, err := c.WitnessAtBlock(ctx, blockNumber)
+	if err != nil {
+		c.log.Warn("unable to estimate proof", "blockNumber", blockNumber, "err", err)
+		return nil
+	}
+	var traceLength uint64
+	if p := output.PublicInputProof; p != nil && p.NextBlock != nil {
+		traceLength = p.NextBlock.GasUsed
+	}
+	estimate, ok := c.proofEstimator.estimate(traceLength, witnessSize(output))
+	if !ok {
+		return &ProofEstimate{TraceLength: traceLength, WitnessSize: witnessSize(output)}
+	}
+
+	budget := time.Until(deadline.Add(-c.cfg.ChallengerProofMargin))
+	feasible := estimate.Duration <= budget
+	c.metr.RecordProofEstimate(ProofStageProve, estimate.Duration, feasible)
+	if !feasible {
+		c.log.Error("proof is unlikely to be generated before the deadline", "blockNumber", blockNumber,
+			"traceLength", estimate.TraceLength, "witnessSize", estimate.WitnessSize, "estimate", estimate.Duration,
+			"budget", budget, "deadline", deadline)
+	} else {
+		c.log.Info("estimated proof", "blockNumber", blockNumber, "traceLength", estimate.TraceLength,
+			"witnessSize", estimate.WitnessSize, "estimate", estimate.Duration, "budget", budget)
+	}
+	return &estimate
+}
+
+// checkChallengeProvable returns whether the proof of a full block, the worst case of the faulty block found by the
+// bisection, is likely to be generated within the proving timeout, warning if it is not. It is assumed provable if it
+// cannot be estimated.
+func (c *Challenger) checkChallengeProvable(ctx context.Context, outputIndex uint64) bool {
+	gasLimit := c.cfg.RollupConfig.Genesis.SystemConfig.GasLimit
+	estimate, ok := c.proofEstimator.estimate(gasLimit, 0)
+	if !ok {
+		return true
+	}
+	provingTimeout, err := c.getProvingTimeout(ctx)
+	if err != nil {
+		c.log.Warn("unable to check whether the challenge is provable", "outputIndex", outputIndex, "err", err)
+		return true
+	}
+	budget := provingTimeout - c.cfg.ChallengerProofMargin
+	feasible := estimate.Duration <= budget
+	c.metr.RecordProofEstimate(ProofStageChallenge, estimate.Duration, feasible)
+	if !feasible {
+		c.log.Warn("proof of a full block is unlikely to be generated within the proving timeout", "outputIndex",
+			outputIndex, "gasLimit", gasLimit, "estimate", estimate.Duration, "budget", budget)
+	}
+	return feasible
+}
+
+// deferChallenge returns whether to defer the challenge of the output found invalid at foundAt, as its proof is
+// unlikely to be generated in time, see checkChallengeProvable. The challenge is deferred for the infeasible delay, so
+// that another challenger with a faster prover challenges the output first, and the challenger then coordinates with
+// it. It is created once the delay elapsed, or the delay before the output finalizes, as an unchallenged invalid
+// output would finalize.
+func (c *Challenger) deferChallenge(ctx context.Context, outputRange *OutputRange, foundAt time.Time, now time.Time) bool {
+	if c.checkChallengeProvable(ctx, outputRange.OutputIndex.Uint64()) {
+		return false
+	}
+	delay := c.cfg.ChallengerInfeasibleDelay
+	until := foundAt.Add(delay)
+	if last := outputRange.FinalizesAt.Add(-delay); last.Before(until) {
+		until = last
+	}
+	if !now.Before(until) {
+		return false
+	}
+	c.log.Warn("deferring the challenge to leave the output to another challenger", "outputIndex",
+		outputRange.OutputIndex, "until", until, "finalizesAt", outputRange.FinalizesAt)
+	return true
+}
@@ -0,0 +1,145 @@
+package validator
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kroma-network/kroma/components/node/eth"
+	"github.com/kroma-network/kroma/components/node/rollup"
+	"github.com/kroma-network/kroma/components/node/testlog"
+	"github.com/kroma-network/kroma/components/validator/metrics"
+)
+
+func TestProofEstimator(t *testing.T) {
+	// the proofs are not estimated until the throughput is known
+	e := newProofEstimator(0)
+	_, ok := e.estimate(1_000_000, 0)
+	require.False(t, ok)
+
+	// a proof served from the cache does not measure the throughput
+	e.observe(1_000_000, 0, 10*time.Millisecond)
+	_, ok = e.estimate(1_000_000, 0)
+	require.False(t, ok)
+
+	e.observe(1_000_000, 0, 10*time.Second)
+	estimate, ok := e.estimate(2_000_000, 0)
+	require.True(t, ok)
+	require.Equal(t, 20*time.Second, estimate.Duration)
+
+	// the witness is weighted like calldata
+	estimate, ok = e.estimate(0, 6250)
+	require.True(t, ok)
+	require.Equal(t, time.Second, estimate.Duration)
+
+	// the measured throughput replaces the expected one, and is averaged
+	e = newProofEstimator(1_000_000)
+	estimate, ok = e.estimate(1_000_000, 0)
+	require.True(t, ok)
+	require.Equal(t, time.Second, estimate.Duration)
+	e.observe(1_000_000, 0, 10*time.Second)
+	e.observe(1_000_000, 0, 5*time.Second)
+	require.InDelta(t, 0.3*200_000+0.7*100_000, e.throughput, 1e-6)
+}
+
+type fakeWitnessProvider struct {
+	output *eth.OutputResponse
+}
+
+func (p *fakeWitnessProvider) OutputWithProofAtBlock(_ context.Context, _ uint64) (*eth.OutputResponse, error) {
+	return p.output, nil
+}
+
+type proofEstimateMetrics struct {
+	metrics.Metricer
+	stage    string
+	feasible bool
+}
+
+func (m *proofEstimateMetrics) RecordProofEstimate(stage string, _ time.Duration, feasible bool) {
+	m.stage, m.feasible = stage, feasible
+}
+
+func TestChallengerEstimateProof(t *testing.T) {
+	tx := types.NewTx(&types.LegacyTx{Data: make([]byte, 100)})
+	output := &eth.OutputResponse{PublicInputProof: &eth.PublicInputProof{
+		NextBlock:        &types.Header{Number: big.NewInt(101), GasUsed: 9_000_000},
+		NextTransactions: types.Transactions{tx},
+		MerkleProof:      []hexutil.Bytes{make([]byte, 32), make([]byte, 32)},
+	}}
+	require.Equal(t, tx.Size()+64, witnessSize(output))
+
+	m := &proofEstimateMetrics{Metricer: metrics.NoopMetrics}
+	c := &Challenger{
+		log:             testlog.Logger(t, log.LvlCrit),
+		metr:            m,
+		cfg:             Config{NetworkTimeout: time.Second, ChallengerProofMargin: time.Minute},
+		witnessProvider: &fakeWitnessProvider{output: output},
+		proofEstimator:  newProofEstimator(1_000_000),
+	}
+
+	// the proof takes about 9s, which fits in the budget
+	estimate := c.estimateProof(context.Background(), 100, time.Now().Add(2*time.Minute))
+	require.NotNil(t, estimate)
+	require.Equal(t, uint64(9_000_000), estimate.TraceLength)
+	require.Equal(t, witnessSize(output), estimate.WitnessSize)
+	require.Equal(t, ProofStageProve, m.stage)
+	require.True(t, m.feasible)
+
+	// the budget of the proof requests ends before the proof is generated
+	c.estimateProof(context.Background(), 100, time.Now().Add(time.Minute+5*time.Second))
+	require.False(t, m.feasible)
+
+	// the throughput is unknown, so the proof is not estimated, but measured
+	c.proofEstimator = newProofEstimator(0)
+	m.stage = ""
+	estimate = c.estimateProof(context.Background(), 100, time.Now())
+	require.NotNil(t, estimate)
+	require.Zero(t, estimate.Duration)
+	require.Empty(t, m.stage)
+}
+
+func TestChallengerDeferChallenge(t *testing.T) {
+	m := &proofEstimateMetrics{Metricer: metrics.NoopMetrics}
+	c := &Challenger{
+		log:  testlog.Logger(t, log.LvlCrit),
+		metr: m,
+		cfg: Config{
+			RollupConfig:              &rollup.Config{Genesis: rollup.Genesis{SystemConfig: eth.SystemConfig{GasLimit: 30_000_000}}},
+			ChallengerProofMargin:     time.Minute,
+			ChallengerInfeasibleDelay: 10 * time.Minute,
+		},
+		provingTimeout: time.Hour,
+		proofEstimator: newProofEstimator(10_000),
+	}
+	now := time.Now()
+	outputRange := &OutputRange{OutputIndex: big.NewInt(1), FinalizesAt: now.Add(24 * time.Hour)}
+
+	// the proof of a full block takes 50 minutes, which fits in the proving timeout
+	require.False(t, c.deferChallenge(context.Background(), outputRange, now, now))
+	require.Equal(t, ProofStageChallenge, m.stage)
+	require.True(t, m.feasible)
+
+	// the proof takes 100 minutes, so the challenge is deferred for the delay
+	c.proofEstimator = newProofEstimator(5_000)
+	require.True(t, c.deferChallenge(context.Background(), outputRange, now, now))
+	require.False(t, m.feasible)
+	require.True(t, c.deferChallenge(context.Background(), outputRange, now, now.Add(9*time.Minute)))
+	require.False(t, c.deferChallenge(context.Background(), outputRange, now, now.Add(10*time.Minute)))
+
+	// the challenge is not deferred past the delay before the output finalizes
+	outputRange.FinalizesAt = now.Add(15 * time.Minute)
+	require.True(t, c.deferChallenge(context.Background(), outputRange, now, now.Add(4*time.Minute)))
+	require.False(t, c.deferChallenge(context.Background(), outputRange, now, now.Add(5*time.Minute)))
+
+	// the challenge is not deferred without delay
+	c.cfg.ChallengerInfeasibleDelay = 0
+	outputRange.FinalizesAt = now.Add(24 * time.Hour)
+	require.False(t, c.deferChallenge(context.Background(), outputRange, now, now))
+}
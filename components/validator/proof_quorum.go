@@ -0,0 +1,113 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/log"
+
+	chal "github.com/kroma-network/kroma/components/validator/challenge"
+)
+
+// ErrProofDiscrepancy is returned when only one of the provers of a proofQuorum produced a verifying proof.
+var ErrProofDiscrepancy = errors.New("prover discrepancy")
+
+// ProofVerifier verifies a zk proof, e.g. the ZKVerifier contract.
+type ProofVerifier interface {
+	Verify(opts *bind.CallOpts, proof []*big.Int, pair []*big.Int) (bool, error)
+}
+
+// proofQuorum is a ProofFetcher that requests the proof from two independent provers,
+// and only returns a proof if the proofs of both provers verify.
+// It protects against a buggy prover causing a lost dispute.
+type proofQuorum struct {
+	log       log.Logger
+	primary   ProofFetcher
+	secondary ProofFetcher
+	verifier  ProofVerifier
+	timeout   time.Duration
+}
+
+var _ ProofFetcher = (*proofQuorum)(nil)
+
+func newProofQuorum(l log.Logger, primary, secondary ProofFetcher, verifier ProofVerifier, timeout time.Duration) *proofQuorum {
+	return &proofQuorum{
+		log:       l,
+		primary:   primary,
+		secondary: secondary,
+		verifier:  verifier,
+		timeout:   timeout,
+	}
+}
+
+type proofResult struct {
+	proof *chal.ProofAndPair
+	err   error
+	valid bool
+}
+
+// FetchProofAndPair requests the proof from both provers concurrently.
+// The proof of the primary prover is returned if both proofs verify.
+func (q *proofQuorum) FetchProofAndPair(blockNumber uint64) (*chal.ProofAndPair, error) {
+	var (
+		wg      sync.WaitGroup
+		results [2]proofResult
+	)
+	for i, fetcher := range []ProofFetcher{q.primary, q.secondary} {
+		wg.Add(1)
+		go func(i int, fetcher ProofFetcher) {
+			defer wg.Done()
+			results[i] = q.fetchAndVerify(fetcher, blockNumber)
+		}(i, fetcher)
+	}
+	wg.Wait()
+
+	primary, secondary := results[0], results[1]
+	if primary.err != nil {
+		return nil, fmt.Errorf("primary prover: %w", primary.err)
+	}
+	if secondary.err != nil {
+		return nil, fmt.Errorf("secondary prover: %w", secondary.err)
+	}
+
+	switch {
+	case primary.valid && secondary.valid:
+		return primary.proof, nil
+	case !primary.valid && !secondary.valid:
+		return nil, fmt.Errorf("no prover produced a verifying proof: blockNumber: %d", blockNumber)
+	default:
+		q.log.Error("only one prover produced a verifying proof", "blockNumber", blockNumber,
+			"primary_valid", primary.valid, "secondary_valid", secondary.valid)
+		return nil, fmt.Errorf("%w: blockNumber: %d, primary valid: %t, secondary valid: %t",
+			ErrProofDiscrepancy, blockNumber, primary.valid, secondary.valid)
+	}
+}
+
+func (q *proofQuorum) fetchAndVerify(fetcher ProofFetcher, blockNumber uint64) proofResult {
+	proof, err := fetcher.FetchProofAndPair(blockNumber)
+	if err != nil {
+		return proofResult{err: err}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), q.timeout)
+	defer cancel()
+	valid, err := q.verifier.Verify(&bind.CallOpts{Context: ctx}, proof.Proof, proof.Pair)
+	if err != nil {
+		return proofResult{err: fmt.Errorf("failed to verify proof: %w", err)}
+	}
+	return proofResult{proof: proof, valid: valid}
+}
+
+func (q *proofQuorum) Close() error {
+	primaryErr := q.primary.Close()
+	secondaryErr := q.secondary.Close()
+	if primaryErr != nil {
+		return primaryErr
+	}
+	return secondaryErr
+}
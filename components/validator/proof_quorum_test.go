@@ -0,0 +1,124 @@
+package validator
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kroma-network/kroma/components/node/testlog"
+	chal "github.com/kroma-network/kroma/components/validator/challenge"
+)
+
+// fakeProofFetcher returns a proof that consists of its id, or err if set.
+type fakeProofFetcher struct {
+	id     int64
+	err    error
+	closed bool
+}
+
+func (f *fakeProofFetcher) FetchProofAndPair(_ uint64) (*chal.ProofAndPair, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &chal.ProofAndPair{
+		Proof: []*big.Int{big.NewInt(f.id)},
+		Pair:  []*big.Int{big.NewInt(f.id)},
+	}, nil
+}
+
+func (f *fakeProofFetcher) Close() error {
+	f.closed = true
+	return nil
+}
+
+// fakeProofVerifier accepts the proofs of the valid fetcher ids.
+type fakeProofVerifier struct {
+	valid map[int64]bool
+	err   error
+}
+
+func (v *fakeProofVerifier) Verify(_ *bind.CallOpts, proof []*big.Int, _ []*big.Int) (bool, error) {
+	if v.err != nil {
+		return false, v.err
+	}
+	return v.valid[proof[0].Int64()], nil
+}
+
+func TestProofQuorum(t *testing.T) {
+	const primaryID, secondaryID = 1, 2
+
+	tests := []struct {
+		name         string
+		primaryErr   error
+		secondaryErr error
+		verifier     *fakeProofVerifier
+		expectErr    error
+		expectProof  bool
+	}{
+		{
+			name:        "both proofs verify",
+			verifier:    &fakeProofVerifier{valid: map[int64]bool{primaryID: true, secondaryID: true}},
+			expectProof: true,
+		},
+		{
+			name:      "only primary proof verifies",
+			verifier:  &fakeProofVerifier{valid: map[int64]bool{primaryID: true}},
+			expectErr: ErrProofDiscrepancy,
+		},
+		{
+			name:      "only secondary proof verifies",
+			verifier:  &fakeProofVerifier{valid: map[int64]bool{secondaryID: true}},
+			expectErr: ErrProofDiscrepancy,
+		},
+		{
+			name:     "no proof verifies",
+			verifier: &fakeProofVerifier{},
+		},
+		{
+			name:       "primary prover fails",
+			primaryErr: errFakeRpc,
+			verifier:   &fakeProofVerifier{valid: map[int64]bool{primaryID: true, secondaryID: true}},
+			expectErr:  errFakeRpc,
+		},
+		{
+			name:         "secondary prover fails",
+			secondaryErr: errFakeRpc,
+			verifier:     &fakeProofVerifier{valid: map[int64]bool{primaryID: true, secondaryID: true}},
+			expectErr:    errFakeRpc,
+		},
+		{
+			name:      "verification fails",
+			verifier:  &fakeProofVerifier{err: errFakeRpc},
+			expectErr: errFakeRpc,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			primary := &fakeProofFetcher{id: primaryID, err: test.primaryErr}
+			secondary := &fakeProofFetcher{id: secondaryID, err: test.secondaryErr}
+			q := newProofQuorum(testlog.Logger(t, log.LvlCrit), primary, secondary, test.verifier, time.Second)
+
+			proof, err := q.FetchProofAndPair(100)
+			if test.expectProof {
+				require.NoError(t, err)
+				require.Equal(t, big.NewInt(primaryID), proof.Proof[0])
+			} else {
+				require.Error(t, err)
+				require.Nil(t, proof)
+				if test.expectErr != nil {
+					require.ErrorIs(t, err, test.expectErr)
+				}
+			}
+
+			require.NoError(t, q.Close())
+			require.True(t, primary.closed)
+			require.True(t, secondary.closed)
+		})
+	}
+}
@@ -0,0 +1,289 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/kroma-network/kroma/bindings/bindings"
+	"github.com/kroma-network/kroma/components/validator/metrics"
+	"github.com/kroma-network/kroma/utils"
+)
+
+// The kinds of the findings of the recovery audit.
+const (
+	// RecoveryFindingPendingTransactions are transactions of a previous run still pending in the mempool. The tx
+	// manager sends the next transactions from the mined nonce, so they replace the pending ones.
+	RecoveryFindingPendingTransactions = "pending-transactions"
+	// RecoveryFindingConfirmationMissing is a request recorded as confirmed, whose confirmation is not on chain.
+	RecoveryFindingConfirmationMissing = "confirmation-missing"
+	// RecoveryFindingRevocationMissing is a request recorded as revoked, whose confirmation is still on chain.
+	RecoveryFindingRevocationMissing = "revocation-missing"
+)
+
+// The corrective actions of the findings of the recovery audit.
+const (
+	// RecoveryActionNone is a finding that is only reported.
+	RecoveryActionNone = "none"
+	// RecoveryActionRevalidate validates the request again, see GuardianAPI.Revalidate.
+	RecoveryActionRevalidate = "revalidate"
+)
+
+// recoveryFindingKinds are the kinds of the findings, to reset their metrics.
+var recoveryFindingKinds = []string{
+	RecoveryFindingPendingTransactions,
+	RecoveryFindingConfirmationMissing,
+	RecoveryFindingRevocationMissing,
+}
+
+// RecoveryFinding is a mismatch between the state recorded by the validator and the state on chain.
+type RecoveryFinding struct {
+	Kind string `json:"kind"`
+	// TransactionId is the id of the validation request of the finding, nil if it is not about a request.
+	TransactionId *big.Int `json:"transactionId,omitempty"`
+	Detail        string   `json:"detail"`
+	Action        string   `json:"action"`
+	// Error is the error of the corrective action, empty if it succeeded or there is none.
+	Error string `json:"error,omitempty"`
+}
+
+// RecoveryOutput is the latest output of the L2OutputOracle.
+type RecoveryOutput struct {
+	Index         *big.Int       `json:"index"`
+	L2BlockNumber *big.Int       `json:"l2BlockNumber"`
+	Submitter     common.Address `json:"submitter"`
+	// Own is whether the output was submitted by this validator.
+	Own bool `json:"own"`
+}
+
+// RecoveryReport is the report of the recovery audit, which reconciles the state recorded by the validator with
+// the state on chain on start.
+type RecoveryReport struct {
+	From common.Address `json:"from"`
+	// PendingTransactions is the number of the transactions of the sender pending in the mempool.
+	PendingTransactions uint64 `json:"pendingTransactions"`
+	// LatestOutput is the latest submitted output, nil if none was submitted yet.
+	LatestOutput *RecoveryOutput `json:"latestOutput,omitempty"`
+	// Decisions is the number of the audited decisions of the guardian.
+	Decisions int               `json:"decisions"`
+	Findings  []RecoveryFinding `json:"findings"`
+}
+
+// reconcileDecision returns the finding of the recorded decision of the guardian, given whether its confirmation
+// by the validator is on chain and whether the request is confirmed by the quorum already, nil if it is consistent.
+// dissent is whether the guardian contests the requests of invalid outputs, i.e. can revoke its confirmation again.
+func reconcileDecision(decision *GuardianDecision, ownConfirmation, quorumConfirmed, dissent bool) *RecoveryFinding {
+	switch decision.Outcome {
+	case GuardianOutcomeQueued, GuardianOutcomeConfirmed:
+		if ownConfirmation || quorumConfirmed {
+			return nil
+		}
+		return &RecoveryFinding{
+			Kind:          RecoveryFindingConfirmationMissing,
+			TransactionId: decision.TransactionId,
+			Detail:        "the confirmation was not mined, it was dropped or is still pending",
+			Action:        RecoveryActionRevalidate,
+		}
+	case GuardianOutcomeRevoked:
+		if !ownConfirmation {
+			return nil
+		}
+		finding := &RecoveryFinding{
+			Kind:          RecoveryFindingRevocationMissing,
+			TransactionId: decision.TransactionId,
+			Detail:        "the revocation was not mined, it was dropped or is still pending",
+			Action:        RecoveryActionNone,
+		}
+		// the confirmation cannot be revoked once the request was executed
+		if dissent && !quorumConfirmed {
+			finding.Action = RecoveryActionRevalidate
+		}
+		return finding
+	default:
+		return nil
+	}
+}
+
+// RecoveryL1Client is the set of L1 methods that the recovery audit uses.
+type RecoveryL1Client interface {
+	NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error)
+	PendingNonceAt(ctx context.Context, account common.Address) (uint64, error)
+}
+
+// RecoveryL2OOContract is the set of L2OutputOracle contract methods that the recovery audit uses.
+type RecoveryL2OOContract interface {
+	NextOutputIndex(opts *bind.CallOpts) (*big.Int, error)
+	GetL2Output(opts *bind.CallOpts, l2OutputIndex *big.Int) (bindings.TypesCheckpointOutput, error)
+}
+
+// recoveryAudit reconciles the decisions recorded by the guardian and the transactions of a previous run with the
+// state on chain, and corrects the decisions whose transactions did not land.
+type recoveryAudit struct {
+	log     log.Logger
+	metr    metrics.Metricer
+	from    common.Address
+	l1      RecoveryL1Client
+	l2oo    RecoveryL2OOContract
+	timeout time.Duration
+	// window is how far back the decisions of the guardian are audited.
+	window time.Duration
+	// guardian is the guardian whose decisions are audited, nil if it is disabled.
+	guardian *Guardian
+}
+
+func newRecoveryAudit(l log.Logger, m metrics.Metricer, from common.Address, l1 RecoveryL1Client, l2oo RecoveryL2OOContract,
+	timeout, window time.Duration, guardian *Guardian) *recoveryAudit {
+	return &recoveryAudit{
+		log:      l,
+		metr:     m,
+		from:     from,
+		l1:       l1,
+		l2oo:     l2oo,
+		timeout:  timeout,
+		window:   window,
+		guardian: guardian,
+	}
+}
+
+// run audits the state on start, applies the corrective actions and reports the findings.
+func (a *recoveryAudit) run(ctx context.Context) *RecoveryReport {
+	report, err := a.audit(ctx)
+	if err != nil {
+		a.log.Error("failed to run recovery audit", "err", err)
+		return nil
+	}
+	a.correct(ctx, report)
+
+	counts := make(map[string]int, len(recoveryFindingKinds))
+	for _, finding := range report.Findings {
+		counts[finding.Kind]++
+		a.log.Warn("recovery audit found mismatch with chain", "kind", finding.Kind, "transactionId", finding.TransactionId,
+			"detail", finding.Detail, "action", finding.Action, "err", finding.Error)
+	}
+	for _, kind := range recoveryFindingKinds {
+		a.metr.RecordRecoveryFindings(kind, counts[kind])
+	}
+	logCtx := []any{"from", report.From, "pendingTransactions", report.PendingTransactions, "decisions", report.Decisions,
+		"findings", len(report.Findings)}
+	if report.LatestOutput != nil {
+		logCtx = append(logCtx, "latestOutputIndex", report.LatestOutput.Index, "latestOutputSubmitter", report.LatestOutput.Submitter,
+			"latestOutputOwn", report.LatestOutput.Own)
+	}
+	a.log.Info("recovery audit finished", logCtx...)
+	return report
+}
+
+// audit builds the report of the mismatches between the recorded state and the state on chain.
+func (a *recoveryAudit) audit(ctx context.Context) (*RecoveryReport, error) {
+	report := &RecoveryReport{From: a.from, Findings: []RecoveryFinding{}}
+
+	cCtx, cCancel := context.WithTimeout(ctx, a.timeout)
+	defer cCancel()
+	mined, err := a.l1.NonceAt(cCtx, a.from, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get nonce: %w", err)
+	}
+	pending, err := a.l1.PendingNonceAt(cCtx, a.from)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pending nonce: %w", err)
+	}
+	if pending > mined {
+		report.PendingTransactions = pending - mined
+		report.Findings = append(report.Findings, RecoveryFinding{
+			Kind:   RecoveryFindingPendingTransactions,
+			Detail: fmt.Sprintf("%d transactions from nonce %d are pending, they are replaced by the next transactions", pending-mined, mined),
+			Action: RecoveryActionNone,
+		})
+	}
+
+	callOpts := utils.NewSimpleCallOpts(cCtx)
+	nextOutputIndex, err := a.l2oo.NextOutputIndex(callOpts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get next output index: %w", err)
+	}
+	if nextOutputIndex.Sign() > 0 {
+		index := new(big.Int).Sub(nextOutputIndex, common.Big1)
+		output, err := a.l2oo.GetL2Output(callOpts, index)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get latest output: %w", err)
+		}
+		report.LatestOutput = &RecoveryOutput{
+			Index:         index,
+			L2BlockNumber: output.L2BlockNumber,
+			Submitter:     output.Submitter,
+			Own:           output.Submitter == a.from,
+		}
+	}
+
+	if err := a.auditDecisions(ctx, report); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// auditDecisions adds the findings of the decisions of the guardian recorded within the window.
+func (a *recoveryAudit) auditDecisions(ctx context.Context, report *RecoveryReport) error {
+	if a.guardian == nil || a.guardian.store == nil {
+		return nil
+	}
+	decisions, err := a.guardian.store.Decisions(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to read guardian decisions: %w", err)
+	}
+	since := time.Now().Add(-a.window)
+	for _, decision := range decisions {
+		if decision.Time.Before(since) ||
+			(decision.Outcome != GuardianOutcomeQueued && decision.Outcome != GuardianOutcomeConfirmed &&
+				decision.Outcome != GuardianOutcomeRevoked) {
+			continue
+		}
+		report.Decisions++
+		ownConfirmation, quorumConfirmed, err := a.confirmationState(ctx, decision.TransactionId)
+		if err != nil {
+			return err
+		}
+		if finding := reconcileDecision(decision, ownConfirmation, quorumConfirmed, a.guardian.cfg.GuardianDissent); finding != nil {
+			report.Findings = append(report.Findings, *finding)
+		}
+	}
+	return nil
+}
+
+// confirmationState returns whether the request is confirmed by this validator, and by the quorum.
+func (a *recoveryAudit) confirmationState(ctx context.Context, transactionId *big.Int) (bool, bool, error) {
+	cCtx, cCancel := context.WithTimeout(ctx, a.timeout)
+	defer cCancel()
+	callOpts := utils.NewSimpleCallOpts(cCtx)
+	confirmations, err := a.guardian.securityCouncilContract.GetConfirmations(callOpts, transactionId)
+	if err != nil {
+		return false, false, fmt.Errorf("failed to get confirmations of %s: %w", transactionId, err)
+	}
+	quorumConfirmed, err := a.guardian.securityCouncilContract.IsConfirmed(callOpts, transactionId)
+	if err != nil {
+		return false, false, fmt.Errorf("failed to get confirmation of %s: %w", transactionId, err)
+	}
+	for _, member := range confirmations {
+		if member == a.from {
+			return true, quorumConfirmed, nil
+		}
+	}
+	return false, quorumConfirmed, nil
+}
+
+// correct applies the corrective actions of the findings, recording their errors in the findings.
+func (a *recoveryAudit) correct(ctx context.Context, report *RecoveryReport) {
+	for i := range report.Findings {
+		finding := &report.Findings[i]
+		if finding.Action != RecoveryActionRevalidate {
+			continue
+		}
+		if err := a.guardian.revalidate(ctx, finding.TransactionId); err != nil {
+			finding.Error = err.Error()
+		}
+	}
+}
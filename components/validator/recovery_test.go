@@ -0,0 +1,173 @@
+package validator
+
+import (
+	"context"
+	"math/big"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kroma-network/kroma/bindings/bindings"
+	"github.com/kroma-network/kroma/components/node/eth"
+	"github.com/kroma-network/kroma/components/node/testlog"
+	"github.com/kroma-network/kroma/components/validator/metrics"
+)
+
+type fakeRecoveryL1 struct {
+	mined, pending uint64
+}
+
+func (f *fakeRecoveryL1) NonceAt(_ context.Context, _ common.Address, _ *big.Int) (uint64, error) {
+	return f.mined, nil
+}
+
+func (f *fakeRecoveryL1) PendingNonceAt(_ context.Context, _ common.Address) (uint64, error) {
+	return f.pending, nil
+}
+
+type fakeRecoveryL2OO struct {
+	outputs []bindings.TypesCheckpointOutput
+}
+
+func (f *fakeRecoveryL2OO) NextOutputIndex(_ *bind.CallOpts) (*big.Int, error) {
+	return big.NewInt(int64(len(f.outputs))), nil
+}
+
+func (f *fakeRecoveryL2OO) GetL2Output(_ *bind.CallOpts, index *big.Int) (bindings.TypesCheckpointOutput, error) {
+	return f.outputs[index.Int64()], nil
+}
+
+func TestReconcileDecision(t *testing.T) {
+	tests := []struct {
+		name            string
+		outcome         GuardianOutcome
+		ownConfirmation bool
+		quorumConfirmed bool
+		dissent         bool
+		expectKind      string
+		expectAction    string
+	}{
+		{name: "confirmation landed", outcome: GuardianOutcomeConfirmed, ownConfirmation: true},
+		{name: "executed without the confirmation", outcome: GuardianOutcomeConfirmed, quorumConfirmed: true},
+		{
+			name:         "confirmation missing",
+			outcome:      GuardianOutcomeConfirmed,
+			expectKind:   RecoveryFindingConfirmationMissing,
+			expectAction: RecoveryActionRevalidate,
+		},
+		{name: "revocation landed", outcome: GuardianOutcomeRevoked, dissent: true},
+		{
+			name:            "revocation missing",
+			outcome:         GuardianOutcomeRevoked,
+			ownConfirmation: true,
+			dissent:         true,
+			expectKind:      RecoveryFindingRevocationMissing,
+			expectAction:    RecoveryActionRevalidate,
+		},
+		{
+			name:            "revocation missing without dissent",
+			outcome:         GuardianOutcomeRevoked,
+			ownConfirmation: true,
+			expectKind:      RecoveryFindingRevocationMissing,
+			expectAction:    RecoveryActionNone,
+		},
+		{
+			name:            "revocation missing of an executed request",
+			outcome:         GuardianOutcomeRevoked,
+			ownConfirmation: true,
+			quorumConfirmed: true,
+			dissent:         true,
+			expectKind:      RecoveryFindingRevocationMissing,
+			expectAction:    RecoveryActionNone,
+		},
+		{name: "no transaction", outcome: GuardianOutcomeMismatch},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			decision := &GuardianDecision{TransactionId: big.NewInt(7), Outcome: test.outcome}
+			finding := reconcileDecision(decision, test.ownConfirmation, test.quorumConfirmed, test.dissent)
+			if test.expectKind == "" {
+				require.Nil(t, finding)
+				return
+			}
+			require.NotNil(t, finding)
+			require.Equal(t, test.expectKind, finding.Kind)
+			require.Equal(t, test.expectAction, finding.Action)
+			require.Equal(t, big.NewInt(7), finding.TransactionId)
+		})
+	}
+}
+
+func TestRecoveryAudit(t *testing.T) {
+	const l2BlockNumber = 100
+	localOutputRoot := eth.Bytes32{0xaa}
+	from := common.Address{0x01}
+	ctx := context.Background()
+
+	rollupClient := &fakeRollupClient{outputRoot: localOutputRoot, blockNumber: l2BlockNumber}
+	// the confirmation of request 8 landed, the one of request 7 did not
+	council := &recoveryCouncil{
+		fakeSecurityCouncil: &fakeSecurityCouncil{requests: []types.Log{
+			validationRequestedLog(t, 3000, 7, localOutputRoot, l2BlockNumber),
+		}},
+		confirmationsOf: map[uint64][]common.Address{8: {from}},
+	}
+	g, candidates := newTestGuardian(t, rollupClient, council)
+	store, err := NewGuardianStore(filepath.Join(t.TempDir(), "store"))
+	require.NoError(t, err)
+	defer store.Close()
+	g.store = store
+	g.cfg.GuardianEnabled = true
+	g.ctx = ctx
+	for _, decision := range []*GuardianDecision{
+		{TransactionId: big.NewInt(6), L1Block: 2000, Outcome: GuardianOutcomeConfirmed, Time: time.Now().Add(-48 * time.Hour)},
+		{TransactionId: big.NewInt(7), L1Block: 3000, Outcome: GuardianOutcomeConfirmed, Time: time.Now()},
+		{TransactionId: big.NewInt(8), L1Block: 3001, Outcome: GuardianOutcomeConfirmed, Time: time.Now()},
+	} {
+		require.NoError(t, store.RecordDecision(ctx, decision))
+	}
+
+	l2oo := &fakeRecoveryL2OO{outputs: []bindings.TypesCheckpointOutput{
+		{Submitter: common.Address{0x02}, L2BlockNumber: big.NewInt(90)},
+		{Submitter: from, L2BlockNumber: big.NewInt(l2BlockNumber)},
+	}}
+	audit := newRecoveryAudit(testlog.Logger(t, log.LvlCrit), metrics.NoopMetrics, from, &fakeRecoveryL1{mined: 5, pending: 7},
+		l2oo, time.Second, 24*time.Hour, g)
+
+	report := audit.run(ctx)
+	require.NotNil(t, report)
+	require.Equal(t, uint64(2), report.PendingTransactions)
+	require.Equal(t, &RecoveryOutput{Index: big.NewInt(1), L2BlockNumber: big.NewInt(l2BlockNumber), Submitter: from, Own: true},
+		report.LatestOutput)
+	require.Equal(t, 2, report.Decisions, "the decisions older than the window are not audited")
+	require.Len(t, report.Findings, 2)
+	require.Equal(t, RecoveryFindingPendingTransactions, report.Findings[0].Kind)
+	require.Equal(t, RecoveryFindingConfirmationMissing, report.Findings[1].Kind)
+	require.Equal(t, big.NewInt(7), report.Findings[1].TransactionId)
+	require.Empty(t, report.Findings[1].Error)
+
+	select {
+	case <-candidates:
+	case <-time.After(5 * time.Second):
+		t.Fatal("request of the missing confirmation was not revalidated")
+	}
+	g.wg.Wait()
+}
+
+// recoveryCouncil serves the confirmations per request.
+type recoveryCouncil struct {
+	*fakeSecurityCouncil
+	confirmationsOf map[uint64][]common.Address
+}
+
+func (c *recoveryCouncil) GetConfirmations(_ *bind.CallOpts, transactionId *big.Int) ([]common.Address, error) {
+	return c.confirmationsOf[transactionId.Uint64()], nil
+}
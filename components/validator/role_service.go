@@ -0,0 +1,311 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/kroma-network/kroma/utils/service/txmgr"
+)
+
+// roleComponent is a component of a role of the validator, queueing the transactions of the role.
+type roleComponent interface {
+	Start(ctx context.Context, txCandidatesChan chan<- txmgr.TxCandidate) error
+	Stop() error
+}
+
+// drainableComponent is a roleComponent with work in progress queueing transactions, e.g. the challenge turns,
+// which is finished before the role is stopped.
+type drainableComponent interface {
+	roleComponent
+	// Drain stops starting new work, and waits for the work in progress to finish or the context to be done.
+	Drain(ctx context.Context)
+}
+
+// txObserverComponent is a roleComponent following the outcome of its queued transactions, e.g. to record whether a
+// confirmation was mined.
+type txObserverComponent interface {
+	roleComponent
+	// TxSent is called once the transaction candidate is sent, with its receipt, or with the error if it was not.
+	TxSent(candidate txmgr.TxCandidate, receipt *types.Receipt, err error)
+}
+
+// workTracker tracks the work in progress of a drainableComponent.
+type workTracker struct {
+	mu       sync.Mutex
+	draining bool
+	inFlight int
+	// drained is closed once draining and no work is in progress, nil until drained is waited for
+	drained chan struct{}
+}
+
+// begin starts a work, it returns false if the component is draining instead.
+func (w *workTracker) begin() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.draining {
+		return false
+	}
+	w.inFlight++
+	return true
+}
+
+func (w *workTracker) end() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.inFlight--
+	if w.inFlight == 0 && w.drained != nil {
+		close(w.drained)
+		w.drained = nil
+	}
+}
+
+// drain stops starting new work, and waits for the work in progress to end or the context to be done.
+func (w *workTracker) drain(ctx context.Context) {
+	w.mu.Lock()
+	w.draining = true
+	if w.inFlight == 0 {
+		w.mu.Unlock()
+		return
+	}
+	if w.drained == nil {
+		w.drained = make(chan struct{})
+	}
+	drained := w.drained
+	w.mu.Unlock()
+
+	select {
+	case <-drained:
+	case <-ctx.Done():
+	}
+}
+
+// roleService runs a role of the validator with a lifecycle of its own. The transactions of the role are queued to a
+// channel of its own and sent with the tx manager of the role, so that the role is started, stopped and drained
+// independently of the other roles, and may send from an account of its own.
+type roleService struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	role         string
+	l            log.Logger
+	txMgr        *txmgr.SimpleTxManager
+	drainTimeout time.Duration
+	components   []roleComponent
+	// gate holds the transactions of the role while it returns an error, optional (may be nil)
+	gate func() error
+	// gateRetryInterval is how frequently the gate is checked again while it holds a transaction
+	gateRetryInterval time.Duration
+
+	txCandidatesChan chan txmgr.TxCandidate
+	// drainChan is closed when the components are stopped, so that the remaining queued transaction candidates are
+	// sent before exiting.
+	drainChan chan struct{}
+
+	wg sync.WaitGroup
+}
+
+func newRoleService(role string, l log.Logger, txMgr *txmgr.SimpleTxManager, drainTimeout time.Duration, components ...roleComponent) *roleService {
+	return &roleService{
+		role:              role,
+		l:                 l.New("role", role),
+		txMgr:             txMgr,
+		drainTimeout:      drainTimeout,
+		components:        components,
+		gateRetryInterval: time.Second,
+	}
+}
+
+func (s *roleService) Start() error {
+	s.ctx, s.cancel = context.WithCancel(context.Background())
+	s.l.Info("starting role", "from", s.txMgr.From())
+
+	s.txCandidatesChan = make(chan txmgr.TxCandidate, 10)
+	s.drainChan = make(chan struct{})
+
+	for _, c := range s.components {
+		if err := c.Start(s.ctx, s.txCandidatesChan); err != nil {
+			return fmt.Errorf("cannot start %s: %w", s.role, err)
+		}
+	}
+
+	s.wg.Add(1)
+	go s.loop()
+
+	return nil
+}
+
+func (s *roleService) Stop() error {
+	s.l.Info("stopping role")
+	// the components are stopped once drained, so that the work in progress is not abandoned
+	s.drain()
+	for _, c := range s.components {
+		if err := c.Stop(); err != nil {
+			return fmt.Errorf("failed to stop %s: %w", s.role, err)
+		}
+	}
+
+	s.cancel()
+	s.wg.Wait()
+
+	close(s.txCandidatesChan)
+
+	return nil
+}
+
+// drain waits for the work in progress of the components, e.g. the challenge turns, and then for the queued
+// transaction candidates to be sent, until the shutdown drain timeout elapses.
+func (s *roleService) drain() {
+	ctx, cancel := context.WithTimeout(context.Background(), s.drainTimeout)
+	defer cancel()
+	for _, c := range s.components {
+		if d, ok := c.(drainableComponent); ok {
+			d.Drain(ctx)
+		}
+	}
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	close(s.drainChan)
+	s.l.Info("draining queued transactions of role", "queued", len(s.txCandidatesChan), "timeout", s.drainTimeout)
+
+	select {
+	case <-done:
+		s.l.Info("drained queued transactions of role")
+	case <-ctx.Done():
+		s.l.Warn("timed out draining queued transactions of role", "remaining", len(s.txCandidatesChan))
+	}
+}
+
+func (s *roleService) loop() {
+	defer s.wg.Done()
+
+	for {
+		select {
+		case txCandidate := <-s.txCandidatesChan:
+			s.send(txCandidate)
+		case <-s.drainChan:
+			for {
+				select {
+				case txCandidate := <-s.txCandidatesChan:
+					s.send(txCandidate)
+				case <-s.ctx.Done():
+					return
+				default:
+					return
+				}
+			}
+		case <-s.ctx.Done():
+			return
+		}
+	}
+}
+
+// send sends the transaction candidate, and returns once it is sent or parked for approval by the tx manager, so that
+// a transaction waiting for approval does not hold the next transactions of the role.
+func (s *roleService) send(txCandidate txmgr.TxCandidate) {
+	parked := make(chan struct{})
+	done := make(chan struct{})
+	ctx := txmgr.WithApprovalWait(s.ctx, func() { close(parked) })
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		defer close(done)
+		if err := s.sendTransaction(ctx, txCandidate); err != nil {
+			s.l.Error("failed to submit transaction of validator", "err", err)
+		}
+	}()
+	select {
+	case <-done:
+	case <-parked:
+		s.l.Info("transaction is waiting for approval, sending the next transactions meanwhile", "id", txCandidate.ID)
+	}
+}
+
+// sendTransaction creates & sends transactions through the tx manager of the role.
+func (s *roleService) sendTransaction(ctx context.Context, txCandidate txmgr.TxCandidate) (err error) {
+	var receipt *types.Receipt
+	defer func() { s.txSent(txCandidate, receipt, err) }()
+	if err := s.waitGate(ctx, txCandidate); err != nil {
+		return err
+	}
+	receipt, err = s.txMgr.Send(ctx, txCandidate)
+	if err != nil {
+		return fmt.Errorf("failed to send transaction of %s: %w", s.role, err)
+	}
+	s.l.Info("validator tx successfully published", "tx_hash", receipt.TxHash)
+	return nil
+}
+
+// waitGate holds the transaction candidate while the gate refuses the transactions of the role, so that it is sent
+// once they are accepted again instead of being dropped. It returns the refusal if the context is done meanwhile.
+func (s *roleService) waitGate(ctx context.Context, txCandidate txmgr.TxCandidate) error {
+	if s.gate == nil {
+		return nil
+	}
+	err := s.gate()
+	if err == nil {
+		return nil
+	}
+	s.l.Warn("holding transaction of role until it is accepted", "id", txCandidate.ID, "err", err)
+	ticker := time.NewTicker(s.gateRetryInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err = s.gate(); err == nil {
+				s.l.Info("sending held transaction of role", "id", txCandidate.ID)
+				return nil
+			}
+		case <-ctx.Done():
+			return fmt.Errorf("refused to send transaction of %s: %w", s.role, err)
+		}
+	}
+}
+
+// txSent notifies the components observing their transactions of the outcome of the transaction candidate.
+func (s *roleService) txSent(txCandidate txmgr.TxCandidate, receipt *types.Receipt, err error) {
+	for _, c := range s.components {
+		if o, ok := c.(txObserverComponent); ok {
+			o.TxSent(txCandidate, receipt, err)
+		}
+	}
+}
+
+// forRole returns the config of the role, sending with the tx manager of the role if it has an account of its own.
+func (c Config) forRole(role string) Config {
+	if txMgr, ok := c.RoleTxManagers[role]; ok {
+		c.TxManager = txMgr
+	}
+	return c
+}
+
+// inFlightTxs returns the in-flight transactions of every tx manager of the validator.
+func (c *Config) inFlightTxs() []*txmgr.InFlightTxs {
+	txs := []*txmgr.InFlightTxs{c.TxManager.InFlight}
+	for _, role := range []string{L1RoleSubmitter, L1RoleChallenger, L1RoleGuardian} {
+		if txMgr, ok := c.RoleTxManagers[role]; ok && txMgr != c.TxManager {
+			txs = append(txs, txMgr.InFlight)
+		}
+	}
+	return txs
+}
+
+// txManagers returns every tx manager of the validator.
+func (c *Config) txManagers() []*txmgr.SimpleTxManager {
+	txMgrs := []*txmgr.SimpleTxManager{c.TxManager}
+	for _, role := range []string{L1RoleSubmitter, L1RoleChallenger, L1RoleGuardian} {
+		if txMgr, ok := c.RoleTxManagers[role]; ok && txMgr != c.TxManager {
+			txMgrs = append(txMgrs, txMgr)
+		}
+	}
+	return txMgrs
+}
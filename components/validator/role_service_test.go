@@ -0,0 +1,312 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kroma-network/kroma/components/node/testlog"
+	"github.com/kroma-network/kroma/utils/service/txmgr"
+	"github.com/kroma-network/kroma/utils/service/txmgr/metrics"
+	"github.com/kroma-network/kroma/utils/service/txmgr/testutil"
+)
+
+// queueingComponent queues its candidates on start.
+type queueingComponent struct {
+	candidates []txmgr.TxCandidate
+	stopped    bool
+}
+
+func (c *queueingComponent) Start(_ context.Context, txCandidatesChan chan<- txmgr.TxCandidate) error {
+	for _, candidate := range c.candidates {
+		txCandidatesChan <- candidate
+	}
+	return nil
+}
+
+func (c *queueingComponent) Stop() error {
+	c.stopped = true
+	return nil
+}
+
+func TestRoleServices(t *testing.T) {
+	l := testlog.Logger(t, log.LvlCrit)
+	backend := testutil.NewBackend(big.NewInt(900), testutil.ConstantFees(big.NewInt(2), big.NewInt(10)))
+	backend.SetAutoMine(true)
+	submitterKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	guardianKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	submitterTxMgr := testutil.NewTxManager(l, backend, submitterKey)
+	guardianTxMgr := testutil.NewTxManager(l, backend, guardianKey)
+
+	to := common.Address{0xff}
+	submitter := &queueingComponent{candidates: []txmgr.TxCandidate{{To: &to, TxData: []byte{0x01}}, {To: &to, TxData: []byte{0x02}}}}
+	guardian := &queueingComponent{candidates: []txmgr.TxCandidate{{To: &to, TxData: []byte{0x03}}}}
+	submitterService := newRoleService(L1RoleSubmitter, l, submitterTxMgr, time.Minute, submitter)
+	guardianService := newRoleService(L1RoleGuardian, l, guardianTxMgr, time.Minute, guardian)
+
+	require.NoError(t, guardianService.Start())
+	require.NoError(t, submitterService.Start())
+	// the guardian is stopped on its own, and its queued confirmation is drained
+	require.NoError(t, guardianService.Stop())
+	require.True(t, guardian.stopped)
+	require.False(t, submitter.stopped)
+	require.NoError(t, submitterService.Stop())
+	require.True(t, submitter.stopped)
+
+	signer := types.LatestSignerForChainID(big.NewInt(900))
+	senders := make(map[byte]common.Address)
+	for _, tx := range backend.Sent() {
+		from, err := types.Sender(signer, tx)
+		require.NoError(t, err)
+		senders[tx.Data()[0]] = from
+	}
+	require.Equal(t, map[byte]common.Address{
+		0x01: submitterTxMgr.From(),
+		0x02: submitterTxMgr.From(),
+		0x03: guardianTxMgr.From(),
+	}, senders)
+}
+
+// observingComponent is a queueingComponent recording the outcome of its transactions.
+type observingComponent struct {
+	queueingComponent
+	mu   sync.Mutex
+	sent []txmgr.TxCandidate
+	errs []error
+}
+
+func (c *observingComponent) TxSent(candidate txmgr.TxCandidate, _ *types.Receipt, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.sent = append(c.sent, candidate)
+	c.errs = append(c.errs, err)
+}
+
+func (c *observingComponent) sentIDs() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ids := make([]string, 0, len(c.sent))
+	for _, candidate := range c.sent {
+		ids = append(ids, candidate.ID)
+	}
+	return ids
+}
+
+func TestRoleServiceNotifiesTxSent(t *testing.T) {
+	l := testlog.Logger(t, log.LvlCrit)
+	backend := testutil.NewBackend(big.NewInt(900), testutil.ConstantFees(big.NewInt(2), big.NewInt(10)))
+	backend.SetAutoMine(true)
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	to := common.Address{0xff}
+	refused := true
+	guardian := &observingComponent{queueingComponent: queueingComponent{candidates: []txmgr.TxCandidate{
+		{To: &to, TxData: []byte{0x01}, ID: "first"},
+		{To: &to, TxData: []byte{0x02}, ID: "second"},
+	}}}
+	service := newRoleService(L1RoleGuardian, l, testutil.NewTxManager(l, backend, key), time.Minute, guardian)
+	service.gate = func() error {
+		if refused {
+			refused = false
+			return errors.New("not compatible")
+		}
+		return nil
+	}
+	service.gateRetryInterval = time.Millisecond
+	require.NoError(t, service.Start())
+	require.NoError(t, service.Stop())
+
+	require.Len(t, guardian.sent, 2)
+	require.Equal(t, "first", guardian.sent[0].ID)
+	require.NoError(t, guardian.errs[0], "a refused transaction is held and sent once accepted")
+	require.Equal(t, "second", guardian.sent[1].ID)
+	require.NoError(t, guardian.errs[1])
+	require.Len(t, backend.Sent(), 2)
+}
+
+func TestRoleServiceSendsWhileAwaitingApproval(t *testing.T) {
+	l := testlog.Logger(t, log.LvlCrit)
+	backend := testutil.NewBackend(big.NewInt(900), testutil.ConstantFees(big.NewInt(2), big.NewInt(10)))
+	backend.SetAutoMine(true)
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	cfg := testutil.NewConfig(backend, key)
+	cfg.Approvals = txmgr.NewApprovalQueue(txmgr.ApprovalPolicy{MaxValue: big.NewInt(0)})
+
+	to := common.Address{0xff}
+	guardian := &observingComponent{queueingComponent: queueingComponent{candidates: []txmgr.TxCandidate{
+		{To: &to, TxData: []byte{0x01}, Value: big.NewInt(1), ID: "approved"},
+		{To: &to, TxData: []byte{0x02}, ID: "next"},
+	}}}
+	txMgr := txmgr.NewSimpleTxManagerFromConfig("test", l, &metrics.NoopTxMetrics{}, cfg)
+	service := newRoleService(L1RoleGuardian, l, txMgr, time.Minute, guardian)
+	require.NoError(t, service.Start())
+
+	require.Eventually(t, func() bool { return len(guardian.sentIDs()) == 1 }, 5*time.Second, 10*time.Millisecond)
+	require.Equal(t, []string{"next"}, guardian.sentIDs(), "the next transaction is sent while the other one waits for approval")
+	pending := cfg.Approvals.Pending()
+	require.Len(t, pending, 1)
+	require.NoError(t, cfg.Approvals.Approve(pending[0].ID))
+
+	require.NoError(t, service.Stop())
+	require.Equal(t, []string{"next", "approved"}, guardian.sentIDs())
+	require.Len(t, backend.Sent(), 2)
+}
+
+// turnComponent queues a transaction at the end of a turn in progress, released once the component is draining.
+type turnComponent struct {
+	tx       txmgr.TxCandidate
+	turns    workTracker
+	started  chan struct{}
+	release  chan struct{}
+	stopped  bool
+	drainErr error
+}
+
+func (c *turnComponent) Start(ctx context.Context, txCandidatesChan chan<- txmgr.TxCandidate) error {
+	c.turns.begin()
+	go func() {
+		defer c.turns.end()
+		close(c.started)
+		select {
+		case <-c.release:
+			txCandidatesChan <- c.tx
+		case <-ctx.Done():
+		}
+	}()
+	return nil
+}
+
+func (c *turnComponent) Drain(ctx context.Context) {
+	close(c.release)
+	c.turns.drain(ctx)
+	if !c.turns.begin() {
+		c.drainErr = errors.New("no turn is started while draining")
+	}
+}
+
+func (c *turnComponent) Stop() error {
+	c.stopped = true
+	return nil
+}
+
+func TestRoleServiceDrainsTurnInProgress(t *testing.T) {
+	l := testlog.Logger(t, log.LvlCrit)
+	backend := testutil.NewBackend(big.NewInt(900), testutil.ConstantFees(big.NewInt(2), big.NewInt(10)))
+	backend.SetAutoMine(true)
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	to := common.Address{0xff}
+	challenger := &turnComponent{
+		tx:      txmgr.TxCandidate{To: &to, TxData: []byte{0x01}},
+		started: make(chan struct{}),
+		release: make(chan struct{}),
+	}
+	service := newRoleService(L1RoleChallenger, l, testutil.NewTxManager(l, backend, key), time.Minute, challenger)
+	require.NoError(t, service.Start())
+	<-challenger.started
+
+	// the validator shuts down in the middle of the turn
+	require.NoError(t, service.Stop())
+	require.True(t, challenger.stopped)
+	require.EqualError(t, challenger.drainErr, "no turn is started while draining")
+	require.Len(t, backend.Sent(), 1, "the transaction of the turn in progress must be sent")
+	require.Equal(t, []byte{0x01}, backend.Sent()[0].Data())
+}
+
+func TestWorkTracker(t *testing.T) {
+	var w workTracker
+	require.True(t, w.begin())
+
+	drained := make(chan struct{})
+	go func() {
+		w.drain(context.Background())
+		close(drained)
+	}()
+	require.Eventually(t, func() bool { return !w.begin() }, time.Second, time.Millisecond, "no work is started while draining")
+	select {
+	case <-drained:
+		t.Fatal("drained with work in progress")
+	case <-time.After(10 * time.Millisecond):
+	}
+	w.end()
+	<-drained
+
+	// the drain gives up on the work in progress once the context is done
+	w = workTracker{}
+	require.True(t, w.begin())
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	w.drain(ctx)
+	require.ErrorIs(t, ctx.Err(), context.DeadlineExceeded)
+}
+
+func TestConfigForRole(t *testing.T) {
+	shared := &txmgr.SimpleTxManager{Config: txmgr.Config{From: common.Address{0x01}}}
+	guardian := &txmgr.SimpleTxManager{Config: txmgr.Config{From: common.Address{0x02}}, InFlight: txmgr.NewInFlightTxs("guardian", common.Address{0x02})}
+	shared.InFlight = txmgr.NewInFlightTxs("validator", shared.From())
+	cfg := Config{
+		TxManager:      shared,
+		RoleTxManagers: map[string]*txmgr.SimpleTxManager{L1RoleGuardian: guardian},
+	}
+
+	require.Equal(t, guardian, cfg.forRole(L1RoleGuardian).TxManager)
+	require.Equal(t, shared, cfg.forRole(L1RoleSubmitter).TxManager)
+	require.Equal(t, shared, cfg.TxManager, "expected the config not to be changed")
+	require.Equal(t, []*txmgr.InFlightTxs{shared.InFlight, guardian.InFlight}, cfg.inFlightTxs())
+
+	// the tx manager of the validator is not listed twice if it is the one of a role
+	cfg.TxManager = guardian
+	require.Equal(t, []*txmgr.InFlightTxs{guardian.InFlight}, cfg.inFlightTxs())
+}
+
+func TestCLIConfigCheckRolePrivateKeys(t *testing.T) {
+	valid, err := runWithProfile(t, "--l2oo-address", "0x01", "--colosseum-address", "0x02", "--valpool-address", "0x03")
+	require.NoError(t, err)
+	require.NoError(t, valid.Check())
+	for _, test := range []struct {
+		name string
+		cfg  func(c *CLIConfig)
+		err  string
+	}{
+		{"guardian only", func(c *CLIConfig) {
+			c.OutputSubmitterDisabled, c.ChallengerDisabled, c.GuardianEnabled = true, true, true
+			c.GuardianPrivateKey = "0xaa"
+		}, ""},
+		{"guardian disabled", func(c *CLIConfig) { c.GuardianPrivateKey = "0xaa" }, "guardian private key requires the guardian"},
+		{"challenger disabled", func(c *CLIConfig) {
+			c.ChallengerDisabled = true
+			c.ChallengerPrivateKey = "0xaa"
+		}, "challenger private key requires the challenger"},
+		{"output submitter with challenger", func(c *CLIConfig) { c.OutputSubmitterPrivateKey = "0xaa" }, "must share an account"},
+		{"challenger alone", func(c *CLIConfig) {
+			c.OutputSubmitterDisabled = true
+			c.ChallengerPrivateKey = "0xaa"
+		}, ""},
+	} {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			cfg := valid
+			test.cfg(&cfg)
+			err := cfg.Check()
+			if test.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, test.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/kroma-network/kroma/components/node/eth"
+)
+
+// ErrOutputQuorum is returned when not enough rollup nodes of a rollupQuorum agree on an output.
+var ErrOutputQuorum = errors.New("no output quorum")
+
+// rollupQuorum is a GuardianRollupClient that queries several independent rollup nodes, and only returns
+// an output that at least quorum of them agree on. It protects against a single compromised or buggy
+// rollup node tricking the guardian into confirming an invalid output.
+type rollupQuorum struct {
+	log     log.Logger
+	clients []GuardianRollupClient
+	quorum  int
+}
+
+var _ GuardianRollupClient = (*rollupQuorum)(nil)
+
+func newRollupQuorum(l log.Logger, clients []GuardianRollupClient, quorum int) *rollupQuorum {
+	return &rollupQuorum{
+		log:     l,
+		clients: clients,
+		quorum:  quorum,
+	}
+}
+
+type outputResult struct {
+	output *eth.OutputResponse
+	err    error
+}
+
+// outputKey identifies the output the rollup nodes agree on.
+type outputKey struct {
+	version    eth.Bytes32
+	outputRoot eth.Bytes32
+}
+
+// OutputAtBlock requests the output from all rollup nodes concurrently, and returns the output that
+// at least quorum of them agree on, as returned by the first of them.
+func (q *rollupQuorum) OutputAtBlock(ctx context.Context, blockNumber uint64) (*eth.OutputResponse, error) {
+	var wg sync.WaitGroup
+	results := make([]outputResult, len(q.clients))
+	for i, client := range q.clients {
+		wg.Add(1)
+		go func(i int, client GuardianRollupClient) {
+			defer wg.Done()
+			output, err := client.OutputAtBlock(ctx, blockNumber)
+			results[i] = outputResult{output: output, err: err}
+		}(i, client)
+	}
+	wg.Wait()
+
+	var (
+		keys   []outputKey
+		agreed = make(map[outputKey][]int)
+		failed int
+		errs   error
+	)
+	for i, result := range results {
+		if result.err != nil {
+			failed++
+			if errs == nil {
+				errs = fmt.Errorf("rollup node %d: %w", i, result.err)
+			}
+			continue
+		}
+		key := outputKey{version: result.output.Version, outputRoot: result.output.OutputRoot}
+		if _, ok := agreed[key]; !ok {
+			keys = append(keys, key)
+		}
+		agreed[key] = append(agreed[key], i)
+	}
+
+	var quorate []outputKey
+	for _, key := range keys {
+		if len(agreed[key]) >= q.quorum {
+			quorate = append(quorate, key)
+		}
+	}
+	if len(keys) > 1 {
+		outputs := make([]string, 0, len(keys))
+		for _, key := range keys {
+			outputs = append(outputs, fmt.Sprintf("%s:%v", key.outputRoot, agreed[key]))
+		}
+		q.log.Warn("rollup nodes disagree on output", "blockNumber", blockNumber, "quorum", q.quorum,
+			"outputs", outputs, "failed", failed)
+	}
+	switch {
+	case len(quorate) == 1:
+		return results[agreed[quorate[0]][0]].output, nil
+	case len(quorate) > 1:
+		return nil, fmt.Errorf("%w: %d conflicting outputs at block %d reached the quorum of %d", ErrOutputQuorum, len(quorate), blockNumber, q.quorum)
+	case failed > 0 && failed > len(q.clients)-q.quorum:
+		// the quorum cannot be reached because of the failed nodes, which is not a disagreement
+		return nil, fmt.Errorf("%d of %d rollup nodes failed, %d required: %w", failed, len(q.clients), q.quorum, errs)
+	default:
+		return nil, fmt.Errorf("%w: %d distinct outputs at block %d, none agreed on by %d of %d rollup nodes",
+			ErrOutputQuorum, len(keys), blockNumber, q.quorum, len(q.clients))
+	}
+}
+
+// SyncStatus requests the sync status from all rollup nodes concurrently. The safe and finalized heads are the
+// highest ones that at least quorum of the nodes have reached, the other fields are of the first node that responded.
+func (q *rollupQuorum) SyncStatus(ctx context.Context) (*eth.SyncStatus, error) {
+	var wg sync.WaitGroup
+	statuses := make([]*eth.SyncStatus, len(q.clients))
+	errs := make([]error, len(q.clients))
+	for i, client := range q.clients {
+		wg.Add(1)
+		go func(i int, client GuardianRollupClient) {
+			defer wg.Done()
+			statuses[i], errs[i] = client.SyncStatus(ctx)
+		}(i, client)
+	}
+	wg.Wait()
+
+	var (
+		status    eth.SyncStatus
+		safe      []eth.L2BlockRef
+		finalized []eth.L2BlockRef
+		firstErr  error
+	)
+	for i, s := range statuses {
+		if errs[i] != nil {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("rollup node %d: %w", i, errs[i])
+			}
+			continue
+		}
+		if len(safe) == 0 {
+			status = *s
+		}
+		safe = append(safe, s.SafeL2)
+		finalized = append(finalized, s.FinalizedL2)
+	}
+	if len(safe) < q.quorum {
+		return nil, fmt.Errorf("%d of %d rollup nodes failed, %d required: %w", len(q.clients)-len(safe), len(q.clients), q.quorum, firstErr)
+	}
+	status.SafeL2 = quorumHead(safe, q.quorum)
+	status.FinalizedL2 = quorumHead(finalized, q.quorum)
+	return &status, nil
+}
+
+// quorumHead returns the highest head that at least quorum of the heads have reached.
+func quorumHead(heads []eth.L2BlockRef, quorum int) eth.L2BlockRef {
+	sort.Slice(heads, func(i, j int) bool { return heads[i].Number > heads[j].Number })
+	return heads[quorum-1]
+}
@@ -0,0 +1,142 @@
+package validator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kroma-network/kroma/components/node/eth"
+	"github.com/kroma-network/kroma/components/node/testlog"
+)
+
+func TestRollupQuorumOutputAtBlock(t *testing.T) {
+	const blockNumber = 100
+	// failed is the output root of a rollup node whose calls fail
+	good, bad, failed := eth.Bytes32{0x01}, eth.Bytes32{0x02}, eth.Bytes32{}
+
+	tests := []struct {
+		name         string
+		roots        []eth.Bytes32
+		quorum       int
+		expectRoot   eth.Bytes32
+		expectErr    error
+		expectFailed bool
+	}{
+		{
+			name:       "all nodes agree",
+			roots:      []eth.Bytes32{good, good, good},
+			quorum:     3,
+			expectRoot: good,
+		},
+		{
+			name:       "bad node outvoted",
+			roots:      []eth.Bytes32{bad, good, good},
+			quorum:     2,
+			expectRoot: good,
+		},
+		{
+			name:      "bad node breaks unanimity",
+			roots:     []eth.Bytes32{good, good, bad},
+			quorum:    3,
+			expectErr: ErrOutputQuorum,
+		},
+		{
+			name:      "conflicting outputs reach quorum",
+			roots:     []eth.Bytes32{good, bad},
+			quorum:    1,
+			expectErr: ErrOutputQuorum,
+		},
+		{
+			name:       "failed node tolerated",
+			roots:      []eth.Bytes32{failed, good, good},
+			quorum:     2,
+			expectRoot: good,
+		},
+		{
+			name:         "too many failed nodes",
+			roots:        []eth.Bytes32{failed, failed, good},
+			quorum:       2,
+			expectErr:    errFakeRpc,
+			expectFailed: true,
+		},
+		{
+			name:      "failed and bad nodes",
+			roots:     []eth.Bytes32{failed, good, bad},
+			quorum:    2,
+			expectErr: ErrOutputQuorum,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			clients := make([]GuardianRollupClient, len(test.roots))
+			for i, root := range test.roots {
+				client := &fakeRollupClient{outputRoot: root, blockNumber: blockNumber}
+				if root == failed {
+					client.outputFailures = alwaysFail
+				}
+				clients[i] = client
+			}
+			q := newRollupQuorum(testlog.Logger(t, log.LvlCrit), clients, test.quorum)
+
+			output, err := q.OutputAtBlock(context.Background(), blockNumber)
+			if test.expectErr != nil {
+				require.ErrorIs(t, err, test.expectErr)
+				require.Nil(t, output)
+				if test.expectFailed {
+					require.NotErrorIs(t, err, ErrOutputQuorum)
+				}
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.expectRoot, output.OutputRoot)
+			for _, client := range clients {
+				outputCalls, _ := client.(*fakeRollupClient).calls()
+				require.Equal(t, 1, outputCalls)
+			}
+		})
+	}
+}
+
+func TestRollupQuorumSyncStatus(t *testing.T) {
+	clients := []GuardianRollupClient{
+		&fakeRollupClient{blockNumber: 100},
+		&fakeRollupClient{blockNumber: 90},
+		&fakeRollupClient{blockNumber: 110},
+		&fakeRollupClient{blockNumber: 120, syncFailures: alwaysFail},
+	}
+
+	q := newRollupQuorum(testlog.Logger(t, log.LvlCrit), clients, 2)
+	status, err := q.SyncStatus(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, uint64(100), status.SafeL2.Number)
+	require.Equal(t, uint64(100), status.FinalizedL2.Number)
+
+	q = newRollupQuorum(testlog.Logger(t, log.LvlCrit), clients, 3)
+	status, err = q.SyncStatus(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, uint64(90), status.SafeL2.Number)
+
+	q = newRollupQuorum(testlog.Logger(t, log.LvlCrit), clients, 4)
+	_, err = q.SyncStatus(context.Background())
+	require.ErrorIs(t, err, errFakeRpc)
+}
+
+func TestGuardianValidateL2OutputNoQuorum(t *testing.T) {
+	const l2BlockNumber = 100
+	localOutputRoot := eth.Bytes32{0xaa}
+
+	q := newRollupQuorum(testlog.Logger(t, log.LvlCrit), []GuardianRollupClient{
+		&fakeRollupClient{outputRoot: localOutputRoot, blockNumber: l2BlockNumber},
+		&fakeRollupClient{outputRoot: eth.Bytes32{0xbb}, blockNumber: l2BlockNumber},
+	}, 2)
+	g, _ := newTestGuardian(t, q, &fakeSecurityCouncil{})
+
+	result := g.ValidateL2Output(context.Background(), localOutputRoot, l2BlockNumber)
+	require.Equal(t, ValidationReasonNoQuorum, result.Reason)
+	require.ErrorIs(t, result.Err, ErrOutputQuorum)
+	require.False(t, result.IsValid())
+}
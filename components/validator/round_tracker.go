@@ -0,0 +1,170 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/kroma-network/kroma/bindings/bindings"
+)
+
+// The outcomes of the submission rounds of outputs for the validator.
+const (
+	// RoundOutcomeSubmitted is an output submitted by the validator, in its priority round or in a public round.
+	RoundOutcomeSubmitted = "submitted"
+	// RoundOutcomeMissed is an output submitted by another validator, while the validator was selected
+	// as the priority validator. The validator failed to submit the output in its priority round.
+	RoundOutcomeMissed = "missed"
+	// RoundOutcomeTaken is an output submitted by another validator, while the validator was not selected
+	// as the priority validator.
+	RoundOutcomeTaken = "taken"
+)
+
+type RoundMetrics interface {
+	RecordOutputRound(outcome string)
+}
+
+type OutputReader interface {
+	GetL2Output(opts *bind.CallOpts, _l2OutputIndex *big.Int) (bindings.TypesCheckpointOutput, error)
+}
+
+type TrustedValidatorReader interface {
+	TRUSTEDVALIDATOR(opts *bind.CallOpts) (common.Address, error)
+}
+
+type StorageReader interface {
+	StorageAt(ctx context.Context, account common.Address, key common.Hash, blockNumber *big.Int) ([]byte, error)
+}
+
+// roundTracker derives the outcomes of the submission rounds from the on-chain data of the submitted outputs,
+// to tell the rounds missed by the validator apart from the rounds legitimately taken by other validators.
+type roundTracker struct {
+	log  log.Logger
+	metr RoundMetrics
+
+	from                  common.Address
+	valPoolAddr           common.Address
+	valPool               TrustedValidatorReader
+	priorityValidatorSlot common.Hash
+	l2oo                  OutputReader
+	l1Client              StorageReader
+	networkTimeout        time.Duration
+
+	// trustedValidator is fetched on the first round without a priority validator, nil until then,
+	// so that the ValidatorPool is not required by the roles that do not track the rounds.
+	trustedValidator *common.Address
+	// missed is the number of priority rounds missed by the validator.
+	missed atomic.Uint64
+}
+
+func newRoundTracker(l log.Logger, m RoundMetrics, from common.Address, valPoolAddr common.Address, valPool TrustedValidatorReader,
+	l2oo OutputReader, l1Client StorageReader, networkTimeout time.Duration,
+) (*roundTracker, error) {
+	layout, err := bindings.GetStorageLayout("ValidatorPool")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get storage layout: %w", err)
+	}
+	var priorityValidatorSlot *common.Hash
+	for _, entry := range layout.Storage {
+		if entry.Label == "nextPriorityValidator" {
+			slot := common.BigToHash(big.NewInt(int64(entry.Slot)))
+			priorityValidatorSlot = &slot
+		}
+	}
+	if priorityValidatorSlot == nil {
+		return nil, errors.New("no nextPriorityValidator in storage layout of ValidatorPool")
+	}
+
+	return &roundTracker{
+		log:                   l,
+		metr:                  m,
+		from:                  from,
+		valPoolAddr:           valPoolAddr,
+		valPool:               valPool,
+		priorityValidatorSlot: *priorityValidatorSlot,
+		l2oo:                  l2oo,
+		l1Client:              l1Client,
+		networkTimeout:        networkTimeout,
+	}, nil
+}
+
+// track records the outcome of the submission round of the submitted output, and returns it.
+func (t *roundTracker) track(ctx context.Context, ev *bindings.L2OutputOracleOutputSubmitted) (string, error) {
+	blockNumber := new(big.Int).SetUint64(ev.Raw.BlockNumber)
+
+	cCtx, cCancel := context.WithTimeout(ctx, t.networkTimeout)
+	output, err := t.l2oo.GetL2Output(&bind.CallOpts{Context: cCtx, BlockNumber: blockNumber}, ev.L2OutputIndex)
+	cCancel()
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch output %s: %w", ev.L2OutputIndex, err)
+	}
+
+	// The priority validator of the round is read from the state before the block of the submission,
+	// because the submission selects the priority validator of a later round.
+	cCtx, cCancel = context.WithTimeout(ctx, t.networkTimeout)
+	value, err := t.l1Client.StorageAt(cCtx, t.valPoolAddr, t.priorityValidatorSlot, new(big.Int).Sub(blockNumber, common.Big1))
+	cCancel()
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch priority validator of output %s: %w", ev.L2OutputIndex, err)
+	}
+	priorityValidator := common.BytesToAddress(value)
+	// the trusted validator submits all the outputs until a priority validator is selected.
+	if priorityValidator == (common.Address{}) {
+		if priorityValidator, err = t.fetchTrustedValidator(ctx); err != nil {
+			return "", err
+		}
+	}
+
+	outcome := roundOutcome(t.from, priorityValidator, output.Submitter)
+	switch outcome {
+	case RoundOutcomeMissed:
+		t.missed.Add(1)
+		t.log.Error("missed the priority round of output", "outputIndex", ev.L2OutputIndex, "l2BlockNumber", ev.L2BlockNumber,
+			"submitter", output.Submitter, "l1BlockNumber", blockNumber)
+	case RoundOutcomeTaken:
+		t.log.Info("output submitted by another validator", "outputIndex", ev.L2OutputIndex, "l2BlockNumber", ev.L2BlockNumber,
+			"submitter", output.Submitter, "priorityValidator", priorityValidator)
+	default:
+		t.log.Info("output submitted by the validator", "outputIndex", ev.L2OutputIndex, "l2BlockNumber", ev.L2BlockNumber)
+	}
+	t.metr.RecordOutputRound(outcome)
+
+	return outcome, nil
+}
+
+func (t *roundTracker) fetchTrustedValidator(ctx context.Context) (common.Address, error) {
+	if t.trustedValidator != nil {
+		return *t.trustedValidator, nil
+	}
+	cCtx, cCancel := context.WithTimeout(ctx, t.networkTimeout)
+	defer cCancel()
+	trustedValidator, err := t.valPool.TRUSTEDVALIDATOR(&bind.CallOpts{Context: cCtx})
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to get trusted validator: %w", err)
+	}
+	t.trustedValidator = &trustedValidator
+	return trustedValidator, nil
+}
+
+// Missed returns the number of priority rounds missed by the validator since it started.
+func (t *roundTracker) Missed() uint64 {
+	return t.missed.Load()
+}
+
+// roundOutcome returns the outcome of a submission round for the validator.
+func roundOutcome(validator common.Address, priorityValidator common.Address, submitter common.Address) string {
+	if submitter == validator {
+		return RoundOutcomeSubmitted
+	}
+	if priorityValidator == validator {
+		return RoundOutcomeMissed
+	}
+	return RoundOutcomeTaken
+}
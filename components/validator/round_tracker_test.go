@@ -0,0 +1,118 @@
+package validator
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kroma-network/kroma/bindings/bindings"
+	"github.com/kroma-network/kroma/components/node/testlog"
+)
+
+// fakeRoundState serves the submitters of the outputs, and the priority validators by L1 block number.
+type fakeRoundState struct {
+	submitters         map[uint64]common.Address
+	priorityValidators map[uint64]common.Address
+}
+
+func (s *fakeRoundState) GetL2Output(_ *bind.CallOpts, index *big.Int) (bindings.TypesCheckpointOutput, error) {
+	return bindings.TypesCheckpointOutput{Submitter: s.submitters[index.Uint64()]}, nil
+}
+
+func (s *fakeRoundState) StorageAt(_ context.Context, _ common.Address, _ common.Hash, blockNumber *big.Int) ([]byte, error) {
+	return common.BytesToHash(s.priorityValidators[blockNumber.Uint64()].Bytes()).Bytes(), nil
+}
+
+// fakeTrustedValidator serves the trusted validator of the ValidatorPool, and counts the calls.
+type fakeTrustedValidator struct {
+	addr  common.Address
+	calls int
+}
+
+func (v *fakeTrustedValidator) TRUSTEDVALIDATOR(_ *bind.CallOpts) (common.Address, error) {
+	v.calls++
+	return v.addr, nil
+}
+
+type roundRecorder struct {
+	outcomes []string
+}
+
+func (r *roundRecorder) RecordOutputRound(outcome string) {
+	r.outcomes = append(r.outcomes, outcome)
+}
+
+func TestRoundTracker(t *testing.T) {
+	validator := common.Address{0xaa}
+	other := common.Address{0xbb}
+	trusted := common.Address{0xcc}
+
+	state := &fakeRoundState{
+		submitters: map[uint64]common.Address{
+			0: trusted,
+			1: validator,
+			2: other,
+			3: other,
+			4: validator,
+		},
+		// the priority validators before the blocks of the submissions.
+		priorityValidators: map[uint64]common.Address{
+			9:  {},
+			19: validator,
+			29: validator,
+			39: other,
+			49: other,
+		},
+	}
+	recorder := &roundRecorder{}
+	valPool := &fakeTrustedValidator{addr: trusted}
+	tracker, err := newRoundTracker(testlog.Logger(t, log.LvlCrit), recorder, validator, common.Address{0x01}, valPool,
+		state, state, time.Second)
+	require.NoError(t, err)
+	require.Zero(t, valPool.calls, "the trusted validator is fetched lazily")
+
+	for i := uint64(0); i < 5; i++ {
+		ev := &bindings.L2OutputOracleOutputSubmitted{
+			L2OutputIndex: new(big.Int).SetUint64(i),
+			L2BlockNumber: new(big.Int).SetUint64(i * 1800),
+			Raw:           types.Log{BlockNumber: (i + 1) * 10},
+		}
+		outcome, err := tracker.track(context.Background(), ev)
+		require.NoError(t, err)
+		require.Equal(t, recorder.outcomes[len(recorder.outcomes)-1], outcome)
+	}
+	require.Equal(t, 1, valPool.calls, "the trusted validator is fetched once")
+	require.Equal(t, uint64(1), tracker.Missed())
+
+	require.Equal(t, []string{
+		RoundOutcomeTaken,     // submitted by the trusted validator, as no priority validator was selected.
+		RoundOutcomeSubmitted, // submitted in the priority round of the validator.
+		RoundOutcomeMissed,    // submitted by another validator, after the priority round of the validator.
+		RoundOutcomeTaken,     // submitted in the priority round of another validator.
+		RoundOutcomeSubmitted, // submitted by the validator, after the priority round of another validator.
+	}, recorder.outcomes)
+}
+
+func TestRoundInfo(t *testing.T) {
+	from := common.Address{0xaa}
+
+	public := newRoundInfo(PublicRoundAddress, from)
+	require.True(t, public.isPublicRound)
+	require.True(t, public.canSubmit())
+
+	priority := newRoundInfo(from, from)
+	require.True(t, priority.isPriorityValidator)
+	require.True(t, priority.canSubmit())
+
+	other := newRoundInfo(common.Address{0xbb}, from)
+	require.False(t, other.isPublicRound)
+	require.False(t, other.isPriorityValidator)
+	require.False(t, other.canSubmit())
+}
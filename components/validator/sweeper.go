@@ -0,0 +1,267 @@
+package validator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/kroma-network/kroma/bindings/bindings"
+	"github.com/kroma-network/kroma/utils"
+	"github.com/kroma-network/kroma/utils/service/txmgr"
+)
+
+// The kinds of sweep transactions.
+const (
+	// SweepKindWithdrawal withdraws the deposit of the validator in the ValidatorPool, which holds the recovered bonds
+	// and rewards of won disputes, to the account of the validator.
+	SweepKindWithdrawal = "withdrawal"
+	// SweepKindTransfer transfers the balance of the account of the validator to the sweep address.
+	SweepKindTransfer = "transfer"
+)
+
+// sweepPendingTicks is the number of sweep intervals a sweep is assumed to be pending while the swept balance
+// did not change. The balance is swept again afterwards, in case the sweep transaction failed.
+const sweepPendingTicks = 3
+
+type SweepMetrics interface {
+	RecordSweep(kind string, amount *big.Int)
+}
+
+// SweepConfig configures the sweep of the recovered funds of the challenger to a cold address.
+type SweepConfig struct {
+	// Address is the cold address the funds are swept to. The sweep is disabled if it is the zero address.
+	Address common.Address
+	// Threshold is the minimum amount (in wei) to sweep, to not spend gas on sweeping dust.
+	Threshold *big.Int
+	// PoolReserve is the deposit (in wei) kept in the ValidatorPool to bond outputs.
+	PoolReserve *big.Int
+	// AccountReserve is the balance (in wei) kept in the account of the validator to pay for gas.
+	AccountReserve *big.Int
+	// Interval is how frequently the balances are checked for funds to sweep.
+	Interval time.Duration
+	// Journal records every sweep transaction. If nil, the sweeps are only logged. It is closed on the stop of the
+	// challenger if it is an io.Closer.
+	Journal SweepJournal
+}
+
+func (c SweepConfig) Enabled() bool {
+	return c.Address != (common.Address{})
+}
+
+// SweepJournalEntry is a sweep transaction queued to be sent by the validator.
+type SweepJournalEntry struct {
+	Time time.Time `json:"time"`
+	Kind string    `json:"kind"`
+	// From is the account of the validator.
+	From common.Address `json:"from"`
+	// To is the ValidatorPool for withdrawals and the sweep address for transfers.
+	To     common.Address `json:"to"`
+	Amount *hexutil.Big   `json:"amount"`
+	// Balance is the balance the amount is swept from, in the ValidatorPool or the account.
+	Balance *hexutil.Big `json:"balance"`
+}
+
+// SweepJournal records the sweep transactions, to account for the funds moved to the sweep address.
+type SweepJournal interface {
+	Record(entry SweepJournalEntry) error
+}
+
+// FileSweepJournal appends every entry as a JSON line to a file.
+type FileSweepJournal struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+func NewFileSweepJournal(path string) (*FileSweepJournal, error) {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o640)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open sweep journal file: %w", err)
+	}
+	return &FileSweepJournal{file: file}, nil
+}
+
+func (j *FileSweepJournal) Record(entry SweepJournalEntry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to encode sweep journal entry: %w", err)
+	}
+	line = append(line, '\n')
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if _, err := j.file.Write(line); err != nil {
+		return fmt.Errorf("failed to write sweep journal entry: %w", err)
+	}
+	if err := j.file.Sync(); err != nil {
+		return fmt.Errorf("failed to sync sweep journal file: %w", err)
+	}
+	return nil
+}
+
+func (j *FileSweepJournal) Close() error {
+	return j.file.Close()
+}
+
+// ReadSweepJournal reads all the entries of a sweep journal file.
+func ReadSweepJournal(path string) ([]SweepJournalEntry, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open sweep journal file: %w", err)
+	}
+	defer file.Close()
+
+	var entries []SweepJournalEntry
+	dec := json.NewDecoder(file)
+	for {
+		var entry SweepJournalEntry
+		if err := dec.Decode(&entry); errors.Is(err, io.EOF) {
+			return entries, nil
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to decode sweep journal entry %d: %w", len(entries), err)
+		}
+		entries = append(entries, entry)
+	}
+}
+
+type ValidatorPoolBalance interface {
+	BalanceOf(opts *bind.CallOpts, _addr common.Address) (*big.Int, error)
+}
+
+type AccountBalance interface {
+	BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error)
+}
+
+// pendingSweep is a queued sweep of a balance.
+type pendingSweep struct {
+	balance *big.Int
+	ticks   int
+}
+
+// sweeper sweeps the recovered funds of the challenger to the cold sweep address in two steps:
+// the deposit in the ValidatorPool exceeding the pool reserve is withdrawn to the account of the validator,
+// and the balance of the account exceeding the account reserve is transferred to the sweep address.
+type sweeper struct {
+	log  log.Logger
+	metr SweepMetrics
+	cfg  SweepConfig
+
+	from           common.Address
+	valPoolAddr    common.Address
+	valPool        ValidatorPoolBalance
+	valPoolABI     *abi.ABI
+	l1Client       AccountBalance
+	networkTimeout time.Duration
+
+	// pending are the queued sweeps by kind, so that a balance is not swept again until the sweep was sent.
+	pending map[string]*pendingSweep
+}
+
+func newSweeper(l log.Logger, m SweepMetrics, cfg SweepConfig, from common.Address, valPoolAddr common.Address,
+	valPool ValidatorPoolBalance, l1Client AccountBalance, networkTimeout time.Duration,
+) (*sweeper, error) {
+	valPoolABI, err := bindings.ValidatorPoolMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return &sweeper{
+		log:            l,
+		metr:           m,
+		cfg:            cfg,
+		from:           from,
+		valPoolAddr:    valPoolAddr,
+		valPool:        valPool,
+		valPoolABI:     valPoolABI,
+		l1Client:       l1Client,
+		networkTimeout: networkTimeout,
+		pending:        make(map[string]*pendingSweep),
+	}, nil
+}
+
+// sweep queues the sweep transactions of the balances exceeding their reserves by at least the threshold.
+func (s *sweeper) sweep(ctx context.Context, txCandidatesChan chan<- txmgr.TxCandidate) error {
+	cCtx, cCancel := context.WithTimeout(ctx, s.networkTimeout)
+	poolBalance, err := s.valPool.BalanceOf(utils.NewCallOptsWithSender(cCtx, s.from), s.from)
+	cCancel()
+	if err != nil {
+		return fmt.Errorf("failed to fetch validator deposit: %w", err)
+	}
+	err = s.maybeSweep(ctx, txCandidatesChan, SweepKindWithdrawal, poolBalance, s.cfg.PoolReserve, func(amount *big.Int) (txmgr.TxCandidate, error) {
+		data, err := s.valPoolABI.Pack("withdraw", amount)
+		if err != nil {
+			return txmgr.TxCandidate{}, fmt.Errorf("failed to create withdraw transaction data: %w", err)
+		}
+		return txmgr.TxCandidate{TxData: data, To: &s.valPoolAddr}, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	cCtx, cCancel = context.WithTimeout(ctx, s.networkTimeout)
+	accountBalance, err := s.l1Client.BalanceAt(cCtx, s.from, nil)
+	cCancel()
+	if err != nil {
+		return fmt.Errorf("failed to fetch validator balance: %w", err)
+	}
+	return s.maybeSweep(ctx, txCandidatesChan, SweepKindTransfer, accountBalance, s.cfg.AccountReserve, func(amount *big.Int) (txmgr.TxCandidate, error) {
+		return txmgr.TxCandidate{To: &s.cfg.Address, Value: amount}, nil
+	})
+}
+
+func (s *sweeper) maybeSweep(ctx context.Context, txCandidatesChan chan<- txmgr.TxCandidate, kind string, balance *big.Int, reserve *big.Int,
+	candidate func(amount *big.Int) (txmgr.TxCandidate, error),
+) error {
+	if p, ok := s.pending[kind]; ok {
+		if p.balance.Cmp(balance) == 0 && p.ticks < sweepPendingTicks {
+			p.ticks++
+			s.log.Debug("sweep is pending", "kind", kind, "balance", balance)
+			return nil
+		}
+		delete(s.pending, kind)
+	}
+
+	amount := new(big.Int).Sub(balance, reserve)
+	if amount.Cmp(s.cfg.Threshold) < 0 {
+		return nil
+	}
+	tx, err := candidate(amount)
+	if err != nil {
+		return err
+	}
+
+	if s.cfg.Journal != nil {
+		entry := SweepJournalEntry{
+			Time:    time.Now().UTC(),
+			Kind:    kind,
+			From:    s.from,
+			To:      *tx.To,
+			Amount:  (*hexutil.Big)(amount),
+			Balance: (*hexutil.Big)(balance),
+		}
+		// funds are not swept without an entry in the journal, to always be accounted for.
+		if err := s.cfg.Journal.Record(entry); err != nil {
+			return fmt.Errorf("failed to record %s of %s wei in sweep journal: %w", kind, amount, err)
+		}
+	}
+
+	select {
+	case txCandidatesChan <- tx:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	s.pending[kind] = &pendingSweep{balance: balance}
+	s.log.Info("queued sweep of recovered funds", "kind", kind, "amount", amount, "balance", balance, "to", *tx.To)
+	s.metr.RecordSweep(kind, amount)
+	return nil
+}
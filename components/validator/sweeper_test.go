@@ -0,0 +1,181 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kroma-network/kroma/bindings/bindings"
+	"github.com/kroma-network/kroma/components/node/testlog"
+	"github.com/kroma-network/kroma/components/validator/metrics"
+	"github.com/kroma-network/kroma/utils/service/txmgr"
+)
+
+type fakeBalances struct {
+	pool    *big.Int
+	account *big.Int
+}
+
+func (b *fakeBalances) BalanceOf(_ *bind.CallOpts, _ common.Address) (*big.Int, error) {
+	return b.pool, nil
+}
+
+func (b *fakeBalances) BalanceAt(_ context.Context, _ common.Address, _ *big.Int) (*big.Int, error) {
+	return b.account, nil
+}
+
+type memSweepJournal struct {
+	entries []SweepJournalEntry
+	err     error
+}
+
+func (j *memSweepJournal) Record(entry SweepJournalEntry) error {
+	if j.err != nil {
+		return j.err
+	}
+	j.entries = append(j.entries, entry)
+	return nil
+}
+
+func newTestSweeper(t *testing.T, balances *fakeBalances, journal *memSweepJournal) *sweeper {
+	cfg := SweepConfig{
+		Address:        common.Address{0xc0},
+		Threshold:      big.NewInt(10),
+		PoolReserve:    big.NewInt(100),
+		AccountReserve: big.NewInt(50),
+		Interval:       time.Minute,
+		Journal:        journal,
+	}
+	s, err := newSweeper(testlog.Logger(t, log.LvlCrit), metrics.NoopMetrics, cfg, common.Address{0xaa}, common.Address{0xbb},
+		balances, balances, time.Second)
+	require.NoError(t, err)
+	return s
+}
+
+func TestSweeper(t *testing.T) {
+	balances := &fakeBalances{pool: big.NewInt(105), account: big.NewInt(55)}
+	journal := &memSweepJournal{}
+	s := newTestSweeper(t, balances, journal)
+	txs := make(chan txmgr.TxCandidate, 10)
+
+	// the excesses are below the threshold.
+	require.NoError(t, s.sweep(context.Background(), txs))
+	require.Empty(t, txs)
+
+	balances.pool = big.NewInt(130)
+	require.NoError(t, s.sweep(context.Background(), txs))
+	require.Len(t, txs, 1)
+	withdrawal := <-txs
+	require.Equal(t, common.Address{0xbb}, *withdrawal.To)
+	withdrawData, err := s.valPoolABI.Pack("withdraw", big.NewInt(30))
+	require.NoError(t, err)
+	require.Equal(t, withdrawData, withdrawal.TxData)
+
+	// the withdrawal is pending while the deposit did not change.
+	require.NoError(t, s.sweep(context.Background(), txs))
+	require.Empty(t, txs)
+
+	balances.pool = big.NewInt(100)
+	balances.account = big.NewInt(110)
+	require.NoError(t, s.sweep(context.Background(), txs))
+	require.Len(t, txs, 1)
+	transfer := <-txs
+	require.Equal(t, common.Address{0xc0}, *transfer.To)
+	require.Equal(t, big.NewInt(60), transfer.Value)
+
+	require.Len(t, journal.entries, 2)
+	require.Equal(t, SweepKindWithdrawal, journal.entries[0].Kind)
+	require.Equal(t, big.NewInt(30), journal.entries[0].Amount.ToInt())
+	require.Equal(t, SweepKindTransfer, journal.entries[1].Kind)
+	require.Equal(t, common.Address{0xc0}, journal.entries[1].To)
+	require.Equal(t, big.NewInt(60), journal.entries[1].Amount.ToInt())
+}
+
+func TestSweeperRetriesPendingSweep(t *testing.T) {
+	balances := &fakeBalances{pool: big.NewInt(130), account: big.NewInt(0)}
+	s := newTestSweeper(t, balances, &memSweepJournal{})
+	txs := make(chan txmgr.TxCandidate, 10)
+
+	for i := 0; i <= sweepPendingTicks; i++ {
+		require.NoError(t, s.sweep(context.Background(), txs))
+	}
+	require.Len(t, txs, 1)
+	// the withdrawal did not change the deposit in time, so it is assumed to have failed.
+	require.NoError(t, s.sweep(context.Background(), txs))
+	require.Len(t, txs, 2)
+}
+
+func TestSweeperJournalFailure(t *testing.T) {
+	balances := &fakeBalances{pool: big.NewInt(130), account: big.NewInt(0)}
+	s := newTestSweeper(t, balances, &memSweepJournal{err: errors.New("disk full")})
+	txs := make(chan txmgr.TxCandidate, 10)
+
+	require.Error(t, s.sweep(context.Background(), txs))
+	require.Empty(t, txs, "funds must not be swept without a journal entry")
+}
+
+func TestCLIConfigCheckSweep(t *testing.T) {
+	valid, err := runWithProfile(t, "--l2oo-address", "0x01", "--colosseum-address", "0x02", "--valpool-address", "0x03",
+		"--challenger.sweep-address", "0xc0", "--challenger.sweep-pool-reserve", "100000000000000000000")
+	require.NoError(t, err)
+	require.NoError(t, valid.Check())
+	for _, test := range []struct {
+		name string
+		cfg  func(c *CLIConfig)
+		err  string
+	}{
+		{"above uint64", func(c *CLIConfig) { c.SweepThreshold = "18446744073709551616" }, ""},
+		{"zero threshold", func(c *CLIConfig) { c.SweepThreshold = "0" }, "sweep threshold must be positive"},
+		{"invalid threshold", func(c *CLIConfig) { c.SweepThreshold = "1e18" }, "sweep threshold is not a valid wei amount"},
+		{"no pool reserve", func(c *CLIConfig) { c.SweepPoolReserve = "" }, "sweep pool reserve must be positive"},
+		{"zero pool reserve", func(c *CLIConfig) {
+			c.OutputSubmitterDisabled = true
+			c.SweepPoolReserve = "0"
+		}, "sweep pool reserve must be positive"},
+		{"zero account reserve", func(c *CLIConfig) { c.SweepAccountReserve = "0" }, "sweep account reserve must be positive"},
+		{"negative account reserve", func(c *CLIConfig) { c.SweepAccountReserve = "-1" }, "sweep account reserve is not a valid wei amount"},
+		{"no sweep", func(c *CLIConfig) {
+			c.SweepAddress = ""
+			c.SweepAccountReserve = "0"
+		}, ""},
+	} {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			cfg := valid
+			test.cfg(&cfg)
+			err := cfg.Check()
+			if test.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, test.err)
+			}
+		})
+	}
+}
+
+func TestChallengerClosesSweepJournal(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sweeps.jsonl")
+	journal, err := NewFileSweepJournal(path)
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	c := &Challenger{
+		log:                        testlog.Logger(t, log.LvlCrit),
+		cfg:                        Config{Sweep: SweepConfig{Journal: journal}},
+		ctx:                        ctx,
+		cancel:                     cancel,
+		l2OutputSubmittedEventChan: make(chan *bindings.L2OutputOracleOutputSubmitted),
+		challengeCreatedEventChan:  make(chan *bindings.ColosseumChallengeCreated),
+	}
+
+	require.NoError(t, c.Stop())
+	require.ErrorIs(t, journal.Record(SweepJournalEntry{Kind: SweepKindTransfer}), os.ErrClosed)
+}
@@ -0,0 +1,165 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/kroma-network/kroma/bindings/bindings"
+	"github.com/kroma-network/kroma/utils"
+	"github.com/kroma-network/kroma/utils/service/txmgr"
+)
+
+// unbondPendingTicks is the number of checks the queued unbonds are assumed to be pending while the next unbond output
+// index did not change. The bonds are unbonded again afterwards, in case the unbond transactions failed.
+const unbondPendingTicks = 3
+
+// unbondMaxPerCheck is the maximum number of unbonds queued at once, since every unbond only releases a single bond.
+const unbondMaxPerCheck = 10
+
+type UnbondMetrics interface {
+	RecordUnbonds(count int)
+}
+
+type ValidatorPoolBonds interface {
+	GetBond(opts *bind.CallOpts, _outputIndex *big.Int) (bindings.TypesBond, error)
+}
+
+// NextOutputIndexReader reads the next output index of the L2OutputOracle, the bound of the outputs with a bond.
+type NextOutputIndexReader interface {
+	NextOutputIndex(opts *bind.CallOpts) (*big.Int, error)
+}
+
+// UnbondL1Client reads the next unbond output index of the ValidatorPool, and the latest L1 block the bonds expire by.
+type UnbondL1Client interface {
+	StorageReader
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+}
+
+// unbonder releases the bonds of the finalized outputs, which are otherwise only released when the next output is
+// submitted. Unbonding releases the bond to the submitter of the output, selects the next priority validator, and
+// sends the reward of the output to L2.
+type unbonder struct {
+	log  log.Logger
+	metr UnbondMetrics
+
+	valPoolAddr        common.Address
+	valPool            ValidatorPoolBonds
+	l2oo               NextOutputIndexReader
+	valPoolABI         *abi.ABI
+	nextUnbondIndexKey common.Hash
+	l1Client           UnbondL1Client
+	networkTimeout     time.Duration
+
+	// pending is the next unbond output index the unbonds were queued at, so that the bonds are not unbonded again
+	// until the unbonds were sent, and pendingTicks the number of checks since.
+	pending      *big.Int
+	pendingTicks int
+}
+
+func newUnbonder(l log.Logger, m UnbondMetrics, valPoolAddr common.Address, valPool ValidatorPoolBonds,
+	l2oo NextOutputIndexReader, l1Client UnbondL1Client, networkTimeout time.Duration,
+) (*unbonder, error) {
+	valPoolABI, err := bindings.ValidatorPoolMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	layout, err := bindings.GetStorageLayout("ValidatorPool")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get storage layout: %w", err)
+	}
+	var nextUnbondIndexKey *common.Hash
+	for _, entry := range layout.Storage {
+		if entry.Label == "nextUnbondOutputIndex" {
+			slot := common.BigToHash(big.NewInt(int64(entry.Slot)))
+			nextUnbondIndexKey = &slot
+		}
+	}
+	if nextUnbondIndexKey == nil {
+		return nil, errors.New("no nextUnbondOutputIndex in storage layout of ValidatorPool")
+	}
+
+	return &unbonder{
+		log:                l,
+		metr:               m,
+		valPoolAddr:        valPoolAddr,
+		valPool:            valPool,
+		l2oo:               l2oo,
+		valPoolABI:         valPoolABI,
+		nextUnbondIndexKey: *nextUnbondIndexKey,
+		l1Client:           l1Client,
+		networkTimeout:     networkTimeout,
+	}, nil
+}
+
+// check queues an unbond for every expired bond in a row from the next unbond output index, at most
+// unbondMaxPerCheck. The bonds are only read up to the latest output, as the ValidatorPool reverts on the bonds
+// that do not exist.
+func (u *unbonder) check(ctx context.Context, txCandidatesChan chan<- txmgr.TxCandidate) error {
+	cCtx, cCancel := context.WithTimeout(ctx, u.networkTimeout)
+	defer cCancel()
+	value, err := u.l1Client.StorageAt(cCtx, u.valPoolAddr, u.nextUnbondIndexKey, nil)
+	if err != nil {
+		return fmt.Errorf("failed to fetch next unbond output index: %w", err)
+	}
+	nextIndex := new(big.Int).SetBytes(value)
+
+	if u.pending != nil {
+		if u.pending.Cmp(nextIndex) == 0 && u.pendingTicks < unbondPendingTicks {
+			u.pendingTicks++
+			u.log.Debug("unbonds are pending", "nextUnbondOutputIndex", nextIndex)
+			return nil
+		}
+		u.pending = nil
+	}
+
+	head, err := u.l1Client.HeaderByNumber(cCtx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to fetch latest L1 block header: %w", err)
+	}
+	nextOutputIndex, err := u.l2oo.NextOutputIndex(utils.NewSimpleCallOpts(cCtx))
+	if err != nil {
+		return fmt.Errorf("failed to fetch next output index: %w", err)
+	}
+	var expired int
+	for ; expired < unbondMaxPerCheck; expired++ {
+		outputIndex := new(big.Int).Add(nextIndex, big.NewInt(int64(expired)))
+		if outputIndex.Cmp(nextOutputIndex) >= 0 {
+			break
+		}
+		bond, err := u.valPool.GetBond(utils.NewSimpleCallOpts(cCtx), outputIndex)
+		if err != nil {
+			return fmt.Errorf("failed to fetch bond of output %d: %w", outputIndex, err)
+		}
+		if bond.ExpiresAt.Cmp(new(big.Int).SetUint64(head.Time)) > 0 {
+			break
+		}
+	}
+	if expired == 0 {
+		return nil
+	}
+
+	data, err := u.valPoolABI.Pack("unbond")
+	if err != nil {
+		return fmt.Errorf("failed to create unbond transaction data: %w", err)
+	}
+	for i := 0; i < expired; i++ {
+		select {
+		case txCandidatesChan <- txmgr.TxCandidate{TxData: data, To: &u.valPoolAddr}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	u.pending, u.pendingTicks = nextIndex, 0
+	u.metr.RecordUnbonds(expired)
+	u.log.Info("queued unbonds of finalized outputs", "nextUnbondOutputIndex", nextIndex, "count", expired)
+	return nil
+}
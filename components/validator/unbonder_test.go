@@ -0,0 +1,134 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kroma-network/kroma/bindings/bindings"
+	"github.com/kroma-network/kroma/components/node/testlog"
+	"github.com/kroma-network/kroma/components/validator/metrics"
+	"github.com/kroma-network/kroma/utils/service/txmgr"
+)
+
+// fakeBonds serves the bonds by output index, the next unbond output index, the next output index and the time of
+// the latest L1 block.
+type fakeBonds struct {
+	expiresAt       map[uint64]uint64
+	nextUnbondIndex uint64
+	nextOutputIndex uint64
+	time            uint64
+}
+
+func (f *fakeBonds) GetBond(_ *bind.CallOpts, outputIndex *big.Int) (bindings.TypesBond, error) {
+	expiresAt, ok := f.expiresAt[outputIndex.Uint64()]
+	if !ok {
+		return bindings.TypesBond{}, errors.New("execution reverted: ValidatorPool: the bond does not exist")
+	}
+	return bindings.TypesBond{Amount: big.NewInt(100), ExpiresAt: new(big.Int).SetUint64(expiresAt)}, nil
+}
+
+func (f *fakeBonds) NextOutputIndex(_ *bind.CallOpts) (*big.Int, error) {
+	return new(big.Int).SetUint64(f.nextOutputIndex), nil
+}
+
+func (f *fakeBonds) StorageAt(_ context.Context, _ common.Address, _ common.Hash, _ *big.Int) ([]byte, error) {
+	return common.BigToHash(new(big.Int).SetUint64(f.nextUnbondIndex)).Bytes(), nil
+}
+
+func (f *fakeBonds) HeaderByNumber(_ context.Context, _ *big.Int) (*types.Header, error) {
+	return &types.Header{Time: f.time}, nil
+}
+
+// unbond releases the next bond, as the unbond of the ValidatorPool.
+func (f *fakeBonds) unbond() {
+	delete(f.expiresAt, f.nextUnbondIndex)
+	f.nextUnbondIndex++
+}
+
+func newTestUnbonder(t *testing.T, bonds *fakeBonds) *unbonder {
+	u, err := newUnbonder(testlog.Logger(t, log.LvlCrit), metrics.NoopMetrics, common.Address{0xbb}, bonds, bonds, bonds, time.Second)
+	require.NoError(t, err)
+	return u
+}
+
+func TestUnbonder(t *testing.T) {
+	bonds := &fakeBonds{
+		expiresAt:       map[uint64]uint64{3: 100, 4: 110, 5: 200},
+		nextUnbondIndex: 3,
+		nextOutputIndex: 6,
+		time:            50,
+	}
+	u := newTestUnbonder(t, bonds)
+	txs := make(chan txmgr.TxCandidate, unbondMaxPerCheck)
+
+	// no bond is expired yet.
+	require.NoError(t, u.check(context.Background(), txs))
+	require.Empty(t, txs)
+
+	// the expired bonds are unbonded in order.
+	bonds.time = 150
+	require.NoError(t, u.check(context.Background(), txs))
+	require.Len(t, txs, 2)
+	unbond := <-txs
+	require.Equal(t, common.Address{0xbb}, *unbond.To)
+	unbondData, err := u.valPoolABI.Pack("unbond")
+	require.NoError(t, err)
+	require.Equal(t, unbondData, unbond.TxData)
+	<-txs
+
+	// the unbonds are pending while the next unbond output index did not change.
+	require.NoError(t, u.check(context.Background(), txs))
+	require.Empty(t, txs)
+
+	bonds.unbond()
+	bonds.unbond()
+	require.NoError(t, u.check(context.Background(), txs))
+	require.Empty(t, txs, "expected the unexpired bond not to be unbonded")
+
+	bonds.time = 200
+	require.NoError(t, u.check(context.Background(), txs))
+	require.Len(t, txs, 1)
+	<-txs
+
+	// the bonds of every output are released.
+	bonds.unbond()
+	for i := 0; i <= unbondPendingTicks; i++ {
+		require.NoError(t, u.check(context.Background(), txs))
+	}
+	require.Empty(t, txs)
+}
+
+func TestUnbonderRetriesPendingUnbonds(t *testing.T) {
+	bonds := &fakeBonds{expiresAt: map[uint64]uint64{0: 100}, nextOutputIndex: 1, time: 100}
+	u := newTestUnbonder(t, bonds)
+	txs := make(chan txmgr.TxCandidate, unbondMaxPerCheck)
+
+	for i := 0; i <= unbondPendingTicks; i++ {
+		require.NoError(t, u.check(context.Background(), txs))
+	}
+	require.Len(t, txs, 1)
+	// the unbond did not change the next unbond output index in time, so it is assumed to have failed.
+	require.NoError(t, u.check(context.Background(), txs))
+	require.Len(t, txs, 2)
+}
+
+func TestUnbonderCapsUnbondsPerCheck(t *testing.T) {
+	bonds := &fakeBonds{expiresAt: make(map[uint64]uint64), nextOutputIndex: 2 * unbondMaxPerCheck, time: 100}
+	for i := uint64(0); i < 2*unbondMaxPerCheck; i++ {
+		bonds.expiresAt[i] = 100
+	}
+	u := newTestUnbonder(t, bonds)
+	txs := make(chan txmgr.TxCandidate, 2*unbondMaxPerCheck)
+
+	require.NoError(t, u.check(context.Background(), txs))
+	require.Len(t, txs, unbondMaxPerCheck)
+}
@@ -5,14 +5,17 @@ import (
 	"fmt"
 	"sync"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/urfave/cli"
 
+	"github.com/kroma-network/kroma/bindings/bindings"
 	"github.com/kroma-network/kroma/components/validator/metrics"
 	"github.com/kroma-network/kroma/utils"
 	"github.com/kroma-network/kroma/utils/monitoring"
 	klog "github.com/kroma-network/kroma/utils/service/log"
 	krpc "github.com/kroma-network/kroma/utils/service/rpc"
+	ktracing "github.com/kroma-network/kroma/utils/service/tracing"
 	"github.com/kroma-network/kroma/utils/service/txmgr"
 )
 
@@ -27,19 +30,42 @@ func Main(version string, cliCtx *cli.Context) error {
 	l := klog.NewLogger(cliCfg.LogConfig)
 	m := metrics.NewMetrics("default")
 	l.Info("initializing Validator")
+	if cliCfg.Profile != "" {
+		l.Info("using configuration profile", "profile", cliCfg.Profile)
+	}
 
 	validatorCfg, err := NewValidatorConfig(cliCfg, l, m)
 	if err != nil {
 		l.Error("Unable to create validator config", "err", err)
 		return err
 	}
+	validatorCfg.Heartbeat.Version = version
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	monitoring.MaybeStartPprof(ctx, cliCfg.PprofConfig, l)
+	if err := monitoring.MaybeStartPprof(ctx, cliCfg.PprofConfig, l); err != nil {
+		return err
+	}
+	tracerProvider, stopTracing, err := ktracing.NewTracerProvider(ctx, cliCfg.TracingConfig, "kroma-validator", version)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := stopTracing(context.Background()); err != nil {
+			l.Error("Error shutting down tracing", "err", err)
+		}
+	}()
+	validatorCfg.TracerProvider = tracerProvider
 	monitoring.MaybeStartMetrics(ctx, cliCfg.MetricsConfig, l, m, validatorCfg.L1Client, validatorCfg.TxManager.From())
-	server, err := monitoring.StartRPC(cliCfg.RPCConfig, version, krpc.WithLogger(l))
+	validator, err := NewValidator(ctx, *validatorCfg, l, m)
+	if err != nil {
+		return err
+	}
+
+	server, err := monitoring.StartRPC(cliCfg.RPCConfig, version, krpc.WithLogger(l),
+		krpc.WithAPIs(append(append(txmgr.ApprovalAPIs(validatorCfg.TxManager.Approvals, cliCfg.EnableAdmin), txmgr.PendingAPIs(cliCfg.EnableAdmin, validatorCfg.inFlightTxs()...)...),
+			GuardianAPIs(validator.guardian, cliCfg.EnableAdmin)...)))
 	if err != nil {
 		return err
 	}
@@ -49,19 +75,30 @@ func Main(version string, cliCtx *cli.Context) error {
 		}
 	}()
 
+	if cliCfg.HealthEnabled {
+		l.Info("starting health server", "addr", cliCfg.HealthAddr, "port", cliCfg.HealthPort)
+		go func() {
+			if err := serveHealth(ctx, cliCfg.HealthAddr, cliCfg.HealthPort, version, validator); err != nil {
+				l.Error("failed to start health server", "err", err)
+			}
+		}()
+	}
+
 	m.RecordInfo(version)
 	m.RecordUp()
 
-	validator, err := NewValidator(ctx, *validatorCfg, l, m)
-	if err != nil {
-		return err
-	}
-
 	if err := validator.Start(); err != nil {
 		l.Error("failed to start validator", "err", err)
 		return err
 	}
+	if _, err := utils.SdNotify(utils.SdNotifyReady); err != nil {
+		l.Warn("failed to notify readiness to service manager", "err", err)
+	}
+
 	<-utils.WaitInterrupt()
+	if _, err := utils.SdNotify(utils.SdNotifyStopping); err != nil {
+		l.Warn("failed to notify stopping to service manager", "err", err)
+	}
 	if err := validator.Stop(); err != nil {
 		l.Error("failed to stop validator", "err", err)
 		return err
@@ -80,8 +117,13 @@ type Validator struct {
 	l2os       *L2OutputSubmitter
 	challenger *Challenger
 	guardian   *Guardian
-
-	txCandidatesChan chan txmgr.TxCandidate
+	heartbeat  *heartbeater
+	// compat checks the compatibility of the rollup node, optional (may be nil)
+	compat *compatibilityMonitor
+	// recoveries are the recovery audits run on start, one per account of the roles, none if it is disabled.
+	recoveries []*recoveryAudit
+	// services are the services of the enabled roles, each sending the transactions of its role.
+	services []*roleService
 
 	wg sync.WaitGroup
 }
@@ -92,21 +134,82 @@ func NewValidator(ctx context.Context, cfg Config, l log.Logger, m metrics.Metri
 		return nil, err
 	}
 
-	l2OutputSubmitter, err := NewL2OutputSubmitter(ctx, cfg, l, m)
+	submitterCfg := cfg.forRole(L1RoleSubmitter)
+	l2OutputSubmitter, err := NewL2OutputSubmitter(ctx, submitterCfg, l, m)
 	if err != nil {
 		return nil, err
 	}
 
-	challenger, err := NewChallenger(ctx, cfg, l)
+	// the challenger also defends the outputs of the output submitter, with its account if the challenger is disabled
+	challengerCfg := cfg.forRole(L1RoleChallenger)
+	if cfg.ChallengerDisabled {
+		challengerCfg = submitterCfg
+	}
+	challenger, err := NewChallenger(ctx, challengerCfg, l, m)
 	if err != nil {
 		return nil, err
 	}
 
-	guardian, err := NewGuardian(cfg, l)
+	guardianCfg := cfg.forRole(L1RoleGuardian)
+	guardian, err := NewGuardian(guardianCfg, l, m)
 	if err != nil {
 		return nil, err
 	}
 
+	var services []*roleService
+	if !cfg.OutputSubmitterDisabled {
+		components := []roleComponent{l2OutputSubmitter}
+		if cfg.ChallengerDisabled {
+			components = append(components, challenger)
+		}
+		services = append(services, newRoleService(L1RoleSubmitter, l, submitterCfg.TxManager, cfg.ShutdownDrainTimeout, components...))
+	}
+	if !cfg.ChallengerDisabled {
+		services = append(services, newRoleService(L1RoleChallenger, l, challengerCfg.TxManager, cfg.ShutdownDrainTimeout, challenger))
+	}
+	if cfg.GuardianEnabled {
+		services = append(services, newRoleService(L1RoleGuardian, l, guardianCfg.TxManager, cfg.ShutdownDrainTimeout, guardian))
+	}
+
+	var compat *compatibilityMonitor
+	if cfg.RollupCompatCheckInterval > 0 {
+		compat = newCompatibilityMonitor(l, m, cfg.RollupClient, cfg.RollupConfig, cfg.RollupCompatCheckInterval, cfg.NetworkTimeout)
+		for _, s := range services {
+			s.gate = compat.Err
+		}
+	}
+
+	var heartbeat *heartbeater
+	if cfg.Heartbeat.Enabled() {
+		heartbeat = newHeartbeater(l, cfg.Heartbeat, cfg.TxManager.From(), cfg.roles(), cfg.RollupClient, cfg.NetworkTimeout)
+	}
+
+	var recoveries []*recoveryAudit
+	if cfg.RecoveryAuditWindow > 0 {
+		l1Client := cfg.L1Limiter.Client(L1RoleTxMgr, cfg.L1Client)
+		l2ooContract, err := bindings.NewL2OutputOracleCaller(cfg.L2OutputOracleAddr, l1Client)
+		if err != nil {
+			return nil, err
+		}
+		// every account is audited once, with the decisions of the guardian if the guardian sends from it
+		var accounts []common.Address
+		audited := make(map[common.Address]*Guardian)
+		for _, s := range services {
+			from := s.txMgr.From()
+			if _, ok := audited[from]; !ok {
+				accounts = append(accounts, from)
+				audited[from] = nil
+			}
+			if s.role == L1RoleGuardian {
+				audited[from] = guardian
+			}
+		}
+		for _, from := range accounts {
+			recoveries = append(recoveries, newRecoveryAudit(l, m, from, l1Client, l2ooContract, cfg.NetworkTimeout,
+				cfg.RecoveryAuditWindow, audited[from]))
+		}
+	}
+
 	return &Validator{
 		cfg:        cfg,
 		l:          l,
@@ -114,90 +217,76 @@ func NewValidator(ctx context.Context, cfg Config, l log.Logger, m metrics.Metri
 		l2os:       l2OutputSubmitter,
 		challenger: challenger,
 		guardian:   guardian,
+		heartbeat:  heartbeat,
+		compat:     compat,
+		recoveries: recoveries,
+		services:   services,
 	}, nil
 }
 
 func (v *Validator) Start() error {
 	v.ctx, v.cancel = context.WithCancel(context.Background())
-	v.l.Info("starting Validator")
+	v.l.Info("starting Validator", "roles", v.cfg.roles())
 
-	v.txCandidatesChan = make(chan txmgr.TxCandidate, 10)
-
-	if !v.cfg.OutputSubmitterDisabled {
-		if err := v.l2os.Start(v.ctx, v.txCandidatesChan); err != nil {
-			return fmt.Errorf("cannot start l2 output submitter: %w", err)
+	// refuse to run with an incompatible rollup node, rather than submitting or confirming outputs computed by rules
+	// the validator does not know of
+	if v.compat != nil {
+		if err := v.compat.Start(v.ctx, &v.wg); err != nil {
+			return err
 		}
 	}
 
-	if err := v.challenger.Start(v.ctx, v.txCandidatesChan); err != nil {
-		return fmt.Errorf("cannot start challenger: %w", err)
+	for _, s := range v.services {
+		if err := s.Start(); err != nil {
+			return err
+		}
 	}
 
-	if v.cfg.GuardianEnabled {
-		if err := v.guardian.Start(v.ctx, v.txCandidatesChan); err != nil {
-			return fmt.Errorf("cannot start guardian: %w", err)
-		}
+	if v.heartbeat != nil {
+		v.heartbeat.Start(v.ctx)
 	}
 
-	v.wg.Add(1)
-	go v.loop()
+	// the audits run once the guardian is started, as its corrective actions revalidate requests
+	for _, recovery := range v.recoveries {
+		recovery := recovery
+		v.wg.Add(1)
+		go func() {
+			defer v.wg.Done()
+			recovery.run(v.ctx)
+		}()
+	}
 
 	return nil
 }
 
 func (v *Validator) Stop() error {
 	v.l.Info("stopping Validator")
-	if v.cfg.ProofFetcher != nil {
-		if err := v.cfg.ProofFetcher.Close(); err != nil {
-			return fmt.Errorf("cannot close gRPC connection: %w", err)
+	for _, s := range v.services {
+		if err := s.Stop(); err != nil {
+			return err
 		}
 	}
 
-	if !v.cfg.OutputSubmitterDisabled {
-		if err := v.l2os.Stop(); err != nil {
-			return fmt.Errorf("failed to stop l2 output submitter: %w", err)
+	// the broadcast hooks of the tx managers are closed once no transaction is sent anymore
+	for _, txMgr := range v.cfg.txManagers() {
+		if err := txMgr.Close(); err != nil {
+			return fmt.Errorf("failed to close tx manager: %w", err)
 		}
 	}
 
-	if err := v.challenger.Stop(); err != nil {
-		return fmt.Errorf("failed to stop challenger: %w", err)
+	// the prover is closed once the challenge turns in progress are drained
+	if v.cfg.ProofFetcher != nil {
+		if err := v.cfg.ProofFetcher.Close(); err != nil {
+			return fmt.Errorf("cannot close gRPC connection: %w", err)
+		}
 	}
 
-	if v.cfg.GuardianEnabled {
-		if err := v.guardian.Stop(); err != nil {
-			return fmt.Errorf("failed to stop guardian: %w", err)
-		}
+	if v.heartbeat != nil {
+		v.heartbeat.Stop()
 	}
 
 	v.cancel()
 	v.wg.Wait()
 
-	close(v.txCandidatesChan)
-
-	return nil
-}
-
-func (v *Validator) loop() {
-	defer v.wg.Done()
-
-	for {
-		select {
-		case txCandidate := <-v.txCandidatesChan:
-			if err := v.sendTransaction(v.ctx, txCandidate); err != nil {
-				v.l.Error("failed to submit transaction of validator", "err", err)
-			}
-		case <-v.ctx.Done():
-			return
-		}
-	}
-}
-
-// sendTransaction creates & sends transactions through the underlying transaction manager.
-func (v *Validator) sendTransaction(ctx context.Context, txCandidate txmgr.TxCandidate) error {
-	receipt, err := v.cfg.TxManager.Send(ctx, txCandidate)
-	if err != nil {
-		return err
-	}
-	v.l.Info("validator tx successfully published", "tx_hash", receipt.TxHash)
 	return nil
 }
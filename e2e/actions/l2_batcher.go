@@ -43,6 +43,9 @@ type BatcherCfg struct {
 	BatcherKey *ecdsa.PrivateKey
 
 	GarbageCfg *GarbageChannelCfg
+
+	// BatchType is the type of the batches, derive.SpanBatchType to batch all the blocks of a channel in a span batch
+	BatchType uint
 }
 
 // L2Batcher buffers and submits L2 batches to L1.
@@ -132,6 +135,8 @@ func (s *L2Batcher) Buffer(t Testing) error {
 		var ch ChannelOutIface
 		if s.l2BatcherCfg.GarbageCfg != nil {
 			ch, err = NewGarbageChannelOut(s.l2BatcherCfg.GarbageCfg)
+		} else if s.l2BatcherCfg.BatchType == derive.SpanBatchType {
+			ch, err = derive.NewSpanChannelOut(derive.CompressionConfig{}, s.rollupCfg)
 		} else {
 			ch, err = derive.NewChannelOut()
 		}
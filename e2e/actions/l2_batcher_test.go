@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
@@ -98,6 +99,53 @@ func TestBatcher(gt *testing.T) {
 	require.NotNil(t, vTx)
 }
 
+// TestDerivationState tests that the channel of a batch submission, which is not closed yet,
+// is reported by the derivation state of the syncer.
+func TestDerivationState(gt *testing.T) {
+	t := NewDefaultTesting(gt)
+	dp := e2eutils.MakeDeployParams(t, defaultRollupTestParams)
+	sd := e2eutils.Setup(t, dp, defaultAlloc)
+	log := testlog.Logger(t, log.LvlDebug)
+	miner, propEngine, proposer := setupProposerTest(t, sd, log)
+	_, syncer := setupSyncer(t, sd, log, miner.L1Client(t, sd.RollupCfg))
+
+	batcher := NewL2Batcher(log, sd.RollupCfg, &BatcherCfg{
+		MinL1TxSize: 0,
+		MaxL1TxSize: 128_000,
+		BatcherKey:  dp.Secrets.Batcher,
+	}, proposer.RollupClient(), miner.EthClient(), propEngine.EthClient())
+
+	proposer.ActL2PipelineFull(t)
+	syncer.ActL2PipelineFull(t)
+
+	proposer.ActL2StartBlock(t)
+	proposer.ActL2EndBlock(t)
+
+	// submit the first frame of the channel, without closing it
+	batcher.ActL2BatchBuffer(t)
+	batcher.ActL2BatchSubmit(t)
+	miner.ActL1StartBlock(12)(t)
+	miner.ActL1IncludeTx(dp.Addresses.Batcher)(t)
+	miner.ActL1EndBlock(t)
+
+	syncer.ActL1HeadSignal(t)
+	syncer.ActL2PipelineFull(t)
+
+	state, err := syncer.RollupClient().DerivationState(t.Ctx())
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), state.Origin.Number)
+	require.False(t, state.Resetting)
+	require.Empty(t, state.PendingFrames)
+	require.Len(t, state.Channels, 1)
+	ch := state.Channels[0]
+	require.Equal(t, uint64(1), ch.OpenBlock.Number)
+	require.Equal(t, []uint16{0}, ch.Frames)
+	require.False(t, ch.Closed)
+	require.False(t, ch.Ready)
+	require.Equal(t, ch.Size, state.ChannelBankSize)
+	require.Equal(t, uint64(0), syncer.SyncStatus().SafeL2.Number, "channel must not be read before it is closed")
+}
+
 func TestL2Finalization(gt *testing.T) {
 	t := NewDefaultTesting(gt)
 	dp := e2eutils.MakeDeployParams(t, defaultRollupTestParams)
@@ -386,6 +434,53 @@ func TestExtendedTimeWithoutL1Batches(gt *testing.T) {
 	require.Equal(t, proposer.L2Unsafe(), proposer.L2Safe(), "same for proposer")
 }
 
+// TestSpanBatch tests that a L2 chain referencing many L1 blocks, submitted as a single span batch,
+// is derived by the syncer.
+func TestSpanBatch(gt *testing.T) {
+	t := NewDefaultTesting(gt)
+	p := &e2eutils.TestParams{
+		MaxProposerDrift:   20, // larger than L1 block time we simulate in this test (12)
+		ProposerWindowSize: 24,
+		ChannelTimeout:     20,
+	}
+	dp := e2eutils.MakeDeployParams(t, p)
+	dp.DeployConfig.L2GenesisSpanBatchTimeOffset = new(hexutil.Uint64)
+	sd := e2eutils.Setup(t, dp, defaultAlloc)
+	log := testlog.Logger(t, log.LvlError)
+	miner, engine, proposer := setupProposerTest(t, sd, log)
+
+	_, syncer := setupSyncer(t, sd, log, miner.L1Client(t, sd.RollupCfg))
+
+	batcher := NewL2Batcher(log, sd.RollupCfg, &BatcherCfg{
+		MinL1TxSize: 0,
+		MaxL1TxSize: 128_000,
+		BatcherKey:  dp.Secrets.Batcher,
+		BatchType:   derive.SpanBatchType,
+	}, proposer.RollupClient(), miner.EthClient(), engine.EthClient())
+
+	proposer.ActL2PipelineFull(t)
+	syncer.ActL2PipelineFull(t)
+
+	for i := 0; i < 5; i++ {
+		miner.ActEmptyBlock(t)
+	}
+	proposer.ActL1HeadSignal(t)
+	proposer.ActBuildToL1Head(t)
+
+	// submit all the L2 blocks in a single channel
+	batcher.ActBufferAll(t)
+	batcher.ActL2ChannelClose(t)
+	batcher.ActL2BatchSubmit(t)
+	miner.ActL1StartBlock(12)(t)
+	miner.ActL1IncludeTx(dp.Addresses.Batcher)(t)
+	miner.ActL1EndBlock(t)
+
+	syncer.ActL1HeadSignal(t)
+	syncer.ActL2PipelineFull(t)
+	require.Equal(t, proposer.L2Unsafe(), syncer.L2Safe(), "all L2 blocks should have been derived from the span batch")
+	require.Equal(t, uint64(5), syncer.L2Safe().L1Origin.Number)
+}
+
 // TestBigL2Txs tests a high-throughput case with constrained batcher:
 //   - Fill 40 L2 blocks to near max-capacity, with txs of 120 KB each
 //   - Buffer the L2 blocks into channels together as much as possible, submit data-txs only when necessary
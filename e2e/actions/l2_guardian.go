@@ -7,12 +7,14 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/kroma-network/kroma/components/node/eth"
+	"github.com/kroma-network/kroma/components/validator"
 )
 
 func (v *L2Validator) ActValidateL2Output(t Testing, outputRoot eth.Bytes32, l2BlockNumber uint64) bool {
-	isValid, err := v.guardian.ValidateL2Output(t.Ctx(), outputRoot, l2BlockNumber)
-	require.NoError(t, err, "unable to validate l2Output")
-	return isValid
+	result := v.guardian.ValidateL2Output(t.Ctx(), outputRoot, l2BlockNumber)
+	require.Contains(t, []validator.ValidationReason{validator.ValidationReasonValid, validator.ValidationReasonMismatch}, result.Reason,
+		"unable to validate l2Output: %v", result.Err)
+	return result.IsValid()
 }
 
 func (v *L2Validator) ActConfirmTransaction(t Testing, transactionId *big.Int) common.Hash {
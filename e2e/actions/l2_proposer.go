@@ -48,7 +48,7 @@ func NewL2Proposer(t Testing, log log.Logger, l1 derive.L1Fetcher, eng L2API, cf
 	}
 	return &L2Proposer{
 		L2Syncer:                *syncer,
-		proposer:                driver.NewProposer(log, cfg, syncer.derivation, attrBuilder, l1OriginSelector, metrics.NoopMetrics),
+		proposer:                driver.NewProposer(log, cfg, syncer.derivation, attrBuilder, l1OriginSelector, metrics.NoopMetrics, nil),
 		mockL1OriginSelector:    l1OriginSelector,
 		failL2GossipUnsafeBlock: nil,
 	}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"io"
+	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -55,11 +56,15 @@ type L2API interface {
 	InfoAndTxsByHash(ctx context.Context, hash common.Hash) (eth.BlockInfo, types.Transactions, error)
 	// GetProof returns a proof of the account, it may return a nil result without error if the address was not found.
 	GetProof(ctx context.Context, address common.Address, storage []common.Hash, blockTag string) (*eth.AccountResult, error)
+	InfoAndTxsByLabel(ctx context.Context, label eth.BlockLabel) (eth.BlockInfo, types.Transactions, error)
+	EstimateGas(ctx context.Context, args any) (uint64, error)
+	MaxPriorityFeePerGas(ctx context.Context) (*big.Int, error)
+	TxPoolContent(ctx context.Context) (*eth.TxPoolContent, error)
 }
 
 func NewL2Syncer(t Testing, log log.Logger, l1 derive.L1Fetcher, eng L2API, cfg *rollup.Config) *L2Syncer {
 	metrics := &testutils.TestDerivationMetrics{}
-	pipeline := derive.NewDerivationPipeline(log, cfg, l1, eng, metrics)
+	pipeline := derive.NewDerivationPipeline(log, cfg, l1, eng, metrics, derive.NoopEvents)
 	pipeline.Reset()
 
 	rollupNode := &L2Syncer{
@@ -127,6 +132,10 @@ func (s *l2SyncerBackend) StopProposer(ctx context.Context) (common.Hash, error)
 	return common.Hash{}, errors.New("stopping the L2Syncer proposer is not supported")
 }
 
+func (s *l2SyncerBackend) DerivationState(ctx context.Context) (*derive.DerivationState, error) {
+	return s.syncer.derivation.State(), nil
+}
+
 func (s *L2Syncer) L2Finalized() eth.L2BlockRef {
 	return s.derivation.Finalized()
 }
@@ -37,6 +37,7 @@ func TestValidator(gt *testing.T) {
 	validator := NewL2Validator(t, log, &ValidatorCfg{
 		OutputOracleAddr:  sd.DeploymentsL1.L2OutputOracleProxy,
 		ValidatorPoolAddr: sd.DeploymentsL1.ValidatorPoolProxy,
+		ColosseumAddr:     sd.DeploymentsL1.ColosseumProxy,
 		ValidatorKey:      dp.Secrets.TrustedValidator,
 		AllowNonFinalized: false,
 	}, miner.EthClient(), proposer.RollupClient())
@@ -61,6 +61,7 @@ func runCrossLayerUserTest(gt *testing.T, test blueScheduledTest) {
 	validator := NewL2Validator(t, log, &ValidatorCfg{
 		OutputOracleAddr:  sd.DeploymentsL1.L2OutputOracleProxy,
 		ValidatorPoolAddr: sd.DeploymentsL1.ValidatorPoolProxy,
+		ColosseumAddr:     sd.DeploymentsL1.ColosseumProxy,
 		ValidatorKey:      dp.Secrets.TrustedValidator,
 		AllowNonFinalized: true,
 	}, miner.EthClient(), proposer.RollupClient())
@@ -68,6 +68,55 @@ func (m *MaliciousL2RPC) EthSubscribe(ctx context.Context, channel interface{},
 	return m.rpc.EthSubscribe(ctx, channel, args...)
 }
 
+// HonestValidatorL2RPC returns the outputs of the chain, except the output before the target block number which is
+// mocked as the starting point of the testdata proof, so that the output at the target block number disputed with the
+// testdata proof is the valid one.
+type HonestValidatorL2RPC struct {
+	rpc client.RPC
+	// targetBlockNumber is the block number for challenge
+	targetBlockNumber *hexutil.Uint64
+}
+
+func NewHonestValidatorL2RPC(rpc client.RPC) *HonestValidatorL2RPC {
+	return &HonestValidatorL2RPC{rpc: rpc}
+}
+
+// SetTargetBlockNumber sets the target block number for challenge.
+// Before the m.targetBlockNumber, mocked output root will be returned for `kroma_outputAtBlock` CallContext
+func (m *HonestValidatorL2RPC) SetTargetBlockNumber(lastValidBlockNumber uint64) {
+	m.targetBlockNumber = new(hexutil.Uint64)
+	*m.targetBlockNumber = hexutil.Uint64(lastValidBlockNumber)
+}
+
+func (m *HonestValidatorL2RPC) Close() {
+	m.rpc.Close()
+}
+
+func (m *HonestValidatorL2RPC) CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error {
+	if method == "kroma_outputAtBlock" || method == "kroma_outputWithProofAtBlock" {
+		blockNumber := args[0].(hexutil.Uint64)
+
+		err := m.rpc.CallContext(ctx, &result, method, blockNumber)
+		if err != nil {
+			return err
+		}
+		if m.targetBlockNumber != nil && *m.targetBlockNumber-1 == blockNumber {
+			return testdata.SetPrevOutputResponse(result.(**eth.OutputResponse))
+		}
+		return nil
+	}
+
+	return m.rpc.CallContext(ctx, result, method, args...)
+}
+
+func (m *HonestValidatorL2RPC) BatchCallContext(ctx context.Context, b []rpc.BatchElem) error {
+	return m.rpc.BatchCallContext(ctx, b)
+}
+
+func (m *HonestValidatorL2RPC) EthSubscribe(ctx context.Context, channel interface{}, args ...interface{}) (ethereum.Subscription, error) {
+	return m.rpc.EthSubscribe(ctx, channel, args...)
+}
+
 type ChallengerL2RPC struct {
 	rpc client.RPC
 	// targetBlockNumber is the block number for challenge
@@ -114,3 +163,31 @@ func (m *ChallengerL2RPC) BatchCallContext(ctx context.Context, b []rpc.BatchEle
 func (m *ChallengerL2RPC) EthSubscribe(ctx context.Context, channel interface{}, args ...interface{}) (ethereum.Subscription, error) {
 	return m.rpc.EthSubscribe(ctx, channel, args...)
 }
+
+// DishonestChallengerL2RPC returns the mocked outputs of the ChallengerL2RPC, and random output roots after the
+// target block number, so that it disputes the valid outputs submitted after the target block number.
+type DishonestChallengerL2RPC struct {
+	*ChallengerL2RPC
+}
+
+func NewDishonestChallengerL2RPC(rpc client.RPC) *DishonestChallengerL2RPC {
+	return &DishonestChallengerL2RPC{ChallengerL2RPC: NewHonestL2RPC(rpc)}
+}
+
+func (m *DishonestChallengerL2RPC) CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error {
+	if err := m.ChallengerL2RPC.CallContext(ctx, result, method, args...); err != nil {
+		return err
+	}
+	if method == "kroma_outputAtBlock" || method == "kroma_outputWithProofAtBlock" {
+		blockNumber := args[0].(hexutil.Uint64)
+		if m.targetBlockNumber != nil && *m.targetBlockNumber < blockNumber {
+			rng := rand.New(rand.NewSource(int64(blockNumber)))
+
+			s := result.(**eth.OutputResponse)
+			(*s).OutputRoot = eth.Bytes32(testutils.RandomHash(rng))
+			(*s).WithdrawalStorageRoot = testutils.RandomHash(rng)
+			(*s).StateRoot = testutils.RandomHash(rng)
+		}
+	}
+	return nil
+}
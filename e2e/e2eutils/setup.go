@@ -229,6 +229,8 @@ func Setup(t require.TestingT, deployParams *DeployParams, alloc *AllocParams) *
 		DepositContractAddress: predeploys.DevKromaPortalAddr,
 		L1SystemConfigAddress:  predeploys.DevSystemConfigAddr,
 		BlueTime:               deployConf.BlueTime(uint64(deployConf.L1GenesisBlockTimestamp)),
+		SpanBatchTime:          deployConf.SpanBatchTime(uint64(deployConf.L1GenesisBlockTimestamp)),
+		ChannelCompressionTime: deployConf.ChannelCompressionTime(uint64(deployConf.L1GenesisBlockTimestamp)),
 	}
 
 	deploymentsL1 := DeploymentsL1{
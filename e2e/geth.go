@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum"
@@ -19,6 +20,8 @@ import (
 	"github.com/ethereum/go-ethereum/eth/catalyst"
 	"github.com/ethereum/go-ethereum/eth/ethconfig"
 	"github.com/ethereum/go-ethereum/eth/tracers"
+	// register the native tracers, as geth does, e.g. for the prestateTracer of the execution witness export
+	_ "github.com/ethereum/go-ethereum/eth/tracers/native"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/log"
@@ -122,7 +125,7 @@ func waitForL2Block(number *big.Int, client *ethclient.Client, timeout time.Dura
 	return waitForBlock(number, client, timeout*timeoutMultiplier)
 }
 
-func initL1Geth(cfg *SystemConfig, genesis *core.Genesis, opts ...GethOption) (*node.Node, *eth.Ethereum, error) {
+func initL1Geth(cfg *SystemConfig, genesis *core.Genesis, opts ...GethOption) (*node.Node, *eth.Ethereum, *fakePoS, error) {
 	ethConfig := &ethconfig.Config{
 		NetworkId: cfg.DeployConfig.L1ChainID,
 		Genesis:   genesis,
@@ -139,13 +142,13 @@ func initL1Geth(cfg *SystemConfig, genesis *core.Genesis, opts ...GethOption) (*
 
 	l1Node, l1Eth, err := createGethNode(false, nodeConfig, ethConfig, []*ecdsa.PrivateKey{cfg.Secrets.CliqueSigner}, opts...)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 	// Activate merge
 	l1Eth.Merger().FinalizePoS()
 
 	// Instead of running a whole beacon node, we run this fake-proof-of-stake sidecar that sequences L1 blocks using the Engine API.
-	l1Node.RegisterLifecycle(&fakePoS{
+	pos := &fakePoS{
 		eth:       l1Eth,
 		log:       log.Root(), // geth logger is global anyway. Would be nice to replace with a local logger though.
 		blockTime: cfg.DeployConfig.L1BlockTime,
@@ -153,9 +156,10 @@ func initL1Geth(cfg *SystemConfig, genesis *core.Genesis, opts ...GethOption) (*
 		finalizedDistance: 8,
 		safeDistance:      4,
 		engineAPI:         catalyst.NewConsensusAPI(l1Eth),
-	})
+	}
+	l1Node.RegisterLifecycle(pos)
 
-	return l1Node, l1Eth, nil
+	return l1Node, l1Eth, pos, nil
 }
 
 // fakePoS is a testing-only utility to attach to Geth,
@@ -170,6 +174,9 @@ type fakePoS struct {
 
 	engineAPI *catalyst.ConsensusAPI
 	sub       ethereum.Subscription
+
+	mu    sync.Mutex
+	forks uint64
 }
 
 func (f *fakePoS) Start() error {
@@ -179,70 +186,9 @@ func (f *fakePoS) Start() error {
 		for {
 			select {
 			case now := <-t.C:
-				chain := f.eth.BlockChain()
-				head := chain.CurrentBlock()
-				finalized := chain.CurrentFinalBlock()
-				if finalized == nil { // fallback to genesis if nothing is finalized
-					finalized = chain.Genesis().Header()
-				}
-				safe := chain.CurrentSafeBlock()
-				if safe == nil { // fallback to finalized if nothing is safe
-					safe = finalized
-				}
-				if head.Number.Uint64() > f.finalizedDistance { // progress finalized block, if we can
-					finalized = chain.GetHeaderByNumber(head.Number.Uint64() - f.finalizedDistance)
-				}
-				if head.Number.Uint64() > f.safeDistance { // progress safe block, if we can
-					safe = chain.GetHeaderByNumber(head.Number.Uint64() - f.safeDistance)
-				}
-				// start building the block as soon as we are past the current head time
-				if head.Time >= uint64(now.Unix()) {
-					continue
-				}
-				res, err := f.engineAPI.ForkchoiceUpdatedV1(engine.ForkchoiceStateV1{
-					HeadBlockHash:      head.Hash(),
-					SafeBlockHash:      safe.Hash(),
-					FinalizedBlockHash: finalized.Hash(),
-				}, &engine.PayloadAttributes{
-					Timestamp:             head.Time + f.blockTime,
-					Random:                common.Hash{},
-					SuggestedFeeRecipient: common.Address{},
-				})
-				if err != nil {
-					f.log.Error("failed to start building L1 block", "err", err)
-					continue
-				}
-				if res.PayloadID == nil {
-					f.log.Error("failed to start block building", "res", res)
-					continue
-				}
-				// wait with sealing, if we are not behind already
-				delay := time.Until(time.Unix(int64(head.Time+f.blockTime), 0))
-				tim := time.NewTimer(delay)
-				select {
-				case <-tim.C:
-					// no-op
-				case <-quit:
-					tim.Stop()
+				if f.buildBlock(now, quit) {
 					return nil
 				}
-				payload, err := f.engineAPI.GetPayloadV1(*res.PayloadID)
-				if err != nil {
-					f.log.Error("failed to finish building L1 block", "err", err)
-					continue
-				}
-				if _, err := f.engineAPI.NewPayloadV1(*payload); err != nil {
-					f.log.Error("failed to insert built L1 block", "err", err)
-					continue
-				}
-				if _, err := f.engineAPI.ForkchoiceUpdatedV1(engine.ForkchoiceStateV1{
-					HeadBlockHash:      payload.BlockHash,
-					SafeBlockHash:      safe.Hash(),
-					FinalizedBlockHash: finalized.Hash(),
-				}, nil); err != nil {
-					f.log.Error("failed to make built L1 block canonical", "err", err)
-					continue
-				}
 			case <-quit:
 				return nil
 			}
@@ -251,6 +197,80 @@ func (f *fakePoS) Start() error {
 	return nil
 }
 
+// buildBlock builds a block on the current head, if the head is older than the block time.
+// It returns true if the sidecar is stopped while building the block.
+func (f *fakePoS) buildBlock(now time.Time, quit <-chan struct{}) bool {
+	// the head must not be switched while building a block on it
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	chain := f.eth.BlockChain()
+	head := chain.CurrentBlock()
+	finalized := chain.CurrentFinalBlock()
+	if finalized == nil { // fallback to genesis if nothing is finalized
+		finalized = chain.Genesis().Header()
+	}
+	safe := chain.CurrentSafeBlock()
+	if safe == nil { // fallback to finalized if nothing is safe
+		safe = finalized
+	}
+	if head.Number.Uint64() > f.finalizedDistance { // progress finalized block, if we can
+		finalized = chain.GetHeaderByNumber(head.Number.Uint64() - f.finalizedDistance)
+	}
+	if head.Number.Uint64() > f.safeDistance { // progress safe block, if we can
+		safe = chain.GetHeaderByNumber(head.Number.Uint64() - f.safeDistance)
+	}
+	// start building the block as soon as we are past the current head time
+	if head.Time >= uint64(now.Unix()) {
+		return false
+	}
+	res, err := f.engineAPI.ForkchoiceUpdatedV1(engine.ForkchoiceStateV1{
+		HeadBlockHash:      head.Hash(),
+		SafeBlockHash:      safe.Hash(),
+		FinalizedBlockHash: finalized.Hash(),
+	}, &engine.PayloadAttributes{
+		Timestamp:             head.Time + f.blockTime,
+		Random:                common.Hash{},
+		SuggestedFeeRecipient: common.Address{},
+	})
+	if err != nil {
+		f.log.Error("failed to start building L1 block", "err", err)
+		return false
+	}
+	if res.PayloadID == nil {
+		f.log.Error("failed to start block building", "res", res)
+		return false
+	}
+	// wait with sealing, if we are not behind already
+	delay := time.Until(time.Unix(int64(head.Time+f.blockTime), 0))
+	tim := time.NewTimer(delay)
+	select {
+	case <-tim.C:
+		// no-op
+	case <-quit:
+		tim.Stop()
+		return true
+	}
+	payload, err := f.engineAPI.GetPayloadV1(*res.PayloadID)
+	if err != nil {
+		f.log.Error("failed to finish building L1 block", "err", err)
+		return false
+	}
+	if _, err := f.engineAPI.NewPayloadV1(*payload); err != nil {
+		f.log.Error("failed to insert built L1 block", "err", err)
+		return false
+	}
+	if _, err := f.engineAPI.ForkchoiceUpdatedV1(engine.ForkchoiceStateV1{
+		HeadBlockHash:      payload.BlockHash,
+		SafeBlockHash:      safe.Hash(),
+		FinalizedBlockHash: finalized.Hash(),
+	}, nil); err != nil {
+		f.log.Error("failed to make built L1 block canonical", "err", err)
+		return false
+	}
+	return false
+}
+
 func (f *fakePoS) Stop() error {
 	f.sub.Unsubscribe()
 	return nil
@@ -0,0 +1,128 @@
+package e2e
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/beacon/engine"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/miner"
+)
+
+// BuildL1Fork builds an alternative chain of `length` blocks on the L1 block `depth` blocks below the canonical head,
+// without making it canonical, and returns the header of its tip. The blocks of the fork are empty,
+// so the transactions of the blocks reorged out return to the tx pool, and are included again after the reorg.
+// The safe L1 chain cannot be reorged.
+func (sys *System) BuildL1Fork(depth uint64, length uint64) (*types.Header, error) {
+	return sys.l1PoS.buildFork(depth, length)
+}
+
+// SwitchL1Head makes the L1 block canonical, e.g. the tip of a fork built by BuildL1Fork,
+// reorging the L1 if the block is not a descendant of the current head.
+func (sys *System) SwitchL1Head(hash common.Hash) error {
+	return sys.l1PoS.switchHead(hash)
+}
+
+// ReorgL1 reorgs the L1 by `depth` blocks, replacing them with a fork of `length` blocks,
+// and returns the header of the new head.
+func (sys *System) ReorgL1(depth uint64, length uint64) (*types.Header, error) {
+	tip, err := sys.BuildL1Fork(depth, length)
+	if err != nil {
+		return nil, err
+	}
+	if err := sys.SwitchL1Head(tip.Hash()); err != nil {
+		return nil, err
+	}
+	return tip, nil
+}
+
+func (f *fakePoS) buildFork(depth uint64, length uint64) (*types.Header, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if length == 0 {
+		return nil, errors.New("fork must have at least one block")
+	}
+	chain := f.eth.BlockChain()
+	head := chain.CurrentBlock()
+	if depth > head.Number.Uint64() {
+		return nil, fmt.Errorf("cannot reorg %d blocks of L1 chain at block %d", depth, head.Number)
+	}
+	parent := chain.GetHeaderByNumber(head.Number.Uint64() - depth)
+	if safe := chain.CurrentSafeBlock(); safe != nil && parent.Number.Cmp(safe.Number) < 0 {
+		return nil, fmt.Errorf("cannot reorg safe L1 block %d", safe.Number)
+	}
+
+	// the randomness tells the blocks of the fork apart from the blocks of the canonical chain
+	f.forks++
+	random := common.BigToHash(new(big.Int).SetUint64(f.forks))
+	for i := uint64(0); i < length; i++ {
+		payload, err := f.eth.Miner().BuildPayload(&miner.BuildPayloadArgs{
+			Parent:    parent.Hash(),
+			Timestamp: parent.Time + f.blockTime,
+			Random:    random,
+			NoTxPool:  true,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to build L1 fork block on %s: %w", parent.Hash(), err)
+		}
+		data := *payload.Resolve().ExecutionPayload
+		res, err := f.engineAPI.NewPayloadV1(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to insert L1 fork block %s: %w", data.BlockHash, err)
+		}
+		if res.Status != engine.VALID {
+			return nil, fmt.Errorf("failed to insert L1 fork block %s: status %s", data.BlockHash, res.Status)
+		}
+		parent = chain.GetHeaderByHash(data.BlockHash)
+	}
+	f.log.Info("built L1 fork", "depth", depth, "length", length, "tip", parent.Hash(), "number", parent.Number)
+	return parent, nil
+}
+
+func (f *fakePoS) switchHead(hash common.Hash) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	chain := f.eth.BlockChain()
+	head := chain.GetHeaderByHash(hash)
+	if head == nil {
+		return fmt.Errorf("unknown L1 block %s", hash)
+	}
+	finalized := chain.CurrentFinalBlock()
+	if finalized == nil {
+		finalized = chain.Genesis().Header()
+	}
+	safe := chain.CurrentSafeBlock()
+	if safe == nil {
+		safe = finalized
+	}
+	// the safe and finalized blocks must remain canonical after the switch
+	if !isAncestor(chain.GetHeaderByHash, safe, head) {
+		return fmt.Errorf("L1 block %s is not a descendant of the safe L1 block %d", hash, safe.Number)
+	}
+
+	res, err := f.engineAPI.ForkchoiceUpdatedV1(engine.ForkchoiceStateV1{
+		HeadBlockHash:      head.Hash(),
+		SafeBlockHash:      safe.Hash(),
+		FinalizedBlockHash: finalized.Hash(),
+	}, nil)
+	if err != nil {
+		return fmt.Errorf("failed to switch L1 head to %s: %w", hash, err)
+	}
+	if res.PayloadStatus.Status != engine.VALID {
+		return fmt.Errorf("failed to switch L1 head to %s: status %s", hash, res.PayloadStatus.Status)
+	}
+	f.log.Info("switched L1 head", "hash", hash, "number", head.Number)
+	return nil
+}
+
+// isAncestor returns whether the block is the header or one of its ancestors.
+func isAncestor(getHeader func(common.Hash) *types.Header, block *types.Header, header *types.Header) bool {
+	for header != nil && header.Number.Cmp(block.Number) > 0 {
+		header = getHeader(header.ParentHash)
+	}
+	return header != nil && header.Hash() == block.Hash()
+}
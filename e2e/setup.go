@@ -63,6 +63,7 @@ func newTxMgrConfig(l1Addr string, privKey *ecdsa.PrivateKey) txmgr.CLIConfig {
 		NetworkTimeout:            2 * time.Second,
 		TxSendTimeout:             10 * time.Minute,
 		TxNotInMempoolTimeout:     2 * time.Minute,
+		BroadcastHookPolicy:       txmgr.HookFailurePolicyBlock,
 	}
 }
 
@@ -241,6 +242,11 @@ type SystemConfig struct {
 
 	// TODO(0xHansLee): temporal flag for malicious validator. If it is set true, the validator acts as a malicious one
 	EnableMaliciousValidator bool
+
+	// EnableDishonestChallenger makes the validator submit the valid output at the target block number, and the
+	// challenger dispute it with the testdata proof, which is valid on-chain although its output is not of the chain.
+	// The guardian is run separately with the honest view of the chain, instead of the view of the challenger.
+	EnableDishonestChallenger bool
 }
 
 type System struct {
@@ -260,12 +266,18 @@ type System struct {
 	Guardian    *validator.Validator
 	Batcher     *batcher.Batcher
 	Mocknet     mocknet.Mocknet
+
+	// the fake proof-of-stake sidecar sequencing the L1 blocks, to reorg the L1
+	l1PoS *fakePoS
 }
 
 func (sys *System) Close() {
 	if sys.Validator != nil {
 		sys.Validator.Stop()
 	}
+	if sys.Guardian != nil {
+		sys.Guardian.Stop()
+	}
 	if sys.Batcher != nil {
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
@@ -404,18 +416,21 @@ func (cfg SystemConfig) Start(_opts ...SystemConfigOption) (*System, error) {
 			DepositContractAddress: predeploys.DevKromaPortalAddr,
 			L1SystemConfigAddress:  predeploys.DevSystemConfigAddr,
 			BlueTime:               cfg.DeployConfig.BlueTime(uint64(cfg.DeployConfig.L1GenesisBlockTimestamp)),
+			SpanBatchTime:          cfg.DeployConfig.SpanBatchTime(uint64(cfg.DeployConfig.L1GenesisBlockTimestamp)),
+			ChannelCompressionTime: cfg.DeployConfig.ChannelCompressionTime(uint64(cfg.DeployConfig.L1GenesisBlockTimestamp)),
 		}
 	}
 	defaultConfig := makeRollupConfig()
 	sys.RollupConfig = &defaultConfig
 
 	// Initialize nodes
-	l1Node, l1Backend, err := initL1Geth(&cfg, l1Genesis, cfg.GethOptions["l1"]...)
+	l1Node, l1Backend, l1PoS, err := initL1Geth(&cfg, l1Genesis, cfg.GethOptions["l1"]...)
 	if err != nil {
 		return nil, err
 	}
 	sys.Nodes["l1"] = l1Node
 	sys.Backends["l1"] = l1Backend
+	sys.l1PoS = l1PoS
 
 	for name := range cfg.Nodes {
 		node, backend, err := initL2Geth(name, big.NewInt(int64(cfg.DeployConfig.L2ChainID)), l2Genesis, cfg.JWTFilePath, cfg.GethOptions[name]...)
@@ -634,12 +649,19 @@ func (cfg SystemConfig) Start(_opts ...SystemConfigOption) (*System, error) {
 		return nil, fmt.Errorf("unable to init validator rollup rpc client: %w", err)
 	}
 	rpcCl := client.NewBaseRPCClient(cl)
-	ValidatorMaliciousL2RPC := e2eutils.NewMaliciousL2RPC(rpcCl)
-	validatorCfg.RollupClient = sources.NewRollupClient(ValidatorMaliciousL2RPC)
-
-	// If malicious validator is turn on, set target block number for submitting invalid output
-	if cfg.EnableMaliciousValidator {
-		ValidatorMaliciousL2RPC.SetTargetBlockNumber(testdata.TargetBlockNumber)
+	if cfg.EnableDishonestChallenger {
+		// If dishonest challenger is turn on, the validator submits the valid output at the target block number.
+		ValidatorHonestL2RPC := e2eutils.NewHonestValidatorL2RPC(rpcCl)
+		ValidatorHonestL2RPC.SetTargetBlockNumber(testdata.TargetBlockNumber)
+		validatorCfg.RollupClient = sources.NewRollupClient(ValidatorHonestL2RPC)
+	} else {
+		ValidatorMaliciousL2RPC := e2eutils.NewMaliciousL2RPC(rpcCl)
+		validatorCfg.RollupClient = sources.NewRollupClient(ValidatorMaliciousL2RPC)
+
+		// If malicious validator is turn on, set target block number for submitting invalid output.
+		if cfg.EnableMaliciousValidator {
+			ValidatorMaliciousL2RPC.SetTargetBlockNumber(testdata.TargetBlockNumber)
+		}
 	}
 
 	sys.Validator, err = validator.NewValidator(context.Background(), *validatorCfg, sys.cfg.Loggers["validator"], validatormetrics.NoopMetrics)
@@ -663,7 +685,7 @@ func (cfg SystemConfig) Start(_opts ...SystemConfigOption) (*System, error) {
 		TxMgrConfig:             newTxMgrConfig(sys.Nodes["l1"].WSEndpoint(), cfg.Secrets.Challenger),
 		OutputSubmitterDisabled: true,
 		SecurityCouncilAddress:  predeploys.DevSecurityCouncilAddr.String(),
-		GuardianEnabled:         true,
+		GuardianEnabled:         !cfg.EnableDishonestChallenger,
 		LogConfig: klog.CLIConfig{
 			Level:  "info",
 			Format: "text",
@@ -681,11 +703,18 @@ func (cfg SystemConfig) Start(_opts ...SystemConfigOption) (*System, error) {
 		return nil, fmt.Errorf("unable to init challenger rollup rpc client: %w", err)
 	}
 	rpcCl = client.NewBaseRPCClient(cl)
-	ChallengerHonestL2RPC := e2eutils.NewHonestL2RPC(rpcCl)
-	challengerCfg.RollupClient = sources.NewRollupClient(ChallengerHonestL2RPC)
-
-	if cfg.EnableMaliciousValidator {
-		ChallengerHonestL2RPC.SetTargetBlockNumber(testdata.TargetBlockNumber)
+	if cfg.EnableDishonestChallenger {
+		// If dishonest challenger is turn on, the challenger disputes the valid outputs from the target block number.
+		ChallengerDishonestL2RPC := e2eutils.NewDishonestChallengerL2RPC(rpcCl)
+		ChallengerDishonestL2RPC.SetTargetBlockNumber(testdata.TargetBlockNumber)
+		challengerCfg.RollupClient = sources.NewRollupClient(ChallengerDishonestL2RPC)
+	} else {
+		ChallengerHonestL2RPC := e2eutils.NewHonestL2RPC(rpcCl)
+		challengerCfg.RollupClient = sources.NewRollupClient(ChallengerHonestL2RPC)
+
+		if cfg.EnableMaliciousValidator {
+			ChallengerHonestL2RPC.SetTargetBlockNumber(testdata.TargetBlockNumber)
+		}
 	}
 
 	// Replace to mock fetcher
@@ -699,6 +728,51 @@ func (cfg SystemConfig) Start(_opts ...SystemConfigOption) (*System, error) {
 		return nil, fmt.Errorf("unable to start challenger: %w", err)
 	}
 
+	// Run validator node (Guardian), validating the disputed output with the honest view of the chain
+	if cfg.EnableDishonestChallenger {
+		guardianCliCfg := validator.CLIConfig{
+			L1EthRpc:                sys.Nodes["l1"].WSEndpoint(),
+			RollupRpc:               sys.RollupNodes["proposer"].HTTPEndpoint(),
+			L2OOAddress:             predeploys.DevL2OutputOracleAddr.String(),
+			ColosseumAddress:        predeploys.DevColosseumAddr.String(),
+			ValPoolAddress:          predeploys.DevValidatorPoolAddr.String(),
+			ChallengerPollInterval:  500 * time.Millisecond,
+			ProverGrpc:              "http://0.0.0.0:0",
+			TxMgrConfig:             newTxMgrConfig(sys.Nodes["l1"].WSEndpoint(), cfg.Secrets.Alice),
+			OutputSubmitterDisabled: true,
+			SecurityCouncilAddress:  predeploys.DevSecurityCouncilAddr.String(),
+			GuardianEnabled:         true,
+			LogConfig: klog.CLIConfig{
+				Level:  "info",
+				Format: "text",
+			},
+		}
+
+		guardianCfg, err := validator.NewValidatorConfig(guardianCliCfg, sys.cfg.Loggers["guardian"], validatormetrics.NoopMetrics)
+		if err != nil {
+			return nil, fmt.Errorf("unable to init guardian config: %w", err)
+		}
+
+		// Replace to mock RPC client
+		cl, err = rpc.DialHTTP(guardianCliCfg.RollupRpc)
+		if err != nil {
+			return nil, fmt.Errorf("unable to init guardian rollup rpc client: %w", err)
+		}
+		rpcCl = client.NewBaseRPCClient(cl)
+		GuardianL2RPC := e2eutils.NewHonestValidatorL2RPC(rpcCl)
+		GuardianL2RPC.SetTargetBlockNumber(testdata.TargetBlockNumber)
+		guardianCfg.RollupClient = sources.NewRollupClient(GuardianL2RPC)
+
+		sys.Guardian, err = validator.NewValidator(context.Background(), *guardianCfg, sys.cfg.Loggers["guardian"], validatormetrics.NoopMetrics)
+		if err != nil {
+			return nil, fmt.Errorf("unable to setup guardian: %w", err)
+		}
+
+		if err := sys.Guardian.Start(); err != nil {
+			return nil, fmt.Errorf("unable to start guardian: %w", err)
+		}
+	}
+
 	// Batcher (Batch Submitter)
 	batcherCliCfg := batcher.CLIConfig{
 		L1EthRpc:           sys.Nodes["l1"].WSEndpoint(),
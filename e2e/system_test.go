@@ -28,6 +28,7 @@ import (
 	"github.com/kroma-network/kroma/bindings/bindings"
 	"github.com/kroma-network/kroma/bindings/predeploys"
 	"github.com/kroma-network/kroma/components/node/client"
+	"github.com/kroma-network/kroma/components/node/cmd/witness"
 	"github.com/kroma-network/kroma/components/node/eth"
 	"github.com/kroma-network/kroma/components/node/metrics"
 	rollupNode "github.com/kroma-network/kroma/components/node/node"
@@ -758,6 +759,60 @@ func TestSystemMockP2P(t *testing.T) {
 	require.Contains(t, received, receiptSync.BlockHash)
 }
 
+// TestExecutionWitness checks that the execution witness exported from the L2 engine verifies
+// against the parent state root, for a block with a deposit and a user transaction.
+func TestExecutionWitness(t *testing.T) {
+	parallel(t)
+	if !verboseGethNodes {
+		log.Root().SetHandler(log.DiscardHandler())
+	}
+
+	cfg := DefaultSystemConfig(t)
+	sys, err := cfg.Start()
+	require.Nil(t, err, "Error starting up system")
+	defer sys.Close()
+
+	l2Prop := sys.Clients["proposer"]
+
+	toAddr := common.Address{0xff, 0xff}
+	tx := types.MustSignNewTx(cfg.Secrets.Alice, types.LatestSignerForChainID(cfg.L2ChainIDBig()), &types.DynamicFeeTx{
+		ChainID:   cfg.L2ChainIDBig(),
+		Nonce:     0,
+		To:        &toAddr,
+		Value:     big.NewInt(1_000_000_000),
+		GasTipCap: big.NewInt(10),
+		GasFeeCap: big.NewInt(200),
+		Gas:       21000,
+	})
+	require.NoError(t, l2Prop.SendTransaction(context.Background(), tx))
+	receipt, err := waitForL2Transaction(tx.Hash(), l2Prop, 10*time.Duration(sys.RollupConfig.BlockTime)*time.Second)
+	require.Nil(t, err, "Waiting for L2 tx on proposer")
+
+	rpcClient, err := rpc.DialContext(context.Background(), sys.Nodes["proposer"].HTTPEndpoint())
+	require.NoError(t, err)
+	defer rpcClient.Close()
+
+	w, err := witness.Export(context.Background(), witness.NewRPCSource(rpcClient), receipt.BlockNumber.Uint64())
+	require.NoError(t, err)
+	require.NoError(t, w.Verify())
+	require.Equal(t, receipt.BlockHash, w.Header.Hash())
+	require.Len(t, w.Transactions, 2, "expected the L1 info deposit and the user transaction")
+
+	addrs := make(map[common.Address]*witness.Account)
+	for _, acc := range w.Accounts {
+		addrs[acc.Proof.Address] = acc
+	}
+	require.Contains(t, addrs, cfg.Secrets.Addresses().Alice)
+	require.Contains(t, addrs, toAddr)
+	require.Contains(t, addrs, predeploys.L1BlockAddr)
+	require.NotEmpty(t, addrs[predeploys.L1BlockAddr].Proof.StorageProof, "expected the L1 block attributes to be witnessed")
+	require.NotEmpty(t, addrs[predeploys.L1BlockAddr].Code)
+
+	// a tampered witness must not verify
+	w.Accounts[0].Proof.Nonce++
+	require.Error(t, w.Verify())
+}
+
 // TestSystemRPCAltSync sets up a L1 Geth node, a rollup node, and a L2 geth node and then confirms that
 // the nodes can sync L2 blocks before they are confirmed on L1.
 //
@@ -846,6 +901,65 @@ func TestSystemRPCAltSync(t *testing.T) {
 	require.ElementsMatch(t, received, published[:len(received)])
 }
 
+// TestSystemL1Only checks that a syncer in L1-only mode runs without P2P, and derives the L2 chain from L1 only.
+func TestSystemL1Only(t *testing.T) {
+	parallel(t)
+	if !verboseGethNodes {
+		log.Root().SetHandler(log.DiscardHandler())
+	}
+
+	cfg := DefaultSystemConfig(t)
+	cfg.P2PTopology = nil
+	cfg.Nodes["syncer"].Driver.L1Only = true
+
+	var received []common.Hash
+	syncTracer := new(FnTracer)
+	syncTracer.OnUnsafeL2PayloadFn = func(ctx context.Context, from peer.ID, payload *eth.ExecutionPayload) {
+		received = append(received, payload.BlockHash)
+	}
+	cfg.Nodes["syncer"].Tracer = syncTracer
+
+	sys, err := cfg.Start()
+	require.Nil(t, err, "Error starting up system")
+	defer sys.Close()
+	require.Nil(t, sys.RollupNodes["syncer"].P2P(), "expected no p2p node in L1-only mode")
+
+	l2Prop := sys.Clients["proposer"]
+	l2Sync := sys.Clients["syncer"]
+
+	// Submit a TX to L2 proposer node
+	toAddr := common.Address{0xff, 0xff}
+	tx := types.MustSignNewTx(cfg.Secrets.Alice, types.LatestSignerForChainID(cfg.L2ChainIDBig()), &types.DynamicFeeTx{
+		ChainID:   cfg.L2ChainIDBig(),
+		Nonce:     0,
+		To:        &toAddr,
+		Value:     big.NewInt(1_000_000_000),
+		GasTipCap: big.NewInt(10),
+		GasFeeCap: big.NewInt(200),
+		Gas:       21000,
+	})
+	err = l2Prop.SendTransaction(context.Background(), tx)
+	require.Nil(t, err, "Sending L2 tx to proposer")
+
+	receiptProp, err := waitForTransaction(tx.Hash(), l2Prop, 6*time.Duration(sys.RollupConfig.BlockTime)*time.Second)
+	require.Nil(t, err, "Waiting for L2 tx on proposer")
+
+	// the syncer only learns about the tx once it is batch submitted to L1
+	receiptSync, err := waitForTransaction(tx.Hash(), l2Sync, 6*time.Duration(sys.RollupConfig.BlockTime)*time.Second)
+	require.Nil(t, err, "Waiting for L2 tx on syncer")
+	require.Equal(t, receiptProp, receiptSync)
+	require.Empty(t, received, "expected no unsafe payloads in L1-only mode")
+
+	rollupRPCClient, err := rpc.DialContext(context.Background(), sys.RollupNodes["syncer"].HTTPEndpoint())
+	require.Nil(t, err)
+	rollupClient := sources.NewRollupClient(client.NewBaseRPCClient(rollupRPCClient))
+	status, err := rollupClient.SyncStatus(context.Background())
+	require.Nil(t, err)
+	require.True(t, status.L1Only)
+	require.Equal(t, status.SafeL2, status.UnsafeL2, "expected the unsafe head to match the safe head")
+	require.LessOrEqual(t, receiptSync.BlockNumber.Uint64(), status.SafeL2.Number)
+}
+
 func TestSystemP2PAltSync(t *testing.T) {
 	parallel(t)
 	if !verboseGethNodes {
@@ -1772,6 +1886,55 @@ func TestStopStartBatcher(t *testing.T) {
 	require.Greater(t, newSeqStatus.SafeL2.Number, propStatus.SafeL2.Number, "Safe chain did not advance after batcher was restarted")
 }
 
+// TestL1Reorg tests that the safe chain keeps advancing on the canonical L1 chain after an L1 reorg.
+func TestL1Reorg(t *testing.T) {
+	parallel(t)
+	if !verboseGethNodes {
+		log.Root().SetHandler(log.DiscardHandler())
+	}
+
+	cfg := DefaultSystemConfig(t)
+	sys, err := cfg.Start()
+	require.Nil(t, err, "Error starting up system")
+	defer sys.Close()
+
+	rollupRPCClient, err := rpc.DialContext(context.Background(), sys.RollupNodes["syncer"].HTTPEndpoint())
+	require.Nil(t, err)
+	rollupClient := sources.NewRollupClient(client.NewBaseRPCClient(rollupRPCClient))
+
+	l1Client := sys.Clients["l1"]
+	l1BlockTime := time.Duration(cfg.DeployConfig.L1BlockTime) * time.Second
+
+	// wait until the safe chain of the syncer is derived from some L1 blocks
+	_, err = waitForBlock(big.NewInt(8), l1Client, 10*l1BlockTime)
+	require.Nil(t, err, "Waiting for L1 blocks")
+	syncStatus, err := rollupClient.SyncStatus(context.Background())
+	require.Nil(t, err)
+
+	const depth, length = 3, 4
+	before, err := l1Client.HeaderByNumber(context.Background(), nil)
+	require.Nil(t, err)
+	tip, err := sys.ReorgL1(depth, length)
+	require.Nil(t, err, "Reorging L1")
+
+	canonical, err := l1Client.HeaderByNumber(context.Background(), before.Number)
+	require.Nil(t, err)
+	require.NotEqual(t, before.Hash(), canonical.Hash(), "L1 head was not reorged out")
+	canonical, err = l1Client.HeaderByNumber(context.Background(), tip.Number)
+	require.Nil(t, err)
+	require.Equal(t, tip.Hash(), canonical.Hash(), "L1 fork is not canonical")
+
+	// the safe chain must advance on the canonical L1 chain, once the batches reorged out are submitted again
+	_, err = waitForBlock(new(big.Int).Add(tip.Number, big.NewInt(6)), l1Client, 10*l1BlockTime)
+	require.Nil(t, err, "Waiting for L1 blocks after the reorg")
+	newSyncStatus, err := rollupClient.SyncStatus(context.Background())
+	require.Nil(t, err)
+	require.Greater(t, newSyncStatus.SafeL2.Number, syncStatus.SafeL2.Number, "Safe chain did not advance after L1 reorg")
+	canonical, err = l1Client.HeaderByNumber(context.Background(), new(big.Int).SetUint64(newSyncStatus.SafeL2.L1Origin.Number))
+	require.Nil(t, err)
+	require.Equal(t, newSyncStatus.SafeL2.L1Origin.Hash, canonical.Hash(), "L1 origin of safe chain is not canonical")
+}
+
 func TestChallenge(t *testing.T) {
 	parallel(t)
 	if !verboseGethNodes {
@@ -1844,6 +2007,128 @@ func TestChallenge(t *testing.T) {
 	}
 }
 
+// TestDishonestChallenge checks that the challenge of a dishonest challenger against the valid output of an honest
+// validator is proven on-chain, but that its validation request is not confirmed by the guardian validating the
+// output of the challenger against the chain, so that the valid output is kept.
+func TestDishonestChallenge(t *testing.T) {
+	parallel(t)
+	if !verboseGethNodes {
+		log.Root().SetHandler(log.DiscardHandler())
+	}
+
+	cfg := DefaultSystemConfig(t)
+	cfg.EnableDishonestChallenger = true
+
+	sys, err := cfg.Start()
+	require.NoError(t, err, "Error starting up system")
+	defer sys.Close()
+
+	l1Client := sys.Clients["l1"]
+
+	// deposit to ValidatorPool to be a challenger
+	err = cfg.DepositValidatorPool(l1Client, cfg.Secrets.Challenger, big.NewInt(1_000_000_000))
+	require.NoError(t, err, "Error challenger deposit to ValidatorPool")
+
+	l2OutputOracle, err := bindings.NewL2OutputOracleCaller(predeploys.DevL2OutputOracleAddr, l1Client)
+	require.NoError(t, err)
+
+	colosseum, err := bindings.NewColosseumCaller(predeploys.DevColosseumAddr, l1Client)
+	require.NoError(t, err)
+
+	securityCouncil, err := bindings.NewSecurityCouncilCaller(predeploys.DevSecurityCouncilAddr, l1Client)
+	require.NoError(t, err)
+
+	rollupRPCClient, err := rpc.DialContext(context.Background(), sys.RollupNodes["proposer"].HTTPEndpoint())
+	require.NoError(t, err)
+	rollupClient := sources.NewRollupClient(client.NewBaseRPCClient(rollupRPCClient))
+
+	// set a timeout for one cycle of challenge
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Second)
+	defer cancel()
+
+	ticker := time.NewTicker(1 * time.Second)
+	defer ticker.Stop()
+
+	targetOutputOracleIndex := uint64(math.Ceil(float64(testdata.TargetBlockNumber) / float64(cfg.DeployConfig.L2OutputOracleSubmissionInterval)))
+	outputIndex := new(big.Int).SetUint64(targetOutputOracleIndex)
+	txID := new(big.Int).SetUint64(0)
+
+	// wait for the dishonest challenger to prove the fault, and request the validation to the security council
+	for {
+		challengeStatus, err := colosseum.GetStatus(&bind.CallOpts{}, outputIndex)
+		require.NoError(t, err)
+		require.NotEqual(t, chal.StatusApproved, challengeStatus, "dishonest challenge must not be approved")
+
+		txCount, err := securityCouncil.TransactionCount(&bind.CallOpts{})
+		require.NoError(t, err)
+		if challengeStatus == chal.StatusProven && txCount.Cmp(txID) > 0 {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			t.Fatalf("Timed out waiting for the validation request of the challenge")
+		case <-ticker.C:
+		}
+	}
+
+	// wait for the guardian to be able to validate the requested output
+	output, err := l2OutputOracle.GetL2Output(&bind.CallOpts{}, outputIndex)
+	require.NoError(t, err)
+	for {
+		status, err := rollupClient.SyncStatus(ctx)
+		require.NoError(t, err)
+		if status.FinalizedL2.Number >= output.L2BlockNumber.Uint64() {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			t.Fatalf("Timed out waiting for the target block to be finalized")
+		case <-ticker.C:
+		}
+	}
+
+	// the guardian validates the request on its polling interval, and must reject it
+	observeUntil := time.Now().Add(25 * time.Second)
+	for time.Now().Before(observeUntil) {
+		confirmations, err := securityCouncil.GetConfirmationCount(&bind.CallOpts{}, txID)
+		require.NoError(t, err)
+		require.Zero(t, confirmations.Uint64(), "guardian must not confirm the validation of a dishonest challenge")
+
+		select {
+		case <-ctx.Done():
+			t.Fatalf("Timed out observing the validation request")
+		case <-ticker.C:
+		}
+	}
+
+	// check tx not executed
+	tx, err := securityCouncil.Transactions(&bind.CallOpts{}, txID)
+	require.NoError(t, err)
+	require.Equal(t, predeploys.DevColosseumAddr, tx.Destination)
+	require.False(t, tx.Executed)
+
+	// check challenge status is not approved
+	challenge, err := colosseum.GetChallenge(&bind.CallOpts{}, outputIndex)
+	require.NoError(t, err)
+	require.False(t, challenge.Approved)
+
+	challengeStatus, err := colosseum.GetStatus(&bind.CallOpts{}, outputIndex)
+	require.NoError(t, err)
+	require.Equal(t, chal.StatusProven, challengeStatus)
+
+	// check the valid output of the validator is kept, and the output of the challenger is not the one of the chain
+	output, err = l2OutputOracle.GetL2Output(&bind.CallOpts{}, outputIndex)
+	require.NoError(t, err)
+	require.Equal(t, cfg.Secrets.Addresses().TrustedValidator, output.Submitter)
+	chainOutput, err := rollupClient.OutputAtBlock(ctx, output.L2BlockNumber.Uint64())
+	require.NoError(t, err)
+	require.Equal(t, chainOutput.OutputRoot, eth.Bytes32(output.OutputRoot), "validator must have submitted the output of the chain")
+	require.Equal(t, cfg.Secrets.Addresses().Challenger, challenge.Challenger)
+	require.NotEqual(t, chainOutput.OutputRoot, eth.Bytes32(challenge.OutputRoot), "challenger must have disputed the valid output")
+}
+
 func safeAddBig(a *big.Int, b *big.Int) *big.Int {
 	return new(big.Int).Add(a, b)
 }
@@ -75,6 +75,11 @@ type DeployConfig struct {
 
 	// Seconds after genesis block that Blue hard fork activates. 0 to activate at genesis. Nil to disable blue
 	L2GenesisBlueTimeOffset *hexutil.Uint64 `json:"l2GenesisBlueTimeOffset,omitempty"`
+	// Seconds after genesis block that span batches are accepted. 0 to accept at genesis. Nil to disable span batches
+	L2GenesisSpanBatchTimeOffset *hexutil.Uint64 `json:"l2GenesisSpanBatchTimeOffset,omitempty"`
+	// Seconds after genesis block that brotli and zstd compressed channels are accepted. 0 to accept at genesis. Nil to
+	// accept zlib compressed channels only
+	L2GenesisChannelCompressionTimeOffset *hexutil.Uint64 `json:"l2GenesisChannelCompressionTimeOffset,omitempty"`
 
 	ColosseumBisectionTimeout uint64      `json:"colosseumBisectionTimeout"`
 	ColosseumProvingTimeout   uint64      `json:"colosseumProvingTimeout"`
@@ -317,6 +322,28 @@ func (d *DeployConfig) BlueTime(genesisTime uint64) *uint64 {
 	return &v
 }
 
+func (d *DeployConfig) SpanBatchTime(genesisTime uint64) *uint64 {
+	if d.L2GenesisSpanBatchTimeOffset == nil {
+		return nil
+	}
+	v := uint64(0)
+	if offset := *d.L2GenesisSpanBatchTimeOffset; offset > 0 {
+		v = genesisTime + uint64(offset)
+	}
+	return &v
+}
+
+func (d *DeployConfig) ChannelCompressionTime(genesisTime uint64) *uint64 {
+	if d.L2GenesisChannelCompressionTimeOffset == nil {
+		return nil
+	}
+	v := uint64(0)
+	if offset := *d.L2GenesisChannelCompressionTimeOffset; offset > 0 {
+		v = genesisTime + uint64(offset)
+	}
+	return &v
+}
+
 // RollupConfig converts a DeployConfig to a rollup.Config
 func (d *DeployConfig) RollupConfig(l1StartBlock *types.Block, l2GenesisBlockHash common.Hash, l2GenesisBlockNumber uint64) (*rollup.Config, error) {
 	if d.KromaPortalProxy == (common.Address{}) {
@@ -354,6 +381,8 @@ func (d *DeployConfig) RollupConfig(l1StartBlock *types.Block, l2GenesisBlockHas
 		DepositContractAddress: d.KromaPortalProxy,
 		L1SystemConfigAddress:  d.SystemConfigProxy,
 		BlueTime:               d.BlueTime(l1StartBlock.Time()),
+		SpanBatchTime:          d.SpanBatchTime(l1StartBlock.Time()),
+		ChannelCompressionTime: d.ChannelCompressionTime(l1StartBlock.Time()),
 	}, nil
 }
 
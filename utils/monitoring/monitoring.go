@@ -3,6 +3,7 @@ package monitoring
 import (
 	"context"
 	"fmt"
+	"os"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
@@ -19,15 +20,27 @@ type metricer interface {
 }
 
 // NOTE(pangssu): MaybeStartPprof requires cancelable context to stop http server
-func MaybeStartPprof(ctx context.Context, cfg pprof.CLIConfig, l log.Logger) {
+func MaybeStartPprof(ctx context.Context, cfg pprof.CLIConfig, l log.Logger) error {
+	if cfg.GoroutineDump {
+		pprof.StartGoroutineDumps(ctx, l, os.Stderr)
+	}
 	if cfg.Enabled {
-		l.Info("starting pprof", "addr", cfg.ListenAddr, "port", cfg.ListenPort)
+		token, err := cfg.ReadAuthToken()
+		if err != nil {
+			return err
+		}
+		var opts []pprof.ServerOption
+		if token != "" {
+			opts = append(opts, pprof.WithAuthToken(token))
+		}
+		l.Info("starting pprof", "addr", cfg.ListenAddr, "port", cfg.ListenPort, "auth", token != "")
 		go func() {
-			if err := pprof.ListenAndServe(ctx, cfg.ListenAddr, cfg.ListenPort); err != nil {
+			if err := pprof.ListenAndServe(ctx, cfg.ListenAddr, cfg.ListenPort, opts...); err != nil {
 				l.Error("failed to start pprof", "err", err)
 			}
 		}()
 	}
+	return nil
 }
 
 // NOTE(pangssu): MaybeStartMetrics requires cancelable context to stop http server
@@ -0,0 +1,37 @@
+package utils
+
+import (
+	"net"
+	"os"
+)
+
+const (
+	// SdNotifyReady tells the service manager that the service startup is finished.
+	SdNotifyReady = "READY=1"
+	// SdNotifyStopping tells the service manager that the service is beginning its shutdown.
+	SdNotifyStopping = "STOPPING=1"
+)
+
+// SdNotify sends the given state to the service manager through the socket in $NOTIFY_SOCKET,
+// as described in sd_notify(3). It returns false without an error if the service is not run
+// under a service manager supporting the notification.
+func SdNotify(state string) (bool, error) {
+	socketAddr := &net.UnixAddr{
+		Name: os.Getenv("NOTIFY_SOCKET"),
+		Net:  "unixgram",
+	}
+	if socketAddr.Name == "" {
+		return false, nil
+	}
+
+	conn, err := net.DialUnix(socketAddr.Net, nil, socketAddr)
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close()
+
+	if _, err = conn.Write([]byte(state)); err != nil {
+		return false, err
+	}
+	return true, nil
+}
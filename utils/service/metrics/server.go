@@ -12,12 +12,14 @@ import (
 	"github.com/kroma-network/kroma/utils/service/httputil"
 )
 
+// ListenAndServe serves the metrics of the registry until the context is done. The OpenMetrics format is served to the
+// scrapers negotiating it, exposing the exemplars of the metrics.
 func ListenAndServe(ctx context.Context, r *prometheus.Registry, hostname string, port int) error {
 	addr := net.JoinHostPort(hostname, strconv.Itoa(port))
 	server := &http.Server{
 		Addr: addr,
 		Handler: promhttp.InstrumentMetricHandler(
-			r, promhttp.HandlerFor(r, promhttp.HandlerOpts{}),
+			r, promhttp.HandlerFor(r, promhttp.HandlerOpts{EnableOpenMetrics: true}),
 		),
 	}
 	return httputil.ListenAndServeContext(ctx, server)
@@ -2,7 +2,10 @@ package pprof
 
 import (
 	"errors"
+	"fmt"
 	"math"
+	"os"
+	"strings"
 
 	"github.com/urfave/cli"
 
@@ -10,16 +13,18 @@ import (
 )
 
 const (
-	EnabledFlagName    = "pprof.enabled"
-	ListenAddrFlagName = "pprof.addr"
-	PortFlagName       = "pprof.port"
+	EnabledFlagName       = "pprof.enabled"
+	ListenAddrFlagName    = "pprof.addr"
+	PortFlagName          = "pprof.port"
+	AuthTokenFileFlagName = "pprof.auth-token-file"
+	GoroutineDumpFlagName = "pprof.goroutine-dump"
 )
 
 func CLIFlags(envPrefix string) []cli.Flag {
 	return []cli.Flag{
 		cli.BoolFlag{
 			Name:   EnabledFlagName,
-			Usage:  "Enable the pprof server",
+			Usage:  "Enable the diagnostics server, serving the pprof profiles and the expvar variables",
 			EnvVar: kservice.PrefixEnvVar(envPrefix, "PPROF_ENABLED"),
 		},
 		cli.StringFlag{
@@ -34,6 +39,16 @@ func CLIFlags(envPrefix string) []cli.Flag {
 			Value:  6060,
 			EnvVar: kservice.PrefixEnvVar(envPrefix, "PPROF_PORT"),
 		},
+		cli.StringFlag{
+			Name:   AuthTokenFileFlagName,
+			Usage:  "Path to the file of the bearer token required by the pprof server. If not set, the requests are not authorized",
+			EnvVar: kservice.PrefixEnvVar(envPrefix, "PPROF_AUTH_TOKEN_FILE"),
+		},
+		cli.BoolFlag{
+			Name:   GoroutineDumpFlagName,
+			Usage:  "Dump the stacks of all goroutines to stderr on SIGUSR1, without terminating the process",
+			EnvVar: kservice.PrefixEnvVar(envPrefix, "PPROF_GOROUTINE_DUMP"),
+		},
 	}
 }
 
@@ -41,6 +56,10 @@ type CLIConfig struct {
 	Enabled    bool
 	ListenAddr string
 	ListenPort int
+	// AuthTokenFile is the file of the bearer token required by the server. If empty, the requests are not authorized.
+	AuthTokenFile string
+	// GoroutineDump enables the goroutine dumps on signal, independently of the server.
+	GoroutineDump bool
 }
 
 func (m CLIConfig) Check() error {
@@ -57,8 +76,26 @@ func (m CLIConfig) Check() error {
 
 func ReadCLIConfig(ctx *cli.Context) CLIConfig {
 	return CLIConfig{
-		Enabled:    ctx.GlobalBool(EnabledFlagName),
-		ListenAddr: ctx.GlobalString(ListenAddrFlagName),
-		ListenPort: ctx.GlobalInt(PortFlagName),
+		Enabled:       ctx.GlobalBool(EnabledFlagName),
+		ListenAddr:    ctx.GlobalString(ListenAddrFlagName),
+		ListenPort:    ctx.GlobalInt(PortFlagName),
+		AuthTokenFile: ctx.GlobalString(AuthTokenFileFlagName),
+		GoroutineDump: ctx.GlobalBool(GoroutineDumpFlagName),
+	}
+}
+
+// ReadAuthToken reads the bearer token from the AuthTokenFile, or returns an empty token if it is not set.
+func (m CLIConfig) ReadAuthToken() (string, error) {
+	if m.AuthTokenFile == "" {
+		return "", nil
+	}
+	data, err := os.ReadFile(m.AuthTokenFile)
+	if err != nil {
+		return "", fmt.Errorf("failed to read pprof auth token: %w", err)
+	}
+	token := strings.TrimSpace(string(data))
+	if token == "" {
+		return "", fmt.Errorf("empty pprof auth token in path %s", m.AuthTokenFile)
 	}
+	return token, nil
 }
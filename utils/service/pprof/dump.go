@@ -0,0 +1,37 @@
+package pprof
+
+import (
+	"context"
+	"io"
+	"os"
+	"os/signal"
+	"runtime/pprof"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// StartGoroutineDumps writes the stacks of all goroutines to w whenever the process receives the dump signal,
+// SIGUSR1, until ctx is done. Unlike SIGQUIT, the dump signal does not terminate the process.
+// It is a no-op on platforms without the dump signal.
+func StartGoroutineDumps(ctx context.Context, l log.Logger, w io.Writer) {
+	if dumpSignal == nil {
+		l.Warn("goroutine dumps on signal are not supported on this platform")
+		return
+	}
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, dumpSignal)
+	go func() {
+		defer signal.Stop(sigCh)
+		for {
+			select {
+			case <-sigCh:
+				l.Info("dumping goroutine stacks")
+				if err := pprof.Lookup("goroutine").WriteTo(w, 2); err != nil {
+					l.Error("failed to dump goroutine stacks", "err", err)
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
@@ -0,0 +1,10 @@
+//go:build !windows
+
+package pprof
+
+import (
+	"os"
+	"syscall"
+)
+
+var dumpSignal os.Signal = syscall.SIGUSR1
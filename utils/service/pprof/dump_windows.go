@@ -0,0 +1,7 @@
+//go:build windows
+
+package pprof
+
+import "os"
+
+var dumpSignal os.Signal
@@ -2,6 +2,8 @@ package pprof
 
 import (
 	"context"
+	"crypto/subtle"
+	"expvar"
 	"net"
 	"net/http"
 	"net/http/pprof"
@@ -10,7 +12,26 @@ import (
 	"github.com/kroma-network/kroma/utils/service/httputil"
 )
 
-func ListenAndServe(ctx context.Context, hostname string, port int) error {
+type serverConfig struct {
+	authToken string
+}
+
+type ServerOption func(*serverConfig)
+
+// WithAuthToken requires the requests to the diagnostics server to be authorized by the bearer token.
+func WithAuthToken(token string) ServerOption {
+	return func(c *serverConfig) {
+		c.authToken = token
+	}
+}
+
+// Handler returns the handler of the diagnostics server, serving the pprof profiles and the expvar variables.
+func Handler(opts ...ServerOption) http.Handler {
+	var cfg serverConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
 	mux := http.NewServeMux()
 
 	// have to do below to support multiple servers, since the
@@ -20,11 +41,27 @@ func ListenAndServe(ctx context.Context, hostname string, port int) error {
 	mux.Handle("/debug/pprof/profile", http.HandlerFunc(pprof.Profile))
 	mux.Handle("/debug/pprof/symbol", http.HandlerFunc(pprof.Symbol))
 	mux.Handle("/debug/pprof/trace", http.HandlerFunc(pprof.Trace))
+	mux.Handle("/debug/vars", expvar.Handler())
+
+	if cfg.authToken == "" {
+		return mux
+	}
+	expected := []byte("Bearer " + cfg.authToken)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), expected) != 1 {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		mux.ServeHTTP(w, r)
+	})
+}
 
+func ListenAndServe(ctx context.Context, hostname string, port int, opts ...ServerOption) error {
 	addr := net.JoinHostPort(hostname, strconv.Itoa(port))
 	server := &http.Server{
 		Addr:    addr,
-		Handler: mux,
+		Handler: Handler(opts...),
 	}
 	return httputil.ListenAndServeContext(ctx, server)
 }
@@ -0,0 +1,54 @@
+package pprof
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler(t *testing.T) {
+	get := func(t *testing.T, h http.Handler, path string, auth string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if auth != "" {
+			req.Header.Set("Authorization", auth)
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("without auth token", func(t *testing.T) {
+		h := Handler()
+		require.Equal(t, http.StatusOK, get(t, h, "/debug/pprof/", "").Code)
+		rec := get(t, h, "/debug/vars", "")
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.Contains(t, rec.Body.String(), "memstats")
+	})
+
+	t.Run("with auth token", func(t *testing.T) {
+		h := Handler(WithAuthToken("secret"))
+		require.Equal(t, http.StatusUnauthorized, get(t, h, "/debug/pprof/", "").Code)
+		require.Equal(t, http.StatusUnauthorized, get(t, h, "/debug/vars", "Bearer other").Code)
+		require.Equal(t, http.StatusOK, get(t, h, "/debug/pprof/", "Bearer secret").Code)
+		require.Equal(t, http.StatusOK, get(t, h, "/debug/vars", "Bearer secret").Code)
+	})
+}
+
+func TestReadAuthToken(t *testing.T) {
+	token, err := CLIConfig{}.ReadAuthToken()
+	require.NoError(t, err)
+	require.Empty(t, token)
+
+	path := t.TempDir() + "/token"
+	require.NoError(t, os.WriteFile(path, []byte("secret\n"), 0o600))
+	token, err = CLIConfig{AuthTokenFile: path}.ReadAuthToken()
+	require.NoError(t, err)
+	require.Equal(t, "secret", token)
+
+	require.NoError(t, os.WriteFile(path, []byte(" \n"), 0o600))
+	_, err = CLIConfig{AuthTokenFile: path}.ReadAuthToken()
+	require.ErrorContains(t, err, "empty pprof auth token")
+}
@@ -0,0 +1,189 @@
+// Package proxy routes the outbound HTTP, WebSocket and gRPC connections of a service through an HTTP forward proxy.
+package proxy
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/gorilla/websocket"
+	"golang.org/x/net/http/httpproxy"
+)
+
+// Direct is the proxy of an endpoint override that connects to the endpoint without a proxy.
+const Direct = "direct"
+
+// The buffer sizes of the WebSocket connections, the same as the default of the geth RPC client.
+const (
+	wsReadBuffer  = 1024
+	wsWriteBuffer = 1024
+)
+
+// Config routes the outbound connections through an HTTP forward proxy. The connections to https, wss and gRPC
+// endpoints are tunneled through the proxy with CONNECT, so the proxy never sees their contents.
+// A nil *Config uses the proxy of the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables.
+type Config struct {
+	// proxy returns the proxy of an endpoint without an override, nil to connect directly.
+	proxy func(*url.URL) (*url.URL, error)
+	// endpoints are the proxies of the overridden endpoints by host[:port], nil to connect directly.
+	endpoints map[string]*url.URL
+}
+
+func NewConfig(cfg CLIConfig) (*Config, error) {
+	if err := cfg.Check(); err != nil {
+		return nil, fmt.Errorf("invalid proxy config: %w", err)
+	}
+	endpoints, err := parseEndpoints(cfg.Endpoints)
+	if err != nil {
+		return nil, err
+	}
+	c := &Config{endpoints: endpoints}
+	if cfg.URL != "" {
+		c.proxy = (&httpproxy.Config{HTTPProxy: cfg.URL, HTTPSProxy: cfg.URL, NoProxy: cfg.NoProxy}).ProxyFunc()
+	} else {
+		c.proxy = func(u *url.URL) (*url.URL, error) {
+			return http.ProxyFromEnvironment(&http.Request{URL: u})
+		}
+	}
+	return c, nil
+}
+
+// Proxy returns the proxy of the request, or nil to connect directly, as the Proxy of an http.Transport.
+// Loopback endpoints are only connected through a proxy if overridden.
+func (c *Config) Proxy(req *http.Request) (*url.URL, error) {
+	if c == nil {
+		return http.ProxyFromEnvironment(req)
+	}
+	for _, endpoint := range []string{req.URL.Host, canonicalAddr(req.URL), req.URL.Hostname()} {
+		if p, ok := c.endpoints[endpoint]; ok {
+			return p, nil
+		}
+	}
+	return c.proxy(req.URL)
+}
+
+// HTTPClient returns an HTTP client connecting through the proxy, without a timeout if 0.
+func (c *Config) HTTPClient(timeout time.Duration) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = c.Proxy
+	return &http.Client{Transport: transport, Timeout: timeout}
+}
+
+// WebsocketDialer returns a WebSocket dialer upgrading the connections through the proxy.
+func (c *Config) WebsocketDialer() websocket.Dialer {
+	return websocket.Dialer{
+		Proxy:           c.Proxy,
+		ReadBufferSize:  wsReadBuffer,
+		WriteBufferSize: wsWriteBuffer,
+	}
+}
+
+// RPCOptions returns the options of an RPC client connecting over HTTP and WebSocket through the proxy.
+func (c *Config) RPCOptions() []rpc.ClientOption {
+	return []rpc.ClientOption{
+		rpc.WithHTTPClient(c.HTTPClient(0)),
+		rpc.WithWebsocketDialer(c.WebsocketDialer()),
+	}
+}
+
+// DialContext connects to the host:port address, tunneled through the proxy with CONNECT,
+// e.g. as the dialer of a gRPC client. The address is matched as an https endpoint.
+func (c *Config) DialContext(ctx context.Context, addr string) (net.Conn, error) {
+	var d net.Dialer
+	p, err := c.Proxy(&http.Request{URL: &url.URL{Scheme: "https", Host: addr}})
+	if err != nil {
+		return nil, err
+	}
+	if p == nil {
+		return d.DialContext(ctx, "tcp", addr)
+	}
+	conn, err := d.DialContext(ctx, "tcp", canonicalAddr(p))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to proxy %s: %w", p.Redacted(), err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+		defer func() { _ = conn.SetDeadline(time.Time{}) }()
+	}
+	bufConn, err := connect(conn, p, addr)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return bufConn, nil
+}
+
+// connect requests the proxy on the connection to tunnel to the address.
+func connect(conn net.Conn, p *url.URL, addr string) (net.Conn, error) {
+	req := &http.Request{
+		Method: http.MethodConnect,
+		URL:    &url.URL{Opaque: addr},
+		Host:   addr,
+		Header: make(http.Header),
+	}
+	if p.User != nil {
+		password, _ := p.User.Password()
+		credential := base64.StdEncoding.EncodeToString([]byte(p.User.Username() + ":" + password))
+		req.Header.Set("Proxy-Authorization", "Basic "+credential)
+	}
+	if err := req.Write(conn); err != nil {
+		return nil, fmt.Errorf("failed to request proxy %s to connect to %s: %w", p.Redacted(), addr, err)
+	}
+	br := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(br, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the response of proxy %s: %w", p.Redacted(), err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("proxy %s refused to connect to %s: %s", p.Redacted(), addr, resp.Status)
+	}
+	return &bufferedConn{Conn: conn, r: br}, nil
+}
+
+// bufferedConn reads what the endpoint sent after the response of the proxy from its buffer first.
+type bufferedConn struct {
+	net.Conn
+	r *bufio.Reader
+}
+
+func (c *bufferedConn) Read(b []byte) (int, error) {
+	return c.r.Read(b)
+}
+
+// parseProxyURL parses the URL of an HTTP forward proxy, with optional basic auth credentials.
+func parseProxyURL(raw string) (*url.URL, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		// the error would include the credentials
+		return nil, errors.New("invalid proxy url")
+	}
+	if u.Scheme != "http" {
+		return nil, fmt.Errorf("proxy url %s must be http, the connections to https endpoints are tunneled", u.Redacted())
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("proxy url %s must have a host", u.Redacted())
+	}
+	return u, nil
+}
+
+// canonicalAddr returns the host:port of the URL, with the default port of its scheme if it has none.
+func canonicalAddr(u *url.URL) string {
+	port := u.Port()
+	if port == "" {
+		switch u.Scheme {
+		case "https", "wss":
+			port = "443"
+		default:
+			port = "80"
+		}
+	}
+	return net.JoinHostPort(u.Hostname(), port)
+}
@@ -0,0 +1,74 @@
+package tracing
+
+import (
+	"errors"
+
+	"github.com/urfave/cli"
+
+	kservice "github.com/kroma-network/kroma/utils/service"
+)
+
+const (
+	EnabledFlagName     = "tracing.enabled"
+	EndpointFlagName    = "tracing.endpoint"
+	InsecureFlagName    = "tracing.insecure"
+	SampleRatioFlagName = "tracing.sample-ratio"
+)
+
+func CLIFlags(envPrefix string) []cli.Flag {
+	return []cli.Flag{
+		cli.BoolFlag{
+			Name:   EnabledFlagName,
+			Usage:  "Enable the export of OpenTelemetry traces",
+			EnvVar: kservice.PrefixEnvVar(envPrefix, "TRACING_ENABLED"),
+		},
+		cli.StringFlag{
+			Name:   EndpointFlagName,
+			Usage:  "OTLP gRPC endpoint (host:port) to export the traces to",
+			Value:  "localhost:4317",
+			EnvVar: kservice.PrefixEnvVar(envPrefix, "TRACING_ENDPOINT"),
+		},
+		cli.BoolFlag{
+			Name:   InsecureFlagName,
+			Usage:  "Export the traces without TLS",
+			EnvVar: kservice.PrefixEnvVar(envPrefix, "TRACING_INSECURE"),
+		},
+		cli.Float64Flag{
+			Name:   SampleRatioFlagName,
+			Usage:  "Ratio (0-1) of the traces to sample",
+			Value:  1,
+			EnvVar: kservice.PrefixEnvVar(envPrefix, "TRACING_SAMPLE_RATIO"),
+		},
+	}
+}
+
+type CLIConfig struct {
+	Enabled     bool
+	Endpoint    string
+	Insecure    bool
+	SampleRatio float64
+}
+
+func (c CLIConfig) Check() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	if c.Endpoint == "" {
+		return errors.New("empty tracing endpoint")
+	}
+	if c.SampleRatio < 0 || c.SampleRatio > 1 {
+		return errors.New("tracing sample ratio must be between 0 and 1")
+	}
+
+	return nil
+}
+
+func ReadCLIConfig(ctx *cli.Context) CLIConfig {
+	return CLIConfig{
+		Enabled:     ctx.GlobalBool(EnabledFlagName),
+		Endpoint:    ctx.GlobalString(EndpointFlagName),
+		Insecure:    ctx.GlobalBool(InsecureFlagName),
+		SampleRatio: ctx.GlobalFloat64(SampleRatioFlagName),
+	}
+}
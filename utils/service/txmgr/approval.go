@@ -0,0 +1,230 @@
+package txmgr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// ErrTxRejected is returned by Send if the transaction was rejected while waiting for approval.
+var ErrTxRejected = errors.New("transaction rejected by the operator")
+
+// The reasons a transaction requires approval.
+const (
+	ApprovalReasonValue   = "value"
+	ApprovalReasonGasCost = "gas-cost"
+)
+
+// ApprovalPolicy decides which transactions require the approval of an operator before they are sent.
+type ApprovalPolicy struct {
+	// MaxValue is the maximum value (in wei) of a transaction sent without approval. No limit if nil.
+	MaxValue *big.Int
+	// MaxGasCost is the maximum gas cost (in wei) of a transaction sent without approval, i.e. its gas limit
+	// multiplied by its gas fee cap. No limit if nil.
+	MaxGasCost *big.Int
+}
+
+func (p ApprovalPolicy) Enabled() bool {
+	return p.MaxValue != nil || p.MaxGasCost != nil
+}
+
+// reason returns why the transaction requires approval, or an empty string if it does not.
+func (p ApprovalPolicy) reason(tx *types.Transaction) string {
+	if p.MaxValue != nil && tx.Value() != nil && tx.Value().Cmp(p.MaxValue) > 0 {
+		return ApprovalReasonValue
+	}
+	if p.MaxGasCost != nil && gasCost(tx).Cmp(p.MaxGasCost) > 0 {
+		return ApprovalReasonGasCost
+	}
+	return ""
+}
+
+func gasCost(tx *types.Transaction) *big.Int {
+	return new(big.Int).Mul(new(big.Int).SetUint64(tx.Gas()), tx.GasFeeCap())
+}
+
+// PendingApproval is a signed transaction waiting for the approval of an operator.
+type PendingApproval struct {
+	ID        uint64          `json:"id"`
+	Time      time.Time       `json:"time"`
+	Reason    string          `json:"reason"`
+	Hash      common.Hash     `json:"hash"`
+	To        *common.Address `json:"to"`
+	Nonce     hexutil.Uint64  `json:"nonce"`
+	Gas       hexutil.Uint64  `json:"gas"`
+	GasFeeCap *hexutil.Big    `json:"gasFeeCap"`
+	GasCost   *hexutil.Big    `json:"gasCost"`
+	Value     *hexutil.Big    `json:"value"`
+	Data      hexutil.Bytes   `json:"data"`
+}
+
+type approvalRequest struct {
+	PendingApproval
+	decision chan bool
+}
+
+// ApprovalQueue parks the transactions requiring approval by its ApprovalPolicy until an operator approves
+// or rejects them, e.g. through the admin RPC of the ApprovalAPI.
+type ApprovalQueue struct {
+	policy ApprovalPolicy
+
+	mu      sync.Mutex
+	nextID  uint64
+	pending map[uint64]*approvalRequest
+}
+
+func NewApprovalQueue(policy ApprovalPolicy) *ApprovalQueue {
+	return &ApprovalQueue{
+		policy:  policy,
+		pending: make(map[uint64]*approvalRequest),
+	}
+}
+
+// Pending returns the transactions waiting for approval, in the order they were parked.
+func (q *ApprovalQueue) Pending() []PendingApproval {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	pending := make([]PendingApproval, 0, len(q.pending))
+	for _, req := range q.pending {
+		pending = append(pending, req.PendingApproval)
+	}
+	sort.Slice(pending, func(i, j int) bool { return pending[i].ID < pending[j].ID })
+	return pending
+}
+
+// Approve sends the transaction waiting for approval.
+func (q *ApprovalQueue) Approve(id uint64) error {
+	return q.decide(id, true)
+}
+
+// Reject drops the transaction waiting for approval, and Send returns ErrTxRejected.
+func (q *ApprovalQueue) Reject(id uint64) error {
+	return q.decide(id, false)
+}
+
+func (q *ApprovalQueue) decide(id uint64, approved bool) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	req, ok := q.pending[id]
+	if !ok {
+		return fmt.Errorf("no transaction waiting for approval with id %d", id)
+	}
+	delete(q.pending, id)
+	req.decision <- approved
+	return nil
+}
+
+func (q *ApprovalQueue) drop(id uint64) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	delete(q.pending, id)
+}
+
+type approvalWaitKey struct{}
+
+// WithApprovalWait returns the context of a Send calling waiting once the transaction is parked for approval, e.g. for
+// the caller to send the next transactions meanwhile.
+func WithApprovalWait(ctx context.Context, waiting func()) context.Context {
+	return context.WithValue(ctx, approvalWaitKey{}, waiting)
+}
+
+// reason returns why the transaction requires approval, or an empty string if it does not or the queue is nil.
+func (q *ApprovalQueue) reason(tx *types.Transaction) string {
+	if q == nil {
+		return ""
+	}
+	return q.policy.reason(tx)
+}
+
+// awaitApproval parks the transaction requiring approval for the reason, and blocks until it is approved.
+// The transaction is dropped from the queue if the context is done or the candidate is cancelled first.
+func (m *SimpleTxManager) awaitApproval(ctx context.Context, tx *types.Transaction, reason string, cancelledCh <-chan struct{}, entry *inFlightEntry) error {
+	q := m.Approvals
+	q.mu.Lock()
+	id := q.nextID
+	q.nextID++
+	req := &approvalRequest{
+		PendingApproval: PendingApproval{
+			ID:        id,
+			Time:      time.Now().UTC(),
+			Reason:    reason,
+			Hash:      tx.Hash(),
+			To:        tx.To(),
+			Nonce:     hexutil.Uint64(tx.Nonce()),
+			Gas:       hexutil.Uint64(tx.Gas()),
+			GasFeeCap: (*hexutil.Big)(tx.GasFeeCap()),
+			GasCost:   (*hexutil.Big)(gasCost(tx)),
+			Value:     (*hexutil.Big)(tx.Value()),
+			Data:      tx.Data(),
+		},
+		// buffered, to not block the decision if the context is done concurrently.
+		decision: make(chan bool, 1),
+	}
+	q.pending[id] = req
+	q.mu.Unlock()
+	m.InFlight.update(entry, func(e *inFlightEntry) { e.State = InFlightStateAwaitingApproval })
+
+	l := m.l.New("id", id, "hash", tx.Hash(), "reason", reason)
+	l.Warn("transaction is waiting for approval", "to", tx.To(), "value", tx.Value(), "gasCost", gasCost(tx))
+	if waiting, ok := ctx.Value(approvalWaitKey{}).(func()); ok {
+		waiting()
+	}
+	select {
+	case approved := <-req.decision:
+		if !approved {
+			l.Warn("transaction was rejected")
+			return ErrTxRejected
+		}
+		l.Info("transaction was approved")
+		return nil
+	case <-ctx.Done():
+		q.drop(id)
+		return ctx.Err()
+	case <-cancelledCh:
+		q.drop(id)
+		l.Info("transaction waiting for approval was cancelled")
+		return ErrTxCancelled
+	}
+}
+
+// ApprovalAPI is the admin RPC API to list, approve and reject the transactions waiting for approval.
+type ApprovalAPI struct {
+	q *ApprovalQueue
+}
+
+func NewApprovalAPI(q *ApprovalQueue) *ApprovalAPI {
+	return &ApprovalAPI{q: q}
+}
+
+func (a *ApprovalAPI) PendingApprovals(_ context.Context) []PendingApproval {
+	return a.q.Pending()
+}
+
+func (a *ApprovalAPI) ApproveTransaction(_ context.Context, id uint64) error {
+	return a.q.Approve(id)
+}
+
+func (a *ApprovalAPI) RejectTransaction(_ context.Context, id uint64) error {
+	return a.q.Reject(id)
+}
+
+// ApprovalAPIs returns the admin RPC APIs of the approval queue, or none if the queue is nil or the admin RPC is not
+// enabled.
+func ApprovalAPIs(q *ApprovalQueue, enableAdmin bool) []rpc.API {
+	if q == nil || !enableAdmin {
+		return nil
+	}
+	return []rpc.API{{
+		Namespace: "admin",
+		Service:   NewApprovalAPI(q),
+	}}
+}
@@ -0,0 +1,139 @@
+package txmgr
+
+import (
+	"context"
+	"math/big"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApprovalPolicy(t *testing.T) {
+	tx := types.NewTx(&types.DynamicFeeTx{
+		Gas:       100,
+		GasFeeCap: big.NewInt(10),
+		Value:     big.NewInt(5),
+	})
+
+	require.Empty(t, ApprovalPolicy{}.reason(tx))
+	require.Empty(t, ApprovalPolicy{MaxValue: big.NewInt(5), MaxGasCost: big.NewInt(1000)}.reason(tx))
+	require.Equal(t, ApprovalReasonValue, ApprovalPolicy{MaxValue: big.NewInt(4)}.reason(tx))
+	require.Equal(t, ApprovalReasonGasCost, ApprovalPolicy{MaxGasCost: big.NewInt(999)}.reason(tx))
+}
+
+func TestCLIConfigApprovalPolicy(t *testing.T) {
+	policy, err := CLIConfig{}.ApprovalPolicy()
+	require.NoError(t, err)
+	require.False(t, policy.Enabled())
+
+	policy, err = CLIConfig{ApprovalMaxValue: "0", ApprovalMaxGasCost: "100000000000000000000"}.ApprovalPolicy()
+	require.NoError(t, err)
+	require.Nil(t, policy.MaxValue, "a 0 threshold is disabled")
+	expected, _ := new(big.Int).SetString("100000000000000000000", 10)
+	require.Equal(t, expected, policy.MaxGasCost, "the thresholds may exceed 64 bits")
+
+	_, err = CLIConfig{ApprovalMaxValue: "1e18"}.ApprovalPolicy()
+	require.ErrorContains(t, err, ApprovalMaxValueFlagName)
+	_, err = CLIConfig{ApprovalMaxGasCost: "-1"}.ApprovalPolicy()
+	require.ErrorContains(t, err, ApprovalMaxGasCostFlagName)
+}
+
+func newApprovalTestHarness(t *testing.T, policy ApprovalPolicy) (*testHarness, *atomic.Bool) {
+	cfg := configWithNumConfs(1)
+	cfg.Approvals = NewApprovalQueue(policy)
+	h := newTestHarnessWithConfig(t, cfg)
+
+	var published atomic.Bool
+	h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+		published.Store(true)
+		txHash := tx.Hash()
+		h.backend.mine(&txHash, tx.GasFeeCap())
+		return nil
+	})
+	return h, &published
+}
+
+// sendAsync sends the candidate, and returns the pending approval of the parked transaction and the result of Send.
+func sendAsync(ctx context.Context, t *testing.T, h *testHarness) (PendingApproval, <-chan error) {
+	errs := make(chan error, 1)
+	go func() {
+		_, err := h.mgr.Send(ctx, h.createTxCandidate())
+		errs <- err
+	}()
+	require.Eventually(t, func() bool { return len(h.mgr.Approvals.Pending()) == 1 }, time.Second, time.Millisecond)
+	return h.mgr.Approvals.Pending()[0], errs
+}
+
+func TestTxMgrApproval(t *testing.T) {
+	t.Parallel()
+
+	t.Run("below thresholds", func(t *testing.T) {
+		h, published := newApprovalTestHarness(t, ApprovalPolicy{MaxValue: big.NewInt(1)})
+		_, err := h.mgr.Send(context.Background(), h.createTxCandidate())
+		require.ErrorIs(t, err, ErrTxReceiptNotSucceed)
+		require.True(t, published.Load())
+	})
+
+	t.Run("approved", func(t *testing.T) {
+		h, published := newApprovalTestHarness(t, ApprovalPolicy{MaxValue: big.NewInt(0)})
+		pending, errs := sendAsync(context.Background(), t, h)
+		require.Equal(t, ApprovalReasonValue, pending.Reason)
+		require.Equal(t, big.NewInt(1), pending.Value.ToInt())
+		require.False(t, published.Load(), "transaction must not be sent before it is approved")
+
+		require.NoError(t, NewApprovalAPI(h.mgr.Approvals).ApproveTransaction(context.Background(), pending.ID))
+		require.ErrorIs(t, <-errs, ErrTxReceiptNotSucceed)
+		require.True(t, published.Load())
+		require.Empty(t, h.mgr.Approvals.Pending())
+	})
+
+	t.Run("rejected", func(t *testing.T) {
+		h, published := newApprovalTestHarness(t, ApprovalPolicy{MaxGasCost: big.NewInt(1)})
+		pending, errs := sendAsync(context.Background(), t, h)
+		require.Equal(t, ApprovalReasonGasCost, pending.Reason)
+
+		require.NoError(t, NewApprovalAPI(h.mgr.Approvals).RejectTransaction(context.Background(), pending.ID))
+		require.ErrorIs(t, <-errs, ErrTxRejected)
+		require.False(t, published.Load())
+		require.Error(t, h.mgr.Approvals.Approve(pending.ID), "decided transaction must not be approved again")
+	})
+
+	t.Run("does not hold the other transactions", func(t *testing.T) {
+		h, published := newApprovalTestHarness(t, ApprovalPolicy{MaxValue: big.NewInt(0)})
+		parked := make(chan struct{})
+		errs := make(chan error, 1)
+		go func() {
+			_, err := h.mgr.Send(WithApprovalWait(context.Background(), func() { close(parked) }), h.createTxCandidate())
+			errs <- err
+		}()
+		select {
+		case <-parked:
+		case <-time.After(time.Second):
+			t.Fatal("transaction was not parked for approval")
+		}
+
+		candidate := h.createTxCandidate()
+		candidate.Value = nil
+		_, err := h.mgr.Send(context.Background(), candidate)
+		require.ErrorIs(t, err, ErrTxReceiptNotSucceed)
+		require.True(t, published.Load(), "transaction below the thresholds must be sent while the other one waits")
+
+		pending := h.mgr.Approvals.Pending()
+		require.Len(t, pending, 1)
+		require.NoError(t, h.mgr.Approvals.Approve(pending[0].ID))
+		require.ErrorIs(t, <-errs, ErrTxReceiptNotSucceed)
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		h, published := newApprovalTestHarness(t, ApprovalPolicy{MaxValue: big.NewInt(0)})
+		ctx, cancel := context.WithCancel(context.Background())
+		_, errs := sendAsync(ctx, t, h)
+		cancel()
+		require.ErrorIs(t, <-errs, context.Canceled)
+		require.False(t, published.Load())
+		require.Empty(t, h.mgr.Approvals.Pending())
+	})
+}
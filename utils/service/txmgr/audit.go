@@ -0,0 +1,152 @@
+package txmgr
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// auditDayFormat is the layout of the UTC day the audit files are named by.
+const auditDayFormat = "2006-01-02"
+
+// AuditBroadcastHook exports the EIP-2718 encoding of every signed transaction as a hex line, to a file per service
+// and UTC day: <dir>/<service>/<YYYY-MM-DD>.txt. Every signed transaction is exported, including the fee bumps and
+// the cancellations replacing a transaction that never mined, so that what was signed can be re-verified offline
+// with any tool decoding raw transactions, see ReadAuditFile.
+type AuditBroadcastHook struct {
+	dir string
+
+	mu sync.Mutex
+	// files are the open audit files by service, and days the UTC day each of them is of
+	files map[string]*os.File
+	days  map[string]string
+	// closed is whether the hook is closed, so that no file is opened anymore
+	closed bool
+}
+
+func NewAuditBroadcastHook(dir string) (*AuditBroadcastHook, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create broadcast audit dir: %w", err)
+	}
+	return &AuditBroadcastHook{dir: dir, files: make(map[string]*os.File), days: make(map[string]string)}, nil
+}
+
+// AuditFilePath returns the path of the audit file of the service and UTC day in the dir.
+func AuditFilePath(dir string, service string, day string) string {
+	return filepath.Join(dir, strings.ReplaceAll(service, string(filepath.Separator), "_"), day+".txt")
+}
+
+func (h *AuditBroadcastHook) OnBroadcast(_ context.Context, record BroadcastRecord) error {
+	line := hexutil.Encode(record.RawTx) + "\n"
+	day := record.Time.UTC().Format(auditDayFormat)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	file, err := h.file(record.Service, day)
+	if err != nil {
+		return err
+	}
+	if _, err := file.WriteString(line); err != nil {
+		return fmt.Errorf("failed to write broadcast audit record: %w", err)
+	}
+	if err := file.Sync(); err != nil {
+		return fmt.Errorf("failed to sync broadcast audit file: %w", err)
+	}
+	return nil
+}
+
+// file returns the audit file of the service and day, and closes the file of the previous day of the service.
+func (h *AuditBroadcastHook) file(service string, day string) (*os.File, error) {
+	if h.closed {
+		return nil, fmt.Errorf("failed to open broadcast audit file: %w", os.ErrClosed)
+	}
+	if file, ok := h.files[service]; ok {
+		if h.days[service] == day {
+			return file, nil
+		}
+		if err := file.Close(); err != nil {
+			return nil, fmt.Errorf("failed to close broadcast audit file: %w", err)
+		}
+		delete(h.files, service)
+	}
+
+	path := AuditFilePath(h.dir, service, day)
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create broadcast audit dir: %w", err)
+	}
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o640)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open broadcast audit file: %w", err)
+	}
+	h.files[service], h.days[service] = file, day
+	return file, nil
+}
+
+// Close closes the open audit files. The transactions broadcast afterwards fail to be exported.
+func (h *AuditBroadcastHook) Close() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.closed = true
+	var firstErr error
+	for service, file := range h.files {
+		if err := file.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+		delete(h.files, service)
+	}
+	return firstErr
+}
+
+// AuditedTx is a transaction of an audit file, with the sender recovered from its signature.
+type AuditedTx struct {
+	Tx   *types.Transaction
+	From common.Address
+}
+
+// ReadAuditFile decodes the raw transactions of an audit file, and recovers their senders from their signatures on
+// the chain. It fails on the first line that is not a validly signed transaction of the chain.
+func ReadAuditFile(path string, chainID *big.Int) ([]AuditedTx, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open broadcast audit file: %w", err)
+	}
+	defer file.Close()
+
+	signer := types.LatestSignerForChainID(chainID)
+	var txs []AuditedTx
+	scanner := bufio.NewScanner(file)
+	// the raw transactions of the batches exceed the default buffer
+	scanner.Buffer(nil, 1<<24)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		raw, err := hexutil.Decode(line)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode raw transaction %d: %w", len(txs), err)
+		}
+		tx := new(types.Transaction)
+		if err := tx.UnmarshalBinary(raw); err != nil {
+			return nil, fmt.Errorf("failed to decode raw transaction %d: %w", len(txs), err)
+		}
+		from, err := types.Sender(signer, tx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to recover sender of transaction %s: %w", tx.Hash(), err)
+		}
+		txs = append(txs, AuditedTx{Tx: tx, From: from})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read broadcast audit file: %w", err)
+	}
+	return txs, nil
+}
@@ -0,0 +1,79 @@
+package txmgr
+
+import (
+	"context"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditBroadcastHook(t *testing.T) {
+	dir := t.TempDir()
+	hook, err := NewAuditBroadcastHook(dir)
+	require.NoError(t, err)
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	chainID := big.NewInt(1)
+	signer := types.LatestSignerForChainID(chainID)
+	signed := func(nonce uint64, tip int64) *types.Transaction {
+		tx, err := types.SignNewTx(key, signer, &types.DynamicFeeTx{
+			ChainID:   chainID,
+			Nonce:     nonce,
+			Gas:       21000,
+			GasTipCap: big.NewInt(tip),
+			GasFeeCap: big.NewInt(100),
+		})
+		require.NoError(t, err)
+		return tx
+	}
+
+	day := time.Date(2024, 5, 1, 23, 59, 0, 0, time.UTC)
+	// the fee bump replacing the first transaction of the day is exported too
+	txs := []*types.Transaction{signed(1, 1), signed(1, 2), signed(2, 1)}
+	times := []time.Time{day, day, day.Add(2 * time.Minute)}
+	for i, tx := range txs {
+		record, err := newBroadcastRecord("validator", chainID, from, tx)
+		require.NoError(t, err)
+		record.Time = times[i]
+		require.NoError(t, hook.OnBroadcast(context.Background(), record))
+	}
+	record, err := newBroadcastRecord("batcher", chainID, from, signed(3, 1))
+	require.NoError(t, err)
+	record.Time = day
+	require.NoError(t, hook.OnBroadcast(context.Background(), record))
+	require.NoError(t, hook.Close())
+
+	audited, err := ReadAuditFile(AuditFilePath(dir, "validator", "2024-05-01"), chainID)
+	require.NoError(t, err)
+	require.Len(t, audited, 2)
+	for i, tx := range audited {
+		require.Equal(t, txs[i].Hash(), tx.Tx.Hash())
+		require.Equal(t, from, tx.From)
+	}
+	audited, err = ReadAuditFile(AuditFilePath(dir, "validator", "2024-05-02"), chainID)
+	require.NoError(t, err)
+	require.Len(t, audited, 1)
+	require.Equal(t, txs[2].Hash(), audited[0].Tx.Hash())
+	audited, err = ReadAuditFile(AuditFilePath(dir, "batcher", "2024-05-01"), chainID)
+	require.NoError(t, err)
+	require.Len(t, audited, 1)
+
+	// the transactions are not validly signed on another chain
+	_, err = ReadAuditFile(AuditFilePath(dir, "batcher", "2024-05-01"), big.NewInt(2))
+	require.ErrorContains(t, err, "failed to recover sender")
+}
+
+func TestReadAuditFileRejectsInvalidLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.txt")
+	require.NoError(t, os.WriteFile(path, []byte("0x02f8\n"), 0o600))
+	_, err := ReadAuditFile(path, big.NewInt(1))
+	require.ErrorContains(t, err, "failed to decode raw transaction 0")
+}
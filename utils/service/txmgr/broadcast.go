@@ -0,0 +1,93 @@
+package txmgr
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// TxBroadcaster is the minimal backend required to broadcast an already signed
+// transaction to an additional L1 endpoint.
+type TxBroadcaster interface {
+	// SendTransaction submits a signed transaction to L1.
+	SendTransaction(ctx context.Context, tx *types.Transaction) error
+}
+
+// backupBroadcaster broadcasts signed transactions to the backup L1 endpoints.
+// It remembers which transaction hashes were already accepted by each endpoint,
+// so that republishing the same transaction (e.g. when a fee bump was not necessary)
+// does not hit the backups again.
+type backupBroadcaster struct {
+	backends []TxBroadcaster
+	l        log.Logger
+
+	mu       sync.Mutex
+	accepted []map[common.Hash]struct{}
+}
+
+func newBackupBroadcaster(backends []TxBroadcaster, l log.Logger) *backupBroadcaster {
+	accepted := make([]map[common.Hash]struct{}, len(backends))
+	for i := range accepted {
+		accepted[i] = make(map[common.Hash]struct{})
+	}
+	return &backupBroadcaster{
+		backends: backends,
+		l:        l,
+		accepted: accepted,
+	}
+}
+
+// broadcast sends the transaction to all backup endpoints that have not accepted it yet.
+// It blocks until every endpoint responded and returns true if at least one of them
+// holds the transaction in its mempool.
+func (b *backupBroadcaster) broadcast(ctx context.Context, tx *types.Transaction) bool {
+	txHash := tx.Hash()
+
+	var (
+		wg       sync.WaitGroup
+		resMu    sync.Mutex
+		accepted bool
+	)
+	for i, backend := range b.backends {
+		if b.isAccepted(i, txHash) {
+			resMu.Lock()
+			accepted = true
+			resMu.Unlock()
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int, backend TxBroadcaster) {
+			defer wg.Done()
+			err := backend.SendTransaction(ctx, tx)
+			if err != nil && !errStringMatch(err, txpool.ErrAlreadyKnown) {
+				b.l.Warn("backup broadcaster failed to publish transaction", "index", i, "hash", txHash, "err", err)
+				return
+			}
+			b.markAccepted(i, txHash)
+			resMu.Lock()
+			accepted = true
+			resMu.Unlock()
+		}(i, backend)
+	}
+	wg.Wait()
+
+	return accepted
+}
+
+func (b *backupBroadcaster) isAccepted(i int, txHash common.Hash) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	_, ok := b.accepted[i][txHash]
+	return ok
+}
+
+func (b *backupBroadcaster) markAccepted(i int, txHash common.Hash) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.accepted[i][txHash] = struct{}{}
+}
@@ -0,0 +1,128 @@
+package txmgr
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// ErrTxCancelled is returned by Send if the candidate was cancelled before it was included.
+var ErrTxCancelled = errors.New("transaction candidate cancelled")
+
+// maxCancelledCandidates bounds the cancelled candidates remembered until they are sent.
+const maxCancelledCandidates = 1024
+
+// candidateCancels tracks the candidates being sent by their ID, to cancel them on demand.
+// The zero value is ready to use.
+type candidateCancels struct {
+	mu sync.Mutex
+	// sending are the cancel channels of the candidates being sent, closed on cancellation.
+	sending map[string]chan struct{}
+	// cancelled are the candidates cancelled before they were sent, e.g. while queued by their producer.
+	cancelled map[string]struct{}
+}
+
+// register registers the candidate being sent, and returns the channel closed on its cancellation.
+// The channel of a candidate without ID is nil, i.e. it cannot be cancelled.
+func (c *candidateCancels) register(id string) <-chan struct{} {
+	if id == "" {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ch := make(chan struct{})
+	if _, ok := c.cancelled[id]; ok {
+		delete(c.cancelled, id)
+		close(ch)
+	}
+	if c.sending == nil {
+		c.sending = make(map[string]chan struct{})
+	}
+	c.sending[id] = ch
+	return ch
+}
+
+func (c *candidateCancels) unregister(id string) {
+	if id == "" {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.sending, id)
+}
+
+// cancel cancels the candidate being sent, or remembers it to cancel it once it is sent.
+// It returns whether the candidate was being sent.
+func (c *candidateCancels) cancel(id string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if ch, ok := c.sending[id]; ok {
+		select {
+		case <-ch:
+		default:
+			close(ch)
+		}
+		return true
+	}
+	if c.cancelled == nil || len(c.cancelled) >= maxCancelledCandidates {
+		c.cancelled = make(map[string]struct{})
+	}
+	c.cancelled[id] = struct{}{}
+	return false
+}
+
+// Cancel aborts the candidate with the ID, e.g. once its producer learns that it is obsolete.
+// A candidate that is not published yet, i.e. waiting for approval or still queued by its producer,
+// is dropped and Send returns ErrTxCancelled. A published transaction is replaced with a self-transfer
+// without value at a bumped fee, and Send returns ErrTxCancelled once the replacement is confirmed.
+// If the original transaction is included first, Send returns its receipt as usual.
+func (m *SimpleTxManager) Cancel(id string) error {
+	if id == "" {
+		return errors.New("candidate without ID cannot be cancelled")
+	}
+	if m.cancels.cancel(id) {
+		m.l.Info("cancelling transaction candidate", "id", id)
+	} else {
+		m.l.Info("cancelling transaction candidate once it is sent", "id", id)
+	}
+	return nil
+}
+
+// cancelled returns whether the candidate was cancelled.
+func cancelled(ch <-chan struct{}) bool {
+	select {
+	case <-ch:
+		return true
+	default:
+		return false
+	}
+}
+
+// replacementTx returns the self-transfer without value replacing the transaction, at a bumped fee.
+func (m *SimpleTxManager) replacementTx(ctx context.Context, tx *types.Transaction) (*types.Transaction, error) {
+	tip, basefee, err := m.suggestGasPriceCaps(ctx)
+	if err != nil {
+		return nil, err
+	}
+	gasTipCap, gasFeeCap := updateFees(tx.GasTipCap(), tx.GasFeeCap(), tip, basefee, m.l)
+	// the replacement must be bumped even if the suggested fees did not increase
+	if tx.GasTipCapIntCmp(gasTipCap) == 0 && tx.GasFeeCapIntCmp(gasFeeCap) == 0 {
+		gasTipCap, gasFeeCap = calcThresholdValue(tx.GasTipCap()), calcThresholdValue(tx.GasFeeCap())
+	}
+
+	from := m.From()
+	rawTx := &types.DynamicFeeTx{
+		ChainID:   tx.ChainId(),
+		Nonce:     tx.Nonce(),
+		GasTipCap: gasTipCap,
+		GasFeeCap: gasFeeCap,
+		Gas:       params.TxGas,
+		To:        &from,
+	}
+	ctx, cancel := context.WithTimeout(ctx, m.NetworkTimeout)
+	defer cancel()
+	return m.Signer(ctx, from, types.NewTx(rawTx))
+}
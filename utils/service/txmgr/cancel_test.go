@@ -0,0 +1,107 @@
+package txmgr
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTxMgrCancel(t *testing.T) {
+	t.Parallel()
+
+	t.Run("without id", func(t *testing.T) {
+		h := newTestHarness(t)
+		require.Error(t, h.mgr.Cancel(""))
+	})
+
+	t.Run("queued", func(t *testing.T) {
+		h, published := newApprovalTestHarness(t, ApprovalPolicy{})
+		candidate := h.createTxCandidate()
+		candidate.ID = "queued"
+		require.NoError(t, h.mgr.Cancel(candidate.ID))
+
+		_, err := h.mgr.Send(context.Background(), candidate)
+		require.ErrorIs(t, err, ErrTxCancelled)
+		require.False(t, published.Load())
+
+		// the cancellation only applies once
+		_, err = h.mgr.Send(context.Background(), candidate)
+		require.ErrorIs(t, err, ErrTxReceiptNotSucceed)
+		require.True(t, published.Load())
+	})
+
+	t.Run("waiting for approval", func(t *testing.T) {
+		h, published := newApprovalTestHarness(t, ApprovalPolicy{MaxValue: big.NewInt(0)})
+		candidate := h.createTxCandidate()
+		candidate.ID = "parked"
+		errs := make(chan error, 1)
+		go func() {
+			_, err := h.mgr.Send(context.Background(), candidate)
+			errs <- err
+		}()
+		require.Eventually(t, func() bool { return len(h.mgr.Approvals.Pending()) == 1 }, time.Second, time.Millisecond)
+
+		require.NoError(t, h.mgr.Cancel(candidate.ID))
+		require.ErrorIs(t, <-errs, ErrTxCancelled)
+		require.False(t, published.Load())
+		require.Empty(t, h.mgr.Approvals.Pending())
+	})
+
+	t.Run("in flight", func(t *testing.T) {
+		h := newTestHarness(t)
+		var (
+			mu        sync.Mutex
+			published []*types.Transaction
+		)
+		// only the replacement is mined
+		h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+			mu.Lock()
+			defer mu.Unlock()
+			published = append(published, tx)
+			if *tx.To() == h.mgr.From() {
+				txHash := tx.Hash()
+				h.backend.mine(&txHash, tx.GasFeeCap())
+			}
+			return nil
+		})
+		candidate := h.createTxCandidate()
+		candidate.ID = "in-flight"
+
+		type result struct {
+			receipt *types.Receipt
+			err     error
+		}
+		results := make(chan result, 1)
+		go func() {
+			receipt, err := h.mgr.Send(context.Background(), candidate)
+			results <- result{receipt, err}
+		}()
+		require.Eventually(t, func() bool {
+			mu.Lock()
+			defer mu.Unlock()
+			return len(published) == 1
+		}, time.Second, time.Millisecond)
+
+		require.NoError(t, h.mgr.Cancel(candidate.ID))
+		res := <-results
+		require.ErrorIs(t, res.err, ErrTxCancelled)
+
+		mu.Lock()
+		defer mu.Unlock()
+		require.Len(t, published, 2)
+		original, replacement := published[0], published[1]
+		require.Equal(t, replacement.Hash(), res.receipt.TxHash)
+		require.Equal(t, original.Nonce(), replacement.Nonce())
+		require.Equal(t, params.TxGas, replacement.Gas())
+		require.Empty(t, replacement.Data())
+		require.Zero(t, replacement.Value().Sign())
+		require.GreaterOrEqual(t, replacement.GasTipCap().Cmp(calcThresholdValue(original.GasTipCap())), 0)
+		require.GreaterOrEqual(t, replacement.GasFeeCap().Cmp(calcThresholdValue(original.GasFeeCap())), 0)
+	})
+}
@@ -10,10 +10,12 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/urfave/cli"
 
 	kservice "github.com/kroma-network/kroma/utils/service"
 	kcrypto "github.com/kroma-network/kroma/utils/service/crypto"
+	"github.com/kroma-network/kroma/utils/service/proxy"
 	"github.com/kroma-network/kroma/utils/signer/client"
 )
 
@@ -25,13 +27,33 @@ const (
 	HDPathFlagName     = "hd-path"
 	PrivateKeyFlagName = "private-key"
 	// TxMgr Flags (new + legacy + some shared flags)
-	NumConfirmationsFlagName          = "num-confirmations"
-	SafeAbortNonceTooLowCountFlagName = "safe-abort-nonce-too-low-count"
-	ResubmissionTimeoutFlagName       = "resubmission-timeout"
-	NetworkTimeoutFlagName            = "network-timeout"
-	TxSendTimeoutFlagName             = "txmgr.send-timeout"
-	TxNotInMempoolTimeoutFlagName     = "txmgr.not-in-mempool-timeout"
-	ReceiptQueryIntervalFlagName      = "txmgr.receipt-query-interval"
+	NumConfirmationsFlagName           = "num-confirmations"
+	SafeAbortNonceTooLowCountFlagName  = "safe-abort-nonce-too-low-count"
+	ResubmissionTimeoutFlagName        = "resubmission-timeout"
+	NetworkTimeoutFlagName             = "network-timeout"
+	TxSendTimeoutFlagName              = "txmgr.send-timeout"
+	TxNotInMempoolTimeoutFlagName      = "txmgr.not-in-mempool-timeout"
+	ReceiptQueryIntervalFlagName       = "txmgr.receipt-query-interval"
+	BackupL1RPCURLsFlagName            = "txmgr.backup-l1-rpc-urls"
+	BroadcastLogFileFlagName           = "txmgr.broadcast-log-file"
+	BroadcastSyslogTagFlagName         = "txmgr.broadcast-syslog-tag"
+	BroadcastAuditDirFlagName          = "txmgr.broadcast-audit-dir"
+	BroadcastHookPolicyFlagName        = "txmgr.broadcast-hook-policy"
+	GasOracleURLFlagName               = "txmgr.gas-oracle-url"
+	GasOracleTipPathFlagName           = "txmgr.gas-oracle-tip-path"
+	GasOracleBaseFeePathFlagName       = "txmgr.gas-oracle-basefee-path"
+	GasOracleMaxDeviationFlagName      = "txmgr.gas-oracle-max-deviation"
+	ApprovalMaxValueFlagName           = "txmgr.approval-max-value"
+	ApprovalMaxGasCostFlagName         = "txmgr.approval-max-gas-cost"
+	StuckBumpsFlagName                 = "txmgr.stuck-bumps"
+	StuckTimeoutFlagName               = "txmgr.stuck-timeout"
+	StuckPriceBumpFlagName             = "txmgr.stuck-price-bump"
+	StuckRPCURLsFlagName               = "txmgr.stuck-rpc-urls"
+	StuckWebhookURLFlagName            = "txmgr.stuck-webhook-url"
+	StuckMaxBumpsFlagName              = "txmgr.stuck-max-bumps"
+	StuckMaxFeeCapFlagName             = "txmgr.stuck-max-fee-cap"
+	FeeHistorySizeFlagName             = "txmgr.fee-history-size"
+	FeeHistoryMaxTipMultiplierFlagName = "txmgr.fee-history-max-tip-multiplier"
 )
 
 func CLIFlags(envPrefix string) []cli.Flag {
@@ -93,22 +115,150 @@ func CLIFlags(envPrefix string) []cli.Flag {
 			Value:  12 * time.Second,
 			EnvVar: kservice.PrefixEnvVar(envPrefix, "TXMGR_RECEIPT_QUERY_INTERVAL"),
 		},
+		cli.StringSliceFlag{
+			Name:   BackupL1RPCURLsFlagName,
+			Usage:  "Additional L1 RPC URLs that signed transactions are broadcast to simultaneously with the primary L1 RPC",
+			EnvVar: kservice.PrefixEnvVar(envPrefix, "TXMGR_BACKUP_L1_RPC_URLS"),
+		},
+		cli.StringFlag{
+			Name:   BroadcastLogFileFlagName,
+			Usage:  "Path of the file every signed transaction is appended to as a JSON line before it is broadcast. Disabled if empty.",
+			EnvVar: kservice.PrefixEnvVar(envPrefix, "TXMGR_BROADCAST_LOG_FILE"),
+		},
+		cli.StringFlag{
+			Name:   BroadcastSyslogTagFlagName,
+			Usage:  "Syslog tag used to record every signed transaction to the local syslog daemon before it is broadcast. Disabled if empty.",
+			EnvVar: kservice.PrefixEnvVar(envPrefix, "TXMGR_BROADCAST_SYSLOG_TAG"),
+		},
+		cli.StringFlag{
+			Name:   BroadcastAuditDirFlagName,
+			Usage:  "Directory every signed transaction is exported to in the EIP-2718 raw format before it is broadcast, to a file per service and UTC day. Disabled if empty.",
+			EnvVar: kservice.PrefixEnvVar(envPrefix, "TXMGR_BROADCAST_AUDIT_DIR"),
+		},
+		cli.StringFlag{
+			Name:   BroadcastHookPolicyFlagName,
+			Usage:  "What to do if a transaction could not be recorded before it is broadcast: 'block' does not broadcast it, 'continue' broadcasts it anyway",
+			Value:  string(HookFailurePolicyBlock),
+			EnvVar: kservice.PrefixEnvVar(envPrefix, "TXMGR_BROADCAST_HOOK_POLICY"),
+		},
+		cli.StringFlag{
+			Name:   GasOracleURLFlagName,
+			Usage:  "URL of an external gas-price API queried for the gas tip cap and base fee, in addition to L1. Disabled if empty.",
+			EnvVar: kservice.PrefixEnvVar(envPrefix, "TXMGR_GAS_ORACLE_URL"),
+		},
+		cli.StringFlag{
+			Name:   GasOracleTipPathFlagName,
+			Usage:  "Dot separated path of the gas tip cap in gwei in the JSON response of the gas oracle",
+			EnvVar: kservice.PrefixEnvVar(envPrefix, "TXMGR_GAS_ORACLE_TIP_PATH"),
+		},
+		cli.StringFlag{
+			Name:   GasOracleBaseFeePathFlagName,
+			Usage:  "Dot separated path of the base fee in gwei in the JSON response of the gas oracle. If empty, the L1 base fee is used.",
+			EnvVar: kservice.PrefixEnvVar(envPrefix, "TXMGR_GAS_ORACLE_BASEFEE_PATH"),
+		},
+		cli.Float64Flag{
+			Name:   GasOracleMaxDeviationFlagName,
+			Usage:  "Maximum factor by which the suggestion of the gas oracle may deviate from L1 in either direction before it is clamped",
+			Value:  2,
+			EnvVar: kservice.PrefixEnvVar(envPrefix, "TXMGR_GAS_ORACLE_MAX_DEVIATION"),
+		},
+		cli.StringFlag{
+			Name:   ApprovalMaxValueFlagName,
+			Usage:  "Maximum value (in wei) of a transaction sent without the approval of an operator through the admin RPC, which must be enabled. Disabled if empty or 0.",
+			EnvVar: kservice.PrefixEnvVar(envPrefix, "TXMGR_APPROVAL_MAX_VALUE"),
+		},
+		cli.StringFlag{
+			Name:   ApprovalMaxGasCostFlagName,
+			Usage:  "Maximum gas cost (in wei, gas limit times gas fee cap) of a transaction sent without the approval of an operator through the admin RPC, which must be enabled. Disabled if empty or 0.",
+			EnvVar: kservice.PrefixEnvVar(envPrefix, "TXMGR_APPROVAL_MAX_GAS_COST"),
+		},
+		cli.Uint64Flag{
+			Name:   StuckBumpsFlagName,
+			Usage:  "Number of fee bumps after which a transaction that is not mined is stuck, and its send escalates one level further: bump harder, re-route, then alert. Disabled if 0.",
+			EnvVar: kservice.PrefixEnvVar(envPrefix, "TXMGR_STUCK_BUMPS"),
+		},
+		cli.DurationFlag{
+			Name:   StuckTimeoutFlagName,
+			Usage:  "Duration after the first publication after which a transaction that is not mined is stuck, and its send escalates one level further. Disabled if 0.",
+			EnvVar: kservice.PrefixEnvVar(envPrefix, "TXMGR_STUCK_TIMEOUT"),
+		},
+		cli.Uint64Flag{
+			Name:   StuckPriceBumpFlagName,
+			Usage:  "Fee bump in percent of a stuck transaction, instead of the minimum replacement bump",
+			Value:  50,
+			EnvVar: kservice.PrefixEnvVar(envPrefix, "TXMGR_STUCK_PRICE_BUMP"),
+		},
+		cli.Uint64Flag{
+			Name:   StuckMaxBumpsFlagName,
+			Usage:  "Maximum number of fee bumps of a stuck transaction by the stuck price bump, after which its fees are only bumped to the network conditions",
+			Value:  5,
+			EnvVar: kservice.PrefixEnvVar(envPrefix, "TXMGR_STUCK_MAX_BUMPS"),
+		},
+		cli.StringFlag{
+			Name:   StuckMaxFeeCapFlagName,
+			Usage:  "Maximum gas fee cap (in wei) that the fees of a stuck transaction are bumped to by the stuck price bump. Unbounded if empty or 0.",
+			EnvVar: kservice.PrefixEnvVar(envPrefix, "TXMGR_STUCK_MAX_FEE_CAP"),
+		},
+		cli.StringSliceFlag{
+			Name:   StuckRPCURLsFlagName,
+			Usage:  "Additional L1 RPC URLs, e.g. private relays, that a stuck transaction is broadcast to once it is re-routed",
+			EnvVar: kservice.PrefixEnvVar(envPrefix, "TXMGR_STUCK_RPC_URLS"),
+		},
+		cli.StringFlag{
+			Name:   StuckWebhookURLFlagName,
+			Usage:  "URL that a stuck transaction is posted to as JSON once it reaches the alert level, e.g. a paging service webhook. Disabled if empty.",
+			EnvVar: kservice.PrefixEnvVar(envPrefix, "TXMGR_STUCK_WEBHOOK_URL"),
+		},
+		cli.Uint64Flag{
+			Name:   FeeHistorySizeFlagName,
+			Usage:  "Number of recently mined transactions whose tips the initial tips of new transactions are learned from, instead of the suggested tip. Disabled if 0.",
+			EnvVar: kservice.PrefixEnvVar(envPrefix, "TXMGR_FEE_HISTORY_SIZE"),
+		},
+		cli.Float64Flag{
+			Name:   FeeHistoryMaxTipMultiplierFlagName,
+			Usage:  "Maximum factor by which the learned initial tip may exceed the suggested tip",
+			Value:  2,
+			EnvVar: kservice.PrefixEnvVar(envPrefix, "TXMGR_FEE_HISTORY_MAX_TIP_MULTIPLIER"),
+		},
 	}, client.CLIFlags(envPrefix)...)
 }
 
 type CLIConfig struct {
-	L1RPCURL                  string
-	Mnemonic                  string
-	HDPath                    string
-	PrivateKey                string
-	SignerCLIConfig           client.CLIConfig
-	NumConfirmations          uint64
-	SafeAbortNonceTooLowCount uint64
-	ResubmissionTimeout       time.Duration
-	ReceiptQueryInterval      time.Duration
-	NetworkTimeout            time.Duration
-	TxSendTimeout             time.Duration
-	TxNotInMempoolTimeout     time.Duration
+	L1RPCURL                   string
+	Mnemonic                   string
+	HDPath                     string
+	PrivateKey                 string
+	SignerCLIConfig            client.CLIConfig
+	NumConfirmations           uint64
+	SafeAbortNonceTooLowCount  uint64
+	ResubmissionTimeout        time.Duration
+	ReceiptQueryInterval       time.Duration
+	NetworkTimeout             time.Duration
+	TxSendTimeout              time.Duration
+	TxNotInMempoolTimeout      time.Duration
+	BackupL1RPCURLs            []string
+	BroadcastLogFile           string
+	BroadcastSyslogTag         string
+	BroadcastAuditDir          string
+	BroadcastHookPolicy        HookFailurePolicy
+	GasOracleURL               string
+	GasOracleTipPath           string
+	GasOracleBaseFeePath       string
+	GasOracleMaxDeviation      float64
+	ApprovalMaxValue           string
+	ApprovalMaxGasCost         string
+	StuckBumps                 uint64
+	StuckTimeout               time.Duration
+	StuckPriceBump             uint64
+	StuckMaxBumps              uint64
+	StuckMaxFeeCap             string
+	StuckRPCURLs               []string
+	StuckWebhookURL            string
+	FeeHistorySize             uint64
+	FeeHistoryMaxTipMultiplier float64
+	// Proxy routes the connections to L1, the gas oracle and the stuck webhook. It is not read from the flags,
+	// but set by the service. If nil, the proxy of the environment is used.
+	Proxy *proxy.Config
 }
 
 func (m CLIConfig) Check() error {
@@ -133,26 +283,120 @@ func (m CLIConfig) Check() error {
 	if m.SafeAbortNonceTooLowCount == 0 {
 		return errors.New("SafeAbortNonceTooLowCount must not be 0")
 	}
+	for _, url := range m.BackupL1RPCURLs {
+		if url == "" {
+			return errors.New("backup L1 RPC url must not be empty")
+		}
+		if url == m.L1RPCURL {
+			return errors.New("backup L1 RPC url must be different from the primary L1 RPC url")
+		}
+	}
+	if err := m.BroadcastHookPolicy.Check(); err != nil {
+		return err
+	}
+	if m.GasOracleURL != "" {
+		if m.GasOracleTipPath == "" {
+			return errors.New("must provide GasOracleTipPath with a gas oracle")
+		}
+		if m.GasOracleMaxDeviation < 1 {
+			return errors.New("GasOracleMaxDeviation must be at least 1")
+		}
+	}
+	if m.StuckBumps != 0 || m.StuckTimeout != 0 {
+		if m.StuckPriceBump < uint64(priceBump) {
+			return fmt.Errorf("StuckPriceBump must be at least the minimum replacement bump of %d percent", priceBump)
+		}
+		if m.StuckMaxBumps == 0 {
+			return errors.New("StuckMaxBumps must not be 0")
+		}
+		if _, err := parseWeiThreshold(StuckMaxFeeCapFlagName, m.StuckMaxFeeCap); err != nil {
+			return err
+		}
+		for _, url := range m.StuckRPCURLs {
+			if url == "" {
+				return errors.New("stuck L1 RPC url must not be empty")
+			}
+			if url == m.L1RPCURL {
+				return errors.New("stuck L1 RPC url must be different from the primary L1 RPC url")
+			}
+		}
+	} else if len(m.StuckRPCURLs) != 0 || m.StuckWebhookURL != "" {
+		return errors.New("must provide StuckBumps or StuckTimeout to re-route or alert stuck transactions")
+	}
+	if m.FeeHistorySize != 0 && m.FeeHistoryMaxTipMultiplier < 1 {
+		return errors.New("FeeHistoryMaxTipMultiplier must be at least 1")
+	}
+	if _, err := m.ApprovalPolicy(); err != nil {
+		return err
+	}
 	if err := m.SignerCLIConfig.Check(); err != nil {
 		return err
 	}
 	return nil
 }
 
+// ApprovalPolicy returns the approval policy of the thresholds, where an empty or 0 threshold is disabled.
+func (m CLIConfig) ApprovalPolicy() (ApprovalPolicy, error) {
+	var policy ApprovalPolicy
+	var err error
+	if policy.MaxValue, err = parseWeiThreshold(ApprovalMaxValueFlagName, m.ApprovalMaxValue); err != nil {
+		return ApprovalPolicy{}, err
+	}
+	if policy.MaxGasCost, err = parseWeiThreshold(ApprovalMaxGasCostFlagName, m.ApprovalMaxGasCost); err != nil {
+		return ApprovalPolicy{}, err
+	}
+	return policy, nil
+}
+
+// parseWeiThreshold parses the decimal wei amount of the flag, nil if it is empty or 0.
+func parseWeiThreshold(name string, s string) (*big.Int, error) {
+	if s == "" {
+		return nil, nil
+	}
+	wei, ok := new(big.Int).SetString(s, 10)
+	if !ok || wei.Sign() < 0 {
+		return nil, fmt.Errorf("%s is not a valid wei amount: %q", name, s)
+	}
+	if wei.Sign() == 0 {
+		return nil, nil
+	}
+	return wei, nil
+}
+
 func ReadCLIConfig(ctx *cli.Context) CLIConfig {
 	return CLIConfig{
-		L1RPCURL:                  ctx.GlobalString(L1RPCFlagName),
-		Mnemonic:                  ctx.GlobalString(MnemonicFlagName),
-		HDPath:                    ctx.GlobalString(HDPathFlagName),
-		PrivateKey:                ctx.GlobalString(PrivateKeyFlagName),
-		SignerCLIConfig:           client.ReadCLIConfig(ctx),
-		NumConfirmations:          ctx.GlobalUint64(NumConfirmationsFlagName),
-		SafeAbortNonceTooLowCount: ctx.GlobalUint64(SafeAbortNonceTooLowCountFlagName),
-		ResubmissionTimeout:       ctx.GlobalDuration(ResubmissionTimeoutFlagName),
-		ReceiptQueryInterval:      ctx.GlobalDuration(ReceiptQueryIntervalFlagName),
-		NetworkTimeout:            ctx.GlobalDuration(NetworkTimeoutFlagName),
-		TxSendTimeout:             ctx.GlobalDuration(TxSendTimeoutFlagName),
-		TxNotInMempoolTimeout:     ctx.GlobalDuration(TxNotInMempoolTimeoutFlagName),
+		L1RPCURL:                   ctx.GlobalString(L1RPCFlagName),
+		Mnemonic:                   ctx.GlobalString(MnemonicFlagName),
+		HDPath:                     ctx.GlobalString(HDPathFlagName),
+		PrivateKey:                 ctx.GlobalString(PrivateKeyFlagName),
+		SignerCLIConfig:            client.ReadCLIConfig(ctx),
+		NumConfirmations:           ctx.GlobalUint64(NumConfirmationsFlagName),
+		SafeAbortNonceTooLowCount:  ctx.GlobalUint64(SafeAbortNonceTooLowCountFlagName),
+		ResubmissionTimeout:        ctx.GlobalDuration(ResubmissionTimeoutFlagName),
+		ReceiptQueryInterval:       ctx.GlobalDuration(ReceiptQueryIntervalFlagName),
+		NetworkTimeout:             ctx.GlobalDuration(NetworkTimeoutFlagName),
+		TxSendTimeout:              ctx.GlobalDuration(TxSendTimeoutFlagName),
+		TxNotInMempoolTimeout:      ctx.GlobalDuration(TxNotInMempoolTimeoutFlagName),
+		BackupL1RPCURLs:            ctx.GlobalStringSlice(BackupL1RPCURLsFlagName),
+		BroadcastLogFile:           ctx.GlobalString(BroadcastLogFileFlagName),
+		BroadcastSyslogTag:         ctx.GlobalString(BroadcastSyslogTagFlagName),
+		BroadcastAuditDir:          ctx.GlobalString(BroadcastAuditDirFlagName),
+		BroadcastHookPolicy:        HookFailurePolicy(ctx.GlobalString(BroadcastHookPolicyFlagName)),
+		GasOracleURL:               ctx.GlobalString(GasOracleURLFlagName),
+		GasOracleTipPath:           ctx.GlobalString(GasOracleTipPathFlagName),
+		GasOracleBaseFeePath:       ctx.GlobalString(GasOracleBaseFeePathFlagName),
+		GasOracleMaxDeviation:      ctx.GlobalFloat64(GasOracleMaxDeviationFlagName),
+		ApprovalMaxValue:           ctx.GlobalString(ApprovalMaxValueFlagName),
+		ApprovalMaxGasCost:         ctx.GlobalString(ApprovalMaxGasCostFlagName),
+		StuckBumps:                 ctx.GlobalUint64(StuckBumpsFlagName),
+		StuckTimeout:               ctx.GlobalDuration(StuckTimeoutFlagName),
+		StuckPriceBump:             ctx.GlobalUint64(StuckPriceBumpFlagName),
+		StuckMaxBumps:              ctx.GlobalUint64(StuckMaxBumpsFlagName),
+		StuckMaxFeeCap:             ctx.GlobalString(StuckMaxFeeCapFlagName),
+		StuckRPCURLs:               ctx.GlobalStringSlice(StuckRPCURLsFlagName),
+		StuckWebhookURL:            ctx.GlobalString(StuckWebhookURLFlagName),
+		FeeHistorySize:             ctx.GlobalUint64(FeeHistorySizeFlagName),
+		FeeHistoryMaxTipMultiplier: ctx.GlobalFloat64(FeeHistoryMaxTipMultiplierFlagName),
 	}
 }
 
@@ -163,7 +407,7 @@ func NewConfig(cfg CLIConfig, l log.Logger) (Config, error) {
 
 	ctx, cancel := context.WithTimeout(context.Background(), cfg.NetworkTimeout)
 	defer cancel()
-	l1, err := ethclient.DialContext(ctx, cfg.L1RPCURL)
+	l1, err := dialEthClient(ctx, cfg.L1RPCURL, cfg.Proxy)
 	if err != nil {
 		return Config{}, fmt.Errorf("could not dial eth client: %w", err)
 	}
@@ -175,13 +419,86 @@ func NewConfig(cfg CLIConfig, l log.Logger) (Config, error) {
 		return Config{}, fmt.Errorf("could not dial fetch L1 chain ID: %w", err)
 	}
 
+	backups := make([]TxBroadcaster, 0, len(cfg.BackupL1RPCURLs))
+	for _, url := range cfg.BackupL1RPCURLs {
+		ctx, cancel = context.WithTimeout(context.Background(), cfg.NetworkTimeout)
+		backup, err := dialEthClient(ctx, url, cfg.Proxy)
+		cancel()
+		if err != nil {
+			return Config{}, fmt.Errorf("could not dial backup eth client: %w", err)
+		}
+		backups = append(backups, backup)
+	}
+
+	var gasOracle GasOracle
+	if cfg.GasOracleURL != "" {
+		httpGasOracle, err := NewHTTPGasOracle(cfg.GasOracleURL, cfg.GasOracleTipPath, cfg.GasOracleBaseFeePath)
+		if err != nil {
+			return Config{}, err
+		}
+		httpGasOracle.client = cfg.Proxy.HTTPClient(0)
+		gasOracle = httpGasOracle
+	}
+
+	maxFeeCap, err := parseWeiThreshold(StuckMaxFeeCapFlagName, cfg.StuckMaxFeeCap)
+	if err != nil {
+		return Config{}, err
+	}
+	escalation := EscalationPolicy{
+		StuckBumps:   int(cfg.StuckBumps),
+		StuckTimeout: cfg.StuckTimeout,
+		PriceBump:    int64(cfg.StuckPriceBump),
+		MaxBumps:     int(cfg.StuckMaxBumps),
+		MaxGasFeeCap: maxFeeCap,
+	}
+	for _, url := range cfg.StuckRPCURLs {
+		ctx, cancel = context.WithTimeout(context.Background(), cfg.NetworkTimeout)
+		broadcaster, err := dialEthClient(ctx, url, cfg.Proxy)
+		cancel()
+		if err != nil {
+			return Config{}, fmt.Errorf("could not dial stuck eth client: %w", err)
+		}
+		escalation.Broadcasters = append(escalation.Broadcasters, broadcaster)
+	}
+	if cfg.StuckWebhookURL != "" {
+		escalation.Alerters = append(escalation.Alerters, NewWebhookStuckAlerter(cfg.StuckWebhookURL, cfg.Proxy.HTTPClient(0)))
+	}
+
+	var feeHistory *FeeHistory
+	if cfg.FeeHistorySize != 0 {
+		feeHistory = NewFeeHistory(int(cfg.FeeHistorySize), cfg.FeeHistoryMaxTipMultiplier)
+	}
+
+	var approvals *ApprovalQueue
+	policy, err := cfg.ApprovalPolicy()
+	if err != nil {
+		return Config{}, err
+	}
+	if policy.Enabled() {
+		approvals = NewApprovalQueue(policy)
+	}
+
 	signerFactory, from, err := kcrypto.SignerFactoryFromConfig(l, cfg.PrivateKey, cfg.Mnemonic, cfg.HDPath, cfg.SignerCLIConfig)
 	if err != nil {
 		return Config{}, fmt.Errorf("could not init signer: %w", err)
 	}
 
+	// the hooks are opened last, not to leak the files they record to if the config fails
+	hooks, err := newBroadcastHooks(cfg, l)
+	if err != nil {
+		return Config{}, err
+	}
+
 	return Config{
 		Backend:                   l1,
+		BackupBroadcasters:        backups,
+		BroadcastHooks:            hooks,
+		BroadcastHookPolicy:       cfg.BroadcastHookPolicy,
+		GasOracle:                 gasOracle,
+		GasOracleMaxDeviation:     cfg.GasOracleMaxDeviation,
+		Escalation:                escalation,
+		Approvals:                 approvals,
+		FeeHistory:                feeHistory,
 		ResubmissionTimeout:       cfg.ResubmissionTimeout,
 		ChainID:                   chainID,
 		TxSendTimeout:             cfg.TxSendTimeout,
@@ -198,6 +515,37 @@ func NewConfig(cfg CLIConfig, l log.Logger) (Config, error) {
 // Config houses parameters for altering the behavior of a SimpleTxManager.
 type Config struct {
 	Backend ETHBackend
+	// BackupBroadcasters are additional L1 endpoints that every signed transaction
+	// is broadcast to, simultaneously with the Backend. This way a single provider
+	// rejecting or delaying the broadcast does not delay the confirmation.
+	BackupBroadcasters []TxBroadcaster
+
+	// BroadcastHooks are invoked with every signed transaction before it is broadcast.
+	BroadcastHooks []BroadcastHook
+
+	// BroadcastHookPolicy decides whether a transaction is still broadcast
+	// if one of the BroadcastHooks failed to record it.
+	BroadcastHookPolicy HookFailurePolicy
+
+	// GasOracle optionally suggests the gas tip cap and base fee, in addition to the Backend.
+	// Its suggestion is clamped to GasOracleMaxDeviation times the values observed on L1.
+	GasOracle GasOracle
+
+	// GasOracleMaxDeviation is the maximum factor by which the suggestion of the GasOracle
+	// may deviate from the values observed on L1 in either direction.
+	GasOracleMaxDeviation float64
+
+	// Escalation escalates the send of stuck transactions, disabled if it defines no stuck transaction.
+	Escalation EscalationPolicy
+
+	// Approvals parks the transactions exceeding its value or gas cost thresholds until an operator
+	// approves them, optional (may be nil).
+	Approvals *ApprovalQueue
+
+	// FeeHistory learns the initial fee bids of new transactions from the recently mined ones, optional (may be nil).
+	// If nil, the initial bid is the suggested tip and a fee cap of the tip plus twice the base fee.
+	FeeHistory *FeeHistory
+
 	// ResubmissionTimeout is the interval at which, if no previously
 	// published transaction has been mined, the new tx with a bumped gas
 	// price will be published. Only one publication at MaxGasPrice will be
@@ -237,3 +585,12 @@ type Config struct {
 	Signer kcrypto.SignerFn
 	From   common.Address
 }
+
+// dialEthClient dials the L1 RPC through the proxy.
+func dialEthClient(ctx context.Context, url string, p *proxy.Config) (*ethclient.Client, error) {
+	rpcCl, err := rpc.DialOptions(ctx, url, p.RPCOptions()...)
+	if err != nil {
+		return nil, err
+	}
+	return ethclient.NewClient(rpcCl), nil
+}
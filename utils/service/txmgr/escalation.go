@@ -0,0 +1,162 @@
+package txmgr
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net/http"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/kroma-network/kroma/utils/service/webhook"
+)
+
+// EscalationLevel is how far the send of a stuck transaction is escalated.
+type EscalationLevel int
+
+const (
+	// EscalationNone is the level of a transaction that is not stuck.
+	EscalationNone EscalationLevel = iota
+	// EscalationBump bumps the fees of the transaction by EscalationPolicy.PriceBump percent,
+	// instead of the minimum replacement bump.
+	EscalationBump
+	// EscalationReroute additionally broadcasts the transaction to the EscalationPolicy.Broadcasters.
+	EscalationReroute
+	// EscalationAlert notifies the operator through the EscalationPolicy.Alerters.
+	EscalationAlert
+)
+
+func (l EscalationLevel) String() string {
+	switch l {
+	case EscalationNone:
+		return "none"
+	case EscalationBump:
+		return "bump"
+	case EscalationReroute:
+		return "reroute"
+	case EscalationAlert:
+		return "alert"
+	default:
+		return fmt.Sprintf("unknown(%d)", int(l))
+	}
+}
+
+// EscalationPolicy defines when a transaction is stuck, and how its send is escalated.
+// A transaction is stuck once it is not mined after StuckBumps fee bumps, or StuckTimeout after it was
+// first published, whichever comes first. Its send escalates one level further after every such period,
+// up to EscalationAlert.
+type EscalationPolicy struct {
+	// StuckBumps is the number of fee bumps after which a transaction is stuck, disabled if 0.
+	StuckBumps int
+	// StuckTimeout is the duration after which a transaction is stuck, disabled if 0.
+	StuckTimeout time.Duration
+	// PriceBump is the fee bump of a stuck transaction in percent, at least the minimum replacement bump.
+	PriceBump int64
+	// MaxBumps is the number of fee bumps of a stuck transaction by PriceBump, after which its fees are only bumped
+	// to the network conditions again. Unbounded if 0.
+	MaxBumps int
+	// MaxGasFeeCap is the gas fee cap that the fees of a stuck transaction are not bumped by PriceBump above,
+	// unbounded if nil.
+	MaxGasFeeCap *big.Int
+	// Broadcasters are the L1 endpoints that a stuck transaction is additionally broadcast to, e.g. private relays.
+	Broadcasters []TxBroadcaster
+	// Alerters are notified once a stuck transaction reaches EscalationAlert.
+	Alerters []StuckAlerter
+}
+
+func (p EscalationPolicy) Enabled() bool {
+	return p.StuckBumps > 0 || p.StuckTimeout > 0
+}
+
+// level returns the escalation level of a transaction not mined after the bumps and the elapsed time.
+func (p EscalationPolicy) level(bumps int, elapsed time.Duration) EscalationLevel {
+	var periods int
+	if p.StuckBumps > 0 {
+		periods = bumps / p.StuckBumps
+	}
+	if p.StuckTimeout > 0 {
+		if n := int(elapsed / p.StuckTimeout); n > periods {
+			periods = n
+		}
+	}
+	if periods > int(EscalationAlert) {
+		return EscalationAlert
+	}
+	return EscalationLevel(periods)
+}
+
+// canBump returns whether a stuck transaction bumped by PriceBump the number of times may be bumped by it again.
+func (p EscalationPolicy) canBump(bumps int) bool {
+	return p.MaxBumps == 0 || bumps < p.MaxBumps
+}
+
+// StuckRecord is the stuck transaction and its metadata passed to a StuckAlerter.
+type StuckRecord struct {
+	BroadcastRecord
+	Bumps          int       `json:"bumps"`
+	FirstPublished time.Time `json:"firstPublished"`
+}
+
+// StuckAlerter is notified of a stuck transaction that reached EscalationAlert, e.g. to page the operator.
+type StuckAlerter interface {
+	OnStuck(ctx context.Context, record StuckRecord) error
+}
+
+// WebhookStuckAlerter posts every record as JSON to a webhook, e.g. of an alerting or paging service.
+type WebhookStuckAlerter struct {
+	notifier *webhook.Notifier
+}
+
+// NewWebhookStuckAlerter creates the alerter posting with the client, the default client if nil.
+func NewWebhookStuckAlerter(url string, client *http.Client) *WebhookStuckAlerter {
+	return &WebhookStuckAlerter{notifier: webhook.NewNotifier(url, client)}
+}
+
+func (a *WebhookStuckAlerter) OnStuck(ctx context.Context, record StuckRecord) error {
+	if err := a.notifier.Notify(ctx, record); err != nil {
+		return fmt.Errorf("failed to alert stuck transaction: %w", err)
+	}
+	return nil
+}
+
+// escalate handles the transaction reaching the escalation level.
+func (m *SimpleTxManager) escalate(tx *types.Transaction, level EscalationLevel, bumps int, firstPublished time.Time) {
+	m.l.Warn("transaction is stuck, escalating", "hash", tx.Hash(), "nonce", tx.Nonce(), "level", level,
+		"bumps", bumps, "elapsed", time.Since(firstPublished))
+	m.metr.RecordEscalationLevel(int(level), level.String())
+	if level != EscalationAlert {
+		return
+	}
+
+	m.l.Error("transaction is stuck, alerting the operator", "hash", tx.Hash(), "nonce", tx.Nonce(),
+		"gasTipCap", tx.GasTipCap(), "gasFeeCap", tx.GasFeeCap())
+	if len(m.Escalation.Alerters) == 0 {
+		return
+	}
+	record, err := newBroadcastRecord(m.name, m.chainID, m.From(), tx)
+	if err != nil {
+		m.l.Error("failed to create stuck record", "hash", tx.Hash(), "err", err)
+		return
+	}
+	stuck := StuckRecord{BroadcastRecord: record, Bumps: bumps, FirstPublished: firstPublished.UTC()}
+	// the alerters are notified in the background, so that a slow alerter does not delay the fee bumps, and the
+	// alert is still delivered if the send ends meanwhile
+	m.alerts.Add(1)
+	go func() {
+		defer m.alerts.Done()
+		for i, alerter := range m.Escalation.Alerters {
+			cCtx, cancel := context.WithTimeout(context.Background(), m.NetworkTimeout)
+			if err := alerter.OnStuck(cCtx, stuck); err != nil {
+				m.l.Error("stuck alerter failed", "index", i, "hash", tx.Hash(), "err", err)
+			}
+			cancel()
+		}
+	}()
+}
+
+// calcBumpedValue returns x * (100 + percent) / 100
+func calcBumpedValue(x *big.Int, percent int64) *big.Int {
+	bumped := new(big.Int).Mul(big.NewInt(100+percent), x)
+	return bumped.Div(bumped, oneHundred)
+}
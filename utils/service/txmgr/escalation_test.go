@@ -0,0 +1,291 @@
+package txmgr
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEscalationPolicyLevel(t *testing.T) {
+	require.False(t, EscalationPolicy{}.Enabled())
+	require.Equal(t, EscalationNone, EscalationPolicy{}.level(100, time.Hour))
+
+	byBumps := EscalationPolicy{StuckBumps: 3}
+	require.True(t, byBumps.Enabled())
+	require.Equal(t, EscalationNone, byBumps.level(2, time.Hour))
+	require.Equal(t, EscalationBump, byBumps.level(3, 0))
+	require.Equal(t, EscalationReroute, byBumps.level(6, 0))
+	require.Equal(t, EscalationAlert, byBumps.level(9, 0))
+	require.Equal(t, EscalationAlert, byBumps.level(100, 0))
+
+	byBoth := EscalationPolicy{StuckBumps: 3, StuckTimeout: time.Minute}
+	require.Equal(t, EscalationNone, byBoth.level(2, 59*time.Second))
+	require.Equal(t, EscalationBump, byBoth.level(0, time.Minute), "whichever comes first")
+	require.Equal(t, EscalationReroute, byBoth.level(3, 2*time.Minute))
+}
+
+// mockStuckAlerter records the stuck transactions.
+type mockStuckAlerter struct {
+	mu      sync.Mutex
+	records []StuckRecord
+}
+
+func (a *mockStuckAlerter) OnStuck(_ context.Context, record StuckRecord) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.records = append(a.records, record)
+	return nil
+}
+
+func (a *mockStuckAlerter) alerted() []StuckRecord {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return append([]StuckRecord(nil), a.records...)
+}
+
+// TestTxMgrEscalatesStuckTx asserts that a stuck transaction is bumped harder, and then re-routed.
+func TestTxMgrEscalatesStuckTx(t *testing.T) {
+	t.Parallel()
+
+	stuckBroadcaster := &mockBroadcaster{}
+	alerter := &mockStuckAlerter{}
+	cfg := configWithNumConfs(1)
+	cfg.ResubmissionTimeout = 100 * time.Millisecond
+	cfg.ReceiptQueryInterval = 5 * time.Millisecond
+	cfg.Escalation = EscalationPolicy{
+		StuckBumps:   1,
+		PriceBump:    50,
+		Broadcasters: []TxBroadcaster{stuckBroadcaster},
+		Alerters:     []StuckAlerter{alerter},
+	}
+	h := newTestHarnessWithConfig(t, cfg)
+	// the network suggests a lot less than the bump of a stuck transaction
+	h.gasPricer.baseGasTipFee = big.NewInt(0)
+	h.gasPricer.baseBaseFee = big.NewInt(0)
+
+	var mu sync.Mutex
+	var sent []*types.Transaction
+	h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+		mu.Lock()
+		defer mu.Unlock()
+		sent = append(sent, tx)
+		return nil
+	})
+	stuckBroadcaster.send = func(ctx context.Context, tx *types.Transaction) error {
+		txHash := tx.Hash()
+		h.backend.mine(&txHash, tx.GasFeeCap())
+		return nil
+	}
+
+	tx := types.NewTx(&types.DynamicFeeTx{GasTipCap: big.NewInt(100), GasFeeCap: big.NewInt(1000)})
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	receipt, err := h.mgr.send(ctx, tx, nil, nil, nil)
+	require.ErrorIs(t, err, ErrTxReceiptNotSucceed)
+	require.NotNil(t, receipt)
+
+	mu.Lock()
+	defer mu.Unlock()
+	// published: the original, the original again as the network suggests no bump,
+	// bumped by 50% as stuck, and bumped by 50% again as re-routed
+	require.Len(t, sent, 4)
+	require.Equal(t, sent[0].Hash(), sent[1].Hash())
+	require.Equal(t, big.NewInt(150), sent[2].GasTipCap())
+	require.Equal(t, big.NewInt(1500), sent[2].GasFeeCap())
+	require.Equal(t, big.NewInt(225), sent[3].GasTipCap())
+	require.Equal(t, sent[3].Hash(), receipt.TxHash, "expected the re-routed transaction to be mined")
+	require.Equal(t, 1, stuckBroadcaster.calls(), "expected the transaction to be re-routed once stuck")
+	require.Empty(t, alerter.alerted())
+}
+
+// TestTxMgrBoundsStuckTxBumps asserts that a stuck transaction is bumped harder at most MaxBumps times,
+// and not above MaxGasFeeCap.
+func TestTxMgrBoundsStuckTxBumps(t *testing.T) {
+	t.Parallel()
+
+	send := func(t *testing.T, escalation EscalationPolicy, maxTxFee *big.Int) []*types.Transaction {
+		cfg := configWithNumConfs(1)
+		cfg.ResubmissionTimeout = 20 * time.Millisecond
+		cfg.ReceiptQueryInterval = 5 * time.Millisecond
+		cfg.Escalation = escalation
+		h := newTestHarnessWithConfig(t, cfg)
+		// the network suggests a lot less than the bump of a stuck transaction
+		h.gasPricer.baseGasTipFee = big.NewInt(0)
+		h.gasPricer.baseBaseFee = big.NewInt(0)
+
+		var mu sync.Mutex
+		var sent []*types.Transaction
+		h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+			mu.Lock()
+			defer mu.Unlock()
+			sent = append(sent, tx)
+			if len(sent) == 5 {
+				txHash := tx.Hash()
+				h.backend.mine(&txHash, tx.GasFeeCap())
+			}
+			return nil
+		})
+
+		tx := types.NewTx(&types.DynamicFeeTx{Gas: 10, GasTipCap: big.NewInt(100), GasFeeCap: big.NewInt(1000)})
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_, err := h.mgr.send(ctx, tx, maxTxFee, nil, nil)
+		require.ErrorIs(t, err, ErrTxReceiptNotSucceed)
+
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]*types.Transaction(nil), sent[:5]...)
+	}
+
+	t.Run("max bumps", func(t *testing.T) {
+		sent := send(t, EscalationPolicy{StuckBumps: 1, PriceBump: 50, MaxBumps: 1}, nil)
+		require.Equal(t, big.NewInt(1000), sent[1].GasFeeCap())
+		require.Equal(t, big.NewInt(1500), sent[2].GasFeeCap())
+		for _, tx := range sent[3:] {
+			require.Equal(t, sent[2].Hash(), tx.Hash(), "expected no more bump by the price bump")
+		}
+	})
+
+	t.Run("max fee cap", func(t *testing.T) {
+		sent := send(t, EscalationPolicy{StuckBumps: 1, PriceBump: 50, MaxGasFeeCap: big.NewInt(2000)}, nil)
+		require.Equal(t, big.NewInt(1500), sent[2].GasFeeCap())
+		for _, tx := range sent[3:] {
+			require.Equal(t, sent[2].Hash(), tx.Hash(), "expected no bump above the max fee cap")
+		}
+	})
+
+	t.Run("max tx fee", func(t *testing.T) {
+		// the bumped fee cap of 1500 is at the max tx fee of 10 gas at 1500, the next bumps are above it
+		sent := send(t, EscalationPolicy{StuckBumps: 1, PriceBump: 50}, big.NewInt(15000))
+		require.Equal(t, big.NewInt(1500), sent[2].GasFeeCap())
+		for _, tx := range sent[3:] {
+			require.Equal(t, sent[2].Hash(), tx.Hash(), "expected no bump above the max tx fee")
+		}
+
+		// the bumps by the network conditions are bounded too
+		sent = send(t, EscalationPolicy{}, big.NewInt(10500))
+		for _, tx := range sent[1:] {
+			require.Equal(t, sent[0].Hash(), tx.Hash(), "expected no bump above the max tx fee")
+		}
+	})
+}
+
+// blockingStuckAlerter blocks the alerts until it is released.
+type blockingStuckAlerter struct {
+	mockStuckAlerter
+	release chan struct{}
+}
+
+func (a *blockingStuckAlerter) OnStuck(ctx context.Context, record StuckRecord) error {
+	<-a.release
+	return a.mockStuckAlerter.OnStuck(ctx, record)
+}
+
+// TestTxMgrAlertsStuckTxAsync asserts that the fees of a stuck transaction are still bumped while it is alerted,
+// and that the alert is delivered before the txmgr is closed.
+func TestTxMgrAlertsStuckTxAsync(t *testing.T) {
+	t.Parallel()
+
+	alerter := &blockingStuckAlerter{release: make(chan struct{})}
+	cfg := configWithNumConfs(1)
+	cfg.ResubmissionTimeout = 20 * time.Millisecond
+	cfg.Escalation = EscalationPolicy{StuckBumps: 1, PriceBump: 50, Alerters: []StuckAlerter{alerter}}
+	h := newTestHarnessWithConfig(t, cfg)
+	var mu sync.Mutex
+	var sent int
+	h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+		mu.Lock()
+		defer mu.Unlock()
+		sent++
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error, 1)
+	go func() {
+		_, err := h.mgr.send(ctx, types.NewTx(&types.DynamicFeeTx{GasTipCap: big.NewInt(1), GasFeeCap: big.NewInt(10)}), nil, nil, nil)
+		errCh <- err
+	}()
+	// the alert is due after the third bump
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return sent > 6
+	}, 5*time.Second, 10*time.Millisecond, "expected the fees to be bumped while alerting")
+	cancel()
+	require.ErrorIs(t, <-errCh, context.Canceled)
+	require.Empty(t, alerter.alerted())
+
+	closed := make(chan struct{})
+	go func() {
+		require.NoError(t, h.mgr.Close())
+		close(closed)
+	}()
+	close(alerter.release)
+	<-closed
+	require.Len(t, alerter.alerted(), 1)
+}
+
+// TestTxMgrAlertsStuckTx asserts that the operator is alerted once about a stuck transaction.
+func TestTxMgrAlertsStuckTx(t *testing.T) {
+	t.Parallel()
+
+	alerter := &mockStuckAlerter{}
+	cfg := configWithNumConfs(1)
+	cfg.ResubmissionTimeout = 20 * time.Millisecond
+	cfg.Escalation = EscalationPolicy{StuckBumps: 1, PriceBump: 50, Alerters: []StuckAlerter{alerter}}
+	h := newTestHarnessWithConfig(t, cfg)
+	h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	errCh := make(chan error, 1)
+	go func() {
+		_, err := h.mgr.send(ctx, types.NewTx(&types.DynamicFeeTx{GasTipCap: big.NewInt(1), GasFeeCap: big.NewInt(10)}), nil, nil, nil)
+		errCh <- err
+	}()
+	require.Eventually(t, func() bool { return len(alerter.alerted()) > 0 }, 5*time.Second, 10*time.Millisecond)
+	// keep sending at the alert level, without alerting again
+	time.Sleep(5 * cfg.ResubmissionTimeout)
+	cancel()
+	require.ErrorIs(t, <-errCh, context.Canceled)
+
+	records := alerter.alerted()
+	require.Len(t, records, 1)
+	require.Equal(t, "TEST", records[0].Service)
+	require.Equal(t, 3, records[0].Bumps, "expected the alert after three stuck periods")
+	require.NotEmpty(t, records[0].RawTx)
+}
+
+func TestWebhookStuckAlerter(t *testing.T) {
+	var received StuckRecord
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		if received.Bumps > 5 {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer srv.Close()
+
+	alerter := NewWebhookStuckAlerter(srv.URL, nil)
+	record := StuckRecord{BroadcastRecord: BroadcastRecord{Service: "validator", Hash: common.Hash{0x01}}, Bumps: 3}
+	require.NoError(t, alerter.OnStuck(context.Background(), record))
+	require.Equal(t, record.Hash, received.Hash)
+	require.Equal(t, 3, received.Bumps)
+
+	record.Bumps = 6
+	require.ErrorContains(t, alerter.OnStuck(context.Background(), record), "status 500")
+}
@@ -0,0 +1,145 @@
+package txmgr
+
+import (
+	"context"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+const (
+	// minTipMultiplier is the lowest fraction of the suggested tip a learned initial tip may bid.
+	minTipMultiplier = 0.5
+	// tipDecay scales down the tip sample of a transaction mined at its initial bid, as a lower bid may have
+	// sufficed.
+	tipDecay = 0.9
+)
+
+// feeSuggestion is the tip and base fee suggested for a new transaction, before the tip is adjusted by the FeeHistory.
+type feeSuggestion struct {
+	tip     *big.Int
+	baseFee *big.Int
+}
+
+// FeeHistory learns the initial tips of new transactions from the inclusions of the recent ones, instead of bidding
+// the suggested tip.
+//
+// The tip is the suggested tip times the mean of the recent tip samples, from 0.5 up to the maximum tip multiplier.
+// A sample is the ratio of the tip a transaction was mined with to the tip suggested when it was crafted. A transaction
+// mined after fee bumps contributes the ratio of its winning tip, so that the next bids need fewer bumps. A transaction
+// mined at its initial bid contributes the ratio of its tip scaled down by 0.9, as a lower bid may have sufficed, so
+// that the bids come down while no bumps are needed. The learned tip is at least 1 wei if the suggested tip is not zero.
+//
+// The fee cap is not paid, and is not learned: it is the tip plus twice the suggested base fee, see calcGasFeeCap.
+type FeeHistory struct {
+	size             int
+	maxTipMultiplier float64
+
+	mu      sync.Mutex
+	samples []float64
+}
+
+// NewFeeHistory creates a FeeHistory learning from the last size transactions,
+// whose tip multiplier is at most maxTipMultiplier.
+func NewFeeHistory(size int, maxTipMultiplier float64) *FeeHistory {
+	return &FeeHistory{
+		size:             size,
+		maxTipMultiplier: maxTipMultiplier,
+	}
+}
+
+// TipMultiplier returns the learned multiplier of the suggested tip of the initial bids, 1 without samples.
+func (h *FeeHistory) TipMultiplier() float64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.tipMultiplier()
+}
+
+func (h *FeeHistory) tipMultiplier() float64 {
+	if len(h.samples) == 0 {
+		return 1
+	}
+	var tip float64
+	for _, s := range h.samples {
+		tip += s
+	}
+	return clampFloat(tip/float64(len(h.samples)), minTipMultiplier, h.maxTipMultiplier)
+}
+
+// add records the tip sample, dropping the oldest one beyond the size, and returns the updated tip multiplier.
+func (h *FeeHistory) add(tip float64) float64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.samples = append(h.samples, tip)
+	if len(h.samples) > h.size {
+		h.samples = h.samples[len(h.samples)-h.size:]
+	}
+	return h.tipMultiplier()
+}
+
+// bid returns the initial tip of a transaction at the suggested tip.
+func (h *FeeHistory) bid(suggested *big.Int) *big.Int {
+	tip := mulFloat(suggested, h.TipMultiplier())
+	if tip.Sign() == 0 && suggested.Sign() > 0 {
+		return big.NewInt(1)
+	}
+	return tip
+}
+
+// initialFees returns the tip and fee cap of a new transaction at the suggested fees, see FeeHistory.
+func (m *SimpleTxManager) initialFees(s feeSuggestion) (*big.Int, *big.Int) {
+	tip := s.tip
+	if m.FeeHistory != nil {
+		tip = m.FeeHistory.bid(s.tip)
+	}
+	return tip, calcGasFeeCap(s.baseFee, tip)
+}
+
+// learnFees records the tip the receipt was mined with in the FeeHistory, compared to the suggestion the initial
+// transaction was crafted with.
+func (m *SimpleTxManager) learnFees(ctx context.Context, s feeSuggestion, initial *types.Transaction, receipt *types.Receipt) {
+	if m.FeeHistory == nil || receipt.EffectiveGasPrice == nil || s.tip.Sign() <= 0 {
+		return
+	}
+	cCtx, cancel := context.WithTimeout(ctx, m.NetworkTimeout)
+	defer cancel()
+	head, err := m.backend.HeaderByNumber(cCtx, receipt.BlockNumber)
+	if err != nil {
+		m.metr.RPCError()
+		m.l.Warn("failed to fetch the block of the mined transaction, not learning its fees", "hash", receipt.TxHash, "err", err)
+		return
+	} else if head.BaseFee == nil {
+		return
+	}
+
+	sample := ratio(new(big.Int).Sub(receipt.EffectiveGasPrice, head.BaseFee), s.tip)
+	if receipt.TxHash == initial.Hash() {
+		sample *= tipDecay
+	}
+	tipMultiplier := m.FeeHistory.add(sample)
+	m.metr.RecordTipMultiplier(tipMultiplier)
+	m.l.Debug("learned tip of mined transaction", "hash", receipt.TxHash, "tip_sample", sample, "tip_multiplier", tipMultiplier)
+}
+
+// ratio returns x / y.
+func ratio(x, y *big.Int) float64 {
+	r, _ := new(big.Float).Quo(new(big.Float).SetInt(x), new(big.Float).SetInt(y)).Float64()
+	return r
+}
+
+// mulFloat returns x * f, rounded down.
+func mulFloat(x *big.Int, f float64) *big.Int {
+	product, _ := new(big.Float).Mul(new(big.Float).SetInt(x), big.NewFloat(f)).Int(nil)
+	return product
+}
+
+func clampFloat(x, lower, upper float64) float64 {
+	if x < lower {
+		return lower
+	}
+	if x > upper {
+		return upper
+	}
+	return x
+}
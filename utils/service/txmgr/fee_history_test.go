@@ -0,0 +1,142 @@
+package txmgr_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kroma-network/kroma/components/node/testlog"
+	"github.com/kroma-network/kroma/utils/service/txmgr"
+	"github.com/kroma-network/kroma/utils/service/txmgr/metrics"
+	"github.com/kroma-network/kroma/utils/service/txmgr/testutil"
+)
+
+// TestTxMgrLearnsFees asserts that the initial tips follow the tips the recent transactions were mined with.
+func TestTxMgrLearnsFees(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	// the base fee triples after block 0, so that the first transaction is only mined once bumped.
+	fees := func(block uint64) (*big.Int, *big.Int) {
+		if block == 0 {
+			return big.NewInt(10), big.NewInt(100)
+		}
+		return big.NewInt(10), big.NewInt(300)
+	}
+	backend := testutil.NewBackend(big.NewInt(900), fees)
+	backend.SetAutoMine(true)
+	cfg := testutil.NewConfig(backend, key)
+	cfg.FeeHistory = txmgr.NewFeeHistory(2, 2)
+	mgr := txmgr.NewSimpleTxManagerFromConfig("test", testlog.Logger(t, log.LvlCrit), &metrics.NoopTxMetrics{}, cfg)
+
+	to := common.Address{0xff}
+	candidate := txmgr.TxCandidate{To: &to, Value: big.NewInt(0)}
+	send := func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		_, err := mgr.Send(ctx, candidate)
+		require.NoError(t, err)
+	}
+	requireTipMultiplier := func(tip float64) {
+		require.InDelta(t, tip, cfg.FeeHistory.TipMultiplier(), 1e-9)
+	}
+
+	// without samples, the suggested tip is bid.
+	requireTipMultiplier(1)
+	send()
+	sent := backend.Sent()
+	require.Len(t, sent, 2)
+	require.Equal(t, big.NewInt(10), sent[0].GasTipCap())
+	require.Equal(t, big.NewInt(210), sent[0].GasFeeCap())
+	// mined at the bumped tip of 11.
+	requireTipMultiplier(1.1)
+
+	// the fee cap is the learned tip plus twice the base fee.
+	quote, err := mgr.Quote(context.Background(), candidate)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(11), quote.GasTipCap)
+	require.Equal(t, big.NewInt(611), quote.GasFeeCap)
+
+	send()
+	sent = backend.Sent()
+	require.Len(t, sent, 3, "mined at the initial bid")
+	require.Equal(t, big.NewInt(11), sent[2].GasTipCap())
+	require.Equal(t, big.NewInt(611), sent[2].GasFeeCap())
+	requireTipMultiplier((1.1 + 1.1*0.9) / 2)
+
+	// the tips come down below the suggested tip while no bumps are needed.
+	send()
+	sent = backend.Sent()
+	require.Len(t, sent, 4)
+	require.Equal(t, big.NewInt(10), sent[3].GasTipCap())
+	require.Equal(t, big.NewInt(610), sent[3].GasFeeCap())
+	requireTipMultiplier((1.1*0.9 + 0.9) / 2)
+
+	send()
+	sent = backend.Sent()
+	require.Len(t, sent, 5)
+	require.Equal(t, big.NewInt(9), sent[4].GasTipCap())
+	require.Equal(t, big.NewInt(609), sent[4].GasFeeCap())
+	requireTipMultiplier((0.9 + 0.81) / 2)
+}
+
+// TestTxMgrLearnsNearZeroTips asserts that the learned tips of near-zero suggested tips are bounded by half the
+// suggested tip and 1 wei.
+func TestTxMgrLearnsNearZeroTips(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	for _, test := range []struct {
+		tip  int64
+		tips []int64
+	}{
+		{tip: 1, tips: []int64{1, 1, 1}},
+		{tip: 3, tips: []int64{3, 2, 2, 1, 1}},
+	} {
+		backend := testutil.NewBackend(big.NewInt(900), testutil.ConstantFees(big.NewInt(test.tip), big.NewInt(100)))
+		backend.SetAutoMine(true)
+		cfg := testutil.NewConfig(backend, key)
+		cfg.FeeHistory = txmgr.NewFeeHistory(2, 2)
+		mgr := txmgr.NewSimpleTxManagerFromConfig("test", testlog.Logger(t, log.LvlCrit), &metrics.NoopTxMetrics{}, cfg)
+
+		to := common.Address{0xff}
+		for range test.tips {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			_, err := mgr.Send(ctx, txmgr.TxCandidate{To: &to, Value: big.NewInt(0)})
+			cancel()
+			require.NoError(t, err)
+		}
+		sent := backend.Sent()
+		require.Len(t, sent, len(test.tips), "mined at the initial bids")
+		for i, tip := range test.tips {
+			require.Equal(t, big.NewInt(tip), sent[i].GasTipCap(), "tip of transaction %d at suggested tip %d", i, test.tip)
+			require.Equal(t, big.NewInt(tip+200), sent[i].GasFeeCap())
+		}
+		require.GreaterOrEqual(t, cfg.FeeHistory.TipMultiplier(), 0.5)
+	}
+}
+
+func TestTxMgrLearnsFeesWithoutBlock(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	backend := testutil.NewBackend(big.NewInt(900), testutil.ConstantFees(big.NewInt(10), big.NewInt(100)))
+	backend.SetAutoMine(true)
+	cfg := testutil.NewConfig(backend, key)
+	cfg.FeeHistory = txmgr.NewFeeHistory(2, 2)
+	mgr := txmgr.NewSimpleTxManagerFromConfig("test", testlog.Logger(t, log.LvlCrit), &metrics.NoopTxMetrics{}, cfg)
+
+	// the latest header is fetched to craft the transaction, and the one of the including block once it is confirmed.
+	backend.FailNext(testutil.MethodHeaderByNumber, nil, errors.New("rpc failure"))
+	to := common.Address{0xff}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_, err = mgr.Send(ctx, txmgr.TxCandidate{To: &to, Value: big.NewInt(0)})
+	require.NoError(t, err, "the send succeeds although its fees are not learned")
+
+	require.Equal(t, float64(1), cfg.FeeHistory.TipMultiplier())
+}
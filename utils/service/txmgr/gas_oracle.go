@@ -0,0 +1,163 @@
+package txmgr
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// GasOracle suggests the gas tip cap and base fee of new transactions, in addition to the
+// on-chain observation of the backend. e.g. an external gas-price API of a provider.
+type GasOracle interface {
+	// SuggestGasPriceCaps returns the suggested tip and base fee in wei.
+	// The base fee may be nil, if the oracle only suggests a tip.
+	SuggestGasPriceCaps(ctx context.Context) (tip *big.Int, baseFee *big.Int, err error)
+}
+
+// HTTPGasOracle is a GasOracle that queries an external gas-price API with a GET request.
+// The tip and base fee are read, in gwei, from the JSON response at the configured paths.
+// A path is a dot separated list of object keys, e.g. "medium.suggestedMaxPriorityFeePerGas".
+type HTTPGasOracle struct {
+	url         string
+	tipPath     []string
+	baseFeePath []string
+	client      *http.Client
+}
+
+func NewHTTPGasOracle(url string, tipPath string, baseFeePath string) (*HTTPGasOracle, error) {
+	if url == "" {
+		return nil, errors.New("gas oracle url must not be empty")
+	}
+	if tipPath == "" {
+		return nil, errors.New("gas oracle tip path must not be empty")
+	}
+	o := &HTTPGasOracle{
+		url:     url,
+		tipPath: strings.Split(tipPath, "."),
+		client:  &http.Client{},
+	}
+	if baseFeePath != "" {
+		o.baseFeePath = strings.Split(baseFeePath, ".")
+	}
+	return o, nil
+}
+
+func (o *HTTPGasOracle) SuggestGasPriceCaps(ctx context.Context) (*big.Int, *big.Int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, o.url, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create gas oracle request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	res, err := o.client.Do(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to request gas oracle: %w", err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, nil, fmt.Errorf("gas oracle responded with status %d", res.StatusCode)
+	}
+
+	dec := json.NewDecoder(res.Body)
+	dec.UseNumber()
+	var body any
+	if err := dec.Decode(&body); err != nil {
+		return nil, nil, fmt.Errorf("failed to decode gas oracle response: %w", err)
+	}
+
+	tip, err := gweiAtPath(body, o.tipPath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid gas oracle tip: %w", err)
+	}
+	if o.baseFeePath == nil {
+		return tip, nil, nil
+	}
+	baseFee, err := gweiAtPath(body, o.baseFeePath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid gas oracle base fee: %w", err)
+	}
+	return tip, baseFee, nil
+}
+
+// gweiAtPath returns the gwei value at the path of the decoded JSON, converted to wei.
+// The value may be a JSON number or a decimal string.
+func gweiAtPath(body any, path []string) (*big.Int, error) {
+	v := body
+	for _, key := range path {
+		obj, ok := v.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("%q is not an object", key)
+		}
+		if v, ok = obj[key]; !ok {
+			return nil, fmt.Errorf("missing %q", key)
+		}
+	}
+
+	var s string
+	switch value := v.(type) {
+	case json.Number:
+		s = value.String()
+	case string:
+		s = value
+	default:
+		return nil, fmt.Errorf("%q is not a number", strings.Join(path, "."))
+	}
+	gwei, ok := new(big.Float).SetString(s)
+	if !ok || gwei.Sign() < 0 {
+		return nil, fmt.Errorf("%q is not a valid gwei amount: %q", strings.Join(path, "."), s)
+	}
+	wei, _ := gwei.Mul(gwei, big.NewFloat(params.GWei)).Int(nil)
+	return wei, nil
+}
+
+// clampToDeviation clamps x to [ref / maxDeviation, ref * maxDeviation].
+// It returns true if x was clamped.
+func clampToDeviation(x, ref *big.Int, maxDeviation float64) (*big.Int, bool) {
+	refFloat := new(big.Float).SetInt(ref)
+	upper, _ := new(big.Float).Mul(refFloat, big.NewFloat(maxDeviation)).Int(nil)
+	lower, _ := new(big.Float).Quo(refFloat, big.NewFloat(maxDeviation)).Int(nil)
+	if x.Cmp(upper) > 0 {
+		return upper, true
+	}
+	if x.Cmp(lower) < 0 {
+		return lower, true
+	}
+	return x, false
+}
+
+// oracleGasPriceCaps cross-checks the suggestion of the GasOracle against the on-chain tip and base fee.
+// The suggested values are clamped to GasOracleMaxDeviation times the on-chain values, so that a bad oracle
+// cannot cause absurd fee bids. If the oracle fails, the on-chain values are used.
+func (m *SimpleTxManager) oracleGasPriceCaps(ctx context.Context, chainTip, chainBaseFee *big.Int) (*big.Int, *big.Int) {
+	cCtx, cancel := context.WithTimeout(ctx, m.NetworkTimeout)
+	defer cancel()
+	tip, baseFee, err := m.GasOracle.SuggestGasPriceCaps(cCtx)
+	if err != nil {
+		m.l.Warn("failed to query gas oracle, using on-chain gas price", "err", err)
+		return chainTip, chainBaseFee
+	}
+	if tip == nil {
+		m.l.Warn("gas oracle suggested no tip, using on-chain gas price")
+		return chainTip, chainBaseFee
+	}
+
+	clampedTip, tipClamped := clampToDeviation(tip, chainTip, m.GasOracleMaxDeviation)
+	if tipClamped {
+		m.l.Warn("gas oracle tip deviates too much from the on-chain tip, clamping it",
+			"oracle_tip", tip, "chain_tip", chainTip, "clamped_tip", clampedTip)
+	}
+	if baseFee == nil {
+		return clampedTip, chainBaseFee
+	}
+	clampedBaseFee, baseFeeClamped := clampToDeviation(baseFee, chainBaseFee, m.GasOracleMaxDeviation)
+	if baseFeeClamped {
+		m.l.Warn("gas oracle base fee deviates too much from the on-chain base fee, clamping it",
+			"oracle_basefee", baseFee, "chain_basefee", chainBaseFee, "clamped_basefee", clampedBaseFee)
+	}
+	return clampedTip, clampedBaseFee
+}
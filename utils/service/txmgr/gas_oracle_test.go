@@ -0,0 +1,152 @@
+package txmgr
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kroma-network/kroma/components/node/testlog"
+	"github.com/kroma-network/kroma/utils/service/txmgr/metrics"
+)
+
+type fakeGasOracle struct {
+	tip, baseFee *big.Int
+	err          error
+}
+
+func (o *fakeGasOracle) SuggestGasPriceCaps(_ context.Context) (*big.Int, *big.Int, error) {
+	return o.tip, o.baseFee, o.err
+}
+
+func TestHTTPGasOracle(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"medium":{"suggestedMaxPriorityFeePerGas":"1.5"},"estimatedBaseFee":30}`))
+	}))
+	defer srv.Close()
+
+	o, err := NewHTTPGasOracle(srv.URL, "medium.suggestedMaxPriorityFeePerGas", "estimatedBaseFee")
+	require.NoError(t, err)
+	tip, baseFee, err := o.SuggestGasPriceCaps(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(1_500_000_000), tip)
+	require.Equal(t, big.NewInt(30_000_000_000), baseFee)
+
+	o, err = NewHTTPGasOracle(srv.URL, "medium.suggestedMaxPriorityFeePerGas", "")
+	require.NoError(t, err)
+	tip, baseFee, err = o.SuggestGasPriceCaps(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(1_500_000_000), tip)
+	require.Nil(t, baseFee)
+
+	o, err = NewHTTPGasOracle(srv.URL, "high.suggestedMaxPriorityFeePerGas", "")
+	require.NoError(t, err)
+	_, _, err = o.SuggestGasPriceCaps(context.Background())
+	require.ErrorContains(t, err, "missing")
+
+	o, err = NewHTTPGasOracle(srv.URL, "medium", "")
+	require.NoError(t, err)
+	_, _, err = o.SuggestGasPriceCaps(context.Background())
+	require.ErrorContains(t, err, "not a number")
+}
+
+func TestHTTPGasOracleBadStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	o, err := NewHTTPGasOracle(srv.URL, "tip", "")
+	require.NoError(t, err)
+	_, _, err = o.SuggestGasPriceCaps(context.Background())
+	require.ErrorContains(t, err, "status 503")
+}
+
+func TestClampToDeviation(t *testing.T) {
+	ref := big.NewInt(100)
+	tests := []struct {
+		x, expect int64
+		clamped   bool
+	}{
+		{x: 100, expect: 100},
+		{x: 150, expect: 150},
+		{x: 50, expect: 50},
+		{x: 1000, expect: 200, clamped: true},
+		{x: 10, expect: 50, clamped: true},
+	}
+	for _, test := range tests {
+		x, clamped := clampToDeviation(big.NewInt(test.x), ref, 2)
+		require.Equal(t, big.NewInt(test.expect), x, "x: %d", test.x)
+		require.Equal(t, test.clamped, clamped, "x: %d", test.x)
+	}
+}
+
+// TestSuggestGasPriceCapsWithOracle asserts that the suggestion of the gas oracle
+// is clamped to the on-chain values, and that the on-chain values are used if the oracle fails.
+func TestSuggestGasPriceCapsWithOracle(t *testing.T) {
+	tests := []struct {
+		name          string
+		oracle        *fakeGasOracle
+		expectTip     int64
+		expectBaseFee int64
+	}{
+		{
+			name:          "within deviation",
+			oracle:        &fakeGasOracle{tip: big.NewInt(150), baseFee: big.NewInt(1500)},
+			expectTip:     150,
+			expectBaseFee: 1500,
+		},
+		{
+			name:          "above deviation",
+			oracle:        &fakeGasOracle{tip: big.NewInt(100_000), baseFee: big.NewInt(1_000_000)},
+			expectTip:     200,
+			expectBaseFee: 2000,
+		},
+		{
+			name:          "below deviation",
+			oracle:        &fakeGasOracle{tip: big.NewInt(1), baseFee: big.NewInt(1)},
+			expectTip:     50,
+			expectBaseFee: 500,
+		},
+		{
+			name:          "tip only",
+			oracle:        &fakeGasOracle{tip: big.NewInt(120)},
+			expectTip:     120,
+			expectBaseFee: 1000,
+		},
+		{
+			name:          "oracle fails",
+			oracle:        &fakeGasOracle{err: errors.New("oracle down")},
+			expectTip:     100,
+			expectBaseFee: 1000,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			mgr := &SimpleTxManager{
+				Config: Config{
+					NetworkTimeout:        time.Second,
+					GasOracle:             test.oracle,
+					GasOracleMaxDeviation: 2,
+				},
+				name:    "TEST",
+				backend: &failingBackend{gasTip: big.NewInt(100), baseFee: big.NewInt(1000)},
+				l:       testlog.Logger(t, log.LvlCrit),
+				metr:    &metrics.NoopTxMetrics{},
+			}
+
+			tip, baseFee, err := mgr.suggestGasPriceCaps(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, big.NewInt(test.expectTip), tip)
+			require.Equal(t, big.NewInt(test.expectBaseFee), baseFee)
+		})
+	}
+}
@@ -0,0 +1,226 @@
+package txmgr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/syslog"
+	"math/big"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// HookFailurePolicy decides what happens to a transaction if a BroadcastHook fails to record it.
+type HookFailurePolicy string
+
+const (
+	// HookFailurePolicyBlock does not broadcast a transaction that could not be recorded.
+	HookFailurePolicyBlock HookFailurePolicy = "block"
+	// HookFailurePolicyContinue broadcasts a transaction even if it could not be recorded.
+	HookFailurePolicyContinue HookFailurePolicy = "continue"
+)
+
+func (p HookFailurePolicy) Check() error {
+	switch p {
+	case HookFailurePolicyBlock, HookFailurePolicyContinue:
+		return nil
+	default:
+		return fmt.Errorf("unknown broadcast hook failure policy: %q", p)
+	}
+}
+
+// BroadcastRecord is the signed raw transaction and its metadata passed to a BroadcastHook
+// right before the transaction is broadcast.
+type BroadcastRecord struct {
+	Service   string          `json:"service"`
+	Time      time.Time       `json:"time"`
+	ChainID   *hexutil.Big    `json:"chainId"`
+	From      common.Address  `json:"from"`
+	To        *common.Address `json:"to"`
+	Hash      common.Hash     `json:"hash"`
+	Nonce     hexutil.Uint64  `json:"nonce"`
+	Gas       hexutil.Uint64  `json:"gas"`
+	GasTipCap *hexutil.Big    `json:"gasTipCap"`
+	GasFeeCap *hexutil.Big    `json:"gasFeeCap"`
+	Value     *hexutil.Big    `json:"value"`
+	RawTx     hexutil.Bytes   `json:"rawTx"`
+}
+
+func newBroadcastRecord(service string, chainID *big.Int, from common.Address, tx *types.Transaction) (BroadcastRecord, error) {
+	rawTx, err := tx.MarshalBinary()
+	if err != nil {
+		return BroadcastRecord{}, fmt.Errorf("failed to encode transaction: %w", err)
+	}
+	return BroadcastRecord{
+		Service:   service,
+		Time:      time.Now().UTC(),
+		ChainID:   (*hexutil.Big)(chainID),
+		From:      from,
+		To:        tx.To(),
+		Hash:      tx.Hash(),
+		Nonce:     hexutil.Uint64(tx.Nonce()),
+		Gas:       hexutil.Uint64(tx.Gas()),
+		GasTipCap: (*hexutil.Big)(tx.GasTipCap()),
+		GasFeeCap: (*hexutil.Big)(tx.GasFeeCap()),
+		Value:     (*hexutil.Big)(tx.Value()),
+		RawTx:     rawTx,
+	}, nil
+}
+
+// BroadcastHook is invoked with every signed transaction before it is broadcast,
+// e.g. to keep a compliance record of all outbound transactions.
+type BroadcastHook interface {
+	// OnBroadcast records the transaction. It must not modify the record.
+	OnBroadcast(ctx context.Context, record BroadcastRecord) error
+}
+
+// FileBroadcastHook appends every record as a JSON line to a file.
+// The file is synced after every record, so that a record is never lost once the
+// transaction was broadcast.
+type FileBroadcastHook struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+func NewFileBroadcastHook(path string) (*FileBroadcastHook, error) {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o640)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open broadcast log file: %w", err)
+	}
+	return &FileBroadcastHook{file: file}, nil
+}
+
+func (h *FileBroadcastHook) OnBroadcast(_ context.Context, record BroadcastRecord) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to encode broadcast record: %w", err)
+	}
+	line = append(line, '\n')
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if _, err := h.file.Write(line); err != nil {
+		return fmt.Errorf("failed to write broadcast record: %w", err)
+	}
+	if err := h.file.Sync(); err != nil {
+		return fmt.Errorf("failed to sync broadcast log file: %w", err)
+	}
+	return nil
+}
+
+func (h *FileBroadcastHook) Close() error {
+	return h.file.Close()
+}
+
+// SyslogBroadcastHook writes every record as JSON to the local syslog daemon.
+type SyslogBroadcastHook struct {
+	w *syslog.Writer
+}
+
+func NewSyslogBroadcastHook(tag string) (*SyslogBroadcastHook, error) {
+	w, err := syslog.New(syslog.LOG_INFO|syslog.LOG_DAEMON, tag)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to syslog: %w", err)
+	}
+	return &SyslogBroadcastHook{w: w}, nil
+}
+
+func (h *SyslogBroadcastHook) OnBroadcast(_ context.Context, record BroadcastRecord) error {
+	msg, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to encode broadcast record: %w", err)
+	}
+	if err := h.w.Info(string(msg)); err != nil {
+		return fmt.Errorf("failed to write broadcast record to syslog: %w", err)
+	}
+	return nil
+}
+
+func (h *SyslogBroadcastHook) Close() error {
+	return h.w.Close()
+}
+
+// runBroadcastHooks invokes all broadcast hooks with the transaction.
+// All hooks are invoked even if one of them fails. Failures are logged and the first one is returned.
+func (m *SimpleTxManager) runBroadcastHooks(ctx context.Context, tx *types.Transaction) error {
+	if len(m.BroadcastHooks) == 0 {
+		return nil
+	}
+	record, err := newBroadcastRecord(m.name, m.chainID, m.From(), tx)
+	if err != nil {
+		return err
+	}
+	var firstErr error
+	for i, hook := range m.BroadcastHooks {
+		if err := hook.OnBroadcast(ctx, record); err != nil {
+			m.l.Warn("broadcast hook failed to record transaction", "index", i, "hash", record.Hash, "err", err)
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+	return firstErr
+}
+
+// Close closes the broadcast hooks holding resources, e.g. the files they record to, once no transaction is sent
+// anymore. It first waits for the stuck transactions being alerted.
+func (m *SimpleTxManager) Close() error {
+	m.alerts.Wait()
+	return closeBroadcastHooks(m.l, m.BroadcastHooks)
+}
+
+// newBroadcastHooks opens the broadcast hooks of the config. The hooks already opened are closed if one fails to open.
+func newBroadcastHooks(cfg CLIConfig, l log.Logger) ([]BroadcastHook, error) {
+	var hooks []BroadcastHook
+	fail := func(err error) ([]BroadcastHook, error) {
+		_ = closeBroadcastHooks(l, hooks)
+		return nil, err
+	}
+	if cfg.BroadcastLogFile != "" {
+		hook, err := NewFileBroadcastHook(cfg.BroadcastLogFile)
+		if err != nil {
+			return fail(err)
+		}
+		hooks = append(hooks, hook)
+	}
+	if cfg.BroadcastSyslogTag != "" {
+		hook, err := NewSyslogBroadcastHook(cfg.BroadcastSyslogTag)
+		if err != nil {
+			return fail(err)
+		}
+		hooks = append(hooks, hook)
+	}
+	if cfg.BroadcastAuditDir != "" {
+		hook, err := NewAuditBroadcastHook(cfg.BroadcastAuditDir)
+		if err != nil {
+			return fail(err)
+		}
+		hooks = append(hooks, hook)
+	}
+	return hooks, nil
+}
+
+// closeBroadcastHooks closes the hooks that are an io.Closer, and returns the first failure.
+func closeBroadcastHooks(l log.Logger, hooks []BroadcastHook) error {
+	var firstErr error
+	for i, hook := range hooks {
+		closer, ok := hook.(io.Closer)
+		if !ok {
+			continue
+		}
+		if err := closer.Close(); err != nil {
+			l.Warn("failed to close broadcast hook", "index", i, "err", err)
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+	return firstErr
+}
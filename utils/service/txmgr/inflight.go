@@ -0,0 +1,207 @@
+package txmgr
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// The states of an in-flight transaction.
+const (
+	// InFlightStateCrafting is a candidate whose transaction is being created, i.e. queued for its nonce and fees.
+	InFlightStateCrafting = "crafting"
+	// InFlightStateAwaitingApproval is a transaction waiting for the approval of an operator.
+	InFlightStateAwaitingApproval = "awaiting-approval"
+	// InFlightStatePublished is a transaction published and not mined yet.
+	InFlightStatePublished = "published"
+	// InFlightStateMined is a transaction mined and waiting for the confirmation depth.
+	InFlightStateMined = "mined"
+)
+
+// selectorSize is the size of the data prefix of a candidate in an InFlightTx, i.e. the selector of a call.
+const selectorSize = 4
+
+// InFlightTx is a candidate being sent by a SimpleTxManager.
+type InFlightTx struct {
+	// Seq orders the candidates in the order their sends started.
+	Seq   uint64 `json:"seq"`
+	State string `json:"state"`
+	// CandidateID is the ID of the candidate, empty if it has none.
+	CandidateID string          `json:"candidateId,omitempty"`
+	To          *common.Address `json:"to"`
+	Value       *hexutil.Big    `json:"value,omitempty"`
+	// Selector is the start of the candidate data, and DataSize its size.
+	Selector hexutil.Bytes `json:"selector,omitempty"`
+	DataSize int           `json:"dataSize"`
+	// GasLimit is the gas limit of the candidate, 0 if it is estimated.
+	GasLimit hexutil.Uint64 `json:"gasLimit"`
+
+	// Hash, Nonce and the fees are of the latest transaction of the candidate, nil while crafting.
+	Hash      *common.Hash    `json:"hash,omitempty"`
+	Nonce     *hexutil.Uint64 `json:"nonce,omitempty"`
+	GasTipCap *hexutil.Big    `json:"gasTipCap,omitempty"`
+	GasFeeCap *hexutil.Big    `json:"gasFeeCap,omitempty"`
+	// Bumps is the number of fee bumps of the published transaction.
+	Bumps      int    `json:"bumps"`
+	Escalation string `json:"escalation"`
+	// Cancelled is whether the published transaction is being replaced with a no-op, see Cancel.
+	Cancelled bool `json:"cancelled,omitempty"`
+
+	Started        time.Time  `json:"started"`
+	FirstPublished *time.Time `json:"firstPublished,omitempty"`
+	// Age is the time since the send started.
+	Age string `json:"age"`
+}
+
+// InFlightSnapshot is a snapshot of the candidates being sent from a sender.
+type InFlightSnapshot struct {
+	Service      string         `json:"service"`
+	From         common.Address `json:"from"`
+	Transactions []InFlightTx   `json:"transactions"`
+}
+
+type inFlightEntry struct {
+	InFlightTx
+	// sendState tells a mined transaction apart, nil until the transaction is published
+	sendState *SendState
+}
+
+// InFlightTxs tracks the candidates being sent by a SimpleTxManager, for the PendingAPI.
+// All methods are no-ops on a nil InFlightTxs.
+type InFlightTxs struct {
+	service string
+	from    common.Address
+
+	mu      sync.Mutex
+	nextSeq uint64
+	entries map[uint64]*inFlightEntry
+}
+
+func NewInFlightTxs(service string, from common.Address) *InFlightTxs {
+	return &InFlightTxs{
+		service: service,
+		from:    from,
+		entries: make(map[uint64]*inFlightEntry),
+	}
+}
+
+// Snapshot returns the candidates being sent, in the order their sends started.
+func (t *InFlightTxs) Snapshot() InFlightSnapshot {
+	if t == nil {
+		return InFlightSnapshot{}
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	snapshot := InFlightSnapshot{
+		Service:      t.service,
+		From:         t.from,
+		Transactions: make([]InFlightTx, 0, len(t.entries)),
+	}
+	now := time.Now()
+	for _, entry := range t.entries {
+		tx := entry.InFlightTx
+		if entry.sendState != nil && entry.sendState.IsWaitingForConfirmation() {
+			tx.State = InFlightStateMined
+		}
+		tx.Age = now.Sub(tx.Started).Truncate(time.Millisecond).String()
+		snapshot.Transactions = append(snapshot.Transactions, tx)
+	}
+	sort.Slice(snapshot.Transactions, func(i, j int) bool {
+		return snapshot.Transactions[i].Seq < snapshot.Transactions[j].Seq
+	})
+	return snapshot
+}
+
+// add tracks the candidate once its send started, until its entry is removed.
+func (t *InFlightTxs) add(candidate TxCandidate) *inFlightEntry {
+	if t == nil {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	entry := &inFlightEntry{InFlightTx: InFlightTx{
+		Seq:         t.nextSeq,
+		State:       InFlightStateCrafting,
+		CandidateID: candidate.ID,
+		To:          candidate.To,
+		Value:       (*hexutil.Big)(candidate.Value),
+		DataSize:    len(candidate.TxData),
+		GasLimit:    hexutil.Uint64(candidate.GasLimit),
+		Escalation:  EscalationNone.String(),
+		Started:     time.Now().UTC(),
+	}}
+	if n := len(candidate.TxData); n > 0 {
+		if n > selectorSize {
+			n = selectorSize
+		}
+		entry.Selector = common.CopyBytes(candidate.TxData[:n])
+	}
+	t.nextSeq++
+	t.entries[entry.Seq] = entry
+	return entry
+}
+
+func (t *InFlightTxs) remove(entry *inFlightEntry) {
+	if t == nil || entry == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.entries, entry.Seq)
+}
+
+// update applies the change to the tracked entry, if any.
+func (t *InFlightTxs) update(entry *inFlightEntry, change func(e *inFlightEntry)) {
+	if t == nil || entry == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	change(entry)
+}
+
+// setTx sets the latest transaction of the candidate.
+func (t *InFlightTxs) setTx(entry *inFlightEntry, tx *types.Transaction) {
+	t.update(entry, func(e *inFlightEntry) {
+		hash, nonce := tx.Hash(), hexutil.Uint64(tx.Nonce())
+		e.Hash, e.Nonce = &hash, &nonce
+		e.GasTipCap, e.GasFeeCap = (*hexutil.Big)(tx.GasTipCap()), (*hexutil.Big)(tx.GasFeeCap())
+	})
+}
+
+// PendingAPI is the RPC API listing the candidates being sent by the tx managers, per sender.
+type PendingAPI struct {
+	txs []*InFlightTxs
+}
+
+func NewPendingAPI(txs ...*InFlightTxs) *PendingAPI {
+	return &PendingAPI{txs: txs}
+}
+
+func (a *PendingAPI) Pending(_ context.Context) []InFlightSnapshot {
+	snapshots := make([]InFlightSnapshot, 0, len(a.txs))
+	for _, t := range a.txs {
+		if t != nil {
+			snapshots = append(snapshots, t.Snapshot())
+		}
+	}
+	return snapshots
+}
+
+// PendingAPIs returns the RPC APIs serving txmgr_pending for the tracked tx managers, nil unless the admin API is
+// enabled.
+func PendingAPIs(enableAdmin bool, txs ...*InFlightTxs) []rpc.API {
+	if !enableAdmin {
+		return nil
+	}
+	return []rpc.API{{
+		Namespace: "txmgr",
+		Service:   NewPendingAPI(txs...),
+	}}
+}
@@ -0,0 +1,103 @@
+package txmgr
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+// pending calls txmgr_pending through an in-process RPC server of the PendingAPIs.
+func pending(t *testing.T, txs *InFlightTxs) InFlightSnapshot {
+	srv := rpc.NewServer()
+	defer srv.Stop()
+	for _, api := range PendingAPIs(true, txs) {
+		require.NoError(t, srv.RegisterName(api.Namespace, api.Service))
+	}
+	client := rpc.DialInProc(srv)
+	defer client.Close()
+	var snapshots []InFlightSnapshot
+	require.NoError(t, client.Call(&snapshots, "txmgr_pending"))
+	require.Len(t, snapshots, 1)
+	return snapshots[0]
+}
+
+func TestInFlightTxs(t *testing.T) {
+	t.Parallel()
+
+	t.Run("awaiting approval", func(t *testing.T) {
+		h, _ := newApprovalTestHarness(t, ApprovalPolicy{MaxValue: big.NewInt(0)})
+		from := common.Address{0x01}
+		h.mgr.InFlight = NewInFlightTxs("TEST", from)
+		approval, errs := sendAsync(context.Background(), t, h)
+
+		snapshot := pending(t, h.mgr.InFlight)
+		require.Equal(t, "TEST", snapshot.Service)
+		require.Equal(t, from, snapshot.From)
+		require.Len(t, snapshot.Transactions, 1)
+		tx := snapshot.Transactions[0]
+		require.Equal(t, InFlightStateAwaitingApproval, tx.State)
+		require.Equal(t, approval.Hash, *tx.Hash)
+		require.Equal(t, approval.Nonce, *tx.Nonce)
+		require.Equal(t, hexutil.Bytes{0x00, 0x01, 0x02}, tx.Selector)
+		require.Equal(t, 3, tx.DataSize)
+		require.Equal(t, big.NewInt(1), tx.Value.ToInt())
+		require.Nil(t, tx.FirstPublished)
+
+		require.NoError(t, h.mgr.Approvals.Approve(approval.ID))
+		require.ErrorIs(t, <-errs, ErrTxReceiptNotSucceed)
+		require.Empty(t, pending(t, h.mgr.InFlight).Transactions)
+	})
+
+	t.Run("published and bumped", func(t *testing.T) {
+		cfg := configWithNumConfs(1)
+		cfg.ResubmissionTimeout = 20 * time.Millisecond
+		h := newTestHarnessWithConfig(t, cfg)
+		h.mgr.InFlight = NewInFlightTxs("TEST", common.Address{})
+		// the transactions are never mined
+		h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error { return nil })
+
+		ctx, cancel := context.WithCancel(context.Background())
+		errs := make(chan error, 1)
+		go func() {
+			candidate := h.createTxCandidate()
+			candidate.ID = "candidate"
+			_, err := h.mgr.Send(ctx, candidate)
+			errs <- err
+		}()
+		require.Eventually(t, func() bool {
+			txs := h.mgr.InFlight.Snapshot().Transactions
+			return len(txs) == 1 && txs[0].State == InFlightStatePublished && txs[0].Bumps >= 2
+		}, 5*time.Second, 10*time.Millisecond)
+
+		tx := pending(t, h.mgr.InFlight).Transactions[0]
+		require.Equal(t, "candidate", tx.CandidateID)
+		require.NotNil(t, tx.FirstPublished)
+		require.NotEmpty(t, tx.Age)
+		require.Equal(t, EscalationNone.String(), tx.Escalation)
+
+		cancel()
+		require.ErrorIs(t, <-errs, context.Canceled)
+		require.Empty(t, h.mgr.InFlight.Snapshot().Transactions)
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		var txs *InFlightTxs
+		entry := txs.add(TxCandidate{})
+		txs.setTx(entry, types.NewTx(&types.DynamicFeeTx{}))
+		txs.remove(entry)
+		require.Empty(t, NewPendingAPI(txs).Pending(context.Background()))
+	})
+}
+
+func TestPendingAPIsAdmin(t *testing.T) {
+	txs := NewInFlightTxs("TEST", common.Address{0x01})
+	require.Empty(t, PendingAPIs(false, txs), "txmgr_pending must not be served without the admin API")
+	require.Len(t, PendingAPIs(true, txs), 1)
+}
@@ -12,6 +12,8 @@ type TxMetricer interface {
 	RecordGasBumpCount(int)
 	RecordTxConfirmationLatency(int64)
 	RecordNonce(uint64)
+	RecordEscalationLevel(level int, name string)
+	RecordTipMultiplier(tip float64)
 	TxConfirmed(*types.Receipt)
 	TxPublished(string)
 	RPCError()
@@ -26,6 +28,9 @@ type TxMetrics struct {
 	publishEvent       metrics.Event
 	confirmEvent       metrics.EventVec
 	rpcError           prometheus.Counter
+	escalationLevel    prometheus.Gauge
+	escalations        *prometheus.CounterVec
+	tipMultiplier      prometheus.Gauge
 }
 
 func receiptStatusString(receipt *types.Receipt) string {
@@ -81,6 +86,24 @@ func MakeTxMetrics(ns string, factory metrics.Factory) TxMetrics {
 			Help:      "Temporary: Count of RPC errors (like timeouts) that have occurred",
 			Subsystem: "txmgr",
 		}),
+		escalationLevel: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "tx_escalation_level",
+			Help:      "Escalation level of the transaction being sent, 0 if it is not stuck",
+			Subsystem: "txmgr",
+		}),
+		escalations: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "tx_escalation_count",
+			Help:      "Count of stuck transactions escalated to each level",
+			Subsystem: "txmgr",
+		}, []string{"level"}),
+		tipMultiplier: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "learned_tip_multiplier",
+			Help:      "Multiplier of the suggested tip bid by new transactions, learned from the recently mined ones",
+			Subsystem: "txmgr",
+		}),
 	}
 }
 
@@ -110,6 +133,19 @@ func (t *TxMetrics) TxPublished(errString string) {
 	}
 }
 
+// RecordEscalationLevel records the escalation level of the transaction being sent, see txmgr.EscalationLevel.
+func (t *TxMetrics) RecordEscalationLevel(level int, name string) {
+	t.escalationLevel.Set(float64(level))
+	if level > 0 {
+		t.escalations.WithLabelValues(name).Inc()
+	}
+}
+
+// RecordTipMultiplier records the multiplier of the initial tips learned by the txmgr.FeeHistory.
+func (t *TxMetrics) RecordTipMultiplier(tip float64) {
+	t.tipMultiplier.Set(tip)
+}
+
 func (t *TxMetrics) RPCError() {
 	t.rpcError.Inc()
 }
@@ -19,6 +19,20 @@ type TxManager struct {
 	mock.Mock
 }
 
+// Cancel provides a mock function with given fields: id
+func (_m *TxManager) Cancel(id string) error {
+	ret := _m.Called(id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // From provides a mock function with given fields:
 func (_m *TxManager) From() common.Address {
 	ret := _m.Called()
@@ -35,6 +49,32 @@ func (_m *TxManager) From() common.Address {
 	return r0
 }
 
+// Quote provides a mock function with given fields: ctx, candidate
+func (_m *TxManager) Quote(ctx context.Context, candidate txmgr.TxCandidate) (*txmgr.Quote, error) {
+	ret := _m.Called(ctx, candidate)
+
+	var r0 *txmgr.Quote
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, txmgr.TxCandidate) (*txmgr.Quote, error)); ok {
+		return rf(ctx, candidate)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, txmgr.TxCandidate) *txmgr.Quote); ok {
+		r0 = rf(ctx, candidate)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*txmgr.Quote)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, txmgr.TxCandidate) error); ok {
+		r1 = rf(ctx, candidate)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Send provides a mock function with given fields: ctx, candidate
 func (_m *TxManager) Send(ctx context.Context, candidate txmgr.TxCandidate) (*types.Receipt, error) {
 	ret := _m.Called(ctx, candidate)
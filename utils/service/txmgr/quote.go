@@ -0,0 +1,102 @@
+package txmgr
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Quote is the projected cost of a candidate under the current L1 fee conditions, as it would be crafted by Send.
+// The candidates are sent as calldata, so the data availability cost of a candidate is the gas of its calldata.
+type Quote struct {
+	// GasLimit is the gas limit of the candidate, or its estimate if the candidate has none.
+	GasLimit uint64
+	// DataGas is the intrinsic gas of the calldata of the candidate, and ExecutionGas the rest of the gas limit
+	// beyond the intrinsic gas of the transaction.
+	DataGas      uint64
+	ExecutionGas uint64
+
+	BaseFee *big.Int
+	// GasTipCap and GasFeeCap are the fees the transaction would be crafted with, i.e. the suggested tip, unless it is
+	// learned by the FeeHistory, and the tip plus twice the base fee.
+	GasTipCap *big.Int
+	GasFeeCap *big.Int
+
+	// DataCost is the cost of the calldata at the current base fee plus the tip.
+	DataCost *big.Int
+	// ExpectedCost is the cost of the gas limit at the current base fee plus the tip, and MaxCost at the fee cap.
+	// The value of the candidate is not included.
+	ExpectedCost *big.Int
+	MaxCost      *big.Int
+	// ApprovalReason is why the transaction would wait for the approval of an operator, empty if it would not.
+	ApprovalReason string
+}
+
+// Quote returns the projected cost of the candidate under the current L1 fee conditions, without sending it,
+// so that a producer can compare the costs of its candidates before committing to one.
+func (m *SimpleTxManager) Quote(ctx context.Context, candidate TxCandidate) (*Quote, error) {
+	tip, baseFee, err := m.suggestGasPriceCaps(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get gas price info: %w", err)
+	}
+	gasTipCap, gasFeeCap := m.initialFees(feeSuggestion{tip: tip, baseFee: baseFee})
+
+	gasLimit := candidate.GasLimit
+	if gasLimit == 0 {
+		cCtx, cancel := context.WithTimeout(ctx, m.NetworkTimeout)
+		defer cancel()
+		gasLimit, err = m.backend.EstimateGas(cCtx, ethereum.CallMsg{
+			From:      m.From(),
+			To:        candidate.To,
+			GasFeeCap: gasFeeCap,
+			GasTipCap: gasTipCap,
+			Data:      candidate.TxData,
+			Value:     candidate.Value,
+		})
+		if err != nil {
+			m.metr.RPCError()
+			return nil, fmt.Errorf("failed to estimate gas: %w", err)
+		}
+	}
+
+	intrinsicGas, err := core.IntrinsicGas(candidate.TxData, candidate.AccessList, candidate.To == nil, true, true, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate intrinsic gas: %w", err)
+	}
+	// the intrinsic gas without the calldata, the initcode gas of a contract creation is counted as data gas
+	baseGas, err := core.IntrinsicGas(nil, candidate.AccessList, candidate.To == nil, true, true, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate intrinsic gas: %w", err)
+	}
+	quote := &Quote{
+		GasLimit:  gasLimit,
+		DataGas:   intrinsicGas - baseGas,
+		GasTipCap: gasTipCap,
+		BaseFee:   baseFee,
+		GasFeeCap: gasFeeCap,
+	}
+	if gasLimit > intrinsicGas {
+		quote.ExecutionGas = gasLimit - intrinsicGas
+	}
+	price := new(big.Int).Add(baseFee, gasTipCap)
+	quote.DataCost = new(big.Int).Mul(new(big.Int).SetUint64(quote.DataGas), price)
+	quote.ExpectedCost = new(big.Int).Mul(new(big.Int).SetUint64(gasLimit), price)
+	quote.MaxCost = new(big.Int).Mul(new(big.Int).SetUint64(gasLimit), gasFeeCap)
+
+	if m.Approvals != nil {
+		tx := types.NewTx(&types.DynamicFeeTx{
+			ChainID:   m.chainID,
+			To:        candidate.To,
+			Gas:       gasLimit,
+			GasTipCap: gasTipCap,
+			GasFeeCap: gasFeeCap,
+			Value:     candidate.Value,
+		})
+		quote.ApprovalReason = m.Approvals.policy.reason(tx)
+	}
+	return quote, nil
+}
@@ -0,0 +1,72 @@
+package txmgr_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kroma-network/kroma/components/node/testlog"
+	"github.com/kroma-network/kroma/utils/service/txmgr"
+	"github.com/kroma-network/kroma/utils/service/txmgr/testutil"
+)
+
+func TestQuote(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	backend := testutil.NewBackend(big.NewInt(900), testutil.ConstantFees(big.NewInt(2), big.NewInt(10)))
+	mgr := testutil.NewTxManager(testlog.Logger(t, log.LvlCrit), backend, key)
+	to := common.Address{0xff}
+	// 2 zero and 2 non-zero bytes
+	data := []byte{0x00, 0x01, 0x00, 0x02}
+	dataGas := 2*params.TxDataZeroGas + 2*params.TxDataNonZeroGasEIP2028
+
+	t.Run("estimated", func(t *testing.T) {
+		quote, err := mgr.Quote(context.Background(), txmgr.TxCandidate{To: &to, TxData: data})
+		require.NoError(t, err)
+		// the backend estimates the intrinsic gas
+		require.Equal(t, params.TxGas+dataGas, quote.GasLimit)
+		require.Equal(t, dataGas, quote.DataGas)
+		require.Zero(t, quote.ExecutionGas)
+		require.Equal(t, big.NewInt(2), quote.GasTipCap)
+		require.Equal(t, big.NewInt(10), quote.BaseFee)
+		require.Equal(t, big.NewInt(22), quote.GasFeeCap)
+		require.Equal(t, new(big.Int).SetUint64(dataGas*12), quote.DataCost)
+		require.Equal(t, new(big.Int).SetUint64((params.TxGas+dataGas)*12), quote.ExpectedCost)
+		require.Equal(t, new(big.Int).SetUint64((params.TxGas+dataGas)*22), quote.MaxCost)
+		require.Empty(t, quote.ApprovalReason)
+	})
+
+	t.Run("gas limit", func(t *testing.T) {
+		quote, err := mgr.Quote(context.Background(), txmgr.TxCandidate{To: &to, TxData: data, GasLimit: 100_000})
+		require.NoError(t, err)
+		require.Equal(t, uint64(100_000), quote.GasLimit)
+		require.Equal(t, dataGas, quote.DataGas)
+		require.Equal(t, 100_000-params.TxGas-dataGas, quote.ExecutionGas)
+		require.Equal(t, big.NewInt(100_000*22), quote.MaxCost)
+	})
+
+	t.Run("approval", func(t *testing.T) {
+		mgr.Approvals = txmgr.NewApprovalQueue(txmgr.ApprovalPolicy{MaxGasCost: big.NewInt(100_000*22 - 1)})
+		defer func() { mgr.Approvals = nil }()
+		quote, err := mgr.Quote(context.Background(), txmgr.TxCandidate{To: &to, TxData: data, GasLimit: 100_000})
+		require.NoError(t, err)
+		require.Equal(t, txmgr.ApprovalReasonGasCost, quote.ApprovalReason)
+		require.Empty(t, mgr.Approvals.Pending(), "a quote is not parked for approval")
+	})
+
+	t.Run("estimation failure", func(t *testing.T) {
+		errRevert := errors.New("execution reverted")
+		backend.FailNext(testutil.MethodEstimateGas, errRevert)
+		_, err := mgr.Quote(context.Background(), txmgr.TxCandidate{To: &to, TxData: data})
+		require.ErrorIs(t, err, errRevert)
+	})
+
+	require.Empty(t, backend.Sent(), "a quote sends no transaction")
+}
@@ -0,0 +1,341 @@
+// Package testutil provides a scripted L1 backend for the txmgr, so that the tests of the components
+// sending transactions can assert their transaction behavior deterministically.
+package testutil
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/kroma-network/kroma/utils/service/txmgr"
+	"github.com/kroma-network/kroma/utils/service/txmgr/metrics"
+)
+
+// Method is a method of the Backend that can be scripted to fail.
+type Method string
+
+const (
+	MethodBlockNumber        Method = "BlockNumber"
+	MethodTransactionReceipt Method = "TransactionReceipt"
+	MethodSendTransaction    Method = "SendTransaction"
+	MethodHeaderByNumber     Method = "HeaderByNumber"
+	MethodSuggestGasTipCap   Method = "SuggestGasTipCap"
+	MethodNonceAt            Method = "NonceAt"
+	MethodPendingNonceAt     Method = "PendingNonceAt"
+	MethodEstimateGas        Method = "EstimateGas"
+)
+
+// replacementBumpPercent is the minimum fee bump of a replacement transaction, as enforced by the geth tx pool.
+var replacementBumpPercent = big.NewInt(110)
+
+// Backend is a scripted txmgr.ETHBackend. Sent transactions are kept in a mempool until a block is mined,
+// which includes every transaction whose nonce is next and whose fee cap covers the base fee of the block.
+type Backend struct {
+	mu sync.Mutex
+
+	chainID *big.Int
+	signer  types.Signer
+	fees    FeeSchedule
+
+	head     uint64
+	nonces   map[common.Address]uint64
+	pending  []*types.Transaction
+	sent     []*types.Transaction
+	receipts map[common.Hash]*types.Receipt
+
+	failures map[Method][]error
+	autoMine bool
+	revert   func(tx *types.Transaction) bool
+}
+
+var _ txmgr.ETHBackend = (*Backend)(nil)
+
+// NewBackend creates a Backend at block 0, with the fees of the given schedule.
+func NewBackend(chainID *big.Int, fees FeeSchedule) *Backend {
+	return &Backend{
+		chainID:  chainID,
+		signer:   types.LatestSignerForChainID(chainID),
+		fees:     fees,
+		nonces:   make(map[common.Address]uint64),
+		receipts: make(map[common.Hash]*types.Receipt),
+		failures: make(map[Method][]error),
+	}
+}
+
+// SetAutoMine sets whether a block is mined right after every accepted transaction.
+func (b *Backend) SetAutoMine(autoMine bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.autoMine = autoMine
+}
+
+// FailNext makes the next calls of the method fail with the given errors, one call per error.
+func (b *Backend) FailNext(method Method, errs ...error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures[method] = append(b.failures[method], errs...)
+}
+
+// RevertIf makes the mined transactions for which fn returns true fail with a reverted receipt.
+func (b *Backend) RevertIf(fn func(tx *types.Transaction) bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.revert = fn
+}
+
+// Mine mines the next block with the includable transactions of the mempool, and returns its number.
+func (b *Backend) Mine() uint64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.mine()
+}
+
+// Head returns the number of the latest block.
+func (b *Backend) Head() uint64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.head
+}
+
+// Sent returns all transactions accepted by SendTransaction, in order.
+func (b *Backend) Sent() []*types.Transaction {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]*types.Transaction(nil), b.sent...)
+}
+
+// Pending returns the transactions in the mempool.
+func (b *Backend) Pending() []*types.Transaction {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]*types.Transaction(nil), b.pending...)
+}
+
+func (b *Backend) mine() uint64 {
+	b.head++
+	_, baseFee := b.fees(b.head)
+
+	var index uint
+	for included := true; included; {
+		included = false
+		for i, tx := range b.pending {
+			from := b.sender(tx)
+			if tx.Nonce() != b.nonces[from] || tx.GasFeeCap().Cmp(baseFee) < 0 {
+				continue
+			}
+			b.include(tx, from, baseFee, index)
+			index++
+			b.pending = append(b.pending[:i], b.pending[i+1:]...)
+			included = true
+			break
+		}
+	}
+	return b.head
+}
+
+func (b *Backend) include(tx *types.Transaction, from common.Address, baseFee *big.Int, index uint) {
+	status := types.ReceiptStatusSuccessful
+	if b.revert != nil && b.revert(tx) {
+		status = types.ReceiptStatusFailed
+	}
+	effectiveTip := tx.EffectiveGasTipValue(baseFee)
+	b.receipts[tx.Hash()] = &types.Receipt{
+		Type:              tx.Type(),
+		Status:            status,
+		TxHash:            tx.Hash(),
+		GasUsed:           tx.Gas(),
+		EffectiveGasPrice: new(big.Int).Add(baseFee, effectiveTip),
+		BlockHash:         blockHash(b.head),
+		BlockNumber:       new(big.Int).SetUint64(b.head),
+		TransactionIndex:  index,
+	}
+	b.nonces[from]++
+}
+
+func (b *Backend) sender(tx *types.Transaction) common.Address {
+	// transactions signed by a no-op signer are attributed to the zero address.
+	from, _ := types.Sender(b.signer, tx)
+	return from
+}
+
+// fail returns the next scripted error of the method, if any.
+func (b *Backend) fail(method Method) error {
+	errs := b.failures[method]
+	if len(errs) == 0 {
+		return nil
+	}
+	b.failures[method] = errs[1:]
+	return errs[0]
+}
+
+func blockHash(number uint64) common.Hash {
+	return common.BigToHash(new(big.Int).SetUint64(number + 1))
+}
+
+func (b *Backend) BlockNumber(_ context.Context) (uint64, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err := b.fail(MethodBlockNumber); err != nil {
+		return 0, err
+	}
+	return b.head, nil
+}
+
+func (b *Backend) TransactionReceipt(_ context.Context, txHash common.Hash) (*types.Receipt, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err := b.fail(MethodTransactionReceipt); err != nil {
+		return nil, err
+	}
+	receipt, ok := b.receipts[txHash]
+	if !ok {
+		return nil, ethereum.NotFound
+	}
+	return receipt, nil
+}
+
+// SendTransaction accepts the transaction into the mempool, with the nonce and replacement rules of the geth tx pool.
+func (b *Backend) SendTransaction(_ context.Context, tx *types.Transaction) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err := b.fail(MethodSendTransaction); err != nil {
+		return err
+	}
+	if _, ok := b.receipts[tx.Hash()]; ok {
+		return txpool.ErrAlreadyKnown
+	}
+	from := b.sender(tx)
+	if tx.Nonce() < b.nonces[from] {
+		return core.ErrNonceTooLow
+	}
+	for i, other := range b.pending {
+		if other.Hash() == tx.Hash() {
+			return txpool.ErrAlreadyKnown
+		}
+		if other.Nonce() != tx.Nonce() || b.sender(other) != from {
+			continue
+		}
+		if !bumped(tx.GasFeeCap(), other.GasFeeCap()) || !bumped(tx.GasTipCap(), other.GasTipCap()) {
+			return txpool.ErrReplaceUnderpriced
+		}
+		b.pending = append(b.pending[:i], b.pending[i+1:]...)
+		break
+	}
+	b.pending = append(b.pending, tx)
+	b.sent = append(b.sent, tx)
+	if b.autoMine {
+		b.mine()
+	}
+	return nil
+}
+
+func bumped(x, old *big.Int) bool {
+	threshold := new(big.Int).Mul(old, replacementBumpPercent)
+	return new(big.Int).Mul(x, big.NewInt(100)).Cmp(threshold) >= 0
+}
+
+// HeaderByNumber returns the header of the block with the given number, or of the latest block if nil.
+func (b *Backend) HeaderByNumber(_ context.Context, number *big.Int) (*types.Header, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err := b.fail(MethodHeaderByNumber); err != nil {
+		return nil, err
+	}
+	n := b.head
+	if number != nil {
+		if !number.IsUint64() || number.Uint64() > b.head {
+			return nil, ethereum.NotFound
+		}
+		n = number.Uint64()
+	}
+	_, baseFee := b.fees(n)
+	return &types.Header{
+		ParentHash: blockHash(n - 1),
+		Number:     new(big.Int).SetUint64(n),
+		BaseFee:    baseFee,
+		Time:       n * 12,
+	}, nil
+}
+
+func (b *Backend) SuggestGasTipCap(_ context.Context) (*big.Int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err := b.fail(MethodSuggestGasTipCap); err != nil {
+		return nil, err
+	}
+	tip, _ := b.fees(b.head)
+	return tip, nil
+}
+
+// NonceAt returns the nonce of the account after the latest block, the block number is ignored.
+func (b *Backend) NonceAt(_ context.Context, account common.Address, _ *big.Int) (uint64, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err := b.fail(MethodNonceAt); err != nil {
+		return 0, err
+	}
+	return b.nonces[account], nil
+}
+
+func (b *Backend) PendingNonceAt(_ context.Context, account common.Address) (uint64, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err := b.fail(MethodPendingNonceAt); err != nil {
+		return 0, err
+	}
+	nonce := b.nonces[account]
+	for _, tx := range b.pending {
+		if b.sender(tx) == account && tx.Nonce() >= nonce {
+			nonce = tx.Nonce() + 1
+		}
+	}
+	return nonce, nil
+}
+
+// EstimateGas returns the intrinsic gas of the call.
+func (b *Backend) EstimateGas(_ context.Context, msg ethereum.CallMsg) (uint64, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err := b.fail(MethodEstimateGas); err != nil {
+		return 0, err
+	}
+	return core.IntrinsicGas(msg.Data, msg.AccessList, msg.To == nil, true, true, true)
+}
+
+func (b *Backend) ChainID(_ context.Context) (*big.Int, error) {
+	return new(big.Int).Set(b.chainID), nil
+}
+
+// NewConfig returns a txmgr config sending from the key through the backend, with short intervals for tests.
+func NewConfig(backend *Backend, key *ecdsa.PrivateKey) txmgr.Config {
+	signer := types.LatestSignerForChainID(backend.chainID)
+	return txmgr.Config{
+		Backend:                   backend,
+		ResubmissionTimeout:       100 * time.Millisecond,
+		ChainID:                   backend.chainID,
+		TxNotInMempoolTimeout:     time.Hour,
+		NetworkTimeout:            time.Second,
+		ReceiptQueryInterval:      10 * time.Millisecond,
+		NumConfirmations:          1,
+		SafeAbortNonceTooLowCount: 3,
+		Signer: func(_ context.Context, _ common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			return types.SignTx(tx, signer, key)
+		},
+		From: crypto.PubkeyToAddress(key.PublicKey),
+	}
+}
+
+// NewTxManager returns a tx manager sending from the key through the backend.
+func NewTxManager(l log.Logger, backend *Backend, key *ecdsa.PrivateKey) *txmgr.SimpleTxManager {
+	return txmgr.NewSimpleTxManagerFromConfig("test", l, &metrics.NoopTxMetrics{}, NewConfig(backend, key))
+}
@@ -0,0 +1,142 @@
+package testutil
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kroma-network/kroma/components/node/testlog"
+	"github.com/kroma-network/kroma/utils/service/txmgr"
+)
+
+func testCandidate() txmgr.TxCandidate {
+	to := common.Address{0xff}
+	return txmgr.TxCandidate{
+		To:     &to,
+		TxData: []byte{0x01, 0x02},
+		Value:  big.NewInt(0),
+	}
+}
+
+func send(t *testing.T, mgr *txmgr.SimpleTxManager) (*types.Receipt, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	return mgr.Send(ctx, testCandidate())
+}
+
+func TestBackendConfirms(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	backend := NewBackend(big.NewInt(900), ConstantFees(big.NewInt(1), big.NewInt(10)))
+	backend.SetAutoMine(true)
+	mgr := NewTxManager(testlog.Logger(t, log.LvlCrit), backend, key)
+
+	for i := 0; i < 2; i++ {
+		receipt, err := send(t, mgr)
+		require.NoError(t, err)
+		require.Equal(t, uint64(i+1), receipt.BlockNumber.Uint64())
+		require.Equal(t, big.NewInt(11), receipt.EffectiveGasPrice)
+	}
+	sent := backend.Sent()
+	require.Len(t, sent, 2)
+	require.Equal(t, uint64(1), sent[1].Nonce())
+	require.Equal(t, uint64(21_000+2*16), sent[0].Gas())
+}
+
+func TestBackendFeeBump(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	// the base fee triples after block 0, so that the first transaction is not includable.
+	fees := func(block uint64) (*big.Int, *big.Int) {
+		if block == 0 {
+			return big.NewInt(1), big.NewInt(10)
+		}
+		return big.NewInt(2), big.NewInt(30)
+	}
+	backend := NewBackend(big.NewInt(900), fees)
+	backend.SetAutoMine(true)
+	mgr := NewTxManager(testlog.Logger(t, log.LvlCrit), backend, key)
+
+	receipt, err := send(t, mgr)
+	require.NoError(t, err)
+	sent := backend.Sent()
+	require.Len(t, sent, 2)
+	require.Equal(t, big.NewInt(21), sent[0].GasFeeCap())
+	require.Equal(t, sent[1].Hash(), receipt.TxHash)
+	require.Equal(t, big.NewInt(62), sent[1].GasFeeCap())
+	require.Empty(t, backend.Pending())
+}
+
+func TestBackendForcedFailures(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	backend := NewBackend(big.NewInt(900), ConstantFees(big.NewInt(1), big.NewInt(10)))
+	backend.SetAutoMine(true)
+	mgr := NewTxManager(testlog.Logger(t, log.LvlCrit), backend, key)
+
+	t.Run("send is retried", func(t *testing.T) {
+		backend.FailNext(MethodSendTransaction, errors.New("connection refused"))
+		receipt, err := send(t, mgr)
+		require.NoError(t, err)
+		require.Equal(t, types.ReceiptStatusSuccessful, receipt.Status)
+	})
+
+	t.Run("fee query fails", func(t *testing.T) {
+		backend.FailNext(MethodSuggestGasTipCap, errors.New("rate limited"))
+		_, err := send(t, mgr)
+		require.ErrorContains(t, err, "rate limited")
+	})
+
+	t.Run("reverted", func(t *testing.T) {
+		backend.RevertIf(func(*types.Transaction) bool { return true })
+		receipt, err := send(t, mgr)
+		require.ErrorIs(t, err, txmgr.ErrTxReceiptNotSucceed)
+		require.Equal(t, types.ReceiptStatusFailed, receipt.Status)
+	})
+}
+
+func TestBackendMempool(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	backend := NewBackend(big.NewInt(900), LinearFees(big.NewInt(1), big.NewInt(10), big.NewInt(0), big.NewInt(5)))
+	cfg := NewConfig(backend, key)
+	signer := types.LatestSignerForChainID(big.NewInt(900))
+	tx := func(nonce uint64, feeCap int64) *types.Transaction {
+		return types.MustSignNewTx(key, signer, &types.DynamicFeeTx{
+			ChainID:   big.NewInt(900),
+			Nonce:     nonce,
+			GasTipCap: big.NewInt(feeCap),
+			GasFeeCap: big.NewInt(feeCap),
+			Gas:       21_000,
+		})
+	}
+
+	ctx := context.Background()
+	require.NoError(t, backend.SendTransaction(ctx, tx(1, 100)))
+	require.NoError(t, backend.SendTransaction(ctx, tx(0, 12)))
+	nonce, err := backend.PendingNonceAt(ctx, cfg.From)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), nonce)
+
+	// the base fee of block 1 is 15, so the nonce gap is not filled
+	require.Equal(t, uint64(1), backend.Mine())
+	require.Len(t, backend.Pending(), 2)
+
+	require.ErrorContains(t, backend.SendTransaction(ctx, tx(0, 13)), "replacement transaction underpriced")
+	require.NoError(t, backend.SendTransaction(ctx, tx(0, 20)))
+	require.Equal(t, uint64(2), backend.Mine())
+	require.Empty(t, backend.Pending())
+
+	nonce, err = backend.NonceAt(ctx, cfg.From, nil)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), nonce)
+	require.ErrorContains(t, backend.SendTransaction(ctx, tx(1, 200)), "nonce too low")
+}
@@ -0,0 +1,25 @@
+package testutil
+
+import (
+	"math/big"
+)
+
+// FeeSchedule returns the suggested gas tip cap and the base fee of the given block,
+// so that the fee market of the Backend is deterministic.
+type FeeSchedule func(block uint64) (tip *big.Int, baseFee *big.Int)
+
+// ConstantFees is a FeeSchedule with the same fees in every block.
+func ConstantFees(tip, baseFee *big.Int) FeeSchedule {
+	return func(uint64) (*big.Int, *big.Int) {
+		return new(big.Int).Set(tip), new(big.Int).Set(baseFee)
+	}
+}
+
+// LinearFees is a FeeSchedule whose fees increase by the given steps with every block.
+func LinearFees(tip, baseFee, tipStep, baseFeeStep *big.Int) FeeSchedule {
+	return func(block uint64) (*big.Int, *big.Int) {
+		n := new(big.Int).SetUint64(block)
+		return new(big.Int).Add(tip, new(big.Int).Mul(tipStep, n)),
+			new(big.Int).Add(baseFee, new(big.Int).Mul(baseFeeStep, n))
+	}
+}
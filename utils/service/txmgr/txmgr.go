@@ -48,6 +48,13 @@ type TxManager interface {
 	// From returns the sending address associated with the instance of the transaction manager.
 	// It is static for a single instance of a TxManager.
 	From() common.Address
+
+	// Cancel aborts the candidate with the ID, dropping it if it is not published yet,
+	// or replacing its transaction with a no-op otherwise.
+	Cancel(id string) error
+
+	// Quote returns the projected cost of the candidate under the current L1 fee conditions, without sending it.
+	Quote(ctx context.Context, candidate TxCandidate) (*Quote, error)
 }
 
 // ETHBackend is the set of methods that the transaction manager uses to resubmit gas & determine
@@ -88,6 +95,15 @@ type SimpleTxManager struct {
 	backend ETHBackend
 	l       log.Logger
 	metr    metrics.TxMetricer
+
+	cancels candidateCancels
+	// sendMu serializes the creation and the publication of the transactions, which are sent one at a time
+	sendMu sync.Mutex
+	// alerts tracks the stuck transactions being alerted
+	alerts sync.WaitGroup
+
+	// InFlight tracks the candidates being sent, optional (may be nil).
+	InFlight *InFlightTxs
 }
 
 // NewSimpleTxManager initializes a new SimpleTxManager with the passed Config.
@@ -97,14 +113,21 @@ func NewSimpleTxManager(name string, l log.Logger, m metrics.TxMetricer, cfg CLI
 		return nil, err
 	}
 
+	return NewSimpleTxManagerFromConfig(name, l, m, conf), nil
+}
+
+// NewSimpleTxManagerFromConfig initializes a new SimpleTxManager with an already resolved Config,
+// e.g. with a scripted Backend in tests.
+func NewSimpleTxManagerFromConfig(name string, l log.Logger, m metrics.TxMetricer, conf Config) *SimpleTxManager {
 	return &SimpleTxManager{
-		chainID: conf.ChainID,
-		name:    name,
-		Config:  conf,
-		backend: conf.Backend,
-		l:       l.New("service", name),
-		metr:    m,
-	}, nil
+		chainID:  conf.ChainID,
+		name:     name,
+		Config:   conf,
+		backend:  conf.Backend,
+		l:        l.New("service", name),
+		metr:     m,
+		InFlight: NewInFlightTxs(name, conf.From),
+	}
 }
 
 func (m *SimpleTxManager) From() common.Address {
@@ -124,6 +147,11 @@ type TxCandidate struct {
 	AccessList types.AccessList
 	// Value is the value that is passed to the constructed tx.
 	Value *big.Int
+	// ID identifies the candidate to cancel it with Cancel. Optional, a candidate without ID cannot be cancelled.
+	ID string
+	// MaxTxFee is the fee the tx may cost at most, its gas limit times its gas fee cap. The initial gas fee cap is
+	// lowered to fit it, and the fees are not bumped above it. Optional, unbounded if nil.
+	MaxTxFee *big.Int
 }
 
 // Send is used to publish a transaction with incrementally higher gas prices
@@ -134,32 +162,85 @@ type TxCandidate struct {
 // The transaction manager handles all signing. If and only if the gas limit is 0, the
 // transaction manager will do a gas estimation.
 //
-// NOTE: Send should be called by AT MOST one caller at a time.
+// Send may be called concurrently, the transactions are sent one at a time.
+//
+// If the transaction requires approval by the Approvals, Send blocks until it is approved or rejected, without
+// holding the other transactions. Once approved, the transaction is created again with the current nonce and fees,
+// and the send timeout starts over.
+//
+// If the candidate is cancelled by its ID, Send returns ErrTxCancelled. See Cancel for details.
 func (m *SimpleTxManager) Send(ctx context.Context, candidate TxCandidate) (*types.Receipt, error) {
-	if m.TxSendTimeout != 0 {
-		var cancel context.CancelFunc
-		ctx, cancel = context.WithTimeout(ctx, m.TxSendTimeout)
-		defer cancel()
+	cancelledCh := m.cancels.register(candidate.ID)
+	defer m.cancels.unregister(candidate.ID)
+	if cancelled(cancelledCh) {
+		return nil, ErrTxCancelled
 	}
-	tx, err := m.craftTx(ctx, candidate)
+
+	entry := m.InFlight.add(candidate)
+	defer m.InFlight.remove(entry)
+
+	m.sendMu.Lock()
+	locked := true
+	defer func() {
+		if locked {
+			m.sendMu.Unlock()
+		}
+	}()
+	sendCtx, cancel := m.sendContext(ctx)
+	defer func() { cancel() }()
+	tx, suggestion, err := m.craftTx(sendCtx, candidate)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create the tx: %w", err)
 	}
-	return m.send(ctx, tx)
+	m.InFlight.setTx(entry, tx)
+	if reason := m.Approvals.reason(tx); reason != "" {
+		m.sendMu.Unlock()
+		locked = false
+		if err := m.awaitApproval(ctx, tx, reason, cancelledCh, entry); err != nil {
+			return nil, err
+		}
+		m.sendMu.Lock()
+		locked = true
+		cancel()
+		sendCtx, cancel = m.sendContext(ctx)
+		tx, suggestion, err = m.craftTx(sendCtx, candidate)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create the approved tx: %w", err)
+		}
+		m.InFlight.setTx(entry, tx)
+	}
+	if cancelled(cancelledCh) {
+		return nil, ErrTxCancelled
+	}
+	receipt, err := m.send(sendCtx, tx, candidate.MaxTxFee, cancelledCh, entry)
+	if receipt != nil && (err == nil || errors.Is(err, ErrTxReceiptNotSucceed)) {
+		m.learnFees(ctx, suggestion, tx, receipt)
+	}
+	return receipt, err
+}
+
+// sendContext returns the context bounded by the send timeout, if any.
+func (m *SimpleTxManager) sendContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if m.TxSendTimeout != 0 {
+		return context.WithTimeout(ctx, m.TxSendTimeout)
+	}
+	return context.WithCancel(ctx)
 }
 
-// craftTx creates the signed transaction
+// craftTx creates the signed transaction, and returns it with the fees suggested for it.
 // It queries L1 for the current fee market conditions as well as for the nonce.
+// The fees are bid as learned by the FeeHistory, if any.
 // NOTE: This method SHOULD NOT publish the resulting transaction.
 // NOTE: If the [TxCandidate.GasLimit] is non-zero, it will be used as the transaction's gas.
 // NOTE: Otherwise, the [SimpleTxManager] will query the specified backend for an estimate.
-func (m *SimpleTxManager) craftTx(ctx context.Context, candidate TxCandidate) (*types.Transaction, error) {
-	gasTipCap, basefee, err := m.suggestGasPriceCaps(ctx)
+func (m *SimpleTxManager) craftTx(ctx context.Context, candidate TxCandidate) (*types.Transaction, feeSuggestion, error) {
+	tip, basefee, err := m.suggestGasPriceCaps(ctx)
 	if err != nil {
 		m.metr.RPCError()
-		return nil, fmt.Errorf("failed to get gas price info: %w", err)
+		return nil, feeSuggestion{}, fmt.Errorf("failed to get gas price info: %w", err)
 	}
-	gasFeeCap := calcGasFeeCap(basefee, gasTipCap)
+	suggestion := feeSuggestion{tip: tip, baseFee: basefee}
+	gasTipCap, gasFeeCap := m.initialFees(suggestion)
 
 	// Fetch the sender's nonce from the latest known block (nil `blockNumber`)
 	childCtx, cancel := context.WithTimeout(ctx, m.NetworkTimeout)
@@ -167,7 +248,7 @@ func (m *SimpleTxManager) craftTx(ctx context.Context, candidate TxCandidate) (*
 	nonce, err := m.backend.NonceAt(childCtx, m.From(), nil)
 	if err != nil {
 		m.metr.RPCError()
-		return nil, fmt.Errorf("failed to get nonce: %w", err)
+		return nil, feeSuggestion{}, fmt.Errorf("failed to get nonce: %w", err)
 	}
 	m.metr.RecordNonce(nonce)
 
@@ -200,41 +281,90 @@ func (m *SimpleTxManager) craftTx(ctx context.Context, candidate TxCandidate) (*
 			Value:     candidate.Value,
 		})
 		if err != nil {
-			return nil, fmt.Errorf("failed to estimate gas: %w", err)
+			return nil, feeSuggestion{}, fmt.Errorf("failed to estimate gas: %w", err)
 		}
 		rawTx.Gas = gas
 	}
+	if candidate.MaxTxFee != nil && rawTx.Gas != 0 {
+		if maxFeeCap := new(big.Int).Div(candidate.MaxTxFee, new(big.Int).SetUint64(rawTx.Gas)); rawTx.GasFeeCap.Cmp(maxFeeCap) > 0 {
+			rawTx.GasFeeCap = maxFeeCap
+			if rawTx.GasTipCap.Cmp(maxFeeCap) > 0 {
+				rawTx.GasTipCap = maxFeeCap
+			}
+		}
+	}
 
 	ctx, cancel = context.WithTimeout(ctx, m.NetworkTimeout)
 	defer cancel()
-	return m.Signer(ctx, m.From(), types.NewTx(rawTx))
+	tx, err := m.Signer(ctx, m.From(), types.NewTx(rawTx))
+	return tx, suggestion, err
 }
 
-// send submits the same transaction several times with increasing gas prices as necessary.
-// It waits for the transaction to be confirmed on chain.
-func (m *SimpleTxManager) send(ctx context.Context, tx *types.Transaction) (*types.Receipt, error) {
+// send submits the same transaction several times with increasing gas prices as necessary, not above the maxTxFee
+// if not nil. It waits for the transaction to be confirmed on chain.
+// Once cancelledCh is closed, the transaction is replaced with a no-op, see Cancel.
+// The progress is tracked in the entry of the InFlight transactions, if not nil.
+func (m *SimpleTxManager) send(ctx context.Context, tx *types.Transaction, maxTxFee *big.Int, cancelledCh <-chan struct{}, entry *inFlightEntry) (*types.Receipt, error) {
 	var wg sync.WaitGroup
 	defer wg.Wait()
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
 	sendState := NewSendState(m.SafeAbortNonceTooLowCount, m.TxNotInMempoolTimeout)
+	backups := newBackupBroadcaster(m.BackupBroadcasters, m.l)
 	receiptChan := make(chan *types.Receipt, 1)
-	sendTxAsync := func(tx *types.Transaction) {
+	sendTxAsync := func(tx *types.Transaction, backups *backupBroadcaster) {
 		defer wg.Done()
-		m.publishAndWaitForTx(ctx, tx, sendState, receiptChan)
+		m.publishAndWaitForTx(ctx, tx, sendState, backups, receiptChan)
 	}
 
 	// Immediately publish a transaction before starting the resubmission loop
 	wg.Add(1)
-	go sendTxAsync(tx)
+	go sendTxAsync(tx, backups)
+	firstPublished := time.Now()
+	m.InFlight.setTx(entry, tx)
+	m.InFlight.update(entry, func(e *inFlightEntry) {
+		published := firstPublished.UTC()
+		e.State, e.FirstPublished, e.sendState = InFlightStatePublished, &published, sendState
+	})
+	level := EscalationNone
+	defer func() {
+		if level != EscalationNone {
+			m.metr.RecordEscalationLevel(int(EscalationNone), EscalationNone.String())
+		}
+	}()
 
 	ticker := time.NewTicker(m.ResubmissionTimeout)
 	defer ticker.Stop()
 
 	bumpCounter := 0
+	// escalatedBumps is the number of fee bumps by the price bump of a stuck transaction
+	escalatedBumps := 0
+	// replacements are the hashes of the no-op transactions replacing the cancelled transaction
+	var replacements map[common.Hash]struct{}
 	for {
 		select {
+		case <-cancelledCh:
+			// the channel stays closed, so it is only handled once
+			cancelledCh = nil
+			if sendState.IsWaitingForConfirmation() {
+				m.l.Warn("not replacing cancelled transaction, it is already mined", "hash", tx.Hash())
+				continue
+			}
+			replacement, err := m.replacementTx(ctx, tx)
+			if err != nil {
+				// the transaction may still be included, so keep waiting for it
+				m.l.Error("failed to create replacement of cancelled transaction", "hash", tx.Hash(), "err", err)
+				continue
+			}
+			m.l.Info("replacing cancelled transaction", "hash", tx.Hash(), "replacement", replacement.Hash())
+			tx = replacement
+			replacements = map[common.Hash]struct{}{tx.Hash(): {}}
+			m.InFlight.setTx(entry, tx)
+			m.InFlight.update(entry, func(e *inFlightEntry) { e.Cancelled = true })
+			wg.Add(1)
+			go sendTxAsync(tx, backups)
+
 		case <-ticker.C:
 			// Don't resubmit a transaction if it has been mined, but we are waiting for the conf depth.
 			if sendState.IsWaitingForConfirmation() {
@@ -245,11 +375,28 @@ func (m *SimpleTxManager) send(ctx context.Context, tx *types.Transaction) (*typ
 				m.l.Warn("Aborting transaction submission")
 				return nil, errors.New("aborted transaction sending")
 			}
+			if next := m.Escalation.level(bumpCounter, time.Since(firstPublished)); next > level {
+				level = next
+				m.escalate(tx, level, bumpCounter, firstPublished)
+				if level >= EscalationReroute && len(m.Escalation.Broadcasters) > 0 {
+					backups = newBackupBroadcaster(append(append([]TxBroadcaster{}, m.BackupBroadcasters...), m.Escalation.Broadcasters...), m.l)
+				}
+			}
 			// Increase the gas price & submit the new transaction
-			tx = m.increaseGasPrice(ctx, tx)
+			if level >= EscalationBump && m.Escalation.canBump(escalatedBumps) {
+				tx = m.increaseGasPriceBy(ctx, tx, m.Escalation.PriceBump, m.Escalation.MaxGasFeeCap, maxTxFee)
+				escalatedBumps += 1
+			} else {
+				tx = m.increaseGasPriceBy(ctx, tx, 0, nil, maxTxFee)
+			}
+			if replacements != nil {
+				replacements[tx.Hash()] = struct{}{}
+			}
 			wg.Add(1)
 			bumpCounter += 1
-			go sendTxAsync(tx)
+			m.InFlight.setTx(entry, tx)
+			m.InFlight.update(entry, func(e *inFlightEntry) { e.Bumps, e.Escalation = bumpCounter, level.String() })
+			go sendTxAsync(tx, backups)
 
 		case <-ctx.Done():
 			return nil, ctx.Err()
@@ -257,6 +404,9 @@ func (m *SimpleTxManager) send(ctx context.Context, tx *types.Transaction) (*typ
 		case receipt := <-receiptChan:
 			m.metr.RecordGasBumpCount(bumpCounter)
 			m.metr.TxConfirmed(receipt)
+			if _, ok := replacements[receipt.TxHash]; ok {
+				return receipt, ErrTxCancelled
+			}
 			// If transaction confirmed but the status is not success, return ErrTxReceiptNotSucceed
 			if receipt.Status != types.ReceiptStatusSuccessful {
 				return receipt, ErrTxReceiptNotSucceed
@@ -269,14 +419,23 @@ func (m *SimpleTxManager) send(ctx context.Context, tx *types.Transaction) (*typ
 // publishAndWaitForTx publishes the transaction to the transaction pool and then waits for it with [waitMined].
 // It should be called in a new go-routine. It will send the receipt to receiptChan in a non-blocking way if a receipt is found
 // for the transaction.
-func (m *SimpleTxManager) publishAndWaitForTx(ctx context.Context, tx *types.Transaction, sendState *SendState, receiptChan chan *types.Receipt) {
+func (m *SimpleTxManager) publishAndWaitForTx(ctx context.Context, tx *types.Transaction, sendState *SendState, backups *backupBroadcaster, receiptChan chan *types.Receipt) {
 	l := m.l.New("hash", tx.Hash(), "nonce", tx.Nonce(), "gasTipCap", tx.GasTipCap(), "gasFeeCap", tx.GasFeeCap())
 	l.Info("publishing transaction")
 
+	if err := m.runBroadcastHooks(ctx, tx); err != nil {
+		if m.BroadcastHookPolicy != HookFailurePolicyContinue {
+			l.Error("not publishing transaction, failed to record it with broadcast hooks", "err", err)
+			m.metr.TxPublished("broadcast_hook_failed")
+			return
+		}
+		l.Warn("publishing transaction although it could not be recorded with broadcast hooks", "err", err)
+	}
+
 	cCtx, cancel := context.WithTimeout(ctx, m.NetworkTimeout)
 	defer cancel()
 	t := time.Now()
-	err := m.backend.SendTransaction(cCtx, tx)
+	err := m.publishTx(cCtx, tx, backups)
 	sendState.ProcessSendError(err)
 
 	// Properly log & exit if there is an error
@@ -321,6 +480,27 @@ func (m *SimpleTxManager) publishAndWaitForTx(ctx context.Context, tx *types.Tra
 	}
 }
 
+// publishTx sends the transaction to the primary backend and simultaneously to all backup broadcasters.
+// The error of the primary backend is returned, unless one of the backups accepted the transaction.
+// In that case the transaction is in a mempool and the error of the primary backend is only logged.
+func (m *SimpleTxManager) publishTx(ctx context.Context, tx *types.Transaction, backups *backupBroadcaster) error {
+	if len(backups.backends) == 0 {
+		return m.backend.SendTransaction(ctx, tx)
+	}
+
+	backupAccepted := make(chan bool, 1)
+	go func() {
+		backupAccepted <- backups.broadcast(ctx, tx)
+	}()
+
+	err := m.backend.SendTransaction(ctx, tx)
+	if <-backupAccepted && err != nil && !errStringMatch(err, core.ErrNonceTooLow) {
+		m.l.Warn("primary backend failed to publish transaction, but backup broadcaster accepted it", "hash", tx.Hash(), "err", err)
+		return nil
+	}
+	return err
+}
+
 // waitMined waits for the transaction to be mined or for the context to be cancelled.
 func (m *SimpleTxManager) waitMined(ctx context.Context, tx *types.Transaction, sendState *SendState) (*types.Receipt, error) {
 	txHash := tx.Hash()
@@ -398,16 +578,43 @@ func (m *SimpleTxManager) queryReceipt(ctx context.Context, txHash common.Hash,
 //
 // If it encounters an error with creating the new transaction, it will return the old transaction.
 func (m *SimpleTxManager) increaseGasPrice(ctx context.Context, tx *types.Transaction) *types.Transaction {
+	return m.increaseGasPriceBy(ctx, tx, 0, nil, nil)
+}
+
+// increaseGasPriceBy is increaseGasPrice, but bumps the fees by at least minBump percent, regardless of the
+// network conditions, unless the bumped gas fee cap exceeds maxFeeCap. It is used to escalate a stuck transaction,
+// see EscalationPolicy. The fees are not bumped if the bumped fee of the tx, its gas times its gas fee cap, exceeds
+// maxTxFee, see TxCandidate.MaxTxFee.
+func (m *SimpleTxManager) increaseGasPriceBy(ctx context.Context, tx *types.Transaction, minBump int64, maxFeeCap *big.Int, maxTxFee *big.Int) *types.Transaction {
 	tip, basefee, err := m.suggestGasPriceCaps(ctx)
 	if err != nil {
 		m.l.Warn("failed to get suggested gas tip and basefee", "err", err)
 		return tx
 	}
 	gasTipCap, gasFeeCap := updateFees(tx.GasTipCap(), tx.GasFeeCap(), tip, basefee, m.l)
+	if minBump > 0 {
+		minTip, minFeeCap := calcBumpedValue(tx.GasTipCap(), minBump), calcBumpedValue(tx.GasFeeCap(), minBump)
+		if maxFeeCap != nil && minFeeCap.Cmp(maxFeeCap) > 0 {
+			m.l.Warn("not bumping the fees of stuck transaction above the max fee cap", "hash", tx.Hash(),
+				"gasFeeCap", minFeeCap, "maxFeeCap", maxFeeCap)
+		} else {
+			if gasTipCap.Cmp(minTip) < 0 {
+				gasTipCap = minTip
+			}
+			if gasFeeCap.Cmp(minFeeCap) < 0 {
+				gasFeeCap = minFeeCap
+			}
+		}
+	}
 
 	if tx.GasTipCapIntCmp(gasTipCap) == 0 && tx.GasFeeCapIntCmp(gasFeeCap) == 0 {
 		return tx
 	}
+	if fee := new(big.Int).Mul(gasFeeCap, new(big.Int).SetUint64(tx.Gas())); maxTxFee != nil && fee.Cmp(maxTxFee) > 0 {
+		m.l.Warn("not bumping the fees of transaction above the max tx fee", "hash", tx.Hash(),
+			"gasFeeCap", gasFeeCap, "fee", fee, "maxTxFee", maxTxFee)
+		return tx
+	}
 
 	rawTx := &types.DynamicFeeTx{
 		ChainID:    tx.ChainId(),
@@ -450,6 +657,10 @@ func (m *SimpleTxManager) suggestGasPriceCaps(ctx context.Context) (*big.Int, *b
 	} else if head.BaseFee == nil {
 		return nil, nil, errors.New("txmgr does not support pre-london blocks that do not have a basefee")
 	}
+	if m.GasOracle != nil {
+		tip, basefee := m.oracleGasPriceCaps(ctx, tip, head.BaseFee)
+		return tip, basefee, nil
+	}
 	return tip, head.BaseFee, nil
 }
 
@@ -2,10 +2,15 @@ package txmgr
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -284,7 +289,7 @@ func TestTxMgrConfirmAtMinGasPrice(t *testing.T) {
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
-	receipt, err := h.mgr.send(ctx, tx)
+	receipt, err := h.mgr.send(ctx, tx, nil, nil, nil)
 	require.ErrorIs(t, err, ErrTxReceiptNotSucceed)
 	require.NotNil(t, receipt)
 	require.Equal(t, gasPricer.expGasFeeCap().Uint64(), receipt.GasUsed)
@@ -312,7 +317,7 @@ func TestTxMgrNeverConfirmCancel(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	receipt, err := h.mgr.send(ctx, tx)
+	receipt, err := h.mgr.send(ctx, tx, nil, nil, nil)
 	require.Equal(t, err, context.DeadlineExceeded)
 	require.Nil(t, receipt)
 }
@@ -340,7 +345,7 @@ func TestTxMgrConfirmsAtHigherGasPrice(t *testing.T) {
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
-	receipt, err := h.mgr.send(ctx, tx)
+	receipt, err := h.mgr.send(ctx, tx, nil, nil, nil)
 	require.ErrorIs(t, err, ErrTxReceiptNotSucceed)
 	require.NotNil(t, receipt)
 	require.Equal(t, h.gasPricer.expGasFeeCap().Uint64(), receipt.GasUsed)
@@ -371,11 +376,193 @@ func TestTxMgrBlocksOnFailingRpcCalls(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	receipt, err := h.mgr.send(ctx, tx)
+	receipt, err := h.mgr.send(ctx, tx, nil, nil, nil)
 	require.Equal(t, err, context.DeadlineExceeded)
 	require.Nil(t, receipt)
 }
 
+// TestTxMgrConfirmsWithBackupBroadcaster asserts that a transaction is confirmed
+// when the primary backend rejects the broadcast, but a backup broadcaster accepts it.
+func TestTxMgrConfirmsWithBackupBroadcaster(t *testing.T) {
+	t.Parallel()
+
+	backup := &mockBroadcaster{}
+	cfg := configWithNumConfs(1)
+	cfg.BackupBroadcasters = []TxBroadcaster{backup}
+	h := newTestHarnessWithConfig(t, cfg)
+
+	gasTipCap, gasFeeCap := h.gasPricer.sample()
+	tx := types.NewTx(&types.DynamicFeeTx{
+		GasTipCap: gasTipCap,
+		GasFeeCap: gasFeeCap,
+	})
+
+	h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+		return errRpcFailure
+	})
+	backup.send = func(ctx context.Context, tx *types.Transaction) error {
+		txHash := tx.Hash()
+		h.backend.mine(&txHash, tx.GasFeeCap())
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	receipt, err := h.mgr.send(ctx, tx, nil, nil, nil)
+	require.ErrorIs(t, err, ErrTxReceiptNotSucceed)
+	require.NotNil(t, receipt)
+	require.Equal(t, tx.Hash(), receipt.TxHash)
+	require.Equal(t, 1, backup.calls())
+}
+
+// TestTxMgrBroadcastHookPolicy asserts that a transaction which could not be recorded by a
+// broadcast hook is only published with the continue policy.
+func TestTxMgrBroadcastHookPolicy(t *testing.T) {
+	t.Parallel()
+
+	for _, policy := range []HookFailurePolicy{HookFailurePolicyBlock, HookFailurePolicyContinue} {
+		policy := policy
+		t.Run(string(policy), func(t *testing.T) {
+			t.Parallel()
+
+			hook := &mockBroadcastHook{err: errors.New("sink unavailable")}
+			cfg := configWithNumConfs(1)
+			cfg.BroadcastHooks = []BroadcastHook{hook}
+			cfg.BroadcastHookPolicy = policy
+			h := newTestHarnessWithConfig(t, cfg)
+
+			gasTipCap, gasFeeCap := h.gasPricer.sample()
+			tx := types.NewTx(&types.DynamicFeeTx{
+				GasTipCap: gasTipCap,
+				GasFeeCap: gasFeeCap,
+			})
+
+			var published atomic.Bool
+			h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+				published.Store(true)
+				txHash := tx.Hash()
+				h.backend.mine(&txHash, tx.GasFeeCap())
+				return nil
+			})
+
+			ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+			defer cancel()
+
+			_, err := h.mgr.send(ctx, tx, nil, nil, nil)
+			if policy == HookFailurePolicyBlock {
+				require.ErrorIs(t, err, context.DeadlineExceeded)
+				require.False(t, published.Load())
+			} else {
+				require.ErrorIs(t, err, ErrTxReceiptNotSucceed)
+				require.True(t, published.Load())
+			}
+			require.NotEmpty(t, hook.recorded())
+			require.Equal(t, tx.Hash(), hook.recorded()[0].Hash)
+		})
+	}
+}
+
+func TestFileBroadcastHook(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "broadcast.log")
+	hook, err := NewFileBroadcastHook(path)
+	require.NoError(t, err)
+
+	tx := types.NewTx(&types.DynamicFeeTx{Nonce: 1, Gas: 21000})
+	record, err := newBroadcastRecord("TEST", big.NewInt(1), common.Address{0x01}, tx)
+	require.NoError(t, err)
+	require.NoError(t, hook.OnBroadcast(context.Background(), record))
+	require.NoError(t, hook.OnBroadcast(context.Background(), record))
+	require.NoError(t, hook.Close())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Len(t, lines, 2)
+
+	var decoded BroadcastRecord
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &decoded))
+	require.Equal(t, tx.Hash(), decoded.Hash)
+	require.Equal(t, record.RawTx, decoded.RawTx)
+}
+
+// TestTxMgrCloseBroadcastHooks asserts that closing the tx manager closes the broadcast hooks holding a file.
+func TestTxMgrCloseBroadcastHooks(t *testing.T) {
+	fileHook, err := NewFileBroadcastHook(filepath.Join(t.TempDir(), "broadcast.log"))
+	require.NoError(t, err)
+	cfg := configWithNumConfs(1)
+	cfg.BroadcastHooks = []BroadcastHook{&mockBroadcastHook{}, fileHook}
+	h := newTestHarnessWithConfig(t, cfg)
+
+	require.NoError(t, h.mgr.Close())
+	record, err := newBroadcastRecord("TEST", big.NewInt(1), common.Address{0x01}, types.NewTx(&types.DynamicFeeTx{}))
+	require.NoError(t, err)
+	require.ErrorIs(t, fileHook.OnBroadcast(context.Background(), record), os.ErrClosed)
+	require.ErrorIs(t, h.mgr.Close(), os.ErrClosed, "the failure to close a hook is returned")
+}
+
+// TestTxMgrCloseAuditBroadcastHook asserts that closing the tx manager closes the audit files, which are not opened
+// again by a later broadcast.
+func TestTxMgrCloseAuditBroadcastHook(t *testing.T) {
+	dir := t.TempDir()
+	auditHook, err := NewAuditBroadcastHook(dir)
+	require.NoError(t, err)
+	cfg := configWithNumConfs(1)
+	cfg.BroadcastHooks = []BroadcastHook{auditHook}
+	h := newTestHarnessWithConfig(t, cfg)
+	record, err := newBroadcastRecord("TEST", big.NewInt(1), common.Address{0x01}, types.NewTx(&types.DynamicFeeTx{}))
+	require.NoError(t, err)
+	require.NoError(t, auditHook.OnBroadcast(context.Background(), record))
+	file := auditHook.files["TEST"]
+	require.NotNil(t, file)
+
+	require.NoError(t, h.mgr.Close())
+	require.ErrorIs(t, file.Close(), os.ErrClosed, "the audit file is closed")
+	require.ErrorIs(t, auditHook.OnBroadcast(context.Background(), record), os.ErrClosed)
+	require.Empty(t, auditHook.files, "no audit file is opened after the close")
+	require.NoError(t, h.mgr.Close(), "closing again is a no-op")
+}
+
+// mockBroadcastHook is a BroadcastHook that keeps the records and fails with err if set.
+type mockBroadcastHook struct {
+	mu      sync.Mutex
+	err     error
+	records []BroadcastRecord
+}
+
+func (h *mockBroadcastHook) OnBroadcast(_ context.Context, record BroadcastRecord) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.records = append(h.records, record)
+	return h.err
+}
+
+func (h *mockBroadcastHook) recorded() []BroadcastRecord {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.records
+}
+
+// mockBroadcaster is a TxBroadcaster that counts the published transactions.
+type mockBroadcaster struct {
+	mu    sync.Mutex
+	send  sendTransactionFunc
+	count int
+}
+
+func (b *mockBroadcaster) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	b.mu.Lock()
+	b.count++
+	b.mu.Unlock()
+	return b.send(ctx, tx)
+}
+
+func (b *mockBroadcaster) calls() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.count
+}
+
 // TestTxMgr_CraftTx ensures that the tx manager will create transactions as expected.
 func TestTxMgr_CraftTx(t *testing.T) {
 	t.Parallel()
@@ -384,7 +571,7 @@ func TestTxMgr_CraftTx(t *testing.T) {
 
 	// Craft the transaction.
 	gasTipCap, gasFeeCap := h.gasPricer.feesForEpoch(h.gasPricer.epoch + 1)
-	tx, err := h.mgr.craftTx(context.Background(), candidate)
+	tx, _, err := h.mgr.craftTx(context.Background(), candidate)
 	require.NoError(t, err)
 	require.NotNil(t, tx)
 
@@ -399,6 +586,29 @@ func TestTxMgr_CraftTx(t *testing.T) {
 	require.Equal(t, candidate.GasLimit, tx.Gas())
 }
 
+// TestTxMgr_CraftTxMaxTxFee ensures that the fee cap of a crafted transaction is lowered to fit its max tx fee.
+func TestTxMgr_CraftTxMaxTxFee(t *testing.T) {
+	t.Parallel()
+	h := newTestHarness(t)
+	candidate := h.createTxCandidate()
+	gasTipCap, gasFeeCap := h.gasPricer.feesForEpoch(h.gasPricer.epoch + 1)
+
+	// a max tx fee above the fee of the transaction does not change its fees
+	candidate.MaxTxFee = new(big.Int).Mul(gasFeeCap, new(big.Int).SetUint64(candidate.GasLimit))
+	tx, _, err := h.mgr.craftTx(context.Background(), candidate)
+	require.NoError(t, err)
+	require.Equal(t, gasTipCap, tx.GasTipCap())
+	require.Equal(t, gasFeeCap, tx.GasFeeCap())
+
+	gasTipCap, gasFeeCap = h.gasPricer.feesForEpoch(h.gasPricer.epoch + 1)
+	maxFeeCap := new(big.Int).Sub(gasFeeCap, big.NewInt(1))
+	candidate.MaxTxFee = new(big.Int).Mul(maxFeeCap, new(big.Int).SetUint64(candidate.GasLimit))
+	tx, _, err = h.mgr.craftTx(context.Background(), candidate)
+	require.NoError(t, err)
+	require.Equal(t, gasTipCap, tx.GasTipCap())
+	require.Equal(t, maxFeeCap, tx.GasFeeCap())
+}
+
 // TestTxMgr_EstimateGas ensures that the tx manager will estimate
 // the gas when candidate gas limit is zero in [CraftTx].
 func TestTxMgr_EstimateGas(t *testing.T) {
@@ -413,7 +623,7 @@ func TestTxMgr_EstimateGas(t *testing.T) {
 	gasEstimate := h.gasPricer.baseBaseFee.Uint64()
 
 	// Craft the transaction.
-	tx, err := h.mgr.craftTx(context.Background(), candidate)
+	tx, _, err := h.mgr.craftTx(context.Background(), candidate)
 	require.NoError(t, err)
 	require.NotNil(t, tx)
 
@@ -449,7 +659,7 @@ func TestTxMgrOnlyOnePublicationSucceeds(t *testing.T) {
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
-	receipt, err := h.mgr.send(ctx, tx)
+	receipt, err := h.mgr.send(ctx, tx, nil, nil, nil)
 	require.ErrorIs(t, err, ErrTxReceiptNotSucceed)
 	require.NotNil(t, receipt)
 	require.Equal(t, h.gasPricer.expGasFeeCap().Uint64(), receipt.GasUsed)
@@ -483,7 +693,7 @@ func TestTxMgrConfirmsMinGasPriceAfterBumping(t *testing.T) {
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
-	receipt, err := h.mgr.send(ctx, tx)
+	receipt, err := h.mgr.send(ctx, tx, nil, nil, nil)
 	require.ErrorIs(t, err, ErrTxReceiptNotSucceed)
 	require.NotNil(t, receipt)
 	require.Equal(t, h.gasPricer.expGasFeeCap().Uint64(), receipt.GasUsed)
@@ -528,7 +738,7 @@ func TestTxMgrDoesntAbortNonceTooLowAfterMiningTx(t *testing.T) {
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
-	receipt, err := h.mgr.send(ctx, tx)
+	receipt, err := h.mgr.send(ctx, tx, nil, nil, nil)
 	require.ErrorIs(t, err, ErrTxReceiptNotSucceed)
 	require.NotNil(t, receipt)
 	require.Equal(t, h.gasPricer.expGasFeeCap().Uint64(), receipt.GasUsed)
@@ -0,0 +1,56 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// Notifier posts notifications as JSON to a webhook, e.g. of an alerting or paging service.
+type Notifier struct {
+	url    string
+	client *http.Client
+}
+
+// NewNotifier creates a notifier posting to the url with the client, the default client if nil.
+func NewNotifier(url string, client *http.Client) *Notifier {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &Notifier{url: url, client: client}
+}
+
+// Notify posts the body encoded as JSON.
+func (n *Notifier) Notify(ctx context.Context, body any) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to encode notification: %w", err)
+	}
+	return n.Post(ctx, data, nil)
+}
+
+// Post posts the JSON data with the extra headers, e.g. to sign the data. It fails if the webhook does not respond
+// with a 2xx status.
+func (n *Notifier) Post(ctx context.Context, data []byte, header http.Header) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post to webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotifier(t *testing.T) {
+	type notification struct {
+		Text string `json:"text"`
+	}
+	var received notification
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		if r.Header.Get("X-Signature") != "" {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer srv.Close()
+
+	n := NewNotifier(srv.URL, nil)
+	require.NoError(t, n.Notify(context.Background(), notification{Text: "stuck"}))
+	require.Equal(t, "stuck", received.Text)
+
+	header := http.Header{"X-Signature": []string{"0x01"}}
+	require.ErrorContains(t, n.Post(context.Background(), []byte(`{"text":"signed"}`), header), "status 500")
+	require.Equal(t, "signed", received.Text)
+
+	require.Error(t, n.Notify(context.Background(), make(chan int)), "unencodable body")
+}
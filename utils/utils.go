@@ -23,23 +23,28 @@ const (
 )
 
 // DialEthClientWithTimeout attempts to dial the L1 provider using the provided
-// URL. If the dial doesn't complete within defaultDialTimeout seconds, this
-// method will return an error.
-func DialEthClientWithTimeout(ctx context.Context, url string) (*ethclient.Client, error) {
+// URL and options, e.g. of the proxy. If the dial doesn't complete within
+// defaultDialTimeout seconds, this method will return an error.
+func DialEthClientWithTimeout(ctx context.Context, url string, opts ...rpc.ClientOption) (*ethclient.Client, error) {
 	ctx, cancel := context.WithTimeout(ctx, DefaultDialTimeout)
 	defer cancel()
 
-	return ethclient.DialContext(ctx, url)
+	rpcCl, err := rpc.DialOptions(ctx, url, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return ethclient.NewClient(rpcCl), nil
 }
 
 // DialRollupClientWithTimeout attempts to dial the RPC provider using the provided
-// URL. If the dial doesn't complete within defaultDialTimeout seconds, this
-// method will return an error.
-func DialRollupClientWithTimeout(ctx context.Context, url string) (*sources.RollupClient, error) {
+// URL and options, e.g. of the proxy. If the dial doesn't complete within
+// defaultDialTimeout seconds, this method will return an error.
+func DialRollupClientWithTimeout(ctx context.Context, url string, opts ...rpc.ClientOption) (*sources.RollupClient, error) {
 	ctx, cancel := context.WithTimeout(ctx, DefaultDialTimeout)
 	defer cancel()
 
-	rpcCl, err := rpc.DialContext(ctx, url)
+	rpcCl, err := rpc.DialOptions(ctx, url, opts...)
 	if err != nil {
 		return nil, err
 	}